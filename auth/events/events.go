@@ -22,6 +22,10 @@ const (
 	domainAssign              = domainPrefix + "assign"
 	domainUnassign            = domainPrefix + "unassign"
 	domainUserList            = domainPrefix + "user_list"
+
+	keyPrefix = "key."
+	keyIssue  = keyPrefix + "issue"
+	keyRevoke = keyPrefix + "revoke"
 )
 
 var (
@@ -34,6 +38,8 @@ var (
 	_ events.Event = (*assignUsersEvent)(nil)
 	_ events.Event = (*unassignUsersEvent)(nil)
 	_ events.Event = (*listUserDomainsEvent)(nil)
+	_ events.Event = (*issueKeyEvent)(nil)
+	_ events.Event = (*revokeKeyEvent)(nil)
 )
 
 type createDomainEvent struct {
@@ -294,3 +300,53 @@ func (lde listUserDomainsEvent) Encode() (map[string]interface{}, error) {
 
 	return val, nil
 }
+
+type issueKeyEvent struct {
+	auth.Key
+}
+
+func (ike issueKeyEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation": keyIssue,
+		"id":        ike.ID,
+		"type":      ike.Type.String(),
+		"issuer":    ike.Issuer,
+		"issued_at": ike.IssuedAt,
+	}
+
+	if ike.User != "" {
+		val["user_id"] = ike.User
+	}
+	if ike.Domain != "" {
+		val["domain_id"] = ike.Domain
+	}
+	if !ike.ExpiresAt.IsZero() {
+		val["expires_at"] = ike.ExpiresAt
+	}
+
+	return val, nil
+}
+
+type revokeKeyEvent struct {
+	id       string
+	issuer   string
+	userID   string
+	domainID string
+}
+
+func (rke revokeKeyEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation": keyRevoke,
+		"id":        rke.id,
+		"issuer":    rke.issuer,
+	}
+
+	if rke.userID != "" {
+		val["user_id"] = rke.userID
+	}
+	if rke.domainID != "" {
+		val["domain_id"] = rke.domainID
+	}
+
+	return val, nil
+}
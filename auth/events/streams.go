@@ -197,11 +197,54 @@ func (es *eventStore) ListUserDomains(ctx context.Context, token, userID string,
 }
 
 func (es *eventStore) Issue(ctx context.Context, token string, key auth.Key) (auth.Token, error) {
-	return es.svc.Issue(ctx, token, key)
+	tkn, err := es.svc.Issue(ctx, token, key)
+	if err != nil {
+		return tkn, err
+	}
+	// Login and temporary keys are issued too often to be recorded,
+	// so only API keys issuance is published.
+	if key.Type != auth.APIKey {
+		return tkn, nil
+	}
+
+	issued, err := es.svc.Identify(ctx, tkn.AccessToken)
+	if err != nil {
+		return tkn, err
+	}
+
+	event := issueKeyEvent{
+		issued,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return tkn, err
+	}
+
+	return tkn, nil
 }
 
 func (es *eventStore) Revoke(ctx context.Context, token, id string) error {
-	return es.svc.Revoke(ctx, token, id)
+	key, err := es.svc.Identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if err := es.svc.Revoke(ctx, token, id); err != nil {
+		return err
+	}
+
+	event := revokeKeyEvent{
+		id:       id,
+		issuer:   key.Issuer,
+		userID:   key.User,
+		domainID: key.Domain,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func (es *eventStore) RetrieveKey(ctx context.Context, token, id string) (auth.Key, error) {
@@ -22,7 +22,13 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	ThingsService_Authorize_FullMethodName = "/magistrala.ThingsService/Authorize"
+	ThingsService_Authorize_FullMethodName         = "/magistrala.ThingsService/Authorize"
+	ThingsService_DerivePSK_FullMethodName         = "/magistrala.ThingsService/DerivePSK"
+	ThingsService_ConnectedChannels_FullMethodName = "/magistrala.ThingsService/ConnectedChannels"
+	ThingsService_ChannelMetadata_FullMethodName   = "/magistrala.ThingsService/ChannelMetadata"
+	ThingsService_ThingsConnections_FullMethodName = "/magistrala.ThingsService/ThingsConnections"
+	ThingsService_CreateThing_FullMethodName       = "/magistrala.ThingsService/CreateThing"
+	ThingsService_DeleteThing_FullMethodName       = "/magistrala.ThingsService/DeleteThing"
 )
 
 // ThingsServiceClient is the client API for ThingsService service.
@@ -35,6 +41,26 @@ type ThingsServiceClient interface {
 	// Authorize checks if the thing is authorized to perform
 	// the action on the channel.
 	Authorize(ctx context.Context, in *ThingsAuthzReq, opts ...grpc.CallOption) (*ThingsAuthzRes, error)
+	// DerivePSK derives the DTLS pre-shared key of the enabled thing from
+	// its key. The key itself never leaves the things service.
+	DerivePSK(ctx context.Context, in *ThingsPSKReq, opts ...grpc.CallOption) (*ThingsPSKRes, error)
+	// ConnectedChannels lists the channels the thing is connected to. The
+	// thing is identified by its key, or by its ID and pre-shared key when
+	// the key is empty.
+	ConnectedChannels(ctx context.Context, in *ThingsChannelsReq, opts ...grpc.CallOption) (*ThingsChannelsRes, error)
+	// ChannelMetadata retrieves the metadata of the channel. It is used by
+	// the protocol adapters loading the channel schemas and retention.
+	ChannelMetadata(ctx context.Context, in *ChannelMetadataReq, opts ...grpc.CallOption) (*ChannelMetadataRes, error)
+	// ThingsConnections lists the channels each of the things is connected
+	// to. Things which do not belong to the domain are omitted.
+	ThingsConnections(ctx context.Context, in *ThingsConnectionsReq, opts ...grpc.CallOption) (*ThingsConnectionsRes, error)
+	// CreateThing creates the thing in the domain on behalf of the user, who
+	// must be allowed to create things in the domain. It is used by the
+	// bootstrap service to create the things of enrolled devices.
+	CreateThing(ctx context.Context, in *CreateThingReq, opts ...grpc.CallOption) (*CreateThingRes, error)
+	// DeleteThing deletes the thing on behalf of the user, who must be allowed
+	// to delete the thing.
+	DeleteThing(ctx context.Context, in *DeleteThingReq, opts ...grpc.CallOption) (*DeleteThingRes, error)
 }
 
 type thingsServiceClient struct {
@@ -55,6 +81,66 @@ func (c *thingsServiceClient) Authorize(ctx context.Context, in *ThingsAuthzReq,
 	return out, nil
 }
 
+func (c *thingsServiceClient) DerivePSK(ctx context.Context, in *ThingsPSKReq, opts ...grpc.CallOption) (*ThingsPSKRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ThingsPSKRes)
+	err := c.cc.Invoke(ctx, ThingsService_DerivePSK_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *thingsServiceClient) ConnectedChannels(ctx context.Context, in *ThingsChannelsReq, opts ...grpc.CallOption) (*ThingsChannelsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ThingsChannelsRes)
+	err := c.cc.Invoke(ctx, ThingsService_ConnectedChannels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *thingsServiceClient) ChannelMetadata(ctx context.Context, in *ChannelMetadataReq, opts ...grpc.CallOption) (*ChannelMetadataRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChannelMetadataRes)
+	err := c.cc.Invoke(ctx, ThingsService_ChannelMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *thingsServiceClient) ThingsConnections(ctx context.Context, in *ThingsConnectionsReq, opts ...grpc.CallOption) (*ThingsConnectionsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ThingsConnectionsRes)
+	err := c.cc.Invoke(ctx, ThingsService_ThingsConnections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *thingsServiceClient) CreateThing(ctx context.Context, in *CreateThingReq, opts ...grpc.CallOption) (*CreateThingRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateThingRes)
+	err := c.cc.Invoke(ctx, ThingsService_CreateThing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *thingsServiceClient) DeleteThing(ctx context.Context, in *DeleteThingReq, opts ...grpc.CallOption) (*DeleteThingRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteThingRes)
+	err := c.cc.Invoke(ctx, ThingsService_DeleteThing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ThingsServiceServer is the server API for ThingsService service.
 // All implementations must embed UnimplementedThingsServiceServer
 // for forward compatibility
@@ -65,6 +151,26 @@ type ThingsServiceServer interface {
 	// Authorize checks if the thing is authorized to perform
 	// the action on the channel.
 	Authorize(context.Context, *ThingsAuthzReq) (*ThingsAuthzRes, error)
+	// DerivePSK derives the DTLS pre-shared key of the enabled thing from
+	// its key. The key itself never leaves the things service.
+	DerivePSK(context.Context, *ThingsPSKReq) (*ThingsPSKRes, error)
+	// ConnectedChannels lists the channels the thing is connected to. The
+	// thing is identified by its key, or by its ID and pre-shared key when
+	// the key is empty.
+	ConnectedChannels(context.Context, *ThingsChannelsReq) (*ThingsChannelsRes, error)
+	// ChannelMetadata retrieves the metadata of the channel. It is used by
+	// the protocol adapters loading the channel schemas and retention.
+	ChannelMetadata(context.Context, *ChannelMetadataReq) (*ChannelMetadataRes, error)
+	// ThingsConnections lists the channels each of the things is connected
+	// to. Things which do not belong to the domain are omitted.
+	ThingsConnections(context.Context, *ThingsConnectionsReq) (*ThingsConnectionsRes, error)
+	// CreateThing creates the thing in the domain on behalf of the user, who
+	// must be allowed to create things in the domain. It is used by the
+	// bootstrap service to create the things of enrolled devices.
+	CreateThing(context.Context, *CreateThingReq) (*CreateThingRes, error)
+	// DeleteThing deletes the thing on behalf of the user, who must be allowed
+	// to delete the thing.
+	DeleteThing(context.Context, *DeleteThingReq) (*DeleteThingRes, error)
 	mustEmbedUnimplementedThingsServiceServer()
 }
 
@@ -75,6 +181,24 @@ type UnimplementedThingsServiceServer struct {
 func (UnimplementedThingsServiceServer) Authorize(context.Context, *ThingsAuthzReq) (*ThingsAuthzRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Authorize not implemented")
 }
+func (UnimplementedThingsServiceServer) DerivePSK(context.Context, *ThingsPSKReq) (*ThingsPSKRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DerivePSK not implemented")
+}
+func (UnimplementedThingsServiceServer) ConnectedChannels(context.Context, *ThingsChannelsReq) (*ThingsChannelsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConnectedChannels not implemented")
+}
+func (UnimplementedThingsServiceServer) ChannelMetadata(context.Context, *ChannelMetadataReq) (*ChannelMetadataRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChannelMetadata not implemented")
+}
+func (UnimplementedThingsServiceServer) ThingsConnections(context.Context, *ThingsConnectionsReq) (*ThingsConnectionsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ThingsConnections not implemented")
+}
+func (UnimplementedThingsServiceServer) CreateThing(context.Context, *CreateThingReq) (*CreateThingRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateThing not implemented")
+}
+func (UnimplementedThingsServiceServer) DeleteThing(context.Context, *DeleteThingReq) (*DeleteThingRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteThing not implemented")
+}
 func (UnimplementedThingsServiceServer) mustEmbedUnimplementedThingsServiceServer() {}
 
 // UnsafeThingsServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -106,6 +230,114 @@ func _ThingsService_Authorize_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ThingsService_DerivePSK_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ThingsPSKReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThingsServiceServer).DerivePSK(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ThingsService_DerivePSK_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThingsServiceServer).DerivePSK(ctx, req.(*ThingsPSKReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ThingsService_ConnectedChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ThingsChannelsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThingsServiceServer).ConnectedChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ThingsService_ConnectedChannels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThingsServiceServer).ConnectedChannels(ctx, req.(*ThingsChannelsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ThingsService_ChannelMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelMetadataReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThingsServiceServer).ChannelMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ThingsService_ChannelMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThingsServiceServer).ChannelMetadata(ctx, req.(*ChannelMetadataReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ThingsService_ThingsConnections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ThingsConnectionsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThingsServiceServer).ThingsConnections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ThingsService_ThingsConnections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThingsServiceServer).ThingsConnections(ctx, req.(*ThingsConnectionsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ThingsService_CreateThing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateThingReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThingsServiceServer).CreateThing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ThingsService_CreateThing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThingsServiceServer).CreateThing(ctx, req.(*CreateThingReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ThingsService_DeleteThing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteThingReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThingsServiceServer).DeleteThing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ThingsService_DeleteThing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThingsServiceServer).DeleteThing(ctx, req.(*DeleteThingReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ThingsService_ServiceDesc is the grpc.ServiceDesc for ThingsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -117,6 +349,30 @@ var ThingsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Authorize",
 			Handler:    _ThingsService_Authorize_Handler,
 		},
+		{
+			MethodName: "DerivePSK",
+			Handler:    _ThingsService_DerivePSK_Handler,
+		},
+		{
+			MethodName: "ConnectedChannels",
+			Handler:    _ThingsService_ConnectedChannels_Handler,
+		},
+		{
+			MethodName: "ChannelMetadata",
+			Handler:    _ThingsService_ChannelMetadata_Handler,
+		},
+		{
+			MethodName: "ThingsConnections",
+			Handler:    _ThingsService_ThingsConnections_Handler,
+		},
+		{
+			MethodName: "CreateThing",
+			Handler:    _ThingsService_CreateThing_Handler,
+		},
+		{
+			MethodName: "DeleteThing",
+			Handler:    _ThingsService_DeleteThing_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "auth.proto",
@@ -482,3 +738,146 @@ var DomainsService_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "auth.proto",
 }
+
+const (
+	CertsService_RetrieveCert_FullMethodName      = "/magistrala.CertsService/RetrieveCert"
+	CertsService_EnrollmentCACerts_FullMethodName = "/magistrala.CertsService/EnrollmentCACerts"
+)
+
+// CertsServiceClient is the client API for CertsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CertsService is a service that provides the certificates issued to the
+// things for magistrala services.
+type CertsServiceClient interface {
+	// RetrieveCert retrieves the certificate issued by the PKI or enrolled over
+	// EST by its serial number. The private key is never returned.
+	RetrieveCert(ctx context.Context, in *RetrieveCertReq, opts ...grpc.CallOption) (*RetrieveCertRes, error)
+	// EnrollmentCACerts retrieves the DER encoded CA certificates which sign
+	// the certificates enrolled over EST.
+	EnrollmentCACerts(ctx context.Context, in *EnrollmentCACertsReq, opts ...grpc.CallOption) (*EnrollmentCACertsRes, error)
+}
+
+type certsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCertsServiceClient(cc grpc.ClientConnInterface) CertsServiceClient {
+	return &certsServiceClient{cc}
+}
+
+func (c *certsServiceClient) RetrieveCert(ctx context.Context, in *RetrieveCertReq, opts ...grpc.CallOption) (*RetrieveCertRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RetrieveCertRes)
+	err := c.cc.Invoke(ctx, CertsService_RetrieveCert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certsServiceClient) EnrollmentCACerts(ctx context.Context, in *EnrollmentCACertsReq, opts ...grpc.CallOption) (*EnrollmentCACertsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrollmentCACertsRes)
+	err := c.cc.Invoke(ctx, CertsService_EnrollmentCACerts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CertsServiceServer is the server API for CertsService service.
+// All implementations must embed UnimplementedCertsServiceServer
+// for forward compatibility
+//
+// CertsService is a service that provides the certificates issued to the
+// things for magistrala services.
+type CertsServiceServer interface {
+	// RetrieveCert retrieves the certificate issued by the PKI or enrolled over
+	// EST by its serial number. The private key is never returned.
+	RetrieveCert(context.Context, *RetrieveCertReq) (*RetrieveCertRes, error)
+	// EnrollmentCACerts retrieves the DER encoded CA certificates which sign
+	// the certificates enrolled over EST.
+	EnrollmentCACerts(context.Context, *EnrollmentCACertsReq) (*EnrollmentCACertsRes, error)
+	mustEmbedUnimplementedCertsServiceServer()
+}
+
+// UnimplementedCertsServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCertsServiceServer struct {
+}
+
+func (UnimplementedCertsServiceServer) RetrieveCert(context.Context, *RetrieveCertReq) (*RetrieveCertRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetrieveCert not implemented")
+}
+func (UnimplementedCertsServiceServer) EnrollmentCACerts(context.Context, *EnrollmentCACertsReq) (*EnrollmentCACertsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrollmentCACerts not implemented")
+}
+func (UnimplementedCertsServiceServer) mustEmbedUnimplementedCertsServiceServer() {}
+
+// UnsafeCertsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CertsServiceServer will
+// result in compilation errors.
+type UnsafeCertsServiceServer interface {
+	mustEmbedUnimplementedCertsServiceServer()
+}
+
+func RegisterCertsServiceServer(s grpc.ServiceRegistrar, srv CertsServiceServer) {
+	s.RegisterService(&CertsService_ServiceDesc, srv)
+}
+
+func _CertsService_RetrieveCert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetrieveCertReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertsServiceServer).RetrieveCert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CertsService_RetrieveCert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertsServiceServer).RetrieveCert(ctx, req.(*RetrieveCertReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CertsService_EnrollmentCACerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollmentCACertsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertsServiceServer).EnrollmentCACerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CertsService_EnrollmentCACerts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertsServiceServer).EnrollmentCACerts(ctx, req.(*EnrollmentCACertsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CertsService_ServiceDesc is the grpc.ServiceDesc for CertsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CertsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "magistrala.CertsService",
+	HandlerType: (*CertsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RetrieveCert",
+			Handler:    _CertsService_RetrieveCert_Handler,
+		},
+		{
+			MethodName: "EnrollmentCACerts",
+			Handler:    _CertsService_EnrollmentCACerts_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "auth.proto",
+}
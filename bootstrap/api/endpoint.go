@@ -50,8 +50,10 @@ func addEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 		}
 
 		res := configRes{
-			id:      saved.ThingID,
-			created: true,
+			id:         saved.ThingID,
+			created:    true,
+			DeviceKey:  saved.DeviceKey,
+			KeyVersion: saved.KeyVersion,
 		}
 
 		return res, nil
@@ -121,6 +123,8 @@ func viewEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 			Name:        config.Name,
 			Content:     config.Content,
 			State:       config.State,
+			DeviceKey:   config.DeviceKey,
+			KeyVersion:  config.KeyVersion,
 		}
 
 		return res, nil
@@ -253,6 +257,148 @@ func removeEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 	}
 }
 
+func addTemplateEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(templateReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		saved, err := svc.AddTemplate(ctx, session, req.token, req.template())
+		if err != nil {
+			return nil, err
+		}
+
+		return templateRes{Template: saved, created: true}, nil
+	}
+}
+
+func viewTemplateEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		tpl, err := svc.ViewTemplate(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return templateRes{Template: tpl}, nil
+	}
+}
+
+func updateTemplateEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(templateReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		if req.id == "" {
+			return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingID)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		saved, err := svc.UpdateTemplate(ctx, session, req.token, req.template())
+		if err != nil {
+			return nil, err
+		}
+
+		return templateRes{Template: saved}, nil
+	}
+}
+
+func listTemplatesEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listTemplatesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		page, err := svc.ListTemplates(ctx, session, req.offset, req.limit)
+		if err != nil {
+			return nil, err
+		}
+
+		return templatesPageRes{page}, nil
+	}
+}
+
+func removeTemplateEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityReq)
+		if err := req.validate(); err != nil {
+			return removeRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		if err := svc.RemoveTemplate(ctx, session, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
+func enrollEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(enrollReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		saved, err := svc.Enroll(ctx, session, req.templateID, req.enrollments())
+		if err != nil {
+			return nil, err
+		}
+
+		res := enrollRes{
+			TemplateID:  req.templateID,
+			Total:       len(saved),
+			Enrollments: make([]enrollmentRes, len(saved)),
+		}
+		for i, e := range saved {
+			res.Enrollments[i] = enrollmentRes{
+				ExternalID:  e.ExternalID,
+				ExternalKey: e.ExternalKey,
+				DeviceKey:   e.DeviceKey,
+				KeyVersion:  e.KeyVersion,
+			}
+		}
+
+		return res, nil
+	}
+}
+
 func bootstrapEndpoint(svc bootstrap.Service, reader bootstrap.ConfigReader, secure bool) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(bootstrapReq)
@@ -265,7 +411,14 @@ func bootstrapEndpoint(svc bootstrap.Service, reader bootstrap.ConfigReader, sec
 			return nil, err
 		}
 
-		return reader.ReadConfig(cfg, secure)
+		var version bootstrap.KeyVersion
+		if secure {
+			if version, err = bootstrap.SecureKeyVersion(req.key); err != nil {
+				return nil, errors.Wrap(bootstrap.ErrExternalKeySecure, err)
+			}
+		}
+
+		return reader.ReadConfig(cfg, secure, version)
 	}
 }
 
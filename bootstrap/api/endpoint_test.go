@@ -8,6 +8,7 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -30,6 +31,7 @@ import (
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/hkdf"
 )
 
 const (
@@ -50,6 +52,7 @@ const (
 
 var (
 	encKey         = []byte("1234567891011121")
+	masterKey      = []byte("12345678910111213141516171819202")
 	metadata       = map[string]interface{}{"meta": "data"}
 	addExternalID  = testsutil.GenerateUUID(&testing.T{})
 	addExternalKey = testsutil.GenerateUUID(&testing.T{})
@@ -176,11 +179,49 @@ func dec(in []byte) ([]byte, error) {
 	return in, nil
 }
 
+func deviceCipher(cfg bootstrap.Config) (cipher.AEAD, error) {
+	key := make([]byte, 32)
+	kdf := hkdf.New(sha256.New, []byte(cfg.ExternalKey), masterKey, []byte("magistrala bootstrap device key "+cfg.ExternalID))
+	if _, err := io.ReadFull(kdf, key); err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func seal(cfg bootstrap.Config, in []byte) ([]byte, error) {
+	aead, err := deviceCipher(cfg)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, in, []byte(cfg.ExternalID)), nil
+}
+
+func open(cfg bootstrap.Config, in []byte) ([]byte, error) {
+	aead, err := deviceCipher(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(in) < 1+aead.NonceSize() || in[0] != 1 {
+		return nil, errors.ErrMalformedEntity
+	}
+	in = in[1:]
+	return aead.Open(nil, in[:aead.NonceSize()], in[aead.NonceSize():], []byte(cfg.ExternalID))
+}
+
 func newBootstrapServer() (*httptest.Server, *mocks.Service, *authnmocks.Authentication) {
 	logger := mglog.NewMock()
 	svc := new(mocks.Service)
 	authn := new(authnmocks.Authentication)
-	mux := bsapi.MakeHandler(svc, authn, bootstrap.NewConfigReader(encKey), logger, instanceID)
+	keys, _ := bootstrap.NewKeyring(encKey, map[bootstrap.KeyVersion][]byte{1: masterKey})
+	mux := bsapi.MakeHandler(svc, authn, bootstrap.NewConfigReader(keys), logger, instanceID)
 	return httptest.NewServer(mux), svc, authn
 }
 
@@ -1149,6 +1190,8 @@ func TestBootstrap(t *testing.T) {
 
 	encExternKey, err := enc([]byte(c.ExternalKey))
 	assert.Nil(t, err, fmt.Sprintf("Encrypting config expected to succeed: %s.\n", err))
+	sealedExternKey, err := seal(c, []byte(c.ExternalKey))
+	assert.Nil(t, err, fmt.Sprintf("Sealing config expected to succeed: %s.\n", err))
 
 	var channels []channel
 	for _, ch := range c.Channels {
@@ -1238,6 +1281,15 @@ func TestBootstrap(t *testing.T) {
 			secure:      true,
 			err:         nil,
 		},
+		{
+			desc:        "bootstrap secure with versioned key",
+			externalID:  fmt.Sprintf("secure/%s", c.ExternalID),
+			externalKey: "1." + hex.EncodeToString(sealedExternKey),
+			status:      http.StatusOK,
+			res:         data,
+			secure:      true,
+			err:         nil,
+		},
 		{
 			desc:        "bootstrap secure with unencrypted key",
 			externalID:  fmt.Sprintf("secure/%s", c.ExternalID),
@@ -1265,7 +1317,11 @@ func TestBootstrap(t *testing.T) {
 			body, err := io.ReadAll(res.Body)
 			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 			if tc.secure && tc.status == http.StatusOK {
-				body, err = dec(body)
+				if strings.HasPrefix(tc.externalKey, "1.") {
+					body, err = open(c, body)
+				} else {
+					body, err = dec(body)
+				}
 				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding body: %s", tc.desc, err))
 			}
 			data := strings.Trim(string(body), "\n")
@@ -4,11 +4,17 @@
 package api
 
 import (
+	"fmt"
+
 	"github.com/absmach/magistrala/bootstrap"
 	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
 )
 
-const maxLimitSize = 100
+const (
+	maxLimitSize       = 100
+	maxEnrollmentsSize = 10000
+)
 
 type addReq struct {
 	token       string
@@ -161,3 +167,122 @@ func (req changeStateReq) validate() error {
 
 	return nil
 }
+
+type templateReq struct {
+	token    string
+	id       string
+	Name     string                 `json:"name"`
+	Content  string                 `json:"content"`
+	Channels []string               `json:"channels"`
+	CertTTL  string                 `json:"cert_ttl"`
+	Metadata map[string]interface{} `json:"metadata"`
+}
+
+func (req templateReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.Name == "" {
+		return apiutil.ErrMissingName
+	}
+
+	for _, channel := range req.Channels {
+		if channel == "" {
+			return apiutil.ErrMissingID
+		}
+	}
+
+	return nil
+}
+
+func (req templateReq) template() bootstrap.Template {
+	return bootstrap.Template{
+		ID:       req.id,
+		Name:     req.Name,
+		Content:  req.Content,
+		Channels: req.Channels,
+		CertTTL:  req.CertTTL,
+		Metadata: req.Metadata,
+	}
+}
+
+type listTemplatesReq struct {
+	offset uint64
+	limit  uint64
+}
+
+func (req listTemplatesReq) validate() error {
+	if req.limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+// enrollRange enrolls external IDs formed as the prefix followed by each
+// number from start to end inclusive.
+type enrollRange struct {
+	Prefix string `json:"prefix"`
+	Start  uint64 `json:"start"`
+	End    uint64 `json:"end"`
+}
+
+type enrollReq struct {
+	templateID  string
+	Range       *enrollRange           `json:"range,omitempty"`
+	Enrollments []bootstrap.Enrollment `json:"enrollments,omitempty"`
+}
+
+func (req enrollReq) validate() error {
+	if req.templateID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.Range != nil {
+		if req.Range.End < req.Range.Start {
+			return errors.Wrap(errors.ErrMalformedEntity, errors.New("range end is lower than start"))
+		}
+		if req.Range.End-req.Range.Start >= maxEnrollmentsSize || len(req.Enrollments) > 0 {
+			return apiutil.ErrLimitSize
+		}
+		return nil
+	}
+
+	if len(req.Enrollments) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	if len(req.Enrollments) > maxEnrollmentsSize {
+		return apiutil.ErrLimitSize
+	}
+
+	ids := make(map[string]struct{}, len(req.Enrollments))
+	for _, e := range req.Enrollments {
+		if e.ExternalID == "" {
+			return apiutil.ErrMissingID
+		}
+		if _, ok := ids[e.ExternalID]; ok {
+			return errors.Wrap(errors.ErrMalformedEntity, fmt.Errorf("duplicate external ID %s", e.ExternalID))
+		}
+		ids[e.ExternalID] = struct{}{}
+	}
+
+	return nil
+}
+
+// enrollments returns the requested enrollments, expanding the range if set.
+func (req enrollReq) enrollments() []bootstrap.Enrollment {
+	if req.Range == nil {
+		return req.Enrollments
+	}
+
+	enrollments := make([]bootstrap.Enrollment, 0, req.Range.End-req.Range.Start+1)
+	for i := req.Range.Start; i <= req.Range.End; i++ {
+		enrollments = append(enrollments, bootstrap.Enrollment{
+			ExternalID: fmt.Sprintf("%s%d", req.Range.Prefix, i),
+		})
+	}
+
+	return enrollments
+}
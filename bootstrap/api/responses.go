@@ -17,6 +17,9 @@ var (
 	_ magistrala.Response = (*stateRes)(nil)
 	_ magistrala.Response = (*viewRes)(nil)
 	_ magistrala.Response = (*listRes)(nil)
+	_ magistrala.Response = (*templateRes)(nil)
+	_ magistrala.Response = (*templatesPageRes)(nil)
+	_ magistrala.Response = (*enrollRes)(nil)
 )
 
 type removeRes struct{}
@@ -34,8 +37,10 @@ func (res removeRes) Empty() bool {
 }
 
 type configRes struct {
-	id      string
-	created bool
+	id         string
+	created    bool
+	DeviceKey  string               `json:"device_key,omitempty"`
+	KeyVersion bootstrap.KeyVersion `json:"key_version,omitempty"`
 }
 
 func (res configRes) Code() int {
@@ -57,7 +62,7 @@ func (res configRes) Headers() map[string]string {
 }
 
 func (res configRes) Empty() bool {
-	return true
+	return res.DeviceKey == ""
 }
 
 type channelRes struct {
@@ -67,16 +72,18 @@ type channelRes struct {
 }
 
 type viewRes struct {
-	ThingID     string          `json:"thing_id,omitempty"`
-	ThingKey    string          `json:"thing_key,omitempty"`
-	Channels    []channelRes    `json:"channels,omitempty"`
-	ExternalID  string          `json:"external_id"`
-	ExternalKey string          `json:"external_key,omitempty"`
-	Content     string          `json:"content,omitempty"`
-	Name        string          `json:"name,omitempty"`
-	State       bootstrap.State `json:"state"`
-	ClientCert  string          `json:"client_cert,omitempty"`
-	CACert      string          `json:"ca_cert,omitempty"`
+	ThingID     string               `json:"thing_id,omitempty"`
+	ThingKey    string               `json:"thing_key,omitempty"`
+	Channels    []channelRes         `json:"channels,omitempty"`
+	ExternalID  string               `json:"external_id"`
+	ExternalKey string               `json:"external_key,omitempty"`
+	Content     string               `json:"content,omitempty"`
+	Name        string               `json:"name,omitempty"`
+	State       bootstrap.State      `json:"state"`
+	ClientCert  string               `json:"client_cert,omitempty"`
+	CACert      string               `json:"ca_cert,omitempty"`
+	DeviceKey   string               `json:"device_key,omitempty"`
+	KeyVersion  bootstrap.KeyVersion `json:"key_version,omitempty"`
 }
 
 func (res viewRes) Code() int {
@@ -142,3 +149,71 @@ func (res updateConfigRes) Headers() map[string]string {
 func (res updateConfigRes) Empty() bool {
 	return false
 }
+
+type templateRes struct {
+	bootstrap.Template
+	created bool
+}
+
+func (res templateRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res templateRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/things/templates/%s", res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res templateRes) Empty() bool {
+	return false
+}
+
+type templatesPageRes struct {
+	bootstrap.TemplatesPage
+}
+
+func (res templatesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res templatesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res templatesPageRes) Empty() bool {
+	return false
+}
+
+type enrollmentRes struct {
+	ExternalID  string               `json:"external_id"`
+	ExternalKey string               `json:"external_key"`
+	DeviceKey   string               `json:"device_key,omitempty"`
+	KeyVersion  bootstrap.KeyVersion `json:"key_version,omitempty"`
+}
+
+type enrollRes struct {
+	TemplateID  string          `json:"template_id"`
+	Total       int             `json:"total"`
+	Enrollments []enrollmentRes `json:"enrollments"`
+}
+
+func (res enrollRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res enrollRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res enrollRes) Empty() bool {
+	return false
+}
@@ -5,7 +5,9 @@ package api
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
@@ -25,11 +27,17 @@ import (
 
 const (
 	contentType     = "application/json"
+	csvContentType  = "text/csv"
 	byteContentType = "application/octet-stream"
 	offsetKey       = "offset"
 	limitKey        = "limit"
 	defOffset       = 0
 	defLimit        = 10
+
+	// Reserved enrollment CSV columns, others are treated as template variables.
+	externalIDColumn  = "external_id"
+	externalKeyColumn = "external_key"
+	nameColumn        = "name"
 )
 
 var (
@@ -94,6 +102,44 @@ func MakeHandler(svc bootstrap.Service, authn mgauthn.Authentication, reader boo
 					api.EncodeResponse,
 					opts...), "update_connections").ServeHTTP)
 			})
+
+			r.Route("/templates", func(r chi.Router) {
+				r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+					addTemplateEndpoint(svc),
+					decodeTemplateRequest,
+					api.EncodeResponse,
+					opts...), "add_template").ServeHTTP)
+
+				r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+					listTemplatesEndpoint(svc),
+					decodeListTemplatesRequest,
+					api.EncodeResponse,
+					opts...), "list_templates").ServeHTTP)
+
+				r.Get("/{templateID}", otelhttp.NewHandler(kithttp.NewServer(
+					viewTemplateEndpoint(svc),
+					decodeTemplateEntityRequest,
+					api.EncodeResponse,
+					opts...), "view_template").ServeHTTP)
+
+				r.Put("/{templateID}", otelhttp.NewHandler(kithttp.NewServer(
+					updateTemplateEndpoint(svc),
+					decodeTemplateRequest,
+					api.EncodeResponse,
+					opts...), "update_template").ServeHTTP)
+
+				r.Delete("/{templateID}", otelhttp.NewHandler(kithttp.NewServer(
+					removeTemplateEndpoint(svc),
+					decodeTemplateEntityRequest,
+					api.EncodeResponse,
+					opts...), "remove_template").ServeHTTP)
+
+				r.Post("/{templateID}/enrollments", otelhttp.NewHandler(kithttp.NewServer(
+					enrollEndpoint(svc),
+					decodeEnrollRequest,
+					api.EncodeResponse,
+					opts...), "enroll").ServeHTTP)
+			})
 		})
 
 		r.With(api.AuthenticateMiddleware(authn, true)).Put("/state/{thingID}", otelhttp.NewHandler(kithttp.NewServer(
@@ -246,6 +292,125 @@ func decodeEntityRequest(_ context.Context, r *http.Request) (interface{}, error
 	return req, nil
 }
 
+func decodeTemplateRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := templateReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    chi.URLParam(r, "templateID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeListTemplatesRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := apiutil.ReadNumQuery[uint64](r, offsetKey, defOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	l, err := apiutil.ReadNumQuery[uint64](r, limitKey, defLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listTemplatesReq{
+		offset: o,
+		limit:  l,
+	}
+
+	return req, nil
+}
+
+func decodeTemplateEntityRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := entityReq{
+		id: chi.URLParam(r, "templateID"),
+	}
+
+	return req, nil
+}
+
+func decodeEnrollRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := enrollReq{
+		templateID: chi.URLParam(r, "templateID"),
+	}
+
+	switch ct := r.Header.Get("Content-Type"); {
+	case strings.Contains(ct, csvContentType):
+		enrollments, err := decodeEnrollmentsCSV(r.Body)
+		if err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+		}
+		req.Enrollments = enrollments
+	case strings.Contains(ct, contentType):
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+		}
+	default:
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	return req, nil
+}
+
+// decodeEnrollmentsCSV reads enrollments from CSV with a header row. The
+// external_id column is required, external_key and name are optional and
+// the remaining columns are used as template variables.
+func decodeEnrollmentsCSV(body io.Reader) ([]bootstrap.Enrollment, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	idCol := -1
+	for i, col := range header {
+		header[i] = strings.TrimSpace(col)
+		if header[i] == externalIDColumn {
+			idCol = i
+		}
+	}
+	if idCol < 0 {
+		return nil, errors.New("missing external_id column")
+	}
+
+	var enrollments []bootstrap.Enrollment
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(enrollments) == maxEnrollmentsSize {
+			return nil, apiutil.ErrLimitSize
+		}
+		e := bootstrap.Enrollment{Vars: map[string]string{}}
+		for i, value := range record {
+			switch header[i] {
+			case externalIDColumn:
+				e.ExternalID = value
+			case externalKeyColumn:
+				e.ExternalKey = value
+			case nameColumn:
+				e.Name = value
+			default:
+				e.Vars[header[i]] = value
+			}
+		}
+		enrollments = append(enrollments, e)
+	}
+
+	return enrollments, nil
+}
+
 func encodeSecureRes(_ context.Context, w http.ResponseWriter, response interface{}) error {
 	w.Header().Set("Content-Type", byteContentType)
 	w.WriteHeader(http.StatusOK)
@@ -15,19 +15,26 @@ import (
 // MGThing represents corresponding Magistrala Thing ID.
 // MGKey is key of corresponding Magistrala Thing.
 // MGChannels is a list of Magistrala Channels corresponding Magistrala Thing connects to.
+// CertTTL is the TTL of the client certificate issued when the Config of the
+// enrolled device is enabled.
+// DeviceKey is the secure bootstrap key of the device, derived from the master
+// key of KeyVersion. It is not persisted.
 type Config struct {
-	ThingID     string    `json:"thing_id"`
-	DomainID    string    `json:"domain_id,omitempty"`
-	Name        string    `json:"name,omitempty"`
-	ClientCert  string    `json:"client_cert,omitempty"`
-	ClientKey   string    `json:"client_key,omitempty"`
-	CACert      string    `json:"ca_cert,omitempty"`
-	ThingKey    string    `json:"thing_key"`
-	Channels    []Channel `json:"channels,omitempty"`
-	ExternalID  string    `json:"external_id"`
-	ExternalKey string    `json:"external_key"`
-	Content     string    `json:"content,omitempty"`
-	State       State     `json:"state"`
+	ThingID     string     `json:"thing_id"`
+	DomainID    string     `json:"domain_id,omitempty"`
+	Name        string     `json:"name,omitempty"`
+	ClientCert  string     `json:"client_cert,omitempty"`
+	ClientKey   string     `json:"client_key,omitempty"`
+	CACert      string     `json:"ca_cert,omitempty"`
+	ThingKey    string     `json:"thing_key"`
+	Channels    []Channel  `json:"channels,omitempty"`
+	ExternalID  string     `json:"external_id"`
+	ExternalKey string     `json:"external_key"`
+	Content     string     `json:"content,omitempty"`
+	State       State      `json:"state"`
+	CertTTL     string     `json:"cert_ttl,omitempty"`
+	DeviceKey   string     `json:"device_key,omitempty"`
+	KeyVersion  KeyVersion `json:"key_version,omitempty"`
 }
 
 // Channel represents Magistrala channel corresponding Magistrala Thing is connected to.
@@ -100,6 +107,10 @@ type ConfigRepository interface {
 	// ListExisting retrieves those channels from the given list that exist in DB.
 	ListExisting(ctx context.Context, domainID string, ids []string) ([]Channel, error)
 
+	// SaveChannels persists the channels of the domain, updating the
+	// existing ones.
+	SaveChannels(ctx context.Context, domainID string, channels []Channel) error
+
 	// Methods RemoveThing, UpdateChannel, and RemoveChannel are related to
 	// event sourcing. That's why these methods surpass ownership check.
 
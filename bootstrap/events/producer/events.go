@@ -24,6 +24,14 @@ const (
 	thingConnect           = thingPrefix + "connect"
 	thingDisconnect        = thingPrefix + "disconnect"
 
+	templatePrefix = "bootstrap.template."
+	templateCreate = templatePrefix + "create"
+	templateView   = templatePrefix + "view"
+	templateUpdate = templatePrefix + "update"
+	templateList   = templatePrefix + "list"
+	templateRemove = templatePrefix + "remove"
+	templateEnroll = templatePrefix + "enroll"
+
 	channelPrefix        = "bootstrap.channel."
 	channelHandlerRemove = channelPrefix + "remove_handler"
 	channelUpdateHandler = channelPrefix + "update_handler"
@@ -40,6 +48,10 @@ var (
 	_ events.Event = (*updateCertEvent)(nil)
 	_ events.Event = (*listConfigsEvent)(nil)
 	_ events.Event = (*removeHandlerEvent)(nil)
+	_ events.Event = (*templateEvent)(nil)
+	_ events.Event = (*removeTemplateEvent)(nil)
+	_ events.Event = (*listTemplatesEvent)(nil)
+	_ events.Event = (*enrollEvent)(nil)
 )
 
 type configEvent struct {
@@ -59,7 +71,7 @@ func (ce configEvent) Encode() (map[string]interface{}, error) {
 		val["content"] = ce.Content
 	}
 	if ce.DomainID != "" {
-		val["domain_id "] = ce.DomainID
+		val["domain_id"] = ce.DomainID
 	}
 	if ce.Name != "" {
 		val["name"] = ce.Name
@@ -91,12 +103,14 @@ func (ce configEvent) Encode() (map[string]interface{}, error) {
 }
 
 type removeConfigEvent struct {
-	mgThing string
+	mgThing  string
+	domainID string
 }
 
 func (rce removeConfigEvent) Encode() (map[string]interface{}, error) {
 	return map[string]interface{}{
 		"thing_id":  rce.mgThing,
+		"domain_id": rce.domainID,
 		"operation": configRemove,
 	}, nil
 }
@@ -144,7 +158,7 @@ func (be bootstrapEvent) Encode() (map[string]interface{}, error) {
 		val["content"] = be.Content
 	}
 	if be.DomainID != "" {
-		val["domain_id "] = be.DomainID
+		val["domain_id"] = be.DomainID
 	}
 	if be.Name != "" {
 		val["name"] = be.Name
@@ -175,13 +189,15 @@ func (be bootstrapEvent) Encode() (map[string]interface{}, error) {
 }
 
 type changeStateEvent struct {
-	mgThing string
-	state   bootstrap.State
+	mgThing  string
+	domainID string
+	state    bootstrap.State
 }
 
 func (cse changeStateEvent) Encode() (map[string]interface{}, error) {
 	return map[string]interface{}{
 		"thing_id":  cse.mgThing,
+		"domain_id": cse.domainID,
 		"state":     cse.state.String(),
 		"operation": thingStateChange,
 	}, nil
@@ -189,12 +205,14 @@ func (cse changeStateEvent) Encode() (map[string]interface{}, error) {
 
 type updateConnectionsEvent struct {
 	mgThing    string
+	domainID   string
 	mgChannels []string
 }
 
 func (uce updateConnectionsEvent) Encode() (map[string]interface{}, error) {
 	return map[string]interface{}{
 		"thing_id":  uce.mgThing,
+		"domain_id": uce.domainID,
 		"channels":  uce.mgChannels,
 		"operation": thingUpdateConnections,
 	}, nil
@@ -272,3 +290,72 @@ func (dte disconnectThingEvent) Encode() (map[string]interface{}, error) {
 		"operation":  thingDisconnect,
 	}, nil
 }
+
+type templateEvent struct {
+	bootstrap.Template
+	operation string
+}
+
+func (te templateEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"id":        te.ID,
+		"operation": te.operation,
+	}
+	if te.DomainID != "" {
+		val["domain_id"] = te.DomainID
+	}
+	if te.Name != "" {
+		val["name"] = te.Name
+	}
+	if te.Content != "" {
+		val["content"] = te.Content
+	}
+	if len(te.Channels) > 0 {
+		val["channels"] = te.Channels
+	}
+	if te.CertTTL != "" {
+		val["cert_ttl"] = te.CertTTL
+	}
+	if te.Metadata != nil {
+		val["metadata"] = te.Metadata
+	}
+
+	return val, nil
+}
+
+type removeTemplateEvent struct {
+	id string
+}
+
+func (rte removeTemplateEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"id":        rte.id,
+		"operation": templateRemove,
+	}, nil
+}
+
+type listTemplatesEvent struct {
+	offset uint64
+	limit  uint64
+}
+
+func (lte listTemplatesEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"offset":    lte.offset,
+		"limit":     lte.limit,
+		"operation": templateList,
+	}, nil
+}
+
+type enrollEvent struct {
+	templateID  string
+	externalIDs []string
+}
+
+func (ee enrollEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"template_id":  ee.templateID,
+		"external_ids": ee.externalIDs,
+		"operation":    templateEnroll,
+	}, nil
+}
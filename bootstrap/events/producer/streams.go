@@ -99,6 +99,7 @@ func (es *eventStore) UpdateConnections(ctx context.Context, session mgauthn.Ses
 
 	ev := updateConnectionsEvent{
 		mgThing:    id,
+		domainID:   session.DomainID,
 		mgChannels: connections,
 	}
 
@@ -131,7 +132,8 @@ func (es *eventStore) Remove(ctx context.Context, session mgauthn.Session, id st
 	}
 
 	ev := removeConfigEvent{
-		mgThing: id,
+		mgThing:  id,
+		domainID: session.DomainID,
 	}
 
 	return es.Publish(ctx, ev)
@@ -163,13 +165,116 @@ func (es *eventStore) ChangeState(ctx context.Context, session mgauthn.Session,
 	}
 
 	ev := changeStateEvent{
-		mgThing: id,
-		state:   state,
+		mgThing:  id,
+		domainID: session.DomainID,
+		state:    state,
 	}
 
 	return es.Publish(ctx, ev)
 }
 
+func (es *eventStore) AddTemplate(ctx context.Context, session mgauthn.Session, token string, tpl bootstrap.Template) (bootstrap.Template, error) {
+	saved, err := es.svc.AddTemplate(ctx, session, token, tpl)
+	if err != nil {
+		return saved, err
+	}
+
+	ev := templateEvent{
+		saved, templateCreate,
+	}
+
+	if err := es.Publish(ctx, ev); err != nil {
+		return saved, err
+	}
+
+	return saved, nil
+}
+
+func (es *eventStore) ViewTemplate(ctx context.Context, session mgauthn.Session, id string) (bootstrap.Template, error) {
+	tpl, err := es.svc.ViewTemplate(ctx, session, id)
+	if err != nil {
+		return tpl, err
+	}
+
+	ev := templateEvent{
+		tpl, templateView,
+	}
+
+	if err := es.Publish(ctx, ev); err != nil {
+		return tpl, err
+	}
+
+	return tpl, nil
+}
+
+func (es *eventStore) UpdateTemplate(ctx context.Context, session mgauthn.Session, token string, tpl bootstrap.Template) (bootstrap.Template, error) {
+	saved, err := es.svc.UpdateTemplate(ctx, session, token, tpl)
+	if err != nil {
+		return saved, err
+	}
+
+	ev := templateEvent{
+		saved, templateUpdate,
+	}
+
+	if err := es.Publish(ctx, ev); err != nil {
+		return saved, err
+	}
+
+	return saved, nil
+}
+
+func (es *eventStore) ListTemplates(ctx context.Context, session mgauthn.Session, offset, limit uint64) (bootstrap.TemplatesPage, error) {
+	page, err := es.svc.ListTemplates(ctx, session, offset, limit)
+	if err != nil {
+		return page, err
+	}
+
+	ev := listTemplatesEvent{
+		offset: offset,
+		limit:  limit,
+	}
+
+	if err := es.Publish(ctx, ev); err != nil {
+		return page, err
+	}
+
+	return page, nil
+}
+
+func (es *eventStore) RemoveTemplate(ctx context.Context, session mgauthn.Session, id string) error {
+	if err := es.svc.RemoveTemplate(ctx, session, id); err != nil {
+		return err
+	}
+
+	ev := removeTemplateEvent{
+		id: id,
+	}
+
+	return es.Publish(ctx, ev)
+}
+
+func (es *eventStore) Enroll(ctx context.Context, session mgauthn.Session, templateID string, enrollments []bootstrap.Enrollment) ([]bootstrap.Enrollment, error) {
+	saved, err := es.svc.Enroll(ctx, session, templateID, enrollments)
+	if err != nil {
+		return saved, err
+	}
+
+	ev := enrollEvent{
+		templateID:  templateID,
+		externalIDs: make([]string, len(saved)),
+	}
+	for i, e := range saved {
+		ev.externalIDs[i] = e.ExternalID
+	}
+
+	if err := es.Publish(ctx, ev); err != nil {
+		return saved, err
+	}
+
+	return saved, nil
+}
+
 func (es *eventStore) RemoveConfigHandler(ctx context.Context, id string) error {
 	if err := es.svc.RemoveConfigHandler(ctx, id); err != nil {
 		return err
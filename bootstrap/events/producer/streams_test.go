@@ -25,6 +25,7 @@ import (
 	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
 	sdkmocks "github.com/absmach/magistrala/pkg/sdk/mocks"
 	"github.com/absmach/magistrala/pkg/uuid"
+	thmocks "github.com/absmach/magistrala/things/mocks"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -97,7 +98,8 @@ func newTestVariable(t *testing.T, redisURL string) testVariable {
 	policies := new(policymocks.Service)
 	sdk := new(sdkmocks.SDK)
 	idp := uuid.NewMock()
-	svc := bootstrap.New(policies, boot, sdk, encKey, idp)
+	keys, _ := bootstrap.NewKeyring(encKey, nil)
+	svc := bootstrap.New(policies, boot, new(mocks.TemplateRepository), sdk, keys, new(thmocks.ThingsServiceClient), idp)
 	publisher, err := store.NewPublisher(context.Background(), redisURL, streamID)
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
 	svc = producer.NewEventStoreMiddleware(svc, publisher)
@@ -421,6 +423,7 @@ func TestUpdateConnections(t *testing.T) {
 			err:         nil,
 			event: map[string]interface{}{
 				"thing_id":  config.ThingID,
+				"domain_id": domainID,
 				"channels":  "2",
 				"timestamp": time.Now().Unix(),
 				"operation": thingUpdateConnections,
@@ -873,6 +876,7 @@ func TestRemove(t *testing.T) {
 			err:      nil,
 			event: map[string]interface{}{
 				"thing_id":  config.ThingID,
+				"domain_id": domainID,
 				"timestamp": time.Now().Unix(),
 				"operation": configRemove,
 			},
@@ -1010,6 +1014,7 @@ func TestChangeState(t *testing.T) {
 			err:          nil,
 			event: map[string]interface{}{
 				"thing_id":  config.ThingID,
+				"domain_id": domainID,
 				"state":     bootstrap.Active.String(),
 				"timestamp": time.Now().Unix(),
 				"operation": thingStateChange,
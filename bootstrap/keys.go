@@ -0,0 +1,278 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"golang.org/x/crypto/hkdf"
+)
+
+// LegacyKeyVersion denotes the deprecated AES-CFB scheme, which encrypts
+// secure bootstrap requests and responses with the service-wide key.
+const LegacyKeyVersion KeyVersion = 0
+
+const (
+	minMasterKeyLen = 32
+	deviceKeyLen    = 32
+	versionSep      = "."
+	deviceKeyInfo   = "magistrala bootstrap device key "
+)
+
+var (
+	// ErrUnknownKeyVersion indicates that the key version is not configured.
+	ErrUnknownKeyVersion = errors.New("unknown bootstrap key version")
+
+	// ErrInvalidMasterKeys indicates malformed master keys configuration.
+	ErrInvalidMasterKeys = errors.New("invalid bootstrap master keys")
+
+	errDecrypt = errors.New("failed to decrypt secure bootstrap payload")
+)
+
+// KeyVersion identifies the master key used to derive the device key.
+type KeyVersion uint8
+
+// Keyring holds the versioned master keys the per-device secure bootstrap
+// keys are derived from, and the legacy service-wide key. Keeping several
+// versions configured allows the master key to be rotated while devices
+// provisioned with previous versions keep working.
+type Keyring struct {
+	legacy []byte
+	keys   map[KeyVersion][]byte
+}
+
+// NewKeyring returns a keyring with the given master keys. The legacy key
+// is optional and, if empty, the deprecated AES-CFB scheme is rejected.
+func NewKeyring(legacy []byte, keys map[KeyVersion][]byte) (Keyring, error) {
+	if len(legacy) > 0 {
+		if _, err := aes.NewCipher(legacy); err != nil {
+			return Keyring{}, errors.Wrap(ErrInvalidMasterKeys, err)
+		}
+	}
+	for v, k := range keys {
+		if v == LegacyKeyVersion {
+			return Keyring{}, errors.Wrap(ErrInvalidMasterKeys, fmt.Errorf("version %d is reserved for the legacy key", v))
+		}
+		if len(k) < minMasterKeyLen {
+			return Keyring{}, errors.Wrap(ErrInvalidMasterKeys, fmt.Errorf("key version %d is shorter than %d bytes", v, minMasterKeyLen))
+		}
+	}
+
+	return Keyring{legacy: legacy, keys: keys}, nil
+}
+
+// ParseMasterKeys parses comma separated master keys in the
+// <version>:<base64 key> format.
+func ParseMasterKeys(s string) (map[KeyVersion][]byte, error) {
+	keys := make(map[KeyVersion][]byte)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		v, k, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, errors.Wrap(ErrInvalidMasterKeys, errors.New("missing key version"))
+		}
+		version, err := strconv.ParseUint(v, 10, 8)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidMasterKeys, err)
+		}
+		key, err := base64.StdEncoding.DecodeString(k)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidMasterKeys, err)
+		}
+		if _, ok := keys[KeyVersion(version)]; ok {
+			return nil, errors.Wrap(ErrInvalidMasterKeys, fmt.Errorf("duplicate key version %d", version))
+		}
+		keys[KeyVersion(version)] = key
+	}
+
+	return keys, nil
+}
+
+// SecureKeyVersion returns the key version of the encrypted external key
+// sent to the secure bootstrap endpoint. Keys encrypted with the legacy
+// scheme carry no version.
+func SecureKeyVersion(encKey string) (KeyVersion, error) {
+	v, _, ok := strings.Cut(encKey, versionSep)
+	if !ok {
+		return LegacyKeyVersion, nil
+	}
+	version, err := strconv.ParseUint(v, 10, 8)
+	if err != nil || version == uint64(LegacyKeyVersion) {
+		return LegacyKeyVersion, ErrUnknownKeyVersion
+	}
+
+	return KeyVersion(version), nil
+}
+
+// decryptExternalKey decrypts the external key sent to the secure bootstrap
+// endpoint. Versioned keys are sealed with the device key derived from the
+// expected external key, so a successful decryption authenticates the device.
+func (kr Keyring) decryptExternalKey(cfg Config, encKey string) (string, error) {
+	version, err := SecureKeyVersion(encKey)
+	if err != nil {
+		return "", err
+	}
+	if version == LegacyKeyVersion {
+		if len(kr.legacy) == 0 {
+			return "", ErrUnknownKeyVersion
+		}
+		return kr.decryptLegacy(encKey)
+	}
+
+	_, payload, _ := strings.Cut(encKey, versionSep)
+	ciphertext, err := hex.DecodeString(payload)
+	if err != nil {
+		return "", err
+	}
+	aead, err := kr.deviceCipher(version, cfg)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(aead, ciphertext, []byte(cfg.ExternalID))
+	if err != nil {
+		return "", err
+	}
+	if subtle.ConstantTimeCompare(plaintext, []byte(cfg.ExternalKey)) != 1 {
+		return "", errDecrypt
+	}
+
+	return string(plaintext), nil
+}
+
+// encrypt encrypts the bootstrap response for the device. Versioned
+// responses are prefixed with the key version, followed by the nonce and
+// the sealed payload.
+func (kr Keyring) encrypt(version KeyVersion, cfg Config, in []byte) ([]byte, error) {
+	if version == LegacyKeyVersion {
+		if len(kr.legacy) == 0 {
+			return nil, ErrUnknownKeyVersion
+		}
+		return kr.encryptLegacy(in)
+	}
+
+	aead, err := kr.deviceCipher(version, cfg)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 1+aead.NonceSize(), 1+aead.NonceSize()+len(in)+aead.Overhead())
+	out[0] = byte(version)
+	if _, err := io.ReadFull(rand.Reader, out[1:]); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(out, out[1:], in, []byte(cfg.ExternalID)), nil
+}
+
+// DeviceKey returns the base64 encoded key of the device with the given
+// external ID and key, derived from the latest master key. The device key is
+// handed out to the device owner, so the master keys never leave the service.
+// If no master keys are configured, an empty key and the legacy version are
+// returned.
+func (kr Keyring) DeviceKey(externalID, externalKey string) (KeyVersion, string, error) {
+	var latest KeyVersion
+	for v := range kr.keys {
+		if v > latest {
+			latest = v
+		}
+	}
+	if latest == LegacyKeyVersion {
+		return LegacyKeyVersion, "", nil
+	}
+	key, err := kr.deriveDeviceKey(latest, externalID, externalKey)
+	if err != nil {
+		return LegacyKeyVersion, "", err
+	}
+
+	return latest, base64.StdEncoding.EncodeToString(key), nil
+}
+
+// deviceCipher returns the AEAD sealed with the device key of the Config.
+func (kr Keyring) deviceCipher(version KeyVersion, cfg Config) (cipher.AEAD, error) {
+	key, err := kr.deriveDeviceKey(version, cfg.ExternalID, cfg.ExternalKey)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// deriveDeviceKey derives the device key from the external key, salted with
+// the master key of the given version and bound to the external ID.
+func (kr Keyring) deriveDeviceKey(version KeyVersion, externalID, externalKey string) ([]byte, error) {
+	master, ok := kr.keys[version]
+	if !ok {
+		return nil, ErrUnknownKeyVersion
+	}
+	key := make([]byte, deviceKeyLen)
+	kdf := hkdf.New(sha256.New, []byte(externalKey), master, []byte(deviceKeyInfo+externalID))
+	if _, err := io.ReadFull(kdf, key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func open(aead cipher.AEAD, in, ad []byte) ([]byte, error) {
+	if len(in) < aead.NonceSize() {
+		return nil, errDecrypt
+	}
+	plaintext, err := aead.Open(nil, in[:aead.NonceSize()], in[aead.NonceSize():], ad)
+	if err != nil {
+		return nil, errors.Wrap(errDecrypt, err)
+	}
+
+	return plaintext, nil
+}
+
+func (kr Keyring) encryptLegacy(in []byte) ([]byte, error) {
+	block, err := aes.NewCipher(kr.legacy)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext := make([]byte, aes.BlockSize+len(in))
+	iv := ciphertext[:aes.BlockSize]
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return nil, err
+	}
+	stream := cipher.NewCFBEncrypter(block, iv)
+	stream.XORKeyStream(ciphertext[aes.BlockSize:], in)
+	return ciphertext, nil
+}
+
+func (kr Keyring) decryptLegacy(in string) (string, error) {
+	ciphertext, err := hex.DecodeString(in)
+	if err != nil {
+		return "", err
+	}
+	block, err := aes.NewCipher(kr.legacy)
+	if err != nil {
+		return "", err
+	}
+	if len(ciphertext) < aes.BlockSize {
+		return "", errDecrypt
+	}
+	iv := ciphertext[:aes.BlockSize]
+	ciphertext = ciphertext[aes.BlockSize:]
+	stream := cipher.NewCFBDecrypter(block, iv)
+	stream.XORKeyStream(ciphertext, ciphertext)
+	return string(ciphertext), nil
+}
@@ -0,0 +1,192 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/bootstrap"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMasterKeys(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(masterKey)
+
+	cases := []struct {
+		desc string
+		keys string
+		res  map[bootstrap.KeyVersion][]byte
+		err  error
+	}{
+		{
+			desc: "parse empty master keys",
+			keys: "",
+			res:  map[bootstrap.KeyVersion][]byte{},
+		},
+		{
+			desc: "parse multiple master keys",
+			keys: fmt.Sprintf("1:%s, 2:%s", key, key),
+			res:  map[bootstrap.KeyVersion][]byte{1: masterKey, 2: masterKey},
+		},
+		{
+			desc: "parse master key without version",
+			keys: key,
+			err:  bootstrap.ErrInvalidMasterKeys,
+		},
+		{
+			desc: "parse master key with invalid version",
+			keys: fmt.Sprintf("256:%s", key),
+			err:  bootstrap.ErrInvalidMasterKeys,
+		},
+		{
+			desc: "parse master key with invalid encoding",
+			keys: "1:invalid!",
+			err:  bootstrap.ErrInvalidMasterKeys,
+		},
+		{
+			desc: "parse master keys with duplicate version",
+			keys: fmt.Sprintf("1:%s,1:%s", key, key),
+			err:  bootstrap.ErrInvalidMasterKeys,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			res, err := bootstrap.ParseMasterKeys(tc.keys)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, res))
+			}
+		})
+	}
+}
+
+func TestNewKeyring(t *testing.T) {
+	cases := []struct {
+		desc   string
+		legacy []byte
+		keys   map[bootstrap.KeyVersion][]byte
+		err    error
+	}{
+		{
+			desc:   "create keyring with legacy and master keys",
+			legacy: encKey,
+			keys:   map[bootstrap.KeyVersion][]byte{1: masterKey},
+		},
+		{
+			desc: "create keyring without legacy key",
+			keys: map[bootstrap.KeyVersion][]byte{1: masterKey},
+		},
+		{
+			desc:   "create keyring with invalid legacy key",
+			legacy: []byte("invalid"),
+			err:    bootstrap.ErrInvalidMasterKeys,
+		},
+		{
+			desc: "create keyring with reserved key version",
+			keys: map[bootstrap.KeyVersion][]byte{bootstrap.LegacyKeyVersion: masterKey},
+			err:  bootstrap.ErrInvalidMasterKeys,
+		},
+		{
+			desc: "create keyring with short master key",
+			keys: map[bootstrap.KeyVersion][]byte{1: encKey},
+			err:  bootstrap.ErrInvalidMasterKeys,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := bootstrap.NewKeyring(tc.legacy, tc.keys)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestSecureKeyVersion(t *testing.T) {
+	cases := []struct {
+		desc    string
+		key     string
+		version bootstrap.KeyVersion
+		err     error
+	}{
+		{
+			desc:    "legacy key",
+			key:     "0a1b2c",
+			version: bootstrap.LegacyKeyVersion,
+		},
+		{
+			desc:    "versioned key",
+			key:     "3.0a1b2c",
+			version: 3,
+		},
+		{
+			desc: "key with reserved version",
+			key:  "0.0a1b2c",
+			err:  bootstrap.ErrUnknownKeyVersion,
+		},
+		{
+			desc: "key with invalid version",
+			key:  "v1.0a1b2c",
+			err:  bootstrap.ErrUnknownKeyVersion,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			version, err := bootstrap.SecureKeyVersion(tc.key)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.version, version, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.version, version))
+		})
+	}
+}
+
+func TestDeviceKey(t *testing.T) {
+	rotatedKey := []byte("21020291817161514131211101987654")
+	key, err := deviceKey("external-id", "external-key")
+	assert.Nil(t, err, fmt.Sprintf("deriving device key expected to succeed: %s\n", err))
+
+	cases := []struct {
+		desc      string
+		keys      map[bootstrap.KeyVersion][]byte
+		version   bootstrap.KeyVersion
+		deviceKey string
+	}{
+		{
+			desc:      "derive device key",
+			keys:      map[bootstrap.KeyVersion][]byte{1: masterKey},
+			version:   1,
+			deviceKey: base64.StdEncoding.EncodeToString(key),
+		},
+		{
+			desc:    "derive device key with the latest master key",
+			keys:    map[bootstrap.KeyVersion][]byte{1: masterKey, 2: rotatedKey},
+			version: 2,
+		},
+		{
+			desc:    "derive device key without master keys",
+			version: bootstrap.LegacyKeyVersion,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			keys, err := bootstrap.NewKeyring(encKey, tc.keys)
+			assert.Nil(t, err, fmt.Sprintf("%s: creating keyring expected to succeed: %s\n", tc.desc, err))
+			version, deviceKey, err := keys.DeviceKey("external-id", "external-key")
+			assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+			assert.Equal(t, tc.version, version, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.version, version))
+			switch {
+			case tc.deviceKey != "":
+				assert.Equal(t, tc.deviceKey, deviceKey, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.deviceKey, deviceKey))
+			case tc.version == bootstrap.LegacyKeyVersion:
+				assert.Empty(t, deviceKey, fmt.Sprintf("%s: expected empty device key got %s\n", tc.desc, deviceKey))
+			default:
+				assert.NotEqual(t, base64.StdEncoding.EncodeToString(key), deviceKey, fmt.Sprintf("%s: expected device key of version %d\n", tc.desc, tc.version))
+			}
+		})
+	}
+}
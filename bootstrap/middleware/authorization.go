@@ -95,6 +95,54 @@ func (am *authorizationMiddleware) ChangeState(ctx context.Context, session mgau
 	return am.svc.ChangeState(ctx, session, token, id, state)
 }
 
+func (am *authorizationMiddleware) AddTemplate(ctx context.Context, session mgauthn.Session, token string, tpl bootstrap.Template) (bootstrap.Template, error) {
+	if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, session.DomainUserID, policies.MembershipPermission, policies.DomainType, session.DomainID); err != nil {
+		return bootstrap.Template{}, err
+	}
+
+	return am.svc.AddTemplate(ctx, session, token, tpl)
+}
+
+func (am *authorizationMiddleware) ViewTemplate(ctx context.Context, session mgauthn.Session, id string) (bootstrap.Template, error) {
+	if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, session.DomainUserID, policies.MembershipPermission, policies.DomainType, session.DomainID); err != nil {
+		return bootstrap.Template{}, err
+	}
+
+	return am.svc.ViewTemplate(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) UpdateTemplate(ctx context.Context, session mgauthn.Session, token string, tpl bootstrap.Template) (bootstrap.Template, error) {
+	if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, session.DomainUserID, policies.MembershipPermission, policies.DomainType, session.DomainID); err != nil {
+		return bootstrap.Template{}, err
+	}
+
+	return am.svc.UpdateTemplate(ctx, session, token, tpl)
+}
+
+func (am *authorizationMiddleware) ListTemplates(ctx context.Context, session mgauthn.Session, offset, limit uint64) (bootstrap.TemplatesPage, error) {
+	if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, session.DomainUserID, policies.MembershipPermission, policies.DomainType, session.DomainID); err != nil {
+		return bootstrap.TemplatesPage{}, err
+	}
+
+	return am.svc.ListTemplates(ctx, session, offset, limit)
+}
+
+func (am *authorizationMiddleware) RemoveTemplate(ctx context.Context, session mgauthn.Session, id string) error {
+	if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, session.DomainUserID, policies.MembershipPermission, policies.DomainType, session.DomainID); err != nil {
+		return err
+	}
+
+	return am.svc.RemoveTemplate(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) Enroll(ctx context.Context, session mgauthn.Session, templateID string, enrollments []bootstrap.Enrollment) ([]bootstrap.Enrollment, error) {
+	if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, session.DomainUserID, policies.MembershipPermission, policies.DomainType, session.DomainID); err != nil {
+		return nil, err
+	}
+
+	return am.svc.Enroll(ctx, session, templateID, enrollments)
+}
+
 func (am *authorizationMiddleware) UpdateChannelHandler(ctx context.Context, channel bootstrap.Channel) error {
 	return am.svc.UpdateChannelHandler(ctx, channel)
 }
@@ -203,6 +203,131 @@ func (lm *loggingMiddleware) ChangeState(ctx context.Context, session mgauthn.Se
 	return lm.svc.ChangeState(ctx, session, token, id, state)
 }
 
+// AddTemplate logs the add_template request. It logs the template ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) AddTemplate(ctx context.Context, session mgauthn.Session, token string, tpl bootstrap.Template) (saved bootstrap.Template, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("template",
+				slog.String("id", saved.ID),
+				slog.String("name", tpl.Name),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Add bootstrap template failed", args...)
+			return
+		}
+		lm.logger.Info("Add bootstrap template completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.AddTemplate(ctx, session, token, tpl)
+}
+
+// ViewTemplate logs the view_template request. It logs the template ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ViewTemplate(ctx context.Context, session mgauthn.Session, id string) (tpl bootstrap.Template, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("template_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View bootstrap template failed", args...)
+			return
+		}
+		lm.logger.Info("View bootstrap template completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewTemplate(ctx, session, id)
+}
+
+// UpdateTemplate logs the update_template request. It logs the template ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) UpdateTemplate(ctx context.Context, session mgauthn.Session, token string, tpl bootstrap.Template) (saved bootstrap.Template, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("template",
+				slog.String("id", tpl.ID),
+				slog.String("name", tpl.Name),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Update bootstrap template failed", args...)
+			return
+		}
+		lm.logger.Info("Update bootstrap template completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.UpdateTemplate(ctx, session, token, tpl)
+}
+
+// ListTemplates logs the list_templates request. It logs offset, limit and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ListTemplates(ctx context.Context, session mgauthn.Session, offset, limit uint64) (page bootstrap.TemplatesPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("page",
+				slog.Uint64("offset", offset),
+				slog.Uint64("limit", limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List bootstrap templates failed", args...)
+			return
+		}
+		lm.logger.Info("List bootstrap templates completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListTemplates(ctx, session, offset, limit)
+}
+
+// RemoveTemplate logs the remove_template request. It logs the template ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) RemoveTemplate(ctx context.Context, session mgauthn.Session, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("template_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove bootstrap template failed", args...)
+			return
+		}
+		lm.logger.Info("Remove bootstrap template completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveTemplate(ctx, session, id)
+}
+
+// Enroll logs the enroll request. It logs the template ID, the number of devices and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) Enroll(ctx context.Context, session mgauthn.Session, templateID string, enrollments []bootstrap.Enrollment) (saved []bootstrap.Enrollment, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("template_id", templateID),
+			slog.Int("enrollments", len(enrollments)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Enroll bootstrap devices failed", args...)
+			return
+		}
+		lm.logger.Info("Enroll bootstrap devices completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Enroll(ctx, session, templateID, enrollments)
+}
+
 func (lm *loggingMiddleware) UpdateChannelHandler(ctx context.Context, channel bootstrap.Channel) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
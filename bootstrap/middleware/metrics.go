@@ -121,6 +121,66 @@ func (mm *metricsMiddleware) ChangeState(ctx context.Context, session mgauthn.Se
 	return mm.svc.ChangeState(ctx, session, token, id, state)
 }
 
+// AddTemplate instruments AddTemplate method with metrics.
+func (mm *metricsMiddleware) AddTemplate(ctx context.Context, session mgauthn.Session, token string, tpl bootstrap.Template) (saved bootstrap.Template, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "add_template").Add(1)
+		mm.latency.With("method", "add_template").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.AddTemplate(ctx, session, token, tpl)
+}
+
+// ViewTemplate instruments ViewTemplate method with metrics.
+func (mm *metricsMiddleware) ViewTemplate(ctx context.Context, session mgauthn.Session, id string) (tpl bootstrap.Template, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_template").Add(1)
+		mm.latency.With("method", "view_template").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewTemplate(ctx, session, id)
+}
+
+// UpdateTemplate instruments UpdateTemplate method with metrics.
+func (mm *metricsMiddleware) UpdateTemplate(ctx context.Context, session mgauthn.Session, token string, tpl bootstrap.Template) (saved bootstrap.Template, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "update_template").Add(1)
+		mm.latency.With("method", "update_template").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.UpdateTemplate(ctx, session, token, tpl)
+}
+
+// ListTemplates instruments ListTemplates method with metrics.
+func (mm *metricsMiddleware) ListTemplates(ctx context.Context, session mgauthn.Session, offset, limit uint64) (page bootstrap.TemplatesPage, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_templates").Add(1)
+		mm.latency.With("method", "list_templates").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListTemplates(ctx, session, offset, limit)
+}
+
+// RemoveTemplate instruments RemoveTemplate method with metrics.
+func (mm *metricsMiddleware) RemoveTemplate(ctx context.Context, session mgauthn.Session, id string) (err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_template").Add(1)
+		mm.latency.With("method", "remove_template").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveTemplate(ctx, session, id)
+}
+
+// Enroll instruments Enroll method with metrics.
+func (mm *metricsMiddleware) Enroll(ctx context.Context, session mgauthn.Session, templateID string, enrollments []bootstrap.Enrollment) (saved []bootstrap.Enrollment, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "enroll").Add(1)
+		mm.latency.With("method", "enroll").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Enroll(ctx, session, templateID, enrollments)
+}
+
 // UpdateChannelHandler instruments UpdateChannelHandler method with metrics.
 func (mm *metricsMiddleware) UpdateChannelHandler(ctx context.Context, channel bootstrap.Channel) (err error) {
 	defer func(begin time.Time) {
@@ -14,9 +14,9 @@ type ConfigReader struct {
 	mock.Mock
 }
 
-// ReadConfig provides a mock function with given fields: _a0, _a1
-func (_m *ConfigReader) ReadConfig(_a0 bootstrap.Config, _a1 bool) (interface{}, error) {
-	ret := _m.Called(_a0, _a1)
+// ReadConfig provides a mock function with given fields: cfg, secure, version
+func (_m *ConfigReader) ReadConfig(cfg bootstrap.Config, secure bool, version bootstrap.KeyVersion) (interface{}, error) {
+	ret := _m.Called(cfg, secure, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ReadConfig")
@@ -24,19 +24,19 @@ func (_m *ConfigReader) ReadConfig(_a0 bootstrap.Config, _a1 bool) (interface{},
 
 	var r0 interface{}
 	var r1 error
-	if rf, ok := ret.Get(0).(func(bootstrap.Config, bool) (interface{}, error)); ok {
-		return rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(bootstrap.Config, bool, bootstrap.KeyVersion) (interface{}, error)); ok {
+		return rf(cfg, secure, version)
 	}
-	if rf, ok := ret.Get(0).(func(bootstrap.Config, bool) interface{}); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(bootstrap.Config, bool, bootstrap.KeyVersion) interface{}); ok {
+		r0 = rf(cfg, secure, version)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(interface{})
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(bootstrap.Config, bool) error); ok {
-		r1 = rf(_a0, _a1)
+	if rf, ok := ret.Get(1).(func(bootstrap.Config, bool, bootstrap.KeyVersion) error); ok {
+		r1 = rf(cfg, secure, version)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -257,6 +257,24 @@ func (_m *ConfigRepository) Save(ctx context.Context, cfg bootstrap.Config, chsC
 	return r0, r1
 }
 
+// SaveChannels provides a mock function with given fields: ctx, domainID, channels
+func (_m *ConfigRepository) SaveChannels(ctx context.Context, domainID string, channels []bootstrap.Channel) error {
+	ret := _m.Called(ctx, domainID, channels)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveChannels")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []bootstrap.Channel) error); ok {
+		r0 = rf(ctx, domainID, channels)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, cfg
 func (_m *ConfigRepository) Update(ctx context.Context, cfg bootstrap.Config) error {
 	ret := _m.Called(ctx, cfg)
@@ -46,6 +46,34 @@ func (_m *Service) Add(ctx context.Context, session authn.Session, token string,
 	return r0, r1
 }
 
+// AddTemplate provides a mock function with given fields: ctx, session, token, tpl
+func (_m *Service) AddTemplate(ctx context.Context, session authn.Session, token string, tpl bootstrap.Template) (bootstrap.Template, error) {
+	ret := _m.Called(ctx, session, token, tpl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTemplate")
+	}
+
+	var r0 bootstrap.Template
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, bootstrap.Template) (bootstrap.Template, error)); ok {
+		return rf(ctx, session, token, tpl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, bootstrap.Template) bootstrap.Template); ok {
+		r0 = rf(ctx, session, token, tpl)
+	} else {
+		r0 = ret.Get(0).(bootstrap.Template)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string, bootstrap.Template) error); ok {
+		r1 = rf(ctx, session, token, tpl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Bootstrap provides a mock function with given fields: ctx, externalKey, externalID, secure
 func (_m *Service) Bootstrap(ctx context.Context, externalKey string, externalID string, secure bool) (bootstrap.Config, error) {
 	ret := _m.Called(ctx, externalKey, externalID, secure)
@@ -128,6 +156,36 @@ func (_m *Service) DisconnectThingHandler(ctx context.Context, channelID string,
 	return r0
 }
 
+// Enroll provides a mock function with given fields: ctx, session, templateID, enrollments
+func (_m *Service) Enroll(ctx context.Context, session authn.Session, templateID string, enrollments []bootstrap.Enrollment) ([]bootstrap.Enrollment, error) {
+	ret := _m.Called(ctx, session, templateID, enrollments)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enroll")
+	}
+
+	var r0 []bootstrap.Enrollment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, []bootstrap.Enrollment) ([]bootstrap.Enrollment, error)); ok {
+		return rf(ctx, session, templateID, enrollments)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, []bootstrap.Enrollment) []bootstrap.Enrollment); ok {
+		r0 = rf(ctx, session, templateID, enrollments)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bootstrap.Enrollment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string, []bootstrap.Enrollment) error); ok {
+		r1 = rf(ctx, session, templateID, enrollments)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: ctx, session, filter, offset, limit
 func (_m *Service) List(ctx context.Context, session authn.Session, filter bootstrap.Filter, offset uint64, limit uint64) (bootstrap.ConfigsPage, error) {
 	ret := _m.Called(ctx, session, filter, offset, limit)
@@ -156,6 +214,34 @@ func (_m *Service) List(ctx context.Context, session authn.Session, filter boots
 	return r0, r1
 }
 
+// ListTemplates provides a mock function with given fields: ctx, session, offset, limit
+func (_m *Service) ListTemplates(ctx context.Context, session authn.Session, offset uint64, limit uint64) (bootstrap.TemplatesPage, error) {
+	ret := _m.Called(ctx, session, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTemplates")
+	}
+
+	var r0 bootstrap.TemplatesPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, uint64, uint64) (bootstrap.TemplatesPage, error)); ok {
+		return rf(ctx, session, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, uint64, uint64) bootstrap.TemplatesPage); ok {
+		r0 = rf(ctx, session, offset, limit)
+	} else {
+		r0 = ret.Get(0).(bootstrap.TemplatesPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, uint64, uint64) error); ok {
+		r1 = rf(ctx, session, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Remove provides a mock function with given fields: ctx, session, id
 func (_m *Service) Remove(ctx context.Context, session authn.Session, id string) error {
 	ret := _m.Called(ctx, session, id)
@@ -210,6 +296,24 @@ func (_m *Service) RemoveConfigHandler(ctx context.Context, id string) error {
 	return r0
 }
 
+// RemoveTemplate provides a mock function with given fields: ctx, session, id
+func (_m *Service) RemoveTemplate(ctx context.Context, session authn.Session, id string) error {
+	ret := _m.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveTemplate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) error); ok {
+		r0 = rf(ctx, session, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, session, cfg
 func (_m *Service) Update(ctx context.Context, session authn.Session, cfg bootstrap.Config) error {
 	ret := _m.Called(ctx, session, cfg)
@@ -292,6 +396,34 @@ func (_m *Service) UpdateConnections(ctx context.Context, session authn.Session,
 	return r0
 }
 
+// UpdateTemplate provides a mock function with given fields: ctx, session, token, tpl
+func (_m *Service) UpdateTemplate(ctx context.Context, session authn.Session, token string, tpl bootstrap.Template) (bootstrap.Template, error) {
+	ret := _m.Called(ctx, session, token, tpl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTemplate")
+	}
+
+	var r0 bootstrap.Template
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, bootstrap.Template) (bootstrap.Template, error)); ok {
+		return rf(ctx, session, token, tpl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, bootstrap.Template) bootstrap.Template); ok {
+		r0 = rf(ctx, session, token, tpl)
+	} else {
+		r0 = ret.Get(0).(bootstrap.Template)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string, bootstrap.Template) error); ok {
+		r1 = rf(ctx, session, token, tpl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // View provides a mock function with given fields: ctx, session, id
 func (_m *Service) View(ctx context.Context, session authn.Session, id string) (bootstrap.Config, error) {
 	ret := _m.Called(ctx, session, id)
@@ -320,6 +452,34 @@ func (_m *Service) View(ctx context.Context, session authn.Session, id string) (
 	return r0, r1
 }
 
+// ViewTemplate provides a mock function with given fields: ctx, session, id
+func (_m *Service) ViewTemplate(ctx context.Context, session authn.Session, id string) (bootstrap.Template, error) {
+	ret := _m.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewTemplate")
+	}
+
+	var r0 bootstrap.Template
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) (bootstrap.Template, error)); ok {
+		return rf(ctx, session, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) bootstrap.Template); ok {
+		r0 = rf(ctx, session, id)
+	} else {
+		r0 = ret.Get(0).(bootstrap.Template)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
+		r1 = rf(ctx, session, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewService(t interface {
@@ -49,8 +49,8 @@ func NewConfigRepository(db postgres.Database, log *slog.Logger) bootstrap.Confi
 }
 
 func (cr configRepository) Save(ctx context.Context, cfg bootstrap.Config, chsConnIDs []string) (thingID string, err error) {
-	q := `INSERT INTO configs (magistrala_thing, domain_id, name, client_cert, client_key, ca_cert, magistrala_key, external_id, external_key, content, state)
-	VALUES (:magistrala_thing, :domain_id, :name, :client_cert, :client_key, :ca_cert, :magistrala_key, :external_id, :external_key, :content, :state)`
+	q := `INSERT INTO configs (magistrala_thing, domain_id, name, client_cert, client_key, ca_cert, magistrala_key, external_id, external_key, content, state, cert_ttl)
+	VALUES (:magistrala_thing, :domain_id, :name, :client_cert, :client_key, :ca_cert, :magistrala_key, :external_id, :external_key, :content, :state, :cert_ttl)`
 
 	tx, err := cr.db.BeginTxx(ctx, nil)
 	if err != nil {
@@ -90,7 +90,7 @@ func (cr configRepository) Save(ctx context.Context, cfg bootstrap.Config, chsCo
 }
 
 func (cr configRepository) RetrieveByID(ctx context.Context, domainID, id string) (bootstrap.Config, error) {
-	q := `SELECT magistrala_thing, magistrala_key, external_id, external_key, name, content, state, client_cert, ca_cert
+	q := `SELECT magistrala_thing, magistrala_key, external_id, external_key, name, content, state, client_cert, ca_cert, cert_ttl
 		  FROM configs
 		  WHERE magistrala_thing = :magistrala_thing AND domain_id = :domain_id`
 
@@ -424,6 +424,30 @@ func (cr configRepository) ListExisting(ctx context.Context, domainID string, id
 	return channels, nil
 }
 
+func (cr configRepository) SaveChannels(ctx context.Context, domainID string, channels []bootstrap.Channel) error {
+	if len(channels) == 0 {
+		return nil
+	}
+
+	var chans []dbChannel
+	for _, ch := range channels {
+		dbch, err := toDBChannel(domainID, ch)
+		if err != nil {
+			return errors.Wrap(errSaveChannels, err)
+		}
+		chans = append(chans, dbch)
+	}
+	q := `INSERT INTO channels (magistrala_channel, domain_id, name, metadata, parent_id, description, created_at, updated_at, updated_by, status)
+		  VALUES (:magistrala_channel, :domain_id, :name, :metadata, :parent_id, :description, :created_at, :updated_at, :updated_by, :status)
+		  ON CONFLICT (magistrala_channel, domain_id) DO UPDATE SET name = excluded.name, metadata = excluded.metadata,
+		  description = excluded.description, updated_at = excluded.updated_at, updated_by = excluded.updated_by, status = excluded.status`
+	if _, err := cr.db.NamedExecContext(ctx, q, chans); err != nil {
+		return errors.Wrap(errSaveChannels, err)
+	}
+
+	return nil
+}
+
 func (cr configRepository) RemoveThing(ctx context.Context, id string) error {
 	q := `DELETE FROM configs WHERE magistrala_thing = $1`
 	_, err := cr.db.ExecContext(ctx, q, id)
@@ -655,6 +679,7 @@ type dbConfig struct {
 	ExternalKey string          `db:"external_key"`
 	Content     sql.NullString  `db:"content"`
 	State       bootstrap.State `db:"state"`
+	CertTTL     sql.NullString  `db:"cert_ttl"`
 }
 
 func toDBConfig(cfg bootstrap.Config) dbConfig {
@@ -670,6 +695,7 @@ func toDBConfig(cfg bootstrap.Config) dbConfig {
 		ExternalKey: cfg.ExternalKey,
 		Content:     nullString(cfg.Content),
 		State:       cfg.State,
+		CertTTL:     nullString(cfg.CertTTL),
 	}
 }
 
@@ -702,6 +728,10 @@ func toConfig(dbcfg dbConfig) bootstrap.Config {
 	if dbcfg.CaCert.Valid {
 		cfg.CACert = dbcfg.CaCert.String
 	}
+
+	if dbcfg.CertTTL.Valid {
+		cfg.CertTTL = dbcfg.CertTTL.String
+	}
 	return cfg
 }
 
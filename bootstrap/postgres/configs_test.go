@@ -114,6 +114,7 @@ func TestRetrieveByID(t *testing.T) {
 	c.ThingID = uid.String()
 	c.ExternalID = uid.String()
 	c.ExternalKey = uid.String()
+	c.CertTTL = "24h"
 	id, err := repo.Save(context.Background(), c, channels)
 	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
 
@@ -152,8 +153,11 @@ func TestRetrieveByID(t *testing.T) {
 		},
 	}
 	for _, tc := range cases {
-		_, err := repo.RetrieveByID(context.Background(), tc.domainID, tc.id)
+		cfg, err := repo.RetrieveByID(context.Background(), tc.domainID, tc.id)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, c.CertTTL, cfg.CertTTL, fmt.Sprintf("%s: expected cert TTL %s got %s\n", tc.desc, c.CertTTL, cfg.CertTTL))
+		}
 	}
 }
 
@@ -417,7 +421,7 @@ func TestUpdateCert(t *testing.T) {
 	for _, tc := range cases {
 		cfg, err := repo.UpdateCert(context.Background(), tc.domainID, tc.thingID, tc.cert, tc.certKey, tc.ca)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-		assert.Equal(t, tc.expectedConfig, cfg, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.expectedConfig, cfg))
+		assert.Equal(t, tc.expectedConfig, cfg, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.expectedConfig, cfg))
 	}
 }
 
@@ -628,6 +632,50 @@ func TestListExisting(t *testing.T) {
 	}
 }
 
+func TestSaveChannels(t *testing.T) {
+	repo := postgres.NewConfigRepository(db, testLog)
+	err := deleteChannels(context.Background(), repo)
+	require.Nil(t, err, "Channels cleanup expected to succeed.")
+
+	domainID := testsutil.GenerateUUID(t)
+	chs := []bootstrap.Channel{
+		{ID: "1", Name: "name 1", Metadata: map[string]interface{}{"meta": 1.0}},
+		{ID: "2", Name: "name 2", Metadata: map[string]interface{}{"meta": 2.0}},
+	}
+	updated := []bootstrap.Channel{
+		{ID: "1", Name: "updated name 1", Metadata: map[string]interface{}{"meta": 3.0}},
+	}
+
+	cases := []struct {
+		desc     string
+		channels []bootstrap.Channel
+		existing []bootstrap.Channel
+	}{
+		{
+			desc:     "save new channels",
+			channels: chs,
+			existing: chs,
+		},
+		{
+			desc:     "save existing channels",
+			channels: updated,
+			existing: []bootstrap.Channel{updated[0], chs[1]},
+		},
+		{
+			desc:     "save empty channels",
+			channels: []bootstrap.Channel{},
+			existing: []bootstrap.Channel{updated[0], chs[1]},
+		},
+	}
+	for _, tc := range cases {
+		err := repo.SaveChannels(context.Background(), domainID, tc.channels)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		existing, err := repo.ListExisting(context.Background(), domainID, channels)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.ElementsMatch(t, tc.existing, existing, fmt.Sprintf("%s: Got non-matching elements.", tc.desc))
+	}
+}
+
 func TestRemoveThing(t *testing.T) {
 	repo := postgres.NewConfigRepository(db, testLog)
 	err := deleteChannels(context.Background(), repo)
@@ -797,7 +845,7 @@ func TestConnectThing(t *testing.T) {
 				assert.Equal(t, tc.err, err, fmt.Sprintf("%s: Expected error: %s, got: %s.\n", tc.desc, tc.err, err))
 				cfg, err := repo.RetrieveByID(context.Background(), c.DomainID, c.ThingID)
 				assert.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
-				assert.Equal(t, cfg.State, bootstrap.Active, fmt.Sprintf("expected to be active when a connection is added from %s", cfg))
+				assert.Equal(t, cfg.State, bootstrap.Active, fmt.Sprintf("expected to be active when a connection is added from %v", cfg))
 			} else {
 				_ = repo.ConnectThing(context.Background(), ch.ID, tc.id)
 			}
@@ -805,7 +853,7 @@ func TestConnectThing(t *testing.T) {
 
 		cfg, err := repo.RetrieveByID(context.Background(), c.DomainID, c.ThingID)
 		assert.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
-		assert.Equal(t, cfg.State, bootstrap.Active, fmt.Sprintf("expected to be active when a connection is added from %s", cfg))
+		assert.Equal(t, cfg.State, bootstrap.Active, fmt.Sprintf("expected to be active when a connection is added from %v", cfg))
 	}
 }
 
@@ -898,7 +946,7 @@ func TestDisconnectThing(t *testing.T) {
 
 		cfg, err := repo.RetrieveByID(context.Background(), c.DomainID, c.ThingID)
 		assert.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
-		assert.Equal(t, cfg.State, bootstrap.Inactive, fmt.Sprintf("expected to be inactive when a connection is removed from %s", cfg))
+		assert.Equal(t, cfg.State, bootstrap.Inactive, fmt.Sprintf("expected to be inactive when a connection is removed from %v", cfg))
 	}
 }
 
@@ -103,6 +103,56 @@ func Migration() *migrate.MemoryMigrationSource {
 					`ALTER TABLE IF EXISTS connections ADD FOREIGN KEY (config_id, domain_id) REFERENCES configs (magistrala_thing, domain_id) ON DELETE CASCADE ON UPDATE CASCADE`,
 				},
 			},
+			{
+				Id: "configs_7",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS templates (
+						id         VARCHAR(36) PRIMARY KEY,
+						domain_id  VARCHAR(36) NOT NULL,
+						name       TEXT NOT NULL,
+						content    TEXT,
+						channels   JSONB,
+						cert_ttl   VARCHAR(64),
+						metadata   JSONB,
+						created_at TIMESTAMP NOT NULL,
+						updated_at TIMESTAMP,
+						UNIQUE (domain_id, name)
+					)`,
+					`CREATE TABLE IF NOT EXISTS enrollments (
+						external_id  TEXT PRIMARY KEY,
+						external_key TEXT NOT NULL,
+						template_id  VARCHAR(36) NOT NULL REFERENCES templates (id) ON DELETE CASCADE,
+						domain_id    VARCHAR(36) NOT NULL,
+						name         TEXT,
+						vars         JSONB,
+						created_at   TIMESTAMP NOT NULL
+					)`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS enrollments",
+					"DROP TABLE IF EXISTS templates",
+				},
+			},
+			{
+				// Templates created before are left without the creator, so
+				// they can't create Things of enrolled devices until recreated.
+				Id: "configs_8",
+				Up: []string{
+					`ALTER TABLE IF EXISTS templates ADD COLUMN IF NOT EXISTS created_by VARCHAR(36)`,
+				},
+				Down: []string{
+					`ALTER TABLE IF EXISTS templates DROP COLUMN IF EXISTS created_by`,
+				},
+			},
+			{
+				Id: "configs_9",
+				Up: []string{
+					`ALTER TABLE IF EXISTS configs ADD COLUMN IF NOT EXISTS cert_ttl VARCHAR(64)`,
+				},
+				Down: []string{
+					`ALTER TABLE IF EXISTS configs DROP COLUMN IF EXISTS cert_ttl`,
+				},
+			},
 		},
 	}
 }
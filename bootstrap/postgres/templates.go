@@ -0,0 +1,318 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/magistrala/bootstrap"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+)
+
+const templateColumns = `id, domain_id, name, content, channels, cert_ttl, metadata, created_by, created_at, updated_at`
+
+var _ bootstrap.TemplateRepository = (*templateRepository)(nil)
+
+type templateRepository struct {
+	db postgres.Database
+}
+
+// NewTemplateRepository instantiates a PostgreSQL implementation of template
+// repository.
+func NewTemplateRepository(db postgres.Database) bootstrap.TemplateRepository {
+	return &templateRepository{db: db}
+}
+
+func (tr templateRepository) Save(ctx context.Context, tpl bootstrap.Template) (bootstrap.Template, error) {
+	q := `INSERT INTO templates (` + templateColumns + `)
+		VALUES (:id, :domain_id, :name, :content, :channels, :cert_ttl, :metadata, :created_by, :created_at, :updated_at)
+		RETURNING ` + templateColumns
+
+	dbt, err := toDBTemplate(tpl)
+	if err != nil {
+		return bootstrap.Template{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return tr.namedQueryRow(ctx, q, dbt, repoerr.ErrCreateEntity)
+}
+
+func (tr templateRepository) RetrieveByID(ctx context.Context, domainID, id string) (bootstrap.Template, error) {
+	q := `SELECT ` + templateColumns + ` FROM templates WHERE id = :id AND domain_id = :domain_id`
+
+	dbt := dbTemplate{
+		ID:       id,
+		DomainID: domainID,
+	}
+
+	return tr.namedQueryRow(ctx, q, dbt, repoerr.ErrViewEntity)
+}
+
+func (tr templateRepository) RetrieveAll(ctx context.Context, domainID string, offset, limit uint64) (bootstrap.TemplatesPage, error) {
+	q := `SELECT ` + templateColumns + ` FROM templates WHERE domain_id = :domain_id
+		ORDER BY created_at LIMIT :limit OFFSET :offset`
+
+	params := map[string]interface{}{
+		"domain_id": domainID,
+		"limit":     limit,
+		"offset":    offset,
+	}
+	rows, err := tr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return bootstrap.TemplatesPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	templates := []bootstrap.Template{}
+	for rows.Next() {
+		var dbt dbTemplate
+		if err := rows.StructScan(&dbt); err != nil {
+			return bootstrap.TemplatesPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		tpl, err := toTemplate(dbt)
+		if err != nil {
+			return bootstrap.TemplatesPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		templates = append(templates, tpl)
+	}
+
+	total, err := postgres.Total(ctx, tr.db, `SELECT COUNT(*) FROM templates WHERE domain_id = :domain_id`, params)
+	if err != nil {
+		return bootstrap.TemplatesPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return bootstrap.TemplatesPage{
+		Total:     total,
+		Offset:    offset,
+		Limit:     limit,
+		Templates: templates,
+	}, nil
+}
+
+func (tr templateRepository) Update(ctx context.Context, tpl bootstrap.Template) (bootstrap.Template, error) {
+	q := `UPDATE templates SET name = :name, content = :content, channels = :channels, cert_ttl = :cert_ttl,
+		metadata = :metadata, updated_at = :updated_at
+		WHERE id = :id AND domain_id = :domain_id
+		RETURNING ` + templateColumns
+
+	dbt, err := toDBTemplate(tpl)
+	if err != nil {
+		return bootstrap.Template{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return tr.namedQueryRow(ctx, q, dbt, repoerr.ErrUpdateEntity)
+}
+
+func (tr templateRepository) Remove(ctx context.Context, domainID, id string) error {
+	q := `DELETE FROM templates WHERE id = :id AND domain_id = :domain_id`
+
+	dbt := dbTemplate{
+		ID:       id,
+		DomainID: domainID,
+	}
+	res, err := tr.db.NamedExecContext(ctx, q, dbt)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if cnt, err := res.RowsAffected(); err != nil || cnt == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (tr templateRepository) SaveEnrollments(ctx context.Context, enrollments []bootstrap.Enrollment) (err error) {
+	q := `INSERT INTO enrollments (external_id, external_key, template_id, domain_id, name, vars, created_at)
+		VALUES (:external_id, :external_key, :template_id, :domain_id, :name, :vars, :created_at)`
+
+	tx, err := tr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = errors.Wrap(err, errRollback)
+			}
+		}
+	}()
+
+	for _, e := range enrollments {
+		dbe, err := toDBEnrollment(e)
+		if err != nil {
+			return errors.Wrap(repoerr.ErrCreateEntity, err)
+		}
+		if _, err := tx.NamedExecContext(ctx, q, dbe); err != nil {
+			return postgres.HandleError(repoerr.ErrCreateEntity, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (tr templateRepository) RetrieveEnrollment(ctx context.Context, externalID string) (bootstrap.Enrollment, error) {
+	q := `SELECT external_id, external_key, template_id, domain_id, name, vars, created_at
+		FROM enrollments WHERE external_id = :external_id`
+
+	rows, err := tr.db.NamedQueryContext(ctx, q, dbEnrollment{ExternalID: externalID})
+	if err != nil {
+		return bootstrap.Enrollment{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return bootstrap.Enrollment{}, errors.Wrap(repoerr.ErrNotFound, rows.Err())
+	}
+	var dbe dbEnrollment
+	if err := rows.StructScan(&dbe); err != nil {
+		return bootstrap.Enrollment{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return toEnrollment(dbe)
+}
+
+func (tr templateRepository) RemoveEnrollment(ctx context.Context, externalID string) error {
+	q := `DELETE FROM enrollments WHERE external_id = :external_id`
+
+	if _, err := tr.db.NamedExecContext(ctx, q, dbEnrollment{ExternalID: externalID}); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (tr templateRepository) namedQueryRow(ctx context.Context, q string, arg interface{}, wrapper error) (bootstrap.Template, error) {
+	rows, err := tr.db.NamedQueryContext(ctx, q, arg)
+	if err != nil {
+		return bootstrap.Template{}, postgres.HandleError(wrapper, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return bootstrap.Template{}, errors.Wrap(repoerr.ErrNotFound, rows.Err())
+	}
+	var dbt dbTemplate
+	if err := rows.StructScan(&dbt); err != nil {
+		return bootstrap.Template{}, errors.Wrap(wrapper, err)
+	}
+
+	return toTemplate(dbt)
+}
+
+type dbTemplate struct {
+	ID        string         `db:"id"`
+	DomainID  string         `db:"domain_id"`
+	Name      string         `db:"name"`
+	Content   sql.NullString `db:"content"`
+	Channels  []byte         `db:"channels"`
+	CertTTL   sql.NullString `db:"cert_ttl"`
+	Metadata  []byte         `db:"metadata"`
+	CreatedBy sql.NullString `db:"created_by"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt sql.NullTime   `db:"updated_at"`
+}
+
+func toDBTemplate(tpl bootstrap.Template) (dbTemplate, error) {
+	channels, err := json.Marshal(tpl.Channels)
+	if err != nil {
+		return dbTemplate{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	metadata, err := json.Marshal(tpl.Metadata)
+	if err != nil {
+		return dbTemplate{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return dbTemplate{
+		ID:        tpl.ID,
+		DomainID:  tpl.DomainID,
+		Name:      tpl.Name,
+		Content:   nullString(tpl.Content),
+		Channels:  channels,
+		CertTTL:   nullString(tpl.CertTTL),
+		Metadata:  metadata,
+		CreatedBy: nullString(tpl.CreatedBy),
+		CreatedAt: tpl.CreatedAt,
+		UpdatedAt: nullTime(tpl.UpdatedAt),
+	}, nil
+}
+
+func toTemplate(dbt dbTemplate) (bootstrap.Template, error) {
+	tpl := bootstrap.Template{
+		ID:        dbt.ID,
+		DomainID:  dbt.DomainID,
+		Name:      dbt.Name,
+		Content:   dbt.Content.String,
+		CertTTL:   dbt.CertTTL.String,
+		CreatedBy: dbt.CreatedBy.String,
+		CreatedAt: dbt.CreatedAt,
+	}
+	if dbt.UpdatedAt.Valid {
+		tpl.UpdatedAt = dbt.UpdatedAt.Time
+	}
+	if len(dbt.Channels) > 0 {
+		if err := json.Unmarshal(dbt.Channels, &tpl.Channels); err != nil {
+			return bootstrap.Template{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+	if len(dbt.Metadata) > 0 {
+		if err := json.Unmarshal(dbt.Metadata, &tpl.Metadata); err != nil {
+			return bootstrap.Template{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+
+	return tpl, nil
+}
+
+type dbEnrollment struct {
+	ExternalID  string         `db:"external_id"`
+	ExternalKey string         `db:"external_key"`
+	TemplateID  string         `db:"template_id"`
+	DomainID    string         `db:"domain_id"`
+	Name        sql.NullString `db:"name"`
+	Vars        []byte         `db:"vars"`
+	CreatedAt   time.Time      `db:"created_at"`
+}
+
+func toDBEnrollment(e bootstrap.Enrollment) (dbEnrollment, error) {
+	vars, err := json.Marshal(e.Vars)
+	if err != nil {
+		return dbEnrollment{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return dbEnrollment{
+		ExternalID:  e.ExternalID,
+		ExternalKey: e.ExternalKey,
+		TemplateID:  e.TemplateID,
+		DomainID:    e.DomainID,
+		Name:        nullString(e.Name),
+		Vars:        vars,
+		CreatedAt:   e.CreatedAt,
+	}, nil
+}
+
+func toEnrollment(dbe dbEnrollment) (bootstrap.Enrollment, error) {
+	e := bootstrap.Enrollment{
+		ExternalID:  dbe.ExternalID,
+		ExternalKey: dbe.ExternalKey,
+		TemplateID:  dbe.TemplateID,
+		DomainID:    dbe.DomainID,
+		Name:        dbe.Name.String,
+		CreatedAt:   dbe.CreatedAt,
+	}
+	if len(dbe.Vars) > 0 {
+		if err := json.Unmarshal(dbe.Vars, &e.Vars); err != nil {
+			return bootstrap.Enrollment{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+
+	return e, nil
+}
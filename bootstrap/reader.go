@@ -4,11 +4,7 @@
 package bootstrap
 
 import (
-	"crypto/aes"
-	"crypto/cipher"
-	"crypto/rand"
 	"encoding/json"
-	"io"
 	"net/http"
 )
 
@@ -44,16 +40,16 @@ func (res bootstrapRes) Empty() bool {
 }
 
 type reader struct {
-	encKey []byte
+	keys Keyring
 }
 
 // NewConfigReader return new reader which is used to generate response
 // from the config.
-func NewConfigReader(encKey []byte) ConfigReader {
-	return reader{encKey: encKey}
+func NewConfigReader(keys Keyring) ConfigReader {
+	return reader{keys: keys}
 }
 
-func (r reader) ReadConfig(cfg Config, secure bool) (interface{}, error) {
+func (r reader) ReadConfig(cfg Config, secure bool, version KeyVersion) (interface{}, error) {
 	var channels []channelRes
 	for _, ch := range cfg.Channels {
 		channels = append(channels, channelRes{ID: ch.ID, Name: ch.Name, Metadata: ch.Metadata})
@@ -73,23 +69,8 @@ func (r reader) ReadConfig(cfg Config, secure bool) (interface{}, error) {
 		if err != nil {
 			return nil, err
 		}
-		return r.encrypt(b)
+		return r.keys.encrypt(version, cfg, b)
 	}
 
 	return res, nil
 }
-
-func (r reader) encrypt(in []byte) ([]byte, error) {
-	block, err := aes.NewCipher(r.encKey)
-	if err != nil {
-		return nil, err
-	}
-	ciphertext := make([]byte, aes.BlockSize+len(in))
-	iv := ciphertext[:aes.BlockSize]
-	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
-		return nil, err
-	}
-	stream := cipher.NewCFBEncrypter(block, iv)
-	stream.XORKeyStream(ciphertext[aes.BlockSize:], in)
-	return ciphertext, nil
-}
@@ -48,13 +48,27 @@ func dec(in []byte) ([]byte, error) {
 	return in, nil
 }
 
+func open(cfg bootstrap.Config, in []byte) ([]byte, error) {
+	aead, err := deviceCipher(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(in) < 1+aead.NonceSize() {
+		return nil, errors.ErrMalformedEntity
+	}
+	in = in[1:]
+	return aead.Open(nil, in[:aead.NonceSize()], in[aead.NonceSize():], []byte(cfg.ExternalID))
+}
+
 func TestReadConfig(t *testing.T) {
 	cfg := bootstrap.Config{
-		ThingID:    "mg_id",
-		ClientCert: "client_cert",
-		ClientKey:  "client_key",
-		CACert:     "ca_cert",
-		ThingKey:   "mg_key",
+		ThingID:     "mg_id",
+		ExternalID:  "external_id",
+		ExternalKey: "external_key",
+		ClientCert:  "client_cert",
+		ClientKey:   "client_key",
+		CACert:      "ca_cert",
+		ThingKey:    "mg_key",
 		Channels: []bootstrap.Channel{
 			{
 				ID:       "mg_id",
@@ -83,13 +97,14 @@ func TestReadConfig(t *testing.T) {
 	bin, err := json.Marshal(ret)
 	assert.Nil(t, err, fmt.Sprintf("Marshalling expected to succeed: %s.\n", err))
 
-	reader := bootstrap.NewConfigReader(encKey)
+	reader := bootstrap.NewConfigReader(newKeyring())
 	cases := []struct {
-		desc   string
-		config bootstrap.Config
-		enc    []byte
-		secret bool
-		err    error
+		desc    string
+		config  bootstrap.Config
+		enc     []byte
+		secret  bool
+		version bootstrap.KeyVersion
+		err     error
 	}{
 		{
 			desc:   "read a config",
@@ -103,12 +118,37 @@ func TestReadConfig(t *testing.T) {
 			enc:    bin,
 			secret: true,
 		},
+		{
+			desc:    "read encrypted config with versioned key",
+			config:  cfg,
+			enc:     bin,
+			secret:  true,
+			version: 1,
+		},
+		{
+			desc:    "read encrypted config with unknown key version",
+			config:  cfg,
+			secret:  true,
+			version: 2,
+			err:     bootstrap.ErrUnknownKeyVersion,
+		},
 	}
 
 	for _, tc := range cases {
-		res, err := reader.ReadConfig(tc.config, tc.secret)
-		assert.Nil(t, err, fmt.Sprintf("Reading config to succeed: %s.\n", err))
+		res, err := reader.ReadConfig(tc.config, tc.secret, tc.version)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
 
+		if tc.secret && tc.version != bootstrap.LegacyKeyVersion {
+			b := res.([]byte)
+			assert.Equal(t, byte(tc.version), b[0], fmt.Sprintf("%s: expected key version %d got %d\n", tc.desc, tc.version, b[0]))
+			d, err := open(tc.config, b)
+			assert.Nil(t, err, fmt.Sprintf("Decrypting expected to succeed: %s.\n", err))
+			assert.Equal(t, tc.enc, d, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.enc, d))
+			continue
+		}
 		if tc.secret {
 			d, err := dec(res.([]byte))
 			assert.Nil(t, err, fmt.Sprintf("Decrypting expected to succeed: %s.\n", err))
@@ -5,9 +5,8 @@ package bootstrap
 
 import (
 	"context"
-	"crypto/aes"
-	"crypto/cipher"
-	"encoding/hex"
+	"encoding/json"
+	"time"
 
 	"github.com/absmach/magistrala"
 	mgauthn "github.com/absmach/magistrala/pkg/authn"
@@ -35,6 +34,10 @@ var (
 	// ErrAddBootstrap indicates error in adding bootstrap configuration.
 	ErrAddBootstrap = errors.New("failed to add bootstrap configuration")
 
+	// ErrMissingCredentials indicates the template has no creator on whose
+	// behalf the Things of enrolled devices are created.
+	ErrMissingCredentials = errors.New("missing credentials for creating enrolled things")
+
 	// ErrNotInSameDomain indicates entities are not in the same domain.
 	errNotInSameDomain = errors.New("entities are not in the same domain")
 
@@ -51,6 +54,12 @@ var (
 	errConnectionChannels = errors.New("failed to check channels connections")
 	errThingNotFound      = errors.New("failed to find thing")
 	errUpdateCert         = errors.New("failed to update cert")
+	errAddTemplate        = errors.New("failed to add bootstrap template")
+	errUpdateTemplate     = errors.New("failed to update bootstrap template")
+	errRemoveTemplate     = errors.New("failed to remove bootstrap template")
+	errEnroll             = errors.New("failed to enroll devices")
+	errCreateEnrolled     = errors.New("failed to create enrolled thing")
+	errIssueCert          = errors.New("failed to issue certificate")
 )
 
 var _ Service = (*bootstrapService)(nil)
@@ -89,6 +98,26 @@ type Service interface {
 	// ChangeState changes state of the Thing with given thing ID and domain ID.
 	ChangeState(ctx context.Context, session mgauthn.Session, token, id string, state State) error
 
+	// AddTemplate adds new bootstrap Template to the domain.
+	AddTemplate(ctx context.Context, session mgauthn.Session, token string, tpl Template) (Template, error)
+
+	// ViewTemplate returns the Template with the given ID.
+	ViewTemplate(ctx context.Context, session mgauthn.Session, id string) (Template, error)
+
+	// UpdateTemplate updates editable fields of the provided Template.
+	UpdateTemplate(ctx context.Context, session mgauthn.Session, token string, tpl Template) (Template, error)
+
+	// ListTemplates returns a subset of Templates of the domain.
+	ListTemplates(ctx context.Context, session mgauthn.Session, offset, limit uint64) (TemplatesPage, error)
+
+	// RemoveTemplate removes the Template and its pending Enrollments.
+	RemoveTemplate(ctx context.Context, session mgauthn.Session, id string) error
+
+	// Enroll pre-registers devices with the Template. Missing external keys
+	// are generated. The Thing and its Config are created on the first
+	// Bootstrap call of the device.
+	Enroll(ctx context.Context, session mgauthn.Session, templateID string, enrollments []Enrollment) ([]Enrollment, error)
+
 	// Methods RemoveConfig, UpdateChannel, and RemoveChannel are used as
 	// handlers for events. That's why these methods surpass ownership check.
 
@@ -115,24 +144,32 @@ type Service interface {
 //
 //go:generate mockery --name ConfigReader --output=./mocks --filename config_reader.go --quiet --note "Copyright (c) Abstract Machines"
 type ConfigReader interface {
-	ReadConfig(Config, bool) (interface{}, error)
+	// ReadConfig returns the response for the Config. Secure responses are
+	// encrypted for the device with the key of the given version.
+	ReadConfig(cfg Config, secure bool, version KeyVersion) (interface{}, error)
 }
 
 type bootstrapService struct {
 	policies   policies.Service
 	configs    ConfigRepository
+	templates  TemplateRepository
 	sdk        mgsdk.SDK
-	encKey     []byte
+	keys       Keyring
+	things     magistrala.ThingsServiceClient
 	idProvider magistrala.IDProvider
 }
 
-// New returns new Bootstrap service.
-func New(policyService policies.Service, configs ConfigRepository, sdk mgsdk.SDK, encKey []byte, idp magistrala.IDProvider) Service {
+// New returns new Bootstrap service. The Things of enrolled devices are
+// created through the Things service on behalf of the user who created the
+// template.
+func New(policyService policies.Service, configs ConfigRepository, templates TemplateRepository, sdk mgsdk.SDK, keys Keyring, things magistrala.ThingsServiceClient, idp magistrala.IDProvider) Service {
 	return &bootstrapService{
 		configs:    configs,
+		templates:  templates,
 		sdk:        sdk,
 		policies:   policyService,
-		encKey:     encKey,
+		keys:       keys,
+		things:     things,
 		idProvider: idp,
 	}
 }
@@ -182,6 +219,9 @@ func (bs bootstrapService) Add(ctx context.Context, session mgauthn.Session, tok
 
 	cfg.ThingID = saved
 	cfg.Channels = append(cfg.Channels, existing...)
+	if cfg.KeyVersion, cfg.DeviceKey, err = bs.keys.DeviceKey(cfg.ExternalID, cfg.ExternalKey); err != nil {
+		return Config{}, errors.Wrap(ErrAddBootstrap, err)
+	}
 
 	return cfg, nil
 }
@@ -191,6 +231,9 @@ func (bs bootstrapService) View(ctx context.Context, session mgauthn.Session, id
 	if err != nil {
 		return Config{}, errors.Wrap(svcerr.ErrViewEntity, err)
 	}
+	if cfg.KeyVersion, cfg.DeviceKey, err = bs.keys.DeviceKey(cfg.ExternalID, cfg.ExternalKey); err != nil {
+		return Config{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
 	return cfg, nil
 }
 
@@ -307,22 +350,138 @@ func (bs bootstrapService) Remove(ctx context.Context, session mgauthn.Session,
 func (bs bootstrapService) Bootstrap(ctx context.Context, externalKey, externalID string, secure bool) (Config, error) {
 	cfg, err := bs.configs.RetrieveByExternalID(ctx, externalID)
 	if err != nil {
-		return cfg, errors.Wrap(ErrBootstrap, err)
-	}
-	if secure {
-		dec, err := bs.dec(externalKey)
+		if !errors.Contains(err, repoerr.ErrNotFound) {
+			return cfg, errors.Wrap(ErrBootstrap, err)
+		}
+		// Devices enrolled through a template get their Config on first bootstrap.
+		enrollment, eerr := bs.templates.RetrieveEnrollment(ctx, externalID)
+		if eerr != nil {
+			if errors.Contains(eerr, repoerr.ErrNotFound) {
+				return cfg, errors.Wrap(ErrBootstrap, err)
+			}
+			return cfg, errors.Wrap(ErrBootstrap, eerr)
+		}
+		pending := Config{ExternalID: enrollment.ExternalID, ExternalKey: enrollment.ExternalKey}
+		if err := bs.checkExternalKey(pending, externalKey, secure); err != nil {
+			return Config{}, err
+		}
+		cfg, err := bs.createEnrolled(ctx, enrollment)
 		if err != nil {
-			return Config{}, errors.Wrap(ErrExternalKeySecure, err)
+			return Config{}, errors.Wrap(ErrBootstrap, err)
 		}
-		externalKey = dec
+		return cfg, nil
 	}
-	if cfg.ExternalKey != externalKey {
-		return Config{}, ErrExternalKey
+	if err := bs.checkExternalKey(cfg, externalKey, secure); err != nil {
+		return Config{}, err
 	}
 
 	return cfg, nil
 }
 
+func (bs bootstrapService) AddTemplate(ctx context.Context, session mgauthn.Session, token string, tpl Template) (Template, error) {
+	channels, err := bs.validateTemplate(session.DomainID, token, tpl)
+	if err != nil {
+		return Template{}, errors.Wrap(errAddTemplate, err)
+	}
+	id, err := bs.idProvider.ID()
+	if err != nil {
+		return Template{}, errors.Wrap(errAddTemplate, err)
+	}
+	tpl.ID = id
+	tpl.DomainID = session.DomainID
+	tpl.CreatedBy = session.UserID
+	tpl.CreatedAt = time.Now().UTC()
+	tpl.UpdatedAt = time.Time{}
+
+	saved, err := bs.templates.Save(ctx, tpl)
+	if err != nil {
+		return Template{}, errors.Wrap(errAddTemplate, err)
+	}
+	if err := bs.configs.SaveChannels(ctx, session.DomainID, channels); err != nil {
+		return Template{}, errors.Wrap(errAddTemplate, err)
+	}
+
+	return saved, nil
+}
+
+func (bs bootstrapService) ViewTemplate(ctx context.Context, session mgauthn.Session, id string) (Template, error) {
+	tpl, err := bs.templates.RetrieveByID(ctx, session.DomainID, id)
+	if err != nil {
+		return Template{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return tpl, nil
+}
+
+func (bs bootstrapService) UpdateTemplate(ctx context.Context, session mgauthn.Session, token string, tpl Template) (Template, error) {
+	channels, err := bs.validateTemplate(session.DomainID, token, tpl)
+	if err != nil {
+		return Template{}, errors.Wrap(errUpdateTemplate, err)
+	}
+	tpl.DomainID = session.DomainID
+	tpl.UpdatedAt = time.Now().UTC()
+
+	saved, err := bs.templates.Update(ctx, tpl)
+	if err != nil {
+		return Template{}, errors.Wrap(errUpdateTemplate, err)
+	}
+	if err := bs.configs.SaveChannels(ctx, session.DomainID, channels); err != nil {
+		return Template{}, errors.Wrap(errUpdateTemplate, err)
+	}
+
+	return saved, nil
+}
+
+func (bs bootstrapService) ListTemplates(ctx context.Context, session mgauthn.Session, offset, limit uint64) (TemplatesPage, error) {
+	page, err := bs.templates.RetrieveAll(ctx, session.DomainID, offset, limit)
+	if err != nil {
+		return TemplatesPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (bs bootstrapService) RemoveTemplate(ctx context.Context, session mgauthn.Session, id string) error {
+	if err := bs.templates.Remove(ctx, session.DomainID, id); err != nil {
+		return errors.Wrap(errRemoveTemplate, err)
+	}
+
+	return nil
+}
+
+func (bs bootstrapService) Enroll(ctx context.Context, session mgauthn.Session, templateID string, enrollments []Enrollment) ([]Enrollment, error) {
+	if _, err := bs.templates.RetrieveByID(ctx, session.DomainID, templateID); err != nil {
+		return nil, errors.Wrap(errEnroll, err)
+	}
+
+	now := time.Now().UTC()
+	for i := range enrollments {
+		if enrollments[i].ExternalKey == "" {
+			key, err := bs.idProvider.ID()
+			if err != nil {
+				return nil, errors.Wrap(errEnroll, err)
+			}
+			enrollments[i].ExternalKey = key
+		}
+		enrollments[i].TemplateID = templateID
+		enrollments[i].DomainID = session.DomainID
+		enrollments[i].CreatedAt = now
+	}
+	if err := bs.templates.SaveEnrollments(ctx, enrollments); err != nil {
+		return nil, errors.Wrap(errEnroll, err)
+	}
+	for i, e := range enrollments {
+		version, key, err := bs.keys.DeviceKey(e.ExternalID, e.ExternalKey)
+		if err != nil {
+			return nil, errors.Wrap(errEnroll, err)
+		}
+		enrollments[i].KeyVersion = version
+		enrollments[i].DeviceKey = key
+	}
+
+	return enrollments, nil
+}
+
 func (bs bootstrapService) ChangeState(ctx context.Context, session mgauthn.Session, token, id string, state State) error {
 	cfg, err := bs.configs.RetrieveByID(ctx, session.DomainID, id)
 	if err != nil {
@@ -335,6 +494,9 @@ func (bs bootstrapService) ChangeState(ctx context.Context, session mgauthn.Sess
 
 	switch state {
 	case Active:
+		if err := bs.issueCert(ctx, session, token, cfg); err != nil {
+			return err
+		}
 		for _, c := range cfg.Channels {
 			conIDs := mgsdk.Connection{
 				ChannelID: c.ID,
@@ -364,6 +526,23 @@ func (bs bootstrapService) ChangeState(ctx context.Context, session mgauthn.Sess
 	return nil
 }
 
+// Method issueCert issues the client certificate of the enrolled device with
+// the TTL of its template, unless the certificate has already been issued.
+func (bs bootstrapService) issueCert(ctx context.Context, session mgauthn.Session, token string, cfg Config) error {
+	if cfg.CertTTL == "" || cfg.ClientCert != "" {
+		return nil
+	}
+	cert, sdkErr := bs.sdk.IssueCert(cfg.ThingID, cfg.CertTTL, session.DomainID, token)
+	if sdkErr != nil {
+		return errors.Wrap(errIssueCert, sdkErr)
+	}
+	if _, err := bs.configs.UpdateCert(ctx, session.DomainID, cfg.ThingID, cert.Certificate, cert.Key, cfg.CACert); err != nil {
+		return errors.Wrap(errUpdateCert, err)
+	}
+
+	return nil
+}
+
 func (bs bootstrapService) UpdateChannelHandler(ctx context.Context, channel Channel) error {
 	if err := bs.configs.UpdateChannel(ctx, channel); err != nil {
 		return errors.Wrap(errUpdateChannel, err)
@@ -399,6 +578,130 @@ func (bs bootstrapService) DisconnectThingHandler(ctx context.Context, channelID
 	return nil
 }
 
+func (bs bootstrapService) checkExternalKey(cfg Config, externalKey string, secure bool) error {
+	if secure {
+		dec, err := bs.keys.decryptExternalKey(cfg, externalKey)
+		if err != nil {
+			return errors.Wrap(ErrExternalKeySecure, err)
+		}
+		externalKey = dec
+	}
+	if cfg.ExternalKey != externalKey {
+		return ErrExternalKey
+	}
+
+	return nil
+}
+
+// Method validateTemplate validates the template and returns its channels.
+// The channels are saved with the template, so the Configs of enrolled
+// devices are created without retrieving the channels on their behalf.
+func (bs bootstrapService) validateTemplate(domainID, token string, tpl Template) ([]Channel, error) {
+	if err := tpl.Validate(); err != nil {
+		return nil, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	var channels []Channel
+	for _, id := range tpl.Channels {
+		ch, err := bs.sdk.Channel(id, domainID, token)
+		if err != nil {
+			return nil, errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+		if ch.DomainID != domainID {
+			return nil, errors.Wrap(svcerr.ErrMalformedEntity, errNotInSameDomain)
+		}
+		channels = append(channels, Channel{
+			ID:       ch.ID,
+			Name:     ch.Name,
+			Metadata: ch.Metadata,
+			DomainID: ch.DomainID,
+		})
+	}
+
+	return channels, nil
+}
+
+// Method createEnrolled creates the Thing and the Config of the enrolled
+// device from its template, and removes the fulfilled enrollment. The Thing
+// is created by the Things service on behalf of the template creator, who
+// must still be allowed to create Things in the template domain.
+func (bs bootstrapService) createEnrolled(ctx context.Context, e Enrollment) (Config, error) {
+	tpl, err := bs.templates.RetrieveByID(ctx, e.DomainID, e.TemplateID)
+	if err != nil {
+		return Config{}, errors.Wrap(errCreateEnrolled, err)
+	}
+	if tpl.CreatedBy == "" {
+		return Config{}, errors.Wrap(errCreateEnrolled, ErrMissingCredentials)
+	}
+	rendered, err := tpl.render(e, true)
+	if err != nil {
+		return Config{}, errors.Wrap(errCreateEnrolled, err)
+	}
+
+	// The channels are saved with the template, so the missing ones have
+	// been removed in the meantime.
+	channels, err := bs.configs.ListExisting(ctx, e.DomainID, tpl.Channels)
+	if err != nil {
+		return Config{}, errors.Wrap(errCheckChannels, err)
+	}
+	if len(channels) != len(tpl.Channels) {
+		return Config{}, errors.Wrap(errConnectionChannels, repoerr.ErrNotFound)
+	}
+
+	id, err := bs.idProvider.ID()
+	if err != nil {
+		return Config{}, errors.Wrap(errCreateThing, err)
+	}
+	name := e.Name
+	if name == "" {
+		name = "Bootstrapped Thing " + id
+	}
+	metadata, err := json.Marshal(rendered.Metadata)
+	if err != nil {
+		return Config{}, errors.Wrap(errCreateThing, err)
+	}
+	thing, err := bs.things.CreateThing(ctx, &magistrala.CreateThingReq{
+		DomainId: e.DomainID,
+		UserId:   tpl.CreatedBy,
+		Id:       id,
+		Name:     name,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return Config{}, errors.Wrap(errCreateThing, err)
+	}
+
+	// The client certificate is issued when the Config is enabled by a user.
+	cfg := Config{
+		ThingID:     thing.GetId(),
+		DomainID:    e.DomainID,
+		Name:        name,
+		ThingKey:    thing.GetKey(),
+		ExternalID:  e.ExternalID,
+		ExternalKey: e.ExternalKey,
+		Content:     rendered.Content,
+		State:       Inactive,
+		CertTTL:     tpl.CertTTL,
+	}
+	if _, err := bs.configs.Save(ctx, cfg, tpl.Channels); err != nil {
+		return Config{}, bs.removeThing(ctx, tpl, cfg.ThingID, errors.Wrap(ErrAddBootstrap, err))
+	}
+	if err := bs.templates.RemoveEnrollment(ctx, e.ExternalID); err != nil {
+		return Config{}, errors.Wrap(errCreateEnrolled, err)
+	}
+	cfg.Channels = channels
+
+	return cfg, nil
+}
+
+func (bs bootstrapService) removeThing(ctx context.Context, tpl Template, id string, err error) error {
+	req := &magistrala.DeleteThingReq{DomainId: tpl.DomainID, UserId: tpl.CreatedBy, Id: id}
+	if _, errT := bs.things.DeleteThing(ctx, req); errT != nil {
+		return errors.Wrap(err, errT)
+	}
+
+	return err
+}
+
 // Method thing retrieves Magistrala Thing creating one if an empty ID is passed.
 func (bs bootstrapService) thing(domainID, id, token string) (mgsdk.Thing, error) {
 	// If Thing ID is not provided, then create new thing.
@@ -487,22 +790,3 @@ func (bs bootstrapService) toIDList(channels []Channel) []string {
 
 	return ret
 }
-
-func (bs bootstrapService) dec(in string) (string, error) {
-	ciphertext, err := hex.DecodeString(in)
-	if err != nil {
-		return "", err
-	}
-	block, err := aes.NewCipher(bs.encKey)
-	if err != nil {
-		return "", err
-	}
-	if len(ciphertext) < aes.BlockSize {
-		return "", err
-	}
-	iv := ciphertext[:aes.BlockSize]
-	ciphertext = ciphertext[aes.BlockSize:]
-	stream := cipher.NewCFBDecrypter(block, iv)
-	stream.XORKeyStream(ciphertext, ciphertext)
-	return string(ciphertext), nil
-}
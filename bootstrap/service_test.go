@@ -8,25 +8,31 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"fmt"
 	"io"
 	"sort"
 	"testing"
 
+	"github.com/absmach/magistrala"
 	"github.com/absmach/magistrala/bootstrap"
 	"github.com/absmach/magistrala/bootstrap/mocks"
 	"github.com/absmach/magistrala/internal/testsutil"
 	mgauthn "github.com/absmach/magistrala/pkg/authn"
 	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	policysvc "github.com/absmach/magistrala/pkg/policies"
 	policymocks "github.com/absmach/magistrala/pkg/policies/mocks"
 	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
 	sdkmocks "github.com/absmach/magistrala/pkg/sdk/mocks"
 	"github.com/absmach/magistrala/pkg/uuid"
+	thmocks "github.com/absmach/magistrala/things/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/hkdf"
 )
 
 const (
@@ -41,9 +47,10 @@ const (
 )
 
 var (
-	encKey   = []byte("1234567891011121")
-	domainID = testsutil.GenerateUUID(&testing.T{})
-	channel  = bootstrap.Channel{
+	encKey    = []byte("1234567891011121")
+	masterKey = []byte("12345678910111213141516171819202")
+	domainID  = testsutil.GenerateUUID(&testing.T{})
+	channel   = bootstrap.Channel{
 		ID:       testsutil.GenerateUUID(&testing.T{}),
 		Name:     "name",
 		Metadata: map[string]interface{}{"name": "value"},
@@ -60,17 +67,59 @@ var (
 )
 
 var (
-	boot     *mocks.ConfigRepository
-	policies *policymocks.Service
-	sdk      *sdkmocks.SDK
+	boot      *mocks.ConfigRepository
+	templates *mocks.TemplateRepository
+	policies  *policymocks.Service
+	sdk       *sdkmocks.SDK
+	things    *thmocks.ThingsServiceClient
 )
 
 func newService() bootstrap.Service {
 	boot = new(mocks.ConfigRepository)
+	templates = new(mocks.TemplateRepository)
 	policies = new(policymocks.Service)
 	sdk = new(sdkmocks.SDK)
+	things = new(thmocks.ThingsServiceClient)
 	idp := uuid.NewMock()
-	return bootstrap.New(policies, boot, sdk, encKey, idp)
+	return bootstrap.New(policies, boot, templates, sdk, newKeyring(), things, idp)
+}
+
+func newKeyring() bootstrap.Keyring {
+	keys, _ := bootstrap.NewKeyring(encKey, map[bootstrap.KeyVersion][]byte{1: masterKey})
+	return keys
+}
+
+func deviceKey(externalID, externalKey string) ([]byte, error) {
+	key := make([]byte, 32)
+	kdf := hkdf.New(sha256.New, []byte(externalKey), masterKey, []byte("magistrala bootstrap device key "+externalID))
+	if _, err := io.ReadFull(kdf, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func deviceCipher(cfg bootstrap.Config) (cipher.AEAD, error) {
+	key, err := deviceKey(cfg.ExternalID, cfg.ExternalKey)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func seal(cfg bootstrap.Config, in []byte) ([]byte, error) {
+	aead, err := deviceCipher(cfg)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, in, []byte(cfg.ExternalID)), nil
 }
 
 func enc(in []byte) ([]byte, error) {
@@ -219,8 +268,14 @@ func TestView(t *testing.T) {
 		t.Run(tc.desc, func(t *testing.T) {
 			tc.session = mgauthn.Session{UserID: tc.userID, DomainID: tc.domain, DomainUserID: validID}
 			repoCall := boot.On("RetrieveByID", context.Background(), tc.thingDomain, tc.configID).Return(config, tc.retrieveErr)
-			_, err := svc.View(context.Background(), tc.session, tc.configID)
+			cfg, err := svc.View(context.Background(), tc.session, tc.configID)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				key, err := deviceKey(config.ExternalID, config.ExternalKey)
+				assert.Nil(t, err, fmt.Sprintf("%s: deriving device key expected to succeed: %s\n", tc.desc, err))
+				assert.Equal(t, base64.StdEncoding.EncodeToString(key), cfg.DeviceKey, fmt.Sprintf("%s: expected device key %s got %s\n", tc.desc, base64.StdEncoding.EncodeToString(key), cfg.DeviceKey))
+				assert.Equal(t, bootstrap.KeyVersion(1), cfg.KeyVersion, fmt.Sprintf("%s: expected key version 1 got %d\n", tc.desc, cfg.KeyVersion))
+			}
 			repoCall.Unset()
 		})
 	}
@@ -366,7 +421,7 @@ func TestUpdateCert(t *testing.T) {
 			sort.Slice(tc.expectedConfig.Channels, func(i, j int) bool {
 				return tc.expectedConfig.Channels[i].ID < tc.expectedConfig.Channels[j].ID
 			})
-			assert.Equal(t, tc.expectedConfig, cfg, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.expectedConfig, cfg))
+			assert.Equal(t, tc.expectedConfig, cfg, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.expectedConfig, cfg))
 			repoCall.Unset()
 		})
 	}
@@ -794,6 +849,8 @@ func TestBootstrap(t *testing.T) {
 	c := config
 	e, err := enc([]byte(c.ExternalKey))
 	assert.Nil(t, err, fmt.Sprintf("Encrypting external key expected to succeed: %s.\n", err))
+	s, err := seal(c, []byte(c.ExternalKey))
+	assert.Nil(t, err, fmt.Sprintf("Sealing external key expected to succeed: %s.\n", err))
 
 	cases := []struct {
 		desc        string
@@ -845,15 +902,91 @@ func TestBootstrap(t *testing.T) {
 			err:         nil,
 			encrypted:   true,
 		},
+		{
+			desc:        "bootstrap encrypted with versioned key",
+			config:      c,
+			externalID:  c.ExternalID,
+			externalKey: "1." + hex.EncodeToString(s),
+			userID:      validID,
+			domainID:    domainID,
+			err:         nil,
+			encrypted:   true,
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			repoCall := boot.On("RetrieveByExternalID", context.Background(), mock.Anything).Return(tc.config, tc.err)
+			repoCall1 := templates.On("RetrieveEnrollment", context.Background(), tc.externalID).Return(bootstrap.Enrollment{}, repoerr.ErrNotFound)
 			config, err := svc.Bootstrap(context.Background(), tc.externalKey, tc.externalID, tc.encrypted)
 			assert.Equal(t, tc.config, config, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.config, config))
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 			repoCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
+
+func TestBootstrapSecure(t *testing.T) {
+	svc := newService()
+
+	c := config
+	s, err := seal(c, []byte(c.ExternalKey))
+	assert.Nil(t, err, fmt.Sprintf("Sealing external key expected to succeed: %s.\n", err))
+	tampered := make([]byte, len(s))
+	copy(tampered, s)
+	tampered[len(tampered)-1] ^= 0xff
+	other := c
+	other.ExternalID = testsutil.GenerateUUID(t)
+	o, err := seal(other, []byte(c.ExternalKey))
+	assert.Nil(t, err, fmt.Sprintf("Sealing external key expected to succeed: %s.\n", err))
+
+	cases := []struct {
+		desc        string
+		externalKey string
+		err         error
+	}{
+		{
+			desc:        "bootstrap with versioned key",
+			externalKey: "1." + hex.EncodeToString(s),
+			err:         nil,
+		},
+		{
+			desc:        "bootstrap with tampered versioned key",
+			externalKey: "1." + hex.EncodeToString(tampered),
+			err:         bootstrap.ErrExternalKeySecure,
+		},
+		{
+			desc:        "bootstrap with key sealed for another device",
+			externalKey: "1." + hex.EncodeToString(o),
+			err:         bootstrap.ErrExternalKeySecure,
+		},
+		{
+			desc:        "bootstrap with unknown key version",
+			externalKey: "2." + hex.EncodeToString(s),
+			err:         bootstrap.ErrUnknownKeyVersion,
+		},
+		{
+			desc:        "bootstrap with invalid key version",
+			externalKey: "invalid." + hex.EncodeToString(s),
+			err:         bootstrap.ErrUnknownKeyVersion,
+		},
+		{
+			desc:        "bootstrap with malformed versioned key",
+			externalKey: "1.invalid",
+			err:         bootstrap.ErrExternalKeySecure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := boot.On("RetrieveByExternalID", context.Background(), c.ExternalID).Return(c, nil)
+			cfg, err := svc.Bootstrap(context.Background(), tc.externalKey, c.ExternalID, true)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, c, cfg, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, c, cfg))
+			}
+			repoCall.Unset()
 		})
 	}
 }
@@ -870,7 +1003,10 @@ func TestChangeState(t *testing.T) {
 		session       mgauthn.Session
 		userID        string
 		domainID      string
+		certTTL       string
 		retrieveErr   error
+		issueErr      errors.SDKError
+		updateCertErr error
 		connectErr    errors.SDKError
 		disconenctErr error
 		stateErr      error
@@ -895,6 +1031,38 @@ func TestChangeState(t *testing.T) {
 			domainID: domainID,
 			err:      nil,
 		},
+		{
+			desc:     "change state to Active of enrolled config",
+			state:    bootstrap.Active,
+			id:       c.ThingID,
+			token:    validToken,
+			userID:   validID,
+			domainID: domainID,
+			certTTL:  "24h",
+			err:      nil,
+		},
+		{
+			desc:     "change state to Active of enrolled config with failed certificate issuance",
+			state:    bootstrap.Active,
+			id:       c.ThingID,
+			token:    validToken,
+			userID:   validID,
+			domainID: domainID,
+			certTTL:  "24h",
+			issueErr: errors.NewSDKError(svcerr.ErrAuthorization),
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:          "change state to Active of enrolled config with failed certificate update",
+			state:         bootstrap.Active,
+			id:            c.ThingID,
+			token:         validToken,
+			userID:        validID,
+			domainID:      domainID,
+			certTTL:       "24h",
+			updateCertErr: repoerr.ErrUpdateEntity,
+			err:           repoerr.ErrUpdateEntity,
+		},
 		{
 			desc:     "change state to current state",
 			state:    bootstrap.Active,
@@ -938,14 +1106,23 @@ func TestChangeState(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			tc.session = mgauthn.Session{UserID: tc.userID, DomainID: tc.domainID, DomainUserID: validID}
-			repoCall := boot.On("RetrieveByID", context.Background(), tc.domainID, tc.id).Return(c, tc.retrieveErr)
+			cfg := c
+			cfg.CertTTL = tc.certTTL
+			repoCall := boot.On("RetrieveByID", context.Background(), tc.domainID, tc.id).Return(cfg, tc.retrieveErr)
 			sdkCall := sdk.On("Connect", mock.Anything, mock.Anything, mock.Anything).Return(tc.connectErr)
+			sdkCall1 := sdk.On("IssueCert", cfg.ThingID, tc.certTTL, tc.domainID, tc.token).Return(mgsdk.Cert{Certificate: "cert", Key: "key"}, tc.issueErr)
 			repoCall1 := boot.On("ChangeState", context.Background(), mock.Anything, mock.Anything, mock.Anything).Return(tc.stateErr)
+			repoCall2 := boot.On("UpdateCert", context.Background(), tc.domainID, cfg.ThingID, "cert", "key", cfg.CACert).Return(cfg, tc.updateCertErr)
 			err := svc.ChangeState(context.Background(), tc.session, tc.token, tc.id, tc.state)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.certTTL == "" {
+				sdkCall1.Parent.AssertNotCalled(t, "IssueCert", cfg.ThingID, tc.certTTL, tc.domainID, tc.token)
+			}
 			sdkCall.Unset()
+			sdkCall1.Unset()
 			repoCall.Unset()
 			repoCall1.Unset()
+			repoCall2.Unset()
 		})
 	}
 }
@@ -1111,3 +1288,275 @@ func TestDisconnectThingsHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestAddTemplate(t *testing.T) {
+	svc := newService()
+
+	tpl := bootstrap.Template{
+		Name:     "template",
+		Content:  `{"serial": "{{.ExternalID}}"}`,
+		Channels: []string{channel.ID},
+		CertTTL:  "24h",
+	}
+	invalidTTL := tpl
+	invalidTTL.CertTTL = "invalid"
+	invalidContent := tpl
+	invalidContent.Content = "{{.ExternalID"
+
+	cases := []struct {
+		desc            string
+		template        bootstrap.Template
+		channel         mgsdk.Channel
+		saveErr         error
+		saveChannelsErr error
+		err             error
+	}{
+		{
+			desc:     "add a new template",
+			template: tpl,
+			channel:  mgsdk.Channel{ID: channel.ID, DomainID: domainID},
+			err:      nil,
+		},
+		{
+			desc:     "add a template with invalid cert TTL",
+			template: invalidTTL,
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "add a template with invalid content",
+			template: invalidContent,
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "add a template with channel from another domain",
+			template: tpl,
+			channel:  mgsdk.Channel{ID: channel.ID, DomainID: invalidDomainID},
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "add a template with failed save",
+			template: tpl,
+			channel:  mgsdk.Channel{ID: channel.ID, DomainID: domainID},
+			saveErr:  repoerr.ErrCreateEntity,
+			err:      repoerr.ErrCreateEntity,
+		},
+		{
+			desc:            "add a template with failed channels save",
+			template:        tpl,
+			channel:         mgsdk.Channel{ID: channel.ID, DomainID: domainID},
+			saveChannelsErr: repoerr.ErrCreateEntity,
+			err:             repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			session := mgauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: validID}
+			sdkCall := sdk.On("Channel", channel.ID, domainID, validToken).Return(tc.channel, nil)
+			repoCall := templates.On("Save", context.Background(), mock.Anything).Return(tc.template, tc.saveErr)
+			repoCall1 := boot.On("SaveChannels", context.Background(), domainID, []bootstrap.Channel{{ID: channel.ID, DomainID: domainID}}).Return(tc.saveChannelsErr)
+			_, err := svc.AddTemplate(context.Background(), session, validToken, tc.template)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				saved := repoCall.Parent.Calls[len(repoCall.Parent.Calls)-1].Arguments.Get(1).(bootstrap.Template)
+				assert.Equal(t, session.UserID, saved.CreatedBy, fmt.Sprintf("%s: expected template creator %s got %s\n", tc.desc, session.UserID, saved.CreatedBy))
+			}
+			sdkCall.Unset()
+			repoCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
+
+func TestEnroll(t *testing.T) {
+	svc := newService()
+
+	templateID := testsutil.GenerateUUID(t)
+	cases := []struct {
+		desc        string
+		enrollments []bootstrap.Enrollment
+		retrieveErr error
+		saveErr     error
+		err         error
+	}{
+		{
+			desc: "enroll devices with and without external keys",
+			enrollments: []bootstrap.Enrollment{
+				{ExternalID: "device-1", ExternalKey: "key-1"},
+				{ExternalID: "device-2"},
+			},
+			err: nil,
+		},
+		{
+			desc:        "enroll devices with non-existing template",
+			enrollments: []bootstrap.Enrollment{{ExternalID: "device-1"}},
+			retrieveErr: repoerr.ErrNotFound,
+			err:         repoerr.ErrNotFound,
+		},
+		{
+			desc:        "enroll devices with failed save",
+			enrollments: []bootstrap.Enrollment{{ExternalID: "device-1"}},
+			saveErr:     repoerr.ErrConflict,
+			err:         repoerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			session := mgauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: validID}
+			repoCall := templates.On("RetrieveByID", context.Background(), domainID, templateID).Return(bootstrap.Template{ID: templateID}, tc.retrieveErr)
+			repoCall1 := templates.On("SaveEnrollments", context.Background(), mock.Anything).Return(tc.saveErr)
+			saved, err := svc.Enroll(context.Background(), session, templateID, tc.enrollments)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				for _, e := range saved {
+					assert.NotEmpty(t, e.ExternalKey, fmt.Sprintf("%s: expected external key to be set\n", tc.desc))
+					assert.Equal(t, templateID, e.TemplateID, fmt.Sprintf("%s: expected template ID %s got %s\n", tc.desc, templateID, e.TemplateID))
+					assert.Equal(t, domainID, e.DomainID, fmt.Sprintf("%s: expected domain ID %s got %s\n", tc.desc, domainID, e.DomainID))
+					key, err := deviceKey(e.ExternalID, e.ExternalKey)
+					assert.Nil(t, err, fmt.Sprintf("%s: deriving device key expected to succeed: %s\n", tc.desc, err))
+					assert.Equal(t, base64.StdEncoding.EncodeToString(key), e.DeviceKey, fmt.Sprintf("%s: expected device key %s got %s\n", tc.desc, base64.StdEncoding.EncodeToString(key), e.DeviceKey))
+				}
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
+
+func TestBootstrapEnrolled(t *testing.T) {
+	svc := newService()
+
+	tpl := bootstrap.Template{
+		ID:        testsutil.GenerateUUID(t),
+		DomainID:  domainID,
+		Name:      "template",
+		Content:   `{"serial": "{{.ExternalID}}", "region": "{{.Vars.region}}"}`,
+		Channels:  []string{channel.ID},
+		CertTTL:   "24h",
+		CreatedBy: validID,
+	}
+	withoutCreator := tpl
+	withoutCreator.CreatedBy = ""
+	enrollment := bootstrap.Enrollment{
+		ExternalID:  "device-1",
+		ExternalKey: "key-1",
+		TemplateID:  tpl.ID,
+		DomainID:    domainID,
+		Vars:        map[string]string{"region": "eu"},
+	}
+	missingVar := enrollment
+	missingVar.Vars = map[string]string{}
+	existing := []bootstrap.Channel{{ID: channel.ID, Name: channel.Name}}
+
+	cases := []struct {
+		desc        string
+		enrollment  bootstrap.Enrollment
+		template    bootstrap.Template
+		externalKey string
+		existing    []bootstrap.Channel
+		content     string
+		createErr   error
+		saveErr     error
+		err         error
+	}{
+		{
+			desc:        "bootstrap an enrolled device",
+			enrollment:  enrollment,
+			template:    tpl,
+			externalKey: enrollment.ExternalKey,
+			existing:    existing,
+			content:     `{"serial": "device-1", "region": "eu"}`,
+			err:         nil,
+		},
+		{
+			desc:        "bootstrap an enrolled device with invalid external key",
+			enrollment:  enrollment,
+			template:    tpl,
+			externalKey: "invalid",
+			existing:    existing,
+			err:         bootstrap.ErrExternalKey,
+		},
+		{
+			desc:        "bootstrap an enrolled device with missing template variable",
+			enrollment:  missingVar,
+			template:    tpl,
+			externalKey: enrollment.ExternalKey,
+			existing:    existing,
+			err:         bootstrap.ErrInvalidTemplate,
+		},
+		{
+			desc:        "bootstrap an enrolled device with removed channel",
+			enrollment:  enrollment,
+			template:    tpl,
+			externalKey: enrollment.ExternalKey,
+			existing:    []bootstrap.Channel{},
+			err:         repoerr.ErrNotFound,
+		},
+		{
+			desc:        "bootstrap an enrolled device of template creator without permission",
+			enrollment:  enrollment,
+			template:    tpl,
+			externalKey: enrollment.ExternalKey,
+			existing:    existing,
+			createErr:   svcerr.ErrAuthorization,
+			err:         svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "bootstrap an enrolled device with failed config save",
+			enrollment:  enrollment,
+			template:    tpl,
+			externalKey: enrollment.ExternalKey,
+			existing:    existing,
+			saveErr:     repoerr.ErrCreateEntity,
+			err:         repoerr.ErrCreateEntity,
+		},
+		{
+			desc:        "bootstrap an enrolled device of template without creator",
+			enrollment:  enrollment,
+			template:    withoutCreator,
+			externalKey: enrollment.ExternalKey,
+			existing:    existing,
+			err:         bootstrap.ErrMissingCredentials,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := boot.On("RetrieveByExternalID", context.Background(), tc.enrollment.ExternalID).Return(bootstrap.Config{}, repoerr.ErrNotFound)
+			repoCall1 := templates.On("RetrieveEnrollment", context.Background(), tc.enrollment.ExternalID).Return(tc.enrollment, nil)
+			repoCall2 := templates.On("RetrieveByID", context.Background(), domainID, tpl.ID).Return(tc.template, nil)
+			repoCall3 := templates.On("RemoveEnrollment", context.Background(), tc.enrollment.ExternalID).Return(nil)
+			repoCall4 := boot.On("ListExisting", context.Background(), domainID, tpl.Channels).Return(tc.existing, nil)
+			repoCall5 := boot.On("Save", context.Background(), mock.Anything, tpl.Channels).Return(config.ThingID, tc.saveErr)
+			thingsCall := things.On("CreateThing", context.Background(), mock.Anything).Return(&magistrala.CreateThingRes{Id: config.ThingID, Key: config.ThingKey}, tc.createErr)
+			thingsCall1 := things.On("DeleteThing", context.Background(), &magistrala.DeleteThingReq{DomainId: domainID, UserId: validID, Id: config.ThingID}).Return(&magistrala.DeleteThingRes{}, nil)
+			cfg, err := svc.Bootstrap(context.Background(), tc.externalKey, tc.enrollment.ExternalID, false)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.content, cfg.Content, fmt.Sprintf("%s: expected content %s got %s\n", tc.desc, tc.content, cfg.Content))
+				assert.Equal(t, config.ThingID, cfg.ThingID, fmt.Sprintf("%s: expected thing ID %s got %s\n", tc.desc, config.ThingID, cfg.ThingID))
+				assert.Equal(t, tc.existing, cfg.Channels, fmt.Sprintf("%s: expected channels %v got %v\n", tc.desc, tc.existing, cfg.Channels))
+				req := thingsCall.Parent.Calls[len(thingsCall.Parent.Calls)-1].Arguments.Get(1).(*magistrala.CreateThingReq)
+				assert.Equal(t, tpl.CreatedBy, req.GetUserId(), fmt.Sprintf("%s: expected thing creator %s got %s\n", tc.desc, tpl.CreatedBy, req.GetUserId()))
+				assert.Equal(t, domainID, req.GetDomainId(), fmt.Sprintf("%s: expected thing domain %s got %s\n", tc.desc, domainID, req.GetDomainId()))
+				saved := repoCall5.Parent.Calls[len(repoCall5.Parent.Calls)-1].Arguments.Get(1).(bootstrap.Config)
+				assert.Equal(t, tpl.CertTTL, saved.CertTTL, fmt.Sprintf("%s: expected cert TTL %s got %s\n", tc.desc, tpl.CertTTL, saved.CertTTL))
+				ok := repoCall3.Parent.AssertCalled(t, "RemoveEnrollment", context.Background(), tc.enrollment.ExternalID)
+				assert.True(t, ok, fmt.Sprintf("%s: expected enrollment to be removed\n", tc.desc))
+			}
+			if tc.saveErr != nil {
+				ok := thingsCall1.Parent.AssertCalled(t, "DeleteThing", context.Background(), &magistrala.DeleteThingReq{DomainId: domainID, UserId: validID, Id: config.ThingID})
+				assert.True(t, ok, fmt.Sprintf("%s: expected thing to be deleted\n", tc.desc))
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			repoCall3.Unset()
+			repoCall4.Unset()
+			repoCall5.Unset()
+			thingsCall.Unset()
+			thingsCall1.Unset()
+		})
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"text/template"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// ErrInvalidTemplate indicates a malformed bootstrap template.
+var ErrInvalidTemplate = errors.New("invalid bootstrap template")
+
+// Template represents a reusable bootstrap configuration. Content and string
+// metadata values are Go text templates rendered with the enrollment of the
+// device, so {{.ExternalID}}, {{.Name}} and {{.Vars.key}} can be used.
+// CertTTL, if set, issues a client certificate with the given validity when
+// the Thing is created. Things are created on behalf of the template creator.
+type Template struct {
+	ID        string                 `json:"id"`
+	DomainID  string                 `json:"domain_id,omitempty"`
+	Name      string                 `json:"name"`
+	Content   string                 `json:"content,omitempty"`
+	Channels  []string               `json:"channels,omitempty"`
+	CertTTL   string                 `json:"cert_ttl,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	CreatedBy string                 `json:"created_by,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at,omitempty"`
+}
+
+// TemplatesPage contains page related metadata as well as list of Templates
+// that belong to this page.
+type TemplatesPage struct {
+	Total     uint64     `json:"total"`
+	Offset    uint64     `json:"offset"`
+	Limit     uint64     `json:"limit"`
+	Templates []Template `json:"templates"`
+}
+
+// Enrollment represents a device pre-registered with a template. The Thing
+// and its Config are created on the first bootstrap of the device. The
+// DeviceKey is the secure bootstrap key of the device and is not persisted.
+type Enrollment struct {
+	ExternalID  string            `json:"external_id"`
+	ExternalKey string            `json:"external_key"`
+	TemplateID  string            `json:"template_id"`
+	DomainID    string            `json:"domain_id,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Vars        map[string]string `json:"vars,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	DeviceKey   string            `json:"device_key,omitempty"`
+	KeyVersion  KeyVersion        `json:"key_version,omitempty"`
+}
+
+// TemplateRepository specifies a Template and Enrollment persistence API.
+//
+//go:generate mockery --name TemplateRepository --output=./mocks --filename templates.go --quiet --note "Copyright (c) Abstract Machines"
+type TemplateRepository interface {
+	// Save persists the Template.
+	Save(ctx context.Context, tpl Template) (Template, error)
+
+	// RetrieveByID retrieves the Template with the given ID from the domain.
+	RetrieveByID(ctx context.Context, domainID, id string) (Template, error)
+
+	// RetrieveAll retrieves a subset of Templates of the domain.
+	RetrieveAll(ctx context.Context, domainID string, offset, limit uint64) (TemplatesPage, error)
+
+	// Update updates editable fields of the Template.
+	Update(ctx context.Context, tpl Template) (Template, error)
+
+	// Remove removes the Template and its pending Enrollments.
+	Remove(ctx context.Context, domainID, id string) error
+
+	// SaveEnrollments persists the Enrollments atomically.
+	SaveEnrollments(ctx context.Context, enrollments []Enrollment) error
+
+	// RetrieveEnrollment retrieves the pending Enrollment for the external ID.
+	RetrieveEnrollment(ctx context.Context, externalID string) (Enrollment, error)
+
+	// RemoveEnrollment removes the Enrollment for the external ID.
+	RemoveEnrollment(ctx context.Context, externalID string) error
+}
+
+// Validate checks whether the template can be rendered.
+func (tpl Template) Validate() error {
+	if tpl.CertTTL != "" {
+		if _, err := time.ParseDuration(tpl.CertTTL); err != nil {
+			return errors.Wrap(ErrInvalidTemplate, err)
+		}
+	}
+	if _, err := tpl.render(Enrollment{Vars: map[string]string{}}, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type renderedTemplate struct {
+	Content  string
+	Metadata map[string]interface{}
+}
+
+// render renders the template for the enrollment. Missing variables are
+// reported only if strict, so templates can be validated without vars.
+func (tpl Template) render(e Enrollment, strict bool) (renderedTemplate, error) {
+	content, err := renderText("content", tpl.Content, e, strict)
+	if err != nil {
+		return renderedTemplate{}, err
+	}
+	metadata := make(map[string]interface{}, len(tpl.Metadata))
+	for k, v := range tpl.Metadata {
+		s, ok := v.(string)
+		if !ok {
+			metadata[k] = v
+			continue
+		}
+		if metadata[k], err = renderText(k, s, e, strict); err != nil {
+			return renderedTemplate{}, err
+		}
+	}
+
+	return renderedTemplate{Content: content, Metadata: metadata}, nil
+}
+
+func renderText(name, text string, e Enrollment, strict bool) (string, error) {
+	missing := "missingkey=zero"
+	if strict {
+		missing = "missingkey=error"
+	}
+	t, err := template.New(name).Option(missing).Parse(text)
+	if err != nil {
+		return "", errors.Wrap(ErrInvalidTemplate, err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, e); err != nil {
+		return "", errors.Wrap(ErrInvalidTemplate, err)
+	}
+
+	return buf.String(), nil
+}
@@ -127,6 +127,73 @@ func (tm *tracingMiddleware) ChangeState(ctx context.Context, session mgauthn.Se
 	return tm.svc.ChangeState(ctx, session, token, id, state)
 }
 
+// AddTemplate traces the "AddTemplate" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) AddTemplate(ctx context.Context, session mgauthn.Session, token string, tpl bootstrap.Template) (bootstrap.Template, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_add_template", trace.WithAttributes(
+		attribute.String("name", tpl.Name),
+		attribute.StringSlice("channels", tpl.Channels),
+		attribute.String("cert_ttl", tpl.CertTTL),
+	))
+	defer span.End()
+
+	return tm.svc.AddTemplate(ctx, session, token, tpl)
+}
+
+// ViewTemplate traces the "ViewTemplate" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) ViewTemplate(ctx context.Context, session mgauthn.Session, id string) (bootstrap.Template, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_template", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.ViewTemplate(ctx, session, id)
+}
+
+// UpdateTemplate traces the "UpdateTemplate" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) UpdateTemplate(ctx context.Context, session mgauthn.Session, token string, tpl bootstrap.Template) (bootstrap.Template, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_update_template", trace.WithAttributes(
+		attribute.String("id", tpl.ID),
+		attribute.String("name", tpl.Name),
+		attribute.StringSlice("channels", tpl.Channels),
+		attribute.String("cert_ttl", tpl.CertTTL),
+	))
+	defer span.End()
+
+	return tm.svc.UpdateTemplate(ctx, session, token, tpl)
+}
+
+// ListTemplates traces the "ListTemplates" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) ListTemplates(ctx context.Context, session mgauthn.Session, offset, limit uint64) (bootstrap.TemplatesPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_templates", trace.WithAttributes(
+		attribute.Int64("offset", int64(offset)),
+		attribute.Int64("limit", int64(limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListTemplates(ctx, session, offset, limit)
+}
+
+// RemoveTemplate traces the "RemoveTemplate" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) RemoveTemplate(ctx context.Context, session mgauthn.Session, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_remove_template", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.RemoveTemplate(ctx, session, id)
+}
+
+// Enroll traces the "Enroll" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) Enroll(ctx context.Context, session mgauthn.Session, templateID string, enrollments []bootstrap.Enrollment) ([]bootstrap.Enrollment, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_enroll", trace.WithAttributes(
+		attribute.String("template_id", templateID),
+		attribute.Int("enrollments", len(enrollments)),
+	))
+	defer span.End()
+
+	return tm.svc.Enroll(ctx, session, templateID, enrollments)
+}
+
 // UpdateChannelHandler traces the "UpdateChannelHandler" operation of the wrapped bootstrap.Service.
 func (tm *tracingMiddleware) UpdateChannelHandler(ctx context.Context, channel bootstrap.Channel) error {
 	ctx, span := tm.tracer.Start(ctx, "svc_update_channel_handler", trace.WithAttributes(
@@ -5,6 +5,8 @@ package api
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 
 	"github.com/absmach/magistrala/certs"
 	"github.com/absmach/magistrala/pkg/apiutil"
@@ -106,3 +108,59 @@ func revokeCert(svc certs.Service) endpoint.Endpoint {
 		}, nil
 	}
 }
+
+func caCerts(svc certs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, _ interface{}) (interface{}, error) {
+		cas, err := svc.CACerts(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return estCertsRes{certs: cas}, nil
+	}
+}
+
+func enroll(svc certs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(enrollReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		cert, err := svc.Enroll(ctx, req.externalID, req.externalKey, req.csr)
+		if err != nil {
+			return nil, err
+		}
+
+		return toESTRes(cert)
+	}
+}
+
+func reenroll(svc certs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(reenrollReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		cert, err := svc.Reenroll(ctx, req.cert, req.csr)
+		if err != nil {
+			return nil, err
+		}
+
+		return toESTRes(cert)
+	}
+}
+
+func toESTRes(cert certs.Cert) (estCertsRes, error) {
+	block, _ := pem.Decode([]byte(cert.Certificate))
+	if block == nil {
+		return estCertsRes{}, certs.ErrFailedCertCreation
+	}
+	c, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return estCertsRes{}, errors.Wrap(certs.ErrFailedCertCreation, err)
+	}
+
+	return estCertsRes{certs: []*x509.Certificate{c}}, nil
+}
@@ -4,9 +4,17 @@
 package api_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -48,6 +56,8 @@ type testRequest struct {
 	url         string
 	contentType string
 	token       string
+	username    string
+	password    string
 	body        io.Reader
 }
 
@@ -62,6 +72,9 @@ func (tr testRequest) make() (*http.Response, error) {
 	if tr.contentType != "" {
 		req.Header.Set("Content-Type", tr.contentType)
 	}
+	if tr.username != "" {
+		req.SetBasicAuth(tr.username, tr.password)
+	}
 
 	return tr.client.Do(req)
 }
@@ -666,6 +679,204 @@ func TestListSerials(t *testing.T) {
 	}
 }
 
+func newX509Cert(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err, fmt.Sprintf("generating key expected to succeed: %s", err))
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: thingID},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	assert.Nil(t, err, fmt.Sprintf("creating certificate expected to succeed: %s", err))
+	crt, err := x509.ParseCertificate(der)
+	assert.Nil(t, err, fmt.Sprintf("parsing certificate expected to succeed: %s", err))
+
+	return crt, key
+}
+
+func TestCACerts(t *testing.T) {
+	cs, svc, _ := newCertServer()
+	defer cs.Close()
+
+	ca, _ := newX509Cert(t)
+
+	cases := []struct {
+		desc   string
+		svcRes []*x509.Certificate
+		svcErr error
+		status int
+	}{
+		{
+			desc:   "retrieve CA certificates successfully",
+			svcRes: []*x509.Certificate{ca},
+			status: http.StatusOK,
+		},
+		{
+			desc:   "retrieve CA certificates with disabled enrollment",
+			svcErr: certs.ErrEnrollmentDisabled,
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: cs.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/.well-known/est/cacerts", cs.URL),
+			}
+			svcCall := svc.On("CACerts", mock.Anything).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				assert.Equal(t, "application/pkcs7-mime; smime-type=certs-only", res.Header.Get("Content-Type"), fmt.Sprintf("%s: unexpected content type", tc.desc))
+				body, err := io.ReadAll(res.Body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				_, err = base64.StdEncoding.DecodeString(string(body))
+				assert.Nil(t, err, fmt.Sprintf("%s: expected base64 encoded body: %s", tc.desc, err))
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestEnroll(t *testing.T) {
+	cs, svc, _ := newCertServer()
+	defer cs.Close()
+
+	crt, key := newX509Cert(t)
+	enrolled := certs.Cert{
+		ThingID:      thingID,
+		SerialNumber: serial,
+		Certificate:  string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: crt.Raw})),
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: thingID}}, key)
+	assert.Nil(t, err, fmt.Sprintf("creating CSR expected to succeed: %s", err))
+	csr := base64.StdEncoding.EncodeToString(der)
+	// CSRs are commonly line wrapped by clients.
+	wrappedCSR := csr[:64] + "\r\n" + csr[64:] + "\n"
+
+	cases := []struct {
+		desc        string
+		username    string
+		password    string
+		contentType string
+		body        string
+		svcRes      certs.Cert
+		svcErr      error
+		status      int
+		err         error
+	}{
+		{
+			desc:        "enroll successfully",
+			username:    valid,
+			password:    valid,
+			contentType: "application/pkcs10",
+			body:        csr,
+			svcRes:      enrolled,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "enroll with line wrapped CSR",
+			username:    valid,
+			password:    valid,
+			contentType: "application/pkcs10",
+			body:        wrappedCSR,
+			svcRes:      enrolled,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "enroll with invalid credentials",
+			username:    valid,
+			password:    invalid,
+			contentType: "application/pkcs10",
+			body:        csr,
+			svcErr:      svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "enroll without credentials",
+			contentType: "application/pkcs10",
+			body:        csr,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "enroll with invalid content type",
+			username:    valid,
+			password:    valid,
+			contentType: contentType,
+			body:        csr,
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+		{
+			desc:        "enroll with malformed CSR",
+			username:    valid,
+			password:    valid,
+			contentType: "application/pkcs10",
+			body:        base64.StdEncoding.EncodeToString([]byte(invalid)),
+			status:      http.StatusBadRequest,
+			err:         certs.ErrInvalidCSR,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client:      cs.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/.well-known/est/simpleenroll", cs.URL),
+				contentType: tc.contentType,
+				username:    tc.username,
+				password:    tc.password,
+				body:        strings.NewReader(tc.body),
+			}
+			svcCall := svc.On("Enroll", mock.Anything, tc.username, tc.password, mock.Anything).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.err != nil {
+				var errRes respBody
+				err = json.NewDecoder(res.Body).Decode(&errRes)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+				if errRes.Err != "" || errRes.Message != "" {
+					err = errors.Wrap(errors.New(errRes.Err), errors.New(errRes.Message))
+				}
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n ", tc.desc, tc.err, err))
+			}
+			if tc.status == http.StatusUnauthorized {
+				assert.NotEmpty(t, res.Header.Get("WWW-Authenticate"), fmt.Sprintf("%s: expected authentication challenge", tc.desc))
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestReenroll(t *testing.T) {
+	cs, _, _ := newCertServer()
+	defer cs.Close()
+
+	_, key := newX509Cert(t)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: thingID}}, key)
+	assert.Nil(t, err, fmt.Sprintf("creating CSR expected to succeed: %s", err))
+
+	req := testRequest{
+		client:      cs.Client(),
+		method:      http.MethodPost,
+		url:         fmt.Sprintf("%s/.well-known/est/simplereenroll", cs.URL),
+		contentType: "application/pkcs10",
+		body:        strings.NewReader(base64.StdEncoding.EncodeToString(der)),
+	}
+	res, err := req.make()
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode, fmt.Sprintf("reenroll without client certificate: expected status code %d got %d", http.StatusUnauthorized, res.StatusCode))
+}
+
 type respBody struct {
 	Err     string `json:"error"`
 	Message string `json:"message"`
@@ -0,0 +1,117 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/go-kit/kit/endpoint"
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const svcName = "magistrala.CertsService"
+
+var _ magistrala.CertsServiceClient = (*grpcClient)(nil)
+
+type grpcClient struct {
+	timeout           time.Duration
+	retrieveCert      endpoint.Endpoint
+	enrollmentCACerts endpoint.Endpoint
+}
+
+// NewClient returns new gRPC client instance.
+func NewClient(conn *grpc.ClientConn, timeout time.Duration) magistrala.CertsServiceClient {
+	return &grpcClient{
+		retrieveCert: kitgrpc.NewClient(
+			conn,
+			svcName,
+			"RetrieveCert",
+			encodeRetrieveCertRequest,
+			decodeRetrieveCertResponse,
+			magistrala.RetrieveCertRes{},
+		).Endpoint(),
+		enrollmentCACerts: kitgrpc.NewClient(
+			conn,
+			svcName,
+			"EnrollmentCACerts",
+			encodeEnrollmentCACertsRequest,
+			decodeEnrollmentCACertsResponse,
+			magistrala.EnrollmentCACertsRes{},
+		).Endpoint(),
+
+		timeout: timeout,
+	}
+}
+
+func (client grpcClient) RetrieveCert(ctx context.Context, req *magistrala.RetrieveCertReq, _ ...grpc.CallOption) (*magistrala.RetrieveCertRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.retrieveCert(ctx, retrieveCertReq{serialNumber: req.GetSerialNumber()})
+	if err != nil {
+		return &magistrala.RetrieveCertRes{}, decodeError(err)
+	}
+
+	rc := res.(retrieveCertRes)
+	return &magistrala.RetrieveCertRes{ThingId: rc.thingID, Revoked: rc.revoked}, nil
+}
+
+func encodeRetrieveCertRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(retrieveCertReq)
+	return &magistrala.RetrieveCertReq{SerialNumber: req.serialNumber}, nil
+}
+
+func decodeRetrieveCertResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*magistrala.RetrieveCertRes)
+	return retrieveCertRes{thingID: res.GetThingId(), revoked: res.GetRevoked()}, nil
+}
+
+func (client grpcClient) EnrollmentCACerts(ctx context.Context, _ *magistrala.EnrollmentCACertsReq, _ ...grpc.CallOption) (*magistrala.EnrollmentCACertsRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.enrollmentCACerts(ctx, enrollmentCACertsReq{})
+	if err != nil {
+		return &magistrala.EnrollmentCACertsRes{}, decodeError(err)
+	}
+
+	ec := res.(enrollmentCACertsRes)
+	return &magistrala.EnrollmentCACertsRes{Certs: ec.certs}, nil
+}
+
+func encodeEnrollmentCACertsRequest(_ context.Context, _ interface{}) (interface{}, error) {
+	return &magistrala.EnrollmentCACertsReq{}, nil
+}
+
+func decodeEnrollmentCACertsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*magistrala.EnrollmentCACertsRes)
+	return enrollmentCACertsRes{certs: res.GetCerts()}, nil
+}
+
+func decodeError(err error) error {
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.InvalidArgument:
+			return errors.Wrap(errors.ErrMalformedEntity, errors.New(st.Message()))
+		case codes.NotFound:
+			return errors.Wrap(svcerr.ErrNotFound, errors.New(st.Message()))
+		case codes.OK:
+			if msg := st.Message(); msg != "" {
+				return errors.Wrap(errors.ErrUnidentified, errors.New(msg))
+			}
+			return nil
+		default:
+			return errors.Wrap(fmt.Errorf("unexpected gRPC status: %s (status code:%v)", st.Code().String(), st.Code()), errors.New(st.Message()))
+		}
+	}
+	return err
+}
@@ -0,0 +1,48 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/absmach/magistrala/certs"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func retrieveCertEndpoint(svc certs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(retrieveCertReq)
+		if err := req.validate(); err != nil {
+			return retrieveCertRes{}, err
+		}
+
+		cert, err := svc.RetrieveCert(ctx, new(big.Int).SetBytes(req.serialNumber))
+		if err != nil {
+			return retrieveCertRes{}, err
+		}
+
+		return retrieveCertRes{thingID: cert.ThingID, revoked: cert.Revoked}, nil
+	}
+}
+
+func enrollmentCACertsEndpoint(svc certs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		cas, err := svc.CACerts(ctx)
+		switch {
+		case errors.Contains(err, certs.ErrEnrollmentDisabled):
+			return enrollmentCACertsRes{}, nil
+		case err != nil:
+			return enrollmentCACertsRes{}, err
+		}
+
+		res := enrollmentCACertsRes{certs: make([][]byte, len(cas))}
+		for i, ca := range cas {
+			res.certs[i] = ca.Raw
+		}
+
+		return res, nil
+	}
+}
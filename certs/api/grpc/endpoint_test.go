@@ -0,0 +1,141 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc_test
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/certs"
+	grpcapi "github.com/absmach/magistrala/certs/api/grpc"
+	"github.com/absmach/magistrala/certs/mocks"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	certPort = 7010
+	caPort   = 7011
+	thingID  = "testID"
+)
+
+var serial = big.NewInt(1234)
+
+func startGRPCServer(svc *mocks.Service, port int) {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		panic(fmt.Sprintf("failed to obtain port: %s", err))
+	}
+	server := grpc.NewServer()
+	magistrala.RegisterCertsServiceServer(server, grpcapi.NewServer(svc))
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			panic(fmt.Sprintf("failed to serve: %s", err))
+		}
+	}()
+}
+
+func TestRetrieveCert(t *testing.T) {
+	svc := new(mocks.Service)
+	startGRPCServer(svc, certPort)
+	conn, _ := grpc.NewClient(fmt.Sprintf("localhost:%d", certPort), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	client := grpcapi.NewClient(conn, time.Second)
+
+	cases := []struct {
+		desc   string
+		req    *magistrala.RetrieveCertReq
+		res    *magistrala.RetrieveCertRes
+		svcRes certs.Cert
+		svcErr error
+		err    error
+	}{
+		{
+			desc:   "retrieve certificate successfully",
+			req:    &magistrala.RetrieveCertReq{SerialNumber: serial.Bytes()},
+			res:    &magistrala.RetrieveCertRes{ThingId: thingID},
+			svcRes: certs.Cert{SerialNumber: serial.String(), ThingID: thingID, Key: "key"},
+		},
+		{
+			desc:   "retrieve revoked certificate",
+			req:    &magistrala.RetrieveCertReq{SerialNumber: serial.Bytes()},
+			res:    &magistrala.RetrieveCertRes{ThingId: thingID, Revoked: true},
+			svcRes: certs.Cert{SerialNumber: serial.String(), ThingID: thingID, Revoked: true},
+		},
+		{
+			desc: "retrieve certificate without serial number",
+			req:  &magistrala.RetrieveCertReq{},
+			res:  &magistrala.RetrieveCertRes{},
+			err:  errors.ErrMalformedEntity,
+		},
+		{
+			desc:   "retrieve non existing certificate",
+			req:    &magistrala.RetrieveCertReq{SerialNumber: serial.Bytes()},
+			res:    &magistrala.RetrieveCertRes{},
+			svcErr: errors.Wrap(svcerr.ErrViewEntity, repoerr.ErrNotFound),
+			err:    svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("RetrieveCert", mock.Anything, new(big.Int).SetBytes(tc.req.GetSerialNumber())).Return(tc.svcRes, tc.svcErr)
+		res, err := client.RetrieveCert(context.Background(), tc.req)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res.GetThingId(), res.GetThingId(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.res.GetThingId(), res.GetThingId()))
+		assert.Equal(t, tc.res.GetRevoked(), res.GetRevoked(), fmt.Sprintf("%s: expected %t got %t", tc.desc, tc.res.GetRevoked(), res.GetRevoked()))
+		svcCall.Unset()
+	}
+}
+
+func TestEnrollmentCACerts(t *testing.T) {
+	svc := new(mocks.Service)
+	startGRPCServer(svc, caPort)
+	conn, _ := grpc.NewClient(fmt.Sprintf("localhost:%d", caPort), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	client := grpcapi.NewClient(conn, time.Second)
+
+	ca := &x509.Certificate{Raw: []byte("ca")}
+
+	cases := []struct {
+		desc   string
+		res    *magistrala.EnrollmentCACertsRes
+		svcRes []*x509.Certificate
+		svcErr error
+		err    error
+	}{
+		{
+			desc:   "retrieve enrollment CA certificates successfully",
+			res:    &magistrala.EnrollmentCACertsRes{Certs: [][]byte{ca.Raw}},
+			svcRes: []*x509.Certificate{ca},
+		},
+		{
+			desc:   "retrieve enrollment CA certificates with enrollment disabled",
+			res:    &magistrala.EnrollmentCACertsRes{},
+			svcErr: certs.ErrEnrollmentDisabled,
+		},
+		{
+			desc:   "retrieve enrollment CA certificates with failed service",
+			res:    &magistrala.EnrollmentCACertsRes{},
+			svcErr: svcerr.ErrViewEntity,
+			err:    svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("CACerts", mock.Anything).Return(tc.svcRes, tc.svcErr)
+		res, err := client.EnrollmentCACerts(context.Background(), &magistrala.EnrollmentCACertsReq{})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res.GetCerts(), res.GetCerts(), fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res.GetCerts(), res.GetCerts()))
+		svcCall.Unset()
+	}
+}
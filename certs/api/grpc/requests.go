@@ -0,0 +1,20 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import "github.com/absmach/magistrala/pkg/apiutil"
+
+type retrieveCertReq struct {
+	serialNumber []byte
+}
+
+func (req retrieveCertReq) validate() error {
+	if len(req.serialNumber) == 0 {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type enrollmentCACertsReq struct{}
@@ -0,0 +1,13 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+type retrieveCertRes struct {
+	thingID string
+	revoked bool
+}
+
+type enrollmentCACertsRes struct {
+	certs [][]byte
+}
@@ -0,0 +1,91 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/certs"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ magistrala.CertsServiceServer = (*grpcServer)(nil)
+
+type grpcServer struct {
+	magistrala.UnimplementedCertsServiceServer
+	retrieveCert      kitgrpc.Handler
+	enrollmentCACerts kitgrpc.Handler
+}
+
+// NewServer returns new CertsServiceServer instance.
+func NewServer(svc certs.Service) magistrala.CertsServiceServer {
+	return &grpcServer{
+		retrieveCert: kitgrpc.NewServer(
+			retrieveCertEndpoint(svc),
+			decodeRetrieveCertRequest,
+			encodeRetrieveCertResponse,
+		),
+		enrollmentCACerts: kitgrpc.NewServer(
+			enrollmentCACertsEndpoint(svc),
+			decodeEnrollmentCACertsRequest,
+			encodeEnrollmentCACertsResponse,
+		),
+	}
+}
+
+func (s *grpcServer) RetrieveCert(ctx context.Context, req *magistrala.RetrieveCertReq) (*magistrala.RetrieveCertRes, error) {
+	_, res, err := s.retrieveCert.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*magistrala.RetrieveCertRes), nil
+}
+
+func decodeRetrieveCertRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*magistrala.RetrieveCertReq)
+	return retrieveCertReq{serialNumber: req.GetSerialNumber()}, nil
+}
+
+func encodeRetrieveCertResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(retrieveCertRes)
+	return &magistrala.RetrieveCertRes{ThingId: res.thingID, Revoked: res.revoked}, nil
+}
+
+func (s *grpcServer) EnrollmentCACerts(ctx context.Context, req *magistrala.EnrollmentCACertsReq) (*magistrala.EnrollmentCACertsRes, error) {
+	_, res, err := s.enrollmentCACerts.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*magistrala.EnrollmentCACertsRes), nil
+}
+
+func decodeEnrollmentCACertsRequest(_ context.Context, _ interface{}) (interface{}, error) {
+	return enrollmentCACertsReq{}, nil
+}
+
+func encodeEnrollmentCACertsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(enrollmentCACertsRes)
+	return &magistrala.EnrollmentCACertsRes{Certs: res.certs}, nil
+}
+
+func encodeError(err error) error {
+	switch {
+	case errors.Contains(err, nil):
+		return nil
+	case err == apiutil.ErrMissingID:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Contains(err, repoerr.ErrNotFound),
+		errors.Contains(err, svcerr.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
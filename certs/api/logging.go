@@ -7,7 +7,9 @@ package api
 
 import (
 	"context"
+	"crypto/x509"
 	"log/slog"
+	"math/big"
 	"time"
 
 	"github.com/absmach/magistrala/certs"
@@ -112,6 +114,25 @@ func (lm *loggingMiddleware) ViewCert(ctx context.Context, serialID string) (c c
 	return lm.svc.ViewCert(ctx, serialID)
 }
 
+// RetrieveCert logs the retrieve_cert request. It logs the serial number and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) RetrieveCert(ctx context.Context, serialNumber *big.Int) (c certs.Cert, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("serial_number", serialNumber.String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Retrieve certificate failed", args...)
+			return
+		}
+		lm.logger.Info("Retrieve certificate completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RetrieveCert(ctx, serialNumber)
+}
+
 // RevokeCert logs the revoke_cert request. It logs the thing ID and the time it took to complete the request.
 // If the request fails, it logs the error.
 func (lm *loggingMiddleware) RevokeCert(ctx context.Context, domainID, token, thingID string) (c certs.Revoke, err error) {
@@ -130,3 +151,61 @@ func (lm *loggingMiddleware) RevokeCert(ctx context.Context, domainID, token, th
 
 	return lm.svc.RevokeCert(ctx, domainID, token, thingID)
 }
+
+// CACerts logs the ca_certs request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) CACerts(ctx context.Context) (cas []*x509.Certificate, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Retrieve CA certificates failed", args...)
+			return
+		}
+		lm.logger.Info("Retrieve CA certificates completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CACerts(ctx)
+}
+
+// Enroll logs the enroll request. It logs the external ID, the issued serial number and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) Enroll(ctx context.Context, externalID, externalKey string, csr *x509.CertificateRequest) (c certs.Cert, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("external_id", externalID),
+			slog.String("serial_number", c.SerialNumber),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Enroll certificate failed", args...)
+			return
+		}
+		lm.logger.Info("Enroll certificate completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Enroll(ctx, externalID, externalKey, csr)
+}
+
+// Reenroll logs the reenroll request. It logs the current and the issued serial numbers and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) Reenroll(ctx context.Context, cert *x509.Certificate, csr *x509.CertificateRequest) (c certs.Cert, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("current_serial_number", cert.SerialNumber.Text(16)),
+			slog.String("serial_number", c.SerialNumber),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Reenroll certificate failed", args...)
+			return
+		}
+		lm.logger.Info("Reenroll certificate completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Reenroll(ctx, cert, csr)
+}
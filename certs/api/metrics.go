@@ -7,6 +7,8 @@ package api
 
 import (
 	"context"
+	"crypto/x509"
+	"math/big"
 	"time"
 
 	"github.com/absmach/magistrala/certs"
@@ -70,6 +72,16 @@ func (ms *metricsMiddleware) ViewCert(ctx context.Context, serialID string) (cer
 	return ms.svc.ViewCert(ctx, serialID)
 }
 
+// RetrieveCert instruments RetrieveCert method with metrics.
+func (ms *metricsMiddleware) RetrieveCert(ctx context.Context, serialNumber *big.Int) (certs.Cert, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "retrieve_cert").Add(1)
+		ms.latency.With("method", "retrieve_cert").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RetrieveCert(ctx, serialNumber)
+}
+
 // RevokeCert instruments RevokeCert method with metrics.
 func (ms *metricsMiddleware) RevokeCert(ctx context.Context, domainID, token, thingID string) (certs.Revoke, error) {
 	defer func(begin time.Time) {
@@ -79,3 +91,33 @@ func (ms *metricsMiddleware) RevokeCert(ctx context.Context, domainID, token, th
 
 	return ms.svc.RevokeCert(ctx, domainID, token, thingID)
 }
+
+// CACerts instruments CACerts method with metrics.
+func (ms *metricsMiddleware) CACerts(ctx context.Context) ([]*x509.Certificate, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "ca_certs").Add(1)
+		ms.latency.With("method", "ca_certs").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.CACerts(ctx)
+}
+
+// Enroll instruments Enroll method with metrics.
+func (ms *metricsMiddleware) Enroll(ctx context.Context, externalID, externalKey string, csr *x509.CertificateRequest) (certs.Cert, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "enroll").Add(1)
+		ms.latency.With("method", "enroll").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Enroll(ctx, externalID, externalKey, csr)
+}
+
+// Reenroll instruments Reenroll method with metrics.
+func (ms *metricsMiddleware) Reenroll(ctx context.Context, cert *x509.Certificate, csr *x509.CertificateRequest) (certs.Cert, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "reenroll").Add(1)
+		ms.latency.With("method", "reenroll").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Reenroll(ctx, cert, csr)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCertsOnly(t *testing.T) {
+	var crts []*x509.Certificate
+	for i := 1; i <= 2; i++ {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.Nil(t, err, fmt.Sprintf("generating key expected to succeed: %s", err))
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(int64(i)),
+			Subject:      pkix.Name{CommonName: fmt.Sprintf("cert-%d", i)},
+			NotBefore:    time.Now(),
+			NotAfter:     time.Now().Add(time.Hour),
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+		assert.Nil(t, err, fmt.Sprintf("creating certificate expected to succeed: %s", err))
+		crt, err := x509.ParseCertificate(der)
+		assert.Nil(t, err, fmt.Sprintf("parsing certificate expected to succeed: %s", err))
+		crts = append(crts, crt)
+	}
+
+	b, err := certsOnly(crts)
+	assert.Nil(t, err, fmt.Sprintf("encoding certificates expected to succeed: %s", err))
+
+	var ci contentInfo
+	_, err = asn1.Unmarshal(b, &ci)
+	assert.Nil(t, err, fmt.Sprintf("decoding content info expected to succeed: %s", err))
+	assert.True(t, ci.ContentType.Equal(oidSignedData), fmt.Sprintf("expected content type %s got %s", oidSignedData, ci.ContentType))
+
+	var sd signedData
+	_, err = asn1.Unmarshal(ci.Content.Bytes, &sd)
+	assert.Nil(t, err, fmt.Sprintf("decoding signed data expected to succeed: %s", err))
+	assert.Equal(t, 1, sd.Version, fmt.Sprintf("expected version 1 got %d", sd.Version))
+	assert.Empty(t, sd.SignerInfos.Bytes, "expected no signer infos")
+
+	parsed, err := x509.ParseCertificates(sd.Certificates.Bytes)
+	assert.Nil(t, err, fmt.Sprintf("parsing certificates expected to succeed: %s", err))
+	assert.Equal(t, crts, parsed, "expected the encoded certificates")
+}
@@ -4,10 +4,12 @@
 package api
 
 import (
+	"crypto/x509"
 	"time"
 
 	"github.com/absmach/magistrala/certs"
 	"github.com/absmach/magistrala/pkg/apiutil"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 )
 
 const maxLimitSize = 100
@@ -89,3 +91,38 @@ func (req *revokeReq) validate() error {
 
 	return nil
 }
+
+type enrollReq struct {
+	externalID  string
+	externalKey string
+	csr         *x509.CertificateRequest
+}
+
+func (req enrollReq) validate() error {
+	if req.externalID == "" || req.externalKey == "" {
+		return svcerr.ErrAuthentication
+	}
+
+	if req.csr == nil {
+		return certs.ErrInvalidCSR
+	}
+
+	return nil
+}
+
+type reenrollReq struct {
+	cert *x509.Certificate
+	csr  *x509.CertificateRequest
+}
+
+func (req reenrollReq) validate() error {
+	if req.cert == nil {
+		return svcerr.ErrAuthentication
+	}
+
+	if req.csr == nil {
+		return certs.ErrInvalidCSR
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@
 package api
 
 import (
+	"crypto/x509"
 	"net/http"
 	"time"
 )
@@ -29,6 +30,12 @@ type certsRes struct {
 	issued       bool
 }
 
+// estCertsRes holds the certificates returned from EST endpoints, which are
+// encoded as a certs-only PKCS#7 structure instead of JSON.
+type estCertsRes struct {
+	certs []*x509.Certificate
+}
+
 type revokeCertsRes struct {
 	RevocationTime time.Time `json:"revocation_time"`
 }
@@ -5,9 +5,14 @@ package api
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
+	"io"
 	"log/slog"
+	"mime"
 	"net/http"
+	"strings"
 
 	"github.com/absmach/magistrala"
 	"github.com/absmach/magistrala/certs"
@@ -15,6 +20,7 @@ import (
 	"github.com/absmach/magistrala/pkg/apiutil"
 	mgauthn "github.com/absmach/magistrala/pkg/authn"
 	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/go-chi/chi/v5"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -23,6 +29,9 @@ import (
 
 const (
 	contentType = "application/json"
+	csrType     = "application/pkcs10"
+	certsType   = "application/pkcs7-mime; smime-type=certs-only"
+	maxCSRSize  = 64 * 1024
 	offsetKey   = "offset"
 	limitKey    = "limit"
 	revokeKey   = "revoked"
@@ -70,6 +79,30 @@ func MakeHandler(svc certs.Service, authn mgauthn.Authentication, logger *slog.L
 			), "list_serials").ServeHTTP)
 		})
 	})
+	estOpts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeESTError)),
+	}
+	r.Route("/.well-known/est", func(r chi.Router) {
+		r.Get("/cacerts", otelhttp.NewHandler(kithttp.NewServer(
+			caCerts(svc),
+			kithttp.NopRequestDecoder,
+			encodeESTResponse,
+			estOpts...,
+		), "est_cacerts").ServeHTTP)
+		r.Post("/simpleenroll", otelhttp.NewHandler(kithttp.NewServer(
+			enroll(svc),
+			decodeEnroll,
+			encodeESTResponse,
+			estOpts...,
+		), "est_simpleenroll").ServeHTTP)
+		r.Post("/simplereenroll", otelhttp.NewHandler(kithttp.NewServer(
+			reenroll(svc),
+			decodeReenroll,
+			encodeESTResponse,
+			estOpts...,
+		), "est_simplereenroll").ServeHTTP)
+	})
+
 	r.Handle("/metrics", promhttp.Handler())
 	r.Get("/health", magistrala.Health("certs", instanceID))
 
@@ -134,3 +167,79 @@ func decodeRevokeCerts(_ context.Context, r *http.Request) (interface{}, error)
 
 	return req, nil
 }
+
+func decodeEnroll(_ context.Context, r *http.Request) (interface{}, error) {
+	csr, err := decodeCSR(r)
+	if err != nil {
+		return nil, err
+	}
+
+	id, key, _ := r.BasicAuth()
+	req := enrollReq{
+		externalID:  id,
+		externalKey: key,
+		csr:         csr,
+	}
+
+	return req, nil
+}
+
+func decodeReenroll(_ context.Context, r *http.Request) (interface{}, error) {
+	csr, err := decodeCSR(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := reenrollReq{csr: csr}
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		req.cert = r.TLS.PeerCertificates[0]
+	}
+
+	return req, nil
+}
+
+// decodeCSR reads the base64 encoded PKCS#10 request from the body, as
+// specified in RFC 7030, section 4.2.1.
+func decodeCSR(r *http.Request) (*x509.CertificateRequest, error) {
+	if ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); ct != csrType {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxCSRSize))
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(certs.ErrInvalidCSR, err))
+	}
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(string(body)), ""))
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(certs.ErrInvalidCSR, err))
+	}
+	csr, err := x509.ParseCertificateRequest(der)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(certs.ErrInvalidCSR, err))
+	}
+
+	return csr, nil
+}
+
+func encodeESTResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res := response.(estCertsRes)
+	b, err := certsOnly(res.certs)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", certsType)
+	w.Header().Set("Content-Transfer-Encoding", "base64")
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write([]byte(base64.StdEncoding.EncodeToString(b)))
+
+	return err
+}
+
+func encodeESTError(ctx context.Context, err error, w http.ResponseWriter) {
+	if errors.Contains(err, svcerr.ErrAuthentication) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="est"`)
+	}
+
+	api.EncodeError(ctx, err, w)
+}
@@ -4,6 +4,7 @@
 package certs
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
@@ -39,6 +40,33 @@ type PageMetadata struct {
 	Revoked    string `json:"revoked,omitempty"`
 }
 
+// EnrollmentConfig contains the CA used to sign certificates requested by
+// devices over EST, and the validity of the certificates it issues.
+type EnrollmentConfig struct {
+	CA     tls.Certificate
+	CACert *x509.Certificate
+	TTL    time.Duration
+}
+
+// Repository specifies the persistence API for certificates signed by the
+// service from device generated certificate signing requests.
+//
+//go:generate mockery --name Repository --output=./mocks --filename repository.go --quiet --note "Copyright (c) Abstract Machines"
+type Repository interface {
+	// Save persists the issued certificate.
+	Save(ctx context.Context, cert Cert) error
+
+	// RetrieveAll retrieves a page of certificates issued for the thing.
+	RetrieveAll(ctx context.Context, thingID string, pm PageMetadata) (CertPage, error)
+
+	// RetrieveBySerial retrieves the certificate with the given serial number.
+	RetrieveBySerial(ctx context.Context, serial string) (Cert, error)
+
+	// Revoke revokes all certificates issued for the thing and returns the
+	// number of revoked certificates.
+	Revoke(ctx context.Context, thingID string) (uint64, error)
+}
+
 var ErrMissingCerts = errors.New("CA path or CA key path not set")
 
 func LoadCertificates(caPath, caKeyPath string) (tls.Certificate, *x509.Certificate, error) {
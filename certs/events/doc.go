@@ -1,6 +1,6 @@
 // Copyright (c) Abstract Machines
 // SPDX-License-Identifier: Apache-2.0
 
-// Package events provides the domain concept definitions needed to support
-// mqtt events functionality.
+// Package events provides the domain concept definitions needed to
+// support Magistrala certs service functionality.
 package events
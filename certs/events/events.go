@@ -0,0 +1,62 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"time"
+
+	"github.com/absmach/magistrala/certs"
+	"github.com/absmach/magistrala/pkg/events"
+)
+
+const (
+	certPrefix = "certificate."
+	certIssue  = certPrefix + "issue"
+	certRevoke = certPrefix + "revoke"
+)
+
+var (
+	_ events.Event = (*issueCertEvent)(nil)
+	_ events.Event = (*revokeCertEvent)(nil)
+)
+
+type issueCertEvent struct {
+	certs.Cert
+	domainID string
+}
+
+func (ice issueCertEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation":     certIssue,
+		"serial_number": ice.SerialNumber,
+		"thing_id":      ice.ThingID,
+		"domain_id":     ice.domainID,
+	}
+
+	if !ice.ExpiryTime.IsZero() {
+		val["expiry_time"] = ice.ExpiryTime
+	}
+
+	return val, nil
+}
+
+type revokeCertEvent struct {
+	thingID        string
+	domainID       string
+	revocationTime time.Time
+}
+
+func (rce revokeCertEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation": certRevoke,
+		"thing_id":  rce.thingID,
+		"domain_id": rce.domainID,
+	}
+
+	if !rce.revocationTime.IsZero() {
+		val["revocation_time"] = rce.revocationTime
+	}
+
+	return val, nil
+}
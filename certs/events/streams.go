@@ -0,0 +1,102 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"crypto/x509"
+	"math/big"
+
+	"github.com/absmach/magistrala/certs"
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/events/store"
+)
+
+const streamID = "magistrala.certs"
+
+var _ certs.Service = (*eventStore)(nil)
+
+type eventStore struct {
+	events.Publisher
+	svc certs.Service
+}
+
+// NewEventStoreMiddleware returns wrapper around certs service that sends
+// events to event store.
+func NewEventStoreMiddleware(ctx context.Context, svc certs.Service, url string) (certs.Service, error) {
+	publisher, err := store.NewPublisher(ctx, url, streamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &eventStore{
+		svc:       svc,
+		Publisher: publisher,
+	}, nil
+}
+
+func (es *eventStore) IssueCert(ctx context.Context, domainID, token, thingID, ttl string) (certs.Cert, error) {
+	cert, err := es.svc.IssueCert(ctx, domainID, token, thingID, ttl)
+	if err != nil {
+		return cert, err
+	}
+
+	event := issueCertEvent{
+		Cert:     cert,
+		domainID: domainID,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return cert, err
+	}
+
+	return cert, nil
+}
+
+func (es *eventStore) RevokeCert(ctx context.Context, domainID, token, thingID string) (certs.Revoke, error) {
+	revoke, err := es.svc.RevokeCert(ctx, domainID, token, thingID)
+	if err != nil {
+		return revoke, err
+	}
+
+	event := revokeCertEvent{
+		thingID:        thingID,
+		domainID:       domainID,
+		revocationTime: revoke.RevocationTime,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return revoke, err
+	}
+
+	return revoke, nil
+}
+
+func (es *eventStore) ListCerts(ctx context.Context, thingID string, pm certs.PageMetadata) (certs.CertPage, error) {
+	return es.svc.ListCerts(ctx, thingID, pm)
+}
+
+func (es *eventStore) ListSerials(ctx context.Context, thingID string, pm certs.PageMetadata) (certs.CertPage, error) {
+	return es.svc.ListSerials(ctx, thingID, pm)
+}
+
+func (es *eventStore) ViewCert(ctx context.Context, serialID string) (certs.Cert, error) {
+	return es.svc.ViewCert(ctx, serialID)
+}
+
+func (es *eventStore) RetrieveCert(ctx context.Context, serialNumber *big.Int) (certs.Cert, error) {
+	return es.svc.RetrieveCert(ctx, serialNumber)
+}
+
+func (es *eventStore) CACerts(ctx context.Context) ([]*x509.Certificate, error) {
+	return es.svc.CACerts(ctx)
+}
+
+func (es *eventStore) Enroll(ctx context.Context, externalID, externalKey string, csr *x509.CertificateRequest) (certs.Cert, error) {
+	return es.svc.Enroll(ctx, externalID, externalKey, csr)
+}
+
+func (es *eventStore) Reenroll(ctx context.Context, cert *x509.Certificate, csr *x509.CertificateRequest) (certs.Cert, error) {
+	return es.svc.Reenroll(ctx, cert, csr)
+}
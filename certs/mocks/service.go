@@ -6,10 +6,13 @@ package mocks
 
 import (
 	context "context"
+	big "math/big"
 
 	certs "github.com/absmach/magistrala/certs"
 
 	mock "github.com/stretchr/testify/mock"
+
+	x509 "crypto/x509"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -17,6 +20,64 @@ type Service struct {
 	mock.Mock
 }
 
+// CACerts provides a mock function with given fields: ctx
+func (_m *Service) CACerts(ctx context.Context) ([]*x509.Certificate, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CACerts")
+	}
+
+	var r0 []*x509.Certificate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*x509.Certificate, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*x509.Certificate); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*x509.Certificate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Enroll provides a mock function with given fields: ctx, externalID, externalKey, csr
+func (_m *Service) Enroll(ctx context.Context, externalID string, externalKey string, csr *x509.CertificateRequest) (certs.Cert, error) {
+	ret := _m.Called(ctx, externalID, externalKey, csr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enroll")
+	}
+
+	var r0 certs.Cert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *x509.CertificateRequest) (certs.Cert, error)); ok {
+		return rf(ctx, externalID, externalKey, csr)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *x509.CertificateRequest) certs.Cert); ok {
+		r0 = rf(ctx, externalID, externalKey, csr)
+	} else {
+		r0 = ret.Get(0).(certs.Cert)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *x509.CertificateRequest) error); ok {
+		r1 = rf(ctx, externalID, externalKey, csr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IssueCert provides a mock function with given fields: ctx, domainID, token, thingID, ttl
 func (_m *Service) IssueCert(ctx context.Context, domainID string, token string, thingID string, ttl string) (certs.Cert, error) {
 	ret := _m.Called(ctx, domainID, token, thingID, ttl)
@@ -101,6 +162,62 @@ func (_m *Service) ListSerials(ctx context.Context, thingID string, pm certs.Pag
 	return r0, r1
 }
 
+// Reenroll provides a mock function with given fields: ctx, cert, csr
+func (_m *Service) Reenroll(ctx context.Context, cert *x509.Certificate, csr *x509.CertificateRequest) (certs.Cert, error) {
+	ret := _m.Called(ctx, cert, csr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reenroll")
+	}
+
+	var r0 certs.Cert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *x509.Certificate, *x509.CertificateRequest) (certs.Cert, error)); ok {
+		return rf(ctx, cert, csr)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *x509.Certificate, *x509.CertificateRequest) certs.Cert); ok {
+		r0 = rf(ctx, cert, csr)
+	} else {
+		r0 = ret.Get(0).(certs.Cert)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *x509.Certificate, *x509.CertificateRequest) error); ok {
+		r1 = rf(ctx, cert, csr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveCert provides a mock function with given fields: ctx, serialNumber
+func (_m *Service) RetrieveCert(ctx context.Context, serialNumber *big.Int) (certs.Cert, error) {
+	ret := _m.Called(ctx, serialNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveCert")
+	}
+
+	var r0 certs.Cert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *big.Int) (certs.Cert, error)); ok {
+		return rf(ctx, serialNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *big.Int) certs.Cert); ok {
+		r0 = rf(ctx, serialNumber)
+	} else {
+		r0 = ret.Get(0).(certs.Cert)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *big.Int) error); ok {
+		r1 = rf(ctx, serialNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RevokeCert provides a mock function with given fields: ctx, domainID, token, thingID
 func (_m *Service) RevokeCert(ctx context.Context, domainID string, token string, thingID string) (certs.Revoke, error) {
 	ret := _m.Called(ctx, domainID, token, thingID)
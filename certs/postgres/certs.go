@@ -0,0 +1,149 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/absmach/magistrala/certs"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+)
+
+const certsColumns = `serial_number, thing_id, certificate, revoked, expiry_time`
+
+var _ certs.Repository = (*certsRepository)(nil)
+
+type certsRepository struct {
+	db postgres.Database
+}
+
+// NewRepository instantiates a PostgreSQL implementation of certs
+// repository.
+func NewRepository(db postgres.Database) certs.Repository {
+	return &certsRepository{db: db}
+}
+
+func (repo *certsRepository) Save(ctx context.Context, cert certs.Cert) error {
+	q := fmt.Sprintf(`INSERT INTO certs (%s)
+		VALUES (:serial_number, :thing_id, :certificate, :revoked, :expiry_time);`, certsColumns)
+
+	if _, err := repo.db.NamedExecContext(ctx, q, toDBCert(cert)); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *certsRepository) RetrieveAll(ctx context.Context, thingID string, pm certs.PageMetadata) (certs.CertPage, error) {
+	where := "WHERE thing_id = :thing_id"
+	switch pm.Revoked {
+	case "true":
+		where += " AND revoked = TRUE"
+	case "false":
+		where += " AND revoked = FALSE"
+	}
+	q := fmt.Sprintf(`SELECT %s FROM certs %s ORDER BY expiry_time LIMIT :limit OFFSET :offset;`, certsColumns, where)
+
+	params := map[string]interface{}{
+		"thing_id": thingID,
+		"limit":    pm.Limit,
+		"offset":   pm.Offset,
+	}
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return certs.CertPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var items []certs.Cert
+	for rows.Next() {
+		var dbc dbCert
+		if err := rows.StructScan(&dbc); err != nil {
+			return certs.CertPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		items = append(items, toCert(dbc))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM certs %s;`, where)
+	total, err := postgres.Total(ctx, repo.db, cq, params)
+	if err != nil {
+		return certs.CertPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return certs.CertPage{
+		Total:        total,
+		Offset:       pm.Offset,
+		Limit:        pm.Limit,
+		Certificates: items,
+	}, nil
+}
+
+func (repo *certsRepository) RetrieveBySerial(ctx context.Context, serial string) (certs.Cert, error) {
+	q := fmt.Sprintf(`SELECT %s FROM certs WHERE serial_number = :serial_number;`, certsColumns)
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbCert{SerialNumber: serial})
+	if err != nil {
+		return certs.Cert{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return certs.Cert{}, errors.Wrap(repoerr.ErrNotFound, sql.ErrNoRows)
+	}
+	var dbc dbCert
+	if err := rows.StructScan(&dbc); err != nil {
+		return certs.Cert{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return toCert(dbc), nil
+}
+
+func (repo *certsRepository) Revoke(ctx context.Context, thingID string) (uint64, error) {
+	q := `UPDATE certs SET revoked = TRUE WHERE thing_id = :thing_id AND revoked = FALSE;`
+
+	res, err := repo.db.NamedExecContext(ctx, q, dbCert{ThingID: thingID})
+	if err != nil {
+		return 0, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return 0, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	return uint64(cnt), nil
+}
+
+type dbCert struct {
+	SerialNumber string    `db:"serial_number"`
+	ThingID      string    `db:"thing_id"`
+	Certificate  string    `db:"certificate"`
+	Revoked      bool      `db:"revoked"`
+	ExpiryTime   time.Time `db:"expiry_time"`
+}
+
+func toDBCert(c certs.Cert) dbCert {
+	return dbCert{
+		SerialNumber: c.SerialNumber,
+		ThingID:      c.ThingID,
+		Certificate:  c.Certificate,
+		Revoked:      c.Revoked,
+		ExpiryTime:   c.ExpiryTime.UTC(),
+	}
+}
+
+func toCert(dbc dbCert) certs.Cert {
+	return certs.Cert{
+		SerialNumber: dbc.SerialNumber,
+		ThingID:      dbc.ThingID,
+		Certificate:  dbc.Certificate,
+		Revoked:      dbc.Revoked,
+		ExpiryTime:   dbc.ExpiryTime,
+	}
+}
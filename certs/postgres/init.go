@@ -0,0 +1,33 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Migration of certs service.
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "certs_01",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS certs (
+						serial_number	VARCHAR(40) PRIMARY KEY,
+						thing_id		VARCHAR(36) NOT NULL,
+						certificate		TEXT NOT NULL,
+						revoked			BOOLEAN NOT NULL DEFAULT FALSE,
+						expiry_time		TIMESTAMP NOT NULL
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_certs_thing ON certs(thing_id, expiry_time)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS certs`,
+				},
+			},
+		},
+	}
+}
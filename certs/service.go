@@ -5,11 +5,18 @@ package certs
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/absmach/certs/sdk"
 	pki "github.com/absmach/magistrala/certs/pki/amcerts"
 	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
 )
@@ -24,8 +31,16 @@ var (
 	ErrFailedToRemoveCertFromDB = errors.New("failed to remove cert serial from db")
 
 	ErrFailedReadFromPKI = errors.New("failed to read certificate from PKI")
+
+	// ErrInvalidCSR indicates a malformed or improperly signed certificate signing request.
+	ErrInvalidCSR = errors.New("invalid certificate signing request")
+
+	// ErrEnrollmentDisabled indicates that no signing CA is configured for enrollment.
+	ErrEnrollmentDisabled = errors.New("certificate enrollment is not configured")
 )
 
+const serialBits = 128
+
 var _ Service = (*certsService)(nil)
 
 // Service specifies an API that must be fulfilled by the domain service
@@ -45,20 +60,37 @@ type Service interface {
 	// ViewCert retrieves the certificate issued for a given serial ID
 	ViewCert(ctx context.Context, serialID string) (Cert, error)
 
+	// RetrieveCert retrieves the certificate, issued by the PKI or enrolled over
+	// EST, with the given serial number. The private key is not returned.
+	RetrieveCert(ctx context.Context, serialNumber *big.Int) (Cert, error)
+
 	// RevokeCert revokes a certificate for a given thing ID
 	RevokeCert(ctx context.Context, domainID, token, thingID string) (Revoke, error)
+
+	// CACerts returns the CA certificates used to sign enrolled certificates.
+	CACerts(ctx context.Context) ([]*x509.Certificate, error)
+
+	// Enroll signs the CSR for the thing bootstrapped with the given external ID and key.
+	Enroll(ctx context.Context, externalID, externalKey string, csr *x509.CertificateRequest) (Cert, error)
+
+	// Reenroll signs the CSR for the thing which owns the given, previously enrolled, certificate.
+	Reenroll(ctx context.Context, cert *x509.Certificate, csr *x509.CertificateRequest) (Cert, error)
 }
 
 type certsService struct {
-	sdk mgsdk.SDK
-	pki pki.Agent
+	sdk        mgsdk.SDK
+	pki        pki.Agent
+	repo       Repository
+	enrollment EnrollmentConfig
 }
 
 // New returns new Certs service.
-func New(sdk mgsdk.SDK, pkiAgent pki.Agent) Service {
+func New(sdk mgsdk.SDK, pkiAgent pki.Agent, repo Repository, enrollment EnrollmentConfig) Service {
 	return &certsService{
-		sdk: sdk,
-		pki: pkiAgent,
+		sdk:        sdk,
+		pki:        pkiAgent,
+		repo:       repo,
+		enrollment: enrollment,
 	}
 }
 
@@ -112,6 +144,14 @@ func (cs *certsService) RevokeCert(ctx context.Context, domainID, token, thingID
 		revoke.RevocationTime = time.Now()
 	}
 
+	n, err := cs.repo.Revoke(ctx, thing.ID)
+	if err != nil {
+		return revoke, errors.Wrap(ErrFailedCertRevocation, err)
+	}
+	if n > 0 {
+		revoke.RevocationTime = time.Now()
+	}
+
 	return revoke, nil
 }
 
@@ -134,8 +174,13 @@ func (cs *certsService) ListCerts(ctx context.Context, thingID string, pm PageMe
 		})
 	}
 
+	crts, total, err := cs.appendEnrolled(ctx, thingID, pm, cp.Total, crts)
+	if err != nil {
+		return CertPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
 	return CertPage{
-		Total:        cp.Total,
+		Total:        cp.Total + total,
 		Limit:        cp.Limit,
 		Offset:       cp.Offset,
 		Certificates: crts,
@@ -160,15 +205,32 @@ func (cs *certsService) ListSerials(ctx context.Context, thingID string, pm Page
 		}
 	}
 
+	issued := uint64(len(certs))
+	certs, total, err := cs.appendEnrolled(ctx, thingID, pm, cp.Total, certs)
+	if err != nil {
+		return CertPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	for i := range certs {
+		certs[i].Certificate = ""
+	}
+
 	return CertPage{
 		Offset:       cp.Offset,
 		Limit:        cp.Limit,
-		Total:        uint64(len(certs)),
+		Total:        issued + total,
 		Certificates: certs,
 	}, nil
 }
 
 func (cs *certsService) ViewCert(ctx context.Context, serialID string) (Cert, error) {
+	enrolled, err := cs.repo.RetrieveBySerial(ctx, serialID)
+	switch {
+	case err == nil:
+		return enrolled, nil
+	case !errors.Contains(err, repoerr.ErrNotFound):
+		return Cert{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
 	cert, err := cs.pki.View(serialID)
 	if err != nil {
 		return Cert{}, errors.Wrap(ErrFailedReadFromPKI, err)
@@ -183,3 +245,146 @@ func (cs *certsService) ViewCert(ctx context.Context, serialID string) (Cert, er
 		ThingID:      cert.ThingID,
 	}, nil
 }
+
+func (cs *certsService) RetrieveCert(ctx context.Context, serialNumber *big.Int) (Cert, error) {
+	enrolled, err := cs.repo.RetrieveBySerial(ctx, serial(serialNumber))
+	switch {
+	case err == nil:
+		enrolled.Key = ""
+		return enrolled, nil
+	case !errors.Contains(err, repoerr.ErrNotFound):
+		return Cert{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	// The PKI identifies the certificates by the decimal serial number.
+	cert, err := cs.pki.View(serialNumber.String())
+	if err != nil {
+		return Cert{}, errors.Wrap(ErrFailedReadFromPKI, err)
+	}
+
+	return Cert{
+		SerialNumber: cert.SerialNumber,
+		Certificate:  cert.Certificate,
+		Revoked:      cert.Revoked,
+		ExpiryTime:   cert.ExpiryTime,
+		ThingID:      cert.ThingID,
+	}, nil
+}
+
+func (cs *certsService) CACerts(ctx context.Context) ([]*x509.Certificate, error) {
+	if cs.enrollment.CACert == nil {
+		return nil, ErrEnrollmentDisabled
+	}
+
+	return []*x509.Certificate{cs.enrollment.CACert}, nil
+}
+
+func (cs *certsService) Enroll(ctx context.Context, externalID, externalKey string, csr *x509.CertificateRequest) (Cert, error) {
+	if cs.enrollment.CACert == nil {
+		return Cert{}, ErrEnrollmentDisabled
+	}
+
+	cfg, sdkErr := cs.sdk.Bootstrap(externalID, externalKey)
+	if sdkErr != nil {
+		return Cert{}, errors.Wrap(svcerr.ErrAuthentication, sdkErr)
+	}
+
+	return cs.sign(ctx, cfg.ThingID, csr)
+}
+
+func (cs *certsService) Reenroll(ctx context.Context, cert *x509.Certificate, csr *x509.CertificateRequest) (Cert, error) {
+	if cs.enrollment.CACert == nil {
+		return Cert{}, ErrEnrollmentDisabled
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(cs.enrollment.CACert)
+	if _, err := cert.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}}); err != nil {
+		return Cert{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+
+	enrolled, err := cs.repo.RetrieveBySerial(ctx, serial(cert.SerialNumber))
+	if err != nil {
+		return Cert{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if enrolled.Revoked {
+		return Cert{}, errors.Wrap(svcerr.ErrAuthentication, ErrFailedCertRevocation)
+	}
+
+	// RFC 7030 requires the re-enrollment request to keep the subject of the current certificate.
+	if csr.Subject.CommonName != cert.Subject.CommonName {
+		return Cert{}, ErrInvalidCSR
+	}
+
+	return cs.sign(ctx, enrolled.ThingID, csr)
+}
+
+// appendEnrolled fills the rest of the PKI page with certificates enrolled
+// over EST, which are listed after the ones issued by the PKI.
+func (cs *certsService) appendEnrolled(ctx context.Context, thingID string, pm PageMetadata, pkiTotal uint64, crts []Cert) ([]Cert, uint64, error) {
+	var offset, limit uint64
+	if pm.Offset > pkiTotal {
+		offset = pm.Offset - pkiTotal
+	}
+	if n := uint64(len(crts)); n < pm.Limit {
+		limit = pm.Limit - n
+	}
+
+	page, err := cs.repo.RetrieveAll(ctx, thingID, PageMetadata{Offset: offset, Limit: limit, Revoked: pm.Revoked})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return append(crts, page.Certificates...), page.Total, nil
+}
+
+func (cs *certsService) sign(ctx context.Context, thingID string, csr *x509.CertificateRequest) (Cert, error) {
+	if err := csr.CheckSignature(); err != nil {
+		return Cert{}, errors.Wrap(ErrInvalidCSR, err)
+	}
+
+	sn, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), serialBits))
+	if err != nil {
+		return Cert{}, errors.Wrap(ErrFailedCertCreation, err)
+	}
+
+	now := time.Now()
+	notAfter := now.Add(cs.enrollment.TTL)
+	if notAfter.After(cs.enrollment.CACert.NotAfter) {
+		notAfter = cs.enrollment.CACert.NotAfter
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: sn,
+		Subject: pkix.Name{
+			CommonName:         thingID,
+			Organization:       csr.Subject.Organization,
+			OrganizationalUnit: csr.Subject.OrganizationalUnit,
+		},
+		NotBefore:   now,
+		NotAfter:    notAfter,
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, cs.enrollment.CACert, csr.PublicKey, cs.enrollment.CA.PrivateKey)
+	if err != nil {
+		return Cert{}, errors.Wrap(ErrFailedCertCreation, err)
+	}
+
+	cert := Cert{
+		SerialNumber: serial(sn),
+		Certificate:  string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		ExpiryTime:   notAfter,
+		ThingID:      thingID,
+	}
+	if err := cs.repo.Save(ctx, cert); err != nil {
+		return Cert{}, errors.Wrap(ErrFailedCertCreation, err)
+	}
+
+	return cert, nil
+}
+
+func serial(sn *big.Int) string {
+	return fmt.Sprintf("%x", sn)
+}
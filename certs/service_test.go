@@ -5,7 +5,15 @@ package certs_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"testing"
 	"time"
 
@@ -13,6 +21,7 @@ import (
 	"github.com/absmach/magistrala/certs/mocks"
 	mgcrt "github.com/absmach/magistrala/certs/pki/amcerts"
 	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
 	sdkmocks "github.com/absmach/magistrala/pkg/sdk/mocks"
@@ -33,11 +42,60 @@ const (
 	validID   = "d4ebb847-5d0e-4e46-bdd9-b6aceaaa3a22"
 )
 
-func newService(_ *testing.T) (certs.Service, *mocks.Agent, *sdkmocks.SDK) {
+func newService(_ *testing.T) (certs.Service, *mocks.Agent, *sdkmocks.SDK, *mocks.Repository) {
 	agent := new(mocks.Agent)
 	sdk := new(sdkmocks.SDK)
+	repo := new(mocks.Repository)
 
-	return certs.New(sdk, agent), agent, sdk
+	return certs.New(sdk, agent, repo, certs.EnrollmentConfig{}), agent, sdk, repo
+}
+
+func newEnrollmentService(t *testing.T) (certs.Service, *sdkmocks.SDK, *mocks.Repository, certs.EnrollmentConfig) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err, fmt.Sprintf("generating CA key expected to succeed: %s", err))
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	assert.Nil(t, err, fmt.Sprintf("creating CA certificate expected to succeed: %s", err))
+	caCert, err := x509.ParseCertificate(der)
+	assert.Nil(t, err, fmt.Sprintf("parsing CA certificate expected to succeed: %s", err))
+
+	enrollment := certs.EnrollmentConfig{
+		CA:     tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key},
+		CACert: caCert,
+		TTL:    time.Hour,
+	}
+	sdk := new(sdkmocks.SDK)
+	repo := new(mocks.Repository)
+
+	return certs.New(sdk, new(mocks.Agent), repo, enrollment), sdk, repo, enrollment
+}
+
+func newCSR(t *testing.T, cn string) *x509.CertificateRequest {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err, fmt.Sprintf("generating key expected to succeed: %s", err))
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: cn}}, key)
+	assert.Nil(t, err, fmt.Sprintf("creating CSR expected to succeed: %s", err))
+	csr, err := x509.ParseCertificateRequest(der)
+	assert.Nil(t, err, fmt.Sprintf("parsing CSR expected to succeed: %s", err))
+
+	return csr
+}
+
+func parseCert(t *testing.T, c certs.Cert) *x509.Certificate {
+	block, _ := pem.Decode([]byte(c.Certificate))
+	assert.NotNil(t, block, "decoding certificate PEM expected to succeed")
+	crt, err := x509.ParseCertificate(block.Bytes)
+	assert.Nil(t, err, fmt.Sprintf("parsing certificate expected to succeed: %s", err))
+
+	return crt
 }
 
 var cert = mgcrt.Cert{
@@ -48,7 +106,7 @@ var cert = mgcrt.Cert{
 }
 
 func TestIssueCert(t *testing.T) {
-	svc, agent, sdk := newService(t)
+	svc, agent, sdk, _ := newService(t)
 	cases := []struct {
 		domainID     string
 		token        string
@@ -118,18 +176,20 @@ func TestIssueCert(t *testing.T) {
 }
 
 func TestRevokeCert(t *testing.T) {
-	svc, agent, sdk := newService(t)
+	svc, agent, sdk, repo := newService(t)
 	cases := []struct {
-		domainID  string
-		token     string
-		desc      string
-		thingID   string
-		page      mgcrt.CertPage
-		authErr   error
-		thingErr  errors.SDKError
-		revokeErr error
-		listErr   error
-		err       error
+		domainID    string
+		token       string
+		desc        string
+		thingID     string
+		page        mgcrt.CertPage
+		authErr     error
+		thingErr    errors.SDKError
+		revokeErr   error
+		listErr     error
+		repoRevoked uint64
+		repoErr     error
+		err         error
 	}{
 		{
 			desc:     "revoke cert",
@@ -156,6 +216,23 @@ func TestRevokeCert(t *testing.T) {
 			thingErr: errors.NewSDKError(certs.ErrFailedCertCreation),
 			err:      certs.ErrFailedCertRevocation,
 		},
+		{
+			desc:        "revoke cert with enrolled certs",
+			domainID:    domain,
+			token:       token,
+			thingID:     thingID,
+			page:        mgcrt.CertPage{},
+			repoRevoked: 1,
+		},
+		{
+			desc:     "revoke cert with failed to revoke enrolled certs",
+			domainID: domain,
+			token:    token,
+			thingID:  thingID,
+			page:     mgcrt.CertPage{Limit: 10000, Offset: 0, Total: 1, Certificates: []mgcrt.Cert{cert}},
+			repoErr:  repoerr.ErrUpdateEntity,
+			err:      certs.ErrFailedCertRevocation,
+		},
 		{
 			desc:     "revoke cert with failed to list certs",
 			domainID: domain,
@@ -172,17 +249,19 @@ func TestRevokeCert(t *testing.T) {
 			sdkCall := sdk.On("Thing", tc.thingID, tc.domainID, tc.token).Return(mgsdk.Thing{ID: tc.thingID, Credentials: mgsdk.ClientCredentials{Secret: thingKey}}, tc.thingErr)
 			agentCall := agent.On("Revoke", mock.Anything).Return(tc.revokeErr)
 			agentCall1 := agent.On("ListCerts", mock.Anything).Return(tc.page, tc.listErr)
+			repoCall := repo.On("Revoke", mock.Anything, tc.thingID).Return(tc.repoRevoked, tc.repoErr)
 			_, err := svc.RevokeCert(context.Background(), tc.domainID, tc.token, tc.thingID)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 			sdkCall.Unset()
 			agentCall.Unset()
 			agentCall1.Unset()
+			repoCall.Unset()
 		})
 	}
 }
 
 func TestListCerts(t *testing.T) {
-	svc, agent, _ := newService(t)
+	svc, agent, _, repo := newService(t)
 	var mycerts []mgcrt.Cert
 	for i := 0; i < certNum; i++ {
 		c := mgcrt.Cert{
@@ -194,11 +273,13 @@ func TestListCerts(t *testing.T) {
 	}
 
 	cases := []struct {
-		desc    string
-		thingID string
-		page    mgcrt.CertPage
-		listErr error
-		err     error
+		desc     string
+		thingID  string
+		page     mgcrt.CertPage
+		enrolled certs.CertPage
+		listErr  error
+		repoErr  error
+		err      error
 	}{
 		{
 			desc:    "list all certs successfully",
@@ -222,22 +303,37 @@ func TestListCerts(t *testing.T) {
 			thingID: thingID,
 			page:    mgcrt.CertPage{Limit: certNum, Offset: certNum - 1, Total: 1, Certificates: []mgcrt.Cert{mycerts[certNum-1]}},
 		},
+		{
+			desc:     "list certs with enrolled certs successfully",
+			thingID:  thingID,
+			page:     mgcrt.CertPage{Limit: certNum, Offset: certNum / 2, Total: certNum / 2, Certificates: mycerts[certNum/2:]},
+			enrolled: certs.CertPage{Total: 1, Certificates: []certs.Cert{{ThingID: thingID, SerialNumber: "enrolled"}}},
+		},
+		{
+			desc:    "list certs with failed repository",
+			thingID: thingID,
+			page:    mgcrt.CertPage{},
+			repoErr: repoerr.ErrViewEntity,
+			err:     svcerr.ErrViewEntity,
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			agentCall := agent.On("ListCerts", mock.Anything).Return(tc.page, tc.listErr)
+			repoCall := repo.On("RetrieveAll", mock.Anything, tc.thingID, mock.Anything).Return(tc.enrolled, tc.repoErr)
 			page, err := svc.ListCerts(context.Background(), tc.thingID, certs.PageMetadata{Offset: tc.page.Offset, Limit: tc.page.Limit})
 			size := uint64(len(page.Certificates))
-			assert.Equal(t, tc.page.Total, size, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.page.Total, size))
+			assert.Equal(t, tc.page.Total+tc.enrolled.Total, size, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.page.Total+tc.enrolled.Total, size))
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 			agentCall.Unset()
+			repoCall.Unset()
 		})
 	}
 }
 
 func TestListSerials(t *testing.T) {
-	svc, agent, _ := newService(t)
+	svc, agent, _, repo := newService(t)
 	revoke := "false"
 
 	var issuedCerts []mgcrt.Cert
@@ -300,21 +396,24 @@ func TestListSerials(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			agentCall := agent.On("ListCerts", mock.Anything).Return(mgcrt.CertPage{Certificates: tc.certs}, tc.listErr)
+			repoCall := repo.On("RetrieveAll", mock.Anything, tc.thingID, mock.Anything).Return(certs.CertPage{}, nil)
 			page, err := svc.ListSerials(context.Background(), tc.thingID, certs.PageMetadata{Revoked: tc.revoke, Offset: tc.offset, Limit: tc.limit})
 			assert.Equal(t, len(tc.certs), len(page.Certificates), fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.certs, page.Certificates))
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 			agentCall.Unset()
+			repoCall.Unset()
 		})
 	}
 }
 
 func TestViewCert(t *testing.T) {
-	svc, agent, _ := newService(t)
+	svc, agent, _, repo := newService(t)
 
 	cases := []struct {
 		desc     string
 		serialID string
 		cert     mgcrt.Cert
+		enrolled certs.Cert
 		repoErr  error
 		agentErr error
 		err      error
@@ -331,15 +430,247 @@ func TestViewCert(t *testing.T) {
 			agentErr: svcerr.ErrNotFound,
 			err:      svcerr.ErrNotFound,
 		},
+		{
+			desc:     "view enrolled cert",
+			serialID: "enrolled",
+			enrolled: certs.Cert{SerialNumber: "enrolled", ThingID: thingID},
+			cert:     mgcrt.Cert{SerialNumber: "enrolled"},
+		},
+		{
+			desc:     "view cert with failed repository",
+			serialID: cert.SerialNumber,
+			cert:     mgcrt.Cert{},
+			repoErr:  repoerr.ErrViewEntity,
+			err:      svcerr.ErrViewEntity,
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
+			repoErr := tc.repoErr
+			if tc.enrolled.SerialNumber == "" && repoErr == nil {
+				repoErr = repoerr.ErrNotFound
+			}
+			repoCall := repo.On("RetrieveBySerial", mock.Anything, tc.serialID).Return(tc.enrolled, repoErr)
 			agentCall := agent.On("View", tc.serialID).Return(tc.cert, tc.agentErr)
 			res, err := svc.ViewCert(context.Background(), tc.serialID)
 			assert.Equal(t, tc.cert.SerialNumber, res.SerialNumber, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.cert.SerialNumber, res.SerialNumber))
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			repoCall.Unset()
 			agentCall.Unset()
 		})
 	}
 }
+
+func TestRetrieveCert(t *testing.T) {
+	svc, agent, _, repo := newService(t)
+
+	sn := big.NewInt(1234)
+
+	cases := []struct {
+		desc     string
+		cert     mgcrt.Cert
+		enrolled certs.Cert
+		repoErr  error
+		agentErr error
+		thingID  string
+		err      error
+	}{
+		{
+			desc:    "retrieve cert issued by the PKI",
+			cert:    mgcrt.Cert{SerialNumber: sn.String(), ThingID: thingID, Key: "key"},
+			repoErr: repoerr.ErrNotFound,
+			thingID: thingID,
+		},
+		{
+			desc:     "retrieve enrolled cert",
+			enrolled: certs.Cert{SerialNumber: fmt.Sprintf("%x", sn), ThingID: thingID},
+			thingID:  thingID,
+		},
+		{
+			desc:     "retrieve non-existing cert",
+			repoErr:  repoerr.ErrNotFound,
+			agentErr: svcerr.ErrNotFound,
+			err:      certs.ErrFailedReadFromPKI,
+		},
+		{
+			desc:    "retrieve cert with failed repository",
+			repoErr: repoerr.ErrViewEntity,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveBySerial", mock.Anything, fmt.Sprintf("%x", sn)).Return(tc.enrolled, tc.repoErr)
+			agentCall := agent.On("View", sn.String()).Return(tc.cert, tc.agentErr)
+			res, err := svc.RetrieveCert(context.Background(), sn)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.thingID, res.ThingID, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.thingID, res.ThingID))
+			assert.Empty(t, res.Key, fmt.Sprintf("%s: expected no private key\n", tc.desc))
+			repoCall.Unset()
+			agentCall.Unset()
+		})
+	}
+}
+
+func TestCACerts(t *testing.T) {
+	svc, _, _, enrollment := newEnrollmentService(t)
+	cas, err := svc.CACerts(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("retrieving CA certificates expected to succeed: %s", err))
+	assert.Equal(t, []*x509.Certificate{enrollment.CACert}, cas, "expected the enrollment CA certificate")
+
+	svc, _, _, _ = newService(t)
+	_, err = svc.CACerts(context.Background())
+	assert.True(t, errors.Contains(err, certs.ErrEnrollmentDisabled), fmt.Sprintf("expected %s got %s", certs.ErrEnrollmentDisabled, err))
+}
+
+func TestEnroll(t *testing.T) {
+	svc, sdk, repo, enrollment := newEnrollmentService(t)
+
+	csr := newCSR(t, "device")
+	invalidCSR := newCSR(t, "device")
+	invalidCSR.Signature[len(invalidCSR.Signature)-1] ^= 0xff
+
+	cases := []struct {
+		desc        string
+		externalID  string
+		externalKey string
+		csr         *x509.CertificateRequest
+		bootstrap   mgsdk.BootstrapConfig
+		sdkErr      errors.SDKError
+		saveErr     error
+		err         error
+	}{
+		{
+			desc:        "enroll successfully",
+			externalID:  validID,
+			externalKey: thingKey,
+			csr:         csr,
+			bootstrap:   mgsdk.BootstrapConfig{ThingID: thingID},
+		},
+		{
+			desc:        "enroll with invalid external key",
+			externalID:  validID,
+			externalKey: invalid,
+			csr:         csr,
+			sdkErr:      errors.NewSDKError(svcerr.ErrAuthentication),
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "enroll with invalid CSR signature",
+			externalID:  validID,
+			externalKey: thingKey,
+			csr:         invalidCSR,
+			bootstrap:   mgsdk.BootstrapConfig{ThingID: thingID},
+			err:         certs.ErrInvalidCSR,
+		},
+		{
+			desc:        "enroll with failed to save certificate",
+			externalID:  validID,
+			externalKey: thingKey,
+			csr:         csr,
+			bootstrap:   mgsdk.BootstrapConfig{ThingID: thingID},
+			saveErr:     repoerr.ErrCreateEntity,
+			err:         certs.ErrFailedCertCreation,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdk.On("Bootstrap", tc.externalID, tc.externalKey).Return(tc.bootstrap, tc.sdkErr)
+			repoCall := repo.On("Save", mock.Anything, mock.Anything).Return(tc.saveErr)
+			res, err := svc.Enroll(context.Background(), tc.externalID, tc.externalKey, tc.csr)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				crt := parseCert(t, res)
+				assert.Equal(t, thingID, crt.Subject.CommonName, fmt.Sprintf("%s: expected common name %s got %s\n", tc.desc, thingID, crt.Subject.CommonName))
+				assert.Equal(t, tc.csr.PublicKey, crt.PublicKey, fmt.Sprintf("%s: expected certificate for the CSR public key\n", tc.desc))
+				assert.Nil(t, crt.CheckSignatureFrom(enrollment.CACert), fmt.Sprintf("%s: expected certificate signed by the CA\n", tc.desc))
+				assert.Empty(t, res.Key, fmt.Sprintf("%s: expected no private key in the response\n", tc.desc))
+				assert.Equal(t, thingID, res.ThingID, fmt.Sprintf("%s: expected thing ID %s got %s\n", tc.desc, thingID, res.ThingID))
+			}
+			sdkCall.Unset()
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestReenroll(t *testing.T) {
+	svc, sdk, repo, _ := newEnrollmentService(t)
+	foreignSvc, foreignSDK, foreignRepo, _ := newEnrollmentService(t)
+
+	enroll := func(svc certs.Service, sdk *sdkmocks.SDK, repo *mocks.Repository) (certs.Cert, *x509.Certificate) {
+		sdkCall := sdk.On("Bootstrap", validID, thingKey).Return(mgsdk.BootstrapConfig{ThingID: thingID}, nil)
+		repoCall := repo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		defer sdkCall.Unset()
+		defer repoCall.Unset()
+		c, err := svc.Enroll(context.Background(), validID, thingKey, newCSR(t, "device"))
+		assert.Nil(t, err, fmt.Sprintf("enrolling certificate expected to succeed: %s", err))
+
+		return c, parseCert(t, c)
+	}
+	enrolled, current := enroll(svc, sdk, repo)
+	_, foreign := enroll(foreignSvc, foreignSDK, foreignRepo)
+	revoked := enrolled
+	revoked.Revoked = true
+
+	cases := []struct {
+		desc     string
+		cert     *x509.Certificate
+		csr      *x509.CertificateRequest
+		enrolled certs.Cert
+		repoErr  error
+		err      error
+	}{
+		{
+			desc:     "reenroll successfully",
+			cert:     current,
+			csr:      newCSR(t, thingID),
+			enrolled: enrolled,
+		},
+		{
+			desc:     "reenroll with certificate signed by another CA",
+			cert:     foreign,
+			csr:      newCSR(t, thingID),
+			enrolled: enrolled,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "reenroll with unknown certificate",
+			cert:    current,
+			csr:     newCSR(t, thingID),
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "reenroll with revoked certificate",
+			cert:     current,
+			csr:      newCSR(t, thingID),
+			enrolled: revoked,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "reenroll with changed subject",
+			cert:     current,
+			csr:      newCSR(t, "other"),
+			enrolled: enrolled,
+			err:      certs.ErrInvalidCSR,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveBySerial", mock.Anything, enrolled.SerialNumber).Return(tc.enrolled, tc.repoErr)
+			repoCall1 := repo.On("Save", mock.Anything, mock.Anything).Return(nil)
+			res, err := svc.Reenroll(context.Background(), tc.cert, tc.csr)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, thingID, res.ThingID, fmt.Sprintf("%s: expected thing ID %s got %s\n", tc.desc, thingID, res.ThingID))
+				assert.NotEqual(t, enrolled.SerialNumber, res.SerialNumber, fmt.Sprintf("%s: expected a new serial number\n", tc.desc))
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
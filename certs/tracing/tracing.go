@@ -5,6 +5,8 @@ package tracing
 
 import (
 	"context"
+	"crypto/x509"
+	"math/big"
 
 	"github.com/absmach/magistrala/certs"
 	"go.opentelemetry.io/otel/attribute"
@@ -68,6 +70,16 @@ func (tm *tracingMiddleware) ViewCert(ctx context.Context, serialID string) (cer
 	return tm.svc.ViewCert(ctx, serialID)
 }
 
+// RetrieveCert traces the "RetrieveCert" operation of the wrapped certs.Service.
+func (tm *tracingMiddleware) RetrieveCert(ctx context.Context, serialNumber *big.Int) (certs.Cert, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_retrieve_cert", trace.WithAttributes(
+		attribute.String("serial_number", serialNumber.String()),
+	))
+	defer span.End()
+
+	return tm.svc.RetrieveCert(ctx, serialNumber)
+}
+
 // RevokeCert traces the "RevokeCert" operation of the wrapped certs.Service.
 func (tm *tracingMiddleware) RevokeCert(ctx context.Context, domainID, token, serialID string) (certs.Revoke, error) {
 	ctx, span := tm.tracer.Start(ctx, "svc_revoke_cert", trace.WithAttributes(
@@ -77,3 +89,31 @@ func (tm *tracingMiddleware) RevokeCert(ctx context.Context, domainID, token, se
 
 	return tm.svc.RevokeCert(ctx, domainID, token, serialID)
 }
+
+// CACerts traces the "CACerts" operation of the wrapped certs.Service.
+func (tm *tracingMiddleware) CACerts(ctx context.Context) ([]*x509.Certificate, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_ca_certs")
+	defer span.End()
+
+	return tm.svc.CACerts(ctx)
+}
+
+// Enroll traces the "Enroll" operation of the wrapped certs.Service.
+func (tm *tracingMiddleware) Enroll(ctx context.Context, externalID, externalKey string, csr *x509.CertificateRequest) (certs.Cert, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_enroll", trace.WithAttributes(
+		attribute.String("external_id", externalID),
+	))
+	defer span.End()
+
+	return tm.svc.Enroll(ctx, externalID, externalKey, csr)
+}
+
+// Reenroll traces the "Reenroll" operation of the wrapped certs.Service.
+func (tm *tracingMiddleware) Reenroll(ctx context.Context, cert *x509.Certificate, csr *x509.CertificateRequest) (certs.Cert, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_reenroll", trace.WithAttributes(
+		attribute.String("serial_number", cert.SerialNumber.Text(16)),
+	))
+	defer span.End()
+
+	return tm.svc.Reenroll(ctx, cert, csr)
+}
@@ -5,6 +5,7 @@ package cli
 
 import (
 	"encoding/json"
+	"strconv"
 
 	mgxsdk "github.com/absmach/magistrala/pkg/sdk/go"
 	"github.com/spf13/cobra"
@@ -146,17 +147,33 @@ var cmdBootstrap = []cobra.Command{
 		},
 	},
 	{
-		Use:   "bootstrap [<external_id> <external_key> | secure <external_id> <external_key> <crypto_key> ]",
+		Use:   "bootstrap [<external_id> <external_key> | secure <external_id> <external_key> <crypto_key> [<key_version>] ]",
 		Short: "Bootstrap config",
 		Long: `Returns Config to the Thing with provided external ID using external key.
-				secure - Retrieves a configuration with given external ID and encrypted external key.`,
+				secure - Retrieves a configuration with given external ID and encrypted external key.
+				Without key version, crypto key is the deprecated service-wide encryption key.
+				Otherwise, crypto key is the base64 encoded device key of the given version,
+				returned when the config is created or viewed.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) < 2 {
 				logUsageCmd(*cmd, cmd.Use)
 				return
 			}
 			if args[0] == "secure" {
-				c, err := sdk.BootstrapSecure(args[1], args[2], args[3])
+				if len(args) < 4 || len(args) > 5 {
+					logUsageCmd(*cmd, cmd.Use)
+					return
+				}
+				var version uint64
+				if len(args) == 5 {
+					v, err := strconv.ParseUint(args[4], 10, 8)
+					if err != nil {
+						logErrorCmd(*cmd, err)
+						return
+					}
+					version = v
+				}
+				c, err := sdk.BootstrapSecure(args[1], args[2], args[3], uint8(version))
 				if err != nil {
 					logErrorCmd(*cmd, err)
 					return
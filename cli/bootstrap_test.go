@@ -559,6 +559,39 @@ func TestBootstrapConfigCmd(t *testing.T) {
 			boot:    bootConfig,
 			logType: entityLog,
 		},
+		{
+			desc: "bootstrap secure config with key version successfully",
+			args: []string{
+				"secure",
+				bootConfig.ExternalID,
+				bootConfig.ExternalKey,
+				crptoKey,
+				"1",
+			},
+			boot:    bootConfig,
+			logType: entityLog,
+		},
+		{
+			desc: "bootstrap secure config with invalid key version",
+			args: []string{
+				"secure",
+				bootConfig.ExternalID,
+				bootConfig.ExternalKey,
+				crptoKey,
+				"invalid",
+			},
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", `strconv.ParseUint: parsing "invalid": invalid syntax`),
+			logType:       errLog,
+		},
+		{
+			desc: "bootstrap secure config without crypto key",
+			args: []string{
+				"secure",
+				bootConfig.ExternalID,
+				bootConfig.ExternalKey,
+			},
+			logType: usageLog,
+		},
 		{
 			desc: "bootstrap config successfully",
 			args: []string{
@@ -602,7 +635,7 @@ func TestBootstrapConfigCmd(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			sdkCall := sdkMock.On("BootstrapSecure", mock.Anything, mock.Anything, mock.Anything).Return(tc.boot, tc.sdkErr)
+			sdkCall := sdkMock.On("BootstrapSecure", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.boot, tc.sdkErr)
 			sdkCall1 := sdkMock.On("Bootstrap", mock.Anything, mock.Anything).Return(tc.boot, tc.sdkErr)
 			out := executeCommand(t, rootCmd, append([]string{bootStrapCmd}, tc.args...)...)
 			switch tc.logType {
@@ -67,6 +67,26 @@ const (
 
 // Invitations commands
 const (
-	acceptCmd = "accept"
-	rejectCmd = "reject"
+	acceptCmd       = "accept"
+	rejectCmd       = "reject"
+	sendEmailsCmd   = "send-emails"
+	resendEmailsCmd = "resend-emails"
+	getEmailsCmd    = "get-emails"
+	deleteEmailCmd  = "delete-email"
+	acceptEmailCmd  = "accept-email"
+)
+
+// Twins commands
+const (
+	desiredCmd = "desired"
+	patchCmd   = "patch"
+	historyCmd = "history"
+)
+
+// Journal commands
+const (
+	verifyCmd    = "verify"
+	checkCmd     = "check"
+	downloadCmd  = "download"
+	retentionCmd = "retention"
 )
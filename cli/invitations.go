@@ -4,6 +4,8 @@
 package cli
 
 import (
+	"strings"
+
 	mgxsdk "github.com/absmach/magistrala/pkg/sdk/go"
 	"github.com/spf13/cobra"
 )
@@ -130,14 +132,118 @@ var cmdInvitations = []cobra.Command{
 			logOKCmd(*cmd)
 		},
 	},
+	{
+		Use:   "send-emails <domain_id> <relation> <email>[,<email>...] <user_auth_token>",
+		Short: "Send email invitations",
+		Long: "Send invitations to join the domain by email\n" +
+			"Each email contains the single use invitation token. Already invited emails are skipped.\n" +
+			"For example:\n" +
+			"\tmagistrala-cli invitations send-emails 4ef09eff-d500-4d56-b04f-d23a512d6f2a contributor jane.doe@example.com,john.doe@example.com $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			sendEmailInvitations(cmd, args, false)
+		},
+	},
+	{
+		Use:   "resend-emails <domain_id> <relation> <email>[,<email>...] <user_auth_token>",
+		Short: "Resend email invitations",
+		Long: "Resend invitations to join the domain with the new token and renew their expiry\n" +
+			"For example:\n" +
+			"\tmagistrala-cli invitations resend-emails 4ef09eff-d500-4d56-b04f-d23a512d6f2a contributor jane.doe@example.com $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			sendEmailInvitations(cmd, args, true)
+		},
+	},
+	{
+		Use:   "get-emails <domain_id> <user_auth_token>",
+		Short: "Get email invitations",
+		Long: "Get pending email invitations of the domain\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli invitations get-emails <domain_id> <user_auth_token> - lists pending email invitations\n" +
+			"\tmagistrala-cli invitations get-emails <domain_id> <user_auth_token> --offset <offset> --limit <limit> - lists pending email invitations with provided offset and limit\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			pageMetadata := mgxsdk.PageMetadata{
+				DomainID: args[0],
+				Offset:   Offset,
+				Limit:    Limit,
+			}
+			l, err := sdk.EmailInvitations(pageMetadata, args[1])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, l)
+		},
+	},
+	{
+		Use:   "delete-email <email> <domain_id> <user_auth_token>",
+		Short: "Delete email invitation",
+		Long: "Delete pending email invitation\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli invitations delete-email jane.doe@example.com 4ef09eff-d500-4d56-b04f-d23a512d6f2a $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			if err := sdk.DeleteEmailInvitation(args[0], args[1], args[2]); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logOKCmd(*cmd)
+		},
+	},
+	{
+		Use:   "accept-email <invitation_token> <user_auth_token>",
+		Short: "Accept email invitation",
+		Long: "Accept email invitation using the invitation token from the email\n" +
+			"For example:\n" +
+			"\tmagistrala-cli invitations accept-email $INVITATION_TOKEN $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			inv, err := sdk.AcceptEmailInvitation(args[0], args[1])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, inv)
+		},
+	},
+}
+
+func sendEmailInvitations(cmd *cobra.Command, args []string, resend bool) {
+	if len(args) != 4 {
+		logUsageCmd(*cmd, cmd.Use)
+		return
+	}
+
+	invs, err := sdk.SendEmailInvitations(args[0], args[1], strings.Split(args[2], ","), resend, args[3])
+	if err != nil {
+		logErrorCmd(*cmd, err)
+		return
+	}
+
+	logJSONCmd(*cmd, invs)
 }
 
 // NewInvitationsCmd returns invitations command.
 func NewInvitationsCmd() *cobra.Command {
 	cmd := cobra.Command{
-		Use:   "invitations [send | get | accept | delete]",
+		Use:   "invitations [send | get | accept | reject | delete | send-emails | resend-emails | get-emails | delete-email | accept-email]",
 		Short: "Invitations management",
-		Long:  `Invitations management to send, get, accept and delete invitations`,
+		Long:  `Invitations management to send, get, accept and delete invitations and email invitations`,
 	}
 
 	for i := range cmdInvitations {
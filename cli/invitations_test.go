@@ -374,3 +374,242 @@ func TestDeleteInvitationCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestSendEmailInvitationsCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	invCmd := cli.NewInvitationsCmd()
+	rootCmd := setFlags(invCmd)
+
+	emails := []string{"jane.doe@example.com", "john.doe@example.com"}
+	invs := []mgsdk.EmailInvitation{
+		{Email: emails[0], DomainID: domain.ID, Relation: relation, SendCount: 1},
+		{Email: emails[1], DomainID: domain.ID, Relation: relation, SendCount: 1},
+	}
+
+	cases := []struct {
+		desc          string
+		cmd           string
+		args          []string
+		resend        bool
+		invs          []mgsdk.EmailInvitation
+		logType       outputLog
+		errLogMessage string
+		sdkErr        errors.SDKError
+	}{
+		{
+			desc:    "send email invitations successfully",
+			cmd:     sendEmailsCmd,
+			args:    []string{domain.ID, relation, strings.Join(emails, ","), validToken},
+			invs:    invs,
+			logType: entityLog,
+		},
+		{
+			desc:    "resend email invitations successfully",
+			cmd:     resendEmailsCmd,
+			args:    []string{domain.ID, relation, strings.Join(emails, ","), validToken},
+			resend:  true,
+			invs:    invs,
+			logType: entityLog,
+		},
+		{
+			desc:    "send email invitations with invalid args",
+			cmd:     sendEmailsCmd,
+			args:    []string{domain.ID, relation, strings.Join(emails, ","), validToken, extraArg},
+			logType: usageLog,
+		},
+		{
+			desc:          "send email invitations with invalid token",
+			cmd:           sendEmailsCmd,
+			args:          []string{domain.ID, relation, strings.Join(emails, ","), invalidToken},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusUnauthorized)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("SendEmailInvitations", domain.ID, relation, emails, tc.resend, mock.Anything).Return(tc.invs, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{tc.cmd}, tc.args...)...)
+			switch tc.logType {
+			case entityLog:
+				var res []mgsdk.EmailInvitation
+				err := json.Unmarshal([]byte(out), &res)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.invs, res, fmt.Sprintf("%v unexpected response, expected: %v, got: %v", tc.desc, tc.invs, res))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestGetEmailInvitationsCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	invCmd := cli.NewInvitationsCmd()
+	rootCmd := setFlags(invCmd)
+
+	cases := []struct {
+		desc          string
+		args          []string
+		page          mgsdk.EmailInvitationPage
+		logType       outputLog
+		errLogMessage string
+		sdkErr        errors.SDKError
+	}{
+		{
+			desc: "get email invitations successfully",
+			args: []string{domain.ID, validToken},
+			page: mgsdk.EmailInvitationPage{
+				Total:       1,
+				Limit:       10,
+				Invitations: []mgsdk.EmailInvitation{{Email: "jane.doe@example.com", DomainID: domain.ID, Relation: relation, SendCount: 1}},
+			},
+			logType: entityLog,
+		},
+		{
+			desc:    "get email invitations with invalid args",
+			args:    []string{domain.ID, validToken, extraArg},
+			logType: usageLog,
+		},
+		{
+			desc:          "get email invitations with invalid token",
+			args:          []string{domain.ID, invalidToken},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("EmailInvitations", mock.Anything, tc.args[1]).Return(tc.page, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{getEmailsCmd}, tc.args...)...)
+			switch tc.logType {
+			case entityLog:
+				var page mgsdk.EmailInvitationPage
+				err := json.Unmarshal([]byte(out), &page)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.page, page, fmt.Sprintf("%v unexpected response, expected: %v, got: %v", tc.desc, tc.page, page))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestDeleteEmailInvitationCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	invCmd := cli.NewInvitationsCmd()
+	rootCmd := setFlags(invCmd)
+
+	email := "jane.doe@example.com"
+
+	cases := []struct {
+		desc          string
+		args          []string
+		logType       outputLog
+		errLogMessage string
+		sdkErr        errors.SDKError
+	}{
+		{
+			desc:    "delete email invitation successfully",
+			args:    []string{email, domain.ID, validToken},
+			logType: okLog,
+		},
+		{
+			desc:    "delete email invitation with invalid args",
+			args:    []string{email, domain.ID, validToken, extraArg},
+			logType: usageLog,
+		},
+		{
+			desc:          "delete email invitation with invalid token",
+			args:          []string{email, domain.ID, invalidToken},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("DeleteEmailInvitation", email, domain.ID, mock.Anything).Return(tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{deleteEmailCmd}, tc.args...)...)
+			switch tc.logType {
+			case okLog:
+				assert.True(t, strings.Contains(out, "ok"), fmt.Sprintf("%s unexpected response: expected success message, got: %v", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestAcceptEmailInvitationCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	invCmd := cli.NewInvitationsCmd()
+	rootCmd := setFlags(invCmd)
+
+	invitationToken := "invitation_token"
+	inv := mgsdk.Invitation{UserID: user.ID, DomainID: domain.ID, Relation: relation}
+
+	cases := []struct {
+		desc          string
+		args          []string
+		inv           mgsdk.Invitation
+		logType       outputLog
+		errLogMessage string
+		sdkErr        errors.SDKError
+	}{
+		{
+			desc:    "accept email invitation successfully",
+			args:    []string{invitationToken, validToken},
+			inv:     inv,
+			logType: entityLog,
+		},
+		{
+			desc:    "accept email invitation with invalid args",
+			args:    []string{invitationToken, validToken, extraArg},
+			logType: usageLog,
+		},
+		{
+			desc:          "accept email invitation with invalid invitation token",
+			args:          []string{invitationToken, validToken},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("AcceptEmailInvitation", invitationToken, mock.Anything).Return(tc.inv, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{acceptEmailCmd}, tc.args...)...)
+			switch tc.logType {
+			case entityLog:
+				var res mgsdk.Invitation
+				err := json.Unmarshal([]byte(out), &res)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.inv, res, fmt.Sprintf("%v unexpected response, expected: %v, got: %v", tc.desc, tc.inv, res))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
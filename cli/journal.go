@@ -4,53 +4,244 @@
 package cli
 
 import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"strconv"
+
+	"github.com/absmach/magistrala/journal"
+	"github.com/absmach/magistrala/pkg/errors"
 	mgxsdk "github.com/absmach/magistrala/pkg/sdk/go"
 	"github.com/spf13/cobra"
 )
 
-var cmdJournal = cobra.Command{
-	Use:   "get <entity_type> <entity_id> <domain_id> <user_auth_token>",
-	Short: "Get journal",
-	Long: "Get journal\n" +
-		"Usage:\n" +
-		"\tmagistrala-cli journal get user <user_id> <user_auth_token> - lists user journal logs\n" +
-		"\tmagistrala-cli journal get <entity_type> <entity_id> <domain_id> <user_auth_token> - lists entity journal logs\n" +
-		"\tmagistrala-cli journal get <entity_type> <entity_id> <domain_id> <user_auth_token> --offset <offset> --limit <limit> - lists user journal logs with provided offset and limit\n",
-	Run: func(cmd *cobra.Command, args []string) {
-		if len(args) < 3 || len(args) > 4 {
-			logUsageCmd(*cmd, cmd.Use)
-			return
-		}
-		pageMetadata := mgxsdk.PageMetadata{
-			Offset: Offset,
-			Limit:  Limit,
-		}
-
-		entityType, entityID, token := args[0], args[1], args[2]
-		domainID := ""
-		if len(args) == 4 {
-			entityType, entityID, domainID, token = args[0], args[1], args[2], args[3]
-		}
-
-		journal, err := sdk.Journal(entityType, entityID, domainID, pageMetadata, token)
-		if err != nil {
-			logErrorCmd(*cmd, err)
-			return
-		}
-
-		logJSONCmd(*cmd, journal)
+var errInvalidPublicKey = errors.New("public key must be a PEM encoded Ed25519 public key")
+
+var cmdJournal = []cobra.Command{
+	{
+		Use:   "get <entity_type> <entity_id> <domain_id> <user_auth_token>",
+		Short: "Get journal",
+		Long: "Get journal\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli journal get user <user_id> <user_auth_token> - lists user journal logs\n" +
+			"\tmagistrala-cli journal get <entity_type> <entity_id> <domain_id> <user_auth_token> - lists entity journal logs\n" +
+			"\tmagistrala-cli journal get <entity_type> <entity_id> <domain_id> <user_auth_token> --offset <offset> --limit <limit> - lists user journal logs with provided offset and limit\n" +
+			"Entity types: group, thing, channel, domain, key, certificate, invitation and bootstrap\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 3 || len(args) > 4 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+			pageMetadata := mgxsdk.PageMetadata{
+				Offset: Offset,
+				Limit:  Limit,
+			}
+
+			entityType, entityID, token := args[0], args[1], args[2]
+			domainID := ""
+			if len(args) == 4 {
+				entityType, entityID, domainID, token = args[0], args[1], args[2], args[3]
+			}
+
+			journal, err := sdk.Journal(entityType, entityID, domainID, pageMetadata, token)
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, journal)
+		},
+	},
+	{
+		Use:   "verify <domain_id> <user_auth_token>",
+		Short: "Verify journal chain",
+		Long: "Verify the domain journal hash chain for missing or modified entries.\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli journal verify <domain_id> $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			v, err := sdk.VerifyJournal(args[0], args[1])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, v)
+		},
+	},
+	{
+		Use:   "export <domain_id> <file> <user_auth_token>",
+		Short: "Export journal",
+		Long: "Export the signed domain journals to the NDJSON file.\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli journal export <domain_id> journal.ndjson $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			export, err := sdk.ExportJournal(args[0], 1, 0, args[2])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			if err := os.WriteFile(args[1], export, filePermission); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logOKCmd(*cmd)
+		},
+	},
+	{
+		Use:   "download <domain_id> <csv | ndjson> <file> <user_auth_token>",
+		Short: "Download journal",
+		Long: "Download the domain journals matching the filters to the CSV or NDJSON file.\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli journal download <domain_id> csv journal.csv $USERTOKEN\n" +
+			"\tmagistrala-cli journal download <domain_id> ndjson journal.ndjson $USERTOKEN --limit <limit>\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 4 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+			pageMetadata := mgxsdk.PageMetadata{
+				Limit: Limit,
+			}
+
+			content, err := sdk.DownloadJournal(args[0], args[1], pageMetadata, args[3])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			if err := os.WriteFile(args[2], content, filePermission); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logOKCmd(*cmd)
+		},
+	},
+	{
+		Use:   "retention [set <domain_id> <days> | get <domain_id> | remove <domain_id>] <user_auth_token>",
+		Short: "Journal retention",
+		Long: "Manage the domain journal retention policy. Journals older than the retention period are archived.\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli journal retention set <domain_id> 90 $USERTOKEN - archives journals older than 90 days\n" +
+			"\tmagistrala-cli journal retention get <domain_id> $USERTOKEN - shows retention policy\n" +
+			"\tmagistrala-cli journal retention remove <domain_id> $USERTOKEN - removes retention policy\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+			switch {
+			case args[0] == "set" && len(args) == 4:
+				days, err := strconv.ParseUint(args[2], 10, 64)
+				if err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+				r, err := sdk.SetJournalRetention(args[1], days, args[3])
+				if err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+
+				logJSONCmd(*cmd, r)
+			case args[0] == "get" && len(args) == 3:
+				r, err := sdk.JournalRetention(args[1], args[2])
+				if err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+
+				logJSONCmd(*cmd, r)
+			case args[0] == "remove" && len(args) == 3:
+				if err := sdk.RemoveJournalRetention(args[1], args[2]); err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+
+				logOKCmd(*cmd)
+			default:
+				logUsageCmd(*cmd, cmd.Use)
+			}
+		},
+	},
+	{
+		Use:   "check <file> <public_key_file>",
+		Short: "Check journal export",
+		Long: "Check the signature and the chain of the journal export offline, using the journal service public key.\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli journal check journal.ndjson journal.pub\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			key, err := readPublicKey(args[1])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			f, err := os.Open(args[0])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			defer f.Close()
+
+			proof, err := journal.VerifyExport(f, key)
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, proof)
+		},
 	},
 }
 
 // NewJournalCmd returns journal log command.
 func NewJournalCmd() *cobra.Command {
 	cmd := cobra.Command{
-		Use:   "journal get",
+		Use:   "journal [get | verify | export | download | retention | check]",
 		Short: "journal log",
-		Long:  `journal to read journal log`,
+		Long:  `journal to read, verify, export and archive journal log`,
 	}
 
-	cmd.AddCommand(&cmdJournal)
+	for i := range cmdJournal {
+		cmd.AddCommand(&cmdJournal[i])
+	}
 
 	return &cmd
 }
+
+func readPublicKey(path string) (ed25519.PublicKey, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errInvalidPublicKey
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(errInvalidPublicKey, err)
+	}
+	edKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, errInvalidPublicKey
+	}
+
+	return edKey, nil
+}
@@ -4,14 +4,22 @@
 package cli_test
 
 import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/absmach/magistrala/cli"
 	"github.com/absmach/magistrala/internal/testsutil"
+	mgjournal "github.com/absmach/magistrala/journal"
 	"github.com/absmach/magistrala/pkg/errors"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
@@ -121,3 +129,357 @@ func TestGetJournalCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyJournalCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	journalCmd := cli.NewJournalCmd()
+	rootCmd := setFlags(journalCmd)
+
+	domainID := testsutil.GenerateUUID(t)
+	var v mgsdk.JournalVerification
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		verification  mgsdk.JournalVerification
+		logType       outputLog
+		errLogMessage string
+	}{
+		{
+			desc: "verify journal successfully",
+			args: []string{
+				domainID,
+				token,
+			},
+			verification: mgsdk.JournalVerification{
+				Domain:       domainID,
+				Valid:        true,
+				Entries:      2,
+				HeadSequence: 2,
+			},
+			logType: entityLog,
+		},
+		{
+			desc: "verify journal with invalid args",
+			args: []string{
+				domainID,
+				token,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "verify journal with invalid token",
+			args: []string{
+				domainID,
+				invalidToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("VerifyJournal", tc.args[0], tc.args[1]).Return(tc.verification, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{verifyCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &v)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.verification, v, fmt.Sprintf("%v unexpected response, expected: %v, got: %v", tc.desc, tc.verification, v))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestDownloadJournalCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	journalCmd := cli.NewJournalCmd()
+	rootCmd := setFlags(journalCmd)
+
+	domainID := testsutil.GenerateUUID(t)
+	file := filepath.Join(t.TempDir(), "journal.csv")
+	content := []byte("id,domain,sequence,operation,occurred_at,attributes,metadata\n")
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		logType       outputLog
+		errLogMessage string
+	}{
+		{
+			desc: "download journal successfully",
+			args: []string{
+				domainID,
+				"csv",
+				file,
+				token,
+			},
+			logType: okLog,
+		},
+		{
+			desc: "download journal with invalid args",
+			args: []string{
+				domainID,
+				"csv",
+				file,
+				token,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "download journal with invalid token",
+			args: []string{
+				domainID,
+				"csv",
+				file,
+				invalidToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("DownloadJournal", tc.args[0], tc.args[1], mock.Anything, tc.args[3]).Return(content, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{downloadCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case okLog:
+				assert.True(t, strings.Contains(out, "ok"), fmt.Sprintf("%s unexpected response: expected success message, got: %v", tc.desc, out))
+				b, err := os.ReadFile(file)
+				assert.Nil(t, err, fmt.Sprintf("reading file unexpected error: %s", err))
+				assert.Equal(t, content, b, fmt.Sprintf("%s unexpected file content", tc.desc))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestJournalRetentionCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	journalCmd := cli.NewJournalCmd()
+	rootCmd := setFlags(journalCmd)
+
+	domainID := testsutil.GenerateUUID(t)
+	retention := mgsdk.JournalRetention{
+		Domain: domainID,
+		Days:   30,
+	}
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		logType       outputLog
+		errLogMessage string
+	}{
+		{
+			desc: "set journal retention successfully",
+			args: []string{
+				"set",
+				domainID,
+				"30",
+				token,
+			},
+			logType: entityLog,
+		},
+		{
+			desc: "set journal retention with invalid days",
+			args: []string{
+				"set",
+				domainID,
+				"invalid",
+				token,
+			},
+			logType:       errLog,
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", `strconv.ParseUint: parsing "invalid": invalid syntax`),
+		},
+		{
+			desc: "get journal retention successfully",
+			args: []string{
+				"get",
+				domainID,
+				token,
+			},
+			logType: entityLog,
+		},
+		{
+			desc: "get journal retention with invalid token",
+			args: []string{
+				"get",
+				domainID,
+				invalidToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden)),
+			logType:       errLog,
+		},
+		{
+			desc: "remove journal retention successfully",
+			args: []string{
+				"remove",
+				domainID,
+				token,
+			},
+			logType: okLog,
+		},
+		{
+			desc: "journal retention with invalid args",
+			args: []string{
+				"get",
+				domainID,
+				token,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			setCall := sdkMock.On("SetJournalRetention", domainID, uint64(30), mock.Anything).Return(retention, tc.sdkErr)
+			getCall := sdkMock.On("JournalRetention", domainID, mock.Anything).Return(retention, tc.sdkErr)
+			removeCall := sdkMock.On("RemoveJournalRetention", domainID, mock.Anything).Return(tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{retentionCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				var r mgsdk.JournalRetention
+				err := json.Unmarshal([]byte(out), &r)
+				assert.Nil(t, err)
+				assert.Equal(t, retention, r, fmt.Sprintf("%v unexpected response, expected: %v, got: %v", tc.desc, retention, r))
+			case okLog:
+				assert.True(t, strings.Contains(out, "ok"), fmt.Sprintf("%s unexpected response: expected success message, got: %v", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			setCall.Unset()
+			getCall.Unset()
+			removeCall.Unset()
+		})
+	}
+}
+
+func TestCheckJournalCmd(t *testing.T) {
+	journalCmd := cli.NewJournalCmd()
+	rootCmd := setFlags(journalCmd)
+
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err, fmt.Sprintf("generating key unexpected error: %s", err))
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err, fmt.Sprintf("generating key unexpected error: %s", err))
+
+	dir := t.TempDir()
+	pubFile := writePublicKey(t, dir, "journal.pub", pub)
+	otherPubFile := writePublicKey(t, dir, "other.pub", otherPub)
+
+	domainID := testsutil.GenerateUUID(t)
+	j := mgjournal.Journal{
+		ID:        testsutil.GenerateUUID(t),
+		Operation: "thing.create",
+		Domain:    domainID,
+		Sequence:  1,
+	}
+	j.Hash, err = j.ComputeHash()
+	assert.Nil(t, err, fmt.Sprintf("computing hash unexpected error: %s", err))
+	proof := mgjournal.Proof{
+		Domain:        domainID,
+		FirstSequence: 1,
+		LastSequence:  1,
+		HeadHash:      j.Hash,
+		Entries:       1,
+	}
+	err = proof.Sign(key)
+	assert.Nil(t, err, fmt.Sprintf("signing proof unexpected error: %s", err))
+	var buf bytes.Buffer
+	err = mgjournal.Export{Journals: []mgjournal.Journal{j}, Proof: proof}.Encode(&buf)
+	assert.Nil(t, err, fmt.Sprintf("encoding export unexpected error: %s", err))
+	exportFile := filepath.Join(dir, "journal.ndjson")
+	err = os.WriteFile(exportFile, buf.Bytes(), 0o600)
+	assert.Nil(t, err, fmt.Sprintf("writing export unexpected error: %s", err))
+
+	cases := []struct {
+		desc          string
+		args          []string
+		logType       outputLog
+		errLogMessage string
+	}{
+		{
+			desc: "check journal export successfully",
+			args: []string{
+				exportFile,
+				pubFile,
+			},
+			logType: entityLog,
+		},
+		{
+			desc: "check journal export with other key",
+			args: []string{
+				exportFile,
+				otherPubFile,
+			},
+			logType:       errLog,
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", mgjournal.ErrInvalidSignature),
+		},
+		{
+			desc: "check journal export with invalid args",
+			args: []string{
+				exportFile,
+				pubFile,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			out := executeCommand(t, rootCmd, append([]string{checkCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				var p mgjournal.Proof
+				err := json.Unmarshal([]byte(out), &p)
+				assert.Nil(t, err)
+				assert.Equal(t, proof.HeadHash, p.HeadHash, fmt.Sprintf("%v unexpected response, expected: %v, got: %v", tc.desc, proof.HeadHash, p.HeadHash))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+		})
+	}
+}
+
+func writePublicKey(t *testing.T, dir, name string, key ed25519.PublicKey) string {
+	b, err := x509.MarshalPKIXPublicKey(key)
+	assert.Nil(t, err, fmt.Sprintf("marshaling public key unexpected error: %s", err))
+	path := filepath.Join(dir, name)
+	err = os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: b}), 0o600)
+	assert.Nil(t, err, fmt.Sprintf("writing public key unexpected error: %s", err))
+
+	return path
+}
@@ -0,0 +1,130 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+
+	mgxsdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/spf13/cobra"
+)
+
+var cmdTwins = []cobra.Command{
+	{
+		Use:   "get <domain_id> <thing_id> <user_auth_token>",
+		Short: "Get twin",
+		Long: "Get the digital twin of the thing with its reported and desired state and the delta between them.\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli twins get <domain_id> <thing_id> $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			tw, err := sdk.Twin(args[0], args[1], args[2])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, tw)
+		},
+	},
+	{
+		Use:   "desired <domain_id> <thing_id> <JSON_state> <user_auth_token>",
+		Short: "Set desired state",
+		Long: "Replace the desired state of the thing twin.\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli twins desired <domain_id> <thing_id> '{\"temperature\":23}' $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 4 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			var desired map[string]interface{}
+			if err := json.Unmarshal([]byte(args[2]), &desired); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			tw, err := sdk.SetTwinDesired(args[0], args[1], desired, args[3])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, tw)
+		},
+	},
+	{
+		Use:   "patch <domain_id> <thing_id> <JSON_patch> <user_auth_token>",
+		Short: "Patch desired state",
+		Long: "Apply the JSON patch (RFC 6902) to the desired state of the thing twin.\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli twins patch <domain_id> <thing_id> '[{\"op\":\"replace\",\"path\":\"/temperature\",\"value\":22}]' $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 4 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			var patch []mgxsdk.TwinPatchOp
+			if err := json.Unmarshal([]byte(args[2]), &patch); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			tw, err := sdk.PatchTwinDesired(args[0], args[1], patch, args[3])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, tw)
+		},
+	},
+	{
+		Use:   "history <domain_id> <thing_id> <user_auth_token>",
+		Short: "Get twin history",
+		Long: "Get the state history of the thing twin, starting from the latest version.\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli twins history <domain_id> <thing_id> $USERTOKEN --offset <offset> --limit <limit>\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			pageMetadata := mgxsdk.PageMetadata{
+				Offset: Offset,
+				Limit:  Limit,
+			}
+
+			page, err := sdk.TwinHistory(args[0], args[1], pageMetadata, args[2])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, page)
+		},
+	},
+}
+
+// NewTwinsCmd returns twins command.
+func NewTwinsCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "twins [get | desired | patch | history]",
+		Short: "Twins management",
+		Long:  `Twins management: view thing twins, set and patch their desired state and list their history`,
+	}
+
+	for i := range cmdTwins {
+		cmd.AddCommand(&cmdTwins[i])
+	}
+
+	return &cmd
+}
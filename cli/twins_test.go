@@ -0,0 +1,331 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/cli"
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
+	sdkmocks "github.com/absmach/magistrala/pkg/sdk/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var twin = mgsdk.Twin{
+	ThingID:  testsutil.GenerateUUID(&testing.T{}),
+	Reported: map[string]interface{}{"temperature": 21.0},
+	Desired:  map[string]interface{}{"temperature": 23.0},
+	Delta:    map[string]interface{}{"temperature": 23.0},
+	Version:  2,
+}
+
+func TestGetTwinCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	twinsCmd := cli.NewTwinsCmd()
+	rootCmd := setFlags(twinsCmd)
+
+	domainID := testsutil.GenerateUUID(t)
+	var tw mgsdk.Twin
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		twin          mgsdk.Twin
+		logType       outputLog
+		errLogMessage string
+	}{
+		{
+			desc: "get twin successfully",
+			args: []string{
+				domainID,
+				twin.ThingID,
+				token,
+			},
+			twin:    twin,
+			logType: entityLog,
+		},
+		{
+			desc: "get twin with invalid args",
+			args: []string{
+				domainID,
+				twin.ThingID,
+				token,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "get twin with invalid token",
+			args: []string{
+				domainID,
+				twin.ThingID,
+				invalidToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("Twin", mock.Anything, mock.Anything, mock.Anything).Return(tc.twin, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{getCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &tw)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.twin, tw, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.twin, tw))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestSetTwinDesiredCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	twinsCmd := cli.NewTwinsCmd()
+	rootCmd := setFlags(twinsCmd)
+
+	domainID := testsutil.GenerateUUID(t)
+	desired := "{\"temperature\":23}"
+	var tw mgsdk.Twin
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		twin          mgsdk.Twin
+		logType       outputLog
+		errLogMessage string
+	}{
+		{
+			desc: "set desired state successfully",
+			args: []string{
+				domainID,
+				twin.ThingID,
+				desired,
+				token,
+			},
+			twin:    twin,
+			logType: entityLog,
+		},
+		{
+			desc: "set desired state with invalid args",
+			args: []string{
+				domainID,
+				twin.ThingID,
+				desired,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "set desired state with invalid JSON",
+			args: []string{
+				domainID,
+				twin.ThingID,
+				"{\"temperature\":23",
+				token,
+			},
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", "unexpected end of JSON input"),
+			logType:       errLog,
+		},
+		{
+			desc: "set desired state with invalid token",
+			args: []string{
+				domainID,
+				twin.ThingID,
+				desired,
+				invalidToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("SetTwinDesired", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.twin, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{desiredCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &tw)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.twin, tw, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.twin, tw))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestPatchTwinDesiredCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	twinsCmd := cli.NewTwinsCmd()
+	rootCmd := setFlags(twinsCmd)
+
+	domainID := testsutil.GenerateUUID(t)
+	patch := "[{\"op\":\"replace\",\"path\":\"/temperature\",\"value\":23}]"
+	var tw mgsdk.Twin
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		twin          mgsdk.Twin
+		logType       outputLog
+		errLogMessage string
+	}{
+		{
+			desc: "patch desired state successfully",
+			args: []string{
+				domainID,
+				twin.ThingID,
+				patch,
+				token,
+			},
+			twin:    twin,
+			logType: entityLog,
+		},
+		{
+			desc: "patch desired state with invalid args",
+			args: []string{
+				domainID,
+				twin.ThingID,
+				patch,
+				token,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "patch desired state with failed test operation",
+			args: []string{
+				domainID,
+				twin.ThingID,
+				patch,
+				token,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrConflict, http.StatusConflict),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrConflict, http.StatusConflict)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("PatchTwinDesired", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.twin, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{patchCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &tw)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.twin, tw, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.twin, tw))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestTwinHistoryCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	twinsCmd := cli.NewTwinsCmd()
+	rootCmd := setFlags(twinsCmd)
+
+	domainID := testsutil.GenerateUUID(t)
+	var page mgsdk.TwinStatesPage
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		page          mgsdk.TwinStatesPage
+		logType       outputLog
+		errLogMessage string
+	}{
+		{
+			desc: "get twin history successfully",
+			args: []string{
+				domainID,
+				twin.ThingID,
+				token,
+			},
+			page: mgsdk.TwinStatesPage{
+				Total:  1,
+				Offset: 0,
+				Limit:  10,
+				States: []mgsdk.TwinState{
+					{ThingID: twin.ThingID, Version: 1, Cause: "desired", Desired: twin.Desired},
+				},
+			},
+			logType: entityLog,
+		},
+		{
+			desc: "get twin history with invalid args",
+			args: []string{
+				domainID,
+				twin.ThingID,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "get twin history with invalid token",
+			args: []string{
+				domainID,
+				twin.ThingID,
+				invalidToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("TwinHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.page, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{historyCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &page)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.page, page, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.page, page))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
@@ -48,12 +48,13 @@ import (
 )
 
 const (
-	svcName        = "bootstrap"
-	envPrefixDB    = "MG_BOOTSTRAP_DB_"
-	envPrefixHTTP  = "MG_BOOTSTRAP_HTTP_"
-	envPrefixAuth  = "MG_AUTH_GRPC_"
-	defDB          = "bootstrap"
-	defSvcHTTPPort = "9013"
+	svcName         = "bootstrap"
+	envPrefixDB     = "MG_BOOTSTRAP_DB_"
+	envPrefixHTTP   = "MG_BOOTSTRAP_HTTP_"
+	envPrefixAuth   = "MG_AUTH_GRPC_"
+	envPrefixThings = "MG_THINGS_AUTH_GRPC_"
+	defDB           = "bootstrap"
+	defSvcHTTPPort  = "9013"
 
 	thingsStream = "events.magistrala.things"
 	streamID     = "magistrala.bootstrap"
@@ -61,9 +62,11 @@ const (
 
 type config struct {
 	LogLevel            string  `env:"MG_BOOTSTRAP_LOG_LEVEL"        envDefault:"info"`
-	EncKey              string  `env:"MG_BOOTSTRAP_ENCRYPT_KEY"      envDefault:"12345678910111213141516171819202"`
+	EncKey              string  `env:"MG_BOOTSTRAP_ENCRYPT_KEY"      envDefault:""`
+	MasterKeys          string  `env:"MG_BOOTSTRAP_MASTER_KEYS"      envDefault:""`
 	ESConsumerName      string  `env:"MG_BOOTSTRAP_EVENT_CONSUMER"   envDefault:"bootstrap"`
 	ThingsURL           string  `env:"MG_THINGS_URL"                 envDefault:"http://localhost:9000"`
+	CertsURL            string  `env:"MG_CERTS_URL"                  envDefault:"http://localhost:9019"`
 	JaegerURL           url.URL `env:"MG_JAEGER_URL"                 envDefault:"http://localhost:4318/v1/traces"`
 	SendTelemetry       bool    `env:"MG_SEND_TELEMETRY"             envDefault:"true"`
 	InstanceID          string  `env:"MG_BOOTSTRAP_INSTANCE_ID"      envDefault:""`
@@ -99,6 +102,22 @@ func main() {
 		}
 	}
 
+	masterKeys, err := bootstrap.ParseMasterKeys(cfg.MasterKeys)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s master keys: %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	keys, err := bootstrap.NewKeyring([]byte(cfg.EncKey), masterKeys)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create %s keyring: %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	if cfg.EncKey != "" {
+		logger.Warn("Legacy secure bootstrap encryption is enabled, unset MG_BOOTSTRAP_ENCRYPT_KEY once all devices use versioned master keys")
+	}
+
 	// Create new postgres client
 	dbConfig := pgclient.Config{Name: defDB}
 	if err := env.ParseWithOptions(&dbConfig, env.Options{Prefix: envPrefixDB}); err != nil {
@@ -157,8 +176,23 @@ func main() {
 	defer authzClient.Close()
 	logger.Info("AuthZ successfully connected to auth gRPC server " + authzClient.Secure())
 
+	thingsClientCfg := grpcclient.Config{}
+	if err := env.ParseWithOptions(&thingsClientCfg, env.Options{Prefix: envPrefixThings}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load things gRPC client configuration : %s", err))
+		exitCode = 1
+		return
+	}
+	thingsClient, thingsHandler, err := grpcclient.SetupThingsClient(ctx, thingsClientCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer thingsHandler.Close()
+	logger.Info("Things service gRPC client successfully connected to things gRPC server " + thingsHandler.Secure())
+
 	// Create new service
-	svc, err := newService(ctx, authz, policySvc, db, tracer, logger, cfg, dbConfig)
+	svc, err := newService(ctx, authz, policySvc, db, thingsClient, tracer, logger, cfg, dbConfig, keys)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to create %s service: %s", svcName, err))
 		exitCode = 1
@@ -179,7 +213,7 @@ func main() {
 		exitCode = 1
 		return
 	}
-	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(svc, authn, bootstrap.NewConfigReader([]byte(cfg.EncKey)), logger, cfg.InstanceID), logger)
+	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(svc, authn, bootstrap.NewConfigReader(keys), logger, cfg.InstanceID), logger)
 
 	if cfg.SendTelemetry {
 		chc := chclient.New(svcName, magistrala.Version, logger, cancel)
@@ -199,19 +233,21 @@ func main() {
 	}
 }
 
-func newService(ctx context.Context, authz mgauthz.Authorization, policySvc policies.Service, db *sqlx.DB, tracer trace.Tracer, logger *slog.Logger, cfg config, dbConfig pgclient.Config) (bootstrap.Service, error) {
+func newService(ctx context.Context, authz mgauthz.Authorization, policySvc policies.Service, db *sqlx.DB, things magistrala.ThingsServiceClient, tracer trace.Tracer, logger *slog.Logger, cfg config, dbConfig pgclient.Config, keys bootstrap.Keyring) (bootstrap.Service, error) {
 	database := pgclient.NewDatabase(db, dbConfig, tracer)
 
 	repoConfig := bootstrappg.NewConfigRepository(database, logger)
+	repoTemplates := bootstrappg.NewTemplateRepository(database)
 
 	config := mgsdk.Config{
 		ThingsURL: cfg.ThingsURL,
+		CertsURL:  cfg.CertsURL,
 	}
 
 	sdk := mgsdk.NewSDK(config)
 	idp := uuid.New()
 
-	svc := bootstrap.New(policySvc, repoConfig, sdk, []byte(cfg.EncKey), idp)
+	svc := bootstrap.New(policySvc, repoConfig, repoTemplates, sdk, keys, things, idp)
 
 	publisher, err := store.NewPublisher(ctx, cfg.ESURL, streamID)
 	if err != nil {
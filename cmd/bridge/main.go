@@ -0,0 +1,266 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package main contains bridge main function to start the bridge service.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/url"
+	"os"
+	"time"
+
+	chclient "github.com/absmach/callhome/pkg/client"
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/consumers"
+	"github.com/absmach/magistrala/consumers/bridge"
+	bridgeamqp "github.com/absmach/magistrala/consumers/bridge/amqp"
+	"github.com/absmach/magistrala/consumers/bridge/api"
+	bridgehttp "github.com/absmach/magistrala/consumers/bridge/http"
+	"github.com/absmach/magistrala/consumers/bridge/middleware"
+	bridgemqtt "github.com/absmach/magistrala/consumers/bridge/mqtt"
+	bridgepg "github.com/absmach/magistrala/consumers/bridge/postgres"
+	consumertracing "github.com/absmach/magistrala/consumers/tracing"
+	mglog "github.com/absmach/magistrala/logger"
+	authsvcAuthn "github.com/absmach/magistrala/pkg/authn/authsvc"
+	mgauthz "github.com/absmach/magistrala/pkg/authz"
+	authsvcAuthz "github.com/absmach/magistrala/pkg/authz/authsvc"
+	"github.com/absmach/magistrala/pkg/grpcclient"
+	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
+	"github.com/absmach/magistrala/pkg/messaging/brokers"
+	brokerstracing "github.com/absmach/magistrala/pkg/messaging/brokers/tracing"
+	"github.com/absmach/magistrala/pkg/postgres"
+	pgclient "github.com/absmach/magistrala/pkg/postgres"
+	"github.com/absmach/magistrala/pkg/prometheus"
+	"github.com/absmach/magistrala/pkg/server"
+	httpserver "github.com/absmach/magistrala/pkg/server/http"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/absmach/magistrala/webhooks"
+	"github.com/caarlos0/env/v11"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/jmoiron/sqlx"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	svcName        = "bridge"
+	envPrefixDB    = "MG_BRIDGE_DB_"
+	envPrefixHTTP  = "MG_BRIDGE_HTTP_"
+	envPrefixAuth  = "MG_AUTH_GRPC_"
+	defDB          = "bridge"
+	defSvcHTTPPort = "9026"
+)
+
+type config struct {
+	LogLevel        string        `env:"MG_BRIDGE_LOG_LEVEL"          envDefault:"info"`
+	ConfigPath      string        `env:"MG_BRIDGE_CONFIG_PATH"        envDefault:"/config.toml"`
+	BrokerURL       string        `env:"MG_MESSAGE_BROKER_URL"        envDefault:"nats://localhost:4222"`
+	MQTTQoS         uint8         `env:"MG_BRIDGE_MQTT_QOS"           envDefault:"1"`
+	ForwardTimeout  time.Duration `env:"MG_BRIDGE_FORWARD_TIMEOUT"    envDefault:"10s"`
+	AllowedNetworks []string      `env:"MG_BRIDGE_ALLOWED_NETWORKS"   envDefault:"" envSeparator:","`
+	RoutesTTL       time.Duration `env:"MG_BRIDGE_ROUTES_TTL"         envDefault:"1m"`
+	QueueSize       int           `env:"MG_BRIDGE_QUEUE_SIZE"         envDefault:"1000"`
+	JaegerURL       url.URL       `env:"MG_JAEGER_URL"                envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry   bool          `env:"MG_SEND_TELEMETRY"            envDefault:"true"`
+	InstanceID      string        `env:"MG_BRIDGE_INSTANCE_ID"        envDefault:""`
+	TraceRatio      float64       `env:"MG_JAEGER_TRACE_RATIO"        envDefault:"1.0"`
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	cfg := config{}
+	if err := env.Parse(&cfg); err != nil {
+		log.Fatalf("failed to load %s configuration : %s", svcName, err)
+	}
+
+	logger, err := mglog.New(os.Stdout, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("failed to init logger: %s", err)
+	}
+
+	var exitCode int
+	defer mglog.ExitWithError(&exitCode)
+
+	if cfg.InstanceID == "" {
+		if cfg.InstanceID, err = uuid.New().ID(); err != nil {
+			logger.Error(fmt.Sprintf("failed to generate instanceID: %s", err))
+			exitCode = 1
+			return
+		}
+	}
+
+	httpServerConfig := server.Config{Port: defSvcHTTPPort}
+	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	dbConfig := pgclient.Config{Name: defDB}
+	if err := env.ParseWithOptions(&dbConfig, env.Options{Prefix: envPrefixDB}); err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	db, err := pgclient.Setup(dbConfig, *bridgepg.Migration())
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer db.Close()
+
+	authClientCfg := grpcclient.Config{}
+	if err := env.ParseWithOptions(&authClientCfg, env.Options{Prefix: envPrefixAuth}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load auth gRPC client configuration : %s", err))
+		exitCode = 1
+		return
+	}
+
+	authn, authnHandler, err := authsvcAuthn.NewAuthentication(ctx, authClientCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer authnHandler.Close()
+	logger.Info("AuthN successfully connected to auth gRPC server " + authnHandler.Secure())
+
+	authz, authzHandler, err := authsvcAuthz.NewAuthorization(ctx, authClientCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer authzHandler.Close()
+	logger.Info("AuthZ successfully connected to auth gRPC server " + authzHandler.Secure())
+
+	tp, err := jaegerclient.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to init Jaeger: %s", err))
+		exitCode = 1
+		return
+	}
+	defer func() {
+		if err := tp.Shutdown(ctx); err != nil {
+			logger.Error(fmt.Sprintf("error shutting down tracer provider: %s", err))
+		}
+	}()
+	tracer := tp.Tracer(svcName)
+
+	pubSub, err := brokers.NewPubSub(ctx, cfg.BrokerURL, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to message broker: %s", err))
+		exitCode = 1
+		return
+	}
+	defer pubSub.Close()
+	pubSub = brokerstracing.NewPubSub(httpServerConfig, tracer, pubSub)
+
+	addresses, err := webhooks.NewAddressFilter(cfg.AllowedNetworks)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to parse allowed networks: %s", err))
+		exitCode = 1
+		return
+	}
+
+	svc := newService(db, dbConfig, authz, addresses, cfg, logger, tracer)
+
+	if err = consumers.Start(ctx, svcName, pubSub, consumertracing.NewAsync(tracer, svc, httpServerConfig), nil, cfg.ConfigPath, logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to create bridge consumer: %s", err))
+		exitCode = 1
+		return
+	}
+
+	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(svc, authn, logger, svcName, cfg.InstanceID), logger)
+
+	if cfg.SendTelemetry {
+		chc := chclient.New(svcName, magistrala.Version, logger, cancel)
+		go chc.CallHome(ctx)
+	}
+
+	g.Go(func() error {
+		return hs.Start()
+	})
+
+	g.Go(func() error {
+		return handleErrors(ctx, svc.Errors(), logger)
+	})
+
+	g.Go(func() error {
+		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs)
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("%s service terminated: %s", svcName, err))
+	}
+}
+
+func newService(db *sqlx.DB, dbConfig pgclient.Config, authz mgauthz.Authorization, addresses webhooks.AddressFilter, cfg config, logger *slog.Logger, tracer trace.Tracer) bridge.Service {
+	database := postgres.NewDatabase(db, dbConfig, tracer)
+	repo := bridgepg.NewRepository(database)
+	idp := uuid.New()
+
+	fwdCounter, fwdLatency := makeForwarderMetrics()
+	forwarders := map[bridge.Type]bridge.Forwarder{
+		bridge.MQTTType: bridgemqtt.NewForwarder(cfg.MQTTQoS, cfg.ForwardTimeout, addresses),
+		bridge.HTTPType: bridgehttp.NewForwarder(cfg.ForwardTimeout, addresses),
+		bridge.AMQPType: bridgeamqp.NewForwarder(cfg.ForwardTimeout, addresses),
+	}
+	for t, fwd := range forwarders {
+		forwarders[t] = middleware.ForwarderMetrics(fwd, fwdCounter, fwdLatency)
+	}
+
+	dispatch := bridge.DispatchConfig{
+		RoutesTTL: cfg.RoutesTTL,
+		QueueSize: cfg.QueueSize,
+	}
+
+	svc := bridge.New(idp, repo, forwarders, addresses, dispatch)
+	svc = middleware.AuthorizationMiddleware(svc, authz)
+	svc = middleware.LoggingMiddleware(svc, logger)
+	counter, latency := prometheus.MakeMetrics(svcName, "api")
+	svc = middleware.MetricsMiddleware(svc, counter, latency)
+	svc = middleware.Tracing(svc, tracer)
+
+	return svc
+}
+
+// makeForwarderMetrics returns delivery counter and latency labeled per route.
+func makeForwarderMetrics() (*kitprometheus.Counter, *kitprometheus.Summary) {
+	labels := []string{"route", "type", "status"}
+	counter := kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: svcName,
+		Subsystem: "forwarder",
+		Name:      "delivery_count",
+		Help:      "Number of message deliveries to external endpoints.",
+	}, labels)
+	latency := kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+		Namespace:  svcName,
+		Subsystem:  "forwarder",
+		Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		Name:       "delivery_latency_seconds",
+		Help:       "Total duration of message deliveries in seconds.",
+	}, labels)
+
+	return counter, latency
+}
+
+// handleErrors logs forwarding errors reported by the bridge service.
+func handleErrors(ctx context.Context, errs <-chan error, logger *slog.Logger) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			logger.Warn(fmt.Sprintf("failed to forward message: %s", err))
+		}
+	}
+}
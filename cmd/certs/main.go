@@ -11,47 +11,62 @@ import (
 	"log/slog"
 	"net/url"
 	"os"
+	"time"
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/magistrala"
 	"github.com/absmach/magistrala/certs"
 	"github.com/absmach/magistrala/certs/api"
+	grpcapi "github.com/absmach/magistrala/certs/api/grpc"
+	"github.com/absmach/magistrala/certs/events"
 	pki "github.com/absmach/magistrala/certs/pki/amcerts"
+	certspg "github.com/absmach/magistrala/certs/postgres"
 	"github.com/absmach/magistrala/certs/tracing"
 	mglog "github.com/absmach/magistrala/logger"
 	authsvcAuthn "github.com/absmach/magistrala/pkg/authn/authsvc"
 	"github.com/absmach/magistrala/pkg/grpcclient"
 	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
+	"github.com/absmach/magistrala/pkg/postgres"
+	pgclient "github.com/absmach/magistrala/pkg/postgres"
 	"github.com/absmach/magistrala/pkg/prometheus"
 	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
 	"github.com/absmach/magistrala/pkg/server"
+	grpcserver "github.com/absmach/magistrala/pkg/server/grpc"
 	httpserver "github.com/absmach/magistrala/pkg/server/http"
 	"github.com/absmach/magistrala/pkg/uuid"
 	"github.com/caarlos0/env/v11"
+	"github.com/jmoiron/sqlx"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 const (
 	svcName        = "certs"
-	envPrefixDB    = "MG_CERTS_DB_"
+	envPrefixDB    = "MG_CERTS_EST_DB_"
 	envPrefixHTTP  = "MG_CERTS_HTTP_"
+	envPrefixGRPC  = "MG_CERTS_GRPC_"
 	envPrefixAuth  = "MG_AUTH_GRPC_"
-	defDB          = "certs"
+	defDB          = "certs_est"
 	defSvcHTTPPort = "9019"
+	defSvcGRPCPort = "7019"
 )
 
 type config struct {
 	LogLevel      string  `env:"MG_CERTS_LOG_LEVEL"        envDefault:"info"`
 	ThingsURL     string  `env:"MG_THINGS_URL"             envDefault:"http://localhost:9000"`
+	BootstrapURL  string  `env:"MG_BOOTSTRAP_URL"          envDefault:"http://localhost:9013"`
 	JaegerURL     url.URL `env:"MG_JAEGER_URL"             envDefault:"http://localhost:4318/v1/traces"`
 	SendTelemetry bool    `env:"MG_SEND_TELEMETRY"         envDefault:"true"`
 	InstanceID    string  `env:"MG_CERTS_INSTANCE_ID"      envDefault:""`
 	TraceRatio    float64 `env:"MG_JAEGER_TRACE_RATIO"     envDefault:"1.0"`
+	ESURL         string  `env:"MG_ES_URL"                 envDefault:"nats://localhost:4222"`
 
 	// Sign and issue certificates without 3rd party PKI
-	SignCAPath    string `env:"MG_CERTS_SIGN_CA_PATH"        envDefault:"ca.crt"`
-	SignCAKeyPath string `env:"MG_CERTS_SIGN_CA_KEY_PATH"    envDefault:"ca.key"`
+	SignCAPath    string        `env:"MG_CERTS_SIGN_CA_PATH"        envDefault:"ca.crt"`
+	SignCAKeyPath string        `env:"MG_CERTS_SIGN_CA_KEY_PATH"    envDefault:"ca.key"`
+	ESTCertTTL    time.Duration `env:"MG_CERTS_EST_CERT_TTL"        envDefault:"2160h"`
 
 	// Amcerts SDK settings
 	SDKHost         string `env:"MG_CERTS_SDK_HOST"             envDefault:""`
@@ -97,6 +112,30 @@ func main() {
 		return
 	}
 
+	// Enrollment over EST signs device CSRs with the local CA, so it is
+	// only available when the signing CA is configured.
+	enrollment := certs.EnrollmentConfig{TTL: cfg.ESTCertTTL}
+	ca, caCert, err := certs.LoadCertificates(cfg.SignCAPath, cfg.SignCAKeyPath)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("failed to load signing CA, EST enrollment is disabled: %s", err))
+	} else {
+		enrollment.CA, enrollment.CACert = ca, caCert
+	}
+
+	dbConfig := pgclient.Config{Name: defDB}
+	if err := env.ParseWithOptions(&dbConfig, env.Options{Prefix: envPrefixDB}); err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	db, err := pgclient.Setup(dbConfig, *certspg.Migration())
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer db.Close()
+
 	grpcCfg := grpcclient.Config{}
 	if err := env.ParseWithOptions(&grpcCfg, env.Options{Prefix: envPrefixAuth}); err != nil {
 		logger.Error(fmt.Sprintf("failed to load auth gRPC client configuration : %s", err))
@@ -125,7 +164,12 @@ func main() {
 	}()
 	tracer := tp.Tracer(svcName)
 
-	svc := newService(tracer, logger, cfg, pkiclient)
+	svc, err := newService(ctx, db, dbConfig, tracer, logger, cfg, pkiclient, enrollment)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create %s service: %s", svcName, err))
+		exitCode = 1
+		return
+	}
 
 	httpServerConfig := server.Config{Port: defSvcHTTPPort}
 	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
@@ -135,6 +179,18 @@ func main() {
 	}
 	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(svc, authn, logger, cfg.InstanceID), logger)
 
+	grpcServerConfig := server.Config{Port: defSvcGRPCPort}
+	if err := env.ParseWithOptions(&grpcServerConfig, env.Options{Prefix: envPrefixGRPC}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s gRPC server configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	registerCertsServer := func(srv *grpc.Server) {
+		reflection.Register(srv)
+		magistrala.RegisterCertsServiceServer(srv, grpcapi.NewServer(svc))
+	}
+	gs := grpcserver.NewServer(ctx, cancel, svcName, grpcServerConfig, registerCertsServer, logger)
+
 	if cfg.SendTelemetry {
 		chc := chclient.New(svcName, magistrala.Version, logger, cancel)
 		go chc.CallHome(ctx)
@@ -143,9 +199,12 @@ func main() {
 	g.Go(func() error {
 		return hs.Start()
 	})
+	g.Go(func() error {
+		return gs.Start()
+	})
 
 	g.Go(func() error {
-		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs)
+		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs, gs)
 	})
 
 	if err := g.Wait(); err != nil {
@@ -153,16 +212,23 @@ func main() {
 	}
 }
 
-func newService(tracer trace.Tracer, logger *slog.Logger, cfg config, pkiAgent pki.Agent) certs.Service {
+func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, tracer trace.Tracer, logger *slog.Logger, cfg config, pkiAgent pki.Agent, enrollment certs.EnrollmentConfig) (certs.Service, error) {
+	database := postgres.NewDatabase(db, dbConfig, tracer)
+	repo := certspg.NewRepository(database)
 	config := mgsdk.Config{
-		ThingsURL: cfg.ThingsURL,
+		ThingsURL:    cfg.ThingsURL,
+		BootstrapURL: cfg.BootstrapURL,
 	}
 	sdk := mgsdk.NewSDK(config)
-	svc := certs.New(sdk, pkiAgent)
+	svc := certs.New(sdk, pkiAgent, repo, enrollment)
+	svc, err := events.NewEventStoreMiddleware(ctx, svc, cfg.ESURL)
+	if err != nil {
+		return nil, err
+	}
 	svc = api.LoggingMiddleware(svc, logger)
 	counter, latency := prometheus.MakeMetrics(svcName, "api")
 	svc = api.MetricsMiddleware(svc, counter, latency)
 	svc = tracing.New(svc, tracer)
 
-	return svc
+	return svc, nil
 }
@@ -48,6 +48,7 @@ func main() {
 	configCmd := cli.NewConfigCmd()
 	invitationsCmd := cli.NewInvitationsCmd()
 	journalCmd := cli.NewJournalCmd()
+	twinsCmd := cli.NewTwinsCmd()
 
 	// Root Commands
 	rootCmd.AddCommand(healthCmd)
@@ -64,6 +65,7 @@ func main() {
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(invitationsCmd)
 	rootCmd.AddCommand(journalCmd)
+	rootCmd.AddCommand(twinsCmd)
 
 	// Root Flags
 	rootCmd.PersistentFlags().StringVarP(
@@ -138,6 +140,14 @@ func main() {
 		"Journal Log URL",
 	)
 
+	rootCmd.PersistentFlags().StringVarP(
+		&sdkConf.TwinsURL,
+		"twins-url",
+		"w",
+		sdkConf.TwinsURL,
+		"Twins service URL",
+	)
+
 	rootCmd.PersistentFlags().StringVarP(
 		&sdkConf.HostURL,
 		"host-url",
@@ -6,10 +6,12 @@ package main
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 	"log"
 	"net/url"
 	"os"
+	"time"
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/magistrala"
@@ -17,11 +19,20 @@ import (
 	"github.com/absmach/magistrala/coap/api"
 	"github.com/absmach/magistrala/coap/tracing"
 	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/authzcache"
+	authzevents "github.com/absmach/magistrala/pkg/authzcache/events"
+	"github.com/absmach/magistrala/pkg/events/store"
 	"github.com/absmach/magistrala/pkg/grpcclient"
 	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
 	"github.com/absmach/magistrala/pkg/messaging/brokers"
 	brokerstracing "github.com/absmach/magistrala/pkg/messaging/brokers/tracing"
+	"github.com/absmach/magistrala/pkg/presence"
 	"github.com/absmach/magistrala/pkg/prometheus"
+	"github.com/absmach/magistrala/pkg/retained"
+	retainedevents "github.com/absmach/magistrala/pkg/retained/events"
+	retainedstore "github.com/absmach/magistrala/pkg/retained/store"
+	"github.com/absmach/magistrala/pkg/schema"
+	schemaevents "github.com/absmach/magistrala/pkg/schema/events"
 	"github.com/absmach/magistrala/pkg/server"
 	coapserver "github.com/absmach/magistrala/pkg/server/coap"
 	httpserver "github.com/absmach/magistrala/pkg/server/http"
@@ -34,18 +45,31 @@ const (
 	svcName         = "coap_adapter"
 	envPrefix       = "MG_COAP_ADAPTER_"
 	envPrefixHTTP   = "MG_COAP_ADAPTER_HTTP_"
+	envPrefixDTLS   = "MG_COAP_ADAPTER_DTLS_"
 	envPrefixThings = "MG_THINGS_AUTH_GRPC_"
+	envPrefixCerts  = "MG_CERTS_GRPC_"
 	defSvcHTTPPort  = "5683"
 	defSvcCoAPPort  = "5683"
+	defSvcDTLSPort  = "5684"
+	dtlsModePSK     = "psk"
+	dtlsModeCert    = "cert"
 )
 
 type config struct {
-	LogLevel      string  `env:"MG_COAP_ADAPTER_LOG_LEVEL"   envDefault:"info"`
-	BrokerURL     string  `env:"MG_MESSAGE_BROKER_URL"       envDefault:"nats://localhost:4222"`
-	JaegerURL     url.URL `env:"MG_JAEGER_URL"               envDefault:"http://localhost:4318/v1/traces"`
-	SendTelemetry bool    `env:"MG_SEND_TELEMETRY"           envDefault:"true"`
-	InstanceID    string  `env:"MG_COAP_ADAPTER_INSTANCE_ID" envDefault:""`
-	TraceRatio    float64 `env:"MG_JAEGER_TRACE_RATIO"       envDefault:"1.0"`
+	LogLevel         string        `env:"MG_COAP_ADAPTER_LOG_LEVEL"          envDefault:"info"`
+	BrokerURL        string        `env:"MG_MESSAGE_BROKER_URL"              envDefault:"nats://localhost:4222"`
+	ESURL            string        `env:"MG_ES_URL"                          envDefault:"nats://localhost:4222"`
+	RetainedURL      string        `env:"MG_RETAINED_URL"                    envDefault:"nats://localhost:4222"`
+	JaegerURL        url.URL       `env:"MG_JAEGER_URL"                      envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry    bool          `env:"MG_SEND_TELEMETRY"                  envDefault:"true"`
+	InstanceID       string        `env:"MG_COAP_ADAPTER_INSTANCE_ID"        envDefault:""`
+	AuthzCacheTTL    time.Duration `env:"MG_COAP_ADAPTER_AUTHZ_CACHE_TTL"    envDefault:"30s"`
+	SchemaCacheTTL   time.Duration `env:"MG_COAP_ADAPTER_SCHEMA_CACHE_TTL"   envDefault:"1m"`
+	RetainedCacheTTL time.Duration `env:"MG_COAP_ADAPTER_RETAINED_CACHE_TTL" envDefault:"1m"`
+	PresenceInterval time.Duration `env:"MG_COAP_ADAPTER_PRESENCE_INTERVAL"  envDefault:"1m"`
+	DTLSMode         string        `env:"MG_COAP_ADAPTER_DTLS_MODE"          envDefault:""`
+	ConfirmInterval  time.Duration `env:"MG_COAP_ADAPTER_CONFIRM_INTERVAL"   envDefault:"1m"`
+	TraceRatio       float64       `env:"MG_JAEGER_TRACE_RATIO"              envDefault:"1.0"`
 }
 
 func main() {
@@ -65,6 +89,11 @@ func main() {
 	var exitCode int
 	defer mglog.ExitWithError(&exitCode)
 
+	if cfg.AuthzCacheTTL > 0 && cfg.InstanceID == "" {
+		logger.Error("MG_COAP_ADAPTER_INSTANCE_ID must be set when the authorization cache is enabled")
+		exitCode = 1
+		return
+	}
 	if cfg.InstanceID == "" {
 		if cfg.InstanceID, err = uuid.New().ID(); err != nil {
 			logger.Error(fmt.Sprintf("failed to generate instanceID: %s", err))
@@ -87,6 +116,20 @@ func main() {
 		return
 	}
 
+	transport := coapserver.Transport{}
+	if err := env.ParseWithOptions(&transport, env.Options{Prefix: envPrefix}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s CoAP transport configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	dtlsServerConfig := server.Config{Port: defSvcDTLSPort}
+	if err := env.ParseWithOptions(&dtlsServerConfig, env.Options{Prefix: envPrefixDTLS}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s DTLS server configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
 	thingsClientCfg := grpcclient.Config{}
 	if err := env.ParseWithOptions(&thingsClientCfg, env.Options{Prefix: envPrefixThings}); err != nil {
 		logger.Error(fmt.Sprintf("failed to load %s auth configuration : %s", svcName, err))
@@ -126,7 +169,53 @@ func main() {
 	defer nps.Close()
 	nps = brokerstracing.NewPubSub(coapServerConfig, tracer, nps)
 
-	svc := coap.New(thingsClient, nps)
+	es, err := presence.NewEventStore(ctx, cfg.ESURL, presence.CoAP, cfg.InstanceID, cfg.PresenceInterval)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create %s event store : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	schemas := schema.NewCache(thingsClient, cfg.SchemaCacheTTL)
+	subscriber, err := store.NewSubscriber(ctx, cfg.ESURL, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create event store subscriber: %s", err))
+		exitCode = 1
+		return
+	}
+	defer subscriber.Close()
+	if err := schemaevents.Start(ctx, svcName, subscriber, schemas); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to channel schema events: %s", err))
+		exitCode = 1
+		return
+	}
+
+	retainedRepo, retainedStore, err := retainedstore.New(ctx, cfg.RetainedURL)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to retained messages store: %s", err))
+		exitCode = 1
+		return
+	}
+	defer retainedStore.Close()
+	retainedChannels := retained.NewChannels(thingsClient, cfg.RetainedCacheTTL)
+	if err := retainedevents.Start(ctx, svcName+"-retained", subscriber, retainedChannels, retainedRepo); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to channel retention events: %s", err))
+		exitCode = 1
+		return
+	}
+	nps = retained.NewPubSub(nps, retainedRepo, retainedChannels, logger)
+
+	if cfg.AuthzCacheTTL > 0 {
+		authzCache := authzcache.NewThingsClient(thingsClient, cfg.AuthzCacheTTL, authzcache.MakeMetrics(svcName))
+		if err := authzevents.Start(ctx, fmt.Sprintf("%s-authz-%s", svcName, cfg.InstanceID), subscriber, authzCache); err != nil {
+			logger.Error(fmt.Sprintf("failed to subscribe to authorization cache events: %s", err))
+			exitCode = 1
+			return
+		}
+		thingsClient = authzCache
+	}
+
+	svc := coap.New(thingsClient, nps, schemas, es)
 
 	svc = tracing.New(tracer, svc)
 
@@ -137,21 +226,76 @@ func main() {
 
 	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(cfg.InstanceID), logger)
 
-	cs := coapserver.NewServer(ctx, cancel, svcName, coapServerConfig, api.MakeCoAPHandler(svc, logger), logger)
+	var (
+		dtls      *api.DTLS
+		psk       coapserver.PSKCallback
+		clientCAs []*x509.Certificate
+	)
+	switch cfg.DTLSMode {
+	case "":
+	case dtlsModePSK:
+		dtls = api.NewDTLS(thingsClient, nil)
+		psk = dtls.PSK
+	case dtlsModeCert:
+		certsClientCfg := grpcclient.Config{}
+		if err := env.ParseWithOptions(&certsClientCfg, env.Options{Prefix: envPrefixCerts}); err != nil {
+			logger.Error(fmt.Sprintf("failed to load %s certs configuration : %s", svcName, err))
+			exitCode = 1
+			return
+		}
+		certsClient, certsHandler, err := grpcclient.SetupCertsClient(ctx, certsClientCfg)
+		if err != nil {
+			logger.Error(err.Error())
+			exitCode = 1
+			return
+		}
+		defer certsHandler.Close()
+
+		logger.Info("Certs service gRPC client successfully connected to certs gRPC server " + certsHandler.Secure())
+
+		// Certificates enrolled over EST are signed by the enrollment CA, so it
+		// is trusted in addition to the configured client CA.
+		cas, err := certsClient.EnrollmentCACerts(ctx, &magistrala.EnrollmentCACertsReq{})
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to retrieve enrollment CA certificates: %s", err))
+			exitCode = 1
+			return
+		}
+		for _, raw := range cas.GetCerts() {
+			ca, err := x509.ParseCertificate(raw)
+			if err != nil {
+				logger.Error(fmt.Sprintf("failed to parse enrollment CA certificate: %s", err))
+				exitCode = 1
+				return
+			}
+			clientCAs = append(clientCAs, ca)
+		}
+		dtls = api.NewDTLS(thingsClient, certsClient)
+	default:
+		logger.Error(fmt.Sprintf("invalid DTLS mode %q, expected %q or %q", cfg.DTLSMode, dtlsModePSK, dtlsModeCert))
+		exitCode = 1
+		return
+	}
+
+	coapHandler := api.MakeCoAPHandler(svc, dtls, cfg.ConfirmInterval, logger)
+	cs := coapserver.NewServer(ctx, cancel, svcName, coapServerConfig, transport, coapHandler, logger)
+	servers := []server.Server{hs, cs}
+	if dtls != nil {
+		servers = append(servers, coapserver.NewDTLSServer(ctx, cancel, svcName, dtlsServerConfig, transport, psk, clientCAs, coapHandler, logger))
+	}
 
 	if cfg.SendTelemetry {
 		chc := chclient.New(svcName, magistrala.Version, logger, cancel)
 		go chc.CallHome(ctx)
 	}
 
+	for _, s := range servers {
+		g.Go(func() error {
+			return s.Start()
+		})
+	}
 	g.Go(func() error {
-		return hs.Start()
-	})
-	g.Go(func() error {
-		return cs.Start()
-	})
-	g.Go(func() error {
-		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs, cs)
+		return server.StopSignalHandler(ctx, cancel, logger, svcName, servers...)
 	})
 
 	if err := g.Wait(); err != nil {
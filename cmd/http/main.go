@@ -13,19 +13,30 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"time"
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/magistrala"
 	adapter "github.com/absmach/magistrala/http"
 	"github.com/absmach/magistrala/http/api"
+	"github.com/absmach/magistrala/http/tracing"
 	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/authzcache"
+	authzevents "github.com/absmach/magistrala/pkg/authzcache/events"
+	"github.com/absmach/magistrala/pkg/events/store"
 	"github.com/absmach/magistrala/pkg/grpcclient"
 	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
 	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/absmach/magistrala/pkg/messaging/brokers"
 	brokerstracing "github.com/absmach/magistrala/pkg/messaging/brokers/tracing"
 	"github.com/absmach/magistrala/pkg/messaging/handler"
+	"github.com/absmach/magistrala/pkg/presence"
 	"github.com/absmach/magistrala/pkg/prometheus"
+	"github.com/absmach/magistrala/pkg/retained"
+	retainedevents "github.com/absmach/magistrala/pkg/retained/events"
+	retainedstore "github.com/absmach/magistrala/pkg/retained/store"
+	"github.com/absmach/magistrala/pkg/schema"
+	schemaevents "github.com/absmach/magistrala/pkg/schema/events"
 	"github.com/absmach/magistrala/pkg/server"
 	httpserver "github.com/absmach/magistrala/pkg/server/http"
 	"github.com/absmach/magistrala/pkg/uuid"
@@ -47,12 +58,19 @@ const (
 )
 
 type config struct {
-	LogLevel      string  `env:"MG_HTTP_ADAPTER_LOG_LEVEL"   envDefault:"info"`
-	BrokerURL     string  `env:"MG_MESSAGE_BROKER_URL"       envDefault:"nats://localhost:4222"`
-	JaegerURL     url.URL `env:"MG_JAEGER_URL"               envDefault:"http://localhost:4318/v1/traces"`
-	SendTelemetry bool    `env:"MG_SEND_TELEMETRY"           envDefault:"true"`
-	InstanceID    string  `env:"MG_HTTP_ADAPTER_INSTANCE_ID" envDefault:""`
-	TraceRatio    float64 `env:"MG_JAEGER_TRACE_RATIO"       envDefault:"1.0"`
+	LogLevel         string        `env:"MG_HTTP_ADAPTER_LOG_LEVEL"          envDefault:"info"`
+	BrokerURL        string        `env:"MG_MESSAGE_BROKER_URL"              envDefault:"nats://localhost:4222"`
+	ESURL            string        `env:"MG_ES_URL"                          envDefault:"nats://localhost:4222"`
+	RetainedURL      string        `env:"MG_RETAINED_URL"                    envDefault:"nats://localhost:4222"`
+	JaegerURL        url.URL       `env:"MG_JAEGER_URL"                      envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry    bool          `env:"MG_SEND_TELEMETRY"                  envDefault:"true"`
+	InstanceID       string        `env:"MG_HTTP_ADAPTER_INSTANCE_ID"        envDefault:""`
+	AuthzCacheTTL    time.Duration `env:"MG_HTTP_ADAPTER_AUTHZ_CACHE_TTL"    envDefault:"30s"`
+	SchemaCacheTTL   time.Duration `env:"MG_HTTP_ADAPTER_SCHEMA_CACHE_TTL"   envDefault:"1m"`
+	RetainedCacheTTL time.Duration `env:"MG_HTTP_ADAPTER_RETAINED_CACHE_TTL" envDefault:"1m"`
+	PresenceInterval time.Duration `env:"MG_HTTP_ADAPTER_PRESENCE_INTERVAL"  envDefault:"1m"`
+	PollTimeout      time.Duration `env:"MG_HTTP_ADAPTER_POLL_TIMEOUT"       envDefault:"30s"`
+	TraceRatio       float64       `env:"MG_JAEGER_TRACE_RATIO"              envDefault:"1.0"`
 }
 
 func main() {
@@ -72,6 +90,11 @@ func main() {
 	var exitCode int
 	defer mglog.ExitWithError(&exitCode)
 
+	if cfg.AuthzCacheTTL > 0 && cfg.InstanceID == "" {
+		logger.Error("MG_HTTP_ADAPTER_INSTANCE_ID must be set when the authorization cache is enabled")
+		exitCode = 1
+		return
+	}
 	if cfg.InstanceID == "" {
 		if cfg.InstanceID, err = uuid.New().ID(); err != nil {
 			logger.Error(fmt.Sprintf("failed to generate instanceID: %s", err))
@@ -126,10 +149,67 @@ func main() {
 	defer pub.Close()
 	pub = brokerstracing.NewPublisher(httpServerConfig, tracer, pub)
 
-	svc := newService(pub, thingsClient, logger, tracer)
+	nps, err := brokers.NewPubSub(ctx, cfg.BrokerURL, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to message broker: %s", err))
+		exitCode = 1
+		return
+	}
+	defer nps.Close()
+	nps = brokerstracing.NewPubSub(httpServerConfig, tracer, nps)
+
+	es, err := presence.NewEventStore(ctx, cfg.ESURL, presence.HTTP, cfg.InstanceID, cfg.PresenceInterval)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create %s event store : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	schemas := schema.NewCache(thingsClient, cfg.SchemaCacheTTL)
+	subscriber, err := store.NewSubscriber(ctx, cfg.ESURL, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create event store subscriber: %s", err))
+		exitCode = 1
+		return
+	}
+	defer subscriber.Close()
+	if err := schemaevents.Start(ctx, svcName, subscriber, schemas); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to channel schema events: %s", err))
+		exitCode = 1
+		return
+	}
+
+	retainedRepo, retainedStore, err := retainedstore.New(ctx, cfg.RetainedURL)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to retained messages store: %s", err))
+		exitCode = 1
+		return
+	}
+	defer retainedStore.Close()
+	retainedChannels := retained.NewChannels(thingsClient, cfg.RetainedCacheTTL)
+	if err := retainedevents.Start(ctx, svcName+"-retained", subscriber, retainedChannels, retainedRepo); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to channel retention events: %s", err))
+		exitCode = 1
+		return
+	}
+	pub = retained.NewPublisher(pub, retainedRepo, retainedChannels, logger)
+	nps = retained.NewPubSub(nps, retainedRepo, retainedChannels, logger)
+
+	if cfg.AuthzCacheTTL > 0 {
+		authzCache := authzcache.NewThingsClient(thingsClient, cfg.AuthzCacheTTL, authzcache.MakeMetrics(svcName))
+		if err := authzevents.Start(ctx, fmt.Sprintf("%s-authz-%s", svcName, cfg.InstanceID), subscriber, authzCache); err != nil {
+			logger.Error(fmt.Sprintf("failed to subscribe to authorization cache events: %s", err))
+			exitCode = 1
+			return
+		}
+		thingsClient = authzCache
+	}
+
+	svc := newService(pub, es, thingsClient, schemas, logger, tracer)
+	asvc := newAdapterService(thingsClient, nps, schemas, es, logger, tracer)
 	targetServerCfg := server.Config{Port: targetHTTPPort}
 
-	hs := httpserver.NewServer(ctx, cancel, svcName, targetServerCfg, api.MakeHandler(logger, cfg.InstanceID), logger)
+	hs := httpserver.NewServer(ctx, cancel, svcName, targetServerCfg, api.MakeHandler(asvc, cfg.PollTimeout, logger, cfg.InstanceID), logger)
 
 	if cfg.SendTelemetry {
 		chc := chclient.New(svcName, magistrala.Version, logger, cancel)
@@ -153,8 +233,8 @@ func main() {
 	}
 }
 
-func newService(pub messaging.Publisher, tc magistrala.ThingsServiceClient, logger *slog.Logger, tracer trace.Tracer) session.Handler {
-	svc := adapter.NewHandler(pub, logger, tc)
+func newService(pub messaging.Publisher, es presence.EventStore, tc magistrala.ThingsServiceClient, validator schema.Validator, logger *slog.Logger, tracer trace.Tracer) session.Handler {
+	svc := adapter.NewHandler(pub, es, logger, tc, validator)
 	svc = handler.NewTracing(tracer, svc)
 	svc = handler.LoggingMiddleware(svc, logger)
 	counter, latency := prometheus.MakeMetrics(svcName, "api")
@@ -162,6 +242,15 @@ func newService(pub messaging.Publisher, tc magistrala.ThingsServiceClient, logg
 	return svc
 }
 
+func newAdapterService(tc magistrala.ThingsServiceClient, nps messaging.PubSub, validator schema.Validator, es presence.EventStore, logger *slog.Logger, tracer trace.Tracer) adapter.Service {
+	svc := adapter.New(tc, nps, validator, es, uuid.New(), logger)
+	svc = tracing.New(tracer, svc)
+	svc = api.LoggingMiddleware(svc, logger)
+	counter, latency := prometheus.MakeMetrics(svcName, "service")
+	svc = api.MetricsMiddleware(svc, counter, latency)
+	return svc
+}
+
 func proxyHTTP(ctx context.Context, cfg server.Config, logger *slog.Logger, sessionHandler session.Handler) error {
 	config := mgate.Config{
 		Address:    fmt.Sprintf("%s:%s", "", cfg.Port),
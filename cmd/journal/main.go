@@ -6,11 +6,15 @@ package main
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"log"
 	"log/slog"
 	"net/url"
 	"os"
+	"time"
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/magistrala"
@@ -19,6 +23,8 @@ import (
 	"github.com/absmach/magistrala/journal/events"
 	"github.com/absmach/magistrala/journal/middleware"
 	journalpg "github.com/absmach/magistrala/journal/postgres"
+	"github.com/absmach/magistrala/journal/storage"
+	"github.com/absmach/magistrala/journal/syslog"
 	mglog "github.com/absmach/magistrala/logger"
 	authsvcAuthn "github.com/absmach/magistrala/pkg/authn/authsvc"
 	mgauthz "github.com/absmach/magistrala/pkg/authz"
@@ -48,12 +54,19 @@ const (
 )
 
 type config struct {
-	LogLevel      string  `env:"MG_JOURNAL_LOG_LEVEL"   envDefault:"info"`
-	ESURL         string  `env:"MG_ES_URL"              envDefault:"nats://localhost:4222"`
-	JaegerURL     url.URL `env:"MG_JAEGER_URL"          envDefault:"http://localhost:4318/v1/traces"`
-	SendTelemetry bool    `env:"MG_SEND_TELEMETRY"      envDefault:"true"`
-	InstanceID    string  `env:"MG_JOURNAL_INSTANCE_ID" envDefault:""`
-	TraceRatio    float64 `env:"MG_JAEGER_TRACE_RATIO"  envDefault:"1.0"`
+	LogLevel           string        `env:"MG_JOURNAL_LOG_LEVEL"           envDefault:"info"`
+	ESURL              string        `env:"MG_ES_URL"                      envDefault:"nats://localhost:4222"`
+	JaegerURL          url.URL       `env:"MG_JAEGER_URL"                  envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry      bool          `env:"MG_SEND_TELEMETRY"              envDefault:"true"`
+	InstanceID         string        `env:"MG_JOURNAL_INSTANCE_ID"         envDefault:""`
+	TraceRatio         float64       `env:"MG_JAEGER_TRACE_RATIO"          envDefault:"1.0"`
+	SigningKey         string        `env:"MG_JOURNAL_SIGNING_KEY_FILE"    envDefault:""`
+	ArchiveDir         string        `env:"MG_JOURNAL_ARCHIVE_DIR"         envDefault:"./journal-archives"`
+	RetentionInterval  time.Duration `env:"MG_JOURNAL_RETENTION_INTERVAL"  envDefault:"1h"`
+	CheckpointDir      string        `env:"MG_JOURNAL_CHECKPOINT_DIR"      envDefault:"./journal-checkpoints"`
+	CheckpointInterval time.Duration `env:"MG_JOURNAL_CHECKPOINT_INTERVAL" envDefault:"1m"`
+	SyslogURL          string        `env:"MG_JOURNAL_SYSLOG_URL"          envDefault:""`
+	SyslogFormat       string        `env:"MG_JOURNAL_SYSLOG_FORMAT"       envDefault:"rfc5424"`
 }
 
 func main() {
@@ -81,6 +94,16 @@ func main() {
 		}
 	}
 
+	signingKey, err := loadSigningKey(cfg.SigningKey)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to load journal signing key: %s", err))
+		exitCode = 1
+		return
+	}
+	if signingKey == nil {
+		logger.Warn("journal signing key is not configured, journal exports are disabled")
+	}
+
 	dbConfig := pgclient.Config{Name: defDB}
 	if err := env.ParseWithOptions(&dbConfig, env.Options{Prefix: envPrefixDB}); err != nil {
 		logger.Error(err.Error())
@@ -133,7 +156,31 @@ func main() {
 	}()
 	tracer := tp.Tracer(svcName)
 
-	svc := newService(db, dbConfig, authz, logger, tracer)
+	archives, err := storage.NewFS(cfg.ArchiveDir)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create journal archive storage: %s", err))
+		exitCode = 1
+		return
+	}
+
+	checkpoints, err := storage.NewFSCheckpoints(cfg.CheckpointDir)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create journal checkpoint storage: %s", err))
+		exitCode = 1
+		return
+	}
+
+	hub := journal.NewHub()
+	if cfg.SyslogURL != "" {
+		if err := syslog.Start(ctx, hub, cfg.SyslogURL, cfg.SyslogFormat, logger); err != nil {
+			logger.Error(fmt.Sprintf("failed to start journal syslog forwarder: %s", err))
+			exitCode = 1
+			return
+		}
+		logger.Info("Forwarding journals to syslog server " + cfg.SyslogURL)
+	}
+
+	svc := newService(ctx, db, dbConfig, authz, hub, archives, checkpoints, signingKey, cfg.RetentionInterval, cfg.CheckpointInterval, logger, tracer)
 
 	subscriber, err := store.NewSubscriber(ctx, cfg.ESURL, logger)
 	if err != nil {
@@ -177,12 +224,15 @@ func main() {
 	}
 }
 
-func newService(db *sqlx.DB, dbConfig pgclient.Config, authz mgauthz.Authorization, logger *slog.Logger, tracer trace.Tracer) journal.Service {
+func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, authz mgauthz.Authorization, hub *journal.Hub, archives journal.Storage, checkpoints journal.Checkpoints, signingKey ed25519.PrivateKey, retentionInterval, checkpointInterval time.Duration, logger *slog.Logger, tracer trace.Tracer) journal.Service {
 	database := postgres.NewDatabase(db, dbConfig, tracer)
 	repo := journalpg.NewRepository(database)
 	idp := uuid.New()
 
-	svc := journal.NewService(idp, repo)
+	journal.NewRetentionHandler(ctx, repo, archives, signingKey, retentionInterval, logger)
+	journal.NewCheckpointHandler(ctx, repo, checkpoints, signingKey, checkpointInterval, logger)
+
+	svc := journal.NewService(idp, repo, checkpoints, hub, signingKey)
 	svc = middleware.AuthorizationMiddleware(svc, authz)
 	svc = middleware.LoggingMiddleware(svc, logger)
 	counter, latency := prometheus.MakeMetrics("journal", "journal_writer")
@@ -191,3 +241,28 @@ func newService(db *sqlx.DB, dbConfig pgclient.Config, authz mgauthz.Authorizati
 
 	return svc
 }
+
+// loadSigningKey reads the PEM encoded PKCS #8 Ed25519 private key.
+func loadSigningKey(path string) (ed25519.PrivateKey, error) {
+	if path == "" {
+		return nil, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", path)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an Ed25519 private key", path)
+	}
+
+	return edKey, nil
+}
@@ -21,15 +21,24 @@ import (
 	"github.com/absmach/magistrala"
 	mglog "github.com/absmach/magistrala/logger"
 	"github.com/absmach/magistrala/mqtt"
-	"github.com/absmach/magistrala/mqtt/events"
 	mqtttracing "github.com/absmach/magistrala/mqtt/tracing"
+	"github.com/absmach/magistrala/pkg/authzcache"
+	authzevents "github.com/absmach/magistrala/pkg/authzcache/events"
 	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/events/store"
 	"github.com/absmach/magistrala/pkg/grpcclient"
 	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
+	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/absmach/magistrala/pkg/messaging/brokers"
 	brokerstracing "github.com/absmach/magistrala/pkg/messaging/brokers/tracing"
 	"github.com/absmach/magistrala/pkg/messaging/handler"
 	mqttpub "github.com/absmach/magistrala/pkg/messaging/mqtt"
+	"github.com/absmach/magistrala/pkg/presence"
+	"github.com/absmach/magistrala/pkg/retained"
+	retainedevents "github.com/absmach/magistrala/pkg/retained/events"
+	retainedstore "github.com/absmach/magistrala/pkg/retained/store"
+	"github.com/absmach/magistrala/pkg/schema"
+	schemaevents "github.com/absmach/magistrala/pkg/schema/events"
 	"github.com/absmach/magistrala/pkg/server"
 	"github.com/absmach/magistrala/pkg/uuid"
 	mgate "github.com/absmach/mgate"
@@ -64,7 +73,12 @@ type config struct {
 	BrokerURL             string        `env:"MG_MESSAGE_BROKER_URL"                        envDefault:"nats://localhost:4222"`
 	SendTelemetry         bool          `env:"MG_SEND_TELEMETRY"                            envDefault:"true"`
 	InstanceID            string        `env:"MG_MQTT_ADAPTER_INSTANCE_ID"                  envDefault:""`
+	AuthzCacheTTL         time.Duration `env:"MG_MQTT_ADAPTER_AUTHZ_CACHE_TTL"              envDefault:"30s"`
+	SchemaCacheTTL        time.Duration `env:"MG_MQTT_ADAPTER_SCHEMA_CACHE_TTL"             envDefault:"1m"`
+	RetainedCacheTTL      time.Duration `env:"MG_MQTT_ADAPTER_RETAINED_CACHE_TTL"           envDefault:"1m"`
+	PresenceInterval      time.Duration `env:"MG_MQTT_ADAPTER_PRESENCE_INTERVAL"            envDefault:"1m"`
 	ESURL                 string        `env:"MG_ES_URL"                                    envDefault:"nats://localhost:4222"`
+	RetainedURL           string        `env:"MG_RETAINED_URL"                                    envDefault:"nats://localhost:4222"`
 	TraceRatio            float64       `env:"MG_JAEGER_TRACE_RATIO"                        envDefault:"1.0"`
 }
 
@@ -85,6 +99,11 @@ func main() {
 	var exitCode int
 	defer mglog.ExitWithError(&exitCode)
 
+	if cfg.AuthzCacheTTL > 0 && cfg.InstanceID == "" {
+		logger.Error("MG_MQTT_ADAPTER_INSTANCE_ID must be set when the authorization cache is enabled")
+		exitCode = 1
+		return
+	}
 	if cfg.InstanceID == "" {
 		if cfg.InstanceID, err = uuid.New().ID(); err != nil {
 			logger.Error(fmt.Sprintf("failed to generate instanceID: %s", err))
@@ -133,7 +152,38 @@ func main() {
 	defer bsub.Close()
 	bsub = brokerstracing.NewPubSub(serverConfig, tracer, bsub)
 
-	mpub, err := mqttpub.NewPublisher(fmt.Sprintf("mqtt://%s:%s", cfg.MQTTTargetHost, cfg.MQTTTargetPort), cfg.MQTTQoS, cfg.MQTTForwarderTimeout)
+	thingsClientCfg := grpcclient.Config{}
+	if err := env.ParseWithOptions(&thingsClientCfg, env.Options{Prefix: envPrefixThings}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s auth configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	thingsClient, thingsHandler, err := grpcclient.SetupThingsClient(ctx, thingsClientCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer thingsHandler.Close()
+
+	logger.Info("Things service gRPC client successfully connected to things gRPC server " + thingsHandler.Secure())
+
+	retainedRepo, retainedStore, err := retainedstore.New(ctx, cfg.RetainedURL)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to retained messages store: %s", err))
+		exitCode = 1
+		return
+	}
+	defer retainedStore.Close()
+	retainedChannels := retained.NewChannels(thingsClient, cfg.RetainedCacheTTL)
+
+	// Messages of the channels with enabled retention are forwarded as MQTT
+	// retained messages, so the MQTT broker delivers them to new subscribers.
+	retain := func(msg *messaging.Message) bool {
+		return retainedChannels.Retained(ctx, msg.GetChannel())
+	}
+	mpub, err := mqttpub.NewPublisher(fmt.Sprintf("mqtt://%s:%s", cfg.MQTTTargetHost, cfg.MQTTTargetPort), cfg.MQTTQoS, cfg.MQTTForwarderTimeout, mqttpub.Retain(retain))
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to create MQTT publisher: %s", err))
 		exitCode = 1
@@ -157,48 +207,60 @@ func main() {
 	}
 	defer np.Close()
 	np = brokerstracing.NewPublisher(serverConfig, tracer, np)
+	np = retained.NewPublisher(np, retainedRepo, retainedChannels, logger)
 
-	es, err := events.NewEventStore(ctx, cfg.ESURL, cfg.Instance)
+	es, err := presence.NewEventStore(ctx, cfg.ESURL, presence.MQTT, cfg.Instance, cfg.PresenceInterval)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to create %s event store : %s", svcName, err))
 		exitCode = 1
 		return
 	}
 
-	thingsClientCfg := grpcclient.Config{}
-	if err := env.ParseWithOptions(&thingsClientCfg, env.Options{Prefix: envPrefixThings}); err != nil {
-		logger.Error(fmt.Sprintf("failed to load %s auth configuration : %s", svcName, err))
+	schemas := schema.NewCache(thingsClient, cfg.SchemaCacheTTL)
+	subscriber, err := store.NewSubscriber(ctx, cfg.ESURL, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create event store subscriber: %s", err))
 		exitCode = 1
 		return
 	}
-
-	thingsClient, thingsHandler, err := grpcclient.SetupThingsClient(ctx, thingsClientCfg)
-	if err != nil {
-		logger.Error(err.Error())
+	defer subscriber.Close()
+	if err := schemaevents.Start(ctx, svcName, subscriber, schemas); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to channel schema events: %s", err))
+		exitCode = 1
+		return
+	}
+	if err := retainedevents.Start(ctx, svcName+"-retained", subscriber, retainedChannels, retainedRepo); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to channel retention events: %s", err))
 		exitCode = 1
 		return
 	}
-	defer thingsHandler.Close()
 
-	logger.Info("Things service gRPC client successfully connected to things gRPC server " + thingsHandler.Secure())
+	if cfg.AuthzCacheTTL > 0 {
+		authzCache := authzcache.NewThingsClient(thingsClient, cfg.AuthzCacheTTL, authzcache.MakeMetrics(svcName))
+		if err := authzevents.Start(ctx, fmt.Sprintf("%s-authz-%s", svcName, cfg.InstanceID), subscriber, authzCache); err != nil {
+			logger.Error(fmt.Sprintf("failed to subscribe to authorization cache events: %s", err))
+			exitCode = 1
+			return
+		}
+		thingsClient = authzCache
+	}
 
-	h := mqtt.NewHandler(np, es, logger, thingsClient)
-	h = handler.NewTracing(tracer, h)
+	mh := mqtt.NewHandler(np, es, logger, thingsClient, schemas, retainedChannels)
+	h := handler.NewTracing(tracer, mh)
 
 	if cfg.SendTelemetry {
 		chc := chclient.New(svcName, magistrala.Version, logger, cancel)
 		go chc.CallHome(ctx)
 	}
 
-	var interceptor session.Interceptor
 	logger.Info(fmt.Sprintf("Starting MQTT proxy on port %s", cfg.MQTTPort))
 	g.Go(func() error {
-		return proxyMQTT(ctx, cfg, logger, h, interceptor)
+		return proxyMQTT(ctx, cfg, logger, h, mh)
 	})
 
 	logger.Info(fmt.Sprintf("Starting MQTT over WS  proxy on port %s", cfg.HTTPPort))
 	g.Go(func() error {
-		return proxyWS(ctx, cfg, logger, h, interceptor)
+		return proxyWS(ctx, cfg, logger, h, mh)
 	})
 
 	g.Go(func() error {
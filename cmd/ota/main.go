@@ -0,0 +1,272 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package main contains OTA main function to start the OTA service.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/url"
+	"os"
+
+	chclient "github.com/absmach/callhome/pkg/client"
+	"github.com/absmach/magistrala"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/ota"
+	"github.com/absmach/magistrala/ota/api"
+	"github.com/absmach/magistrala/ota/middleware"
+	otapg "github.com/absmach/magistrala/ota/postgres"
+	"github.com/absmach/magistrala/ota/storage"
+	authsvcAuthn "github.com/absmach/magistrala/pkg/authn/authsvc"
+	mgauthz "github.com/absmach/magistrala/pkg/authz"
+	authsvcAuthz "github.com/absmach/magistrala/pkg/authz/authsvc"
+	"github.com/absmach/magistrala/pkg/grpcclient"
+	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/messaging/brokers"
+	brokerstracing "github.com/absmach/magistrala/pkg/messaging/brokers/tracing"
+	"github.com/absmach/magistrala/pkg/postgres"
+	pgclient "github.com/absmach/magistrala/pkg/postgres"
+	"github.com/absmach/magistrala/pkg/prometheus"
+	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/absmach/magistrala/pkg/server"
+	httpserver "github.com/absmach/magistrala/pkg/server/http"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/caarlos0/env/v11"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	svcName         = "ota"
+	envPrefixDB     = "MG_OTA_DB_"
+	envPrefixHTTP   = "MG_OTA_HTTP_"
+	envPrefixS3     = "MG_OTA_S3_"
+	envPrefixAuth   = "MG_AUTH_GRPC_"
+	envPrefixThings = "MG_THINGS_AUTH_GRPC_"
+	defDB           = "ota"
+	defSvcHTTPPort  = "9029"
+	fsStorage       = "fs"
+	s3Storage       = "s3"
+)
+
+type config struct {
+	LogLevel        string  `env:"MG_OTA_LOG_LEVEL"          envDefault:"info"`
+	DownloadURL     string  `env:"MG_OTA_DOWNLOAD_URL"       envDefault:"http://localhost:9029"`
+	Storage         string  `env:"MG_OTA_STORAGE"            envDefault:"fs"`
+	StorageDir      string  `env:"MG_OTA_STORAGE_DIR"        envDefault:"/firmware"`
+	MaxFirmwareSize int64   `env:"MG_OTA_MAX_FIRMWARE_SIZE"  envDefault:"104857600"`
+	SigningKey      string  `env:"MG_OTA_SIGNING_KEY"        envDefault:""`
+	ThingsURL       string  `env:"MG_THINGS_URL"             envDefault:"http://localhost:9000"`
+	BrokerURL       string  `env:"MG_MESSAGE_BROKER_URL"     envDefault:"nats://localhost:4222"`
+	JaegerURL       url.URL `env:"MG_JAEGER_URL"             envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry   bool    `env:"MG_SEND_TELEMETRY"         envDefault:"true"`
+	InstanceID      string  `env:"MG_OTA_INSTANCE_ID"        envDefault:""`
+	TraceRatio      float64 `env:"MG_JAEGER_TRACE_RATIO"     envDefault:"1.0"`
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	cfg := config{}
+	if err := env.Parse(&cfg); err != nil {
+		log.Fatalf("failed to load %s configuration : %s", svcName, err)
+	}
+
+	logger, err := mglog.New(os.Stdout, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("failed to init logger: %s", err)
+	}
+
+	var exitCode int
+	defer mglog.ExitWithError(&exitCode)
+
+	if cfg.InstanceID == "" {
+		if cfg.InstanceID, err = uuid.New().ID(); err != nil {
+			logger.Error(fmt.Sprintf("failed to generate instanceID: %s", err))
+			exitCode = 1
+			return
+		}
+	}
+
+	httpServerConfig := server.Config{Port: defSvcHTTPPort}
+	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	fwStorage, err := newStorage(cfg)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create firmware storage: %s", err))
+		exitCode = 1
+		return
+	}
+
+	dbConfig := pgclient.Config{Name: defDB}
+	if err := env.ParseWithOptions(&dbConfig, env.Options{Prefix: envPrefixDB}); err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	db, err := pgclient.Setup(dbConfig, *otapg.Migration())
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer db.Close()
+
+	authClientCfg := grpcclient.Config{}
+	if err := env.ParseWithOptions(&authClientCfg, env.Options{Prefix: envPrefixAuth}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load auth gRPC client configuration : %s", err))
+		exitCode = 1
+		return
+	}
+
+	authn, authnHandler, err := authsvcAuthn.NewAuthentication(ctx, authClientCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer authnHandler.Close()
+	logger.Info("AuthN successfully connected to auth gRPC server " + authnHandler.Secure())
+
+	authz, authzHandler, err := authsvcAuthz.NewAuthorization(ctx, authClientCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer authzHandler.Close()
+	logger.Info("AuthZ successfully connected to auth gRPC server " + authzHandler.Secure())
+
+	thingsClientCfg := grpcclient.Config{}
+	if err := env.ParseWithOptions(&thingsClientCfg, env.Options{Prefix: envPrefixThings}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load things gRPC client configuration : %s", err))
+		exitCode = 1
+		return
+	}
+
+	thingsClient, thingsHandler, err := grpcclient.SetupThingsClient(ctx, thingsClientCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer thingsHandler.Close()
+	logger.Info("Things service gRPC client successfully connected to things gRPC server " + thingsHandler.Secure())
+
+	tp, err := jaegerclient.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to init Jaeger: %s", err))
+		exitCode = 1
+		return
+	}
+	defer func() {
+		if err := tp.Shutdown(ctx); err != nil {
+			logger.Error(fmt.Sprintf("error shutting down tracer provider: %s", err))
+		}
+	}()
+	tracer := tp.Tracer(svcName)
+
+	pubSub, err := brokers.NewPubSub(ctx, cfg.BrokerURL, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to message broker: %s", err))
+		exitCode = 1
+		return
+	}
+	defer pubSub.Close()
+	pubSub = brokerstracing.NewPubSub(httpServerConfig, tracer, pubSub)
+
+	verifier, err := newVerifier(cfg)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to load firmware signing key: %s", err))
+		exitCode = 1
+		return
+	}
+
+	svc := newService(db, dbConfig, authz, fwStorage, verifier, pubSub, thingsClient, cfg, logger, tracer)
+
+	subCfg := messaging.SubscriberConfig{
+		ID:      svcName,
+		Topic:   ota.ProgressTopic,
+		Handler: ota.NewProgressHandler(svc),
+	}
+	if err := pubSub.Subscribe(ctx, subCfg); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to update progress: %s", err))
+		exitCode = 1
+		return
+	}
+
+	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(svc, authn, logger, cfg.MaxFirmwareSize, svcName, cfg.InstanceID), logger)
+
+	if cfg.SendTelemetry {
+		chc := chclient.New(svcName, magistrala.Version, logger, cancel)
+		go chc.CallHome(ctx)
+	}
+
+	g.Go(func() error {
+		return hs.Start()
+	})
+
+	g.Go(func() error {
+		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs)
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("%s service terminated: %s", svcName, err))
+	}
+}
+
+func newStorage(cfg config) (ota.Storage, error) {
+	switch cfg.Storage {
+	case fsStorage:
+		return storage.NewFS(cfg.StorageDir)
+	case s3Storage:
+		s3Config := storage.S3Config{}
+		if err := env.ParseWithOptions(&s3Config, env.Options{Prefix: envPrefixS3}); err != nil {
+			return nil, err
+		}
+		return storage.NewS3(s3Config), nil
+	default:
+		return nil, fmt.Errorf("unsupported storage %q", cfg.Storage)
+	}
+}
+
+// newVerifier returns nil if the signing key is not configured, in which case
+// signed firmware is rejected.
+func newVerifier(cfg config) (ota.Verifier, error) {
+	if cfg.SigningKey == "" {
+		return nil, nil
+	}
+	key, err := os.ReadFile(cfg.SigningKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return ota.NewVerifier(key)
+}
+
+func newService(db *sqlx.DB, dbConfig pgclient.Config, authz mgauthz.Authorization, fwStorage ota.Storage, verifier ota.Verifier, pub messaging.Publisher, things magistrala.ThingsServiceClient, cfg config, logger *slog.Logger, tracer trace.Tracer) ota.Service {
+	database := postgres.NewDatabase(db, dbConfig, tracer)
+	firmwareRepo := otapg.NewFirmwareRepository(database)
+	campaignRepo := otapg.NewCampaignRepository(database)
+	idp := uuid.New()
+	sdk := mgsdk.NewSDK(mgsdk.Config{ThingsURL: cfg.ThingsURL})
+
+	svc := ota.New(idp, firmwareRepo, campaignRepo, fwStorage, verifier, pub, sdk, things, cfg.DownloadURL)
+	svc = middleware.AuthorizationMiddleware(svc, authz)
+	svc = middleware.LoggingMiddleware(svc, logger)
+	counter, latency := prometheus.MakeMetrics(svcName, "api")
+	svc = middleware.MetricsMiddleware(svc, counter, latency)
+	svc = middleware.Tracing(svc, tracer)
+
+	return svc
+}
@@ -17,20 +17,25 @@ import (
 	mglog "github.com/absmach/magistrala/logger"
 	"github.com/absmach/magistrala/pkg/errors"
 	mggroups "github.com/absmach/magistrala/pkg/groups"
+	pgclient "github.com/absmach/magistrala/pkg/postgres"
 	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
 	"github.com/absmach/magistrala/pkg/server"
 	httpserver "github.com/absmach/magistrala/pkg/server/http"
 	"github.com/absmach/magistrala/pkg/uuid"
 	"github.com/absmach/magistrala/provision"
 	"github.com/absmach/magistrala/provision/api"
+	provisionpg "github.com/absmach/magistrala/provision/postgres"
 	"github.com/absmach/magistrala/things"
 	"github.com/caarlos0/env/v11"
+	"go.opentelemetry.io/otel/trace/noop"
 	"golang.org/x/sync/errgroup"
 )
 
 const (
 	svcName     = "provision"
 	contentType = "application/json"
+	envPrefixDB = "MG_PROVISION_DB_"
+	defDB       = "provision"
 )
 
 var (
@@ -83,7 +88,24 @@ func main() {
 	}
 	SDK := mgsdk.NewSDK(SDKCfg)
 
-	svc := provision.New(cfg, SDK, logger)
+	dbConfig := pgclient.Config{Name: defDB}
+	if err := env.ParseWithOptions(&dbConfig, env.Options{Prefix: envPrefixDB}); err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	db, err := pgclient.Setup(dbConfig, *provisionpg.Migration())
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer db.Close()
+
+	database := pgclient.NewDatabase(db, dbConfig, noop.NewTracerProvider().Tracer(svcName))
+	profiles := provisionpg.NewProfileRepository(database)
+
+	svc := provision.New(cfg, SDK, profiles, logger)
 	svc = api.NewLoggingMiddleware(svc, logger)
 
 	httpServerConfig := server.Config{Host: "", Port: cfg.Server.HTTPPort, KeyFile: cfg.Server.ServerKey, CertFile: cfg.Server.ServerCert}
@@ -25,6 +25,9 @@ import (
 	authsvcAuthn "github.com/absmach/magistrala/pkg/authn/authsvc"
 	mgauthz "github.com/absmach/magistrala/pkg/authz"
 	authsvcAuthz "github.com/absmach/magistrala/pkg/authz/authsvc"
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/events/outbox"
+	"github.com/absmach/magistrala/pkg/events/store"
 	"github.com/absmach/magistrala/pkg/groups"
 	"github.com/absmach/magistrala/pkg/grpcclient"
 	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
@@ -32,6 +35,7 @@ import (
 	"github.com/absmach/magistrala/pkg/policies/spicedb"
 	"github.com/absmach/magistrala/pkg/postgres"
 	pgclient "github.com/absmach/magistrala/pkg/postgres"
+	"github.com/absmach/magistrala/pkg/presence"
 	"github.com/absmach/magistrala/pkg/prometheus"
 	"github.com/absmach/magistrala/pkg/server"
 	grpcserver "github.com/absmach/magistrala/pkg/server/grpc"
@@ -42,6 +46,7 @@ import (
 	httpapi "github.com/absmach/magistrala/things/api/http"
 	thcache "github.com/absmach/magistrala/things/cache"
 	thevents "github.com/absmach/magistrala/things/events"
+	"github.com/absmach/magistrala/things/events/consumer"
 	tmiddleware "github.com/absmach/magistrala/things/middleware"
 	thingspg "github.com/absmach/magistrala/things/postgres"
 	ctracing "github.com/absmach/magistrala/things/tracing"
@@ -80,6 +85,10 @@ type config struct {
 	SendTelemetry       bool          `env:"MG_SEND_TELEMETRY"             envDefault:"true"`
 	InstanceID          string        `env:"MG_THINGS_INSTANCE_ID"         envDefault:""`
 	ESURL               string        `env:"MG_ES_URL"                     envDefault:"nats://localhost:4222"`
+	ESConsumerName      string        `env:"MG_THINGS_EVENT_CONSUMER"      envDefault:"things"`
+	OutboxInterval      time.Duration `env:"MG_THINGS_OUTBOX_INTERVAL"     envDefault:"100ms"`
+	PresenceTTL         time.Duration `env:"MG_THINGS_PRESENCE_TTL"        envDefault:"3m"`
+	PresenceInterval    time.Duration `env:"MG_THINGS_PRESENCE_INTERVAL"   envDefault:"10s"`
 	CacheURL            string        `env:"MG_THINGS_CACHE_URL"           envDefault:"redis://localhost:6379/0"`
 	TraceRatio          float64       `env:"MG_JAEGER_TRACE_RATIO"         envDefault:"1.0"`
 	SpicedbHost         string        `env:"MG_SPICEDB_HOST"               envDefault:"localhost"`
@@ -124,6 +133,7 @@ func main() {
 	tm := thingspg.Migration()
 	gm := gpostgres.Migration()
 	tm.Migrations = append(tm.Migrations, gm.Migrations...)
+	tm.Migrations = append(tm.Migrations, outbox.Migration().Migrations...)
 	db, err := pgclient.Setup(dbConfig, *tm)
 	if err != nil {
 		logger.Error(err.Error())
@@ -186,13 +196,23 @@ func main() {
 	defer authzClient.Close()
 	logger.Info("AuthZ  successfully connected to auth gRPC server " + authnClient.Secure())
 
-	csvc, gsvc, err := newService(ctx, db, dbConfig, authz, policyEvaluator, policyService, cacheclient, cfg.CacheKeyDuration, cfg.ESURL, tracer, logger)
+	csvc, gsvc, err := newService(ctx, db, dbConfig, authz, policyEvaluator, policyService, cacheclient, cfg.CacheKeyDuration, cfg.PresenceTTL, cfg.ESURL, tracer, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to create services: %s", err))
 		exitCode = 1
 		return
 	}
 
+	relay := outbox.NewRelay(postgres.NewDatabase(db, dbConfig, tracer), func(ctx context.Context, stream string) (events.Publisher, error) {
+		return store.NewUnbufferedPublisher(ctx, cfg.ESURL, stream)
+	}, logger)
+
+	if err = subscribeToPresenceES(ctx, csvc, cfg, logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to presence event store: %s", err))
+		exitCode = 1
+		return
+	}
+
 	httpServerConfig := server.Config{Port: defSvcHTTPPort}
 	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
 		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
@@ -228,6 +248,14 @@ func main() {
 		return gs.Start()
 	})
 
+	g.Go(func() error {
+		return relay.Start(ctx, cfg.OutboxInterval)
+	})
+
+	g.Go(func() error {
+		return expirePresence(ctx, csvc, cfg.PresenceInterval)
+	})
+
 	g.Go(func() error {
 		return server.StopSignalHandler(ctx, cancel, logger, svcName, httpSvc)
 	})
@@ -237,24 +265,25 @@ func main() {
 	}
 }
 
-func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, authz mgauthz.Authorization, pe policies.Evaluator, ps policies.Service, cacheClient *redis.Client, keyDuration time.Duration, esURL string, tracer trace.Tracer, logger *slog.Logger) (things.Service, groups.Service, error) {
+func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, authz mgauthz.Authorization, pe policies.Evaluator, ps policies.Service, cacheClient *redis.Client, keyDuration, presenceTTL time.Duration, esURL string, tracer trace.Tracer, logger *slog.Logger) (things.Service, groups.Service, error) {
 	database := postgres.NewDatabase(db, dbConfig, tracer)
-	cRepo := thingspg.NewRepository(database)
-	gRepo := gpostgres.New(database)
-
 	idp := uuid.New()
 
+	// Repository writes and their outbox events are committed together.
+	cRepo := thevents.NewRepositoryMiddleware(thingspg.NewRepository(database), database, idp)
+	gRepo := gevents.NewRepositoryMiddleware(gpostgres.New(database), database, idp, streamID)
+
 	thingCache := thcache.NewCache(cacheClient, keyDuration)
 
-	csvc := things.NewService(pe, ps, cRepo, thingCache, idp)
+	csvc := things.NewService(pe, ps, cRepo, gRepo, thingCache, idp, presenceTTL)
 	gsvc := mggroups.NewService(gRepo, idp, ps)
 
-	csvc, err := thevents.NewEventStoreMiddleware(ctx, csvc, esURL)
+	csvc, err := thevents.NewEventStoreMiddleware(ctx, csvc, database, idp, esURL)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	gsvc, err = gevents.NewEventStoreMiddleware(ctx, gsvc, esURL, streamID)
+	gsvc, err = gevents.NewOutboxMiddleware(ctx, gsvc, database, idp, esURL, streamID)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -275,6 +304,26 @@ func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, auth
 	return csvc, gsvc, err
 }
 
+func subscribeToPresenceES(ctx context.Context, svc things.Service, cfg config, logger *slog.Logger) error {
+	subscriber, err := store.NewSubscriber(ctx, cfg.ESURL, logger)
+	if err != nil {
+		return err
+	}
+
+	for _, protocol := range presence.Protocols {
+		subConfig := events.SubscriberConfig{
+			Stream:   presence.Stream(protocol),
+			Consumer: cfg.ESConsumerName,
+			Handler:  consumer.NewEventHandler(svc),
+		}
+		if err := subscriber.Subscribe(ctx, subConfig); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func newSpiceDBPolicyServiceEvaluator(cfg config, logger *slog.Logger) (policies.Evaluator, policies.Service, error) {
 	client, err := authzed.NewClientWithExperimentalAPIs(
 		fmt.Sprintf("%s:%s", cfg.SpicedbHost, cfg.SpicedbPort),
@@ -289,3 +338,20 @@ func newSpiceDBPolicyServiceEvaluator(cfg config, logger *slog.Logger) (policies
 
 	return pe, ps, nil
 }
+
+// expirePresence periodically closes the thing connections which were not
+// reported by the protocol adapters within the presence TTL. Errors are
+// logged by the logging middleware.
+func expirePresence(ctx context.Context, svc things.Service, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			_, _ = svc.ExpirePresence(ctx)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"log/slog"
 	"net/url"
 	"os"
+	"time"
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/magistrala"
@@ -19,13 +20,17 @@ import (
 	"github.com/absmach/magistrala/consumers/writers/api"
 	"github.com/absmach/magistrala/consumers/writers/timescale"
 	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/events/store"
+	"github.com/absmach/magistrala/pkg/grpcclient"
 	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
 	"github.com/absmach/magistrala/pkg/messaging/brokers"
 	brokerstracing "github.com/absmach/magistrala/pkg/messaging/brokers/tracing"
 	pgclient "github.com/absmach/magistrala/pkg/postgres"
 	"github.com/absmach/magistrala/pkg/prometheus"
+	schemaevents "github.com/absmach/magistrala/pkg/schema/events"
 	"github.com/absmach/magistrala/pkg/server"
 	httpserver "github.com/absmach/magistrala/pkg/server/http"
+	"github.com/absmach/magistrala/pkg/transformers/protobuf"
 	"github.com/absmach/magistrala/pkg/uuid"
 	"github.com/caarlos0/env/v11"
 	"github.com/jmoiron/sqlx"
@@ -33,21 +38,24 @@ import (
 )
 
 const (
-	svcName        = "timescaledb-writer"
-	envPrefixDB    = "MG_TIMESCALE_"
-	envPrefixHTTP  = "MG_TIMESCALE_WRITER_HTTP_"
-	defDB          = "messages"
-	defSvcHTTPPort = "9012"
+	svcName         = "timescaledb-writer"
+	envPrefixDB     = "MG_TIMESCALE_"
+	envPrefixHTTP   = "MG_TIMESCALE_WRITER_HTTP_"
+	envPrefixThings = "MG_THINGS_AUTH_GRPC_"
+	defDB           = "messages"
+	defSvcHTTPPort  = "9012"
 )
 
 type config struct {
-	LogLevel      string  `env:"MG_TIMESCALE_WRITER_LOG_LEVEL"    envDefault:"info"`
-	ConfigPath    string  `env:"MG_TIMESCALE_WRITER_CONFIG_PATH"  envDefault:"/config.toml"`
-	BrokerURL     string  `env:"MG_MESSAGE_BROKER_URL"            envDefault:"nats://localhost:4222"`
-	JaegerURL     url.URL `env:"MG_JAEGER_URL"                    envDefault:"http://localhost:4318/v1/traces"`
-	SendTelemetry bool    `env:"MG_SEND_TELEMETRY"                envDefault:"true"`
-	InstanceID    string  `env:"MG_TIMESCALE_WRITER_INSTANCE_ID"  envDefault:""`
-	TraceRatio    float64 `env:"MG_JAEGER_TRACE_RATIO"            envDefault:"1.0"`
+	LogLevel       string        `env:"MG_TIMESCALE_WRITER_LOG_LEVEL"        envDefault:"info"`
+	ConfigPath     string        `env:"MG_TIMESCALE_WRITER_CONFIG_PATH"      envDefault:"/config.toml"`
+	SchemaCacheTTL time.Duration `env:"MG_TIMESCALE_WRITER_SCHEMA_CACHE_TTL" envDefault:"1m"`
+	BrokerURL      string        `env:"MG_MESSAGE_BROKER_URL"                envDefault:"nats://localhost:4222"`
+	ESURL          string        `env:"MG_ES_URL"                            envDefault:"nats://localhost:4222"`
+	JaegerURL      url.URL       `env:"MG_JAEGER_URL"                        envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry  bool          `env:"MG_SEND_TELEMETRY"                    envDefault:"true"`
+	InstanceID     string        `env:"MG_TIMESCALE_WRITER_INSTANCE_ID"      envDefault:""`
+	TraceRatio     float64       `env:"MG_JAEGER_TRACE_RATIO"                envDefault:"1.0"`
 }
 
 func main() {
@@ -121,7 +129,38 @@ func main() {
 	defer pubSub.Close()
 	pubSub = brokerstracing.NewPubSub(httpServerConfig, tracer, pubSub)
 
-	if err = consumers.Start(ctx, svcName, pubSub, repo, cfg.ConfigPath, logger); err != nil {
+	thingsClientCfg := grpcclient.Config{}
+	if err := env.ParseWithOptions(&thingsClientCfg, env.Options{Prefix: envPrefixThings}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s auth configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	thingsClient, thingsHandler, err := grpcclient.SetupThingsClient(ctx, thingsClientCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer thingsHandler.Close()
+
+	logger.Info("Things service gRPC client successfully connected to things gRPC server " + thingsHandler.Secure())
+
+	schemas := protobuf.NewRegistry(thingsClient, cfg.SchemaCacheTTL)
+	subscriber, err := store.NewSubscriber(ctx, cfg.ESURL, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create event store subscriber: %s", err))
+		exitCode = 1
+		return
+	}
+	defer subscriber.Close()
+	if err := schemaevents.Start(ctx, svcName+"-protobuf", subscriber, schemas); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to channel protobuf schema events: %s", err))
+		exitCode = 1
+		return
+	}
+
+	if err = consumers.Start(ctx, svcName, pubSub, repo, schemas, cfg.ConfigPath, logger); err != nil {
 		logger.Error(fmt.Sprintf("failed to create Timescale writer: %s", err))
 		exitCode = 1
 		return
@@ -0,0 +1,233 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package main contains webhooks main function to start the webhooks service.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/url"
+	"os"
+	"time"
+
+	chclient "github.com/absmach/callhome/pkg/client"
+	"github.com/absmach/magistrala"
+	mglog "github.com/absmach/magistrala/logger"
+	authsvcAuthn "github.com/absmach/magistrala/pkg/authn/authsvc"
+	mgauthz "github.com/absmach/magistrala/pkg/authz"
+	authsvcAuthz "github.com/absmach/magistrala/pkg/authz/authsvc"
+	"github.com/absmach/magistrala/pkg/events/store"
+	"github.com/absmach/magistrala/pkg/grpcclient"
+	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
+	"github.com/absmach/magistrala/pkg/postgres"
+	pgclient "github.com/absmach/magistrala/pkg/postgres"
+	"github.com/absmach/magistrala/pkg/prometheus"
+	"github.com/absmach/magistrala/pkg/server"
+	httpserver "github.com/absmach/magistrala/pkg/server/http"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/absmach/magistrala/webhooks"
+	"github.com/absmach/magistrala/webhooks/api"
+	"github.com/absmach/magistrala/webhooks/events"
+	whhttp "github.com/absmach/magistrala/webhooks/http"
+	"github.com/absmach/magistrala/webhooks/middleware"
+	webhookspg "github.com/absmach/magistrala/webhooks/postgres"
+	"github.com/caarlos0/env/v11"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	svcName        = "webhooks"
+	envPrefixDB    = "MG_WEBHOOKS_DB_"
+	envPrefixHTTP  = "MG_WEBHOOKS_HTTP_"
+	envPrefixAuth  = "MG_AUTH_GRPC_"
+	defDB          = "webhooks"
+	defSvcHTTPPort = "9030"
+)
+
+type config struct {
+	LogLevel             string        `env:"MG_WEBHOOKS_LOG_LEVEL"                 envDefault:"info"`
+	ESURL                string        `env:"MG_ES_URL"                             envDefault:"nats://localhost:4222"`
+	ESConsumerName       string        `env:"MG_WEBHOOKS_EVENT_CONSUMER"            envDefault:"webhooks"`
+	Timeout              time.Duration `env:"MG_WEBHOOKS_TIMEOUT"                   envDefault:"10s"`
+	DispatchInterval     time.Duration `env:"MG_WEBHOOKS_DISPATCH_INTERVAL"         envDefault:"1s"`
+	MaxAttempts          uint64        `env:"MG_WEBHOOKS_MAX_ATTEMPTS"              envDefault:"8"`
+	RetryInitialInterval time.Duration `env:"MG_WEBHOOKS_RETRY_INITIAL_INTERVAL"    envDefault:"10s"`
+	RetryMaxInterval     time.Duration `env:"MG_WEBHOOKS_RETRY_MAX_INTERVAL"        envDefault:"1h"`
+	AllowedNetworks      []string      `env:"MG_WEBHOOKS_ALLOWED_NETWORKS"          envDefault:"" envSeparator:","`
+	JaegerURL            url.URL       `env:"MG_JAEGER_URL"                         envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry        bool          `env:"MG_SEND_TELEMETRY"                     envDefault:"true"`
+	InstanceID           string        `env:"MG_WEBHOOKS_INSTANCE_ID"               envDefault:""`
+	TraceRatio           float64       `env:"MG_JAEGER_TRACE_RATIO"                 envDefault:"1.0"`
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	cfg := config{}
+	if err := env.Parse(&cfg); err != nil {
+		log.Fatalf("failed to load %s configuration : %s", svcName, err)
+	}
+
+	logger, err := mglog.New(os.Stdout, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("failed to init logger: %s", err)
+	}
+
+	var exitCode int
+	defer mglog.ExitWithError(&exitCode)
+
+	if cfg.InstanceID == "" {
+		if cfg.InstanceID, err = uuid.New().ID(); err != nil {
+			logger.Error(fmt.Sprintf("failed to generate instanceID: %s", err))
+			exitCode = 1
+			return
+		}
+	}
+
+	httpServerConfig := server.Config{Port: defSvcHTTPPort}
+	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	dbConfig := pgclient.Config{Name: defDB}
+	if err := env.ParseWithOptions(&dbConfig, env.Options{Prefix: envPrefixDB}); err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	db, err := pgclient.Setup(dbConfig, *webhookspg.Migration())
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer db.Close()
+
+	authClientCfg := grpcclient.Config{}
+	if err := env.ParseWithOptions(&authClientCfg, env.Options{Prefix: envPrefixAuth}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load auth gRPC client configuration : %s", err))
+		exitCode = 1
+		return
+	}
+
+	authn, authnHandler, err := authsvcAuthn.NewAuthentication(ctx, authClientCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer authnHandler.Close()
+	logger.Info("AuthN successfully connected to auth gRPC server " + authnHandler.Secure())
+
+	authz, authzHandler, err := authsvcAuthz.NewAuthorization(ctx, authClientCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer authzHandler.Close()
+	logger.Info("AuthZ successfully connected to auth gRPC server " + authzHandler.Secure())
+
+	tp, err := jaegerclient.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to init Jaeger: %s", err))
+		exitCode = 1
+		return
+	}
+	defer func() {
+		if err := tp.Shutdown(ctx); err != nil {
+			logger.Error(fmt.Sprintf("error shutting down tracer provider: %s", err))
+		}
+	}()
+	tracer := tp.Tracer(svcName)
+
+	addresses, err := webhooks.NewAddressFilter(cfg.AllowedNetworks)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to parse allowed networks: %s", err))
+		exitCode = 1
+		return
+	}
+
+	svc := newService(db, dbConfig, authz, addresses, cfg, logger, tracer)
+
+	subscriber, err := store.NewSubscriber(ctx, cfg.ESURL, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create subscriber: %s", err))
+		exitCode = 1
+		return
+	}
+	defer subscriber.Close()
+
+	if err := events.Start(ctx, cfg.ESConsumerName, subscriber, svc); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to event store: %s", err))
+		exitCode = 1
+		return
+	}
+
+	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(svc, authn, logger, svcName, cfg.InstanceID), logger)
+
+	if cfg.SendTelemetry {
+		chc := chclient.New(svcName, magistrala.Version, logger, cancel)
+		go chc.CallHome(ctx)
+	}
+
+	g.Go(func() error {
+		return hs.Start()
+	})
+
+	g.Go(func() error {
+		return dispatchDeliveries(ctx, svc, cfg.DispatchInterval)
+	})
+
+	g.Go(func() error {
+		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs)
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("%s service terminated: %s", svcName, err))
+	}
+}
+
+func newService(db *sqlx.DB, dbConfig pgclient.Config, authz mgauthz.Authorization, addresses webhooks.AddressFilter, cfg config, logger *slog.Logger, tracer trace.Tracer) webhooks.Service {
+	database := postgres.NewDatabase(db, dbConfig, tracer)
+	repo := webhookspg.NewRepository(database)
+	idp := uuid.New()
+	retry := webhooks.RetryConfig{
+		MaxAttempts:     cfg.MaxAttempts,
+		InitialInterval: cfg.RetryInitialInterval,
+		MaxInterval:     cfg.RetryMaxInterval,
+	}
+
+	svc := webhooks.New(idp, repo, whhttp.NewSender(cfg.Timeout, addresses), addresses, retry)
+	svc = middleware.AuthorizationMiddleware(svc, authz)
+	svc = middleware.LoggingMiddleware(svc, logger)
+	counter, latency := prometheus.MakeMetrics(svcName, "api")
+	svc = middleware.MetricsMiddleware(svc, counter, latency)
+	svc = middleware.Tracing(svc, tracer)
+
+	return svc
+}
+
+// dispatchDeliveries periodically attempts pending deliveries which are
+// due. Errors are logged by the logging middleware.
+func dispatchDeliveries(ctx context.Context, svc webhooks.Service, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			_, _ = svc.DispatchDeliveries(ctx)
+		}
+	}
+}
@@ -11,16 +11,26 @@ import (
 	"log/slog"
 	"net/url"
 	"os"
+	"time"
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/magistrala"
 	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/authzcache"
+	authzevents "github.com/absmach/magistrala/pkg/authzcache/events"
+	"github.com/absmach/magistrala/pkg/events/store"
 	"github.com/absmach/magistrala/pkg/grpcclient"
 	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
 	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/absmach/magistrala/pkg/messaging/brokers"
 	brokerstracing "github.com/absmach/magistrala/pkg/messaging/brokers/tracing"
+	"github.com/absmach/magistrala/pkg/presence"
 	"github.com/absmach/magistrala/pkg/prometheus"
+	"github.com/absmach/magistrala/pkg/retained"
+	retainedevents "github.com/absmach/magistrala/pkg/retained/events"
+	retainedstore "github.com/absmach/magistrala/pkg/retained/store"
+	"github.com/absmach/magistrala/pkg/schema"
+	schemaevents "github.com/absmach/magistrala/pkg/schema/events"
 	"github.com/absmach/magistrala/pkg/server"
 	httpserver "github.com/absmach/magistrala/pkg/server/http"
 	"github.com/absmach/magistrala/pkg/uuid"
@@ -44,12 +54,18 @@ const (
 )
 
 type config struct {
-	LogLevel      string  `env:"MG_WS_ADAPTER_LOG_LEVEL"    envDefault:"info"`
-	BrokerURL     string  `env:"MG_MESSAGE_BROKER_URL"      envDefault:"nats://localhost:4222"`
-	JaegerURL     url.URL `env:"MG_JAEGER_URL"              envDefault:"http://localhost:4318/v1/traces"`
-	SendTelemetry bool    `env:"MG_SEND_TELEMETRY"          envDefault:"true"`
-	InstanceID    string  `env:"MG_WS_ADAPTER_INSTANCE_ID"  envDefault:""`
-	TraceRatio    float64 `env:"MG_JAEGER_TRACE_RATIO"      envDefault:"1.0"`
+	LogLevel         string        `env:"MG_WS_ADAPTER_LOG_LEVEL"          envDefault:"info"`
+	BrokerURL        string        `env:"MG_MESSAGE_BROKER_URL"            envDefault:"nats://localhost:4222"`
+	ESURL            string        `env:"MG_ES_URL"                        envDefault:"nats://localhost:4222"`
+	RetainedURL      string        `env:"MG_RETAINED_URL"                  envDefault:"nats://localhost:4222"`
+	JaegerURL        url.URL       `env:"MG_JAEGER_URL"                    envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry    bool          `env:"MG_SEND_TELEMETRY"                envDefault:"true"`
+	InstanceID       string        `env:"MG_WS_ADAPTER_INSTANCE_ID"        envDefault:""`
+	AuthzCacheTTL    time.Duration `env:"MG_WS_ADAPTER_AUTHZ_CACHE_TTL"    envDefault:"30s"`
+	SchemaCacheTTL   time.Duration `env:"MG_WS_ADAPTER_SCHEMA_CACHE_TTL"   envDefault:"1m"`
+	RetainedCacheTTL time.Duration `env:"MG_WS_ADAPTER_RETAINED_CACHE_TTL" envDefault:"1m"`
+	PresenceInterval time.Duration `env:"MG_WS_ADAPTER_PRESENCE_INTERVAL"  envDefault:"1m"`
+	TraceRatio       float64       `env:"MG_JAEGER_TRACE_RATIO"            envDefault:"1.0"`
 }
 
 func main() {
@@ -69,6 +85,11 @@ func main() {
 	var exitCode int
 	defer mglog.ExitWithError(&exitCode)
 
+	if cfg.AuthzCacheTTL > 0 && cfg.InstanceID == "" {
+		logger.Error("MG_WS_ADAPTER_INSTANCE_ID must be set when the authorization cache is enabled")
+		exitCode = 1
+		return
+	}
 	if cfg.InstanceID == "" {
 		if cfg.InstanceID, err = uuid.New().ID(); err != nil {
 			logger.Error(fmt.Sprintf("failed to generate instanceID: %s", err))
@@ -128,7 +149,53 @@ func main() {
 	defer nps.Close()
 	nps = brokerstracing.NewPubSub(targetServerConfig, tracer, nps)
 
-	svc := newService(thingsClient, nps, logger, tracer)
+	es, err := presence.NewEventStore(ctx, cfg.ESURL, presence.WS, cfg.InstanceID, cfg.PresenceInterval)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create %s event store : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	schemas := schema.NewCache(thingsClient, cfg.SchemaCacheTTL)
+	subscriber, err := store.NewSubscriber(ctx, cfg.ESURL, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create event store subscriber: %s", err))
+		exitCode = 1
+		return
+	}
+	defer subscriber.Close()
+	if err := schemaevents.Start(ctx, svcName, subscriber, schemas); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to channel schema events: %s", err))
+		exitCode = 1
+		return
+	}
+
+	retainedRepo, retainedStore, err := retainedstore.New(ctx, cfg.RetainedURL)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to retained messages store: %s", err))
+		exitCode = 1
+		return
+	}
+	defer retainedStore.Close()
+	retainedChannels := retained.NewChannels(thingsClient, cfg.RetainedCacheTTL)
+	if err := retainedevents.Start(ctx, svcName+"-retained", subscriber, retainedChannels, retainedRepo); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to channel retention events: %s", err))
+		exitCode = 1
+		return
+	}
+	nps = retained.NewPubSub(nps, retainedRepo, retainedChannels, logger)
+
+	if cfg.AuthzCacheTTL > 0 {
+		authzCache := authzcache.NewThingsClient(thingsClient, cfg.AuthzCacheTTL, authzcache.MakeMetrics("ws_adapter"))
+		if err := authzevents.Start(ctx, fmt.Sprintf("%s-authz-%s", svcName, cfg.InstanceID), subscriber, authzCache); err != nil {
+			logger.Error(fmt.Sprintf("failed to subscribe to authorization cache events: %s", err))
+			exitCode = 1
+			return
+		}
+		thingsClient = authzCache
+	}
+
+	svc := newService(thingsClient, nps, schemas, es, logger, tracer)
 
 	hs := httpserver.NewServer(ctx, cancel, svcName, targetServerConfig, api.MakeHandler(ctx, svc, logger, cfg.InstanceID), logger)
 
@@ -141,7 +208,7 @@ func main() {
 		g.Go(func() error {
 			return hs.Start()
 		})
-		handler := ws.NewHandler(nps, logger, thingsClient)
+		handler := ws.NewHandler(nps, es, logger, thingsClient, schemas)
 		return proxyWS(ctx, httpServerConfig, targetServerConfig, logger, handler)
 	})
 
@@ -154,8 +221,8 @@ func main() {
 	}
 }
 
-func newService(thingsClient magistrala.ThingsServiceClient, nps messaging.PubSub, logger *slog.Logger, tracer trace.Tracer) ws.Service {
-	svc := ws.New(thingsClient, nps)
+func newService(thingsClient magistrala.ThingsServiceClient, nps messaging.PubSub, validator schema.Validator, es presence.EventStore, logger *slog.Logger, tracer trace.Tracer) ws.Service {
+	svc := ws.New(thingsClient, nps, validator, es, uuid.New(), logger)
 	svc = tracing.New(tracer, svc)
 	svc = api.LoggingMiddleware(svc, logger)
 	counter, latency := prometheus.MakeMetrics("ws_adapter", "api")
@@ -15,14 +15,38 @@ import (
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/absmach/magistrala/pkg/policies"
+	"github.com/absmach/magistrala/pkg/presence"
+	"github.com/absmach/magistrala/pkg/schema"
 )
 
 const chansPrefix = "channels"
 
+type thingKey struct{}
+
+// thing is the thing authenticated by the DTLS session.
+type thing struct {
+	id  string
+	psk []byte
+}
+
+// WithThing returns the context carrying the ID and the pre-shared key of
+// the thing authenticated by the DTLS session. Such a thing is authorized
+// by its ID and pre-shared key, so the service methods are called with an
+// empty key.
+func WithThing(ctx context.Context, id string, psk []byte) context.Context {
+	return context.WithValue(ctx, thingKey{}, thing{id: id, psk: psk})
+}
+
+func thingFrom(ctx context.Context) thing {
+	th, _ := ctx.Value(thingKey{}).(thing)
+	return th
+}
+
 // Service specifies CoAP service API.
 type Service interface {
 	// Publish publishes message to specified channel.
-	// Key is used to authorize publisher.
+	// Key is used to authorize publisher, unless the publisher is
+	// authenticated by the DTLS session.
 	Publish(ctx context.Context, key string, msg *messaging.Message) error
 
 	// Subscribes to channel with specified id, subtopic and adds subscription to
@@ -31,30 +55,42 @@ type Service interface {
 
 	// Unsubscribe method is used to stop observing resource.
 	Unsubscribe(ctx context.Context, key, chanID, subptopic, token string) error
+
+	// Discover returns the IDs of the channels the thing may access.
+	Discover(ctx context.Context, key string) ([]string, error)
 }
 
 var _ Service = (*adapterService)(nil)
 
 // Observers is a map of maps,.
 type adapterService struct {
-	things magistrala.ThingsServiceClient
-	pubsub messaging.PubSub
+	things    magistrala.ThingsServiceClient
+	pubsub    messaging.PubSub
+	validator schema.Validator
+	es        presence.EventStore
 }
 
-// New instantiates the CoAP adapter implementation.
-func New(thingsClient magistrala.ThingsServiceClient, pubsub messaging.PubSub) Service {
+// New instantiates the CoAP adapter implementation. Observing a resource
+// is reported as the thing connection, and cancelling the observation as
+// the thing disconnection.
+func New(thingsClient magistrala.ThingsServiceClient, pubsub messaging.PubSub, validator schema.Validator, es presence.EventStore) Service {
 	as := &adapterService{
-		things: thingsClient,
-		pubsub: pubsub,
+		things:    thingsClient,
+		pubsub:    pubsub,
+		validator: validator,
+		es:        es,
 	}
 
 	return as
 }
 
 func (svc *adapterService) Publish(ctx context.Context, key string, msg *messaging.Message) error {
+	th := thingFrom(ctx)
 	ar := &magistrala.ThingsAuthzReq{
 		Permission: policies.PublishPermission,
+		ThingId:    th.id,
 		ThingKey:   key,
+		ThingPsk:   th.psk,
 		ChannelId:  msg.GetChannel(),
 	}
 	res, err := svc.things.Authorize(ctx, ar)
@@ -66,13 +102,24 @@ func (svc *adapterService) Publish(ctx context.Context, key string, msg *messagi
 	}
 	msg.Publisher = res.GetId()
 
-	return svc.pubsub.Publish(ctx, msg.GetChannel(), msg)
+	if err := svc.validator.Validate(ctx, msg.GetChannel(), msg.GetPayload()); err != nil {
+		return errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	if err := svc.pubsub.Publish(ctx, msg.GetChannel(), msg); err != nil {
+		return err
+	}
+
+	return svc.es.Published(ctx, msg.GetPublisher())
 }
 
 func (svc *adapterService) Subscribe(ctx context.Context, key, chanID, subtopic string, c Client) error {
+	th := thingFrom(ctx)
 	ar := &magistrala.ThingsAuthzReq{
 		Permission: policies.SubscribePermission,
+		ThingId:    th.id,
 		ThingKey:   key,
+		ThingPsk:   th.psk,
 		ChannelId:  chanID,
 	}
 	res, err := svc.things.Authorize(ctx, ar)
@@ -91,13 +138,20 @@ func (svc *adapterService) Subscribe(ctx context.Context, key, chanID, subtopic
 		Topic:   subject,
 		Handler: c,
 	}
-	return svc.pubsub.Subscribe(ctx, subCfg)
+	if err := svc.pubsub.Subscribe(ctx, subCfg); err != nil {
+		return err
+	}
+
+	return svc.es.Connect(ctx, res.GetId(), c.Token())
 }
 
 func (svc *adapterService) Unsubscribe(ctx context.Context, key, chanID, subtopic, token string) error {
+	th := thingFrom(ctx)
 	ar := &magistrala.ThingsAuthzReq{
 		Permission: policies.SubscribePermission,
+		ThingId:    th.id,
 		ThingKey:   key,
+		ThingPsk:   th.psk,
 		ChannelId:  chanID,
 	}
 	res, err := svc.things.Authorize(ctx, ar)
@@ -112,5 +166,24 @@ func (svc *adapterService) Unsubscribe(ctx context.Context, key, chanID, subtopi
 		subject = fmt.Sprintf("%s.%s", subject, subtopic)
 	}
 
-	return svc.pubsub.Unsubscribe(ctx, token, subject)
+	if err := svc.pubsub.Unsubscribe(ctx, token, subject); err != nil {
+		return err
+	}
+
+	return svc.es.Disconnect(ctx, res.GetId(), token)
+}
+
+func (svc *adapterService) Discover(ctx context.Context, key string) ([]string, error) {
+	th := thingFrom(ctx)
+	cr := &magistrala.ThingsChannelsReq{
+		ThingId:  th.id,
+		ThingKey: key,
+		ThingPsk: th.psk,
+	}
+	res, err := svc.things.ConnectedChannels(ctx, cr)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+
+	return res.GetChannelIds(), nil
 }
@@ -0,0 +1,149 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	piondtls "github.com/pion/dtls/v3"
+	"github.com/plgd-dev/go-coap/v3/mux"
+)
+
+var (
+	errRevokedCert  = errors.New("client certificate is revoked")
+	errUnknownCert  = errors.New("client certificate is not issued to a thing")
+	errMissingCerts = errors.New("certs service is not configured")
+)
+
+// session is the thing authenticated by the DTLS session.
+type session struct {
+	thingID string
+	psk     []byte
+}
+
+// DTLS authenticates the things connected over DTLS. The things are
+// authorized by their ID and the pre-shared key derived by the things
+// service, so the thing key is never disclosed to the adapter.
+type DTLS struct {
+	things   magistrala.ThingsServiceClient
+	certs    magistrala.CertsServiceClient
+	mu       sync.Mutex
+	sessions map[net.Conn]session
+}
+
+// NewDTLS returns the DTLS authentication of the things. The client
+// certificates, either issued by the PKI or enrolled over EST, are looked up
+// in the certs service, so the revoked certificates are rejected. Certs
+// client is not used in PSK mode.
+func NewDTLS(things magistrala.ThingsServiceClient, certs magistrala.CertsServiceClient) *DTLS {
+	return &DTLS{
+		things:   things,
+		certs:    certs,
+		sessions: make(map[net.Conn]session),
+	}
+}
+
+// PSK returns the pre-shared key of the thing during the DTLS handshake. The
+// PSK identity is the thing ID.
+func (d *DTLS) PSK(identity []byte) ([]byte, error) {
+	res, err := d.things.DerivePSK(context.Background(), &magistrala.ThingsPSKReq{ThingId: string(identity)})
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Error deriving pre-shared key: %s", err))
+		return nil, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+
+	return res.GetPsk(), nil
+}
+
+// authenticate returns the thing authenticated by the DTLS session of the
+// connection. The thing is resolved once per session. Empty session is
+// returned for the connections without DTLS.
+func (d *DTLS) authenticate(conn mux.Conn) (session, error) {
+	dc, ok := conn.NetConn().(*piondtls.Conn)
+	if d == nil || !ok {
+		return session{}, nil
+	}
+
+	d.mu.Lock()
+	s, ok := d.sessions[dc]
+	d.mu.Unlock()
+	if ok {
+		return s, nil
+	}
+
+	s, err := d.resolve(dc)
+	if err != nil {
+		return session{}, err
+	}
+	d.mu.Lock()
+	d.sessions[dc] = s
+	d.mu.Unlock()
+	conn.AddOnClose(func() {
+		d.mu.Lock()
+		delete(d.sessions, dc)
+		d.mu.Unlock()
+	})
+
+	return s, nil
+}
+
+// resolve identifies the thing by the PSK identity or by the certs service
+// record of the client certificate, and derives its pre-shared key which
+// the things service requires to authorize the thing by its ID.
+func (d *DTLS) resolve(dc *piondtls.Conn) (session, error) {
+	state, ok := dc.ConnectionState()
+	if !ok {
+		return session{}, svcerr.ErrAuthentication
+	}
+	thingID := string(state.IdentityHint)
+	if len(state.PeerCertificates) > 0 {
+		id, err := d.certThing(state.PeerCertificates[0])
+		if err != nil {
+			return session{}, errors.Wrap(svcerr.ErrAuthentication, err)
+		}
+		thingID = id
+	}
+	if thingID == "" {
+		return session{}, svcerr.ErrAuthentication
+	}
+
+	res, err := d.things.DerivePSK(context.Background(), &magistrala.ThingsPSKReq{ThingId: thingID})
+	if err != nil {
+		return session{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+
+	return session{thingID: thingID, psk: res.GetPsk()}, nil
+}
+
+// certThing returns the ID of the thing the client certificate is issued
+// to. The thing is taken from the certs service record rather than from the
+// certificate subject.
+func (d *DTLS) certThing(raw []byte) (string, error) {
+	if d.certs == nil {
+		return "", errMissingCerts
+	}
+	cert, err := x509.ParseCertificate(raw)
+	if err != nil {
+		return "", err
+	}
+	c, err := d.certs.RetrieveCert(context.Background(), &magistrala.RetrieveCertReq{SerialNumber: cert.SerialNumber.Bytes()})
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case c.GetRevoked():
+		return "", errRevokedCert
+	case c.GetThingId() == "":
+		return "", errUnknownCert
+	}
+
+	return c.GetThingId(), nil
+}
@@ -91,3 +91,23 @@ func (lm *loggingMiddleware) Unsubscribe(ctx context.Context, key, chanID, subto
 
 	return lm.svc.Unsubscribe(ctx, key, chanID, subtopic, token)
 }
+
+// Discover logs the resource discovery request. It logs the number of the
+// discovered channels and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) Discover(ctx context.Context, key string) (chanIDs []string, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Int("channels", len(chanIDs)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Discover resources failed", args...)
+			return
+		}
+		lm.logger.Info("Discover resources completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Discover(ctx, key)
+}
@@ -60,3 +60,13 @@ func (mm *metricsMiddleware) Unsubscribe(ctx context.Context, key, chanID, subto
 
 	return mm.svc.Unsubscribe(ctx, key, chanID, subtopic, token)
 }
+
+// Discover instruments Discover method with metrics.
+func (mm *metricsMiddleware) Discover(ctx context.Context, key string) ([]string, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "discover").Add(1)
+		mm.latency.With("method", "discover").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Discover(ctx, key)
+}
@@ -4,6 +4,7 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -28,9 +29,11 @@ import (
 )
 
 const (
-	protocol     = "coap"
-	authQuery    = "auth"
-	startObserve = 0 // observe option value that indicates start of observation
+	protocol      = "coap"
+	authQuery     = "auth"
+	startObserve  = 0 // observe option value that indicates start of observation
+	wellKnownCore = "/.well-known/core"
+	channelRT     = "magistrala.channel"
 )
 
 var channelPartRegExp = regexp.MustCompile(`^/channels/([\w\-]+)/messages(/[^?]*)?(\?.*)?$`)
@@ -47,8 +50,10 @@ var (
 )
 
 var (
-	logger  *slog.Logger
-	service coap.Service
+	logger          *slog.Logger
+	service         coap.Service
+	dtls            *DTLS
+	confirmInterval time.Duration
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
@@ -60,10 +65,15 @@ func MakeHandler(instanceID string) http.Handler {
 	return b
 }
 
-// MakeCoAPHandler creates handler for CoAP messages.
-func MakeCoAPHandler(svc coap.Service, l *slog.Logger) mux.HandlerFunc {
+// MakeCoAPHandler creates handler for CoAP messages. Observe notifications
+// are sent as confirmable messages once per confirm interval. DTLS
+// authenticates the things connected over DTLS, and is nil when DTLS is
+// disabled.
+func MakeCoAPHandler(svc coap.Service, d *DTLS, confirm time.Duration, l *slog.Logger) mux.HandlerFunc {
 	logger = l
 	service = svc
+	dtls = d
+	confirmInterval = confirm
 
 	return handler
 }
@@ -82,13 +92,20 @@ func handler(w mux.ResponseWriter, m *mux.Message) {
 	}
 	defer sendResp(w, resp)
 
+	if path, err := m.Path(); err == nil && path == wellKnownCore {
+		if err := handleDiscovery(w, m, resp); err != nil {
+			setErrorCode(resp, err)
+		}
+		return
+	}
+
 	msg, err := decodeMessage(m)
 	if err != nil {
 		logger.Warn(fmt.Sprintf("Error decoding message: %s", err))
 		resp.SetCode(codes.BadRequest)
 		return
 	}
-	key, err := parseKey(m)
+	key, s, err := credentials(w, m)
 	if err != nil {
 		logger.Warn(fmt.Sprintf("Error parsing auth: %s", err))
 		resp.SetCode(codes.Unauthorized)
@@ -98,31 +115,69 @@ func handler(w mux.ResponseWriter, m *mux.Message) {
 	switch m.Code() {
 	case codes.GET:
 		resp.SetCode(codes.Content)
-		err = handleGet(m, w, msg, key)
+		err = handleGet(m, w, msg, key, s)
 	case codes.POST:
 		resp.SetCode(codes.Created)
-		err = service.Publish(m.Context(), key, msg)
+		err = service.Publish(withSession(m.Context(), s), key, msg)
 	default:
 		err = errMethodNotAllowed
 	}
 
 	if err != nil {
-		switch {
-		case err == errBadOptions:
-			resp.SetCode(codes.BadOption)
-		case err == errMethodNotAllowed:
-			resp.SetCode(codes.MethodNotAllowed)
-		case errors.Contains(err, svcerr.ErrAuthorization):
-			resp.SetCode(codes.Forbidden)
-		case errors.Contains(err, svcerr.ErrAuthentication):
-			resp.SetCode(codes.Unauthorized)
-		default:
-			resp.SetCode(codes.InternalServerError)
-		}
+		setErrorCode(resp, err)
+	}
+}
+
+func setErrorCode(resp *pool.Message, err error) {
+	switch {
+	case err == errBadOptions:
+		resp.SetCode(codes.BadOption)
+	case err == errMethodNotAllowed:
+		resp.SetCode(codes.MethodNotAllowed)
+	case errors.Contains(err, svcerr.ErrAuthorization):
+		resp.SetCode(codes.Forbidden)
+	case errors.Contains(err, svcerr.ErrAuthentication):
+		resp.SetCode(codes.Unauthorized)
+	case errors.Contains(err, svcerr.ErrMalformedEntity):
+		resp.SetCode(codes.BadRequest)
+	default:
+		resp.SetCode(codes.InternalServerError)
+	}
+}
+
+// handleDiscovery responds to the CoRE resource discovery (RFC 6690) with
+// the links to the messages resources of the channels the thing may access.
+// Large responses are transferred block-wise.
+func handleDiscovery(w mux.ResponseWriter, m *mux.Message, resp *pool.Message) error {
+	if m.Code() != codes.GET {
+		return errMethodNotAllowed
+	}
+	key, s, err := credentials(w, m)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Error parsing auth: %s", err))
+		return errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	chanIDs, err := service.Discover(withSession(m.Context(), s), key)
+	if err != nil {
+		return err
 	}
+
+	resp.SetCode(codes.Content)
+	resp.SetContentFormat(message.AppLinkFormat)
+	resp.SetBody(bytes.NewReader(encodeLinks(chanIDs)))
+
+	return nil
 }
 
-func handleGet(m *mux.Message, w mux.ResponseWriter, msg *messaging.Message, key string) error {
+func encodeLinks(chanIDs []string) []byte {
+	links := make([]string, len(chanIDs))
+	for i, id := range chanIDs {
+		links[i] = fmt.Sprintf(`</channels/%s/messages>;rt="%s";obs`, id, channelRT)
+	}
+	return []byte(strings.Join(links, ","))
+}
+
+func handleGet(m *mux.Message, w mux.ResponseWriter, msg *messaging.Message, key string, s session) error {
 	var obs uint32
 	obs, err := m.Options().Observe()
 	if err != nil {
@@ -130,9 +185,9 @@ func handleGet(m *mux.Message, w mux.ResponseWriter, msg *messaging.Message, key
 		return errBadOptions
 	}
 	if obs == startObserve {
-		c := coap.NewClient(w.Conn(), m.Token(), logger)
+		c := coap.NewClient(w.Conn(), m.Token(), confirmInterval, logger)
 		w.Conn().AddOnClose(func() {
-			err := service.Unsubscribe(context.Background(), key, msg.GetChannel(), msg.GetSubtopic(), c.Token())
+			err := service.Unsubscribe(withSession(context.Background(), s), key, msg.GetChannel(), msg.GetSubtopic(), c.Token())
 			args := []any{
 				slog.String("channel_id", msg.GetChannel()),
 				slog.String("subtopic", msg.GetSubtopic()),
@@ -145,9 +200,9 @@ func handleGet(m *mux.Message, w mux.ResponseWriter, msg *messaging.Message, key
 			}
 			logger.Warn("Unsubscribe idle client completed successfully", args...)
 		})
-		return service.Subscribe(w.Conn().Context(), key, msg.GetChannel(), msg.GetSubtopic(), c)
+		return service.Subscribe(withSession(w.Conn().Context(), s), key, msg.GetChannel(), msg.GetSubtopic(), c)
 	}
-	return service.Unsubscribe(w.Conn().Context(), key, msg.GetChannel(), msg.GetSubtopic(), m.Token().String())
+	return service.Unsubscribe(withSession(w.Conn().Context(), s), key, msg.GetChannel(), msg.GetSubtopic(), m.Token().String())
 }
 
 func decodeMessage(msg *mux.Message) (*messaging.Message, error) {
@@ -197,6 +252,25 @@ func parseKey(msg *mux.Message) (string, error) {
 	return vars[1], nil
 }
 
+// credentials returns the thing key sent in the URI query. Things
+// authenticated by the DTLS session are identified by the session instead,
+// so the session of such thing is returned with an empty key.
+func credentials(w mux.ResponseWriter, m *mux.Message) (key string, s session, err error) {
+	s, err = dtls.authenticate(w.Conn())
+	if err != nil || s.thingID != "" {
+		return "", s, err
+	}
+	key, err = parseKey(m)
+	return key, session{}, err
+}
+
+func withSession(ctx context.Context, s session) context.Context {
+	if s.thingID == "" {
+		return ctx
+	}
+	return coap.WithThing(ctx, s.thingID, s.psk)
+}
+
 func parseSubtopic(subtopic string) (string, error) {
 	if subtopic == "" {
 		return subtopic, nil
@@ -5,9 +5,11 @@ package coap
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log/slog"
 	"sync/atomic"
+	"time"
 
 	"github.com/absmach/magistrala/pkg/errors"
 	"github.com/absmach/magistrala/pkg/messaging"
@@ -34,20 +36,32 @@ type Client interface {
 // ErrOption indicates an error when adding an option.
 var ErrOption = errors.New("unable to set option")
 
+// confirmTimeout bounds waiting for the acknowledgement of the confirmable
+// notification, including the retransmissions done by the CoAP transport.
+const confirmTimeout = 30 * time.Second
+
 type client struct {
-	conn    mux.Conn
-	token   message.Token
-	observe uint32
-	logger  *slog.Logger
+	conn            mux.Conn
+	token           message.Token
+	observe         uint32
+	confirmInterval time.Duration
+	lastConfirmed   atomic.Int64
+	logger          *slog.Logger
 }
 
-// NewClient instantiates a new Observer.
-func NewClient(conn mux.Conn, tkn message.Token, l *slog.Logger) Client {
+// NewClient instantiates a new Observer. Notifications are sent as
+// non-confirmable messages, except for one confirmable notification per
+// confirm interval (RFC 7641, section 4.5). If the confirmable notification
+// is not acknowledged, the observer is considered dead and its connection
+// is closed, which cancels all the observations made over it. Zero confirm
+// interval makes all the notifications confirmable.
+func NewClient(conn mux.Conn, tkn message.Token, confirmInterval time.Duration, l *slog.Logger) Client {
 	return &client{
-		conn:    conn,
-		token:   tkn,
-		logger:  l,
-		observe: 0,
+		conn:            conn,
+		token:           tkn,
+		logger:          l,
+		observe:         0,
+		confirmInterval: confirmInterval,
 	}
 }
 
@@ -71,8 +85,16 @@ func (c *client) Token() string {
 }
 
 func (c *client) Handle(msg *messaging.Message) error {
-	pm := c.conn.AcquireMessage(c.conn.Context())
+	ctx, typ := c.conn.Context(), message.NonConfirmable
+	if c.confirm() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, confirmTimeout)
+		defer cancel()
+		typ = message.Confirmable
+	}
+	pm := c.conn.AcquireMessage(ctx)
 	defer c.conn.ReleaseMessage(pm)
+	pm.SetType(typ)
 	pm.SetCode(codes.Content)
 	pm.SetToken(c.token)
 	pm.SetBody(bytes.NewReader(msg.GetPayload()))
@@ -101,5 +123,25 @@ func (c *client) Handle(msg *messaging.Message) error {
 	for _, option := range opts {
 		pm.SetOptionBytes(option.ID, option.Value)
 	}
-	return c.conn.WriteMessage(pm)
+	if err := c.conn.WriteMessage(pm); err != nil {
+		if typ == message.Confirmable {
+			c.logger.Warn(fmt.Sprintf("Observer %s did not acknowledge notification, closing connection: %s", c.conn.RemoteAddr(), err))
+			if err := c.conn.Close(); err != nil {
+				c.logger.Error(fmt.Sprintf("Error closing observer connection: %s.", err))
+			}
+		}
+		return err
+	}
+
+	return nil
+}
+
+// confirm reports whether the next notification should be confirmable.
+func (c *client) confirm() bool {
+	now := time.Now().UnixNano()
+	last := c.lastConfirmed.Load()
+	if last != 0 && now-last < int64(c.confirmInterval) {
+		return false
+	}
+	return c.lastConfirmed.CompareAndSwap(last, now)
 }
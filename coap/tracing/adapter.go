@@ -19,6 +19,7 @@ const (
 	publishOP     = "publish_op"
 	subscribeOP   = "subscribe_op"
 	unsubscribeOP = "unsubscribe_op"
+	discoverOP    = "discover_op"
 )
 
 // tracingServiceMiddleware is a middleware implementation for tracing CoAP service operations using OpenTelemetry.
@@ -61,3 +62,10 @@ func (tm *tracingServiceMiddleware) Unsubscribe(ctx context.Context, key, chanID
 	defer span.End()
 	return tm.svc.Unsubscribe(ctx, key, chanID, subptopic, token)
 }
+
+// Discover traces a CoAP resource discovery operation.
+func (tm *tracingServiceMiddleware) Discover(ctx context.Context, key string) ([]string, error) {
+	ctx, span := tm.tracer.Start(ctx, discoverOP)
+	defer span.End()
+	return tm.svc.Discover(ctx, key)
+}
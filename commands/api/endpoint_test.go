@@ -0,0 +1,389 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/commands"
+	"github.com/absmach/magistrala/commands/api"
+	"github.com/absmach/magistrala/commands/mocks"
+	"github.com/absmach/magistrala/internal/testsutil"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	authnmocks "github.com/absmach/magistrala/pkg/authn/mocks"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	validToken       = "valid"
+	validContentType = "application/json"
+	userID           = testsutil.GenerateUUID(&testing.T{})
+	domainID         = testsutil.GenerateUUID(&testing.T{})
+	channelID        = testsutil.GenerateUUID(&testing.T{})
+	thingID          = testsutil.GenerateUUID(&testing.T{})
+	commandID        = testsutil.GenerateUUID(&testing.T{})
+	validSession     = mgauthn.Session{UserID: userID, DomainID: domainID, DomainUserID: domainID + "_" + userID}
+	validCommand     = commands.Command{
+		ID:        commandID,
+		DomainID:  domainID,
+		ChannelID: channelID,
+		ThingID:   thingID,
+		Name:      "reboot",
+		Payload:   json.RawMessage(`{"delay":5}`),
+		Status:    commands.QueuedStatus,
+		CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	token       string
+	contentType string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+
+	return tr.client.Do(req)
+}
+
+func newCommandsServer() (*httptest.Server, *mocks.Service, *authnmocks.Authentication) {
+	svc := new(mocks.Service)
+	authn := new(authnmocks.Authentication)
+	mux := api.MakeHandler(svc, authn, mglog.NewMock(), "commands", "test")
+
+	return httptest.NewServer(mux), svc, authn
+}
+
+func TestIssueCommand(t *testing.T) {
+	cs, svc, authn := newCommandsServer()
+	defer cs.Close()
+
+	validReq := fmt.Sprintf(`{"channel_id":"%s","thing_id":"%s","name":"reboot","payload":{"delay":5},"ttl":"30s"}`, channelID, thingID)
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		authnRes    mgauthn.Session
+		authnErr    error
+		cmd         commands.Command
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "issue command successfully",
+			token:       validToken,
+			data:        validReq,
+			contentType: validContentType,
+			authnRes:    validSession,
+			cmd: commands.Command{
+				ChannelID: channelID,
+				ThingID:   thingID,
+				Name:      "reboot",
+				Payload:   json.RawMessage(`{"delay":5}`),
+				TTL:       30 * time.Second,
+			},
+			status: http.StatusCreated,
+		},
+		{
+			desc:        "issue channel command without ttl",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"channel_id":"%s","name":"reboot"}`, channelID),
+			contentType: validContentType,
+			authnRes:    validSession,
+			cmd: commands.Command{
+				ChannelID: channelID,
+				Name:      "reboot",
+			},
+			status: http.StatusCreated,
+		},
+		{
+			desc:        "issue command with empty token",
+			data:        validReq,
+			contentType: validContentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "issue command with invalid token",
+			token:       "invalid",
+			data:        validReq,
+			contentType: validContentType,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "issue command with invalid content type",
+			token:       validToken,
+			data:        validReq,
+			contentType: "text/plain",
+			authnRes:    validSession,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "issue command with malformed body",
+			token:       validToken,
+			data:        "{",
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "issue command without channel",
+			token:       validToken,
+			data:        `{"name":"reboot"}`,
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "issue command without name",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"channel_id":"%s"}`, channelID),
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "issue command with too long name",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"channel_id":"%s","name":"%s"}`, channelID, strings.Repeat("a", 1025)),
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "issue command with invalid ttl",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"channel_id":"%s","name":"reboot","ttl":"invalid"}`, channelID),
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "issue command with negative ttl",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"channel_id":"%s","name":"reboot","ttl":"-1s"}`, channelID),
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "issue command with service error",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"channel_id":"%s","name":"reboot"}`, channelID),
+			contentType: validContentType,
+			authnRes:    validSession,
+			cmd: commands.Command{
+				ChannelID: channelID,
+				Name:      "reboot",
+			},
+			svcErr: svcerr.ErrAuthorization,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("IssueCommand", mock.Anything, tc.authnRes, tc.cmd).Return(validCommand, tc.svcErr)
+			req := testRequest{
+				client:      cs.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/commands", cs.URL, domainID),
+				token:       tc.token,
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusCreated {
+				ok := svc.AssertCalled(t, "IssueCommand", mock.Anything, tc.authnRes, tc.cmd)
+				assert.True(t, ok, fmt.Sprintf("%s: expected command %v to be issued", tc.desc, tc.cmd))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestViewCommand(t *testing.T) {
+	cs, svc, authn := newCommandsServer()
+	defer cs.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "view command successfully",
+			token:    validToken,
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "view command with invalid token",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "view non-existing command",
+			token:    validToken,
+			authnRes: validSession,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+		{
+			desc:     "view command of unauthorized channel",
+			token:    validToken,
+			authnRes: validSession,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("ViewCommand", mock.Anything, tc.authnRes, commandID).Return(validCommand, tc.svcErr)
+			req := testRequest{
+				client: cs.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/commands/%s", cs.URL, domainID, commandID),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var cmd commands.Command
+				err := json.NewDecoder(res.Body).Decode(&cmd)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Equal(t, validCommand.ID, cmd.ID, fmt.Sprintf("%s: expected command %s got %s", tc.desc, validCommand.ID, cmd.ID))
+				assert.Equal(t, validCommand.Status, cmd.Status, fmt.Sprintf("%s: expected status %s got %s", tc.desc, validCommand.Status, cmd.Status))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestListCommands(t *testing.T) {
+	cs, svc, authn := newCommandsServer()
+	defer cs.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		query    string
+		pm       commands.PageMetadata
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "list commands successfully",
+			token:    validToken,
+			pm:       commands.PageMetadata{Limit: 10, Status: commands.AllStatus},
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list commands with filters",
+			token:    validToken,
+			query:    fmt.Sprintf("channel_id=%s&thing_id=%s&name=reboot&status=failed&offset=1&limit=5", channelID, thingID),
+			pm:       commands.PageMetadata{Offset: 1, Limit: 5, ChannelID: channelID, ThingID: thingID, Name: "reboot", Status: commands.FailedStatus},
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list commands with invalid token",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "list commands with invalid offset",
+			token:    validToken,
+			query:    "offset=invalid",
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list commands with zero limit",
+			token:    validToken,
+			query:    "limit=0",
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list commands with invalid status",
+			token:    validToken,
+			query:    "status=invalid",
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list commands with service error",
+			token:    validToken,
+			pm:       commands.PageMetadata{Limit: 10, Status: commands.AllStatus},
+			authnRes: validSession,
+			svcErr:   svcerr.ErrViewEntity,
+			status:   http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			page := commands.CommandsPage{PageMetadata: tc.pm, Total: 1, Commands: []commands.Command{validCommand}}
+			svcCall := svc.On("ListCommands", mock.Anything, tc.authnRes, tc.pm).Return(page, tc.svcErr)
+			req := testRequest{
+				client: cs.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/commands?%s", cs.URL, domainID, tc.query),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
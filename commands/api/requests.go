@@ -0,0 +1,82 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/magistrala/commands"
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/pkg/apiutil"
+)
+
+type issueCommandReq struct {
+	ChannelID string          `json:"channel_id"`
+	ThingID   string          `json:"thing_id,omitempty"`
+	Name      string          `json:"name"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	// TTL is a duration string, e.g. "30s" or "5m".
+	TTL string `json:"ttl,omitempty"`
+}
+
+func (req issueCommandReq) validate() error {
+	if req.ChannelID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.Name == "" {
+		return apiutil.ErrMissingName
+	}
+	if len(req.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+	if req.TTL != "" {
+		ttl, err := time.ParseDuration(req.TTL)
+		if err != nil || ttl <= 0 {
+			return apiutil.ErrInvalidTTL
+		}
+	}
+
+	return nil
+}
+
+func (req issueCommandReq) command() commands.Command {
+	// TTL is validated before the command is created.
+	ttl, _ := time.ParseDuration(req.TTL)
+
+	return commands.Command{
+		ChannelID: req.ChannelID,
+		ThingID:   req.ThingID,
+		Name:      req.Name,
+		Payload:   req.Payload,
+		TTL:       ttl,
+	}
+}
+
+type commandIDReq struct {
+	id string
+}
+
+func (req commandIDReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listCommandsReq struct {
+	pm commands.PageMetadata
+}
+
+func (req listCommandsReq) validate() error {
+	if req.pm.Limit > api.MaxLimitSize || req.pm.Limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+	if len(req.pm.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	return nil
+}
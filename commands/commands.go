@@ -0,0 +1,245 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// Subtopics used to exchange commands with things. Commands issued for
+// a thing are delivered to the `commands.<thing_id>` subtopic, and commands
+// issued for a channel are delivered to the `commands` subtopic of the channel.
+// Things publish responses to the `commands.responses` subtopic.
+const (
+	Subtopic          = "commands"
+	ResponsesSubtopic = Subtopic + ".responses"
+
+	// ResponsesTopic is the message broker topic of the responses published
+	// to any channel.
+	ResponsesTopic = "channels.*." + ResponsesSubtopic
+
+	// Protocol is set as the protocol of the command messages.
+	Protocol = "commands"
+)
+
+// Status represents command status.
+type Status uint8
+
+// Possible command status values. Status values are ordered, so the command
+// status can only move forward.
+const (
+	// QueuedStatus represents a command which is stored, but not yet
+	// delivered, because the thing is offline.
+	QueuedStatus Status = iota
+	// DeliveredStatus represents a command published to the channel.
+	DeliveredStatus
+	// AckedStatus represents a command the thing acknowledged.
+	AckedStatus
+	// SucceededStatus represents a command the thing executed successfully.
+	SucceededStatus
+	// FailedStatus represents a command the thing failed to execute.
+	FailedStatus
+	// ExpiredStatus represents a command which was not completed before
+	// its TTL passed.
+	ExpiredStatus
+
+	// AllStatus is used for querying purposes to list commands irrespective
+	// of their status. It is never stored in the database.
+	AllStatus
+)
+
+// String representation of the possible status values.
+const (
+	Queued    = "queued"
+	Delivered = "delivered"
+	Acked     = "acked"
+	Succeeded = "succeeded"
+	Failed    = "failed"
+	Expired   = "expired"
+	All       = "all"
+	Unknown   = "unknown"
+)
+
+// String converts command status to string literal.
+func (s Status) String() string {
+	switch s {
+	case QueuedStatus:
+		return Queued
+	case DeliveredStatus:
+		return Delivered
+	case AckedStatus:
+		return Acked
+	case SucceededStatus:
+		return Succeeded
+	case FailedStatus:
+		return Failed
+	case ExpiredStatus:
+		return Expired
+	case AllStatus:
+		return All
+	default:
+		return Unknown
+	}
+}
+
+// ToStatus converts string value to a valid command status.
+func ToStatus(status string) (Status, error) {
+	switch status {
+	case Queued:
+		return QueuedStatus, nil
+	case Delivered:
+		return DeliveredStatus, nil
+	case Acked:
+		return AckedStatus, nil
+	case Succeeded:
+		return SucceededStatus, nil
+	case Failed:
+		return FailedStatus, nil
+	case Expired:
+		return ExpiredStatus, nil
+	case "", All:
+		return AllStatus, nil
+	}
+
+	return Status(0), svcerr.ErrInvalidStatus
+}
+
+// Final reports whether the command status can no longer change.
+func (s Status) Final() bool {
+	return s >= SucceededStatus && s != AllStatus
+}
+
+// MarshalJSON converts command status to JSON string.
+func (s Status) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON parses command status from JSON string.
+func (s *Status) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	val, err := ToStatus(str)
+	*s = val
+
+	return err
+}
+
+// Command is an instruction issued by a user to a thing or to all things
+// connected to a channel.
+type Command struct {
+	ID        string `json:"id"`
+	DomainID  string `json:"domain_id"`
+	ChannelID string `json:"channel_id"`
+	// ThingID is the thing the command is issued for. Empty thing ID means
+	// the command is issued for all things connected to the channel.
+	ThingID string          `json:"thing_id,omitempty"`
+	Name    string          `json:"name"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+	// TTL is the time the command waits to be completed. It is used only
+	// to calculate the expiration time when the command is issued.
+	TTL    time.Duration   `json:"-"`
+	Status Status          `json:"status"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+	// Responder is the thing which reported the latest command status.
+	Responder   string    `json:"responder,omitempty"`
+	CreatedBy   string    `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	DeliveredAt time.Time `json:"delivered_at,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// Subtopic returns the subtopic the command is delivered to.
+func (cmd Command) Subtopic() string {
+	if cmd.ThingID == "" {
+		return Subtopic
+	}
+
+	return Subtopic + "." + cmd.ThingID
+}
+
+// Request is the payload of the message delivering the command to things.
+// Command ID is used as the correlation ID.
+type Request struct {
+	CorrelationID string          `json:"correlation_id"`
+	Name          string          `json:"name"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+	ExpiresAt     int64           `json:"expires_at"`
+}
+
+// Response is the payload of the message things publish to the responses
+// subtopic to report the command status. Status is one of `acked`,
+// `succeeded` and `failed`.
+type Response struct {
+	CorrelationID string          `json:"correlation_id"`
+	Status        Status          `json:"status"`
+	Result        json.RawMessage `json:"result,omitempty"`
+	Error         string          `json:"error,omitempty"`
+}
+
+// Presence is the thing online state reported by the things service.
+type Presence struct {
+	ThingID  string
+	Online   bool
+	LastSeen time.Time
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Offset    uint64 `json:"offset"`
+	Limit     uint64 `json:"limit"`
+	DomainID  string `json:"domain_id,omitempty"`
+	ChannelID string `json:"channel_id,omitempty"`
+	ThingID   string `json:"thing_id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Status    Status `json:"status,omitempty"`
+}
+
+// CommandsPage contains page related metadata as well as list of commands
+// that belong to this page.
+type CommandsPage struct {
+	PageMetadata
+	Total    uint64    `json:"total"`
+	Commands []Command `json:"commands"`
+}
+
+// Repository specifies a command persistence API.
+//
+//go:generate mockery --name Repository --output=./mocks --filename repository.go --quiet --note "Copyright (c) Abstract Machines"
+type Repository interface {
+	// Save persists the command.
+	Save(ctx context.Context, cmd Command) (Command, error)
+
+	// RetrieveByID retrieves the command having the provided identifier.
+	RetrieveByID(ctx context.Context, id string) (Command, error)
+
+	// RetrieveAll retrieves commands of the domain.
+	RetrieveAll(ctx context.Context, pm PageMetadata) (CommandsPage, error)
+
+	// RetrieveQueued retrieves queued commands of the thing which are not
+	// expired.
+	RetrieveQueued(ctx context.Context, thingID string) ([]Command, error)
+
+	// UpdateStatus updates the command status, result and responder. Status
+	// is updated only if the stored status precedes the new one.
+	UpdateStatus(ctx context.Context, cmd Command) (Command, error)
+
+	// Expire marks commands which are not completed before the provided
+	// time as expired and returns the number of expired commands.
+	Expire(ctx context.Context, now time.Time) (uint64, error)
+
+	// UpdatePresence stores the thing presence, unless the stored one is
+	// more recent, and returns the stored presence.
+	UpdatePresence(ctx context.Context, presence Presence) (Presence, error)
+
+	// RetrievePresence retrieves the presence of the thing.
+	RetrievePresence(ctx context.Context, thingID string) (Presence, error)
+}
@@ -0,0 +1,9 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package commands contains the domain concept definitions needed to support
+// Magistrala commands service functionality. Commands service delivers
+// commands issued by users to things over the channel the things are connected
+// to, matches the responses the things publish back and tracks the command
+// status.
+package commands
@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package events provides the presence events consumer for the commands
+// service. Queued commands are delivered once the things service reports
+// the thing they are issued for online.
+package events
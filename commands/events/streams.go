@@ -0,0 +1,72 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/commands"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/events"
+)
+
+const (
+	// thingsStream is the things service events stream. Thing presence is
+	// tracked by the things service, which publishes the changes of the
+	// thing online state there.
+	thingsStream   = "events.magistrala.things"
+	thingsPresence = "thing.change_presence"
+)
+
+// Start starts consuming presence events of the things service.
+func Start(ctx context.Context, consumer string, sub events.Subscriber, svc commands.Service) error {
+	subCfg := events.SubscriberConfig{
+		Consumer: consumer,
+		Stream:   thingsStream,
+		Handler:  NewEventHandler(svc),
+	}
+
+	return sub.Subscribe(ctx, subCfg)
+}
+
+type eventHandler struct {
+	svc commands.Service
+}
+
+// NewEventHandler returns the presence events handler.
+func NewEventHandler(svc commands.Service) events.EventHandler {
+	return &eventHandler{svc: svc}
+}
+
+func (eh *eventHandler) Handle(ctx context.Context, event events.Event) error {
+	data, err := event.Encode()
+	if err != nil {
+		return err
+	}
+	if events.Read(data, "operation", "") != thingsPresence {
+		return nil
+	}
+
+	// Presence events which don't change the online state are not
+	// published, so the online state is always set.
+	online, ok := data["online"].(bool)
+	if !ok {
+		return nil
+	}
+	presence := commands.Presence{
+		ThingID: events.Read(data, "id", ""),
+		Online:  online,
+	}
+	if presence.ThingID == "" {
+		return svcerr.ErrMalformedEntity
+	}
+	lastSeen, err := time.Parse(time.RFC3339Nano, events.Read(data, "last_seen", ""))
+	if err != nil {
+		return svcerr.ErrMalformedEntity
+	}
+	presence.LastSeen = lastSeen
+
+	return eh.svc.UpdatePresence(ctx, presence)
+}
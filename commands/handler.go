@@ -0,0 +1,30 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/pkg/messaging"
+)
+
+var _ messaging.MessageHandler = (*responseHandler)(nil)
+
+type responseHandler struct {
+	svc Service
+}
+
+// NewResponseHandler returns the message handler passing the responses
+// published by things to the service.
+func NewResponseHandler(svc Service) messaging.MessageHandler {
+	return &responseHandler{svc: svc}
+}
+
+func (h *responseHandler) Handle(msg *messaging.Message) error {
+	return h.svc.HandleResponse(context.Background(), msg)
+}
+
+func (h *responseHandler) Cancel() error {
+	return nil
+}
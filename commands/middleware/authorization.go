@@ -0,0 +1,94 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/commands"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	mgauthz "github.com/absmach/magistrala/pkg/authz"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/policies"
+)
+
+var _ commands.Service = (*authorizationMiddleware)(nil)
+
+type authorizationMiddleware struct {
+	svc   commands.Service
+	authz mgauthz.Authorization
+}
+
+// AuthorizationMiddleware adds authorization to the commands service.
+// Commands are issued by users who can edit the thing or the channel
+// the command is issued for, and can be viewed by domain members.
+func AuthorizationMiddleware(svc commands.Service, authz mgauthz.Authorization) commands.Service {
+	return &authorizationMiddleware{
+		svc:   svc,
+		authz: authz,
+	}
+}
+
+func (am *authorizationMiddleware) IssueCommand(ctx context.Context, session mgauthn.Session, cmd commands.Command) (commands.Command, error) {
+	switch cmd.ThingID {
+	case "":
+		if err := am.authorize(ctx, session.DomainID, policies.UserType, session.DomainUserID, policies.EditPermission, policies.GroupType, cmd.ChannelID); err != nil {
+			return commands.Command{}, err
+		}
+	default:
+		if err := am.authorize(ctx, session.DomainID, policies.UserType, session.DomainUserID, policies.EditPermission, policies.ThingType, cmd.ThingID); err != nil {
+			return commands.Command{}, err
+		}
+		// The thing must be connected to the channel to receive the command.
+		if err := am.authorize(ctx, session.DomainID, policies.GroupType, cmd.ChannelID, policies.SubscribePermission, policies.ThingType, cmd.ThingID); err != nil {
+			return commands.Command{}, err
+		}
+	}
+
+	return am.svc.IssueCommand(ctx, session, cmd)
+}
+
+func (am *authorizationMiddleware) ViewCommand(ctx context.Context, session mgauthn.Session, id string) (commands.Command, error) {
+	if err := am.authorize(ctx, "", policies.UserType, session.DomainUserID, policies.MembershipPermission, policies.DomainType, session.DomainID); err != nil {
+		return commands.Command{}, err
+	}
+
+	return am.svc.ViewCommand(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) ListCommands(ctx context.Context, session mgauthn.Session, pm commands.PageMetadata) (commands.CommandsPage, error) {
+	if err := am.authorize(ctx, "", policies.UserType, session.DomainUserID, policies.MembershipPermission, policies.DomainType, session.DomainID); err != nil {
+		return commands.CommandsPage{}, err
+	}
+
+	return am.svc.ListCommands(ctx, session, pm)
+}
+
+func (am *authorizationMiddleware) HandleResponse(ctx context.Context, msg *messaging.Message) error {
+	return am.svc.HandleResponse(ctx, msg)
+}
+
+func (am *authorizationMiddleware) UpdatePresence(ctx context.Context, presence commands.Presence) error {
+	return am.svc.UpdatePresence(ctx, presence)
+}
+
+func (am *authorizationMiddleware) ExpireCommands(ctx context.Context) (uint64, error) {
+	return am.svc.ExpireCommands(ctx)
+}
+
+func (am *authorizationMiddleware) authorize(ctx context.Context, domain, subjType, subj, perm, objType, obj string) error {
+	req := mgauthz.PolicyReq{
+		Domain:      domain,
+		SubjectType: subjType,
+		Subject:     subj,
+		Permission:  perm,
+		ObjectType:  objType,
+		Object:      obj,
+	}
+	if subjType == policies.UserType {
+		req.SubjectKind = policies.UsersKind
+	}
+
+	return am.authz.Authorize(ctx, req)
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package middleware provides authorization, logging, metrics and tracing
+// middlewares for the commands service.
+package middleware
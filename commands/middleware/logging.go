@@ -0,0 +1,146 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/magistrala/commands"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/messaging"
+)
+
+var _ commands.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger *slog.Logger
+	svc    commands.Service
+}
+
+// LoggingMiddleware adds logging facilities to the commands service.
+func LoggingMiddleware(svc commands.Service, logger *slog.Logger) commands.Service {
+	return &loggingMiddleware{
+		logger: logger,
+		svc:    svc,
+	}
+}
+
+func (lm *loggingMiddleware) IssueCommand(ctx context.Context, session mgauthn.Session, cmd commands.Command) (c commands.Command, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("command",
+				slog.String("id", c.ID),
+				slog.String("name", cmd.Name),
+				slog.String("channel_id", cmd.ChannelID),
+				slog.String("thing_id", cmd.ThingID),
+				slog.String("status", c.Status.String()),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Issue command failed", args...)
+			return
+		}
+		lm.logger.Info("Issue command completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.IssueCommand(ctx, session, cmd)
+}
+
+func (lm *loggingMiddleware) ViewCommand(ctx context.Context, session mgauthn.Session, id string) (c commands.Command, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("command_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View command failed", args...)
+			return
+		}
+		lm.logger.Info("View command completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewCommand(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ListCommands(ctx context.Context, session mgauthn.Session, pm commands.PageMetadata) (page commands.CommandsPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("page",
+				slog.String("channel_id", pm.ChannelID),
+				slog.String("thing_id", pm.ThingID),
+				slog.String("status", pm.Status.String()),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List commands failed", args...)
+			return
+		}
+		lm.logger.Info("List commands completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListCommands(ctx, session, pm)
+}
+
+func (lm *loggingMiddleware) HandleResponse(ctx context.Context, msg *messaging.Message) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("channel_id", msg.GetChannel()),
+			slog.String("thing_id", msg.GetPublisher()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Handle command response failed", args...)
+			return
+		}
+		lm.logger.Info("Handle command response completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.HandleResponse(ctx, msg)
+}
+
+func (lm *loggingMiddleware) UpdatePresence(ctx context.Context, presence commands.Presence) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("thing_id", presence.ThingID),
+			slog.Bool("online", presence.Online),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Update thing presence failed", args...)
+			return
+		}
+		lm.logger.Debug("Update thing presence completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.UpdatePresence(ctx, presence)
+}
+
+func (lm *loggingMiddleware) ExpireCommands(ctx context.Context) (count uint64, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Uint64("count", count),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Expire commands failed", args...)
+			return
+		}
+		lm.logger.Debug("Expire commands completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ExpireCommands(ctx)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/commands"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ commands.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     commands.Service
+}
+
+// MetricsMiddleware instruments commands service by tracking request count and latency.
+func MetricsMiddleware(svc commands.Service, counter metrics.Counter, latency metrics.Histogram) commands.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (mm *metricsMiddleware) IssueCommand(ctx context.Context, session mgauthn.Session, cmd commands.Command) (commands.Command, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "issue_command").Add(1)
+		mm.latency.With("method", "issue_command").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.IssueCommand(ctx, session, cmd)
+}
+
+func (mm *metricsMiddleware) ViewCommand(ctx context.Context, session mgauthn.Session, id string) (commands.Command, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_command").Add(1)
+		mm.latency.With("method", "view_command").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewCommand(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) ListCommands(ctx context.Context, session mgauthn.Session, pm commands.PageMetadata) (commands.CommandsPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_commands").Add(1)
+		mm.latency.With("method", "list_commands").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListCommands(ctx, session, pm)
+}
+
+func (mm *metricsMiddleware) HandleResponse(ctx context.Context, msg *messaging.Message) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "handle_response").Add(1)
+		mm.latency.With("method", "handle_response").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.HandleResponse(ctx, msg)
+}
+
+func (mm *metricsMiddleware) UpdatePresence(ctx context.Context, presence commands.Presence) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "update_presence").Add(1)
+		mm.latency.With("method", "update_presence").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.UpdatePresence(ctx, presence)
+}
+
+func (mm *metricsMiddleware) ExpireCommands(ctx context.Context) (uint64, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "expire_commands").Add(1)
+		mm.latency.With("method", "expire_commands").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ExpireCommands(ctx)
+}
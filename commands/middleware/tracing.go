@@ -0,0 +1,83 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/commands"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ commands.Service = (*tracing)(nil)
+
+type tracing struct {
+	tracer trace.Tracer
+	svc    commands.Service
+}
+
+// Tracing adds tracing to the commands service.
+func Tracing(svc commands.Service, tracer trace.Tracer) commands.Service {
+	return &tracing{tracer, svc}
+}
+
+func (tm *tracing) IssueCommand(ctx context.Context, session mgauthn.Session, cmd commands.Command) (commands.Command, error) {
+	ctx, span := tm.tracer.Start(ctx, "issue_command", trace.WithAttributes(
+		attribute.String("name", cmd.Name),
+		attribute.String("channel_id", cmd.ChannelID),
+		attribute.String("thing_id", cmd.ThingID),
+	))
+	defer span.End()
+
+	return tm.svc.IssueCommand(ctx, session, cmd)
+}
+
+func (tm *tracing) ViewCommand(ctx context.Context, session mgauthn.Session, id string) (commands.Command, error) {
+	ctx, span := tm.tracer.Start(ctx, "view_command", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.ViewCommand(ctx, session, id)
+}
+
+func (tm *tracing) ListCommands(ctx context.Context, session mgauthn.Session, pm commands.PageMetadata) (commands.CommandsPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_commands", trace.WithAttributes(
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+		attribute.String("channel_id", pm.ChannelID),
+		attribute.String("thing_id", pm.ThingID),
+	))
+	defer span.End()
+
+	return tm.svc.ListCommands(ctx, session, pm)
+}
+
+func (tm *tracing) HandleResponse(ctx context.Context, msg *messaging.Message) error {
+	ctx, span := tm.tracer.Start(ctx, "handle_response", trace.WithAttributes(
+		attribute.String("channel_id", msg.GetChannel()),
+		attribute.String("thing_id", msg.GetPublisher()),
+	))
+	defer span.End()
+
+	return tm.svc.HandleResponse(ctx, msg)
+}
+
+func (tm *tracing) UpdatePresence(ctx context.Context, presence commands.Presence) error {
+	ctx, span := tm.tracer.Start(ctx, "update_presence", trace.WithAttributes(
+		attribute.String("thing_id", presence.ThingID),
+		attribute.Bool("online", presence.Online),
+	))
+	defer span.End()
+
+	return tm.svc.UpdatePresence(ctx, presence)
+}
+
+func (tm *tracing) ExpireCommands(ctx context.Context) (uint64, error) {
+	ctx, span := tm.tracer.Start(ctx, "expire_commands")
+	defer span.End()
+
+	return tm.svc.ExpireCommands(ctx)
+}
@@ -0,0 +1,310 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/commands"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+)
+
+const commandColumns = `id, domain_id, channel_id, thing_id, name, payload, status, result, error,
+	responder, created_by, created_at, delivered_at, updated_at, expires_at`
+
+var _ commands.Repository = (*repository)(nil)
+
+type repository struct {
+	db postgres.Database
+}
+
+// NewRepository instantiates a PostgreSQL implementation of commands repository.
+func NewRepository(db postgres.Database) commands.Repository {
+	return &repository{db: db}
+}
+
+func (repo *repository) Save(ctx context.Context, cmd commands.Command) (commands.Command, error) {
+	q := fmt.Sprintf(`INSERT INTO commands (%s)
+		VALUES (:id, :domain_id, :channel_id, :thing_id, :name, :payload, :status, :result, :error,
+		:responder, :created_by, :created_at, :delivered_at, :updated_at, :expires_at)
+		RETURNING %s;`, commandColumns, commandColumns)
+
+	return repo.namedQueryRow(ctx, q, toDBCommand(cmd), repoerr.ErrCreateEntity)
+}
+
+func (repo *repository) RetrieveByID(ctx context.Context, id string) (commands.Command, error) {
+	q := fmt.Sprintf(`SELECT %s FROM commands WHERE id = :id;`, commandColumns)
+
+	return repo.namedQueryRow(ctx, q, dbCommand{ID: id}, repoerr.ErrViewEntity)
+}
+
+func (repo *repository) RetrieveAll(ctx context.Context, pm commands.PageMetadata) (commands.CommandsPage, error) {
+	query := pageQuery(pm)
+	q := fmt.Sprintf(`SELECT %s FROM commands %s ORDER BY created_at DESC LIMIT :limit OFFSET :offset;`, commandColumns, query)
+
+	params := map[string]interface{}{
+		"domain_id":  pm.DomainID,
+		"channel_id": pm.ChannelID,
+		"thing_id":   pm.ThingID,
+		"name":       pm.Name,
+		"status":     pm.Status,
+		"limit":      pm.Limit,
+		"offset":     pm.Offset,
+	}
+
+	cmds, err := repo.namedQuery(ctx, q, params)
+	if err != nil {
+		return commands.CommandsPage{}, err
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM commands %s;`, query)
+	total, err := postgres.Total(ctx, repo.db, cq, params)
+	if err != nil {
+		return commands.CommandsPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return commands.CommandsPage{
+		PageMetadata: pm,
+		Total:        total,
+		Commands:     cmds,
+	}, nil
+}
+
+func (repo *repository) RetrieveQueued(ctx context.Context, thingID string) ([]commands.Command, error) {
+	q := fmt.Sprintf(`SELECT %s FROM commands WHERE thing_id = :thing_id AND status = :status AND expires_at > :now
+		ORDER BY created_at;`, commandColumns)
+
+	params := map[string]interface{}{
+		"thing_id": thingID,
+		"status":   commands.QueuedStatus,
+		"now":      time.Now().UTC(),
+	}
+
+	return repo.namedQuery(ctx, q, params)
+}
+
+func (repo *repository) UpdateStatus(ctx context.Context, cmd commands.Command) (commands.Command, error) {
+	q := fmt.Sprintf(`UPDATE commands SET status = :status, result = :result, error = :error, responder = :responder,
+		delivered_at = :delivered_at, updated_at = :updated_at
+		WHERE id = :id AND status < :status AND status < :final
+		RETURNING %s;`, commandColumns)
+
+	params := struct {
+		dbCommand
+		Final commands.Status `db:"final"`
+	}{
+		dbCommand: toDBCommand(cmd),
+		Final:     commands.SucceededStatus,
+	}
+
+	return repo.namedQueryRow(ctx, q, params, repoerr.ErrUpdateEntity)
+}
+
+func (repo *repository) Expire(ctx context.Context, now time.Time) (uint64, error) {
+	q := `UPDATE commands SET status = :expired, updated_at = :now WHERE status < :final AND expires_at <= :now;`
+
+	params := map[string]interface{}{
+		"expired": commands.ExpiredStatus,
+		"final":   commands.SucceededStatus,
+		"now":     now.UTC(),
+	}
+
+	res, err := repo.db.NamedExecContext(ctx, q, params)
+	if err != nil {
+		return 0, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return 0, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	return uint64(cnt), nil
+}
+
+func (repo *repository) UpdatePresence(ctx context.Context, presence commands.Presence) (commands.Presence, error) {
+	// Presence is updated only by the more recent one, and the stored
+	// presence is returned either way.
+	q := `INSERT INTO presence (thing_id, online, last_seen) VALUES (:thing_id, :online, :last_seen)
+		ON CONFLICT (thing_id) DO UPDATE SET online = EXCLUDED.online, last_seen = EXCLUDED.last_seen
+		WHERE presence.last_seen <= EXCLUDED.last_seen;`
+	if _, err := repo.db.NamedExecContext(ctx, q, toDBPresence(presence)); err != nil {
+		return commands.Presence{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	return repo.RetrievePresence(ctx, presence.ThingID)
+}
+
+func (repo *repository) RetrievePresence(ctx context.Context, thingID string) (commands.Presence, error) {
+	q := `SELECT thing_id, online, last_seen FROM presence WHERE thing_id = :thing_id;`
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbPresence{ThingID: thingID})
+	if err != nil {
+		return commands.Presence{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return commands.Presence{}, errors.Wrap(repoerr.ErrNotFound, sql.ErrNoRows)
+	}
+	var dbp dbPresence
+	if err := rows.StructScan(&dbp); err != nil {
+		return commands.Presence{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return commands.Presence{
+		ThingID:  dbp.ThingID,
+		Online:   dbp.Online,
+		LastSeen: dbp.LastSeen,
+	}, nil
+}
+
+func (repo *repository) namedQueryRow(ctx context.Context, q string, params interface{}, wrapper error) (commands.Command, error) {
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return commands.Command{}, postgres.HandleError(wrapper, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return commands.Command{}, errors.Wrap(repoerr.ErrNotFound, sql.ErrNoRows)
+	}
+	var dbc dbCommand
+	if err := rows.StructScan(&dbc); err != nil {
+		return commands.Command{}, postgres.HandleError(wrapper, err)
+	}
+
+	return toCommand(dbc), nil
+}
+
+func (repo *repository) namedQuery(ctx context.Context, q string, params interface{}) ([]commands.Command, error) {
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var cmds []commands.Command
+	for rows.Next() {
+		var dbc dbCommand
+		if err := rows.StructScan(&dbc); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		cmds = append(cmds, toCommand(dbc))
+	}
+
+	return cmds, nil
+}
+
+func pageQuery(pm commands.PageMetadata) string {
+	query := []string{"domain_id = :domain_id"}
+	if pm.ChannelID != "" {
+		query = append(query, "channel_id = :channel_id")
+	}
+	if pm.ThingID != "" {
+		query = append(query, "thing_id = :thing_id")
+	}
+	if pm.Name != "" {
+		query = append(query, "name = :name")
+	}
+	if pm.Status != commands.AllStatus {
+		query = append(query, "status = :status")
+	}
+
+	return fmt.Sprintf("WHERE %s", strings.Join(query, " AND "))
+}
+
+type dbCommand struct {
+	ID          string          `db:"id"`
+	DomainID    string          `db:"domain_id"`
+	ChannelID   string          `db:"channel_id"`
+	ThingID     sql.NullString  `db:"thing_id"`
+	Name        string          `db:"name"`
+	Payload     []byte          `db:"payload"`
+	Status      commands.Status `db:"status"`
+	Result      []byte          `db:"result"`
+	Error       sql.NullString  `db:"error"`
+	Responder   sql.NullString  `db:"responder"`
+	CreatedBy   string          `db:"created_by"`
+	CreatedAt   time.Time       `db:"created_at"`
+	DeliveredAt sql.NullTime    `db:"delivered_at"`
+	UpdatedAt   sql.NullTime    `db:"updated_at"`
+	ExpiresAt   time.Time       `db:"expires_at"`
+}
+
+type dbPresence struct {
+	ThingID  string    `db:"thing_id"`
+	Online   bool      `db:"online"`
+	LastSeen time.Time `db:"last_seen"`
+}
+
+func toDBPresence(p commands.Presence) dbPresence {
+	return dbPresence{
+		ThingID:  p.ThingID,
+		Online:   p.Online,
+		LastSeen: p.LastSeen.UTC(),
+	}
+}
+
+func toDBCommand(cmd commands.Command) dbCommand {
+	return dbCommand{
+		ID:          cmd.ID,
+		DomainID:    cmd.DomainID,
+		ChannelID:   cmd.ChannelID,
+		ThingID:     nullString(cmd.ThingID),
+		Name:        cmd.Name,
+		Payload:     nullJSON(cmd.Payload),
+		Status:      cmd.Status,
+		Result:      nullJSON(cmd.Result),
+		Error:       nullString(cmd.Error),
+		Responder:   nullString(cmd.Responder),
+		CreatedBy:   cmd.CreatedBy,
+		CreatedAt:   cmd.CreatedAt.UTC(),
+		DeliveredAt: nullTime(cmd.DeliveredAt),
+		UpdatedAt:   nullTime(cmd.UpdatedAt),
+		ExpiresAt:   cmd.ExpiresAt.UTC(),
+	}
+}
+
+func toCommand(dbc dbCommand) commands.Command {
+	return commands.Command{
+		ID:          dbc.ID,
+		DomainID:    dbc.DomainID,
+		ChannelID:   dbc.ChannelID,
+		ThingID:     dbc.ThingID.String,
+		Name:        dbc.Name,
+		Payload:     dbc.Payload,
+		Status:      dbc.Status,
+		Result:      dbc.Result,
+		Error:       dbc.Error.String,
+		Responder:   dbc.Responder.String,
+		CreatedBy:   dbc.CreatedBy,
+		CreatedAt:   dbc.CreatedAt,
+		DeliveredAt: dbc.DeliveredAt.Time,
+		UpdatedAt:   dbc.UpdatedAt.Time,
+		ExpiresAt:   dbc.ExpiresAt,
+	}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t.UTC(), Valid: !t.IsZero()}
+}
+
+// nullJSON stores empty JSON documents as NULL.
+func nullJSON(b []byte) []byte {
+	if len(b) == 0 {
+		return nil
+	}
+
+	return b
+}
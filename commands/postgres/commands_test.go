@@ -0,0 +1,460 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/commands"
+	cpostgres "github.com/absmach/magistrala/commands/postgres"
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cleanup(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM commands")
+		require.Nil(t, err, fmt.Sprintf("clean commands unexpected error: %s", err))
+		_, err = db.Exec("DELETE FROM presence")
+		require.Nil(t, err, fmt.Sprintf("clean presence unexpected error: %s", err))
+	})
+}
+
+func newCommand(t *testing.T, domainID, channelID, thingID, name string, createdAt time.Time) commands.Command {
+	return commands.Command{
+		ID:        testsutil.GenerateUUID(t),
+		DomainID:  domainID,
+		ChannelID: channelID,
+		ThingID:   thingID,
+		Name:      name,
+		Payload:   json.RawMessage(`{"delay":5}`),
+		Status:    commands.QueuedStatus,
+		CreatedBy: testsutil.GenerateUUID(t),
+		CreatedAt: createdAt,
+		ExpiresAt: createdAt.Add(time.Hour),
+	}
+}
+
+func TestSave(t *testing.T) {
+	cleanup(t)
+	repo := cpostgres.NewRepository(database)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	cmd := newCommand(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t), testsutil.GenerateUUID(t), "reboot", now)
+	channelCmd := newCommand(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t), "", "reboot", now)
+	channelCmd.Payload = nil
+
+	cases := []struct {
+		desc string
+		cmd  commands.Command
+		err  error
+	}{
+		{
+			desc: "save thing command successfully",
+			cmd:  cmd,
+		},
+		{
+			desc: "save channel command successfully",
+			cmd:  channelCmd,
+		},
+		{
+			desc: "save command with duplicate id",
+			cmd:  cmd,
+			err:  repoerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			saved, err := repo.Save(context.Background(), tc.cmd)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.cmd.ID, saved.ID)
+				assert.Equal(t, tc.cmd.ThingID, saved.ThingID)
+				assert.Equal(t, tc.cmd.Status, saved.Status)
+				assert.Equal(t, tc.cmd.CreatedAt, saved.CreatedAt)
+				assert.Equal(t, tc.cmd.ExpiresAt, saved.ExpiresAt)
+				if tc.cmd.Payload != nil {
+					assert.JSONEq(t, string(tc.cmd.Payload), string(saved.Payload))
+				}
+			}
+		})
+	}
+}
+
+func TestRetrieveByID(t *testing.T) {
+	cleanup(t)
+	repo := cpostgres.NewRepository(database)
+
+	cmd := newCommand(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t), testsutil.GenerateUUID(t), "reboot", time.Now().UTC().Truncate(time.Microsecond))
+	_, err := repo.Save(context.Background(), cmd)
+	require.Nil(t, err, fmt.Sprintf("save command unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "retrieve existing command",
+			id:   cmd.ID,
+		},
+		{
+			desc: "retrieve non-existing command",
+			id:   testsutil.GenerateUUID(t),
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := repo.RetrieveByID(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, cmd.ID, got.ID)
+				assert.Equal(t, cmd.DomainID, got.DomainID)
+				assert.Equal(t, cmd.ChannelID, got.ChannelID)
+				assert.Equal(t, cmd.Name, got.Name)
+				assert.Equal(t, cmd.CreatedBy, got.CreatedBy)
+			}
+		})
+	}
+}
+
+func TestRetrieveAll(t *testing.T) {
+	cleanup(t)
+	repo := cpostgres.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	channelID := testsutil.GenerateUUID(t)
+	thingID := testsutil.GenerateUUID(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	var cmds []commands.Command
+	for i := 0; i < 10; i++ {
+		cmd := newCommand(t, domainID, channelID, "", "reboot", now.Add(time.Duration(i)*time.Second))
+		if i%2 == 0 {
+			cmd.ThingID = thingID
+			cmd.Name = "update"
+			cmd.Status = commands.FailedStatus
+		}
+		_, err := repo.Save(context.Background(), cmd)
+		require.Nil(t, err, fmt.Sprintf("save command unexpected error: %s", err))
+		cmds = append(cmds, cmd)
+	}
+	other := newCommand(t, testsutil.GenerateUUID(t), channelID, thingID, "update", now)
+	_, err := repo.Save(context.Background(), other)
+	require.Nil(t, err, fmt.Sprintf("save command unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		pm    commands.PageMetadata
+		total uint64
+		size  int
+		first string
+	}{
+		{
+			desc:  "retrieve all domain commands",
+			pm:    commands.PageMetadata{Limit: 100, DomainID: domainID, Status: commands.AllStatus},
+			total: 10,
+			size:  10,
+			first: cmds[9].ID,
+		},
+		{
+			desc:  "retrieve domain commands with offset and limit",
+			pm:    commands.PageMetadata{Offset: 2, Limit: 3, DomainID: domainID, Status: commands.AllStatus},
+			total: 10,
+			size:  3,
+			first: cmds[7].ID,
+		},
+		{
+			desc:  "retrieve domain commands by thing",
+			pm:    commands.PageMetadata{Limit: 100, DomainID: domainID, ThingID: thingID, Status: commands.AllStatus},
+			total: 5,
+			size:  5,
+			first: cmds[8].ID,
+		},
+		{
+			desc:  "retrieve domain commands by name",
+			pm:    commands.PageMetadata{Limit: 100, DomainID: domainID, Name: "reboot", Status: commands.AllStatus},
+			total: 5,
+			size:  5,
+			first: cmds[9].ID,
+		},
+		{
+			desc:  "retrieve domain commands by status",
+			pm:    commands.PageMetadata{Limit: 100, DomainID: domainID, Status: commands.QueuedStatus},
+			total: 5,
+			size:  5,
+			first: cmds[9].ID,
+		},
+		{
+			desc:  "retrieve domain commands by channel and status",
+			pm:    commands.PageMetadata{Limit: 100, DomainID: domainID, ChannelID: channelID, Status: commands.FailedStatus},
+			total: 5,
+			size:  5,
+			first: cmds[8].ID,
+		},
+		{
+			desc: "retrieve commands of domain without commands",
+			pm:   commands.PageMetadata{Limit: 100, DomainID: testsutil.GenerateUUID(t), Status: commands.AllStatus},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RetrieveAll(context.Background(), tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+			assert.Len(t, page.Commands, tc.size, fmt.Sprintf("%s: expected %d commands got %d", tc.desc, tc.size, len(page.Commands)))
+			if tc.size > 0 {
+				assert.Equal(t, tc.first, page.Commands[0].ID, fmt.Sprintf("%s: expected newest command first", tc.desc))
+			}
+		})
+	}
+}
+
+func TestRetrieveQueued(t *testing.T) {
+	cleanup(t)
+	repo := cpostgres.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	channelID := testsutil.GenerateUUID(t)
+	thingID := testsutil.GenerateUUID(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	first := newCommand(t, domainID, channelID, thingID, "reboot", now.Add(-2*time.Second))
+	second := newCommand(t, domainID, channelID, thingID, "update", now.Add(-time.Second))
+	delivered := newCommand(t, domainID, channelID, thingID, "reboot", now)
+	delivered.Status = commands.DeliveredStatus
+	expired := newCommand(t, domainID, channelID, thingID, "reboot", now.Add(-2*time.Hour))
+	otherThing := newCommand(t, domainID, channelID, testsutil.GenerateUUID(t), "reboot", now)
+	for _, cmd := range []commands.Command{second, first, delivered, expired, otherThing} {
+		_, err := repo.Save(context.Background(), cmd)
+		require.Nil(t, err, fmt.Sprintf("save command unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc    string
+		thingID string
+		ids     []string
+	}{
+		{
+			desc:    "retrieve queued commands of thing in creation order",
+			thingID: thingID,
+			ids:     []string{first.ID, second.ID},
+		},
+		{
+			desc:    "retrieve queued commands of thing without commands",
+			thingID: testsutil.GenerateUUID(t),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cmds, err := repo.RetrieveQueued(context.Background(), tc.thingID)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			var ids []string
+			for _, cmd := range cmds {
+				ids = append(ids, cmd.ID)
+			}
+			assert.Equal(t, tc.ids, ids, fmt.Sprintf("%s: expected commands %v got %v", tc.desc, tc.ids, ids))
+		})
+	}
+}
+
+func TestUpdateStatus(t *testing.T) {
+	cleanup(t)
+	repo := cpostgres.NewRepository(database)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	cmd := newCommand(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t), testsutil.GenerateUUID(t), "reboot", now)
+	_, err := repo.Save(context.Background(), cmd)
+	require.Nil(t, err, fmt.Sprintf("save command unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		cmd    commands.Command
+		status commands.Status
+		err    error
+	}{
+		{
+			desc: "update queued command to delivered",
+			cmd: commands.Command{
+				ID:          cmd.ID,
+				Status:      commands.DeliveredStatus,
+				DeliveredAt: now,
+				UpdatedAt:   now,
+			},
+			status: commands.DeliveredStatus,
+		},
+		{
+			desc: "update delivered command back to queued",
+			cmd: commands.Command{
+				ID:        cmd.ID,
+				Status:    commands.QueuedStatus,
+				UpdatedAt: now,
+			},
+			err: repoerr.ErrNotFound,
+		},
+		{
+			desc: "update delivered command to succeeded",
+			cmd: commands.Command{
+				ID:        cmd.ID,
+				Status:    commands.SucceededStatus,
+				Result:    json.RawMessage(`{"ok":true}`),
+				Responder: testsutil.GenerateUUID(t),
+				UpdatedAt: now,
+			},
+			status: commands.SucceededStatus,
+		},
+		{
+			desc: "update succeeded command to failed",
+			cmd: commands.Command{
+				ID:        cmd.ID,
+				Status:    commands.FailedStatus,
+				Error:     "failed",
+				UpdatedAt: now,
+			},
+			err: repoerr.ErrNotFound,
+		},
+		{
+			desc: "update non-existing command",
+			cmd: commands.Command{
+				ID:        testsutil.GenerateUUID(t),
+				Status:    commands.DeliveredStatus,
+				UpdatedAt: now,
+			},
+			err: repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := repo.UpdateStatus(context.Background(), tc.cmd)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.status, got.Status, fmt.Sprintf("%s: expected status %s got %s", tc.desc, tc.status, got.Status))
+				assert.Equal(t, tc.cmd.Responder, got.Responder)
+				if tc.cmd.Result != nil {
+					assert.JSONEq(t, string(tc.cmd.Result), string(got.Result))
+				}
+			}
+		})
+	}
+}
+
+func TestExpire(t *testing.T) {
+	cleanup(t)
+	repo := cpostgres.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	channelID := testsutil.GenerateUUID(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	queued := newCommand(t, domainID, channelID, testsutil.GenerateUUID(t), "reboot", now.Add(-2*time.Hour))
+	delivered := newCommand(t, domainID, channelID, testsutil.GenerateUUID(t), "reboot", now.Add(-2*time.Hour))
+	delivered.Status = commands.DeliveredStatus
+	succeeded := newCommand(t, domainID, channelID, testsutil.GenerateUUID(t), "reboot", now.Add(-2*time.Hour))
+	succeeded.Status = commands.SucceededStatus
+	active := newCommand(t, domainID, channelID, testsutil.GenerateUUID(t), "reboot", now)
+	for _, cmd := range []commands.Command{queued, delivered, succeeded, active} {
+		_, err := repo.Save(context.Background(), cmd)
+		require.Nil(t, err, fmt.Sprintf("save command unexpected error: %s", err))
+	}
+
+	cnt, err := repo.Expire(context.Background(), now)
+	assert.Nil(t, err, fmt.Sprintf("expire commands unexpected error: %s", err))
+	assert.Equal(t, uint64(2), cnt, fmt.Sprintf("expected 2 expired commands got %d", cnt))
+
+	cases := []struct {
+		desc   string
+		id     string
+		status commands.Status
+	}{
+		{
+			desc:   "queued command past expiry is expired",
+			id:     queued.ID,
+			status: commands.ExpiredStatus,
+		},
+		{
+			desc:   "delivered command past expiry is expired",
+			id:     delivered.ID,
+			status: commands.ExpiredStatus,
+		},
+		{
+			desc:   "succeeded command keeps its status",
+			id:     succeeded.ID,
+			status: commands.SucceededStatus,
+		},
+		{
+			desc:   "command before expiry keeps its status",
+			id:     active.ID,
+			status: commands.QueuedStatus,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cmd, err := repo.RetrieveByID(context.Background(), tc.id)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, cmd.Status, fmt.Sprintf("%s: expected status %s got %s", tc.desc, tc.status, cmd.Status))
+		})
+	}
+
+	cnt, err = repo.Expire(context.Background(), now)
+	assert.Nil(t, err, fmt.Sprintf("expire commands unexpected error: %s", err))
+	assert.Equal(t, uint64(0), cnt, fmt.Sprintf("expected no expired commands got %d", cnt))
+}
+
+func TestPresence(t *testing.T) {
+	cleanup(t)
+	repo := cpostgres.NewRepository(database)
+
+	thingID := testsutil.GenerateUUID(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	_, err := repo.RetrievePresence(context.Background(), thingID)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("expected error %s got %s", repoerr.ErrNotFound, err))
+
+	cases := []struct {
+		desc     string
+		presence commands.Presence
+		expected commands.Presence
+	}{
+		{
+			desc:     "save presence of thing",
+			presence: commands.Presence{ThingID: thingID, Online: true, LastSeen: now},
+			expected: commands.Presence{ThingID: thingID, Online: true, LastSeen: now},
+		},
+		{
+			desc:     "update presence with more recent one",
+			presence: commands.Presence{ThingID: thingID, Online: false, LastSeen: now.Add(time.Second)},
+			expected: commands.Presence{ThingID: thingID, Online: false, LastSeen: now.Add(time.Second)},
+		},
+		{
+			desc:     "update presence with older one",
+			presence: commands.Presence{ThingID: thingID, Online: true, LastSeen: now.Add(-time.Second)},
+			expected: commands.Presence{ThingID: thingID, Online: false, LastSeen: now.Add(time.Second)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := repo.UpdatePresence(context.Background(), tc.presence)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.expected, got, fmt.Sprintf("%s: expected presence %v got %v", tc.desc, tc.expected, got))
+			stored, err := repo.RetrievePresence(context.Background(), thingID)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.expected, stored, fmt.Sprintf("%s: expected stored presence %v got %v", tc.desc, tc.expected, stored))
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Migration of commands service.
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "commands_01",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS commands (
+						id				VARCHAR(36) PRIMARY KEY,
+						domain_id		VARCHAR(36) NOT NULL,
+						channel_id		VARCHAR(36) NOT NULL,
+						thing_id		VARCHAR(36),
+						name			VARCHAR(1024) NOT NULL,
+						payload			JSONB,
+						status			SMALLINT NOT NULL DEFAULT 0 CHECK (status >= 0),
+						result			JSONB,
+						error			TEXT,
+						responder		VARCHAR(36),
+						created_by		VARCHAR(254),
+						created_at		TIMESTAMP NOT NULL,
+						delivered_at	TIMESTAMP,
+						updated_at		TIMESTAMP,
+						expires_at		TIMESTAMP NOT NULL
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_commands_domain ON commands(domain_id, created_at)`,
+					`CREATE INDEX IF NOT EXISTS idx_commands_thing ON commands(thing_id, status)`,
+					`CREATE INDEX IF NOT EXISTS idx_commands_expires ON commands(status, expires_at)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS commands`,
+				},
+			},
+			{
+				Id: "commands_02",
+				// Presence of things reported by the things service, so the
+				// service knows which things are offline across restarts.
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS presence (
+						thing_id	VARCHAR(36) PRIMARY KEY,
+						online		BOOLEAN NOT NULL,
+						last_seen	TIMESTAMP NOT NULL
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS presence`,
+				},
+			},
+		},
+	}
+}
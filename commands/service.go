@@ -0,0 +1,272 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/magistrala"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+)
+
+var (
+	// ErrInvalidResponse indicates a malformed command response.
+	ErrInvalidResponse = errors.New("invalid command response")
+
+	// ErrStatusTransition indicates that the response status does not follow
+	// the current command status.
+	ErrStatusTransition = errors.New("invalid command status transition")
+
+	// ErrDeliver indicates a failure to publish the command to the channel.
+	ErrDeliver = errors.New("failed to deliver command")
+)
+
+// Service specifies an API for issuing commands to things and tracking their
+// status.
+//
+//go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
+type Service interface {
+	// IssueCommand stores the command and delivers it, unless the thing the
+	// command is issued for is offline. Commands which are not delivered
+	// stay queued until the thing comes online.
+	IssueCommand(ctx context.Context, session mgauthn.Session, cmd Command) (Command, error)
+
+	// ViewCommand retrieves the command having the provided identifier.
+	ViewCommand(ctx context.Context, session mgauthn.Session, id string) (Command, error)
+
+	// ListCommands retrieves commands of the session domain.
+	ListCommands(ctx context.Context, session mgauthn.Session, pm PageMetadata) (CommandsPage, error)
+
+	// HandleResponse updates the status of the command the response message
+	// published by the thing refers to.
+	HandleResponse(ctx context.Context, msg *messaging.Message) error
+
+	// UpdatePresence records whether the thing is online and delivers the
+	// queued commands of the thing once it comes online.
+	UpdatePresence(ctx context.Context, presence Presence) error
+
+	// ExpireCommands marks the commands which were not completed before
+	// their TTL passed as expired.
+	ExpireCommands(ctx context.Context) (uint64, error)
+}
+
+var _ Service = (*service)(nil)
+
+type service struct {
+	idProvider magistrala.IDProvider
+	repo       Repository
+	publisher  messaging.Publisher
+	ttl        time.Duration
+}
+
+// New instantiates the commands service implementation. TTL is used for the
+// commands issued without one.
+func New(idp magistrala.IDProvider, repo Repository, publisher messaging.Publisher, ttl time.Duration) Service {
+	return &service{
+		idProvider: idp,
+		repo:       repo,
+		publisher:  publisher,
+		ttl:        ttl,
+	}
+}
+
+func (svc *service) IssueCommand(ctx context.Context, session mgauthn.Session, cmd Command) (Command, error) {
+	if cmd.ChannelID == "" || cmd.Name == "" {
+		return Command{}, svcerr.ErrMalformedEntity
+	}
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return Command{}, err
+	}
+	ttl := cmd.TTL
+	if ttl <= 0 {
+		ttl = svc.ttl
+	}
+	now := time.Now()
+	cmd.ID = id
+	cmd.DomainID = session.DomainID
+	cmd.Status = QueuedStatus
+	cmd.CreatedBy = session.UserID
+	cmd.CreatedAt = now
+	cmd.ExpiresAt = now.Add(ttl)
+
+	saved, err := svc.repo.Save(ctx, cmd)
+	if err != nil {
+		return Command{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	// Presence is checked after the command is stored, so the command is
+	// either delivered here or once the thing comes online.
+	if saved.ThingID != "" && svc.offline(ctx, saved.ThingID) {
+		return saved, nil
+	}
+
+	// The command is stored, so delivery failure is not reported to
+	// the issuer. The command stays queued and is delivered once the
+	// thing reports its presence.
+	delivered, err := svc.deliver(ctx, saved)
+	if err != nil {
+		return saved, nil
+	}
+
+	return delivered, nil
+}
+
+func (svc *service) ViewCommand(ctx context.Context, session mgauthn.Session, id string) (Command, error) {
+	cmd, err := svc.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		return Command{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if cmd.DomainID != session.DomainID {
+		return Command{}, svcerr.ErrNotFound
+	}
+
+	return cmd, nil
+}
+
+func (svc *service) ListCommands(ctx context.Context, session mgauthn.Session, pm PageMetadata) (CommandsPage, error) {
+	pm.DomainID = session.DomainID
+	page, err := svc.repo.RetrieveAll(ctx, pm)
+	if err != nil {
+		return CommandsPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (svc *service) HandleResponse(ctx context.Context, msg *messaging.Message) error {
+	var res Response
+	if err := json.Unmarshal(msg.GetPayload(), &res); err != nil {
+		return errors.Wrap(ErrInvalidResponse, err)
+	}
+	if res.CorrelationID == "" {
+		return ErrInvalidResponse
+	}
+
+	cmd, err := svc.repo.RetrieveByID(ctx, res.CorrelationID)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	// Only things the command is issued for can respond to it. Publisher
+	// is the thing authenticated by the protocol adapter, which is
+	// authorized to publish to the channel.
+	if msg.GetPublisher() == "" || cmd.ChannelID != msg.GetChannel() || (cmd.ThingID != "" && cmd.ThingID != msg.GetPublisher()) {
+		return svcerr.ErrAuthorization
+	}
+	if !transition(cmd.Status, res.Status) {
+		return ErrStatusTransition
+	}
+
+	cmd.Status = res.Status
+	cmd.Result = res.Result
+	cmd.Error = res.Error
+	cmd.Responder = msg.GetPublisher()
+	cmd.UpdatedAt = time.Now()
+	if _, err := svc.repo.UpdateStatus(ctx, cmd); err != nil {
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func (svc *service) UpdatePresence(ctx context.Context, presence Presence) error {
+	// Stored presence is the most recent one, since the presence events
+	// may be handled out of order.
+	stored, err := svc.repo.UpdatePresence(ctx, presence)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	if !stored.Online {
+		return nil
+	}
+
+	cmds, err := svc.repo.RetrieveQueued(ctx, presence.ThingID)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	for _, cmd := range cmds {
+		if _, err := svc.deliver(ctx, cmd); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (svc *service) ExpireCommands(ctx context.Context) (uint64, error) {
+	count, err := svc.repo.Expire(ctx, time.Now())
+	if err != nil {
+		return 0, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return count, nil
+}
+
+// deliver publishes the command to the channel and marks it as delivered.
+func (svc *service) deliver(ctx context.Context, cmd Command) (Command, error) {
+	payload, err := json.Marshal(Request{
+		CorrelationID: cmd.ID,
+		Name:          cmd.Name,
+		Payload:       cmd.Payload,
+		ExpiresAt:     cmd.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return Command{}, errors.Wrap(ErrDeliver, err)
+	}
+
+	now := time.Now()
+	msg := &messaging.Message{
+		Channel:   cmd.ChannelID,
+		Subtopic:  cmd.Subtopic(),
+		Publisher: cmd.CreatedBy,
+		Protocol:  Protocol,
+		Payload:   payload,
+		Created:   now.UnixNano(),
+	}
+	if err := svc.publisher.Publish(ctx, cmd.ChannelID, msg); err != nil {
+		return Command{}, errors.Wrap(ErrDeliver, err)
+	}
+
+	cmd.Status = DeliveredStatus
+	cmd.DeliveredAt = now
+	cmd.UpdatedAt = now
+	updated, err := svc.repo.UpdateStatus(ctx, cmd)
+	switch {
+	// The thing responded before the delivery was recorded.
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return cmd, nil
+	case err != nil:
+		return Command{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return updated, nil
+}
+
+// offline reports whether the thing is known to be offline. Commands for the
+// things which presence is unknown, or can't be retrieved, are delivered
+// right away, since they may be online.
+func (svc *service) offline(ctx context.Context, thingID string) bool {
+	presence, err := svc.repo.RetrievePresence(ctx, thingID)
+	if err != nil {
+		return false
+	}
+
+	return !presence.Online
+}
+
+// transition reports whether the command status can be changed to the status
+// reported by the thing.
+func transition(current, next Status) bool {
+	switch next {
+	case AckedStatus, SucceededStatus, FailedStatus:
+		return !current.Final() && current < next
+	default:
+		return false
+	}
+}
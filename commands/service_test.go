@@ -0,0 +1,323 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package commands_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/commands"
+	"github.com/absmach/magistrala/commands/mocks"
+	"github.com/absmach/magistrala/internal/testsutil"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+	pubsubmocks "github.com/absmach/magistrala/pkg/messaging/mocks"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const ttl = time.Minute
+
+var (
+	domainID  = testsutil.GenerateUUID(&testing.T{})
+	userID    = testsutil.GenerateUUID(&testing.T{})
+	channelID = testsutil.GenerateUUID(&testing.T{})
+	thingID   = testsutil.GenerateUUID(&testing.T{})
+	session   = mgauthn.Session{DomainID: domainID, UserID: userID, DomainUserID: domainID + "_" + userID}
+)
+
+func newService() (commands.Service, *mocks.Repository, *pubsubmocks.PubSub) {
+	repo := new(mocks.Repository)
+	pub := new(pubsubmocks.PubSub)
+	idp := uuid.NewMock()
+
+	return commands.New(idp, repo, pub, ttl), repo, pub
+}
+
+func updated(_ context.Context, cmd commands.Command) commands.Command {
+	return cmd
+}
+
+func TestIssueCommand(t *testing.T) {
+	svc, repo, pub := newService()
+
+	online := commands.Presence{ThingID: thingID, Online: true, LastSeen: time.Now()}
+	offline := commands.Presence{ThingID: thingID, Online: false, LastSeen: time.Now()}
+
+	cases := []struct {
+		desc        string
+		cmd         commands.Command
+		saveErr     error
+		presence    commands.Presence
+		presenceErr error
+		publishErr  error
+		status      commands.Status
+		subtopic    string
+		err         error
+	}{
+		{
+			desc:     "issue command for thing",
+			cmd:      commands.Command{ChannelID: channelID, ThingID: thingID, Name: "reboot", Payload: json.RawMessage(`{"delay":5}`)},
+			presence: online,
+			status:   commands.DeliveredStatus,
+			subtopic: "commands." + thingID,
+		},
+		{
+			desc:     "issue command for offline thing",
+			cmd:      commands.Command{ChannelID: channelID, ThingID: thingID, Name: "reboot"},
+			presence: offline,
+			status:   commands.QueuedStatus,
+		},
+		{
+			desc:        "issue command for thing with unknown presence",
+			cmd:         commands.Command{ChannelID: channelID, ThingID: thingID, Name: "reboot"},
+			presenceErr: repoerr.ErrNotFound,
+			status:      commands.DeliveredStatus,
+			subtopic:    "commands." + thingID,
+		},
+		{
+			desc:     "issue command for channel",
+			cmd:      commands.Command{ChannelID: channelID, Name: "reboot", TTL: time.Second},
+			status:   commands.DeliveredStatus,
+			subtopic: "commands",
+		},
+		{
+			desc:       "issue command with failed delivery",
+			cmd:        commands.Command{ChannelID: channelID, ThingID: thingID, Name: "reboot"},
+			presence:   online,
+			publishErr: errors.New("broker unavailable"),
+			status:     commands.QueuedStatus,
+		},
+		{
+			desc: "issue command without name",
+			cmd:  commands.Command{ChannelID: channelID, ThingID: thingID},
+			err:  svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:    "issue command with failed repo save",
+			cmd:     commands.Command{ChannelID: channelID, ThingID: thingID, Name: "reboot"},
+			saveErr: repoerr.ErrCreateEntity,
+			err:     svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("Save", context.Background(), mock.Anything).Return(updated, tc.saveErr)
+			repoCall1 := repo.On("UpdateStatus", context.Background(), mock.Anything).Return(updated, nil)
+			repoCall2 := repo.On("RetrievePresence", context.Background(), thingID).Return(tc.presence, tc.presenceErr)
+			pubCall := pub.On("Publish", context.Background(), channelID, mock.Anything).Return(tc.publishErr)
+			cmd, err := svc.IssueCommand(context.Background(), session, tc.cmd)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.NotEmpty(t, cmd.ID, fmt.Sprintf("%s: expected command ID to be set", tc.desc))
+				assert.Equal(t, domainID, cmd.DomainID, fmt.Sprintf("%s: expected domain %s got %s\n", tc.desc, domainID, cmd.DomainID))
+				assert.Equal(t, tc.status, cmd.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, cmd.Status))
+				assert.True(t, cmd.ExpiresAt.After(cmd.CreatedAt), fmt.Sprintf("%s: expected expiration time to be set", tc.desc))
+			}
+			if tc.subtopic != "" {
+				msg := pub.Calls[len(pub.Calls)-1].Arguments.Get(2).(*messaging.Message)
+				assert.Equal(t, tc.subtopic, msg.GetSubtopic(), fmt.Sprintf("%s: expected subtopic %s got %s\n", tc.desc, tc.subtopic, msg.GetSubtopic()))
+				var req commands.Request
+				assert.Nil(t, json.Unmarshal(msg.GetPayload(), &req), fmt.Sprintf("%s: unexpected error decoding request", tc.desc))
+				assert.Equal(t, cmd.ID, req.CorrelationID, fmt.Sprintf("%s: expected correlation ID %s got %s\n", tc.desc, cmd.ID, req.CorrelationID))
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			pubCall.Unset()
+		})
+	}
+}
+
+func TestHandleResponse(t *testing.T) {
+	svc, repo, _ := newService()
+
+	cmd := commands.Command{
+		ID:        testsutil.GenerateUUID(t),
+		DomainID:  domainID,
+		ChannelID: channelID,
+		ThingID:   thingID,
+		Name:      "reboot",
+		Status:    commands.DeliveredStatus,
+	}
+	channelCmd := cmd
+	channelCmd.ThingID = ""
+	succeeded := cmd
+	succeeded.Status = commands.SucceededStatus
+
+	response := func(status string) []byte {
+		return []byte(fmt.Sprintf(`{"correlation_id":"%s","status":"%s","result":{"uptime":0}}`, cmd.ID, status))
+	}
+
+	cases := []struct {
+		desc        string
+		msg         *messaging.Message
+		cmd         commands.Command
+		retrieveErr error
+		status      commands.Status
+		err         error
+	}{
+		{
+			desc:   "acknowledge command",
+			msg:    &messaging.Message{Channel: channelID, Publisher: thingID, Payload: response("acked")},
+			cmd:    cmd,
+			status: commands.AckedStatus,
+		},
+		{
+			desc:   "complete command",
+			msg:    &messaging.Message{Channel: channelID, Publisher: thingID, Payload: response("succeeded")},
+			cmd:    cmd,
+			status: commands.SucceededStatus,
+		},
+		{
+			desc:   "complete channel command by any thing",
+			msg:    &messaging.Message{Channel: channelID, Publisher: testsutil.GenerateUUID(t), Payload: response("failed")},
+			cmd:    channelCmd,
+			status: commands.FailedStatus,
+		},
+		{
+			desc: "respond from other thing",
+			msg:  &messaging.Message{Channel: channelID, Publisher: testsutil.GenerateUUID(t), Payload: response("succeeded")},
+			cmd:  cmd,
+			err:  svcerr.ErrAuthorization,
+		},
+		{
+			desc: "respond to channel command without publisher",
+			msg:  &messaging.Message{Channel: channelID, Payload: response("succeeded")},
+			cmd:  channelCmd,
+			err:  svcerr.ErrAuthorization,
+		},
+		{
+			desc: "respond on other channel",
+			msg:  &messaging.Message{Channel: testsutil.GenerateUUID(t), Publisher: thingID, Payload: response("succeeded")},
+			cmd:  cmd,
+			err:  svcerr.ErrAuthorization,
+		},
+		{
+			desc: "respond to completed command",
+			msg:  &messaging.Message{Channel: channelID, Publisher: thingID, Payload: response("failed")},
+			cmd:  succeeded,
+			err:  commands.ErrStatusTransition,
+		},
+		{
+			desc: "respond with invalid status",
+			msg:  &messaging.Message{Channel: channelID, Publisher: thingID, Payload: response("expired")},
+			cmd:  cmd,
+			err:  commands.ErrStatusTransition,
+		},
+		{
+			desc: "respond with malformed payload",
+			msg:  &messaging.Message{Channel: channelID, Publisher: thingID, Payload: []byte("{")},
+			err:  commands.ErrInvalidResponse,
+		},
+		{
+			desc: "respond without correlation ID",
+			msg:  &messaging.Message{Channel: channelID, Publisher: thingID, Payload: []byte(`{"status":"acked"}`)},
+			err:  commands.ErrInvalidResponse,
+		},
+		{
+			desc:        "respond to non-existing command",
+			msg:         &messaging.Message{Channel: channelID, Publisher: thingID, Payload: response("acked")},
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveByID", context.Background(), cmd.ID).Return(tc.cmd, tc.retrieveErr)
+			repoCall1 := repo.On("UpdateStatus", context.Background(), mock.Anything).Return(updated, nil)
+			err := svc.HandleResponse(context.Background(), tc.msg)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				saved := repo.Calls[len(repo.Calls)-1].Arguments.Get(1).(commands.Command)
+				assert.Equal(t, tc.status, saved.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, saved.Status))
+				assert.Equal(t, tc.msg.GetPublisher(), saved.Responder, fmt.Sprintf("%s: expected responder %s got %s\n", tc.desc, tc.msg.GetPublisher(), saved.Responder))
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
+
+func TestUpdatePresence(t *testing.T) {
+	svc, repo, pub := newService()
+
+	queued := commands.Command{
+		ID:        testsutil.GenerateUUID(t),
+		DomainID:  domainID,
+		ChannelID: channelID,
+		ThingID:   thingID,
+		Name:      "reboot",
+		Status:    commands.QueuedStatus,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	now := time.Now()
+	online := commands.Presence{ThingID: thingID, Online: true, LastSeen: now}
+	offline := commands.Presence{ThingID: thingID, Online: false, LastSeen: now}
+
+	cases := []struct {
+		desc      string
+		presence  commands.Presence
+		stored    commands.Presence
+		updateErr error
+		delivered bool
+		err       error
+	}{
+		{
+			desc:      "mark thing online",
+			presence:  online,
+			stored:    online,
+			delivered: true,
+		},
+		{
+			desc:     "mark thing offline",
+			presence: offline,
+			stored:   offline,
+		},
+		{
+			desc:     "mark thing online with more recent offline presence",
+			presence: commands.Presence{ThingID: thingID, Online: true, LastSeen: now.Add(-time.Second)},
+			stored:   offline,
+		},
+		{
+			desc:      "mark thing online with failed to update presence",
+			presence:  online,
+			updateErr: repoerr.ErrUpdateEntity,
+			err:       svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("UpdatePresence", context.Background(), tc.presence).Return(tc.stored, tc.updateErr)
+			repoCall1 := repo.On("UpdateStatus", context.Background(), mock.Anything).Return(updated, nil)
+			repoCall2 := repo.On("RetrieveQueued", context.Background(), thingID).Return([]commands.Command{queued}, nil)
+			pubCall := pub.On("Publish", context.Background(), channelID, mock.Anything).Return(nil)
+			err := svc.UpdatePresence(context.Background(), tc.presence)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.delivered {
+				pub.AssertNumberOfCalls(t, "Publish", 1)
+				delivered := repo.Calls[len(repo.Calls)-1].Arguments.Get(1).(commands.Command)
+				assert.Equal(t, queued.ID, delivered.ID, fmt.Sprintf("%s: expected command %s to be delivered, got %s\n", tc.desc, queued.ID, delivered.ID))
+				assert.Equal(t, commands.DeliveredStatus, delivered.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, commands.DeliveredStatus, delivered.Status))
+			} else {
+				pub.AssertNotCalled(t, "Publish", context.Background(), channelID, mock.Anything)
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			pubCall.Unset()
+			pub.Calls = nil
+		})
+	}
+}
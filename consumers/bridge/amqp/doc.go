@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package amqp contains the forwarder implementation which publishes
+// messages to external AMQP exchanges.
+package amqp
@@ -0,0 +1,166 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package amqp
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/bridge"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/webhooks"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	contentType = "application/octet-stream"
+	appID       = "magistrala-bridge"
+	locale      = "en_US"
+
+	channelHeader   = "channel"
+	subtopicHeader  = "subtopic"
+	publisherHeader = "publisher"
+	protocolHeader  = "protocol"
+)
+
+var _ bridge.Forwarder = (*forwarder)(nil)
+
+type conn struct {
+	conn    *amqp.Connection
+	channel *amqp.Channel
+	version time.Time
+}
+
+func (c conn) close() error {
+	if err := c.channel.Close(); err != nil {
+		return err
+	}
+
+	return c.conn.Close()
+}
+
+type forwarder struct {
+	mu     sync.Mutex
+	conns  map[string]conn
+	dialer *net.Dialer
+}
+
+// NewForwarder returns a new forwarder which publishes message payloads to
+// external AMQP exchanges, using the rendered route topic as routing key.
+// Connections are made only to the addresses permitted by the filter.
+func NewForwarder(timeout time.Duration, addresses webhooks.AddressFilter) bridge.Forwarder {
+	return &forwarder{
+		conns: make(map[string]conn),
+		dialer: &net.Dialer{
+			Timeout: timeout,
+			Control: addresses.Control,
+		},
+	}
+}
+
+func (fwd *forwarder) Forward(ctx context.Context, route bridge.Route, topic string, msg *messaging.Message) error {
+	ch, err := fwd.channel(route)
+	if err != nil {
+		return err
+	}
+
+	err = ch.PublishWithContext(
+		ctx,
+		route.Exchange,
+		topic,
+		false,
+		false,
+		amqp.Publishing{
+			Headers: amqp.Table{
+				channelHeader:   msg.GetChannel(),
+				subtopicHeader:  msg.GetSubtopic(),
+				publisherHeader: msg.GetPublisher(),
+				protocolHeader:  msg.GetProtocol(),
+			},
+			ContentType: contentType,
+			AppId:       appID,
+			Timestamp:   time.Unix(0, msg.GetCreated()),
+			Body:        msg.GetPayload(),
+		})
+	if err != nil {
+		// Drop the connection so the next attempt reconnects.
+		fwd.drop(route.ID)
+		return err
+	}
+
+	return nil
+}
+
+func (fwd *forwarder) Release(routeID string) error {
+	fwd.mu.Lock()
+	defer fwd.mu.Unlock()
+
+	if c, ok := fwd.conns[routeID]; ok {
+		delete(fwd.conns, routeID)
+		return c.close()
+	}
+
+	return nil
+}
+
+func (fwd *forwarder) drop(routeID string) {
+	fwd.mu.Lock()
+	defer fwd.mu.Unlock()
+
+	if c, ok := fwd.conns[routeID]; ok {
+		delete(fwd.conns, routeID)
+		_ = c.close()
+	}
+}
+
+func (fwd *forwarder) channel(route bridge.Route) (*amqp.Channel, error) {
+	fwd.mu.Lock()
+	defer fwd.mu.Unlock()
+
+	if c, ok := fwd.conns[route.ID]; ok {
+		if c.version.Equal(route.UpdatedAt) && !c.conn.IsClosed() {
+			return c.channel, nil
+		}
+		delete(fwd.conns, route.ID)
+		_ = c.close()
+	}
+
+	tc, err := bridge.LoadTLSConfig(route.TLS)
+	if err != nil {
+		return nil, err
+	}
+	c, err := amqp.DialConfig(route.URL, amqp.Config{
+		TLSClientConfig: tc,
+		Locale:          locale,
+		Dial:            fwd.dial,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ch, err := c.Channel()
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+	fwd.conns[route.ID] = conn{conn: c, channel: ch, version: route.UpdatedAt}
+
+	return ch, nil
+}
+
+// dial connects to the broker and sets the deadline of the handshake, which
+// is cleared by the client once the connection is established.
+func (fwd *forwarder) dial(network, addr string) (net.Conn, error) {
+	c, err := fwd.dialer.Dial(network, addr)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.SetDeadline(time.Now().Add(fwd.dialer.Timeout)); err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,171 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/consumers/bridge"
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func createRouteEndpoint(svc bridge.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createRouteReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		route, err := svc.CreateRoute(ctx, session, req.route())
+		if err != nil {
+			return nil, err
+		}
+
+		return newRouteRes(route, true), nil
+	}
+}
+
+func viewRouteEndpoint(svc bridge.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(routeIDReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		route, err := svc.ViewRoute(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return newRouteRes(route, false), nil
+	}
+}
+
+func listRoutesEndpoint(svc bridge.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listRoutesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		page, err := svc.ListRoutes(ctx, session, req.pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := routesPageRes{
+			PageMetadata: page.PageMetadata,
+			Total:        page.Total,
+			Routes:       []routeRes{},
+		}
+		for _, route := range page.Routes {
+			res.Routes = append(res.Routes, newRouteRes(route, false))
+		}
+
+		return res, nil
+	}
+}
+
+func updateRouteEndpoint(svc bridge.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateRouteReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		route, err := svc.UpdateRoute(ctx, session, req.route())
+		if err != nil {
+			return nil, err
+		}
+
+		return newRouteRes(route, false), nil
+	}
+}
+
+func enableRouteEndpoint(svc bridge.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(routeIDReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		route, err := svc.EnableRoute(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return newRouteRes(route, false), nil
+	}
+}
+
+func disableRouteEndpoint(svc bridge.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(routeIDReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		route, err := svc.DisableRoute(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return newRouteRes(route, false), nil
+	}
+}
+
+func removeRouteEndpoint(svc bridge.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(routeIDReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		if err := svc.RemoveRoute(ctx, session, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeRouteRes{}, nil
+	}
+}
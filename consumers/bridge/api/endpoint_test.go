@@ -0,0 +1,676 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/bridge"
+	"github.com/absmach/magistrala/consumers/bridge/api"
+	"github.com/absmach/magistrala/consumers/bridge/mocks"
+	"github.com/absmach/magistrala/internal/testsutil"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	authnmocks "github.com/absmach/magistrala/pkg/authn/mocks"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/absmach/magistrala/webhooks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	validToken       = "valid"
+	validContentType = "application/json"
+	userID           = testsutil.GenerateUUID(&testing.T{})
+	domainID         = testsutil.GenerateUUID(&testing.T{})
+	channelID        = testsutil.GenerateUUID(&testing.T{})
+	routeID          = testsutil.GenerateUUID(&testing.T{})
+	validSession     = mgauthn.Session{UserID: userID, DomainID: domainID, DomainUserID: domainID + "_" + userID}
+	validRoute       = bridge.Route{
+		ID:       routeID,
+		Name:     "route",
+		DomainID: domainID,
+		Channel:  channelID,
+		Subtopic: "room.*",
+		Type:     bridge.MQTTType,
+		URL:      "tcp://10.1.0.5:1883",
+		Topic:    "devices/{{.Publisher}}",
+		Status:   bridge.EnabledStatus,
+	}
+	validReq = fmt.Sprintf(`{"name":"route","channel_id":"%s","subtopic":"room.*","type":"mqtt","url":"tcp://10.1.0.5:1883","topic":"devices/{{.Publisher}}"}`, channelID)
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	token       string
+	contentType string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+
+	return tr.client.Do(req)
+}
+
+func newBridgeServer() (*httptest.Server, *mocks.Service, *authnmocks.Authentication) {
+	svc := new(mocks.Service)
+	authn := new(authnmocks.Authentication)
+	mux := api.MakeHandler(svc, authn, mglog.NewMock(), "bridge", "test")
+
+	return httptest.NewServer(mux), svc, authn
+}
+
+func TestCreateRoute(t *testing.T) {
+	bs, svc, authn := newBridgeServer()
+	defer bs.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		authnRes    mgauthn.Session
+		authnErr    error
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "create route successfully",
+			token:       validToken,
+			data:        validReq,
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create route with empty token",
+			data:        validReq,
+			contentType: validContentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create route with invalid token",
+			token:       "invalid",
+			data:        validReq,
+			contentType: validContentType,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create route with invalid content type",
+			token:       validToken,
+			data:        validReq,
+			contentType: "text/plain",
+			authnRes:    validSession,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "create route with malformed body",
+			token:       validToken,
+			data:        "{",
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create route with invalid type",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"channel_id":"%s","type":"smtp","url":"smtp://10.1.0.5"}`, channelID),
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create route without channel",
+			token:       validToken,
+			data:        `{"type":"mqtt","url":"tcp://10.1.0.5:1883"}`,
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create route without url",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"channel_id":"%s","type":"mqtt"}`, channelID),
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create route with too long name",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"name":"%s","channel_id":"%s","type":"mqtt","url":"tcp://10.1.0.5:1883"}`, strings.Repeat("a", 1025), channelID),
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create route with service error",
+			token:       validToken,
+			data:        validReq,
+			contentType: validContentType,
+			authnRes:    validSession,
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("CreateRoute", mock.Anything, tc.authnRes, mock.Anything).Return(validRoute, tc.svcErr)
+			req := testRequest{
+				client:      bs.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/routes", bs.URL, domainID),
+				token:       tc.token,
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusCreated {
+				location := fmt.Sprintf("/%s/routes/%s", domainID, routeID)
+				assert.Equal(t, location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, location, res.Header.Get("Location")))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestCreateRouteAddresses(t *testing.T) {
+	repo := new(mocks.Repository)
+	forwarders := map[bridge.Type]bridge.Forwarder{
+		bridge.MQTTType: new(mocks.Forwarder),
+		bridge.HTTPType: new(mocks.Forwarder),
+	}
+	addresses, _ := webhooks.NewAddressFilter([]string{"10.1.0.0/16"})
+	svc := bridge.New(uuid.NewMock(), repo, forwarders, addresses, bridge.DispatchConfig{RoutesTTL: time.Minute, QueueSize: 1})
+	authn := new(authnmocks.Authentication)
+	bs := httptest.NewServer(api.MakeHandler(svc, authn, mglog.NewMock(), "bridge", "test"))
+	defer bs.Close()
+
+	cases := []struct {
+		desc   string
+		url    string
+		typ    string
+		status int
+	}{
+		{
+			desc:   "create route with allowed network url",
+			url:    "tcp://10.1.0.5:1883",
+			typ:    "mqtt",
+			status: http.StatusCreated,
+		},
+		{
+			desc:   "create route with loopback url",
+			url:    "tcp://127.0.0.1:1883",
+			typ:    "mqtt",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "create route with localhost url",
+			url:    "http://localhost:9026/ingest",
+			typ:    "http",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "create route with private url",
+			url:    "http://192.168.1.10/ingest",
+			typ:    "http",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "create route with metadata service url",
+			url:    "http://169.254.169.254/latest/meta-data",
+			typ:    "http",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "create route with unsupported scheme",
+			url:    "ws://10.1.0.5/mqtt",
+			typ:    "mqtt",
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, validToken).Return(validSession, nil)
+			repoCall := repo.On("Save", mock.Anything, mock.Anything).Return(validRoute, nil)
+			req := testRequest{
+				client:      bs.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/routes", bs.URL, domainID),
+				token:       validToken,
+				contentType: validContentType,
+				body:        strings.NewReader(fmt.Sprintf(`{"channel_id":"%s","type":"%s","url":"%s"}`, channelID, tc.typ, tc.url)),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			repoCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestViewRoute(t *testing.T) {
+	bs, svc, authn := newBridgeServer()
+	defer bs.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "view route successfully",
+			token:    validToken,
+			id:       routeID,
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "view route with invalid token",
+			token:    "invalid",
+			id:       routeID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "view non-existing route",
+			token:    validToken,
+			id:       routeID,
+			authnRes: validSession,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("ViewRoute", mock.Anything, tc.authnRes, tc.id).Return(validRoute, tc.svcErr)
+			req := testRequest{
+				client: bs.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/routes/%s", bs.URL, domainID, tc.id),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var route struct {
+					ID string `json:"id"`
+				}
+				err := json.NewDecoder(res.Body).Decode(&route)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Equal(t, validRoute.ID, route.ID, fmt.Sprintf("%s: expected route %s got %s", tc.desc, validRoute.ID, route.ID))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestListRoutes(t *testing.T) {
+	bs, svc, authn := newBridgeServer()
+	defer bs.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		query    string
+		pm       bridge.PageMetadata
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "list routes successfully",
+			token:    validToken,
+			pm:       bridge.PageMetadata{Limit: 10, Status: bridge.AllStatus},
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list routes with channel and status",
+			token:    validToken,
+			query:    fmt.Sprintf("channel_id=%s&status=disabled&offset=1&limit=5", channelID),
+			pm:       bridge.PageMetadata{Offset: 1, Limit: 5, Channel: channelID, Status: bridge.DisabledStatus},
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list routes with invalid token",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "list routes with invalid offset",
+			token:    validToken,
+			query:    "offset=invalid",
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list routes with limit exceeding maximum",
+			token:    validToken,
+			query:    "limit=1000",
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list routes with invalid status",
+			token:    validToken,
+			query:    "status=invalid",
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list routes with service error",
+			token:    validToken,
+			pm:       bridge.PageMetadata{Limit: 10, Status: bridge.AllStatus},
+			authnRes: validSession,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			page := bridge.RoutesPage{PageMetadata: tc.pm, Total: 1, Routes: []bridge.Route{validRoute}}
+			svcCall := svc.On("ListRoutes", mock.Anything, tc.authnRes, tc.pm).Return(page, tc.svcErr)
+			req := testRequest{
+				client: bs.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/routes?%s", bs.URL, domainID, tc.query),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestUpdateRoute(t *testing.T) {
+	bs, svc, authn := newBridgeServer()
+	defer bs.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		authnRes    mgauthn.Session
+		authnErr    error
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "update route successfully",
+			token:       validToken,
+			data:        validReq,
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "update route with invalid token",
+			token:       "invalid",
+			data:        validReq,
+			contentType: validContentType,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "update route with invalid content type",
+			token:       validToken,
+			data:        validReq,
+			contentType: "text/plain",
+			authnRes:    validSession,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "update route without url",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"channel_id":"%s","type":"mqtt"}`, channelID),
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update route with forbidden address",
+			token:       validToken,
+			data:        validReq,
+			contentType: validContentType,
+			authnRes:    validSession,
+			svcErr:      errors.Wrap(svcerr.ErrMalformedEntity, webhooks.ErrForbiddenAddress),
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update non-existing route",
+			token:       validToken,
+			data:        validReq,
+			contentType: validContentType,
+			authnRes:    validSession,
+			svcErr:      svcerr.ErrNotFound,
+			status:      http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("UpdateRoute", mock.Anything, tc.authnRes, mock.Anything).Return(validRoute, tc.svcErr)
+			req := testRequest{
+				client:      bs.Client(),
+				method:      http.MethodPut,
+				url:         fmt.Sprintf("%s/%s/routes/%s", bs.URL, domainID, routeID),
+				token:       tc.token,
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestEnableRoute(t *testing.T) {
+	bs, svc, authn := newBridgeServer()
+	defer bs.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "enable route successfully",
+			token:    validToken,
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "enable route with invalid token",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "enable enabled route",
+			token:    validToken,
+			authnRes: validSession,
+			svcErr:   errors.ErrStatusAlreadyAssigned,
+			status:   http.StatusConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("EnableRoute", mock.Anything, tc.authnRes, routeID).Return(validRoute, tc.svcErr)
+			req := testRequest{
+				client: bs.Client(),
+				method: http.MethodPost,
+				url:    fmt.Sprintf("%s/%s/routes/%s/enable", bs.URL, domainID, routeID),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestDisableRoute(t *testing.T) {
+	bs, svc, authn := newBridgeServer()
+	defer bs.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "disable route successfully",
+			token:    validToken,
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "disable route with invalid token",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "disable disabled route",
+			token:    validToken,
+			authnRes: validSession,
+			svcErr:   errors.ErrStatusAlreadyAssigned,
+			status:   http.StatusConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("DisableRoute", mock.Anything, tc.authnRes, routeID).Return(validRoute, tc.svcErr)
+			req := testRequest{
+				client: bs.Client(),
+				method: http.MethodPost,
+				url:    fmt.Sprintf("%s/%s/routes/%s/disable", bs.URL, domainID, routeID),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestRemoveRoute(t *testing.T) {
+	bs, svc, authn := newBridgeServer()
+	defer bs.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "remove route successfully",
+			token:    validToken,
+			authnRes: validSession,
+			status:   http.StatusNoContent,
+		},
+		{
+			desc:     "remove route with invalid token",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "remove route with service error",
+			token:    validToken,
+			authnRes: validSession,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("RemoveRoute", mock.Anything, tc.authnRes, routeID).Return(tc.svcErr)
+			req := testRequest{
+				client: bs.Client(),
+				method: http.MethodDelete,
+				url:    fmt.Sprintf("%s/%s/routes/%s", bs.URL, domainID, routeID),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"github.com/absmach/magistrala/consumers/bridge"
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/pkg/apiutil"
+)
+
+type routeReq struct {
+	id       string
+	Name     string             `json:"name,omitempty"`
+	Channel  string             `json:"channel_id"`
+	Subtopic string             `json:"subtopic,omitempty"`
+	Type     bridge.Type        `json:"type"`
+	URL      string             `json:"url"`
+	Topic    string             `json:"topic,omitempty"`
+	Exchange string             `json:"exchange,omitempty"`
+	Headers  map[string]string  `json:"headers,omitempty"`
+	Username string             `json:"username,omitempty"`
+	Password string             `json:"password,omitempty"`
+	TLS      bridge.TLSConfig   `json:"tls,omitempty"`
+	Retry    bridge.RetryConfig `json:"retry"`
+}
+
+func (req routeReq) route() bridge.Route {
+	return bridge.Route{
+		ID:       req.id,
+		Name:     req.Name,
+		Channel:  req.Channel,
+		Subtopic: req.Subtopic,
+		Type:     req.Type,
+		URL:      req.URL,
+		Topic:    req.Topic,
+		Exchange: req.Exchange,
+		Headers:  req.Headers,
+		Username: req.Username,
+		Password: req.Password,
+		TLS:      req.TLS,
+		Retry:    req.Retry,
+	}
+}
+
+type createRouteReq struct {
+	routeReq
+}
+
+func (req createRouteReq) validate() error {
+	return req.routeReq.validate()
+}
+
+type updateRouteReq struct {
+	routeReq
+}
+
+func (req updateRouteReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return req.routeReq.validate()
+}
+
+func (req routeReq) validate() error {
+	if req.Channel == "" {
+		return apiutil.ErrMissingID
+	}
+	if len(req.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+	if req.URL == "" {
+		return apiutil.ErrMissingHost
+	}
+
+	return nil
+}
+
+type routeIDReq struct {
+	id string
+}
+
+func (req routeIDReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listRoutesReq struct {
+	pm bridge.PageMetadata
+}
+
+func (req listRoutesReq) validate() error {
+	if req.pm.Limit > api.MaxLimitSize || req.pm.Limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+	if len(req.pm.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/consumers/bridge"
+)
+
+var (
+	_ magistrala.Response = (*routeRes)(nil)
+	_ magistrala.Response = (*routesPageRes)(nil)
+	_ magistrala.Response = (*removeRouteRes)(nil)
+)
+
+type routeRes struct {
+	bridge.Route `json:",inline"`
+	created      bool
+}
+
+func (res routeRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res routeRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/%s/routes/%s", res.DomainID, res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res routeRes) Empty() bool {
+	return false
+}
+
+type routesPageRes struct {
+	bridge.PageMetadata `json:",inline"`
+	Total               uint64     `json:"total"`
+	Routes              []routeRes `json:"routes"`
+}
+
+func (res routesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res routesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res routesPageRes) Empty() bool {
+	return false
+}
+
+type removeRouteRes struct{}
+
+func (res removeRouteRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeRouteRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeRouteRes) Empty() bool {
+	return true
+}
+
+// newRouteRes hides route credentials, so they are never sent back.
+func newRouteRes(route bridge.Route, created bool) routeRes {
+	route.Password = ""
+	route.TLS.ClientKey = ""
+
+	return routeRes{Route: route, created: created}
+}
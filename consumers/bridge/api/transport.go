@@ -0,0 +1,169 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/consumers/bridge"
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/go-chi/chi/v5"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const (
+	channelIDKey = "channel_id"
+	routeIDKey   = "routeID"
+)
+
+// MakeHandler returns a HTTP handler for bridge API endpoints.
+func MakeHandler(svc bridge.Service, authn mgauthn.Authentication, logger *slog.Logger, svcName, instanceID string) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+	}
+
+	mux := chi.NewRouter()
+
+	mux.Group(func(r chi.Router) {
+		r.Use(api.AuthenticateMiddleware(authn, true))
+
+		r.Route("/{domainID}/routes", func(r chi.Router) {
+			r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+				createRouteEndpoint(svc),
+				decodeCreateRouteReq,
+				api.EncodeResponse,
+				opts...,
+			), "create_route").ServeHTTP)
+
+			r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+				listRoutesEndpoint(svc),
+				decodeListRoutesReq,
+				api.EncodeResponse,
+				opts...,
+			), "list_routes").ServeHTTP)
+
+			r.Route("/{routeID}", func(r chi.Router) {
+				r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+					viewRouteEndpoint(svc),
+					decodeRouteIDReq,
+					api.EncodeResponse,
+					opts...,
+				), "view_route").ServeHTTP)
+
+				r.Put("/", otelhttp.NewHandler(kithttp.NewServer(
+					updateRouteEndpoint(svc),
+					decodeUpdateRouteReq,
+					api.EncodeResponse,
+					opts...,
+				), "update_route").ServeHTTP)
+
+				r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+					removeRouteEndpoint(svc),
+					decodeRouteIDReq,
+					api.EncodeResponse,
+					opts...,
+				), "remove_route").ServeHTTP)
+
+				r.Post("/enable", otelhttp.NewHandler(kithttp.NewServer(
+					enableRouteEndpoint(svc),
+					decodeRouteIDReq,
+					api.EncodeResponse,
+					opts...,
+				), "enable_route").ServeHTTP)
+
+				r.Post("/disable", otelhttp.NewHandler(kithttp.NewServer(
+					disableRouteEndpoint(svc),
+					decodeRouteIDReq,
+					api.EncodeResponse,
+					opts...,
+				), "disable_route").ServeHTTP)
+			})
+		})
+	})
+
+	mux.Get("/health", magistrala.Health(svcName, instanceID))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+func decodeCreateRouteReq(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	var req createRouteReq
+	if err := json.NewDecoder(r.Body).Decode(&req.routeReq); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeUpdateRouteReq(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	var req updateRouteReq
+	if err := json.NewDecoder(r.Body).Decode(&req.routeReq); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+	req.id = chi.URLParam(r, routeIDKey)
+
+	return req, nil
+}
+
+func decodeRouteIDReq(_ context.Context, r *http.Request) (interface{}, error) {
+	return routeIDReq{id: chi.URLParam(r, routeIDKey)}, nil
+}
+
+func decodeListRoutesReq(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	name, err := apiutil.ReadStringQuery(r, api.NameKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	channelID, err := apiutil.ReadStringQuery(r, channelIDKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	s, err := apiutil.ReadStringQuery(r, api.StatusKey, bridge.All)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	status, err := bridge.ToStatus(s)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listRoutesReq{
+		pm: bridge.PageMetadata{
+			Offset:  offset,
+			Limit:   limit,
+			Name:    name,
+			Channel: channelID,
+			Status:  status,
+		},
+	}
+
+	return req, nil
+}
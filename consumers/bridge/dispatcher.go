@@ -0,0 +1,159 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+import (
+	"context"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+)
+
+const (
+	defRoutesTTL = time.Minute
+	defQueueSize = 1000
+)
+
+// ErrQueueFull indicates that the message is dropped because the route
+// queue is full, since the route endpoint doesn't keep up with messages.
+var ErrQueueFull = errors.New("route queue is full")
+
+// DispatchConfig contains parameters of dispatching messages to routes.
+type DispatchConfig struct {
+	// RoutesTTL is the time routes of the channel are cached for. Routes
+	// changed using this service instance are reloaded right away, and
+	// routes changed using other instances are reloaded once they expire.
+	RoutesTTL time.Duration
+	// QueueSize is the number of messages queued for each route. Messages
+	// of a route are forwarded one by one in the order they are consumed.
+	QueueSize int
+}
+
+// compiledRoute is a route together with its parsed topic template.
+type compiledRoute struct {
+	Route
+	topic *template.Template
+}
+
+type cachedRoutes struct {
+	routes  []compiledRoute
+	expires time.Time
+}
+
+type job struct {
+	route compiledRoute
+	msg   *messaging.Message
+}
+
+// worker forwards the queued messages of a single route.
+type worker struct {
+	jobs   chan job
+	cancel context.CancelFunc
+}
+
+// dispatcher caches routes of channels and forwards messages to them
+// using a worker per route.
+type dispatcher struct {
+	cfg     DispatchConfig
+	mu      sync.Mutex
+	routes  map[string]cachedRoutes
+	workers map[string]*worker
+}
+
+func newDispatcher(cfg DispatchConfig) *dispatcher {
+	if cfg.RoutesTTL <= 0 {
+		cfg.RoutesTTL = defRoutesTTL
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = defQueueSize
+	}
+
+	return &dispatcher{
+		cfg:     cfg,
+		routes:  make(map[string]cachedRoutes),
+		workers: make(map[string]*worker),
+	}
+}
+
+// cached returns the routes of the channel if they are cached and not expired.
+func (d *dispatcher) cached(channelID string) ([]compiledRoute, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	c, ok := d.routes[channelID]
+	if !ok || time.Now().After(c.expires) {
+		return nil, false
+	}
+
+	return c.routes, true
+}
+
+// cache parses topic templates of the routes of the channel and caches
+// them. Routes with invalid topic templates are skipped and their errors
+// are returned.
+func (d *dispatcher) cache(channelID string, routes []Route) ([]compiledRoute, []error) {
+	var errs []error
+	compiled := make([]compiledRoute, 0, len(routes))
+	for _, r := range routes {
+		tmpl, err := ParseTopic(r.Topic)
+		if err != nil {
+			errs = append(errs, errors.Wrap(ErrForward, err))
+			continue
+		}
+		compiled = append(compiled, compiledRoute{Route: r, topic: tmpl})
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.routes[channelID] = cachedRoutes{routes: compiled, expires: time.Now().Add(d.cfg.RoutesTTL)}
+
+	return compiled, errs
+}
+
+// enqueue queues the message for the route worker, starting the worker if
+// needed. Message is dropped if the route queue is full.
+func (d *dispatcher) enqueue(route compiledRoute, msg *messaging.Message, forward func(context.Context, compiledRoute, *messaging.Message)) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	w, ok := d.workers[route.ID]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		w = &worker{jobs: make(chan job, d.cfg.QueueSize), cancel: cancel}
+		d.workers[route.ID] = w
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case j := <-w.jobs:
+					forward(ctx, j.route, j.msg)
+				}
+			}
+		}()
+	}
+
+	select {
+	case w.jobs <- job{route: route, msg: msg}:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// invalidate drops the cached routes and stops the route worker, so the
+// messages queued for the previous route endpoint are dropped.
+func (d *dispatcher) invalidate(routeID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	clear(d.routes)
+	if w, ok := d.workers[routeID]; ok {
+		w.cancel()
+		delete(d.workers, routeID)
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bridge contains the domain concept definitions needed to support
+// Magistrala bridge service functionality. Bridge service forwards channel
+// messages to external MQTT brokers, HTTP endpoints and AMQP exchanges.
+package bridge
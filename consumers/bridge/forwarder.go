@@ -0,0 +1,60 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+)
+
+var (
+	// ErrForward indicates failure to forward the message to the external endpoint.
+	ErrForward = errors.New("failed to forward message")
+
+	errLoadCerts = errors.New("failed to load route certificates")
+	errLoadCA    = errors.New("failed to append route CA certificate")
+)
+
+// Forwarder forwards messages to the external endpoints of one route type.
+//
+//go:generate mockery --name Forwarder --output=./mocks --filename forwarder.go --quiet --note "Copyright (c) Abstract Machines"
+type Forwarder interface {
+	// Forward sends the message payload to the route endpoint using the
+	// rendered topic.
+	Forward(ctx context.Context, route Route, topic string, msg *messaging.Message) error
+
+	// Release closes connections held for the route, if any.
+	Release(routeID string) error
+}
+
+// LoadTLSConfig returns TLS configuration for the route. It returns nil if
+// route does not contain TLS certificates.
+func LoadTLSConfig(cfg TLSConfig) (*tls.Config, error) {
+	if cfg.ClientCert == "" && cfg.CACert == "" && !cfg.InsecureSkipVerify {
+		return nil, nil
+	}
+	tc := &tls.Config{
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+	if cfg.ClientCert != "" {
+		cert, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey))
+		if err != nil {
+			return nil, errors.Wrap(errLoadCerts, err)
+		}
+		tc.Certificates = []tls.Certificate{cert}
+	}
+	if cfg.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(cfg.CACert)) {
+			return nil, errLoadCA
+		}
+		tc.RootCAs = pool
+	}
+
+	return tc, nil
+}
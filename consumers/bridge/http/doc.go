@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package http contains the forwarder implementation which posts
+// messages to external HTTP endpoints.
+package http
@@ -0,0 +1,141 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/bridge"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/webhooks"
+)
+
+const (
+	contentType = "application/octet-stream"
+
+	channelHeader   = "X-Magistrala-Channel"
+	subtopicHeader  = "X-Magistrala-Subtopic"
+	publisherHeader = "X-Magistrala-Publisher"
+	protocolHeader  = "X-Magistrala-Protocol"
+	createdHeader   = "X-Magistrala-Created"
+)
+
+var errUnexpectedStatus = errors.New("unexpected response status")
+
+var _ bridge.Forwarder = (*forwarder)(nil)
+
+type conn struct {
+	client  *http.Client
+	version time.Time
+}
+
+type forwarder struct {
+	mu      sync.Mutex
+	conns   map[string]conn
+	timeout time.Duration
+	dialer  *net.Dialer
+}
+
+// NewForwarder returns a new forwarder which posts message payloads to
+// external HTTP endpoints. Any 2xx response is considered a successful
+// delivery. Redirects are not followed, and connections are made only to
+// the addresses permitted by the filter.
+func NewForwarder(timeout time.Duration, addresses webhooks.AddressFilter) bridge.Forwarder {
+	return &forwarder{
+		conns:   make(map[string]conn),
+		timeout: timeout,
+		dialer: &net.Dialer{
+			Timeout: timeout,
+			Control: addresses.Control,
+		},
+	}
+}
+
+func (fwd *forwarder) Forward(ctx context.Context, route bridge.Route, topic string, msg *messaging.Message) error {
+	client, err := fwd.client(route)
+	if err != nil {
+		return err
+	}
+
+	url := route.URL
+	if topic != "" {
+		url = strings.TrimSuffix(url, "/") + "/" + strings.TrimPrefix(topic, "/")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(msg.GetPayload()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set(channelHeader, msg.GetChannel())
+	req.Header.Set(subtopicHeader, msg.GetSubtopic())
+	req.Header.Set(publisherHeader, msg.GetPublisher())
+	req.Header.Set(protocolHeader, msg.GetProtocol())
+	req.Header.Set(createdHeader, fmt.Sprint(msg.GetCreated()))
+	for k, v := range route.Headers {
+		req.Header.Set(k, v)
+	}
+	if route.Username != "" {
+		req.SetBasicAuth(route.Username, route.Password)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.Wrap(errUnexpectedStatus, fmt.Errorf("status code %d", resp.StatusCode))
+	}
+
+	return nil
+}
+
+func (fwd *forwarder) Release(routeID string) error {
+	fwd.mu.Lock()
+	defer fwd.mu.Unlock()
+
+	if c, ok := fwd.conns[routeID]; ok {
+		c.client.CloseIdleConnections()
+		delete(fwd.conns, routeID)
+	}
+
+	return nil
+}
+
+func (fwd *forwarder) client(route bridge.Route) (*http.Client, error) {
+	fwd.mu.Lock()
+	defer fwd.mu.Unlock()
+
+	if c, ok := fwd.conns[route.ID]; ok && c.version.Equal(route.UpdatedAt) {
+		return c.client, nil
+	}
+
+	tc, err := bridge.LoadTLSConfig(route.TLS)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext:         fwd.dialer.DialContext,
+			TLSClientConfig:     tc,
+			TLSHandshakeTimeout: fwd.timeout,
+		},
+		Timeout: fwd.timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	fwd.conns[route.ID] = conn{client: client, version: route.UpdatedAt}
+
+	return client, nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/consumers/bridge"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	mgauthz "github.com/absmach/magistrala/pkg/authz"
+	"github.com/absmach/magistrala/pkg/policies"
+)
+
+var _ bridge.Service = (*authorizationMiddleware)(nil)
+
+type authorizationMiddleware struct {
+	svc   bridge.Service
+	authz mgauthz.Authorization
+}
+
+// AuthorizationMiddleware adds authorization to the bridge service.
+// Routes are managed by domain administrators and can be viewed
+// by domain members.
+func AuthorizationMiddleware(svc bridge.Service, authz mgauthz.Authorization) bridge.Service {
+	return &authorizationMiddleware{
+		svc:   svc,
+		authz: authz,
+	}
+}
+
+func (am *authorizationMiddleware) CreateRoute(ctx context.Context, session mgauthn.Session, route bridge.Route) (bridge.Route, error) {
+	if err := am.authorize(ctx, "", session.DomainUserID, policies.AdminPermission, policies.DomainType, session.DomainID); err != nil {
+		return bridge.Route{}, err
+	}
+	if err := am.authorize(ctx, session.DomainID, session.DomainUserID, policies.SubscribePermission, policies.GroupType, route.Channel); err != nil {
+		return bridge.Route{}, err
+	}
+
+	return am.svc.CreateRoute(ctx, session, route)
+}
+
+func (am *authorizationMiddleware) ViewRoute(ctx context.Context, session mgauthn.Session, id string) (bridge.Route, error) {
+	if err := am.authorize(ctx, "", session.DomainUserID, policies.MembershipPermission, policies.DomainType, session.DomainID); err != nil {
+		return bridge.Route{}, err
+	}
+
+	return am.svc.ViewRoute(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) ListRoutes(ctx context.Context, session mgauthn.Session, pm bridge.PageMetadata) (bridge.RoutesPage, error) {
+	if err := am.authorize(ctx, "", session.DomainUserID, policies.MembershipPermission, policies.DomainType, session.DomainID); err != nil {
+		return bridge.RoutesPage{}, err
+	}
+
+	return am.svc.ListRoutes(ctx, session, pm)
+}
+
+func (am *authorizationMiddleware) UpdateRoute(ctx context.Context, session mgauthn.Session, route bridge.Route) (bridge.Route, error) {
+	if err := am.authorize(ctx, "", session.DomainUserID, policies.AdminPermission, policies.DomainType, session.DomainID); err != nil {
+		return bridge.Route{}, err
+	}
+	if err := am.authorize(ctx, session.DomainID, session.DomainUserID, policies.SubscribePermission, policies.GroupType, route.Channel); err != nil {
+		return bridge.Route{}, err
+	}
+
+	return am.svc.UpdateRoute(ctx, session, route)
+}
+
+func (am *authorizationMiddleware) EnableRoute(ctx context.Context, session mgauthn.Session, id string) (bridge.Route, error) {
+	if err := am.authorize(ctx, "", session.DomainUserID, policies.AdminPermission, policies.DomainType, session.DomainID); err != nil {
+		return bridge.Route{}, err
+	}
+
+	return am.svc.EnableRoute(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) DisableRoute(ctx context.Context, session mgauthn.Session, id string) (bridge.Route, error) {
+	if err := am.authorize(ctx, "", session.DomainUserID, policies.AdminPermission, policies.DomainType, session.DomainID); err != nil {
+		return bridge.Route{}, err
+	}
+
+	return am.svc.DisableRoute(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) RemoveRoute(ctx context.Context, session mgauthn.Session, id string) error {
+	if err := am.authorize(ctx, "", session.DomainUserID, policies.AdminPermission, policies.DomainType, session.DomainID); err != nil {
+		return err
+	}
+
+	return am.svc.RemoveRoute(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) ConsumeAsync(ctx context.Context, messages interface{}) {
+	am.svc.ConsumeAsync(ctx, messages)
+}
+
+func (am *authorizationMiddleware) Errors() <-chan error {
+	return am.svc.Errors()
+}
+
+func (am *authorizationMiddleware) authorize(ctx context.Context, domain, subj, perm, objType, obj string) error {
+	req := mgauthz.PolicyReq{
+		Domain:      domain,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     subj,
+		Permission:  perm,
+		ObjectType:  objType,
+		Object:      obj,
+	}
+
+	return am.authz.Authorize(ctx, req)
+}
@@ -0,0 +1,172 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/bridge"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+)
+
+var _ bridge.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger *slog.Logger
+	svc    bridge.Service
+}
+
+// LoggingMiddleware adds logging facilities to the bridge service.
+func LoggingMiddleware(svc bridge.Service, logger *slog.Logger) bridge.Service {
+	return &loggingMiddleware{
+		logger: logger,
+		svc:    svc,
+	}
+}
+
+func (lm *loggingMiddleware) CreateRoute(ctx context.Context, session mgauthn.Session, route bridge.Route) (r bridge.Route, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("route",
+				slog.String("id", r.ID),
+				slog.String("channel_id", route.Channel),
+				slog.String("type", route.Type.String()),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create route failed", args...)
+			return
+		}
+		lm.logger.Info("Create route completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CreateRoute(ctx, session, route)
+}
+
+func (lm *loggingMiddleware) ViewRoute(ctx context.Context, session mgauthn.Session, id string) (r bridge.Route, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("route_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View route failed", args...)
+			return
+		}
+		lm.logger.Info("View route completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewRoute(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ListRoutes(ctx context.Context, session mgauthn.Session, pm bridge.PageMetadata) (page bridge.RoutesPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("page",
+				slog.String("channel_id", pm.Channel),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List routes failed", args...)
+			return
+		}
+		lm.logger.Info("List routes completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListRoutes(ctx, session, pm)
+}
+
+func (lm *loggingMiddleware) UpdateRoute(ctx context.Context, session mgauthn.Session, route bridge.Route) (r bridge.Route, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("route",
+				slog.String("id", route.ID),
+				slog.String("channel_id", route.Channel),
+				slog.String("type", route.Type.String()),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Update route failed", args...)
+			return
+		}
+		lm.logger.Info("Update route completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.UpdateRoute(ctx, session, route)
+}
+
+func (lm *loggingMiddleware) EnableRoute(ctx context.Context, session mgauthn.Session, id string) (r bridge.Route, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("route_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Enable route failed", args...)
+			return
+		}
+		lm.logger.Info("Enable route completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.EnableRoute(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) DisableRoute(ctx context.Context, session mgauthn.Session, id string) (r bridge.Route, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("route_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Disable route failed", args...)
+			return
+		}
+		lm.logger.Info("Disable route completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.DisableRoute(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) RemoveRoute(ctx context.Context, session mgauthn.Session, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("route_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove route failed", args...)
+			return
+		}
+		lm.logger.Info("Remove route completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveRoute(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ConsumeAsync(ctx context.Context, messages interface{}) {
+	defer func(begin time.Time) {
+		lm.logger.Debug("Async consumer dispatched message", slog.String("duration", time.Since(begin).String()))
+	}(time.Now())
+
+	lm.svc.ConsumeAsync(ctx, messages)
+}
+
+func (lm *loggingMiddleware) Errors() <-chan error {
+	return lm.svc.Errors()
+}
@@ -0,0 +1,144 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/bridge"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/go-kit/kit/metrics"
+)
+
+var (
+	_ bridge.Service   = (*metricsMiddleware)(nil)
+	_ bridge.Forwarder = (*forwarderMetrics)(nil)
+)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     bridge.Service
+}
+
+// MetricsMiddleware instruments bridge service by tracking request count and latency.
+func MetricsMiddleware(svc bridge.Service, counter metrics.Counter, latency metrics.Histogram) bridge.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (mm *metricsMiddleware) CreateRoute(ctx context.Context, session mgauthn.Session, route bridge.Route) (bridge.Route, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_route").Add(1)
+		mm.latency.With("method", "create_route").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateRoute(ctx, session, route)
+}
+
+func (mm *metricsMiddleware) ViewRoute(ctx context.Context, session mgauthn.Session, id string) (bridge.Route, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_route").Add(1)
+		mm.latency.With("method", "view_route").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewRoute(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) ListRoutes(ctx context.Context, session mgauthn.Session, pm bridge.PageMetadata) (bridge.RoutesPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_routes").Add(1)
+		mm.latency.With("method", "list_routes").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListRoutes(ctx, session, pm)
+}
+
+func (mm *metricsMiddleware) UpdateRoute(ctx context.Context, session mgauthn.Session, route bridge.Route) (bridge.Route, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "update_route").Add(1)
+		mm.latency.With("method", "update_route").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.UpdateRoute(ctx, session, route)
+}
+
+func (mm *metricsMiddleware) EnableRoute(ctx context.Context, session mgauthn.Session, id string) (bridge.Route, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "enable_route").Add(1)
+		mm.latency.With("method", "enable_route").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.EnableRoute(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) DisableRoute(ctx context.Context, session mgauthn.Session, id string) (bridge.Route, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "disable_route").Add(1)
+		mm.latency.With("method", "disable_route").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.DisableRoute(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) RemoveRoute(ctx context.Context, session mgauthn.Session, id string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_route").Add(1)
+		mm.latency.With("method", "remove_route").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveRoute(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) ConsumeAsync(ctx context.Context, messages interface{}) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "consume").Add(1)
+		mm.latency.With("method", "consume").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	mm.svc.ConsumeAsync(ctx, messages)
+}
+
+func (mm *metricsMiddleware) Errors() <-chan error {
+	return mm.svc.Errors()
+}
+
+type forwarderMetrics struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	fwd     bridge.Forwarder
+}
+
+// ForwarderMetrics instruments forwarder by tracking delivery attempts and
+// latency per route. Metrics are labeled with route ID, route type and
+// delivery status, which is either "delivered" or "failed".
+func ForwarderMetrics(fwd bridge.Forwarder, counter metrics.Counter, latency metrics.Histogram) bridge.Forwarder {
+	return &forwarderMetrics{
+		counter: counter,
+		latency: latency,
+		fwd:     fwd,
+	}
+}
+
+func (fm *forwarderMetrics) Forward(ctx context.Context, route bridge.Route, topic string, msg *messaging.Message) (err error) {
+	defer func(begin time.Time) {
+		status := "delivered"
+		if err != nil {
+			status = "failed"
+		}
+		fm.counter.With("route", route.ID, "type", route.Type.String(), "status", status).Add(1)
+		fm.latency.With("route", route.ID, "type", route.Type.String(), "status", status).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return fm.fwd.Forward(ctx, route, topic, msg)
+}
+
+func (fm *forwarderMetrics) Release(routeID string) error {
+	return fm.fwd.Release(routeID)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/consumers/bridge"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ bridge.Service = (*tracing)(nil)
+
+type tracing struct {
+	tracer trace.Tracer
+	svc    bridge.Service
+}
+
+// Tracing adds tracing to the bridge service.
+func Tracing(svc bridge.Service, tracer trace.Tracer) bridge.Service {
+	return &tracing{tracer, svc}
+}
+
+func (tm *tracing) CreateRoute(ctx context.Context, session mgauthn.Session, route bridge.Route) (bridge.Route, error) {
+	ctx, span := tm.tracer.Start(ctx, "create_route", trace.WithAttributes(
+		attribute.String("channel_id", route.Channel),
+		attribute.String("type", route.Type.String()),
+	))
+	defer span.End()
+
+	return tm.svc.CreateRoute(ctx, session, route)
+}
+
+func (tm *tracing) ViewRoute(ctx context.Context, session mgauthn.Session, id string) (bridge.Route, error) {
+	ctx, span := tm.tracer.Start(ctx, "view_route", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.ViewRoute(ctx, session, id)
+}
+
+func (tm *tracing) ListRoutes(ctx context.Context, session mgauthn.Session, pm bridge.PageMetadata) (bridge.RoutesPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_routes", trace.WithAttributes(
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+		attribute.String("channel_id", pm.Channel),
+	))
+	defer span.End()
+
+	return tm.svc.ListRoutes(ctx, session, pm)
+}
+
+func (tm *tracing) UpdateRoute(ctx context.Context, session mgauthn.Session, route bridge.Route) (bridge.Route, error) {
+	ctx, span := tm.tracer.Start(ctx, "update_route", trace.WithAttributes(
+		attribute.String("id", route.ID),
+		attribute.String("channel_id", route.Channel),
+		attribute.String("type", route.Type.String()),
+	))
+	defer span.End()
+
+	return tm.svc.UpdateRoute(ctx, session, route)
+}
+
+func (tm *tracing) EnableRoute(ctx context.Context, session mgauthn.Session, id string) (bridge.Route, error) {
+	ctx, span := tm.tracer.Start(ctx, "enable_route", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.EnableRoute(ctx, session, id)
+}
+
+func (tm *tracing) DisableRoute(ctx context.Context, session mgauthn.Session, id string) (bridge.Route, error) {
+	ctx, span := tm.tracer.Start(ctx, "disable_route", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.DisableRoute(ctx, session, id)
+}
+
+func (tm *tracing) RemoveRoute(ctx context.Context, session mgauthn.Session, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "remove_route", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.RemoveRoute(ctx, session, id)
+}
+
+func (tm *tracing) ConsumeAsync(ctx context.Context, messages interface{}) {
+	tm.svc.ConsumeAsync(ctx, messages)
+}
+
+func (tm *tracing) Errors() <-chan error {
+	return tm.svc.Errors()
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mqtt contains the forwarder implementation which publishes
+// messages to external MQTT brokers.
+package mqtt
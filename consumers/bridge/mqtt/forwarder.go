@@ -0,0 +1,128 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/bridge"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/webhooks"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+var (
+	errConnect        = errors.New("failed to connect to MQTT broker")
+	errPublishTimeout = errors.New("failed to publish due to timeout reached")
+	errEmptyTopic     = errors.New("empty MQTT topic")
+)
+
+var _ bridge.Forwarder = (*forwarder)(nil)
+
+type conn struct {
+	client  mqtt.Client
+	version time.Time
+}
+
+type forwarder struct {
+	mu      sync.Mutex
+	conns   map[string]conn
+	qos     byte
+	timeout time.Duration
+	dialer  *net.Dialer
+}
+
+// NewForwarder returns a new forwarder which publishes messages to external
+// MQTT brokers. One client connection is kept per route, and connections
+// are made only to the addresses permitted by the filter.
+func NewForwarder(qos byte, timeout time.Duration, addresses webhooks.AddressFilter) bridge.Forwarder {
+	return &forwarder{
+		conns:   make(map[string]conn),
+		qos:     qos,
+		timeout: timeout,
+		dialer: &net.Dialer{
+			Timeout: timeout,
+			Control: addresses.Control,
+		},
+	}
+}
+
+func (fwd *forwarder) Forward(ctx context.Context, route bridge.Route, topic string, msg *messaging.Message) error {
+	if topic == "" {
+		return errEmptyTopic
+	}
+	client, err := fwd.client(route)
+	if err != nil {
+		return err
+	}
+
+	token := client.Publish(topic, fwd.qos, false, msg.GetPayload())
+	if ok := token.WaitTimeout(fwd.timeout); !ok {
+		return errPublishTimeout
+	}
+
+	return token.Error()
+}
+
+func (fwd *forwarder) Release(routeID string) error {
+	fwd.mu.Lock()
+	defer fwd.mu.Unlock()
+
+	if c, ok := fwd.conns[routeID]; ok {
+		c.client.Disconnect(uint(fwd.timeout.Milliseconds()))
+		delete(fwd.conns, routeID)
+	}
+
+	return nil
+}
+
+// client returns the connection for the route. Connection is reestablished
+// if the route has been updated since the connection was created.
+func (fwd *forwarder) client(route bridge.Route) (mqtt.Client, error) {
+	fwd.mu.Lock()
+	defer fwd.mu.Unlock()
+
+	if c, ok := fwd.conns[route.ID]; ok {
+		if c.version.Equal(route.UpdatedAt) && c.client.IsConnectionOpen() {
+			return c.client, nil
+		}
+		c.client.Disconnect(uint(fwd.timeout.Milliseconds()))
+		delete(fwd.conns, route.ID)
+	}
+
+	tc, err := bridge.LoadTLSConfig(route.TLS)
+	if err != nil {
+		return nil, err
+	}
+	opts := mqtt.NewClientOptions().
+		AddBroker(route.URL).
+		SetClientID("magistrala-bridge-" + route.ID).
+		SetCleanSession(true).
+		SetAutoReconnect(true).
+		SetConnectTimeout(fwd.timeout).
+		SetDialer(fwd.dialer)
+	if tc != nil {
+		opts.SetTLSConfig(tc)
+	}
+	if route.Username != "" {
+		opts.SetUsername(route.Username)
+		opts.SetPassword(route.Password)
+	}
+
+	client := mqtt.NewClient(opts)
+	token := client.Connect()
+	if ok := token.WaitTimeout(fwd.timeout); !ok {
+		return nil, errConnect
+	}
+	if err := token.Error(); err != nil {
+		return nil, errors.Wrap(errConnect, err)
+	}
+	fwd.conns[route.ID] = conn{client: client, version: route.UpdatedAt}
+
+	return client, nil
+}
@@ -0,0 +1,48 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Migration of bridge service.
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "bridge_01",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS routes (
+						id			VARCHAR(36) PRIMARY KEY,
+						name		VARCHAR(1024),
+						domain_id	VARCHAR(36) NOT NULL,
+						channel_id	VARCHAR(36) NOT NULL,
+						subtopic	VARCHAR(1024),
+						type		SMALLINT NOT NULL,
+						url			TEXT NOT NULL,
+						topic		TEXT,
+						exchange	VARCHAR(1024),
+						headers		JSONB,
+						username	VARCHAR(1024),
+						password	TEXT,
+						tls			JSONB,
+						retry		JSONB,
+						status		SMALLINT NOT NULL DEFAULT 0 CHECK (status >= 0),
+						created_by	VARCHAR(254),
+						created_at	TIMESTAMP,
+						updated_by	VARCHAR(254),
+						updated_at	TIMESTAMP
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_routes_channel ON routes(channel_id, status)`,
+					`CREATE INDEX IF NOT EXISTS idx_routes_domain ON routes(domain_id)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS routes`,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,311 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/bridge"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+)
+
+const routeColumns = `id, name, domain_id, channel_id, subtopic, type, url, topic, exchange, headers,
+	username, password, tls, retry, status, created_by, created_at, updated_by, updated_at`
+
+var _ bridge.Repository = (*repository)(nil)
+
+type repository struct {
+	db postgres.Database
+}
+
+// NewRepository instantiates a PostgreSQL implementation of routes repository.
+func NewRepository(db postgres.Database) bridge.Repository {
+	return &repository{db: db}
+}
+
+func (repo *repository) Save(ctx context.Context, route bridge.Route) (bridge.Route, error) {
+	q := fmt.Sprintf(`INSERT INTO routes (%s)
+		VALUES (:id, :name, :domain_id, :channel_id, :subtopic, :type, :url, :topic, :exchange, :headers,
+		:username, :password, :tls, :retry, :status, :created_by, :created_at, :updated_by, :updated_at)
+		RETURNING %s;`, routeColumns, routeColumns)
+
+	dbr, err := toDBRoute(route)
+	if err != nil {
+		return bridge.Route{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return repo.namedQueryRow(ctx, q, dbr, repoerr.ErrCreateEntity)
+}
+
+func (repo *repository) RetrieveByID(ctx context.Context, domainID, id string) (bridge.Route, error) {
+	q := fmt.Sprintf(`SELECT %s FROM routes WHERE domain_id = :domain_id AND id = :id;`, routeColumns)
+
+	dbr := dbRoute{ID: id, DomainID: domainID}
+
+	return repo.namedQueryRow(ctx, q, dbr, repoerr.ErrViewEntity)
+}
+
+func (repo *repository) RetrieveAll(ctx context.Context, pm bridge.PageMetadata) (bridge.RoutesPage, error) {
+	query := pageQuery(pm)
+	q := fmt.Sprintf(`SELECT %s FROM routes %s ORDER BY created_at LIMIT :limit OFFSET :offset;`, routeColumns, query)
+
+	params := map[string]interface{}{
+		"domain_id":  pm.DomainID,
+		"channel_id": pm.Channel,
+		"name":       "%" + pm.Name + "%",
+		"status":     pm.Status,
+		"limit":      pm.Limit,
+		"offset":     pm.Offset,
+	}
+
+	routes, err := repo.namedQuery(ctx, q, params)
+	if err != nil {
+		return bridge.RoutesPage{}, err
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM routes %s;`, query)
+	total, err := postgres.Total(ctx, repo.db, cq, params)
+	if err != nil {
+		return bridge.RoutesPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return bridge.RoutesPage{
+		PageMetadata: pm,
+		Total:        total,
+		Routes:       routes,
+	}, nil
+}
+
+func (repo *repository) RetrieveByChannel(ctx context.Context, channelID string) ([]bridge.Route, error) {
+	q := fmt.Sprintf(`SELECT %s FROM routes WHERE channel_id = :channel_id AND status = :status;`, routeColumns)
+
+	params := map[string]interface{}{
+		"channel_id": channelID,
+		"status":     bridge.EnabledStatus,
+	}
+
+	return repo.namedQuery(ctx, q, params)
+}
+
+func (repo *repository) Update(ctx context.Context, route bridge.Route) (bridge.Route, error) {
+	// Password and TLS client key are not returned by the API, so the
+	// stored ones are kept unless new ones are provided.
+	q := fmt.Sprintf(`UPDATE routes SET name = :name, channel_id = :channel_id, subtopic = :subtopic, type = :type,
+		url = :url, topic = :topic, exchange = :exchange, headers = :headers, username = :username,
+		password = CASE WHEN :password = '' THEN password ELSE :password END,
+		tls = CASE
+			WHEN CAST(:tls AS jsonb) ->> 'client_cert' IS NOT NULL AND CAST(:tls AS jsonb) ->> 'client_key' IS NULL
+			THEN jsonb_strip_nulls(CAST(:tls AS jsonb) || jsonb_build_object('client_key', tls -> 'client_key'))
+			ELSE CAST(:tls AS jsonb)
+		END,
+		retry = :retry, updated_by = :updated_by, updated_at = :updated_at
+		WHERE domain_id = :domain_id AND id = :id
+		RETURNING %s;`, routeColumns)
+
+	dbr, err := toDBRoute(route)
+	if err != nil {
+		return bridge.Route{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return repo.namedQueryRow(ctx, q, dbr, repoerr.ErrUpdateEntity)
+}
+
+func (repo *repository) ChangeStatus(ctx context.Context, route bridge.Route) (bridge.Route, error) {
+	q := fmt.Sprintf(`UPDATE routes SET status = :status, updated_by = :updated_by, updated_at = :updated_at
+		WHERE domain_id = :domain_id AND id = :id
+		RETURNING %s;`, routeColumns)
+
+	dbr, err := toDBRoute(route)
+	if err != nil {
+		return bridge.Route{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return repo.namedQueryRow(ctx, q, dbr, repoerr.ErrUpdateEntity)
+}
+
+func (repo *repository) Remove(ctx context.Context, domainID, id string) error {
+	q := `DELETE FROM routes WHERE domain_id = :domain_id AND id = :id;`
+
+	res, err := repo.db.NamedExecContext(ctx, q, dbRoute{ID: id, DomainID: domainID})
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *repository) namedQueryRow(ctx context.Context, q string, params interface{}, wrapper error) (bridge.Route, error) {
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return bridge.Route{}, postgres.HandleError(wrapper, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return bridge.Route{}, errors.Wrap(repoerr.ErrNotFound, sql.ErrNoRows)
+	}
+	var dbr dbRoute
+	if err := rows.StructScan(&dbr); err != nil {
+		return bridge.Route{}, postgres.HandleError(wrapper, err)
+	}
+
+	return toRoute(dbr)
+}
+
+func (repo *repository) namedQuery(ctx context.Context, q string, params interface{}) ([]bridge.Route, error) {
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var routes []bridge.Route
+	for rows.Next() {
+		var dbr dbRoute
+		if err := rows.StructScan(&dbr); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		r, err := toRoute(dbr)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, r)
+	}
+
+	return routes, nil
+}
+
+func pageQuery(pm bridge.PageMetadata) string {
+	query := []string{"domain_id = :domain_id"}
+	if pm.Channel != "" {
+		query = append(query, "channel_id = :channel_id")
+	}
+	if pm.Name != "" {
+		query = append(query, "name ILIKE :name")
+	}
+	if pm.Status != bridge.AllStatus {
+		query = append(query, "status = :status")
+	}
+
+	return fmt.Sprintf("WHERE %s", strings.Join(query, " AND "))
+}
+
+type dbRoute struct {
+	ID        string         `db:"id"`
+	Name      string         `db:"name"`
+	DomainID  string         `db:"domain_id"`
+	Channel   string         `db:"channel_id"`
+	Subtopic  string         `db:"subtopic"`
+	Type      bridge.Type    `db:"type"`
+	URL       string         `db:"url"`
+	Topic     string         `db:"topic"`
+	Exchange  string         `db:"exchange"`
+	Headers   []byte         `db:"headers"`
+	Username  string         `db:"username"`
+	Password  string         `db:"password"`
+	TLS       []byte         `db:"tls"`
+	Retry     []byte         `db:"retry"`
+	Status    bridge.Status  `db:"status"`
+	CreatedBy string         `db:"created_by"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedBy sql.NullString `db:"updated_by"`
+	UpdatedAt sql.NullTime   `db:"updated_at"`
+}
+
+func toDBRoute(r bridge.Route) (dbRoute, error) {
+	headers := []byte("{}")
+	if len(r.Headers) > 0 {
+		b, err := json.Marshal(r.Headers)
+		if err != nil {
+			return dbRoute{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+		}
+		headers = b
+	}
+	tls, err := json.Marshal(r.TLS)
+	if err != nil {
+		return dbRoute{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+	retry, err := json.Marshal(r.Retry)
+	if err != nil {
+		return dbRoute{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+	var updatedBy sql.NullString
+	if r.UpdatedBy != "" {
+		updatedBy = sql.NullString{String: r.UpdatedBy, Valid: true}
+	}
+	var updatedAt sql.NullTime
+	if !r.UpdatedAt.IsZero() {
+		updatedAt = sql.NullTime{Time: r.UpdatedAt, Valid: true}
+	}
+
+	return dbRoute{
+		ID:        r.ID,
+		Name:      r.Name,
+		DomainID:  r.DomainID,
+		Channel:   r.Channel,
+		Subtopic:  r.Subtopic,
+		Type:      r.Type,
+		URL:       r.URL,
+		Topic:     r.Topic,
+		Exchange:  r.Exchange,
+		Headers:   headers,
+		Username:  r.Username,
+		Password:  r.Password,
+		TLS:       tls,
+		Retry:     retry,
+		Status:    r.Status,
+		CreatedBy: r.CreatedBy,
+		CreatedAt: r.CreatedAt,
+		UpdatedBy: updatedBy,
+		UpdatedAt: updatedAt,
+	}, nil
+}
+
+func toRoute(dbr dbRoute) (bridge.Route, error) {
+	r := bridge.Route{
+		ID:        dbr.ID,
+		Name:      dbr.Name,
+		DomainID:  dbr.DomainID,
+		Channel:   dbr.Channel,
+		Subtopic:  dbr.Subtopic,
+		Type:      dbr.Type,
+		URL:       dbr.URL,
+		Topic:     dbr.Topic,
+		Exchange:  dbr.Exchange,
+		Username:  dbr.Username,
+		Password:  dbr.Password,
+		Status:    dbr.Status,
+		CreatedBy: dbr.CreatedBy,
+		CreatedAt: dbr.CreatedAt,
+		UpdatedBy: dbr.UpdatedBy.String,
+		UpdatedAt: dbr.UpdatedAt.Time,
+	}
+	if len(dbr.Headers) > 0 {
+		if err := json.Unmarshal(dbr.Headers, &r.Headers); err != nil {
+			return bridge.Route{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+		}
+	}
+	if len(dbr.TLS) > 0 {
+		if err := json.Unmarshal(dbr.TLS, &r.TLS); err != nil {
+			return bridge.Route{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+		}
+	}
+	if len(dbr.Retry) > 0 {
+		if err := json.Unmarshal(dbr.Retry, &r.Retry); err != nil {
+			return bridge.Route{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+		}
+	}
+
+	return r, nil
+}
@@ -0,0 +1,403 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/bridge"
+	"github.com/absmach/magistrala/consumers/bridge/postgres"
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRoute(t *testing.T, domainID, channelID string) bridge.Route {
+	return bridge.Route{
+		ID:        testsutil.GenerateUUID(t),
+		Name:      "route",
+		DomainID:  domainID,
+		Channel:   channelID,
+		Subtopic:  "room.*",
+		Type:      bridge.HTTPType,
+		URL:       "https://example.com/ingest",
+		Topic:     "{{.SubtopicPath}}",
+		Headers:   map[string]string{"X-Source": "magistrala"},
+		Username:  "user",
+		Password:  "password",
+		TLS:       bridge.TLSConfig{ClientCert: "cert", ClientKey: "key"},
+		Retry:     bridge.RetryConfig{MaxRetries: 3, InitialInterval: time.Second, MaxInterval: time.Minute},
+		Status:    bridge.EnabledStatus,
+		CreatedBy: testsutil.GenerateUUID(t),
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+}
+
+func TestSave(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM routes")
+		require.Nil(t, err, fmt.Sprintf("clean routes unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	route := newRoute(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+
+	cases := []struct {
+		desc  string
+		route bridge.Route
+		err   error
+	}{
+		{
+			desc:  "save route successfully",
+			route: route,
+		},
+		{
+			desc:  "save route with duplicate ID",
+			route: route,
+			err:   repoerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			saved, err := repo.Save(context.Background(), tc.route)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.route, saved, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.route, saved))
+			}
+		})
+	}
+}
+
+func TestRetrieveByID(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM routes")
+		require.Nil(t, err, fmt.Sprintf("clean routes unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	route := newRoute(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), route)
+	require.Nil(t, err, fmt.Sprintf("save route unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		domainID string
+		id       string
+		err      error
+	}{
+		{
+			desc:     "retrieve existing route",
+			domainID: route.DomainID,
+			id:       route.ID,
+		},
+		{
+			desc:     "retrieve route of another domain",
+			domainID: testsutil.GenerateUUID(t),
+			id:       route.ID,
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "retrieve non-existing route",
+			domainID: route.DomainID,
+			id:       testsutil.GenerateUUID(t),
+			err:      repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			r, err := repo.RetrieveByID(context.Background(), tc.domainID, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, route, r, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, route, r))
+			}
+		})
+	}
+}
+
+func TestRetrieveAll(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM routes")
+		require.Nil(t, err, fmt.Sprintf("clean routes unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	channelID := testsutil.GenerateUUID(t)
+	num := 10
+	var routes []bridge.Route
+	for i := 0; i < num; i++ {
+		route := newRoute(t, domainID, channelID)
+		route.Name = fmt.Sprintf("route-%d", i)
+		route.CreatedAt = route.CreatedAt.Add(time.Duration(i) * time.Second)
+		if i%2 == 1 {
+			route.Channel = testsutil.GenerateUUID(t)
+			route.Status = bridge.DisabledStatus
+		}
+		_, err := repo.Save(context.Background(), route)
+		require.Nil(t, err, fmt.Sprintf("save route unexpected error: %s", err))
+		routes = append(routes, route)
+	}
+	_, err := repo.Save(context.Background(), newRoute(t, testsutil.GenerateUUID(t), channelID))
+	require.Nil(t, err, fmt.Sprintf("save route unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		pm    bridge.PageMetadata
+		total uint64
+		size  int
+	}{
+		{
+			desc:  "retrieve all routes of the domain",
+			pm:    bridge.PageMetadata{DomainID: domainID, Limit: uint64(num), Status: bridge.AllStatus},
+			total: uint64(num),
+			size:  num,
+		},
+		{
+			desc:  "retrieve routes with offset and limit",
+			pm:    bridge.PageMetadata{DomainID: domainID, Offset: 8, Limit: 5, Status: bridge.AllStatus},
+			total: uint64(num),
+			size:  2,
+		},
+		{
+			desc:  "retrieve enabled routes",
+			pm:    bridge.PageMetadata{DomainID: domainID, Limit: uint64(num), Status: bridge.EnabledStatus},
+			total: uint64(num / 2),
+			size:  num / 2,
+		},
+		{
+			desc:  "retrieve routes of the channel",
+			pm:    bridge.PageMetadata{DomainID: domainID, Channel: channelID, Limit: uint64(num), Status: bridge.AllStatus},
+			total: uint64(num / 2),
+			size:  num / 2,
+		},
+		{
+			desc:  "retrieve routes by name",
+			pm:    bridge.PageMetadata{DomainID: domainID, Name: "route-1", Limit: uint64(num), Status: bridge.AllStatus},
+			total: 1,
+			size:  1,
+		},
+		{
+			desc:  "retrieve routes of domain without routes",
+			pm:    bridge.PageMetadata{DomainID: testsutil.GenerateUUID(t), Limit: uint64(num), Status: bridge.AllStatus},
+			total: 0,
+			size:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RetrieveAll(context.Background(), tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+			assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+			assert.Len(t, page.Routes, tc.size, fmt.Sprintf("%s: expected %d routes got %d\n", tc.desc, tc.size, len(page.Routes)))
+		})
+	}
+
+	page, err := repo.RetrieveAll(context.Background(), bridge.PageMetadata{DomainID: domainID, Limit: uint64(num), Status: bridge.AllStatus})
+	require.Nil(t, err, fmt.Sprintf("retrieve routes unexpected error: %s", err))
+	assert.Equal(t, routes, page.Routes, "expected routes ordered by creation time")
+}
+
+func TestRetrieveByChannel(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM routes")
+		require.Nil(t, err, fmt.Sprintf("clean routes unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	channelID := testsutil.GenerateUUID(t)
+	enabled := newRoute(t, testsutil.GenerateUUID(t), channelID)
+	disabled := newRoute(t, testsutil.GenerateUUID(t), channelID)
+	disabled.Status = bridge.DisabledStatus
+	for _, route := range []bridge.Route{enabled, disabled} {
+		_, err := repo.Save(context.Background(), route)
+		require.Nil(t, err, fmt.Sprintf("save route unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc      string
+		channelID string
+		routes    []bridge.Route
+	}{
+		{
+			desc:      "retrieve enabled routes of the channel",
+			channelID: channelID,
+			routes:    []bridge.Route{enabled},
+		},
+		{
+			desc:      "retrieve routes of channel without routes",
+			channelID: testsutil.GenerateUUID(t),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			routes, err := repo.RetrieveByChannel(context.Background(), tc.channelID)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+			assert.Equal(t, tc.routes, routes, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.routes, routes))
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM routes")
+		require.Nil(t, err, fmt.Sprintf("clean routes unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	route := newRoute(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), route)
+	require.Nil(t, err, fmt.Sprintf("save route unexpected error: %s", err))
+
+	updated := route
+	updated.URL = "https://example.org/ingest"
+	updated.Password = "new"
+	updated.TLS = bridge.TLSConfig{ClientCert: "new-cert", ClientKey: "new-key"}
+	updated.UpdatedBy = testsutil.GenerateUUID(t)
+	updated.UpdatedAt = time.Now().UTC().Truncate(time.Microsecond)
+
+	keep := updated
+	keep.Password = ""
+	keep.TLS = bridge.TLSConfig{ClientCert: "cert"}
+
+	notFound := updated
+	notFound.ID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc     string
+		route    bridge.Route
+		password string
+		tls      bridge.TLSConfig
+		err      error
+	}{
+		{
+			desc:     "update route with new credentials",
+			route:    updated,
+			password: "new",
+			tls:      bridge.TLSConfig{ClientCert: "new-cert", ClientKey: "new-key"},
+		},
+		{
+			desc:     "update route keeping stored credentials",
+			route:    keep,
+			password: "new",
+			tls:      bridge.TLSConfig{ClientCert: "cert", ClientKey: "new-key"},
+		},
+		{
+			desc:  "update non-existing route",
+			route: notFound,
+			err:   repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			r, err := repo.Update(context.Background(), tc.route)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.route.URL, r.URL, fmt.Sprintf("%s: expected url %s got %s\n", tc.desc, tc.route.URL, r.URL))
+				assert.Equal(t, tc.password, r.Password, fmt.Sprintf("%s: expected password %s got %s\n", tc.desc, tc.password, r.Password))
+				assert.Equal(t, tc.tls, r.TLS, fmt.Sprintf("%s: expected tls %v got %v\n", tc.desc, tc.tls, r.TLS))
+				assert.Equal(t, tc.route.UpdatedBy, r.UpdatedBy, fmt.Sprintf("%s: expected updated by %s got %s\n", tc.desc, tc.route.UpdatedBy, r.UpdatedBy))
+			}
+		})
+	}
+}
+
+func TestChangeStatus(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM routes")
+		require.Nil(t, err, fmt.Sprintf("clean routes unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	route := newRoute(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), route)
+	require.Nil(t, err, fmt.Sprintf("save route unexpected error: %s", err))
+
+	disabled := route
+	disabled.Status = bridge.DisabledStatus
+	disabled.UpdatedBy = testsutil.GenerateUUID(t)
+	disabled.UpdatedAt = time.Now().UTC().Truncate(time.Microsecond)
+
+	notFound := disabled
+	notFound.ID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc  string
+		route bridge.Route
+		err   error
+	}{
+		{
+			desc:  "disable route",
+			route: disabled,
+		},
+		{
+			desc:  "change status of non-existing route",
+			route: notFound,
+			err:   repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			r, err := repo.ChangeStatus(context.Background(), tc.route)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.route, r, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.route, r))
+			}
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM routes")
+		require.Nil(t, err, fmt.Sprintf("clean routes unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	route := newRoute(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), route)
+	require.Nil(t, err, fmt.Sprintf("save route unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		domainID string
+		id       string
+		err      error
+	}{
+		{
+			desc:     "remove route of another domain",
+			domainID: testsutil.GenerateUUID(t),
+			id:       route.ID,
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "remove existing route",
+			domainID: route.DomainID,
+			id:       route.ID,
+		},
+		{
+			desc:     "remove removed route",
+			domainID: route.DomainID,
+			id:       route.ID,
+			err:      repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.Remove(context.Background(), tc.domainID, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
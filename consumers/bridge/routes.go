@@ -0,0 +1,281 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+var (
+	// ErrInvalidType indicates unsupported route type.
+	ErrInvalidType = errors.New("invalid route type")
+
+	// ErrInvalidTopic indicates invalid route topic template.
+	ErrInvalidTopic = errors.New("invalid route topic template")
+
+	// ErrInvalidURL indicates invalid route endpoint URL.
+	ErrInvalidURL = errors.New("invalid route url")
+)
+
+// schemes are the URL schemes of the endpoints each route type can reach.
+// The schemes are limited to the ones dialed over TCP, so the connections
+// are made only to the addresses permitted by the address filter.
+var schemes = map[Type][]string{
+	MQTTType: {"tcp", "mqtt", "ssl", "tls", "mqtts"},
+	HTTPType: {"http", "https"},
+	AMQPType: {"amqp", "amqps"},
+}
+
+// Type represents the type of the external endpoint.
+type Type uint8
+
+// Possible route types.
+const (
+	MQTTType Type = iota
+	HTTPType
+	AMQPType
+)
+
+// String representation of the possible route types.
+const (
+	MQTT = "mqtt"
+	HTTP = "http"
+	AMQP = "amqp"
+)
+
+// String converts route type to string literal.
+func (t Type) String() string {
+	switch t {
+	case MQTTType:
+		return MQTT
+	case HTTPType:
+		return HTTP
+	case AMQPType:
+		return AMQP
+	default:
+		return Unknown
+	}
+}
+
+// ToType converts string value to a valid route type.
+func ToType(t string) (Type, error) {
+	switch t {
+	case MQTT:
+		return MQTTType, nil
+	case HTTP:
+		return HTTPType, nil
+	case AMQP:
+		return AMQPType, nil
+	}
+
+	return Type(0), ErrInvalidType
+}
+
+// MarshalJSON converts route type to JSON string.
+func (t Type) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON parses route type from JSON string.
+func (t *Type) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	val, err := ToType(s)
+	*t = val
+
+	return err
+}
+
+// Status represents route status.
+type Status uint8
+
+// Possible route status values.
+const (
+	EnabledStatus Status = iota
+	DisabledStatus
+
+	// AllStatus is used for querying purposes to list routes irrespective
+	// of their status. It is never stored in the database.
+	AllStatus
+)
+
+// String representation of the possible status values.
+const (
+	Enabled  = "enabled"
+	Disabled = "disabled"
+	All      = "all"
+	Unknown  = "unknown"
+)
+
+// String converts route status to string literal.
+func (s Status) String() string {
+	switch s {
+	case EnabledStatus:
+		return Enabled
+	case DisabledStatus:
+		return Disabled
+	case AllStatus:
+		return All
+	default:
+		return Unknown
+	}
+}
+
+// ToStatus converts string value to a valid route status.
+func ToStatus(status string) (Status, error) {
+	switch status {
+	case "", Enabled:
+		return EnabledStatus, nil
+	case Disabled:
+		return DisabledStatus, nil
+	case All:
+		return AllStatus, nil
+	}
+
+	return Status(0), svcerr.ErrInvalidStatus
+}
+
+// MarshalJSON converts route status to JSON string.
+func (s Status) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// TLSConfig contains PEM encoded certificates used to connect to the
+// external endpoint.
+type TLSConfig struct {
+	ClientCert         string `json:"client_cert,omitempty"`
+	ClientKey          string `json:"client_key,omitempty"`
+	CACert             string `json:"ca_cert,omitempty"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty"`
+}
+
+// RetryConfig contains delivery retry parameters. Delivery is retried with
+// exponential backoff starting from the initial interval.
+type RetryConfig struct {
+	MaxRetries      uint64        `json:"max_retries"`
+	InitialInterval time.Duration `json:"initial_interval"`
+	MaxInterval     time.Duration `json:"max_interval"`
+}
+
+// MarshalJSON encodes retry intervals as duration strings, e.g. "1.5s".
+func (rc RetryConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(retryConfig{
+		MaxRetries:      rc.MaxRetries,
+		InitialInterval: rc.InitialInterval.String(),
+		MaxInterval:     rc.MaxInterval.String(),
+	})
+}
+
+// UnmarshalJSON decodes retry intervals from duration strings.
+func (rc *RetryConfig) UnmarshalJSON(data []byte) error {
+	var r retryConfig
+	if err := json.Unmarshal(data, &r); err != nil {
+		return err
+	}
+	rc.MaxRetries = r.MaxRetries
+	var err error
+	if r.InitialInterval != "" {
+		if rc.InitialInterval, err = time.ParseDuration(r.InitialInterval); err != nil {
+			return err
+		}
+	}
+	if r.MaxInterval != "" {
+		if rc.MaxInterval, err = time.ParseDuration(r.MaxInterval); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type retryConfig struct {
+	MaxRetries      uint64 `json:"max_retries"`
+	InitialInterval string `json:"initial_interval,omitempty"`
+	MaxInterval     string `json:"max_interval,omitempty"`
+}
+
+// Route forwards messages published to the channel and subtopic matching
+// the subtopic pattern to the external endpoint.
+type Route struct {
+	ID       string `json:"id"`
+	Name     string `json:"name,omitempty"`
+	DomainID string `json:"domain_id"`
+	Channel  string `json:"channel_id"`
+	// Subtopic is a pattern of dot separated subtopic tokens. Token `*`
+	// matches any single token, and token `>` matches one or more trailing
+	// tokens. Empty subtopic matches any subtopic.
+	Subtopic string `json:"subtopic,omitempty"`
+	Type     Type   `json:"type"`
+	// URL is the address of the external endpoint, for example
+	// `ssl://broker:8883`, `https://example.com/ingest` or `amqp://host:5672`.
+	URL string `json:"url"`
+	// Topic is the template of the MQTT topic, HTTP path appended to the URL
+	// or AMQP routing key. See Template for the available fields.
+	Topic string `json:"topic,omitempty"`
+	// Exchange is the AMQP exchange messages are published to.
+	Exchange string `json:"exchange,omitempty"`
+	// Headers are sent with every HTTP request.
+	Headers map[string]string `json:"headers,omitempty"`
+	// Username and Password are used for MQTT and HTTP basic authentication.
+	Username  string      `json:"username,omitempty"`
+	Password  string      `json:"password,omitempty"`
+	TLS       TLSConfig   `json:"tls,omitempty"`
+	Retry     RetryConfig `json:"retry"`
+	Status    Status      `json:"status"`
+	CreatedBy string      `json:"created_by,omitempty"`
+	CreatedAt time.Time   `json:"created_at,omitempty"`
+	UpdatedBy string      `json:"updated_by,omitempty"`
+	UpdatedAt time.Time   `json:"updated_at,omitempty"`
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Offset   uint64 `json:"offset"`
+	Limit    uint64 `json:"limit"`
+	DomainID string `json:"domain_id,omitempty"`
+	Channel  string `json:"channel_id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Status   Status `json:"status,omitempty"`
+}
+
+// RoutesPage contains page related metadata as well as list of routes that
+// belong to this page.
+type RoutesPage struct {
+	PageMetadata
+	Total  uint64  `json:"total"`
+	Routes []Route `json:"routes"`
+}
+
+// Repository specifies a route persistence API.
+//
+//go:generate mockery --name Repository --output=./mocks --filename repository.go --quiet --note "Copyright (c) Abstract Machines"
+type Repository interface {
+	// Save persists the route.
+	Save(ctx context.Context, route Route) (Route, error)
+
+	// RetrieveByID retrieves the route of the domain having the provided identifier.
+	RetrieveByID(ctx context.Context, domainID, id string) (Route, error)
+
+	// RetrieveAll retrieves routes of the domain.
+	RetrieveAll(ctx context.Context, pm PageMetadata) (RoutesPage, error)
+
+	// RetrieveByChannel retrieves all enabled routes of the channel.
+	RetrieveByChannel(ctx context.Context, channelID string) ([]Route, error)
+
+	// Update updates the route endpoint, pattern and delivery parameters.
+	Update(ctx context.Context, route Route) (Route, error)
+
+	// ChangeStatus changes the route status.
+	ChangeStatus(ctx context.Context, route Route) (Route, error)
+
+	// Remove removes the route of the domain having the provided identifier.
+	Remove(ctx context.Context, domainID, id string) error
+}
@@ -0,0 +1,328 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/consumers"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/webhooks"
+	"github.com/cenkalti/backoff/v4"
+)
+
+const (
+	defInitialInterval = time.Second
+	defMaxInterval     = 30 * time.Second
+)
+
+var (
+	// ErrMessage indicates an error converting a message to Magistrala message.
+	ErrMessage = errors.New("failed to convert to Magistrala message")
+
+	// ErrMissingForwarder indicates that there is no forwarder for the route type.
+	ErrMissingForwarder = errors.New("missing forwarder for route type")
+)
+
+// Service specifies an API for managing bridge routes. Service consumes
+// channel messages and forwards them to the external endpoints of the
+// matching routes.
+//
+//go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
+type Service interface {
+	// CreateRoute creates the route in the session domain.
+	CreateRoute(ctx context.Context, session mgauthn.Session, route Route) (Route, error)
+
+	// ViewRoute retrieves the route having the provided identifier.
+	ViewRoute(ctx context.Context, session mgauthn.Session, id string) (Route, error)
+
+	// ListRoutes retrieves routes of the session domain.
+	ListRoutes(ctx context.Context, session mgauthn.Session, pm PageMetadata) (RoutesPage, error)
+
+	// UpdateRoute updates the route endpoint, pattern and delivery parameters.
+	UpdateRoute(ctx context.Context, session mgauthn.Session, route Route) (Route, error)
+
+	// EnableRoute enables the route, so messages are forwarded.
+	EnableRoute(ctx context.Context, session mgauthn.Session, id string) (Route, error)
+
+	// DisableRoute disables the route, so messages are no longer forwarded.
+	DisableRoute(ctx context.Context, session mgauthn.Session, id string) (Route, error)
+
+	// RemoveRoute removes the route having the provided identifier.
+	RemoveRoute(ctx context.Context, session mgauthn.Session, id string) error
+
+	consumers.AsyncConsumer
+}
+
+var _ Service = (*bridgeService)(nil)
+
+type bridgeService struct {
+	idProvider magistrala.IDProvider
+	repo       Repository
+	forwarders map[Type]Forwarder
+	addresses  webhooks.AddressFilter
+	dispatcher *dispatcher
+	errCh      chan error
+}
+
+// New instantiates the bridge service implementation. Route endpoints
+// must resolve to the addresses permitted by the filter.
+func New(idp magistrala.IDProvider, repo Repository, forwarders map[Type]Forwarder, addresses webhooks.AddressFilter, cfg DispatchConfig) Service {
+	return &bridgeService{
+		idProvider: idp,
+		repo:       repo,
+		forwarders: forwarders,
+		addresses:  addresses,
+		dispatcher: newDispatcher(cfg),
+		errCh:      make(chan error, 1),
+	}
+}
+
+func (svc *bridgeService) CreateRoute(ctx context.Context, session mgauthn.Session, route Route) (Route, error) {
+	if err := svc.validate(ctx, route); err != nil {
+		return Route{}, err
+	}
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return Route{}, err
+	}
+	route.ID = id
+	route.DomainID = session.DomainID
+	route.Status = EnabledStatus
+	route.CreatedBy = session.UserID
+	route.CreatedAt = time.Now()
+
+	saved, err := svc.repo.Save(ctx, route)
+	if err != nil {
+		return Route{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	svc.dispatcher.invalidate(saved.ID)
+
+	return saved, nil
+}
+
+func (svc *bridgeService) ViewRoute(ctx context.Context, session mgauthn.Session, id string) (Route, error) {
+	route, err := svc.repo.RetrieveByID(ctx, session.DomainID, id)
+	if err != nil {
+		return Route{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return route, nil
+}
+
+func (svc *bridgeService) ListRoutes(ctx context.Context, session mgauthn.Session, pm PageMetadata) (RoutesPage, error) {
+	pm.DomainID = session.DomainID
+	page, err := svc.repo.RetrieveAll(ctx, pm)
+	if err != nil {
+		return RoutesPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (svc *bridgeService) UpdateRoute(ctx context.Context, session mgauthn.Session, route Route) (Route, error) {
+	current, err := svc.repo.RetrieveByID(ctx, session.DomainID, route.ID)
+	if err != nil {
+		return Route{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	// Credentials are never returned by the API, so they are kept unless
+	// provided.
+	if route.Password == "" {
+		route.Password = current.Password
+	}
+	if route.TLS.ClientKey == "" && route.TLS.ClientCert != "" {
+		route.TLS.ClientKey = current.TLS.ClientKey
+	}
+	if err := svc.validate(ctx, route); err != nil {
+		return Route{}, err
+	}
+	route.DomainID = session.DomainID
+	route.UpdatedBy = session.UserID
+	route.UpdatedAt = time.Now()
+
+	updated, err := svc.repo.Update(ctx, route)
+	if err != nil {
+		return Route{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	svc.release(updated)
+
+	return updated, nil
+}
+
+func (svc *bridgeService) EnableRoute(ctx context.Context, session mgauthn.Session, id string) (Route, error) {
+	route, err := svc.changeStatus(ctx, session, id, EnabledStatus)
+	if err != nil {
+		return Route{}, err
+	}
+	svc.dispatcher.invalidate(route.ID)
+
+	return route, nil
+}
+
+func (svc *bridgeService) DisableRoute(ctx context.Context, session mgauthn.Session, id string) (Route, error) {
+	route, err := svc.changeStatus(ctx, session, id, DisabledStatus)
+	if err != nil {
+		return Route{}, err
+	}
+	svc.release(route)
+
+	return route, nil
+}
+
+func (svc *bridgeService) RemoveRoute(ctx context.Context, session mgauthn.Session, id string) error {
+	route, err := svc.repo.RetrieveByID(ctx, session.DomainID, id)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+	if err := svc.repo.Remove(ctx, session.DomainID, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+	svc.release(route)
+
+	return nil
+}
+
+func (svc *bridgeService) ConsumeAsync(ctx context.Context, message interface{}) {
+	msg, ok := message.(*messaging.Message)
+	if !ok {
+		svc.sendErr(ErrMessage)
+		return
+	}
+
+	routes, ok := svc.dispatcher.cached(msg.GetChannel())
+	if !ok {
+		rs, err := svc.repo.RetrieveByChannel(ctx, msg.GetChannel())
+		if err != nil {
+			svc.sendErr(err)
+			return
+		}
+		var errs []error
+		routes, errs = svc.dispatcher.cache(msg.GetChannel(), rs)
+		for _, err := range errs {
+			svc.sendErr(err)
+		}
+	}
+
+	for _, route := range routes {
+		if !route.Matches(msg.GetSubtopic()) {
+			continue
+		}
+		if err := svc.dispatcher.enqueue(route, msg, svc.forward); err != nil {
+			svc.sendErr(errors.Wrap(ErrForward, err))
+		}
+	}
+}
+
+func (svc *bridgeService) Errors() <-chan error {
+	return svc.errCh
+}
+
+// forward delivers the message to the route endpoint, retrying failed
+// deliveries. It is called by the route worker.
+func (svc *bridgeService) forward(ctx context.Context, route compiledRoute, msg *messaging.Message) {
+	if err := svc.deliver(ctx, route, msg); err != nil {
+		svc.sendErr(err)
+	}
+}
+
+func (svc *bridgeService) deliver(ctx context.Context, route compiledRoute, msg *messaging.Message) error {
+	fwd, ok := svc.forwarders[route.Type]
+	if !ok {
+		return ErrMissingForwarder
+	}
+	topic, err := RenderTopic(route.topic, route.Route, msg)
+	if err != nil {
+		return errors.Wrap(ErrForward, err)
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = route.Retry.InitialInterval
+	if b.InitialInterval == 0 {
+		b.InitialInterval = defInitialInterval
+	}
+	b.MaxInterval = route.Retry.MaxInterval
+	if b.MaxInterval == 0 {
+		b.MaxInterval = defMaxInterval
+	}
+	b.MaxElapsedTime = 0
+
+	op := func() error {
+		return fwd.Forward(ctx, route.Route, topic, msg)
+	}
+	if err := backoff.Retry(op, backoff.WithContext(backoff.WithMaxRetries(b, route.Retry.MaxRetries), ctx)); err != nil {
+		return errors.Wrap(ErrForward, err)
+	}
+
+	return nil
+}
+
+func (svc *bridgeService) changeStatus(ctx context.Context, session mgauthn.Session, id string, status Status) (Route, error) {
+	route, err := svc.repo.RetrieveByID(ctx, session.DomainID, id)
+	if err != nil {
+		return Route{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if route.Status == status {
+		return Route{}, errors.ErrStatusAlreadyAssigned
+	}
+	route.Status = status
+	route.UpdatedBy = session.UserID
+	route.UpdatedAt = time.Now()
+
+	route, err = svc.repo.ChangeStatus(ctx, route)
+	if err != nil {
+		return Route{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return route, nil
+}
+
+func (svc *bridgeService) validate(ctx context.Context, route Route) error {
+	if _, ok := svc.forwarders[route.Type]; !ok {
+		return errors.Wrap(svcerr.ErrMalformedEntity, ErrMissingForwarder)
+	}
+	u, err := url.Parse(route.URL)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrMalformedEntity, errors.Wrap(ErrInvalidURL, err))
+	}
+	if !slices.Contains(schemes[route.Type], u.Scheme) || u.Hostname() == "" {
+		return errors.Wrap(svcerr.ErrMalformedEntity, ErrInvalidURL)
+	}
+	if err := svc.addresses.CheckHost(ctx, u.Hostname()); err != nil {
+		return errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if _, err := ParseTopic(route.Topic); err != nil {
+		return errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if _, err := LoadTLSConfig(route.TLS); err != nil {
+		return errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	return nil
+}
+
+// release drops the cached routes, the messages queued for the route and
+// the connections held for the previous route endpoint.
+func (svc *bridgeService) release(route Route) {
+	svc.dispatcher.invalidate(route.ID)
+	for _, fwd := range svc.forwarders {
+		if err := fwd.Release(route.ID); err != nil {
+			svc.sendErr(err)
+		}
+	}
+}
+
+// sendErr reports the error without blocking if the errors channel is not drained.
+func (svc *bridgeService) sendErr(err error) {
+	select {
+	case svc.errCh <- err:
+	default:
+	}
+}
@@ -0,0 +1,468 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/bridge"
+	"github.com/absmach/magistrala/consumers/bridge/mocks"
+	"github.com/absmach/magistrala/internal/testsutil"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/absmach/magistrala/webhooks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	domainID   = testsutil.GenerateUUID(&testing.T{})
+	userID     = testsutil.GenerateUUID(&testing.T{})
+	channelID  = testsutil.GenerateUUID(&testing.T{})
+	session    = mgauthn.Session{DomainID: domainID, UserID: userID, DomainUserID: domainID + "_" + userID}
+	validRoute = bridge.Route{
+		Name:     "route",
+		Channel:  channelID,
+		Subtopic: "room.*",
+		Type:     bridge.MQTTType,
+		URL:      "tcp://10.1.0.5:1883",
+		Topic:    "devices/{{.Publisher}}/{{.SubtopicPath}}",
+		Retry:    bridge.RetryConfig{MaxRetries: 1, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond},
+	}
+)
+
+func newService() (bridge.Service, *mocks.Repository, *mocks.Forwarder) {
+	repo := new(mocks.Repository)
+	fwd := new(mocks.Forwarder)
+	idp := uuid.NewMock()
+	forwarders := map[bridge.Type]bridge.Forwarder{
+		bridge.MQTTType: fwd,
+	}
+	addresses, _ := webhooks.NewAddressFilter([]string{"10.1.0.0/16"})
+
+	return bridge.New(idp, repo, forwarders, addresses, bridge.DispatchConfig{RoutesTTL: time.Minute, QueueSize: 2}), repo, fwd
+}
+
+func TestCreateRoute(t *testing.T) {
+	svc, repo, _ := newService()
+
+	cases := []struct {
+		desc    string
+		route   bridge.Route
+		saveErr error
+		err     error
+	}{
+		{
+			desc:  "create route successfully",
+			route: validRoute,
+		},
+		{
+			desc: "create route with missing forwarder",
+			route: bridge.Route{
+				Channel: channelID,
+				Type:    bridge.HTTPType,
+				URL:     "http://10.1.0.5",
+			},
+			err: svcerr.ErrMalformedEntity,
+		},
+		{
+			desc: "create route with url scheme of another route type",
+			route: bridge.Route{
+				Channel: channelID,
+				Type:    bridge.MQTTType,
+				URL:     "http://10.1.0.5",
+			},
+			err: bridge.ErrInvalidURL,
+		},
+		{
+			desc: "create route with url without host",
+			route: bridge.Route{
+				Channel: channelID,
+				Type:    bridge.MQTTType,
+				URL:     "tcp://:1883",
+			},
+			err: bridge.ErrInvalidURL,
+		},
+		{
+			desc: "create route with loopback url",
+			route: bridge.Route{
+				Channel: channelID,
+				Type:    bridge.MQTTType,
+				URL:     "tcp://localhost:1883",
+			},
+			err: webhooks.ErrForbiddenAddress,
+		},
+		{
+			desc: "create route with private url",
+			route: bridge.Route{
+				Channel: channelID,
+				Type:    bridge.MQTTType,
+				URL:     "tcp://192.168.1.10:1883",
+			},
+			err: webhooks.ErrForbiddenAddress,
+		},
+		{
+			desc: "create route with link-local url",
+			route: bridge.Route{
+				Channel: channelID,
+				Type:    bridge.MQTTType,
+				URL:     "tcp://169.254.169.254:1883",
+			},
+			err: webhooks.ErrForbiddenAddress,
+		},
+		{
+			desc: "create route with invalid topic template",
+			route: bridge.Route{
+				Channel: channelID,
+				Type:    bridge.MQTTType,
+				URL:     "tcp://10.1.0.5:1883",
+				Topic:   "devices/{{.Publisher",
+			},
+			err: bridge.ErrInvalidTopic,
+		},
+		{
+			desc: "create route with invalid client certificate",
+			route: bridge.Route{
+				Channel: channelID,
+				Type:    bridge.MQTTType,
+				URL:     "ssl://10.1.0.5:8883",
+				TLS:     bridge.TLSConfig{ClientCert: "invalid", ClientKey: "invalid"},
+			},
+			err: svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:    "create route with failed repo save",
+			route:   validRoute,
+			saveErr: repoerr.ErrCreateEntity,
+			err:     svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("Save", context.Background(), mock.Anything).Return(func(_ context.Context, r bridge.Route) bridge.Route { return r }, tc.saveErr)
+			route, err := svc.CreateRoute(context.Background(), session, tc.route)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.NotEmpty(t, route.ID, fmt.Sprintf("%s: expected route ID to be set", tc.desc))
+				assert.Equal(t, domainID, route.DomainID, fmt.Sprintf("%s: expected domain %s got %s\n", tc.desc, domainID, route.DomainID))
+				assert.Equal(t, bridge.EnabledStatus, route.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, bridge.EnabledStatus, route.Status))
+			}
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestUpdateRoute(t *testing.T) {
+	svc, repo, fwd := newService()
+
+	current := validRoute
+	current.ID = testsutil.GenerateUUID(t)
+	current.Password = "current"
+	current.TLS = bridge.TLSConfig{ClientCert: "cert", ClientKey: "key"}
+
+	route := validRoute
+	route.ID = current.ID
+	withPassword := route
+	withPassword.Password = "new"
+	loopback := route
+	loopback.URL = "tcp://127.0.0.1:1883"
+
+	cases := []struct {
+		desc        string
+		route       bridge.Route
+		password    string
+		retrieveErr error
+		updateErr   error
+		err         error
+	}{
+		{
+			desc:     "update route successfully",
+			route:    withPassword,
+			password: "new",
+		},
+		{
+			desc:     "update route without password",
+			route:    route,
+			password: "current",
+		},
+		{
+			desc:  "update route with loopback url",
+			route: loopback,
+			err:   webhooks.ErrForbiddenAddress,
+		},
+		{
+			desc:        "update non-existing route",
+			route:       route,
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:      "update route with failed repo update",
+			route:     route,
+			updateErr: repoerr.ErrNotFound,
+			err:       svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveByID", context.Background(), domainID, tc.route.ID).Return(current, tc.retrieveErr)
+			repoCall1 := repo.On("Update", context.Background(), mock.Anything).Return(tc.route, tc.updateErr)
+			fwdCall := fwd.On("Release", tc.route.ID).Return(nil)
+			_, err := svc.UpdateRoute(context.Background(), session, tc.route)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				ok := fwd.AssertCalled(t, "Release", tc.route.ID)
+				assert.True(t, ok, fmt.Sprintf("%s: expected route connections to be released", tc.desc))
+				updated := repo.Calls[len(repo.Calls)-1].Arguments.Get(1).(bridge.Route)
+				assert.Equal(t, tc.password, updated.Password, fmt.Sprintf("%s: expected password %s got %s\n", tc.desc, tc.password, updated.Password))
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+			fwdCall.Unset()
+		})
+	}
+}
+
+func TestDisableRoute(t *testing.T) {
+	svc, repo, fwd := newService()
+
+	enabled := validRoute
+	enabled.ID = testsutil.GenerateUUID(t)
+	disabled := enabled
+	disabled.Status = bridge.DisabledStatus
+
+	cases := []struct {
+		desc        string
+		route       bridge.Route
+		retrieveErr error
+		err         error
+	}{
+		{
+			desc:  "disable enabled route",
+			route: enabled,
+		},
+		{
+			desc:  "disable disabled route",
+			route: disabled,
+			err:   errors.ErrStatusAlreadyAssigned,
+		},
+		{
+			desc:        "disable non-existing route",
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveByID", context.Background(), domainID, tc.route.ID).Return(tc.route, tc.retrieveErr)
+			repoCall1 := repo.On("ChangeStatus", context.Background(), mock.Anything).Return(disabled, nil)
+			fwdCall := fwd.On("Release", tc.route.ID).Return(nil)
+			route, err := svc.DisableRoute(context.Background(), session, tc.route.ID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, bridge.DisabledStatus, route.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, bridge.DisabledStatus, route.Status))
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+			fwdCall.Unset()
+		})
+	}
+}
+
+func TestRemoveRoute(t *testing.T) {
+	svc, repo, fwd := newService()
+
+	route := validRoute
+	route.ID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc        string
+		id          string
+		retrieveErr error
+		removeErr   error
+		err         error
+	}{
+		{
+			desc: "remove route successfully",
+			id:   route.ID,
+		},
+		{
+			desc:        "remove non-existing route",
+			id:          testsutil.GenerateUUID(t),
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrRemoveEntity,
+		},
+		{
+			desc:      "remove route with failed repo remove",
+			id:        route.ID,
+			removeErr: repoerr.ErrRemoveEntity,
+			err:       svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveByID", context.Background(), domainID, tc.id).Return(route, tc.retrieveErr)
+			repoCall1 := repo.On("Remove", context.Background(), domainID, tc.id).Return(tc.removeErr)
+			fwdCall := fwd.On("Release", route.ID).Return(nil)
+			err := svc.RemoveRoute(context.Background(), session, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			repoCall.Unset()
+			repoCall1.Unset()
+			fwdCall.Unset()
+		})
+	}
+}
+
+func TestConsumeAsync(t *testing.T) {
+	svc, repo, fwd := newService()
+
+	route := validRoute
+	route.ID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc       string
+		msg        interface{}
+		topic      string
+		forwardErr error
+		forwarded  bool
+		err        error
+	}{
+		{
+			desc:      "forward matching message",
+			msg:       &messaging.Message{Channel: channelID, Subtopic: "room.temp", Publisher: "thing"},
+			topic:     "devices/thing/room/temp",
+			forwarded: true,
+		},
+		{
+			desc: "skip message not matching subtopic pattern",
+			msg:  &messaging.Message{Channel: channelID, Subtopic: "hall.temp", Publisher: "thing"},
+		},
+		{
+			desc:       "forward message with failed delivery",
+			msg:        &messaging.Message{Channel: channelID, Subtopic: "room.hum", Publisher: "thing"},
+			topic:      "devices/thing/room/hum",
+			forwardErr: errors.New("connection refused"),
+			forwarded:  true,
+			err:        bridge.ErrForward,
+		},
+		{
+			desc: "consume invalid message",
+			msg:  "invalid",
+			err:  bridge.ErrMessage,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			done := make(chan struct{})
+			repoCall := repo.On("RetrieveByChannel", context.Background(), channelID).Return([]bridge.Route{route}, nil)
+			fwdCall := fwd.On("Forward", mock.Anything, route, tc.topic, mock.Anything).Return(tc.forwardErr).Run(func(_ mock.Arguments) {
+				select {
+				case <-done:
+				default:
+					close(done)
+				}
+			})
+			svc.ConsumeAsync(context.Background(), tc.msg)
+			if tc.forwarded {
+				select {
+				case <-done:
+				case <-time.After(time.Second):
+					t.Errorf("%s: expected message to be forwarded", tc.desc)
+				}
+			}
+			if tc.err != nil {
+				select {
+				case err := <-svc.Errors():
+					assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+				case <-time.After(time.Second):
+					t.Errorf("%s: expected error %s", tc.desc, tc.err)
+				}
+			}
+			repoCall.Unset()
+			fwdCall.Unset()
+		})
+	}
+}
+
+func TestConsumeAsyncOrdering(t *testing.T) {
+	svc, repo, fwd := newService()
+
+	route := validRoute
+	route.ID = testsutil.GenerateUUID(t)
+
+	release := make(chan struct{})
+	forwarded := make(chan string, 4)
+	repoCall := repo.On("RetrieveByChannel", context.Background(), channelID).Return([]bridge.Route{route}, nil)
+	fwdCall := fwd.On("Forward", mock.Anything, route, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		<-release
+		forwarded <- args.Get(2).(string)
+	})
+	defer repoCall.Unset()
+	defer fwdCall.Unset()
+
+	// The first message is taken by the route worker and the next two fill
+	// the route queue, so the last message is dropped.
+	subtopics := []string{"room.1", "room.2", "room.3", "room.4"}
+	for i, subtopic := range subtopics {
+		svc.ConsumeAsync(context.Background(), &messaging.Message{Channel: channelID, Subtopic: subtopic, Publisher: "thing"})
+		if i == 0 {
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+	select {
+	case err := <-svc.Errors():
+		assert.True(t, errors.Contains(err, bridge.ErrQueueFull), fmt.Sprintf("expected %s got %s\n", bridge.ErrQueueFull, err))
+	case <-time.After(time.Second):
+		t.Errorf("expected error %s", bridge.ErrQueueFull)
+	}
+	close(release)
+
+	for _, subtopic := range subtopics[:3] {
+		select {
+		case topic := <-forwarded:
+			assert.Equal(t, "devices/thing/"+strings.ReplaceAll(subtopic, ".", "/"), topic, "expected messages to be forwarded in order")
+		case <-time.After(time.Second):
+			t.Errorf("expected message to subtopic %s to be forwarded", subtopic)
+		}
+	}
+	repo.AssertNumberOfCalls(t, "RetrieveByChannel", 1)
+}
+
+func TestMatches(t *testing.T) {
+	cases := []struct {
+		desc     string
+		pattern  string
+		subtopic string
+		matches  bool
+	}{
+		{desc: "empty pattern", pattern: "", subtopic: "a.b", matches: true},
+		{desc: "exact match", pattern: "a.b", subtopic: "a.b", matches: true},
+		{desc: "exact mismatch", pattern: "a.b", subtopic: "a.c", matches: false},
+		{desc: "single wildcard", pattern: "a.*", subtopic: "a.c", matches: true},
+		{desc: "single wildcard with more tokens", pattern: "a.*", subtopic: "a.c.d", matches: false},
+		{desc: "multi wildcard", pattern: "a.>", subtopic: "a.c.d", matches: true},
+		{desc: "multi wildcard without trailing tokens", pattern: "a.>", subtopic: "a", matches: false},
+		{desc: "pattern with empty subtopic", pattern: "a", subtopic: "", matches: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			route := bridge.Route{Subtopic: tc.pattern}
+			assert.Equal(t, tc.matches, route.Matches(tc.subtopic), fmt.Sprintf("%s: expected %t", tc.desc, tc.matches))
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+)
+
+const (
+	subtopicSep   = "."
+	singleWild    = "*"
+	multiWild     = ">"
+	topicTmplName = "topic"
+)
+
+// Template contains the fields available in the route topic template.
+// For example, topic `devices/{{.Publisher}}/{{.SubtopicPath}}` forwards
+// message published by the thing `t1` to subtopic `room.temp` to
+// `devices/t1/room/temp`.
+type Template struct {
+	Channel  string
+	Subtopic string
+	// SubtopicPath is the subtopic with `/` instead of `.` separators.
+	SubtopicPath string
+	Publisher    string
+	Protocol     string
+	RouteID      string
+	RouteName    string
+	DomainID     string
+}
+
+// ParseTopic validates the route topic template.
+func ParseTopic(topic string) (*template.Template, error) {
+	tmpl, err := template.New(topicTmplName).Option("missingkey=error").Parse(topic)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidTopic, err)
+	}
+
+	return tmpl, nil
+}
+
+// RenderTopic renders the route topic template, parsed by ParseTopic, for
+// the message.
+func RenderTopic(tmpl *template.Template, route Route, msg *messaging.Message) (string, error) {
+	data := Template{
+		Channel:      msg.GetChannel(),
+		Subtopic:     msg.GetSubtopic(),
+		SubtopicPath: strings.ReplaceAll(msg.GetSubtopic(), subtopicSep, "/"),
+		Publisher:    msg.GetPublisher(),
+		Protocol:     msg.GetProtocol(),
+		RouteID:      route.ID,
+		RouteName:    route.Name,
+		DomainID:     route.DomainID,
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", errors.Wrap(ErrInvalidTopic, err)
+	}
+
+	return buf.String(), nil
+}
+
+// Matches reports whether the message subtopic matches the route subtopic pattern.
+func (r Route) Matches(subtopic string) bool {
+	if r.Subtopic == "" || r.Subtopic == multiWild {
+		return true
+	}
+	pattern := strings.Split(r.Subtopic, subtopicSep)
+	tokens := strings.Split(subtopic, subtopicSep)
+	if subtopic == "" {
+		return false
+	}
+	for i, p := range pattern {
+		if p == multiWild {
+			return len(tokens) > i
+		}
+		if i >= len(tokens) {
+			return false
+		}
+		if p != singleWild && p != tokens[i] {
+			return false
+		}
+	}
+
+	return len(pattern) == len(tokens)
+}
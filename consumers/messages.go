@@ -15,7 +15,9 @@ import (
 	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/absmach/magistrala/pkg/messaging/brokers"
 	"github.com/absmach/magistrala/pkg/transformers"
+	"github.com/absmach/magistrala/pkg/transformers/cbor"
 	"github.com/absmach/magistrala/pkg/transformers/json"
+	"github.com/absmach/magistrala/pkg/transformers/protobuf"
 	"github.com/absmach/magistrala/pkg/transformers/senml"
 	"github.com/pelletier/go-toml"
 )
@@ -32,14 +34,16 @@ var (
 
 // Start method starts consuming messages received from Message broker.
 // This method transforms messages to SenML format before
-// using MessageRepository to store them.
-func Start(ctx context.Context, id string, sub messaging.Subscriber, consumer interface{}, configPath string, logger *slog.Logger) error {
+// using MessageRepository to store them. The protobuf schema registry is
+// used by the Protobuf transformer and may be nil if the consumer doesn't
+// support it.
+func Start(ctx context.Context, id string, sub messaging.Subscriber, consumer interface{}, schemas protobuf.Registry, configPath string, logger *slog.Logger) error {
 	cfg, err := loadConfig(configPath)
 	if err != nil {
 		logger.Warn(fmt.Sprintf("Failed to load consumer config: %s", err))
 	}
 
-	transformer := makeTransformer(cfg.TransformerCfg, logger)
+	transformer := makeTransformer(cfg.TransformerCfg, schemas, logger)
 
 	for _, subject := range cfg.SubscriberCfg.Subjects {
 		subCfg := messaging.SubscriberConfig{
@@ -113,6 +117,9 @@ type transformerConfig struct {
 	Format      string           `toml:"format"`
 	ContentType string           `toml:"content_type"`
 	TimeFields  []json.TimeField `toml:"time_fields"`
+	// Fallback is the format of the messages published to the channels
+	// without protobuf schema, used with the Protobuf transformer.
+	Fallback string `toml:"fallback"`
 }
 
 type config struct {
@@ -128,6 +135,7 @@ func loadConfig(configPath string) (config, error) {
 		TransformerCfg: transformerConfig{
 			Format:      defFormat,
 			ContentType: defContentType,
+			Fallback:    defFormat,
 		},
 	}
 
@@ -143,7 +151,7 @@ func loadConfig(configPath string) (config, error) {
 	return cfg, nil
 }
 
-func makeTransformer(cfg transformerConfig, logger *slog.Logger) transformers.Transformer {
+func makeTransformer(cfg transformerConfig, schemas protobuf.Registry, logger *slog.Logger) transformers.Transformer {
 	switch strings.ToUpper(cfg.Format) {
 	case "SENML":
 		logger.Info("Using SenML transformer")
@@ -151,6 +159,30 @@ func makeTransformer(cfg transformerConfig, logger *slog.Logger) transformers.Tr
 	case "JSON":
 		logger.Info("Using JSON transformer")
 		return json.New(cfg.TimeFields)
+	case "CBOR":
+		logger.Info("Using CBOR transformer")
+		t, err := cbor.New(cfg.TimeFields)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Can't create CBOR transformer: %s", err))
+			os.Exit(1)
+		}
+		return t
+	case "PROTOBUF":
+		logger.Info("Using Protobuf transformer")
+		if schemas == nil {
+			logger.Error("Can't create Protobuf transformer: protobuf schemas are not supported by the consumer")
+			os.Exit(1)
+		}
+		if strings.EqualFold(cfg.Fallback, "PROTOBUF") {
+			logger.Error("Can't create Protobuf transformer: invalid fallback transformer type PROTOBUF")
+			os.Exit(1)
+		}
+		fallback := cfg
+		fallback.Format = cfg.Fallback
+		return protobuf.New(schemas, makeTransformer(fallback, nil, logger))
+	case "RAW":
+		logger.Info("Using raw messages without transformation")
+		return nil
 	default:
 		logger.Error(fmt.Sprintf("Can't create transformer: unknown transformer type %s", cfg.Format))
 		os.Exit(1)
@@ -0,0 +1,173 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/policies"
+	"github.com/absmach/magistrala/pkg/presence"
+	"github.com/absmach/magistrala/pkg/schema"
+)
+
+const chansPrefix = "channels"
+
+var (
+	// ErrFailedSubscription indicates that client couldn't subscribe to specified channel.
+	ErrFailedSubscription = errors.New("failed to subscribe to a channel")
+
+	// errFailedUnsubscribe indicates that client couldn't unsubscribe from specified channel.
+	errFailedUnsubscribe = errors.New("failed to unsubscribe from a channel")
+)
+
+// Service specifies HTTP adapter batch publishing and subscription API.
+type Service interface {
+	// PublishBatch authorizes and publishes every message separately using
+	// the thingKey for authorization. It returns the error of every message,
+	// in the order of the messages, which is nil if the message is published.
+	PublishBatch(ctx context.Context, thingKey string, msgs []*messaging.Message) []error
+
+	// Subscribe subscribes the client to the channel messages using the
+	// thingKey for authorization. Subtopic is optional and may contain
+	// wildcards. Received messages are buffered by the client until the
+	// client is unsubscribed.
+	Subscribe(ctx context.Context, thingKey, chanID, subtopic string, c *Client) error
+
+	// Unsubscribe cancels the client subscription.
+	Unsubscribe(ctx context.Context, c *Client) error
+}
+
+var _ Service = (*adapterService)(nil)
+
+type adapterService struct {
+	things     magistrala.ThingsServiceClient
+	pubsub     messaging.PubSub
+	validator  schema.Validator
+	es         presence.EventStore
+	idProvider magistrala.IDProvider
+	logger     *slog.Logger
+}
+
+// New instantiates the HTTP adapter batch publishing and subscription service implementation.
+func New(thingsClient magistrala.ThingsServiceClient, pubsub messaging.PubSub, validator schema.Validator, es presence.EventStore, idp magistrala.IDProvider, logger *slog.Logger) Service {
+	return &adapterService{
+		things:     thingsClient,
+		pubsub:     pubsub,
+		validator:  validator,
+		es:         es,
+		idProvider: idp,
+		logger:     logger,
+	}
+}
+
+func (svc *adapterService) PublishBatch(ctx context.Context, thingKey string, msgs []*messaging.Message) []error {
+	errs := make([]error, len(msgs))
+	for i, msg := range msgs {
+		errs[i] = svc.publish(ctx, thingKey, msg)
+	}
+
+	return errs
+}
+
+func (svc *adapterService) publish(ctx context.Context, thingKey string, msg *messaging.Message) error {
+	if thingKey == "" {
+		return svcerr.ErrAuthentication
+	}
+
+	ar := &magistrala.ThingsAuthzReq{
+		Permission: policies.PublishPermission,
+		ThingKey:   thingKey,
+		ChannelId:  msg.GetChannel(),
+	}
+	res, err := svc.things.Authorize(ctx, ar)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	if !res.GetAuthorized() {
+		return svcerr.ErrAuthorization
+	}
+	msg.Publisher = res.GetId()
+	msg.Protocol = protocol
+	if msg.Created == 0 {
+		msg.Created = time.Now().UnixNano()
+	}
+
+	if err := svc.validator.Validate(ctx, msg.GetChannel(), msg.GetPayload()); err != nil {
+		return errors.Wrap(errFailedPublish, err)
+	}
+
+	if err := svc.pubsub.Publish(ctx, msg.GetChannel(), msg); err != nil {
+		return errors.Wrap(errFailedPublishToMsgBroker, err)
+	}
+
+	if err := svc.es.Published(ctx, msg.GetPublisher()); err != nil {
+		svc.logger.Error(errors.Wrap(errFailedPresenceEvent, err).Error())
+	}
+
+	return nil
+}
+
+func (svc *adapterService) Subscribe(ctx context.Context, thingKey, chanID, subtopic string, c *Client) error {
+	if chanID == "" || thingKey == "" {
+		return svcerr.ErrAuthentication
+	}
+
+	ar := &magistrala.ThingsAuthzReq{
+		Permission: policies.SubscribePermission,
+		ThingKey:   thingKey,
+		ChannelId:  chanID,
+	}
+	res, err := svc.things.Authorize(ctx, ar)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	if !res.GetAuthorized() {
+		return svcerr.ErrAuthorization
+	}
+
+	// Every request gets its own subscription, so that the same thing
+	// can keep several streams open on the same topic.
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return errors.Wrap(ErrFailedSubscription, err)
+	}
+	c.id = fmt.Sprintf("%s-%s", res.GetId(), id)
+	c.thingID = res.GetId()
+	c.topic = fmt.Sprintf("%s.%s", chansPrefix, chanID)
+	if subtopic != "" {
+		c.topic = fmt.Sprintf("%s.%s", c.topic, subtopic)
+	}
+
+	subCfg := messaging.SubscriberConfig{
+		ID:      c.id,
+		Topic:   c.topic,
+		Handler: c,
+	}
+	if c.since > 0 {
+		subCfg.DeliveryPolicy = messaging.DeliverByStartTimePolicy
+		subCfg.StartTime = time.Unix(0, c.since)
+	}
+	if err := svc.pubsub.Subscribe(ctx, subCfg); err != nil {
+		return errors.Wrap(ErrFailedSubscription, err)
+	}
+
+	return nil
+}
+
+func (svc *adapterService) Unsubscribe(ctx context.Context, c *Client) error {
+	defer c.Cancel()
+
+	if err := svc.pubsub.Unsubscribe(ctx, c.id, c.topic); err != nil {
+		return errors.Wrap(errFailedUnsubscribe, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,230 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package http_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	adapter "github.com/absmach/magistrala/http"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/messaging/mocks"
+	presencemocks "github.com/absmach/magistrala/pkg/presence/mocks"
+	"github.com/absmach/magistrala/pkg/schema"
+	"github.com/absmach/magistrala/pkg/uuid"
+	thmocks "github.com/absmach/magistrala/things/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	chanID   = "1"
+	thingID  = "thing"
+	thingKey = "thing_key"
+	subtopic = "subtopic"
+)
+
+func newService() (adapter.Service, *mocks.PubSub, *thmocks.ThingsServiceClient) {
+	pubsub := new(mocks.PubSub)
+	things := new(thmocks.ThingsServiceClient)
+
+	eventStore := new(presencemocks.EventStore)
+	eventStore.On("Published", mock.Anything, mock.Anything).Return(nil)
+
+	return adapter.New(things, pubsub, newSchemaCache(), eventStore, uuid.NewMock(), mglog.NewMock()), pubsub, things
+}
+
+func TestPublishBatch(t *testing.T) {
+	svc, pubsub, things := newService()
+
+	things.On("Authorize", mock.Anything, &magistrala.ThingsAuthzReq{ThingKey: thingKey, ChannelId: chanID, Permission: "publish"}).Return(&magistrala.ThingsAuthzRes{Authorized: true, Id: thingID}, nil)
+	things.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.ThingsAuthzRes{Authorized: false}, nil)
+
+	cases := []struct {
+		desc       string
+		thingKey   string
+		msgs       []*messaging.Message
+		publishErr error
+		errs       []error
+	}{
+		{
+			desc:     "publish batch",
+			thingKey: thingKey,
+			msgs: []*messaging.Message{
+				{Channel: chanID, Payload: []byte(`{"v":1}`), Created: 1000},
+				{Channel: chanID, Subtopic: subtopic, Payload: []byte(`{"v":2}`)},
+			},
+			errs: []error{nil, nil},
+		},
+		{
+			desc:     "publish batch spanning unauthorized channel",
+			thingKey: thingKey,
+			msgs: []*messaging.Message{
+				{Channel: chanID, Payload: []byte(`{"v":1}`)},
+				{Channel: "2", Payload: []byte(`{"v":2}`)},
+			},
+			errs: []error{nil, svcerr.ErrAuthorization},
+		},
+		{
+			desc: "publish batch with empty thing key",
+			msgs: []*messaging.Message{
+				{Channel: chanID, Payload: []byte(`{"v":1}`)},
+			},
+			errs: []error{svcerr.ErrAuthentication},
+		},
+		{
+			desc:     "publish batch with failed publish",
+			thingKey: thingKey,
+			msgs: []*messaging.Message{
+				{Channel: chanID, Payload: []byte(`{"v":1}`)},
+			},
+			publishErr: errors.New("failed"),
+			errs:       []error{errors.New("failed to publish to magistrala message broker")},
+		},
+	}
+
+	for _, tc := range cases {
+		pubCall := pubsub.On("Publish", mock.Anything, chanID, mock.Anything).Return(tc.publishErr)
+		errs := svc.PublishBatch(context.Background(), tc.thingKey, tc.msgs)
+		assert.Len(t, errs, len(tc.errs), fmt.Sprintf("%s: expected %d errors got %d\n", tc.desc, len(tc.errs), len(errs)))
+		for i, err := range errs {
+			assert.True(t, errors.Contains(err, tc.errs[i]), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.errs[i], err))
+			if err == nil {
+				assert.Equal(t, thingID, tc.msgs[i].GetPublisher(), fmt.Sprintf("%s: expected publisher %s got %s\n", tc.desc, thingID, tc.msgs[i].GetPublisher()))
+				assert.NotZero(t, tc.msgs[i].GetCreated(), fmt.Sprintf("%s: expected created time to be set\n", tc.desc))
+			}
+		}
+		pubCall.Unset()
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	svc, pubsub, things := newService()
+
+	cases := []struct {
+		desc         string
+		thingKey     string
+		chanID       string
+		subtopic     string
+		since        int64
+		authzRes     *magistrala.ThingsAuthzRes
+		authzErr     error
+		subscribeErr error
+		topic        string
+		policy       messaging.DeliveryPolicy
+		err          error
+	}{
+		{
+			desc:     "subscribe to channel",
+			thingKey: thingKey,
+			chanID:   chanID,
+			authzRes: &magistrala.ThingsAuthzRes{Authorized: true, Id: thingID},
+			topic:    "channels.1",
+			policy:   messaging.DeliverNewPolicy,
+		},
+		{
+			desc:     "subscribe to channel subtopic from the last event",
+			thingKey: thingKey,
+			chanID:   chanID,
+			subtopic: subtopic,
+			since:    1000,
+			authzRes: &magistrala.ThingsAuthzRes{Authorized: true, Id: thingID},
+			topic:    "channels.1.subtopic",
+			policy:   messaging.DeliverByStartTimePolicy,
+		},
+		{
+			desc:     "subscribe to channel with empty thing key",
+			chanID:   chanID,
+			authzRes: &magistrala.ThingsAuthzRes{},
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "subscribe to channel with unauthorized thing",
+			thingKey: thingKey,
+			chanID:   chanID,
+			authzRes: &magistrala.ThingsAuthzRes{Authorized: false},
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:     "subscribe to channel with failed authorization",
+			thingKey: thingKey,
+			chanID:   chanID,
+			authzRes: &magistrala.ThingsAuthzRes{},
+			authzErr: svcerr.ErrAuthentication,
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:         "subscribe to channel with failed subscription",
+			thingKey:     thingKey,
+			chanID:       chanID,
+			authzRes:     &magistrala.ThingsAuthzRes{Authorized: true, Id: thingID},
+			subscribeErr: errors.New("failed"),
+			topic:        "channels.1",
+			policy:       messaging.DeliverNewPolicy,
+			err:          adapter.ErrFailedSubscription,
+		},
+	}
+
+	for _, tc := range cases {
+		authzCall := things.On("Authorize", mock.Anything, mock.Anything).Return(tc.authzRes, tc.authzErr)
+		subCall := pubsub.On("Subscribe", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			cfg := args.Get(1).(messaging.SubscriberConfig)
+			assert.Equal(t, tc.topic, cfg.Topic, fmt.Sprintf("%s: expected topic %s got %s", tc.desc, tc.topic, cfg.Topic))
+			assert.Equal(t, tc.policy, cfg.DeliveryPolicy, fmt.Sprintf("%s: expected delivery policy %d got %d", tc.desc, tc.policy, cfg.DeliveryPolicy))
+			if tc.since > 0 {
+				assert.Equal(t, time.Unix(0, tc.since), cfg.StartTime, fmt.Sprintf("%s: expected start time %s got %s", tc.desc, time.Unix(0, tc.since), cfg.StartTime))
+			}
+		}).Return(tc.subscribeErr)
+		err := svc.Subscribe(context.Background(), tc.thingKey, tc.chanID, tc.subtopic, adapter.NewClient(tc.since))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		authzCall.Unset()
+		subCall.Unset()
+	}
+}
+
+func TestClientHandle(t *testing.T) {
+	c := adapter.NewClient(1000)
+
+	cases := []struct {
+		desc     string
+		msg      *messaging.Message
+		received bool
+	}{
+		{
+			desc:     "handle new message",
+			msg:      &messaging.Message{Channel: chanID, Publisher: "publisher", Created: 1001},
+			received: true,
+		},
+		{
+			desc: "handle message created before the last event",
+			msg:  &messaging.Message{Channel: chanID, Publisher: "publisher", Created: 1000},
+		},
+	}
+
+	for _, tc := range cases {
+		err := c.Handle(tc.msg)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		select {
+		case msg := <-c.Messages():
+			assert.True(t, tc.received, fmt.Sprintf("%s: unexpected message %v", tc.desc, msg))
+			assert.Equal(t, tc.msg, msg, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.msg, msg))
+		default:
+			assert.False(t, tc.received, fmt.Sprintf("%s: expected message", tc.desc))
+		}
+	}
+}
+
+// newSchemaCache returns the schema cache of the channels without schema.
+func newSchemaCache() schema.Cache {
+	things := new(thmocks.ThingsServiceClient)
+	things.On("ChannelMetadata", mock.Anything, mock.Anything).Return(&magistrala.ChannelMetadataRes{}, nil)
+
+	return schema.NewCache(things, time.Minute)
+}
@@ -5,9 +5,13 @@ package api
 
 import (
 	"context"
+	"net/http"
+	"time"
 
+	adapter "github.com/absmach/magistrala/http"
 	"github.com/absmach/magistrala/pkg/apiutil"
 	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/go-kit/kit/endpoint"
 )
 
@@ -21,3 +25,86 @@ func sendMessageEndpoint() endpoint.Endpoint {
 		return publishMessageRes{}, nil
 	}
 }
+
+func publishBatchEndpoint(svc adapter.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(publishBatchReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		res := publishBatchRes{Results: make([]batchItemRes, len(req.items))}
+		// Malformed items are reported without failing the rest of the batch.
+		msgs := []*messaging.Message{}
+		indices := []int{}
+		for i, item := range req.items {
+			msg, err := item.message()
+			if err != nil {
+				res.Results[i] = newBatchItemRes(errors.Wrap(apiutil.ErrValidation, err))
+				continue
+			}
+			msgs = append(msgs, msg)
+			indices = append(indices, i)
+		}
+
+		for i, err := range svc.PublishBatch(ctx, req.token, msgs) {
+			res.Results[indices[i]] = newBatchItemRes(err)
+		}
+		for _, r := range res.Results {
+			if r.Status == http.StatusAccepted {
+				res.Published++
+				continue
+			}
+			res.Failed++
+		}
+
+		return res, nil
+	}
+}
+
+func pollMessagesEndpoint(svc adapter.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(subscribeReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		c := adapter.NewClient(req.lastEventID)
+		if err := svc.Subscribe(ctx, req.token, req.chanID, req.subtopic, c); err != nil {
+			return nil, err
+		}
+		// Unsubscribe failure does not affect the received messages
+		// and it is reported by the logging middleware.
+		defer svc.Unsubscribe(context.WithoutCancel(ctx), c)
+
+		return pollMessagesRes{Messages: poll(ctx, c, req.timeout, req.limit)}, nil
+	}
+}
+
+// poll waits for the first message until the timeout expires and returns
+// it together with the messages already received, up to the limit.
+func poll(ctx context.Context, c *adapter.Client, timeout time.Duration, limit uint64) []messageRes {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	msgs := []messageRes{}
+	select {
+	case <-ctx.Done():
+		return msgs
+	case <-timer.C:
+		return msgs
+	case msg := <-c.Messages():
+		msgs = append(msgs, newMessageRes(msg))
+	}
+
+	for uint64(len(msgs)) < limit {
+		select {
+		case msg := <-c.Messages():
+			msgs = append(msgs, newMessageRes(msg))
+		default:
+			return msgs
+		}
+	}
+
+	return msgs
+}
@@ -4,19 +4,28 @@
 package api_test
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/absmach/magistrala"
 	server "github.com/absmach/magistrala/http"
 	"github.com/absmach/magistrala/http/api"
 	mglog "github.com/absmach/magistrala/logger"
 	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/messaging"
 	pubsub "github.com/absmach/magistrala/pkg/messaging/mocks"
+	presencemocks "github.com/absmach/magistrala/pkg/presence/mocks"
+	"github.com/absmach/magistrala/pkg/schema"
+	"github.com/absmach/magistrala/pkg/uuid"
 	thmocks "github.com/absmach/magistrala/things/mocks"
 	"github.com/absmach/mgate"
 	proxy "github.com/absmach/mgate/pkg/http"
@@ -28,15 +37,20 @@ import (
 const (
 	instanceID   = "5de9b29a-feb9-11ed-be56-0242ac120002"
 	invalidValue = "invalid"
+	pollTimeout  = time.Second
 )
 
-func newService(things magistrala.ThingsServiceClient) (session.Handler, *pubsub.PubSub) {
+func newService(things magistrala.ThingsServiceClient, validator schema.Validator) (session.Handler, server.Service, *pubsub.PubSub) {
 	pub := new(pubsub.PubSub)
-	return server.NewHandler(pub, mglog.NewMock(), things), pub
+	eventStore := new(presencemocks.EventStore)
+	eventStore.On("Published", mock.Anything, mock.Anything).Return(nil)
+	handler := server.NewHandler(pub, eventStore, mglog.NewMock(), things, validator)
+	svc := server.New(things, pub, validator, eventStore, uuid.NewMock(), mglog.NewMock())
+	return handler, svc, pub
 }
 
-func newTargetHTTPServer() *httptest.Server {
-	mux := api.MakeHandler(mglog.NewMock(), instanceID)
+func newTargetHTTPServer(svc server.Service) *httptest.Server {
+	mux := api.MakeHandler(svc, pollTimeout, mglog.NewMock(), instanceID)
 	return httptest.NewServer(mux)
 }
 
@@ -57,6 +71,8 @@ type testRequest struct {
 	method      string
 	url         string
 	contentType string
+	encoding    string
+	accept      string
 	token       string
 	body        io.Reader
 	basicAuth   bool
@@ -77,6 +93,12 @@ func (tr testRequest) make() (*http.Response, error) {
 	if tr.contentType != "" {
 		req.Header.Set("Content-Type", tr.contentType)
 	}
+	if tr.encoding != "" {
+		req.Header.Set("Content-Encoding", tr.encoding)
+	}
+	if tr.accept != "" {
+		req.Header.Set("Accept", tr.accept)
+	}
 	return tr.client.Do(req)
 }
 
@@ -91,8 +113,18 @@ func TestPublish(t *testing.T) {
 	msg := `[{"n":"current","t":-1,"v":1.6}]`
 	msgJSON := `{"field1":"val1","field2":"val2"}`
 	msgCBOR := `81A3616E6763757272656E746174206176FB3FF999999999999A`
-	svc, pub := newService(things)
-	target := newTargetHTTPServer()
+	schemaChanID := "2"
+	schemas := newSchemaCache()
+	err := schemas.Save(schemaChanID, map[string]interface{}{
+		schema.JSONSchemaKey: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{"temperature": map[string]interface{}{"type": "number"}},
+			"required":   []interface{}{"temperature"},
+		},
+	})
+	assert.Nil(t, err, fmt.Sprintf("failed to save channel schema with err: %v", err))
+	svc, asvc, pub := newService(things, schemas)
+	target := newTargetHTTPServer(asvc)
 	defer target.Close()
 	ts, err := newProxyHTPPServer(svc, target)
 	assert.Nil(t, err, fmt.Sprintf("failed to create proxy server with err: %v", err))
@@ -100,6 +132,7 @@ func TestPublish(t *testing.T) {
 	defer ts.Close()
 
 	things.On("Authorize", mock.Anything, &magistrala.ThingsAuthzReq{ThingKey: thingKey, ChannelId: chanID, Permission: "publish"}).Return(&magistrala.ThingsAuthzRes{Authorized: true, Id: ""}, nil)
+	things.On("Authorize", mock.Anything, &magistrala.ThingsAuthzReq{ThingKey: thingKey, ChannelId: schemaChanID, Permission: "publish"}).Return(&magistrala.ThingsAuthzRes{Authorized: true, Id: ""}, nil)
 	things.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.ThingsAuthzRes{Authorized: false, Id: ""}, nil)
 
 	cases := map[string]struct {
@@ -175,6 +208,20 @@ func TestPublish(t *testing.T) {
 			key:         thingKey,
 			status:      http.StatusBadRequest,
 		},
+		"publish message conforming to channel schema": {
+			chanID:      schemaChanID,
+			msg:         `{"temperature":21.5}`,
+			contentType: ctJSON,
+			key:         thingKey,
+			status:      http.StatusAccepted,
+		},
+		"publish message not conforming to channel schema": {
+			chanID:      schemaChanID,
+			msg:         `{"temperature":"hot"}`,
+			contentType: ctJSON,
+			key:         thingKey,
+			status:      http.StatusBadRequest,
+		},
 	}
 
 	for desc, tc := range cases {
@@ -196,3 +243,400 @@ func TestPublish(t *testing.T) {
 		})
 	}
 }
+
+func TestPublishBatch(t *testing.T) {
+	things := new(thmocks.ThingsServiceClient)
+	chanID := "1"
+	schemaChanID := "3"
+	thingKey := "thing_key"
+	schemas := newSchemaCache()
+	err := schemas.Save(schemaChanID, map[string]interface{}{
+		schema.JSONSchemaKey: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{"temperature": map[string]interface{}{"type": "number"}},
+			"required":   []interface{}{"temperature"},
+		},
+	})
+	assert.Nil(t, err, fmt.Sprintf("failed to save channel schema with err: %v", err))
+	handler, svc, pub := newService(things, schemas)
+	target := newTargetHTTPServer(svc)
+	defer target.Close()
+	ts, err := newProxyHTPPServer(handler, target)
+	assert.Nil(t, err, fmt.Sprintf("failed to create proxy server with err: %v", err))
+	defer ts.Close()
+
+	things.On("Authorize", mock.Anything, &magistrala.ThingsAuthzReq{ThingKey: thingKey, ChannelId: chanID, Permission: "publish"}).Return(&magistrala.ThingsAuthzRes{Authorized: true, Id: "thing"}, nil)
+	things.On("Authorize", mock.Anything, &magistrala.ThingsAuthzReq{ThingKey: thingKey, ChannelId: schemaChanID, Permission: "publish"}).Return(&magistrala.ThingsAuthzRes{Authorized: true, Id: "thing"}, nil)
+	things.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.ThingsAuthzRes{Authorized: false}, nil)
+	pub.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	senml := `{"channel":"1","subtopic":"sensors/temperature","payload":[{"n":"current","t":-1,"v":1.6}],"content_type":"application/senml+json","timestamp":1000}`
+	cbor := `{"channel":"1","payload":"gaNhbmdjdXJyZW50YXQgYXb7P/mZmZmZmZo=","content_type":"application/senml+cbor"}`
+	unauthorized := `{"channel":"2","payload":{"field":"value"},"content_type":"application/json"}`
+	conforming := `{"channel":"3","payload":{"temperature":21.5},"content_type":"application/json"}`
+	notConforming := `{"channel":"3","payload":{"temperature":"hot"},"content_type":"application/json"}`
+	missingChannel := `{"payload":{"field":"value"}}`
+	unsupported := `{"channel":"1","payload":"value","content_type":"text/plain"}`
+
+	gzipped := func(body string) io.Reader {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		_, err := w.Write([]byte(body))
+		assert.Nil(t, err, fmt.Sprintf("failed to compress body with err: %v", err))
+		assert.Nil(t, w.Close(), "failed to close gzip writer")
+		return &buf
+	}
+
+	cases := []struct {
+		desc        string
+		body        io.Reader
+		contentType string
+		encoding    string
+		key         string
+		status      int
+		results     []int
+	}{
+		{
+			desc:        "publish batch spanning channels",
+			body:        strings.NewReader(fmt.Sprintf("[%s,%s,%s,%s,%s,%s,%s]", senml, cbor, unauthorized, conforming, notConforming, missingChannel, unsupported)),
+			contentType: "application/json",
+			key:         thingKey,
+			status:      http.StatusOK,
+			results: []int{
+				http.StatusAccepted,
+				http.StatusAccepted,
+				http.StatusForbidden,
+				http.StatusAccepted,
+				http.StatusBadRequest,
+				http.StatusBadRequest,
+				http.StatusUnsupportedMediaType,
+			},
+		},
+		{
+			desc:        "publish NDJSON batch",
+			body:        strings.NewReader(fmt.Sprintf("%s\n%s\n", senml, unauthorized)),
+			contentType: "application/x-ndjson",
+			key:         thingKey,
+			status:      http.StatusOK,
+			results:     []int{http.StatusAccepted, http.StatusForbidden},
+		},
+		{
+			desc:        "publish gzip compressed NDJSON batch",
+			body:        gzipped(fmt.Sprintf("%s\n%s\n", senml, conforming)),
+			contentType: "application/x-ndjson",
+			encoding:    "gzip",
+			key:         thingKey,
+			status:      http.StatusOK,
+			results:     []int{http.StatusAccepted, http.StatusAccepted},
+		},
+		{
+			desc:        "publish batch with invalid key",
+			body:        strings.NewReader(fmt.Sprintf("[%s]", senml)),
+			contentType: "application/json",
+			key:         invalidValue,
+			status:      http.StatusOK,
+			results:     []int{http.StatusForbidden},
+		},
+		{
+			desc:        "publish empty batch",
+			body:        strings.NewReader("[]"),
+			contentType: "application/json",
+			key:         thingKey,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "publish malformed batch",
+			body:        strings.NewReader(fmt.Sprintf("[%s", senml)),
+			contentType: "application/json",
+			key:         thingKey,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "publish batch with invalid gzip body",
+			body:        strings.NewReader(fmt.Sprintf("[%s]", senml)),
+			contentType: "application/json",
+			encoding:    "gzip",
+			key:         thingKey,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "publish batch with unsupported content type",
+			body:        strings.NewReader(fmt.Sprintf("[%s]", senml)),
+			contentType: "application/senml+json",
+			key:         thingKey,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "publish batch with empty key",
+			body:        strings.NewReader(fmt.Sprintf("[%s]", senml)),
+			contentType: "application/json",
+			status:      http.StatusBadGateway,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client:      ts.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/messages", ts.URL),
+				contentType: tc.contentType,
+				encoding:    tc.encoding,
+				token:       tc.key,
+				body:        tc.body,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status != http.StatusOK {
+				return
+			}
+			var page struct {
+				Published int `json:"published"`
+				Failed    int `json:"failed"`
+				Results   []struct {
+					Status int `json:"status"`
+				} `json:"results"`
+			}
+			err = json.NewDecoder(res.Body).Decode(&page)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			statuses := []int{}
+			published := 0
+			for _, r := range page.Results {
+				statuses = append(statuses, r.Status)
+				if r.Status == http.StatusAccepted {
+					published++
+				}
+			}
+			assert.Equal(t, tc.results, statuses, fmt.Sprintf("%s: expected results %v got %v", tc.desc, tc.results, statuses))
+			assert.Equal(t, published, page.Published, fmt.Sprintf("%s: expected %d published got %d", tc.desc, published, page.Published))
+			assert.Equal(t, len(tc.results)-published, page.Failed, fmt.Sprintf("%s: expected %d failed got %d", tc.desc, len(tc.results)-published, page.Failed))
+		})
+	}
+}
+
+func TestPollMessages(t *testing.T) {
+	things := new(thmocks.ThingsServiceClient)
+	chanID := "1"
+	thingKey := "thing_key"
+	received := messaging.Message{
+		Channel:   chanID,
+		Subtopic:  "temperature",
+		Publisher: "publisher",
+		Protocol:  "mqtt",
+		Payload:   []byte(`[{"n":"current","t":-1,"v":1.6}]`),
+		Created:   1000,
+	}
+	handler, svc, pub := newService(things, newSchemaCache())
+	target := newTargetHTTPServer(svc)
+	defer target.Close()
+	ts, err := newProxyHTPPServer(handler, target)
+	assert.Nil(t, err, fmt.Sprintf("failed to create proxy server with err: %v", err))
+	defer ts.Close()
+
+	things.On("Authorize", mock.Anything, &magistrala.ThingsAuthzReq{ThingKey: thingKey, ChannelId: chanID, Permission: "subscribe"}).Return(&magistrala.ThingsAuthzRes{Authorized: true, Id: "thing"}, nil)
+	things.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.ThingsAuthzRes{Authorized: false}, nil)
+
+	cases := []struct {
+		desc     string
+		chanID   string
+		subtopic string
+		query    string
+		key      string
+		deliver  bool
+		topic    string
+		status   int
+		messages int
+	}{
+		{
+			desc:     "poll messages",
+			chanID:   chanID,
+			key:      thingKey,
+			deliver:  true,
+			topic:    "channels.1",
+			status:   http.StatusOK,
+			messages: 1,
+		},
+		{
+			desc:     "poll messages with subtopic wildcard",
+			chanID:   chanID,
+			subtopic: "/sensors/>",
+			key:      thingKey,
+			deliver:  true,
+			topic:    "channels.1.sensors.>",
+			status:   http.StatusOK,
+			messages: 1,
+		},
+		{
+			desc:   "poll messages with timeout",
+			chanID: chanID,
+			query:  "?timeout=10ms",
+			key:    thingKey,
+			topic:  "channels.1",
+			status: http.StatusNoContent,
+		},
+		{
+			desc:    "poll messages after last event ID",
+			chanID:  chanID,
+			query:   "?timeout=10ms&last_event_id=1000",
+			key:     thingKey,
+			deliver: true,
+			topic:   "channels.1",
+			status:  http.StatusNoContent,
+		},
+		{
+			desc:   "poll messages with invalid last event ID",
+			chanID: chanID,
+			query:  "?last_event_id=invalid",
+			key:    thingKey,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "poll messages with invalid timeout",
+			chanID: chanID,
+			query:  "?timeout=invalid",
+			key:    thingKey,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "poll messages with invalid limit",
+			chanID: chanID,
+			query:  "?limit=0",
+			key:    thingKey,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:     "poll messages with malformed subtopic",
+			chanID:   chanID,
+			subtopic: "/sensors*",
+			key:      thingKey,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:   "poll messages with invalid key",
+			chanID: chanID,
+			key:    invalidValue,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "poll messages with empty key",
+			chanID: chanID,
+			status: http.StatusBadGateway,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			subCall := pub.On("Subscribe", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				cfg := args.Get(1).(messaging.SubscriberConfig)
+				assert.Equal(t, tc.topic, cfg.Topic, fmt.Sprintf("%s: expected topic %s got %s", tc.desc, tc.topic, cfg.Topic))
+				if tc.deliver {
+					err := cfg.Handler.Handle(&received)
+					assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				}
+			}).Return(nil)
+			unsubCall := pub.On("Unsubscribe", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/channels/%s/messages%s%s", ts.URL, tc.chanID, tc.subtopic, tc.query),
+				token:  tc.key,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var page struct {
+					Messages []struct {
+						Subtopic string `json:"subtopic"`
+						Created  int64  `json:"created"`
+						Payload  []byte `json:"payload"`
+					} `json:"messages"`
+				}
+				err := json.NewDecoder(res.Body).Decode(&page)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Len(t, page.Messages, tc.messages, fmt.Sprintf("%s: expected %d messages got %d", tc.desc, tc.messages, len(page.Messages)))
+				assert.Equal(t, received.GetPayload(), page.Messages[0].Payload, fmt.Sprintf("%s: expected payload %s got %s", tc.desc, received.GetPayload(), page.Messages[0].Payload))
+				assert.Equal(t, received.GetCreated(), page.Messages[0].Created, fmt.Sprintf("%s: expected created %d got %d", tc.desc, received.GetCreated(), page.Messages[0].Created))
+			}
+			subCall.Unset()
+			unsubCall.Unset()
+		})
+	}
+}
+
+func TestStreamMessages(t *testing.T) {
+	things := new(thmocks.ThingsServiceClient)
+	chanID := "1"
+	thingKey := "thing_key"
+	received := messaging.Message{
+		Channel:   chanID,
+		Publisher: "publisher",
+		Protocol:  "mqtt",
+		Payload:   []byte(`[{"n":"current","t":-1,"v":1.6}]`),
+		Created:   1000,
+	}
+	handler, svc, pub := newService(things, newSchemaCache())
+	target := newTargetHTTPServer(svc)
+	defer target.Close()
+	ts, err := newProxyHTPPServer(handler, target)
+	assert.Nil(t, err, fmt.Sprintf("failed to create proxy server with err: %v", err))
+	defer ts.Close()
+
+	things.On("Authorize", mock.Anything, &magistrala.ThingsAuthzReq{ThingKey: thingKey, ChannelId: chanID, Permission: "subscribe"}).Return(&magistrala.ThingsAuthzRes{Authorized: true, Id: "thing"}, nil)
+	things.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.ThingsAuthzRes{Authorized: false}, nil)
+	pub.On("Subscribe", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		cfg := args.Get(1).(messaging.SubscriberConfig)
+		err := cfg.Handler.Handle(&received)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	}).Return(nil)
+	pub.On("Unsubscribe", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	cases := []struct {
+		desc   string
+		key    string
+		status int
+		event  string
+	}{
+		{
+			desc:   "stream messages",
+			key:    thingKey,
+			status: http.StatusOK,
+			event:  "id: 1000",
+		},
+		{
+			desc:   "stream messages with invalid key",
+			key:    invalidValue,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/channels/%s/messages", ts.URL, chanID),
+				accept: "text/event-stream",
+				token:  tc.key,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			defer res.Body.Close()
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.event != "" {
+				line, err := bufio.NewReader(res.Body).ReadString('\n')
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Equal(t, tc.event, strings.TrimSpace(line), fmt.Sprintf("%s: expected event %s got %s", tc.desc, tc.event, line))
+			}
+		})
+	}
+}
+
+// newSchemaCache returns the schema cache of the channels without schema.
+func newSchemaCache() schema.Cache {
+	things := new(thmocks.ThingsServiceClient)
+	things.On("ChannelMetadata", mock.Anything, mock.Anything).Return(&magistrala.ChannelMetadataRes{}, nil)
+
+	return schema.NewCache(things, time.Minute)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	adapter "github.com/absmach/magistrala/http"
+	"github.com/absmach/magistrala/pkg/messaging"
+)
+
+var _ adapter.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger *slog.Logger
+	svc    adapter.Service
+}
+
+// LoggingMiddleware adds logging facilities to the HTTP adapter subscription service.
+func LoggingMiddleware(svc adapter.Service, logger *slog.Logger) adapter.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+// PublishBatch logs the batch publish request. It logs the number of the published and failed messages
+// and the time it took to complete the request.
+func (lm *loggingMiddleware) PublishBatch(ctx context.Context, thingKey string, msgs []*messaging.Message) (errs []error) {
+	defer func(begin time.Time) {
+		failed := 0
+		for _, err := range errs {
+			if err != nil {
+				failed++
+			}
+		}
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Int("published", len(msgs)-failed),
+			slog.Int("failed", failed),
+		}
+		if failed > 0 {
+			lm.logger.Warn("Publish batch completed with failures", args...)
+			return
+		}
+		lm.logger.Info("Publish batch completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.PublishBatch(ctx, thingKey, msgs)
+}
+
+// Subscribe logs the subscribe request. It logs the channel and subtopic(if present) and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) Subscribe(ctx context.Context, thingKey, chanID, subtopic string, c *adapter.Client) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("channel_id", chanID),
+		}
+		if subtopic != "" {
+			args = append(args, slog.String("subtopic", subtopic))
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Subscribe failed", args...)
+			return
+		}
+		lm.logger.Info("Subscribe completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Subscribe(ctx, thingKey, chanID, subtopic, c)
+}
+
+// Unsubscribe logs the unsubscribe request and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) Unsubscribe(ctx context.Context, c *adapter.Client) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Unsubscribe failed", args...)
+			return
+		}
+		lm.logger.Info("Unsubscribe completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Unsubscribe(ctx, c)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"time"
+
+	adapter "github.com/absmach/magistrala/http"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ adapter.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     adapter.Service
+}
+
+// MetricsMiddleware instruments adapter by tracking request count and latency.
+func MetricsMiddleware(svc adapter.Service, counter metrics.Counter, latency metrics.Histogram) adapter.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+// PublishBatch instruments PublishBatch method with metrics.
+func (mm *metricsMiddleware) PublishBatch(ctx context.Context, thingKey string, msgs []*messaging.Message) []error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "publish_batch").Add(1)
+		mm.latency.With("method", "publish_batch").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.PublishBatch(ctx, thingKey, msgs)
+}
+
+// Subscribe instruments Subscribe method with metrics.
+func (mm *metricsMiddleware) Subscribe(ctx context.Context, thingKey, chanID, subtopic string, c *adapter.Client) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "subscribe").Add(1)
+		mm.latency.With("method", "subscribe").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Subscribe(ctx, thingKey, chanID, subtopic, c)
+}
+
+// Unsubscribe instruments Unsubscribe method with metrics.
+func (mm *metricsMiddleware) Unsubscribe(ctx context.Context, c *adapter.Client) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "unsubscribe").Add(1)
+		mm.latency.With("method", "unsubscribe").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Unsubscribe(ctx, c)
+}
@@ -4,10 +4,19 @@
 package api
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
 	"github.com/absmach/magistrala/pkg/messaging"
 )
 
+const (
+	maxLimitSize = 1000
+	maxBatchSize = 1000
+)
+
 type publishReq struct {
 	msg   *messaging.Message
 	token string
@@ -23,3 +32,98 @@ func (req publishReq) validate() error {
 
 	return nil
 }
+
+type subscribeReq struct {
+	token       string
+	chanID      string
+	subtopic    string
+	lastEventID int64
+	timeout     time.Duration
+	limit       uint64
+}
+
+func (req subscribeReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerKey
+	}
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.lastEventID < 0 {
+		return apiutil.ErrInvalidQueryParams
+	}
+	if req.timeout <= 0 {
+		return apiutil.ErrInvalidQueryParams
+	}
+	if req.limit < 1 || req.limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type publishBatchReq struct {
+	token string
+	items []batchItem
+}
+
+func (req publishBatchReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerKey
+	}
+	if len(req.items) == 0 {
+		return apiutil.ErrEmptyList
+	}
+	if len(req.items) > maxBatchSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+// batchItem is a single message of the batch publish request. The payload
+// of the JSON and SenML JSON items is the JSON value itself, while the
+// payload of the SenML CBOR items is the base64 encoded string.
+type batchItem struct {
+	Channel     string          `json:"channel"`
+	Subtopic    string          `json:"subtopic,omitempty"`
+	Payload     json.RawMessage `json:"payload"`
+	ContentType string          `json:"content_type,omitempty"`
+	Timestamp   int64           `json:"timestamp,omitempty"`
+}
+
+// message validates the batch item and converts it to the message.
+func (item batchItem) message() (*messaging.Message, error) {
+	if item.Channel == "" {
+		return nil, apiutil.ErrMissingID
+	}
+	if item.Timestamp < 0 {
+		return nil, errors.ErrMalformedEntity
+	}
+	subtopic, err := parseSubtopic(item.Subtopic)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	var payload []byte
+	switch item.ContentType {
+	case "", ctSenmlJSON, contentType:
+		payload = item.Payload
+	case ctSenmlCBOR:
+		if err := json.Unmarshal(item.Payload, &payload); err != nil {
+			return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	default:
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+	if len(payload) == 0 || string(payload) == "null" {
+		return nil, apiutil.ErrEmptyMessage
+	}
+
+	return &messaging.Message{
+		Channel:  item.Channel,
+		Subtopic: subtopic,
+		Payload:  payload,
+		Created:  item.Timestamp,
+	}, nil
+}
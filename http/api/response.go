@@ -7,9 +7,18 @@ import (
 	"net/http"
 
 	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/schema"
 )
 
-var _ magistrala.Response = (*publishMessageRes)(nil)
+var (
+	_ magistrala.Response = (*publishMessageRes)(nil)
+	_ magistrala.Response = (*publishBatchRes)(nil)
+	_ magistrala.Response = (*pollMessagesRes)(nil)
+)
 
 type publishMessageRes struct{}
 
@@ -24,3 +33,93 @@ func (res publishMessageRes) Headers() map[string]string {
 func (res publishMessageRes) Empty() bool {
 	return true
 }
+
+type publishBatchRes struct {
+	Published int            `json:"published"`
+	Failed    int            `json:"failed"`
+	Results   []batchItemRes `json:"results"`
+}
+
+func (res publishBatchRes) Code() int {
+	return http.StatusOK
+}
+
+func (res publishBatchRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res publishBatchRes) Empty() bool {
+	return false
+}
+
+// batchItemRes reports the status of the batch item
+// using the status code of the equivalent single publish.
+type batchItemRes struct {
+	Status int    `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+func newBatchItemRes(err error) batchItemRes {
+	if err == nil {
+		return batchItemRes{Status: http.StatusAccepted}
+	}
+
+	res := batchItemRes{Error: err.Error()}
+	switch {
+	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
+		res.Status = http.StatusUnsupportedMediaType
+	case errors.Contains(err, apiutil.ErrValidation),
+		errors.Contains(err, schema.ErrInvalidPayload):
+		res.Status = http.StatusBadRequest
+	case errors.Contains(err, svcerr.ErrAuthentication):
+		res.Status = http.StatusUnauthorized
+	case errors.Contains(err, svcerr.ErrAuthorization):
+		res.Status = http.StatusForbidden
+	default:
+		res.Status = http.StatusInternalServerError
+	}
+
+	return res
+}
+
+// messageRes represents a received message. The message creation
+// time is used as the event ID to resume the subscription from.
+type messageRes struct {
+	Channel   string `json:"channel"`
+	Subtopic  string `json:"subtopic,omitempty"`
+	Publisher string `json:"publisher"`
+	Protocol  string `json:"protocol"`
+	Created   int64  `json:"created"`
+	Payload   []byte `json:"payload"`
+}
+
+func newMessageRes(msg *messaging.Message) messageRes {
+	return messageRes{
+		Channel:   msg.GetChannel(),
+		Subtopic:  msg.GetSubtopic(),
+		Publisher: msg.GetPublisher(),
+		Protocol:  msg.GetProtocol(),
+		Created:   msg.GetCreated(),
+		Payload:   msg.GetPayload(),
+	}
+}
+
+type pollMessagesRes struct {
+	Messages []messageRes `json:"messages"`
+}
+
+func (res pollMessagesRes) Code() int {
+	if len(res.Messages) == 0 {
+		return http.StatusNoContent
+	}
+
+	return http.StatusOK
+}
+
+func (res pollMessagesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res pollMessagesRes) Empty() bool {
+	return len(res.Messages) == 0
+}
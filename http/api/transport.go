@@ -4,12 +4,20 @@
 package api
 
 import (
+	"compress/gzip"
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/absmach/magistrala"
+	adapter "github.com/absmach/magistrala/http"
 	"github.com/absmach/magistrala/internal/api"
 	"github.com/absmach/magistrala/pkg/apiutil"
 	"github.com/absmach/magistrala/pkg/errors"
@@ -21,15 +29,34 @@ import (
 )
 
 const (
-	ctSenmlJSON = "application/senml+json"
-	ctSenmlCBOR = "application/senml+cbor"
-	contentType = "application/json"
+	ctSenmlJSON   = "application/senml+json"
+	ctSenmlCBOR   = "application/senml+cbor"
+	contentType   = "application/json"
+	ctEventStream = "text/event-stream"
+	ctNDJSON      = "application/x-ndjson"
+
+	// maxBatchBodySize limits the size of the decompressed batch request body.
+	maxBatchBodySize = 16 << 20
+
+	lastEventIDHeader = "Last-Event-ID"
+	lastEventIDKey    = "last_event_id"
+	timeoutKey        = "timeout"
+	limitKey          = "limit"
+	defLimit          = 100
+
+	// keepAliveInterval is the interval of the comments sent over the idle
+	// event stream, so that the intermediaries do not close the connection.
+	keepAliveInterval = 30 * time.Second
 )
 
-// MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(logger *slog.Logger, instanceID string) http.Handler {
+var errMalformedSubtopic = errors.New("malformed subtopic")
+
+// MakeHandler returns a HTTP handler for API endpoints. Poll timeout is the
+// default and the maximal duration of the long-poll subscribe request.
+func MakeHandler(svc adapter.Service, pollTimeout time.Duration, logger *slog.Logger, instanceID string) http.Handler {
+	errorEncoder := apiutil.LoggingErrorEncoder(logger, api.EncodeError)
 	opts := []kithttp.ServerOption{
-		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+		kithttp.ServerErrorEncoder(errorEncoder),
 	}
 
 	r := chi.NewRouter()
@@ -46,6 +73,26 @@ func MakeHandler(logger *slog.Logger, instanceID string) http.Handler {
 		api.EncodeResponse,
 		opts...,
 	), "publish").ServeHTTP)
+
+	r.Post("/messages", otelhttp.NewHandler(kithttp.NewServer(
+		publishBatchEndpoint(svc),
+		decodeBatchRequest,
+		api.EncodeResponse,
+		opts...,
+	), "publish_batch").ServeHTTP)
+
+	subscribe := subscribeHandler(
+		otelhttp.NewHandler(streamMessages(svc, pollTimeout, errorEncoder), "stream_messages"),
+		otelhttp.NewHandler(kithttp.NewServer(
+			pollMessagesEndpoint(svc),
+			decodeSubscribeRequest(pollTimeout),
+			api.EncodeResponse,
+			opts...,
+		), "poll_messages"),
+	)
+	r.Get("/channels/{chanID}/messages", subscribe)
+	r.Get("/channels/{chanID}/messages/*", subscribe)
+
 	r.Get("/health", magistrala.Health("http", instanceID))
 	r.Handle("/metrics", promhttp.Handler())
 
@@ -77,3 +124,216 @@ func decodeRequest(_ context.Context, r *http.Request) (interface{}, error) {
 
 	return req, nil
 }
+
+// decodeBatchRequest decodes the JSON array or the newline delimited JSON
+// batch items. The request body may be compressed using gzip.
+func decodeBatchRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	ct := r.Header.Get("Content-Type")
+	if ct != contentType && ct != ctNDJSON {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := publishBatchReq{}
+	_, pass, ok := r.BasicAuth()
+	switch {
+	case ok:
+		req.token = pass
+	case !ok:
+		req.token = apiutil.ExtractThingKey(r)
+	}
+
+	body := io.Reader(r.Body)
+	defer r.Body.Close()
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+		}
+		defer gr.Close()
+		body = gr
+	}
+
+	dec := json.NewDecoder(io.LimitReader(body, maxBatchBodySize))
+	if ct == contentType {
+		if err := dec.Decode(&req.items); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+		}
+		return req, nil
+	}
+
+	for {
+		var item batchItem
+		err := dec.Decode(&item)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+		}
+		req.items = append(req.items, item)
+	}
+
+	return req, nil
+}
+
+// subscribeHandler streams the messages as Server-Sent Events to the clients
+// accepting the event stream and long-polls the messages for the others.
+func subscribeHandler(stream, poll http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.Header.Get("Accept"), ctEventStream) {
+			stream.ServeHTTP(w, r)
+			return
+		}
+		poll.ServeHTTP(w, r)
+	}
+}
+
+func streamMessages(svc adapter.Service, pollTimeout time.Duration, encodeError kithttp.ErrorEncoder) http.HandlerFunc {
+	decode := decodeSubscribeRequest(pollTimeout)
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			encodeError(ctx, errors.New("streaming is not supported"), w)
+			return
+		}
+
+		request, err := decode(ctx, r)
+		if err != nil {
+			encodeError(ctx, err, w)
+			return
+		}
+		req := request.(subscribeReq)
+		if err := req.validate(); err != nil {
+			encodeError(ctx, errors.Wrap(apiutil.ErrValidation, err), w)
+			return
+		}
+
+		c := adapter.NewClient(req.lastEventID)
+		if err := svc.Subscribe(ctx, req.token, req.chanID, req.subtopic, c); err != nil {
+			encodeError(ctx, err, w)
+			return
+		}
+		defer svc.Unsubscribe(context.WithoutCancel(ctx), c)
+
+		w.Header().Set("Content-Type", ctEventStream)
+		w.Header().Set("Cache-Control", "no-cache")
+		// Disables response buffering by the nginx reverse proxy.
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		ticker := time.NewTicker(keepAliveInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+					return
+				}
+			case msg := <-c.Messages():
+				if err := encodeEvent(w, msg); err != nil {
+					return
+				}
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// encodeEvent writes the message as the Server-Sent Event
+// identified by the message creation time.
+func encodeEvent(w io.Writer, msg *messaging.Message) error {
+	data, err := json.Marshal(newMessageRes(msg))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\ndata: %s\n\n", msg.GetCreated(), data)
+
+	return err
+}
+
+func decodeSubscribeRequest(pollTimeout time.Duration) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		req := subscribeReq{
+			chanID:  chi.URLParam(r, "chanID"),
+			timeout: pollTimeout,
+		}
+		_, pass, ok := r.BasicAuth()
+		switch {
+		case ok:
+			req.token = pass
+		case !ok:
+			req.token = apiutil.ExtractThingKey(r)
+		}
+
+		subtopic, err := parseSubtopic(chi.URLParam(r, "*"))
+		if err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+		}
+		req.subtopic = subtopic
+
+		// Browsers send the ID of the last received event in the header
+		// when they reconnect to the event stream.
+		lastEventID := r.Header.Get(lastEventIDHeader)
+		if lastEventID == "" {
+			if lastEventID, err = apiutil.ReadStringQuery(r, lastEventIDKey, ""); err != nil {
+				return nil, errors.Wrap(apiutil.ErrValidation, err)
+			}
+		}
+		if lastEventID != "" {
+			if req.lastEventID, err = strconv.ParseInt(lastEventID, 10, 64); err != nil {
+				return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(apiutil.ErrInvalidQueryParams, err))
+			}
+		}
+
+		timeout, err := apiutil.ReadStringQuery(r, timeoutKey, "")
+		if err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		if timeout != "" {
+			if req.timeout, err = time.ParseDuration(timeout); err != nil {
+				return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(apiutil.ErrInvalidQueryParams, err))
+			}
+			req.timeout = min(req.timeout, pollTimeout)
+		}
+
+		if req.limit, err = apiutil.ReadNumQuery[uint64](r, limitKey, defLimit); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		return req, nil
+	}
+}
+
+// parseSubtopic converts the URL subtopic to the message broker subtopic.
+// Single "*" and ">" elements are kept as the subtopic wildcards.
+func parseSubtopic(subtopic string) (string, error) {
+	if subtopic == "" {
+		return subtopic, nil
+	}
+
+	subtopic, err := url.QueryUnescape(subtopic)
+	if err != nil {
+		return "", errMalformedSubtopic
+	}
+	subtopic = strings.ReplaceAll(subtopic, "/", ".")
+
+	elems := strings.Split(subtopic, ".")
+	filteredElems := []string{}
+	for _, elem := range elems {
+		if elem == "" {
+			continue
+		}
+
+		if len(elem) > 1 && (strings.Contains(elem, "*") || strings.Contains(elem, ">")) {
+			return "", errMalformedSubtopic
+		}
+
+		filteredElems = append(filteredElems, elem)
+	}
+
+	return strings.Join(filteredElems, "."), nil
+}
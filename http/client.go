@@ -0,0 +1,73 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"sync"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+)
+
+// clientBuffer is the number of received messages the client
+// holds before it blocks the subscription.
+const clientBuffer = 100
+
+var errClientCanceled = errors.New("client is canceled")
+
+var _ messaging.MessageHandler = (*Client)(nil)
+
+// Client buffers the messages received by an HTTP subscription.
+type Client struct {
+	id       string
+	thingID  string
+	topic    string
+	since    int64
+	messages chan *messaging.Message
+	done     chan struct{}
+	once     sync.Once
+}
+
+// NewClient returns a new HTTP subscription client. Since is the creation
+// time, in Unix nanoseconds, of the last message the subscriber received.
+// Messages created at or before it are not delivered again.
+func NewClient(since int64) *Client {
+	return &Client{
+		since:    since,
+		messages: make(chan *messaging.Message, clientBuffer),
+		done:     make(chan struct{}),
+	}
+}
+
+// Messages returns the channel of the received messages.
+func (c *Client) Messages() <-chan *messaging.Message {
+	return c.messages
+}
+
+// Handle buffers the message received from the broker.
+func (c *Client) Handle(msg *messaging.Message) error {
+	// To prevent publisher from receiving its own published message
+	if msg.GetPublisher() == c.thingID {
+		return nil
+	}
+	if msg.GetCreated() <= c.since {
+		return nil
+	}
+
+	select {
+	case c.messages <- msg:
+		return nil
+	case <-c.done:
+		return errClientCanceled
+	}
+}
+
+// Cancel stops the client from receiving further messages.
+func (c *Client) Cancel() error {
+	c.once.Do(func() {
+		close(c.done)
+	})
+
+	return nil
+}
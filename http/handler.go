@@ -19,13 +19,21 @@ import (
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/absmach/magistrala/pkg/policies"
+	"github.com/absmach/magistrala/pkg/presence"
+	"github.com/absmach/magistrala/pkg/schema"
 	mgate "github.com/absmach/mgate/pkg/http"
 	"github.com/absmach/mgate/pkg/session"
 )
 
 var _ session.Handler = (*handler)(nil)
 
-const protocol = "http"
+const (
+	protocol = "http"
+
+	// batchPath is the path of the batch publish requests. Batch messages
+	// are authorized and published one by one by the HTTP server.
+	batchPath = "/messages"
+)
 
 // Log message formats.
 const (
@@ -38,6 +46,7 @@ var (
 	errClientNotInitialized     = errors.New("client is not initialized")
 	errFailedPublish            = errors.New("failed to publish")
 	errFailedPublishToMsgBroker = errors.New("failed to publish to magistrala message broker")
+	errFailedPresenceEvent      = errors.New("failed to publish presence event")
 	errMalformedSubtopic        = mgate.NewHTTPProxyError(http.StatusBadRequest, errors.New("malformed subtopic"))
 	errMalformedTopic           = mgate.NewHTTPProxyError(http.StatusBadRequest, errors.New("malformed topic"))
 	errMissingTopicPub          = mgate.NewHTTPProxyError(http.StatusBadRequest, errors.New("failed to publish due to missing topic"))
@@ -50,15 +59,19 @@ var channelRegExp = regexp.MustCompile(`^\/?channels\/([\w\-]+)\/messages(\/[^?]
 type handler struct {
 	publisher messaging.Publisher
 	things    magistrala.ThingsServiceClient
+	validator schema.Validator
+	es        presence.EventStore
 	logger    *slog.Logger
 }
 
 // NewHandler creates new Handler entity.
-func NewHandler(publisher messaging.Publisher, logger *slog.Logger, thingsClient magistrala.ThingsServiceClient) session.Handler {
+func NewHandler(publisher messaging.Publisher, es presence.EventStore, logger *slog.Logger, thingsClient magistrala.ThingsServiceClient, validator schema.Validator) session.Handler {
 	return &handler{
 		logger:    logger,
 		publisher: publisher,
 		things:    thingsClient,
+		validator: validator,
+		es:        es,
 	}
 }
 
@@ -104,7 +117,15 @@ func (h *handler) Publish(ctx context.Context, topic *string, payload *[]byte) e
 	if topic == nil {
 		return errMissingTopicPub
 	}
+	// Subscriptions carry no payload. They are forwarded to
+	// the HTTP server, which authorizes them on its own.
+	if len(*payload) == 0 {
+		return nil
+	}
 	topic = &strings.Split(*topic, "?")[0]
+	if *topic == batchPath {
+		return nil
+	}
 	s, ok := session.FromContext(ctx)
 	if !ok {
 		return errors.Wrap(errFailedPublish, errClientNotInitialized)
@@ -156,10 +177,18 @@ func (h *handler) Publish(ctx context.Context, topic *string, payload *[]byte) e
 	}
 	msg.Publisher = res.GetId()
 
+	if err := h.validator.Validate(ctx, msg.Channel, msg.Payload); err != nil {
+		return mgate.NewHTTPProxyError(http.StatusBadRequest, errors.Wrap(errFailedPublish, err))
+	}
+
 	if err := h.publisher.Publish(ctx, msg.Channel, &msg); err != nil {
 		return errors.Wrap(errFailedPublishToMsgBroker, err)
 	}
 
+	if err := h.es.Published(ctx, msg.Publisher); err != nil {
+		h.logger.Error(errors.Wrap(errFailedPresenceEvent, err).Error())
+	}
+
 	return nil
 }
 
@@ -0,0 +1,12 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing provides tracing instrumentation for Magistrala HTTP adapter service.
+//
+// This package provides tracing middleware for Magistrala HTTP adapter subscription service.
+// It can be used to trace incoming requests and add tracing capabilities to
+// Magistrala HTTP adapter service.
+//
+// For more details about tracing instrumentation for Magistrala messaging refer
+// to the documentation at https://docs.magistrala.abstractmachines.fr/tracing/.
+package tracing
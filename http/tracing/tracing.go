@@ -0,0 +1,60 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	adapter "github.com/absmach/magistrala/http"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ adapter.Service = (*tracingMiddleware)(nil)
+
+const (
+	publishBatchOP = "publish_batch_op"
+	subscribeOP    = "subscribe_op"
+	unsubscribeOP  = "unsubscribe_op"
+)
+
+type tracingMiddleware struct {
+	tracer trace.Tracer
+	svc    adapter.Service
+}
+
+// New returns a new HTTP adapter subscription service with tracing capabilities.
+func New(tracer trace.Tracer, svc adapter.Service) adapter.Service {
+	return &tracingMiddleware{
+		tracer: tracer,
+		svc:    svc,
+	}
+}
+
+// PublishBatch traces the "PublishBatch" operation of the wrapped adapter.Service.
+func (tm *tracingMiddleware) PublishBatch(ctx context.Context, thingKey string, msgs []*messaging.Message) []error {
+	ctx, span := tm.tracer.Start(ctx, publishBatchOP, trace.WithAttributes(
+		attribute.Int("messages", len(msgs)),
+	))
+	defer span.End()
+
+	return tm.svc.PublishBatch(ctx, thingKey, msgs)
+}
+
+// Subscribe traces the "Subscribe" operation of the wrapped adapter.Service.
+func (tm *tracingMiddleware) Subscribe(ctx context.Context, thingKey, chanID, subtopic string, c *adapter.Client) error {
+	ctx, span := tm.tracer.Start(ctx, subscribeOP)
+	defer span.End()
+
+	return tm.svc.Subscribe(ctx, thingKey, chanID, subtopic, c)
+}
+
+// Unsubscribe traces the "Unsubscribe" operation of the wrapped adapter.Service.
+func (tm *tracingMiddleware) Unsubscribe(ctx context.Context, c *adapter.Client) error {
+	ctx, span := tm.tracer.Start(ctx, unsubscribeOP)
+	defer span.End()
+
+	return tm.svc.Unsubscribe(ctx, c)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/absmach/magistrala/bootstrap"
 	"github.com/absmach/magistrala/certs"
 	"github.com/absmach/magistrala/internal/groups"
+	"github.com/absmach/magistrala/journal"
 	"github.com/absmach/magistrala/pkg/apiutil"
 	"github.com/absmach/magistrala/pkg/errors"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
@@ -167,11 +168,24 @@ func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
 		errors.Contains(err, apiutil.ErrInvalidEntityType),
 		errors.Contains(err, apiutil.ErrMissingEntityType),
 		errors.Contains(err, apiutil.ErrInvalidTimeFormat),
+		errors.Contains(err, apiutil.ErrInvalidTTL),
+		errors.Contains(err, apiutil.ErrInvalidPatchOp),
+		errors.Contains(err, apiutil.ErrMissingFile),
+		errors.Contains(err, apiutil.ErrFileSize),
+		errors.Contains(err, apiutil.ErrMissingVersion),
+		errors.Contains(err, apiutil.ErrMissingTarget),
+		errors.Contains(err, apiutil.ErrInvalidRolloutStages),
+		errors.Contains(err, apiutil.ErrInvalidFailureThreshold),
+		errors.Contains(err, apiutil.ErrInvalidRetention),
+		errors.Contains(err, apiutil.ErrInvalidExportFormat),
+		errors.Contains(err, apiutil.ErrInvalidWebhookURL),
+		errors.Contains(err, apiutil.ErrMissingEvents),
 		errors.Contains(err, svcerr.ErrSearch),
 		errors.Contains(err, apiutil.ErrEmptySearchQuery),
 		errors.Contains(err, apiutil.ErrLenSearchQuery),
 		errors.Contains(err, apiutil.ErrMissingDomainID),
 		errors.Contains(err, certs.ErrFailedReadFromPKI),
+		errors.Contains(err, certs.ErrInvalidCSR),
 		errors.Contains(err, apiutil.ErrMissingUsername),
 		errors.Contains(err, apiutil.ErrMissingFirstName),
 		errors.Contains(err, apiutil.ErrMissingLastName),
@@ -196,7 +210,10 @@ func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
 	case errors.Contains(err, errors.ErrStatusAlreadyAssigned),
 		errors.Contains(err, svcerr.ErrInvitationAlreadyRejected),
 		errors.Contains(err, svcerr.ErrInvitationAlreadyAccepted),
-		errors.Contains(err, svcerr.ErrConflict):
+		errors.Contains(err, svcerr.ErrInvitationExpired),
+		errors.Contains(err, svcerr.ErrInvitationResendLimit),
+		errors.Contains(err, svcerr.ErrConflict),
+		errors.Contains(err, journal.ErrChainBroken):
 		err = unwrap(err)
 		w.WriteHeader(http.StatusConflict)
 
@@ -0,0 +1,88 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/events/outbox"
+	"github.com/absmach/magistrala/pkg/groups"
+	"github.com/absmach/magistrala/pkg/postgres"
+)
+
+var _ groups.Repository = (*repository)(nil)
+
+// repository writes the events of the group changes to the outbox in the
+// same transaction as the change itself. Only the repository write and the
+// outbox insert are part of the transaction, so policies and other remote
+// calls made by the service are never run while the transaction is open.
+type repository struct {
+	groups.Repository
+	db     postgres.Database
+	outbox events.Publisher
+}
+
+// NewRepositoryMiddleware returns wrapper around groups repository which
+// writes the events of the group changes to the outbox. It is used together
+// with the service returned by NewOutboxMiddleware.
+func NewRepositoryMiddleware(repo groups.Repository, db postgres.Database, idp magistrala.IDProvider, streamID string) groups.Repository {
+	return &repository{
+		Repository: repo,
+		db:         db,
+		outbox:     outbox.NewPublisher(db, idp, streamID),
+	}
+}
+
+func (repo *repository) Save(ctx context.Context, g groups.Group) (groups.Group, error) {
+	return repo.change(ctx, func(ctx context.Context) (groups.Group, error) {
+		return repo.Repository.Save(ctx, g)
+	}, func(group groups.Group) events.Event {
+		return createGroupEvent{
+			group,
+		}
+	})
+}
+
+func (repo *repository) Update(ctx context.Context, g groups.Group) (groups.Group, error) {
+	return repo.change(ctx, func(ctx context.Context) (groups.Group, error) {
+		return repo.Repository.Update(ctx, g)
+	}, func(group groups.Group) events.Event {
+		return updateGroupEvent{
+			group,
+		}
+	})
+}
+
+func (repo *repository) ChangeStatus(ctx context.Context, g groups.Group) (groups.Group, error) {
+	return repo.change(ctx, func(ctx context.Context) (groups.Group, error) {
+		return repo.Repository.ChangeStatus(ctx, g)
+	}, changeStatusEvent)
+}
+
+func (repo *repository) Delete(ctx context.Context, groupID string) error {
+	return postgres.Transaction(ctx, repo.db, func(ctx context.Context) error {
+		if err := repo.Repository.Delete(ctx, groupID); err != nil {
+			return err
+		}
+
+		return repo.outbox.Publish(ctx, deleteGroupEvent{groupID})
+	})
+}
+
+// change runs the repository write and writes its event to the outbox.
+func (repo *repository) change(ctx context.Context, fn func(ctx context.Context) (groups.Group, error), event func(groups.Group) events.Event) (groups.Group, error) {
+	var group groups.Group
+	err := postgres.Transaction(ctx, repo.db, func(ctx context.Context) error {
+		var err error
+		if group, err = fn(ctx); err != nil {
+			return err
+		}
+
+		return repo.outbox.Publish(ctx, event(group))
+	})
+
+	return group, err
+}
@@ -6,17 +6,27 @@ package events
 import (
 	"context"
 
+	"github.com/absmach/magistrala"
 	"github.com/absmach/magistrala/pkg/authn"
 	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/events/outbox"
 	"github.com/absmach/magistrala/pkg/events/store"
 	"github.com/absmach/magistrala/pkg/groups"
+	"github.com/absmach/magistrala/pkg/postgres"
 )
 
 var _ groups.Service = (*eventStore)(nil)
 
+// eventStore publishes the events of the operations which change groups to
+// the changes publisher, and the events of the other operations directly to
+// the event store. If the group changes are published by the repository,
+// only the events of the membership changes are published to the changes
+// publisher.
 type eventStore struct {
 	events.Publisher
-	svc groups.Service
+	changes   events.Publisher
+	persisted bool
+	svc       groups.Service
 }
 
 // NewEventStoreMiddleware returns wrapper around things service that sends
@@ -30,41 +40,47 @@ func NewEventStoreMiddleware(ctx context.Context, svc groups.Service, url, strea
 	return &eventStore{
 		svc:       svc,
 		Publisher: publisher,
+		changes:   publisher,
 	}, nil
 }
 
-func (es eventStore) CreateGroup(ctx context.Context, session authn.Session, kind string, group groups.Group) (groups.Group, error) {
-	group, err := es.svc.CreateGroup(ctx, session, kind, group)
+// NewOutboxMiddleware returns wrapper around groups service which writes the
+// events of the membership changes to the outbox. The events of the group
+// changes are written to the outbox by the repository returned by
+// NewRepositoryMiddleware, in the same transaction as the change itself. The
+// other events are sent to the event store directly.
+func NewOutboxMiddleware(ctx context.Context, svc groups.Service, db postgres.Database, idp magistrala.IDProvider, url, streamID string) (groups.Service, error) {
+	publisher, err := store.NewPublisher(ctx, url, streamID)
 	if err != nil {
-		return group, err
-	}
-
-	event := createGroupEvent{
-		group,
+		return nil, err
 	}
 
-	if err := es.Publish(ctx, event); err != nil {
-		return group, err
-	}
+	return &eventStore{
+		svc:       svc,
+		Publisher: publisher,
+		changes:   outbox.NewPublisher(db, idp, streamID),
+		persisted: true,
+	}, nil
+}
 
-	return group, nil
+func (es eventStore) CreateGroup(ctx context.Context, session authn.Session, kind string, group groups.Group) (groups.Group, error) {
+	return es.change(ctx, func(ctx context.Context) (groups.Group, error) {
+		return es.svc.CreateGroup(ctx, session, kind, group)
+	}, func(group groups.Group) events.Event {
+		return createGroupEvent{
+			group,
+		}
+	})
 }
 
 func (es eventStore) UpdateGroup(ctx context.Context, session authn.Session, group groups.Group) (groups.Group, error) {
-	group, err := es.svc.UpdateGroup(ctx, session, group)
-	if err != nil {
-		return group, err
-	}
-
-	event := updateGroupEvent{
-		group,
-	}
-
-	if err := es.Publish(ctx, event); err != nil {
-		return group, err
-	}
-
-	return group, nil
+	return es.change(ctx, func(ctx context.Context) (groups.Group, error) {
+		return es.svc.UpdateGroup(ctx, session, group)
+	}, func(group groups.Group) events.Event {
+		return updateGroupEvent{
+			group,
+		}
+	})
 }
 
 func (es eventStore) ViewGroup(ctx context.Context, session authn.Session, id string) (groups.Group, error) {
@@ -132,12 +148,9 @@ func (es eventStore) ListMembers(ctx context.Context, session authn.Session, gro
 }
 
 func (es eventStore) EnableGroup(ctx context.Context, session authn.Session, id string) (groups.Group, error) {
-	group, err := es.svc.EnableGroup(ctx, session, id)
-	if err != nil {
-		return group, err
-	}
-
-	return es.changeStatus(ctx, group)
+	return es.change(ctx, func(ctx context.Context) (groups.Group, error) {
+		return es.svc.EnableGroup(ctx, session, id)
+	}, changeStatusEvent)
 }
 
 func (es eventStore) Assign(ctx context.Context, session authn.Session, groupID, relation, memberKind string, memberIDs ...string) error {
@@ -152,11 +165,7 @@ func (es eventStore) Assign(ctx context.Context, session authn.Session, groupID,
 		memberIDs:  memberIDs,
 	}
 
-	if err := es.Publish(ctx, event); err != nil {
-		return err
-	}
-
-	return nil
+	return es.changes.Publish(ctx, event)
 }
 
 func (es eventStore) Unassign(ctx context.Context, session authn.Session, groupID, relation, memberKind string, memberIDs ...string) error {
@@ -171,42 +180,42 @@ func (es eventStore) Unassign(ctx context.Context, session authn.Session, groupI
 		memberIDs:  memberIDs,
 	}
 
-	if err := es.Publish(ctx, event); err != nil {
-		return err
-	}
-	return es.svc.Unassign(ctx, session, groupID, relation, memberKind, memberIDs...)
+	return es.changes.Publish(ctx, event)
 }
 
 func (es eventStore) DisableGroup(ctx context.Context, session authn.Session, id string) (groups.Group, error) {
-	group, err := es.svc.DisableGroup(ctx, session, id)
-	if err != nil {
-		return group, err
-	}
-
-	return es.changeStatus(ctx, group)
+	return es.change(ctx, func(ctx context.Context) (groups.Group, error) {
+		return es.svc.DisableGroup(ctx, session, id)
+	}, changeStatusEvent)
 }
 
-func (es eventStore) changeStatus(ctx context.Context, group groups.Group) (groups.Group, error) {
-	event := changeStatusGroupEvent{
+func changeStatusEvent(group groups.Group) events.Event {
+	return changeStatusGroupEvent{
 		id:        group.ID,
 		updatedAt: group.UpdatedAt,
 		updatedBy: group.UpdatedBy,
 		status:    group.Status.String(),
 	}
-
-	if err := es.Publish(ctx, event); err != nil {
-		return group, err
-	}
-
-	return group, nil
 }
 
 func (es eventStore) DeleteGroup(ctx context.Context, session authn.Session, id string) error {
 	if err := es.svc.DeleteGroup(ctx, session, id); err != nil {
 		return err
 	}
-	if err := es.Publish(ctx, deleteGroupEvent{id}); err != nil {
-		return err
+	if es.persisted {
+		return nil
 	}
-	return nil
+
+	return es.changes.Publish(ctx, deleteGroupEvent{id})
+}
+
+// change runs the group change and publishes its event, unless the event
+// is published by the repository.
+func (es eventStore) change(ctx context.Context, fn func(ctx context.Context) (groups.Group, error), event func(groups.Group) events.Event) (groups.Group, error) {
+	group, err := fn(ctx)
+	if err != nil || es.persisted {
+		return group, err
+	}
+
+	return group, es.changes.Publish(ctx, event(group))
 }
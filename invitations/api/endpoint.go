@@ -152,3 +152,96 @@ func deleteInvitationEndpoint(svc invitations.Service) endpoint.Endpoint {
 		return deleteInvitationRes{}, nil
 	}
 }
+
+func sendEmailInvitationsEndpoint(svc invitations.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(sendEmailInvitationsReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+		session.DomainID = req.DomainID
+
+		invs, err := svc.SendEmailInvitations(ctx, session, req.DomainID, req.Relation, req.Emails, req.Resend)
+		if err != nil {
+			return nil, err
+		}
+		if invs == nil {
+			invs = []invitations.EmailInvitation{}
+		}
+
+		return sendEmailInvitationsRes{
+			Invitations: invs,
+		}, nil
+	}
+}
+
+func listEmailInvitationsEndpoint(svc invitations.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listEmailInvitationsReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+		session.DomainID = req.DomainID
+
+		page, err := svc.ListEmailInvitations(ctx, session, req.Page)
+		if err != nil {
+			return nil, err
+		}
+
+		return listEmailInvitationsRes{
+			page,
+		}, nil
+	}
+}
+
+func deleteEmailInvitationEndpoint(svc invitations.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(emailInvitationReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+		session.DomainID = req.domainID
+
+		if err := svc.DeleteEmailInvitation(ctx, session, req.email, req.domainID); err != nil {
+			return nil, err
+		}
+
+		return deleteInvitationRes{}, nil
+	}
+}
+
+func acceptEmailInvitationEndpoint(svc invitations.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(acceptEmailInvitationReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		invitation, err := svc.AcceptEmailInvitation(ctx, session, req.Token)
+		if err != nil {
+			return nil, err
+		}
+
+		return acceptEmailInvitationRes{Invitation: invitation}, nil
+	}
+}
@@ -670,3 +670,346 @@ func TestRejectInvitation(t *testing.T) {
 		})
 	}
 }
+
+func TestSendEmailInvitations(t *testing.T) {
+	is, svc, authn := newIvitationsServer()
+	email := "invitee@example.com"
+	session := mgauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: domainID + "_" + validID}
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		authnRes    mgauthn.Session
+		authnErr    error
+		svcRes      []invitations.EmailInvitation
+		svcErr      error
+	}{
+		{
+			desc:        "valid request",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"domain_id": "%s", "relation": "%s", "emails": ["%s"]}`, domainID, "contributor", email),
+			authnRes:    session,
+			status:      http.StatusCreated,
+			contentType: validContenType,
+			svcRes:      []invitations.EmailInvitation{{Email: email, DomainID: domainID, Relation: "contributor", SendCount: 1}},
+		},
+		{
+			desc:        "invalid token",
+			token:       "",
+			data:        fmt.Sprintf(`{"domain_id": "%s", "relation": "%s", "emails": ["%s"]}`, domainID, "contributor", email),
+			status:      http.StatusUnauthorized,
+			contentType: validContenType,
+		},
+		{
+			desc:        "invalid email",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"domain_id": "%s", "relation": "%s", "emails": ["%s"]}`, domainID, "contributor", "invalid"),
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+			contentType: validContenType,
+		},
+		{
+			desc:        "empty emails",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"domain_id": "%s", "relation": "%s", "emails": []}`, domainID, "contributor"),
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+			contentType: validContenType,
+		},
+		{
+			desc:        "invalid content type",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"domain_id": "%s", "relation": "%s", "emails": ["%s"]}`, domainID, "contributor", email),
+			authnRes:    session,
+			status:      http.StatusUnsupportedMediaType,
+			contentType: "text/plain",
+		},
+		{
+			desc:        "invalid data",
+			token:       validToken,
+			data:        `data`,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+			contentType: validContenType,
+		},
+		{
+			desc:        "with resend limit reached",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"domain_id": "%s", "relation": "%s", "emails": ["%s"], "resend": true}`, domainID, "contributor", email),
+			authnRes:    session,
+			status:      http.StatusConflict,
+			contentType: validContenType,
+			svcErr:      svcerr.ErrInvitationResendLimit,
+		},
+		{
+			desc:        "with service error",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"domain_id": "%s", "relation": "%s", "emails": ["%s"]}`, domainID, "contributor", email),
+			authnRes:    session,
+			status:      http.StatusForbidden,
+			contentType: validContenType,
+			svcErr:      svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("SendEmailInvitations", mock.Anything, tc.authnRes, domainID, "contributor", []string{email}, mock.Anything).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client:      is.Client(),
+				method:      http.MethodPost,
+				url:         is.URL + "/invitations/email",
+				token:       tc.token,
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+
+			res, err := req.make()
+			assert.Nil(t, err, tc.desc)
+			assert.Equal(t, tc.status, res.StatusCode, tc.desc)
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestListEmailInvitations(t *testing.T) {
+	is, svc, authn := newIvitationsServer()
+	session := mgauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: domainID + "_" + validID}
+
+	cases := []struct {
+		desc     string
+		token    string
+		query    string
+		status   int
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+	}{
+		{
+			desc:     "valid request",
+			token:    validToken,
+			query:    "domain_id=" + domainID,
+			authnRes: session,
+			status:   http.StatusOK,
+		},
+		{
+			desc:   "invalid token",
+			token:  "",
+			query:  "domain_id=" + domainID,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:     "without domain",
+			token:    validToken,
+			authnRes: session,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "with invalid limit",
+			token:    validToken,
+			query:    "domain_id=" + domainID + "&limit=1000",
+			authnRes: session,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "with service error",
+			token:    validToken,
+			query:    "domain_id=" + domainID,
+			authnRes: session,
+			status:   http.StatusForbidden,
+			svcErr:   svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("ListEmailInvitations", mock.Anything, tc.authnRes, mock.Anything).Return(invitations.EmailInvitationPage{}, tc.svcErr)
+			req := testRequest{
+				client: is.Client(),
+				method: http.MethodGet,
+				url:    is.URL + "/invitations/email?" + tc.query,
+				token:  tc.token,
+			}
+
+			res, err := req.make()
+			assert.Nil(t, err, tc.desc)
+			assert.Equal(t, tc.status, res.StatusCode, tc.desc)
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestDeleteEmailInvitation(t *testing.T) {
+	is, svc, authn := newIvitationsServer()
+	email := "invitee@example.com"
+	session := mgauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: domainID + "_" + validID}
+
+	cases := []struct {
+		desc     string
+		token    string
+		email    string
+		domainID string
+		status   int
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+	}{
+		{
+			desc:     "valid request",
+			token:    validToken,
+			email:    email,
+			domainID: domainID,
+			authnRes: session,
+			status:   http.StatusNoContent,
+		},
+		{
+			desc:     "invalid token",
+			token:    "",
+			email:    email,
+			domainID: domainID,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "with non-existing invitation",
+			token:    validToken,
+			email:    email,
+			domainID: domainID,
+			authnRes: session,
+			status:   http.StatusNotFound,
+			svcErr:   svcerr.ErrNotFound,
+		},
+		{
+			desc:     "with service error",
+			token:    validToken,
+			email:    email,
+			domainID: domainID,
+			authnRes: session,
+			status:   http.StatusForbidden,
+			svcErr:   svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("DeleteEmailInvitation", mock.Anything, tc.authnRes, tc.email, tc.domainID).Return(tc.svcErr)
+			req := testRequest{
+				client: is.Client(),
+				method: http.MethodDelete,
+				url:    is.URL + "/invitations/email/" + tc.email + "/" + tc.domainID,
+				token:  tc.token,
+			}
+
+			res, err := req.make()
+			assert.Nil(t, err, tc.desc)
+			assert.Equal(t, tc.status, res.StatusCode, tc.desc)
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestAcceptEmailInvitation(t *testing.T) {
+	is, svc, authn := newIvitationsServer()
+	invitationToken := "invitation-token"
+	session := mgauthn.Session{UserID: validID}
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		authnRes    mgauthn.Session
+		authnErr    error
+		svcRes      invitations.Invitation
+		svcErr      error
+	}{
+		{
+			desc:        "valid request",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"token": "%s"}`, invitationToken),
+			authnRes:    session,
+			status:      http.StatusOK,
+			contentType: validContenType,
+			svcRes:      invitations.Invitation{UserID: validID, DomainID: domainID, Relation: "contributor"},
+		},
+		{
+			desc:        "invalid token",
+			token:       "",
+			data:        fmt.Sprintf(`{"token": "%s"}`, invitationToken),
+			status:      http.StatusUnauthorized,
+			contentType: validContenType,
+		},
+		{
+			desc:        "without invitation token",
+			token:       validToken,
+			data:        `{}`,
+			authnRes:    session,
+			status:      http.StatusUnauthorized,
+			contentType: validContenType,
+		},
+		{
+			desc:        "invalid content type",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"token": "%s"}`, invitationToken),
+			authnRes:    session,
+			status:      http.StatusUnsupportedMediaType,
+			contentType: "text/plain",
+		},
+		{
+			desc:        "invalid data",
+			token:       validToken,
+			data:        `data`,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+			contentType: validContenType,
+		},
+		{
+			desc:        "with invalid invitation token",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"token": "%s"}`, invitationToken),
+			authnRes:    session,
+			status:      http.StatusNotFound,
+			contentType: validContenType,
+			svcErr:      svcerr.ErrNotFound,
+		},
+		{
+			desc:        "with expired invitation",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"token": "%s"}`, invitationToken),
+			authnRes:    session,
+			status:      http.StatusConflict,
+			contentType: validContenType,
+			svcErr:      svcerr.ErrInvitationExpired,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("AcceptEmailInvitation", mock.Anything, tc.authnRes, invitationToken).Return(tc.svcRes, tc.svcErr)
+			req := testRequest{
+				client:      is.Client(),
+				method:      http.MethodPost,
+				url:         is.URL + "/invitations/email/accept",
+				token:       tc.token,
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+
+			res, err := req.make()
+			assert.Nil(t, err, tc.desc)
+			assert.Equal(t, tc.status, res.StatusCode, tc.desc)
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
@@ -4,6 +4,8 @@
 package api
 
 import (
+	"net/mail"
+
 	"github.com/absmach/magistrala/invitations"
 	"github.com/absmach/magistrala/pkg/apiutil"
 )
@@ -70,3 +72,75 @@ func (req *invitationReq) validate() error {
 
 	return nil
 }
+
+type sendEmailInvitationsReq struct {
+	DomainID string   `json:"domain_id,omitempty"`
+	Relation string   `json:"relation,omitempty"`
+	Emails   []string `json:"emails,omitempty"`
+	Resend   bool     `json:"resend,omitempty"`
+}
+
+func (req *sendEmailInvitationsReq) validate() error {
+	if req.DomainID == "" {
+		return apiutil.ErrMissingDomainID
+	}
+	if err := invitations.CheckRelation(req.Relation); err != nil {
+		return err
+	}
+	if len(req.Emails) == 0 {
+		return apiutil.ErrEmptyList
+	}
+	if len(req.Emails) > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+	for _, email := range req.Emails {
+		if _, err := mail.ParseAddress(email); err != nil {
+			return apiutil.ErrInvalidEmail
+		}
+	}
+
+	return nil
+}
+
+type listEmailInvitationsReq struct {
+	invitations.Page
+}
+
+func (req *listEmailInvitationsReq) validate() error {
+	if req.Page.DomainID == "" {
+		return apiutil.ErrMissingDomainID
+	}
+	if req.Page.Limit > maxLimitSize || req.Page.Limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type emailInvitationReq struct {
+	email    string
+	domainID string
+}
+
+func (req *emailInvitationReq) validate() error {
+	if req.email == "" {
+		return apiutil.ErrMissingEmail
+	}
+	if req.domainID == "" {
+		return apiutil.ErrMissingDomainID
+	}
+
+	return nil
+}
+
+type acceptEmailInvitationReq struct {
+	Token string `json:"token,omitempty"`
+}
+
+func (req *acceptEmailInvitationReq) validate() error {
+	if req.Token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
@@ -180,3 +180,164 @@ func TestInvitationReqValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestSendEmailInvitationsReqValidation(t *testing.T) {
+	emails := make([]string, maxLimitSize+1)
+	for i := range emails {
+		emails[i] = fmt.Sprintf("invitee%d@example.com", i)
+	}
+
+	cases := []struct {
+		desc string
+		req  sendEmailInvitationsReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: sendEmailInvitationsReq{
+				DomainID: valid,
+				Relation: policies.ContributorRelation,
+				Emails:   []string{"invitee@example.com"},
+			},
+			err: nil,
+		},
+		{
+			desc: "empty domain",
+			req: sendEmailInvitationsReq{
+				Relation: policies.ContributorRelation,
+				Emails:   []string{"invitee@example.com"},
+			},
+			err: apiutil.ErrMissingDomainID,
+		},
+		{
+			desc: "invalid relation",
+			req: sendEmailInvitationsReq{
+				DomainID: valid,
+				Relation: "invalid",
+				Emails:   []string{"invitee@example.com"},
+			},
+			err: apiutil.ErrInvalidRelation,
+		},
+		{
+			desc: "empty emails",
+			req: sendEmailInvitationsReq{
+				DomainID: valid,
+				Relation: policies.ContributorRelation,
+			},
+			err: apiutil.ErrEmptyList,
+		},
+		{
+			desc: "too many emails",
+			req: sendEmailInvitationsReq{
+				DomainID: valid,
+				Relation: policies.ContributorRelation,
+				Emails:   emails,
+			},
+			err: apiutil.ErrLimitSize,
+		},
+		{
+			desc: "invalid email",
+			req: sendEmailInvitationsReq{
+				DomainID: valid,
+				Relation: policies.ContributorRelation,
+				Emails:   []string{"invitee@example.com", "invalid"},
+			},
+			err: apiutil.ErrInvalidEmail,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.req.validate()
+			assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestListEmailInvitationsReqValidation(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  listEmailInvitationsReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req:  listEmailInvitationsReq{Page: invitations.Page{DomainID: valid, Limit: 1}},
+			err:  nil,
+		},
+		{
+			desc: "empty domain",
+			req:  listEmailInvitationsReq{Page: invitations.Page{Limit: 1}},
+			err:  apiutil.ErrMissingDomainID,
+		},
+		{
+			desc: "invalid limit",
+			req:  listEmailInvitationsReq{Page: invitations.Page{DomainID: valid, Limit: maxLimitSize + 1}},
+			err:  apiutil.ErrLimitSize,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.req.validate()
+			assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestEmailInvitationReqValidation(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  emailInvitationReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req:  emailInvitationReq{email: "invitee@example.com", domainID: valid},
+			err:  nil,
+		},
+		{
+			desc: "empty email",
+			req:  emailInvitationReq{domainID: valid},
+			err:  apiutil.ErrMissingEmail,
+		},
+		{
+			desc: "empty domain",
+			req:  emailInvitationReq{email: "invitee@example.com"},
+			err:  apiutil.ErrMissingDomainID,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.req.validate()
+			assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestAcceptEmailInvitationReqValidation(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  acceptEmailInvitationReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req:  acceptEmailInvitationReq{Token: valid},
+			err:  nil,
+		},
+		{
+			desc: "empty token",
+			req:  acceptEmailInvitationReq{},
+			err:  apiutil.ErrBearerToken,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.req.validate()
+			assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
@@ -17,6 +17,9 @@ var (
 	_ magistrala.Response = (*acceptInvitationRes)(nil)
 	_ magistrala.Response = (*rejectInvitationRes)(nil)
 	_ magistrala.Response = (*deleteInvitationRes)(nil)
+	_ magistrala.Response = (*sendEmailInvitationsRes)(nil)
+	_ magistrala.Response = (*listEmailInvitationsRes)(nil)
+	_ magistrala.Response = (*acceptEmailInvitationRes)(nil)
 )
 
 type sendInvitationRes struct {
@@ -108,3 +111,51 @@ func (res rejectInvitationRes) Headers() map[string]string {
 func (res rejectInvitationRes) Empty() bool {
 	return true
 }
+
+type sendEmailInvitationsRes struct {
+	Invitations []invitations.EmailInvitation `json:"invitations"`
+}
+
+func (res sendEmailInvitationsRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res sendEmailInvitationsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res sendEmailInvitationsRes) Empty() bool {
+	return false
+}
+
+type listEmailInvitationsRes struct {
+	invitations.EmailInvitationPage `json:",inline"`
+}
+
+func (res listEmailInvitationsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listEmailInvitationsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listEmailInvitationsRes) Empty() bool {
+	return false
+}
+
+type acceptEmailInvitationRes struct {
+	invitations.Invitation `json:",inline"`
+}
+
+func (res acceptEmailInvitationRes) Code() int {
+	return http.StatusOK
+}
+
+func (res acceptEmailInvitationRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res acceptEmailInvitationRes) Empty() bool {
+	return false
+}
@@ -28,6 +28,7 @@ const (
 	invitedByKey = "invited_by"
 	relationKey  = "relation"
 	stateKey     = "state"
+	emailKey     = "email"
 )
 
 func MakeHandler(svc invitations.Service, logger *slog.Logger, authn mgauthn.Authentication, instanceID string) http.Handler {
@@ -53,6 +54,32 @@ func MakeHandler(svc invitations.Service, logger *slog.Logger, authn mgauthn.Aut
 				api.EncodeResponse,
 				opts...,
 			), "list_invitations").ServeHTTP)
+			r.Route("/email", func(r chi.Router) {
+				r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+					sendEmailInvitationsEndpoint(svc),
+					decodeSendEmailInvitationsReq,
+					api.EncodeResponse,
+					opts...,
+				), "send_email_invitations").ServeHTTP)
+				r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+					listEmailInvitationsEndpoint(svc),
+					decodeListEmailInvitationsReq,
+					api.EncodeResponse,
+					opts...,
+				), "list_email_invitations").ServeHTTP)
+				r.Delete("/{email}/{domain_id}", otelhttp.NewHandler(kithttp.NewServer(
+					deleteEmailInvitationEndpoint(svc),
+					decodeEmailInvitationReq,
+					api.EncodeResponse,
+					opts...,
+				), "delete_email_invitation").ServeHTTP)
+				r.Post("/accept", otelhttp.NewHandler(kithttp.NewServer(
+					acceptEmailInvitationEndpoint(svc),
+					decodeAcceptEmailInvitationReq,
+					api.EncodeResponse,
+					opts...,
+				), "accept_email_invitation").ServeHTTP)
+			})
 			r.Route("/{user_id}/{domain_id}", func(r chi.Router) {
 				r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
 					viewInvitationEndpoint(svc),
@@ -170,3 +197,67 @@ func decodeInvitationReq(_ context.Context, r *http.Request) (interface{}, error
 
 	return req, nil
 }
+
+func decodeSendEmailInvitationsReq(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	var req sendEmailInvitationsReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeListEmailInvitationsReq(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	domainID, err := apiutil.ReadStringQuery(r, domainIDKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	email, err := apiutil.ReadStringQuery(r, emailKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	req := listEmailInvitationsReq{
+		Page: invitations.Page{
+			Offset:   offset,
+			Limit:    limit,
+			DomainID: domainID,
+			Email:    email,
+		},
+	}
+
+	return req, nil
+}
+
+func decodeEmailInvitationReq(_ context.Context, r *http.Request) (interface{}, error) {
+	req := emailInvitationReq{
+		email:    chi.URLParam(r, "email"),
+		domainID: chi.URLParam(r, "domain_id"),
+	}
+
+	return req, nil
+}
+
+func decodeAcceptEmailInvitationReq(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	var req acceptEmailInvitationReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
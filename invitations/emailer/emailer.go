@@ -0,0 +1,36 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package emailer
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/absmach/magistrala/internal/email"
+	"github.com/absmach/magistrala/invitations"
+)
+
+var _ invitations.Emailer = (*emailer)(nil)
+
+type emailer struct {
+	signupURL string
+	agent     *email.Agent
+}
+
+// New creates new emailer utility. The signup page accepts the invitation
+// with the token from the link once the person registers or logs in.
+func New(signupURL string, c *email.Config) (invitations.Emailer, error) {
+	e, err := email.New(c)
+	return &emailer{signupURL: signupURL, agent: e}, err
+}
+
+func (e *emailer) SendInvitation(to, domainID, token string) error {
+	query := url.Values{}
+	query.Set("email", to)
+	query.Set("domain_id", domainID)
+	query.Set("token", token)
+	link := fmt.Sprintf("%s?%s", e.signupURL, query.Encode())
+
+	return e.agent.Send([]string{to}, "", "Invitation to join Magistrala", "", to, link, "")
+}
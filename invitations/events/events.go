@@ -0,0 +1,125 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"github.com/absmach/magistrala/invitations"
+	"github.com/absmach/magistrala/pkg/events"
+)
+
+const (
+	invitationPrefix = "invitation."
+	invitationSend   = invitationPrefix + "send"
+	invitationAccept = invitationPrefix + "accept"
+	invitationReject = invitationPrefix + "reject"
+	invitationDelete = invitationPrefix + "delete"
+
+	emailInvitationPrefix = invitationPrefix + "email."
+	emailInvitationSend   = emailInvitationPrefix + "send"
+	emailInvitationDelete = emailInvitationPrefix + "delete"
+	emailInvitationAccept = emailInvitationPrefix + "accept"
+)
+
+var (
+	_ events.Event = (*sendInvitationEvent)(nil)
+	_ events.Event = (*respondInvitationEvent)(nil)
+	_ events.Event = (*deleteInvitationEvent)(nil)
+	_ events.Event = (*sendEmailInvitationEvent)(nil)
+	_ events.Event = (*deleteEmailInvitationEvent)(nil)
+	_ events.Event = (*acceptEmailInvitationEvent)(nil)
+)
+
+type sendInvitationEvent struct {
+	invitations.Invitation
+}
+
+func (sie sendInvitationEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation":  invitationSend,
+		"user_id":    sie.UserID,
+		"domain_id":  sie.DomainID,
+		"invited_by": sie.InvitedBy,
+		"resend":     sie.Resend,
+	}
+
+	if sie.Relation != "" {
+		val["relation"] = sie.Relation
+	}
+
+	return val, nil
+}
+
+type respondInvitationEvent struct {
+	operation string
+	userID    string
+	domainID  string
+}
+
+func (rie respondInvitationEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": rie.operation,
+		"user_id":   rie.userID,
+		"domain_id": rie.domainID,
+	}, nil
+}
+
+type deleteInvitationEvent struct {
+	userID    string
+	domainID  string
+	deletedBy string
+}
+
+func (die deleteInvitationEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":  invitationDelete,
+		"user_id":    die.userID,
+		"domain_id":  die.domainID,
+		"deleted_by": die.deletedBy,
+	}, nil
+}
+
+type sendEmailInvitationEvent struct {
+	invitations.EmailInvitation
+}
+
+func (seie sendEmailInvitationEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":  emailInvitationSend,
+		"email":      seie.Email,
+		"domain_id":  seie.DomainID,
+		"invited_by": seie.InvitedBy,
+		"relation":   seie.Relation,
+		"send_count": seie.SendCount,
+		"expires_at": seie.ExpiresAt,
+	}, nil
+}
+
+type deleteEmailInvitationEvent struct {
+	email     string
+	domainID  string
+	deletedBy string
+}
+
+func (deie deleteEmailInvitationEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":  emailInvitationDelete,
+		"email":      deie.email,
+		"domain_id":  deie.domainID,
+		"deleted_by": deie.deletedBy,
+	}, nil
+}
+
+type acceptEmailInvitationEvent struct {
+	invitations.Invitation
+}
+
+func (aeie acceptEmailInvitationEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":  emailInvitationAccept,
+		"user_id":    aeie.UserID,
+		"domain_id":  aeie.DomainID,
+		"invited_by": aeie.InvitedBy,
+		"relation":   aeie.Relation,
+	}, nil
+}
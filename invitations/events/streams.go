@@ -0,0 +1,163 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/invitations"
+	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/events/store"
+)
+
+const streamID = "magistrala.invitations"
+
+var _ invitations.Service = (*eventStore)(nil)
+
+type eventStore struct {
+	events.Publisher
+	svc invitations.Service
+}
+
+// NewEventStoreMiddleware returns wrapper around invitations service that
+// sends events to event store.
+func NewEventStoreMiddleware(ctx context.Context, svc invitations.Service, url string) (invitations.Service, error) {
+	publisher, err := store.NewPublisher(ctx, url, streamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &eventStore{
+		svc:       svc,
+		Publisher: publisher,
+	}, nil
+}
+
+func (es *eventStore) SendInvitation(ctx context.Context, session authn.Session, invitation invitations.Invitation) error {
+	if err := es.svc.SendInvitation(ctx, session, invitation); err != nil {
+		return err
+	}
+
+	// The invitation is sent by the session user.
+	invitation.InvitedBy = session.UserID
+	event := sendInvitationEvent{
+		invitation,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (es *eventStore) ViewInvitation(ctx context.Context, session authn.Session, userID, domainID string) (invitations.Invitation, error) {
+	return es.svc.ViewInvitation(ctx, session, userID, domainID)
+}
+
+func (es *eventStore) ListInvitations(ctx context.Context, session authn.Session, page invitations.Page) (invitations.InvitationPage, error) {
+	return es.svc.ListInvitations(ctx, session, page)
+}
+
+func (es *eventStore) AcceptInvitation(ctx context.Context, session authn.Session, domainID string) error {
+	if err := es.svc.AcceptInvitation(ctx, session, domainID); err != nil {
+		return err
+	}
+
+	event := respondInvitationEvent{
+		operation: invitationAccept,
+		userID:    session.UserID,
+		domainID:  domainID,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (es *eventStore) DeleteInvitation(ctx context.Context, session authn.Session, userID, domainID string) error {
+	if err := es.svc.DeleteInvitation(ctx, session, userID, domainID); err != nil {
+		return err
+	}
+
+	event := deleteInvitationEvent{
+		userID:    userID,
+		domainID:  domainID,
+		deletedBy: session.UserID,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (es *eventStore) RejectInvitation(ctx context.Context, session authn.Session, domainID string) error {
+	if err := es.svc.RejectInvitation(ctx, session, domainID); err != nil {
+		return err
+	}
+
+	event := respondInvitationEvent{
+		operation: invitationReject,
+		userID:    session.UserID,
+		domainID:  domainID,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (es *eventStore) SendEmailInvitations(ctx context.Context, session authn.Session, domainID, relation string, emails []string, resend bool) ([]invitations.EmailInvitation, error) {
+	invs, err := es.svc.SendEmailInvitations(ctx, session, domainID, relation, emails, resend)
+	// Invitations sent before the failure are published as well.
+	for _, inv := range invs {
+		if perr := es.Publish(ctx, sendEmailInvitationEvent{inv}); perr != nil {
+			return invs, perr
+		}
+	}
+
+	return invs, err
+}
+
+func (es *eventStore) ListEmailInvitations(ctx context.Context, session authn.Session, page invitations.Page) (invitations.EmailInvitationPage, error) {
+	return es.svc.ListEmailInvitations(ctx, session, page)
+}
+
+func (es *eventStore) DeleteEmailInvitation(ctx context.Context, session authn.Session, email, domainID string) error {
+	if err := es.svc.DeleteEmailInvitation(ctx, session, email, domainID); err != nil {
+		return err
+	}
+
+	event := deleteEmailInvitationEvent{
+		email:     email,
+		domainID:  domainID,
+		deletedBy: session.UserID,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (es *eventStore) AcceptEmailInvitation(ctx context.Context, session authn.Session, token string) (invitations.Invitation, error) {
+	inv, err := es.svc.AcceptEmailInvitation(ctx, session, token)
+	if err != nil {
+		return inv, err
+	}
+
+	if err := es.Publish(ctx, acceptEmailInvitationEvent{inv}); err != nil {
+		return inv, err
+	}
+
+	return inv, nil
+}
@@ -24,9 +24,26 @@ type Invitation struct {
 	UpdatedAt   time.Time `json:"updated_at,omitempty"`
 	ConfirmedAt time.Time `json:"confirmed_at,omitempty"`
 	RejectedAt  time.Time `json:"rejected_at,omitempty"`
+	ExpiresAt   time.Time `json:"expires_at,omitempty"`
 	Resend      bool      `json:"resend,omitempty"`
 }
 
+// EmailInvitation is an invitation to join a domain sent to an email. The email
+// contains the single-use token and the invitation is accepted by the user who
+// presents the token, whether they register after receiving the email or
+// already have an account. Only the token hash is stored.
+type EmailInvitation struct {
+	InvitedBy string    `json:"invited_by"`
+	Email     string    `json:"email"`
+	DomainID  string    `json:"domain_id"`
+	Relation  string    `json:"relation"`
+	TokenHash string    `json:"-"`
+	SendCount uint64    `json:"send_count"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
 // Page is a page of invitations.
 type Page struct {
 	Offset            uint64 `json:"offset" db:"offset"`
@@ -37,6 +54,7 @@ type Page struct {
 	Relation          string `json:"relation,omitempty" db:"relation,omitempty"`
 	InvitedByOrUserID string `db:"invited_by_or_user_id,omitempty"`
 	State             State  `json:"state,omitempty"`
+	Email             string `json:"email,omitempty" db:"email,omitempty"`
 }
 
 // InvitationPage is a page of invitations.
@@ -62,6 +80,29 @@ func (page InvitationPage) MarshalJSON() ([]byte, error) {
 	return json.Marshal(a)
 }
 
+// EmailInvitationPage is a page of email invitations.
+type EmailInvitationPage struct {
+	Total       uint64            `json:"total"`
+	Offset      uint64            `json:"offset"`
+	Limit       uint64            `json:"limit"`
+	Invitations []EmailInvitation `json:"invitations"`
+}
+
+func (page EmailInvitationPage) MarshalJSON() ([]byte, error) {
+	type Alias EmailInvitationPage
+	a := struct {
+		Alias
+	}{
+		Alias: Alias(page),
+	}
+
+	if a.Invitations == nil {
+		a.Invitations = make([]EmailInvitation, 0)
+	}
+
+	return json.Marshal(a)
+}
+
 // Service is an interface that defines methods for managing invitations.
 //
 //go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
@@ -100,6 +141,34 @@ type Service interface {
 	// People who can reject invitations are:
 	// - the invited user: they can reject their own invitations
 	RejectInvitation(ctx context.Context, session authn.Session, domainID string) (err error)
+
+	// SendEmailInvitations invites people to the domain by email. Each email
+	// receives the link with the single-use invitation token. Emails which are
+	// already invited are skipped unless resend is set. Resending issues the
+	// new token, renews the invitation expiry and it's limited.
+	// Only domain administrators and platform administrators can send invitations.
+	SendEmailInvitations(ctx context.Context, session authn.Session, domainID, relation string, emails []string, resend bool) (invitations []EmailInvitation, err error)
+
+	// ListEmailInvitations returns a list of the pending email invitations of the domain.
+	// Only domain administrators and platform administrators can list email invitations.
+	ListEmailInvitations(ctx context.Context, session authn.Session, page Page) (invitations EmailInvitationPage, err error)
+
+	// DeleteEmailInvitation deletes the pending email invitation.
+	// Only domain administrators and platform administrators can delete email invitations.
+	DeleteEmailInvitation(ctx context.Context, session authn.Session, email, domainID string) (err error)
+
+	// AcceptEmailInvitation accepts the email invitation with the token by
+	// adding the current user to the domain. The token can be used only once.
+	AcceptEmailInvitation(ctx context.Context, session authn.Session, token string) (invitation Invitation, err error)
+}
+
+// Emailer sends the invitation emails.
+//
+//go:generate mockery --name Emailer --output=./mocks --filename emailer.go --quiet --note "Copyright (c) Abstract Machines"
+type Emailer interface {
+	// SendInvitation sends the email with the link to accept the invitation
+	// to join the domain using the token.
+	SendInvitation(to, domainID, token string) error
 }
 
 //go:generate mockery --name Repository --output=./mocks --filename repository.go --quiet --note "Copyright (c) Abstract Machines"
@@ -124,6 +193,26 @@ type Repository interface {
 
 	// Delete deletes an invitation.
 	Delete(ctx context.Context, userID, domainID string) (err error)
+
+	// CreateEmailInvitation creates an email invitation.
+	CreateEmailInvitation(ctx context.Context, invitation EmailInvitation) (err error)
+
+	// RetrieveEmailInvitation returns an email invitation.
+	RetrieveEmailInvitation(ctx context.Context, email, domainID string) (EmailInvitation, error)
+
+	// RetrieveAllEmailInvitations returns a list of email invitations filtered
+	// by the page domain and email.
+	RetrieveAllEmailInvitations(ctx context.Context, page Page) (invitations EmailInvitationPage, err error)
+
+	// UpdateEmailInvitation updates the email invitation relation, send count and expiry.
+	UpdateEmailInvitation(ctx context.Context, invitation EmailInvitation) (err error)
+
+	// ClaimEmailInvitation deletes the email invitation with the token hash
+	// and returns it, so the token can be used only once.
+	ClaimEmailInvitation(ctx context.Context, tokenHash string) (EmailInvitation, error)
+
+	// DeleteEmailInvitation deletes an email invitation.
+	DeleteEmailInvitation(ctx context.Context, email, domainID string) (err error)
 }
 
 // CheckRelation checks if the given relation is valid.
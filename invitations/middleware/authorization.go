@@ -92,6 +92,38 @@ func (am *authorizationMiddleware) DeleteInvitation(ctx context.Context, session
 	return am.svc.DeleteInvitation(ctx, session, userID, domainID)
 }
 
+func (am *authorizationMiddleware) SendEmailInvitations(ctx context.Context, session authn.Session, domainID, relation string, emails []string, resend bool) (invs []invitations.EmailInvitation, err error) {
+	session.DomainUserID = auth.EncodeDomainUserID(session.DomainID, session.UserID)
+	if err := am.checkAdmin(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return am.svc.SendEmailInvitations(ctx, session, domainID, relation, emails, resend)
+}
+
+func (am *authorizationMiddleware) ListEmailInvitations(ctx context.Context, session authn.Session, page invitations.Page) (invs invitations.EmailInvitationPage, err error) {
+	session.DomainUserID = auth.EncodeDomainUserID(session.DomainID, session.UserID)
+	if err := am.checkAdmin(ctx, session); err != nil {
+		return invitations.EmailInvitationPage{}, err
+	}
+
+	return am.svc.ListEmailInvitations(ctx, session, page)
+}
+
+func (am *authorizationMiddleware) DeleteEmailInvitation(ctx context.Context, session authn.Session, email, domainID string) (err error) {
+	session.DomainUserID = auth.EncodeDomainUserID(session.DomainID, session.UserID)
+	if err := am.checkAdmin(ctx, session); err != nil {
+		return err
+	}
+
+	return am.svc.DeleteEmailInvitation(ctx, session, email, domainID)
+}
+
+// AcceptEmailInvitation is authorized by the invitation token.
+func (am *authorizationMiddleware) AcceptEmailInvitation(ctx context.Context, session authn.Session, token string) (inv invitations.Invitation, err error) {
+	return am.svc.AcceptEmailInvitation(ctx, session, token)
+}
+
 // checkAdmin checks if the given user is a domain or platform administrator.
 func (am *authorizationMiddleware) checkAdmin(ctx context.Context, session authn.Session) error {
 	if err := am.authorize(ctx, session.DomainUserID, policies.AdminPermission, policies.DomainType, session.DomainID); err == nil {
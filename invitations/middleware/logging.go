@@ -125,3 +125,76 @@ func (lm *logging) DeleteInvitation(ctx context.Context, session authn.Session,
 	}(time.Now())
 	return lm.svc.DeleteInvitation(ctx, session, userID, domainID)
 }
+
+func (lm *logging) SendEmailInvitations(ctx context.Context, session authn.Session, domainID, relation string, emails []string, resend bool) (invs []invitations.EmailInvitation, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.String("relation", relation),
+			slog.Int("emails", len(emails)),
+			slog.Int("sent", len(invs)),
+			slog.Bool("resend", resend),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Send email invitations failed", args...)
+			return
+		}
+		lm.logger.Info("Send email invitations completed successfully", args...)
+	}(time.Now())
+	return lm.svc.SendEmailInvitations(ctx, session, domainID, relation, emails, resend)
+}
+
+func (lm *logging) ListEmailInvitations(ctx context.Context, session authn.Session, page invitations.Page) (invs invitations.EmailInvitationPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("page",
+				slog.String("domain_id", page.DomainID),
+				slog.Uint64("offset", page.Offset),
+				slog.Uint64("limit", page.Limit),
+				slog.Uint64("total", invs.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List email invitations failed", args...)
+			return
+		}
+		lm.logger.Info("List email invitations completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ListEmailInvitations(ctx, session, page)
+}
+
+func (lm *logging) DeleteEmailInvitation(ctx context.Context, session authn.Session, email, domainID string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Delete email invitation failed", args...)
+			return
+		}
+		lm.logger.Info("Delete email invitation completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DeleteEmailInvitation(ctx, session, email, domainID)
+}
+
+func (lm *logging) AcceptEmailInvitation(ctx context.Context, session authn.Session, token string) (inv invitations.Invitation, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", inv.DomainID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Accept email invitation failed", args...)
+			return
+		}
+		lm.logger.Info("Accept email invitation completed successfully", args...)
+	}(time.Now())
+	return lm.svc.AcceptEmailInvitation(ctx, session, token)
+}
@@ -75,3 +75,35 @@ func (mm *metricsmw) DeleteInvitation(ctx context.Context, session authn.Session
 	}(time.Now())
 	return mm.svc.DeleteInvitation(ctx, session, userID, domainID)
 }
+
+func (mm *metricsmw) SendEmailInvitations(ctx context.Context, session authn.Session, domainID, relation string, emails []string, resend bool) (invs []invitations.EmailInvitation, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "send_email_invitations").Add(1)
+		mm.latency.With("method", "send_email_invitations").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return mm.svc.SendEmailInvitations(ctx, session, domainID, relation, emails, resend)
+}
+
+func (mm *metricsmw) ListEmailInvitations(ctx context.Context, session authn.Session, page invitations.Page) (invs invitations.EmailInvitationPage, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_email_invitations").Add(1)
+		mm.latency.With("method", "list_email_invitations").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return mm.svc.ListEmailInvitations(ctx, session, page)
+}
+
+func (mm *metricsmw) DeleteEmailInvitation(ctx context.Context, session authn.Session, email, domainID string) (err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "delete_email_invitation").Add(1)
+		mm.latency.With("method", "delete_email_invitation").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return mm.svc.DeleteEmailInvitation(ctx, session, email, domainID)
+}
+
+func (mm *metricsmw) AcceptEmailInvitation(ctx context.Context, session authn.Session, token string) (inv invitations.Invitation, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "accept_email_invitation").Add(1)
+		mm.latency.With("method", "accept_email_invitation").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return mm.svc.AcceptEmailInvitation(ctx, session, token)
+}
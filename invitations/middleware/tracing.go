@@ -83,3 +83,42 @@ func (tm *tracing) DeleteInvitation(ctx context.Context, session authn.Session,
 
 	return tm.svc.DeleteInvitation(ctx, session, userID, domainID)
 }
+
+func (tm *tracing) SendEmailInvitations(ctx context.Context, session authn.Session, domainID, relation string, emails []string, resend bool) (invs []invitations.EmailInvitation, err error) {
+	ctx, span := tm.tracer.Start(ctx, "send_email_invitations", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+		attribute.String("relation", relation),
+		attribute.Int("emails", len(emails)),
+		attribute.Bool("resend", resend),
+	))
+	defer span.End()
+
+	return tm.svc.SendEmailInvitations(ctx, session, domainID, relation, emails, resend)
+}
+
+func (tm *tracing) ListEmailInvitations(ctx context.Context, session authn.Session, page invitations.Page) (invs invitations.EmailInvitationPage, err error) {
+	ctx, span := tm.tracer.Start(ctx, "list_email_invitations", trace.WithAttributes(
+		attribute.Int("limit", int(page.Limit)),
+		attribute.Int("offset", int(page.Offset)),
+		attribute.String("domain_id", page.DomainID),
+	))
+	defer span.End()
+
+	return tm.svc.ListEmailInvitations(ctx, session, page)
+}
+
+func (tm *tracing) DeleteEmailInvitation(ctx context.Context, session authn.Session, email, domainID string) (err error) {
+	ctx, span := tm.tracer.Start(ctx, "delete_email_invitation", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+	))
+	defer span.End()
+
+	return tm.svc.DeleteEmailInvitation(ctx, session, email, domainID)
+}
+
+func (tm *tracing) AcceptEmailInvitation(ctx context.Context, session authn.Session, token string) (inv invitations.Invitation, err error) {
+	ctx, span := tm.tracer.Start(ctx, "accept_email_invitation")
+	defer span.End()
+
+	return tm.svc.AcceptEmailInvitation(ctx, session, token)
+}
@@ -16,6 +16,34 @@ type Repository struct {
 	mock.Mock
 }
 
+// ClaimEmailInvitation provides a mock function with given fields: ctx, tokenHash
+func (_m *Repository) ClaimEmailInvitation(ctx context.Context, tokenHash string) (invitations.EmailInvitation, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimEmailInvitation")
+	}
+
+	var r0 invitations.EmailInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (invitations.EmailInvitation, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) invitations.EmailInvitation); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(invitations.EmailInvitation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, invitation
 func (_m *Repository) Create(ctx context.Context, invitation invitations.Invitation) error {
 	ret := _m.Called(ctx, invitation)
@@ -34,6 +62,24 @@ func (_m *Repository) Create(ctx context.Context, invitation invitations.Invitat
 	return r0
 }
 
+// CreateEmailInvitation provides a mock function with given fields: ctx, invitation
+func (_m *Repository) CreateEmailInvitation(ctx context.Context, invitation invitations.EmailInvitation) error {
+	ret := _m.Called(ctx, invitation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEmailInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, invitations.EmailInvitation) error); ok {
+		r0 = rf(ctx, invitation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Delete provides a mock function with given fields: ctx, userID, domainID
 func (_m *Repository) Delete(ctx context.Context, userID string, domainID string) error {
 	ret := _m.Called(ctx, userID, domainID)
@@ -52,6 +98,24 @@ func (_m *Repository) Delete(ctx context.Context, userID string, domainID string
 	return r0
 }
 
+// DeleteEmailInvitation provides a mock function with given fields: ctx, email, domainID
+func (_m *Repository) DeleteEmailInvitation(ctx context.Context, email string, domainID string) error {
+	ret := _m.Called(ctx, email, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEmailInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, email, domainID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Retrieve provides a mock function with given fields: ctx, userID, domainID
 func (_m *Repository) Retrieve(ctx context.Context, userID string, domainID string) (invitations.Invitation, error) {
 	ret := _m.Called(ctx, userID, domainID)
@@ -108,6 +172,62 @@ func (_m *Repository) RetrieveAll(ctx context.Context, page invitations.Page) (i
 	return r0, r1
 }
 
+// RetrieveAllEmailInvitations provides a mock function with given fields: ctx, page
+func (_m *Repository) RetrieveAllEmailInvitations(ctx context.Context, page invitations.Page) (invitations.EmailInvitationPage, error) {
+	ret := _m.Called(ctx, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveAllEmailInvitations")
+	}
+
+	var r0 invitations.EmailInvitationPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, invitations.Page) (invitations.EmailInvitationPage, error)); ok {
+		return rf(ctx, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, invitations.Page) invitations.EmailInvitationPage); ok {
+		r0 = rf(ctx, page)
+	} else {
+		r0 = ret.Get(0).(invitations.EmailInvitationPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, invitations.Page) error); ok {
+		r1 = rf(ctx, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveEmailInvitation provides a mock function with given fields: ctx, email, domainID
+func (_m *Repository) RetrieveEmailInvitation(ctx context.Context, email string, domainID string) (invitations.EmailInvitation, error) {
+	ret := _m.Called(ctx, email, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveEmailInvitation")
+	}
+
+	var r0 invitations.EmailInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (invitations.EmailInvitation, error)); ok {
+		return rf(ctx, email, domainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) invitations.EmailInvitation); ok {
+		r0 = rf(ctx, email, domainID)
+	} else {
+		r0 = ret.Get(0).(invitations.EmailInvitation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, email, domainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateConfirmation provides a mock function with given fields: ctx, invitation
 func (_m *Repository) UpdateConfirmation(ctx context.Context, invitation invitations.Invitation) error {
 	ret := _m.Called(ctx, invitation)
@@ -126,6 +246,24 @@ func (_m *Repository) UpdateConfirmation(ctx context.Context, invitation invitat
 	return r0
 }
 
+// UpdateEmailInvitation provides a mock function with given fields: ctx, invitation
+func (_m *Repository) UpdateEmailInvitation(ctx context.Context, invitation invitations.EmailInvitation) error {
+	ret := _m.Called(ctx, invitation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEmailInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, invitations.EmailInvitation) error); ok {
+		r0 = rf(ctx, invitation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateRejection provides a mock function with given fields: ctx, invitation
 func (_m *Repository) UpdateRejection(ctx context.Context, invitation invitations.Invitation) error {
 	ret := _m.Called(ctx, invitation)
@@ -19,6 +19,34 @@ type Service struct {
 	mock.Mock
 }
 
+// AcceptEmailInvitation provides a mock function with given fields: ctx, session, token
+func (_m *Service) AcceptEmailInvitation(ctx context.Context, session authn.Session, token string) (invitations.Invitation, error) {
+	ret := _m.Called(ctx, session, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptEmailInvitation")
+	}
+
+	var r0 invitations.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) (invitations.Invitation, error)); ok {
+		return rf(ctx, session, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) invitations.Invitation); ok {
+		r0 = rf(ctx, session, token)
+	} else {
+		r0 = ret.Get(0).(invitations.Invitation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
+		r1 = rf(ctx, session, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AcceptInvitation provides a mock function with given fields: ctx, session, domainID
 func (_m *Service) AcceptInvitation(ctx context.Context, session authn.Session, domainID string) error {
 	ret := _m.Called(ctx, session, domainID)
@@ -37,6 +65,24 @@ func (_m *Service) AcceptInvitation(ctx context.Context, session authn.Session,
 	return r0
 }
 
+// DeleteEmailInvitation provides a mock function with given fields: ctx, session, email, domainID
+func (_m *Service) DeleteEmailInvitation(ctx context.Context, session authn.Session, email string, domainID string) error {
+	ret := _m.Called(ctx, session, email, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEmailInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, string) error); ok {
+		r0 = rf(ctx, session, email, domainID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteInvitation provides a mock function with given fields: ctx, session, userID, domainID
 func (_m *Service) DeleteInvitation(ctx context.Context, session authn.Session, userID string, domainID string) error {
 	ret := _m.Called(ctx, session, userID, domainID)
@@ -55,6 +101,34 @@ func (_m *Service) DeleteInvitation(ctx context.Context, session authn.Session,
 	return r0
 }
 
+// ListEmailInvitations provides a mock function with given fields: ctx, session, page
+func (_m *Service) ListEmailInvitations(ctx context.Context, session authn.Session, page invitations.Page) (invitations.EmailInvitationPage, error) {
+	ret := _m.Called(ctx, session, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEmailInvitations")
+	}
+
+	var r0 invitations.EmailInvitationPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, invitations.Page) (invitations.EmailInvitationPage, error)); ok {
+		return rf(ctx, session, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, invitations.Page) invitations.EmailInvitationPage); ok {
+		r0 = rf(ctx, session, page)
+	} else {
+		r0 = ret.Get(0).(invitations.EmailInvitationPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, invitations.Page) error); ok {
+		r1 = rf(ctx, session, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListInvitations provides a mock function with given fields: ctx, session, page
 func (_m *Service) ListInvitations(ctx context.Context, session authn.Session, page invitations.Page) (invitations.InvitationPage, error) {
 	ret := _m.Called(ctx, session, page)
@@ -101,6 +175,36 @@ func (_m *Service) RejectInvitation(ctx context.Context, session authn.Session,
 	return r0
 }
 
+// SendEmailInvitations provides a mock function with given fields: ctx, session, domainID, relation, emails, resend
+func (_m *Service) SendEmailInvitations(ctx context.Context, session authn.Session, domainID string, relation string, emails []string, resend bool) ([]invitations.EmailInvitation, error) {
+	ret := _m.Called(ctx, session, domainID, relation, emails, resend)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendEmailInvitations")
+	}
+
+	var r0 []invitations.EmailInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, string, []string, bool) ([]invitations.EmailInvitation, error)); ok {
+		return rf(ctx, session, domainID, relation, emails, resend)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, string, []string, bool) []invitations.EmailInvitation); ok {
+		r0 = rf(ctx, session, domainID, relation, emails, resend)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]invitations.EmailInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string, string, []string, bool) error); ok {
+		r1 = rf(ctx, session, domainID, relation, emails, resend)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SendInvitation provides a mock function with given fields: ctx, session, invitation
 func (_m *Service) SendInvitation(ctx context.Context, session authn.Session, invitation invitations.Invitation) error {
 	ret := _m.Called(ctx, session, invitation)
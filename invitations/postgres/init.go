@@ -43,6 +43,42 @@ func Migration() *migrate.MemoryMigrationSource {
 					 DROP COLUMN rejected_at`,
 				},
 			},
+			{
+				Id: "invitations_03_add_email_invitations",
+				Up: []string{
+					`ALTER TABLE invitations
+					 ADD COLUMN expires_at TIMESTAMP`,
+					`CREATE TABLE IF NOT EXISTS email_invitations (
+						invited_by		VARCHAR(36) NOT NULL,
+						email			VARCHAR(254) NOT NULL,
+						domain_id		VARCHAR(36) NOT NULL,
+						relation		VARCHAR(254) NOT NULL,
+						send_count		BIGINT NOT NULL DEFAULT 1,
+						created_at		TIMESTAMP NOT NULL,
+						updated_at		TIMESTAMP,
+						expires_at		TIMESTAMP NOT NULL,
+						PRIMARY KEY (email, domain_id)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS email_invitations`,
+					`ALTER TABLE invitations
+					 DROP COLUMN expires_at`,
+				},
+			},
+			{
+				// Email invitations sent before the tokens were introduced
+				// can't be accepted until they are resent.
+				Id: "invitations_04_add_email_invitation_tokens",
+				Up: []string{
+					`ALTER TABLE email_invitations
+					 ADD COLUMN token_hash VARCHAR(64) UNIQUE`,
+				},
+				Down: []string{
+					`ALTER TABLE email_invitations
+					 DROP COLUMN token_hash`,
+				},
+			},
 		},
 	}
 }
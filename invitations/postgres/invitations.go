@@ -24,8 +24,8 @@ func NewRepository(db postgres.Database) invitations.Repository {
 }
 
 func (repo *repository) Create(ctx context.Context, invitation invitations.Invitation) (err error) {
-	q := `INSERT INTO invitations (invited_by, user_id, domain_id, token, relation, created_at)
-		VALUES (:invited_by, :user_id, :domain_id, :token, :relation, :created_at)`
+	q := `INSERT INTO invitations (invited_by, user_id, domain_id, token, relation, created_at, expires_at)
+		VALUES (:invited_by, :user_id, :domain_id, :token, :relation, :created_at, :expires_at)`
 
 	dbInv := toDBInvitation(invitation)
 	if _, err = repo.db.NamedExecContext(ctx, q, dbInv); err != nil {
@@ -36,7 +36,7 @@ func (repo *repository) Create(ctx context.Context, invitation invitations.Invit
 }
 
 func (repo *repository) Retrieve(ctx context.Context, userID, domainID string) (invitations.Invitation, error) {
-	q := `SELECT invited_by, user_id, domain_id, token, relation, created_at, updated_at, confirmed_at, rejected_at FROM invitations WHERE user_id = :user_id AND domain_id = :domain_id;`
+	q := `SELECT invited_by, user_id, domain_id, token, relation, created_at, updated_at, confirmed_at, rejected_at, expires_at FROM invitations WHERE user_id = :user_id AND domain_id = :domain_id;`
 
 	dbinv := dbInvitation{
 		UserID:   userID,
@@ -63,7 +63,7 @@ func (repo *repository) Retrieve(ctx context.Context, userID, domainID string) (
 func (repo *repository) RetrieveAll(ctx context.Context, page invitations.Page) (invitations.InvitationPage, error) {
 	query := pageQuery(page)
 
-	q := fmt.Sprintf("SELECT invited_by, user_id, domain_id, relation, created_at, updated_at, confirmed_at, rejected_at FROM invitations %s LIMIT :limit OFFSET :offset;", query)
+	q := fmt.Sprintf("SELECT invited_by, user_id, domain_id, relation, created_at, updated_at, confirmed_at, rejected_at, expires_at FROM invitations %s LIMIT :limit OFFSET :offset;", query)
 
 	rows, err := repo.db.NamedQueryContext(ctx, q, page)
 	if err != nil {
@@ -98,7 +98,7 @@ func (repo *repository) RetrieveAll(ctx context.Context, page invitations.Page)
 }
 
 func (repo *repository) UpdateToken(ctx context.Context, invitation invitations.Invitation) (err error) {
-	q := `UPDATE invitations SET token = :token, updated_at = :updated_at WHERE user_id = :user_id AND domain_id = :domain_id`
+	q := `UPDATE invitations SET token = :token, updated_at = :updated_at, expires_at = :expires_at WHERE user_id = :user_id AND domain_id = :domain_id`
 
 	dbinv := toDBInvitation(invitation)
 	result, err := repo.db.NamedExecContext(ctx, q, dbinv)
@@ -156,6 +156,118 @@ func (repo *repository) Delete(ctx context.Context, userID, domain string) (err
 	return nil
 }
 
+func (repo *repository) CreateEmailInvitation(ctx context.Context, invitation invitations.EmailInvitation) error {
+	q := `INSERT INTO email_invitations (invited_by, email, domain_id, relation, token_hash, send_count, created_at, updated_at, expires_at)
+		VALUES (:invited_by, :email, :domain_id, :relation, :token_hash, :send_count, :created_at, :updated_at, :expires_at)`
+
+	if _, err := repo.db.NamedExecContext(ctx, q, toDBEmailInvitation(invitation)); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveEmailInvitation(ctx context.Context, email, domainID string) (invitations.EmailInvitation, error) {
+	q := `SELECT invited_by, email, domain_id, relation, send_count, created_at, updated_at, expires_at FROM email_invitations WHERE email = $1 AND domain_id = $2;`
+
+	var dbinv dbEmailInvitation
+	if err := repo.db.QueryRowxContext(ctx, q, email, domainID).StructScan(&dbinv); err != nil {
+		if err == sql.ErrNoRows {
+			return invitations.EmailInvitation{}, repoerr.ErrNotFound
+		}
+		return invitations.EmailInvitation{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return toEmailInvitation(dbinv), nil
+}
+
+func (repo *repository) RetrieveAllEmailInvitations(ctx context.Context, page invitations.Page) (invitations.EmailInvitationPage, error) {
+	var query []string
+	if page.DomainID != "" {
+		query = append(query, "domain_id = :domain_id")
+	}
+	if page.Email != "" {
+		query = append(query, "email = :email")
+	}
+	var emq string
+	if len(query) > 0 {
+		emq = fmt.Sprintf("WHERE %s", strings.Join(query, " AND "))
+	}
+
+	q := fmt.Sprintf("SELECT invited_by, email, domain_id, relation, send_count, created_at, updated_at, expires_at FROM email_invitations %s ORDER BY created_at LIMIT :limit OFFSET :offset;", emq)
+	rows, err := repo.db.NamedQueryContext(ctx, q, page)
+	if err != nil {
+		return invitations.EmailInvitationPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var items []invitations.EmailInvitation
+	for rows.Next() {
+		var dbinv dbEmailInvitation
+		if err = rows.StructScan(&dbinv); err != nil {
+			return invitations.EmailInvitationPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		items = append(items, toEmailInvitation(dbinv))
+	}
+
+	tq := fmt.Sprintf(`SELECT COUNT(*) FROM email_invitations %s`, emq)
+	total, err := postgres.Total(ctx, repo.db, tq, page)
+	if err != nil {
+		return invitations.EmailInvitationPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return invitations.EmailInvitationPage{
+		Total:       total,
+		Offset:      page.Offset,
+		Limit:       page.Limit,
+		Invitations: items,
+	}, nil
+}
+
+func (repo *repository) UpdateEmailInvitation(ctx context.Context, invitation invitations.EmailInvitation) error {
+	q := `UPDATE email_invitations SET relation = :relation, token_hash = :token_hash, send_count = :send_count, updated_at = :updated_at, expires_at = :expires_at
+		WHERE email = :email AND domain_id = :domain_id`
+
+	result, err := repo.db.NamedExecContext(ctx, q, toDBEmailInvitation(invitation))
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *repository) ClaimEmailInvitation(ctx context.Context, tokenHash string) (invitations.EmailInvitation, error) {
+	q := `DELETE FROM email_invitations WHERE token_hash = $1
+		RETURNING invited_by, email, domain_id, relation, token_hash, send_count, created_at, updated_at, expires_at;`
+
+	var dbinv dbEmailInvitation
+	if err := repo.db.QueryRowxContext(ctx, q, tokenHash).StructScan(&dbinv); err != nil {
+		if err == sql.ErrNoRows {
+			return invitations.EmailInvitation{}, repoerr.ErrNotFound
+		}
+		return invitations.EmailInvitation{}, postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return toEmailInvitation(dbinv), nil
+}
+
+func (repo *repository) DeleteEmailInvitation(ctx context.Context, email, domainID string) error {
+	q := `DELETE FROM email_invitations WHERE email = $1 AND domain_id = $2`
+
+	result, err := repo.db.ExecContext(ctx, q, email, domainID)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
 func pageQuery(pm invitations.Page) string {
 	var query []string
 	var emq string
@@ -201,10 +313,11 @@ type dbInvitation struct {
 	UpdatedAt   sql.NullTime `db:"updated_at,omitempty"`
 	ConfirmedAt sql.NullTime `db:"confirmed_at,omitempty"`
 	RejectedAt  sql.NullTime `db:"rejected_at,omitempty"`
+	ExpiresAt   sql.NullTime `db:"expires_at,omitempty"`
 }
 
 func toDBInvitation(inv invitations.Invitation) dbInvitation {
-	var updatedAt, confirmedAt, rejectedAt sql.NullTime
+	var updatedAt, confirmedAt, rejectedAt, expiresAt sql.NullTime
 	if inv.UpdatedAt != (time.Time{}) {
 		updatedAt = sql.NullTime{Time: inv.UpdatedAt, Valid: true}
 	}
@@ -214,6 +327,9 @@ func toDBInvitation(inv invitations.Invitation) dbInvitation {
 	if inv.RejectedAt != (time.Time{}) {
 		rejectedAt = sql.NullTime{Time: inv.RejectedAt, Valid: true}
 	}
+	if inv.ExpiresAt != (time.Time{}) {
+		expiresAt = sql.NullTime{Time: inv.ExpiresAt, Valid: true}
+	}
 
 	return dbInvitation{
 		InvitedBy:   inv.InvitedBy,
@@ -225,11 +341,12 @@ func toDBInvitation(inv invitations.Invitation) dbInvitation {
 		UpdatedAt:   updatedAt,
 		ConfirmedAt: confirmedAt,
 		RejectedAt:  rejectedAt,
+		ExpiresAt:   expiresAt,
 	}
 }
 
 func toInvitation(dbinv dbInvitation) invitations.Invitation {
-	var updatedAt, confirmedAt, rejectedAt time.Time
+	var updatedAt, confirmedAt, rejectedAt, expiresAt time.Time
 	if dbinv.UpdatedAt.Valid {
 		updatedAt = dbinv.UpdatedAt.Time
 	}
@@ -239,6 +356,9 @@ func toInvitation(dbinv dbInvitation) invitations.Invitation {
 	if dbinv.RejectedAt.Valid {
 		rejectedAt = dbinv.RejectedAt.Time
 	}
+	if dbinv.ExpiresAt.Valid {
+		expiresAt = dbinv.ExpiresAt.Time
+	}
 
 	return invitations.Invitation{
 		InvitedBy:   dbinv.InvitedBy,
@@ -250,5 +370,60 @@ func toInvitation(dbinv dbInvitation) invitations.Invitation {
 		UpdatedAt:   updatedAt,
 		ConfirmedAt: confirmedAt,
 		RejectedAt:  rejectedAt,
+		ExpiresAt:   expiresAt,
+	}
+}
+
+type dbEmailInvitation struct {
+	InvitedBy string         `db:"invited_by"`
+	Email     string         `db:"email"`
+	DomainID  string         `db:"domain_id"`
+	Relation  string         `db:"relation"`
+	TokenHash sql.NullString `db:"token_hash,omitempty"`
+	SendCount uint64         `db:"send_count"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt sql.NullTime   `db:"updated_at,omitempty"`
+	ExpiresAt time.Time      `db:"expires_at"`
+}
+
+func toDBEmailInvitation(inv invitations.EmailInvitation) dbEmailInvitation {
+	var updatedAt sql.NullTime
+	if inv.UpdatedAt != (time.Time{}) {
+		updatedAt = sql.NullTime{Time: inv.UpdatedAt, Valid: true}
+	}
+	var tokenHash sql.NullString
+	if inv.TokenHash != "" {
+		tokenHash = sql.NullString{String: inv.TokenHash, Valid: true}
+	}
+
+	return dbEmailInvitation{
+		InvitedBy: inv.InvitedBy,
+		Email:     inv.Email,
+		DomainID:  inv.DomainID,
+		Relation:  inv.Relation,
+		TokenHash: tokenHash,
+		SendCount: inv.SendCount,
+		CreatedAt: inv.CreatedAt,
+		UpdatedAt: updatedAt,
+		ExpiresAt: inv.ExpiresAt,
+	}
+}
+
+func toEmailInvitation(dbinv dbEmailInvitation) invitations.EmailInvitation {
+	var updatedAt time.Time
+	if dbinv.UpdatedAt.Valid {
+		updatedAt = dbinv.UpdatedAt.Time
+	}
+
+	return invitations.EmailInvitation{
+		InvitedBy: dbinv.InvitedBy,
+		Email:     dbinv.Email,
+		DomainID:  dbinv.DomainID,
+		Relation:  dbinv.Relation,
+		TokenHash: dbinv.TokenHash.String,
+		SendCount: dbinv.SendCount,
+		CreatedAt: dbinv.CreatedAt,
+		UpdatedAt: updatedAt,
+		ExpiresAt: dbinv.ExpiresAt,
 	}
 }
@@ -809,3 +809,61 @@ func TestInvitationDelete(t *testing.T) {
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestEmailInvitationClaim(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM email_invitations")
+		require.Nil(t, err, fmt.Sprintf("clean email invitations unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	tokenHash := strings.Repeat("b", 64)
+	invitation := invitations.EmailInvitation{
+		InvitedBy: testsutil.GenerateUUID(t),
+		Email:     "invitee@example.com",
+		DomainID:  testsutil.GenerateUUID(t),
+		Relation:  relation,
+		TokenHash: tokenHash,
+		SendCount: 1,
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+		ExpiresAt: time.Now().Add(time.Hour).UTC().Truncate(time.Millisecond),
+	}
+	err := repo.CreateEmailInvitation(context.Background(), invitation)
+	require.Nil(t, err, fmt.Sprintf("create email invitation unexpected error: %s", err))
+
+	cases := []struct {
+		desc       string
+		tokenHash  string
+		invitation invitations.EmailInvitation
+		err        error
+	}{
+		{
+			desc:       "claim email invitation successfully",
+			tokenHash:  tokenHash,
+			invitation: invitation,
+			err:        nil,
+		},
+		{
+			desc:      "claim already claimed email invitation",
+			tokenHash: tokenHash,
+			err:       repoerr.ErrNotFound,
+		},
+		{
+			desc:      "claim email invitation with invalid token hash",
+			tokenHash: strings.Repeat("c", 64),
+			err:       repoerr.ErrNotFound,
+		},
+		{
+			desc:      "claim email invitation with empty token hash",
+			tokenHash: "",
+			err:       repoerr.ErrNotFound,
+		},
+	}
+	for _, tc := range cases {
+		inv, err := repo.ClaimEmailInvitation(context.Background(), tc.tokenHash)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.invitation.Email, inv.Email, tc.desc)
+		assert.Equal(t, tc.invitation.DomainID, inv.DomainID, tc.desc)
+		assert.Equal(t, tc.invitation.TokenHash, inv.TokenHash, tc.desc)
+	}
+}
@@ -5,26 +5,44 @@ package invitations
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
 	"time"
 
 	"github.com/absmach/magistrala"
 	"github.com/absmach/magistrala/auth"
 	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
 )
 
+// tokenSize is the number of random bytes of the email invitation token.
+const tokenSize = 32
+
 type service struct {
-	token magistrala.TokenServiceClient
-	repo  Repository
-	sdk   mgsdk.SDK
+	token       magistrala.TokenServiceClient
+	repo        Repository
+	sdk         mgsdk.SDK
+	emailer     Emailer
+	expiry      time.Duration
+	resendLimit uint64
 }
 
-func NewService(token magistrala.TokenServiceClient, repo Repository, sdk mgsdk.SDK) Service {
+// NewService instantiates the invitations service. Invitations expire after
+// the expiry and email invitations can be resent at most resendLimit times.
+func NewService(token magistrala.TokenServiceClient, repo Repository, sdk mgsdk.SDK, emailer Emailer, expiry time.Duration, resendLimit uint64) Service {
 	return &service{
-		token: token,
-		repo:  repo,
-		sdk:   sdk,
+		token:       token,
+		repo:        repo,
+		sdk:         sdk,
+		emailer:     emailer,
+		expiry:      expiry,
+		resendLimit: resendLimit,
 	}
 }
 
@@ -40,6 +58,7 @@ func (svc *service) SendInvitation(ctx context.Context, session authn.Session, i
 		return err
 	}
 	invitation.Token = joinToken.GetAccessToken()
+	invitation.ExpiresAt = time.Now().Add(svc.expiry)
 
 	if invitation.Resend {
 		invitation.UpdatedAt = time.Now()
@@ -91,6 +110,10 @@ func (svc *service) AcceptInvitation(ctx context.Context, session authn.Session,
 		return svcerr.ErrInvitationAlreadyRejected
 	}
 
+	if !inv.ExpiresAt.IsZero() && time.Now().After(inv.ExpiresAt) {
+		return svcerr.ErrInvitationExpired
+	}
+
 	req := mgsdk.UsersRelationRequest{
 		Relation: inv.Relation,
 		UserIDs:  []string{session.UserID},
@@ -143,3 +166,164 @@ func (svc *service) DeleteInvitation(ctx context.Context, session authn.Session,
 
 	return svc.repo.Delete(ctx, userID, domainID)
 }
+
+func (svc *service) SendEmailInvitations(ctx context.Context, session authn.Session, domainID, relation string, emails []string, resend bool) ([]EmailInvitation, error) {
+	if err := CheckRelation(relation); err != nil {
+		return nil, err
+	}
+
+	var invs []EmailInvitation
+	for _, email := range emails {
+		email = normalizeEmail(email)
+		now := time.Now()
+		// Each email gets the new token, which invalidates the previous one.
+		token, err := newToken()
+		if err != nil {
+			return invs, err
+		}
+		inv, err := svc.repo.RetrieveEmailInvitation(ctx, email, domainID)
+		switch {
+		case err == nil:
+			if !resend {
+				continue
+			}
+			// The send count includes the first invitation.
+			if inv.SendCount > svc.resendLimit {
+				return invs, errors.Wrap(svcerr.ErrInvitationResendLimit, errors.New(email))
+			}
+			inv.Relation = relation
+			inv.TokenHash = hashToken(token)
+			inv.SendCount++
+			inv.UpdatedAt = now
+			inv.ExpiresAt = now.Add(svc.expiry)
+			if err := svc.repo.UpdateEmailInvitation(ctx, inv); err != nil {
+				return invs, err
+			}
+		case errors.Contains(err, repoerr.ErrNotFound):
+			inv = EmailInvitation{
+				InvitedBy: session.UserID,
+				Email:     email,
+				DomainID:  domainID,
+				Relation:  relation,
+				TokenHash: hashToken(token),
+				SendCount: 1,
+				CreatedAt: now,
+				ExpiresAt: now.Add(svc.expiry),
+			}
+			if err := svc.repo.CreateEmailInvitation(ctx, inv); err != nil {
+				return invs, err
+			}
+		default:
+			return invs, err
+		}
+
+		if err := svc.emailer.SendInvitation(email, domainID, token); err != nil {
+			return invs, err
+		}
+		invs = append(invs, inv)
+	}
+
+	return invs, nil
+}
+
+func (svc *service) ListEmailInvitations(ctx context.Context, session authn.Session, page Page) (EmailInvitationPage, error) {
+	page.Email = normalizeEmail(page.Email)
+
+	return svc.repo.RetrieveAllEmailInvitations(ctx, page)
+}
+
+func (svc *service) DeleteEmailInvitation(ctx context.Context, session authn.Session, email, domainID string) error {
+	return svc.repo.DeleteEmailInvitation(ctx, normalizeEmail(email), domainID)
+}
+
+func (svc *service) AcceptEmailInvitation(ctx context.Context, session authn.Session, token string) (Invitation, error) {
+	// Claiming removes the email invitation, so concurrent requests can't
+	// accept it with the same token.
+	ei, err := svc.repo.ClaimEmailInvitation(ctx, hashToken(token))
+	if err != nil {
+		return Invitation{}, err
+	}
+	if time.Now().After(ei.ExpiresAt) {
+		return Invitation{}, svcerr.ErrInvitationExpired
+	}
+
+	inv, err := svc.acceptEmailInvitation(ctx, session, ei)
+	if err != nil {
+		// The email invitation is restored, so the token can be used again.
+		if rerr := svc.repo.CreateEmailInvitation(ctx, ei); rerr != nil {
+			return Invitation{}, errors.Wrap(err, rerr)
+		}
+		return Invitation{}, err
+	}
+
+	return inv, nil
+}
+
+func (svc *service) acceptEmailInvitation(ctx context.Context, session authn.Session, ei EmailInvitation) (Invitation, error) {
+	// The invitation is accepted with the token of the inviter, as it is
+	// when the invitation is sent to the existing user.
+	joinToken, err := svc.token.Issue(ctx, &magistrala.IssueReq{UserId: ei.InvitedBy, Type: uint32(auth.InvitationKey)})
+	if err != nil {
+		return Invitation{}, err
+	}
+	now := time.Now()
+	inv := Invitation{
+		InvitedBy: ei.InvitedBy,
+		UserID:    session.UserID,
+		DomainID:  ei.DomainID,
+		Token:     joinToken.GetAccessToken(),
+		Relation:  ei.Relation,
+		CreatedAt: now,
+		ExpiresAt: ei.ExpiresAt,
+	}
+	switch err := svc.repo.Create(ctx, inv); {
+	case err == nil:
+	// The user is already invited to the domain directly.
+	case errors.Contains(err, repoerr.ErrConflict):
+		inv.UpdatedAt = now
+		if err := svc.repo.UpdateToken(ctx, inv); err != nil {
+			return Invitation{}, err
+		}
+	default:
+		return Invitation{}, err
+	}
+
+	req := mgsdk.UsersRelationRequest{
+		Relation: inv.Relation,
+		UserIDs:  []string{session.UserID},
+	}
+	if sdkerr := svc.sdk.AddUserToDomain(inv.DomainID, req, inv.Token); sdkerr != nil {
+		return Invitation{}, sdkerr
+	}
+
+	inv.ConfirmedAt = now
+	inv.UpdatedAt = now
+	if err := svc.repo.UpdateConfirmation(ctx, inv); err != nil {
+		return Invitation{}, err
+	}
+	inv.Token = ""
+
+	return inv, nil
+}
+
+// newToken returns the random URL safe email invitation token.
+func newToken() (string, error) {
+	b := make([]byte, tokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken returns the hash of the email invitation token, which is stored
+// instead of the token.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
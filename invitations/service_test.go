@@ -5,10 +5,14 @@ package invitations_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/auth"
 	authmocks "github.com/absmach/magistrala/auth/mocks"
 	"github.com/absmach/magistrala/internal/testsutil"
 	"github.com/absmach/magistrala/invitations"
@@ -35,12 +39,15 @@ var (
 	validDomainID     = "domain_id"
 	validToken        = "valid_token"
 	invalidToken      = "invalid"
+	validEmail        = "invitee@example.com"
+	expiry            = time.Hour
+	resendLimit       = uint64(2)
 )
 
 func TestSendInvitation(t *testing.T) {
 	repo := new(mocks.Repository)
 	token := new(authmocks.TokenServiceClient)
-	svc := invitations.NewService(token, repo, nil)
+	svc := invitations.NewService(token, repo, nil, nil, expiry, resendLimit)
 
 	cases := []struct {
 		desc        string
@@ -125,7 +132,7 @@ func TestSendInvitation(t *testing.T) {
 func TestViewInvitation(t *testing.T) {
 	repo := new(mocks.Repository)
 	token := new(authmocks.TokenServiceClient)
-	svc := invitations.NewService(token, repo, nil)
+	svc := invitations.NewService(token, repo, nil, nil, expiry, resendLimit)
 
 	validInvitation := invitations.Invitation{
 		InvitedBy:   testsutil.GenerateUUID(t),
@@ -209,7 +216,7 @@ func TestViewInvitation(t *testing.T) {
 func TestListInvitations(t *testing.T) {
 	repo := new(mocks.Repository)
 	token := new(authmocks.TokenServiceClient)
-	svc := invitations.NewService(token, repo, nil)
+	svc := invitations.NewService(token, repo, nil, nil, expiry, resendLimit)
 
 	validPage := invitations.Page{
 		Offset: 0,
@@ -274,7 +281,7 @@ func TestAcceptInvitation(t *testing.T) {
 	repo := new(mocks.Repository)
 	token := new(authmocks.TokenServiceClient)
 	sdksvc := new(sdkmocks.SDK)
-	svc := invitations.NewService(token, repo, sdksvc)
+	svc := invitations.NewService(token, repo, sdksvc, nil, expiry, resendLimit)
 
 	userID := testsutil.GenerateUUID(t)
 
@@ -370,6 +377,21 @@ func TestAcceptInvitation(t *testing.T) {
 			err:     svcerr.ErrInvitationAlreadyRejected,
 			repoErr: nil,
 		},
+		{
+			desc:     "accept expired invitation",
+			token:    validToken,
+			session:  authn.Session{DomainUserID: validDomainUserID, DomainID: validDomainID, UserID: userID},
+			domainID: "",
+			resp: invitations.Invitation{
+				UserID:    userID,
+				DomainID:  testsutil.GenerateUUID(t),
+				Token:     validToken,
+				Relation:  policies.ContributorRelation,
+				ExpiresAt: time.Now().Add(-time.Minute),
+			},
+			err:     svcerr.ErrInvitationExpired,
+			repoErr: nil,
+		},
 	}
 
 	for _, tc := range cases {
@@ -389,7 +411,7 @@ func TestAcceptInvitation(t *testing.T) {
 func TestDeleteInvitation(t *testing.T) {
 	repo := new(mocks.Repository)
 	token := new(authmocks.TokenServiceClient)
-	svc := invitations.NewService(token, repo, nil)
+	svc := invitations.NewService(token, repo, nil, nil, expiry, resendLimit)
 
 	cases := []struct {
 		desc     string
@@ -452,7 +474,7 @@ func TestDeleteInvitation(t *testing.T) {
 func TestRejectInvitation(t *testing.T) {
 	repo := new(mocks.Repository)
 	token := new(authmocks.TokenServiceClient)
-	svc := invitations.NewService(token, repo, nil)
+	svc := invitations.NewService(token, repo, nil, nil, expiry, resendLimit)
 	userID := validInvitation.UserID
 
 	cases := []struct {
@@ -513,3 +535,246 @@ func TestRejectInvitation(t *testing.T) {
 		})
 	}
 }
+
+func TestSendEmailInvitations(t *testing.T) {
+	session := authn.Session{DomainUserID: validDomainUserID, DomainID: validDomainID, UserID: validUserID}
+	pending := invitations.EmailInvitation{
+		InvitedBy: validUserID,
+		Email:     validEmail,
+		DomainID:  validDomainID,
+		Relation:  policies.ContributorRelation,
+		SendCount: 1,
+		CreatedAt: time.Now().Add(-time.Hour),
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}
+	exhausted := pending
+	exhausted.SendCount = resendLimit + 1
+
+	cases := []struct {
+		desc        string
+		relation    string
+		emails      []string
+		resend      bool
+		retrieveRes invitations.EmailInvitation
+		retrieveErr error
+		repoErr     error
+		emailErr    error
+		create      bool
+		update      bool
+		count       int
+		err         error
+	}{
+		{
+			desc:        "send email invitation successfully",
+			relation:    policies.ContributorRelation,
+			emails:      []string{" Invitee@Example.com "},
+			retrieveErr: repoerr.ErrNotFound,
+			create:      true,
+			count:       1,
+		},
+		{
+			desc:     "send email invitation with invalid relation",
+			relation: "invalid",
+			emails:   []string{validEmail},
+			err:      apiutil.ErrInvalidRelation,
+		},
+		{
+			desc:        "send already sent email invitation",
+			relation:    policies.ContributorRelation,
+			emails:      []string{validEmail},
+			retrieveRes: pending,
+			count:       0,
+		},
+		{
+			desc:        "resend email invitation",
+			relation:    policies.AdministratorRelation,
+			emails:      []string{validEmail},
+			resend:      true,
+			retrieveRes: pending,
+			update:      true,
+			count:       1,
+		},
+		{
+			desc:        "resend email invitation over the limit",
+			relation:    policies.ContributorRelation,
+			emails:      []string{validEmail},
+			resend:      true,
+			retrieveRes: exhausted,
+			err:         svcerr.ErrInvitationResendLimit,
+		},
+		{
+			desc:        "send email invitation with failed retrieve",
+			relation:    policies.ContributorRelation,
+			emails:      []string{validEmail},
+			retrieveErr: repoerr.ErrViewEntity,
+			err:         repoerr.ErrViewEntity,
+		},
+		{
+			desc:        "send email invitation with failed create",
+			relation:    policies.ContributorRelation,
+			emails:      []string{validEmail},
+			retrieveErr: repoerr.ErrNotFound,
+			create:      true,
+			repoErr:     repoerr.ErrCreateEntity,
+			err:         repoerr.ErrCreateEntity,
+		},
+		{
+			desc:        "send email invitation with failed email",
+			relation:    policies.ContributorRelation,
+			emails:      []string{validEmail},
+			retrieveErr: repoerr.ErrNotFound,
+			create:      true,
+			emailErr:    svcerr.ErrCreateEntity,
+			err:         svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			emailer := new(mocks.Emailer)
+			svc := invitations.NewService(nil, repo, nil, emailer, expiry, resendLimit)
+			repo.On("RetrieveEmailInvitation", context.Background(), validEmail, validDomainID).Return(tc.retrieveRes, tc.retrieveErr)
+			repo.On("CreateEmailInvitation", context.Background(), mock.Anything).Return(tc.repoErr)
+			repo.On("UpdateEmailInvitation", context.Background(), mock.Anything).Return(tc.repoErr)
+			var sent string
+			emailer.On("SendInvitation", validEmail, validDomainID, mock.Anything).Return(tc.emailErr).Run(func(args mock.Arguments) {
+				sent = args.String(2)
+			})
+			invs, err := svc.SendEmailInvitations(context.Background(), session, validDomainID, tc.relation, tc.emails, tc.resend)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Len(t, invs, tc.count, tc.desc)
+			if tc.create {
+				repo.AssertCalled(t, "CreateEmailInvitation", context.Background(), mock.Anything)
+			}
+			// Only the hash of the emailed token is stored.
+			for _, inv := range invs {
+				sum := sha256.Sum256([]byte(sent))
+				assert.NotEmpty(t, sent, tc.desc)
+				assert.Equal(t, hex.EncodeToString(sum[:]), inv.TokenHash, tc.desc)
+			}
+			if tc.update {
+				inv := invs[0]
+				assert.Equal(t, tc.retrieveRes.SendCount+1, inv.SendCount, tc.desc)
+				assert.Equal(t, tc.relation, inv.Relation, tc.desc)
+				assert.True(t, inv.ExpiresAt.After(time.Now()), tc.desc)
+			}
+			if tc.err == nil && tc.count == 0 {
+				emailer.AssertNotCalled(t, "SendInvitation", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAcceptEmailInvitation(t *testing.T) {
+	invitationToken := "invitation_token"
+	sum := sha256.Sum256([]byte(invitationToken))
+	tokenHash := hex.EncodeToString(sum[:])
+	session := authn.Session{UserID: testsutil.GenerateUUID(t)}
+	valid := invitations.EmailInvitation{
+		InvitedBy: validUserID,
+		Email:     validEmail,
+		DomainID:  validDomainID,
+		Relation:  policies.ContributorRelation,
+		TokenHash: tokenHash,
+		SendCount: 1,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	expired := valid
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+
+	cases := []struct {
+		desc      string
+		token     string
+		claimRes  invitations.EmailInvitation
+		claimErr  error
+		issueErr  error
+		createErr error
+		updateErr error
+		sdkErr    errors.SDKError
+		restore   bool
+		err       error
+	}{
+		{
+			desc:     "accept email invitation successfully",
+			token:    invitationToken,
+			claimRes: valid,
+		},
+		{
+			desc:      "accept email invitation of already invited user",
+			token:     invitationToken,
+			claimRes:  valid,
+			createErr: repoerr.ErrConflict,
+		},
+		{
+			desc:     "accept email invitation with invalid token",
+			token:    invalidToken,
+			claimErr: repoerr.ErrNotFound,
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "accept expired email invitation",
+			token:    invitationToken,
+			claimRes: expired,
+			err:      svcerr.ErrInvitationExpired,
+		},
+		{
+			desc:     "accept email invitation with failed token issuance",
+			token:    invitationToken,
+			claimRes: valid,
+			issueErr: svcerr.ErrAuthentication,
+			restore:  true,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:      "accept email invitation with failed create",
+			token:     invitationToken,
+			claimRes:  valid,
+			createErr: repoerr.ErrCreateEntity,
+			restore:   true,
+			err:       repoerr.ErrCreateEntity,
+		},
+		{
+			desc:     "accept email invitation with sdk error",
+			token:    invitationToken,
+			claimRes: valid,
+			sdkErr:   errors.NewSDKError(svcerr.ErrConflict),
+			restore:  true,
+			err:      svcerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			token := new(authmocks.TokenServiceClient)
+			sdksvc := new(sdkmocks.SDK)
+			svc := invitations.NewService(token, repo, sdksvc, nil, expiry, resendLimit)
+			claimHash := tokenHash
+			if tc.token != invitationToken {
+				sum := sha256.Sum256([]byte(tc.token))
+				claimHash = hex.EncodeToString(sum[:])
+			}
+			repo.On("ClaimEmailInvitation", context.Background(), claimHash).Return(tc.claimRes, tc.claimErr)
+			token.On("Issue", context.Background(), &magistrala.IssueReq{UserId: validUserID, Type: uint32(auth.InvitationKey)}).Return(&magistrala.Token{AccessToken: validToken}, tc.issueErr)
+			repo.On("Create", context.Background(), mock.Anything).Return(tc.createErr)
+			repo.On("UpdateToken", context.Background(), mock.Anything).Return(tc.updateErr)
+			sdksvc.On("AddUserToDomain", validDomainID, mock.Anything, validToken).Return(tc.sdkErr)
+			repo.On("UpdateConfirmation", context.Background(), mock.Anything).Return(nil)
+			repo.On("CreateEmailInvitation", context.Background(), tc.claimRes).Return(nil)
+			inv, err := svc.AcceptEmailInvitation(context.Background(), session, tc.token)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, session.UserID, inv.UserID, tc.desc)
+				assert.Equal(t, validDomainID, inv.DomainID, tc.desc)
+				assert.False(t, inv.ConfirmedAt.IsZero(), tc.desc)
+				assert.Empty(t, inv.Token, tc.desc)
+			}
+			if tc.restore {
+				repo.AssertCalled(t, "CreateEmailInvitation", context.Background(), tc.claimRes)
+			} else {
+				repo.AssertNotCalled(t, "CreateEmailInvitation", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
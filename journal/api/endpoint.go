@@ -37,3 +37,136 @@ func retrieveJournalsEndpoint(svc journal.Service) endpoint.Endpoint {
 		}, nil
 	}
 }
+
+func verifyEndpoint(svc journal.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(verifyReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		v, err := svc.Verify(ctx, session)
+		if err != nil {
+			return nil, err
+		}
+
+		return verifyRes{
+			Verification: v,
+		}, nil
+	}
+}
+
+func exportEndpoint(svc journal.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(exportReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		e, err := svc.Export(ctx, session, req.from, req.to)
+		if err != nil {
+			return nil, err
+		}
+
+		return exportRes{
+			Export: e,
+		}, nil
+	}
+}
+
+func downloadEndpoint(svc journal.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(downloadReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		return downloadRes{
+			format: req.format,
+			export: func(fn func(journal.Journal) error) error {
+				return svc.ExportPage(ctx, session, req.page, fn)
+			},
+		}, nil
+	}
+}
+
+func setRetentionEndpoint(svc journal.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(setRetentionReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		r, err := svc.SetRetention(ctx, session, req.Days)
+		if err != nil {
+			return nil, err
+		}
+
+		return retentionRes{
+			Retention: r,
+		}, nil
+	}
+}
+
+func viewRetentionEndpoint(svc journal.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(retentionReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		r, err := svc.ViewRetention(ctx, session)
+		if err != nil {
+			return nil, err
+		}
+
+		return retentionRes{
+			Retention: r,
+		}, nil
+	}
+}
+
+func removeRetentionEndpoint(svc journal.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(retentionReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		if err := svc.RemoveRetention(ctx, session); err != nil {
+			return nil, err
+		}
+
+		return removeRetentionRes{}, nil
+	}
+}
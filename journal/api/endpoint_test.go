@@ -4,11 +4,13 @@
 package api_test
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -25,14 +27,18 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
-var validToken = "valid"
+var (
+	validToken  = "valid"
+	contentType = "application/json"
+)
 
 type testRequest struct {
-	client *http.Client
-	method string
-	url    string
-	token  string
-	body   io.Reader
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
 }
 
 func (tr testRequest) make() (*http.Response, error) {
@@ -44,6 +50,9 @@ func (tr testRequest) make() (*http.Response, error) {
 	if tr.token != "" {
 		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
 	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
 
 	return tr.client.Do(req)
 }
@@ -344,6 +353,46 @@ func TestListEntityJournalsEndpoint(t *testing.T) {
 			status:   http.StatusBadRequest,
 			svcErr:   nil,
 		},
+		{
+			desc:     "with domain entity type",
+			token:    validToken,
+			domainID: domainID,
+			url:      "/domain/" + domainID,
+			status:   http.StatusOK,
+			svcErr:   nil,
+		},
+		{
+			desc:     "with key entity type",
+			token:    validToken,
+			domainID: domainID,
+			url:      "/key/123",
+			status:   http.StatusOK,
+			svcErr:   nil,
+		},
+		{
+			desc:     "with certificate entity type",
+			token:    validToken,
+			domainID: domainID,
+			url:      "/certificate/123?operation_prefix=certificate.",
+			status:   http.StatusOK,
+			svcErr:   nil,
+		},
+		{
+			desc:     "with invitation entity type",
+			token:    validToken,
+			domainID: domainID,
+			url:      "/invitation/" + userID,
+			status:   http.StatusOK,
+			svcErr:   nil,
+		},
+		{
+			desc:     "with bootstrap entity type",
+			token:    validToken,
+			domainID: domainID,
+			url:      "/bootstrap/123",
+			status:   http.StatusOK,
+			svcErr:   nil,
+		},
 		{
 			desc:     "with invalid entity type",
 			token:    validToken,
@@ -360,6 +409,22 @@ func TestListEntityJournalsEndpoint(t *testing.T) {
 			status:   http.StatusOK,
 			svcErr:   nil,
 		},
+		{
+			desc:     "with filters",
+			token:    validToken,
+			domainID: domainID,
+			url:      "/thing/123?actor=" + userID + "&operation_prefix=thing.&search=lab&attributes=%7B%22metadata.location%22%3A%22lab%22%7D",
+			status:   http.StatusOK,
+			svcErr:   nil,
+		},
+		{
+			desc:     "with malformed attributes filter",
+			token:    validToken,
+			domainID: domainID,
+			url:      "/thing/123?attributes=location",
+			status:   http.StatusBadRequest,
+			svcErr:   nil,
+		},
 		{
 			desc:     " with empty token",
 			url:      "/group/123",
@@ -402,3 +467,613 @@ func TestListEntityJournalsEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyEndpoint(t *testing.T) {
+	es, svc, authn := newjournalServer()
+
+	userID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc     string
+		token    string
+		session  mgauthn.Session
+		domainID string
+		status   int
+		authnErr error
+		svcErr   error
+	}{
+		{
+			desc:     "successful",
+			token:    validToken,
+			domainID: domainID,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "with empty token",
+			domainID: domainID,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "with invalid token",
+			token:    "invalid",
+			domainID: domainID,
+			status:   http.StatusUnauthorized,
+			authnErr: svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "with service error",
+			token:    validToken,
+			domainID: domainID,
+			status:   http.StatusForbidden,
+			svcErr:   svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			if c.token == validToken {
+				c.session = mgauthn.Session{
+					UserID:       userID,
+					DomainID:     domainID,
+					DomainUserID: domainID + "_" + userID,
+				}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, c.token).Return(c.session, c.authnErr)
+			svcCall := svc.On("Verify", mock.Anything, c.session).Return(journal.Verification{Domain: domainID, Valid: true}, c.svcErr)
+			req := testRequest{
+				client: es.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/journal/verify", es.URL, c.domainID),
+				token:  c.token,
+			}
+			resp, err := req.make()
+			assert.Nil(t, err, c.desc)
+			defer resp.Body.Close()
+			assert.Equal(t, c.status, resp.StatusCode, c.desc)
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestExportEndpoint(t *testing.T) {
+	es, svc, authn := newjournalServer()
+
+	userID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc        string
+		token       string
+		session     mgauthn.Session
+		url         string
+		from        uint64
+		to          uint64
+		status      int
+		contentType string
+		authnErr    error
+		svcErr      error
+	}{
+		{
+			desc:        "successful",
+			token:       validToken,
+			status:      http.StatusOK,
+			contentType: "application/x-ndjson",
+		},
+		{
+			desc:        "with sequence range",
+			token:       validToken,
+			url:         "?from_seq=2&to_seq=10",
+			from:        2,
+			to:          10,
+			status:      http.StatusOK,
+			contentType: "application/x-ndjson",
+		},
+		{
+			desc:   "with invalid sequence range",
+			token:  validToken,
+			url:    "?from_seq=10&to_seq=2",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "with invalid from sequence",
+			token:  validToken,
+			url:    "?from_seq=ten",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "with empty token",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "with broken chain",
+			token:  validToken,
+			status: http.StatusConflict,
+			svcErr: journal.ErrChainBroken,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			if c.token == validToken {
+				c.session = mgauthn.Session{
+					UserID:       userID,
+					DomainID:     domainID,
+					DomainUserID: domainID + "_" + userID,
+				}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, c.token).Return(c.session, c.authnErr)
+			svcCall := svc.On("Export", mock.Anything, c.session, c.from, c.to).Return(journal.Export{Proof: journal.Proof{Domain: domainID}}, c.svcErr)
+			req := testRequest{
+				client: es.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/journal/export%s", es.URL, domainID, c.url),
+				token:  c.token,
+			}
+			resp, err := req.make()
+			assert.Nil(t, err, c.desc)
+			defer resp.Body.Close()
+			assert.Equal(t, c.status, resp.StatusCode, c.desc)
+			if c.contentType != "" {
+				assert.Equal(t, c.contentType, resp.Header.Get("Content-Type"), c.desc)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestDownloadEndpoint(t *testing.T) {
+	es, svc, authn := newjournalServer()
+
+	userID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+	journals := []journal.Journal{
+		{
+			ID:         testsutil.GenerateUUID(t),
+			Domain:     domainID,
+			Sequence:   1,
+			Operation:  "thing.create",
+			OccurredAt: time.Now().UTC(),
+			Attributes: map[string]interface{}{"id": testsutil.GenerateUUID(t)},
+			Metadata:   map[string]interface{}{"location": "lab"},
+		},
+	}
+
+	cases := []struct {
+		desc        string
+		token       string
+		session     mgauthn.Session
+		url         string
+		page        journal.Page
+		status      int
+		contentType string
+		lines       int
+		authnErr    error
+		svcErr      error
+	}{
+		{
+			desc:        "download ndjson",
+			token:       validToken,
+			page:        journal.Page{Direction: "desc"},
+			status:      http.StatusOK,
+			contentType: "application/x-ndjson",
+			lines:       1,
+		},
+		{
+			desc:        "download csv",
+			token:       validToken,
+			url:         "?format=csv",
+			page:        journal.Page{Direction: "desc"},
+			status:      http.StatusOK,
+			contentType: "text/csv",
+			lines:       2,
+		},
+		{
+			desc:  "download with filters",
+			token: validToken,
+			url:   "?format=csv&actor=" + userID + "&operation_prefix=thing.&limit=100&dir=asc",
+			page: journal.Page{
+				Limit:           100,
+				Direction:       "asc",
+				Actor:           userID,
+				OperationPrefix: "thing.",
+			},
+			status:      http.StatusOK,
+			contentType: "text/csv",
+			lines:       2,
+		},
+		{
+			desc:   "download with invalid format",
+			token:  validToken,
+			url:    "?format=xml",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "download with empty token",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "download with service error",
+			token:  validToken,
+			page:   journal.Page{Direction: "desc"},
+			status: http.StatusForbidden,
+			svcErr: svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			if c.token == validToken {
+				c.session = mgauthn.Session{
+					UserID:       userID,
+					DomainID:     domainID,
+					DomainUserID: domainID + "_" + userID,
+				}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, c.token).Return(c.session, c.authnErr)
+			svcCall := svc.On("ExportPage", mock.Anything, c.session, c.page, mock.Anything).Return(func(_ context.Context, _ mgauthn.Session, _ journal.Page, fn func(journal.Journal) error) error {
+				if c.svcErr != nil {
+					return c.svcErr
+				}
+				for _, j := range journals {
+					if err := fn(j); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			req := testRequest{
+				client: es.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/journal/download%s", es.URL, domainID, c.url),
+				token:  c.token,
+			}
+			resp, err := req.make()
+			assert.Nil(t, err, c.desc)
+			defer resp.Body.Close()
+			assert.Equal(t, c.status, resp.StatusCode, c.desc)
+			if c.contentType != "" {
+				assert.Equal(t, c.contentType, resp.Header.Get("Content-Type"), c.desc)
+				body, err := io.ReadAll(resp.Body)
+				assert.Nil(t, err, c.desc)
+				assert.Equal(t, c.lines, strings.Count(string(body), "\n"), c.desc)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestSetRetentionEndpoint(t *testing.T) {
+	es, svc, authn := newjournalServer()
+
+	userID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc        string
+		token       string
+		session     mgauthn.Session
+		data        string
+		contentType string
+		days        uint64
+		status      int
+		authnErr    error
+		svcErr      error
+	}{
+		{
+			desc:        "set retention",
+			token:       validToken,
+			data:        `{"days": 90}`,
+			contentType: contentType,
+			days:        90,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "set zero retention",
+			token:       validToken,
+			data:        `{"days": 0}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "set too long retention",
+			token:       validToken,
+			data:        `{"days": 36501}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "set retention with malformed body",
+			token:       validToken,
+			data:        `{"days": "ninety"}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:   "set retention with invalid content type",
+			token:  validToken,
+			data:   `{"days": 90}`,
+			status: http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "set retention with empty token",
+			data:        `{"days": 90}`,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "set retention with service error",
+			token:       validToken,
+			data:        `{"days": 90}`,
+			contentType: contentType,
+			days:        90,
+			status:      http.StatusForbidden,
+			svcErr:      svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			if c.token == validToken {
+				c.session = mgauthn.Session{
+					UserID:       userID,
+					DomainID:     domainID,
+					DomainUserID: domainID + "_" + userID,
+				}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, c.token).Return(c.session, c.authnErr)
+			svcCall := svc.On("SetRetention", mock.Anything, c.session, c.days).Return(journal.Retention{Domain: domainID, Days: c.days}, c.svcErr)
+			req := testRequest{
+				client:      es.Client(),
+				method:      http.MethodPut,
+				url:         fmt.Sprintf("%s/%s/journal/retention", es.URL, domainID),
+				contentType: c.contentType,
+				token:       c.token,
+				body:        strings.NewReader(c.data),
+			}
+			resp, err := req.make()
+			assert.Nil(t, err, c.desc)
+			defer resp.Body.Close()
+			assert.Equal(t, c.status, resp.StatusCode, c.desc)
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestViewRetentionEndpoint(t *testing.T) {
+	es, svc, authn := newjournalServer()
+
+	userID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc     string
+		token    string
+		session  mgauthn.Session
+		status   int
+		authnErr error
+		svcErr   error
+	}{
+		{
+			desc:   "view retention",
+			token:  validToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view missing retention",
+			token:  validToken,
+			status: http.StatusNotFound,
+			svcErr: svcerr.ErrNotFound,
+		},
+		{
+			desc:   "view retention with empty token",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			if c.token == validToken {
+				c.session = mgauthn.Session{
+					UserID:       userID,
+					DomainID:     domainID,
+					DomainUserID: domainID + "_" + userID,
+				}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, c.token).Return(c.session, c.authnErr)
+			svcCall := svc.On("ViewRetention", mock.Anything, c.session).Return(journal.Retention{Domain: domainID, Days: 30}, c.svcErr)
+			req := testRequest{
+				client: es.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/journal/retention", es.URL, domainID),
+				token:  c.token,
+			}
+			resp, err := req.make()
+			assert.Nil(t, err, c.desc)
+			defer resp.Body.Close()
+			assert.Equal(t, c.status, resp.StatusCode, c.desc)
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestRemoveRetentionEndpoint(t *testing.T) {
+	es, svc, authn := newjournalServer()
+
+	userID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc     string
+		token    string
+		session  mgauthn.Session
+		status   int
+		authnErr error
+		svcErr   error
+	}{
+		{
+			desc:   "remove retention",
+			token:  validToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove missing retention",
+			token:  validToken,
+			status: http.StatusNotFound,
+			svcErr: svcerr.ErrNotFound,
+		},
+		{
+			desc:   "remove retention with empty token",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			if c.token == validToken {
+				c.session = mgauthn.Session{
+					UserID:       userID,
+					DomainID:     domainID,
+					DomainUserID: domainID + "_" + userID,
+				}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, c.token).Return(c.session, c.authnErr)
+			svcCall := svc.On("RemoveRetention", mock.Anything, c.session).Return(c.svcErr)
+			req := testRequest{
+				client: es.Client(),
+				method: http.MethodDelete,
+				url:    fmt.Sprintf("%s/%s/journal/retention", es.URL, domainID),
+				token:  c.token,
+			}
+			resp, err := req.make()
+			assert.Nil(t, err, c.desc)
+			defer resp.Body.Close()
+			assert.Equal(t, c.status, resp.StatusCode, c.desc)
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestStreamEndpoint(t *testing.T) {
+	es, svc, authn := newjournalServer()
+
+	userID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+	thingID := testsutil.GenerateUUID(t)
+	j := journal.Journal{
+		ID:         testsutil.GenerateUUID(t),
+		Domain:     domainID,
+		Operation:  "thing.create",
+		OccurredAt: time.Now().UTC(),
+		Attributes: map[string]interface{}{"id": thingID},
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		session  mgauthn.Session
+		url      string
+		page     journal.Page
+		status   int
+		event    string
+		authnErr error
+		svcErr   error
+	}{
+		{
+			desc:   "stream domain journals",
+			token:  validToken,
+			status: http.StatusOK,
+			event:  "id: " + j.ID,
+		},
+		{
+			desc:  "stream entity journals",
+			token: validToken,
+			url:   "?entity_type=thing&id=" + thingID + "&operation_prefix=thing.",
+			page: journal.Page{
+				EntityType:      journal.ThingEntity,
+				EntityID:        thingID,
+				OperationPrefix: "thing.",
+			},
+			status: http.StatusOK,
+			event:  "id: " + j.ID,
+		},
+		{
+			desc:   "stream channel journals",
+			token:  validToken,
+			url:    "?entity_type=channel&id=" + thingID + "&operation=channel.create",
+			page:   journal.Page{EntityType: journal.ChannelEntity, EntityID: thingID, Operation: "group.create"},
+			status: http.StatusOK,
+			event:  "id: " + j.ID,
+		},
+		{
+			desc:   "stream with invalid entity type",
+			token:  validToken,
+			url:    "?entity_type=invalid&id=" + thingID,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "stream with entity ID without entity type",
+			token:  validToken,
+			url:    "?id=" + thingID,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "stream with entity type without entity ID",
+			token:  validToken,
+			url:    "?entity_type=thing",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "stream with empty token",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "stream with service error",
+			token:  validToken,
+			status: http.StatusForbidden,
+			svcErr: svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			if c.token == validToken {
+				c.session = mgauthn.Session{
+					UserID:       userID,
+					DomainID:     domainID,
+					DomainUserID: domainID + "_" + userID,
+				}
+			}
+			// The closed subscription ends the stream after the buffered journal.
+			journals := make(chan journal.Journal, 1)
+			journals <- j
+			close(journals)
+			authCall := authn.On("Authenticate", mock.Anything, c.token).Return(c.session, c.authnErr)
+			svcCall := svc.On("Subscribe", mock.Anything, c.session, c.page).Return((<-chan journal.Journal)(journals), c.svcErr)
+			req := testRequest{
+				client: es.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/journal/stream%s", es.URL, domainID, c.url),
+				token:  c.token,
+			}
+			resp, err := req.make()
+			assert.Nil(t, err, c.desc)
+			defer resp.Body.Close()
+			assert.Equal(t, c.status, resp.StatusCode, c.desc)
+			if c.event != "" {
+				assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"), c.desc)
+				body, err := io.ReadAll(resp.Body)
+				assert.Nil(t, err, c.desc)
+				assert.True(t, strings.HasPrefix(string(body), c.event+"\ndata: "), fmt.Sprintf("%s: unexpected event %s", c.desc, body))
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
@@ -9,6 +9,14 @@ import (
 	"github.com/absmach/magistrala/pkg/apiutil"
 )
 
+const (
+	csvFormat    = "csv"
+	ndjsonFormat = "ndjson"
+
+	// maxRetentionDays is the longest retention period, a hundred years.
+	maxRetentionDays = 36500
+)
+
 type retrieveJournalsReq struct {
 	token string
 	page  journal.Page
@@ -30,3 +38,93 @@ func (req retrieveJournalsReq) validate() error {
 
 	return nil
 }
+
+type verifyReq struct {
+	token string
+}
+
+func (req verifyReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type exportReq struct {
+	token string
+	from  uint64
+	to    uint64
+}
+
+func (req exportReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.to != 0 && req.to < req.from {
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	return nil
+}
+
+type downloadReq struct {
+	token  string
+	format string
+	page   journal.Page
+}
+
+func (req downloadReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.format != csvFormat && req.format != ndjsonFormat {
+		return apiutil.ErrInvalidExportFormat
+	}
+	if req.page.Direction != "" && req.page.Direction != api.AscDir && req.page.Direction != api.DescDir {
+		return apiutil.ErrInvalidDirection
+	}
+
+	return nil
+}
+
+type setRetentionReq struct {
+	token string
+	Days  uint64 `json:"days"`
+}
+
+func (req setRetentionReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.Days == 0 || req.Days > maxRetentionDays {
+		return apiutil.ErrInvalidRetention
+	}
+
+	return nil
+}
+
+type retentionReq struct {
+	token string
+}
+
+func (req retentionReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type streamReq struct {
+	token string
+	page  journal.Page
+}
+
+func (req streamReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
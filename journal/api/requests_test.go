@@ -124,3 +124,106 @@ func TestRetrieveJournalsReqValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestDownloadReqValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  downloadReq
+		err  error
+	}{
+		{
+			desc: "valid csv",
+			req: downloadReq{
+				token:  token,
+				format: csvFormat,
+			},
+			err: nil,
+		},
+		{
+			desc: "valid ndjson",
+			req: downloadReq{
+				token:  token,
+				format: ndjsonFormat,
+				page:   journal.Page{Direction: "asc"},
+			},
+			err: nil,
+		},
+		{
+			desc: "empty token",
+			req: downloadReq{
+				format: csvFormat,
+			},
+			err: apiutil.ErrBearerToken,
+		},
+		{
+			desc: "invalid format",
+			req: downloadReq{
+				token:  token,
+				format: "xml",
+			},
+			err: apiutil.ErrInvalidExportFormat,
+		},
+		{
+			desc: "invalid direction",
+			req: downloadReq{
+				token:  token,
+				format: csvFormat,
+				page:   journal.Page{Direction: "invalid"},
+			},
+			err: apiutil.ErrInvalidDirection,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			err := c.req.validate()
+			assert.Equal(t, c.err, err)
+		})
+	}
+}
+
+func TestSetRetentionReqValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  setRetentionReq
+		err  error
+	}{
+		{
+			desc: "valid",
+			req: setRetentionReq{
+				token: token,
+				Days:  90,
+			},
+			err: nil,
+		},
+		{
+			desc: "empty token",
+			req: setRetentionReq{
+				Days: 90,
+			},
+			err: apiutil.ErrBearerToken,
+		},
+		{
+			desc: "zero days",
+			req: setRetentionReq{
+				token: token,
+			},
+			err: apiutil.ErrInvalidRetention,
+		},
+		{
+			desc: "too many days",
+			req: setRetentionReq{
+				token: token,
+				Days:  maxRetentionDays + 1,
+			},
+			err: apiutil.ErrInvalidRetention,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			err := c.req.validate()
+			assert.Equal(t, c.err, err)
+		})
+	}
+}
@@ -10,7 +10,12 @@ import (
 	"github.com/absmach/magistrala/journal"
 )
 
-var _ magistrala.Response = (*pageRes)(nil)
+var (
+	_ magistrala.Response = (*pageRes)(nil)
+	_ magistrala.Response = (*verifyRes)(nil)
+	_ magistrala.Response = (*retentionRes)(nil)
+	_ magistrala.Response = (*removeRetentionRes)(nil)
+)
 
 type pageRes struct {
 	journal.JournalsPage `json:",inline"`
@@ -27,3 +32,61 @@ func (res pageRes) Code() int {
 func (res pageRes) Empty() bool {
 	return false
 }
+
+type verifyRes struct {
+	journal.Verification `json:",inline"`
+}
+
+func (res verifyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res verifyRes) Code() int {
+	return http.StatusOK
+}
+
+func (res verifyRes) Empty() bool {
+	return false
+}
+
+// exportRes is encoded as NDJSON by encodeExportResponse.
+type exportRes struct {
+	journal.Export
+}
+
+// downloadRes is encoded as CSV or NDJSON by encodeDownloadResponse.
+// Journals are retrieved while the response is written.
+type downloadRes struct {
+	format string
+	export func(fn func(journal.Journal) error) error
+}
+
+type retentionRes struct {
+	journal.Retention `json:",inline"`
+}
+
+func (res retentionRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res retentionRes) Code() int {
+	return http.StatusOK
+}
+
+func (res retentionRes) Empty() bool {
+	return false
+}
+
+type removeRetentionRes struct{}
+
+func (res removeRetentionRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeRetentionRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeRetentionRes) Empty() bool {
+	return true
+}
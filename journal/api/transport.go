@@ -5,9 +5,14 @@ package api
 
 import (
 	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
 	"log/slog"
 	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,6 +22,7 @@ import (
 	"github.com/absmach/magistrala/pkg/apiutil"
 	mgauthn "github.com/absmach/magistrala/pkg/authn"
 	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/go-chi/chi/v5"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -31,8 +37,26 @@ const (
 	metadataKey   = "with_metadata"
 	entityIDKey   = "id"
 	entityTypeKey = "entity_type"
+	fromSeqKey    = "from_seq"
+	toSeqKey      = "to_seq"
+	actorKey      = "actor"
+	prefixKey     = "operation_prefix"
+	filterKey     = "attributes"
+	searchKey     = "search"
+	formatKey     = "format"
+
+	ndjsonContentType = "application/x-ndjson"
+	csvContentType    = "text/csv"
+	ctEventStream     = "text/event-stream"
+
+	// keepAliveInterval is the interval of the comments sent over the idle
+	// event stream, so that the intermediaries do not close the connection.
+	keepAliveInterval = 30 * time.Second
 )
 
+// csvHeader lists the columns of the CSV export.
+var csvHeader = []string{"id", "domain", "sequence", "operation", "occurred_at", "attributes", "metadata"}
+
 // MakeHandler returns a HTTP API handler with health check and metrics.
 func MakeHandler(svc journal.Service, authn mgauthn.Authentication, logger *slog.Logger, svcName, instanceID string) http.Handler {
 	opts := []kithttp.ServerOption{
@@ -55,6 +79,54 @@ func MakeHandler(svc journal.Service, authn mgauthn.Authentication, logger *slog
 		opts...,
 	), "list__entity_journals").ServeHTTP)
 
+	mux.With(api.AuthenticateMiddleware(authn, true)).Get("/{domainID}/journal/verify", otelhttp.NewHandler(kithttp.NewServer(
+		verifyEndpoint(svc),
+		decodeVerifyReq,
+		api.EncodeResponse,
+		opts...,
+	), "verify_journal_chain").ServeHTTP)
+
+	mux.With(api.AuthenticateMiddleware(authn, true)).Get("/{domainID}/journal/export", otelhttp.NewHandler(kithttp.NewServer(
+		exportEndpoint(svc),
+		decodeExportReq,
+		encodeExportResponse,
+		opts...,
+	), "export_journals").ServeHTTP)
+
+	mux.With(api.AuthenticateMiddleware(authn, true)).Get("/{domainID}/journal/download", otelhttp.NewHandler(kithttp.NewServer(
+		downloadEndpoint(svc),
+		decodeDownloadReq,
+		encodeDownloadResponse,
+		opts...,
+	), "download_journals").ServeHTTP)
+
+	mux.With(api.AuthenticateMiddleware(authn, true)).Get("/{domainID}/journal/stream", otelhttp.NewHandler(
+		streamJournals(svc, apiutil.LoggingErrorEncoder(logger, api.EncodeError)), "stream_journals",
+	).ServeHTTP)
+
+	mux.With(api.AuthenticateMiddleware(authn, true)).Route("/{domainID}/journal/retention", func(r chi.Router) {
+		r.Put("/", otelhttp.NewHandler(kithttp.NewServer(
+			setRetentionEndpoint(svc),
+			decodeSetRetentionReq,
+			api.EncodeResponse,
+			opts...,
+		), "set_journal_retention").ServeHTTP)
+
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			viewRetentionEndpoint(svc),
+			decodeRetentionReq,
+			api.EncodeResponse,
+			opts...,
+		), "view_journal_retention").ServeHTTP)
+
+		r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+			removeRetentionEndpoint(svc),
+			decodeRetentionReq,
+			api.EncodeResponse,
+			opts...,
+		), "remove_journal_retention").ServeHTTP)
+	})
+
 	mux.Get("/health", magistrala.Health(svcName, instanceID))
 	mux.Handle("/metrics", promhttp.Handler())
 
@@ -101,6 +173,294 @@ func decodeRetrieveUserJournalReq(_ context.Context, r *http.Request) (interface
 	return req, nil
 }
 
+func decodeVerifyReq(_ context.Context, r *http.Request) (interface{}, error) {
+	req := verifyReq{
+		token: apiutil.ExtractBearerToken(r),
+	}
+
+	return req, nil
+}
+
+func decodeExportReq(_ context.Context, r *http.Request) (interface{}, error) {
+	from, err := apiutil.ReadNumQuery[uint64](r, fromSeqKey, 0)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	to, err := apiutil.ReadNumQuery[uint64](r, toSeqKey, 0)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := exportReq{
+		token: apiutil.ExtractBearerToken(r),
+		from:  from,
+		to:    to,
+	}
+
+	return req, nil
+}
+
+func encodeExportResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res := response.(exportRes)
+	w.Header().Set("Content-Type", ndjsonContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"journal-%s-%d-%d.ndjson\"", res.Proof.Domain, res.Proof.FirstSequence, res.Proof.LastSequence))
+	w.WriteHeader(http.StatusOK)
+
+	return res.Encode(w)
+}
+
+func decodeDownloadReq(_ context.Context, r *http.Request) (interface{}, error) {
+	page, err := decodePageQuery(r)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	// Downloads are not limited unless the limit is provided.
+	if page.Limit, err = apiutil.ReadNumQuery[uint64](r, api.LimitKey, 0); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	format, err := apiutil.ReadStringQuery(r, formatKey, ndjsonFormat)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := downloadReq{
+		token:  apiutil.ExtractBearerToken(r),
+		format: format,
+		page:   page,
+	}
+
+	return req, nil
+}
+
+// streamJournals pushes the saved journals to the client as Server-Sent Events.
+func streamJournals(svc journal.Service, encodeError kithttp.ErrorEncoder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			encodeError(ctx, errors.New("streaming is not supported"), w)
+			return
+		}
+
+		request, err := decodeStreamReq(ctx, r)
+		if err != nil {
+			encodeError(ctx, err, w)
+			return
+		}
+		req := request.(streamReq)
+		if err := req.validate(); err != nil {
+			encodeError(ctx, errors.Wrap(apiutil.ErrValidation, err), w)
+			return
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(mgauthn.Session)
+		if !ok {
+			encodeError(ctx, svcerr.ErrAuthorization, w)
+			return
+		}
+
+		journals, err := svc.Subscribe(ctx, session, req.page)
+		if err != nil {
+			encodeError(ctx, err, w)
+			return
+		}
+
+		w.Header().Set("Content-Type", ctEventStream)
+		w.Header().Set("Cache-Control", "no-cache")
+		// Disables response buffering by the nginx reverse proxy.
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		ticker := time.NewTicker(keepAliveInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+					return
+				}
+			case j, ok := <-journals:
+				// The subscription is closed if the client falls behind, so
+				// the stream ends and the client reconnects.
+				if !ok {
+					return
+				}
+				if err := encodeEvent(w, j); err != nil {
+					return
+				}
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// encodeEvent writes the journal as the Server-Sent Event
+// identified by the journal ID.
+func encodeEvent(w io.Writer, j journal.Journal) error {
+	data, err := json.Marshal(j)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\ndata: %s\n\n", j.ID, data)
+
+	return err
+}
+
+func decodeStreamReq(_ context.Context, r *http.Request) (interface{}, error) {
+	operation, err := apiutil.ReadStringQuery(r, operationKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	prefix, err := apiutil.ReadStringQuery(r, prefixKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	entityType, err := apiutil.ReadStringQuery(r, entityTypeKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	entityID, err := apiutil.ReadStringQuery(r, entityIDKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	page := journal.Page{
+		Operation:       operation,
+		OperationPrefix: prefix,
+		EntityID:        entityID,
+	}
+	// Without the entity, journals of the whole domain are streamed.
+	if entityType != "" || entityID != "" {
+		if page.EntityType, err = journal.ToEntityType(entityType); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		if entityID == "" {
+			return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingID)
+		}
+	}
+	if page.EntityType == journal.ChannelEntity {
+		page.Operation = strings.ReplaceAll(page.Operation, "channel", "group")
+	}
+
+	req := streamReq{
+		token: apiutil.ExtractBearerToken(r),
+		page:  page,
+	}
+
+	return req, nil
+}
+
+func decodeSetRetentionReq(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := setRetentionReq{
+		token: apiutil.ExtractBearerToken(r),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeRetentionReq(_ context.Context, r *http.Request) (interface{}, error) {
+	req := retentionReq{
+		token: apiutil.ExtractBearerToken(r),
+	}
+
+	return req, nil
+}
+
+// encodeDownloadResponse streams journals as they are retrieved. Headers
+// are written with the first journal, so errors which occur before that are
+// still encoded as the error response.
+func encodeDownloadResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res := response.(downloadRes)
+	jw := &journalWriter{w: w, format: res.format}
+	if err := res.export(jw.write); err != nil {
+		if !jw.started {
+			return err
+		}
+		// The response is already partially written, so the connection is
+		// aborted to let the client know the download is incomplete.
+		panic(http.ErrAbortHandler)
+	}
+
+	return jw.close()
+}
+
+type journalWriter struct {
+	w       http.ResponseWriter
+	format  string
+	started bool
+	csv     *csv.Writer
+	json    *json.Encoder
+}
+
+func (jw *journalWriter) start() error {
+	jw.started = true
+	contentType, ext := ndjsonContentType, ndjsonFormat
+	if jw.format == csvFormat {
+		contentType, ext = csvContentType, csvFormat
+	}
+	jw.w.Header().Set("Content-Type", contentType)
+	jw.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"journal.%s\"", ext))
+	jw.w.WriteHeader(http.StatusOK)
+
+	if jw.format == csvFormat {
+		jw.csv = csv.NewWriter(jw.w)
+		return jw.csv.Write(csvHeader)
+	}
+	jw.json = json.NewEncoder(jw.w)
+
+	return nil
+}
+
+func (jw *journalWriter) write(j journal.Journal) error {
+	if !jw.started {
+		if err := jw.start(); err != nil {
+			return err
+		}
+	}
+	if jw.format != csvFormat {
+		return jw.json.Encode(j)
+	}
+
+	attributes, err := json.Marshal(j.Attributes)
+	if err != nil {
+		return err
+	}
+	metadata, err := json.Marshal(j.Metadata)
+	if err != nil {
+		return err
+	}
+	var sequence string
+	if j.Sequence > 0 {
+		sequence = strconv.FormatUint(j.Sequence, 10)
+	}
+
+	return jw.csv.Write([]string{j.ID, j.Domain, sequence, j.Operation, j.OccurredAt.UTC().Format(time.RFC3339Nano), string(attributes), string(metadata)})
+}
+
+func (jw *journalWriter) close() error {
+	if !jw.started {
+		if err := jw.start(); err != nil {
+			return err
+		}
+	}
+	if jw.csv != nil {
+		jw.csv.Flush()
+		return jw.csv.Error()
+	}
+
+	return nil
+}
+
 func decodePageQuery(r *http.Request) (journal.Page, error) {
 	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
 	if err != nil {
@@ -148,15 +508,35 @@ func decodePageQuery(r *http.Request) (journal.Page, error) {
 	if err != nil {
 		return journal.Page{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
+	actor, err := apiutil.ReadStringQuery(r, actorKey, "")
+	if err != nil {
+		return journal.Page{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	prefix, err := apiutil.ReadStringQuery(r, prefixKey, "")
+	if err != nil {
+		return journal.Page{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	filter, err := apiutil.ReadMetadataQuery(r, filterKey, nil)
+	if err != nil {
+		return journal.Page{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	search, err := apiutil.ReadStringQuery(r, searchKey, "")
+	if err != nil {
+		return journal.Page{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
 
 	return journal.Page{
-		Offset:         offset,
-		Limit:          limit,
-		Operation:      operation,
-		From:           fromTime,
-		To:             toTime,
-		WithAttributes: attributes,
-		WithMetadata:   metadata,
-		Direction:      dir,
+		Offset:          offset,
+		Limit:           limit,
+		Operation:       operation,
+		From:            fromTime,
+		To:              toTime,
+		WithAttributes:  attributes,
+		WithMetadata:    metadata,
+		Direction:       dir,
+		Actor:           actor,
+		OperationPrefix: prefix,
+		Attributes:      filter,
+		Search:          search,
 	}, nil
 }
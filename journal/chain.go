@@ -0,0 +1,137 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package journal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// Reasons of the chain verification failure.
+const (
+	// MissingEntry indicates a gap in the chain sequence.
+	MissingEntry = "missing entry"
+	// BrokenLink indicates the entry is not linked to the previous one.
+	BrokenLink = "broken link"
+	// HashMismatch indicates the entry is modified after it's been chained.
+	HashMismatch = "hash mismatch"
+	// CheckpointMismatch indicates the chain differs from the checkpointed one.
+	CheckpointMismatch = "checkpoint mismatch"
+	// Truncated indicates the checkpointed entries are missing from the chain end.
+	Truncated = "truncated chain"
+	// InvalidCheckpoint indicates the checkpoint signature doesn't match.
+	InvalidCheckpoint = "invalid checkpoint"
+)
+
+// ErrChainBroken indicates the journal chain failed verification.
+var ErrChainBroken = errors.New("journal chain is broken")
+
+// Verification represents the result of the domain journal chain verification.
+// Verification starts after the last archived journal and checks the chain
+// against the last checkpoint.
+type Verification struct {
+	Domain             string `json:"domain"`
+	Valid              bool   `json:"valid"`
+	Entries            uint64 `json:"entries"`
+	ArchivedSequence   uint64 `json:"archived_sequence,omitempty"`
+	CheckpointSequence uint64 `json:"checkpoint_sequence,omitempty"`
+	HeadSequence       uint64 `json:"head_sequence"`
+	HeadHash           string `json:"head_hash,omitempty"`
+	BrokenAt           uint64 `json:"broken_at,omitempty"`
+	Reason             string `json:"reason,omitempty"`
+}
+
+// ComputeHash returns the hex encoded SHA-256 hash of the journal linked to
+// the previous journal of the chain. Attributes and metadata are normalized
+// the way they are stored, so the hash of a retrieved journal matches the
+// hash of the saved one.
+func (j Journal) ComputeHash() (string, error) {
+	attributes, err := normalize(j.Attributes)
+	if err != nil {
+		return "", err
+	}
+	metadata, err := normalize(j.Metadata)
+	if err != nil {
+		return "", err
+	}
+	entry := struct {
+		Domain     string                 `json:"domain"`
+		Sequence   uint64                 `json:"sequence"`
+		PrevHash   string                 `json:"prev_hash"`
+		ID         string                 `json:"id"`
+		Operation  string                 `json:"operation"`
+		OccurredAt string                 `json:"occurred_at"`
+		Attributes map[string]interface{} `json:"attributes"`
+		Metadata   map[string]interface{} `json:"metadata"`
+	}{
+		Domain:     j.Domain,
+		Sequence:   j.Sequence,
+		PrevHash:   j.PrevHash,
+		ID:         j.ID,
+		Operation:  j.Operation,
+		OccurredAt: j.OccurredAt.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+		Attributes: attributes,
+		Metadata:   metadata,
+	}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return "", errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	sum := sha256.Sum256(b)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func normalize(m map[string]interface{}) (map[string]interface{}, error) {
+	ret := map[string]interface{}{}
+	if len(m) == 0 {
+		return ret, nil
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	if err := json.Unmarshal(b, &ret); err != nil {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return ret, nil
+}
+
+// chain walks consecutive journals of the domain chain. The journal at the
+// checkpoint sequence must match the checkpoint hash.
+type chain struct {
+	sequence   uint64
+	hash       string
+	checkpoint Checkpoint
+}
+
+// next checks whether the journal is the next valid link of the chain and
+// returns the failure reason if it's not.
+func (c *chain) next(j Journal) (string, error) {
+	if j.Sequence != c.sequence+1 {
+		return MissingEntry, nil
+	}
+	if j.PrevHash != c.hash {
+		return BrokenLink, nil
+	}
+	hash, err := j.ComputeHash()
+	if err != nil {
+		return "", err
+	}
+	if hash != j.Hash {
+		return HashMismatch, nil
+	}
+	if j.Sequence == c.checkpoint.Sequence && j.Hash != c.checkpoint.HeadHash {
+		return CheckpointMismatch, nil
+	}
+	c.sequence = j.Sequence
+	c.hash = j.Hash
+
+	return "", nil
+}
@@ -0,0 +1,204 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package journal
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+)
+
+// Checkpoint represents the signed head of the domain chain. Chain hashes can
+// be recomputed by anyone who can write to the journal database, so the
+// checkpoints are kept outside of it. Verification compares the chain to the
+// last checkpoint, which detects rewritten and truncated chains.
+type Checkpoint struct {
+	Domain    string    `json:"domain"`
+	Sequence  uint64    `json:"sequence"`
+	HeadHash  string    `json:"head_hash"`
+	CreatedAt time.Time `json:"created_at"`
+	PublicKey []byte    `json:"public_key,omitempty"`
+	Signature []byte    `json:"signature,omitempty"`
+}
+
+// Sign signs the checkpoint using the private key.
+func (cp *Checkpoint) Sign(key ed25519.PrivateKey) error {
+	cp.PublicKey = key.Public().(ed25519.PublicKey)
+	msg, err := cp.message()
+	if err != nil {
+		return err
+	}
+	cp.Signature = ed25519.Sign(key, msg)
+
+	return nil
+}
+
+// Verify checks the checkpoint signature using the trusted public key.
+func (cp Checkpoint) Verify(key ed25519.PublicKey) error {
+	msg, err := cp.message()
+	if err != nil {
+		return err
+	}
+	if len(key) != ed25519.PublicKeySize || !ed25519.Verify(key, msg, cp.Signature) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (cp Checkpoint) message() ([]byte, error) {
+	cp.Signature = nil
+	b, err := json.Marshal(cp)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return b, nil
+}
+
+// Checkpoints specifies the checkpoint storage API. The storage must not be
+// writable by the journal database administrators.
+//
+//go:generate mockery --name Checkpoints --output=./mocks --filename checkpoints.go --quiet --note "Copyright (c) Abstract Machines"
+type Checkpoints interface {
+	// Save stores the checkpoint. Existing checkpoints are never replaced.
+	Save(ctx context.Context, checkpoint Checkpoint) error
+
+	// RetrieveLast retrieves the latest checkpoint of the domain chain.
+	RetrieveLast(ctx context.Context, domain string) (Checkpoint, error)
+}
+
+type checkpointHandler struct {
+	repository  Repository
+	checkpoints Checkpoints
+	signingKey  ed25519.PrivateKey
+	interval    time.Duration
+	logger      *slog.Logger
+}
+
+// NewCheckpointHandler starts the job which periodically checkpoints the
+// heads of the domain chains. The chain is verified from the previous
+// checkpoint up to the head first, so the modified chain is never
+// checkpointed. Checkpoints are signed if the signing key is provided.
+func NewCheckpointHandler(ctx context.Context, repository Repository, checkpoints Checkpoints, signingKey ed25519.PrivateKey, interval time.Duration, logger *slog.Logger) {
+	handler := &checkpointHandler{
+		repository:  repository,
+		checkpoints: checkpoints,
+		signingKey:  signingKey,
+		interval:    interval,
+		logger:      logger,
+	}
+
+	go func() {
+		ticker := time.NewTicker(handler.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				handler.handle(ctx)
+			}
+		}
+	}()
+}
+
+func (h *checkpointHandler) handle(ctx context.Context) {
+	heads, err := h.repository.RetrieveHeads(ctx)
+	if err != nil {
+		h.logger.Error("failed to retrieve journal chain heads", slog.Any("error", err))
+		return
+	}
+
+	for _, head := range heads {
+		cp, err := checkpoint(ctx, h.repository, h.checkpoints, h.signingKey, head)
+		if err != nil {
+			h.logger.Error("failed to checkpoint journal chain", slog.String("domain", head.Domain), slog.Any("error", err))
+			continue
+		}
+		if cp.Sequence == 0 {
+			continue
+		}
+		// Checkpoints are logged as well, so they can be collected by
+		// the log aggregation independently of the checkpoint storage.
+		h.logger.Info("journal chain checkpointed", slog.Group("checkpoint",
+			slog.String("domain", cp.Domain),
+			slog.Uint64("sequence", cp.Sequence),
+			slog.String("head_hash", cp.HeadHash),
+		))
+	}
+}
+
+// checkpoint verifies the domain chain from the last checkpoint up to the head
+// and saves the new checkpoint. Zero checkpoint is returned if the head is
+// already checkpointed.
+func checkpoint(ctx context.Context, repo Repository, checkpoints Checkpoints, signingKey ed25519.PrivateKey, head Checkpoint) (Checkpoint, error) {
+	last, err := checkpoints.RetrieveLast(ctx, head.Domain)
+	if err != nil && !errors.Contains(err, repoerr.ErrNotFound) {
+		return Checkpoint{}, err
+	}
+	switch {
+	case head.Sequence < last.Sequence:
+		return Checkpoint{}, errors.Wrap(ErrChainBroken, fmt.Errorf("%s after sequence %d", Truncated, head.Sequence))
+	case head.Sequence == last.Sequence && head.HeadHash == last.HeadHash:
+		return Checkpoint{}, nil
+	case head.Sequence == last.Sequence:
+		return Checkpoint{}, errors.Wrap(ErrChainBroken, fmt.Errorf("%s at sequence %d", CheckpointMismatch, last.Sequence))
+	}
+
+	c, err := anchor(ctx, repo, head.Domain)
+	if err != nil {
+		return Checkpoint{}, err
+	}
+	// Journals up to the last checkpoint are already verified, unless
+	// they are archived since.
+	switch {
+	case last.Sequence > c.sequence:
+		c = chain{sequence: last.Sequence, hash: last.HeadHash}
+	case last.Sequence == c.sequence && last.HeadHash != c.hash:
+		return Checkpoint{}, errors.Wrap(ErrChainBroken, fmt.Errorf("%s at sequence %d", CheckpointMismatch, last.Sequence))
+	}
+	for c.sequence < head.Sequence {
+		batch, err := repo.RetrieveChain(ctx, head.Domain, c.sequence+1, chainBatch)
+		if err != nil {
+			return Checkpoint{}, err
+		}
+		if len(batch) == 0 {
+			return Checkpoint{}, errors.Wrap(ErrChainBroken, fmt.Errorf("%s at sequence %d", MissingEntry, c.sequence+1))
+		}
+		for _, j := range batch {
+			reason, err := c.next(j)
+			if err != nil {
+				return Checkpoint{}, err
+			}
+			if reason != "" {
+				return Checkpoint{}, errors.Wrap(ErrChainBroken, fmt.Errorf("%s at sequence %d", reason, c.sequence+1))
+			}
+		}
+	}
+
+	cp := Checkpoint{
+		Domain:    head.Domain,
+		Sequence:  c.sequence,
+		HeadHash:  c.hash,
+		CreatedAt: time.Now().UTC(),
+	}
+	if signingKey != nil {
+		if err := cp.Sign(signingKey); err != nil {
+			return Checkpoint{}, err
+		}
+	}
+	if err := checkpoints.Save(ctx, cp); err != nil {
+		return Checkpoint{}, err
+	}
+
+	return cp, nil
+}
@@ -0,0 +1,81 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package journal_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/journal"
+	"github.com/absmach/magistrala/journal/mocks"
+	mglog "github.com/absmach/magistrala/logger"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCheckpointHandler(t *testing.T) {
+	repo := new(mocks.Repository)
+	checkpoints := new(mocks.Checkpoints)
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err, fmt.Sprintf("generating key expected to succeed: %s", err))
+
+	domainID := testsutil.GenerateUUID(t)
+	chain := newChain(t, domainID, 3)
+	// Rewritten domain chain doesn't match its last checkpoint, so it's
+	// never checkpointed again.
+	rewrittenID := testsutil.GenerateUUID(t)
+	rewritten := newChain(t, rewrittenID, 3)
+
+	heads := []journal.Checkpoint{
+		{Domain: domainID, Sequence: 3, HeadHash: chain[2].Hash},
+		{Domain: rewrittenID, Sequence: 3, HeadHash: rewritten[2].Hash},
+	}
+	repo.On("RetrieveHeads", mock.Anything).Return(heads, nil)
+	repo.On("RetrieveLastArchive", mock.Anything, mock.Anything).Return(journal.Archive{}, repoerr.ErrNotFound)
+	repo.On("RetrieveChain", mock.Anything, domainID, uint64(2), mock.Anything).Return(chain[1:], nil)
+	repo.On("RetrieveChain", mock.Anything, rewrittenID, uint64(2), mock.Anything).Return(rewritten[1:], nil)
+	checkpoints.On("RetrieveLast", mock.Anything, domainID).Return(newCheckpoint(t, key, domainID, 1, chain[0].Hash), nil)
+	checkpoints.On("RetrieveLast", mock.Anything, rewrittenID).Return(newCheckpoint(t, key, rewrittenID, 1, chain[0].Hash), nil)
+
+	saved := make(chan journal.Checkpoint, 2)
+	checkpoints.On("Save", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		select {
+		case saved <- args.Get(1).(journal.Checkpoint):
+		default:
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	journal.NewCheckpointHandler(ctx, repo, checkpoints, key, 10*time.Millisecond, mglog.NewMock())
+
+	select {
+	case cp := <-saved:
+		assert.Equal(t, domainID, cp.Domain)
+		assert.Equal(t, uint64(3), cp.Sequence)
+		assert.Equal(t, chain[2].Hash, cp.HeadHash)
+		err := cp.Verify(pub)
+		assert.Nil(t, err, fmt.Sprintf("verifying checkpoint expected to succeed: %s", err))
+	case <-time.After(time.Second):
+		t.Fatal("journal chain is not checkpointed")
+	}
+
+	// Let the handler run a few more times.
+	timeout := time.After(100 * time.Millisecond)
+	for {
+		select {
+		case cp := <-saved:
+			assert.NotEqual(t, rewrittenID, cp.Domain, "rewritten journal chain is checkpointed")
+		case <-timeout:
+			cancel()
+			return
+		}
+	}
+}
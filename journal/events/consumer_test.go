@@ -51,7 +51,7 @@ func NewTestEvent(data map[string]interface{}, err error) testEvent {
 
 func TestHandle(t *testing.T) {
 	repo := new(mocks.Repository)
-	svc := journal.NewService(idProvider, repo)
+	svc := journal.NewService(idProvider, repo, new(mocks.Checkpoints), journal.NewHub(), nil)
 
 	cases := []struct {
 		desc      string
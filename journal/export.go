@@ -0,0 +1,152 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package journal
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+var (
+	// ErrMissingSigningKey indicates the service is not configured to sign exports.
+	ErrMissingSigningKey = errors.New("journal signing key is not configured")
+
+	// ErrInvalidExport indicates a malformed journal export.
+	ErrInvalidExport = errors.New("invalid journal export")
+
+	// ErrInvalidSignature indicates the export proof signature doesn't match.
+	ErrInvalidSignature = errors.New("invalid journal export signature")
+)
+
+// Proof binds the exported journals to the domain chain. It is signed by
+// the journal service, so the export can be verified offline.
+type Proof struct {
+	Domain        string    `json:"domain"`
+	FirstSequence uint64    `json:"first_sequence"`
+	LastSequence  uint64    `json:"last_sequence"`
+	PrevHash      string    `json:"prev_hash"`
+	HeadHash      string    `json:"head_hash"`
+	Entries       uint64    `json:"entries"`
+	ExportedAt    time.Time `json:"exported_at"`
+	PublicKey     []byte    `json:"public_key"`
+	Signature     []byte    `json:"signature,omitempty"`
+}
+
+// Sign signs the proof using the private key.
+func (p *Proof) Sign(key ed25519.PrivateKey) error {
+	p.PublicKey = key.Public().(ed25519.PublicKey)
+	msg, err := p.message()
+	if err != nil {
+		return err
+	}
+	p.Signature = ed25519.Sign(key, msg)
+
+	return nil
+}
+
+// Verify checks the proof signature using the trusted public key.
+func (p Proof) Verify(key ed25519.PublicKey) error {
+	msg, err := p.message()
+	if err != nil {
+		return err
+	}
+	if len(key) != ed25519.PublicKeySize || !ed25519.Verify(key, msg, p.Signature) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (p Proof) message() ([]byte, error) {
+	p.Signature = nil
+	b, err := json.Marshal(p)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidExport, err)
+	}
+
+	return b, nil
+}
+
+// Export represents the signed export bundle of the domain journals.
+type Export struct {
+	Journals []Journal
+	Proof    Proof
+}
+
+// exportLine is a single line of the NDJSON export. Each journal is written
+// in its own line and the proof is written last.
+type exportLine struct {
+	Journal
+	Proof *Proof `json:"proof,omitempty"`
+}
+
+// Encode writes the export as NDJSON.
+func (e Export) Encode(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for _, j := range e.Journals {
+		if err := enc.Encode(j); err != nil {
+			return err
+		}
+	}
+
+	return enc.Encode(struct {
+		Proof Proof `json:"proof"`
+	}{e.Proof})
+}
+
+// VerifyExport reads the NDJSON export and verifies both the proof signature
+// and the chain of the exported journals.
+func VerifyExport(r io.Reader, key ed25519.PublicKey) (Proof, error) {
+	var journals []Journal
+	var proof *Proof
+
+	dec := json.NewDecoder(r)
+	for dec.More() {
+		if proof != nil {
+			return Proof{}, errors.Wrap(ErrInvalidExport, errors.New("proof is not the last line"))
+		}
+		var line exportLine
+		if err := dec.Decode(&line); err != nil {
+			return Proof{}, errors.Wrap(ErrInvalidExport, err)
+		}
+		if line.Proof != nil {
+			proof = line.Proof
+			continue
+		}
+		journals = append(journals, line.Journal)
+	}
+	if proof == nil {
+		return Proof{}, errors.Wrap(ErrInvalidExport, errors.New("missing proof"))
+	}
+	if err := proof.Verify(key); err != nil {
+		return Proof{}, err
+	}
+	if uint64(len(journals)) != proof.Entries || proof.FirstSequence == 0 {
+		return Proof{}, errors.Wrap(ErrInvalidExport, errors.New("entries don't match the proof"))
+	}
+
+	c := chain{sequence: proof.FirstSequence - 1, hash: proof.PrevHash}
+	for _, j := range journals {
+		if j.Domain != proof.Domain {
+			return Proof{}, errors.Wrap(ErrInvalidExport, fmt.Errorf("journal %s doesn't belong to domain %s", j.ID, proof.Domain))
+		}
+		reason, err := c.next(j)
+		if err != nil {
+			return Proof{}, errors.Wrap(ErrInvalidExport, err)
+		}
+		if reason != "" {
+			return Proof{}, errors.Wrap(ErrChainBroken, fmt.Errorf("%s at sequence %d", reason, c.sequence+1))
+		}
+	}
+	if c.sequence != proof.LastSequence || c.hash != proof.HeadHash {
+		return Proof{}, errors.Wrap(ErrChainBroken, errors.New("head doesn't match the proof"))
+	}
+
+	return *proof, nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package journal_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/journal"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyExport(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err, fmt.Sprintf("generating key expected to succeed: %s", err))
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err, fmt.Sprintf("generating key expected to succeed: %s", err))
+
+	domainID := testsutil.GenerateUUID(t)
+	chain := newChain(t, domainID, 3)
+	proof := journal.Proof{
+		Domain:        domainID,
+		FirstSequence: 1,
+		LastSequence:  3,
+		HeadHash:      chain[2].Hash,
+		Entries:       3,
+	}
+	err = proof.Sign(key)
+	assert.Nil(t, err, fmt.Sprintf("signing proof expected to succeed: %s", err))
+
+	var buf bytes.Buffer
+	err = journal.Export{Journals: chain, Proof: proof}.Encode(&buf)
+	assert.Nil(t, err, fmt.Sprintf("encoding export expected to succeed: %s", err))
+	export := buf.String()
+	lines := strings.SplitAfter(export, "\n")
+
+	cases := []struct {
+		desc   string
+		export string
+		key    ed25519.PublicKey
+		err    error
+	}{
+		{
+			desc:   "valid export",
+			export: export,
+			key:    pub,
+		},
+		{
+			desc:   "export signed with other key",
+			export: export,
+			key:    otherPub,
+			err:    journal.ErrInvalidSignature,
+		},
+		{
+			desc:   "export with modified journal",
+			export: strings.Replace(export, chain[1].Operation, "user.remove", 1),
+			key:    pub,
+			err:    journal.ErrChainBroken,
+		},
+		{
+			desc:   "export with removed journal",
+			export: lines[0] + lines[2] + lines[3],
+			key:    pub,
+			err:    journal.ErrInvalidExport,
+		},
+		{
+			desc:   "export with modified proof",
+			export: strings.Replace(export, `"entries":3`, `"entries":2`, 1),
+			key:    pub,
+			err:    journal.ErrInvalidSignature,
+		},
+		{
+			desc:   "export without proof",
+			export: lines[0] + lines[1] + lines[2],
+			key:    pub,
+			err:    journal.ErrInvalidExport,
+		},
+		{
+			desc:   "malformed export",
+			export: "{",
+			key:    pub,
+			err:    journal.ErrInvalidExport,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := journal.VerifyExport(strings.NewReader(tc.export), tc.key)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, proof.HeadHash, resp.HeadHash, tc.desc)
+			}
+		})
+	}
+}
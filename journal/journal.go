@@ -20,14 +20,24 @@ const (
 	GroupEntity
 	ThingEntity
 	ChannelEntity
+	DomainEntity
+	KeyEntity
+	CertificateEntity
+	InvitationEntity
+	BootstrapEntity
 )
 
 // String representation of the possible entity type values.
 const (
-	userEntityType    = "user"
-	groupEntityType   = "group"
-	thingEntityType   = "thing"
-	channelEntityType = "channel"
+	userEntityType        = "user"
+	groupEntityType       = "group"
+	thingEntityType       = "thing"
+	channelEntityType     = "channel"
+	domainEntityType      = "domain"
+	keyEntityType         = "key"
+	certificateEntityType = "certificate"
+	invitationEntityType  = "invitation"
+	bootstrapEntityType   = "bootstrap"
 )
 
 // String converts entity type to string literal.
@@ -41,6 +51,16 @@ func (e EntityType) String() string {
 		return thingEntityType
 	case ChannelEntity:
 		return channelEntityType
+	case DomainEntity:
+		return domainEntityType
+	case KeyEntity:
+		return keyEntityType
+	case CertificateEntity:
+		return certificateEntityType
+	case InvitationEntity:
+		return invitationEntityType
+	case BootstrapEntity:
+		return bootstrapEntityType
 	default:
 		return ""
 	}
@@ -55,11 +75,24 @@ func (e EntityType) AuthString() string {
 		return policies.GroupType
 	case ThingEntity:
 		return policies.ThingType
+	case DomainEntity, KeyEntity, CertificateEntity, InvitationEntity, BootstrapEntity:
+		return policies.DomainType
 	default:
 		return ""
 	}
 }
 
+// domainOwned reports whether the entity journals are retrieved only from
+// the chain of the domain the entity belongs to.
+func (e EntityType) domainOwned() bool {
+	switch e {
+	case KeyEntity, CertificateEntity, InvitationEntity, BootstrapEntity:
+		return true
+	default:
+		return false
+	}
+}
+
 // ToEntityType converts string value to a valid entity type.
 func ToEntityType(entityType string) (EntityType, error) {
 	switch entityType {
@@ -71,6 +104,16 @@ func ToEntityType(entityType string) (EntityType, error) {
 		return ThingEntity, nil
 	case channelEntityType:
 		return ChannelEntity, nil
+	case domainEntityType:
+		return DomainEntity, nil
+	case keyEntityType:
+		return KeyEntity, nil
+	case certificateEntityType:
+		return CertificateEntity, nil
+	case invitationEntityType:
+		return InvitationEntity, nil
+	case bootstrapEntityType:
+		return BootstrapEntity, nil
 	default:
 		return EntityType(0), apiutil.ErrInvalidEntityType
 	}
@@ -85,6 +128,18 @@ func (e EntityType) Query() string {
 		return "((operation LIKE 'group.%' AND attributes->>'id' = :entity_id) OR (attributes->>'group_id' = :entity_id))"
 	case ThingEntity:
 		return "((operation LIKE 'thing.%' AND attributes->>'id' = :entity_id) OR (attributes->>'thing_id' = :entity_id))"
+	case DomainEntity:
+		return "(operation LIKE 'domain.%' AND (attributes->>'id' = :entity_id OR attributes->>'domain_id' = :entity_id))"
+	case KeyEntity:
+		return "(operation LIKE 'key.%' AND attributes->>'id' = :entity_id)"
+	case CertificateEntity:
+		// Certificates are revoked per thing, so the entity is either the serial number or the thing.
+		return "(operation LIKE 'certificate.%' AND (attributes->>'serial_number' = :entity_id OR attributes->>'thing_id' = :entity_id))"
+	case InvitationEntity:
+		// Invitations are identified by the invited user.
+		return "(operation LIKE 'invitation.%' AND attributes->>'user_id' = :entity_id)"
+	case BootstrapEntity:
+		return "(operation LIKE 'bootstrap.%' AND attributes->>'thing_id' = :entity_id)"
 	default:
 		return ""
 	}
@@ -97,6 +152,10 @@ type Journal struct {
 	OccurredAt time.Time              `json:"occurred_at,omitempty" db:"occurred_at,omitempty"`
 	Attributes map[string]interface{} `json:"attributes,omitempty" db:"attributes,omitempty"` // This is extra information about the journal for example thing_id, user_id, group_id etc.
 	Metadata   map[string]interface{} `json:"metadata,omitempty" db:"metadata,omitempty"`     // This is decoded metadata from the journal.
+	Domain     string                 `json:"domain,omitempty" db:"domain"`
+	Sequence   uint64                 `json:"sequence,omitempty" db:"sequence"`   // Position of the journal in the domain chain.
+	PrevHash   string                 `json:"prev_hash,omitempty" db:"prev_hash"` // Hash of the previous journal in the domain chain.
+	Hash       string                 `json:"hash,omitempty" db:"hash"`
 }
 
 // JournalsPage represents a page of journals.
@@ -109,16 +168,21 @@ type JournalsPage struct {
 
 // Page is used to filter journals.
 type Page struct {
-	Offset         uint64     `json:"offset" db:"offset"`
-	Limit          uint64     `json:"limit" db:"limit"`
-	Operation      string     `json:"operation,omitempty" db:"operation,omitempty"`
-	From           time.Time  `json:"from,omitempty" db:"from,omitempty"`
-	To             time.Time  `json:"to,omitempty" db:"to,omitempty"`
-	WithAttributes bool       `json:"with_attributes,omitempty"`
-	WithMetadata   bool       `json:"with_metadata,omitempty"`
-	EntityID       string     `json:"entity_id,omitempty" db:"entity_id,omitempty"`
-	EntityType     EntityType `json:"entity_type,omitempty" db:"entity_type,omitempty"`
-	Direction      string     `json:"direction,omitempty"`
+	Offset          uint64                 `json:"offset" db:"offset"`
+	Limit           uint64                 `json:"limit" db:"limit"`
+	Operation       string                 `json:"operation,omitempty" db:"operation,omitempty"`
+	From            time.Time              `json:"from,omitempty" db:"from,omitempty"`
+	To              time.Time              `json:"to,omitempty" db:"to,omitempty"`
+	WithAttributes  bool                   `json:"with_attributes,omitempty"`
+	WithMetadata    bool                   `json:"with_metadata,omitempty"`
+	EntityID        string                 `json:"entity_id,omitempty" db:"entity_id,omitempty"`
+	EntityType      EntityType             `json:"entity_type,omitempty" db:"entity_type,omitempty"`
+	Direction       string                 `json:"direction,omitempty"`
+	Domain          string                 `json:"domain,omitempty" db:"domain,omitempty"`
+	Actor           string                 `json:"actor,omitempty" db:"actor,omitempty"`                       // ID of the user who performed the operation.
+	OperationPrefix string                 `json:"operation_prefix,omitempty" db:"operation_prefix,omitempty"` // For example "thing." matches all thing operations.
+	Attributes      map[string]interface{} `json:"attributes,omitempty"`                                       // Keys are attribute JSON paths, such as "metadata.location".
+	Search          string                 `json:"search,omitempty" db:"search,omitempty"`                     // Full-text search on metadata.
 }
 
 func (page JournalsPage) MarshalJSON() ([]byte, error) {
@@ -145,15 +209,75 @@ type Service interface {
 
 	// RetrieveAll retrieves all journals from the database with the given page.
 	RetrieveAll(ctx context.Context, session mgauthn.Session, page Page) (JournalsPage, error)
+
+	// Verify verifies the journal chain of the session domain.
+	Verify(ctx context.Context, session mgauthn.Session) (Verification, error)
+
+	// Export returns the signed export of the session domain journals
+	// in the given sequence range. Zero upper bound exports up to the head.
+	Export(ctx context.Context, session mgauthn.Session, from, to uint64) (Export, error)
+
+	// ExportPage calls fn for each of the session domain journals matching
+	// the page, so journals are streamed without loading them all at once.
+	ExportPage(ctx context.Context, session mgauthn.Session, page Page, fn func(Journal) error) error
+
+	// SetRetention sets the journal retention policy of the session domain.
+	SetRetention(ctx context.Context, session mgauthn.Session, days uint64) (Retention, error)
+
+	// ViewRetention retrieves the journal retention policy of the session domain.
+	ViewRetention(ctx context.Context, session mgauthn.Session) (Retention, error)
+
+	// RemoveRetention removes the journal retention policy of the session
+	// domain, so journals are kept indefinitely.
+	RemoveRetention(ctx context.Context, session mgauthn.Session) error
+
+	// Subscribe returns the channel of the journals saved after the
+	// subscription which match the page domain, entity and operation
+	// filters. Journals are scoped like in RetrieveAll, and to the session
+	// domain if the entity is not provided. The channel is closed once the
+	// context is done or once the subscriber falls behind.
+	Subscribe(ctx context.Context, session mgauthn.Session, page Page) (<-chan Journal, error)
 }
 
 // Repository provides access to the journal log database.
 //
 //go:generate mockery --name Repository --output=./mocks --filename repository.go --quiet --note "Copyright (c) Abstract Machines"
 type Repository interface {
-	// Save persists the journal to a database.
+	// Save links the journal to the head of its domain chain and persists it
+	// to a database.
 	Save(ctx context.Context, journal Journal) error
 
 	// RetrieveAll retrieves all journals from the database with the given page.
 	RetrieveAll(ctx context.Context, page Page) (JournalsPage, error)
+
+	// RetrieveChain retrieves at most limit journals of the domain chain
+	// starting from the given sequence, ordered by sequence.
+	RetrieveChain(ctx context.Context, domain string, from, limit uint64) ([]Journal, error)
+
+	// RetrieveHeads retrieves the heads of all domain chains, including
+	// the chains whose journals are all archived.
+	RetrieveHeads(ctx context.Context) ([]Checkpoint, error)
+
+	// Iterate calls fn for each journal matching the page, ordered by the
+	// time of occurrence. Zero limit doesn't limit the number of journals.
+	Iterate(ctx context.Context, page Page, fn func(Journal) error) error
+
+	// SaveRetention creates or updates the domain retention policy.
+	SaveRetention(ctx context.Context, retention Retention) error
+
+	// RetrieveRetention retrieves the domain retention policy.
+	RetrieveRetention(ctx context.Context, domain string) (Retention, error)
+
+	// RetrieveAllRetentions retrieves retention policies of all domains.
+	RetrieveAllRetentions(ctx context.Context) ([]Retention, error)
+
+	// RemoveRetention removes the domain retention policy.
+	RemoveRetention(ctx context.Context, domain string) error
+
+	// RetrieveLastArchive retrieves the latest archive of the domain chain.
+	RetrieveLastArchive(ctx context.Context, domain string) (Archive, error)
+
+	// Archive saves the archive and removes the archived journals from
+	// the domain chain.
+	Archive(ctx context.Context, archive Archive) error
 }
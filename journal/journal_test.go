@@ -88,6 +88,36 @@ func TestEntityType(t *testing.T) {
 			str:        "channel",
 			authString: "group",
 		},
+		{
+			desc:       "DomainEntity",
+			e:          journal.DomainEntity,
+			str:        "domain",
+			authString: "domain",
+		},
+		{
+			desc:       "KeyEntity",
+			e:          journal.KeyEntity,
+			str:        "key",
+			authString: "domain",
+		},
+		{
+			desc:       "CertificateEntity",
+			e:          journal.CertificateEntity,
+			str:        "certificate",
+			authString: "domain",
+		},
+		{
+			desc:       "InvitationEntity",
+			e:          journal.InvitationEntity,
+			str:        "invitation",
+			authString: "domain",
+		},
+		{
+			desc:       "BootstrapEntity",
+			e:          journal.BootstrapEntity,
+			str:        "bootstrap",
+			authString: "domain",
+		},
 	}
 
 	for _, tc := range cases {
@@ -126,6 +156,31 @@ func TestToEntityType(t *testing.T) {
 			entityType: "channel",
 			expected:   journal.ChannelEntity,
 		},
+		{
+			desc:       "DomainEntity",
+			entityType: "domain",
+			expected:   journal.DomainEntity,
+		},
+		{
+			desc:       "KeyEntity",
+			entityType: "key",
+			expected:   journal.KeyEntity,
+		},
+		{
+			desc:       "CertificateEntity",
+			entityType: "certificate",
+			expected:   journal.CertificateEntity,
+		},
+		{
+			desc:       "InvitationEntity",
+			entityType: "invitation",
+			expected:   journal.InvitationEntity,
+		},
+		{
+			desc:       "BootstrapEntity",
+			entityType: "bootstrap",
+			expected:   journal.BootstrapEntity,
+		},
 		{
 			desc:        "Invalid entity type",
 			entityType:  "invalid",
@@ -32,17 +32,96 @@ func (am *authorizationMiddleware) Save(ctx context.Context, journal journal.Jou
 }
 
 func (am *authorizationMiddleware) RetrieveAll(ctx context.Context, session mgauthn.Session, page journal.Page) (journal.JournalsPage, error) {
+	if err := am.authorizeEntity(ctx, session, page); err != nil {
+		return journal.JournalsPage{}, err
+	}
+
+	return am.svc.RetrieveAll(ctx, session, page)
+}
+
+func (am *authorizationMiddleware) Verify(ctx context.Context, session mgauthn.Session) (journal.Verification, error) {
+	if err := am.authorizeDomainAdmin(ctx, session); err != nil {
+		return journal.Verification{}, err
+	}
+
+	return am.svc.Verify(ctx, session)
+}
+
+func (am *authorizationMiddleware) Export(ctx context.Context, session mgauthn.Session, from, to uint64) (journal.Export, error) {
+	if err := am.authorizeDomainAdmin(ctx, session); err != nil {
+		return journal.Export{}, err
+	}
+
+	return am.svc.Export(ctx, session, from, to)
+}
+
+func (am *authorizationMiddleware) ExportPage(ctx context.Context, session mgauthn.Session, page journal.Page, fn func(journal.Journal) error) error {
+	if err := am.authorizeDomainAdmin(ctx, session); err != nil {
+		return err
+	}
+
+	return am.svc.ExportPage(ctx, session, page, fn)
+}
+
+func (am *authorizationMiddleware) SetRetention(ctx context.Context, session mgauthn.Session, days uint64) (journal.Retention, error) {
+	if err := am.authorizeDomainAdmin(ctx, session); err != nil {
+		return journal.Retention{}, err
+	}
+
+	return am.svc.SetRetention(ctx, session, days)
+}
+
+func (am *authorizationMiddleware) ViewRetention(ctx context.Context, session mgauthn.Session) (journal.Retention, error) {
+	if err := am.authorizeDomainAdmin(ctx, session); err != nil {
+		return journal.Retention{}, err
+	}
+
+	return am.svc.ViewRetention(ctx, session)
+}
+
+func (am *authorizationMiddleware) RemoveRetention(ctx context.Context, session mgauthn.Session) error {
+	if err := am.authorizeDomainAdmin(ctx, session); err != nil {
+		return err
+	}
+
+	return am.svc.RemoveRetention(ctx, session)
+}
+
+func (am *authorizationMiddleware) Subscribe(ctx context.Context, session mgauthn.Session, page journal.Page) (<-chan journal.Journal, error) {
+	var err error
+	switch page.EntityID {
+	// Journals of the whole domain are available to the domain admins.
+	case "":
+		err = am.authorizeDomainAdmin(ctx, session)
+	default:
+		err = am.authorizeEntity(ctx, session, page)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return am.svc.Subscribe(ctx, session, page)
+}
+
+func (am *authorizationMiddleware) authorizeEntity(ctx context.Context, session mgauthn.Session, page journal.Page) error {
 	permission := policies.ViewPermission
 	objectType := page.EntityType.AuthString()
 	object := page.EntityID
 	subject := session.DomainUserID
 
+	switch objectType {
 	// If the entity is a user, we need to check if the user is an admin
-	if page.EntityType.AuthString() == policies.UserType {
+	case policies.UserType:
 		permission = policies.AdminPermission
 		objectType = policies.PlatformType
 		object = policies.MagistralaObject
 		subject = session.UserID
+	// Journals of the domain and the entities it owns are available to the domain admins.
+	case policies.DomainType:
+		permission = policies.AdminPermission
+		if page.EntityType != journal.DomainEntity {
+			object = session.DomainID
+		}
 	}
 
 	req := mgauthz.PolicyReq{
@@ -54,9 +133,20 @@ func (am *authorizationMiddleware) RetrieveAll(ctx context.Context, session mgau
 		ObjectType:  objectType,
 		Object:      object,
 	}
-	if err := am.authz.Authorize(ctx, req); err != nil {
-		return journal.JournalsPage{}, err
+
+	return am.authz.Authorize(ctx, req)
+}
+
+func (am *authorizationMiddleware) authorizeDomainAdmin(ctx context.Context, session mgauthn.Session) error {
+	req := mgauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     session.DomainUserID,
+		Permission:  policies.AdminPermission,
+		ObjectType:  policies.DomainType,
+		Object:      session.DomainID,
 	}
 
-	return am.svc.RetrieveAll(ctx, session, page)
+	return am.authz.Authorize(ctx, req)
 }
@@ -69,3 +69,153 @@ func (lm *loggingMiddleware) RetrieveAll(ctx context.Context, session mgauthn.Se
 
 	return lm.service.RetrieveAll(ctx, session, page)
 }
+
+func (lm *loggingMiddleware) Verify(ctx context.Context, session mgauthn.Session) (v journal.Verification, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("verification",
+				slog.String("domain_id", session.DomainID),
+				slog.Bool("valid", v.Valid),
+				slog.Uint64("entries", v.Entries),
+			),
+		}
+		if v.Reason != "" {
+			args = append(args, slog.String("reason", v.Reason), slog.Uint64("broken_at", v.BrokenAt))
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Verify journal chain failed", args...)
+			return
+		}
+		lm.logger.Info("Verify journal chain completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.Verify(ctx, session)
+}
+
+func (lm *loggingMiddleware) Export(ctx context.Context, session mgauthn.Session, from, to uint64) (e journal.Export, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("export",
+				slog.String("domain_id", session.DomainID),
+				slog.Uint64("from", from),
+				slog.Uint64("to", to),
+				slog.Uint64("entries", e.Proof.Entries),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Export journals failed", args...)
+			return
+		}
+		lm.logger.Info("Export journals completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.Export(ctx, session, from, to)
+}
+
+func (lm *loggingMiddleware) ExportPage(ctx context.Context, session mgauthn.Session, page journal.Page, fn func(journal.Journal) error) (err error) {
+	var entries uint64
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("page",
+				slog.String("domain_id", session.DomainID),
+				slog.String("operation", page.Operation),
+				slog.String("operation_prefix", page.OperationPrefix),
+				slog.String("actor", page.Actor),
+				slog.Uint64("entries", entries),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Export journals page failed", args...)
+			return
+		}
+		lm.logger.Info("Export journals page completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.ExportPage(ctx, session, page, func(j journal.Journal) error {
+		entries++
+		return fn(j)
+	})
+}
+
+func (lm *loggingMiddleware) SetRetention(ctx context.Context, session mgauthn.Session, days uint64) (r journal.Retention, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("retention",
+				slog.String("domain_id", session.DomainID),
+				slog.Uint64("days", days),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Set journal retention failed", args...)
+			return
+		}
+		lm.logger.Info("Set journal retention completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.SetRetention(ctx, session, days)
+}
+
+func (lm *loggingMiddleware) ViewRetention(ctx context.Context, session mgauthn.Session) (r journal.Retention, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View journal retention failed", args...)
+			return
+		}
+		lm.logger.Info("View journal retention completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.ViewRetention(ctx, session)
+}
+
+func (lm *loggingMiddleware) RemoveRetention(ctx context.Context, session mgauthn.Session) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove journal retention failed", args...)
+			return
+		}
+		lm.logger.Info("Remove journal retention completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.RemoveRetention(ctx, session)
+}
+
+func (lm *loggingMiddleware) Subscribe(ctx context.Context, session mgauthn.Session, page journal.Page) (journals <-chan journal.Journal, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("page",
+				slog.String("operation", page.Operation),
+				slog.String("operation_prefix", page.OperationPrefix),
+				slog.String("entity_type", page.EntityType.String()),
+				slog.String("entity_id", page.EntityID),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Subscribe to journals failed", args...)
+			return
+		}
+		lm.logger.Info("Subscribe to journals completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.Subscribe(ctx, session, page)
+}
@@ -47,3 +47,66 @@ func (mm *metricsMiddleware) RetrieveAll(ctx context.Context, session mgauthn.Se
 
 	return mm.service.RetrieveAll(ctx, session, page)
 }
+
+func (mm *metricsMiddleware) Verify(ctx context.Context, session mgauthn.Session) (journal.Verification, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "verify").Add(1)
+		mm.latency.With("method", "verify").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.Verify(ctx, session)
+}
+
+func (mm *metricsMiddleware) Export(ctx context.Context, session mgauthn.Session, from, to uint64) (journal.Export, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "export").Add(1)
+		mm.latency.With("method", "export").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.Export(ctx, session, from, to)
+}
+
+func (mm *metricsMiddleware) ExportPage(ctx context.Context, session mgauthn.Session, page journal.Page, fn func(journal.Journal) error) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "export_page").Add(1)
+		mm.latency.With("method", "export_page").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.ExportPage(ctx, session, page, fn)
+}
+
+func (mm *metricsMiddleware) SetRetention(ctx context.Context, session mgauthn.Session, days uint64) (journal.Retention, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "set_retention").Add(1)
+		mm.latency.With("method", "set_retention").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.SetRetention(ctx, session, days)
+}
+
+func (mm *metricsMiddleware) ViewRetention(ctx context.Context, session mgauthn.Session) (journal.Retention, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_retention").Add(1)
+		mm.latency.With("method", "view_retention").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.ViewRetention(ctx, session)
+}
+
+func (mm *metricsMiddleware) RemoveRetention(ctx context.Context, session mgauthn.Session) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_retention").Add(1)
+		mm.latency.With("method", "remove_retention").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.RemoveRetention(ctx, session)
+}
+
+func (mm *metricsMiddleware) Subscribe(ctx context.Context, session mgauthn.Session, page journal.Page) (<-chan journal.Journal, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "subscribe").Add(1)
+		mm.latency.With("method", "subscribe").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.Subscribe(ctx, session, page)
+}
@@ -45,3 +45,74 @@ func (tm *tracing) RetrieveAll(ctx context.Context, session mgauthn.Session, pag
 
 	return tm.svc.RetrieveAll(ctx, session, page)
 }
+
+func (tm *tracing) Verify(ctx context.Context, session mgauthn.Session) (journal.Verification, error) {
+	ctx, span := tm.tracer.Start(ctx, "verify", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+	))
+	defer span.End()
+
+	return tm.svc.Verify(ctx, session)
+}
+
+func (tm *tracing) Export(ctx context.Context, session mgauthn.Session, from, to uint64) (journal.Export, error) {
+	ctx, span := tm.tracer.Start(ctx, "export", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.Int64("from", int64(from)),
+		attribute.Int64("to", int64(to)),
+	))
+	defer span.End()
+
+	return tm.svc.Export(ctx, session, from, to)
+}
+
+func (tm *tracing) ExportPage(ctx context.Context, session mgauthn.Session, page journal.Page, fn func(journal.Journal) error) error {
+	ctx, span := tm.tracer.Start(ctx, "export_page", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("operation", page.Operation),
+		attribute.String("operation_prefix", page.OperationPrefix),
+		attribute.String("actor", page.Actor),
+	))
+	defer span.End()
+
+	return tm.svc.ExportPage(ctx, session, page, fn)
+}
+
+func (tm *tracing) SetRetention(ctx context.Context, session mgauthn.Session, days uint64) (journal.Retention, error) {
+	ctx, span := tm.tracer.Start(ctx, "set_retention", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.Int64("days", int64(days)),
+	))
+	defer span.End()
+
+	return tm.svc.SetRetention(ctx, session, days)
+}
+
+func (tm *tracing) ViewRetention(ctx context.Context, session mgauthn.Session) (journal.Retention, error) {
+	ctx, span := tm.tracer.Start(ctx, "view_retention", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+	))
+	defer span.End()
+
+	return tm.svc.ViewRetention(ctx, session)
+}
+
+func (tm *tracing) RemoveRetention(ctx context.Context, session mgauthn.Session) error {
+	ctx, span := tm.tracer.Start(ctx, "remove_retention", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+	))
+	defer span.End()
+
+	return tm.svc.RemoveRetention(ctx, session)
+}
+
+func (tm *tracing) Subscribe(ctx context.Context, session mgauthn.Session, page journal.Page) (<-chan journal.Journal, error) {
+	ctx, span := tm.tracer.Start(ctx, "subscribe", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("entity_type", page.EntityType.String()),
+		attribute.String("operation", page.Operation),
+	))
+	defer span.End()
+
+	return tm.svc.Subscribe(ctx, session, page)
+}
@@ -16,6 +16,60 @@ type Repository struct {
 	mock.Mock
 }
 
+// Archive provides a mock function with given fields: ctx, archive
+func (_m *Repository) Archive(ctx context.Context, archive journal.Archive) error {
+	ret := _m.Called(ctx, archive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Archive")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, journal.Archive) error); ok {
+		r0 = rf(ctx, archive)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Iterate provides a mock function with given fields: ctx, page, fn
+func (_m *Repository) Iterate(ctx context.Context, page journal.Page, fn func(journal.Journal) error) error {
+	ret := _m.Called(ctx, page, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Iterate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, journal.Page, func(journal.Journal) error) error); ok {
+		r0 = rf(ctx, page, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveRetention provides a mock function with given fields: ctx, domain
+func (_m *Repository) RemoveRetention(ctx context.Context, domain string) error {
+	ret := _m.Called(ctx, domain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveRetention")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, domain)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RetrieveAll provides a mock function with given fields: ctx, page
 func (_m *Repository) RetrieveAll(ctx context.Context, page journal.Page) (journal.JournalsPage, error) {
 	ret := _m.Called(ctx, page)
@@ -44,6 +98,152 @@ func (_m *Repository) RetrieveAll(ctx context.Context, page journal.Page) (journ
 	return r0, r1
 }
 
+// RetrieveAllRetentions provides a mock function with given fields: ctx
+func (_m *Repository) RetrieveAllRetentions(ctx context.Context) ([]journal.Retention, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveAllRetentions")
+	}
+
+	var r0 []journal.Retention
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]journal.Retention, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []journal.Retention); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]journal.Retention)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveChain provides a mock function with given fields: ctx, domain, from, limit
+func (_m *Repository) RetrieveChain(ctx context.Context, domain string, from uint64, limit uint64) ([]journal.Journal, error) {
+	ret := _m.Called(ctx, domain, from, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveChain")
+	}
+
+	var r0 []journal.Journal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, uint64) ([]journal.Journal, error)); ok {
+		return rf(ctx, domain, from, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, uint64) []journal.Journal); ok {
+		r0 = rf(ctx, domain, from, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]journal.Journal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint64, uint64) error); ok {
+		r1 = rf(ctx, domain, from, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveHeads provides a mock function with given fields: ctx
+func (_m *Repository) RetrieveHeads(ctx context.Context) ([]journal.Checkpoint, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveHeads")
+	}
+
+	var r0 []journal.Checkpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]journal.Checkpoint, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []journal.Checkpoint); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]journal.Checkpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveLastArchive provides a mock function with given fields: ctx, domain
+func (_m *Repository) RetrieveLastArchive(ctx context.Context, domain string) (journal.Archive, error) {
+	ret := _m.Called(ctx, domain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveLastArchive")
+	}
+
+	var r0 journal.Archive
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (journal.Archive, error)); ok {
+		return rf(ctx, domain)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) journal.Archive); ok {
+		r0 = rf(ctx, domain)
+	} else {
+		r0 = ret.Get(0).(journal.Archive)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, domain)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveRetention provides a mock function with given fields: ctx, domain
+func (_m *Repository) RetrieveRetention(ctx context.Context, domain string) (journal.Retention, error) {
+	ret := _m.Called(ctx, domain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveRetention")
+	}
+
+	var r0 journal.Retention
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (journal.Retention, error)); ok {
+		return rf(ctx, domain)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) journal.Retention); ok {
+		r0 = rf(ctx, domain)
+	} else {
+		r0 = ret.Get(0).(journal.Retention)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, domain)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: ctx, _a1
 func (_m *Repository) Save(ctx context.Context, _a1 journal.Journal) error {
 	ret := _m.Called(ctx, _a1)
@@ -62,6 +262,24 @@ func (_m *Repository) Save(ctx context.Context, _a1 journal.Journal) error {
 	return r0
 }
 
+// SaveRetention provides a mock function with given fields: ctx, retention
+func (_m *Repository) SaveRetention(ctx context.Context, retention journal.Retention) error {
+	ret := _m.Called(ctx, retention)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveRetention")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, journal.Retention) error); ok {
+		r0 = rf(ctx, retention)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRepository(t interface {
@@ -19,6 +19,70 @@ type Service struct {
 	mock.Mock
 }
 
+// Export provides a mock function with given fields: ctx, session, from, to
+func (_m *Service) Export(ctx context.Context, session authn.Session, from uint64, to uint64) (journal.Export, error) {
+	ret := _m.Called(ctx, session, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 journal.Export
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, uint64, uint64) (journal.Export, error)); ok {
+		return rf(ctx, session, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, uint64, uint64) journal.Export); ok {
+		r0 = rf(ctx, session, from, to)
+	} else {
+		r0 = ret.Get(0).(journal.Export)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, uint64, uint64) error); ok {
+		r1 = rf(ctx, session, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportPage provides a mock function with given fields: ctx, session, page, fn
+func (_m *Service) ExportPage(ctx context.Context, session authn.Session, page journal.Page, fn func(journal.Journal) error) error {
+	ret := _m.Called(ctx, session, page, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportPage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, journal.Page, func(journal.Journal) error) error); ok {
+		r0 = rf(ctx, session, page, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveRetention provides a mock function with given fields: ctx, session
+func (_m *Service) RemoveRetention(ctx context.Context, session authn.Session) error {
+	ret := _m.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveRetention")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session) error); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RetrieveAll provides a mock function with given fields: ctx, session, page
 func (_m *Service) RetrieveAll(ctx context.Context, session authn.Session, page journal.Page) (journal.JournalsPage, error) {
 	ret := _m.Called(ctx, session, page)
@@ -65,6 +129,120 @@ func (_m *Service) Save(ctx context.Context, _a1 journal.Journal) error {
 	return r0
 }
 
+// SetRetention provides a mock function with given fields: ctx, session, days
+func (_m *Service) SetRetention(ctx context.Context, session authn.Session, days uint64) (journal.Retention, error) {
+	ret := _m.Called(ctx, session, days)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRetention")
+	}
+
+	var r0 journal.Retention
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, uint64) (journal.Retention, error)); ok {
+		return rf(ctx, session, days)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, uint64) journal.Retention); ok {
+		r0 = rf(ctx, session, days)
+	} else {
+		r0 = ret.Get(0).(journal.Retention)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, uint64) error); ok {
+		r1 = rf(ctx, session, days)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Subscribe provides a mock function with given fields: ctx, session, page
+func (_m *Service) Subscribe(ctx context.Context, session authn.Session, page journal.Page) (<-chan journal.Journal, error) {
+	ret := _m.Called(ctx, session, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 <-chan journal.Journal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, journal.Page) (<-chan journal.Journal, error)); ok {
+		return rf(ctx, session, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, journal.Page) <-chan journal.Journal); ok {
+		r0 = rf(ctx, session, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan journal.Journal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, journal.Page) error); ok {
+		r1 = rf(ctx, session, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Verify provides a mock function with given fields: ctx, session
+func (_m *Service) Verify(ctx context.Context, session authn.Session) (journal.Verification, error) {
+	ret := _m.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 journal.Verification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session) (journal.Verification, error)); ok {
+		return rf(ctx, session)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session) journal.Verification); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Get(0).(journal.Verification)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session) error); ok {
+		r1 = rf(ctx, session)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ViewRetention provides a mock function with given fields: ctx, session
+func (_m *Service) ViewRetention(ctx context.Context, session authn.Session) (journal.Retention, error) {
+	ret := _m.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewRetention")
+	}
+
+	var r0 journal.Retention
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session) (journal.Retention, error)); ok {
+		return rf(ctx, session)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session) journal.Retention); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Get(0).(journal.Retention)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session) error); ok {
+		r1 = rf(ctx, session)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewService(t interface {
@@ -31,6 +31,54 @@ func Migration() *migrate.MemoryMigrationSource {
 					`DROP TABLE IF EXISTS journal`,
 				},
 			},
+			{
+				// Journals saved before the chain was introduced stay unchained.
+				Id: "journal_02",
+				Up: []string{
+					`ALTER TABLE journal
+						ADD COLUMN domain VARCHAR NOT NULL DEFAULT '',
+						ADD COLUMN sequence BIGINT,
+						ADD COLUMN prev_hash VARCHAR(64),
+						ADD COLUMN hash VARCHAR(64)`,
+					`UPDATE journal SET domain = attributes->>'domain' WHERE attributes->>'domain' IS NOT NULL`,
+					`CREATE UNIQUE INDEX idx_journal_chain ON journal(domain, sequence);`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS idx_journal_chain`,
+					`ALTER TABLE journal DROP COLUMN domain, DROP COLUMN sequence, DROP COLUMN prev_hash, DROP COLUMN hash`,
+				},
+			},
+			{
+				Id: "journal_03",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS journal_retentions (
+						domain      VARCHAR PRIMARY KEY,
+						days        BIGINT NOT NULL CHECK (days > 0),
+						updated_at  TIMESTAMP NOT NULL,
+						updated_by  VARCHAR(254)
+					)`,
+					`CREATE TABLE IF NOT EXISTS journal_archives (
+						domain          VARCHAR NOT NULL,
+						first_sequence  BIGINT NOT NULL,
+						last_sequence   BIGINT NOT NULL,
+						head_hash       VARCHAR(64) NOT NULL,
+						entries         BIGINT NOT NULL,
+						key             VARCHAR NOT NULL,
+						created_at      TIMESTAMP NOT NULL,
+						PRIMARY KEY (domain, last_sequence)
+					)`,
+					`CREATE INDEX idx_journal_attributes ON journal USING GIN (attributes jsonb_path_ops);`,
+					`CREATE INDEX idx_journal_metadata_search ON journal USING GIN (to_tsvector('simple', COALESCE(metadata, '{}'::jsonb)));`,
+					`CREATE INDEX idx_journal_domain_occurred_at ON journal(domain, occurred_at DESC);`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS idx_journal_domain_occurred_at`,
+					`DROP INDEX IF EXISTS idx_journal_metadata_search`,
+					`DROP INDEX IF EXISTS idx_journal_attributes`,
+					`DROP TABLE IF EXISTS journal_archives`,
+					`DROP TABLE IF EXISTS journal_retentions`,
+				},
+			},
 		},
 	}
 }
@@ -5,12 +5,14 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/absmach/magistrala/journal"
+	"github.com/absmach/magistrala/pkg/apiutil"
 	"github.com/absmach/magistrala/pkg/errors"
 	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
 	"github.com/absmach/magistrala/pkg/postgres"
@@ -25,15 +27,60 @@ func NewRepository(db postgres.Database) journal.Repository {
 }
 
 func (repo *repository) Save(ctx context.Context, j journal.Journal) (err error) {
-	q := `INSERT INTO journal (id, operation, occurred_at, attributes, metadata)
-		VALUES (:id, :operation, :occurred_at, :attributes, :metadata);`
+	// Timestamps are stored with microsecond precision, so the journal is
+	// hashed the way it's going to be retrieved.
+	if j.OccurredAt.IsZero() {
+		j.OccurredAt = time.Now()
+	}
+	j.OccurredAt = j.OccurredAt.UTC().Truncate(time.Microsecond)
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = errors.Wrap(apiutil.ErrRollbackTx, errRollback)
+			}
+		}
+	}()
+
+	// Journals of the same domain are appended to the chain one at a time.
+	if _, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1));`, j.Domain); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	var head struct {
+		Sequence uint64 `db:"sequence"`
+		Hash     string `db:"hash"`
+	}
+	// The whole chain may be archived, so the last archive is the head then.
+	hq := `SELECT sequence, hash FROM (
+			SELECT sequence, hash FROM journal WHERE domain = $1 AND sequence IS NOT NULL
+			UNION ALL
+			SELECT last_sequence, head_hash FROM journal_archives WHERE domain = $1
+		) AS head ORDER BY sequence DESC LIMIT 1;`
+	if err = tx.GetContext(ctx, &head, hq, j.Domain); err != nil && err != sql.ErrNoRows {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	j.Sequence = head.Sequence + 1
+	j.PrevHash = head.Hash
+	if j.Hash, err = j.ComputeHash(); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
 
 	dbJournal, err := toDBJournal(j)
 	if err != nil {
 		return errors.Wrap(repoerr.ErrCreateEntity, err)
 	}
 
-	if _, err = repo.db.NamedExecContext(ctx, q, dbJournal); err != nil {
+	q := `INSERT INTO journal (id, operation, occurred_at, attributes, metadata, domain, sequence, prev_hash, hash)
+		VALUES (:id, :operation, :occurred_at, :attributes, :metadata, :domain, :sequence, :prev_hash, :hash);`
+	if _, err = tx.NamedExecContext(ctx, q, dbJournal); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	if err = tx.Commit(); err != nil {
 		return postgres.HandleError(repoerr.ErrCreateEntity, err)
 	}
 
@@ -42,6 +89,10 @@ func (repo *repository) Save(ctx context.Context, j journal.Journal) (err error)
 
 func (repo *repository) RetrieveAll(ctx context.Context, page journal.Page) (journal.JournalsPage, error) {
 	query := pageQuery(page)
+	dbPage, err := toDBPage(page)
+	if err != nil {
+		return journal.JournalsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
 
 	sq := "operation, occurred_at"
 	if page.WithAttributes {
@@ -55,7 +106,7 @@ func (repo *repository) RetrieveAll(ctx context.Context, page journal.Page) (jou
 	}
 	q := fmt.Sprintf("SELECT %s FROM journal %s ORDER BY occurred_at %s LIMIT :limit OFFSET :offset;", sq, query, page.Direction)
 
-	rows, err := repo.db.NamedQueryContext(ctx, q, page)
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbPage)
 	if err != nil {
 		return journal.JournalsPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
 	}
@@ -76,7 +127,7 @@ func (repo *repository) RetrieveAll(ctx context.Context, page journal.Page) (jou
 
 	tq := fmt.Sprintf(`SELECT COUNT(*) FROM journal %s;`, query)
 
-	total, err := postgres.Total(ctx, repo.db, tq, page)
+	total, err := postgres.Total(ctx, repo.db, tq, dbPage)
 	if err != nil {
 		return journal.JournalsPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
 	}
@@ -91,12 +142,221 @@ func (repo *repository) RetrieveAll(ctx context.Context, page journal.Page) (jou
 	return journalsPage, nil
 }
 
+func (repo *repository) Iterate(ctx context.Context, page journal.Page, fn func(journal.Journal) error) error {
+	query := pageQuery(page)
+	dbPage, err := toDBPage(page)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	if page.Direction == "" {
+		page.Direction = "ASC"
+	}
+	lq := "OFFSET :offset"
+	if page.Limit > 0 {
+		lq = "LIMIT :limit OFFSET :offset"
+	}
+	q := fmt.Sprintf(`SELECT id, operation, occurred_at, attributes, metadata, domain, sequence, prev_hash, hash FROM journal %s
+		ORDER BY occurred_at %s %s;`, query, page.Direction, lq)
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbPage)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item dbJournal
+		if err = rows.StructScan(&item); err != nil {
+			return postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		j, err := toJournal(item)
+		if err != nil {
+			return err
+		}
+		if err := fn(j); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (repo *repository) RetrieveHeads(ctx context.Context) ([]journal.Checkpoint, error) {
+	q := `SELECT DISTINCT ON (domain) domain, sequence, hash FROM (
+			SELECT domain, sequence, hash FROM journal WHERE sequence IS NOT NULL
+			UNION ALL
+			SELECT domain, last_sequence, head_hash FROM journal_archives
+		) AS heads ORDER BY domain, sequence DESC;`
+
+	rows, err := repo.db.QueryxContext(ctx, q)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var items []journal.Checkpoint
+	for rows.Next() {
+		var head journal.Checkpoint
+		if err := rows.Scan(&head.Domain, &head.Sequence, &head.HeadHash); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		items = append(items, head)
+	}
+
+	return items, nil
+}
+
+func (repo *repository) RetrieveChain(ctx context.Context, domain string, from, limit uint64) ([]journal.Journal, error) {
+	q := `SELECT id, operation, occurred_at, attributes, metadata, domain, sequence, prev_hash, hash FROM journal
+		WHERE domain = :domain AND sequence >= :from ORDER BY sequence LIMIT :limit;`
+
+	params := map[string]interface{}{
+		"domain": domain,
+		"from":   from,
+		"limit":  limit,
+	}
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var items []journal.Journal
+	for rows.Next() {
+		var item dbJournal
+		if err = rows.StructScan(&item); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		j, err := toJournal(item)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, j)
+	}
+
+	return items, nil
+}
+
+func (repo *repository) SaveRetention(ctx context.Context, r journal.Retention) error {
+	q := `INSERT INTO journal_retentions (domain, days, updated_at, updated_by)
+		VALUES (:domain, :days, :updated_at, :updated_by)
+		ON CONFLICT (domain) DO UPDATE SET days = :days, updated_at = :updated_at, updated_by = :updated_by;`
+
+	if _, err := repo.db.NamedExecContext(ctx, q, toDBRetention(r)); err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveRetention(ctx context.Context, domain string) (journal.Retention, error) {
+	q := `SELECT domain, days, updated_at, updated_by FROM journal_retentions WHERE domain = $1;`
+
+	var r dbRetention
+	if err := repo.db.QueryRowxContext(ctx, q, domain).StructScan(&r); err != nil {
+		if err == sql.ErrNoRows {
+			return journal.Retention{}, repoerr.ErrNotFound
+		}
+		return journal.Retention{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return toRetention(r), nil
+}
+
+func (repo *repository) RetrieveAllRetentions(ctx context.Context) ([]journal.Retention, error) {
+	q := `SELECT domain, days, updated_at, updated_by FROM journal_retentions ORDER BY domain;`
+
+	rows, err := repo.db.QueryxContext(ctx, q)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var items []journal.Retention
+	for rows.Next() {
+		var r dbRetention
+		if err := rows.StructScan(&r); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		items = append(items, toRetention(r))
+	}
+
+	return items, nil
+}
+
+func (repo *repository) RemoveRetention(ctx context.Context, domain string) error {
+	q := `DELETE FROM journal_retentions WHERE domain = $1;`
+
+	res, err := repo.db.ExecContext(ctx, q, domain)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveLastArchive(ctx context.Context, domain string) (journal.Archive, error) {
+	q := `SELECT domain, first_sequence, last_sequence, head_hash, entries, key, created_at FROM journal_archives
+		WHERE domain = $1 ORDER BY last_sequence DESC LIMIT 1;`
+
+	var a journal.Archive
+	if err := repo.db.QueryRowxContext(ctx, q, domain).StructScan(&a); err != nil {
+		if err == sql.ErrNoRows {
+			return journal.Archive{}, repoerr.ErrNotFound
+		}
+		return journal.Archive{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return a, nil
+}
+
+func (repo *repository) Archive(ctx context.Context, a journal.Archive) (err error) {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = errors.Wrap(apiutil.ErrRollbackTx, errRollback)
+			}
+		}
+	}()
+
+	q := `INSERT INTO journal_archives (domain, first_sequence, last_sequence, head_hash, entries, key, created_at)
+		VALUES (:domain, :first_sequence, :last_sequence, :head_hash, :entries, :key, :created_at);`
+	if _, err = tx.NamedExecContext(ctx, q, a); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	dq := `DELETE FROM journal WHERE domain = $1 AND sequence BETWEEN $2 AND $3;`
+	if _, err = tx.ExecContext(ctx, dq, a.Domain, a.FirstSequence, a.LastSequence); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
 func pageQuery(pm journal.Page) string {
 	var query []string
 	var emq string
+	if pm.Domain != "" {
+		query = append(query, "domain = :domain")
+	}
 	if pm.Operation != "" {
 		query = append(query, "operation = :operation")
 	}
+	if pm.OperationPrefix != "" {
+		query = append(query, "operation LIKE :operation_prefix")
+	}
 	if !pm.From.IsZero() {
 		query = append(query, "occurred_at >= :from")
 	}
@@ -106,6 +366,16 @@ func pageQuery(pm journal.Page) string {
 	if pm.EntityID != "" {
 		query = append(query, pm.EntityType.Query())
 	}
+	// Events record the user who performed the operation as its creator or updater.
+	if pm.Actor != "" {
+		query = append(query, "(attributes->>'created_by' = :actor OR attributes->>'updated_by' = :actor)")
+	}
+	if len(pm.Attributes) > 0 {
+		query = append(query, "attributes @> :attributes")
+	}
+	if pm.Search != "" {
+		query = append(query, "to_tsvector('simple', COALESCE(metadata, '{}'::jsonb)) @@ plainto_tsquery('simple', :search)")
+	}
 
 	if len(query) > 0 {
 		emq = fmt.Sprintf("WHERE %s", strings.Join(query, " AND "))
@@ -114,12 +384,78 @@ func pageQuery(pm journal.Page) string {
 	return emq
 }
 
+type dbPage struct {
+	Offset          uint64    `db:"offset"`
+	Limit           uint64    `db:"limit"`
+	Domain          string    `db:"domain"`
+	Operation       string    `db:"operation"`
+	OperationPrefix string    `db:"operation_prefix"`
+	From            time.Time `db:"from"`
+	To              time.Time `db:"to"`
+	EntityID        string    `db:"entity_id"`
+	Actor           string    `db:"actor"`
+	Attributes      []byte    `db:"attributes"`
+	Search          string    `db:"search"`
+}
+
+func toDBPage(pm journal.Page) (dbPage, error) {
+	var attributes []byte
+	if len(pm.Attributes) > 0 {
+		b, err := json.Marshal(expandPaths(pm.Attributes))
+		if err != nil {
+			return dbPage{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+		}
+		attributes = b
+	}
+
+	return dbPage{
+		Offset:          pm.Offset,
+		Limit:           pm.Limit,
+		Domain:          pm.Domain,
+		Operation:       pm.Operation,
+		OperationPrefix: likeEscaper.Replace(pm.OperationPrefix) + "%",
+		From:            pm.From,
+		To:              pm.To,
+		EntityID:        pm.EntityID,
+		Actor:           pm.Actor,
+		Attributes:      attributes,
+		Search:          pm.Search,
+	}, nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// expandPaths converts the dotted JSON paths to the nested object, so
+// {"metadata.location": "lab"} matches {"metadata": {"location": "lab"}}.
+func expandPaths(attributes map[string]interface{}) map[string]interface{} {
+	ret := map[string]interface{}{}
+	for path, value := range attributes {
+		keys := strings.Split(path, ".")
+		node := ret
+		for _, key := range keys[:len(keys)-1] {
+			child, ok := node[key].(map[string]interface{})
+			if !ok {
+				child = map[string]interface{}{}
+				node[key] = child
+			}
+			node = child
+		}
+		node[keys[len(keys)-1]] = value
+	}
+
+	return ret
+}
+
 type dbJournal struct {
-	ID         string    `db:"id"`
-	Operation  string    `db:"operation"`
-	OccurredAt time.Time `db:"occurred_at"`
-	Attributes []byte    `db:"attributes"`
-	Metadata   []byte    `db:"metadata"`
+	ID         string         `db:"id"`
+	Operation  string         `db:"operation"`
+	OccurredAt time.Time      `db:"occurred_at"`
+	Attributes []byte         `db:"attributes"`
+	Metadata   []byte         `db:"metadata"`
+	Domain     string         `db:"domain"`
+	Sequence   sql.NullInt64  `db:"sequence"`
+	PrevHash   sql.NullString `db:"prev_hash"`
+	Hash       sql.NullString `db:"hash"`
 }
 
 func toDBJournal(j journal.Journal) (dbJournal, error) {
@@ -151,6 +487,10 @@ func toDBJournal(j journal.Journal) (dbJournal, error) {
 		OccurredAt: j.OccurredAt,
 		Attributes: attributes,
 		Metadata:   metadata,
+		Domain:     j.Domain,
+		Sequence:   sql.NullInt64{Int64: int64(j.Sequence), Valid: j.Sequence > 0},
+		PrevHash:   sql.NullString{String: j.PrevHash, Valid: j.Sequence > 0},
+		Hash:       sql.NullString{String: j.Hash, Valid: j.Hash != ""},
 	}, nil
 }
 
@@ -170,9 +510,39 @@ func toJournal(dbj dbJournal) (journal.Journal, error) {
 	}
 
 	return journal.Journal{
+		ID:         dbj.ID,
 		Operation:  dbj.Operation,
 		OccurredAt: dbj.OccurredAt,
 		Attributes: attributes,
 		Metadata:   metadata,
+		Domain:     dbj.Domain,
+		Sequence:   uint64(dbj.Sequence.Int64),
+		PrevHash:   dbj.PrevHash.String,
+		Hash:       dbj.Hash.String,
 	}, nil
 }
+
+type dbRetention struct {
+	Domain    string         `db:"domain"`
+	Days      uint64         `db:"days"`
+	UpdatedAt time.Time      `db:"updated_at"`
+	UpdatedBy sql.NullString `db:"updated_by"`
+}
+
+func toDBRetention(r journal.Retention) dbRetention {
+	return dbRetention{
+		Domain:    r.Domain,
+		Days:      r.Days,
+		UpdatedAt: r.UpdatedAt,
+		UpdatedBy: sql.NullString{String: r.UpdatedBy, Valid: r.UpdatedBy != ""},
+	}
+}
+
+func toRetention(r dbRetention) journal.Retention {
+	return journal.Retention{
+		Domain:    r.Domain,
+		Days:      r.Days,
+		UpdatedAt: r.UpdatedAt,
+		UpdatedBy: r.UpdatedBy.String,
+	}
+}
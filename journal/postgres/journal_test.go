@@ -700,6 +700,438 @@ func TestJournalRetrieveAll(t *testing.T) {
 	}
 }
 
+func TestJournalRetrieveEntity(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM journal")
+		require.Nil(t, err, fmt.Sprintf("clean journal unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	userID := testsutil.GenerateUUID(t)
+	thingID := testsutil.GenerateUUID(t)
+	keyID := testsutil.GenerateUUID(t)
+	serial := "1f:2e:3d"
+	journals := []journal.Journal{
+		{Operation: "domain.create", Attributes: map[string]interface{}{"id": domainID}},
+		{Operation: "domain.assign", Attributes: map[string]interface{}{"domain_id": domainID, "user_ids": []string{userID}}},
+		{Operation: "key.issue", Attributes: map[string]interface{}{"id": keyID, "user_id": userID, "domain_id": domainID}},
+		{Operation: "key.revoke", Attributes: map[string]interface{}{"id": keyID, "user_id": userID, "domain_id": domainID}},
+		{Operation: "certificate.issue", Attributes: map[string]interface{}{"serial_number": serial, "thing_id": thingID, "domain_id": domainID}},
+		{Operation: "certificate.revoke", Attributes: map[string]interface{}{"thing_id": thingID, "domain_id": domainID}},
+		{Operation: "invitation.send", Attributes: map[string]interface{}{"user_id": userID, "domain_id": domainID}},
+		{Operation: "invitation.accept", Attributes: map[string]interface{}{"user_id": userID, "domain_id": domainID}},
+		{Operation: "bootstrap.thing.change_state", Attributes: map[string]interface{}{"thing_id": thingID, "domain_id": domainID}},
+	}
+	for _, j := range journals {
+		j.ID = testsutil.GenerateUUID(t)
+		j.OccurredAt = time.Now()
+		err := repo.Save(context.Background(), j)
+		require.Nil(t, err, fmt.Sprintf("create journal unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc       string
+		entityType journal.EntityType
+		entityID   string
+		total      uint64
+	}{
+		{
+			desc:       "domain history",
+			entityType: journal.DomainEntity,
+			entityID:   domainID,
+			total:      2,
+		},
+		{
+			desc:       "key history",
+			entityType: journal.KeyEntity,
+			entityID:   keyID,
+			total:      2,
+		},
+		{
+			desc:       "certificate history by serial number",
+			entityType: journal.CertificateEntity,
+			entityID:   serial,
+			total:      1,
+		},
+		{
+			desc:       "certificate history by thing",
+			entityType: journal.CertificateEntity,
+			entityID:   thingID,
+			total:      2,
+		},
+		{
+			desc:       "invitation history",
+			entityType: journal.InvitationEntity,
+			entityID:   userID,
+			total:      2,
+		},
+		{
+			desc:       "bootstrap history",
+			entityType: journal.BootstrapEntity,
+			entityID:   thingID,
+			total:      1,
+		},
+		{
+			desc:       "user history includes keys and invitations",
+			entityType: journal.UserEntity,
+			entityID:   userID,
+			total:      4,
+		},
+		{
+			desc:       "thing history includes certificates and bootstrap",
+			entityType: journal.ThingEntity,
+			entityID:   thingID,
+			total:      3,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RetrieveAll(context.Background(), journal.Page{
+				Limit:      10,
+				EntityID:   tc.entityID,
+				EntityType: tc.entityType,
+			})
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.total, page.Total, tc.desc)
+		})
+	}
+}
+
+func TestJournalRetrieveChain(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM journal")
+		require.Nil(t, err, fmt.Sprintf("clean journal unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	num := 10
+	for i := 0; i < num; i++ {
+		j := journal.Journal{
+			ID:         testsutil.GenerateUUID(t),
+			Operation:  fmt.Sprintf("%s-%d", thingOperation, i),
+			OccurredAt: time.Now(),
+			Attributes: thingAttributesV1,
+			Metadata:   payload,
+			Domain:     domainID,
+		}
+		err := repo.Save(context.Background(), j)
+		require.Nil(t, err, fmt.Sprintf("create journal unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		domain string
+		from   uint64
+		limit  uint64
+		size   int
+		first  uint64
+	}{
+		{
+			desc:   "retrieve whole chain",
+			domain: domainID,
+			from:   1,
+			limit:  100,
+			size:   num,
+			first:  1,
+		},
+		{
+			desc:   "retrieve chain subset",
+			domain: domainID,
+			from:   5,
+			limit:  3,
+			size:   3,
+			first:  5,
+		},
+		{
+			desc:   "retrieve chain of other domain",
+			domain: testsutil.GenerateUUID(t),
+			from:   1,
+			limit:  100,
+			size:   0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			journals, err := repo.RetrieveChain(context.Background(), tc.domain, tc.from, tc.limit)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Len(t, journals, tc.size, tc.desc)
+			prevHash := ""
+			for i, j := range journals {
+				assert.Equal(t, tc.first+uint64(i), j.Sequence, tc.desc)
+				if i > 0 {
+					assert.Equal(t, prevHash, j.PrevHash, tc.desc)
+				}
+				hash, err := j.ComputeHash()
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+				assert.Equal(t, j.Hash, hash, tc.desc)
+				prevHash = j.Hash
+			}
+		})
+	}
+}
+
+func TestJournalRetrieveHeads(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM journal")
+		require.Nil(t, err, fmt.Sprintf("clean journal unexpected error: %s", err))
+		_, err = db.Exec("DELETE FROM journal_archives")
+		require.Nil(t, err, fmt.Sprintf("clean journal archives unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	domains := map[string]int{
+		testsutil.GenerateUUID(t): 3,
+		testsutil.GenerateUUID(t): 5,
+	}
+	for domainID, num := range domains {
+		for i := 0; i < num; i++ {
+			j := journal.Journal{
+				ID:         testsutil.GenerateUUID(t),
+				Operation:  thingOperation,
+				OccurredAt: time.Now(),
+				Domain:     domainID,
+			}
+			err := repo.Save(context.Background(), j)
+			require.Nil(t, err, fmt.Sprintf("create journal unexpected error: %s", err))
+		}
+	}
+
+	heads, err := repo.RetrieveHeads(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("retrieve heads unexpected error: %s", err))
+	assert.Len(t, heads, len(domains))
+	for _, head := range heads {
+		num, ok := domains[head.Domain]
+		assert.True(t, ok, fmt.Sprintf("unexpected head of domain %s", head.Domain))
+		assert.Equal(t, uint64(num), head.Sequence)
+		chain, err := repo.RetrieveChain(context.Background(), head.Domain, head.Sequence, 1)
+		require.Nil(t, err, fmt.Sprintf("retrieve chain unexpected error: %s", err))
+		assert.Equal(t, chain[0].Hash, head.HeadHash)
+	}
+}
+
+func TestJournalIterate(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM journal")
+		require.Nil(t, err, fmt.Sprintf("clean journal unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	actorID := testsutil.GenerateUUID(t)
+	num := 10
+	for i := 0; i < num; i++ {
+		attributes := map[string]interface{}{
+			"id":       testsutil.GenerateUUID(t),
+			"domain":   domainID,
+			"metadata": map[string]interface{}{"location": "lab"},
+		}
+		operation := "thing.update"
+		metadata := map[string]interface{}{"description": "sensor in the basement"}
+		if i%2 == 0 {
+			attributes["updated_by"] = actorID
+			attributes["metadata"] = map[string]interface{}{"location": "field"}
+			operation = "group.update"
+			metadata = map[string]interface{}{"description": "gateway on the roof"}
+		}
+		j := journal.Journal{
+			ID:         testsutil.GenerateUUID(t),
+			Operation:  operation,
+			OccurredAt: time.Now().Add(time.Duration(i) * time.Second),
+			Attributes: attributes,
+			Metadata:   metadata,
+			Domain:     domainID,
+		}
+		err := repo.Save(context.Background(), j)
+		require.Nil(t, err, fmt.Sprintf("create journal unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc string
+		page journal.Page
+		size int
+	}{
+		{
+			desc: "iterate domain journals",
+			page: journal.Page{Domain: domainID},
+			size: num,
+		},
+		{
+			desc: "iterate with limit",
+			page: journal.Page{Domain: domainID, Limit: 3},
+			size: 3,
+		},
+		{
+			desc: "iterate other domain journals",
+			page: journal.Page{Domain: testsutil.GenerateUUID(t)},
+			size: 0,
+		},
+		{
+			desc: "iterate by actor",
+			page: journal.Page{Domain: domainID, Actor: actorID},
+			size: num / 2,
+		},
+		{
+			desc: "iterate by operation prefix",
+			page: journal.Page{Domain: domainID, OperationPrefix: "thing."},
+			size: num / 2,
+		},
+		{
+			desc: "iterate by operation prefix with wildcard",
+			page: journal.Page{Domain: domainID, OperationPrefix: "%"},
+			size: 0,
+		},
+		{
+			desc: "iterate by attribute path",
+			page: journal.Page{Domain: domainID, Attributes: map[string]interface{}{"metadata.location": "field"}},
+			size: num / 2,
+		},
+		{
+			desc: "iterate by metadata search",
+			page: journal.Page{Domain: domainID, Search: "basement"},
+			size: num / 2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var journals []journal.Journal
+			err := repo.Iterate(context.Background(), tc.page, func(j journal.Journal) error {
+				journals = append(journals, j)
+				return nil
+			})
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Len(t, journals, tc.size, tc.desc)
+			for i := 1; i < len(journals); i++ {
+				assert.False(t, journals[i].OccurredAt.Before(journals[i-1].OccurredAt), tc.desc)
+			}
+		})
+	}
+}
+
+func TestRetention(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM journal_retentions")
+		require.Nil(t, err, fmt.Sprintf("clean journal retentions unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	retention := journal.Retention{
+		Domain:    testsutil.GenerateUUID(t),
+		Days:      30,
+		UpdatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		UpdatedBy: testsutil.GenerateUUID(t),
+	}
+
+	err := repo.SaveRetention(context.Background(), retention)
+	require.Nil(t, err, fmt.Sprintf("save retention unexpected error: %s", err))
+
+	retention.Days = 90
+	err = repo.SaveRetention(context.Background(), retention)
+	require.Nil(t, err, fmt.Sprintf("update retention unexpected error: %s", err))
+
+	r, err := repo.RetrieveRetention(context.Background(), retention.Domain)
+	assert.Nil(t, err, fmt.Sprintf("retrieve retention unexpected error: %s", err))
+	assert.Equal(t, retention, r)
+
+	_, err = repo.RetrieveRetention(context.Background(), testsutil.GenerateUUID(t))
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("expected %s got %s", repoerr.ErrNotFound, err))
+
+	retentions, err := repo.RetrieveAllRetentions(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("retrieve retentions unexpected error: %s", err))
+	assert.Equal(t, []journal.Retention{retention}, retentions)
+
+	err = repo.RemoveRetention(context.Background(), retention.Domain)
+	assert.Nil(t, err, fmt.Sprintf("remove retention unexpected error: %s", err))
+
+	err = repo.RemoveRetention(context.Background(), retention.Domain)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("expected %s got %s", repoerr.ErrNotFound, err))
+}
+
+func TestArchive(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM journal")
+		require.Nil(t, err, fmt.Sprintf("clean journal unexpected error: %s", err))
+		_, err = db.Exec("DELETE FROM journal_archives")
+		require.Nil(t, err, fmt.Sprintf("clean journal archives unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	num := 5
+	for i := 0; i < num; i++ {
+		j := journal.Journal{
+			ID:         testsutil.GenerateUUID(t),
+			Operation:  thingOperation,
+			OccurredAt: time.Now(),
+			Attributes: map[string]interface{}{"id": testsutil.GenerateUUID(t), "domain": domainID},
+			Domain:     domainID,
+		}
+		err := repo.Save(context.Background(), j)
+		require.Nil(t, err, fmt.Sprintf("create journal unexpected error: %s", err))
+	}
+
+	_, err := repo.RetrieveLastArchive(context.Background(), domainID)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("expected %s got %s", repoerr.ErrNotFound, err))
+
+	chain, err := repo.RetrieveChain(context.Background(), domainID, 1, 3)
+	require.Nil(t, err, fmt.Sprintf("retrieve chain unexpected error: %s", err))
+	archive := journal.Archive{
+		Domain:        domainID,
+		FirstSequence: 1,
+		LastSequence:  3,
+		HeadHash:      chain[2].Hash,
+		Entries:       3,
+		Key:           fmt.Sprintf("%s/archive.ndjson.gz", domainID),
+		CreatedAt:     time.Now().UTC().Truncate(time.Microsecond),
+	}
+	err = repo.Archive(context.Background(), archive)
+	assert.Nil(t, err, fmt.Sprintf("archive unexpected error: %s", err))
+
+	a, err := repo.RetrieveLastArchive(context.Background(), domainID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve archive unexpected error: %s", err))
+	assert.Equal(t, archive, a)
+
+	remaining, err := repo.RetrieveChain(context.Background(), domainID, 1, 100)
+	assert.Nil(t, err, fmt.Sprintf("retrieve chain unexpected error: %s", err))
+	assert.Len(t, remaining, num-3)
+	assert.Equal(t, uint64(4), remaining[0].Sequence)
+
+	err = repo.Archive(context.Background(), archive)
+	assert.True(t, errors.Contains(err, repoerr.ErrConflict), fmt.Sprintf("expected %s got %s", repoerr.ErrConflict, err))
+	// Journals saved after the whole chain is archived are linked to the archive.
+	remaining, err = repo.RetrieveChain(context.Background(), domainID, 4, 100)
+	require.Nil(t, err, fmt.Sprintf("retrieve chain unexpected error: %s", err))
+	archive = journal.Archive{
+		Domain:        domainID,
+		FirstSequence: 4,
+		LastSequence:  uint64(num),
+		HeadHash:      remaining[len(remaining)-1].Hash,
+		Entries:       uint64(len(remaining)),
+		Key:           fmt.Sprintf("%s/archive-2.ndjson.gz", domainID),
+		CreatedAt:     time.Now().UTC().Truncate(time.Microsecond),
+	}
+	err = repo.Archive(context.Background(), archive)
+	assert.Nil(t, err, fmt.Sprintf("archive unexpected error: %s", err))
+
+	err = repo.Save(context.Background(), journal.Journal{
+		ID:         testsutil.GenerateUUID(t),
+		Operation:  thingOperation,
+		OccurredAt: time.Now(),
+		Attributes: map[string]interface{}{"id": testsutil.GenerateUUID(t), "domain": domainID},
+		Domain:     domainID,
+	})
+	require.Nil(t, err, fmt.Sprintf("create journal unexpected error: %s", err))
+	remaining, err = repo.RetrieveChain(context.Background(), domainID, 1, 100)
+	assert.Nil(t, err, fmt.Sprintf("retrieve chain unexpected error: %s", err))
+	assert.Len(t, remaining, 1)
+	assert.Equal(t, uint64(num+1), remaining[0].Sequence)
+	assert.Equal(t, archive.HeadHash, remaining[0].PrevHash)
+}
+
 func extractEntities(journals []journal.Journal, entityType journal.EntityType, entityID string) []journal.Journal {
 	var entities []journal.Journal
 	for _, j := range journals {
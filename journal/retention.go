@@ -0,0 +1,214 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package journal
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+)
+
+// maxArchiveEntries is the maximum number of journals in a single archive.
+const maxArchiveEntries = 10 * chainBatch
+
+// Retention represents the domain journal retention policy. Journals older
+// than the retention period are archived and removed from the domain chain.
+type Retention struct {
+	Domain    string    `json:"domain"`
+	Days      uint64    `json:"days"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UpdatedBy string    `json:"updated_by"`
+}
+
+// Archive represents the range of the domain chain archived to the storage.
+// The last archive anchors the remaining chain, so it can be verified after
+// the archived journals are removed.
+type Archive struct {
+	Domain        string    `json:"domain" db:"domain"`
+	FirstSequence uint64    `json:"first_sequence" db:"first_sequence"`
+	LastSequence  uint64    `json:"last_sequence" db:"last_sequence"`
+	HeadHash      string    `json:"head_hash" db:"head_hash"`
+	Entries       uint64    `json:"entries" db:"entries"`
+	Key           string    `json:"key" db:"key"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// Storage specifies the journal archive storage API.
+//
+//go:generate mockery --name Storage --output=./mocks --filename storage.go --quiet --note "Copyright (c) Abstract Machines"
+type Storage interface {
+	// Save stores the content under the key.
+	Save(ctx context.Context, key string, content io.Reader) error
+}
+
+type retentionHandler struct {
+	repository    Repository
+	storage       Storage
+	signingKey    ed25519.PrivateKey
+	checkInterval time.Duration
+	logger        *slog.Logger
+}
+
+// NewRetentionHandler starts the job which periodically applies the domain
+// retention policies. Journals older than the retention period are written
+// to the storage as the gzip compressed export and then removed. Archives are
+// signed if the signing key is provided.
+func NewRetentionHandler(ctx context.Context, repository Repository, storage Storage, signingKey ed25519.PrivateKey, checkInterval time.Duration, logger *slog.Logger) {
+	handler := &retentionHandler{
+		repository:    repository,
+		storage:       storage,
+		signingKey:    signingKey,
+		checkInterval: checkInterval,
+		logger:        logger,
+	}
+
+	go func() {
+		ticker := time.NewTicker(handler.checkInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				handler.handle(ctx)
+			}
+		}
+	}()
+}
+
+func (h *retentionHandler) handle(ctx context.Context) {
+	retentions, err := h.repository.RetrieveAllRetentions(ctx)
+	if err != nil {
+		h.logger.Error("failed to retrieve journal retention policies", slog.Any("error", err))
+		return
+	}
+
+	for _, r := range retentions {
+		cutoff := time.Now().Add(-time.Duration(r.Days) * 24 * time.Hour)
+		for {
+			archive, err := archiveChain(ctx, h.repository, h.storage, h.signingKey, r.Domain, cutoff)
+			if err != nil {
+				h.logger.Error("failed to archive journals", slog.String("domain", r.Domain), slog.Any("error", err))
+				break
+			}
+			if archive.Entries == 0 {
+				break
+			}
+			h.logger.Info("journals archived", slog.Group("archive",
+				slog.String("domain", archive.Domain),
+				slog.Uint64("first_sequence", archive.FirstSequence),
+				slog.Uint64("last_sequence", archive.LastSequence),
+				slog.String("key", archive.Key),
+			))
+			if archive.Entries < maxArchiveEntries {
+				break
+			}
+		}
+	}
+}
+
+// archiveChain archives the journals of the domain chain which occurred
+// before the cutoff. Archiving stops at the first newer journal, so the
+// archived range is always the beginning of the remaining chain. The chain is
+// verified before it's archived and journals are never removed from the
+// broken chain.
+func archiveChain(ctx context.Context, repo Repository, storage Storage, signingKey ed25519.PrivateKey, domain string, cutoff time.Time) (Archive, error) {
+	c, err := anchor(ctx, repo, domain)
+	if err != nil {
+		return Archive{}, err
+	}
+
+	proof := Proof{
+		Domain:        domain,
+		FirstSequence: c.sequence + 1,
+		PrevHash:      c.hash,
+	}
+	var journals []Journal
+	for done := false; !done; {
+		batch, err := repo.RetrieveChain(ctx, domain, c.sequence+1, chainBatch)
+		if err != nil {
+			return Archive{}, err
+		}
+		for _, j := range batch {
+			if !j.OccurredAt.Before(cutoff) || len(journals) == maxArchiveEntries {
+				done = true
+				break
+			}
+			reason, err := c.next(j)
+			if err != nil {
+				return Archive{}, err
+			}
+			if reason != "" {
+				return Archive{}, errors.Wrap(ErrChainBroken, fmt.Errorf("%s at sequence %d", reason, c.sequence+1))
+			}
+			journals = append(journals, j)
+		}
+		if len(batch) < chainBatch {
+			break
+		}
+	}
+	if len(journals) == 0 {
+		return Archive{}, nil
+	}
+
+	proof.LastSequence = c.sequence
+	proof.HeadHash = c.hash
+	proof.Entries = uint64(len(journals))
+	proof.ExportedAt = time.Now().UTC()
+	if signingKey != nil {
+		if err := proof.Sign(signingKey); err != nil {
+			return Archive{}, err
+		}
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := (Export{Journals: journals, Proof: proof}).Encode(zw); err != nil {
+		return Archive{}, err
+	}
+	if err := zw.Close(); err != nil {
+		return Archive{}, err
+	}
+
+	archive := Archive{
+		Domain:        domain,
+		FirstSequence: proof.FirstSequence,
+		LastSequence:  proof.LastSequence,
+		HeadHash:      proof.HeadHash,
+		Entries:       proof.Entries,
+		Key:           fmt.Sprintf("%s/%020d-%020d.ndjson.gz", domain, proof.FirstSequence, proof.LastSequence),
+		CreatedAt:     proof.ExportedAt,
+	}
+	// Journals are removed only once they are safely stored.
+	if err := storage.Save(ctx, archive.Key, &buf); err != nil {
+		return Archive{}, err
+	}
+	if err := repo.Archive(ctx, archive); err != nil {
+		return Archive{}, err
+	}
+
+	return archive, nil
+}
+
+// anchor returns the beginning of the remaining domain chain.
+func anchor(ctx context.Context, repo Repository, domain string) (chain, error) {
+	archive, err := repo.RetrieveLastArchive(ctx, domain)
+	switch {
+	case err == nil:
+		return chain{sequence: archive.LastSequence, hash: archive.HeadHash}, nil
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return chain{}, nil
+	default:
+		return chain{}, err
+	}
+}
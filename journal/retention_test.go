@@ -0,0 +1,88 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package journal_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/journal"
+	"github.com/absmach/magistrala/journal/mocks"
+	mglog "github.com/absmach/magistrala/logger"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRetentionHandler(t *testing.T) {
+	repo := new(mocks.Repository)
+	storage := new(mocks.Storage)
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err, fmt.Sprintf("generating key expected to succeed: %s", err))
+
+	domainID := testsutil.GenerateUUID(t)
+	chain := newChain(t, domainID, 3)
+	// Only the journals older than the retention period are archived.
+	occurredAt := []time.Time{time.Now().Add(-72 * time.Hour), time.Now().Add(-48 * time.Hour), time.Now()}
+	var prevHash string
+	for i := range chain {
+		chain[i].OccurredAt = occurredAt[i]
+		chain[i].PrevHash = prevHash
+		chain[i].Hash, err = chain[i].ComputeHash()
+		assert.Nil(t, err, fmt.Sprintf("computing hash expected to succeed: %s", err))
+		prevHash = chain[i].Hash
+	}
+
+	retention := journal.Retention{Domain: domainID, Days: 1}
+	repo.On("RetrieveAllRetentions", mock.Anything).Return([]journal.Retention{retention}, nil)
+	repo.On("RetrieveLastArchive", mock.Anything, domainID).Return(journal.Archive{}, repoerr.ErrNotFound)
+	repo.On("RetrieveChain", mock.Anything, domainID, uint64(1), mock.Anything).Return(chain, nil)
+
+	type archived struct {
+		archive journal.Archive
+		content []byte
+	}
+	archives := make(chan archived, 1)
+	// Content is accessed only by the handler until it's sent with the archive.
+	var content []byte
+	storage.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		zr, err := gzip.NewReader(args.Get(2).(io.Reader))
+		assert.Nil(t, err, fmt.Sprintf("reading archive expected to succeed: %s", err))
+		content, err = io.ReadAll(zr)
+		assert.Nil(t, err, fmt.Sprintf("reading archive expected to succeed: %s", err))
+	})
+	repo.On("Archive", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		select {
+		case archives <- archived{archive: args.Get(1).(journal.Archive), content: content}:
+		default:
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	journal.NewRetentionHandler(ctx, repo, storage, key, 10*time.Millisecond, mglog.NewMock())
+
+	select {
+	case a := <-archives:
+		cancel()
+		assert.Equal(t, uint64(1), a.archive.FirstSequence)
+		assert.Equal(t, uint64(2), a.archive.LastSequence)
+		assert.Equal(t, chain[1].Hash, a.archive.HeadHash)
+		assert.Equal(t, fmt.Sprintf("%s/%020d-%020d.ndjson.gz", domainID, 1, 2), a.archive.Key)
+
+		proof, err := journal.VerifyExport(bytes.NewReader(a.content), pub)
+		assert.Nil(t, err, fmt.Sprintf("verifying archive expected to succeed: %s", err))
+		assert.Equal(t, uint64(2), proof.Entries)
+	case <-time.After(time.Second):
+		t.Fatal("journals are not archived")
+	}
+}
@@ -5,22 +5,38 @@ package journal
 
 import (
 	"context"
+	"crypto/ed25519"
+	"fmt"
+	"time"
 
 	"github.com/absmach/magistrala"
 	mgauthn "github.com/absmach/magistrala/pkg/authn"
 	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 )
 
+// chainBatch is the number of journals retrieved at once while walking the chain.
+const chainBatch = 1000
+
 type service struct {
-	idProvider magistrala.IDProvider
-	repository Repository
+	idProvider  magistrala.IDProvider
+	repository  Repository
+	checkpoints Checkpoints
+	hub         *Hub
+	signingKey  ed25519.PrivateKey
 }
 
-func NewService(idp magistrala.IDProvider, repository Repository) Service {
+// NewService instantiates the journal service. Saved journals are published
+// to the hub. Exports can't be created if the signing key is nil, and the
+// checkpoint signatures are verified only if it's set.
+func NewService(idp magistrala.IDProvider, repository Repository, checkpoints Checkpoints, hub *Hub, signingKey ed25519.PrivateKey) Service {
 	return &service{
-		idProvider: idp,
-		repository: repository,
+		idProvider:  idp,
+		repository:  repository,
+		checkpoints: checkpoints,
+		hub:         hub,
+		signingKey:  signingKey,
 	}
 }
 
@@ -30,11 +46,25 @@ func (svc *service) Save(ctx context.Context, journal Journal) error {
 		return err
 	}
 	journal.ID = id
+	// Journals which don't belong to a domain are chained in the platform chain.
+	domain, ok := journal.Attributes["domain"].(string)
+	if !ok {
+		domain, _ = journal.Attributes["domain_id"].(string)
+	}
+	journal.Domain = domain
 
-	return svc.repository.Save(ctx, journal)
+	if err := svc.repository.Save(ctx, journal); err != nil {
+		return err
+	}
+	svc.hub.Publish(journal)
+
+	return nil
 }
 
 func (svc *service) RetrieveAll(ctx context.Context, session mgauthn.Session, page Page) (JournalsPage, error) {
+	if page.EntityType.domainOwned() {
+		page.Domain = session.DomainID
+	}
 	journalPage, err := svc.repository.RetrieveAll(ctx, page)
 	if err != nil {
 		return JournalsPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
@@ -42,3 +72,209 @@ func (svc *service) RetrieveAll(ctx context.Context, session mgauthn.Session, pa
 
 	return journalPage, nil
 }
+
+func (svc *service) Verify(ctx context.Context, session mgauthn.Session) (Verification, error) {
+	c, err := anchor(ctx, svc.repository, session.DomainID)
+	if err != nil {
+		return Verification{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	v := Verification{
+		Domain:           session.DomainID,
+		Valid:            true,
+		ArchivedSequence: c.sequence,
+	}
+	cp, err := svc.checkpoints.RetrieveLast(ctx, session.DomainID)
+	if err != nil && !errors.Contains(err, repoerr.ErrNotFound) {
+		return Verification{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	v.CheckpointSequence = cp.Sequence
+	switch {
+	case cp.Sequence == 0:
+	case svc.signingKey != nil && cp.Verify(svc.signingKey.Public().(ed25519.PublicKey)) != nil:
+		v.Valid = false
+		v.BrokenAt = cp.Sequence
+		v.Reason = InvalidCheckpoint
+	case cp.Sequence == c.sequence && cp.HeadHash != c.hash:
+		v.Valid = false
+		v.BrokenAt = cp.Sequence
+		v.Reason = CheckpointMismatch
+	}
+	c.checkpoint = cp
+
+	for v.Valid {
+		journals, err := svc.repository.RetrieveChain(ctx, session.DomainID, c.sequence+1, chainBatch)
+		if err != nil {
+			return Verification{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		for _, j := range journals {
+			reason, err := c.next(j)
+			if err != nil {
+				return Verification{}, err
+			}
+			if reason != "" {
+				v.Valid = false
+				v.BrokenAt = c.sequence + 1
+				v.Reason = reason
+				break
+			}
+			v.Entries++
+		}
+		if len(journals) < chainBatch {
+			break
+		}
+	}
+	// Checkpointed journals must not be missing from the end of the chain.
+	if v.Valid && c.sequence < cp.Sequence {
+		v.Valid = false
+		v.BrokenAt = c.sequence + 1
+		v.Reason = Truncated
+	}
+	v.HeadSequence = c.sequence
+	v.HeadHash = c.hash
+
+	return v, nil
+}
+
+func (svc *service) Export(ctx context.Context, session mgauthn.Session, from, to uint64) (Export, error) {
+	if svc.signingKey == nil {
+		return Export{}, ErrMissingSigningKey
+	}
+	if to != 0 && to < from {
+		return Export{}, errors.Wrap(svcerr.ErrMalformedEntity, errors.New("invalid sequence range"))
+	}
+
+	// Archived journals are no longer part of the chain.
+	c, err := anchor(ctx, svc.repository, session.DomainID)
+	if err != nil {
+		return Export{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if from == 0 {
+		from = c.sequence + 1
+	}
+	if from <= c.sequence {
+		return Export{}, errors.Wrap(svcerr.ErrNotFound, fmt.Errorf("journals up to sequence %d are archived", c.sequence))
+	}
+
+	if from-1 > c.sequence {
+		c = chain{sequence: from - 1}
+		// The previous journal anchors the exported range to the chain.
+		prev, err := svc.repository.RetrieveChain(ctx, session.DomainID, from-1, 1)
+		if err != nil {
+			return Export{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		if len(prev) == 0 {
+			return Export{}, svcerr.ErrNotFound
+		}
+		if prev[0].Sequence != from-1 {
+			return Export{}, errors.Wrap(ErrChainBroken, fmt.Errorf("%s at sequence %d", MissingEntry, from-1))
+		}
+		hash, err := prev[0].ComputeHash()
+		if err != nil {
+			return Export{}, err
+		}
+		if hash != prev[0].Hash {
+			return Export{}, errors.Wrap(ErrChainBroken, fmt.Errorf("%s at sequence %d", HashMismatch, from-1))
+		}
+		c.hash = hash
+	}
+
+	proof := Proof{
+		Domain:        session.DomainID,
+		FirstSequence: from,
+		PrevHash:      c.hash,
+	}
+	var journals []Journal
+	for {
+		batch, err := svc.repository.RetrieveChain(ctx, session.DomainID, c.sequence+1, chainBatch)
+		if err != nil {
+			return Export{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		for _, j := range batch {
+			if to != 0 && c.sequence >= to {
+				break
+			}
+			reason, err := c.next(j)
+			if err != nil {
+				return Export{}, err
+			}
+			if reason != "" {
+				return Export{}, errors.Wrap(ErrChainBroken, fmt.Errorf("%s at sequence %d", reason, c.sequence+1))
+			}
+			journals = append(journals, j)
+		}
+		if len(batch) < chainBatch || (to != 0 && c.sequence >= to) {
+			break
+		}
+	}
+	if len(journals) == 0 {
+		return Export{}, svcerr.ErrNotFound
+	}
+
+	proof.LastSequence = c.sequence
+	proof.HeadHash = c.hash
+	proof.Entries = uint64(len(journals))
+	proof.ExportedAt = time.Now().UTC()
+	if err := proof.Sign(svc.signingKey); err != nil {
+		return Export{}, err
+	}
+
+	return Export{
+		Journals: journals,
+		Proof:    proof,
+	}, nil
+}
+
+func (svc *service) ExportPage(ctx context.Context, session mgauthn.Session, page Page, fn func(Journal) error) error {
+	page.Domain = session.DomainID
+	if err := svc.repository.Iterate(ctx, page, fn); err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return nil
+}
+
+func (svc *service) SetRetention(ctx context.Context, session mgauthn.Session, days uint64) (Retention, error) {
+	r := Retention{
+		Domain:    session.DomainID,
+		Days:      days,
+		UpdatedAt: time.Now().UTC(),
+		UpdatedBy: session.UserID,
+	}
+	if err := svc.repository.SaveRetention(ctx, r); err != nil {
+		return Retention{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return r, nil
+}
+
+func (svc *service) ViewRetention(ctx context.Context, session mgauthn.Session) (Retention, error) {
+	r, err := svc.repository.RetrieveRetention(ctx, session.DomainID)
+	switch {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return Retention{}, svcerr.ErrNotFound
+	case err != nil:
+		return Retention{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return r, nil
+}
+
+func (svc *service) RemoveRetention(ctx context.Context, session mgauthn.Session) error {
+	err := svc.repository.RemoveRetention(ctx, session.DomainID)
+	switch {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return svcerr.ErrNotFound
+	case err != nil:
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (svc *service) Subscribe(ctx context.Context, session mgauthn.Session, page Page) (<-chan Journal, error) {
+	if page.EntityID == "" || page.EntityType.domainOwned() {
+		page.Domain = session.DomainID
+	}
+
+	return svc.hub.Subscribe(ctx, page.Match), nil
+}
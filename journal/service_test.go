@@ -4,9 +4,12 @@
 package journal_test
 
 import (
+	"bytes"
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"fmt"
-	"math/rand"
+	mrand "math/rand"
 	"testing"
 	"time"
 
@@ -16,6 +19,7 @@ import (
 	mgauthn "github.com/absmach/magistrala/pkg/authn"
 	"github.com/absmach/magistrala/pkg/errors"
 	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/absmach/magistrala/pkg/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -26,23 +30,27 @@ var (
 		Operation:  "user.create",
 		OccurredAt: time.Now().Add(-time.Hour),
 		Attributes: map[string]interface{}{
-			"temperature": rand.Float64(),
-			"humidity":    rand.Float64(),
+			"temperature": mrand.Float64(),
+			"humidity":    mrand.Float64(),
 		},
 		Metadata: map[string]interface{}{
-			"sensor_id": rand.Intn(1000),
+			"sensor_id": mrand.Intn(1000),
 		},
 	}
 	idProvider = uuid.New()
 )
 
 func TestSave(t *testing.T) {
-	repo := new(mocks.Repository)
-	svc := journal.NewService(idProvider, repo)
+	domainID := testsutil.GenerateUUID(t)
+	withDomain := validJournal
+	withDomain.Attributes = map[string]interface{}{"domain": domainID}
+	withDomainID := validJournal
+	withDomainID.Attributes = map[string]interface{}{"domain_id": domainID}
 
 	cases := []struct {
 		desc    string
 		journal journal.Journal
+		domain  string
 		repoErr error
 		err     error
 	}{
@@ -52,6 +60,16 @@ func TestSave(t *testing.T) {
 			repoErr: nil,
 			err:     nil,
 		},
+		{
+			desc:    "successful with domain",
+			journal: withDomain,
+			domain:  domainID,
+		},
+		{
+			desc:    "successful with domain ID",
+			journal: withDomainID,
+			domain:  domainID,
+		},
 		{
 			desc:    "with repo error",
 			repoErr: repoerr.ErrCreateEntity,
@@ -61,17 +79,20 @@ func TestSave(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			repoCall := repo.On("Save", context.Background(), mock.Anything).Return(tc.repoErr)
+			repo := new(mocks.Repository)
+			svc := journal.NewService(idProvider, repo, new(mocks.Checkpoints), journal.NewHub(), nil)
+			repo.On("Save", context.Background(), mock.MatchedBy(func(j journal.Journal) bool {
+				return j.Domain == tc.domain
+			})).Return(tc.repoErr)
 			err := svc.Save(context.Background(), tc.journal)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-			repoCall.Unset()
 		})
 	}
 }
 
 func TestReadAll(t *testing.T) {
 	repo := new(mocks.Repository)
-	svc := journal.NewService(idProvider, repo)
+	svc := journal.NewService(idProvider, repo, new(mocks.Checkpoints), journal.NewHub(), nil)
 
 	validSession := mgauthn.Session{DomainUserID: testsutil.GenerateUUID(t), UserID: testsutil.GenerateUUID(t), DomainID: testsutil.GenerateUUID(t)}
 	validPage := journal.Page{
@@ -82,13 +103,14 @@ func TestReadAll(t *testing.T) {
 	}
 
 	cases := []struct {
-		desc    string
-		session mgauthn.Session
-		page    journal.Page
-		resp    journal.JournalsPage
-		authErr error
-		repoErr error
-		err     error
+		desc     string
+		session  mgauthn.Session
+		page     journal.Page
+		repoPage journal.Page
+		resp     journal.JournalsPage
+		authErr  error
+		repoErr  error
+		err      error
 	}{
 		{
 			desc:    "successful",
@@ -123,6 +145,25 @@ func TestReadAll(t *testing.T) {
 			repoErr: nil,
 			err:     nil,
 		},
+		{
+			desc:    "successful for domain owned entity",
+			session: validSession,
+			page: journal.Page{
+				Limit:      10,
+				EntityID:   testsutil.GenerateUUID(t),
+				EntityType: journal.CertificateEntity,
+			},
+			repoPage: journal.Page{
+				Limit:      10,
+				EntityType: journal.CertificateEntity,
+				Domain:     validSession.DomainID,
+			},
+			resp: journal.JournalsPage{
+				Total:    1,
+				Limit:    10,
+				Journals: []journal.Journal{validJournal},
+			},
+		},
 		{
 			desc:    "with repo error",
 			session: validSession,
@@ -135,7 +176,12 @@ func TestReadAll(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			repoCall := repo.On("RetrieveAll", context.Background(), tc.page).Return(tc.resp, tc.repoErr)
+			repoPage := tc.page
+			if tc.repoPage.Domain != "" {
+				repoPage = tc.repoPage
+				repoPage.EntityID = tc.page.EntityID
+			}
+			repoCall := repo.On("RetrieveAll", context.Background(), repoPage).Return(tc.resp, tc.repoErr)
 			resp, err := svc.RetrieveAll(context.Background(), tc.session, tc.page)
 			if tc.err == nil {
 				assert.Equal(t, tc.resp, resp, tc.desc)
@@ -145,3 +191,649 @@ func TestReadAll(t *testing.T) {
 		})
 	}
 }
+
+func newChain(t *testing.T, domain string, n int) []journal.Journal {
+	var prevHash string
+	chain := make([]journal.Journal, n)
+	for i := range chain {
+		j := validJournal
+		j.ID = testsutil.GenerateUUID(t)
+		j.Domain = domain
+		j.Sequence = uint64(i + 1)
+		j.PrevHash = prevHash
+		hash, err := j.ComputeHash()
+		assert.Nil(t, err, fmt.Sprintf("computing hash expected to succeed: %s", err))
+		j.Hash = hash
+		chain[i] = j
+		prevHash = hash
+	}
+
+	return chain
+}
+
+func archiveErr(archive journal.Archive) error {
+	if archive.LastSequence == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func newCheckpoint(t *testing.T, key ed25519.PrivateKey, domain string, sequence uint64, hash string) journal.Checkpoint {
+	cp := journal.Checkpoint{
+		Domain:    domain,
+		Sequence:  sequence,
+		HeadHash:  hash,
+		CreatedAt: time.Now().UTC(),
+	}
+	err := cp.Sign(key)
+	assert.Nil(t, err, fmt.Sprintf("signing checkpoint expected to succeed: %s", err))
+
+	return cp
+}
+
+func checkpointErr(cp journal.Checkpoint) error {
+	if cp.Sequence == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func TestVerify(t *testing.T) {
+	repo := new(mocks.Repository)
+	checkpoints := new(mocks.Checkpoints)
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err, fmt.Sprintf("generating key expected to succeed: %s", err))
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err, fmt.Sprintf("generating key expected to succeed: %s", err))
+	svc := journal.NewService(idProvider, repo, checkpoints, journal.NewHub(), key)
+
+	session := mgauthn.Session{DomainUserID: testsutil.GenerateUUID(t), UserID: testsutil.GenerateUUID(t), DomainID: testsutil.GenerateUUID(t)}
+	chain := newChain(t, session.DomainID, 3)
+
+	modified := append([]journal.Journal{}, chain...)
+	modified[1].Operation = "user.remove"
+
+	relinked := append([]journal.Journal{}, chain...)
+	relinked[2].PrevHash = chain[0].Hash
+
+	// Rewritten chain is relinked from the modified entry on.
+	rewritten := append([]journal.Journal{}, chain...)
+	rewritten[1].Operation = "user.remove"
+	for i := 1; i < len(rewritten); i++ {
+		rewritten[i].PrevHash = rewritten[i-1].Hash
+		rewritten[i].Hash, err = rewritten[i].ComputeHash()
+		assert.Nil(t, err, fmt.Sprintf("computing hash expected to succeed: %s", err))
+	}
+
+	archive := journal.Archive{
+		Domain:        session.DomainID,
+		FirstSequence: 1,
+		LastSequence:  1,
+		HeadHash:      chain[0].Hash,
+		Entries:       1,
+	}
+
+	cases := []struct {
+		desc       string
+		archive    journal.Archive
+		checkpoint journal.Checkpoint
+		journals   []journal.Journal
+		repoErr    error
+		resp       journal.Verification
+		err        error
+	}{
+		{
+			desc:     "valid chain",
+			journals: chain,
+			resp: journal.Verification{
+				Domain:       session.DomainID,
+				Valid:        true,
+				Entries:      3,
+				HeadSequence: 3,
+				HeadHash:     chain[2].Hash,
+			},
+		},
+		{
+			desc:     "archived chain",
+			archive:  archive,
+			journals: chain[1:],
+			resp: journal.Verification{
+				Domain:           session.DomainID,
+				Valid:            true,
+				Entries:          2,
+				ArchivedSequence: 1,
+				HeadSequence:     3,
+				HeadHash:         chain[2].Hash,
+			},
+		},
+		{
+			desc:     "archived chain with missing entry",
+			archive:  archive,
+			journals: chain[2:],
+			resp: journal.Verification{
+				Domain:           session.DomainID,
+				ArchivedSequence: 1,
+				HeadSequence:     1,
+				HeadHash:         chain[0].Hash,
+				BrokenAt:         2,
+				Reason:           journal.MissingEntry,
+			},
+		},
+		{
+			desc: "empty chain",
+			resp: journal.Verification{
+				Domain: session.DomainID,
+				Valid:  true,
+			},
+		},
+		{
+			desc:     "chain with missing entry",
+			journals: []journal.Journal{chain[0], chain[2]},
+			resp: journal.Verification{
+				Domain:       session.DomainID,
+				Entries:      1,
+				HeadSequence: 1,
+				HeadHash:     chain[0].Hash,
+				BrokenAt:     2,
+				Reason:       journal.MissingEntry,
+			},
+		},
+		{
+			desc:     "chain with missing first entry",
+			journals: chain[1:],
+			resp: journal.Verification{
+				Domain:   session.DomainID,
+				BrokenAt: 1,
+				Reason:   journal.MissingEntry,
+			},
+		},
+		{
+			desc:     "chain with modified entry",
+			journals: modified,
+			resp: journal.Verification{
+				Domain:       session.DomainID,
+				Entries:      1,
+				HeadSequence: 1,
+				HeadHash:     chain[0].Hash,
+				BrokenAt:     2,
+				Reason:       journal.HashMismatch,
+			},
+		},
+		{
+			desc:     "chain with broken link",
+			journals: relinked,
+			resp: journal.Verification{
+				Domain:       session.DomainID,
+				Entries:      2,
+				HeadSequence: 2,
+				HeadHash:     chain[1].Hash,
+				BrokenAt:     3,
+				Reason:       journal.BrokenLink,
+			},
+		},
+		{
+			desc:       "chain matching checkpoint",
+			checkpoint: newCheckpoint(t, key, session.DomainID, 2, chain[1].Hash),
+			journals:   chain,
+			resp: journal.Verification{
+				Domain:             session.DomainID,
+				Valid:              true,
+				Entries:            3,
+				CheckpointSequence: 2,
+				HeadSequence:       3,
+				HeadHash:           chain[2].Hash,
+			},
+		},
+		{
+			desc:       "truncated chain",
+			checkpoint: newCheckpoint(t, key, session.DomainID, 3, chain[2].Hash),
+			journals:   chain[:2],
+			resp: journal.Verification{
+				Domain:             session.DomainID,
+				Entries:            2,
+				CheckpointSequence: 3,
+				HeadSequence:       2,
+				HeadHash:           chain[1].Hash,
+				BrokenAt:           3,
+				Reason:             journal.Truncated,
+			},
+		},
+		{
+			desc:       "rewritten chain",
+			checkpoint: newCheckpoint(t, key, session.DomainID, 3, chain[2].Hash),
+			journals:   rewritten,
+			resp: journal.Verification{
+				Domain:             session.DomainID,
+				Entries:            2,
+				CheckpointSequence: 3,
+				HeadSequence:       2,
+				HeadHash:           rewritten[1].Hash,
+				BrokenAt:           3,
+				Reason:             journal.CheckpointMismatch,
+			},
+		},
+		{
+			desc:       "archived chain not matching checkpoint",
+			archive:    archive,
+			checkpoint: newCheckpoint(t, key, session.DomainID, 1, chain[1].Hash),
+			journals:   chain[1:],
+			resp: journal.Verification{
+				Domain:             session.DomainID,
+				ArchivedSequence:   1,
+				CheckpointSequence: 1,
+				HeadSequence:       1,
+				HeadHash:           chain[0].Hash,
+				BrokenAt:           1,
+				Reason:             journal.CheckpointMismatch,
+			},
+		},
+		{
+			desc:       "checkpoint with invalid signature",
+			checkpoint: newCheckpoint(t, otherKey, session.DomainID, 3, chain[2].Hash),
+			journals:   chain,
+			resp: journal.Verification{
+				Domain:             session.DomainID,
+				CheckpointSequence: 3,
+				BrokenAt:           3,
+				Reason:             journal.InvalidCheckpoint,
+			},
+		},
+		{
+			desc:    "with repo error",
+			repoErr: repoerr.ErrViewEntity,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			archiveCall := repo.On("RetrieveLastArchive", context.Background(), session.DomainID).Return(tc.archive, archiveErr(tc.archive))
+			checkpointCall := checkpoints.On("RetrieveLast", context.Background(), session.DomainID).Return(tc.checkpoint, checkpointErr(tc.checkpoint))
+			repoCall := repo.On("RetrieveChain", context.Background(), session.DomainID, tc.archive.LastSequence+1, mock.Anything).Return(tc.journals, tc.repoErr)
+			resp, err := svc.Verify(context.Background(), session)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, resp, tc.desc)
+			archiveCall.Unset()
+			checkpointCall.Unset()
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	repo := new(mocks.Repository)
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err, fmt.Sprintf("generating key expected to succeed: %s", err))
+	svc := journal.NewService(idProvider, repo, new(mocks.Checkpoints), journal.NewHub(), key)
+
+	session := mgauthn.Session{DomainUserID: testsutil.GenerateUUID(t), UserID: testsutil.GenerateUUID(t), DomainID: testsutil.GenerateUUID(t)}
+	chain := newChain(t, session.DomainID, 4)
+
+	modified := append([]journal.Journal{}, chain...)
+	modified[2].Attributes = map[string]interface{}{"id": testsutil.GenerateUUID(t)}
+
+	cases := []struct {
+		desc     string
+		svc      journal.Service
+		archive  journal.Archive
+		from     uint64
+		to       uint64
+		prev     []journal.Journal
+		journals []journal.Journal
+		entries  uint64
+		err      error
+	}{
+		{
+			desc:     "export whole chain",
+			svc:      svc,
+			journals: chain,
+			entries:  4,
+		},
+		{
+			desc:     "export range",
+			svc:      svc,
+			from:     2,
+			to:       3,
+			prev:     chain[:1],
+			journals: chain[1:],
+			entries:  2,
+		},
+		{
+			desc: "export archived chain",
+			svc:  svc,
+			archive: journal.Archive{
+				Domain:        session.DomainID,
+				FirstSequence: 1,
+				LastSequence:  2,
+				HeadHash:      chain[1].Hash,
+				Entries:       2,
+			},
+			journals: chain[2:],
+			entries:  2,
+		},
+		{
+			desc: "export archived range",
+			svc:  svc,
+			archive: journal.Archive{
+				Domain:        session.DomainID,
+				FirstSequence: 1,
+				LastSequence:  2,
+				HeadHash:      chain[1].Hash,
+				Entries:       2,
+			},
+			from: 1,
+			err:  svcerr.ErrNotFound,
+		},
+		{
+			desc: "export without signing key",
+			svc:  journal.NewService(idProvider, repo, new(mocks.Checkpoints), journal.NewHub(), nil),
+			err:  journal.ErrMissingSigningKey,
+		},
+		{
+			desc: "export invalid range",
+			svc:  svc,
+			from: 3,
+			to:   2,
+			err:  svcerr.ErrMalformedEntity,
+		},
+		{
+			desc: "export empty chain",
+			svc:  svc,
+			err:  svcerr.ErrNotFound,
+		},
+		{
+			desc:     "export modified chain",
+			svc:      svc,
+			journals: modified,
+			err:      journal.ErrChainBroken,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			from := tc.from
+			if from == 0 {
+				from = tc.archive.LastSequence + 1
+			}
+			archiveCall := repo.On("RetrieveLastArchive", context.Background(), session.DomainID).Return(tc.archive, archiveErr(tc.archive))
+			prevCall := repo.On("RetrieveChain", context.Background(), session.DomainID, from-1, uint64(1)).Return(tc.prev, nil)
+			repoCall := repo.On("RetrieveChain", context.Background(), session.DomainID, from, mock.Anything).Return(tc.journals, nil)
+			export, err := tc.svc.Export(context.Background(), session, tc.from, tc.to)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.entries, export.Proof.Entries, tc.desc)
+				var buf bytes.Buffer
+				err = export.Encode(&buf)
+				assert.Nil(t, err, fmt.Sprintf("%s: encoding export expected to succeed: %s", tc.desc, err))
+				proof, err := journal.VerifyExport(&buf, pub)
+				assert.Nil(t, err, fmt.Sprintf("%s: verifying export expected to succeed: %s", tc.desc, err))
+				assert.Equal(t, export.Proof.HeadHash, proof.HeadHash, tc.desc)
+			}
+			archiveCall.Unset()
+			prevCall.Unset()
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestExportPage(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := journal.NewService(idProvider, repo, new(mocks.Checkpoints), journal.NewHub(), nil)
+
+	session := mgauthn.Session{DomainUserID: testsutil.GenerateUUID(t), UserID: testsutil.GenerateUUID(t), DomainID: testsutil.GenerateUUID(t)}
+	chain := newChain(t, session.DomainID, 3)
+
+	cases := []struct {
+		desc     string
+		page     journal.Page
+		journals []journal.Journal
+		repoErr  error
+		err      error
+	}{
+		{
+			desc: "export page",
+			page: journal.Page{
+				OperationPrefix: "user.",
+				Actor:           session.UserID,
+			},
+			journals: chain,
+		},
+		{
+			desc:    "export page with repo error",
+			repoErr: repoerr.ErrViewEntity,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page := tc.page
+			page.Domain = session.DomainID
+			repoCall := repo.On("Iterate", context.Background(), page, mock.Anything).Return(func(_ context.Context, _ journal.Page, fn func(journal.Journal) error) error {
+				for _, j := range tc.journals {
+					if err := fn(j); err != nil {
+						return err
+					}
+				}
+				return tc.repoErr
+			})
+			var journals []journal.Journal
+			err := svc.ExportPage(context.Background(), session, tc.page, func(j journal.Journal) error {
+				journals = append(journals, j)
+				return nil
+			})
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.journals, journals, tc.desc)
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestSetRetention(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := journal.NewService(idProvider, repo, new(mocks.Checkpoints), journal.NewHub(), nil)
+
+	session := mgauthn.Session{DomainUserID: testsutil.GenerateUUID(t), UserID: testsutil.GenerateUUID(t), DomainID: testsutil.GenerateUUID(t)}
+
+	cases := []struct {
+		desc    string
+		days    uint64
+		repoErr error
+		err     error
+	}{
+		{
+			desc: "set retention",
+			days: 90,
+		},
+		{
+			desc:    "set retention with repo error",
+			days:    90,
+			repoErr: repoerr.ErrUpdateEntity,
+			err:     svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("SaveRetention", context.Background(), mock.Anything).Return(tc.repoErr)
+			resp, err := svc.SetRetention(context.Background(), session, tc.days)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, session.DomainID, resp.Domain, tc.desc)
+				assert.Equal(t, tc.days, resp.Days, tc.desc)
+				assert.Equal(t, session.UserID, resp.UpdatedBy, tc.desc)
+			}
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestViewRetention(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := journal.NewService(idProvider, repo, new(mocks.Checkpoints), journal.NewHub(), nil)
+
+	session := mgauthn.Session{DomainUserID: testsutil.GenerateUUID(t), UserID: testsutil.GenerateUUID(t), DomainID: testsutil.GenerateUUID(t)}
+	retention := journal.Retention{
+		Domain:    session.DomainID,
+		Days:      30,
+		UpdatedAt: time.Now().UTC(),
+		UpdatedBy: session.UserID,
+	}
+
+	cases := []struct {
+		desc    string
+		resp    journal.Retention
+		repoErr error
+		err     error
+	}{
+		{
+			desc: "view retention",
+			resp: retention,
+		},
+		{
+			desc:    "view missing retention",
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrNotFound,
+		},
+		{
+			desc:    "view retention with repo error",
+			repoErr: repoerr.ErrViewEntity,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveRetention", context.Background(), session.DomainID).Return(tc.resp, tc.repoErr)
+			resp, err := svc.ViewRetention(context.Background(), session)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, resp, tc.desc)
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestRemoveRetention(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := journal.NewService(idProvider, repo, new(mocks.Checkpoints), journal.NewHub(), nil)
+
+	session := mgauthn.Session{DomainUserID: testsutil.GenerateUUID(t), UserID: testsutil.GenerateUUID(t), DomainID: testsutil.GenerateUUID(t)}
+
+	cases := []struct {
+		desc    string
+		repoErr error
+		err     error
+	}{
+		{
+			desc: "remove retention",
+		},
+		{
+			desc:    "remove missing retention",
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrNotFound,
+		},
+		{
+			desc:    "remove retention with repo error",
+			repoErr: repoerr.ErrRemoveEntity,
+			err:     svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RemoveRetention", context.Background(), session.DomainID).Return(tc.repoErr)
+			err := svc.RemoveRetention(context.Background(), session)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	session := mgauthn.Session{DomainUserID: testsutil.GenerateUUID(t), UserID: testsutil.GenerateUUID(t), DomainID: testsutil.GenerateUUID(t)}
+	thingID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc     string
+		page     journal.Page
+		journal  journal.Journal
+		received bool
+	}{
+		{
+			desc: "subscribe to domain journals",
+			journal: journal.Journal{
+				Operation:  "thing.create",
+				Attributes: map[string]interface{}{"domain": session.DomainID, "id": thingID},
+			},
+			received: true,
+		},
+		{
+			desc: "subscribe to domain journals of another domain",
+			journal: journal.Journal{
+				Operation:  "thing.create",
+				Attributes: map[string]interface{}{"domain": testsutil.GenerateUUID(t), "id": thingID},
+			},
+			received: false,
+		},
+		{
+			desc: "subscribe to entity journals",
+			page: journal.Page{EntityType: journal.ThingEntity, EntityID: thingID},
+			journal: journal.Journal{
+				Operation:  "thing.update",
+				Attributes: map[string]interface{}{"domain": session.DomainID, "id": thingID},
+			},
+			received: true,
+		},
+		{
+			desc: "subscribe to entity journals of another entity",
+			page: journal.Page{EntityType: journal.ThingEntity, EntityID: thingID},
+			journal: journal.Journal{
+				Operation:  "thing.update",
+				Attributes: map[string]interface{}{"domain": session.DomainID, "id": testsutil.GenerateUUID(t)},
+			},
+			received: false,
+		},
+		{
+			desc: "subscribe to operation journals",
+			page: journal.Page{OperationPrefix: "thing."},
+			journal: journal.Journal{
+				Operation:  "group.create",
+				Attributes: map[string]interface{}{"domain": session.DomainID},
+			},
+			received: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			svc := journal.NewService(idProvider, repo, new(mocks.Checkpoints), journal.NewHub(), nil)
+			repo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			journals, err := svc.Subscribe(ctx, session, tc.page)
+			assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+
+			err = svc.Save(context.Background(), tc.journal)
+			assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+			// Journals are published synchronously on save.
+			select {
+			case j := <-journals:
+				assert.True(t, tc.received, fmt.Sprintf("%s: unexpected journal %v\n", tc.desc, j))
+				assert.Equal(t, tc.journal.Operation, j.Operation, tc.desc)
+				assert.NotEmpty(t, j.ID, tc.desc)
+			default:
+				assert.False(t, tc.received, fmt.Sprintf("%s: expected journal to be received\n", tc.desc))
+			}
+
+			cancel()
+			assert.Eventually(t, func() bool {
+				_, ok := <-journals
+				return !ok
+			}, time.Second, 10*time.Millisecond, tc.desc)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/absmach/magistrala/journal"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+)
+
+// platformDir is the directory of the platform chain checkpoints, since
+// the platform chain doesn't belong to a domain.
+const platformDir = "_platform"
+
+var _ journal.Checkpoints = (*fsCheckpoints)(nil)
+
+type fsCheckpoints struct {
+	root string
+}
+
+// NewFSCheckpoints instantiates the journal checkpoint storage keeping each
+// checkpoint in its own file under the domain directory. Checkpoint files are
+// created once and never replaced.
+func NewFSCheckpoints(root string) (journal.Checkpoints, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &fsCheckpoints{root: root}, nil
+}
+
+func (fs *fsCheckpoints) Save(ctx context.Context, cp journal.Checkpoint) error {
+	dir, err := fs.dir(cp.Domain)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	b, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, fmt.Sprintf("%020d.json", cp.Sequence)), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	switch {
+	case os.IsExist(err):
+		return errors.Wrap(repoerr.ErrConflict, err)
+	case err != nil:
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func (fs *fsCheckpoints) RetrieveLast(ctx context.Context, domain string) (journal.Checkpoint, error) {
+	dir, err := fs.dir(domain)
+	if err != nil {
+		return journal.Checkpoint{}, err
+	}
+	entries, err := os.ReadDir(dir)
+	switch {
+	case os.IsNotExist(err):
+		return journal.Checkpoint{}, repoerr.ErrNotFound
+	case err != nil:
+		return journal.Checkpoint{}, err
+	}
+
+	// File names are zero padded sequences, so the last name is the
+	// last checkpoint.
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".json") {
+			names = append(names, e.Name())
+		}
+	}
+	if len(names) == 0 {
+		return journal.Checkpoint{}, repoerr.ErrNotFound
+	}
+	sort.Strings(names)
+
+	b, err := os.ReadFile(filepath.Join(dir, names[len(names)-1]))
+	if err != nil {
+		return journal.Checkpoint{}, err
+	}
+	var cp journal.Checkpoint
+	if err := json.Unmarshal(b, &cp); err != nil {
+		return journal.Checkpoint{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+
+	return cp, nil
+}
+
+func (fs *fsCheckpoints) dir(domain string) (string, error) {
+	if domain == "" {
+		domain = platformDir
+	}
+	dir := filepath.Join(fs.root, domain)
+	if filepath.Dir(dir) != fs.root {
+		return "", ErrInvalidKey
+	}
+
+	return dir, nil
+}
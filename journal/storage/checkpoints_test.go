@@ -0,0 +1,96 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/journal"
+	"github.com/absmach/magistrala/journal/storage"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFSCheckpoints(t *testing.T) {
+	cps, err := storage.NewFSCheckpoints(t.TempDir())
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating storage: %s", err))
+
+	domainID := testsutil.GenerateUUID(t)
+	first := journal.Checkpoint{Domain: domainID, Sequence: 9, HeadHash: "hash9", CreatedAt: time.Now().UTC()}
+	last := journal.Checkpoint{Domain: domainID, Sequence: 10, HeadHash: "hash10", CreatedAt: time.Now().UTC()}
+	platform := journal.Checkpoint{Sequence: 1, HeadHash: "hash1", CreatedAt: time.Now().UTC()}
+
+	cases := []struct {
+		desc       string
+		checkpoint journal.Checkpoint
+		err        error
+	}{
+		{
+			desc:       "save checkpoint",
+			checkpoint: first,
+		},
+		{
+			desc:       "save next checkpoint",
+			checkpoint: last,
+		},
+		{
+			desc:       "save platform chain checkpoint",
+			checkpoint: platform,
+		},
+		{
+			desc:       "replace existing checkpoint",
+			checkpoint: journal.Checkpoint{Domain: domainID, Sequence: 9, HeadHash: "rewritten"},
+			err:        repoerr.ErrConflict,
+		},
+		{
+			desc:       "save checkpoint outside of the storage",
+			checkpoint: journal.Checkpoint{Domain: "..", Sequence: 1},
+			err:        storage.ErrInvalidKey,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := cps.Save(context.Background(), tc.checkpoint)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+
+	retrieveCases := []struct {
+		desc       string
+		domain     string
+		checkpoint journal.Checkpoint
+		err        error
+	}{
+		{
+			desc:       "retrieve last checkpoint",
+			domain:     domainID,
+			checkpoint: last,
+		},
+		{
+			desc:       "retrieve last platform chain checkpoint",
+			checkpoint: platform,
+		},
+		{
+			desc:   "retrieve last checkpoint of domain without checkpoints",
+			domain: testsutil.GenerateUUID(t),
+			err:    repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range retrieveCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cp, err := cps.RetrieveLast(context.Background(), tc.domain)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.checkpoint.Sequence, cp.Sequence, tc.desc)
+			assert.Equal(t, tc.checkpoint.HeadHash, cp.HeadHash, tc.desc)
+		})
+	}
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package storage contains the journal archive and checkpoint storage
+// implementations using the local filesystem.
+package storage
@@ -0,0 +1,70 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/absmach/magistrala/journal"
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// ErrInvalidKey indicates the key points outside of the storage.
+var ErrInvalidKey = errors.New("invalid storage key")
+
+var _ journal.Storage = (*fsStorage)(nil)
+
+type fsStorage struct {
+	root string
+}
+
+// NewFS instantiates the journal archive storage keeping archives in files
+// under the root directory.
+func NewFS(root string) (journal.Storage, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &fsStorage{root: root}, nil
+}
+
+func (fs *fsStorage) Save(ctx context.Context, key string, content io.Reader) error {
+	path := filepath.Join(fs.root, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, fs.root+string(filepath.Separator)) {
+		return ErrInvalidKey
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	// Archive is written to the temporary file first, so the partially
+	// written archive never replaces the complete one.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".archive-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/journal/storage"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const content = "journal archive"
+
+func TestFSSave(t *testing.T) {
+	root := t.TempDir()
+	fs, err := storage.NewFS(root)
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating storage: %s", err))
+
+	cases := []struct {
+		desc string
+		key  string
+		err  error
+	}{
+		{
+			desc: "save archive",
+			key:  "domain/00000000000000000001-00000000000000000002.ndjson.gz",
+		},
+		{
+			desc: "save archive outside of the storage",
+			key:  "../archive.ndjson.gz",
+			err:  storage.ErrInvalidKey,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := fs.Save(context.Background(), tc.key, strings.NewReader(content))
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				b, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(tc.key)))
+				assert.Nil(t, err, fmt.Sprintf("%s: reading archive expected to succeed: %s", tc.desc, err))
+				assert.Equal(t, content, string(b), tc.desc)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package journal
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// subscriberBuffer is the number of journals buffered for each subscriber.
+const subscriberBuffer = 100
+
+// Hub fans out the saved journals to the subscribers. Only the journals saved
+// by this service instance are delivered.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+type subscriber struct {
+	match    func(Journal) bool
+	journals chan Journal
+	stop     func() bool
+}
+
+// NewHub returns the hub without subscribers.
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// Subscribe returns the channel of the saved journals which match the filter.
+// Nil filter matches all the journals. The channel is closed once the context
+// is done or once the subscriber falls behind, so journals are never dropped
+// silently.
+func (h *Hub) Subscribe(ctx context.Context, match func(Journal) bool) <-chan Journal {
+	s := &subscriber{
+		match:    match,
+		journals: make(chan Journal, subscriberBuffer),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subscribers[s] = struct{}{}
+	s.stop = context.AfterFunc(ctx, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.remove(s)
+	})
+
+	return s.journals
+}
+
+// Publish delivers the journal to the matching subscribers without blocking.
+func (h *Hub) Publish(j Journal) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for s := range h.subscribers {
+		if s.match != nil && !s.match(j) {
+			continue
+		}
+		select {
+		case s.journals <- j:
+		default:
+			s.stop()
+			h.remove(s)
+		}
+	}
+}
+
+// remove must be called with the lock held.
+func (h *Hub) remove(s *subscriber) {
+	if _, ok := h.subscribers[s]; !ok {
+		return
+	}
+	delete(h.subscribers, s)
+	close(s.journals)
+}
+
+// Match reports whether the journal matches the page domain, entity and
+// operation filters. It's the in-memory counterpart of the repository query
+// used for the journals which are not saved yet.
+func (page Page) Match(j Journal) bool {
+	if page.Domain != "" && j.Domain != page.Domain {
+		return false
+	}
+	if page.Operation != "" && j.Operation != page.Operation {
+		return false
+	}
+	if page.OperationPrefix != "" && !strings.HasPrefix(j.Operation, page.OperationPrefix) {
+		return false
+	}
+	if page.EntityID != "" && !page.EntityType.match(j, page.EntityID) {
+		return false
+	}
+
+	return true
+}
+
+// match is the in-memory counterpart of Query.
+func (e EntityType) match(j Journal, id string) bool {
+	attr := func(key string) bool {
+		v, ok := j.Attributes[key].(string)
+		return ok && v == id
+	}
+	op := func(prefix string) bool {
+		return strings.HasPrefix(j.Operation, prefix)
+	}
+
+	switch e {
+	case UserEntity:
+		return (op("user.") && attr("id")) || attr("user_id")
+	case GroupEntity, ChannelEntity:
+		return (op("group.") && attr("id")) || attr("group_id")
+	case ThingEntity:
+		return (op("thing.") && attr("id")) || attr("thing_id")
+	case DomainEntity:
+		return op("domain.") && (attr("id") || attr("domain_id"))
+	case KeyEntity:
+		return op("key.") && attr("id")
+	case CertificateEntity:
+		return op("certificate.") && (attr("serial_number") || attr("thing_id"))
+	case InvitationEntity:
+		return op("invitation.") && attr("user_id")
+	case BootstrapEntity:
+		return op("bootstrap.") && attr("thing_id")
+	default:
+		return false
+	}
+}
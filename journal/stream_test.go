@@ -0,0 +1,179 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package journal_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/journal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHub(t *testing.T) {
+	hub := journal.NewHub()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	all := hub.Subscribe(ctx, nil)
+	things := hub.Subscribe(ctx, func(j journal.Journal) bool {
+		return j.Operation == "thing.create"
+	})
+
+	hub.Publish(journal.Journal{Operation: "thing.create"})
+	hub.Publish(journal.Journal{Operation: "group.create"})
+
+	assert.Equal(t, "thing.create", (<-all).Operation)
+	assert.Equal(t, "group.create", (<-all).Operation)
+	assert.Equal(t, "thing.create", (<-things).Operation)
+	assert.Len(t, things, 0)
+
+	// The subscriber which falls behind is closed instead of blocking.
+	for i := 0; i <= cap(all); i++ {
+		hub.Publish(journal.Journal{Operation: fmt.Sprintf("group.update.%d", i)})
+	}
+	received := 0
+	for range all {
+		received++
+	}
+	assert.Equal(t, cap(all), received)
+
+	hub.Publish(journal.Journal{Operation: "thing.create"})
+	assert.Equal(t, "thing.create", (<-things).Operation)
+
+	cancel()
+	assert.Eventually(t, func() bool {
+		_, ok := <-things
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPageMatch(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	entityID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc    string
+		page    journal.Page
+		journal journal.Journal
+		match   bool
+	}{
+		{
+			desc:    "empty page",
+			journal: journal.Journal{Operation: "thing.create"},
+			match:   true,
+		},
+		{
+			desc:    "domain",
+			page:    journal.Page{Domain: domainID},
+			journal: journal.Journal{Operation: "thing.create", Domain: domainID},
+			match:   true,
+		},
+		{
+			desc:    "another domain",
+			page:    journal.Page{Domain: domainID},
+			journal: journal.Journal{Operation: "thing.create", Domain: testsutil.GenerateUUID(t)},
+			match:   false,
+		},
+		{
+			desc:    "operation",
+			page:    journal.Page{Operation: "thing.create"},
+			journal: journal.Journal{Operation: "thing.create"},
+			match:   true,
+		},
+		{
+			desc:    "another operation",
+			page:    journal.Page{Operation: "thing.create"},
+			journal: journal.Journal{Operation: "thing.remove"},
+			match:   false,
+		},
+		{
+			desc:    "operation prefix",
+			page:    journal.Page{OperationPrefix: "thing."},
+			journal: journal.Journal{Operation: "thing.remove"},
+			match:   true,
+		},
+		{
+			desc:    "another operation prefix",
+			page:    journal.Page{OperationPrefix: "thing."},
+			journal: journal.Journal{Operation: "group.remove"},
+			match:   false,
+		},
+		{
+			desc:    "user entity",
+			page:    journal.Page{EntityType: journal.UserEntity, EntityID: entityID},
+			journal: journal.Journal{Operation: "user.update", Attributes: map[string]interface{}{"id": entityID}},
+			match:   true,
+		},
+		{
+			desc:    "user entity reference",
+			page:    journal.Page{EntityType: journal.UserEntity, EntityID: entityID},
+			journal: journal.Journal{Operation: "group.assign", Attributes: map[string]interface{}{"user_id": entityID}},
+			match:   true,
+		},
+		{
+			desc:    "user entity of another operation",
+			page:    journal.Page{EntityType: journal.UserEntity, EntityID: entityID},
+			journal: journal.Journal{Operation: "thing.update", Attributes: map[string]interface{}{"id": entityID}},
+			match:   false,
+		},
+		{
+			desc:    "channel entity",
+			page:    journal.Page{EntityType: journal.ChannelEntity, EntityID: entityID},
+			journal: journal.Journal{Operation: "group.update", Attributes: map[string]interface{}{"id": entityID}},
+			match:   true,
+		},
+		{
+			desc:    "thing entity reference",
+			page:    journal.Page{EntityType: journal.ThingEntity, EntityID: entityID},
+			journal: journal.Journal{Operation: "channel.connect", Attributes: map[string]interface{}{"thing_id": entityID}},
+			match:   true,
+		},
+		{
+			desc:    "domain entity",
+			page:    journal.Page{EntityType: journal.DomainEntity, EntityID: entityID},
+			journal: journal.Journal{Operation: "domain.assign", Attributes: map[string]interface{}{"domain_id": entityID}},
+			match:   true,
+		},
+		{
+			desc:    "key entity",
+			page:    journal.Page{EntityType: journal.KeyEntity, EntityID: entityID},
+			journal: journal.Journal{Operation: "key.revoke", Attributes: map[string]interface{}{"id": entityID}},
+			match:   true,
+		},
+		{
+			desc:    "certificate entity by thing",
+			page:    journal.Page{EntityType: journal.CertificateEntity, EntityID: entityID},
+			journal: journal.Journal{Operation: "certificate.revoke", Attributes: map[string]interface{}{"thing_id": entityID}},
+			match:   true,
+		},
+		{
+			desc:    "invitation entity",
+			page:    journal.Page{EntityType: journal.InvitationEntity, EntityID: entityID},
+			journal: journal.Journal{Operation: "invitation.send", Attributes: map[string]interface{}{"user_id": entityID}},
+			match:   true,
+		},
+		{
+			desc:    "bootstrap entity",
+			page:    journal.Page{EntityType: journal.BootstrapEntity, EntityID: entityID},
+			journal: journal.Journal{Operation: "bootstrap.config.update", Attributes: map[string]interface{}{"thing_id": entityID}},
+			match:   true,
+		},
+		{
+			desc:    "bootstrap entity of another thing",
+			page:    journal.Page{EntityType: journal.BootstrapEntity, EntityID: entityID},
+			journal: journal.Journal{Operation: "bootstrap.config.update", Attributes: map[string]interface{}{"thing_id": testsutil.GenerateUUID(t)}},
+			match:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.match, tc.page.Match(tc.journal), tc.desc)
+		})
+	}
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package syslog contains the forwarder which writes the saved journals to
+// the syslog server as RFC 5424 or CEF messages.
+package syslog
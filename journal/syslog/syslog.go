@@ -0,0 +1,279 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package syslog
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/journal"
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// Formats of the forwarded messages.
+const (
+	RFC5424Format = "rfc5424"
+	CEFFormat     = "cef"
+)
+
+const (
+	appName = "magistrala-journal"
+	// priority is the log audit facility (13) with the informational severity (6).
+	priority = 13*8 + 6
+	// sdID identifies the structured data of the journal. 32473 is the private
+	// enterprise number reserved for documentation by RFC 5612.
+	sdID        = "journal@32473"
+	cefVendor   = "Abstract Machines"
+	cefProduct  = "Magistrala"
+	cefSeverity = 3
+
+	// timestampLayout is RFC 3339 limited to microseconds, as RFC 5424 requires.
+	timestampLayout = "2006-01-02T15:04:05.000000Z07:00"
+	dialTimeout     = 5 * time.Second
+	writeTimeout    = 5 * time.Second
+)
+
+var (
+	// ErrUnsupportedFormat indicates the message format is neither RFC 5424 nor CEF.
+	ErrUnsupportedFormat = errors.New("unsupported syslog message format")
+
+	// ErrInvalidURL indicates the syslog server URL is malformed or its
+	// scheme is not one of udp, tcp or tls.
+	ErrInvalidURL = errors.New("invalid syslog server URL")
+)
+
+type forwarder struct {
+	network  string
+	address  string
+	tls      *tls.Config
+	format   func(journal.Journal) (string, error)
+	hostname string
+	conn     net.Conn
+	logger   *slog.Logger
+}
+
+// Start forwards the journals published to the hub to the syslog server
+// until the context is done. The URL scheme selects the transport, which is
+// one of udp, tcp or tls. Messages are framed by the octet count over the
+// stream transports. Journals which can't be delivered are logged and
+// dropped.
+func Start(ctx context.Context, hub *journal.Hub, serverURL, format string, logger *slog.Logger) error {
+	f := &forwarder{
+		hostname: hostname(),
+		logger:   logger,
+	}
+	switch format {
+	case RFC5424Format:
+		f.format = f.rfc5424
+	case CEFFormat:
+		f.format = f.cef
+	default:
+		return ErrUnsupportedFormat
+	}
+
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return errors.Wrap(ErrInvalidURL, err)
+	}
+	port := u.Port()
+	switch u.Scheme {
+	case "udp", "tcp":
+		f.network = u.Scheme
+		if port == "" {
+			port = "514"
+		}
+	case "tls":
+		f.network = "tcp"
+		f.tls = &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}
+		if port == "" {
+			port = "6514"
+		}
+	default:
+		return ErrInvalidURL
+	}
+	if u.Hostname() == "" {
+		return ErrInvalidURL
+	}
+	f.address = net.JoinHostPort(u.Hostname(), port)
+
+	// Subscription precedes the start, so no journal saved afterwards is missed.
+	journals := hub.Subscribe(ctx, nil)
+	go func() {
+		defer f.close()
+		for {
+			for j := range journals {
+				if err := f.forward(j); err != nil {
+					f.logger.Warn("failed to forward journal to syslog", slog.String("id", j.ID), slog.Any("error", err))
+				}
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			f.logger.Warn("syslog forwarder fell behind, journals were dropped")
+			journals = hub.Subscribe(ctx, nil)
+		}
+	}()
+
+	return nil
+}
+
+func (f *forwarder) forward(j journal.Journal) error {
+	msg, err := f.format(j)
+	if err != nil {
+		return err
+	}
+	if f.conn == nil {
+		if err := f.dial(); err != nil {
+			return err
+		}
+	}
+	// Stream transports use the octet counting framing of RFC 6587.
+	if f.network != "udp" {
+		msg = fmt.Sprintf("%d %s", len(msg), msg)
+	}
+	if err := f.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		f.close()
+		return err
+	}
+	if _, err := io.WriteString(f.conn, msg); err != nil {
+		// The connection is established again with the next journal.
+		f.close()
+		return err
+	}
+
+	return nil
+}
+
+func (f *forwarder) dial() error {
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	var err error
+	if f.tls != nil {
+		f.conn, err = tls.DialWithDialer(dialer, f.network, f.address, f.tls)
+		return err
+	}
+	f.conn, err = dialer.Dial(f.network, f.address)
+
+	return err
+}
+
+func (f *forwarder) close() {
+	if f.conn != nil {
+		f.conn.Close()
+		f.conn = nil
+	}
+}
+
+// header returns the RFC 5424 header of the journal message.
+func (f *forwarder) header(j journal.Journal) string {
+	ts := "-"
+	if !j.OccurredAt.IsZero() {
+		ts = j.OccurredAt.UTC().Format(timestampLayout)
+	}
+
+	return fmt.Sprintf("<%d>1 %s %s %s - %s", priority, ts, f.hostname, appName, printable(j.Operation, 32))
+}
+
+// rfc5424 formats the journal as the RFC 5424 message with the journal
+// identifiers as the structured data and the JSON encoded journal as the
+// message.
+func (f *forwarder) rfc5424(j journal.Journal) (string, error) {
+	data, err := json.Marshal(j)
+	if err != nil {
+		return "", err
+	}
+	sd := fmt.Sprintf(`[%s id="%s" domain="%s" operation="%s"]`, sdID, escapeParam(j.ID), escapeParam(j.Domain), escapeParam(j.Operation))
+
+	return fmt.Sprintf("%s %s %s", f.header(j), sd, data), nil
+}
+
+// cef formats the journal as the ArcSight Common Event Format message
+// carried by the RFC 5424 message.
+func (f *forwarder) cef(j journal.Journal) (string, error) {
+	attributes, err := json.Marshal(j.Attributes)
+	if err != nil {
+		return "", err
+	}
+	ext := []string{
+		"rt=" + strconv.FormatInt(j.OccurredAt.UnixMilli(), 10),
+		"externalId=" + escapeExtension(j.ID),
+		"cs1Label=domain",
+		"cs1=" + escapeExtension(j.Domain),
+	}
+	if actor := actor(j); actor != "" {
+		ext = append(ext, "suser="+escapeExtension(actor))
+	}
+	ext = append(ext, "cs2Label=attributes", "cs2="+escapeExtension(string(attributes)))
+	op := escapeHeader(j.Operation)
+	cef := fmt.Sprintf("CEF:0|%s|%s|%s|%s|%s|%d|%s", cefVendor, cefProduct, escapeHeader(magistrala.Version), op, op, cefSeverity, strings.Join(ext, " "))
+
+	return fmt.Sprintf("%s - %s", f.header(j), cef), nil
+}
+
+// actor returns the ID of the user who performed the operation.
+func actor(j journal.Journal) string {
+	for _, key := range []string{"updated_by", "created_by"} {
+		if id, ok := j.Attributes[key].(string); ok && id != "" {
+			return id
+		}
+	}
+
+	return ""
+}
+
+func hostname() string {
+	host, err := os.Hostname()
+	if err != nil {
+		return "-"
+	}
+
+	return printable(host, 255)
+}
+
+// printable limits the header field to the printable US-ASCII characters
+// and the maximum length, as RFC 5424 requires.
+func printable(s string, size int) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '_'
+		}
+		return r
+	}, s)
+	if len(s) > size {
+		s = s[:size]
+	}
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
+
+var (
+	paramEscaper     = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+	headerEscaper    = strings.NewReplacer(`\`, `\\`, `|`, `\|`)
+	extensionEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\r", `\r`, "\n", `\n`)
+)
+
+func escapeParam(s string) string {
+	return paramEscaper.Replace(s)
+}
+
+func escapeHeader(s string) string {
+	return headerEscaper.Replace(s)
+}
+
+func escapeExtension(s string) string {
+	return extensionEscaper.Replace(s)
+}
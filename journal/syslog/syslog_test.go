@@ -0,0 +1,144 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package syslog_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/journal"
+	"github.com/absmach/magistrala/journal/syslog"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStart(t *testing.T) {
+	cases := []struct {
+		desc   string
+		url    string
+		format string
+		err    error
+	}{
+		{
+			desc:   "start with udp",
+			url:    "udp://localhost",
+			format: syslog.RFC5424Format,
+		},
+		{
+			desc:   "start with tls",
+			url:    "tls://localhost:6514",
+			format: syslog.CEFFormat,
+		},
+		{
+			desc:   "start with unsupported format",
+			url:    "udp://localhost:514",
+			format: "json",
+			err:    syslog.ErrUnsupportedFormat,
+		},
+		{
+			desc:   "start with unsupported scheme",
+			url:    "http://localhost:514",
+			format: syslog.RFC5424Format,
+			err:    syslog.ErrInvalidURL,
+		},
+		{
+			desc:   "start without host",
+			url:    "tcp://:514",
+			format: syslog.RFC5424Format,
+			err:    syslog.ErrInvalidURL,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			err := syslog.Start(ctx, journal.NewHub(), tc.url, tc.format, mglog.NewMock())
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestForwardRFC5424(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.Nil(t, err, fmt.Sprintf("listening expected to succeed: %s", err))
+	defer conn.Close()
+
+	hub := journal.NewHub()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err = syslog.Start(ctx, hub, "udp://"+conn.LocalAddr().String(), syslog.RFC5424Format, mglog.NewMock())
+	assert.Nil(t, err, fmt.Sprintf("starting forwarder expected to succeed: %s", err))
+
+	j := newJournal(t)
+	hub.Publish(j)
+
+	buf := make([]byte, 64*1024)
+	assert.Nil(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	assert.Nil(t, err, fmt.Sprintf("reading message expected to succeed: %s", err))
+	msg := string(buf[:n])
+
+	assert.True(t, strings.HasPrefix(msg, "<110>1 2024-01-02T03:04:05.000006Z "), msg)
+	assert.Contains(t, msg, " magistrala-journal - thing.create ")
+	assert.Contains(t, msg, fmt.Sprintf(`[journal@32473 id="%s" domain="%s" operation="thing.create"]`, j.ID, j.Domain))
+	assert.Contains(t, msg, `"attributes":{"created_by":"admin","name":"a \"quoted\" | name"}`)
+}
+
+func TestForwardCEF(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err, fmt.Sprintf("listening expected to succeed: %s", err))
+	defer l.Close()
+
+	hub := journal.NewHub()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err = syslog.Start(ctx, hub, "tcp://"+l.Addr().String(), syslog.CEFFormat, mglog.NewMock())
+	assert.Nil(t, err, fmt.Sprintf("starting forwarder expected to succeed: %s", err))
+
+	j := newJournal(t)
+	hub.Publish(j)
+
+	assert.Nil(t, l.(*net.TCPListener).SetDeadline(time.Now().Add(time.Second)))
+	conn, err := l.Accept()
+	assert.Nil(t, err, fmt.Sprintf("accepting connection expected to succeed: %s", err))
+	defer conn.Close()
+	assert.Nil(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+
+	// Messages are framed by the octet count.
+	r := bufio.NewReader(conn)
+	size, err := r.ReadString(' ')
+	assert.Nil(t, err, fmt.Sprintf("reading message size expected to succeed: %s", err))
+	n, err := strconv.Atoi(strings.TrimSpace(size))
+	assert.Nil(t, err, fmt.Sprintf("parsing message size expected to succeed: %s", err))
+	buf := make([]byte, n)
+	_, err = io.ReadFull(r, buf)
+	assert.Nil(t, err, fmt.Sprintf("reading message expected to succeed: %s", err))
+	msg := string(buf)
+
+	assert.True(t, strings.HasPrefix(msg, "<110>1 2024-01-02T03:04:05.000006Z "), msg)
+	assert.Contains(t, msg, " - CEF:0|Abstract Machines|Magistrala|")
+	assert.Contains(t, msg, "|thing.create|thing.create|3|rt=1704164645000 externalId="+j.ID)
+	assert.Contains(t, msg, "cs1Label=domain cs1="+j.Domain+" suser=admin cs2Label=attributes")
+	assert.True(t, strings.HasSuffix(msg, `cs2={"created_by":"admin","name":"a \\"quoted\\" | name"}`), msg)
+}
+
+func newJournal(t *testing.T) journal.Journal {
+	return journal.Journal{
+		ID:         testsutil.GenerateUUID(t),
+		Domain:     testsutil.GenerateUUID(t),
+		Operation:  "thing.create",
+		OccurredAt: time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC),
+		Attributes: map[string]interface{}{"created_by": "admin", "name": `a "quoted" | name`},
+	}
+}
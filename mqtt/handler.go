@@ -10,18 +10,22 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/absmach/magistrala"
-	"github.com/absmach/magistrala/mqtt/events"
 	"github.com/absmach/magistrala/pkg/errors"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/absmach/magistrala/pkg/policies"
+	"github.com/absmach/magistrala/pkg/presence"
+	"github.com/absmach/magistrala/pkg/retained"
+	"github.com/absmach/magistrala/pkg/schema"
 	"github.com/absmach/mgate/pkg/session"
+	"github.com/eclipse/paho.mqtt.golang/packets"
 )
 
-var _ session.Handler = (*handler)(nil)
+var _ Handler = (*handler)(nil)
 
 const protocol = "mqtt"
 
@@ -50,26 +54,51 @@ var (
 	ErrFailedPublishDisconnectEvent = errors.New("failed to publish disconnect event")
 	ErrFailedParseSubtopic          = errors.New("failed to parse subtopic")
 	ErrFailedPublishConnectEvent    = errors.New("failed to publish connect event")
+	ErrFailedPublishActivityEvent   = errors.New("failed to publish activity event")
 	ErrFailedPublishToMsgBroker     = errors.New("failed to publish to magistrala message broker")
+	ErrPayloadFormatInvalid         = errors.New("payload format invalid")
+	ErrFailedPublishWill            = errors.New("failed to publish last will")
 )
 
 var channelRegExp = regexp.MustCompile(`^\/?channels\/([\w\-]+)\/messages(\/[^?]*)?(\?.*)?$`)
 
+// Handler handles the MQTT session events and intercepts the packets sent
+// by the client to apply the channel retention and the last will.
+type Handler interface {
+	session.Handler
+	session.Interceptor
+}
+
+// will is the last will of the session.
+type will struct {
+	topic   string
+	payload []byte
+}
+
 // Event implements events.Event interface.
 type handler struct {
 	publisher messaging.Publisher
 	things    magistrala.ThingsServiceClient
+	validator schema.Validator
+	channels  retained.Channels
 	logger    *slog.Logger
-	es        events.EventStore
+	es        presence.EventStore
+	mu        sync.Mutex
+	sessions  map[*session.Session]string
+	wills     map[*session.Session]will
 }
 
 // NewHandler creates new Handler entity.
-func NewHandler(publisher messaging.Publisher, es events.EventStore, logger *slog.Logger, thingsClient magistrala.ThingsServiceClient) session.Handler {
+func NewHandler(publisher messaging.Publisher, es presence.EventStore, logger *slog.Logger, thingsClient magistrala.ThingsServiceClient, validator schema.Validator, channels retained.Channels) Handler {
 	return &handler{
 		es:        es,
 		logger:    logger,
 		publisher: publisher,
 		things:    thingsClient,
+		validator: validator,
+		channels:  channels,
+		sessions:  make(map[*session.Session]string),
+		wills:     make(map[*session.Session]will),
 	}
 }
 
@@ -85,12 +114,6 @@ func (h *handler) AuthConnect(ctx context.Context) error {
 		return ErrMissingClientID
 	}
 
-	pwd := string(s.Password)
-
-	if err := h.es.Connect(ctx, pwd); err != nil {
-		h.logger.Error(errors.Wrap(ErrFailedPublishConnectEvent, err).Error())
-	}
-
 	return nil
 }
 
@@ -105,7 +128,11 @@ func (h *handler) AuthPublish(ctx context.Context, topic *string, payload *[]byt
 		return ErrClientNotInitialized
 	}
 
-	return h.authAccess(ctx, string(s.Password), *topic, policies.PublishPermission)
+	if err := h.authAccess(ctx, s, *topic, policies.PublishPermission); err != nil {
+		return err
+	}
+
+	return h.validate(ctx, *topic, payload)
 }
 
 // AuthSubscribe is called on device subscribe,
@@ -120,7 +147,7 @@ func (h *handler) AuthSubscribe(ctx context.Context, topics *[]string) error {
 	}
 
 	for _, v := range *topics {
-		if err := h.authAccess(ctx, string(s.Password), v, policies.SubscribePermission); err != nil {
+		if err := h.authAccess(ctx, s, v, policies.SubscribePermission); err != nil {
 			return err
 		}
 	}
@@ -161,11 +188,20 @@ func (h *handler) Publish(ctx context.Context, topic *string, payload *[]byte) e
 		return errors.Wrap(ErrFailedParseSubtopic, err)
 	}
 
+	// MQTT username is not verified, so the publisher is the thing
+	// authorized to publish in the session.
+	h.mu.Lock()
+	thingID, ok := h.sessions[s]
+	h.mu.Unlock()
+	if !ok {
+		return errors.Wrap(ErrFailedPublish, svcerr.ErrAuthorization)
+	}
+
 	msg := messaging.Message{
 		Protocol:  protocol,
 		Channel:   chanID,
 		Subtopic:  subtopic,
-		Publisher: s.Username,
+		Publisher: thingID,
 		Payload:   *payload,
 		Created:   time.Now().UnixNano(),
 	}
@@ -174,6 +210,10 @@ func (h *handler) Publish(ctx context.Context, topic *string, payload *[]byte) e
 		return errors.Wrap(ErrFailedPublishToMsgBroker, err)
 	}
 
+	if err := h.es.Published(ctx, thingID); err != nil {
+		h.logger.Error(errors.Wrap(ErrFailedPublishActivityEvent, err).Error())
+	}
+
 	return nil
 }
 
@@ -203,14 +243,71 @@ func (h *handler) Disconnect(ctx context.Context) error {
 	if !ok {
 		return errors.Wrap(ErrFailedDisconnect, ErrClientNotInitialized)
 	}
-	h.logger.Error(fmt.Sprintf(LogInfoDisconnected, s.ID, s.Password))
-	if err := h.es.Disconnect(ctx, string(s.Password)); err != nil {
+	h.logger.Info(fmt.Sprintf(LogInfoDisconnected, s.ID, s.Username))
+
+	h.mu.Lock()
+	thingID, ok := h.sessions[s]
+	delete(h.sessions, s)
+	w, hasWill := h.wills[s]
+	delete(h.wills, s)
+	h.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	if hasWill {
+		if err := h.publishWill(ctx, thingID, w); err != nil {
+			h.logger.Error(errors.Wrap(ErrFailedPublishWill, err).Error())
+		}
+	}
+	if err := h.es.Disconnect(ctx, thingID, s.ID); err != nil {
 		return errors.Wrap(ErrFailedPublishDisconnectEvent, err)
 	}
 	return nil
 }
 
-func (h *handler) authAccess(ctx context.Context, password, topic, action string) error {
+// Intercept is called on every packet flowing through the proxy. The retain
+// flag of the packets published by the client is set by the channel
+// retention, so the MQTT broker retains the same messages as the platform.
+// The last will is authorized on connect and kept by the handler, so it is
+// published as the platform message unless the client disconnects.
+func (h *handler) Intercept(ctx context.Context, pkt packets.ControlPacket, dir session.Direction) (packets.ControlPacket, error) {
+	if dir != session.Up {
+		return pkt, nil
+	}
+	s, ok := session.FromContext(ctx)
+	if !ok {
+		return nil, ErrClientNotInitialized
+	}
+
+	switch p := pkt.(type) {
+	case *packets.ConnectPacket:
+		if !p.WillFlag {
+			return pkt, nil
+		}
+		if err := h.authAccess(ctx, s, p.WillTopic, policies.PublishPermission); err != nil {
+			return nil, errors.Wrap(ErrFailedConnect, err)
+		}
+		if err := h.validate(ctx, p.WillTopic, &p.WillMessage); err != nil {
+			return nil, errors.Wrap(ErrFailedConnect, err)
+		}
+		p.WillRetain = h.retained(ctx, p.WillTopic)
+
+		h.mu.Lock()
+		h.wills[s] = will{topic: p.WillTopic, payload: p.WillMessage}
+		h.mu.Unlock()
+	case *packets.PublishPacket:
+		p.Retain = h.retained(ctx, p.TopicName)
+	case *packets.DisconnectPacket:
+		// The last will is discarded when the client disconnects.
+		h.mu.Lock()
+		delete(h.wills, s)
+		h.mu.Unlock()
+	}
+
+	return pkt, nil
+}
+
+func (h *handler) authAccess(ctx context.Context, s *session.Session, topic, action string) error {
 	// Topics are in the format:
 	// channels/<channel_id>/messages/<subtopic>/.../ct/<content_type>
 	if !channelRegExp.MatchString(topic) {
@@ -226,7 +323,7 @@ func (h *handler) authAccess(ctx context.Context, password, topic, action string
 
 	ar := &magistrala.ThingsAuthzReq{
 		Permission: action,
-		ThingKey:   password,
+		ThingKey:   string(s.Password),
 		ChannelId:  chanID,
 	}
 	res, err := h.things.Authorize(ctx, ar)
@@ -236,10 +333,86 @@ func (h *handler) authAccess(ctx context.Context, password, topic, action string
 	if !res.GetAuthorized() {
 		return svcerr.ErrAuthorization
 	}
+	h.connected(ctx, s, res.GetId())
 
 	return nil
 }
 
+// connected issues the connect event on the first successful authorization
+// of the session. Credentials are not verified on MQTT CONNECT, so the thing
+// is considered connected once it is authorized to publish or subscribe.
+func (h *handler) connected(ctx context.Context, s *session.Session, thingID string) {
+	h.mu.Lock()
+	_, ok := h.sessions[s]
+	if !ok {
+		h.sessions[s] = thingID
+	}
+	h.mu.Unlock()
+	if ok {
+		return
+	}
+
+	if err := h.es.Connect(ctx, thingID, s.ID); err != nil {
+		h.logger.Error(errors.Wrap(ErrFailedPublishConnectEvent, err).Error())
+	}
+}
+
+// validate validates the payload against the channel schema. The MQTT proxy
+// supports MQTT 3.1.1 only, which has no publish reason codes, so the error
+// disconnects the client.
+func (h *handler) validate(ctx context.Context, topic string, payload *[]byte) error {
+	channelParts := channelRegExp.FindStringSubmatch(topic)
+	if len(channelParts) < 2 {
+		return ErrMalformedTopic
+	}
+	var pl []byte
+	if payload != nil {
+		pl = *payload
+	}
+	if err := h.validator.Validate(ctx, channelParts[1], pl); err != nil {
+		return errors.Wrap(ErrPayloadFormatInvalid, err)
+	}
+
+	return nil
+}
+
+// publishWill publishes the last will of the thing. The will is published
+// to the MQTT subscribers by the MQTT broker, so it is not forwarded back.
+func (h *handler) publishWill(ctx context.Context, thingID string, w will) error {
+	channelParts := channelRegExp.FindStringSubmatch(w.topic)
+	if len(channelParts) < 2 {
+		return ErrMalformedTopic
+	}
+	subtopic, err := parseSubtopic(channelParts[2])
+	if err != nil {
+		return errors.Wrap(ErrFailedParseSubtopic, err)
+	}
+
+	msg := messaging.Message{
+		Protocol:  protocol,
+		Channel:   channelParts[1],
+		Subtopic:  subtopic,
+		Publisher: thingID,
+		Payload:   w.payload,
+		Created:   time.Now().UnixNano(),
+	}
+	if err := h.publisher.Publish(ctx, msg.GetChannel(), &msg); err != nil {
+		return errors.Wrap(ErrFailedPublishToMsgBroker, err)
+	}
+
+	return nil
+}
+
+// retained returns true if the channel of the topic retains the messages.
+func (h *handler) retained(ctx context.Context, topic string) bool {
+	channelParts := channelRegExp.FindStringSubmatch(topic)
+	if len(channelParts) < 2 {
+		return false
+	}
+
+	return h.channels.Retained(ctx, channelParts[1])
+}
+
 func parseSubtopic(subtopic string) (string, error) {
 	if subtopic == "" {
 		return subtopic, nil
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"log"
 	"testing"
+	"time"
 
 	"github.com/absmach/magistrala"
 	"github.com/absmach/magistrala/internal/testsutil"
@@ -17,8 +18,14 @@ import (
 	"github.com/absmach/magistrala/mqtt/mocks"
 	"github.com/absmach/magistrala/pkg/errors"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+	msgmocks "github.com/absmach/magistrala/pkg/messaging/mocks"
+	presencemocks "github.com/absmach/magistrala/pkg/presence/mocks"
+	"github.com/absmach/magistrala/pkg/retained"
+	"github.com/absmach/magistrala/pkg/schema"
 	thmocks "github.com/absmach/magistrala/things/mocks"
 	"github.com/absmach/mgate/pkg/session"
+	"github.com/eclipse/paho.mqtt.golang/packets"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -113,7 +120,7 @@ func TestAuthConnect(t *testing.T) {
 			ctx = session.NewContext(ctx, tc.session)
 			password = string(tc.session.Password)
 		}
-		svcCall := eventStore.On("Connect", mock.Anything, password).Return(tc.err)
+		svcCall := eventStore.On("Connect", mock.Anything, password, mock.Anything).Return(tc.err)
 		err := handler.AuthConnect(ctx)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		svcCall.Unset()
@@ -172,6 +179,50 @@ func TestAuthPublish(t *testing.T) {
 	}
 }
 
+func TestAuthPublishWithSchema(t *testing.T) {
+	schemas := newSchemaCache()
+	err := schemas.Save(chanID, map[string]interface{}{
+		schema.SenMLKey: map[string]interface{}{"temperature": "Cel"},
+	})
+	assert.Nil(t, err, fmt.Sprintf("failed to save channel schema with err: %v", err))
+	handler, things, _ := newValidatingHandler(schemas)
+
+	cases := []struct {
+		desc    string
+		payload []byte
+		err     error
+	}{
+		{
+			desc:    "publish payload conforming to channel schema",
+			payload: []byte(`[{"n":"temperature","u":"Cel","v":21.5}]`),
+			err:     nil,
+		},
+		{
+			desc:    "publish payload with invalid unit",
+			payload: []byte(`[{"n":"temperature","u":"K","v":294.6}]`),
+			err:     mqtt.ErrPayloadFormatInvalid,
+		},
+		{
+			desc:    "publish payload with unknown record name",
+			payload: []byte(`[{"n":"humidity","u":"%RH","v":40}]`),
+			err:     mqtt.ErrPayloadFormatInvalid,
+		},
+		{
+			desc:    "publish malformed payload",
+			payload: []byte(`temperature=21.5`),
+			err:     mqtt.ErrPayloadFormatInvalid,
+		},
+	}
+
+	for _, tc := range cases {
+		repocall := things.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.ThingsAuthzRes{Authorized: true, Id: testsutil.GenerateUUID(t)}, nil)
+		ctx := session.NewContext(context.TODO(), &sessionClient)
+		err := handler.AuthPublish(ctx, &topic, &tc.payload)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		repocall.Unset()
+	}
+}
+
 func TestAuthSubscribe(t *testing.T) {
 	handler, things, _ := newHandler()
 
@@ -260,9 +311,15 @@ func TestConnect(t *testing.T) {
 }
 
 func TestPublish(t *testing.T) {
-	handler, _, _ := newHandler()
+	handler, things, eventStore := newHandler()
 	logBuffer.Reset()
 
+	// The publisher is the thing authorized to publish in the session.
+	things.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.ThingsAuthzRes{Authorized: true, Id: thingID}, nil)
+	eventStore.On("Published", mock.Anything, thingID).Return(nil)
+	err := handler.AuthPublish(session.NewContext(context.TODO(), &sessionClient), &topic, &payload)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error authorizing session: %s", err))
+
 	malformedSubtopics := topic + "/" + subtopic + "%"
 	wrongCharSubtopics := topic + "/" + subtopic + ">"
 	validSubtopic := topic + "/" + subtopic
@@ -282,6 +339,13 @@ func TestPublish(t *testing.T) {
 			payload: payload,
 			err:     errors.Wrap(mqtt.ErrFailedPublish, mqtt.ErrClientNotInitialized),
 		},
+		{
+			desc:    "publish without authorized session",
+			session: &sessionClientSub,
+			topic:   topic,
+			payload: payload,
+			err:     errors.Wrap(mqtt.ErrFailedPublish, svcerr.ErrAuthorization),
+		},
 		{
 			desc:    "publish with invalid topic",
 			session: &sessionClient,
@@ -442,7 +506,7 @@ func TestDisconnect(t *testing.T) {
 			ctx = session.NewContext(ctx, tc.session)
 			password = string(tc.session.Password)
 		}
-		svcCall := eventStore.On("Disconnect", mock.Anything, password).Return(tc.err)
+		svcCall := eventStore.On("Disconnect", mock.Anything, password, mock.Anything).Return(tc.err)
 		err := handler.Disconnect(ctx)
 		assert.Contains(t, logBuffer.String(), tc.logMsg)
 		assert.Equal(t, tc.err, err)
@@ -450,12 +514,211 @@ func TestDisconnect(t *testing.T) {
 	}
 }
 
-func newHandler() (session.Handler, *thmocks.ThingsServiceClient, *mocks.EventStore) {
+func newHandler() (session.Handler, *thmocks.ThingsServiceClient, *presencemocks.EventStore) {
+	return newValidatingHandler(newSchemaCache())
+}
+
+func newValidatingHandler(validator schema.Validator) (session.Handler, *thmocks.ThingsServiceClient, *presencemocks.EventStore) {
 	logger, err := mglog.New(&logBuffer, "debug")
 	if err != nil {
 		log.Fatalf("failed to create logger: %s", err)
 	}
 	things := new(thmocks.ThingsServiceClient)
-	eventStore := new(mocks.EventStore)
-	return mqtt.NewHandler(mocks.NewPublisher(), eventStore, logger, things), things, eventStore
+	eventStore := new(presencemocks.EventStore)
+	eventStore.On("Connect", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	return mqtt.NewHandler(mocks.NewPublisher(), eventStore, logger, things, validator, newRetainedChannels()), things, eventStore
+}
+
+func TestPresence(t *testing.T) {
+	logger, err := mglog.New(&logBuffer, "debug")
+	assert.Nil(t, err, fmt.Sprintf("failed to create logger: %s", err))
+	things := new(thmocks.ThingsServiceClient)
+	eventStore := new(presencemocks.EventStore)
+	handler := mqtt.NewHandler(mocks.NewPublisher(), eventStore, logger, things, newSchemaCache(), newRetainedChannels())
+
+	sess := session.Session{
+		ID:       clientID,
+		Username: thingID,
+		Password: []byte(password),
+	}
+	ctx := session.NewContext(context.TODO(), &sess)
+	repoCall := things.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.ThingsAuthzRes{Authorized: true, Id: thingID}, nil)
+	connectCall := eventStore.On("Connect", mock.Anything, thingID, mock.Anything).Return(nil)
+	publishedCall := eventStore.On("Published", mock.Anything, thingID).Return(nil)
+	disconnectCall := eventStore.On("Disconnect", mock.Anything, thingID, mock.Anything).Return(nil)
+	defer func() {
+		repoCall.Unset()
+		connectCall.Unset()
+		publishedCall.Unset()
+		disconnectCall.Unset()
+	}()
+
+	err = handler.Disconnect(ctx)
+	assert.Nil(t, err, fmt.Sprintf("disconnect expected to succeed: %s", err))
+	eventStore.AssertNotCalled(t, "Disconnect", mock.Anything, thingID, mock.Anything)
+
+	err = handler.AuthSubscribe(ctx, &topics)
+	assert.Nil(t, err, fmt.Sprintf("subscribe expected to succeed: %s", err))
+	err = handler.AuthPublish(ctx, &topic, &payload)
+	assert.Nil(t, err, fmt.Sprintf("publish expected to succeed: %s", err))
+	eventStore.AssertNumberOfCalls(t, "Connect", 1)
+
+	err = handler.Publish(ctx, &topic, &payload)
+	assert.Nil(t, err, fmt.Sprintf("publish expected to succeed: %s", err))
+	eventStore.AssertNumberOfCalls(t, "Published", 1)
+
+	err = handler.Disconnect(ctx)
+	assert.Nil(t, err, fmt.Sprintf("disconnect expected to succeed: %s", err))
+	eventStore.AssertNumberOfCalls(t, "Disconnect", 1)
+	assert.NotContains(t, logBuffer.String(), password, "disconnect log must not contain the thing key")
+}
+
+func TestIntercept(t *testing.T) {
+	logger, err := mglog.New(&logBuffer, "debug")
+	assert.Nil(t, err, fmt.Sprintf("failed to create logger: %s", err))
+	things := new(thmocks.ThingsServiceClient)
+	eventStore := new(presencemocks.EventStore)
+	eventStore.On("Connect", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	channels := newRetainedChannels()
+	channels.Save(chanID, map[string]interface{}{"retain": true})
+	handler := mqtt.NewHandler(mocks.NewPublisher(), eventStore, logger, things, newSchemaCache(), channels)
+
+	things.On("Authorize", mock.Anything, &magistrala.ThingsAuthzReq{ThingKey: password, ChannelId: chanID, Permission: "publish"}).Return(&magistrala.ThingsAuthzRes{Authorized: true, Id: thingID}, nil)
+	things.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.ThingsAuthzRes{Authorized: false}, nil)
+
+	otherTopic := fmt.Sprintf(topicMsg, testsutil.GenerateUUID(t))
+
+	cases := []struct {
+		desc   string
+		pkt    packets.ControlPacket
+		dir    session.Direction
+		retain bool
+		err    error
+	}{
+		{
+			desc:   "intercept publish to channel with enabled retention",
+			pkt:    &packets.PublishPacket{TopicName: topic, Payload: payload},
+			dir:    session.Up,
+			retain: true,
+		},
+		{
+			desc: "intercept retained publish to channel without retention",
+			pkt: &packets.PublishPacket{
+				FixedHeader: packets.FixedHeader{MessageType: packets.Publish, Retain: true},
+				TopicName:   otherTopic,
+				Payload:     payload,
+			},
+			dir: session.Up,
+		},
+		{
+			desc: "intercept retained publish sent by the broker",
+			pkt: &packets.PublishPacket{
+				FixedHeader: packets.FixedHeader{MessageType: packets.Publish, Retain: true},
+				TopicName:   otherTopic,
+				Payload:     payload,
+			},
+			dir:    session.Down,
+			retain: true,
+		},
+		{
+			desc:   "intercept connect with last will to channel with enabled retention",
+			pkt:    &packets.ConnectPacket{WillFlag: true, WillTopic: topic, WillMessage: payload},
+			dir:    session.Up,
+			retain: true,
+		},
+		{
+			desc: "intercept connect with unauthorized last will",
+			pkt:  &packets.ConnectPacket{WillFlag: true, WillTopic: otherTopic, WillMessage: payload},
+			dir:  session.Up,
+			err:  svcerr.ErrAuthorization,
+		},
+		{
+			desc: "intercept connect with last will to malformed topic",
+			pkt:  &packets.ConnectPacket{WillFlag: true, WillTopic: invalidTopic, WillMessage: payload},
+			dir:  session.Up,
+			err:  mqtt.ErrMalformedTopic,
+		},
+	}
+
+	for _, tc := range cases {
+		sess := session.Session{ID: clientID, Username: thingID, Password: []byte(password)}
+		ctx := session.NewContext(context.TODO(), &sess)
+		pkt, err := handler.Intercept(ctx, tc.pkt, tc.dir)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		switch p := pkt.(type) {
+		case *packets.PublishPacket:
+			assert.Equal(t, tc.retain, p.Retain, fmt.Sprintf("%s: expected retain %t got %t\n", tc.desc, tc.retain, p.Retain))
+		case *packets.ConnectPacket:
+			assert.Equal(t, tc.retain, p.WillRetain, fmt.Sprintf("%s: expected will retain %t got %t\n", tc.desc, tc.retain, p.WillRetain))
+		}
+	}
+}
+
+func TestLastWill(t *testing.T) {
+	logger, err := mglog.New(&logBuffer, "debug")
+	assert.Nil(t, err, fmt.Sprintf("failed to create logger: %s", err))
+	things := new(thmocks.ThingsServiceClient)
+	eventStore := new(presencemocks.EventStore)
+	eventStore.On("Connect", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	eventStore.On("Disconnect", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	pub := new(msgmocks.PubSub)
+	handler := mqtt.NewHandler(pub, eventStore, logger, things, newSchemaCache(), newRetainedChannels())
+
+	things.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.ThingsAuthzRes{Authorized: true, Id: thingID}, nil)
+	willTopic := fmt.Sprintf(topicMsg, chanID) + "/" + subtopic
+	pub.On("Publish", mock.Anything, chanID, mock.Anything).Run(func(args mock.Arguments) {
+		msg := args.Get(2).(*messaging.Message)
+		assert.Equal(t, thingID, msg.GetPublisher(), fmt.Sprintf("expected publisher %s got %s", thingID, msg.GetPublisher()))
+		assert.Equal(t, subtopic, msg.GetSubtopic(), fmt.Sprintf("expected subtopic %s got %s", subtopic, msg.GetSubtopic()))
+		assert.Equal(t, payload, msg.GetPayload(), fmt.Sprintf("expected payload %s got %s", payload, msg.GetPayload()))
+	}).Return(nil)
+
+	cases := []struct {
+		desc       string
+		disconnect bool
+		published  int
+	}{
+		{
+			desc:      "publish last will when the connection is lost",
+			published: 1,
+		},
+		{
+			desc:       "discard last will when the client disconnects",
+			disconnect: true,
+		},
+	}
+
+	for _, tc := range cases {
+		sess := session.Session{ID: clientID, Username: thingID, Password: []byte(password)}
+		ctx := session.NewContext(context.TODO(), &sess)
+		_, err := handler.Intercept(ctx, &packets.ConnectPacket{WillFlag: true, WillTopic: willTopic, WillMessage: payload}, session.Up)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		if tc.disconnect {
+			_, err = handler.Intercept(ctx, packets.NewControlPacket(packets.Disconnect), session.Up)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		}
+		err = handler.Disconnect(ctx)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		pub.AssertNumberOfCalls(t, "Publish", tc.published)
+		pub.Calls = nil
+	}
+}
+
+// newSchemaCache returns the schema cache of the channels without schema.
+func newSchemaCache() schema.Cache {
+	things := new(thmocks.ThingsServiceClient)
+	things.On("ChannelMetadata", mock.Anything, mock.Anything).Return(&magistrala.ChannelMetadataRes{}, nil)
+
+	return schema.NewCache(things, time.Minute)
+}
+
+// newRetainedChannels returns the set of the channels without retention.
+func newRetainedChannels() retained.Channels {
+	things := new(thmocks.ThingsServiceClient)
+	things.On("ChannelMetadata", mock.Anything, mock.Anything).Return(&magistrala.ChannelMetadataRes{}, nil)
+
+	return retained.NewChannels(things, time.Minute)
 }
@@ -0,0 +1,327 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/ota"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func uploadFirmwareEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(uploadFirmwareReq)
+		defer req.content.Close()
+
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		fw, err := svc.UploadFirmware(ctx, session, req.firmware(), req.content)
+		if err != nil {
+			return nil, err
+		}
+
+		return firmwareRes{Firmware: fw, created: true}, nil
+	}
+}
+
+func viewFirmwareEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityIDReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		fw, err := svc.ViewFirmware(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return firmwareRes{Firmware: fw}, nil
+	}
+}
+
+func listFirmwareEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		page, err := svc.ListFirmware(ctx, session, req.pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := firmwarePageRes{
+			PageMetadata: page.PageMetadata,
+			Total:        page.Total,
+			Firmware:     []ota.Firmware{},
+		}
+		res.Firmware = append(res.Firmware, page.Firmware...)
+
+		return res, nil
+	}
+}
+
+func downloadFirmwareEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityIDReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		fw, content, err := svc.DownloadFirmware(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return contentRes{firmware: fw, content: content}, nil
+	}
+}
+
+func removeFirmwareEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityIDReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		if err := svc.RemoveFirmware(ctx, session, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeFirmwareRes{}, nil
+	}
+}
+
+func createCampaignEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createCampaignReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		c, err := svc.CreateCampaign(ctx, session, req.campaign())
+		if err != nil {
+			return nil, err
+		}
+
+		return campaignRes{Campaign: c, created: true}, nil
+	}
+}
+
+func viewCampaignEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityIDReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		c, err := svc.ViewCampaign(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return campaignRes{Campaign: c}, nil
+	}
+}
+
+func listCampaignsEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		page, err := svc.ListCampaigns(ctx, session, req.pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := campaignsPageRes{
+			PageMetadata: page.PageMetadata,
+			Total:        page.Total,
+			Campaigns:    []ota.Campaign{},
+		}
+		res.Campaigns = append(res.Campaigns, page.Campaigns...)
+
+		return res, nil
+	}
+}
+
+func startCampaignEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(startCampaignReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		c, err := svc.StartCampaign(ctx, session, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return campaignRes{Campaign: c}, nil
+	}
+}
+
+func pauseCampaignEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityIDReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		c, err := svc.PauseCampaign(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return campaignRes{Campaign: c}, nil
+	}
+}
+
+func resumeCampaignEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityIDReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		c, err := svc.ResumeCampaign(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return campaignRes{Campaign: c}, nil
+	}
+}
+
+func cancelCampaignEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityIDReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		c, err := svc.CancelCampaign(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return campaignRes{Campaign: c}, nil
+	}
+}
+
+func listDevicesEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listDevicesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		page, err := svc.ListDevices(ctx, session, req.campaignID, req.pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := devicesPageRes{
+			PageMetadata: page.PageMetadata,
+			Total:        page.Total,
+			Devices:      []ota.Device{},
+		}
+		res.Devices = append(res.Devices, page.Devices...)
+
+		return res, nil
+	}
+}
+
+func fetchFirmwareEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(fetchFirmwareReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		fw, content, err := svc.FetchFirmware(ctx, req.key, req.campaignID, req.thingID)
+		if err != nil {
+			return nil, err
+		}
+
+		return contentRes{firmware: fw, content: content}, nil
+	}
+}
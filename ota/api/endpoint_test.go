@@ -0,0 +1,1079 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/ota"
+	"github.com/absmach/magistrala/ota/api"
+	"github.com/absmach/magistrala/ota/mocks"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	authnmocks "github.com/absmach/magistrala/pkg/authn/mocks"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	maxSize = 1024
+	content = "firmware content"
+)
+
+var (
+	validToken       = "valid"
+	thingKey         = "thingKey"
+	validContentType = "application/json"
+	userID           = testsutil.GenerateUUID(&testing.T{})
+	domainID         = testsutil.GenerateUUID(&testing.T{})
+	thingID          = testsutil.GenerateUUID(&testing.T{})
+	firmwareID       = testsutil.GenerateUUID(&testing.T{})
+	campaignID       = testsutil.GenerateUUID(&testing.T{})
+	validSession     = mgauthn.Session{UserID: userID, DomainID: domainID, DomainUserID: domainID + "_" + userID}
+	validFirmware    = ota.Firmware{
+		ID:        firmwareID,
+		DomainID:  domainID,
+		Name:      "sensor",
+		Version:   "1.0.0",
+		Size:      int64(len(content)),
+		Checksum:  "checksum",
+		CreatedBy: userID,
+		CreatedAt: time.Now().UTC(),
+	}
+	validCampaign = ota.Campaign{
+		ID:         campaignID,
+		DomainID:   domainID,
+		Name:       "rollout",
+		FirmwareID: firmwareID,
+		Target:     ota.Target{Tag: "sensors"},
+		Stages:     []uint8{100},
+		Status:     ota.DraftStatus,
+		CreatedAt:  time.Now().UTC(),
+	}
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	token       string
+	contentType string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+
+	if tr.token != "" {
+		req.Header.Set("Authorization", tr.token)
+	}
+
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+
+	return tr.client.Do(req)
+}
+
+func newOTAServer() (*httptest.Server, *mocks.Service, *authnmocks.Authentication) {
+	svc := new(mocks.Service)
+	authn := new(authnmocks.Authentication)
+	mux := api.MakeHandler(svc, authn, mglog.NewMock(), maxSize, "ota", "test")
+
+	return httptest.NewServer(mux), svc, authn
+}
+
+func bearer(token string) string {
+	if token == "" {
+		return ""
+	}
+
+	return apiutil.BearerPrefix + token
+}
+
+// firmwareForm encodes the multipart upload form. The file is omitted if the
+// content is empty.
+func firmwareForm(t *testing.T, fields map[string]string, content string) (io.Reader, string) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for k, v := range fields {
+		err := w.WriteField(k, v)
+		require.Nil(t, err, fmt.Sprintf("write form field unexpected error: %s", err))
+	}
+	if content != "" {
+		part, err := w.CreateFormFile("file", "firmware.bin")
+		require.Nil(t, err, fmt.Sprintf("create form file unexpected error: %s", err))
+		_, err = part.Write([]byte(content))
+		require.Nil(t, err, fmt.Sprintf("write form file unexpected error: %s", err))
+	}
+	err := w.Close()
+	require.Nil(t, err, fmt.Sprintf("close form unexpected error: %s", err))
+
+	return &body, w.FormDataContentType()
+}
+
+func TestUploadFirmware(t *testing.T) {
+	ts, svc, authn := newOTAServer()
+	defer ts.Close()
+
+	validFields := map[string]string{"name": "sensor", "version": "1.0.0", "checksum": "checksum", "signature": "signature"}
+	fw := ota.Firmware{Name: "sensor", Version: "1.0.0", Size: int64(len(content)), Checksum: "checksum", Signature: "signature"}
+
+	cases := []struct {
+		desc     string
+		token    string
+		fields   map[string]string
+		content  string
+		form     bool
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "upload firmware successfully",
+			token:    validToken,
+			fields:   validFields,
+			content:  content,
+			form:     true,
+			authnRes: validSession,
+			status:   http.StatusCreated,
+		},
+		{
+			desc:    "upload firmware with empty token",
+			fields:  validFields,
+			content: content,
+			form:    true,
+			status:  http.StatusUnauthorized,
+		},
+		{
+			desc:     "upload firmware with invalid token",
+			token:    "invalid",
+			fields:   validFields,
+			content:  content,
+			form:     true,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "upload firmware with invalid content type",
+			token:    validToken,
+			fields:   validFields,
+			content:  content,
+			authnRes: validSession,
+			status:   http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:     "upload firmware without file",
+			token:    validToken,
+			fields:   validFields,
+			form:     true,
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "upload too large firmware",
+			token:    validToken,
+			fields:   validFields,
+			content:  strings.Repeat("a", maxSize+1),
+			form:     true,
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "upload firmware without name",
+			token:    validToken,
+			fields:   map[string]string{"version": "1.0.0"},
+			content:  content,
+			form:     true,
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "upload firmware with too long name",
+			token:    validToken,
+			fields:   map[string]string{"name": strings.Repeat("a", 1025), "version": "1.0.0"},
+			content:  content,
+			form:     true,
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "upload firmware without version",
+			token:    validToken,
+			fields:   map[string]string{"name": "sensor"},
+			content:  content,
+			form:     true,
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "upload firmware with invalid signature",
+			token:    validToken,
+			fields:   validFields,
+			content:  content,
+			form:     true,
+			authnRes: validSession,
+			svcErr:   errors.Wrap(svcerr.ErrMalformedEntity, ota.ErrInvalidSignature),
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "upload existing firmware version",
+			token:    validToken,
+			fields:   validFields,
+			content:  content,
+			form:     true,
+			authnRes: validSession,
+			svcErr:   svcerr.ErrConflict,
+			status:   http.StatusConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			body, contentType := firmwareForm(t, tc.fields, tc.content)
+			if !tc.form {
+				contentType = validContentType
+			}
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("UploadFirmware", mock.Anything, tc.authnRes, fw, mock.Anything).Return(validFirmware, tc.svcErr)
+			req := testRequest{
+				client:      ts.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/firmware", ts.URL, domainID),
+				token:       bearer(tc.token),
+				contentType: contentType,
+				body:        body,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusCreated {
+				location := fmt.Sprintf("/%s/firmware/%s", domainID, firmwareID)
+				assert.Equal(t, location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, location, res.Header.Get("Location")))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestViewFirmware(t *testing.T) {
+	ts, svc, authn := newOTAServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "view firmware successfully",
+			token:    validToken,
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "view firmware with invalid token",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "view non-existing firmware",
+			token:    validToken,
+			authnRes: validSession,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("ViewFirmware", mock.Anything, tc.authnRes, firmwareID).Return(validFirmware, tc.svcErr)
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/firmware/%s", ts.URL, domainID, firmwareID),
+				token:  bearer(tc.token),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var fw ota.Firmware
+				err := json.NewDecoder(res.Body).Decode(&fw)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected decode error %s", tc.desc, err))
+				assert.Equal(t, firmwareID, fw.ID, fmt.Sprintf("%s: expected firmware %s got %s", tc.desc, firmwareID, fw.ID))
+				assert.Equal(t, validFirmware.Checksum, fw.Checksum, fmt.Sprintf("%s: expected checksum %s got %s", tc.desc, validFirmware.Checksum, fw.Checksum))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestListFirmware(t *testing.T) {
+	ts, svc, authn := newOTAServer()
+	defer ts.Close()
+
+	page := ota.FirmwarePage{
+		PageMetadata: ota.PageMetadata{Limit: 10},
+		Total:        1,
+		Firmware:     []ota.Firmware{validFirmware},
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		query    string
+		pm       ota.PageMetadata
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "list firmware successfully",
+			token:    validToken,
+			pm:       ota.PageMetadata{Limit: 10},
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list firmware with name",
+			token:    validToken,
+			query:    "?offset=1&limit=5&name=sensor",
+			pm:       ota.PageMetadata{Offset: 1, Limit: 5, Name: "sensor"},
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list firmware with invalid token",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "list firmware with invalid offset",
+			token:    validToken,
+			query:    "?offset=invalid",
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list firmware with zero limit",
+			token:    validToken,
+			query:    "?limit=0",
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list firmware with too long name",
+			token:    validToken,
+			query:    "?name=" + strings.Repeat("a", 1025),
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list firmware with service error",
+			token:    validToken,
+			pm:       ota.PageMetadata{Limit: 10},
+			authnRes: validSession,
+			svcErr:   svcerr.ErrViewEntity,
+			status:   http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("ListFirmware", mock.Anything, tc.authnRes, tc.pm).Return(page, tc.svcErr)
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/firmware%s", ts.URL, domainID, tc.query),
+				token:  bearer(tc.token),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var body struct {
+					Total    uint64         `json:"total"`
+					Firmware []ota.Firmware `json:"firmware"`
+				}
+				err := json.NewDecoder(res.Body).Decode(&body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected decode error %s", tc.desc, err))
+				assert.Equal(t, page.Total, body.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, page.Total, body.Total))
+				assert.Len(t, body.Firmware, 1, fmt.Sprintf("%s: expected 1 firmware got %d", tc.desc, len(body.Firmware)))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestDownloadFirmware(t *testing.T) {
+	ts, svc, authn := newOTAServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "download firmware successfully",
+			token:    validToken,
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "download firmware with invalid token",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "download non-existing firmware",
+			token:    validToken,
+			authnRes: validSession,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("DownloadFirmware", mock.Anything, tc.authnRes, firmwareID).Return(validFirmware, io.NopCloser(strings.NewReader(content)), tc.svcErr)
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/firmware/%s/download", ts.URL, domainID, firmwareID),
+				token:  bearer(tc.token),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				body, err := io.ReadAll(res.Body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected read error %s", tc.desc, err))
+				assert.Equal(t, content, string(body), fmt.Sprintf("%s: expected content %s got %s", tc.desc, content, body))
+				assert.Equal(t, "application/octet-stream", res.Header.Get("Content-Type"))
+				assert.Equal(t, validFirmware.Checksum, res.Header.Get("X-Checksum-Sha256"))
+				assert.Equal(t, `attachment; filename="sensor-1.0.0"`, res.Header.Get("Content-Disposition"))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestRemoveFirmware(t *testing.T) {
+	ts, svc, authn := newOTAServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "remove firmware successfully",
+			token:    validToken,
+			authnRes: validSession,
+			status:   http.StatusNoContent,
+		},
+		{
+			desc:     "remove firmware with invalid token",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "remove firmware used by campaigns",
+			token:    validToken,
+			authnRes: validSession,
+			svcErr:   errors.Wrap(svcerr.ErrConflict, ota.ErrFirmwareInUse),
+			status:   http.StatusConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("RemoveFirmware", mock.Anything, tc.authnRes, firmwareID).Return(tc.svcErr)
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodDelete,
+				url:    fmt.Sprintf("%s/%s/firmware/%s", ts.URL, domainID, firmwareID),
+				token:  bearer(tc.token),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestCreateCampaign(t *testing.T) {
+	ts, svc, authn := newOTAServer()
+	defer ts.Close()
+
+	validReq := fmt.Sprintf(`{"name":"rollout","firmware_id":"%s","target":{"tag":"sensors"},"stages":[10,50,100],"failure_threshold":20}`, firmwareID)
+	c := ota.Campaign{
+		Name:             "rollout",
+		FirmwareID:       firmwareID,
+		Target:           ota.Target{Tag: "sensors"},
+		Stages:           []uint8{10, 50, 100},
+		FailureThreshold: 20,
+	}
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		authnRes    mgauthn.Session
+		authnErr    error
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "create campaign successfully",
+			token:       validToken,
+			data:        validReq,
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create campaign with invalid token",
+			token:       "invalid",
+			data:        validReq,
+			contentType: validContentType,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create campaign with invalid content type",
+			token:       validToken,
+			data:        validReq,
+			contentType: "text/plain",
+			authnRes:    validSession,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "create campaign with malformed body",
+			token:       validToken,
+			data:        "{",
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create campaign without name",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"firmware_id":"%s","target":{"tag":"sensors"}}`, firmwareID),
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create campaign without firmware",
+			token:       validToken,
+			data:        `{"name":"rollout","target":{"tag":"sensors"}}`,
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create campaign without target",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"name":"rollout","firmware_id":"%s"}`, firmwareID),
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create campaign with decreasing stages",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"name":"rollout","firmware_id":"%s","target":{"tag":"sensors"},"stages":[50,10,100]}`, firmwareID),
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create campaign with invalid failure threshold",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"name":"rollout","firmware_id":"%s","target":{"tag":"sensors"},"failure_threshold":101}`, firmwareID),
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create campaign of non-existing firmware",
+			token:       validToken,
+			data:        validReq,
+			contentType: validContentType,
+			authnRes:    validSession,
+			svcErr:      svcerr.ErrNotFound,
+			status:      http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("CreateCampaign", mock.Anything, tc.authnRes, c).Return(validCampaign, tc.svcErr)
+			req := testRequest{
+				client:      ts.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/campaigns", ts.URL, domainID),
+				token:       bearer(tc.token),
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestViewCampaign(t *testing.T) {
+	ts, svc, authn := newOTAServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "view campaign successfully",
+			token:    validToken,
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "view campaign with invalid token",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "view non-existing campaign",
+			token:    validToken,
+			authnRes: validSession,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("ViewCampaign", mock.Anything, tc.authnRes, campaignID).Return(validCampaign, tc.svcErr)
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/campaigns/%s", ts.URL, domainID, campaignID),
+				token:  bearer(tc.token),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestListCampaigns(t *testing.T) {
+	ts, svc, authn := newOTAServer()
+	defer ts.Close()
+
+	page := ota.CampaignsPage{
+		PageMetadata: ota.PageMetadata{Limit: 10},
+		Total:        1,
+		Campaigns:    []ota.Campaign{validCampaign},
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		query    string
+		pm       ota.PageMetadata
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "list campaigns successfully",
+			token:    validToken,
+			pm:       ota.PageMetadata{Limit: 10, Status: ota.AllCampaignStatus},
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list campaigns with filters",
+			token:    validToken,
+			query:    fmt.Sprintf("?name=rollout&firmware_id=%s&status=running", firmwareID),
+			pm:       ota.PageMetadata{Limit: 10, Name: "rollout", FirmwareID: firmwareID, Status: ota.RunningStatus},
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list campaigns with invalid token",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "list campaigns with invalid limit",
+			token:    validToken,
+			query:    "?limit=invalid",
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list campaigns with invalid status",
+			token:    validToken,
+			query:    "?status=invalid",
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("ListCampaigns", mock.Anything, tc.authnRes, tc.pm).Return(page, tc.svcErr)
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/campaigns%s", ts.URL, domainID, tc.query),
+				token:  bearer(tc.token),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestStartCampaign(t *testing.T) {
+	ts, svc, authn := newOTAServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "start campaign successfully",
+			token:    validToken,
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "start campaign with invalid token",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "start running campaign",
+			token:    validToken,
+			authnRes: validSession,
+			svcErr:   errors.Wrap(svcerr.ErrConflict, ota.ErrStatusTransition),
+			status:   http.StatusConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("StartCampaign", mock.Anything, tc.authnRes, tc.token, campaignID).Return(validCampaign, tc.svcErr)
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodPost,
+				url:    fmt.Sprintf("%s/%s/campaigns/%s/start", ts.URL, domainID, campaignID),
+				token:  bearer(tc.token),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestChangeCampaignStatus(t *testing.T) {
+	ts, svc, authn := newOTAServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		action   string
+		method   string
+		token    string
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "pause campaign successfully",
+			action:   "pause",
+			method:   "PauseCampaign",
+			token:    validToken,
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "resume campaign successfully",
+			action:   "resume",
+			method:   "ResumeCampaign",
+			token:    validToken,
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "cancel campaign successfully",
+			action:   "cancel",
+			method:   "CancelCampaign",
+			token:    validToken,
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "pause campaign with invalid token",
+			action:   "pause",
+			method:   "PauseCampaign",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "resume finished campaign",
+			action:   "resume",
+			method:   "ResumeCampaign",
+			token:    validToken,
+			authnRes: validSession,
+			svcErr:   errors.Wrap(svcerr.ErrConflict, ota.ErrStatusTransition),
+			status:   http.StatusConflict,
+		},
+		{
+			desc:     "cancel non-existing campaign",
+			action:   "cancel",
+			method:   "CancelCampaign",
+			token:    validToken,
+			authnRes: validSession,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On(tc.method, mock.Anything, tc.authnRes, campaignID).Return(validCampaign, tc.svcErr)
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodPost,
+				url:    fmt.Sprintf("%s/%s/campaigns/%s/%s", ts.URL, domainID, campaignID, tc.action),
+				token:  bearer(tc.token),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestListDevices(t *testing.T) {
+	ts, svc, authn := newOTAServer()
+	defer ts.Close()
+
+	page := ota.DevicesPage{
+		PageMetadata: ota.PageMetadata{Limit: 10},
+		Total:        1,
+		Devices:      []ota.Device{{CampaignID: campaignID, ThingID: thingID, Status: ota.PendingStatus}},
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		query    string
+		pm       ota.PageMetadata
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "list devices successfully",
+			token:    validToken,
+			pm:       ota.PageMetadata{Limit: 10, DeviceStatus: ota.AllDeviceStatus},
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list devices with status",
+			token:    validToken,
+			query:    "?offset=2&limit=5&status=failed",
+			pm:       ota.PageMetadata{Offset: 2, Limit: 5, DeviceStatus: ota.FailedStatus},
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list devices with invalid token",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "list devices with invalid status",
+			token:    validToken,
+			query:    "?status=invalid",
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list devices with too large limit",
+			token:    validToken,
+			query:    "?limit=1000",
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list devices of non-existing campaign",
+			token:    validToken,
+			pm:       ota.PageMetadata{Limit: 10, DeviceStatus: ota.AllDeviceStatus},
+			authnRes: validSession,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("ListDevices", mock.Anything, tc.authnRes, campaignID, tc.pm).Return(page, tc.svcErr)
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/campaigns/%s/devices%s", ts.URL, domainID, campaignID, tc.query),
+				token:  bearer(tc.token),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestFetchFirmware(t *testing.T) {
+	ts, svc, _ := newOTAServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		svcErr error
+		status int
+	}{
+		{
+			desc:   "fetch firmware successfully",
+			token:  apiutil.ThingPrefix + thingKey,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "fetch firmware without thing key",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "fetch firmware with bearer token",
+			token:  bearer(validToken),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "fetch firmware of other thing",
+			token:  apiutil.ThingPrefix + thingKey,
+			svcErr: svcerr.ErrAuthorization,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("FetchFirmware", mock.Anything, thingKey, campaignID, thingID).Return(validFirmware, io.NopCloser(strings.NewReader(content)), tc.svcErr)
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/campaigns/%s/things/%s/firmware", ts.URL, campaignID, thingID),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				body, err := io.ReadAll(res.Body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected read error %s", tc.desc, err))
+				assert.Equal(t, content, string(body), fmt.Sprintf("%s: expected content %s got %s", tc.desc, content, body))
+			}
+			svcCall.Unset()
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"io"
+
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/ota"
+	"github.com/absmach/magistrala/pkg/apiutil"
+)
+
+type uploadFirmwareReq struct {
+	name      string
+	version   string
+	checksum  string
+	signature string
+	size      int64
+	content   io.ReadCloser
+}
+
+func (req uploadFirmwareReq) validate() error {
+	if req.name == "" {
+		return apiutil.ErrMissingName
+	}
+	if len(req.name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+	if req.version == "" {
+		return apiutil.ErrMissingVersion
+	}
+	if req.content == nil || req.size <= 0 {
+		return apiutil.ErrMissingFile
+	}
+
+	return nil
+}
+
+func (req uploadFirmwareReq) firmware() ota.Firmware {
+	return ota.Firmware{
+		Name:      req.name,
+		Version:   req.version,
+		Size:      req.size,
+		Checksum:  req.checksum,
+		Signature: req.signature,
+	}
+}
+
+type entityIDReq struct {
+	id string
+}
+
+func (req entityIDReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type startCampaignReq struct {
+	token string
+	id    string
+}
+
+func (req startCampaignReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listReq struct {
+	pm ota.PageMetadata
+}
+
+func (req listReq) validate() error {
+	if req.pm.Limit > api.MaxLimitSize || req.pm.Limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+	if len(req.pm.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	return nil
+}
+
+type createCampaignReq struct {
+	Name             string     `json:"name"`
+	FirmwareID       string     `json:"firmware_id"`
+	Target           ota.Target `json:"target"`
+	Stages           []uint8    `json:"stages,omitempty"`
+	FailureThreshold uint8      `json:"failure_threshold,omitempty"`
+}
+
+func (req createCampaignReq) validate() error {
+	if req.Name == "" {
+		return apiutil.ErrMissingName
+	}
+	if len(req.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+	if req.FirmwareID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.Target.Empty() {
+		return apiutil.ErrMissingTarget
+	}
+	if len(req.Stages) > 0 && !ota.ValidStages(req.Stages) {
+		return apiutil.ErrInvalidRolloutStages
+	}
+	if req.FailureThreshold > 100 {
+		return apiutil.ErrInvalidFailureThreshold
+	}
+
+	return nil
+}
+
+func (req createCampaignReq) campaign() ota.Campaign {
+	return ota.Campaign{
+		Name:             req.Name,
+		FirmwareID:       req.FirmwareID,
+		Target:           req.Target,
+		Stages:           req.Stages,
+		FailureThreshold: req.FailureThreshold,
+	}
+}
+
+type listDevicesReq struct {
+	campaignID string
+	pm         ota.PageMetadata
+}
+
+func (req listDevicesReq) validate() error {
+	if req.campaignID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.pm.Limit > api.MaxLimitSize || req.pm.Limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type fetchFirmwareReq struct {
+	key        string
+	campaignID string
+	thingID    string
+}
+
+func (req fetchFirmwareReq) validate() error {
+	if req.key == "" {
+		return apiutil.ErrBearerKey
+	}
+	if req.campaignID == "" || req.thingID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
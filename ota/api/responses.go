@@ -0,0 +1,150 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/ota"
+)
+
+var (
+	_ magistrala.Response = (*firmwareRes)(nil)
+	_ magistrala.Response = (*firmwarePageRes)(nil)
+	_ magistrala.Response = (*removeFirmwareRes)(nil)
+	_ magistrala.Response = (*campaignRes)(nil)
+	_ magistrala.Response = (*campaignsPageRes)(nil)
+	_ magistrala.Response = (*devicesPageRes)(nil)
+)
+
+type firmwareRes struct {
+	ota.Firmware `json:",inline"`
+	created      bool
+}
+
+func (res firmwareRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res firmwareRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/%s/firmware/%s", res.DomainID, res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res firmwareRes) Empty() bool {
+	return false
+}
+
+type firmwarePageRes struct {
+	ota.PageMetadata `json:",inline"`
+	Total            uint64         `json:"total"`
+	Firmware         []ota.Firmware `json:"firmware"`
+}
+
+func (res firmwarePageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res firmwarePageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res firmwarePageRes) Empty() bool {
+	return false
+}
+
+type removeFirmwareRes struct{}
+
+func (res removeFirmwareRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeFirmwareRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeFirmwareRes) Empty() bool {
+	return true
+}
+
+// contentRes streams the firmware content.
+type contentRes struct {
+	firmware ota.Firmware
+	content  io.ReadCloser
+}
+
+type campaignRes struct {
+	ota.Campaign `json:",inline"`
+	created      bool
+}
+
+func (res campaignRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res campaignRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/%s/campaigns/%s", res.DomainID, res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res campaignRes) Empty() bool {
+	return false
+}
+
+type campaignsPageRes struct {
+	ota.PageMetadata `json:",inline"`
+	Total            uint64         `json:"total"`
+	Campaigns        []ota.Campaign `json:"campaigns"`
+}
+
+func (res campaignsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res campaignsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res campaignsPageRes) Empty() bool {
+	return false
+}
+
+type devicesPageRes struct {
+	ota.PageMetadata `json:",inline"`
+	Total            uint64       `json:"total"`
+	Devices          []ota.Device `json:"devices"`
+}
+
+func (res devicesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res devicesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res devicesPageRes) Empty() bool {
+	return false
+}
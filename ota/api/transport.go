@@ -0,0 +1,343 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/ota"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/go-chi/chi/v5"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const (
+	idKey           = "id"
+	campaignIDKey   = "campaignID"
+	thingIDKey      = "thingID"
+	firmwareIDKey   = "firmware_id"
+	fileKey         = "file"
+	versionKey      = "version"
+	checksumKey     = "checksum"
+	signatureKey    = "signature"
+	byteContentType = "application/octet-stream"
+	formContentType = "multipart/form-data"
+	// checksumHeader carries the hex encoded SHA-256 digest of the firmware.
+	checksumHeader = "X-Checksum-Sha256"
+	// maxMemory is the size of the uploaded firmware kept in memory. The
+	// rest of the content is stored in temporary files.
+	maxMemory = 32 << 20
+)
+
+// MakeHandler returns a HTTP handler for OTA API endpoints. Uploaded firmware
+// is limited to maxSize bytes.
+func MakeHandler(svc ota.Service, authn mgauthn.Authentication, logger *slog.Logger, maxSize int64, svcName, instanceID string) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+	}
+
+	mux := chi.NewRouter()
+
+	mux.Group(func(r chi.Router) {
+		r.Use(api.AuthenticateMiddleware(authn, true))
+
+		r.Route("/{domainID}/firmware", func(r chi.Router) {
+			r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+				uploadFirmwareEndpoint(svc),
+				decodeUploadFirmwareReq(maxSize),
+				api.EncodeResponse,
+				opts...,
+			), "upload_firmware").ServeHTTP)
+
+			r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+				listFirmwareEndpoint(svc),
+				decodeListFirmwareReq,
+				api.EncodeResponse,
+				opts...,
+			), "list_firmware").ServeHTTP)
+
+			r.Get("/{id}", otelhttp.NewHandler(kithttp.NewServer(
+				viewFirmwareEndpoint(svc),
+				decodeEntityIDReq,
+				api.EncodeResponse,
+				opts...,
+			), "view_firmware").ServeHTTP)
+
+			r.Get("/{id}/download", otelhttp.NewHandler(kithttp.NewServer(
+				downloadFirmwareEndpoint(svc),
+				decodeEntityIDReq,
+				encodeContentRes,
+				opts...,
+			), "download_firmware").ServeHTTP)
+
+			r.Delete("/{id}", otelhttp.NewHandler(kithttp.NewServer(
+				removeFirmwareEndpoint(svc),
+				decodeEntityIDReq,
+				api.EncodeResponse,
+				opts...,
+			), "remove_firmware").ServeHTTP)
+		})
+
+		r.Route("/{domainID}/campaigns", func(r chi.Router) {
+			r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+				createCampaignEndpoint(svc),
+				decodeCreateCampaignReq,
+				api.EncodeResponse,
+				opts...,
+			), "create_campaign").ServeHTTP)
+
+			r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+				listCampaignsEndpoint(svc),
+				decodeListCampaignsReq,
+				api.EncodeResponse,
+				opts...,
+			), "list_campaigns").ServeHTTP)
+
+			r.Get("/{id}", otelhttp.NewHandler(kithttp.NewServer(
+				viewCampaignEndpoint(svc),
+				decodeEntityIDReq,
+				api.EncodeResponse,
+				opts...,
+			), "view_campaign").ServeHTTP)
+
+			r.Post("/{id}/start", otelhttp.NewHandler(kithttp.NewServer(
+				startCampaignEndpoint(svc),
+				decodeStartCampaignReq,
+				api.EncodeResponse,
+				opts...,
+			), "start_campaign").ServeHTTP)
+
+			r.Post("/{id}/pause", otelhttp.NewHandler(kithttp.NewServer(
+				pauseCampaignEndpoint(svc),
+				decodeEntityIDReq,
+				api.EncodeResponse,
+				opts...,
+			), "pause_campaign").ServeHTTP)
+
+			r.Post("/{id}/resume", otelhttp.NewHandler(kithttp.NewServer(
+				resumeCampaignEndpoint(svc),
+				decodeEntityIDReq,
+				api.EncodeResponse,
+				opts...,
+			), "resume_campaign").ServeHTTP)
+
+			r.Post("/{id}/cancel", otelhttp.NewHandler(kithttp.NewServer(
+				cancelCampaignEndpoint(svc),
+				decodeEntityIDReq,
+				api.EncodeResponse,
+				opts...,
+			), "cancel_campaign").ServeHTTP)
+
+			r.Get("/{id}/devices", otelhttp.NewHandler(kithttp.NewServer(
+				listDevicesEndpoint(svc),
+				decodeListDevicesReq,
+				api.EncodeResponse,
+				opts...,
+			), "list_devices").ServeHTTP)
+		})
+	})
+
+	// Things download the campaign firmware using their keys.
+	mux.Get("/campaigns/{campaignID}/things/{thingID}/firmware", otelhttp.NewHandler(kithttp.NewServer(
+		fetchFirmwareEndpoint(svc),
+		decodeFetchFirmwareReq,
+		encodeContentRes,
+		opts...,
+	), "fetch_firmware").ServeHTTP)
+
+	mux.Get("/health", magistrala.Health(svcName, instanceID))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+func decodeUploadFirmwareReq(maxSize int64) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		if !strings.Contains(r.Header.Get("Content-Type"), formContentType) {
+			return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+		}
+
+		r.Body = http.MaxBytesReader(nil, r.Body, maxSize+maxMemory)
+		if err := r.ParseMultipartForm(maxMemory); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+		}
+		file, header, err := r.FormFile(fileKey)
+		if err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingFile)
+		}
+		if header.Size > maxSize {
+			file.Close()
+			return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrFileSize)
+		}
+
+		req := uploadFirmwareReq{
+			name:      r.FormValue(api.NameKey),
+			version:   r.FormValue(versionKey),
+			checksum:  r.FormValue(checksumKey),
+			signature: r.FormValue(signatureKey),
+			size:      header.Size,
+			content:   file,
+		}
+
+		return req, nil
+	}
+}
+
+func decodeEntityIDReq(_ context.Context, r *http.Request) (interface{}, error) {
+	return entityIDReq{id: chi.URLParam(r, idKey)}, nil
+}
+
+func decodeStartCampaignReq(_ context.Context, r *http.Request) (interface{}, error) {
+	req := startCampaignReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    chi.URLParam(r, idKey),
+	}
+
+	return req, nil
+}
+
+func decodeListFirmwareReq(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	name, err := apiutil.ReadStringQuery(r, api.NameKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listReq{
+		pm: ota.PageMetadata{
+			Offset: offset,
+			Limit:  limit,
+			Name:   name,
+		},
+	}
+
+	return req, nil
+}
+
+func decodeCreateCampaignReq(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	var req createCampaignReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeListCampaignsReq(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	name, err := apiutil.ReadStringQuery(r, api.NameKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	firmwareID, err := apiutil.ReadStringQuery(r, firmwareIDKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	s, err := apiutil.ReadStringQuery(r, api.StatusKey, ota.All)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	status, err := ota.ToCampaignStatus(s)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listReq{
+		pm: ota.PageMetadata{
+			Offset:     offset,
+			Limit:      limit,
+			Name:       name,
+			FirmwareID: firmwareID,
+			Status:     status,
+		},
+	}
+
+	return req, nil
+}
+
+func decodeListDevicesReq(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	s, err := apiutil.ReadStringQuery(r, api.StatusKey, ota.All)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	status, err := ota.ToDeviceStatus(s)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listDevicesReq{
+		campaignID: chi.URLParam(r, idKey),
+		pm: ota.PageMetadata{
+			Offset:       offset,
+			Limit:        limit,
+			DeviceStatus: status,
+		},
+	}
+
+	return req, nil
+}
+
+func decodeFetchFirmwareReq(_ context.Context, r *http.Request) (interface{}, error) {
+	req := fetchFirmwareReq{
+		key:        apiutil.ExtractThingKey(r),
+		campaignID: chi.URLParam(r, campaignIDKey),
+		thingID:    chi.URLParam(r, thingIDKey),
+	}
+
+	return req, nil
+}
+
+func encodeContentRes(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res := response.(contentRes)
+	defer res.content.Close()
+
+	w.Header().Set("Content-Type", byteContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(res.firmware.Size, 10))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", res.firmware.Name+"-"+res.firmware.Version))
+	w.Header().Set(checksumHeader, res.firmware.Checksum)
+	w.WriteHeader(http.StatusOK)
+
+	_, err := io.Copy(w, res.content)
+
+	return err
+}
@@ -0,0 +1,9 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ota contains the domain concept definitions needed to support
+// Magistrala over-the-air update service functionality. OTA service stores
+// firmware artifacts and rolls them out to things in staged campaigns,
+// notifying the things over their channels and tracking the update progress
+// the things report back.
+package ota
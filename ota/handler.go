@@ -0,0 +1,30 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package ota
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/pkg/messaging"
+)
+
+var _ messaging.MessageHandler = (*progressHandler)(nil)
+
+type progressHandler struct {
+	svc Service
+}
+
+// NewProgressHandler returns the message handler passing the progress
+// reports published by things to the service.
+func NewProgressHandler(svc Service) messaging.MessageHandler {
+	return &progressHandler{svc: svc}
+}
+
+func (h *progressHandler) Handle(msg *messaging.Message) error {
+	return h.svc.HandleProgress(context.Background(), msg)
+}
+
+func (h *progressHandler) Cancel() error {
+	return nil
+}
@@ -0,0 +1,158 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"io"
+
+	"github.com/absmach/magistrala/ota"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	mgauthz "github.com/absmach/magistrala/pkg/authz"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/policies"
+)
+
+var _ ota.Service = (*authorizationMiddleware)(nil)
+
+type authorizationMiddleware struct {
+	svc   ota.Service
+	authz mgauthz.Authorization
+}
+
+// AuthorizationMiddleware adds authorization to the OTA service. Firmware
+// and campaigns are managed by users who can edit the domain, and can be
+// viewed by users who can view the domain. Things are authorized by the
+// service when they download the firmware.
+func AuthorizationMiddleware(svc ota.Service, authz mgauthz.Authorization) ota.Service {
+	return &authorizationMiddleware{
+		svc:   svc,
+		authz: authz,
+	}
+}
+
+func (am *authorizationMiddleware) UploadFirmware(ctx context.Context, session mgauthn.Session, fw ota.Firmware, content io.Reader) (ota.Firmware, error) {
+	if err := am.authorize(ctx, session, policies.EditPermission); err != nil {
+		return ota.Firmware{}, err
+	}
+
+	return am.svc.UploadFirmware(ctx, session, fw, content)
+}
+
+func (am *authorizationMiddleware) ViewFirmware(ctx context.Context, session mgauthn.Session, id string) (ota.Firmware, error) {
+	if err := am.authorize(ctx, session, policies.ViewPermission); err != nil {
+		return ota.Firmware{}, err
+	}
+
+	return am.svc.ViewFirmware(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) ListFirmware(ctx context.Context, session mgauthn.Session, pm ota.PageMetadata) (ota.FirmwarePage, error) {
+	if err := am.authorize(ctx, session, policies.ViewPermission); err != nil {
+		return ota.FirmwarePage{}, err
+	}
+
+	return am.svc.ListFirmware(ctx, session, pm)
+}
+
+func (am *authorizationMiddleware) DownloadFirmware(ctx context.Context, session mgauthn.Session, id string) (ota.Firmware, io.ReadCloser, error) {
+	if err := am.authorize(ctx, session, policies.ViewPermission); err != nil {
+		return ota.Firmware{}, nil, err
+	}
+
+	return am.svc.DownloadFirmware(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) RemoveFirmware(ctx context.Context, session mgauthn.Session, id string) error {
+	if err := am.authorize(ctx, session, policies.EditPermission); err != nil {
+		return err
+	}
+
+	return am.svc.RemoveFirmware(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) CreateCampaign(ctx context.Context, session mgauthn.Session, c ota.Campaign) (ota.Campaign, error) {
+	if err := am.authorize(ctx, session, policies.EditPermission); err != nil {
+		return ota.Campaign{}, err
+	}
+
+	return am.svc.CreateCampaign(ctx, session, c)
+}
+
+func (am *authorizationMiddleware) ViewCampaign(ctx context.Context, session mgauthn.Session, id string) (ota.Campaign, error) {
+	if err := am.authorize(ctx, session, policies.ViewPermission); err != nil {
+		return ota.Campaign{}, err
+	}
+
+	return am.svc.ViewCampaign(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) ListCampaigns(ctx context.Context, session mgauthn.Session, pm ota.PageMetadata) (ota.CampaignsPage, error) {
+	if err := am.authorize(ctx, session, policies.ViewPermission); err != nil {
+		return ota.CampaignsPage{}, err
+	}
+
+	return am.svc.ListCampaigns(ctx, session, pm)
+}
+
+func (am *authorizationMiddleware) StartCampaign(ctx context.Context, session mgauthn.Session, token, id string) (ota.Campaign, error) {
+	if err := am.authorize(ctx, session, policies.EditPermission); err != nil {
+		return ota.Campaign{}, err
+	}
+
+	return am.svc.StartCampaign(ctx, session, token, id)
+}
+
+func (am *authorizationMiddleware) PauseCampaign(ctx context.Context, session mgauthn.Session, id string) (ota.Campaign, error) {
+	if err := am.authorize(ctx, session, policies.EditPermission); err != nil {
+		return ota.Campaign{}, err
+	}
+
+	return am.svc.PauseCampaign(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) ResumeCampaign(ctx context.Context, session mgauthn.Session, id string) (ota.Campaign, error) {
+	if err := am.authorize(ctx, session, policies.EditPermission); err != nil {
+		return ota.Campaign{}, err
+	}
+
+	return am.svc.ResumeCampaign(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) CancelCampaign(ctx context.Context, session mgauthn.Session, id string) (ota.Campaign, error) {
+	if err := am.authorize(ctx, session, policies.EditPermission); err != nil {
+		return ota.Campaign{}, err
+	}
+
+	return am.svc.CancelCampaign(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) ListDevices(ctx context.Context, session mgauthn.Session, campaignID string, pm ota.PageMetadata) (ota.DevicesPage, error) {
+	if err := am.authorize(ctx, session, policies.ViewPermission); err != nil {
+		return ota.DevicesPage{}, err
+	}
+
+	return am.svc.ListDevices(ctx, session, campaignID, pm)
+}
+
+func (am *authorizationMiddleware) FetchFirmware(ctx context.Context, key, campaignID, thingID string) (ota.Firmware, io.ReadCloser, error) {
+	return am.svc.FetchFirmware(ctx, key, campaignID, thingID)
+}
+
+func (am *authorizationMiddleware) HandleProgress(ctx context.Context, msg *messaging.Message) error {
+	return am.svc.HandleProgress(ctx, msg)
+}
+
+func (am *authorizationMiddleware) authorize(ctx context.Context, session mgauthn.Session, perm string) error {
+	req := mgauthz.PolicyReq{
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     session.DomainUserID,
+		Permission:  perm,
+		ObjectType:  policies.DomainType,
+		Object:      session.DomainID,
+	}
+
+	return am.authz.Authorize(ctx, req)
+}
@@ -0,0 +1,320 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/magistrala/ota"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/messaging"
+)
+
+var _ ota.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger *slog.Logger
+	svc    ota.Service
+}
+
+// LoggingMiddleware adds logging facilities to the OTA service.
+func LoggingMiddleware(svc ota.Service, logger *slog.Logger) ota.Service {
+	return &loggingMiddleware{
+		logger: logger,
+		svc:    svc,
+	}
+}
+
+func (lm *loggingMiddleware) UploadFirmware(ctx context.Context, session mgauthn.Session, fw ota.Firmware, content io.Reader) (saved ota.Firmware, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("firmware",
+				slog.String("id", saved.ID),
+				slog.String("name", fw.Name),
+				slog.String("version", fw.Version),
+				slog.Int64("size", fw.Size),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Upload firmware failed", args...)
+			return
+		}
+		lm.logger.Info("Upload firmware completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.UploadFirmware(ctx, session, fw, content)
+}
+
+func (lm *loggingMiddleware) ViewFirmware(ctx context.Context, session mgauthn.Session, id string) (fw ota.Firmware, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("firmware_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View firmware failed", args...)
+			return
+		}
+		lm.logger.Info("View firmware completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewFirmware(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ListFirmware(ctx context.Context, session mgauthn.Session, pm ota.PageMetadata) (page ota.FirmwarePage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("page",
+				slog.String("name", pm.Name),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List firmware failed", args...)
+			return
+		}
+		lm.logger.Info("List firmware completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListFirmware(ctx, session, pm)
+}
+
+func (lm *loggingMiddleware) DownloadFirmware(ctx context.Context, session mgauthn.Session, id string) (fw ota.Firmware, content io.ReadCloser, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("firmware_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Download firmware failed", args...)
+			return
+		}
+		lm.logger.Info("Download firmware completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.DownloadFirmware(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) RemoveFirmware(ctx context.Context, session mgauthn.Session, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("firmware_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove firmware failed", args...)
+			return
+		}
+		lm.logger.Info("Remove firmware completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveFirmware(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) CreateCampaign(ctx context.Context, session mgauthn.Session, c ota.Campaign) (saved ota.Campaign, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("campaign",
+				slog.String("id", saved.ID),
+				slog.String("name", c.Name),
+				slog.String("firmware_id", c.FirmwareID),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create campaign failed", args...)
+			return
+		}
+		lm.logger.Info("Create campaign completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CreateCampaign(ctx, session, c)
+}
+
+func (lm *loggingMiddleware) ViewCampaign(ctx context.Context, session mgauthn.Session, id string) (c ota.Campaign, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("campaign_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View campaign failed", args...)
+			return
+		}
+		lm.logger.Info("View campaign completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewCampaign(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ListCampaigns(ctx context.Context, session mgauthn.Session, pm ota.PageMetadata) (page ota.CampaignsPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("page",
+				slog.String("firmware_id", pm.FirmwareID),
+				slog.String("status", pm.Status.String()),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List campaigns failed", args...)
+			return
+		}
+		lm.logger.Info("List campaigns completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListCampaigns(ctx, session, pm)
+}
+
+func (lm *loggingMiddleware) StartCampaign(ctx context.Context, session mgauthn.Session, token, id string) (c ota.Campaign, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("campaign",
+				slog.String("id", id),
+				slog.String("status", c.Status.String()),
+				slog.Uint64("things", c.Stats.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Start campaign failed", args...)
+			return
+		}
+		lm.logger.Info("Start campaign completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.StartCampaign(ctx, session, token, id)
+}
+
+func (lm *loggingMiddleware) PauseCampaign(ctx context.Context, session mgauthn.Session, id string) (c ota.Campaign, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("campaign_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Pause campaign failed", args...)
+			return
+		}
+		lm.logger.Info("Pause campaign completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.PauseCampaign(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ResumeCampaign(ctx context.Context, session mgauthn.Session, id string) (c ota.Campaign, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("campaign",
+				slog.String("id", id),
+				slog.String("status", c.Status.String()),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Resume campaign failed", args...)
+			return
+		}
+		lm.logger.Info("Resume campaign completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ResumeCampaign(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) CancelCampaign(ctx context.Context, session mgauthn.Session, id string) (c ota.Campaign, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("campaign_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Cancel campaign failed", args...)
+			return
+		}
+		lm.logger.Info("Cancel campaign completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CancelCampaign(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ListDevices(ctx context.Context, session mgauthn.Session, campaignID string, pm ota.PageMetadata) (page ota.DevicesPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("page",
+				slog.String("campaign_id", campaignID),
+				slog.String("status", pm.DeviceStatus.String()),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List campaign devices failed", args...)
+			return
+		}
+		lm.logger.Info("List campaign devices completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListDevices(ctx, session, campaignID, pm)
+}
+
+func (lm *loggingMiddleware) FetchFirmware(ctx context.Context, key, campaignID, thingID string) (fw ota.Firmware, content io.ReadCloser, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("campaign_id", campaignID),
+			slog.String("thing_id", thingID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Fetch campaign firmware failed", args...)
+			return
+		}
+		lm.logger.Info("Fetch campaign firmware completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.FetchFirmware(ctx, key, campaignID, thingID)
+}
+
+func (lm *loggingMiddleware) HandleProgress(ctx context.Context, msg *messaging.Message) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("channel_id", msg.GetChannel()),
+			slog.String("thing_id", msg.GetPublisher()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Handle update progress failed", args...)
+			return
+		}
+		lm.logger.Info("Handle update progress completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.HandleProgress(ctx, msg)
+}
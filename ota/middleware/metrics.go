@@ -0,0 +1,167 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/absmach/magistrala/ota"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ ota.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     ota.Service
+}
+
+// MetricsMiddleware instruments OTA service by tracking request count and latency.
+func MetricsMiddleware(svc ota.Service, counter metrics.Counter, latency metrics.Histogram) ota.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (mm *metricsMiddleware) UploadFirmware(ctx context.Context, session mgauthn.Session, fw ota.Firmware, content io.Reader) (ota.Firmware, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "upload_firmware").Add(1)
+		mm.latency.With("method", "upload_firmware").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.UploadFirmware(ctx, session, fw, content)
+}
+
+func (mm *metricsMiddleware) ViewFirmware(ctx context.Context, session mgauthn.Session, id string) (ota.Firmware, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_firmware").Add(1)
+		mm.latency.With("method", "view_firmware").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewFirmware(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) ListFirmware(ctx context.Context, session mgauthn.Session, pm ota.PageMetadata) (ota.FirmwarePage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_firmware").Add(1)
+		mm.latency.With("method", "list_firmware").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListFirmware(ctx, session, pm)
+}
+
+func (mm *metricsMiddleware) DownloadFirmware(ctx context.Context, session mgauthn.Session, id string) (ota.Firmware, io.ReadCloser, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "download_firmware").Add(1)
+		mm.latency.With("method", "download_firmware").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.DownloadFirmware(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) RemoveFirmware(ctx context.Context, session mgauthn.Session, id string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_firmware").Add(1)
+		mm.latency.With("method", "remove_firmware").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveFirmware(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) CreateCampaign(ctx context.Context, session mgauthn.Session, c ota.Campaign) (ota.Campaign, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_campaign").Add(1)
+		mm.latency.With("method", "create_campaign").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateCampaign(ctx, session, c)
+}
+
+func (mm *metricsMiddleware) ViewCampaign(ctx context.Context, session mgauthn.Session, id string) (ota.Campaign, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_campaign").Add(1)
+		mm.latency.With("method", "view_campaign").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewCampaign(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) ListCampaigns(ctx context.Context, session mgauthn.Session, pm ota.PageMetadata) (ota.CampaignsPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_campaigns").Add(1)
+		mm.latency.With("method", "list_campaigns").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListCampaigns(ctx, session, pm)
+}
+
+func (mm *metricsMiddleware) StartCampaign(ctx context.Context, session mgauthn.Session, token, id string) (ota.Campaign, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "start_campaign").Add(1)
+		mm.latency.With("method", "start_campaign").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.StartCampaign(ctx, session, token, id)
+}
+
+func (mm *metricsMiddleware) PauseCampaign(ctx context.Context, session mgauthn.Session, id string) (ota.Campaign, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "pause_campaign").Add(1)
+		mm.latency.With("method", "pause_campaign").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.PauseCampaign(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) ResumeCampaign(ctx context.Context, session mgauthn.Session, id string) (ota.Campaign, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "resume_campaign").Add(1)
+		mm.latency.With("method", "resume_campaign").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ResumeCampaign(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) CancelCampaign(ctx context.Context, session mgauthn.Session, id string) (ota.Campaign, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "cancel_campaign").Add(1)
+		mm.latency.With("method", "cancel_campaign").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CancelCampaign(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) ListDevices(ctx context.Context, session mgauthn.Session, campaignID string, pm ota.PageMetadata) (ota.DevicesPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_devices").Add(1)
+		mm.latency.With("method", "list_devices").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListDevices(ctx, session, campaignID, pm)
+}
+
+func (mm *metricsMiddleware) FetchFirmware(ctx context.Context, key, campaignID, thingID string) (ota.Firmware, io.ReadCloser, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "fetch_firmware").Add(1)
+		mm.latency.With("method", "fetch_firmware").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.FetchFirmware(ctx, key, campaignID, thingID)
+}
+
+func (mm *metricsMiddleware) HandleProgress(ctx context.Context, msg *messaging.Message) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "handle_progress").Add(1)
+		mm.latency.With("method", "handle_progress").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.HandleProgress(ctx, msg)
+}
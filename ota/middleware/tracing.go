@@ -0,0 +1,159 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"io"
+
+	"github.com/absmach/magistrala/ota"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ ota.Service = (*tracing)(nil)
+
+type tracing struct {
+	tracer trace.Tracer
+	svc    ota.Service
+}
+
+// Tracing adds tracing to the OTA service.
+func Tracing(svc ota.Service, tracer trace.Tracer) ota.Service {
+	return &tracing{tracer, svc}
+}
+
+func (tm *tracing) UploadFirmware(ctx context.Context, session mgauthn.Session, fw ota.Firmware, content io.Reader) (ota.Firmware, error) {
+	ctx, span := tm.tracer.Start(ctx, "upload_firmware", trace.WithAttributes(
+		attribute.String("name", fw.Name),
+		attribute.String("version", fw.Version),
+		attribute.Int64("size", fw.Size),
+	))
+	defer span.End()
+
+	return tm.svc.UploadFirmware(ctx, session, fw, content)
+}
+
+func (tm *tracing) ViewFirmware(ctx context.Context, session mgauthn.Session, id string) (ota.Firmware, error) {
+	ctx, span := tm.tracer.Start(ctx, "view_firmware", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.ViewFirmware(ctx, session, id)
+}
+
+func (tm *tracing) ListFirmware(ctx context.Context, session mgauthn.Session, pm ota.PageMetadata) (ota.FirmwarePage, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_firmware", trace.WithAttributes(
+		attribute.String("name", pm.Name),
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListFirmware(ctx, session, pm)
+}
+
+func (tm *tracing) DownloadFirmware(ctx context.Context, session mgauthn.Session, id string) (ota.Firmware, io.ReadCloser, error) {
+	ctx, span := tm.tracer.Start(ctx, "download_firmware", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.DownloadFirmware(ctx, session, id)
+}
+
+func (tm *tracing) RemoveFirmware(ctx context.Context, session mgauthn.Session, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "remove_firmware", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.RemoveFirmware(ctx, session, id)
+}
+
+func (tm *tracing) CreateCampaign(ctx context.Context, session mgauthn.Session, c ota.Campaign) (ota.Campaign, error) {
+	ctx, span := tm.tracer.Start(ctx, "create_campaign", trace.WithAttributes(
+		attribute.String("name", c.Name),
+		attribute.String("firmware_id", c.FirmwareID),
+	))
+	defer span.End()
+
+	return tm.svc.CreateCampaign(ctx, session, c)
+}
+
+func (tm *tracing) ViewCampaign(ctx context.Context, session mgauthn.Session, id string) (ota.Campaign, error) {
+	ctx, span := tm.tracer.Start(ctx, "view_campaign", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.ViewCampaign(ctx, session, id)
+}
+
+func (tm *tracing) ListCampaigns(ctx context.Context, session mgauthn.Session, pm ota.PageMetadata) (ota.CampaignsPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_campaigns", trace.WithAttributes(
+		attribute.String("firmware_id", pm.FirmwareID),
+		attribute.String("status", pm.Status.String()),
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListCampaigns(ctx, session, pm)
+}
+
+func (tm *tracing) StartCampaign(ctx context.Context, session mgauthn.Session, token, id string) (ota.Campaign, error) {
+	ctx, span := tm.tracer.Start(ctx, "start_campaign", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.StartCampaign(ctx, session, token, id)
+}
+
+func (tm *tracing) PauseCampaign(ctx context.Context, session mgauthn.Session, id string) (ota.Campaign, error) {
+	ctx, span := tm.tracer.Start(ctx, "pause_campaign", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.PauseCampaign(ctx, session, id)
+}
+
+func (tm *tracing) ResumeCampaign(ctx context.Context, session mgauthn.Session, id string) (ota.Campaign, error) {
+	ctx, span := tm.tracer.Start(ctx, "resume_campaign", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.ResumeCampaign(ctx, session, id)
+}
+
+func (tm *tracing) CancelCampaign(ctx context.Context, session mgauthn.Session, id string) (ota.Campaign, error) {
+	ctx, span := tm.tracer.Start(ctx, "cancel_campaign", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.CancelCampaign(ctx, session, id)
+}
+
+func (tm *tracing) ListDevices(ctx context.Context, session mgauthn.Session, campaignID string, pm ota.PageMetadata) (ota.DevicesPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_devices", trace.WithAttributes(
+		attribute.String("campaign_id", campaignID),
+		attribute.String("status", pm.DeviceStatus.String()),
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListDevices(ctx, session, campaignID, pm)
+}
+
+func (tm *tracing) FetchFirmware(ctx context.Context, key, campaignID, thingID string) (ota.Firmware, io.ReadCloser, error) {
+	ctx, span := tm.tracer.Start(ctx, "fetch_firmware", trace.WithAttributes(
+		attribute.String("campaign_id", campaignID),
+		attribute.String("thing_id", thingID),
+	))
+	defer span.End()
+
+	return tm.svc.FetchFirmware(ctx, key, campaignID, thingID)
+}
+
+func (tm *tracing) HandleProgress(ctx context.Context, msg *messaging.Message) error {
+	ctx, span := tm.tracer.Start(ctx, "handle_progress", trace.WithAttributes(
+		attribute.String("channel_id", msg.GetChannel()),
+		attribute.String("thing_id", msg.GetPublisher()),
+	))
+	defer span.End()
+
+	return tm.svc.HandleProgress(ctx, msg)
+}
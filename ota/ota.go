@@ -0,0 +1,463 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package ota
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// Subtopics used to exchange update messages with things. Update
+// notifications are published to the `ota.<thing_id>` subtopic of the channel
+// the thing is connected to, and things report the update progress to the
+// `ota.progress` subtopic.
+const (
+	Subtopic         = "ota"
+	ProgressSubtopic = Subtopic + ".progress"
+
+	// ProgressTopic is the message broker topic of the progress reports
+	// published to any channel.
+	ProgressTopic = "channels.*." + ProgressSubtopic
+
+	// Protocol is set as the protocol of the notification messages.
+	Protocol = "ota"
+)
+
+// String representation of the campaign and device status values.
+const (
+	Draft       = "draft"
+	Running     = "running"
+	Paused      = "paused"
+	Completed   = "completed"
+	Cancelled   = "cancelled"
+	Pending     = "pending"
+	Notified    = "notified"
+	Downloading = "downloading"
+	Installing  = "installing"
+	Succeeded   = "succeeded"
+	Failed      = "failed"
+	All         = "all"
+	Unknown     = "unknown"
+)
+
+// Firmware is the firmware artifact rolled out to things.
+type Firmware struct {
+	ID       string `json:"id"`
+	DomainID string `json:"domain_id"`
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Size     int64  `json:"size"`
+	// Checksum is the hex encoded SHA-256 digest of the firmware content.
+	Checksum string `json:"checksum"`
+	// Signature is the base64 encoded signature of the firmware checksum
+	// digest. It is verified on upload with the configured signing key and
+	// things verify it again before installing the firmware.
+	Signature string    `json:"signature,omitempty"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Key returns the key the firmware content is stored under.
+func (fw Firmware) Key() string {
+	return fw.DomainID + "/" + fw.ID
+}
+
+// FirmwarePage contains page related metadata as well as list of firmware
+// that belong to this page.
+type FirmwarePage struct {
+	PageMetadata
+	Total    uint64     `json:"total"`
+	Firmware []Firmware `json:"firmware"`
+}
+
+// CampaignStatus represents campaign status.
+type CampaignStatus uint8
+
+// Possible campaign status values.
+const (
+	// DraftStatus represents a campaign which is not started.
+	DraftStatus CampaignStatus = iota
+	// RunningStatus represents a campaign which notifies things.
+	RunningStatus
+	// PausedStatus represents a campaign paused by the user or by reaching
+	// the failure threshold. Progress of notified things is still tracked.
+	PausedStatus
+	// CompletedStatus represents a campaign all the target things completed.
+	CompletedStatus
+	// CancelledStatus represents a campaign cancelled by the user.
+	CancelledStatus
+
+	// AllCampaignStatus is used for querying purposes to list campaigns
+	// irrespective of their status. It is never stored in the database.
+	AllCampaignStatus
+)
+
+// String converts campaign status to string literal.
+func (s CampaignStatus) String() string {
+	switch s {
+	case DraftStatus:
+		return Draft
+	case RunningStatus:
+		return Running
+	case PausedStatus:
+		return Paused
+	case CompletedStatus:
+		return Completed
+	case CancelledStatus:
+		return Cancelled
+	case AllCampaignStatus:
+		return All
+	default:
+		return Unknown
+	}
+}
+
+// ToCampaignStatus converts string value to a valid campaign status.
+func ToCampaignStatus(status string) (CampaignStatus, error) {
+	switch status {
+	case Draft:
+		return DraftStatus, nil
+	case Running:
+		return RunningStatus, nil
+	case Paused:
+		return PausedStatus, nil
+	case Completed:
+		return CompletedStatus, nil
+	case Cancelled:
+		return CancelledStatus, nil
+	case "", All:
+		return AllCampaignStatus, nil
+	}
+
+	return CampaignStatus(0), svcerr.ErrInvalidStatus
+}
+
+// MarshalJSON converts campaign status to JSON string.
+func (s CampaignStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON parses campaign status from JSON string.
+func (s *CampaignStatus) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	val, err := ToCampaignStatus(str)
+	*s = val
+
+	return err
+}
+
+// DeviceStatus represents the update status of a campaign target thing.
+type DeviceStatus uint8
+
+// Possible device status values. Status values are ordered, so the device
+// status can only move forward.
+const (
+	// PendingStatus represents a thing which is not notified yet, because
+	// its rollout stage is not reached.
+	PendingStatus DeviceStatus = iota
+	// NotifiedStatus represents a thing the update notification is
+	// published to.
+	NotifiedStatus
+	// DownloadingStatus represents a thing downloading the firmware.
+	DownloadingStatus
+	// InstallingStatus represents a thing installing the firmware.
+	InstallingStatus
+	// SucceededStatus represents a thing which installed the firmware.
+	SucceededStatus
+	// FailedStatus represents a thing which failed to update.
+	FailedStatus
+
+	// AllDeviceStatus is used for querying purposes to list devices
+	// irrespective of their status. It is never stored in the database.
+	AllDeviceStatus
+)
+
+// String converts device status to string literal.
+func (s DeviceStatus) String() string {
+	switch s {
+	case PendingStatus:
+		return Pending
+	case NotifiedStatus:
+		return Notified
+	case DownloadingStatus:
+		return Downloading
+	case InstallingStatus:
+		return Installing
+	case SucceededStatus:
+		return Succeeded
+	case FailedStatus:
+		return Failed
+	case AllDeviceStatus:
+		return All
+	default:
+		return Unknown
+	}
+}
+
+// ToDeviceStatus converts string value to a valid device status.
+func ToDeviceStatus(status string) (DeviceStatus, error) {
+	switch status {
+	case Pending:
+		return PendingStatus, nil
+	case Notified:
+		return NotifiedStatus, nil
+	case Downloading:
+		return DownloadingStatus, nil
+	case Installing:
+		return InstallingStatus, nil
+	case Succeeded:
+		return SucceededStatus, nil
+	case Failed:
+		return FailedStatus, nil
+	case "", All:
+		return AllDeviceStatus, nil
+	}
+
+	return DeviceStatus(0), svcerr.ErrInvalidStatus
+}
+
+// Final reports whether the device status can no longer change.
+func (s DeviceStatus) Final() bool {
+	return s == SucceededStatus || s == FailedStatus
+}
+
+// MarshalJSON converts device status to JSON string.
+func (s DeviceStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON parses device status from JSON string.
+func (s *DeviceStatus) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	val, err := ToDeviceStatus(str)
+	*s = val
+
+	return err
+}
+
+// Target selects the things the campaign rolls the firmware out to. Things
+// are selected either by tag and metadata, or by the group their channels
+// belong to.
+type Target struct {
+	Tag      string                 `json:"tag,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	GroupID  string                 `json:"group_id,omitempty"`
+}
+
+// Empty reports whether the target selects no things.
+func (t Target) Empty() bool {
+	return t.Tag == "" && len(t.Metadata) == 0 && t.GroupID == ""
+}
+
+// Campaign rolls the firmware out to the target things in stages.
+type Campaign struct {
+	ID         string `json:"id"`
+	DomainID   string `json:"domain_id"`
+	Name       string `json:"name"`
+	FirmwareID string `json:"firmware_id"`
+	Target     Target `json:"target"`
+	// Stages are the cumulative percentages of the target things notified
+	// in each rollout stage, e.g. [10, 50, 100]. The next stage starts once
+	// all the things notified in the current stage complete the update.
+	Stages []uint8 `json:"stages"`
+	// Stage is the index of the current rollout stage.
+	Stage int `json:"stage"`
+	// FailureThreshold is the percentage of failed updates among the
+	// notified things which pauses the campaign. Zero threshold disables
+	// pausing the campaign on failures.
+	FailureThreshold uint8          `json:"failure_threshold"`
+	Status           CampaignStatus `json:"status"`
+	// Reason describes why the campaign is paused.
+	Reason    string    `json:"reason,omitempty"`
+	Stats     Stats     `json:"stats"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	StartedAt time.Time `json:"started_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// Revision is incremented on every campaign update. It is used to
+	// detect concurrent updates of the campaign.
+	Revision uint64 `json:"revision"`
+}
+
+// Stats contains the number of campaign target things by their status.
+type Stats struct {
+	Total       uint64 `json:"total"`
+	Pending     uint64 `json:"pending"`
+	Notified    uint64 `json:"notified"`
+	Downloading uint64 `json:"downloading"`
+	Installing  uint64 `json:"installing"`
+	Succeeded   uint64 `json:"succeeded"`
+	Failed      uint64 `json:"failed"`
+}
+
+// Reached returns the number of things which are notified, regardless of
+// their progress.
+func (s Stats) Reached() uint64 {
+	return s.Total - s.Pending
+}
+
+// Done returns the number of things which completed the update.
+func (s Stats) Done() uint64 {
+	return s.Succeeded + s.Failed
+}
+
+// CampaignsPage contains page related metadata as well as list of campaigns
+// that belong to this page.
+type CampaignsPage struct {
+	PageMetadata
+	Total     uint64     `json:"total"`
+	Campaigns []Campaign `json:"campaigns"`
+}
+
+// Device is the campaign target thing together with its update progress.
+type Device struct {
+	CampaignID string       `json:"campaign_id"`
+	ThingID    string       `json:"thing_id"`
+	ChannelID  string       `json:"channel_id"`
+	Status     DeviceStatus `json:"status"`
+	// Progress is the download or installation progress percentage
+	// reported by the thing.
+	Progress   uint8     `json:"progress"`
+	Error      string    `json:"error,omitempty"`
+	NotifiedAt time.Time `json:"notified_at,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at,omitempty"`
+}
+
+// DevicesPage contains page related metadata as well as list of campaign
+// devices that belong to this page.
+type DevicesPage struct {
+	PageMetadata
+	Total   uint64   `json:"total"`
+	Devices []Device `json:"devices"`
+}
+
+// Notification is the payload of the message notifying the thing about the
+// firmware update. Campaign ID is used as the correlation ID of the progress
+// reports.
+type Notification struct {
+	CampaignID string `json:"campaign_id"`
+	FirmwareID string `json:"firmware_id"`
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	Size       int64  `json:"size"`
+	Checksum   string `json:"checksum"`
+	Signature  string `json:"signature,omitempty"`
+	// URL is the location the thing downloads the firmware from using its
+	// key.
+	URL string `json:"url"`
+}
+
+// Progress is the payload of the message things publish to the progress
+// subtopic to report the update status. Status is one of `downloading`,
+// `installing`, `succeeded` and `failed`.
+type Progress struct {
+	CampaignID string       `json:"campaign_id"`
+	Status     DeviceStatus `json:"status"`
+	Progress   uint8        `json:"progress,omitempty"`
+	Error      string       `json:"error,omitempty"`
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Offset     uint64 `json:"offset"`
+	Limit      uint64 `json:"limit"`
+	DomainID   string `json:"domain_id,omitempty"`
+	Name       string `json:"name,omitempty"`
+	FirmwareID string `json:"firmware_id,omitempty"`
+	CampaignID string `json:"campaign_id,omitempty"`
+	// Status filters campaigns by their status.
+	Status CampaignStatus `json:"status,omitempty"`
+	// DeviceStatus filters campaign devices by their status.
+	DeviceStatus DeviceStatus `json:"device_status,omitempty"`
+}
+
+// Storage specifies a firmware content storage API.
+//
+//go:generate mockery --name Storage --output=./mocks --filename storage.go --quiet --note "Copyright (c) Abstract Machines"
+type Storage interface {
+	// Save stores the content of the provided size under the key.
+	Save(ctx context.Context, key string, content io.Reader, size int64) error
+
+	// Open returns the reader of the content stored under the key. The
+	// caller closes the reader.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Remove removes the content stored under the key.
+	Remove(ctx context.Context, key string) error
+}
+
+// FirmwareRepository specifies a firmware persistence API.
+//
+//go:generate mockery --name FirmwareRepository --output=./mocks --filename firmware.go --quiet --note "Copyright (c) Abstract Machines"
+type FirmwareRepository interface {
+	// Save persists the firmware.
+	Save(ctx context.Context, fw Firmware) (Firmware, error)
+
+	// RetrieveByID retrieves the firmware having the provided identifier.
+	RetrieveByID(ctx context.Context, id string) (Firmware, error)
+
+	// RetrieveAll retrieves firmware of the domain.
+	RetrieveAll(ctx context.Context, pm PageMetadata) (FirmwarePage, error)
+
+	// Remove removes the firmware having the provided identifier.
+	Remove(ctx context.Context, id string) error
+}
+
+// CampaignRepository specifies a campaign persistence API.
+//
+//go:generate mockery --name CampaignRepository --output=./mocks --filename campaigns.go --quiet --note "Copyright (c) Abstract Machines"
+type CampaignRepository interface {
+	// Save persists the campaign.
+	Save(ctx context.Context, c Campaign) (Campaign, error)
+
+	// RetrieveByID retrieves the campaign having the provided identifier
+	// together with its device stats.
+	RetrieveByID(ctx context.Context, id string) (Campaign, error)
+
+	// RetrieveAll retrieves campaigns of the domain.
+	RetrieveAll(ctx context.Context, pm PageMetadata) (CampaignsPage, error)
+
+	// Update updates the campaign status, stage and pause reason, provided
+	// the stored campaign revision matches the campaign revision. Conflict
+	// error is returned if the campaign was updated in the meantime.
+	Update(ctx context.Context, c Campaign) (Campaign, error)
+
+	// SaveDevices persists the campaign target things.
+	SaveDevices(ctx context.Context, devices ...Device) error
+
+	// RetrieveDevice retrieves the campaign target thing.
+	RetrieveDevice(ctx context.Context, campaignID, thingID string) (Device, error)
+
+	// RetrieveDevices retrieves the campaign target things.
+	RetrieveDevices(ctx context.Context, pm PageMetadata) (DevicesPage, error)
+
+	// RetrievePending retrieves up to limit things of the campaign which
+	// are not notified yet.
+	RetrievePending(ctx context.Context, campaignID string, limit uint64) ([]Device, error)
+
+	// ClaimDevice marks the pending device as notified, provided the
+	// campaign is running and its revision matches the provided one. Not
+	// found error is returned if the device is claimed by another rollout
+	// or the campaign was updated in the meantime.
+	ClaimDevice(ctx context.Context, revision uint64, d Device) (Device, error)
+
+	// ReleaseDevice returns the device claimed by ClaimDevice to pending,
+	// unless the thing reported its progress in the meantime.
+	ReleaseDevice(ctx context.Context, d Device) error
+
+	// UpdateDevice updates the device status, progress, error and
+	// notification time. Device is updated only if the stored status is not
+	// final and does not follow the new one.
+	UpdateDevice(ctx context.Context, d Device) (Device, error)
+}
@@ -0,0 +1,478 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/ota"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+)
+
+const (
+	campaignColumns = `id, domain_id, name, firmware_id, target, stages, stage, failure_threshold, status, reason,
+	created_by, created_at, started_at, updated_at, revision`
+	deviceColumns = `campaign_id, thing_id, channel_id, status, progress, error, notified_at, updated_at`
+
+	// devicesBatch is the number of devices inserted at once, which keeps
+	// the number of query parameters below the PostgreSQL limit.
+	devicesBatch = 1000
+)
+
+// selectCampaigns selects campaigns together with the number of their
+// devices by status.
+var selectCampaigns = fmt.Sprintf(`SELECT c.id, c.domain_id, c.name, c.firmware_id, c.target, c.stages, c.stage,
+	c.failure_threshold, c.status, c.reason, c.created_by, c.created_at, c.started_at, c.updated_at, c.revision,
+	s.total, s.pending, s.notified, s.downloading, s.installing, s.succeeded, s.failed
+	FROM campaigns c, LATERAL (
+		SELECT COUNT(*) AS total,
+		COUNT(*) FILTER (WHERE d.status = %d) AS pending,
+		COUNT(*) FILTER (WHERE d.status = %d) AS notified,
+		COUNT(*) FILTER (WHERE d.status = %d) AS downloading,
+		COUNT(*) FILTER (WHERE d.status = %d) AS installing,
+		COUNT(*) FILTER (WHERE d.status = %d) AS succeeded,
+		COUNT(*) FILTER (WHERE d.status = %d) AS failed
+		FROM campaign_devices d WHERE d.campaign_id = c.id
+	) s`, ota.PendingStatus, ota.NotifiedStatus, ota.DownloadingStatus, ota.InstallingStatus, ota.SucceededStatus, ota.FailedStatus)
+
+var _ ota.CampaignRepository = (*campaignRepository)(nil)
+
+type campaignRepository struct {
+	db postgres.Database
+}
+
+// NewCampaignRepository instantiates a PostgreSQL implementation of campaign
+// repository.
+func NewCampaignRepository(db postgres.Database) ota.CampaignRepository {
+	return &campaignRepository{db: db}
+}
+
+func (repo *campaignRepository) Save(ctx context.Context, c ota.Campaign) (ota.Campaign, error) {
+	dbc, err := toDBCampaign(c)
+	if err != nil {
+		return ota.Campaign{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+	q := fmt.Sprintf(`INSERT INTO campaigns (%s)
+		VALUES (:id, :domain_id, :name, :firmware_id, :target, :stages, :stage, :failure_threshold, :status, :reason,
+		:created_by, :created_at, :started_at, :updated_at, 0)
+		RETURNING %s;`, campaignColumns, campaignColumns)
+
+	return repo.namedQueryRow(ctx, q, dbc, repoerr.ErrCreateEntity)
+}
+
+func (repo *campaignRepository) RetrieveByID(ctx context.Context, id string) (ota.Campaign, error) {
+	q := fmt.Sprintf(`%s WHERE c.id = :id;`, selectCampaigns)
+
+	return repo.namedQueryRow(ctx, q, map[string]interface{}{"id": id}, repoerr.ErrViewEntity)
+}
+
+func (repo *campaignRepository) RetrieveAll(ctx context.Context, pm ota.PageMetadata) (ota.CampaignsPage, error) {
+	query := []string{"c.domain_id = :domain_id"}
+	if pm.Name != "" {
+		query = append(query, "c.name = :name")
+	}
+	if pm.FirmwareID != "" {
+		query = append(query, "c.firmware_id = :firmware_id")
+	}
+	if pm.Status != ota.AllCampaignStatus {
+		query = append(query, "c.status = :status")
+	}
+	where := fmt.Sprintf("WHERE %s", strings.Join(query, " AND "))
+	q := fmt.Sprintf(`%s %s ORDER BY c.created_at DESC LIMIT :limit OFFSET :offset;`, selectCampaigns, where)
+
+	params := map[string]interface{}{
+		"domain_id":   pm.DomainID,
+		"name":        pm.Name,
+		"firmware_id": pm.FirmwareID,
+		"status":      pm.Status,
+		"limit":       pm.Limit,
+		"offset":      pm.Offset,
+	}
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return ota.CampaignsPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var items []ota.Campaign
+	for rows.Next() {
+		var dbc dbCampaign
+		if err := rows.StructScan(&dbc); err != nil {
+			return ota.CampaignsPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		c, err := toCampaign(dbc)
+		if err != nil {
+			return ota.CampaignsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		items = append(items, c)
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM campaigns c %s;`, where)
+	total, err := postgres.Total(ctx, repo.db, cq, params)
+	if err != nil {
+		return ota.CampaignsPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return ota.CampaignsPage{
+		PageMetadata: pm,
+		Total:        total,
+		Campaigns:    items,
+	}, nil
+}
+
+func (repo *campaignRepository) Update(ctx context.Context, c ota.Campaign) (ota.Campaign, error) {
+	q := `UPDATE campaigns SET stage = :stage, status = :status, reason = :reason, started_at = :started_at,
+		updated_at = :updated_at, revision = revision + 1 WHERE id = :id AND revision = :revision;`
+
+	dbc, err := toDBCampaign(c)
+	if err != nil {
+		return ota.Campaign{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+	res, err := repo.db.NamedExecContext(ctx, q, dbc)
+	if err != nil {
+		return ota.Campaign{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		// Campaigns are never removed, so the campaign was updated since
+		// it was retrieved.
+		return ota.Campaign{}, repoerr.ErrConflict
+	}
+
+	return repo.RetrieveByID(ctx, c.ID)
+}
+
+func (repo *campaignRepository) SaveDevices(ctx context.Context, devices ...ota.Device) error {
+	// Devices which are already saved are skipped, so saving can be
+	// repeated after a partial failure.
+	q := fmt.Sprintf(`INSERT INTO campaign_devices (%s)
+		VALUES (:campaign_id, :thing_id, :channel_id, :status, :progress, :error, :notified_at, :updated_at)
+		ON CONFLICT (campaign_id, thing_id) DO NOTHING;`, deviceColumns)
+
+	for start := 0; start < len(devices); start += devicesBatch {
+		end := min(start+devicesBatch, len(devices))
+		batch := make([]dbDevice, 0, end-start)
+		for _, d := range devices[start:end] {
+			batch = append(batch, toDBDevice(d))
+		}
+		if _, err := repo.db.NamedExecContext(ctx, q, batch); err != nil {
+			return postgres.HandleError(repoerr.ErrCreateEntity, err)
+		}
+	}
+
+	return nil
+}
+
+func (repo *campaignRepository) RetrieveDevice(ctx context.Context, campaignID, thingID string) (ota.Device, error) {
+	q := fmt.Sprintf(`SELECT %s FROM campaign_devices WHERE campaign_id = :campaign_id AND thing_id = :thing_id;`, deviceColumns)
+
+	return repo.deviceQueryRow(ctx, q, dbDevice{CampaignID: campaignID, ThingID: thingID}, repoerr.ErrViewEntity)
+}
+
+func (repo *campaignRepository) RetrieveDevices(ctx context.Context, pm ota.PageMetadata) (ota.DevicesPage, error) {
+	query := []string{"campaign_id = :campaign_id"}
+	if pm.DeviceStatus != ota.AllDeviceStatus {
+		query = append(query, "status = :status")
+	}
+	where := fmt.Sprintf("WHERE %s", strings.Join(query, " AND "))
+	q := fmt.Sprintf(`SELECT %s FROM campaign_devices %s ORDER BY thing_id LIMIT :limit OFFSET :offset;`, deviceColumns, where)
+
+	params := map[string]interface{}{
+		"campaign_id": pm.CampaignID,
+		"status":      pm.DeviceStatus,
+		"limit":       pm.Limit,
+		"offset":      pm.Offset,
+	}
+
+	devices, err := repo.deviceQuery(ctx, q, params)
+	if err != nil {
+		return ota.DevicesPage{}, err
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM campaign_devices %s;`, where)
+	total, err := postgres.Total(ctx, repo.db, cq, params)
+	if err != nil {
+		return ota.DevicesPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return ota.DevicesPage{
+		PageMetadata: pm,
+		Total:        total,
+		Devices:      devices,
+	}, nil
+}
+
+func (repo *campaignRepository) RetrievePending(ctx context.Context, campaignID string, limit uint64) ([]ota.Device, error) {
+	q := fmt.Sprintf(`SELECT %s FROM campaign_devices WHERE campaign_id = :campaign_id AND status = :status
+		ORDER BY thing_id LIMIT :limit;`, deviceColumns)
+
+	params := map[string]interface{}{
+		"campaign_id": campaignID,
+		"status":      ota.PendingStatus,
+		"limit":       limit,
+	}
+
+	return repo.deviceQuery(ctx, q, params)
+}
+
+func (repo *campaignRepository) ClaimDevice(ctx context.Context, revision uint64, d ota.Device) (ota.Device, error) {
+	q := fmt.Sprintf(`UPDATE campaign_devices SET status = :status, notified_at = :notified_at, updated_at = :updated_at
+		WHERE campaign_id = :campaign_id AND thing_id = :thing_id AND status = :pending
+		AND EXISTS (SELECT 1 FROM campaigns WHERE id = :campaign_id AND status = :running AND revision = :revision)
+		RETURNING %s;`, deviceColumns)
+
+	params := struct {
+		dbDevice
+		Pending  ota.DeviceStatus   `db:"pending"`
+		Running  ota.CampaignStatus `db:"running"`
+		Revision uint64             `db:"revision"`
+	}{
+		dbDevice: toDBDevice(d),
+		Pending:  ota.PendingStatus,
+		Running:  ota.RunningStatus,
+		Revision: revision,
+	}
+
+	return repo.deviceQueryRow(ctx, q, params, repoerr.ErrUpdateEntity)
+}
+
+func (repo *campaignRepository) ReleaseDevice(ctx context.Context, d ota.Device) error {
+	q := `UPDATE campaign_devices SET status = :pending, notified_at = NULL, updated_at = :updated_at
+		WHERE campaign_id = :campaign_id AND thing_id = :thing_id AND status = :status;`
+
+	params := struct {
+		dbDevice
+		Pending ota.DeviceStatus `db:"pending"`
+	}{
+		dbDevice: toDBDevice(d),
+		Pending:  ota.PendingStatus,
+	}
+	if _, err := repo.db.NamedExecContext(ctx, q, params); err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *campaignRepository) UpdateDevice(ctx context.Context, d ota.Device) (ota.Device, error) {
+	q := fmt.Sprintf(`UPDATE campaign_devices SET status = :status, progress = :progress, error = :error,
+		notified_at = COALESCE(:notified_at, notified_at), updated_at = :updated_at
+		WHERE campaign_id = :campaign_id AND thing_id = :thing_id AND status <= :status AND status < :final
+		RETURNING %s;`, deviceColumns)
+
+	params := struct {
+		dbDevice
+		Final ota.DeviceStatus `db:"final"`
+	}{
+		dbDevice: toDBDevice(d),
+		Final:    ota.SucceededStatus,
+	}
+
+	return repo.deviceQueryRow(ctx, q, params, repoerr.ErrUpdateEntity)
+}
+
+func (repo *campaignRepository) namedQueryRow(ctx context.Context, q string, params interface{}, wrapper error) (ota.Campaign, error) {
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return ota.Campaign{}, postgres.HandleError(wrapper, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return ota.Campaign{}, errors.Wrap(repoerr.ErrNotFound, sql.ErrNoRows)
+	}
+	var dbc dbCampaign
+	if err := rows.StructScan(&dbc); err != nil {
+		return ota.Campaign{}, postgres.HandleError(wrapper, err)
+	}
+	c, err := toCampaign(dbc)
+	if err != nil {
+		return ota.Campaign{}, errors.Wrap(wrapper, err)
+	}
+
+	return c, nil
+}
+
+func (repo *campaignRepository) deviceQueryRow(ctx context.Context, q string, params interface{}, wrapper error) (ota.Device, error) {
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return ota.Device{}, postgres.HandleError(wrapper, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return ota.Device{}, errors.Wrap(repoerr.ErrNotFound, sql.ErrNoRows)
+	}
+	var dbd dbDevice
+	if err := rows.StructScan(&dbd); err != nil {
+		return ota.Device{}, postgres.HandleError(wrapper, err)
+	}
+
+	return toDevice(dbd), nil
+}
+
+func (repo *campaignRepository) deviceQuery(ctx context.Context, q string, params interface{}) ([]ota.Device, error) {
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var devices []ota.Device
+	for rows.Next() {
+		var dbd dbDevice
+		if err := rows.StructScan(&dbd); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		devices = append(devices, toDevice(dbd))
+	}
+
+	return devices, nil
+}
+
+type dbCampaign struct {
+	ID               string             `db:"id"`
+	DomainID         string             `db:"domain_id"`
+	Name             string             `db:"name"`
+	FirmwareID       string             `db:"firmware_id"`
+	Target           []byte             `db:"target"`
+	Stages           []byte             `db:"stages"`
+	Stage            int                `db:"stage"`
+	FailureThreshold uint8              `db:"failure_threshold"`
+	Status           ota.CampaignStatus `db:"status"`
+	Reason           sql.NullString     `db:"reason"`
+	CreatedBy        sql.NullString     `db:"created_by"`
+	CreatedAt        time.Time          `db:"created_at"`
+	StartedAt        sql.NullTime       `db:"started_at"`
+	UpdatedAt        sql.NullTime       `db:"updated_at"`
+	Revision         uint64             `db:"revision"`
+	Total            uint64             `db:"total"`
+	Pending          uint64             `db:"pending"`
+	Notified         uint64             `db:"notified"`
+	Downloading      uint64             `db:"downloading"`
+	Installing       uint64             `db:"installing"`
+	Succeeded        uint64             `db:"succeeded"`
+	Failed           uint64             `db:"failed"`
+}
+
+func toDBCampaign(c ota.Campaign) (dbCampaign, error) {
+	target, err := json.Marshal(c.Target)
+	if err != nil {
+		return dbCampaign{}, err
+	}
+	// Stages are encoded as numbers, since byte slices are encoded as
+	// base64 strings.
+	stages := make([]int, len(c.Stages))
+	for i, s := range c.Stages {
+		stages[i] = int(s)
+	}
+	stagesJSON, err := json.Marshal(stages)
+	if err != nil {
+		return dbCampaign{}, err
+	}
+
+	return dbCampaign{
+		ID:               c.ID,
+		DomainID:         c.DomainID,
+		Name:             c.Name,
+		FirmwareID:       c.FirmwareID,
+		Target:           target,
+		Stages:           stagesJSON,
+		Stage:            c.Stage,
+		FailureThreshold: c.FailureThreshold,
+		Status:           c.Status,
+		Reason:           nullString(c.Reason),
+		CreatedBy:        nullString(c.CreatedBy),
+		CreatedAt:        c.CreatedAt.UTC(),
+		StartedAt:        nullTime(c.StartedAt),
+		UpdatedAt:        nullTime(c.UpdatedAt),
+		Revision:         c.Revision,
+	}, nil
+}
+
+func toCampaign(dbc dbCampaign) (ota.Campaign, error) {
+	var target ota.Target
+	if err := json.Unmarshal(dbc.Target, &target); err != nil {
+		return ota.Campaign{}, err
+	}
+	var stages []int
+	if err := json.Unmarshal(dbc.Stages, &stages); err != nil {
+		return ota.Campaign{}, err
+	}
+	c := ota.Campaign{
+		ID:               dbc.ID,
+		DomainID:         dbc.DomainID,
+		Name:             dbc.Name,
+		FirmwareID:       dbc.FirmwareID,
+		Target:           target,
+		Stages:           make([]uint8, len(stages)),
+		Stage:            dbc.Stage,
+		FailureThreshold: dbc.FailureThreshold,
+		Status:           dbc.Status,
+		Reason:           dbc.Reason.String,
+		Stats: ota.Stats{
+			Total:       dbc.Total,
+			Pending:     dbc.Pending,
+			Notified:    dbc.Notified,
+			Downloading: dbc.Downloading,
+			Installing:  dbc.Installing,
+			Succeeded:   dbc.Succeeded,
+			Failed:      dbc.Failed,
+		},
+		CreatedBy: dbc.CreatedBy.String,
+		CreatedAt: dbc.CreatedAt,
+		StartedAt: dbc.StartedAt.Time,
+		UpdatedAt: dbc.UpdatedAt.Time,
+		Revision:  dbc.Revision,
+	}
+	for i, s := range stages {
+		c.Stages[i] = uint8(s)
+	}
+
+	return c, nil
+}
+
+type dbDevice struct {
+	CampaignID string           `db:"campaign_id"`
+	ThingID    string           `db:"thing_id"`
+	ChannelID  string           `db:"channel_id"`
+	Status     ota.DeviceStatus `db:"status"`
+	Progress   uint8            `db:"progress"`
+	Error      sql.NullString   `db:"error"`
+	NotifiedAt sql.NullTime     `db:"notified_at"`
+	UpdatedAt  sql.NullTime     `db:"updated_at"`
+}
+
+func toDBDevice(d ota.Device) dbDevice {
+	return dbDevice{
+		CampaignID: d.CampaignID,
+		ThingID:    d.ThingID,
+		ChannelID:  d.ChannelID,
+		Status:     d.Status,
+		Progress:   d.Progress,
+		Error:      nullString(d.Error),
+		NotifiedAt: nullTime(d.NotifiedAt),
+		UpdatedAt:  nullTime(d.UpdatedAt),
+	}
+}
+
+func toDevice(dbd dbDevice) ota.Device {
+	return ota.Device{
+		CampaignID: dbd.CampaignID,
+		ThingID:    dbd.ThingID,
+		ChannelID:  dbd.ChannelID,
+		Status:     dbd.Status,
+		Progress:   dbd.Progress,
+		Error:      dbd.Error.String,
+		NotifiedAt: dbd.NotifiedAt.Time,
+		UpdatedAt:  dbd.UpdatedAt.Time,
+	}
+}
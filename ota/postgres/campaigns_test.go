@@ -0,0 +1,659 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/ota"
+	opostgres "github.com/absmach/magistrala/ota/postgres"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCampaign(t *testing.T, fw ota.Firmware, createdAt time.Time) ota.Campaign {
+	return ota.Campaign{
+		ID:               testsutil.GenerateUUID(t),
+		DomainID:         fw.DomainID,
+		Name:             "rollout",
+		FirmwareID:       fw.ID,
+		Target:           ota.Target{Tag: "sensors", Metadata: map[string]interface{}{"model": "s1"}},
+		Stages:           []uint8{10, 50, 100},
+		FailureThreshold: 20,
+		Status:           ota.DraftStatus,
+		CreatedBy:        testsutil.GenerateUUID(t),
+		CreatedAt:        createdAt,
+	}
+}
+
+// saveCampaign saves the campaign of the new firmware.
+func saveCampaign(t *testing.T, repo ota.CampaignRepository, c ota.Campaign) ota.Campaign {
+	fw := newFirmware(t, c.DomainID, testsutil.GenerateUUID(t), "1.0.0", c.CreatedAt)
+	_, err := opostgres.NewFirmwareRepository(database).Save(context.Background(), fw)
+	require.Nil(t, err, fmt.Sprintf("save firmware unexpected error: %s", err))
+	c.FirmwareID = fw.ID
+
+	saved, err := repo.Save(context.Background(), c)
+	require.Nil(t, err, fmt.Sprintf("save campaign unexpected error: %s", err))
+
+	return saved
+}
+
+func TestSaveCampaign(t *testing.T) {
+	cleanup(t)
+	repo := opostgres.NewCampaignRepository(database)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	fw := newFirmware(t, testsutil.GenerateUUID(t), "sensor", "1.0.0", now)
+	_, err := opostgres.NewFirmwareRepository(database).Save(context.Background(), fw)
+	require.Nil(t, err, fmt.Sprintf("save firmware unexpected error: %s", err))
+
+	c := newCampaign(t, fw, now)
+	withoutFirmware := newCampaign(t, fw, now)
+	withoutFirmware.FirmwareID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc     string
+		campaign ota.Campaign
+		err      error
+	}{
+		{
+			desc:     "save new campaign",
+			campaign: c,
+		},
+		{
+			desc:     "save campaign with existing ID",
+			campaign: c,
+			err:      repoerr.ErrConflict,
+		},
+		{
+			desc:     "save campaign of non-existing firmware",
+			campaign: withoutFirmware,
+			err:      repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			saved, err := repo.Save(context.Background(), tc.campaign)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.campaign, saved, fmt.Sprintf("%s: expected campaign %v got %v", tc.desc, tc.campaign, saved))
+			}
+		})
+	}
+}
+
+func TestRetrieveCampaignByID(t *testing.T) {
+	cleanup(t)
+	repo := opostgres.NewCampaignRepository(database)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	c := saveCampaign(t, repo, newCampaign(t, ota.Firmware{DomainID: testsutil.GenerateUUID(t)}, now))
+
+	statuses := []ota.DeviceStatus{ota.PendingStatus, ota.PendingStatus, ota.NotifiedStatus, ota.DownloadingStatus, ota.SucceededStatus, ota.FailedStatus}
+	var devices []ota.Device
+	for _, s := range statuses {
+		devices = append(devices, ota.Device{
+			CampaignID: c.ID,
+			ThingID:    testsutil.GenerateUUID(t),
+			ChannelID:  testsutil.GenerateUUID(t),
+			Status:     s,
+		})
+	}
+	err := repo.SaveDevices(context.Background(), devices...)
+	require.Nil(t, err, fmt.Sprintf("save devices unexpected error: %s", err))
+
+	c.Stats = ota.Stats{Total: 6, Pending: 2, Notified: 1, Downloading: 1, Succeeded: 1, Failed: 1}
+
+	cases := []struct {
+		desc     string
+		id       string
+		campaign ota.Campaign
+		err      error
+	}{
+		{
+			desc:     "retrieve campaign with devices stats",
+			id:       c.ID,
+			campaign: c,
+		},
+		{
+			desc: "retrieve non-existing campaign",
+			id:   testsutil.GenerateUUID(t),
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := repo.RetrieveByID(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.campaign, got, fmt.Sprintf("%s: expected campaign %v got %v", tc.desc, tc.campaign, got))
+			}
+		})
+	}
+}
+
+func TestRetrieveAllCampaigns(t *testing.T) {
+	cleanup(t)
+	repo := opostgres.NewCampaignRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	num := 10
+
+	var items []ota.Campaign
+	for i := 0; i < num; i++ {
+		c := newCampaign(t, ota.Firmware{DomainID: domainID}, now.Add(time.Duration(i)*time.Second))
+		c.Name = fmt.Sprintf("rollout-%d", i)
+		if i%2 == 1 {
+			c.Status = ota.RunningStatus
+		}
+		items = append(items, saveCampaign(t, repo, c))
+	}
+	saveCampaign(t, repo, newCampaign(t, ota.Firmware{DomainID: testsutil.GenerateUUID(t)}, now))
+
+	cases := []struct {
+		desc  string
+		pm    ota.PageMetadata
+		total uint64
+		first ota.Campaign
+		size  int
+	}{
+		{
+			desc:  "retrieve all campaigns of the domain",
+			pm:    ota.PageMetadata{DomainID: domainID, Limit: 100, Status: ota.AllCampaignStatus},
+			total: uint64(num),
+			first: items[num-1],
+			size:  num,
+		},
+		{
+			desc:  "retrieve campaigns with offset and limit",
+			pm:    ota.PageMetadata{DomainID: domainID, Offset: 3, Limit: 2, Status: ota.AllCampaignStatus},
+			total: uint64(num),
+			first: items[num-4],
+			size:  2,
+		},
+		{
+			desc:  "retrieve campaigns by status",
+			pm:    ota.PageMetadata{DomainID: domainID, Limit: 100, Status: ota.DraftStatus},
+			total: uint64(num / 2),
+			first: items[num-2],
+			size:  num / 2,
+		},
+		{
+			desc:  "retrieve campaigns by name",
+			pm:    ota.PageMetadata{DomainID: domainID, Name: "rollout-3", Limit: 100, Status: ota.AllCampaignStatus},
+			total: 1,
+			first: items[3],
+			size:  1,
+		},
+		{
+			desc:  "retrieve campaigns by firmware",
+			pm:    ota.PageMetadata{DomainID: domainID, FirmwareID: items[5].FirmwareID, Limit: 100, Status: ota.AllCampaignStatus},
+			total: 1,
+			first: items[5],
+			size:  1,
+		},
+		{
+			desc: "retrieve campaigns of domain without campaigns",
+			pm:   ota.PageMetadata{DomainID: testsutil.GenerateUUID(t), Limit: 100, Status: ota.AllCampaignStatus},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RetrieveAll(context.Background(), tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+			assert.Len(t, page.Campaigns, tc.size, fmt.Sprintf("%s: expected %d campaigns got %d", tc.desc, tc.size, len(page.Campaigns)))
+			if tc.size > 0 {
+				assert.Equal(t, tc.first, page.Campaigns[0], fmt.Sprintf("%s: expected latest campaign %v got %v", tc.desc, tc.first, page.Campaigns[0]))
+			}
+		})
+	}
+}
+
+func TestUpdateCampaign(t *testing.T) {
+	cleanup(t)
+	repo := opostgres.NewCampaignRepository(database)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	c := saveCampaign(t, repo, newCampaign(t, ota.Firmware{DomainID: testsutil.GenerateUUID(t)}, now))
+
+	started := c
+	started.Status = ota.RunningStatus
+	started.StartedAt = now.Add(time.Second)
+	started.UpdatedAt = now.Add(time.Second)
+
+	paused := c
+	paused.Status = ota.PausedStatus
+	paused.Reason = "failure threshold reached"
+	paused.UpdatedAt = now.Add(2 * time.Second)
+
+	cases := []struct {
+		desc     string
+		campaign ota.Campaign
+		revision uint64
+		err      error
+	}{
+		{
+			desc:     "start campaign",
+			campaign: started,
+			revision: 1,
+		},
+		{
+			desc:     "pause campaign with stale revision",
+			campaign: paused,
+			err:      repoerr.ErrConflict,
+		},
+		{
+			desc: "update non-existing campaign",
+			campaign: ota.Campaign{
+				ID:     testsutil.GenerateUUID(t),
+				Status: ota.RunningStatus,
+			},
+			err: repoerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := repo.Update(context.Background(), tc.campaign)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			if err == nil {
+				tc.campaign.Revision = tc.revision
+				assert.Equal(t, tc.campaign, got, fmt.Sprintf("%s: expected campaign %v got %v", tc.desc, tc.campaign, got))
+			}
+		})
+	}
+}
+
+func TestSaveDevices(t *testing.T) {
+	cleanup(t)
+	repo := opostgres.NewCampaignRepository(database)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	c := saveCampaign(t, repo, newCampaign(t, ota.Firmware{DomainID: testsutil.GenerateUUID(t)}, now))
+
+	var devices []ota.Device
+	for i := 0; i < 5; i++ {
+		devices = append(devices, ota.Device{
+			CampaignID: c.ID,
+			ThingID:    testsutil.GenerateUUID(t),
+			ChannelID:  testsutil.GenerateUUID(t),
+		})
+	}
+
+	cases := []struct {
+		desc    string
+		devices []ota.Device
+		total   uint64
+		err     error
+	}{
+		{
+			desc:    "save devices",
+			devices: devices[:3],
+			total:   3,
+		},
+		{
+			desc:    "save partially saved devices",
+			devices: devices,
+			total:   5,
+		},
+		{
+			desc: "save devices of non-existing campaign",
+			devices: []ota.Device{{
+				CampaignID: testsutil.GenerateUUID(t),
+				ThingID:    testsutil.GenerateUUID(t),
+				ChannelID:  testsutil.GenerateUUID(t),
+			}},
+			total: 5,
+			err:   repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.SaveDevices(context.Background(), tc.devices...)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			page, err := repo.RetrieveDevices(context.Background(), ota.PageMetadata{CampaignID: c.ID, Limit: 100, DeviceStatus: ota.AllDeviceStatus})
+			require.Nil(t, err, fmt.Sprintf("%s: retrieve devices unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+		})
+	}
+}
+
+func TestRetrieveDevices(t *testing.T) {
+	cleanup(t)
+	repo := opostgres.NewCampaignRepository(database)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	c := saveCampaign(t, repo, newCampaign(t, ota.Firmware{DomainID: testsutil.GenerateUUID(t)}, now))
+
+	num := 10
+	for i := 0; i < num; i++ {
+		d := ota.Device{
+			CampaignID: c.ID,
+			ThingID:    testsutil.GenerateUUID(t),
+			ChannelID:  testsutil.GenerateUUID(t),
+		}
+		if i%2 == 1 {
+			d.Status = ota.FailedStatus
+			d.Error = "checksum mismatch"
+			d.NotifiedAt = now
+			d.UpdatedAt = now
+		}
+		err := repo.SaveDevices(context.Background(), d)
+		require.Nil(t, err, fmt.Sprintf("save device unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc  string
+		pm    ota.PageMetadata
+		total uint64
+		size  int
+	}{
+		{
+			desc:  "retrieve all devices",
+			pm:    ota.PageMetadata{CampaignID: c.ID, Limit: 100, DeviceStatus: ota.AllDeviceStatus},
+			total: uint64(num),
+			size:  num,
+		},
+		{
+			desc:  "retrieve devices with offset and limit",
+			pm:    ota.PageMetadata{CampaignID: c.ID, Offset: 8, Limit: 5, DeviceStatus: ota.AllDeviceStatus},
+			total: uint64(num),
+			size:  2,
+		},
+		{
+			desc:  "retrieve failed devices",
+			pm:    ota.PageMetadata{CampaignID: c.ID, Limit: 100, DeviceStatus: ota.FailedStatus},
+			total: uint64(num / 2),
+			size:  num / 2,
+		},
+		{
+			desc: "retrieve devices of non-existing campaign",
+			pm:   ota.PageMetadata{CampaignID: testsutil.GenerateUUID(t), Limit: 100, DeviceStatus: ota.AllDeviceStatus},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RetrieveDevices(context.Background(), tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+			assert.Len(t, page.Devices, tc.size, fmt.Sprintf("%s: expected %d devices got %d", tc.desc, tc.size, len(page.Devices)))
+			for i, d := range page.Devices {
+				if i > 0 {
+					assert.Less(t, page.Devices[i-1].ThingID, d.ThingID, fmt.Sprintf("%s: expected devices ordered by thing", tc.desc))
+				}
+				if tc.pm.DeviceStatus == ota.FailedStatus {
+					assert.Equal(t, "checksum mismatch", d.Error, fmt.Sprintf("%s: expected device error", tc.desc))
+					assert.Equal(t, now, d.NotifiedAt, fmt.Sprintf("%s: expected device notification time", tc.desc))
+				}
+			}
+		})
+	}
+}
+
+func TestRetrievePending(t *testing.T) {
+	cleanup(t)
+	repo := opostgres.NewCampaignRepository(database)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	c := saveCampaign(t, repo, newCampaign(t, ota.Firmware{DomainID: testsutil.GenerateUUID(t)}, now))
+
+	statuses := []ota.DeviceStatus{ota.PendingStatus, ota.PendingStatus, ota.PendingStatus, ota.NotifiedStatus, ota.SucceededStatus}
+	for _, s := range statuses {
+		d := ota.Device{CampaignID: c.ID, ThingID: testsutil.GenerateUUID(t), ChannelID: testsutil.GenerateUUID(t), Status: s}
+		err := repo.SaveDevices(context.Background(), d)
+		require.Nil(t, err, fmt.Sprintf("save device unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc       string
+		campaignID string
+		limit      uint64
+		size       int
+	}{
+		{
+			desc:       "retrieve all pending devices",
+			campaignID: c.ID,
+			limit:      10,
+			size:       3,
+		},
+		{
+			desc:       "retrieve limited pending devices",
+			campaignID: c.ID,
+			limit:      2,
+			size:       2,
+		},
+		{
+			desc:       "retrieve pending devices of non-existing campaign",
+			campaignID: testsutil.GenerateUUID(t),
+			limit:      10,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			devices, err := repo.RetrievePending(context.Background(), tc.campaignID, tc.limit)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Len(t, devices, tc.size, fmt.Sprintf("%s: expected %d devices got %d", tc.desc, tc.size, len(devices)))
+			for _, d := range devices {
+				assert.Equal(t, ota.PendingStatus, d.Status, fmt.Sprintf("%s: expected pending device got %s", tc.desc, d.Status))
+			}
+		})
+	}
+}
+
+func TestClaimDevice(t *testing.T) {
+	cleanup(t)
+	repo := opostgres.NewCampaignRepository(database)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	c := newCampaign(t, ota.Firmware{DomainID: testsutil.GenerateUUID(t)}, now)
+	c = saveCampaign(t, repo, c)
+	c.Status = ota.RunningStatus
+	c, err := repo.Update(context.Background(), c)
+	require.Nil(t, err, fmt.Sprintf("start campaign unexpected error: %s", err))
+
+	draft := saveCampaign(t, repo, newCampaign(t, ota.Firmware{DomainID: c.DomainID}, now))
+
+	pending := ota.Device{CampaignID: c.ID, ThingID: testsutil.GenerateUUID(t), ChannelID: testsutil.GenerateUUID(t)}
+	other := ota.Device{CampaignID: c.ID, ThingID: testsutil.GenerateUUID(t), ChannelID: testsutil.GenerateUUID(t)}
+	notStarted := ota.Device{CampaignID: draft.ID, ThingID: testsutil.GenerateUUID(t), ChannelID: testsutil.GenerateUUID(t)}
+	err = repo.SaveDevices(context.Background(), pending, other, notStarted)
+	require.Nil(t, err, fmt.Sprintf("save devices unexpected error: %s", err))
+
+	notify := func(d ota.Device) ota.Device {
+		d.Status = ota.NotifiedStatus
+		d.NotifiedAt = now
+		d.UpdatedAt = now
+		return d
+	}
+
+	cases := []struct {
+		desc     string
+		revision uint64
+		device   ota.Device
+		err      error
+	}{
+		{
+			desc:     "claim pending device",
+			revision: c.Revision,
+			device:   notify(pending),
+		},
+		{
+			desc:     "claim claimed device",
+			revision: c.Revision,
+			device:   notify(pending),
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "claim device with stale campaign revision",
+			revision: c.Revision - 1,
+			device:   notify(other),
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "claim device of not started campaign",
+			revision: draft.Revision,
+			device:   notify(notStarted),
+			err:      repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := repo.ClaimDevice(context.Background(), tc.revision, tc.device)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.device, got, fmt.Sprintf("%s: expected device %v got %v", tc.desc, tc.device, got))
+			}
+		})
+	}
+}
+
+func TestReleaseDevice(t *testing.T) {
+	cleanup(t)
+	repo := opostgres.NewCampaignRepository(database)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	c := saveCampaign(t, repo, newCampaign(t, ota.Firmware{DomainID: testsutil.GenerateUUID(t)}, now))
+
+	notified := ota.Device{
+		CampaignID: c.ID,
+		ThingID:    testsutil.GenerateUUID(t),
+		ChannelID:  testsutil.GenerateUUID(t),
+		Status:     ota.NotifiedStatus,
+		NotifiedAt: now,
+		UpdatedAt:  now,
+	}
+	downloading := notified
+	downloading.ThingID = testsutil.GenerateUUID(t)
+	downloading.Status = ota.DownloadingStatus
+	err := repo.SaveDevices(context.Background(), notified, downloading)
+	require.Nil(t, err, fmt.Sprintf("save devices unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		device ota.Device
+		status ota.DeviceStatus
+	}{
+		{
+			desc:   "release notified device",
+			device: notified,
+			status: ota.PendingStatus,
+		},
+		{
+			desc: "release device with changed status",
+			device: ota.Device{
+				CampaignID: downloading.CampaignID,
+				ThingID:    downloading.ThingID,
+				Status:     ota.NotifiedStatus,
+				UpdatedAt:  now,
+			},
+			status: ota.DownloadingStatus,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.ReleaseDevice(context.Background(), tc.device)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			d, err := repo.RetrieveDevice(context.Background(), tc.device.CampaignID, tc.device.ThingID)
+			require.Nil(t, err, fmt.Sprintf("%s: retrieve device unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.status, d.Status, fmt.Sprintf("%s: expected status %s got %s", tc.desc, tc.status, d.Status))
+			if tc.status == ota.PendingStatus {
+				assert.True(t, d.NotifiedAt.IsZero(), fmt.Sprintf("%s: expected notification time to be reset", tc.desc))
+			}
+		})
+	}
+}
+
+func TestUpdateDevice(t *testing.T) {
+	cleanup(t)
+	repo := opostgres.NewCampaignRepository(database)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	c := saveCampaign(t, repo, newCampaign(t, ota.Firmware{DomainID: testsutil.GenerateUUID(t)}, now))
+
+	d := ota.Device{
+		CampaignID: c.ID,
+		ThingID:    testsutil.GenerateUUID(t),
+		ChannelID:  testsutil.GenerateUUID(t),
+		Status:     ota.NotifiedStatus,
+		NotifiedAt: now,
+		UpdatedAt:  now,
+	}
+	err := repo.SaveDevices(context.Background(), d)
+	require.Nil(t, err, fmt.Sprintf("save device unexpected error: %s", err))
+
+	update := func(status ota.DeviceStatus, progress uint8, msg string, at time.Time) ota.Device {
+		u := d
+		u.Status = status
+		u.Progress = progress
+		u.Error = msg
+		u.UpdatedAt = at
+		return u
+	}
+
+	cases := []struct {
+		desc   string
+		device ota.Device
+		err    error
+	}{
+		{
+			desc:   "report download progress",
+			device: update(ota.DownloadingStatus, 40, "", now.Add(time.Second)),
+		},
+		{
+			desc:   "report installation",
+			device: update(ota.InstallingStatus, 10, "", now.Add(2*time.Second)),
+		},
+		{
+			desc:   "report stale download progress",
+			device: update(ota.DownloadingStatus, 80, "", now.Add(3*time.Second)),
+			err:    repoerr.ErrNotFound,
+		},
+		{
+			desc:   "report failure",
+			device: update(ota.FailedStatus, 10, "invalid signature", now.Add(4*time.Second)),
+		},
+		{
+			desc:   "report progress of completed device",
+			device: update(ota.FailedStatus, 10, "invalid signature", now.Add(5*time.Second)),
+			err:    repoerr.ErrNotFound,
+		},
+		{
+			desc: "report progress of non-existing device",
+			device: ota.Device{
+				CampaignID: c.ID,
+				ThingID:    testsutil.GenerateUUID(t),
+				Status:     ota.DownloadingStatus,
+				UpdatedAt:  now,
+			},
+			err: repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := repo.UpdateDevice(context.Background(), tc.device)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.device, got, fmt.Sprintf("%s: expected device %v got %v", tc.desc, tc.device, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/ota"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+)
+
+const firmwareColumns = `id, domain_id, name, version, size, checksum, signature, created_by, created_at`
+
+var _ ota.FirmwareRepository = (*firmwareRepository)(nil)
+
+type firmwareRepository struct {
+	db postgres.Database
+}
+
+// NewFirmwareRepository instantiates a PostgreSQL implementation of firmware
+// repository.
+func NewFirmwareRepository(db postgres.Database) ota.FirmwareRepository {
+	return &firmwareRepository{db: db}
+}
+
+func (repo *firmwareRepository) Save(ctx context.Context, fw ota.Firmware) (ota.Firmware, error) {
+	q := fmt.Sprintf(`INSERT INTO firmware (%s)
+		VALUES (:id, :domain_id, :name, :version, :size, :checksum, :signature, :created_by, :created_at)
+		RETURNING %s;`, firmwareColumns, firmwareColumns)
+
+	return repo.namedQueryRow(ctx, q, toDBFirmware(fw), repoerr.ErrCreateEntity)
+}
+
+func (repo *firmwareRepository) RetrieveByID(ctx context.Context, id string) (ota.Firmware, error) {
+	q := fmt.Sprintf(`SELECT %s FROM firmware WHERE id = :id;`, firmwareColumns)
+
+	return repo.namedQueryRow(ctx, q, dbFirmware{ID: id}, repoerr.ErrViewEntity)
+}
+
+func (repo *firmwareRepository) RetrieveAll(ctx context.Context, pm ota.PageMetadata) (ota.FirmwarePage, error) {
+	query := []string{"domain_id = :domain_id"}
+	if pm.Name != "" {
+		query = append(query, "name = :name")
+	}
+	where := fmt.Sprintf("WHERE %s", strings.Join(query, " AND "))
+	q := fmt.Sprintf(`SELECT %s FROM firmware %s ORDER BY created_at DESC LIMIT :limit OFFSET :offset;`, firmwareColumns, where)
+
+	params := map[string]interface{}{
+		"domain_id": pm.DomainID,
+		"name":      pm.Name,
+		"limit":     pm.Limit,
+		"offset":    pm.Offset,
+	}
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return ota.FirmwarePage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var items []ota.Firmware
+	for rows.Next() {
+		var dbf dbFirmware
+		if err := rows.StructScan(&dbf); err != nil {
+			return ota.FirmwarePage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		items = append(items, toFirmware(dbf))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM firmware %s;`, where)
+	total, err := postgres.Total(ctx, repo.db, cq, params)
+	if err != nil {
+		return ota.FirmwarePage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return ota.FirmwarePage{
+		PageMetadata: pm,
+		Total:        total,
+		Firmware:     items,
+	}, nil
+}
+
+func (repo *firmwareRepository) Remove(ctx context.Context, id string) error {
+	q := `DELETE FROM firmware WHERE id = :id;`
+
+	res, err := repo.db.NamedExecContext(ctx, q, dbFirmware{ID: id})
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *firmwareRepository) namedQueryRow(ctx context.Context, q string, params interface{}, wrapper error) (ota.Firmware, error) {
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return ota.Firmware{}, postgres.HandleError(wrapper, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return ota.Firmware{}, errors.Wrap(repoerr.ErrNotFound, sql.ErrNoRows)
+	}
+	var dbf dbFirmware
+	if err := rows.StructScan(&dbf); err != nil {
+		return ota.Firmware{}, postgres.HandleError(wrapper, err)
+	}
+
+	return toFirmware(dbf), nil
+}
+
+type dbFirmware struct {
+	ID        string         `db:"id"`
+	DomainID  string         `db:"domain_id"`
+	Name      string         `db:"name"`
+	Version   string         `db:"version"`
+	Size      int64          `db:"size"`
+	Checksum  string         `db:"checksum"`
+	Signature sql.NullString `db:"signature"`
+	CreatedBy sql.NullString `db:"created_by"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+func toDBFirmware(fw ota.Firmware) dbFirmware {
+	return dbFirmware{
+		ID:        fw.ID,
+		DomainID:  fw.DomainID,
+		Name:      fw.Name,
+		Version:   fw.Version,
+		Size:      fw.Size,
+		Checksum:  fw.Checksum,
+		Signature: nullString(fw.Signature),
+		CreatedBy: nullString(fw.CreatedBy),
+		CreatedAt: fw.CreatedAt.UTC(),
+	}
+}
+
+func toFirmware(dbf dbFirmware) ota.Firmware {
+	return ota.Firmware{
+		ID:        dbf.ID,
+		DomainID:  dbf.DomainID,
+		Name:      dbf.Name,
+		Version:   dbf.Version,
+		Size:      dbf.Size,
+		Checksum:  dbf.Checksum,
+		Signature: dbf.Signature.String,
+		CreatedBy: dbf.CreatedBy.String,
+		CreatedAt: dbf.CreatedAt,
+	}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t.UTC(), Valid: !t.IsZero()}
+}
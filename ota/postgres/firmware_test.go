@@ -0,0 +1,255 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/ota"
+	opostgres "github.com/absmach/magistrala/ota/postgres"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cleanup(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM campaign_devices")
+		require.Nil(t, err, fmt.Sprintf("clean campaign devices unexpected error: %s", err))
+		_, err = db.Exec("DELETE FROM campaigns")
+		require.Nil(t, err, fmt.Sprintf("clean campaigns unexpected error: %s", err))
+		_, err = db.Exec("DELETE FROM firmware")
+		require.Nil(t, err, fmt.Sprintf("clean firmware unexpected error: %s", err))
+	})
+}
+
+func newFirmware(t *testing.T, domainID, name, version string, createdAt time.Time) ota.Firmware {
+	return ota.Firmware{
+		ID:        testsutil.GenerateUUID(t),
+		DomainID:  domainID,
+		Name:      name,
+		Version:   version,
+		Size:      1024,
+		Checksum:  "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		CreatedBy: testsutil.GenerateUUID(t),
+		CreatedAt: createdAt,
+	}
+}
+
+func TestSaveFirmware(t *testing.T) {
+	cleanup(t)
+	repo := opostgres.NewFirmwareRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	fw := newFirmware(t, domainID, "sensor", "1.0.0", now)
+	fw.Signature = "c2lnbmF0dXJl"
+
+	sameVersion := newFirmware(t, domainID, "sensor", "1.0.0", now)
+	otherDomain := newFirmware(t, testsutil.GenerateUUID(t), "sensor", "1.0.0", now)
+	duplicateID := newFirmware(t, domainID, "gateway", "1.0.0", now)
+	duplicateID.ID = fw.ID
+
+	cases := []struct {
+		desc string
+		fw   ota.Firmware
+		err  error
+	}{
+		{
+			desc: "save new firmware",
+			fw:   fw,
+		},
+		{
+			desc: "save firmware with existing ID",
+			fw:   duplicateID,
+			err:  repoerr.ErrConflict,
+		},
+		{
+			desc: "save existing firmware version",
+			fw:   sameVersion,
+			err:  repoerr.ErrConflict,
+		},
+		{
+			desc: "save firmware version of other domain",
+			fw:   otherDomain,
+		},
+		{
+			desc: "save firmware with invalid size",
+			fw: ota.Firmware{
+				ID:        testsutil.GenerateUUID(t),
+				DomainID:  domainID,
+				Name:      "sensor",
+				Version:   "2.0.0",
+				Checksum:  fw.Checksum,
+				CreatedAt: now,
+			},
+			err: repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			saved, err := repo.Save(context.Background(), tc.fw)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.fw, saved, fmt.Sprintf("%s: expected firmware %v got %v", tc.desc, tc.fw, saved))
+			}
+		})
+	}
+}
+
+func TestRetrieveFirmwareByID(t *testing.T) {
+	cleanup(t)
+	repo := opostgres.NewFirmwareRepository(database)
+
+	fw := newFirmware(t, testsutil.GenerateUUID(t), "sensor", "1.0.0", time.Now().UTC().Truncate(time.Microsecond))
+	_, err := repo.Save(context.Background(), fw)
+	require.Nil(t, err, fmt.Sprintf("save firmware unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "retrieve existing firmware",
+			id:   fw.ID,
+		},
+		{
+			desc: "retrieve non-existing firmware",
+			id:   testsutil.GenerateUUID(t),
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := repo.RetrieveByID(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, fw, got, fmt.Sprintf("%s: expected firmware %v got %v", tc.desc, fw, got))
+			}
+		})
+	}
+}
+
+func TestRetrieveAllFirmware(t *testing.T) {
+	cleanup(t)
+	repo := opostgres.NewFirmwareRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	num := 10
+
+	var items []ota.Firmware
+	for i := 0; i < num; i++ {
+		name := "sensor"
+		if i%2 == 1 {
+			name = "gateway"
+		}
+		fw := newFirmware(t, domainID, name, fmt.Sprintf("1.0.%d", i), now.Add(time.Duration(i)*time.Second))
+		_, err := repo.Save(context.Background(), fw)
+		require.Nil(t, err, fmt.Sprintf("save firmware unexpected error: %s", err))
+		items = append(items, fw)
+	}
+	_, err := repo.Save(context.Background(), newFirmware(t, testsutil.GenerateUUID(t), "sensor", "1.0.0", now))
+	require.Nil(t, err, fmt.Sprintf("save firmware unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		pm    ota.PageMetadata
+		total uint64
+		first ota.Firmware
+		size  int
+	}{
+		{
+			desc:  "retrieve all firmware of the domain",
+			pm:    ota.PageMetadata{DomainID: domainID, Limit: 100},
+			total: uint64(num),
+			first: items[num-1],
+			size:  num,
+		},
+		{
+			desc:  "retrieve firmware with offset and limit",
+			pm:    ota.PageMetadata{DomainID: domainID, Offset: 2, Limit: 3},
+			total: uint64(num),
+			first: items[num-3],
+			size:  3,
+		},
+		{
+			desc:  "retrieve firmware by name",
+			pm:    ota.PageMetadata{DomainID: domainID, Name: "gateway", Limit: 100},
+			total: uint64(num / 2),
+			first: items[num-1],
+			size:  num / 2,
+		},
+		{
+			desc: "retrieve firmware of domain without firmware",
+			pm:   ota.PageMetadata{DomainID: testsutil.GenerateUUID(t), Limit: 100},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RetrieveAll(context.Background(), tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+			assert.Len(t, page.Firmware, tc.size, fmt.Sprintf("%s: expected %d firmware got %d", tc.desc, tc.size, len(page.Firmware)))
+			if tc.size > 0 {
+				assert.Equal(t, tc.first, page.Firmware[0], fmt.Sprintf("%s: expected latest firmware %v got %v", tc.desc, tc.first, page.Firmware[0]))
+			}
+		})
+	}
+}
+
+func TestRemoveFirmware(t *testing.T) {
+	cleanup(t)
+	repo := opostgres.NewFirmwareRepository(database)
+	campaigns := opostgres.NewCampaignRepository(database)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	fw := newFirmware(t, testsutil.GenerateUUID(t), "sensor", "1.0.0", now)
+	_, err := repo.Save(context.Background(), fw)
+	require.Nil(t, err, fmt.Sprintf("save firmware unexpected error: %s", err))
+
+	used := newFirmware(t, fw.DomainID, "sensor", "2.0.0", now)
+	_, err = repo.Save(context.Background(), used)
+	require.Nil(t, err, fmt.Sprintf("save firmware unexpected error: %s", err))
+	_, err = campaigns.Save(context.Background(), newCampaign(t, used, now))
+	require.Nil(t, err, fmt.Sprintf("save campaign unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "remove existing firmware",
+			id:   fw.ID,
+		},
+		{
+			desc: "remove removed firmware",
+			id:   fw.ID,
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.Remove(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+		})
+	}
+
+	// Campaigns reference the firmware, so it can not be removed.
+	err = repo.Remove(context.Background(), used.ID)
+	assert.NotNil(t, err, "remove firmware used by campaign: expected error")
+	_, err = repo.RetrieveByID(context.Background(), used.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve firmware used by campaign unexpected error: %s", err))
+}
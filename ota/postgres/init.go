@@ -0,0 +1,78 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Migration of OTA service.
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "ota_01",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS firmware (
+						id			VARCHAR(36) PRIMARY KEY,
+						domain_id	VARCHAR(36) NOT NULL,
+						name		VARCHAR(1024) NOT NULL,
+						version		VARCHAR(254) NOT NULL,
+						size		BIGINT NOT NULL CHECK (size > 0),
+						checksum	VARCHAR(64) NOT NULL,
+						signature	TEXT,
+						created_by	VARCHAR(254),
+						created_at	TIMESTAMP NOT NULL,
+						UNIQUE		(domain_id, name, version)
+					)`,
+					`CREATE TABLE IF NOT EXISTS campaigns (
+						id					VARCHAR(36) PRIMARY KEY,
+						domain_id			VARCHAR(36) NOT NULL,
+						name				VARCHAR(1024) NOT NULL,
+						firmware_id			VARCHAR(36) NOT NULL REFERENCES firmware (id) ON DELETE RESTRICT,
+						target				JSONB NOT NULL,
+						stages				JSONB NOT NULL,
+						stage				SMALLINT NOT NULL DEFAULT 0 CHECK (stage >= 0),
+						failure_threshold	SMALLINT NOT NULL DEFAULT 0 CHECK (failure_threshold BETWEEN 0 AND 100),
+						status				SMALLINT NOT NULL DEFAULT 0 CHECK (status >= 0),
+						reason				TEXT,
+						created_by			VARCHAR(254),
+						created_at			TIMESTAMP NOT NULL,
+						started_at			TIMESTAMP,
+						updated_at			TIMESTAMP
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_campaigns_domain ON campaigns(domain_id, created_at)`,
+					`CREATE INDEX IF NOT EXISTS idx_campaigns_firmware ON campaigns(firmware_id)`,
+					`CREATE TABLE IF NOT EXISTS campaign_devices (
+						campaign_id	VARCHAR(36) NOT NULL REFERENCES campaigns (id) ON DELETE CASCADE,
+						thing_id	VARCHAR(36) NOT NULL,
+						channel_id	VARCHAR(36) NOT NULL,
+						status		SMALLINT NOT NULL DEFAULT 0 CHECK (status >= 0),
+						progress	SMALLINT NOT NULL DEFAULT 0 CHECK (progress BETWEEN 0 AND 100),
+						error		TEXT,
+						notified_at	TIMESTAMP,
+						updated_at	TIMESTAMP,
+						PRIMARY KEY (campaign_id, thing_id)
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_campaign_devices_status ON campaign_devices(campaign_id, status)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS campaign_devices`,
+					`DROP TABLE IF EXISTS campaigns`,
+					`DROP TABLE IF EXISTS firmware`,
+				},
+			},
+			{
+				Id: "ota_02",
+				Up: []string{
+					`ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 0`,
+				},
+				Down: []string{
+					`ALTER TABLE campaigns DROP COLUMN IF EXISTS revision`,
+				},
+			},
+		},
+	}
+}
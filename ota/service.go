@@ -0,0 +1,741 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package ota
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/policies"
+	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
+)
+
+const (
+	// pageLimit is the page size used to list the campaign target things.
+	pageLimit = 100
+
+	// updateAttempts is the number of attempts to update the campaign which
+	// is concurrently updated by other requests or service instances.
+	updateAttempts = 5
+)
+
+var (
+	// ErrStorage indicates a failure to access the firmware storage.
+	ErrStorage = errors.New("failed to access firmware storage")
+
+	// ErrChecksumMismatch indicates that the uploaded firmware does not
+	// match the provided checksum.
+	ErrChecksumMismatch = errors.New("firmware checksum mismatch")
+
+	// ErrFirmwareInUse indicates that the firmware is rolled out by
+	// campaigns.
+	ErrFirmwareInUse = errors.New("firmware is used by campaigns")
+
+	// ErrStatusTransition indicates that the campaign or device can not be
+	// moved to the requested status.
+	ErrStatusTransition = errors.New("invalid status transition")
+
+	// ErrInvalidProgress indicates a malformed progress report.
+	ErrInvalidProgress = errors.New("invalid progress report")
+
+	// ErrTargets indicates a failure to retrieve the campaign target things.
+	ErrTargets = errors.New("failed to retrieve campaign target things")
+
+	// ErrNotify indicates a failure to publish the update notification.
+	ErrNotify = errors.New("failed to notify thing")
+)
+
+// Service specifies an API for storing firmware and rolling it out to things.
+//
+//go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
+type Service interface {
+	// UploadFirmware stores the firmware content and its checksum. Upload
+	// fails if the checksum is provided and the content does not match it.
+	UploadFirmware(ctx context.Context, session mgauthn.Session, fw Firmware, content io.Reader) (Firmware, error)
+
+	// ViewFirmware retrieves the firmware having the provided identifier.
+	ViewFirmware(ctx context.Context, session mgauthn.Session, id string) (Firmware, error)
+
+	// ListFirmware retrieves firmware of the session domain.
+	ListFirmware(ctx context.Context, session mgauthn.Session, pm PageMetadata) (FirmwarePage, error)
+
+	// DownloadFirmware retrieves the firmware and the reader of its content.
+	DownloadFirmware(ctx context.Context, session mgauthn.Session, id string) (Firmware, io.ReadCloser, error)
+
+	// RemoveFirmware removes the firmware which is not used by campaigns.
+	RemoveFirmware(ctx context.Context, session mgauthn.Session, id string) error
+
+	// CreateCampaign creates the campaign in the draft status.
+	CreateCampaign(ctx context.Context, session mgauthn.Session, c Campaign) (Campaign, error)
+
+	// ViewCampaign retrieves the campaign having the provided identifier.
+	ViewCampaign(ctx context.Context, session mgauthn.Session, id string) (Campaign, error)
+
+	// ListCampaigns retrieves campaigns of the session domain.
+	ListCampaigns(ctx context.Context, session mgauthn.Session, pm PageMetadata) (CampaignsPage, error)
+
+	// StartCampaign resolves the campaign target things visible to the
+	// user and notifies the things of the first rollout stage.
+	StartCampaign(ctx context.Context, session mgauthn.Session, token, id string) (Campaign, error)
+
+	// PauseCampaign stops notifying the campaign target things.
+	PauseCampaign(ctx context.Context, session mgauthn.Session, id string) (Campaign, error)
+
+	// ResumeCampaign continues the rollout of the paused campaign.
+	ResumeCampaign(ctx context.Context, session mgauthn.Session, id string) (Campaign, error)
+
+	// CancelCampaign cancels the campaign which is not completed.
+	CancelCampaign(ctx context.Context, session mgauthn.Session, id string) (Campaign, error)
+
+	// ListDevices retrieves the campaign target things and their progress.
+	ListDevices(ctx context.Context, session mgauthn.Session, campaignID string, pm PageMetadata) (DevicesPage, error)
+
+	// FetchFirmware retrieves the campaign firmware for the target thing
+	// authenticated by its key.
+	FetchFirmware(ctx context.Context, key, campaignID, thingID string) (Firmware, io.ReadCloser, error)
+
+	// HandleProgress updates the progress of the thing which published the
+	// progress report and continues the campaign rollout.
+	HandleProgress(ctx context.Context, msg *messaging.Message) error
+}
+
+var _ Service = (*service)(nil)
+
+type service struct {
+	idProvider  magistrala.IDProvider
+	firmware    FirmwareRepository
+	campaigns   CampaignRepository
+	storage     Storage
+	verifier    Verifier
+	publisher   messaging.Publisher
+	sdk         mgsdk.SDK
+	things      magistrala.ThingsServiceClient
+	downloadURL string
+}
+
+// New instantiates the OTA service implementation. Download URL is the base
+// URL of the service API things download the firmware from. If the verifier
+// is nil, signed firmware is rejected, since the signature can not be verified.
+func New(idp magistrala.IDProvider, firmware FirmwareRepository, campaigns CampaignRepository, storage Storage, verifier Verifier, publisher messaging.Publisher, sdk mgsdk.SDK, things magistrala.ThingsServiceClient, downloadURL string) Service {
+	return &service{
+		idProvider:  idp,
+		firmware:    firmware,
+		campaigns:   campaigns,
+		storage:     storage,
+		verifier:    verifier,
+		publisher:   publisher,
+		sdk:         sdk,
+		things:      things,
+		downloadURL: strings.TrimSuffix(downloadURL, "/"),
+	}
+}
+
+func (svc *service) UploadFirmware(ctx context.Context, session mgauthn.Session, fw Firmware, content io.Reader) (Firmware, error) {
+	if fw.Name == "" || fw.Version == "" || fw.Size <= 0 {
+		return Firmware{}, svcerr.ErrMalformedEntity
+	}
+	// Things rely on the signature, so it is never forwarded unverified.
+	switch {
+	case svc.verifier == nil && fw.Signature != "":
+		return Firmware{}, errors.Wrap(svcerr.ErrMalformedEntity, ErrSignatureNotVerified)
+	case svc.verifier != nil && fw.Signature == "":
+		return Firmware{}, errors.Wrap(svcerr.ErrMalformedEntity, ErrInvalidSignature)
+	}
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return Firmware{}, err
+	}
+	fw.ID = id
+	fw.DomainID = session.DomainID
+	fw.CreatedBy = session.UserID
+	fw.CreatedAt = time.Now()
+
+	hash := sha256.New()
+	if err := svc.storage.Save(ctx, fw.Key(), io.TeeReader(content, hash), fw.Size); err != nil {
+		return Firmware{}, errors.Wrap(ErrStorage, err)
+	}
+	digest := hash.Sum(nil)
+	checksum := hex.EncodeToString(digest)
+	if fw.Checksum != "" && !strings.EqualFold(fw.Checksum, checksum) {
+		_ = svc.storage.Remove(ctx, fw.Key())
+		return Firmware{}, errors.Wrap(svcerr.ErrMalformedEntity, ErrChecksumMismatch)
+	}
+	if svc.verifier != nil {
+		if err := svc.verifier.Verify(digest, fw.Signature); err != nil {
+			_ = svc.storage.Remove(ctx, fw.Key())
+			return Firmware{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+	}
+	fw.Checksum = checksum
+
+	saved, err := svc.firmware.Save(ctx, fw)
+	if err != nil {
+		_ = svc.storage.Remove(ctx, fw.Key())
+		// Firmware name and version are unique within the domain.
+		if errors.Contains(err, repoerr.ErrConflict) {
+			return Firmware{}, errors.Wrap(svcerr.ErrConflict, err)
+		}
+		return Firmware{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return saved, nil
+}
+
+func (svc *service) ViewFirmware(ctx context.Context, session mgauthn.Session, id string) (Firmware, error) {
+	fw, err := svc.firmware.RetrieveByID(ctx, id)
+	if err != nil {
+		return Firmware{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if fw.DomainID != session.DomainID {
+		return Firmware{}, svcerr.ErrNotFound
+	}
+
+	return fw, nil
+}
+
+func (svc *service) ListFirmware(ctx context.Context, session mgauthn.Session, pm PageMetadata) (FirmwarePage, error) {
+	pm.DomainID = session.DomainID
+	page, err := svc.firmware.RetrieveAll(ctx, pm)
+	if err != nil {
+		return FirmwarePage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (svc *service) DownloadFirmware(ctx context.Context, session mgauthn.Session, id string) (Firmware, io.ReadCloser, error) {
+	fw, err := svc.ViewFirmware(ctx, session, id)
+	if err != nil {
+		return Firmware{}, nil, err
+	}
+	content, err := svc.storage.Open(ctx, fw.Key())
+	if err != nil {
+		return Firmware{}, nil, errors.Wrap(ErrStorage, err)
+	}
+
+	return fw, content, nil
+}
+
+func (svc *service) RemoveFirmware(ctx context.Context, session mgauthn.Session, id string) error {
+	fw, err := svc.ViewFirmware(ctx, session, id)
+	if err != nil {
+		return err
+	}
+	pm := PageMetadata{DomainID: fw.DomainID, FirmwareID: fw.ID, Status: AllCampaignStatus}
+	page, err := svc.campaigns.RetrieveAll(ctx, pm)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if page.Total > 0 {
+		return errors.Wrap(svcerr.ErrConflict, ErrFirmwareInUse)
+	}
+
+	if err := svc.firmware.Remove(ctx, fw.ID); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+	if err := svc.storage.Remove(ctx, fw.Key()); err != nil {
+		return errors.Wrap(ErrStorage, err)
+	}
+
+	return nil
+}
+
+func (svc *service) CreateCampaign(ctx context.Context, session mgauthn.Session, c Campaign) (Campaign, error) {
+	if len(c.Stages) == 0 {
+		c.Stages = []uint8{100}
+	}
+	if c.Name == "" || c.Target.Empty() || !ValidStages(c.Stages) || c.FailureThreshold > 100 {
+		return Campaign{}, svcerr.ErrMalformedEntity
+	}
+	if _, err := svc.ViewFirmware(ctx, session, c.FirmwareID); err != nil {
+		return Campaign{}, err
+	}
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return Campaign{}, err
+	}
+	c.ID = id
+	c.DomainID = session.DomainID
+	c.Stage = 0
+	c.Status = DraftStatus
+	c.CreatedBy = session.UserID
+	c.CreatedAt = time.Now()
+
+	saved, err := svc.campaigns.Save(ctx, c)
+	if err != nil {
+		return Campaign{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return saved, nil
+}
+
+func (svc *service) ViewCampaign(ctx context.Context, session mgauthn.Session, id string) (Campaign, error) {
+	c, err := svc.campaigns.RetrieveByID(ctx, id)
+	if err != nil {
+		return Campaign{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if c.DomainID != session.DomainID {
+		return Campaign{}, svcerr.ErrNotFound
+	}
+
+	return c, nil
+}
+
+func (svc *service) ListCampaigns(ctx context.Context, session mgauthn.Session, pm PageMetadata) (CampaignsPage, error) {
+	pm.DomainID = session.DomainID
+	page, err := svc.campaigns.RetrieveAll(ctx, pm)
+	if err != nil {
+		return CampaignsPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (svc *service) StartCampaign(ctx context.Context, session mgauthn.Session, token, id string) (Campaign, error) {
+	c, err := svc.ViewCampaign(ctx, session, id)
+	if err != nil {
+		return Campaign{}, err
+	}
+	if c.Status != DraftStatus {
+		return Campaign{}, errors.Wrap(svcerr.ErrConflict, ErrStatusTransition)
+	}
+
+	devices, err := svc.targets(ctx, c, token)
+	if err != nil {
+		return Campaign{}, errors.Wrap(ErrTargets, err)
+	}
+	if err := svc.campaigns.SaveDevices(ctx, devices...); err != nil {
+		return Campaign{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	c, err = svc.changeStatus(ctx, session, id, RunningStatus, DraftStatus)
+	if err != nil {
+		return Campaign{}, err
+	}
+
+	return svc.rollout(ctx, c.ID)
+}
+
+func (svc *service) PauseCampaign(ctx context.Context, session mgauthn.Session, id string) (Campaign, error) {
+	return svc.changeStatus(ctx, session, id, PausedStatus, RunningStatus)
+}
+
+func (svc *service) ResumeCampaign(ctx context.Context, session mgauthn.Session, id string) (Campaign, error) {
+	c, err := svc.changeStatus(ctx, session, id, RunningStatus, PausedStatus)
+	if err != nil {
+		return Campaign{}, err
+	}
+
+	return svc.rollout(ctx, c.ID)
+}
+
+func (svc *service) CancelCampaign(ctx context.Context, session mgauthn.Session, id string) (Campaign, error) {
+	return svc.changeStatus(ctx, session, id, CancelledStatus, DraftStatus, RunningStatus, PausedStatus)
+}
+
+func (svc *service) ListDevices(ctx context.Context, session mgauthn.Session, campaignID string, pm PageMetadata) (DevicesPage, error) {
+	if _, err := svc.ViewCampaign(ctx, session, campaignID); err != nil {
+		return DevicesPage{}, err
+	}
+	pm.CampaignID = campaignID
+	page, err := svc.campaigns.RetrieveDevices(ctx, pm)
+	if err != nil {
+		return DevicesPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (svc *service) FetchFirmware(ctx context.Context, key, campaignID, thingID string) (Firmware, io.ReadCloser, error) {
+	if key == "" {
+		return Firmware{}, nil, svcerr.ErrAuthentication
+	}
+	// Missing devices are reported as unauthorized, so the campaign target
+	// things are not disclosed.
+	d, err := svc.campaigns.RetrieveDevice(ctx, campaignID, thingID)
+	if err != nil {
+		return Firmware{}, nil, errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	res, err := svc.things.Authorize(ctx, &magistrala.ThingsAuthzReq{
+		ChannelId:  d.ChannelID,
+		ThingKey:   key,
+		Permission: policies.SubscribePermission,
+	})
+	if err != nil {
+		return Firmware{}, nil, errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	if !res.GetAuthorized() || res.GetId() != thingID {
+		return Firmware{}, nil, svcerr.ErrAuthorization
+	}
+
+	c, err := svc.campaigns.RetrieveByID(ctx, campaignID)
+	if err != nil {
+		return Firmware{}, nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	fw, err := svc.firmware.RetrieveByID(ctx, c.FirmwareID)
+	if err != nil {
+		return Firmware{}, nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	content, err := svc.storage.Open(ctx, fw.Key())
+	if err != nil {
+		return Firmware{}, nil, errors.Wrap(ErrStorage, err)
+	}
+
+	return fw, content, nil
+}
+
+func (svc *service) HandleProgress(ctx context.Context, msg *messaging.Message) error {
+	var p Progress
+	if err := json.Unmarshal(msg.GetPayload(), &p); err != nil {
+		return errors.Wrap(ErrInvalidProgress, err)
+	}
+	if p.CampaignID == "" || p.Progress > 100 {
+		return ErrInvalidProgress
+	}
+
+	d, err := svc.campaigns.RetrieveDevice(ctx, p.CampaignID, msg.GetPublisher())
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	// Only the notified thing reports its progress over the channel it is
+	// notified on.
+	if d.ChannelID != msg.GetChannel() {
+		return svcerr.ErrAuthorization
+	}
+	if !transition(d.Status, p.Status) {
+		return ErrStatusTransition
+	}
+
+	d.Status = p.Status
+	d.Progress = p.Progress
+	if d.Status == SucceededStatus {
+		d.Progress = 100
+	}
+	d.Error = p.Error
+	d.UpdatedAt = time.Now()
+	if _, err := svc.campaigns.UpdateDevice(ctx, d); err != nil {
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	if !d.Status.Final() {
+		return nil
+	}
+
+	c, err := svc.campaigns.RetrieveByID(ctx, p.CampaignID)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if d.Status == FailedStatus && thresholdReached(c) {
+		return svc.pause(ctx, c.ID)
+	}
+	_, err = svc.rollout(ctx, c.ID)
+
+	return err
+}
+
+// changeStatus moves the campaign from one of the provided statuses to the
+// new status. The change is retried if the campaign is updated concurrently,
+// such as by the rollout moving it to the next stage.
+func (svc *service) changeStatus(ctx context.Context, session mgauthn.Session, id string, status CampaignStatus, from ...CampaignStatus) (Campaign, error) {
+	for i := 0; ; i++ {
+		c, err := svc.ViewCampaign(ctx, session, id)
+		if err != nil {
+			return Campaign{}, err
+		}
+		allowed := false
+		for _, s := range from {
+			allowed = allowed || c.Status == s
+		}
+		if !allowed {
+			return Campaign{}, errors.Wrap(svcerr.ErrConflict, ErrStatusTransition)
+		}
+
+		now := time.Now()
+		if c.Status == DraftStatus && status == RunningStatus {
+			c.StartedAt = now
+		}
+		c.Status = status
+		c.Reason = ""
+		c.UpdatedAt = now
+
+		c, err = svc.update(ctx, c)
+		if errors.Contains(err, svcerr.ErrConflict) && i < updateAttempts-1 {
+			continue
+		}
+
+		return c, err
+	}
+}
+
+// pause pauses the running campaign which reached the failure threshold.
+func (svc *service) pause(ctx context.Context, id string) error {
+	for i := 0; ; i++ {
+		c, err := svc.campaigns.RetrieveByID(ctx, id)
+		if err != nil {
+			return errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		if c.Status != RunningStatus {
+			return nil
+		}
+		c.Status = PausedStatus
+		c.Reason = fmt.Sprintf("failure threshold of %d%% reached: %d of %d notified things failed", c.FailureThreshold, c.Stats.Failed, c.Stats.Reached())
+		c.UpdatedAt = time.Now()
+
+		_, err = svc.update(ctx, c)
+		if errors.Contains(err, svcerr.ErrConflict) && i < updateAttempts-1 {
+			continue
+		}
+
+		return err
+	}
+}
+
+// rollout notifies the things of the current stage of the running campaign
+// and moves the campaign to the next stage once all the notified things
+// complete the update. Campaign is completed once all the target things
+// complete the update. Rollouts can run concurrently: each thing is claimed
+// before it is notified, and the stage is moved only by the rollout which
+// updates the campaign revision it retrieved.
+func (svc *service) rollout(ctx context.Context, id string) (Campaign, error) {
+	var fw Firmware
+	for {
+		// Stats are reloaded, since things report progress concurrently.
+		c, err := svc.campaigns.RetrieveByID(ctx, id)
+		if err != nil {
+			return Campaign{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		if c.Status != RunningStatus {
+			return c, nil
+		}
+
+		target := stageTarget(c.Stages[c.Stage], c.Stats.Total)
+		if reached := c.Stats.Reached(); reached < target {
+			if fw.ID == "" {
+				if fw, err = svc.firmware.RetrieveByID(ctx, c.FirmwareID); err != nil {
+					return Campaign{}, errors.Wrap(svcerr.ErrViewEntity, err)
+				}
+			}
+			devices, err := svc.campaigns.RetrievePending(ctx, c.ID, target-reached)
+			if err != nil {
+				return Campaign{}, errors.Wrap(svcerr.ErrViewEntity, err)
+			}
+			for _, d := range devices {
+				if err := svc.notify(ctx, c, fw, d); err != nil {
+					return Campaign{}, err
+				}
+			}
+			return svc.campaigns.RetrieveByID(ctx, c.ID)
+		}
+		if c.Stats.Done() < target {
+			return c, nil
+		}
+
+		switch c.Stage {
+		case len(c.Stages) - 1:
+			c.Status = CompletedStatus
+		default:
+			c.Stage++
+		}
+		c.UpdatedAt = time.Now()
+		// Campaign updated by another rollout or status change is reloaded.
+		if _, err := svc.update(ctx, c); err != nil && !errors.Contains(err, svcerr.ErrConflict) {
+			return Campaign{}, err
+		}
+	}
+}
+
+func (svc *service) update(ctx context.Context, c Campaign) (Campaign, error) {
+	updated, err := svc.campaigns.Update(ctx, c)
+	if err != nil {
+		if errors.Contains(err, repoerr.ErrConflict) {
+			return Campaign{}, errors.Wrap(svcerr.ErrConflict, err)
+		}
+		return Campaign{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return updated, nil
+}
+
+// notify claims the thing, so it is notified only once, and publishes the
+// update notification to it. Things claimed by other rollouts are skipped.
+func (svc *service) notify(ctx context.Context, c Campaign, fw Firmware, d Device) error {
+	payload, err := json.Marshal(Notification{
+		CampaignID: c.ID,
+		FirmwareID: fw.ID,
+		Name:       fw.Name,
+		Version:    fw.Version,
+		Size:       fw.Size,
+		Checksum:   fw.Checksum,
+		Signature:  fw.Signature,
+		URL:        fmt.Sprintf("%s/campaigns/%s/things/%s/firmware", svc.downloadURL, c.ID, d.ThingID),
+	})
+	if err != nil {
+		return errors.Wrap(ErrNotify, err)
+	}
+
+	now := time.Now()
+	d.Status = NotifiedStatus
+	d.NotifiedAt = now
+	d.UpdatedAt = now
+	_, err = svc.campaigns.ClaimDevice(ctx, c.Revision, d)
+	switch {
+	// The thing is claimed by another rollout, or the campaign is paused,
+	// cancelled or moved to the next stage since it was retrieved.
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return nil
+	case err != nil:
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	msg := &messaging.Message{
+		Channel:   d.ChannelID,
+		Subtopic:  Subtopic + "." + d.ThingID,
+		Publisher: c.CreatedBy,
+		Protocol:  Protocol,
+		Payload:   payload,
+		Created:   now.UnixNano(),
+	}
+	if err := svc.publisher.Publish(ctx, d.ChannelID, msg); err != nil {
+		// Thing is released, so it is notified by the next rollout.
+		if rerr := svc.campaigns.ReleaseDevice(ctx, d); rerr != nil {
+			err = errors.Wrap(err, rerr)
+		}
+		return errors.Wrap(ErrNotify, err)
+	}
+
+	return nil
+}
+
+// targets lists the things selected by the campaign target together with
+// the channel they are notified on. Connections of each page of things are
+// retrieved from the things service at once.
+func (svc *service) targets(ctx context.Context, c Campaign, token string) ([]Device, error) {
+	if c.Target.GroupID != "" {
+		return svc.groupTargets(c, token)
+	}
+
+	var devices []Device
+	pm := mgsdk.PageMetadata{
+		Limit:    pageLimit,
+		Tag:      c.Target.Tag,
+		Metadata: mgsdk.Metadata(c.Target.Metadata),
+	}
+	for {
+		page, sdkErr := svc.sdk.Things(pm, c.DomainID, token)
+		if sdkErr != nil {
+			return nil, sdkErr
+		}
+		ids := make([]string, len(page.Things))
+		for i, th := range page.Things {
+			ids[i] = th.ID
+		}
+		res, err := svc.things.ThingsConnections(ctx, &magistrala.ThingsConnectionsReq{DomainId: c.DomainID, ThingIds: ids})
+		if err != nil {
+			return nil, err
+		}
+		conns := make(map[string][]string, len(res.GetConnections()))
+		for _, conn := range res.GetConnections() {
+			conns[conn.GetThingId()] = conn.GetChannelIds()
+		}
+		for _, id := range ids {
+			// Things which are not connected to any channel can not be
+			// notified.
+			chids := conns[id]
+			if len(chids) == 0 {
+				continue
+			}
+			devices = append(devices, Device{CampaignID: c.ID, ThingID: id, ChannelID: chids[0]})
+		}
+		pm.Offset += pageLimit
+		if pm.Offset >= page.Total {
+			return devices, nil
+		}
+	}
+}
+
+// groupTargets lists the things connected to the channels of the group.
+func (svc *service) groupTargets(c Campaign, token string) ([]Device, error) {
+	var devices []Device
+	seen := make(map[string]struct{})
+	pm := mgsdk.PageMetadata{Limit: pageLimit}
+	for {
+		page, sdkErr := svc.sdk.ListGroupChannels(c.Target.GroupID, pm, c.DomainID, token)
+		if sdkErr != nil {
+			return nil, sdkErr
+		}
+		for _, ch := range page.Channels {
+			tpm := mgsdk.PageMetadata{Limit: pageLimit}
+			for {
+				things, sdkErr := svc.sdk.ThingsByChannel(ch.ID, tpm, c.DomainID, token)
+				if sdkErr != nil {
+					return nil, sdkErr
+				}
+				for _, th := range things.Things {
+					if _, ok := seen[th.ID]; ok {
+						continue
+					}
+					seen[th.ID] = struct{}{}
+					devices = append(devices, Device{CampaignID: c.ID, ThingID: th.ID, ChannelID: ch.ID})
+				}
+				tpm.Offset += pageLimit
+				if tpm.Offset >= things.Total {
+					break
+				}
+			}
+		}
+		pm.Offset += pageLimit
+		if pm.Offset >= page.Total {
+			return devices, nil
+		}
+	}
+}
+
+// ValidStages reports whether the stages are increasing percentages ending
+// with all the target things.
+func ValidStages(stages []uint8) bool {
+	var prev uint8
+	for _, s := range stages {
+		if s <= prev || s > 100 {
+			return false
+		}
+		prev = s
+	}
+
+	return prev == 100
+}
+
+// stageTarget returns the number of things notified once the stage with the
+// provided percentage is reached.
+func stageTarget(percentage uint8, total uint64) uint64 {
+	return (uint64(percentage)*total + 99) / 100
+}
+
+// thresholdReached reports whether the failed updates reached the failure
+// threshold of the campaign.
+func thresholdReached(c Campaign) bool {
+	return c.FailureThreshold > 0 && c.Stats.Failed > 0 &&
+		c.Stats.Failed*100 >= uint64(c.FailureThreshold)*c.Stats.Reached()
+}
+
+// transition reports whether the device status can be changed to the status
+// reported by the thing. Repeated status reports update the progress.
+func transition(current, next DeviceStatus) bool {
+	switch next {
+	case DownloadingStatus, InstallingStatus, SucceededStatus, FailedStatus:
+		return current != PendingStatus && !current.Final() && current <= next
+	default:
+		return false
+	}
+}
@@ -0,0 +1,624 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package ota_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/ota"
+	"github.com/absmach/magistrala/ota/mocks"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+	pubsubmocks "github.com/absmach/magistrala/pkg/messaging/mocks"
+	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
+	sdkmocks "github.com/absmach/magistrala/pkg/sdk/mocks"
+	"github.com/absmach/magistrala/pkg/uuid"
+	thmocks "github.com/absmach/magistrala/things/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	token       = "token"
+	content     = "firmware content"
+	downloadURL = "http://localhost:9029"
+)
+
+var (
+	domainID   = testsutil.GenerateUUID(&testing.T{})
+	userID     = testsutil.GenerateUUID(&testing.T{})
+	channelID  = testsutil.GenerateUUID(&testing.T{})
+	thingID    = testsutil.GenerateUUID(&testing.T{})
+	firmwareID = testsutil.GenerateUUID(&testing.T{})
+	campaignID = testsutil.GenerateUUID(&testing.T{})
+	session    = mgauthn.Session{DomainID: domainID, UserID: userID, DomainUserID: domainID + "_" + userID}
+	firmware   = ota.Firmware{ID: firmwareID, DomainID: domainID, Name: "sensor", Version: "1.0.0", Size: int64(len(content))}
+)
+
+type mockers struct {
+	firmware  *mocks.FirmwareRepository
+	campaigns *mocks.CampaignRepository
+	storage   *mocks.Storage
+	pub       *pubsubmocks.PubSub
+	sdk       *sdkmocks.SDK
+	things    *thmocks.ThingsServiceClient
+}
+
+func newService(verifier ota.Verifier) (ota.Service, mockers) {
+	m := mockers{
+		firmware:  new(mocks.FirmwareRepository),
+		campaigns: new(mocks.CampaignRepository),
+		storage:   new(mocks.Storage),
+		pub:       new(pubsubmocks.PubSub),
+		sdk:       new(sdkmocks.SDK),
+		things:    new(thmocks.ThingsServiceClient),
+	}
+	svc := ota.New(uuid.NewMock(), m.firmware, m.campaigns, m.storage, verifier, m.pub, m.sdk, m.things, downloadURL)
+
+	return svc, m
+}
+
+func checksum(s string) string {
+	sum := sha256.Sum256([]byte(s))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func TestUploadFirmware(t *testing.T) {
+	svc, m := newService(nil)
+
+	cases := []struct {
+		desc       string
+		fw         ota.Firmware
+		saveErr    error
+		repoErr    error
+		removeCall bool
+		err        error
+	}{
+		{
+			desc: "upload firmware",
+			fw:   ota.Firmware{Name: "sensor", Version: "1.0.0", Size: int64(len(content))},
+		},
+		{
+			desc: "upload firmware with expected checksum",
+			fw:   ota.Firmware{Name: "sensor", Version: "1.0.0", Size: int64(len(content)), Checksum: strings.ToUpper(checksum(content))},
+		},
+		{
+			desc:       "upload firmware with checksum mismatch",
+			fw:         ota.Firmware{Name: "sensor", Version: "1.0.0", Size: int64(len(content)), Checksum: checksum("other")},
+			removeCall: true,
+			err:        ota.ErrChecksumMismatch,
+		},
+		{
+			desc: "upload firmware without version",
+			fw:   ota.Firmware{Name: "sensor", Size: int64(len(content))},
+			err:  svcerr.ErrMalformedEntity,
+		},
+		{
+			desc: "upload signed firmware without signing key",
+			fw:   ota.Firmware{Name: "sensor", Version: "1.0.0", Size: int64(len(content)), Signature: "c2lnbmF0dXJl"},
+			err:  ota.ErrSignatureNotVerified,
+		},
+		{
+			desc:    "upload firmware with failed storage",
+			fw:      ota.Firmware{Name: "sensor", Version: "1.0.0", Size: int64(len(content))},
+			saveErr: errors.New("disk full"),
+			err:     ota.ErrStorage,
+		},
+		{
+			desc:       "upload existing firmware version",
+			fw:         ota.Firmware{Name: "sensor", Version: "1.0.0", Size: int64(len(content))},
+			repoErr:    repoerr.ErrConflict,
+			removeCall: true,
+			err:        svcerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			storageCall := m.storage.On("Save", context.Background(), mock.Anything, mock.Anything, tc.fw.Size).Run(func(args mock.Arguments) {
+				_, _ = io.ReadAll(args.Get(2).(io.Reader))
+			}).Return(tc.saveErr)
+			storageCall1 := m.storage.On("Remove", context.Background(), mock.Anything).Return(nil)
+			repoCall := m.firmware.On("Save", context.Background(), mock.Anything).Return(func(_ context.Context, fw ota.Firmware) ota.Firmware {
+				return fw
+			}, tc.repoErr)
+			fw, err := svc.UploadFirmware(context.Background(), session, tc.fw, strings.NewReader(content))
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.NotEmpty(t, fw.ID, fmt.Sprintf("%s: expected firmware ID to be set", tc.desc))
+				assert.Equal(t, domainID, fw.DomainID, fmt.Sprintf("%s: expected domain %s got %s\n", tc.desc, domainID, fw.DomainID))
+				assert.Equal(t, checksum(content), fw.Checksum, fmt.Sprintf("%s: expected checksum %s got %s\n", tc.desc, checksum(content), fw.Checksum))
+			}
+			if tc.removeCall {
+				m.storage.AssertCalled(t, "Remove", context.Background(), mock.Anything)
+			}
+			storageCall.Unset()
+			storageCall1.Unset()
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestUploadSignedFirmware(t *testing.T) {
+	digest := sha256.Sum256([]byte(content))
+	otherDigest := sha256.Sum256([]byte("other"))
+
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("generate ed25519 key unexpected error: %s", err))
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("generate ecdsa key unexpected error: %s", err))
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("generate rsa key unexpected error: %s", err))
+
+	keys := []struct {
+		name string
+		pub  crypto.PublicKey
+		sign func(digest []byte) []byte
+	}{
+		{
+			name: "ed25519",
+			pub:  edPub,
+			sign: func(digest []byte) []byte {
+				return ed25519.Sign(edKey, digest)
+			},
+		},
+		{
+			name: "ecdsa",
+			pub:  &ecKey.PublicKey,
+			sign: func(digest []byte) []byte {
+				sig, err := ecdsa.SignASN1(rand.Reader, ecKey, digest)
+				require.Nil(t, err, fmt.Sprintf("sign unexpected error: %s", err))
+				return sig
+			},
+		},
+		{
+			name: "rsa",
+			pub:  &rsaKey.PublicKey,
+			sign: func(digest []byte) []byte {
+				sig, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest)
+				require.Nil(t, err, fmt.Sprintf("sign unexpected error: %s", err))
+				return sig
+			},
+		},
+	}
+
+	for _, key := range keys {
+		der, err := x509.MarshalPKIXPublicKey(key.pub)
+		require.Nil(t, err, fmt.Sprintf("marshal public key unexpected error: %s", err))
+		verifier, err := ota.NewVerifier(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+		require.Nil(t, err, fmt.Sprintf("create verifier unexpected error: %s", err))
+		svc, m := newService(verifier)
+
+		cases := []struct {
+			desc       string
+			signature  string
+			removeCall bool
+			err        error
+		}{
+			{
+				desc:      "upload firmware with valid signature",
+				signature: base64.StdEncoding.EncodeToString(key.sign(digest[:])),
+			},
+			{
+				desc: "upload firmware without signature",
+				err:  ota.ErrInvalidSignature,
+			},
+			{
+				desc:       "upload firmware with signature of other content",
+				signature:  base64.StdEncoding.EncodeToString(key.sign(otherDigest[:])),
+				removeCall: true,
+				err:        ota.ErrInvalidSignature,
+			},
+			{
+				desc:       "upload firmware with malformed signature",
+				signature:  "invalid signature",
+				removeCall: true,
+				err:        ota.ErrInvalidSignature,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(fmt.Sprintf("%s %s", tc.desc, key.name), func(t *testing.T) {
+				fw := ota.Firmware{Name: "sensor", Version: "1.0.0", Size: int64(len(content)), Signature: tc.signature}
+				storageCall := m.storage.On("Save", context.Background(), mock.Anything, mock.Anything, fw.Size).Run(func(args mock.Arguments) {
+					_, _ = io.ReadAll(args.Get(2).(io.Reader))
+				}).Return(nil)
+				storageCall1 := m.storage.On("Remove", context.Background(), mock.Anything).Return(nil)
+				repoCall := m.firmware.On("Save", context.Background(), mock.Anything).Return(func(_ context.Context, fw ota.Firmware) ota.Firmware {
+					return fw
+				}, nil)
+				saved, err := svc.UploadFirmware(context.Background(), session, fw, strings.NewReader(content))
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+				if err == nil {
+					assert.Equal(t, tc.signature, saved.Signature, fmt.Sprintf("%s: expected signature %s got %s\n", tc.desc, tc.signature, saved.Signature))
+				}
+				if tc.err != nil {
+					assert.True(t, errors.Contains(err, svcerr.ErrMalformedEntity), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, svcerr.ErrMalformedEntity, err))
+				}
+				if tc.removeCall {
+					m.storage.AssertCalled(t, "Remove", context.Background(), mock.Anything)
+				}
+				storageCall.Unset()
+				storageCall1.Unset()
+				repoCall.Unset()
+			})
+		}
+	}
+}
+
+func TestNewVerifier(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("generate ecdsa key unexpected error: %s", err))
+	der, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	require.Nil(t, err, fmt.Sprintf("marshal public key unexpected error: %s", err))
+	privDER, err := x509.MarshalECPrivateKey(ecKey)
+	require.Nil(t, err, fmt.Sprintf("marshal private key unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		key  []byte
+		err  error
+	}{
+		{
+			desc: "create verifier with public key",
+			key:  pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+		},
+		{
+			desc: "create verifier with private key",
+			key:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privDER}),
+			err:  ota.ErrInvalidSigningKey,
+		},
+		{
+			desc: "create verifier with non-PEM key",
+			key:  der,
+			err:  ota.ErrInvalidSigningKey,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := ota.NewVerifier(tc.key)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestCreateCampaign(t *testing.T) {
+	svc, m := newService(nil)
+
+	target := ota.Target{Tag: "sensors"}
+	cases := []struct {
+		desc     string
+		campaign ota.Campaign
+		fw       ota.Firmware
+		stages   []uint8
+		err      error
+	}{
+		{
+			desc:     "create campaign with default stages",
+			campaign: ota.Campaign{Name: "rollout", FirmwareID: firmwareID, Target: target},
+			fw:       firmware,
+			stages:   []uint8{100},
+		},
+		{
+			desc:     "create staged campaign",
+			campaign: ota.Campaign{Name: "rollout", FirmwareID: firmwareID, Target: target, Stages: []uint8{10, 50, 100}, FailureThreshold: 20},
+			fw:       firmware,
+			stages:   []uint8{10, 50, 100},
+		},
+		{
+			desc:     "create campaign with decreasing stages",
+			campaign: ota.Campaign{Name: "rollout", FirmwareID: firmwareID, Target: target, Stages: []uint8{50, 10, 100}},
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "create campaign with incomplete stages",
+			campaign: ota.Campaign{Name: "rollout", FirmwareID: firmwareID, Target: target, Stages: []uint8{10, 50}},
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "create campaign with invalid failure threshold",
+			campaign: ota.Campaign{Name: "rollout", FirmwareID: firmwareID, Target: target, FailureThreshold: 101},
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "create campaign without target",
+			campaign: ota.Campaign{Name: "rollout", FirmwareID: firmwareID},
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "create campaign with firmware from other domain",
+			campaign: ota.Campaign{Name: "rollout", FirmwareID: firmwareID, Target: target},
+			fw:       ota.Firmware{ID: firmwareID, DomainID: testsutil.GenerateUUID(t)},
+			err:      svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := m.firmware.On("RetrieveByID", context.Background(), firmwareID).Return(tc.fw, nil)
+			repoCall1 := m.campaigns.On("Save", context.Background(), mock.Anything).Return(func(_ context.Context, c ota.Campaign) ota.Campaign {
+				return c
+			}, nil)
+			c, err := svc.CreateCampaign(context.Background(), session, tc.campaign)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, ota.DraftStatus, c.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, ota.DraftStatus, c.Status))
+				assert.Equal(t, tc.stages, c.Stages, fmt.Sprintf("%s: expected stages %v got %v\n", tc.desc, tc.stages, c.Stages))
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
+
+func TestStartCampaign(t *testing.T) {
+	draft := ota.Campaign{
+		ID:         campaignID,
+		DomainID:   domainID,
+		FirmwareID: firmwareID,
+		Target:     ota.Target{Tag: "sensors"},
+		Stages:     []uint8{50, 100},
+		Status:     ota.DraftStatus,
+		CreatedBy:  userID,
+	}
+	running := draft
+	running.Status = ota.RunningStatus
+	running.Stats = ota.Stats{Total: 4, Pending: 4}
+
+	var things []mgsdk.Thing
+	var ids []string
+	var pending []ota.Device
+	var conns []*magistrala.ThingConnections
+	for i := 0; i < 4; i++ {
+		id := testsutil.GenerateUUID(t)
+		things = append(things, mgsdk.Thing{ID: id})
+		ids = append(ids, id)
+		pending = append(pending, ota.Device{CampaignID: campaignID, ThingID: id, ChannelID: channelID})
+		conns = append(conns, &magistrala.ThingConnections{ThingId: id, ChannelIds: []string{channelID}})
+	}
+	// Things which are not connected to any channel are not targeted.
+	disconnected := testsutil.GenerateUUID(t)
+	things = append(things, mgsdk.Thing{ID: disconnected})
+	ids = append(ids, disconnected)
+	connsReq := &magistrala.ThingsConnectionsReq{DomainId: domainID, ThingIds: ids}
+
+	cases := []struct {
+		desc     string
+		campaign ota.Campaign
+		claimErr error
+		pubErr   error
+		notified int
+		released int
+		err      error
+	}{
+		{
+			desc:     "start campaign",
+			campaign: draft,
+			notified: 2,
+		},
+		{
+			desc:     "start campaign with things claimed by another rollout",
+			campaign: draft,
+			claimErr: repoerr.ErrNotFound,
+		},
+		{
+			desc:     "start campaign with failed notification",
+			campaign: draft,
+			pubErr:   errors.New("publish failed"),
+			notified: 1,
+			released: 1,
+			err:      ota.ErrNotify,
+		},
+		{
+			desc:     "start running campaign",
+			campaign: running,
+			err:      svcerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, m := newService(nil)
+			m.campaigns.On("RetrieveByID", context.Background(), campaignID).Return(tc.campaign, nil).Twice()
+			m.campaigns.On("RetrieveByID", context.Background(), campaignID).Return(running, nil)
+			m.campaigns.On("SaveDevices", context.Background(), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			m.campaigns.On("Update", context.Background(), mock.Anything).Return(running, nil)
+			m.campaigns.On("RetrievePending", context.Background(), campaignID, uint64(2)).Return(pending[:2], nil)
+			m.campaigns.On("ClaimDevice", context.Background(), running.Revision, mock.Anything).Return(ota.Device{}, tc.claimErr)
+			m.campaigns.On("ReleaseDevice", context.Background(), mock.Anything).Return(nil)
+			m.firmware.On("RetrieveByID", context.Background(), firmwareID).Return(firmware, nil)
+			m.sdk.On("Things", mock.Anything, domainID, token).Return(mgsdk.ThingsPage{Things: things, PageRes: mgsdk.PageRes{Total: uint64(len(things))}}, nil)
+			m.things.On("ThingsConnections", context.Background(), connsReq).Return(&magistrala.ThingsConnectionsRes{Connections: conns}, nil)
+			m.pub.On("Publish", context.Background(), channelID, mock.Anything).Return(tc.pubErr)
+			_, err := svc.StartCampaign(context.Background(), session, token, campaignID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.campaign.Status == ota.DraftStatus {
+				m.campaigns.AssertCalled(t, "SaveDevices", context.Background(), pending[0], pending[1], pending[2], pending[3])
+			}
+			m.pub.AssertNumberOfCalls(t, "Publish", tc.notified)
+			m.campaigns.AssertNumberOfCalls(t, "ReleaseDevice", tc.released)
+			if tc.notified > 0 && tc.pubErr == nil {
+				msg := m.pub.Calls[len(m.pub.Calls)-1].Arguments.Get(2).(*messaging.Message)
+				var n ota.Notification
+				assert.Nil(t, json.Unmarshal(msg.GetPayload(), &n), fmt.Sprintf("%s: unexpected error decoding notification", tc.desc))
+				assert.Equal(t, campaignID, n.CampaignID, fmt.Sprintf("%s: expected campaign %s got %s\n", tc.desc, campaignID, n.CampaignID))
+				assert.True(t, strings.HasPrefix(n.URL, downloadURL), fmt.Sprintf("%s: expected download URL got %s\n", tc.desc, n.URL))
+			}
+		})
+	}
+}
+
+func TestHandleProgress(t *testing.T) {
+	notified := ota.Device{CampaignID: campaignID, ThingID: thingID, ChannelID: channelID, Status: ota.NotifiedStatus}
+	campaign := ota.Campaign{
+		ID:               campaignID,
+		DomainID:         domainID,
+		FirmwareID:       firmwareID,
+		Stages:           []uint8{100},
+		FailureThreshold: 50,
+		Status:           ota.RunningStatus,
+	}
+	failed := campaign
+	failed.Stats = ota.Stats{Total: 2, Notified: 1, Failed: 1}
+	succeeded := campaign
+	succeeded.Stats = ota.Stats{Total: 2, Succeeded: 2}
+
+	cases := []struct {
+		desc     string
+		progress ota.Progress
+		channel  string
+		device   ota.Device
+		campaign ota.Campaign
+		status   ota.CampaignStatus
+		err      error
+	}{
+		{
+			desc:     "report download progress",
+			progress: ota.Progress{CampaignID: campaignID, Status: ota.DownloadingStatus, Progress: 40},
+			channel:  channelID,
+			device:   notified,
+		},
+		{
+			desc:     "report progress over other channel",
+			progress: ota.Progress{CampaignID: campaignID, Status: ota.DownloadingStatus, Progress: 40},
+			channel:  testsutil.GenerateUUID(t),
+			device:   notified,
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:     "report progress for not notified thing",
+			progress: ota.Progress{CampaignID: campaignID, Status: ota.InstallingStatus},
+			channel:  channelID,
+			device:   ota.Device{CampaignID: campaignID, ThingID: thingID, ChannelID: channelID},
+			err:      ota.ErrStatusTransition,
+		},
+		{
+			desc:     "report invalid progress",
+			progress: ota.Progress{CampaignID: campaignID, Status: ota.DownloadingStatus, Progress: 101},
+			channel:  channelID,
+			device:   notified,
+			err:      ota.ErrInvalidProgress,
+		},
+		{
+			desc:     "report failure reaching the threshold",
+			progress: ota.Progress{CampaignID: campaignID, Status: ota.FailedStatus, Error: "checksum mismatch"},
+			channel:  channelID,
+			device:   notified,
+			campaign: failed,
+			status:   ota.PausedStatus,
+		},
+		{
+			desc:     "report success of the last thing",
+			progress: ota.Progress{CampaignID: campaignID, Status: ota.SucceededStatus},
+			channel:  channelID,
+			device:   notified,
+			campaign: succeeded,
+			status:   ota.CompletedStatus,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, m := newService(nil)
+			payload, err := json.Marshal(tc.progress)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error encoding progress", tc.desc))
+			msg := &messaging.Message{Channel: tc.channel, Publisher: thingID, Subtopic: ota.ProgressSubtopic, Payload: payload}
+
+			m.campaigns.On("RetrieveDevice", context.Background(), campaignID, thingID).Return(tc.device, nil)
+			m.campaigns.On("UpdateDevice", context.Background(), mock.Anything).Return(ota.Device{}, nil)
+			m.campaigns.On("RetrieveByID", context.Background(), campaignID).Return(tc.campaign, nil).Twice()
+			m.campaigns.On("Update", context.Background(), mock.Anything).Return(func(_ context.Context, c ota.Campaign) ota.Campaign {
+				return c
+			}, nil)
+			m.campaigns.On("RetrieveByID", context.Background(), campaignID).Return(ota.Campaign{Status: tc.status}, nil)
+			err = svc.HandleProgress(context.Background(), msg)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.status != ota.DraftStatus {
+				var c ota.Campaign
+				for _, call := range m.campaigns.Calls {
+					if call.Method == "Update" {
+						c = call.Arguments.Get(1).(ota.Campaign)
+					}
+				}
+				assert.Equal(t, tc.status, c.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, c.Status))
+			}
+		})
+	}
+}
+
+func TestPauseCampaign(t *testing.T) {
+	running := ota.Campaign{ID: campaignID, DomainID: domainID, Stages: []uint8{100}, Status: ota.RunningStatus}
+	paused := running
+	paused.Status = ota.PausedStatus
+
+	cases := []struct {
+		desc      string
+		campaign  ota.Campaign
+		conflicts int
+		updates   int
+		err       error
+	}{
+		{
+			desc:     "pause running campaign",
+			campaign: running,
+			updates:  1,
+		},
+		{
+			desc:      "pause campaign updated concurrently",
+			campaign:  running,
+			conflicts: 2,
+			updates:   3,
+		},
+		{
+			desc:      "pause campaign updated concurrently too many times",
+			campaign:  running,
+			conflicts: 5,
+			updates:   5,
+			err:       svcerr.ErrConflict,
+		},
+		{
+			desc:     "pause paused campaign",
+			campaign: paused,
+			err:      ota.ErrStatusTransition,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, m := newService(nil)
+			m.campaigns.On("RetrieveByID", context.Background(), campaignID).Return(tc.campaign, nil)
+			if tc.conflicts > 0 {
+				m.campaigns.On("Update", context.Background(), mock.Anything).Return(ota.Campaign{}, repoerr.ErrConflict).Times(tc.conflicts)
+			}
+			m.campaigns.On("Update", context.Background(), mock.Anything).Return(paused, nil)
+			_, err := svc.PauseCampaign(context.Background(), session, campaignID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			m.campaigns.AssertNumberOfCalls(t, "Update", tc.updates)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package ota
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+var (
+	// ErrInvalidSignature indicates that the firmware signature is missing or
+	// does not match the firmware content.
+	ErrInvalidSignature = errors.New("invalid firmware signature")
+
+	// ErrSignatureNotVerified indicates that the firmware is signed, but the
+	// service is not configured with the key to verify the signature.
+	ErrSignatureNotVerified = errors.New("firmware signature can not be verified")
+
+	// ErrInvalidSigningKey indicates that the signing key can not be parsed.
+	ErrInvalidSigningKey = errors.New("invalid firmware signing public key")
+)
+
+// Verifier verifies firmware signatures.
+type Verifier interface {
+	// Verify verifies the base64 encoded signature of the SHA-256 digest of
+	// the firmware content.
+	Verify(digest []byte, signature string) error
+}
+
+var _ Verifier = (*verifier)(nil)
+
+type verifier struct {
+	key crypto.PublicKey
+}
+
+// NewVerifier instantiates the verifier of the signatures made with the
+// private key of the PEM encoded PKIX public key. ECDSA, Ed25519 and RSA
+// (PKCS #1 v1.5) keys are supported.
+func NewVerifier(pemKey []byte) (Verifier, error) {
+	block, _ := pem.Decode(pemKey)
+	if block == nil {
+		return nil, ErrInvalidSigningKey
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidSigningKey, err)
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey, *rsa.PublicKey:
+		return &verifier{key: key}, nil
+	default:
+		return nil, ErrInvalidSigningKey
+	}
+}
+
+func (v *verifier) Verify(digest []byte, signature string) error {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || len(sig) == 0 {
+		return ErrInvalidSignature
+	}
+	switch key := v.key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest, sig) {
+			return ErrInvalidSignature
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, digest, sig) {
+			return ErrInvalidSignature
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, sig); err != nil {
+			return ErrInvalidSignature
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package storage contains firmware storage implementations using the local
+// filesystem and S3-compatible object storage.
+package storage
@@ -0,0 +1,108 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/absmach/magistrala/ota"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+)
+
+// ErrInvalidKey indicates the key points outside of the storage.
+var ErrInvalidKey = errors.New("invalid storage key")
+
+var _ ota.Storage = (*fsStorage)(nil)
+
+type fsStorage struct {
+	root string
+}
+
+// NewFS instantiates the firmware storage keeping the content in files under
+// the root directory.
+func NewFS(root string) (ota.Storage, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &fsStorage{root: root}, nil
+}
+
+func (fs *fsStorage) Save(ctx context.Context, key string, content io.Reader, size int64) error {
+	path, err := fs.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	// Content is written to the temporary file first, so the partially
+	// written content is never served.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, content)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if n != size {
+		return io.ErrUnexpectedEOF
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (fs *fsStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := fs.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	switch {
+	case os.IsNotExist(err):
+		return nil, errors.Wrap(repoerr.ErrNotFound, err)
+	case err != nil:
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (fs *fsStorage) Remove(ctx context.Context, key string) error {
+	path, err := fs.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (fs *fsStorage) path(key string) (string, error) {
+	path := filepath.Join(fs.root, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, fs.root+string(filepath.Separator)) {
+		return "", ErrInvalidKey
+	}
+
+	return path, nil
+}
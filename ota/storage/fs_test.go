@@ -0,0 +1,83 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/ota/storage"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const content = "firmware content"
+
+func TestFSSave(t *testing.T) {
+	fs, err := storage.NewFS(t.TempDir())
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating storage: %s", err))
+
+	cases := []struct {
+		desc string
+		key  string
+		size int64
+		err  error
+	}{
+		{
+			desc: "save firmware",
+			key:  "domain/firmware",
+			size: int64(len(content)),
+		},
+		{
+			desc: "save firmware with wrong size",
+			key:  "domain/truncated",
+			size: int64(len(content)) + 1,
+			err:  io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "save firmware outside of storage",
+			key:  "../firmware",
+			size: int64(len(content)),
+			err:  storage.ErrInvalidKey,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := fs.Save(context.Background(), tc.key, strings.NewReader(content), tc.size)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			rc, err := fs.Open(context.Background(), tc.key)
+			if tc.err != nil {
+				assert.NotNil(t, err, fmt.Sprintf("%s: expected content not to be saved", tc.desc))
+				return
+			}
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error opening content: %s", tc.desc, err))
+			defer rc.Close()
+			data, err := io.ReadAll(rc)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error reading content: %s", tc.desc, err))
+			assert.Equal(t, content, string(data), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, content, data))
+		})
+	}
+}
+
+func TestFSRemove(t *testing.T) {
+	fs, err := storage.NewFS(t.TempDir())
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating storage: %s", err))
+
+	key := "domain/firmware"
+	err = fs.Save(context.Background(), key, strings.NewReader(content), int64(len(content)))
+	require.Nil(t, err, fmt.Sprintf("unexpected error saving content: %s", err))
+
+	err = fs.Remove(context.Background(), key)
+	assert.Nil(t, err, fmt.Sprintf("remove firmware: unexpected error %s", err))
+	_, err = fs.Open(context.Background(), key)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("open removed firmware: expected %s got %s\n", repoerr.ErrNotFound, err))
+	err = fs.Remove(context.Background(), key)
+	assert.Nil(t, err, fmt.Sprintf("remove missing firmware: unexpected error %s", err))
+}
@@ -0,0 +1,108 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/absmach/magistrala/ota/storage"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	bucket    = "firmware"
+	accessKey = "access"
+)
+
+// objectStore is a minimal in-memory S3-compatible object storage.
+type objectStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (s *objectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential="+accessKey+"/") || r.Header.Get("X-Amz-Date") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.objects[r.URL.EscapedPath()] = data
+	case http.MethodGet:
+		data, ok := s.objects[r.URL.EscapedPath()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	case http.MethodDelete:
+		if _, ok := s.objects[r.URL.EscapedPath()]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(s.objects, r.URL.EscapedPath())
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newS3(t *testing.T) (*objectStore, *httptest.Server) {
+	store := &objectStore{objects: make(map[string][]byte)}
+	server := httptest.NewServer(store)
+	t.Cleanup(server.Close)
+
+	return store, server
+}
+
+func TestS3(t *testing.T) {
+	store, server := newS3(t)
+	s3 := storage.NewS3(storage.S3Config{
+		Endpoint:  server.URL,
+		Region:    "us-east-1",
+		Bucket:    bucket,
+		AccessKey: accessKey,
+		SecretKey: "secret",
+	})
+
+	key := "domain/firmware v1"
+	err := s3.Save(context.Background(), key, strings.NewReader(content), int64(len(content)))
+	require.Nil(t, err, fmt.Sprintf("save firmware: unexpected error %s", err))
+	assert.Contains(t, store.objects, "/"+bucket+"/domain/firmware%20v1", "save firmware: expected object to be stored")
+
+	rc, err := s3.Open(context.Background(), key)
+	require.Nil(t, err, fmt.Sprintf("open firmware: unexpected error %s", err))
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	assert.Nil(t, err, fmt.Sprintf("read firmware: unexpected error %s", err))
+	assert.Equal(t, content, string(data), fmt.Sprintf("open firmware: expected %s got %s\n", content, data))
+
+	err = s3.Remove(context.Background(), key)
+	assert.Nil(t, err, fmt.Sprintf("remove firmware: unexpected error %s", err))
+	_, err = s3.Open(context.Background(), key)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("open removed firmware: expected %s got %s\n", repoerr.ErrNotFound, err))
+	err = s3.Remove(context.Background(), key)
+	assert.Nil(t, err, fmt.Sprintf("remove missing firmware: unexpected error %s", err))
+
+	unauthorized := storage.NewS3(storage.S3Config{Endpoint: server.URL, Bucket: bucket, AccessKey: "other"})
+	err = unauthorized.Save(context.Background(), key, strings.NewReader(content), int64(len(content)))
+	assert.True(t, errors.Contains(err, storage.ErrObjectStorage), fmt.Sprintf("save firmware with invalid credentials: expected %s got %s\n", storage.ErrObjectStorage, err))
+}
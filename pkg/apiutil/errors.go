@@ -206,4 +206,40 @@ var (
 
 	// ErrInvalidProfilePictureURL indicates that the profile picture url is invalid.
 	ErrInvalidProfilePictureURL = errors.New("invalid profile picture url")
+
+	// ErrInvalidTTL indicates invalid time to live value.
+	ErrInvalidTTL = errors.New("invalid time to live")
+
+	// ErrInvalidPatchOp indicates unsupported JSON patch operation.
+	ErrInvalidPatchOp = errors.New("invalid JSON patch operation")
+
+	// ErrMissingFile indicates missing file content.
+	ErrMissingFile = errors.New("missing file")
+
+	// ErrFileSize indicates that the file exceeds the maximum size.
+	ErrFileSize = errors.New("file exceeds the maximum size")
+
+	// ErrMissingVersion indicates missing version.
+	ErrMissingVersion = errors.New("missing version")
+
+	// ErrMissingTarget indicates missing campaign target.
+	ErrMissingTarget = errors.New("missing campaign target")
+
+	// ErrInvalidRolloutStages indicates invalid campaign rollout stages.
+	ErrInvalidRolloutStages = errors.New("rollout stages must be increasing percentages ending with 100")
+
+	// ErrInvalidFailureThreshold indicates invalid campaign failure threshold.
+	ErrInvalidFailureThreshold = errors.New("failure threshold must be a percentage")
+
+	// ErrInvalidRetention indicates invalid retention period.
+	ErrInvalidRetention = errors.New("invalid retention period")
+
+	// ErrInvalidExportFormat indicates unsupported export format.
+	ErrInvalidExportFormat = errors.New("invalid export format")
+
+	// ErrInvalidWebhookURL indicates invalid webhook endpoint URL.
+	ErrInvalidWebhookURL = errors.New("invalid webhook url")
+
+	// ErrMissingEvents indicates missing webhook events.
+	ErrMissingEvents = errors.New("missing webhook events")
 )
@@ -0,0 +1,199 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package authzcache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/go-kit/kit/metrics"
+	"google.golang.org/grpc"
+)
+
+const (
+	hit  = "hit"
+	miss = "miss"
+)
+
+// Cache is the things service client which caches the authorization decisions.
+//
+//go:generate mockery --name Cache --output=./mocks --filename cache.go --quiet --note "Copyright (c) Abstract Machines"
+type Cache interface {
+	magistrala.ThingsServiceClient
+
+	// RemoveThing removes the decisions made for the thing.
+	RemoveThing(thingID string)
+
+	// RemoveChannel removes the decisions made for the channel.
+	RemoveChannel(channelID string)
+
+	// RemoveConnection removes the decisions made for the thing on the channel.
+	RemoveConnection(thingID, channelID string)
+}
+
+type key struct {
+	thingID    string
+	thingKey   string
+	thingPSK   string
+	channelID  string
+	permission string
+}
+
+type entry struct {
+	thingID   string
+	expiresAt time.Time
+}
+
+var _ Cache = (*cache)(nil)
+
+type cache struct {
+	client    magistrala.ThingsServiceClient
+	ttl       time.Duration
+	counter   metrics.Counter
+	mu        sync.Mutex
+	entries   map[key]entry
+	lastSweep time.Time
+}
+
+// NewThingsClient returns the things service client which caches allowed
+// authorization decisions for the given TTL. Denied decisions and errors are
+// not cached, so granting access takes effect immediately. Counter is
+// incremented for each cache lookup with "result" label set to hit or miss.
+func NewThingsClient(client magistrala.ThingsServiceClient, ttl time.Duration, counter metrics.Counter) Cache {
+	return &cache{
+		client:    client,
+		ttl:       ttl,
+		counter:   counter,
+		entries:   make(map[key]entry),
+		lastSweep: time.Now(),
+	}
+}
+
+func (c *cache) Authorize(ctx context.Context, req *magistrala.ThingsAuthzReq, opts ...grpc.CallOption) (*magistrala.ThingsAuthzRes, error) {
+	k := key{
+		thingID:    req.GetThingId(),
+		thingKey:   req.GetThingKey(),
+		thingPSK:   string(req.GetThingPsk()),
+		channelID:  req.GetChannelId(),
+		permission: req.GetPermission(),
+	}
+	if thingID, ok := c.get(k); ok {
+		c.counter.With("result", hit).Add(1)
+		return &magistrala.ThingsAuthzRes{Authorized: true, Id: thingID}, nil
+	}
+	c.counter.With("result", miss).Add(1)
+
+	res, err := c.client.Authorize(ctx, req, opts...)
+	if err != nil {
+		return res, err
+	}
+	if res.GetAuthorized() {
+		c.set(k, res.GetId())
+	}
+
+	return res, nil
+}
+
+// DerivePSK is not cached, so the changed or revoked thing keys are not
+// accepted by the protocol adapters.
+func (c *cache) DerivePSK(ctx context.Context, req *magistrala.ThingsPSKReq, opts ...grpc.CallOption) (*magistrala.ThingsPSKRes, error) {
+	return c.client.DerivePSK(ctx, req, opts...)
+}
+
+// ConnectedChannels is not cached, since it is used only for the resource
+// discovery.
+func (c *cache) ConnectedChannels(ctx context.Context, req *magistrala.ThingsChannelsReq, opts ...grpc.CallOption) (*magistrala.ThingsChannelsRes, error) {
+	return c.client.ConnectedChannels(ctx, req, opts...)
+}
+
+// ChannelMetadata is not cached, since the protocol adapters cache the
+// channel schemas and retention themselves.
+func (c *cache) ChannelMetadata(ctx context.Context, req *magistrala.ChannelMetadataReq, opts ...grpc.CallOption) (*magistrala.ChannelMetadataRes, error) {
+	return c.client.ChannelMetadata(ctx, req, opts...)
+}
+
+// ThingsConnections is not cached, since it is used only by the services
+// resolving the things to notify.
+func (c *cache) ThingsConnections(ctx context.Context, req *magistrala.ThingsConnectionsReq, opts ...grpc.CallOption) (*magistrala.ThingsConnectionsRes, error) {
+	return c.client.ThingsConnections(ctx, req, opts...)
+}
+
+// CreateThing is not cached, since it changes the things.
+func (c *cache) CreateThing(ctx context.Context, req *magistrala.CreateThingReq, opts ...grpc.CallOption) (*magistrala.CreateThingRes, error) {
+	return c.client.CreateThing(ctx, req, opts...)
+}
+
+// DeleteThing is not cached, since it changes the things. The decisions made
+// for the deleted thing are removed when the things service publishes the
+// removal event.
+func (c *cache) DeleteThing(ctx context.Context, req *magistrala.DeleteThingReq, opts ...grpc.CallOption) (*magistrala.DeleteThingRes, error) {
+	return c.client.DeleteThing(ctx, req, opts...)
+}
+
+func (c *cache) RemoveThing(thingID string) {
+	c.remove(func(k key, e entry) bool {
+		return e.thingID == thingID
+	})
+}
+
+func (c *cache) RemoveChannel(channelID string) {
+	c.remove(func(k key, e entry) bool {
+		return k.channelID == channelID
+	})
+}
+
+func (c *cache) RemoveConnection(thingID, channelID string) {
+	c.remove(func(k key, e entry) bool {
+		return e.thingID == thingID && k.channelID == channelID
+	})
+}
+
+func (c *cache) get(k key) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[k]
+	if !ok {
+		return "", false
+	}
+	if time.Now().After(e.expiresAt) {
+		delete(c.entries, k)
+		return "", false
+	}
+
+	return e.thingID, true
+}
+
+func (c *cache) set(k key, thingID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	c.entries[k] = entry{thingID: thingID, expiresAt: now.Add(c.ttl)}
+
+	// Expired entries of the things which stopped publishing are never looked
+	// up again, so they are swept periodically to keep the cache bounded.
+	if now.Sub(c.lastSweep) < c.ttl {
+		return
+	}
+	for k, e := range c.entries {
+		if now.After(e.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.lastSweep = now
+}
+
+func (c *cache) remove(match func(k key, e entry) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, e := range c.entries {
+		if match(k, e) {
+			delete(c.entries, k)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package authzcache_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/authzcache"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	thmocks "github.com/absmach/magistrala/things/mocks"
+	"github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	thingID   = "thing"
+	thingKey  = "key"
+	channelID = "channel"
+	publish   = "publish"
+)
+
+var req = &magistrala.ThingsAuthzReq{
+	ThingKey:   thingKey,
+	ChannelId:  channelID,
+	Permission: publish,
+}
+
+// lookupCounter counts cache lookups by result.
+type lookupCounter struct {
+	results map[string]float64
+	result  string
+}
+
+func (c *lookupCounter) With(labelValues ...string) metrics.Counter {
+	return &lookupCounter{results: c.results, result: labelValues[len(labelValues)-1]}
+}
+
+func (c *lookupCounter) Add(delta float64) {
+	c.results[c.result] += delta
+}
+
+func newCache(ttl time.Duration) (authzcache.Cache, *thmocks.ThingsServiceClient, *lookupCounter) {
+	things := new(thmocks.ThingsServiceClient)
+	counter := &lookupCounter{results: make(map[string]float64)}
+
+	return authzcache.NewThingsClient(things, ttl, counter), things, counter
+}
+
+func TestAuthorize(t *testing.T) {
+	cases := []struct {
+		desc       string
+		res        *magistrala.ThingsAuthzRes
+		err        error
+		cachedCall bool
+	}{
+		{
+			desc:       "authorize allowed request",
+			res:        &magistrala.ThingsAuthzRes{Authorized: true, Id: thingID},
+			cachedCall: true,
+		},
+		{
+			desc: "authorize denied request",
+			res:  &magistrala.ThingsAuthzRes{Authorized: false},
+		},
+		{
+			desc: "authorize request with failed authorization",
+			res:  &magistrala.ThingsAuthzRes{},
+			err:  svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		cache, things, _ := newCache(time.Minute)
+		repoCall := things.On("Authorize", mock.Anything, req).Return(tc.res, tc.err)
+		for i := 0; i < 2; i++ {
+			res, err := cache.Authorize(context.Background(), req)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.res.GetAuthorized(), res.GetAuthorized(), fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.res.GetAuthorized(), res.GetAuthorized()))
+			assert.Equal(t, tc.res.GetId(), res.GetId(), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.res.GetId(), res.GetId()))
+		}
+		calls := 2
+		if tc.cachedCall {
+			calls = 1
+		}
+		things.AssertNumberOfCalls(t, "Authorize", calls)
+		repoCall.Unset()
+	}
+}
+
+func TestAuthorizeExpired(t *testing.T) {
+	cache, things, _ := newCache(time.Millisecond)
+	repoCall := things.On("Authorize", mock.Anything, req).Return(&magistrala.ThingsAuthzRes{Authorized: true, Id: thingID}, nil)
+	defer repoCall.Unset()
+
+	_, err := cache.Authorize(context.Background(), req)
+	assert.Nil(t, err, fmt.Sprintf("authorize expected to succeed: %s", err))
+	time.Sleep(5 * time.Millisecond)
+	_, err = cache.Authorize(context.Background(), req)
+	assert.Nil(t, err, fmt.Sprintf("authorize expected to succeed: %s", err))
+	things.AssertNumberOfCalls(t, "Authorize", 2)
+}
+
+func TestAuthorizeWithPSK(t *testing.T) {
+	cache, things, _ := newCache(time.Minute)
+	pskReq := &magistrala.ThingsAuthzReq{
+		ThingId:    thingID,
+		ThingPsk:   []byte(thingKey),
+		ChannelId:  channelID,
+		Permission: publish,
+	}
+	idReq := &magistrala.ThingsAuthzReq{
+		ThingId:    thingID,
+		ChannelId:  channelID,
+		Permission: publish,
+	}
+	repoCall := things.On("Authorize", mock.Anything, pskReq).Return(&magistrala.ThingsAuthzRes{Authorized: true, Id: thingID}, nil)
+	repoCall1 := things.On("Authorize", mock.Anything, idReq).Return(&magistrala.ThingsAuthzRes{}, svcerr.ErrAuthentication)
+	defer repoCall.Unset()
+	defer repoCall1.Unset()
+
+	_, err := cache.Authorize(context.Background(), pskReq)
+	assert.Nil(t, err, fmt.Sprintf("authorize with pre-shared key expected to succeed: %s", err))
+	_, err = cache.Authorize(context.Background(), idReq)
+	assert.True(t, errors.Contains(err, svcerr.ErrAuthentication), fmt.Sprintf("authorize without pre-shared key: expected %s got %s\n", svcerr.ErrAuthentication, err))
+	things.AssertNumberOfCalls(t, "Authorize", 2)
+}
+
+func TestRemove(t *testing.T) {
+	cases := []struct {
+		desc   string
+		remove func(cache authzcache.Cache)
+		calls  int
+	}{
+		{
+			desc:   "remove thing decisions",
+			remove: func(cache authzcache.Cache) { cache.RemoveThing(thingID) },
+			calls:  2,
+		},
+		{
+			desc:   "remove channel decisions",
+			remove: func(cache authzcache.Cache) { cache.RemoveChannel(channelID) },
+			calls:  2,
+		},
+		{
+			desc:   "remove connection decisions",
+			remove: func(cache authzcache.Cache) { cache.RemoveConnection(thingID, channelID) },
+			calls:  2,
+		},
+		{
+			desc:   "remove other thing decisions",
+			remove: func(cache authzcache.Cache) { cache.RemoveThing("other") },
+			calls:  1,
+		},
+		{
+			desc:   "remove other channel decisions",
+			remove: func(cache authzcache.Cache) { cache.RemoveChannel("other") },
+			calls:  1,
+		},
+		{
+			desc:   "remove other connection decisions",
+			remove: func(cache authzcache.Cache) { cache.RemoveConnection(thingID, "other") },
+			calls:  1,
+		},
+	}
+
+	for _, tc := range cases {
+		cache, things, counter := newCache(time.Minute)
+		repoCall := things.On("Authorize", mock.Anything, req).Return(&magistrala.ThingsAuthzRes{Authorized: true, Id: thingID}, nil)
+		_, err := cache.Authorize(context.Background(), req)
+		assert.Nil(t, err, fmt.Sprintf("%s: authorize expected to succeed: %s", tc.desc, err))
+		tc.remove(cache)
+		_, err = cache.Authorize(context.Background(), req)
+		assert.Nil(t, err, fmt.Sprintf("%s: authorize expected to succeed: %s", tc.desc, err))
+		things.AssertNumberOfCalls(t, "Authorize", tc.calls)
+		hits := float64(2 - tc.calls)
+		assert.Equal(t, hits, counter.results["hit"], fmt.Sprintf("%s: expected %f hits got %f", tc.desc, hits, counter.results["hit"]))
+		assert.Equal(t, float64(tc.calls), counter.results["miss"], fmt.Sprintf("%s: expected %d misses got %f", tc.desc, tc.calls, counter.results["miss"]))
+		repoCall.Unset()
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package authzcache contains the protocol adapters cache of the things
+// authorization decisions. The cache wraps the things gRPC client and keeps
+// the allowed decisions for the configured TTL. Cached decisions are
+// invalidated using the things events.
+package authzcache
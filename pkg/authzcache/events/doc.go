@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package events contains the event handler invalidating the authorization
+// decisions cache using the things events.
+package events
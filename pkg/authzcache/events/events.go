@@ -0,0 +1,98 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/pkg/authzcache"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/policies"
+)
+
+const (
+	// ThingsStream is the things service events stream. It contains both
+	// things and channels events.
+	ThingsStream = "events.magistrala.things"
+
+	thingPrefix       = "thing."
+	thingUpdateSecret = thingPrefix + "update_secret"
+	thingChangeStatus = thingPrefix + "change_status"
+	thingRemove       = thingPrefix + "remove"
+
+	channelPrefix       = "group."
+	channelChangeStatus = channelPrefix + "change_status"
+	channelRemove       = channelPrefix + "remove"
+	channelUnassign     = channelPrefix + "unassign"
+)
+
+// Start subscribes to the things events stream and invalidates the cache.
+// Consumer must be unique per adapter instance and stable across restarts,
+// since every instance keeps its own cache.
+func Start(ctx context.Context, consumer string, sub events.Subscriber, cache authzcache.Cache) error {
+	subCfg := events.SubscriberConfig{
+		Consumer: consumer,
+		Stream:   ThingsStream,
+		Handler:  NewEventHandler(cache),
+	}
+
+	return sub.Subscribe(ctx, subCfg)
+}
+
+type eventHandler struct {
+	cache authzcache.Cache
+}
+
+// NewEventHandler returns new event handler invalidating authorization decisions.
+func NewEventHandler(cache authzcache.Cache) events.EventHandler {
+	return &eventHandler{
+		cache: cache,
+	}
+}
+
+func (eh *eventHandler) Handle(ctx context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	switch msg["operation"] {
+	case thingUpdateSecret, thingChangeStatus, thingRemove:
+		id := events.Read(msg, "id", "")
+		if id == "" {
+			return svcerr.ErrMalformedEntity
+		}
+		eh.cache.RemoveThing(id)
+	case channelChangeStatus, channelRemove:
+		id := events.Read(msg, "id", "")
+		if id == "" {
+			return svcerr.ErrMalformedEntity
+		}
+		eh.cache.RemoveChannel(id)
+	case channelUnassign:
+		return eh.handleUnassign(msg)
+	}
+
+	return nil
+}
+
+// handleUnassign removes the decisions of the disconnected things. If
+// other members are unassigned, permissions inherited through the channel
+// may change, so all the channel decisions are removed.
+func (eh *eventHandler) handleUnassign(msg map[string]interface{}) error {
+	channelID := events.Read(msg, "group_id", "")
+	if channelID == "" {
+		return svcerr.ErrMalformedEntity
+	}
+	if events.Read(msg, "memberKind", "") != policies.ThingsKind {
+		eh.cache.RemoveChannel(channelID)
+		return nil
+	}
+	for _, id := range events.ReadStringSlice(msg, "member_ids") {
+		eh.cache.RemoveConnection(id, channelID)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/authzcache/events"
+	"github.com/absmach/magistrala/pkg/authzcache/mocks"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/stretchr/testify/assert"
+)
+
+type testEvent map[string]interface{}
+
+func (e testEvent) Encode() (map[string]interface{}, error) {
+	return e, nil
+}
+
+func TestHandle(t *testing.T) {
+	cases := []struct {
+		desc       string
+		event      testEvent
+		thingCalls int
+		chanCalls  int
+		connCalls  int
+		err        error
+	}{
+		{
+			desc:       "handle thing secret update event",
+			event:      testEvent{"operation": "thing.update_secret", "id": "thing"},
+			thingCalls: 1,
+		},
+		{
+			desc:       "handle thing status change event",
+			event:      testEvent{"operation": "thing.change_status", "id": "thing", "status": "disabled"},
+			thingCalls: 1,
+		},
+		{
+			desc:       "handle thing remove event",
+			event:      testEvent{"operation": "thing.remove", "id": "thing"},
+			thingCalls: 1,
+		},
+		{
+			desc:  "handle thing event without id",
+			event: testEvent{"operation": "thing.remove"},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:      "handle channel status change event",
+			event:     testEvent{"operation": "group.change_status", "id": "channel", "status": "disabled"},
+			chanCalls: 1,
+		},
+		{
+			desc:      "handle channel remove event",
+			event:     testEvent{"operation": "group.remove", "id": "channel"},
+			chanCalls: 1,
+		},
+		{
+			desc:      "handle things disconnect event",
+			event:     testEvent{"operation": "group.unassign", "group_id": "channel", "memberKind": "things", "member_ids": []interface{}{"thing", "thing"}},
+			connCalls: 2,
+		},
+		{
+			desc:      "handle channel parent unassign event",
+			event:     testEvent{"operation": "group.unassign", "group_id": "channel", "memberKind": "groups", "member_ids": []interface{}{"group"}},
+			chanCalls: 1,
+		},
+		{
+			desc:  "handle unassign event without group",
+			event: testEvent{"operation": "group.unassign", "memberKind": "things", "member_ids": []interface{}{"thing"}},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "handle thing update event",
+			event: testEvent{"operation": "thing.update", "id": "thing"},
+		},
+	}
+
+	for _, tc := range cases {
+		cache := new(mocks.Cache)
+		cache.On("RemoveThing", "thing").Return()
+		cache.On("RemoveChannel", "channel").Return()
+		cache.On("RemoveConnection", "thing", "channel").Return()
+		err := events.NewEventHandler(cache).Handle(context.Background(), tc.event)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		cache.AssertNumberOfCalls(t, "RemoveThing", tc.thingCalls)
+		cache.AssertNumberOfCalls(t, "RemoveChannel", tc.chanCalls)
+		cache.AssertNumberOfCalls(t, "RemoveConnection", tc.connCalls)
+	}
+}
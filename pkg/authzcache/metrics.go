@@ -0,0 +1,19 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package authzcache
+
+import (
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// MakeMetrics returns the authorization cache lookups counter.
+func MakeMetrics(namespace string) *kitprometheus.Counter {
+	return kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "authz_cache",
+		Name:      "lookup_count",
+		Help:      "Number of authorization cache lookups.",
+	}, []string{"result"})
+}
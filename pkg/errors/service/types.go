@@ -70,6 +70,12 @@ var (
 	// ErrInvitationAlreadyAccepted indicates that the invitation is already accepted.
 	ErrInvitationAlreadyAccepted = errors.New("invitation already accepted")
 
+	// ErrInvitationExpired indicates that the invitation is expired.
+	ErrInvitationExpired = errors.New("invitation expired")
+
+	// ErrInvitationResendLimit indicates that the invitation can't be resent anymore.
+	ErrInvitationResendLimit = errors.New("invitation resend limit reached")
+
 	// ErrParentGroupAuthorization indicates failure occurred while authorizing the parent group.
 	ErrParentGroupAuthorization = errors.New("failed to authorize parent group")
 
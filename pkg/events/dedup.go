@@ -0,0 +1,78 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+var _ EventHandler = (*deduplicator)(nil)
+
+type deduplicator struct {
+	handler EventHandler
+	mu      sync.Mutex
+	seen    map[string]struct{}
+	ids     []string
+	next    int
+}
+
+// NewDeduplicator returns event handler which skips the events with the
+// idempotency key of one of the last size handled events. Events published
+// through the outbox are delivered at least once, so the same event may be
+// received more than once. Events without the idempotency key are always
+// handled.
+func NewDeduplicator(handler EventHandler, size int) EventHandler {
+	return &deduplicator{
+		handler: handler,
+		seen:    make(map[string]struct{}, size),
+		ids:     make([]string, size),
+	}
+}
+
+func (d *deduplicator) Handle(ctx context.Context, event Event) error {
+	data, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	id := Read(data, IDKey, "")
+	if id == "" || len(d.ids) == 0 {
+		return d.handler.Handle(ctx, event)
+	}
+	if d.handled(id) {
+		return nil
+	}
+
+	if err := d.handler.Handle(ctx, event); err != nil {
+		return err
+	}
+	d.add(id)
+
+	return nil
+}
+
+func (d *deduplicator) handled(id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	_, ok := d.seen[id]
+
+	return ok
+}
+
+// add remembers the event, forgetting the oldest one when the handler
+// remembers size events already.
+func (d *deduplicator) add(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.seen[id]; ok {
+		return
+	}
+	delete(d.seen, d.ids[d.next])
+	d.ids[d.next] = id
+	d.seen[id] = struct{}{}
+	d.next = (d.next + 1) % len(d.ids)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/stretchr/testify/assert"
+)
+
+var errHandle = errors.New("failed to handle event")
+
+type testEvent map[string]interface{}
+
+func (e testEvent) Encode() (map[string]interface{}, error) {
+	return e, nil
+}
+
+type handler struct {
+	handled []string
+	err     error
+}
+
+func (h *handler) Handle(_ context.Context, event events.Event) error {
+	if h.err != nil {
+		return h.err
+	}
+	data, _ := event.Encode()
+	h.handled = append(h.handled, events.Read(data, "operation", ""))
+
+	return nil
+}
+
+func TestDeduplicator(t *testing.T) {
+	cases := []struct {
+		desc    string
+		size    int
+		events  []testEvent
+		err     error
+		handled []string
+	}{
+		{
+			desc: "handle events with distinct keys",
+			size: 2,
+			events: []testEvent{
+				{events.IDKey: "1", "operation": "thing.create"},
+				{events.IDKey: "2", "operation": "thing.update"},
+			},
+			handled: []string{"thing.create", "thing.update"},
+		},
+		{
+			desc: "handle redelivered event",
+			size: 2,
+			events: []testEvent{
+				{events.IDKey: "1", "operation": "thing.create"},
+				{events.IDKey: "1", "operation": "thing.create"},
+				{events.IDKey: "2", "operation": "thing.update"},
+			},
+			handled: []string{"thing.create", "thing.update"},
+		},
+		{
+			desc: "handle events without key",
+			size: 2,
+			events: []testEvent{
+				{"operation": "thing.view"},
+				{"operation": "thing.view"},
+			},
+			handled: []string{"thing.view", "thing.view"},
+		},
+		{
+			desc: "handle redelivered event forgotten by full deduplicator",
+			size: 1,
+			events: []testEvent{
+				{events.IDKey: "1", "operation": "thing.create"},
+				{events.IDKey: "2", "operation": "thing.update"},
+				{events.IDKey: "1", "operation": "thing.create"},
+			},
+			handled: []string{"thing.create", "thing.update", "thing.create"},
+		},
+		{
+			desc: "handle events with failed handling",
+			size: 2,
+			events: []testEvent{
+				{events.IDKey: "1", "operation": "thing.create"},
+			},
+			err: errHandle,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			h := &handler{err: tc.err}
+			dedup := events.NewDeduplicator(h, tc.size)
+			for _, event := range tc.events {
+				err := dedup.Handle(context.Background(), event)
+				assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			}
+			assert.Equal(t, tc.handled, h.handled, fmt.Sprintf("%s: expected handled events %v got %v\n", tc.desc, tc.handled, h.handled))
+		})
+	}
+}
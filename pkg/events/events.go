@@ -13,8 +13,14 @@ const (
 	ConnCheckInterval                     = 100 * time.Millisecond
 	MaxUnpublishedEvents           uint64 = 1e4
 	MaxEventStreamLen              int64  = 1e6
+	MaxDeduplicatedEvents          int    = 1e4
 )
 
+// IDKey is the event attribute holding the event idempotency key. It is set
+// to events published through the outbox and it is the same for all the
+// deliveries of an event, so consumers can skip the events they handled.
+const IDKey = "event_id"
+
 // Event represents an event.
 type Event interface {
 	// Encode encodes event to map.
@@ -0,0 +1,12 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package outbox provides transactional outbox for the service events.
+//
+// Events are written to the outbox table in the same PostgreSQL transaction
+// as the change they describe, so an event is stored if and only if the
+// change is committed. Relay publishes the stored events to the event store
+// and removes them from the outbox once the event store accepts them. Events
+// are published at least once and each of them carries the idempotency key,
+// which the event store subscribers use to skip redelivered events.
+package outbox
@@ -0,0 +1,106 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/postgres"
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+var _ events.Publisher = (*publisher)(nil)
+
+// Migration returns the outbox table migration. Services using the outbox
+// append it to their own migrations.
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "outbox_01",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS outbox (
+						seq			BIGSERIAL PRIMARY KEY,
+						id			VARCHAR(36) NOT NULL UNIQUE,
+						stream		VARCHAR(254) NOT NULL,
+						payload		JSONB NOT NULL,
+						created_at	TIMESTAMP NOT NULL
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS outbox`,
+				},
+			},
+		},
+	}
+}
+
+type publisher struct {
+	db     postgres.Database
+	idp    magistrala.IDProvider
+	stream string
+}
+
+// NewPublisher returns events publisher which writes events to the outbox
+// table. Events published within postgres.Database Transaction are part of
+// the transaction.
+func NewPublisher(db postgres.Database, idp magistrala.IDProvider, stream string) events.Publisher {
+	return &publisher{
+		db:     db,
+		idp:    idp,
+		stream: stream,
+	}
+}
+
+func (pub *publisher) Publish(ctx context.Context, event events.Event) error {
+	values, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	id, err := pub.idp.ID()
+	if err != nil {
+		return err
+	}
+	values[events.IDKey] = id
+	if _, ok := values["occurred_at"]; !ok {
+		values["occurred_at"] = time.Now().UnixNano()
+	}
+
+	payload, err := json.Marshal(values)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+
+	dbe := dbEvent{
+		ID:        id,
+		Stream:    pub.stream,
+		Payload:   payload,
+		CreatedAt: time.Now().UTC(),
+	}
+	q := `INSERT INTO outbox (id, stream, payload, created_at) VALUES (:id, :stream, :payload, :created_at)`
+	if _, err := pub.db.NamedExecContext(ctx, q, dbe); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (pub *publisher) Close() error {
+	return nil
+}
+
+type dbEvent struct {
+	ID        string    `db:"id"`
+	Stream    string    `db:"stream"`
+	Payload   []byte    `db:"payload"`
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -0,0 +1,174 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package outbox_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/events/mocks"
+	"github.com/absmach/magistrala/pkg/events/outbox"
+	"github.com/absmach/magistrala/pkg/postgres"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	thingsStream = "magistrala.things"
+	usersStream  = "magistrala.users"
+)
+
+var errFailed = errors.New("failed")
+
+type testEvent map[string]interface{}
+
+func (e testEvent) Encode() (map[string]interface{}, error) {
+	data := make(map[string]interface{}, len(e))
+	for k, v := range e {
+		data[k] = v
+	}
+
+	return data, nil
+}
+
+func cleanup(t *testing.T) {
+	_, err := db.Exec("DELETE FROM outbox")
+	require.Nil(t, err, fmt.Sprintf("clean outbox unexpected error: %s", err))
+}
+
+func stored(t *testing.T) uint64 {
+	var total uint64
+	err := db.Get(&total, "SELECT COUNT(*) FROM outbox")
+	require.Nil(t, err, fmt.Sprintf("count outbox unexpected error: %s", err))
+
+	return total
+}
+
+func TestPublish(t *testing.T) {
+	t.Cleanup(func() { cleanup(t) })
+
+	pub := outbox.NewPublisher(database, uuid.New(), thingsStream)
+
+	cases := []struct {
+		desc   string
+		txErr  error
+		stored uint64
+	}{
+		{
+			desc:   "publish event in committed transaction",
+			stored: 1,
+		},
+		{
+			desc:   "publish event in rolled back transaction",
+			txErr:  errFailed,
+			stored: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cleanup(t)
+			err := postgres.Transaction(context.Background(), database, func(ctx context.Context) error {
+				if err := pub.Publish(ctx, testEvent{"operation": "thing.create"}); err != nil {
+					return err
+				}
+
+				return tc.txErr
+			})
+			assert.Equal(t, tc.txErr, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.txErr, err))
+			assert.Equal(t, tc.stored, stored(t), fmt.Sprintf("%s: expected %d stored events\n", tc.desc, tc.stored))
+		})
+	}
+}
+
+func TestRelay(t *testing.T) {
+	t.Cleanup(func() { cleanup(t) })
+
+	thingsPub := outbox.NewPublisher(database, uuid.New(), thingsStream)
+	usersPub := outbox.NewPublisher(database, uuid.New(), usersStream)
+	published := []testEvent{
+		{"operation": "thing.create", "id": "thing"},
+		{"operation": "user.create", "id": "user"},
+		{"operation": "thing.remove", "id": "thing"},
+	}
+
+	cases := []struct {
+		desc      string
+		publishFn func(i int) error
+		relayed   int
+		stored    uint64
+		err       error
+	}{
+		{
+			desc:      "relay events",
+			publishFn: func(int) error { return nil },
+			relayed:   3,
+			stored:    0,
+		},
+		{
+			desc: "relay events with failed publishing",
+			publishFn: func(i int) error {
+				if i == 1 {
+					return errFailed
+				}
+				return nil
+			},
+			relayed: 1,
+			stored:  2,
+			err:     errFailed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cleanup(t)
+			for _, e := range published {
+				pub := thingsPub
+				if e["operation"] == "user.create" {
+					pub = usersPub
+				}
+				err := pub.Publish(context.Background(), e)
+				require.Nil(t, err, fmt.Sprintf("%s: unexpected error publishing event: %s", tc.desc, err))
+			}
+
+			var relayed []map[string]interface{}
+			var streams []string
+			newPublisher := func(_ context.Context, stream string) (events.Publisher, error) {
+				pub := new(mocks.Publisher)
+				pub.On("Publish", mock.Anything, mock.Anything).Return(func(_ context.Context, e events.Event) error {
+					if err := tc.publishFn(len(relayed)); err != nil {
+						return err
+					}
+					data, _ := e.Encode()
+					relayed = append(relayed, data)
+					streams = append(streams, stream)
+					return nil
+				})
+				pub.On("Close").Return(nil)
+				return pub, nil
+			}
+
+			relay := outbox.NewRelay(database, newPublisher, mglog.NewMock())
+			n, err := relay.Relay(context.Background())
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.relayed, n, fmt.Sprintf("%s: expected %d relayed events got %d\n", tc.desc, tc.relayed, n))
+			assert.Equal(t, tc.stored, stored(t), fmt.Sprintf("%s: expected %d stored events\n", tc.desc, tc.stored))
+			for i, data := range relayed {
+				assert.Equal(t, published[i]["operation"], data["operation"], fmt.Sprintf("%s: expected events relayed in order\n", tc.desc))
+				assert.NotEmpty(t, data[events.IDKey], fmt.Sprintf("%s: expected event idempotency key\n", tc.desc))
+				assert.NotNil(t, data["occurred_at"], fmt.Sprintf("%s: expected event occurrence time\n", tc.desc))
+			}
+			if tc.relayed == len(published) {
+				assert.Equal(t, []string{thingsStream, usersStream, thingsStream}, streams, fmt.Sprintf("%s: expected events relayed to their streams\n", tc.desc))
+			}
+			assert.Nil(t, relay.Close(), fmt.Sprintf("%s: unexpected error closing relay", tc.desc))
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/postgres"
+	"github.com/jackc/pgtype"
+)
+
+const batchSize = 100
+
+// PublisherFactory returns event store publisher for the given stream. The
+// publisher must return an error for each event it fails to publish.
+type PublisherFactory func(ctx context.Context, stream string) (events.Publisher, error)
+
+// Relay publishes the outbox events to the event store.
+type Relay struct {
+	db           postgres.Database
+	newPublisher PublisherFactory
+	logger       *slog.Logger
+	mu           sync.Mutex
+	publishers   map[string]events.Publisher
+}
+
+// NewRelay returns outbox relay. Several relays can share the outbox, since
+// each event is picked up by a single relay at a time.
+func NewRelay(db postgres.Database, newPublisher PublisherFactory, logger *slog.Logger) *Relay {
+	return &Relay{
+		db:           db,
+		newPublisher: newPublisher,
+		publishers:   make(map[string]events.Publisher),
+		logger:       logger,
+	}
+}
+
+// Start relays the outbox events every interval until the context is
+// canceled. Events which fail to be published are retried on the next run.
+func (r *Relay) Start(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return r.Close()
+		case <-ticker.C:
+			for {
+				n, err := r.Relay(ctx)
+				if err != nil {
+					r.logger.Warn(fmt.Sprintf("failed to relay outbox events: %s", err))
+				}
+				if err != nil || n < batchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// Relay publishes the oldest batch of outbox events in the order they were
+// written, stopping at the first event which fails to be published. It
+// returns the number of published events, which are removed from the outbox.
+func (r *Relay) Relay(ctx context.Context) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var published []string
+	var pubErr error
+
+	err := postgres.Transaction(ctx, r.db, func(ctx context.Context) error {
+		q := `SELECT id, stream, payload, created_at FROM outbox ORDER BY seq LIMIT $1 FOR UPDATE SKIP LOCKED`
+		rows, err := r.db.QueryxContext(ctx, q, batchSize)
+		if err != nil {
+			return postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		var evs []dbEvent
+		for rows.Next() {
+			var dbe dbEvent
+			if err := rows.StructScan(&dbe); err != nil {
+				rows.Close()
+				return errors.Wrap(repoerr.ErrViewEntity, err)
+			}
+			evs = append(evs, dbe)
+		}
+		rows.Close()
+
+		for _, dbe := range evs {
+			err := r.publish(ctx, dbe)
+			switch {
+			case errors.Contains(err, repoerr.ErrMalformedEntity):
+				// The event can never be published, so it is dropped
+				// instead of blocking the events written after it.
+				r.logger.Error(fmt.Sprintf("dropping malformed outbox event %s: %s", dbe.ID, err))
+			case err != nil:
+				pubErr = err
+			}
+			if pubErr != nil {
+				break
+			}
+			published = append(published, dbe.ID)
+		}
+		if len(published) == 0 {
+			return nil
+		}
+
+		var ids pgtype.TextArray
+		if err := ids.Set(published); err != nil {
+			return errors.Wrap(repoerr.ErrRemoveEntity, err)
+		}
+		if _, err := r.db.ExecContext(ctx, `DELETE FROM outbox WHERE id = ANY($1)`, ids); err != nil {
+			return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(published), pubErr
+}
+
+// Close closes the event store publishers.
+func (r *Relay) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var err error
+	for stream, pub := range r.publishers {
+		if cerr := pub.Close(); cerr != nil {
+			err = errors.Wrap(cerr, err)
+		}
+		delete(r.publishers, stream)
+	}
+
+	return err
+}
+
+func (r *Relay) publish(ctx context.Context, dbe dbEvent) error {
+	pub, ok := r.publishers[dbe.Stream]
+	if !ok {
+		var err error
+		if pub, err = r.newPublisher(ctx, dbe.Stream); err != nil {
+			return err
+		}
+		r.publishers[dbe.Stream] = pub
+	}
+
+	// Numbers are decoded as json.Number so they are published unchanged.
+	ev := event{data: make(map[string]interface{})}
+	dec := json.NewDecoder(bytes.NewReader(dbe.Payload))
+	dec.UseNumber()
+	if err := dec.Decode(&ev.data); err != nil {
+		return errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+
+	return pub.Publish(ctx, ev)
+}
+
+type event struct {
+	data map[string]interface{}
+}
+
+func (e event) Encode() (map[string]interface{}, error) {
+	return e.data, nil
+}
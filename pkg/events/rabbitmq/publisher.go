@@ -52,7 +52,9 @@ func (es *pubEventStore) Publish(ctx context.Context, event events.Event) error
 	if err != nil {
 		return err
 	}
-	values["occurred_at"] = time.Now().UnixNano()
+	if _, ok := values["occurred_at"]; !ok {
+		values["occurred_at"] = time.Now().UnixNano()
+	}
 
 	data, err := json.Marshal(values)
 	if err != nil {
@@ -72,7 +72,7 @@ func (es *subEventStore) Subscribe(ctx context.Context, cfg events.SubscriberCon
 		ID:    cfg.Consumer,
 		Topic: cfg.Stream,
 		Handler: &eventHandler{
-			handler: cfg.Handler,
+			handler: events.NewDeduplicator(cfg.Handler, events.MaxDeduplicatedEvents),
 			ctx:     ctx,
 			logger:  es.logger,
 		},
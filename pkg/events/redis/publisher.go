@@ -21,6 +21,10 @@ type pubEventStore struct {
 	flushPeriod       time.Duration
 }
 
+// NewPublisher returns Redis events publisher. Events which can't be published
+// due to a connection error are kept in memory and published every
+// flushPeriod. If flushPeriod is zero, such events are not kept and Publish
+// returns the connection error instead.
 func NewPublisher(ctx context.Context, url, stream string, flushPeriod time.Duration) (events.Publisher, error) {
 	opts, err := redis.ParseURL(url)
 	if err != nil {
@@ -34,7 +38,9 @@ func NewPublisher(ctx context.Context, url, stream string, flushPeriod time.Dura
 		flushPeriod:       flushPeriod,
 	}
 
-	go es.flushUnpublished(ctx)
+	if flushPeriod > 0 {
+		go es.flushUnpublished(ctx)
+	}
 
 	return es, nil
 }
@@ -44,7 +50,9 @@ func (es *pubEventStore) Publish(ctx context.Context, event events.Event) error
 	if err != nil {
 		return err
 	}
-	values["occurred_at"] = time.Now().UnixNano()
+	if _, ok := values["occurred_at"]; !ok {
+		values["occurred_at"] = time.Now().UnixNano()
+	}
 
 	data, err := json.Marshal(values)
 	if err != nil {
@@ -58,9 +66,11 @@ func (es *pubEventStore) Publish(ctx context.Context, event events.Event) error
 		Values: map[string]interface{}{"data": string(data)},
 	}
 
-	switch err := es.checkConnection(ctx); err {
-	case nil:
+	switch err := es.checkConnection(ctx); {
+	case err == nil:
 		return es.client.XAdd(ctx, record).Err()
+	case es.flushPeriod == 0:
+		return err
 	default:
 		es.mu.Lock()
 		defer es.mu.Unlock()
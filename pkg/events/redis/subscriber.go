@@ -61,6 +61,8 @@ func (es *subEventStore) Subscribe(ctx context.Context, cfg events.SubscriberCon
 		return err
 	}
 
+	handler := events.NewDeduplicator(cfg.Handler, events.MaxDeduplicatedEvents)
+
 	go func() {
 		for {
 			msgs, err := es.client.XReadGroup(ctx, &redis.XReadGroupArgs{
@@ -78,7 +80,7 @@ func (es *subEventStore) Subscribe(ctx context.Context, cfg events.SubscriberCon
 				continue
 			}
 
-			es.handle(ctx, cfg.Stream, msgs[0].Messages, cfg.Handler)
+			es.handle(ctx, cfg.Stream, msgs[0].Messages, handler)
 		}
 	}()
 
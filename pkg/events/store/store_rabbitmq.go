@@ -31,6 +31,18 @@ func NewPublisher(ctx context.Context, url, stream string) (events.Publisher, er
 	return pb, nil
 }
 
+// NewUnbufferedPublisher returns publisher which returns an error for each
+// event it fails to publish, instead of keeping the event to publish it
+// later. It is used to relay the outbox, which keeps such events itself.
+func NewUnbufferedPublisher(ctx context.Context, url, stream string) (events.Publisher, error) {
+	pb, err := rabbitmq.NewPublisher(ctx, url, stream)
+	if err != nil {
+		return nil, err
+	}
+
+	return pb, nil
+}
+
 func NewSubscriber(_ context.Context, url string, logger *slog.Logger) (events.Subscriber, error) {
 	pb, err := rabbitmq.NewSubscriber(url, logger)
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"github.com/absmach/magistrala"
 	domainsgrpc "github.com/absmach/magistrala/auth/api/grpc/domains"
 	tokengrpc "github.com/absmach/magistrala/auth/api/grpc/token"
+	certsgrpc "github.com/absmach/magistrala/certs/api/grpc"
 	thingsauth "github.com/absmach/magistrala/things/api/grpc"
 	grpchealth "google.golang.org/grpc/health/grpc_health_v1"
 )
@@ -78,3 +79,25 @@ func SetupThingsClient(ctx context.Context, cfg Config) (magistrala.ThingsServic
 
 	return thingsauth.NewClient(client.Connection(), cfg.Timeout), client, nil
 }
+
+// SetupCertsClient loads certs gRPC configuration and creates new certs gRPC client.
+//
+// For example:
+//
+// certsClient, certsHandler, err := grpcclient.SetupCertsClient(ctx, grpcclient.Config{}).
+func SetupCertsClient(ctx context.Context, cfg Config) (magistrala.CertsServiceClient, Handler, error) {
+	client, err := NewHandler(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	health := grpchealth.NewHealthClient(client.Connection())
+	resp, err := health.Check(ctx, &grpchealth.HealthCheckRequest{
+		Service: "certs",
+	})
+	if err != nil || resp.GetStatus() != grpchealth.HealthCheckResponse_SERVING {
+		return nil, nil, ErrSvcNotServing
+	}
+
+	return certsgrpc.NewClient(client.Connection(), cfg.Timeout), client, nil
+}
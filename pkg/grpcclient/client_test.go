@@ -13,6 +13,8 @@ import (
 	domainsgrpcapi "github.com/absmach/magistrala/auth/api/grpc/domains"
 	tokengrpcapi "github.com/absmach/magistrala/auth/api/grpc/token"
 	"github.com/absmach/magistrala/auth/mocks"
+	certsgrpcapi "github.com/absmach/magistrala/certs/api/grpc"
+	certsmocks "github.com/absmach/magistrala/certs/mocks"
 	mglog "github.com/absmach/magistrala/logger"
 	"github.com/absmach/magistrala/pkg/errors"
 	"github.com/absmach/magistrala/pkg/grpcclient"
@@ -177,3 +179,54 @@ func TestSetupDomainsClient(t *testing.T) {
 		})
 	}
 }
+
+func TestSetupCertsClient(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	registerCertsServiceServer := func(srv *grpc.Server) {
+		magistrala.RegisterCertsServiceServer(srv, certsgrpcapi.NewServer(new(certsmocks.Service)))
+	}
+	gs := grpcserver.NewServer(ctx, cancel, "certs", server.Config{Port: "12345"}, registerCertsServiceServer, mglog.NewMock())
+	go func() {
+		err := gs.Start()
+		assert.Nil(t, err, fmt.Sprintf("Unexpected error creating server %s", err))
+	}()
+	defer func() {
+		err := gs.Stop()
+		assert.Nil(t, err, fmt.Sprintf("Unexpected error stopping server %s", err))
+	}()
+
+	cases := []struct {
+		desc   string
+		config grpcclient.Config
+		err    error
+	}{
+		{
+			desc: "successfully",
+			config: grpcclient.Config{
+				URL:     "localhost:12345",
+				Timeout: time.Second,
+			},
+			err: nil,
+		},
+		{
+			desc: "failed with empty URL",
+			config: grpcclient.Config{
+				URL:     "",
+				Timeout: time.Second,
+			},
+			err: errors.New("service is not serving"),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			client, handler, err := grpcclient.SetupCertsClient(context.Background(), c.config)
+			assert.True(t, errors.Contains(err, c.err), fmt.Sprintf("expected %s to contain %s", err, c.err))
+			if err == nil {
+				assert.NotNil(t, client)
+				assert.NotNil(t, handler)
+			}
+		})
+	}
+}
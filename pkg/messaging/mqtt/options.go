@@ -0,0 +1,28 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"errors"
+
+	"github.com/absmach/magistrala/pkg/messaging"
+)
+
+// ErrInvalidType is returned when the provided value is not of the expected type.
+var ErrInvalidType = errors.New("invalid type")
+
+// Retain sets the function which decides whether the message is published
+// as the MQTT retained message.
+func Retain(retain func(msg *messaging.Message) bool) messaging.Option {
+	return func(val interface{}) error {
+		p, ok := val.(*publisher)
+		if !ok {
+			return ErrInvalidType
+		}
+
+		p.retain = retain
+
+		return nil
+	}
+}
@@ -20,10 +20,11 @@ type publisher struct {
 	client  mqtt.Client
 	timeout time.Duration
 	qos     uint8
+	retain  func(msg *messaging.Message) bool
 }
 
 // NewPublisher returns a new MQTT message publisher.
-func NewPublisher(address string, qos uint8, timeout time.Duration) (messaging.Publisher, error) {
+func NewPublisher(address string, qos uint8, timeout time.Duration, opts ...messaging.Option) (messaging.Publisher, error) {
 	client, err := newClient(address, "mqtt-publisher", timeout)
 	if err != nil {
 		return nil, err
@@ -34,6 +35,13 @@ func NewPublisher(address string, qos uint8, timeout time.Duration) (messaging.P
 		timeout: timeout,
 		qos:     qos,
 	}
+
+	for _, opt := range opts {
+		if err := opt(&ret); err != nil {
+			return nil, err
+		}
+	}
+
 	return ret, nil
 }
 
@@ -43,7 +51,8 @@ func (pub publisher) Publish(ctx context.Context, topic string, msg *messaging.M
 	}
 
 	// Publish only the payload and not the whole message.
-	token := pub.client.Publish(topic, byte(pub.qos), false, msg.GetPayload())
+	retain := pub.retain != nil && pub.retain(msg)
+	token := pub.client.Publish(topic, byte(pub.qos), retain, msg.GetPayload())
 	if token.Error() != nil {
 		return token.Error()
 	}
@@ -109,6 +109,9 @@ func (ps *pubsub) Subscribe(ctx context.Context, cfg messaging.SubscriberConfig)
 		consumerConfig.DeliverPolicy = jetstream.DeliverNewPolicy
 	case messaging.DeliverAllPolicy:
 		consumerConfig.DeliverPolicy = jetstream.DeliverAllPolicy
+	case messaging.DeliverByStartTimePolicy:
+		consumerConfig.DeliverPolicy = jetstream.DeliverByStartTimePolicy
+		consumerConfig.OptStartTime = &cfg.StartTime
 	}
 
 	consumer, err := ps.stream.CreateOrUpdateConsumer(ctx, consumerConfig)
@@ -3,7 +3,10 @@
 
 package messaging
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type DeliveryPolicy uint8
 
@@ -14,6 +17,11 @@ const (
 
 	// DeliverAllPolicy starts delivering messages from the very beginning of a stream.
 	DeliverAllPolicy
+
+	// DeliverByStartTimePolicy starts delivering messages from the first message
+	// stored at or after the subscriber configuration start time. Brokers which
+	// do not persist messages deliver only new messages.
+	DeliverByStartTimePolicy
 )
 
 // Publisher specifies message publishing API.
@@ -39,6 +47,7 @@ type SubscriberConfig struct {
 	Topic          string
 	Handler        MessageHandler
 	DeliveryPolicy DeliveryPolicy
+	StartTime      time.Time
 }
 
 // Subscriber specifies message subscription API.
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/absmach/magistrala/pkg/errors"
 	"github.com/jmoiron/sqlx"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -16,6 +17,10 @@ import (
 
 var _ Database = (*database)(nil)
 
+var errNestedTx = errors.New("transaction already in progress")
+
+type txKey struct{}
+
 type database struct {
 	Config
 	db     *sqlx.DB
@@ -61,50 +66,88 @@ func (d *database) NamedQueryContext(ctx context.Context, query string, args int
 	ctx, span := d.addSpanTags(ctx, query)
 	defer span.End()
 
-	return d.db.NamedQueryContext(ctx, query, args)
+	return sqlx.NamedQueryContext(ctx, d.conn(ctx), query, args)
 }
 
 func (d *database) NamedExecContext(ctx context.Context, query string, args interface{}) (sql.Result, error) {
 	ctx, span := d.addSpanTags(ctx, query)
 	defer span.End()
 
-	return d.db.NamedExecContext(ctx, query, args)
+	return sqlx.NamedExecContext(ctx, d.conn(ctx), query, args)
 }
 
 func (d *database) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
 	ctx, span := d.addSpanTags(ctx, query)
 	defer span.End()
 
-	return d.db.ExecContext(ctx, query, args...)
+	return d.conn(ctx).ExecContext(ctx, query, args...)
 }
 
 func (d *database) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
 	ctx, span := d.addSpanTags(ctx, query)
 	defer span.End()
 
-	return d.db.QueryRowxContext(ctx, query, args...)
+	return d.conn(ctx).QueryRowxContext(ctx, query, args...)
 }
 
 func (d *database) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
 	ctx, span := d.addSpanTags(ctx, query)
 	defer span.End()
 
-	return d.db.QueryxContext(ctx, query, args...)
+	return d.conn(ctx).QueryxContext(ctx, query, args...)
 }
 
 func (d database) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
 	ctx, span := d.addSpanTags(ctx, query)
 	defer span.End()
-	return d.db.QueryContext(ctx, query, args...)
+	return d.conn(ctx).QueryContext(ctx, query, args...)
 }
 
 func (d database) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	if _, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return nil, errNestedTx
+	}
+
 	ctx, span := d.addSpanTags(ctx, "BeginTxx")
 	defer span.End()
 
 	return d.db.BeginTxx(ctx, opts)
 }
 
+// Transaction runs fn in a transaction. All the queries made through the
+// Database created using NewDatabase with the context passed to fn are part
+// of the transaction, which is committed if fn returns nil and rolled back
+// otherwise. If ctx already carries a transaction, fn joins it.
+func Transaction(ctx context.Context, db Database, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return errors.Wrap(err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// conn returns the transaction carried by the context, if any, so the
+// queries made within Transaction are part of it.
+func (d *database) conn(ctx context.Context) sqlx.ExtContext {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx
+	}
+
+	return d.db
+}
+
 func (d *database) addSpanTags(ctx context.Context, query string) (context.Context, trace.Span) {
 	operation := strings.Replace(strings.Split(query, " ")[0], "(", "", 1)
 
@@ -0,0 +1,8 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package presence contains the thing presence events published by the
+// protocol adapters. Adapters report when the thing connects, disconnects
+// and publishes messages, so the things service can track which things are
+// online and when they were last seen.
+package presence
@@ -0,0 +1,65 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package presence
+
+import (
+	"time"
+
+	"github.com/absmach/magistrala/pkg/events"
+)
+
+// Protocols of the adapters publishing presence events.
+const (
+	MQTT = "mqtt"
+	HTTP = "http"
+	WS   = "ws"
+	CoAP = "coap"
+)
+
+// Presence event operations.
+const (
+	ConnectOp    = "connect"
+	DisconnectOp = "disconnect"
+	HeartbeatOp  = "heartbeat"
+	PublishOp    = "publish"
+)
+
+// Protocols lists protocols of the adapters publishing presence events.
+var Protocols = []string{MQTT, HTTP, WS, CoAP}
+
+// Stream returns the stream the presence events of the protocol adapter are
+// consumed from.
+func Stream(protocol string) string {
+	return "events." + streamID(protocol)
+}
+
+func streamID(protocol string) string {
+	return "magistrala." + protocol
+}
+
+var _ events.Event = (*presenceEvent)(nil)
+
+type presenceEvent struct {
+	thingID    string
+	connID     string
+	operation  string
+	protocol   string
+	instance   string
+	occurredAt time.Time
+}
+
+func (pe presenceEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"thing_id":    pe.thingID,
+		"operation":   pe.operation,
+		"protocol":    pe.protocol,
+		"instance":    pe.instance,
+		"occurred_at": pe.occurredAt.Format(time.RFC3339Nano),
+	}
+	if pe.connID != "" {
+		val["connection"] = pe.connID
+	}
+
+	return val, nil
+}
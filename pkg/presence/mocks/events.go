@@ -15,17 +15,17 @@ type EventStore struct {
 	mock.Mock
 }
 
-// Connect provides a mock function with given fields: ctx, clientID
-func (_m *EventStore) Connect(ctx context.Context, clientID string) error {
-	ret := _m.Called(ctx, clientID)
+// Connect provides a mock function with given fields: ctx, thingID, connID
+func (_m *EventStore) Connect(ctx context.Context, thingID string, connID string) error {
+	ret := _m.Called(ctx, thingID, connID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Connect")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, clientID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, thingID, connID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -33,17 +33,35 @@ func (_m *EventStore) Connect(ctx context.Context, clientID string) error {
 	return r0
 }
 
-// Disconnect provides a mock function with given fields: ctx, clientID
-func (_m *EventStore) Disconnect(ctx context.Context, clientID string) error {
-	ret := _m.Called(ctx, clientID)
+// Disconnect provides a mock function with given fields: ctx, thingID, connID
+func (_m *EventStore) Disconnect(ctx context.Context, thingID string, connID string) error {
+	ret := _m.Called(ctx, thingID, connID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Disconnect")
 	}
 
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, thingID, connID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Published provides a mock function with given fields: ctx, thingID
+func (_m *EventStore) Published(ctx context.Context, thingID string) error {
+	ret := _m.Called(ctx, thingID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Published")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, clientID)
+		r0 = rf(ctx, thingID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -0,0 +1,160 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package presence
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/events/store"
+)
+
+// EventStore publishes thing presence events.
+//
+//go:generate mockery --name EventStore --output=./mocks --filename events.go --quiet --note "Copyright (c) Abstract Machines"
+type EventStore interface {
+	// Connect issues event when the thing opens the connection to the
+	// adapter. The connection is identified by the connection ID, which is
+	// unique for the open connections of the adapter instance.
+	Connect(ctx context.Context, thingID, connID string) error
+
+	// Disconnect issues event when the thing closes the connection to the
+	// adapter.
+	Disconnect(ctx context.Context, thingID, connID string) error
+
+	// Published issues event when the thing publishes a message. At most one
+	// event per thing is issued in the configured interval.
+	Published(ctx context.Context, thingID string) error
+}
+
+type connection struct {
+	thingID string
+	connID  string
+}
+
+type eventStore struct {
+	events.Publisher
+	protocol  string
+	instance  string
+	interval  time.Duration
+	mu        sync.Mutex
+	published map[string]time.Time
+	conns     map[connection]struct{}
+	lastSweep time.Time
+}
+
+// NewEventStore returns the event store publishing presence events of the
+// protocol adapter to the protocol stream. Heartbeat of every open
+// connection is published in the interval until the context is canceled,
+// so connections of the adapter instances which stopped without closing
+// them expire.
+func NewEventStore(ctx context.Context, url, protocol, instance string, interval time.Duration) (EventStore, error) {
+	publisher, err := store.NewPublisher(ctx, url, streamID(protocol))
+	if err != nil {
+		return nil, err
+	}
+
+	es := &eventStore{
+		Publisher: publisher,
+		protocol:  protocol,
+		instance:  instance,
+		interval:  interval,
+		published: make(map[string]time.Time),
+		conns:     make(map[connection]struct{}),
+		lastSweep: time.Now(),
+	}
+	go es.heartbeat(ctx)
+
+	return es, nil
+}
+
+func (es *eventStore) Connect(ctx context.Context, thingID, connID string) error {
+	c := connection{thingID: thingID, connID: connID}
+	es.mu.Lock()
+	es.conns[c] = struct{}{}
+	es.mu.Unlock()
+
+	return es.publish(ctx, c, ConnectOp, time.Now())
+}
+
+func (es *eventStore) Disconnect(ctx context.Context, thingID, connID string) error {
+	c := connection{thingID: thingID, connID: connID}
+	es.mu.Lock()
+	delete(es.conns, c)
+	es.mu.Unlock()
+
+	return es.publish(ctx, c, DisconnectOp, time.Now())
+}
+
+func (es *eventStore) Published(ctx context.Context, thingID string) error {
+	now := time.Now()
+	if !es.due(thingID, now) {
+		return nil
+	}
+
+	return es.publish(ctx, connection{thingID: thingID}, PublishOp, now)
+}
+
+// heartbeat publishes the heartbeat events of the open connections.
+func (es *eventStore) heartbeat(ctx context.Context) {
+	ticker := time.NewTicker(es.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			es.mu.Lock()
+			conns := make([]connection, 0, len(es.conns))
+			for c := range es.conns {
+				conns = append(conns, c)
+			}
+			es.mu.Unlock()
+
+			// Errors are not fatal, since the connections are reported
+			// again on the next tick.
+			for _, c := range conns {
+				_ = es.publish(ctx, c, HeartbeatOp, now)
+			}
+		}
+	}
+}
+
+func (es *eventStore) publish(ctx context.Context, c connection, operation string, occurredAt time.Time) error {
+	ev := presenceEvent{
+		thingID:    c.thingID,
+		connID:     c.connID,
+		operation:  operation,
+		protocol:   es.protocol,
+		instance:   es.instance,
+		occurredAt: occurredAt,
+	}
+
+	return es.Publish(ctx, ev)
+}
+
+// due reports whether the publish event of the thing should be issued.
+func (es *eventStore) due(thingID string, now time.Time) bool {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	if last, ok := es.published[thingID]; ok && now.Sub(last) < es.interval {
+		return false
+	}
+	es.published[thingID] = now
+
+	if now.Sub(es.lastSweep) >= es.interval {
+		for id, last := range es.published {
+			if now.Sub(last) >= es.interval {
+				delete(es.published, id)
+			}
+		}
+		es.lastSweep = now
+	}
+
+	return true
+}
@@ -0,0 +1,8 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package retained contains the retention of the last message published to
+// the channel subtopic. Retention is enabled per channel using the channel
+// metadata, and the retained messages are delivered to the new subscribers
+// of all the protocol adapters.
+package retained
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package events contains the things events handler which keeps the channels
+// with enabled message retention up to date.
+package events
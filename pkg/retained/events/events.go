@@ -0,0 +1,89 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/retained"
+)
+
+const (
+	// ThingsStream is the things service events stream. Channels are
+	// managed by the things service, so channel events are published there.
+	ThingsStream = "events.magistrala.things"
+
+	channelPrefix = "group."
+	channelCreate = channelPrefix + "create"
+	channelUpdate = channelPrefix + "update"
+	channelView   = channelPrefix + "view"
+	channelRemove = channelPrefix + "remove"
+)
+
+// Start subscribes to the things events stream and updates the channels
+// with enabled retention. Consumer name should be stable across restarts of
+// the adapter, so the durable consumers are not left behind. If the consumer
+// is shared by several adapter instances, each event updates the channels of
+// a single instance and the others reload the channel retention once it
+// expires.
+func Start(ctx context.Context, consumer string, sub events.Subscriber, channels retained.Channels, repo retained.Repository) error {
+	subCfg := events.SubscriberConfig{
+		Consumer: consumer,
+		Stream:   ThingsStream,
+		Handler:  NewEventHandler(channels, repo),
+	}
+
+	return sub.Subscribe(ctx, subCfg)
+}
+
+type eventHandler struct {
+	channels retained.Channels
+	repo     retained.Repository
+}
+
+// NewEventHandler returns new event handler updating the channels with
+// enabled retention. Retained messages are removed once the channel
+// retention is disabled or the channel is removed.
+func NewEventHandler(channels retained.Channels, repo retained.Repository) events.EventHandler {
+	return &eventHandler{
+		channels: channels,
+		repo:     repo,
+	}
+}
+
+func (eh *eventHandler) Handle(ctx context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	switch msg["operation"] {
+	case channelCreate, channelUpdate, channelView:
+		id := events.Read(msg, "id", "")
+		if id == "" {
+			return svcerr.ErrMalformedEntity
+		}
+		metadata := events.Read(msg, "metadata", map[string]interface{}{})
+		eh.channels.Save(id, metadata)
+
+		// The retention may have been disabled while the channel was
+		// not in the set, so the update always removes the messages
+		// of the channel without retention.
+		if retain, ok := metadata[retained.RetainKey].(bool); msg["operation"] == channelUpdate && (!ok || !retain) {
+			return eh.repo.Remove(ctx, id)
+		}
+	case channelRemove:
+		id := events.Read(msg, "id", "")
+		if id == "" {
+			return svcerr.ErrMalformedEntity
+		}
+		eh.channels.Remove(id)
+
+		return eh.repo.Remove(ctx, id)
+	}
+
+	return nil
+}
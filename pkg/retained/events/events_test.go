@@ -0,0 +1,97 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/retained"
+	"github.com/absmach/magistrala/pkg/retained/events"
+	"github.com/absmach/magistrala/pkg/retained/mocks"
+	thmocks "github.com/absmach/magistrala/things/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testEvent map[string]interface{}
+
+func (e testEvent) Encode() (map[string]interface{}, error) {
+	return e, nil
+}
+
+func TestHandle(t *testing.T) {
+	things := new(thmocks.ThingsServiceClient)
+	things.On("ChannelMetadata", mock.Anything, mock.Anything).Return(nil, svcerr.ErrNotFound)
+	channels := retained.NewChannels(things, time.Minute)
+	repo := new(mocks.Repository)
+	handler := events.NewEventHandler(channels, repo)
+	retain := map[string]interface{}{"retain": true}
+
+	cases := []struct {
+		desc     string
+		event    testEvent
+		retained bool
+		rmCalls  int
+		err      error
+	}{
+		{
+			desc:     "handle channel create event with enabled retention",
+			event:    testEvent{"operation": "group.create", "id": "1", "metadata": retain},
+			retained: true,
+		},
+		{
+			desc:     "handle channel view event with enabled retention",
+			event:    testEvent{"operation": "group.view", "id": "1", "metadata": retain},
+			retained: true,
+		},
+		{
+			desc:    "handle channel update event disabling retention",
+			event:   testEvent{"operation": "group.update", "id": "1", "metadata": map[string]interface{}{}},
+			rmCalls: 1,
+		},
+		{
+			desc:    "handle channel update event without retention",
+			event:   testEvent{"operation": "group.update", "id": "1", "metadata": map[string]interface{}{}},
+			rmCalls: 1,
+		},
+		{
+			desc:  "handle channel view event without retention",
+			event: testEvent{"operation": "group.view", "id": "1", "metadata": map[string]interface{}{}},
+		},
+		{
+			desc:     "handle channel update event enabling retention",
+			event:    testEvent{"operation": "group.update", "id": "1", "metadata": retain},
+			retained: true,
+		},
+		{
+			desc:    "handle channel remove event",
+			event:   testEvent{"operation": "group.remove", "id": "1"},
+			rmCalls: 1,
+		},
+		{
+			desc:  "handle channel event without id",
+			event: testEvent{"operation": "group.update", "metadata": retain},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "handle thing event",
+			event: testEvent{"operation": "thing.update", "id": "1", "metadata": retain},
+		},
+	}
+
+	for _, tc := range cases {
+		rmCall := repo.On("Remove", mock.Anything, "1").Return(nil)
+		err := handler.Handle(context.Background(), tc.event)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.retained, channels.Retained(context.Background(), "1"), fmt.Sprintf("%s: expected retained %t got %t\n", tc.desc, tc.retained, channels.Retained(context.Background(), "1")))
+		repo.AssertNumberOfCalls(t, "Remove", tc.rmCalls)
+		rmCall.Unset()
+		repo.Calls = nil
+	}
+}
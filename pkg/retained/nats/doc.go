@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package nats contains the retained messages repository backed by the
+// NATS JetStream key-value store of the message broker.
+package nats
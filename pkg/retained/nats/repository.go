@@ -0,0 +1,116 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package nats
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/retained"
+	"github.com/nats-io/nats.go/jetstream"
+	"google.golang.org/protobuf/proto"
+)
+
+// bucket is the key-value bucket of the retained messages. The key of the
+// retained message is the channel ID followed by the subtopic, so the
+// subtopic wildcards are matched by the bucket.
+const bucket = "retained"
+
+var _ retained.Repository = (*repository)(nil)
+
+type repository struct {
+	kv jetstream.KeyValue
+}
+
+// NewRepository returns NATS JetStream retained messages repository.
+func NewRepository(ctx context.Context, js jetstream.JetStream) (retained.Repository, error) {
+	kv, err := js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
+		Bucket:      bucket,
+		Description: "Magistrala retained messages",
+		History:     1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository{
+		kv: kv,
+	}, nil
+}
+
+func (repo *repository) Save(ctx context.Context, msg *messaging.Message) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if _, err := repo.kv.Put(ctx, key(msg.GetChannel(), msg.GetSubtopic()), data); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) Retrieve(ctx context.Context, chanID, subtopic string) ([]*messaging.Message, error) {
+	entries, err := repo.entries(ctx, key(chanID, subtopic))
+	if err != nil {
+		return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	msgs := []*messaging.Message{}
+	for _, entry := range entries {
+		var msg messaging.Message
+		if err := proto.Unmarshal(entry.Value(), &msg); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		msgs = append(msgs, &msg)
+	}
+
+	return msgs, nil
+}
+
+func (repo *repository) Remove(ctx context.Context, chanID string) error {
+	for _, keys := range []string{key(chanID, ""), key(chanID, ">")} {
+		entries, err := repo.entries(ctx, keys, jetstream.MetaOnly())
+		if err != nil {
+			return errors.Wrap(repoerr.ErrRemoveEntity, err)
+		}
+		for _, entry := range entries {
+			if err := repo.kv.Purge(ctx, entry.Key()); err != nil {
+				return errors.Wrap(repoerr.ErrRemoveEntity, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// entries returns the current entries of the keys, which may contain wildcards.
+func (repo *repository) entries(ctx context.Context, keys string, opts ...jetstream.WatchOpt) ([]jetstream.KeyValueEntry, error) {
+	w, err := repo.kv.Watch(ctx, keys, append(opts, jetstream.IgnoreDeletes())...)
+	if err != nil {
+		return nil, err
+	}
+	defer w.Stop()
+
+	entries := []jetstream.KeyValueEntry{}
+	for entry := range w.Updates() {
+		// Nil entry marks the end of the current entries.
+		if entry == nil {
+			break
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func key(chanID, subtopic string) string {
+	if subtopic == "" {
+		return chanID
+	}
+
+	return chanID + "." + subtopic
+}
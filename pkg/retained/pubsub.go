@@ -0,0 +1,117 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package retained
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+)
+
+const chansPrefix = "channels."
+
+var (
+	errSaveRetained     = errors.New("failed to save retained message")
+	errRetrieveRetained = errors.New("failed to retrieve retained messages")
+)
+
+var (
+	_ messaging.Publisher = (*publisher)(nil)
+	_ messaging.PubSub    = (*pubsub)(nil)
+)
+
+type publisher struct {
+	messaging.Publisher
+	repo     Repository
+	channels Channels
+	logger   *slog.Logger
+}
+
+// NewPublisher returns the publisher which retains the messages published
+// to the channels with enabled retention.
+func NewPublisher(pub messaging.Publisher, repo Repository, channels Channels, logger *slog.Logger) messaging.Publisher {
+	return &publisher{
+		Publisher: pub,
+		repo:      repo,
+		channels:  channels,
+		logger:    logger,
+	}
+}
+
+func (pub *publisher) Publish(ctx context.Context, topic string, msg *messaging.Message) error {
+	if err := pub.Publisher.Publish(ctx, topic, msg); err != nil {
+		return err
+	}
+	retain(ctx, pub.repo, pub.channels, pub.logger, msg)
+
+	return nil
+}
+
+type pubsub struct {
+	messaging.PubSub
+	repo     Repository
+	channels Channels
+	logger   *slog.Logger
+}
+
+// NewPubSub returns the pubsub which retains the messages published to the
+// channels with enabled retention and delivers the retained messages to
+// every new subscriber of such channels.
+func NewPubSub(ps messaging.PubSub, repo Repository, channels Channels, logger *slog.Logger) messaging.PubSub {
+	return &pubsub{
+		PubSub:   ps,
+		repo:     repo,
+		channels: channels,
+		logger:   logger,
+	}
+}
+
+func (ps *pubsub) Publish(ctx context.Context, topic string, msg *messaging.Message) error {
+	if err := ps.PubSub.Publish(ctx, topic, msg); err != nil {
+		return err
+	}
+	retain(ctx, ps.repo, ps.channels, ps.logger, msg)
+
+	return nil
+}
+
+// Subscribe subscribes to the topic and then delivers the retained messages
+// to the handler, so the messages published in between are not missed. Such
+// messages may be delivered twice.
+func (ps *pubsub) Subscribe(ctx context.Context, cfg messaging.SubscriberConfig) error {
+	if err := ps.PubSub.Subscribe(ctx, cfg); err != nil {
+		return err
+	}
+
+	chanID, subtopic, _ := strings.Cut(strings.TrimPrefix(cfg.Topic, chansPrefix), ".")
+	if !strings.HasPrefix(cfg.Topic, chansPrefix) || !ps.channels.Retained(ctx, chanID) {
+		return nil
+	}
+
+	msgs, err := ps.repo.Retrieve(ctx, chanID, subtopic)
+	if err != nil {
+		ps.logger.Warn(errors.Wrap(errRetrieveRetained, err).Error())
+		return nil
+	}
+	for _, msg := range msgs {
+		if err := cfg.Handler.Handle(msg); err != nil {
+			ps.logger.Warn(fmt.Sprintf("Failed to handle retained message: %s", err))
+		}
+	}
+
+	return nil
+}
+
+func retain(ctx context.Context, repo Repository, channels Channels, logger *slog.Logger, msg *messaging.Message) {
+	if !channels.Retained(ctx, msg.GetChannel()) {
+		return
+	}
+	if err := repo.Save(ctx, msg); err != nil {
+		logger.Warn(errors.Wrap(errSaveRetained, err).Error())
+	}
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redis contains the retained messages repository backed by Redis.
+// Retained messages of the channel are kept in the hash keyed by subtopic.
+package redis
@@ -0,0 +1,76 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/retained"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/proto"
+)
+
+const keyPrefix = "retained"
+
+var _ retained.Repository = (*repository)(nil)
+
+type repository struct {
+	client *redis.Client
+}
+
+// NewRepository returns Redis retained messages repository.
+func NewRepository(client *redis.Client) retained.Repository {
+	return &repository{
+		client: client,
+	}
+}
+
+func (repo *repository) Save(ctx context.Context, msg *messaging.Message) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if err := repo.client.HSet(ctx, key(msg.GetChannel()), msg.GetSubtopic(), data).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) Retrieve(ctx context.Context, chanID, subtopic string) ([]*messaging.Message, error) {
+	values, err := repo.client.HGetAll(ctx, key(chanID)).Result()
+	if err != nil {
+		return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	msgs := []*messaging.Message{}
+	for st, data := range values {
+		if !retained.Match(subtopic, st) {
+			continue
+		}
+		var msg messaging.Message
+		if err := proto.Unmarshal([]byte(data), &msg); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		msgs = append(msgs, &msg)
+	}
+
+	return msgs, nil
+}
+
+func (repo *repository) Remove(ctx context.Context, chanID string) error {
+	if err := repo.client.Del(ctx, key(chanID)).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func key(chanID string) string {
+	return fmt.Sprintf("%s:%s", keyPrefix, chanID)
+}
@@ -0,0 +1,160 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package retained
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+)
+
+// RetainKey is the channel metadata key which enables the message retention,
+// e.g. {"retain": true}.
+const RetainKey = "retain"
+
+// Repository stores the last message published to the channel subtopic.
+//
+//go:generate mockery --name Repository --output=./mocks --filename repository.go --quiet --note "Copyright (c) Abstract Machines"
+type Repository interface {
+	// Save stores the message as the last message of its channel and subtopic.
+	Save(ctx context.Context, msg *messaging.Message) error
+
+	// Retrieve returns the retained messages of the channel whose subtopic
+	// matches the subtopic. Subtopic may contain wildcards.
+	Retrieve(ctx context.Context, chanID, subtopic string) ([]*messaging.Message, error)
+
+	// Remove removes all the retained messages of the channel.
+	Remove(ctx context.Context, chanID string) error
+}
+
+// Channels contains the channels with enabled message retention.
+//
+//go:generate mockery --name Channels --output=./mocks --filename channels.go --quiet --note "Copyright (c) Abstract Machines"
+type Channels interface {
+	// Retained returns true if the channel messages are retained.
+	Retained(ctx context.Context, chanID string) bool
+
+	// Save enables or disables the retention using the channel metadata.
+	Save(chanID string, metadata map[string]interface{})
+
+	// Remove removes the channel, so its retention is loaded again on the
+	// next lookup.
+	Remove(chanID string)
+}
+
+type channel struct {
+	retain    bool
+	expiresAt time.Time
+}
+
+type channels struct {
+	things   magistrala.ThingsServiceClient
+	ttl      time.Duration
+	mu       sync.RWMutex
+	channels map[string]channel
+}
+
+var _ Channels = (*channels)(nil)
+
+// NewChannels returns in-memory set of the channels with enabled retention.
+// The retention of the channel missing from the set is loaded from the
+// channel metadata retrieved from the things service, and it is kept for the
+// given TTL, so the set is not empty after the restart and converges even if
+// the channel events are missed.
+func NewChannels(things magistrala.ThingsServiceClient, ttl time.Duration) Channels {
+	return &channels{
+		things:   things,
+		ttl:      ttl,
+		channels: make(map[string]channel),
+	}
+}
+
+func (c *channels) Retained(ctx context.Context, chanID string) bool {
+	c.mu.RLock()
+	ch, ok := c.channels[chanID]
+	c.mu.RUnlock()
+	if ok && time.Now().Before(ch.expiresAt) {
+		return ch.retain
+	}
+
+	metadata, err := c.load(ctx, chanID)
+	switch {
+	case errors.Contains(err, svcerr.ErrNotFound):
+		metadata = nil
+	case err != nil:
+		// The expired retention is used until the things service is
+		// reachable again.
+		return ch.retain
+	}
+	c.Save(chanID, metadata)
+
+	return retention(metadata)
+}
+
+func (c *channels) Save(chanID string, metadata map[string]interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.channels[chanID] = channel{
+		retain:    retention(metadata),
+		expiresAt: time.Now().Add(c.ttl),
+	}
+}
+
+func (c *channels) Remove(chanID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.channels, chanID)
+}
+
+func (c *channels) load(ctx context.Context, chanID string) (map[string]interface{}, error) {
+	res, err := c.things.ChannelMetadata(ctx, &magistrala.ChannelMetadataReq{ChannelId: chanID})
+	if err != nil {
+		return nil, err
+	}
+	var metadata map[string]interface{}
+	if len(res.GetMetadata()) == 0 {
+		return metadata, nil
+	}
+	if err := json.Unmarshal(res.GetMetadata(), &metadata); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+func retention(metadata map[string]interface{}) bool {
+	retain, ok := metadata[RetainKey].(bool)
+	return ok && retain
+}
+
+// Match reports whether the subtopic matches the subtopic pattern. The "*"
+// element of the pattern matches exactly one subtopic element and the ">"
+// element matches one or more trailing elements.
+func Match(pattern, subtopic string) bool {
+	if pattern == "" || subtopic == "" {
+		return pattern == subtopic
+	}
+
+	patternElems := strings.Split(pattern, ".")
+	elems := strings.Split(subtopic, ".")
+	for i, pe := range patternElems {
+		if pe == ">" {
+			return len(elems) > i
+		}
+		if i >= len(elems) || (pe != "*" && pe != elems[i]) {
+			return false
+		}
+	}
+
+	return len(patternElems) == len(elems)
+}
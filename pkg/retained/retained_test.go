@@ -0,0 +1,299 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package retained_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+	msgmocks "github.com/absmach/magistrala/pkg/messaging/mocks"
+	"github.com/absmach/magistrala/pkg/retained"
+	"github.com/absmach/magistrala/pkg/retained/mocks"
+	thmocks "github.com/absmach/magistrala/things/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	chanID   = "1"
+	subtopic = "room.temperature"
+)
+
+type handler struct {
+	msgs []*messaging.Message
+}
+
+func (h *handler) Handle(msg *messaging.Message) error {
+	h.msgs = append(h.msgs, msg)
+	return nil
+}
+
+func (h *handler) Cancel() error {
+	return nil
+}
+
+func newChannels(ttl time.Duration) (retained.Channels, *thmocks.ThingsServiceClient) {
+	things := new(thmocks.ThingsServiceClient)
+	return retained.NewChannels(things, ttl), things
+}
+
+func TestChannels(t *testing.T) {
+	channels, things := newChannels(time.Minute)
+	things.On("ChannelMetadata", mock.Anything, mock.Anything).Return(nil, svcerr.ErrNotFound)
+
+	cases := []struct {
+		desc     string
+		metadata map[string]interface{}
+		retained bool
+	}{
+		{
+			desc:     "save channel with enabled retention",
+			metadata: map[string]interface{}{"retain": true},
+			retained: true,
+		},
+		{
+			desc:     "save channel with disabled retention",
+			metadata: map[string]interface{}{"retain": false},
+		},
+		{
+			desc:     "save channel with malformed retention",
+			metadata: map[string]interface{}{"retain": "true"},
+		},
+		{
+			desc:     "save channel without retention",
+			metadata: map[string]interface{}{},
+		},
+	}
+
+	for _, tc := range cases {
+		channels.Save(chanID, tc.metadata)
+		retained := channels.Retained(context.Background(), chanID)
+		assert.Equal(t, tc.retained, retained, fmt.Sprintf("%s: expected %t got %t", tc.desc, tc.retained, retained))
+	}
+
+	channels.Save(chanID, map[string]interface{}{"retain": true})
+	channels.Remove(chanID)
+	assert.False(t, channels.Retained(context.Background(), chanID), "remove channel: expected channel not to be retained")
+}
+
+func TestChannelsLoad(t *testing.T) {
+	cases := []struct {
+		desc     string
+		ttl      time.Duration
+		saved    map[string]interface{}
+		res      *magistrala.ChannelMetadataRes
+		loadErr  error
+		loads    int
+		retained bool
+	}{
+		{
+			desc:     "load channel with enabled retention",
+			ttl:      time.Minute,
+			res:      &magistrala.ChannelMetadataRes{Metadata: []byte(`{"retain": true}`)},
+			loads:    1,
+			retained: true,
+		},
+		{
+			desc:  "load channel without metadata",
+			ttl:   time.Minute,
+			res:   &magistrala.ChannelMetadataRes{},
+			loads: 1,
+		},
+		{
+			desc:    "load non existing channel",
+			ttl:     time.Minute,
+			loadErr: svcerr.ErrNotFound,
+			loads:   1,
+		},
+		{
+			desc:    "load channel with failed to retrieve metadata",
+			ttl:     time.Minute,
+			loadErr: svcerr.ErrAuthentication,
+			loads:   1,
+		},
+		{
+			desc:     "lookup saved channel",
+			ttl:      time.Minute,
+			saved:    map[string]interface{}{"retain": true},
+			retained: true,
+		},
+		{
+			desc:     "lookup expired channel with failed to retrieve metadata",
+			ttl:      -time.Minute,
+			saved:    map[string]interface{}{"retain": true},
+			loadErr:  svcerr.ErrAuthentication,
+			loads:    1,
+			retained: true,
+		},
+		{
+			desc:  "reload expired channel",
+			ttl:   -time.Minute,
+			saved: map[string]interface{}{"retain": true},
+			res:   &magistrala.ChannelMetadataRes{Metadata: []byte(`{"retain": false}`)},
+			loads: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		channels, things := newChannels(tc.ttl)
+		if tc.saved != nil {
+			channels.Save(chanID, tc.saved)
+		}
+		things.On("ChannelMetadata", mock.Anything, &magistrala.ChannelMetadataReq{ChannelId: chanID}).Return(tc.res, tc.loadErr)
+		retained := channels.Retained(context.Background(), chanID)
+		assert.Equal(t, tc.retained, retained, fmt.Sprintf("%s: expected %t got %t", tc.desc, tc.retained, retained))
+		things.AssertNumberOfCalls(t, "ChannelMetadata", tc.loads)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	cases := []struct {
+		pattern  string
+		subtopic string
+		match    bool
+	}{
+		{pattern: "", subtopic: "", match: true},
+		{pattern: "", subtopic: "room", match: false},
+		{pattern: "room", subtopic: "", match: false},
+		{pattern: "room.temperature", subtopic: "room.temperature", match: true},
+		{pattern: "room.temperature", subtopic: "room.humidity", match: false},
+		{pattern: "room.*", subtopic: "room.temperature", match: true},
+		{pattern: "room.*", subtopic: "room.temperature.max", match: false},
+		{pattern: "*.temperature", subtopic: "room.temperature", match: true},
+		{pattern: "room.>", subtopic: "room.temperature.max", match: true},
+		{pattern: "room.>", subtopic: "room", match: false},
+		{pattern: ">", subtopic: "room", match: true},
+		{pattern: "room", subtopic: "room.temperature", match: false},
+	}
+
+	for _, tc := range cases {
+		match := retained.Match(tc.pattern, tc.subtopic)
+		assert.Equal(t, tc.match, match, fmt.Sprintf("match %q with %q: expected %t got %t", tc.pattern, tc.subtopic, tc.match, match))
+	}
+}
+
+func TestPublish(t *testing.T) {
+	ps := new(msgmocks.PubSub)
+	repo := new(mocks.Repository)
+	channels, things := newChannels(time.Minute)
+	things.On("ChannelMetadata", mock.Anything, mock.Anything).Return(nil, svcerr.ErrNotFound)
+	channels.Save(chanID, map[string]interface{}{"retain": true})
+	pub := retained.NewPublisher(ps, repo, channels, mglog.NewMock())
+
+	cases := []struct {
+		desc       string
+		msg        *messaging.Message
+		publishErr error
+		saveCalls  int
+		err        error
+	}{
+		{
+			desc:      "publish to channel with enabled retention",
+			msg:       &messaging.Message{Channel: chanID, Subtopic: subtopic, Payload: []byte(`{"v":1}`)},
+			saveCalls: 1,
+		},
+		{
+			desc: "publish to channel without retention",
+			msg:  &messaging.Message{Channel: "2", Payload: []byte(`{"v":1}`)},
+		},
+		{
+			desc:       "publish with failed publish",
+			msg:        &messaging.Message{Channel: chanID, Payload: []byte(`{"v":1}`)},
+			publishErr: errors.New("failed"),
+			err:        errors.New("failed"),
+		},
+	}
+
+	for _, tc := range cases {
+		pubCall := ps.On("Publish", mock.Anything, tc.msg.GetChannel(), tc.msg).Return(tc.publishErr)
+		saveCall := repo.On("Save", mock.Anything, tc.msg).Return(nil)
+		err := pub.Publish(context.Background(), tc.msg.GetChannel(), tc.msg)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		repo.AssertNumberOfCalls(t, "Save", tc.saveCalls)
+		pubCall.Unset()
+		saveCall.Unset()
+		repo.Calls = nil
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	ps := new(msgmocks.PubSub)
+	repo := new(mocks.Repository)
+	channels, things := newChannels(time.Minute)
+	things.On("ChannelMetadata", mock.Anything, mock.Anything).Return(nil, svcerr.ErrNotFound)
+	channels.Save(chanID, map[string]interface{}{"retain": true})
+	pubsub := retained.NewPubSub(ps, repo, channels, mglog.NewMock())
+
+	msg := &messaging.Message{Channel: chanID, Subtopic: subtopic, Payload: []byte(`{"v":1}`)}
+
+	cases := []struct {
+		desc         string
+		topic        string
+		chanID       string
+		subtopic     string
+		retained     []*messaging.Message
+		retrieveErr  error
+		subscribeErr error
+		received     int
+		err          error
+	}{
+		{
+			desc:     "subscribe to channel with enabled retention",
+			topic:    "channels.1",
+			chanID:   chanID,
+			retained: []*messaging.Message{msg},
+			received: 1,
+		},
+		{
+			desc:     "subscribe to channel subtopic with enabled retention",
+			topic:    "channels.1.room.>",
+			chanID:   chanID,
+			subtopic: "room.>",
+			retained: []*messaging.Message{msg},
+			received: 1,
+		},
+		{
+			desc:   "subscribe to channel without retention",
+			topic:  "channels.2",
+			chanID: "2",
+		},
+		{
+			desc:   "subscribe to all channels",
+			topic:  "channels.>",
+			chanID: ">",
+		},
+		{
+			desc:        "subscribe to channel with failed retrieval",
+			topic:       "channels.1",
+			chanID:      chanID,
+			retrieveErr: errors.New("failed"),
+		},
+		{
+			desc:         "subscribe with failed subscription",
+			topic:        "channels.1",
+			chanID:       chanID,
+			subscribeErr: errors.New("failed"),
+			err:          errors.New("failed"),
+		},
+	}
+
+	for _, tc := range cases {
+		h := &handler{}
+		cfg := messaging.SubscriberConfig{ID: "id", Topic: tc.topic, Handler: h}
+		subCall := ps.On("Subscribe", mock.Anything, cfg).Return(tc.subscribeErr)
+		retrieveCall := repo.On("Retrieve", mock.Anything, tc.chanID, tc.subtopic).Return(tc.retained, tc.retrieveErr)
+		err := pubsub.Subscribe(context.Background(), cfg)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Len(t, h.msgs, tc.received, fmt.Sprintf("%s: expected %d retained messages got %d", tc.desc, tc.received, len(h.msgs)))
+		subCall.Unset()
+		retrieveCall.Unset()
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package store contains the setup of the retained messages repository.
+package store
+
+import (
+	"context"
+	"io"
+	"net/url"
+
+	redisclient "github.com/absmach/magistrala/internal/clients/redis"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/retained"
+	"github.com/absmach/magistrala/pkg/retained/nats"
+	"github.com/absmach/magistrala/pkg/retained/redis"
+	broker "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+var errUnsupportedURL = errors.New("unsupported retained messages store URL")
+
+// New returns the retained messages repository of the store with the
+// given URL. The URL scheme selects the store: "redis" and "rediss" for
+// Redis, and "nats" and "tls" for the NATS message broker. Returned closer
+// closes the store connection.
+func New(ctx context.Context, storeURL string) (retained.Repository, io.Closer, error) {
+	u, err := url.Parse(storeURL)
+	if err != nil {
+		return nil, nil, errors.Wrap(errUnsupportedURL, err)
+	}
+
+	switch u.Scheme {
+	case "redis", "rediss":
+		client, err := redisclient.Connect(storeURL)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return redis.NewRepository(client), client, nil
+	case "nats", "tls":
+		conn, err := broker.Connect(storeURL, broker.MaxReconnects(-1))
+		if err != nil {
+			return nil, nil, err
+		}
+		js, err := jetstream.New(conn)
+		if err != nil {
+			conn.Close()
+			return nil, nil, err
+		}
+		repo, err := nats.NewRepository(ctx, js)
+		if err != nil {
+			conn.Close()
+			return nil, nil, err
+		}
+
+		return repo, closer(conn.Close), nil
+	default:
+		return nil, nil, errUnsupportedURL
+	}
+}
+
+type closer func()
+
+func (c closer) Close() error {
+	c()
+	return nil
+}
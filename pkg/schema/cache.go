@@ -0,0 +1,180 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// Channel metadata keys holding the payload schema.
+const (
+	// JSONSchemaKey is the channel metadata key of the JSON Schema.
+	JSONSchemaKey = "schema"
+
+	// SenMLKey is the channel metadata key of the SenML constraints.
+	SenMLKey = "senml"
+)
+
+var errLoadSchema = errors.New("failed to load channel schema")
+
+// Validator validates message payloads published to the channel.
+//
+//go:generate mockery --name Validator --output=./mocks --filename validator.go --quiet --note "Copyright (c) Abstract Machines"
+type Validator interface {
+	// Validate validates the payload against the channel schema. Payloads
+	// published to the channels without schema are always valid.
+	Validate(ctx context.Context, channelID string, payload []byte) error
+}
+
+// Cache contains compiled schemas of the channels.
+//
+//go:generate mockery --name Cache --output=./mocks --filename cache.go --quiet --note "Copyright (c) Abstract Machines"
+type Cache interface {
+	Validator
+
+	// Save compiles the schema from the channel metadata and stores it.
+	// If metadata contains no schema, the channel is stored without schema.
+	Save(channelID string, metadata map[string]interface{}) error
+
+	// Remove removes the channel schema, so it is loaded again on the next
+	// validation.
+	Remove(channelID string)
+}
+
+type channelSchema struct {
+	json      *Schema
+	senml     SenML
+	err       error
+	expiresAt time.Time
+}
+
+type cache struct {
+	things  magistrala.ThingsServiceClient
+	ttl     time.Duration
+	mu      sync.RWMutex
+	schemas map[string]channelSchema
+}
+
+var _ Cache = (*cache)(nil)
+
+// NewCache returns in-memory schema cache. The schema of the channel missing
+// from the cache is loaded from the channel metadata retrieved from the
+// things service, and it is kept for the given TTL, so the cache converges
+// even if the channel events are missed.
+func NewCache(things magistrala.ThingsServiceClient, ttl time.Duration) Cache {
+	return &cache{
+		things:  things,
+		ttl:     ttl,
+		schemas: make(map[string]channelSchema),
+	}
+}
+
+func (c *cache) Save(channelID string, metadata map[string]interface{}) error {
+	cs := c.compile(metadata)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.schemas[channelID] = cs
+
+	return cs.err
+}
+
+func (c *cache) Remove(channelID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.schemas, channelID)
+}
+
+func (c *cache) Validate(ctx context.Context, channelID string, payload []byte) error {
+	cs, err := c.schema(ctx, channelID)
+	if err != nil {
+		return err
+	}
+	// The channel schema which can not be compiled can not be enforced,
+	// so the payloads are rejected until the schema is fixed.
+	if cs.err != nil {
+		return cs.err
+	}
+
+	if cs.json != nil {
+		if err := cs.json.Validate(payload); err != nil {
+			return err
+		}
+	}
+	if cs.senml != nil {
+		if err := cs.senml.Validate(payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// schema returns the cached channel schema, loading it if it's missing or
+// expired. If loading fails, the expired schema is used until the things
+// service is reachable again.
+func (c *cache) schema(ctx context.Context, channelID string) (channelSchema, error) {
+	c.mu.RLock()
+	cs, ok := c.schemas[channelID]
+	c.mu.RUnlock()
+	if ok && time.Now().Before(cs.expiresAt) {
+		return cs, nil
+	}
+
+	metadata, err := c.load(ctx, channelID)
+	switch {
+	case errors.Contains(err, svcerr.ErrNotFound):
+		metadata = nil
+	case err != nil:
+		if ok {
+			return cs, nil
+		}
+		return channelSchema{}, errors.Wrap(errLoadSchema, err)
+	}
+
+	cs = c.compile(metadata)
+	c.mu.Lock()
+	c.schemas[channelID] = cs
+	c.mu.Unlock()
+
+	return cs, nil
+}
+
+func (c *cache) load(ctx context.Context, channelID string) (map[string]interface{}, error) {
+	res, err := c.things.ChannelMetadata(ctx, &magistrala.ChannelMetadataReq{ChannelId: channelID})
+	if err != nil {
+		return nil, err
+	}
+	var metadata map[string]interface{}
+	if len(res.GetMetadata()) == 0 {
+		return metadata, nil
+	}
+	if err := json.Unmarshal(res.GetMetadata(), &metadata); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+func (c *cache) compile(metadata map[string]interface{}) channelSchema {
+	cs := channelSchema{expiresAt: time.Now().Add(c.ttl)}
+	if s, ok := metadata[JSONSchemaKey]; ok {
+		if cs.json, cs.err = Compile(s); cs.err != nil {
+			return cs
+		}
+	}
+	if s, ok := metadata[SenMLKey]; ok {
+		cs.senml, cs.err = CompileSenML(s)
+	}
+
+	return cs
+}
@@ -0,0 +1,235 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package schema_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/schema"
+	thmocks "github.com/absmach/magistrala/things/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newCache(ttl time.Duration) (schema.Cache, *thmocks.ThingsServiceClient) {
+	things := new(thmocks.ThingsServiceClient)
+	return schema.NewCache(things, ttl), things
+}
+
+func TestCacheSave(t *testing.T) {
+	cases := []struct {
+		desc     string
+		metadata map[string]interface{}
+		err      error
+	}{
+		{
+			desc:     "save channel with JSON schema",
+			metadata: map[string]interface{}{schema.JSONSchemaKey: temperatureSchema},
+			err:      nil,
+		},
+		{
+			desc:     "save channel with SenML constraints",
+			metadata: map[string]interface{}{schema.SenMLKey: map[string]interface{}{"temperature": "Cel"}},
+			err:      nil,
+		},
+		{
+			desc:     "save channel without schema",
+			metadata: map[string]interface{}{"location": "room"},
+			err:      nil,
+		},
+		{
+			desc:     "save channel with invalid JSON schema",
+			metadata: map[string]interface{}{schema.JSONSchemaKey: map[string]interface{}{"type": 1}},
+			err:      schema.ErrInvalidSchema,
+		},
+		{
+			desc:     "save channel with invalid SenML constraints",
+			metadata: map[string]interface{}{schema.SenMLKey: []interface{}{"temperature"}},
+			err:      schema.ErrInvalidSchema,
+		},
+	}
+
+	for _, tc := range cases {
+		cache, _ := newCache(time.Minute)
+		err := cache.Save("1", tc.metadata)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestCacheValidate(t *testing.T) {
+	cache, things := newCache(time.Minute)
+	things.On("ChannelMetadata", mock.Anything, mock.Anything).Return(nil, svcerr.ErrNotFound)
+	err := cache.Save("json", map[string]interface{}{schema.JSONSchemaKey: temperatureSchema})
+	assert.Nil(t, err, fmt.Sprintf("failed to save schema with err: %v", err))
+	err = cache.Save("senml", map[string]interface{}{
+		schema.SenMLKey: map[string]interface{}{"temperature": "Cel", "label": ""},
+	})
+	assert.Nil(t, err, fmt.Sprintf("failed to save schema with err: %v", err))
+	err = cache.Save("removed", map[string]interface{}{schema.JSONSchemaKey: false})
+	assert.Nil(t, err, fmt.Sprintf("failed to save schema with err: %v", err))
+	cache.Remove("removed")
+	err = cache.Save("cleared", map[string]interface{}{schema.JSONSchemaKey: false})
+	assert.Nil(t, err, fmt.Sprintf("failed to save schema with err: %v", err))
+	err = cache.Save("cleared", map[string]interface{}{})
+	assert.Nil(t, err, fmt.Sprintf("failed to save schema with err: %v", err))
+
+	cases := []struct {
+		desc    string
+		channel string
+		payload string
+		err     error
+	}{
+		{
+			desc:    "validate payload conforming to JSON schema",
+			channel: "json",
+			payload: `{"temperature": 21.5}`,
+			err:     nil,
+		},
+		{
+			desc:    "validate payload not conforming to JSON schema",
+			channel: "json",
+			payload: `{"temperature": "hot"}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate SenML payload with base name",
+			channel: "senml",
+			payload: `[{"bn":"temp","n":"erature","u":"Cel","v":21.5},{"n":"erature","u":"Cel","v":21.7}]`,
+			err:     nil,
+		},
+		{
+			desc:    "validate SenML payload with invalid unit",
+			channel: "senml",
+			payload: `[{"n":"temperature","u":"K","v":294.6}]`,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate SenML payload with unknown name",
+			channel: "senml",
+			payload: `[{"n":"humidity","u":"%RH","v":40}]`,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate non-SenML payload",
+			channel: "senml",
+			payload: `{"temperature": 21.5}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate payload published to channel without schema",
+			channel: "unknown",
+			payload: `not a JSON`,
+			err:     nil,
+		},
+		{
+			desc:    "validate payload published to channel with removed schema",
+			channel: "removed",
+			payload: `{}`,
+			err:     nil,
+		},
+		{
+			desc:    "validate payload published to channel with cleared schema",
+			channel: "cleared",
+			payload: `{}`,
+			err:     nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := cache.Validate(context.Background(), tc.channel, []byte(tc.payload))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestCacheLoad(t *testing.T) {
+	metadata := []byte(`{"schema": {"type": "object", "required": ["temperature"]}}`)
+
+	cases := []struct {
+		desc    string
+		ttl     time.Duration
+		saved   map[string]interface{}
+		res     *magistrala.ChannelMetadataRes
+		loadErr error
+		payload string
+		loads   int
+		err     error
+	}{
+		{
+			desc:    "validate payload against loaded schema",
+			ttl:     time.Minute,
+			res:     &magistrala.ChannelMetadataRes{Metadata: metadata},
+			payload: `{}`,
+			loads:   1,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate payload published to channel without metadata",
+			ttl:     time.Minute,
+			res:     &magistrala.ChannelMetadataRes{},
+			payload: `not a JSON`,
+			loads:   1,
+			err:     nil,
+		},
+		{
+			desc:    "validate payload published to non existing channel",
+			ttl:     time.Minute,
+			loadErr: svcerr.ErrNotFound,
+			payload: `not a JSON`,
+			loads:   1,
+			err:     nil,
+		},
+		{
+			desc:    "validate payload with failed to load schema",
+			ttl:     time.Minute,
+			loadErr: svcerr.ErrAuthentication,
+			payload: `{}`,
+			loads:   1,
+			err:     svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "validate payload against saved schema",
+			ttl:     time.Minute,
+			saved:   map[string]interface{}{schema.JSONSchemaKey: temperatureSchema},
+			payload: `{"temperature": "hot"}`,
+			loads:   0,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate payload against expired schema with failed to load schema",
+			ttl:     -time.Minute,
+			saved:   map[string]interface{}{schema.JSONSchemaKey: temperatureSchema},
+			loadErr: svcerr.ErrAuthentication,
+			payload: `{"temperature": "hot"}`,
+			loads:   1,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate payload against reloaded expired schema",
+			ttl:     -time.Minute,
+			saved:   map[string]interface{}{schema.JSONSchemaKey: temperatureSchema},
+			res:     &magistrala.ChannelMetadataRes{},
+			payload: `{"temperature": "hot"}`,
+			loads:   1,
+			err:     nil,
+		},
+	}
+
+	for _, tc := range cases {
+		cache, things := newCache(tc.ttl)
+		if tc.saved != nil {
+			err := cache.Save("1", tc.saved)
+			assert.Nil(t, err, fmt.Sprintf("%s: failed to save schema with err: %v", tc.desc, err))
+		}
+		things.On("ChannelMetadata", mock.Anything, &magistrala.ChannelMetadataReq{ChannelId: "1"}).Return(tc.res, tc.loadErr)
+		err := cache.Validate(context.Background(), "1", []byte(tc.payload))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		things.AssertNumberOfCalls(t, "ChannelMetadata", tc.loads)
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package schema contains validation of published message payloads against
+// the schema attached to the channel metadata. Protocol adapters keep the
+// channel schemas in the cache which loads the missing or expired schemas
+// from the things service and is updated using the things events.
+package schema
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package events contains the things events handler which keeps the channel
+// schema cache and the protobuf schema registry up to date.
+package events
@@ -0,0 +1,85 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/events"
+)
+
+const (
+	// ThingsStream is the things service events stream. Channels are
+	// managed by the things service, so channel events are published there.
+	ThingsStream = "events.magistrala.things"
+
+	channelPrefix = "group."
+	channelCreate = channelPrefix + "create"
+	channelUpdate = channelPrefix + "update"
+	channelView   = channelPrefix + "view"
+	channelRemove = channelPrefix + "remove"
+)
+
+// Store keeps the schemas compiled from the channel metadata. It's
+// implemented by the payload schema cache and the protobuf schema registry.
+type Store interface {
+	// Save compiles the schema from the channel metadata and stores it.
+	Save(channelID string, metadata map[string]interface{}) error
+
+	// Remove removes the channel schema.
+	Remove(channelID string)
+}
+
+// Start subscribes to the things events stream and updates the schema store.
+// Consumer name should be stable across restarts of the adapter, so the
+// durable consumers are not left behind. If the consumer is shared by several
+// adapter instances, each event updates the cache of a single instance and
+// the others reload the channel schema once it expires.
+func Start(ctx context.Context, consumer string, sub events.Subscriber, store Store) error {
+	subCfg := events.SubscriberConfig{
+		Consumer: consumer,
+		Stream:   ThingsStream,
+		Handler:  NewEventHandler(store),
+	}
+
+	return sub.Subscribe(ctx, subCfg)
+}
+
+type eventHandler struct {
+	store Store
+}
+
+// NewEventHandler returns new event handler updating channel schemas.
+func NewEventHandler(store Store) events.EventHandler {
+	return &eventHandler{
+		store: store,
+	}
+}
+
+func (eh *eventHandler) Handle(ctx context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	switch msg["operation"] {
+	case channelCreate, channelUpdate, channelView:
+		id := events.Read(msg, "id", "")
+		if id == "" {
+			return svcerr.ErrMalformedEntity
+		}
+		metadata := events.Read(msg, "metadata", map[string]interface{}{})
+
+		return eh.store.Save(id, metadata)
+	case channelRemove:
+		id := events.Read(msg, "id", "")
+		if id == "" {
+			return svcerr.ErrMalformedEntity
+		}
+		eh.store.Remove(id)
+	}
+
+	return nil
+}
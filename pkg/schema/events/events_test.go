@@ -0,0 +1,78 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/schema/events"
+	"github.com/absmach/magistrala/pkg/schema/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+type testEvent map[string]interface{}
+
+func (e testEvent) Encode() (map[string]interface{}, error) {
+	return e, nil
+}
+
+func TestHandle(t *testing.T) {
+	cache := new(mocks.Cache)
+	handler := events.NewEventHandler(cache)
+	metadata := map[string]interface{}{"schema": map[string]interface{}{"type": "object"}}
+
+	cases := []struct {
+		desc      string
+		event     testEvent
+		saveCalls int
+		rmCalls   int
+		err       error
+	}{
+		{
+			desc:      "handle channel create event",
+			event:     testEvent{"operation": "group.create", "id": "1", "metadata": metadata},
+			saveCalls: 1,
+		},
+		{
+			desc:      "handle channel update event",
+			event:     testEvent{"operation": "group.update", "id": "1", "metadata": metadata},
+			saveCalls: 1,
+		},
+		{
+			desc:      "handle channel view event",
+			event:     testEvent{"operation": "group.view", "id": "1", "metadata": metadata},
+			saveCalls: 1,
+		},
+		{
+			desc:    "handle channel remove event",
+			event:   testEvent{"operation": "group.remove", "id": "1"},
+			rmCalls: 1,
+		},
+		{
+			desc:  "handle channel event without id",
+			event: testEvent{"operation": "group.update", "metadata": metadata},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "handle thing event",
+			event: testEvent{"operation": "thing.update", "id": "1", "metadata": metadata},
+		},
+	}
+
+	for _, tc := range cases {
+		saveCall := cache.On("Save", "1", metadata).Return(nil)
+		rmCall := cache.On("Remove", "1").Return()
+		err := handler.Handle(context.Background(), tc.event)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		cache.AssertNumberOfCalls(t, "Save", tc.saveCalls)
+		cache.AssertNumberOfCalls(t, "Remove", tc.rmCalls)
+		saveCall.Unset()
+		rmCall.Unset()
+		cache.Calls = nil
+	}
+}
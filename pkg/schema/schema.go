@@ -0,0 +1,425 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// JSON types supported by the schema "type" keyword.
+const (
+	objectType  = "object"
+	arrayType   = "array"
+	stringType  = "string"
+	numberType  = "number"
+	integerType = "integer"
+	booleanType = "boolean"
+	nullType    = "null"
+)
+
+var (
+	// ErrInvalidSchema indicates that the channel schema is malformed.
+	ErrInvalidSchema = errors.New("invalid payload schema")
+
+	// ErrInvalidPayload indicates that the payload does not conform to the channel schema.
+	ErrInvalidPayload = errors.New("payload does not conform to channel schema")
+)
+
+// Schema is a compiled JSON Schema. It supports the subset of JSON Schema
+// draft 2020-12 validation keywords which is commonly used to describe
+// device payloads: type, enum, const, properties, required,
+// additionalProperties, items, minItems, maxItems, minimum, maximum,
+// exclusiveMinimum, exclusiveMaximum, multipleOf, minLength, maxLength,
+// pattern, allOf, anyOf, oneOf and not. Other keywords are ignored.
+type Schema struct {
+	types            []string
+	enum             []interface{}
+	constant         *interface{}
+	properties       map[string]*Schema
+	required         []string
+	additional       *Schema
+	noAdditional     bool
+	items            *Schema
+	minItems         *int
+	maxItems         *int
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+	multipleOf       *float64
+	minLength        *int
+	maxLength        *int
+	pattern          *regexp.Regexp
+	allOf            []*Schema
+	anyOf            []*Schema
+	oneOf            []*Schema
+	not              *Schema
+	alwaysFalse      bool
+}
+
+type rawSchema struct {
+	Type                 json.RawMessage            `json:"type"`
+	Enum                 []interface{}              `json:"enum"`
+	Const                json.RawMessage            `json:"const"`
+	Properties           map[string]json.RawMessage `json:"properties"`
+	Required             []string                   `json:"required"`
+	AdditionalProperties json.RawMessage            `json:"additionalProperties"`
+	Items                json.RawMessage            `json:"items"`
+	MinItems             *int                       `json:"minItems"`
+	MaxItems             *int                       `json:"maxItems"`
+	Minimum              *float64                   `json:"minimum"`
+	Maximum              *float64                   `json:"maximum"`
+	ExclusiveMinimum     *float64                   `json:"exclusiveMinimum"`
+	ExclusiveMaximum     *float64                   `json:"exclusiveMaximum"`
+	MultipleOf           *float64                   `json:"multipleOf"`
+	MinLength            *int                       `json:"minLength"`
+	MaxLength            *int                       `json:"maxLength"`
+	Pattern              *string                    `json:"pattern"`
+	AllOf                []json.RawMessage          `json:"allOf"`
+	AnyOf                []json.RawMessage          `json:"anyOf"`
+	OneOf                []json.RawMessage          `json:"oneOf"`
+	Not                  json.RawMessage            `json:"not"`
+}
+
+// Compile compiles the JSON Schema. Schema is usually a value of the channel
+// metadata, so it is accepted in any form which can be encoded to JSON.
+func Compile(schema interface{}) (*Schema, error) {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidSchema, err)
+	}
+
+	return compile(data)
+}
+
+func compile(data json.RawMessage) (*Schema, error) {
+	// Boolean schemas accept, or reject, any value.
+	var b bool
+	if err := json.Unmarshal(data, &b); err == nil {
+		return &Schema{alwaysFalse: !b}, nil
+	}
+
+	var raw rawSchema
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Wrap(ErrInvalidSchema, err)
+	}
+
+	s := &Schema{
+		enum:             raw.Enum,
+		required:         raw.Required,
+		minItems:         raw.MinItems,
+		maxItems:         raw.MaxItems,
+		minimum:          raw.Minimum,
+		maximum:          raw.Maximum,
+		exclusiveMinimum: raw.ExclusiveMinimum,
+		exclusiveMaximum: raw.ExclusiveMaximum,
+		multipleOf:       raw.MultipleOf,
+		minLength:        raw.MinLength,
+		maxLength:        raw.MaxLength,
+	}
+
+	if len(raw.Type) > 0 {
+		var t string
+		if err := json.Unmarshal(raw.Type, &t); err == nil {
+			s.types = []string{t}
+		} else if err := json.Unmarshal(raw.Type, &s.types); err != nil {
+			return nil, errors.Wrap(ErrInvalidSchema, err)
+		}
+		for _, t := range s.types {
+			switch t {
+			case objectType, arrayType, stringType, numberType, integerType, booleanType, nullType:
+			default:
+				return nil, errors.Wrap(ErrInvalidSchema, fmt.Errorf("unknown type %q", t))
+			}
+		}
+	}
+	if len(raw.Const) > 0 {
+		var c interface{}
+		if err := json.Unmarshal(raw.Const, &c); err != nil {
+			return nil, errors.Wrap(ErrInvalidSchema, err)
+		}
+		s.constant = &c
+	}
+	if raw.MultipleOf != nil && *raw.MultipleOf <= 0 {
+		return nil, errors.Wrap(ErrInvalidSchema, fmt.Errorf("multipleOf must be greater than 0"))
+	}
+	if raw.Pattern != nil {
+		re, err := regexp.Compile(*raw.Pattern)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidSchema, err)
+		}
+		s.pattern = re
+	}
+
+	var err error
+	if len(raw.Properties) > 0 {
+		s.properties = make(map[string]*Schema, len(raw.Properties))
+		for name, p := range raw.Properties {
+			if s.properties[name], err = compile(p); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(raw.AdditionalProperties) > 0 {
+		if s.additional, err = compile(raw.AdditionalProperties); err != nil {
+			return nil, err
+		}
+		s.noAdditional = s.additional.alwaysFalse
+	}
+	if len(raw.Items) > 0 {
+		if s.items, err = compile(raw.Items); err != nil {
+			return nil, err
+		}
+	}
+	if len(raw.Not) > 0 {
+		if s.not, err = compile(raw.Not); err != nil {
+			return nil, err
+		}
+	}
+	if s.allOf, err = compileAll(raw.AllOf); err != nil {
+		return nil, err
+	}
+	if s.anyOf, err = compileAll(raw.AnyOf); err != nil {
+		return nil, err
+	}
+	if s.oneOf, err = compileAll(raw.OneOf); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func compileAll(raws []json.RawMessage) ([]*Schema, error) {
+	var schemas []*Schema
+	for _, r := range raws {
+		s, err := compile(r)
+		if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, s)
+	}
+
+	return schemas, nil
+}
+
+// Validate validates JSON encoded payload against the schema.
+func (s *Schema) Validate(payload []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(payload, &v); err != nil {
+		return errors.Wrap(ErrInvalidPayload, err)
+	}
+	if err := s.validate("", v); err != nil {
+		return errors.Wrap(ErrInvalidPayload, err)
+	}
+
+	return nil
+}
+
+func (s *Schema) validate(path string, v interface{}) error {
+	if s.alwaysFalse {
+		return violation(path, "value is not allowed")
+	}
+	if len(s.types) > 0 && !s.matchesType(v) {
+		return violation(path, fmt.Sprintf("expected %v", s.types))
+	}
+	if s.constant != nil && !reflect.DeepEqual(*s.constant, v) {
+		return violation(path, fmt.Sprintf("expected constant %v", *s.constant))
+	}
+	if len(s.enum) > 0 && !contains(s.enum, v) {
+		return violation(path, fmt.Sprintf("expected one of %v", s.enum))
+	}
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if err := s.validateObject(path, val); err != nil {
+			return err
+		}
+	case []interface{}:
+		if err := s.validateArray(path, val); err != nil {
+			return err
+		}
+	case string:
+		if err := s.validateString(path, val); err != nil {
+			return err
+		}
+	case float64:
+		if err := s.validateNumber(path, val); err != nil {
+			return err
+		}
+	}
+
+	for _, sub := range s.allOf {
+		if err := sub.validate(path, v); err != nil {
+			return err
+		}
+	}
+	if len(s.anyOf) > 0 {
+		valid := false
+		for _, sub := range s.anyOf {
+			if sub.validate(path, v) == nil {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return violation(path, "value does not match any of the schemas")
+		}
+	}
+	if len(s.oneOf) > 0 {
+		matches := 0
+		for _, sub := range s.oneOf {
+			if sub.validate(path, v) == nil {
+				matches++
+			}
+		}
+		if matches != 1 {
+			return violation(path, "value must match exactly one schema")
+		}
+	}
+	if s.not != nil && s.not.validate(path, v) == nil {
+		return violation(path, "value must not match the schema")
+	}
+
+	return nil
+}
+
+func (s *Schema) validateObject(path string, obj map[string]interface{}) error {
+	for _, r := range s.required {
+		if _, ok := obj[r]; !ok {
+			return violation(path, fmt.Sprintf("missing required property %q", r))
+		}
+	}
+	for name, val := range obj {
+		p := path + "/" + name
+		if ps, ok := s.properties[name]; ok {
+			if err := ps.validate(p, val); err != nil {
+				return err
+			}
+			continue
+		}
+		if s.noAdditional {
+			return violation(p, "additional property is not allowed")
+		}
+		if s.additional != nil {
+			if err := s.additional.validate(p, val); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) validateArray(path string, arr []interface{}) error {
+	if s.minItems != nil && len(arr) < *s.minItems {
+		return violation(path, fmt.Sprintf("expected at least %d items", *s.minItems))
+	}
+	if s.maxItems != nil && len(arr) > *s.maxItems {
+		return violation(path, fmt.Sprintf("expected at most %d items", *s.maxItems))
+	}
+	if s.items != nil {
+		for i, item := range arr {
+			if err := s.items.validate(fmt.Sprintf("%s/%d", path, i), item); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) validateString(path, str string) error {
+	l := utf8.RuneCountInString(str)
+	if s.minLength != nil && l < *s.minLength {
+		return violation(path, fmt.Sprintf("expected at least %d characters", *s.minLength))
+	}
+	if s.maxLength != nil && l > *s.maxLength {
+		return violation(path, fmt.Sprintf("expected at most %d characters", *s.maxLength))
+	}
+	if s.pattern != nil && !s.pattern.MatchString(str) {
+		return violation(path, fmt.Sprintf("expected to match %q", s.pattern.String()))
+	}
+
+	return nil
+}
+
+func (s *Schema) validateNumber(path string, num float64) error {
+	if s.minimum != nil && num < *s.minimum {
+		return violation(path, fmt.Sprintf("expected minimum %v", *s.minimum))
+	}
+	if s.maximum != nil && num > *s.maximum {
+		return violation(path, fmt.Sprintf("expected maximum %v", *s.maximum))
+	}
+	if s.exclusiveMinimum != nil && num <= *s.exclusiveMinimum {
+		return violation(path, fmt.Sprintf("expected greater than %v", *s.exclusiveMinimum))
+	}
+	if s.exclusiveMaximum != nil && num >= *s.exclusiveMaximum {
+		return violation(path, fmt.Sprintf("expected less than %v", *s.exclusiveMaximum))
+	}
+	if s.multipleOf != nil {
+		if q := num / *s.multipleOf; q != math.Trunc(q) {
+			return violation(path, fmt.Sprintf("expected multiple of %v", *s.multipleOf))
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) matchesType(v interface{}) bool {
+	for _, t := range s.types {
+		switch val := v.(type) {
+		case map[string]interface{}:
+			if t == objectType {
+				return true
+			}
+		case []interface{}:
+			if t == arrayType {
+				return true
+			}
+		case string:
+			if t == stringType {
+				return true
+			}
+		case float64:
+			if t == numberType || (t == integerType && val == math.Trunc(val)) {
+				return true
+			}
+		case bool:
+			if t == booleanType {
+				return true
+			}
+		case nil:
+			if t == nullType {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func contains(vals []interface{}, v interface{}) bool {
+	for _, val := range vals {
+		if reflect.DeepEqual(val, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func violation(path, msg string) error {
+	if path == "" {
+		path = "/"
+	}
+
+	return fmt.Errorf("%s: %s", path, msg)
+}
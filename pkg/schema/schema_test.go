@@ -0,0 +1,135 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package schema_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+var temperatureSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"temperature": map[string]interface{}{
+			"type":    "number",
+			"minimum": -50,
+			"maximum": 150,
+		},
+		"unit": map[string]interface{}{
+			"enum": []interface{}{"C", "F"},
+		},
+		"tags": map[string]interface{}{
+			"type":     "array",
+			"items":    map[string]interface{}{"type": "string", "pattern": "^[a-z]+$"},
+			"maxItems": 2,
+		},
+	},
+	"required":             []interface{}{"temperature"},
+	"additionalProperties": false,
+}
+
+func TestCompile(t *testing.T) {
+	cases := []struct {
+		desc   string
+		schema interface{}
+		err    error
+	}{
+		{
+			desc:   "compile valid schema",
+			schema: temperatureSchema,
+			err:    nil,
+		},
+		{
+			desc:   "compile boolean schema",
+			schema: true,
+			err:    nil,
+		},
+		{
+			desc:   "compile schema with unknown type",
+			schema: map[string]interface{}{"type": "decimal"},
+			err:    schema.ErrInvalidSchema,
+		},
+		{
+			desc:   "compile schema with invalid pattern",
+			schema: map[string]interface{}{"type": "string", "pattern": "(["},
+			err:    schema.ErrInvalidSchema,
+		},
+		{
+			desc:   "compile non-object schema",
+			schema: "object",
+			err:    schema.ErrInvalidSchema,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := schema.Compile(tc.schema)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	s, err := schema.Compile(temperatureSchema)
+	assert.Nil(t, err, fmt.Sprintf("failed to compile schema with err: %v", err))
+
+	cases := []struct {
+		desc    string
+		payload string
+		err     error
+	}{
+		{
+			desc:    "validate conforming payload",
+			payload: `{"temperature": 21.5, "unit": "C", "tags": ["room"]}`,
+			err:     nil,
+		},
+		{
+			desc:    "validate payload with missing required property",
+			payload: `{"unit": "C"}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate payload with invalid property type",
+			payload: `{"temperature": "hot"}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate payload with value out of range",
+			payload: `{"temperature": 200}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate payload with value not in enum",
+			payload: `{"temperature": 21.5, "unit": "K"}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate payload with additional property",
+			payload: `{"temperature": 21.5, "humidity": 40}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate payload with invalid array item",
+			payload: `{"temperature": 21.5, "tags": ["Room"]}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate payload with too many array items",
+			payload: `{"temperature": 21.5, "tags": ["a", "b", "c"]}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate malformed payload",
+			payload: `{"temperature":`,
+			err:     schema.ErrInvalidPayload,
+		},
+	}
+
+	for _, tc := range cases {
+		err := s.Validate([]byte(tc.payload))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
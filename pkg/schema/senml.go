@@ -0,0 +1,59 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"fmt"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/senml"
+)
+
+// SenML constrains the names and units of the SenML records published to
+// the channel. It maps the allowed record name, resolved using the base
+// name, to the required unit. Empty unit allows any unit for the name.
+type SenML map[string]string
+
+// CompileSenML compiles SenML constraints from the channel metadata value.
+// Constraints are given as an object mapping record names to units, e.g.
+// {"temperature": "Cel", "humidity": "%RH", "label": ""}.
+func CompileSenML(constraints interface{}) (SenML, error) {
+	m, ok := constraints.(map[string]interface{})
+	if !ok {
+		return nil, errors.Wrap(ErrInvalidSchema, fmt.Errorf("senml constraints must be an object"))
+	}
+	c := make(SenML, len(m))
+	for name, unit := range m {
+		u, ok := unit.(string)
+		if !ok {
+			return nil, errors.Wrap(ErrInvalidSchema, fmt.Errorf("unit of %q must be a string", name))
+		}
+		c[name] = u
+	}
+
+	return c, nil
+}
+
+// Validate validates SenML JSON payload against the constraints.
+func (c SenML) Validate(payload []byte) error {
+	raw, err := senml.Decode(payload, senml.JSON)
+	if err != nil {
+		return errors.Wrap(ErrInvalidPayload, err)
+	}
+	normalized, err := senml.Normalize(raw)
+	if err != nil {
+		return errors.Wrap(ErrInvalidPayload, err)
+	}
+	for _, r := range normalized.Records {
+		unit, ok := c[r.Name]
+		if !ok {
+			return errors.Wrap(ErrInvalidPayload, fmt.Errorf("record name %q is not allowed", r.Name))
+		}
+		if unit != "" && r.Unit != unit {
+			return errors.Wrap(ErrInvalidPayload, fmt.Errorf("record %q expected unit %q got %q", r.Name, unit, r.Unit))
+		}
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -25,13 +26,18 @@ const (
 	bootstrapCertsEndpoint = "things/configs/certs"
 	bootstrapConnEndpoint  = "things/configs/connections"
 	secureEndpoint         = "secure"
+
+	legacyKeyVersion = 0
 )
 
+var errInvalidBootstrapResponse = errors.New("invalid secure bootstrap response")
+
 // BootstrapConfig represents Configuration entity. It wraps information about external entity
 // as well as info about corresponding Magistrala entities.
 // MGThing represents corresponding Magistrala Thing ID.
 // MGKey is key of corresponding Magistrala Thing.
 // MGChannels is a list of Magistrala Channels corresponding Magistrala Thing connects to.
+// DeviceKey is the secure bootstrap key of the device, derived from the master key of KeyVersion.
 type BootstrapConfig struct {
 	Channels    interface{} `json:"channels,omitempty"`
 	ExternalID  string      `json:"external_id,omitempty"`
@@ -44,6 +50,8 @@ type BootstrapConfig struct {
 	CACert      string      `json:"ca_cert,omitempty"`
 	Content     string      `json:"content,omitempty"`
 	State       int         `json:"state,omitempty"`
+	DeviceKey   string      `json:"device_key,omitempty"`
+	KeyVersion  uint8       `json:"key_version,omitempty"`
 }
 
 func (ts *BootstrapConfig) UnmarshalJSON(data []byte) error {
@@ -77,6 +85,8 @@ func (ts *BootstrapConfig) UnmarshalJSON(data []byte) error {
 		CACert      *string `json:"ca_cert,omitempty"`
 		Content     *string `json:"content,omitempty"`
 		State       *int    `json:"state,omitempty"`
+		DeviceKey   *string `json:"device_key,omitempty"`
+		KeyVersion  *uint8  `json:"key_version,omitempty"`
 	}{
 		ExternalID:  &ts.ExternalID,
 		ExternalKey: &ts.ExternalKey,
@@ -88,6 +98,8 @@ func (ts *BootstrapConfig) UnmarshalJSON(data []byte) error {
 		CACert:      &ts.CACert,
 		Content:     &ts.Content,
 		State:       &ts.State,
+		DeviceKey:   &ts.DeviceKey,
+		KeyVersion:  &ts.KeyVersion,
 	}); err != nil {
 		return err
 	}
@@ -260,34 +272,94 @@ func (sdk mgSDK) Bootstrap(externalID, externalKey string) (BootstrapConfig, err
 	return bc, nil
 }
 
-func (sdk mgSDK) BootstrapSecure(externalID, externalKey, cryptoKey string) (BootstrapConfig, errors.SDKError) {
+func (sdk mgSDK) BootstrapSecure(externalID, externalKey, cryptoKey string, keyVersion uint8) (BootstrapConfig, errors.SDKError) {
 	if externalID == "" {
 		return BootstrapConfig{}, errors.NewSDKError(apiutil.ErrMissingID)
 	}
 	url := fmt.Sprintf("%s/%s/%s/%s", sdk.bootstrapURL, bootstrapEndpoint, secureEndpoint, externalID)
 
-	encExtKey, err := bootstrapEncrypt([]byte(externalKey), cryptoKey)
+	if keyVersion == legacyKeyVersion {
+		encExtKey, err := bootstrapEncrypt([]byte(externalKey), cryptoKey)
+		if err != nil {
+			return BootstrapConfig{}, errors.NewSDKError(err)
+		}
+		_, body, sdkErr := sdk.processRequest(http.MethodGet, url, ThingPrefix+encExtKey, nil, nil, http.StatusOK)
+		if sdkErr != nil {
+			return BootstrapConfig{}, sdkErr
+		}
+		decBody, err := bootstrapDecrypt(body, cryptoKey)
+		if err != nil {
+			return BootstrapConfig{}, errors.NewSDKError(err)
+		}
+		return decodeBootstrapConfig(decBody)
+	}
+
+	aead, err := bootstrapDeviceCipher(cryptoKey)
+	if err != nil {
+		return BootstrapConfig{}, errors.NewSDKError(err)
+	}
+	sealed, err := bootstrapSeal(aead, []byte(externalKey), []byte(externalID))
 	if err != nil {
 		return BootstrapConfig{}, errors.NewSDKError(err)
 	}
+	encExtKey := fmt.Sprintf("%d.%s", keyVersion, hex.EncodeToString(sealed))
 
 	_, body, sdkErr := sdk.processRequest(http.MethodGet, url, ThingPrefix+encExtKey, nil, nil, http.StatusOK)
 	if sdkErr != nil {
 		return BootstrapConfig{}, sdkErr
 	}
-
-	decBody, decErr := bootstrapDecrypt(body, cryptoKey)
-	if decErr != nil {
-		return BootstrapConfig{}, errors.NewSDKError(decErr)
+	if len(body) == 0 || body[0] != keyVersion {
+		return BootstrapConfig{}, errors.NewSDKError(errInvalidBootstrapResponse)
+	}
+	decBody, err := bootstrapOpen(aead, body[1:], []byte(externalID))
+	if err != nil {
+		return BootstrapConfig{}, errors.NewSDKError(err)
 	}
+
+	return decodeBootstrapConfig(decBody)
+}
+
+func decodeBootstrapConfig(body []byte) (BootstrapConfig, errors.SDKError) {
 	var bc BootstrapConfig
-	if err := json.Unmarshal(decBody, &bc); err != nil {
+	if err := json.Unmarshal(body, &bc); err != nil {
 		return BootstrapConfig{}, errors.NewSDKError(err)
 	}
 
 	return bc, nil
 }
 
+// bootstrapDeviceCipher returns the AEAD sealed with the base64 encoded
+// device key.
+func bootstrapDeviceCipher(deviceKey string) (cipher.AEAD, error) {
+	key, err := base64.StdEncoding.DecodeString(deviceKey)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func bootstrapSeal(aead cipher.AEAD, in, ad []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, in, ad), nil
+}
+
+func bootstrapOpen(aead cipher.AEAD, in, ad []byte) ([]byte, error) {
+	if len(in) < aead.NonceSize() {
+		return nil, errInvalidBootstrapResponse
+	}
+
+	return aead.Open(nil, in[:aead.NonceSize()], in[aead.NonceSize():], ad)
+}
+
 func bootstrapEncrypt(in []byte, cryptoKey string) (string, error) {
 	block, err := aes.NewCipher([]byte(cryptoKey))
 	if err != nil {
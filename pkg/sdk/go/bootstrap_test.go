@@ -7,6 +7,7 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -43,6 +44,7 @@ var (
 	state           = 1
 	bsName          = "test"
 	encKey          = []byte("1234567891011121")
+	masterKey       = []byte("12345678910111213141516171819202")
 	bootstrapConfig = bootstrap.Config{
 		ThingID:    thingId,
 		Name:       "test",
@@ -1200,7 +1202,7 @@ func TestBoostrap(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			svcCall := bsvc.On("Bootstrap", mock.Anything, tc.externalKey, tc.externalID, false).Return(tc.svcResp, tc.svcErr)
-			readerCall := reader.On("ReadConfig", tc.svcResp, false).Return(tc.readerResp, tc.readerErr)
+			readerCall := reader.On("ReadConfig", tc.svcResp, false, bootstrap.LegacyKeyVersion).Return(tc.readerResp, tc.readerErr)
 			resp, err := mgsdk.Bootstrap(tc.externalID, tc.externalKey)
 			assert.Equal(t, tc.err, err)
 			if err == nil {
@@ -1227,6 +1229,13 @@ func TestBootstrapSecure(t *testing.T) {
 	assert.Nil(t, err, fmt.Sprintf("Marshalling bootstrap response expected to succeed: %s.\n", err))
 	encResponse, err := encrypt(b, encKey)
 	assert.Nil(t, err, fmt.Sprintf("Encrypting bootstrap response expected to succeed: %s.\n", err))
+	keys, err := bootstrap.NewKeyring(nil, map[bootstrap.KeyVersion][]byte{1: masterKey})
+	assert.Nil(t, err, fmt.Sprintf("Creating bootstrap keyring expected to succeed: %s.\n", err))
+	_, deviceKey, err := keys.DeviceKey(externalId, externalKey)
+	assert.Nil(t, err, fmt.Sprintf("Deriving device key expected to succeed: %s.\n", err))
+	sealedResponse, err := seal(b, deviceKey, externalId)
+	assert.Nil(t, err, fmt.Sprintf("Sealing bootstrap response expected to succeed: %s.\n", err))
+	sealedResponse = append([]byte{1}, sealedResponse...)
 
 	cases := []struct {
 		desc        string
@@ -1234,6 +1243,7 @@ func TestBootstrapSecure(t *testing.T) {
 		externalID  string
 		externalKey string
 		cryptoKey   string
+		keyVersion  uint8
 		svcResp     bootstrap.Config
 		svcErr      error
 		readerResp  []byte
@@ -1254,6 +1264,20 @@ func TestBootstrapSecure(t *testing.T) {
 			response:    sdkBootsrapConfigRes,
 			err:         nil,
 		},
+		{
+			desc:        "bootstrap successfully with versioned key",
+			token:       validToken,
+			externalID:  externalId,
+			externalKey: externalKey,
+			cryptoKey:   deviceKey,
+			keyVersion:  1,
+			svcResp:     bootstrapConfig,
+			svcErr:      nil,
+			readerResp:  sealedResponse,
+			readerErr:   nil,
+			response:    sdkBootsrapConfigRes,
+			err:         nil,
+		},
 		{
 			desc:        "bootstrap with invalid token",
 			token:       invalidToken,
@@ -1266,6 +1290,32 @@ func TestBootstrapSecure(t *testing.T) {
 			readerErr:   nil,
 			err:         errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
 		},
+		{
+			desc:        "bootstrap with versioned key and response encrypted with another version",
+			token:       validToken,
+			externalID:  externalId,
+			externalKey: externalKey,
+			cryptoKey:   deviceKey,
+			keyVersion:  2,
+			svcResp:     bootstrapConfig,
+			svcErr:      nil,
+			readerResp:  sealedResponse,
+			readerErr:   nil,
+			err:         errors.NewSDKError(errors.New("invalid secure bootstrap response")),
+		},
+		{
+			desc:        "bootstrap with versioned key and tampered response",
+			token:       validToken,
+			externalID:  externalId,
+			externalKey: externalKey,
+			cryptoKey:   deviceKey,
+			keyVersion:  1,
+			svcResp:     bootstrapConfig,
+			svcErr:      nil,
+			readerResp:  append(append([]byte{}, sealedResponse[:len(sealedResponse)-1]...), sealedResponse[len(sealedResponse)-1]^0xff),
+			readerErr:   nil,
+			err:         errors.NewSDKError(errors.New("cipher: message authentication failed")),
+		},
 		{
 			desc:        "booostrap with invalid crypto key",
 			token:       validToken,
@@ -1317,8 +1367,8 @@ func TestBootstrapSecure(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			svcCall := bsvc.On("Bootstrap", mock.Anything, mock.Anything, tc.externalID, true).Return(tc.svcResp, tc.svcErr)
-			readerCall := reader.On("ReadConfig", tc.svcResp, true).Return(tc.readerResp, tc.readerErr)
-			resp, err := mgsdk.BootstrapSecure(tc.externalID, tc.externalKey, tc.cryptoKey)
+			readerCall := reader.On("ReadConfig", tc.svcResp, true, bootstrap.KeyVersion(tc.keyVersion)).Return(tc.readerResp, tc.readerErr)
+			resp, err := mgsdk.BootstrapSecure(tc.externalID, tc.externalKey, tc.cryptoKey, tc.keyVersion)
 			assert.Equal(t, tc.err, err)
 			if err == nil {
 				assert.Equal(t, sdkBootsrapConfigRes, resp)
@@ -1345,3 +1395,23 @@ func encrypt(in, encKey []byte) ([]byte, error) {
 	stream.XORKeyStream(ciphertext[aes.BlockSize:], in)
 	return ciphertext, nil
 }
+
+func seal(in []byte, deviceKey, externalID string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(deviceKey)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, in, []byte(externalID)), nil
+}
@@ -15,6 +15,7 @@ const (
 	invitationsEndpoint = "invitations"
 	acceptEndpoint      = "accept"
 	rejectEndpoint      = "reject"
+	emailEndpoint       = "email"
 )
 
 type Invitation struct {
@@ -27,6 +28,7 @@ type Invitation struct {
 	UpdatedAt   time.Time `json:"updated_at,omitempty"`
 	ConfirmedAt time.Time `json:"confirmed_at,omitempty"`
 	RejectedAt  time.Time `json:"rejected_at,omitempty"`
+	ExpiresAt   time.Time `json:"expires_at,omitempty"`
 	Resend      bool      `json:"resend,omitempty"`
 }
 
@@ -37,6 +39,24 @@ type InvitationPage struct {
 	Invitations []Invitation `json:"invitations"`
 }
 
+type EmailInvitation struct {
+	InvitedBy string    `json:"invited_by"`
+	Email     string    `json:"email"`
+	DomainID  string    `json:"domain_id"`
+	Relation  string    `json:"relation"`
+	SendCount uint64    `json:"send_count"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type EmailInvitationPage struct {
+	Total       uint64            `json:"total"`
+	Offset      uint64            `json:"offset"`
+	Limit       uint64            `json:"limit"`
+	Invitations []EmailInvitation `json:"invitations"`
+}
+
 func (sdk mgSDK) SendInvitation(invitation Invitation, token string) (err error) {
 	data, err := json.Marshal(invitation)
 	if err != nil {
@@ -127,3 +147,89 @@ func (sdk mgSDK) DeleteInvitation(userID, domainID, token string) (err error) {
 
 	return sdkerr
 }
+
+func (sdk mgSDK) SendEmailInvitations(domainID, relation string, emails []string, resend bool, token string) (invitations []EmailInvitation, err error) {
+	req := struct {
+		DomainID string   `json:"domain_id"`
+		Relation string   `json:"relation"`
+		Emails   []string `json:"emails"`
+		Resend   bool     `json:"resend,omitempty"`
+	}{
+		DomainID: domainID,
+		Relation: relation,
+		Emails:   emails,
+		Resend:   resend,
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, errors.NewSDKError(err)
+	}
+
+	url := sdk.invitationsURL + "/" + invitationsEndpoint + "/" + emailEndpoint
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return nil, sdkerr
+	}
+
+	var res struct {
+		Invitations []EmailInvitation `json:"invitations"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, errors.NewSDKError(err)
+	}
+
+	return res.Invitations, nil
+}
+
+func (sdk mgSDK) EmailInvitations(pm PageMetadata, token string) (invitations EmailInvitationPage, err error) {
+	url, err := sdk.withQueryParams(sdk.invitationsURL, invitationsEndpoint+"/"+emailEndpoint, pm)
+	if err != nil {
+		return EmailInvitationPage{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return EmailInvitationPage{}, sdkerr
+	}
+
+	var invPage EmailInvitationPage
+	if err := json.Unmarshal(body, &invPage); err != nil {
+		return EmailInvitationPage{}, errors.NewSDKError(err)
+	}
+
+	return invPage, nil
+}
+
+func (sdk mgSDK) DeleteEmailInvitation(email, domainID, token string) (err error) {
+	url := sdk.invitationsURL + "/" + invitationsEndpoint + "/" + emailEndpoint + "/" + email + "/" + domainID
+
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
+
+func (sdk mgSDK) AcceptEmailInvitation(invitationToken, token string) (invitation Invitation, err error) {
+	req := struct {
+		Token string `json:"token"`
+	}{
+		Token: invitationToken,
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return Invitation{}, errors.NewSDKError(err)
+	}
+
+	url := sdk.invitationsURL + "/" + invitationsEndpoint + "/" + emailEndpoint + "/" + acceptEndpoint
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Invitation{}, sdkerr
+	}
+
+	if err := json.Unmarshal(body, &invitation); err != nil {
+		return Invitation{}, errors.NewSDKError(err)
+	}
+
+	return invitation, nil
+}
@@ -559,6 +559,283 @@ func TestDeleteInvitation(t *testing.T) {
 	}
 }
 
+func TestSendEmailInvitations(t *testing.T) {
+	is, svc, auth := setupInvitations()
+	defer is.Close()
+
+	conf := sdk.Config{
+		InvitationsURL: is.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	email := "invitee@example.com"
+	domainID := invitation.DomainID
+	emailInvitation := invitations.EmailInvitation{
+		InvitedBy: invitation.InvitedBy,
+		Email:     email,
+		DomainID:  domainID,
+		Relation:  policies.MemberRelation,
+		SendCount: 1,
+		CreatedAt: invitation.CreatedAt,
+		ExpiresAt: invitation.CreatedAt.Add(time.Hour),
+	}
+
+	cases := []struct {
+		desc            string
+		token           string
+		session         mgauthn.Session
+		emails          []string
+		svcRes          []invitations.EmailInvitation
+		authenticateErr error
+		svcErr          error
+		response        []sdk.EmailInvitation
+		err             error
+	}{
+		{
+			desc:   "send email invitations successfully",
+			token:  validToken,
+			emails: []string{email},
+			svcRes: []invitations.EmailInvitation{emailInvitation},
+			response: []sdk.EmailInvitation{
+				{
+					InvitedBy: emailInvitation.InvitedBy,
+					Email:     email,
+					DomainID:  domainID,
+					Relation:  policies.MemberRelation,
+					SendCount: 1,
+					CreatedAt: emailInvitation.CreatedAt,
+					ExpiresAt: emailInvitation.ExpiresAt,
+				},
+			},
+			err: nil,
+		},
+		{
+			desc:            "send email invitations with invalid token",
+			token:           invalidToken,
+			emails:          []string{email},
+			authenticateErr: svcerr.ErrAuthentication,
+			err:             errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:   "send email invitations with invalid email",
+			token:  validToken,
+			emails: []string{"invalid"},
+			err:    errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrInvalidEmail), http.StatusBadRequest),
+		},
+		{
+			desc:   "send email invitations over the resend limit",
+			token:  validToken,
+			emails: []string{email},
+			svcErr: svcerr.ErrInvitationResendLimit,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrInvitationResendLimit, http.StatusConflict),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == valid {
+				tc.session = mgauthn.Session{DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := svc.On("SendEmailInvitations", mock.Anything, tc.session, domainID, policies.MemberRelation, tc.emails, false).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.SendEmailInvitations(domainID, policies.MemberRelation, tc.emails, false, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestListEmailInvitations(t *testing.T) {
+	is, svc, auth := setupInvitations()
+	defer is.Close()
+
+	conf := sdk.Config{
+		InvitationsURL: is.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	domainID := invitation.DomainID
+	pageRes := invitations.EmailInvitationPage{
+		Total: 1,
+		Limit: 10,
+		Invitations: []invitations.EmailInvitation{
+			{Email: "invitee@example.com", DomainID: domainID, Relation: policies.MemberRelation, SendCount: 1},
+		},
+	}
+
+	cases := []struct {
+		desc            string
+		token           string
+		session         mgauthn.Session
+		pageMeta        sdk.PageMetadata
+		authenticateErr error
+		svcErr          error
+		response        sdk.EmailInvitationPage
+		err             error
+	}{
+		{
+			desc:     "list email invitations successfully",
+			token:    validToken,
+			pageMeta: sdk.PageMetadata{DomainID: domainID, Limit: 10},
+			response: sdk.EmailInvitationPage{
+				Total: 1,
+				Limit: 10,
+				Invitations: []sdk.EmailInvitation{
+					{Email: "invitee@example.com", DomainID: domainID, Relation: policies.MemberRelation, SendCount: 1},
+				},
+			},
+			err: nil,
+		},
+		{
+			desc:            "list email invitations with invalid token",
+			token:           invalidToken,
+			pageMeta:        sdk.PageMetadata{DomainID: domainID, Limit: 10},
+			authenticateErr: svcerr.ErrAuthentication,
+			err:             errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "list email invitations without domain",
+			token:    validToken,
+			pageMeta: sdk.PageMetadata{Limit: 10},
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingDomainID), http.StatusBadRequest),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == valid {
+				tc.session = mgauthn.Session{DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := svc.On("ListEmailInvitations", mock.Anything, tc.session, mock.Anything).Return(pageRes, tc.svcErr)
+			resp, err := mgsdk.EmailInvitations(tc.pageMeta, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestDeleteEmailInvitation(t *testing.T) {
+	is, svc, auth := setupInvitations()
+	defer is.Close()
+
+	conf := sdk.Config{
+		InvitationsURL: is.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	email := "invitee@example.com"
+
+	cases := []struct {
+		desc            string
+		token           string
+		session         mgauthn.Session
+		domainID        string
+		authenticateErr error
+		svcErr          error
+		err             error
+	}{
+		{
+			desc:     "delete email invitation successfully",
+			token:    validToken,
+			domainID: invitation.DomainID,
+			err:      nil,
+		},
+		{
+			desc:            "delete email invitation with invalid token",
+			token:           invalidToken,
+			domainID:        invitation.DomainID,
+			authenticateErr: svcerr.ErrAuthentication,
+			err:             errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "delete non-existing email invitation",
+			token:    validToken,
+			domainID: wrongID,
+			svcErr:   svcerr.ErrNotFound,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == valid {
+				tc.session = mgauthn.Session{DomainID: tc.domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := svc.On("DeleteEmailInvitation", mock.Anything, tc.session, email, tc.domainID).Return(tc.svcErr)
+			err := mgsdk.DeleteEmailInvitation(email, tc.domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestAcceptEmailInvitation(t *testing.T) {
+	is, svc, auth := setupInvitations()
+	defer is.Close()
+
+	conf := sdk.Config{
+		InvitationsURL: is.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	invitationToken := "invitationToken"
+
+	cases := []struct {
+		desc            string
+		token           string
+		session         mgauthn.Session
+		svcRes          invitations.Invitation
+		svcErr          error
+		authenticateErr error
+		response        sdk.Invitation
+		err             error
+	}{
+		{
+			desc:     "accept email invitation successfully",
+			token:    validToken,
+			svcRes:   invitation,
+			response: sdkInvitation,
+			err:      nil,
+		},
+		{
+			desc:            "accept email invitation with invalid token",
+			token:           invalidToken,
+			authenticateErr: svcerr.ErrAuthentication,
+			err:             errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:   "accept email invitation with invalid invitation token",
+			token:  validToken,
+			svcErr: svcerr.ErrNotFound,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+		},
+		{
+			desc:   "accept expired email invitation",
+			token:  validToken,
+			svcErr: svcerr.ErrInvitationExpired,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrInvitationExpired, http.StatusConflict),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == valid {
+				tc.session = mgauthn.Session{UserID: invitation.UserID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := svc.On("AcceptEmailInvitation", mock.Anything, tc.session, invitationToken).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.AcceptEmailInvitation(invitationToken, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
 func generateTestInvitation(t *testing.T) sdk.Invitation {
 	createdAt, err := time.Parse(time.RFC3339, "2024-01-01T00:00:00Z")
 	assert.Nil(t, err, fmt.Sprintf("Unexpected error parsing time: %v", err))
@@ -13,7 +13,13 @@ import (
 	"github.com/absmach/magistrala/pkg/errors"
 )
 
-const journalEndpoint = "journal"
+const (
+	journalEndpoint   = "journal"
+	verifyEndpoint    = "verify"
+	exportEndpoint    = "export"
+	downloadEndpoint  = "download"
+	retentionEndpoint = "retention"
+)
 
 type Journal struct {
 	ID         string    `json:"id,omitempty"`
@@ -21,6 +27,29 @@ type Journal struct {
 	OccurredAt time.Time `json:"occurred_at,omitempty"`
 	Attributes Metadata  `json:"attributes,omitempty"`
 	Metadata   Metadata  `json:"metadata,omitempty"`
+	Domain     string    `json:"domain,omitempty"`
+	Sequence   uint64    `json:"sequence,omitempty"`
+	PrevHash   string    `json:"prev_hash,omitempty"`
+	Hash       string    `json:"hash,omitempty"`
+}
+
+type JournalVerification struct {
+	Domain             string `json:"domain"`
+	Valid              bool   `json:"valid"`
+	Entries            uint64 `json:"entries"`
+	ArchivedSequence   uint64 `json:"archived_sequence,omitempty"`
+	CheckpointSequence uint64 `json:"checkpoint_sequence,omitempty"`
+	HeadSequence       uint64 `json:"head_sequence"`
+	HeadHash           string `json:"head_hash,omitempty"`
+	BrokenAt           uint64 `json:"broken_at,omitempty"`
+	Reason             string `json:"reason,omitempty"`
+}
+
+type JournalRetention struct {
+	Domain    string    `json:"domain"`
+	Days      uint64    `json:"days"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	UpdatedBy string    `json:"updated_by,omitempty"`
 }
 
 type JournalsPage struct {
@@ -60,3 +89,95 @@ func (sdk mgSDK) Journal(entityType, entityID, domainID string, pm PageMetadata,
 
 	return journalsPage, nil
 }
+
+func (sdk mgSDK) VerifyJournal(domainID, token string) (JournalVerification, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.journalURL, domainID, journalEndpoint, verifyEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return JournalVerification{}, sdkerr
+	}
+
+	var v JournalVerification
+	if err := json.Unmarshal(body, &v); err != nil {
+		return JournalVerification{}, errors.NewSDKError(err)
+	}
+
+	return v, nil
+}
+
+func (sdk mgSDK) ExportJournal(domainID string, from, to uint64, token string) ([]byte, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/%s?from_seq=%d", sdk.journalURL, domainID, journalEndpoint, exportEndpoint, from)
+	if to > 0 {
+		url = fmt.Sprintf("%s&to_seq=%d", url, to)
+	}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return nil, sdkerr
+	}
+
+	return body, nil
+}
+
+func (sdk mgSDK) DownloadJournal(domainID, format string, pm PageMetadata, token string) ([]byte, errors.SDKError) {
+	endpoint := fmt.Sprintf("%s/%s/%s", domainID, journalEndpoint, downloadEndpoint)
+	url, err := sdk.withQueryParams(sdk.journalURL, endpoint, pm)
+	if err != nil {
+		return nil, errors.NewSDKError(err)
+	}
+	if format != "" {
+		url = fmt.Sprintf("%s&format=%s", url, format)
+	}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return nil, sdkerr
+	}
+
+	return body, nil
+}
+
+func (sdk mgSDK) SetJournalRetention(domainID string, days uint64, token string) (JournalRetention, errors.SDKError) {
+	data, err := json.Marshal(JournalRetention{Days: days})
+	if err != nil {
+		return JournalRetention{}, errors.NewSDKError(err)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.journalURL, domainID, journalEndpoint, retentionEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPut, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return JournalRetention{}, sdkerr
+	}
+
+	var r JournalRetention
+	if err := json.Unmarshal(body, &r); err != nil {
+		return JournalRetention{}, errors.NewSDKError(err)
+	}
+
+	return r, nil
+}
+
+func (sdk mgSDK) JournalRetention(domainID, token string) (JournalRetention, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.journalURL, domainID, journalEndpoint, retentionEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return JournalRetention{}, sdkerr
+	}
+
+	var r JournalRetention
+	if err := json.Unmarshal(body, &r); err != nil {
+		return JournalRetention{}, errors.NewSDKError(err)
+	}
+
+	return r, nil
+}
+
+func (sdk mgSDK) RemoveJournalRetention(domainID, token string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.journalURL, domainID, journalEndpoint, retentionEndpoint)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
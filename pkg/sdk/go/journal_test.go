@@ -4,9 +4,11 @@
 package sdk_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -346,6 +348,398 @@ func TestRetrieveJournal(t *testing.T) {
 	}
 }
 
+func TestVerifyJournal(t *testing.T) {
+	js, svc, authn := setupJournal()
+	defer js.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{
+		JournalURL: js.URL,
+	})
+
+	cases := []struct {
+		desc     string
+		token    string
+		session  mgauthn.Session
+		domainID string
+		svcRes   journal.Verification
+		svcErr   error
+		authnErr error
+		response sdk.JournalVerification
+		err      errors.SDKError
+	}{
+		{
+			desc:     "verify journal successfully",
+			token:    validToken,
+			domainID: domainID,
+			svcRes:   journal.Verification{Domain: domainID, Valid: true, Entries: 2, HeadSequence: 2, HeadHash: "hash"},
+			response: sdk.JournalVerification{Domain: domainID, Valid: true, Entries: 2, HeadSequence: 2, HeadHash: "hash"},
+		},
+		{
+			desc:     "verify broken journal",
+			token:    validToken,
+			domainID: domainID,
+			svcRes:   journal.Verification{Domain: domainID, BrokenAt: 1, Reason: journal.HashMismatch},
+			response: sdk.JournalVerification{Domain: domainID, BrokenAt: 1, Reason: journal.HashMismatch},
+		},
+		{
+			desc:     "verify journal with invalid token",
+			token:    invalidToken,
+			domainID: domainID,
+			authnErr: svcerr.ErrAuthentication,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "verify journal with service error",
+			token:    validToken,
+			domainID: domainID,
+			svcErr:   svcerr.ErrAuthorization,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = mgauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, mock.Anything).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("Verify", mock.Anything, tc.session).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.VerifyJournal(tc.domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestExportJournal(t *testing.T) {
+	js, svc, authn := setupJournal()
+	defer js.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{
+		JournalURL: js.URL,
+	})
+
+	export := journal.Export{
+		Journals: []journal.Journal{{ID: validID, Operation: "create", Domain: domainID, Sequence: 1, Hash: "hash"}},
+		Proof:    journal.Proof{Domain: domainID, FirstSequence: 1, LastSequence: 1, HeadHash: "hash", Entries: 1},
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		session  mgauthn.Session
+		from     uint64
+		to       uint64
+		svcErr   error
+		authnErr error
+		err      errors.SDKError
+	}{
+		{
+			desc:  "export journal successfully",
+			token: validToken,
+			from:  1,
+		},
+		{
+			desc:  "export journal range successfully",
+			token: validToken,
+			from:  1,
+			to:    10,
+		},
+		{
+			desc:     "export journal with invalid token",
+			token:    invalidToken,
+			from:     1,
+			authnErr: svcerr.ErrAuthentication,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:   "export broken journal",
+			token:  validToken,
+			from:   1,
+			svcErr: journal.ErrChainBroken,
+			err:    errors.NewSDKErrorWithStatus(journal.ErrChainBroken, http.StatusConflict),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = mgauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, mock.Anything).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("Export", mock.Anything, tc.session, tc.from, tc.to).Return(export, tc.svcErr)
+			resp, err := mgsdk.ExportJournal(domainID, tc.from, tc.to, tc.token)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				assert.Contains(t, string(resp), `"proof":`, tc.desc)
+				ok := svcCall.Parent.AssertCalled(t, "Export", mock.Anything, tc.session, tc.from, tc.to)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestDownloadJournal(t *testing.T) {
+	js, svc, authn := setupJournal()
+	defer js.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{
+		JournalURL: js.URL,
+	})
+
+	journals := []journal.Journal{{ID: validID, Operation: "thing.create", Domain: domainID, Sequence: 1}}
+
+	cases := []struct {
+		desc     string
+		token    string
+		session  mgauthn.Session
+		format   string
+		pm       sdk.PageMetadata
+		page     journal.Page
+		lines    int
+		svcErr   error
+		authnErr error
+		err      errors.SDKError
+	}{
+		{
+			desc:  "download journal successfully",
+			token: validToken,
+			page:  journal.Page{Direction: "desc"},
+			lines: 1,
+		},
+		{
+			desc:   "download journal as csv successfully",
+			token:  validToken,
+			format: "csv",
+			pm:     sdk.PageMetadata{OperationPrefix: "thing.", Limit: 10},
+			page:   journal.Page{Limit: 10, Direction: "desc", OperationPrefix: "thing."},
+			lines:  2,
+		},
+		{
+			desc:   "download journal with invalid format",
+			token:  validToken,
+			format: "xml",
+			err:    errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrInvalidExportFormat), http.StatusBadRequest),
+		},
+		{
+			desc:     "download journal with invalid token",
+			token:    invalidToken,
+			page:     journal.Page{Direction: "desc"},
+			authnErr: svcerr.ErrAuthentication,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = mgauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, mock.Anything).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("ExportPage", mock.Anything, tc.session, tc.page, mock.Anything).Return(func(_ context.Context, _ mgauthn.Session, _ journal.Page, fn func(journal.Journal) error) error {
+				if tc.svcErr != nil {
+					return tc.svcErr
+				}
+				for _, j := range journals {
+					if err := fn(j); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			resp, err := mgsdk.DownloadJournal(domainID, tc.format, tc.pm, tc.token)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				assert.Equal(t, tc.lines, strings.Count(string(resp), "\n"), tc.desc)
+				ok := svcCall.Parent.AssertCalled(t, "ExportPage", mock.Anything, tc.session, tc.page, mock.Anything)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestSetJournalRetention(t *testing.T) {
+	js, svc, authn := setupJournal()
+	defer js.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{
+		JournalURL: js.URL,
+	})
+
+	retention := journal.Retention{Domain: domainID, Days: 30, UpdatedBy: validID}
+
+	cases := []struct {
+		desc     string
+		token    string
+		session  mgauthn.Session
+		days     uint64
+		svcRes   journal.Retention
+		svcErr   error
+		authnErr error
+		response sdk.JournalRetention
+		err      errors.SDKError
+	}{
+		{
+			desc:     "set journal retention successfully",
+			token:    validToken,
+			days:     30,
+			svcRes:   retention,
+			response: sdk.JournalRetention{Domain: domainID, Days: 30, UpdatedBy: validID},
+		},
+		{
+			desc:  "set journal retention with zero days",
+			token: validToken,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrInvalidRetention), http.StatusBadRequest),
+		},
+		{
+			desc:     "set journal retention with invalid token",
+			token:    invalidToken,
+			days:     30,
+			authnErr: svcerr.ErrAuthentication,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:   "set journal retention with service error",
+			token:  validToken,
+			days:   30,
+			svcErr: svcerr.ErrAuthorization,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = mgauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, mock.Anything).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("SetRetention", mock.Anything, tc.session, tc.days).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.SetJournalRetention(domainID, tc.days, tc.token)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				assert.Equal(t, tc.response, resp, tc.desc)
+				ok := svcCall.Parent.AssertCalled(t, "SetRetention", mock.Anything, tc.session, tc.days)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestJournalRetention(t *testing.T) {
+	js, svc, authn := setupJournal()
+	defer js.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{
+		JournalURL: js.URL,
+	})
+
+	retention := journal.Retention{Domain: domainID, Days: 30, UpdatedBy: validID}
+
+	cases := []struct {
+		desc     string
+		token    string
+		session  mgauthn.Session
+		svcRes   journal.Retention
+		svcErr   error
+		authnErr error
+		response sdk.JournalRetention
+		err      errors.SDKError
+	}{
+		{
+			desc:     "view journal retention successfully",
+			token:    validToken,
+			svcRes:   retention,
+			response: sdk.JournalRetention{Domain: domainID, Days: 30, UpdatedBy: validID},
+		},
+		{
+			desc:     "view journal retention with invalid token",
+			token:    invalidToken,
+			authnErr: svcerr.ErrAuthentication,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:   "view missing journal retention",
+			token:  validToken,
+			svcErr: svcerr.ErrNotFound,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = mgauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, mock.Anything).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("ViewRetention", mock.Anything, tc.session).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.JournalRetention(domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				assert.Equal(t, tc.response, resp, tc.desc)
+				ok := svcCall.Parent.AssertCalled(t, "ViewRetention", mock.Anything, tc.session)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestRemoveJournalRetention(t *testing.T) {
+	js, svc, authn := setupJournal()
+	defer js.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{
+		JournalURL: js.URL,
+	})
+
+	cases := []struct {
+		desc     string
+		token    string
+		session  mgauthn.Session
+		svcErr   error
+		authnErr error
+		err      errors.SDKError
+	}{
+		{
+			desc:  "remove journal retention successfully",
+			token: validToken,
+		},
+		{
+			desc:     "remove journal retention with invalid token",
+			token:    invalidToken,
+			authnErr: svcerr.ErrAuthentication,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:   "remove missing journal retention",
+			token:  validToken,
+			svcErr: svcerr.ErrNotFound,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = mgauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, mock.Anything).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("RemoveRetention", mock.Anything, tc.session).Return(tc.svcErr)
+			err := mgsdk.RemoveJournalRetention(domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "RemoveRetention", mock.Anything, tc.session)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
 func generateTestJournal(t *testing.T) sdk.Journal {
 	occuredAt, err := time.Parse(time.RFC3339, "2024-01-01T00:00:00Z")
 	assert.Nil(t, err, fmt.Sprintf("Unexpected error parsing time: %v", err))
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/absmach/magistrala"
 	adapter "github.com/absmach/magistrala/http"
@@ -20,8 +21,11 @@ import (
 	"github.com/absmach/magistrala/pkg/errors"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	pubsub "github.com/absmach/magistrala/pkg/messaging/mocks"
+	presencemocks "github.com/absmach/magistrala/pkg/presence/mocks"
+	"github.com/absmach/magistrala/pkg/schema"
 	sdk "github.com/absmach/magistrala/pkg/sdk/go"
 	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"github.com/absmach/magistrala/pkg/uuid"
 	"github.com/absmach/magistrala/readers"
 	readersapi "github.com/absmach/magistrala/readers/api"
 	readersmocks "github.com/absmach/magistrala/readers/mocks"
@@ -35,9 +39,11 @@ import (
 func setupMessages() (*httptest.Server, *thmocks.ThingsServiceClient, *pubsub.PubSub) {
 	things := new(thmocks.ThingsServiceClient)
 	pub := new(pubsub.PubSub)
-	handler := adapter.NewHandler(pub, mglog.NewMock(), things)
+	eventStore := new(presencemocks.EventStore)
+	eventStore.On("Published", mock.Anything, mock.Anything).Return(nil)
+	handler := adapter.NewHandler(pub, eventStore, mglog.NewMock(), things, newSchemaCache())
 
-	mux := api.MakeHandler(mglog.NewMock(), "")
+	mux := api.MakeHandler(adapter.New(things, pub, newSchemaCache(), eventStore, uuid.NewMock(), mglog.NewMock()), time.Second, mglog.NewMock(), "")
 	target := httptest.NewServer(mux)
 
 	config := mgate.Config{
@@ -400,3 +406,11 @@ func TestReadMessages(t *testing.T) {
 		})
 	}
 }
+
+// newSchemaCache returns the schema cache of the channels without schema.
+func newSchemaCache() schema.Cache {
+	things := new(thmocks.ThingsServiceClient)
+	things.On("ChannelMetadata", mock.Anything, mock.Anything).Return(&magistrala.ChannelMetadataRes{}, nil)
+
+	return schema.NewCache(things, time.Minute)
+}
@@ -126,6 +126,10 @@ type PageMetadata struct {
 	WithMetadata    bool     `json:"with_metadata,omitempty"`
 	WithAttributes  bool     `json:"with_attributes,omitempty"`
 	ID              string   `json:"id,omitempty"`
+	Actor           string   `json:"actor,omitempty"`
+	OperationPrefix string   `json:"operation_prefix,omitempty"`
+	Attributes      Metadata `json:"attributes,omitempty"`
+	Search          string   `json:"search,omitempty"`
 }
 
 // Credentials represent client credentials: it contains
@@ -966,11 +970,14 @@ type SDK interface {
 	Bootstrap(externalID, externalKey string) (BootstrapConfig, errors.SDKError)
 
 	// BootstrapSecure retrieves a configuration with given external ID and encrypted external key.
+	// Key version 0 uses the deprecated AES-CFB scheme with cryptoKey as the service-wide key.
+	// Otherwise, cryptoKey is the base64 encoded device key of the given version, returned
+	// when the config is created or viewed, used for AES-GCM encryption.
 	//
 	// example:
-	//  bootstrap, _ := sdk.BootstrapSecure("externalID", "externalKey", "cryptoKey")
+	//  bootstrap, _ := sdk.BootstrapSecure("externalID", "externalKey", "cryptoKey", 1)
 	//  fmt.Println(bootstrap)
-	BootstrapSecure(externalID, externalKey, cryptoKey string) (BootstrapConfig, errors.SDKError)
+	BootstrapSecure(externalID, externalKey, cryptoKey string, keyVersion uint8) (BootstrapConfig, errors.SDKError)
 
 	// Bootstraps retrieves a list of managed configs.
 	//
@@ -1207,12 +1214,122 @@ type SDK interface {
 	//  fmt.Println(err)
 	DeleteInvitation(userID, domainID, token string) (err error)
 
+	// SendEmailInvitations invites people to join the domain by email. Each
+	// email contains the single use invitation token. Already invited emails
+	// are skipped unless resend is set, which sends the new token.
+	//
+	// For example:
+	//  invitations, _ := sdk.SendEmailInvitations("domainID", "contributor", []string{"jane.doe@example.com"}, false, "token")
+	//  fmt.Println(invitations)
+	SendEmailInvitations(domainID, relation string, emails []string, resend bool, token string) (invitations []EmailInvitation, err error)
+
+	// EmailInvitations returns a list of the pending email invitations of the domain.
+	//
+	// For example:
+	//  invitations, _ := sdk.EmailInvitations(PageMetadata{DomainID: "domainID", Offset: 0, Limit: 10}, "token")
+	//  fmt.Println(invitations)
+	EmailInvitations(pm PageMetadata, token string) (invitations EmailInvitationPage, err error)
+
+	// DeleteEmailInvitation deletes a pending email invitation.
+	//
+	// For example:
+	//  err := sdk.DeleteEmailInvitation("jane.doe@example.com", "domainID", "token")
+	//  fmt.Println(err)
+	DeleteEmailInvitation(email, domainID, token string) (err error)
+
+	// AcceptEmailInvitation accepts the email invitation using the invitation
+	// token from the email. The user is added to the domain that they were
+	// invited to.
+	//
+	// For example:
+	//  invitation, _ := sdk.AcceptEmailInvitation("invitationToken", "token")
+	//  fmt.Println(invitation)
+	AcceptEmailInvitation(invitationToken, token string) (invitation Invitation, err error)
+
 	// Journal returns a list of journal logs.
 	//
 	// For example:
 	//  journals, _ := sdk.Journal("thing", "thingID","domainID", PageMetadata{Offset: 0, Limit: 10, Operation: "thing.create"}, "token")
 	//  fmt.Println(journals)
 	Journal(entityType, entityID, domainID string, pm PageMetadata, token string) (journal JournalsPage, err error)
+
+	// VerifyJournal verifies the journal hash chain of the domain.
+	//
+	// For example:
+	//  verification, _ := sdk.VerifyJournal("domainID", "token")
+	//  fmt.Println(verification.Valid)
+	VerifyJournal(domainID, token string) (JournalVerification, errors.SDKError)
+
+	// ExportJournal returns the signed NDJSON export of the domain journals
+	// in the sequence range. Zero upper bound exports up to the chain head.
+	//
+	// For example:
+	//  export, _ := sdk.ExportJournal("domainID", 1, 0, "token")
+	//  os.WriteFile("journal.ndjson", export, 0o644)
+	ExportJournal(domainID string, from, to uint64, token string) ([]byte, errors.SDKError)
+
+	// DownloadJournal returns the domain journals matching the page
+	// metadata filters in the CSV or NDJSON format.
+	//
+	// For example:
+	//  pm := sdk.PageMetadata{
+	//    OperationPrefix: "thing.",
+	//    Attributes:      sdk.Metadata{"metadata.location": "lab"},
+	//  }
+	//  journals, _ := sdk.DownloadJournal("domainID", "csv", pm, "token")
+	//  os.WriteFile("journal.csv", journals, 0o644)
+	DownloadJournal(domainID, format string, pm PageMetadata, token string) ([]byte, errors.SDKError)
+
+	// SetJournalRetention sets the number of days the domain journals are
+	// kept before they are archived.
+	//
+	// For example:
+	//  retention, _ := sdk.SetJournalRetention("domainID", 90, "token")
+	//  fmt.Println(retention)
+	SetJournalRetention(domainID string, days uint64, token string) (JournalRetention, errors.SDKError)
+
+	// JournalRetention returns the domain journal retention policy.
+	//
+	// For example:
+	//  retention, _ := sdk.JournalRetention("domainID", "token")
+	//  fmt.Println(retention.Days)
+	JournalRetention(domainID, token string) (JournalRetention, errors.SDKError)
+
+	// RemoveJournalRetention removes the domain journal retention policy.
+	//
+	// For example:
+	//  err := sdk.RemoveJournalRetention("domainID", "token")
+	//  fmt.Println(err)
+	RemoveJournalRetention(domainID, token string) errors.SDKError
+
+	// Twin returns the digital twin of the thing.
+	//
+	// For example:
+	//  twin, _ := sdk.Twin("domainID", "thingID", "token")
+	//  fmt.Println(twin.Delta)
+	Twin(domainID, thingID, token string) (Twin, errors.SDKError)
+
+	// SetTwinDesired replaces the desired state of the thing twin.
+	//
+	// For example:
+	//  twin, _ := sdk.SetTwinDesired("domainID", "thingID", map[string]interface{}{"temperature": 23}, "token")
+	//  fmt.Println(twin)
+	SetTwinDesired(domainID, thingID string, desired map[string]interface{}, token string) (Twin, errors.SDKError)
+
+	// PatchTwinDesired applies the JSON patch to the desired state of the thing twin.
+	//
+	// For example:
+	//  patch := []sdk.TwinPatchOp{{Op: "replace", Path: "/temperature", Value: 22}}
+	//  twin, _ := sdk.PatchTwinDesired("domainID", "thingID", patch, "token")
+	//  fmt.Println(twin)
+	PatchTwinDesired(domainID, thingID string, patch []TwinPatchOp, token string) (Twin, errors.SDKError)
+
+	// TwinHistory returns the state history of the thing twin, starting from the latest version.
+	//
+	// For example:
+	//  states, _ := sdk.TwinHistory("domainID", "thingID", sdk.PageMetadata{Offset: 0, Limit: 10}, "token")
+	//  fmt.Println(states)
+	TwinHistory(domainID, thingID string, pm PageMetadata, token string) (TwinStatesPage, errors.SDKError)
 }
 
 type mgSDK struct {
@@ -1225,6 +1342,7 @@ type mgSDK struct {
 	domainsURL     string
 	invitationsURL string
 	journalURL     string
+	twinsURL       string
 	HostURL        string
 
 	msgContentType ContentType
@@ -1243,6 +1361,7 @@ type Config struct {
 	DomainsURL     string
 	InvitationsURL string
 	JournalURL     string
+	TwinsURL       string
 	HostURL        string
 
 	MsgContentType  ContentType
@@ -1262,6 +1381,7 @@ func NewSDK(conf Config) SDK {
 		domainsURL:     conf.DomainsURL,
 		invitationsURL: conf.InvitationsURL,
 		journalURL:     conf.JournalURL,
+		twinsURL:       conf.TwinsURL,
 		HostURL:        conf.HostURL,
 
 		msgContentType: conf.MsgContentType,
@@ -1446,6 +1566,22 @@ func (pm PageMetadata) query() (string, error) {
 	if pm.To != 0 {
 		q.Add("to", strconv.FormatInt(pm.To, 10))
 	}
+	if pm.Actor != "" {
+		q.Add("actor", pm.Actor)
+	}
+	if pm.OperationPrefix != "" {
+		q.Add("operation_prefix", pm.OperationPrefix)
+	}
+	if pm.Attributes != nil {
+		attributes, err := json.Marshal(pm.Attributes)
+		if err != nil {
+			return "", errors.NewSDKError(err)
+		}
+		q.Add("attributes", string(attributes))
+	}
+	if pm.Search != "" {
+		q.Add("search", pm.Search)
+	}
 	q.Add("with_attributes", strconv.FormatBool(pm.WithAttributes))
 	q.Add("with_metadata", strconv.FormatBool(pm.WithMetadata))
 
@@ -180,6 +180,9 @@ func convertThing(c sdk.Thing) things.Client {
 		CreatedAt:   c.CreatedAt,
 		UpdatedAt:   c.UpdatedAt,
 		Status:      status,
+		Online:      c.Online,
+		LastSeen:    c.LastSeen,
+		Protocol:    c.Protocol,
 	}
 }
 
@@ -216,6 +219,7 @@ func convertInvitation(i sdk.Invitation) invitations.Invitation {
 		CreatedAt:   i.CreatedAt,
 		UpdatedAt:   i.UpdatedAt,
 		ConfirmedAt: i.ConfirmedAt,
+		ExpiresAt:   i.ExpiresAt,
 		Resend:      i.Resend,
 	}
 }
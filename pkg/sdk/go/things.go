@@ -35,6 +35,9 @@ type Thing struct {
 	UpdatedAt   time.Time              `json:"updated_at,omitempty"`
 	Status      string                 `json:"status,omitempty"`
 	Permissions []string               `json:"permissions,omitempty"`
+	Online      bool                   `json:"online,omitempty"`
+	LastSeen    time.Time              `json:"last_seen,omitempty"`
+	Protocol    string                 `json:"protocol,omitempty"`
 }
 
 type ClientCredentials struct {
@@ -0,0 +1,151 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+const (
+	twinsEndpoint   = "twins"
+	desiredEndpoint = "desired"
+	historyEndpoint = "history"
+)
+
+// Twin represents the digital twin of a thing.
+type Twin struct {
+	ThingID    string                 `json:"thing_id"`
+	ChannelID  string                 `json:"channel_id,omitempty"`
+	Reported   map[string]interface{} `json:"reported"`
+	Desired    map[string]interface{} `json:"desired"`
+	Delta      map[string]interface{} `json:"delta"`
+	Version    uint64                 `json:"version"`
+	ReportedAt time.Time              `json:"reported_at,omitempty"`
+	DesiredAt  time.Time              `json:"desired_at,omitempty"`
+	DesiredBy  string                 `json:"desired_by,omitempty"`
+	CreatedAt  time.Time              `json:"created_at,omitempty"`
+	UpdatedAt  time.Time              `json:"updated_at,omitempty"`
+}
+
+// TwinState represents a single version of the twin state.
+type TwinState struct {
+	ThingID   string                 `json:"thing_id"`
+	Version   uint64                 `json:"version"`
+	Cause     string                 `json:"cause"`
+	Reported  map[string]interface{} `json:"reported"`
+	Desired   map[string]interface{} `json:"desired"`
+	CreatedBy string                 `json:"created_by,omitempty"`
+	CreatedAt time.Time              `json:"created_at,omitempty"`
+}
+
+// TwinStatesPage contains a page of twin states.
+type TwinStatesPage struct {
+	Total  uint64      `json:"total"`
+	Offset uint64      `json:"offset"`
+	Limit  uint64      `json:"limit"`
+	States []TwinState `json:"states"`
+}
+
+// TwinPatchOp represents a JSON patch (RFC 6902) operation.
+type TwinPatchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+func (sdk mgSDK) Twin(domainID, thingID, token string) (Twin, errors.SDKError) {
+	if thingID == "" {
+		return Twin{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.twinsURL, domainID, twinsEndpoint, thingID)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Twin{}, sdkerr
+	}
+
+	var tw Twin
+	if err := json.Unmarshal(body, &tw); err != nil {
+		return Twin{}, errors.NewSDKError(err)
+	}
+
+	return tw, nil
+}
+
+func (sdk mgSDK) SetTwinDesired(domainID, thingID string, desired map[string]interface{}, token string) (Twin, errors.SDKError) {
+	if thingID == "" {
+		return Twin{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	data, err := json.Marshal(desired)
+	if err != nil {
+		return Twin{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%s/%s", sdk.twinsURL, domainID, twinsEndpoint, thingID, desiredEndpoint)
+
+	return sdk.updateTwin(http.MethodPut, url, data, token)
+}
+
+func (sdk mgSDK) PatchTwinDesired(domainID, thingID string, patch []TwinPatchOp, token string) (Twin, errors.SDKError) {
+	if thingID == "" {
+		return Twin{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	if len(patch) == 0 {
+		return Twin{}, errors.NewSDKError(apiutil.ErrEmptyList)
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return Twin{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%s/%s", sdk.twinsURL, domainID, twinsEndpoint, thingID, desiredEndpoint)
+
+	return sdk.updateTwin(http.MethodPatch, url, data, token)
+}
+
+func (sdk mgSDK) TwinHistory(domainID, thingID string, pm PageMetadata, token string) (TwinStatesPage, errors.SDKError) {
+	if thingID == "" {
+		return TwinStatesPage{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/%s/%s", domainID, twinsEndpoint, thingID, historyEndpoint)
+	url, err := sdk.withQueryParams(sdk.twinsURL, endpoint, pm)
+	if err != nil {
+		return TwinStatesPage{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return TwinStatesPage{}, sdkerr
+	}
+
+	var sp TwinStatesPage
+	if err := json.Unmarshal(body, &sp); err != nil {
+		return TwinStatesPage{}, errors.NewSDKError(err)
+	}
+
+	return sp, nil
+}
+
+func (sdk mgSDK) updateTwin(method, url string, data []byte, token string) (Twin, errors.SDKError) {
+	_, body, sdkerr := sdk.processRequest(method, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Twin{}, sdkerr
+	}
+
+	var tw Twin
+	if err := json.Unmarshal(body, &tw); err != nil {
+		return Twin{}, errors.NewSDKError(err)
+	}
+
+	return tw, nil
+}
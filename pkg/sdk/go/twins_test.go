@@ -0,0 +1,340 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	authnmocks "github.com/absmach/magistrala/pkg/authn/mocks"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	sdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/absmach/magistrala/twins"
+	"github.com/absmach/magistrala/twins/api"
+	"github.com/absmach/magistrala/twins/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupTwins() (*httptest.Server, *mocks.Service, *authnmocks.Authentication) {
+	svc := new(mocks.Service)
+	authn := new(authnmocks.Authentication)
+	logger := mglog.NewMock()
+	mux := api.MakeHandler(svc, authn, logger, "twins", "test")
+
+	return httptest.NewServer(mux), svc, authn
+}
+
+func generateTestTwin(t *testing.T) (twins.Twin, sdk.Twin) {
+	thingID := testsutil.GenerateUUID(t)
+	channelID := testsutil.GenerateUUID(t)
+	tw := twins.Twin{
+		ThingID:   thingID,
+		ChannelID: channelID,
+		Reported:  twins.State{"temperature": 21.0},
+		Desired:   twins.State{"temperature": 23.0},
+		Version:   2,
+		DesiredBy: validID,
+	}
+	sdkTwin := sdk.Twin{
+		ThingID:   thingID,
+		ChannelID: channelID,
+		Reported:  map[string]interface{}{"temperature": 21.0},
+		Desired:   map[string]interface{}{"temperature": 23.0},
+		Delta:     map[string]interface{}{"temperature": 23.0},
+		Version:   2,
+		DesiredBy: validID,
+	}
+
+	return tw, sdkTwin
+}
+
+func TestTwin(t *testing.T) {
+	ts, svc, authn := setupTwins()
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{TwinsURL: ts.URL})
+	tw, sdkTwin := generateTestTwin(t)
+
+	cases := []struct {
+		desc     string
+		token    string
+		session  mgauthn.Session
+		thingID  string
+		svcRes   twins.Twin
+		svcErr   error
+		authnErr error
+		response sdk.Twin
+		err      errors.SDKError
+	}{
+		{
+			desc:     "view twin successfully",
+			token:    validToken,
+			thingID:  tw.ThingID,
+			svcRes:   tw,
+			response: sdkTwin,
+		},
+		{
+			desc:     "view twin with invalid token",
+			token:    invalidToken,
+			thingID:  tw.ThingID,
+			authnErr: svcerr.ErrAuthentication,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:    "view twin with empty thing ID",
+			token:   validToken,
+			thingID: "",
+			err:     errors.NewSDKError(apiutil.ErrMissingID),
+		},
+		{
+			desc:    "view twin with unauthorized user",
+			token:   validToken,
+			thingID: tw.ThingID,
+			svcErr:  svcerr.ErrAuthorization,
+			err:     errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = mgauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("ViewTwin", mock.Anything, tc.session, tc.thingID).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.Twin(domainID, tc.thingID, tc.token)
+			assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.response, resp, fmt.Sprintf("%s: expected response %v got %v\n", tc.desc, tc.response, resp))
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "ViewTwin", mock.Anything, tc.session, tc.thingID)
+				assert.True(t, ok)
+			}
+			authCall.Unset()
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestSetTwinDesired(t *testing.T) {
+	ts, svc, authn := setupTwins()
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{TwinsURL: ts.URL})
+	tw, sdkTwin := generateTestTwin(t)
+	desired := map[string]interface{}{"temperature": 23.0}
+
+	cases := []struct {
+		desc     string
+		token    string
+		session  mgauthn.Session
+		thingID  string
+		desired  map[string]interface{}
+		svcRes   twins.Twin
+		svcErr   error
+		response sdk.Twin
+		err      errors.SDKError
+	}{
+		{
+			desc:     "set twin desired state successfully",
+			token:    validToken,
+			thingID:  tw.ThingID,
+			desired:  desired,
+			svcRes:   tw,
+			response: sdkTwin,
+		},
+		{
+			desc:    "set twin desired state with empty thing ID",
+			token:   validToken,
+			desired: desired,
+			err:     errors.NewSDKError(apiutil.ErrMissingID),
+		},
+		{
+			desc:    "set twin desired state with invalid value",
+			token:   validToken,
+			thingID: tw.ThingID,
+			desired: map[string]interface{}{"key": make(chan int)},
+			err:     errors.NewSDKError(errors.New("json: unsupported type: chan int")),
+		},
+		{
+			desc:    "set twin desired state with failed update",
+			token:   validToken,
+			thingID: tw.ThingID,
+			desired: desired,
+			svcErr:  svcerr.ErrUpdateEntity,
+			err:     errors.NewSDKErrorWithStatus(svcerr.ErrUpdateEntity, http.StatusUnprocessableEntity),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = mgauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, nil)
+			svcCall := svc.On("SetDesired", mock.Anything, tc.session, tc.thingID, twins.State(tc.desired)).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.SetTwinDesired(domainID, tc.thingID, tc.desired, tc.token)
+			assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.response, resp, fmt.Sprintf("%s: expected response %v got %v\n", tc.desc, tc.response, resp))
+			authCall.Unset()
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestPatchTwinDesired(t *testing.T) {
+	ts, svc, authn := setupTwins()
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{TwinsURL: ts.URL})
+	tw, sdkTwin := generateTestTwin(t)
+	patch := []sdk.TwinPatchOp{{Op: "replace", Path: "/temperature", Value: 23.0}}
+
+	cases := []struct {
+		desc     string
+		token    string
+		session  mgauthn.Session
+		thingID  string
+		patch    []sdk.TwinPatchOp
+		svcRes   twins.Twin
+		svcErr   error
+		response sdk.Twin
+		err      errors.SDKError
+	}{
+		{
+			desc:     "patch twin desired state successfully",
+			token:    validToken,
+			thingID:  tw.ThingID,
+			patch:    patch,
+			svcRes:   tw,
+			response: sdkTwin,
+		},
+		{
+			desc:    "patch twin desired state with empty patch",
+			token:   validToken,
+			thingID: tw.ThingID,
+			err:     errors.NewSDKError(apiutil.ErrEmptyList),
+		},
+		{
+			desc:    "patch twin desired state with invalid operation",
+			token:   validToken,
+			thingID: tw.ThingID,
+			patch:   []sdk.TwinPatchOp{{Op: "merge", Path: "/temperature"}},
+			err:     errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrInvalidPatchOp), http.StatusBadRequest),
+		},
+		{
+			desc:    "patch twin desired state with failed test",
+			token:   validToken,
+			thingID: tw.ThingID,
+			patch:   patch,
+			svcErr:  svcerr.ErrConflict,
+			err:     errors.NewSDKErrorWithStatus(svcerr.ErrConflict, http.StatusConflict),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = mgauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, nil)
+			svcCall := svc.On("PatchDesired", mock.Anything, tc.session, tc.thingID, mock.Anything).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.PatchTwinDesired(domainID, tc.thingID, tc.patch, tc.token)
+			assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.response, resp, fmt.Sprintf("%s: expected response %v got %v\n", tc.desc, tc.response, resp))
+			authCall.Unset()
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestTwinHistory(t *testing.T) {
+	ts, svc, authn := setupTwins()
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{TwinsURL: ts.URL})
+	tw, _ := generateTestTwin(t)
+	state := twins.TwinState{
+		ThingID:   tw.ThingID,
+		Version:   2,
+		Cause:     twins.DesiredCause,
+		Reported:  tw.Reported,
+		Desired:   tw.Desired,
+		CreatedBy: validID,
+	}
+	sdkState := sdk.TwinState{
+		ThingID:   tw.ThingID,
+		Version:   2,
+		Cause:     twins.Desired,
+		Reported:  map[string]interface{}{"temperature": 21.0},
+		Desired:   map[string]interface{}{"temperature": 23.0},
+		CreatedBy: validID,
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		session  mgauthn.Session
+		thingID  string
+		pm       sdk.PageMetadata
+		svcPM    twins.PageMetadata
+		svcRes   twins.StatesPage
+		svcErr   error
+		response sdk.TwinStatesPage
+		err      errors.SDKError
+	}{
+		{
+			desc:    "list twin history successfully",
+			token:   validToken,
+			thingID: tw.ThingID,
+			pm:      sdk.PageMetadata{Offset: 0, Limit: 10},
+			svcPM:   twins.PageMetadata{Offset: 0, Limit: 10},
+			svcRes: twins.StatesPage{
+				PageMetadata: twins.PageMetadata{Offset: 0, Limit: 10},
+				Total:        1,
+				States:       []twins.TwinState{state},
+			},
+			response: sdk.TwinStatesPage{
+				Limit:  10,
+				Total:  1,
+				States: []sdk.TwinState{sdkState},
+			},
+		},
+		{
+			desc:    "list twin history with limit greater than max",
+			token:   validToken,
+			thingID: tw.ThingID,
+			pm:      sdk.PageMetadata{Offset: 0, Limit: 1000},
+			err:     errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrLimitSize), http.StatusBadRequest),
+		},
+		{
+			desc:  "list twin history with empty thing ID",
+			token: validToken,
+			pm:    sdk.PageMetadata{Offset: 0, Limit: 10},
+			err:   errors.NewSDKError(apiutil.ErrMissingID),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = mgauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, nil)
+			svcCall := svc.On("ListHistory", mock.Anything, tc.session, tc.thingID, tc.svcPM).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.TwinHistory(domainID, tc.thingID, tc.pm, tc.token)
+			assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.response, resp, fmt.Sprintf("%s: expected response %v got %v\n", tc.desc, tc.response, resp))
+			authCall.Unset()
+			svcCall.Unset()
+		})
+	}
+}
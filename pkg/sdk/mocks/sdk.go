@@ -18,6 +18,34 @@ type SDK struct {
 	mock.Mock
 }
 
+// AcceptEmailInvitation provides a mock function with given fields: invitationToken, token
+func (_m *SDK) AcceptEmailInvitation(invitationToken string, token string) (sdk.Invitation, error) {
+	ret := _m.Called(invitationToken, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptEmailInvitation")
+	}
+
+	var r0 sdk.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.Invitation, error)); ok {
+		return rf(invitationToken, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.Invitation); ok {
+		r0 = rf(invitationToken, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Invitation)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(invitationToken, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AcceptInvitation provides a mock function with given fields: domainID, token
 func (_m *SDK) AcceptInvitation(domainID string, token string) error {
 	ret := _m.Called(domainID, token)
@@ -176,9 +204,9 @@ func (_m *SDK) Bootstrap(externalID string, externalKey string) (sdk.BootstrapCo
 	return r0, r1
 }
 
-// BootstrapSecure provides a mock function with given fields: externalID, externalKey, cryptoKey
-func (_m *SDK) BootstrapSecure(externalID string, externalKey string, cryptoKey string) (sdk.BootstrapConfig, errors.SDKError) {
-	ret := _m.Called(externalID, externalKey, cryptoKey)
+// BootstrapSecure provides a mock function with given fields: externalID, externalKey, cryptoKey, keyVersion
+func (_m *SDK) BootstrapSecure(externalID string, externalKey string, cryptoKey string, keyVersion uint8) (sdk.BootstrapConfig, errors.SDKError) {
+	ret := _m.Called(externalID, externalKey, cryptoKey, keyVersion)
 
 	if len(ret) == 0 {
 		panic("no return value specified for BootstrapSecure")
@@ -186,17 +214,17 @@ func (_m *SDK) BootstrapSecure(externalID string, externalKey string, cryptoKey
 
 	var r0 sdk.BootstrapConfig
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.BootstrapConfig, errors.SDKError)); ok {
-		return rf(externalID, externalKey, cryptoKey)
+	if rf, ok := ret.Get(0).(func(string, string, string, uint8) (sdk.BootstrapConfig, errors.SDKError)); ok {
+		return rf(externalID, externalKey, cryptoKey, keyVersion)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string) sdk.BootstrapConfig); ok {
-		r0 = rf(externalID, externalKey, cryptoKey)
+	if rf, ok := ret.Get(0).(func(string, string, string, uint8) sdk.BootstrapConfig); ok {
+		r0 = rf(externalID, externalKey, cryptoKey, keyVersion)
 	} else {
 		r0 = ret.Get(0).(sdk.BootstrapConfig)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
-		r1 = rf(externalID, externalKey, cryptoKey)
+	if rf, ok := ret.Get(1).(func(string, string, string, uint8) errors.SDKError); ok {
+		r1 = rf(externalID, externalKey, cryptoKey, keyVersion)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -688,6 +716,24 @@ func (_m *SDK) DeleteChannel(id string, domainID string, token string) errors.SD
 	return r0
 }
 
+// DeleteEmailInvitation provides a mock function with given fields: email, domainID, token
+func (_m *SDK) DeleteEmailInvitation(email string, domainID string, token string) error {
+	ret := _m.Called(email, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEmailInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(email, domainID, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteGroup provides a mock function with given fields: id, domainID, token
 func (_m *SDK) DeleteGroup(id string, domainID string, token string) errors.SDKError {
 	ret := _m.Called(id, domainID, token)
@@ -1056,6 +1102,66 @@ func (_m *SDK) Domains(pm sdk.PageMetadata, token string) (sdk.DomainsPage, erro
 	return r0, r1
 }
 
+// DownloadJournal provides a mock function with given fields: domainID, format, pm, token
+func (_m *SDK) DownloadJournal(domainID string, format string, pm sdk.PageMetadata, token string) ([]byte, errors.SDKError) {
+	ret := _m.Called(domainID, format, pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DownloadJournal")
+	}
+
+	var r0 []byte
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, sdk.PageMetadata, string) ([]byte, errors.SDKError)); ok {
+		return rf(domainID, format, pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, sdk.PageMetadata, string) []byte); ok {
+		r0 = rf(domainID, format, pm, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(domainID, format, pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// EmailInvitations provides a mock function with given fields: pm, token
+func (_m *SDK) EmailInvitations(pm sdk.PageMetadata, token string) (sdk.EmailInvitationPage, error) {
+	ret := _m.Called(pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EmailInvitations")
+	}
+
+	var r0 sdk.EmailInvitationPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) (sdk.EmailInvitationPage, error)); ok {
+		return rf(pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) sdk.EmailInvitationPage); ok {
+		r0 = rf(pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.EmailInvitationPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.PageMetadata, string) error); ok {
+		r1 = rf(pm, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // EnableChannel provides a mock function with given fields: id, domainID, token
 func (_m *SDK) EnableChannel(id string, domainID string, token string) (sdk.Channel, errors.SDKError) {
 	ret := _m.Called(id, domainID, token)
@@ -1196,6 +1302,38 @@ func (_m *SDK) EnableUser(id string, token string) (sdk.User, errors.SDKError) {
 	return r0, r1
 }
 
+// ExportJournal provides a mock function with given fields: domainID, from, to, token
+func (_m *SDK) ExportJournal(domainID string, from uint64, to uint64, token string) ([]byte, errors.SDKError) {
+	ret := _m.Called(domainID, from, to, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportJournal")
+	}
+
+	var r0 []byte
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, uint64, uint64, string) ([]byte, errors.SDKError)); ok {
+		return rf(domainID, from, to, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, uint64, uint64, string) []byte); ok {
+		r0 = rf(domainID, from, to, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, uint64, uint64, string) errors.SDKError); ok {
+		r1 = rf(domainID, from, to, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Group provides a mock function with given fields: id, domainID, token
 func (_m *SDK) Group(id string, domainID string, token string) (sdk.Group, errors.SDKError) {
 	ret := _m.Called(id, domainID, token)
@@ -1430,6 +1568,36 @@ func (_m *SDK) Journal(entityType string, entityID string, domainID string, pm s
 	return r0, r1
 }
 
+// JournalRetention provides a mock function with given fields: domainID, token
+func (_m *SDK) JournalRetention(domainID string, token string) (sdk.JournalRetention, errors.SDKError) {
+	ret := _m.Called(domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JournalRetention")
+	}
+
+	var r0 sdk.JournalRetention
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.JournalRetention, errors.SDKError)); ok {
+		return rf(domainID, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.JournalRetention); ok {
+		r0 = rf(domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.JournalRetention)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // ListChannelUserGroups provides a mock function with given fields: channelID, pm, domainID, token
 func (_m *SDK) ListChannelUserGroups(channelID string, pm sdk.PageMetadata, domainID string, token string) (sdk.GroupsPage, errors.SDKError) {
 	ret := _m.Called(channelID, pm, domainID, token)
@@ -1820,6 +1988,36 @@ func (_m *SDK) Parents(id string, pm sdk.PageMetadata, domainID string, token st
 	return r0, r1
 }
 
+// PatchTwinDesired provides a mock function with given fields: domainID, thingID, patch, token
+func (_m *SDK) PatchTwinDesired(domainID string, thingID string, patch []sdk.TwinPatchOp, token string) (sdk.Twin, errors.SDKError) {
+	ret := _m.Called(domainID, thingID, patch, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchTwinDesired")
+	}
+
+	var r0 sdk.Twin
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, []sdk.TwinPatchOp, string) (sdk.Twin, errors.SDKError)); ok {
+		return rf(domainID, thingID, patch, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, []sdk.TwinPatchOp, string) sdk.Twin); ok {
+		r0 = rf(domainID, thingID, patch, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Twin)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, []sdk.TwinPatchOp, string) errors.SDKError); ok {
+		r1 = rf(domainID, thingID, patch, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // ReadMessages provides a mock function with given fields: pm, chanID, domainID, token
 func (_m *SDK) ReadMessages(pm sdk.MessagePageMetadata, chanID string, domainID string, token string) (sdk.MessagesPage, errors.SDKError) {
 	ret := _m.Called(pm, chanID, domainID, token)
@@ -1918,6 +2116,26 @@ func (_m *SDK) RemoveBootstrap(id string, domainID string, token string) errors.
 	return r0
 }
 
+// RemoveJournalRetention provides a mock function with given fields: domainID, token
+func (_m *SDK) RemoveJournalRetention(domainID string, token string) errors.SDKError {
+	ret := _m.Called(domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveJournalRetention")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(domainID, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
 // RemoveUserFromChannel provides a mock function with given fields: channelID, req, domainID, token
 func (_m *SDK) RemoveUserFromChannel(channelID string, req sdk.UsersRelationRequest, domainID string, token string) errors.SDKError {
 	ret := _m.Called(channelID, req, domainID, token)
@@ -2098,6 +2316,36 @@ func (_m *SDK) SearchUsers(pm sdk.PageMetadata, token string) (sdk.UsersPage, er
 	return r0, r1
 }
 
+// SendEmailInvitations provides a mock function with given fields: domainID, relation, emails, resend, token
+func (_m *SDK) SendEmailInvitations(domainID string, relation string, emails []string, resend bool, token string) ([]sdk.EmailInvitation, error) {
+	ret := _m.Called(domainID, relation, emails, resend, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendEmailInvitations")
+	}
+
+	var r0 []sdk.EmailInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, []string, bool, string) ([]sdk.EmailInvitation, error)); ok {
+		return rf(domainID, relation, emails, resend, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, []string, bool, string) []sdk.EmailInvitation); ok {
+		r0 = rf(domainID, relation, emails, resend, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sdk.EmailInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, []string, bool, string) error); ok {
+		r1 = rf(domainID, relation, emails, resend, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SendInvitation provides a mock function with given fields: invitation, token
 func (_m *SDK) SendInvitation(invitation sdk.Invitation, token string) error {
 	ret := _m.Called(invitation, token)
@@ -2156,6 +2404,66 @@ func (_m *SDK) SetContentType(ct sdk.ContentType) errors.SDKError {
 	return r0
 }
 
+// SetJournalRetention provides a mock function with given fields: domainID, days, token
+func (_m *SDK) SetJournalRetention(domainID string, days uint64, token string) (sdk.JournalRetention, errors.SDKError) {
+	ret := _m.Called(domainID, days, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetJournalRetention")
+	}
+
+	var r0 sdk.JournalRetention
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, uint64, string) (sdk.JournalRetention, errors.SDKError)); ok {
+		return rf(domainID, days, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, uint64, string) sdk.JournalRetention); ok {
+		r0 = rf(domainID, days, token)
+	} else {
+		r0 = ret.Get(0).(sdk.JournalRetention)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, uint64, string) errors.SDKError); ok {
+		r1 = rf(domainID, days, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SetTwinDesired provides a mock function with given fields: domainID, thingID, desired, token
+func (_m *SDK) SetTwinDesired(domainID string, thingID string, desired map[string]interface{}, token string) (sdk.Twin, errors.SDKError) {
+	ret := _m.Called(domainID, thingID, desired, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTwinDesired")
+	}
+
+	var r0 sdk.Twin
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, map[string]interface{}, string) (sdk.Twin, errors.SDKError)); ok {
+		return rf(domainID, thingID, desired, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, map[string]interface{}, string) sdk.Twin); ok {
+		r0 = rf(domainID, thingID, desired, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Twin)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, map[string]interface{}, string) errors.SDKError); ok {
+		r1 = rf(domainID, thingID, desired, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // ShareThing provides a mock function with given fields: thingID, req, domainID, token
 func (_m *SDK) ShareThing(thingID string, req sdk.UsersRelationRequest, domainID string, token string) errors.SDKError {
 	ret := _m.Called(thingID, req, domainID, token)
@@ -2296,6 +2604,66 @@ func (_m *SDK) ThingsByChannel(chanID string, pm sdk.PageMetadata, domainID stri
 	return r0, r1
 }
 
+// Twin provides a mock function with given fields: domainID, thingID, token
+func (_m *SDK) Twin(domainID string, thingID string, token string) (sdk.Twin, errors.SDKError) {
+	ret := _m.Called(domainID, thingID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Twin")
+	}
+
+	var r0 sdk.Twin
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.Twin, errors.SDKError)); ok {
+		return rf(domainID, thingID, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) sdk.Twin); ok {
+		r0 = rf(domainID, thingID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Twin)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
+		r1 = rf(domainID, thingID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// TwinHistory provides a mock function with given fields: domainID, thingID, pm, token
+func (_m *SDK) TwinHistory(domainID string, thingID string, pm sdk.PageMetadata, token string) (sdk.TwinStatesPage, errors.SDKError) {
+	ret := _m.Called(domainID, thingID, pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TwinHistory")
+	}
+
+	var r0 sdk.TwinStatesPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, sdk.PageMetadata, string) (sdk.TwinStatesPage, errors.SDKError)); ok {
+		return rf(domainID, thingID, pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, sdk.PageMetadata, string) sdk.TwinStatesPage); ok {
+		r0 = rf(domainID, thingID, pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.TwinStatesPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(domainID, thingID, pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // UnshareThing provides a mock function with given fields: thingID, req, domainID, token
 func (_m *SDK) UnshareThing(thingID string, req sdk.UsersRelationRequest, domainID string, token string) errors.SDKError {
 	ret := _m.Called(thingID, req, domainID, token)
@@ -2866,6 +3234,36 @@ func (_m *SDK) Users(pm sdk.PageMetadata, token string) (sdk.UsersPage, errors.S
 	return r0, r1
 }
 
+// VerifyJournal provides a mock function with given fields: domainID, token
+func (_m *SDK) VerifyJournal(domainID string, token string) (sdk.JournalVerification, errors.SDKError) {
+	ret := _m.Called(domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyJournal")
+	}
+
+	var r0 sdk.JournalVerification
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.JournalVerification, errors.SDKError)); ok {
+		return rf(domainID, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.JournalVerification); ok {
+		r0 = rf(domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.JournalVerification)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // ViewBootstrap provides a mock function with given fields: id, domainID, token
 func (_m *SDK) ViewBootstrap(id string, domainID string, token string) (sdk.BootstrapConfig, errors.SDKError) {
 	ret := _m.Called(id, domainID, token)
@@ -5,6 +5,7 @@ package coap
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -12,31 +13,50 @@ import (
 	"github.com/absmach/magistrala/pkg/server"
 	gocoap "github.com/plgd-dev/go-coap/v3"
 	"github.com/plgd-dev/go-coap/v3/mux"
+	"github.com/plgd-dev/go-coap/v3/net/blockwise"
+	"github.com/plgd-dev/go-coap/v3/options"
 )
 
+var errBlockSize = errors.New("block size must be a power of two between 16 and 1024 bytes")
+
+// Transport contains the CoAP transport settings shared by the plain and
+// the DTLS servers. Messages larger than the block size are transferred
+// block-wise (RFC 7959) in both directions.
+type Transport struct {
+	BlockSize        uint32        `env:"BLOCK_SIZE"        envDefault:"1024"`
+	BlockwiseTimeout time.Duration `env:"BLOCKWISE_TIMEOUT" envDefault:"3s"`
+	MaxMessageSize   uint32        `env:"MAX_MESSAGE_SIZE"  envDefault:"65536"`
+}
+
 type coapServer struct {
 	server.BaseServer
-	handler mux.HandlerFunc
+	transport Transport
+	handler   mux.HandlerFunc
 }
 
 var _ server.Server = (*coapServer)(nil)
 
-func NewServer(ctx context.Context, cancel context.CancelFunc, name string, config server.Config, handler mux.HandlerFunc, logger *slog.Logger) server.Server {
+func NewServer(ctx context.Context, cancel context.CancelFunc, name string, config server.Config, transport Transport, handler mux.HandlerFunc, logger *slog.Logger) server.Server {
 	baseServer := server.NewBaseServer(ctx, cancel, name, config, logger)
 
 	return &coapServer{
 		BaseServer: baseServer,
+		transport:  transport,
 		handler:    handler,
 	}
 }
 
 func (s *coapServer) Start() error {
 	errCh := make(chan error)
+	bw, err := s.transport.blockwise()
+	if err != nil {
+		return err
+	}
 	s.Logger.Info(fmt.Sprintf("%s service started using http, exposed port %s", s.Name, s.Address))
 	s.Logger.Info(fmt.Sprintf("%s service %s server listening at %s without TLS", s.Name, s.Protocol, s.Address))
 
 	go func() {
-		errCh <- gocoap.ListenAndServe("udp", s.Address, s.handler)
+		errCh <- gocoap.ListenAndServeWithOptions("udp", s.Address, options.WithMux(s.handler), bw, options.WithMaxMessageSize(s.transport.MaxMessageSize))
 	}()
 
 	select {
@@ -58,3 +78,12 @@ func (s *coapServer) Stop() error {
 	s.Logger.Info(fmt.Sprintf("%s service shutdown of http at %s", s.Name, s.Address))
 	return nil
 }
+
+func (t Transport) blockwise() (options.BlockwiseOpt, error) {
+	for szx := blockwise.SZX16; szx <= blockwise.SZX1024; szx++ {
+		if szx.Size() == int64(t.BlockSize) {
+			return options.WithBlockwise(true, szx, t.BlockwiseTimeout), nil
+		}
+	}
+	return options.BlockwiseOpt{}, errBlockSize
+}
@@ -0,0 +1,142 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package coap
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/server"
+	piondtls "github.com/pion/dtls/v3"
+	gocoap "github.com/plgd-dev/go-coap/v3"
+	"github.com/plgd-dev/go-coap/v3/mux"
+	"github.com/plgd-dev/go-coap/v3/options"
+)
+
+// PSKIdentityHint is sent to the clients to indicate that the PSK identity
+// is expected to be the thing ID.
+const PSKIdentityHint = "magistrala"
+
+var (
+	errMissingCert     = errors.New("DTLS certificate mode requires server certificate and key")
+	errMissingClientCA = errors.New("DTLS certificate mode requires client CA certificates")
+)
+
+// PSKCallback returns the pre-shared key for the given PSK identity.
+type PSKCallback func(identity []byte) ([]byte, error)
+
+type dtlsServer struct {
+	server.BaseServer
+	transport Transport
+	handler   mux.HandlerFunc
+	psk       PSKCallback
+	clientCAs []*x509.Certificate
+}
+
+var _ server.Server = (*dtlsServer)(nil)
+
+// NewDTLSServer returns the CoAP server secured with DTLS 1.2. If the PSK
+// callback is provided, clients are authenticated with the pre-shared keys.
+// Otherwise, the server certificate and key are loaded from the config and
+// the client certificates are verified against the client CA certificates
+// loaded from the config and the given client CA certificates.
+func NewDTLSServer(ctx context.Context, cancel context.CancelFunc, name string, config server.Config, transport Transport, psk PSKCallback, clientCAs []*x509.Certificate, handler mux.HandlerFunc, logger *slog.Logger) server.Server {
+	baseServer := server.NewBaseServer(ctx, cancel, name, config, logger)
+
+	return &dtlsServer{
+		BaseServer: baseServer,
+		transport:  transport,
+		handler:    handler,
+		psk:        psk,
+		clientCAs:  clientCAs,
+	}
+}
+
+func (s *dtlsServer) Start() error {
+	errCh := make(chan error)
+	bw, err := s.transport.blockwise()
+	if err != nil {
+		return err
+	}
+
+	var cfg *piondtls.Config
+	switch s.psk {
+	case nil:
+		c, err := s.certConfig()
+		if err != nil {
+			return err
+		}
+		cfg = c
+		s.Logger.Info(fmt.Sprintf("%s service %s server listening at %s with DTLS cert %s, key %s, client ca %s and %d additional client CAs", s.Name, s.Protocol, s.Address, s.Config.CertFile, s.Config.KeyFile, s.Config.ClientCAFile, len(s.clientCAs)))
+	default:
+		cfg = &piondtls.Config{
+			PSK:                  piondtls.PSKCallback(s.psk),
+			PSKIdentityHint:      []byte(PSKIdentityHint),
+			ExtendedMasterSecret: piondtls.RequireExtendedMasterSecret,
+		}
+		s.Logger.Info(fmt.Sprintf("%s service %s server listening at %s with DTLS pre-shared keys", s.Name, s.Protocol, s.Address))
+	}
+
+	go func() {
+		errCh <- gocoap.ListenAndServeDTLSWithOptions("udp", s.Address, cfg, options.WithMux(s.handler), bw, options.WithMaxMessageSize(s.transport.MaxMessageSize))
+	}()
+
+	select {
+	case <-s.Ctx.Done():
+		return s.Stop()
+	case err := <-errCh:
+		return err
+	}
+}
+
+func (s *dtlsServer) Stop() error {
+	defer s.Cancel()
+	c := make(chan bool)
+	defer close(c)
+	select {
+	case <-c:
+	case <-time.After(server.StopWaitTime):
+	}
+	s.Logger.Info(fmt.Sprintf("%s service shutdown of DTLS at %s", s.Name, s.Address))
+	return nil
+}
+
+func (s *dtlsServer) certConfig() (*piondtls.Config, error) {
+	if s.Config.CertFile == "" || s.Config.KeyFile == "" {
+		return nil, errMissingCert
+	}
+	if s.Config.ClientCAFile == "" && len(s.clientCAs) == 0 {
+		return nil, errMissingClientCA
+	}
+	certificate, err := tls.LoadX509KeyPair(s.Config.CertFile, s.Config.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load auth certificates: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if s.Config.ClientCAFile != "" {
+		clientCA, err := os.ReadFile(s.Config.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client ca file: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(clientCA) {
+			return nil, fmt.Errorf("failed to append client ca to dtls.Config")
+		}
+	}
+	for _, ca := range s.clientCAs {
+		pool.AddCert(ca)
+	}
+
+	return &piondtls.Config{
+		Certificates:         []tls.Certificate{certificate},
+		ClientAuth:           piondtls.RequireAndVerifyClientCert,
+		ClientCAs:            pool,
+		ExtendedMasterSecret: piondtls.RequireExtendedMasterSecret,
+	}, nil
+}
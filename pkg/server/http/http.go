@@ -5,9 +5,12 @@ package http
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"os"
 
 	"github.com/absmach/magistrala/pkg/server"
 )
@@ -40,6 +43,23 @@ func (s *httpServer) Start() error {
 	switch {
 	case s.Config.CertFile != "" || s.Config.KeyFile != "":
 		s.Protocol = httpsProtocol
+		if s.Config.ClientCAFile != "" {
+			clientCA, err := os.ReadFile(s.Config.ClientCAFile)
+			if err != nil {
+				return fmt.Errorf("failed to load client ca file: %w", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(clientCA) {
+				return fmt.Errorf("failed to append client ca to tls.Config")
+			}
+			// Client certificates are optional so that the same server can
+			// serve both token and certificate authenticated endpoints.
+			s.server.TLSConfig = &tls.Config{
+				ClientAuth: tls.VerifyClientCertIfGiven,
+				ClientCAs:  pool,
+			}
+			s.Logger.Info(fmt.Sprintf("%s service %s server verifies client certificates with client ca %s", s.Name, s.Protocol, s.Config.ClientCAFile))
+		}
 		s.Logger.Info(fmt.Sprintf("%s service %s server listening at %s with TLS cert %s and key %s", s.Name, s.Protocol, s.Address, s.Config.CertFile, s.Config.KeyFile))
 		go func() {
 			errCh <- s.server.ListenAndServeTLS(s.Config.CertFile, s.Config.KeyFile)
@@ -0,0 +1,63 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cbor
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/transformers"
+	mgjson "github.com/absmach/magistrala/pkg/transformers/json"
+	"github.com/fxamacker/cbor/v2"
+)
+
+// ErrTransform represents an error during decoding CBOR message.
+var ErrTransform = errors.New("unable to decode CBOR object")
+
+type transformer struct {
+	dec  cbor.DecMode
+	json transformers.Transformer
+}
+
+// New returns a new CBOR transformer. CBOR maps and arrays of maps are
+// transformed the same way as their JSON counterparts.
+func New(tfs []mgjson.TimeField) (transformers.Transformer, error) {
+	dec, err := cbor.DecOptions{
+		DefaultMapType: reflect.TypeOf(map[string]interface{}(nil)),
+	}.DecMode()
+	if err != nil {
+		return nil, err
+	}
+
+	return &transformer{
+		dec:  dec,
+		json: mgjson.New(tfs),
+	}, nil
+}
+
+// Transform transforms CBOR encoded Magistrala message to a list of JSON messages.
+func (t *transformer) Transform(msg *messaging.Message) (interface{}, error) {
+	var payload interface{}
+	if err := t.dec.Unmarshal(msg.GetPayload(), &payload); err != nil {
+		return nil, errors.Wrap(ErrTransform, err)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(ErrTransform, err)
+	}
+
+	m := &messaging.Message{
+		Channel:   msg.GetChannel(),
+		Subtopic:  msg.GetSubtopic(),
+		Publisher: msg.GetPublisher(),
+		Protocol:  msg.GetProtocol(),
+		Payload:   data,
+		Created:   msg.GetCreated(),
+	}
+
+	return t.json.Transform(m)
+}
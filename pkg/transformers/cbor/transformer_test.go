@@ -0,0 +1,134 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cbor_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/transformers/cbor"
+	"github.com/absmach/magistrala/pkg/transformers/json"
+	fxcbor "github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformCBOR(t *testing.T) {
+	now := time.Now().Unix()
+	tr, err := cbor.New([]json.TimeField{{FieldName: "ts", FieldFormat: "unix"}})
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating transformer: %s", err))
+
+	obj, err := fxcbor.Marshal(map[string]interface{}{"key1": "val1", "key2": 123, "key3": map[string]interface{}{"key4": true}})
+	require.Nil(t, err, fmt.Sprintf("unexpected error encoding CBOR: %s", err))
+	list, err := fxcbor.Marshal([]interface{}{map[string]interface{}{"key1": "val1"}, map[string]interface{}{"key1": "val2"}})
+	require.Nil(t, err, fmt.Sprintf("unexpected error encoding CBOR: %s", err))
+	ts, err := fxcbor.Marshal(map[string]interface{}{"key1": "val1", "ts": 1638310819})
+	require.Nil(t, err, fmt.Sprintf("unexpected error encoding CBOR: %s", err))
+
+	newMsg := func(payload []byte) *messaging.Message {
+		return &messaging.Message{
+			Channel:   "channel-1",
+			Subtopic:  "subtopic-1",
+			Publisher: "publisher-1",
+			Protocol:  "protocol",
+			Payload:   payload,
+			Created:   now,
+		}
+	}
+
+	jsonMsg := json.Message{
+		Channel:   "channel-1",
+		Subtopic:  "subtopic-1",
+		Publisher: "publisher-1",
+		Protocol:  "protocol",
+		Created:   now,
+	}
+
+	cases := []struct {
+		desc string
+		msg  *messaging.Message
+		json interface{}
+		err  error
+	}{
+		{
+			desc: "test transform CBOR map",
+			msg:  newMsg(obj),
+			json: json.Messages{
+				Data: []json.Message{
+					{
+						Channel:   jsonMsg.Channel,
+						Subtopic:  jsonMsg.Subtopic,
+						Publisher: jsonMsg.Publisher,
+						Protocol:  jsonMsg.Protocol,
+						Created:   now,
+						Payload: map[string]interface{}{
+							"key1": "val1",
+							"key2": float64(123),
+							"key3": map[string]interface{}{"key4": true},
+						},
+					},
+				},
+				Format: "subtopic-1",
+			},
+		},
+		{
+			desc: "test transform CBOR array",
+			msg:  newMsg(list),
+			json: json.Messages{
+				Data: []json.Message{
+					{
+						Channel:   jsonMsg.Channel,
+						Subtopic:  jsonMsg.Subtopic,
+						Publisher: jsonMsg.Publisher,
+						Protocol:  jsonMsg.Protocol,
+						Created:   now,
+						Payload:   map[string]interface{}{"key1": "val1"},
+					},
+					{
+						Channel:   jsonMsg.Channel,
+						Subtopic:  jsonMsg.Subtopic,
+						Publisher: jsonMsg.Publisher,
+						Protocol:  jsonMsg.Protocol,
+						Created:   now,
+						Payload:   map[string]interface{}{"key1": "val2"},
+					},
+				},
+				Format: "subtopic-1",
+			},
+		},
+		{
+			desc: "test transform CBOR map with time field",
+			msg:  newMsg(ts),
+			json: json.Messages{
+				Data: []json.Message{
+					{
+						Channel:   jsonMsg.Channel,
+						Subtopic:  jsonMsg.Subtopic,
+						Publisher: jsonMsg.Publisher,
+						Protocol:  jsonMsg.Protocol,
+						Created:   1638310819000000000,
+						Payload:   map[string]interface{}{"key1": "val1", "ts": float64(1638310819)},
+					},
+				},
+				Format: "subtopic-1",
+			},
+		},
+		{
+			desc: "test transform invalid CBOR",
+			msg:  newMsg([]byte{0xff, 0x01}),
+			err:  cbor.ErrTransform,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			m, err := tr.Transform(tc.msg)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %s got %s", tc.err, err))
+			assert.Equal(t, tc.json, m)
+		})
+	}
+}
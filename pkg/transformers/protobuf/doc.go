@@ -0,0 +1,8 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package protobuf contains Protocol Buffers transformer and schema registry.
+//
+// Schemas are kept in the channel metadata managed by the things service,
+// and the registry caches them in the consumer.
+package protobuf
@@ -0,0 +1,282 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package protobuf
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// MetadataKey is the channel metadata key of the protobuf schemas.
+const MetadataKey = "protobuf"
+
+var (
+	// ErrSchemaNotFound indicates that there is no schema bound to the channel and subtopic.
+	ErrSchemaNotFound = errors.New("protobuf schema not found")
+	// ErrInvalidSchema indicates that the schema descriptor or message type is invalid.
+	ErrInvalidSchema = errors.New("invalid protobuf schema")
+
+	errLoadSchema        = errors.New("failed to load channel protobuf schemas")
+	errMissingMessage    = errors.New("missing schema message type")
+	errMissingDescriptor = errors.New("missing schema descriptor")
+	errMessageNotFound   = errors.New("message type not found in descriptor")
+	errInvalidDescriptor = errors.New("unable to parse file descriptor set")
+)
+
+// SenMLField maps a decoded protobuf field to a SenML record.
+type SenMLField struct {
+	// Field is the path of the field in the decoded message, using the
+	// `/` separator for nested messages (e.g. "env/temperature").
+	Field string `json:"field"`
+	Name  string `json:"name"`
+	Unit  string `json:"unit,omitempty"`
+}
+
+// SenMLMapping describes how a decoded protobuf message is turned into
+// a list of SenML records.
+type SenMLMapping struct {
+	// TimeField is the path of the field holding the record timestamp.
+	// If empty, message reception time is used.
+	TimeField string       `json:"time_field,omitempty"`
+	Fields    []SenMLField `json:"fields"`
+}
+
+// Schema binds a protobuf message type to the channel subtopic.
+type Schema struct {
+	// Subtopic is matched exactly. Empty subtopic binds the schema to
+	// every subtopic of the channel that has no schema of its own.
+	Subtopic string `json:"subtopic,omitempty"`
+	// Message is the fully qualified name of the protobuf message type.
+	Message string `json:"message"`
+	// Format overrides the JSON message format which otherwise equals
+	// the last part of the subtopic.
+	Format string `json:"format,omitempty"`
+	// SenML, if set, makes the transformer produce SenML records instead
+	// of JSON messages.
+	SenML *SenMLMapping `json:"senml,omitempty"`
+}
+
+// Schemas are the protobuf schemas of the channel, kept in the channel
+// metadata under the MetadataKey.
+type Schemas struct {
+	// Descriptor is the serialized FileDescriptorSet, as produced by
+	// `protoc --include_imports --descriptor_set_out`. It's base64 encoded
+	// in the channel metadata.
+	Descriptor []byte   `json:"descriptor"`
+	Schemas    []Schema `json:"schemas"`
+}
+
+// Registry contains protobuf schemas bound to the channels and subtopics.
+type Registry interface {
+	// Save compiles the protobuf schemas from the channel metadata and
+	// stores them. If metadata contains no schemas, the channel is stored
+	// without schemas.
+	Save(channelID string, metadata map[string]interface{}) error
+
+	// Remove removes the channel schemas, so they are loaded again on the
+	// next resolution.
+	Remove(channelID string)
+
+	// Resolve returns the schema and message descriptor bound to the
+	// channel and subtopic, falling back to the channel-wide schema.
+	Resolve(ctx context.Context, channelID, subtopic string) (Schema, protoreflect.MessageDescriptor, error)
+}
+
+type entry struct {
+	schema Schema
+	desc   protoreflect.MessageDescriptor
+}
+
+type channelSchemas struct {
+	schemas   map[string]entry
+	err       error
+	expiresAt time.Time
+}
+
+type registry struct {
+	things  magistrala.ThingsServiceClient
+	ttl     time.Duration
+	mu      sync.RWMutex
+	schemas map[string]channelSchemas
+}
+
+var _ Registry = (*registry)(nil)
+
+// NewRegistry returns protobuf schema registry backed by the channel
+// metadata. Schemas are uploaded by updating the channel metadata through
+// the things service, so they are persisted with the channel. Schemas of
+// the channel missing from the registry are loaded from the things service
+// and kept for the given TTL, so the registry converges even if the channel
+// events are missed.
+func NewRegistry(things magistrala.ThingsServiceClient, ttl time.Duration) Registry {
+	return &registry{
+		things:  things,
+		ttl:     ttl,
+		schemas: make(map[string]channelSchemas),
+	}
+}
+
+func (r *registry) Save(channelID string, metadata map[string]interface{}) error {
+	cs := r.compile(metadata)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schemas[channelID] = cs
+
+	return cs.err
+}
+
+func (r *registry) Remove(channelID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.schemas, channelID)
+}
+
+func (r *registry) Resolve(ctx context.Context, channelID, subtopic string) (Schema, protoreflect.MessageDescriptor, error) {
+	cs, err := r.channel(ctx, channelID)
+	if err != nil {
+		return Schema{}, nil, err
+	}
+	if cs.err != nil {
+		return Schema{}, nil, cs.err
+	}
+
+	if e, ok := cs.schemas[subtopic]; ok {
+		return e.schema, e.desc, nil
+	}
+	if e, ok := cs.schemas[""]; ok {
+		return e.schema, e.desc, nil
+	}
+
+	return Schema{}, nil, ErrSchemaNotFound
+}
+
+// channel returns the cached channel schemas, loading them if they're
+// missing or expired. If loading fails, the expired schemas are used until
+// the things service is reachable again.
+func (r *registry) channel(ctx context.Context, channelID string) (channelSchemas, error) {
+	r.mu.RLock()
+	cs, ok := r.schemas[channelID]
+	r.mu.RUnlock()
+	if ok && time.Now().Before(cs.expiresAt) {
+		return cs, nil
+	}
+
+	metadata, err := r.load(ctx, channelID)
+	switch {
+	case errors.Contains(err, svcerr.ErrNotFound):
+		metadata = nil
+	case err != nil:
+		if ok {
+			return cs, nil
+		}
+		return channelSchemas{}, errors.Wrap(errLoadSchema, err)
+	}
+
+	cs = r.compile(metadata)
+	r.mu.Lock()
+	r.schemas[channelID] = cs
+	r.mu.Unlock()
+
+	return cs, nil
+}
+
+func (r *registry) load(ctx context.Context, channelID string) (map[string]interface{}, error) {
+	res, err := r.things.ChannelMetadata(ctx, &magistrala.ChannelMetadataReq{ChannelId: channelID})
+	if err != nil {
+		return nil, err
+	}
+	var metadata map[string]interface{}
+	if len(res.GetMetadata()) == 0 {
+		return metadata, nil
+	}
+	if err := json.Unmarshal(res.GetMetadata(), &metadata); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+func (r *registry) compile(metadata map[string]interface{}) channelSchemas {
+	cs := channelSchemas{expiresAt: time.Now().Add(r.ttl)}
+	s, ok := metadata[MetadataKey]
+	if !ok {
+		return cs
+	}
+	cs.schemas, cs.err = compileSchemas(s)
+
+	return cs
+}
+
+// compileSchemas parses the protobuf schemas kept in the channel metadata
+// and returns them keyed by the subtopic.
+func compileSchemas(s interface{}) (map[string]entry, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidSchema, err)
+	}
+	var schemas Schemas
+	if err := json.Unmarshal(data, &schemas); err != nil {
+		return nil, errors.Wrap(ErrInvalidSchema, err)
+	}
+	if len(schemas.Descriptor) == 0 {
+		return nil, errors.Wrap(ErrInvalidSchema, errMissingDescriptor)
+	}
+	files, err := parseDescriptor(schemas.Descriptor)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidSchema, err)
+	}
+
+	ret := make(map[string]entry, len(schemas.Schemas))
+	for _, schema := range schemas.Schemas {
+		if schema.Message == "" {
+			return nil, errors.Wrap(ErrInvalidSchema, errMissingMessage)
+		}
+		desc, err := findMessage(files, schema.Message)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidSchema, err)
+		}
+		ret[schema.Subtopic] = entry{schema: schema, desc: desc}
+	}
+
+	return ret, nil
+}
+
+func parseDescriptor(data []byte) (*protoregistry.Files, error) {
+	var fds descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &fds); err != nil {
+		return nil, errors.Wrap(errInvalidDescriptor, err)
+	}
+	files, err := protodesc.NewFiles(&fds)
+	if err != nil {
+		return nil, errors.Wrap(errInvalidDescriptor, err)
+	}
+
+	return files, nil
+}
+
+func findMessage(files *protoregistry.Files, message string) (protoreflect.MessageDescriptor, error) {
+	d, err := files.FindDescriptorByName(protoreflect.FullName(message))
+	if err != nil {
+		return nil, errors.Wrap(errMessageNotFound, err)
+	}
+	md, ok := d.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, errMessageNotFound
+	}
+
+	return md, nil
+}
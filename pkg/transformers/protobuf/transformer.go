@@ -0,0 +1,219 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package protobuf
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/transformers"
+	mgjson "github.com/absmach/magistrala/pkg/transformers/json"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const sep = "/"
+
+var (
+	// ErrTransform represents an error during decoding protobuf message.
+	ErrTransform = errors.New("unable to decode protobuf message")
+
+	errUnknownFormat = errors.New("unknown format of protobuf message")
+	errMissingField  = errors.New("missing SenML mapped field")
+	errInvalidField  = errors.New("invalid SenML mapped field type")
+)
+
+type transformer struct {
+	registry Registry
+	fallback transformers.Transformer
+}
+
+// New returns a new protobuf transformer which uses schemas from the registry
+// to decode message payloads. Messages published to the channels and
+// subtopics without schema are transformed by the fallback transformer.
+func New(registry Registry, fallback transformers.Transformer) transformers.Transformer {
+	return &transformer{
+		registry: registry,
+		fallback: fallback,
+	}
+}
+
+// Transform decodes protobuf payload of Magistrala message to the list of
+// JSON messages or, if schema contains SenML mapping, to SenML messages.
+func (t *transformer) Transform(msg *messaging.Message) (interface{}, error) {
+	schema, desc, err := t.registry.Resolve(context.Background(), msg.GetChannel(), msg.GetSubtopic())
+	switch {
+	case errors.Contains(err, ErrSchemaNotFound) && t.fallback != nil:
+		return t.fallback.Transform(msg)
+	case err != nil:
+		return nil, errors.Wrap(ErrTransform, err)
+	}
+
+	pm := dynamicpb.NewMessage(desc)
+	if err := proto.Unmarshal(msg.GetPayload(), pm); err != nil {
+		return nil, errors.Wrap(ErrTransform, err)
+	}
+	payload := messageToMap(pm)
+
+	if schema.SenML != nil {
+		return toSenML(msg, payload, *schema.SenML)
+	}
+
+	format := schema.Format
+	if format == "" {
+		subs := strings.Split(msg.GetSubtopic(), ".")
+		format = subs[len(subs)-1]
+	}
+	if format == "" {
+		return nil, errors.Wrap(ErrTransform, errUnknownFormat)
+	}
+
+	ret := mgjson.Message{
+		Publisher: msg.GetPublisher(),
+		Created:   msg.GetCreated(),
+		Protocol:  msg.GetProtocol(),
+		Channel:   msg.GetChannel(),
+		Subtopic:  msg.GetSubtopic(),
+		Payload:   payload,
+	}
+
+	return mgjson.Messages{Data: []mgjson.Message{ret}, Format: format}, nil
+}
+
+func toSenML(msg *messaging.Message, payload map[string]interface{}, mapping SenMLMapping) ([]senml.Message, error) {
+	t := float64(msg.GetCreated())
+	if mapping.TimeField != "" {
+		if v, ok := lookup(payload, mapping.TimeField); ok {
+			ts, ok := toFloat(v)
+			if !ok {
+				return nil, errors.Wrap(ErrTransform, errInvalidField)
+			}
+			t = transformers.ToUnixNano(ts)
+		}
+	}
+
+	msgs := make([]senml.Message, 0, len(mapping.Fields))
+	for _, f := range mapping.Fields {
+		v, ok := lookup(payload, f.Field)
+		if !ok {
+			return nil, errors.Wrap(ErrTransform, errMissingField)
+		}
+		m := senml.Message{
+			Channel:   msg.GetChannel(),
+			Subtopic:  msg.GetSubtopic(),
+			Publisher: msg.GetPublisher(),
+			Protocol:  msg.GetProtocol(),
+			Name:      f.Name,
+			Unit:      f.Unit,
+			Time:      t,
+		}
+		switch val := v.(type) {
+		case bool:
+			m.BoolValue = &val
+		case string:
+			m.StringValue = &val
+		default:
+			fv, ok := toFloat(val)
+			if !ok {
+				return nil, errors.Wrap(ErrTransform, errInvalidField)
+			}
+			m.Value = &fv
+		}
+		msgs = append(msgs, m)
+	}
+
+	return msgs, nil
+}
+
+// lookup returns the value of the nested field using `/` separated path.
+func lookup(payload map[string]interface{}, path string) (interface{}, bool) {
+	keys := strings.Split(path, sep)
+	var cur interface{} = payload
+	for _, k := range keys {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = m[k]; !ok {
+			return nil, false
+		}
+	}
+
+	return cur, true
+}
+
+// messageToMap converts protobuf message to the map keyed by field names.
+// Unpopulated fields are omitted.
+func messageToMap(m protoreflect.Message) map[string]interface{} {
+	ret := make(map[string]interface{})
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		ret[string(fd.Name())] = fieldToValue(fd, v)
+		return true
+	})
+
+	return ret
+}
+
+func fieldToValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch {
+	case fd.IsList():
+		l := v.List()
+		vals := make([]interface{}, l.Len())
+		for i := 0; i < l.Len(); i++ {
+			vals[i] = singularToValue(fd, l.Get(i))
+		}
+		return vals
+	case fd.IsMap():
+		vals := make(map[string]interface{})
+		v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+			vals[k.String()] = singularToValue(fd.MapValue(), mv)
+			return true
+		})
+		return vals
+	default:
+		return singularToValue(fd, v)
+	}
+}
+
+func singularToValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageToMap(v.Message())
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return int64(v.Enum())
+	case protoreflect.BytesKind:
+		return base64.StdEncoding.EncodeToString(v.Bytes())
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return v.Float()
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return v.Int()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return v.Uint()
+	default:
+		return v.Interface()
+	}
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch val := v.(type) {
+	case float64:
+		return val, true
+	case int64:
+		return float64(val), true
+	case uint64:
+		return float64(val), true
+	default:
+		return 0, false
+	}
+}
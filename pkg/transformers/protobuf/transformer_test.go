@@ -0,0 +1,345 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package protobuf_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+	mgjson "github.com/absmach/magistrala/pkg/transformers/json"
+	"github.com/absmach/magistrala/pkg/transformers/protobuf"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	thmocks "github.com/absmach/magistrala/things/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	channel     = "channel-1"
+	subtopic    = "home.readings"
+	messageType = "test.Reading"
+)
+
+func descriptor() *descriptorpb.FileDescriptorProto {
+	field := func(name string, num int32, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(num),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+		}
+	}
+	location := field("location", 5, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE)
+	location.TypeName = proto.String(".test.Location")
+
+	return &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("reading.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Location"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("lat", 1, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE),
+					field("lon", 2, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE),
+				},
+			},
+			{
+				Name: proto.String("Reading"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("temperature", 1, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE),
+					field("humidity", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+					field("status", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+					field("ts", 4, descriptorpb.FieldDescriptorProto_TYPE_INT64),
+					location,
+				},
+			},
+		},
+	}
+}
+
+func payload(t *testing.T, fdp *descriptorpb.FileDescriptorProto, ts int64) []byte {
+	fd, err := protodesc.NewFile(fdp, nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating file descriptor: %s", err))
+
+	md := fd.Messages().ByName("Reading")
+	locMD := fd.Messages().ByName("Location")
+
+	loc := dynamicpb.NewMessage(locMD)
+	loc.Set(locMD.Fields().ByName("lat"), protoreflect.ValueOf(44.8))
+	loc.Set(locMD.Fields().ByName("lon"), protoreflect.ValueOf(20.4))
+
+	m := dynamicpb.NewMessage(md)
+	m.Set(md.Fields().ByName("temperature"), protoreflect.ValueOf(21.5))
+	m.Set(md.Fields().ByName("humidity"), protoreflect.ValueOf(int32(40)))
+	m.Set(md.Fields().ByName("status"), protoreflect.ValueOf("ok"))
+	m.Set(md.Fields().ByName("ts"), protoreflect.ValueOf(ts))
+	m.Set(md.Fields().ByName("location"), protoreflect.ValueOf(loc))
+
+	data, err := proto.Marshal(m)
+	require.Nil(t, err, fmt.Sprintf("unexpected error marshaling message: %s", err))
+
+	return data
+}
+
+// metadata returns the channel metadata holding the protobuf schemas, as
+// decoded from the things service response.
+func metadata(t *testing.T, schemas protobuf.Schemas) map[string]interface{} {
+	data, err := json.Marshal(map[string]interface{}{protobuf.MetadataKey: schemas})
+	require.Nil(t, err, fmt.Sprintf("unexpected error marshaling metadata: %s", err))
+	var ret map[string]interface{}
+	err = json.Unmarshal(data, &ret)
+	require.Nil(t, err, fmt.Sprintf("unexpected error unmarshaling metadata: %s", err))
+
+	return ret
+}
+
+func TestTransform(t *testing.T) {
+	now := time.Now()
+	fdp := descriptor()
+	desc, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{fdp}})
+	require.Nil(t, err, fmt.Sprintf("unexpected error marshaling descriptor: %s", err))
+
+	things := new(thmocks.ThingsServiceClient)
+	things.On("ChannelMetadata", mock.Anything, mock.Anything).Return(nil, svcerr.ErrNotFound)
+	registry := protobuf.NewRegistry(things, time.Minute)
+	err = registry.Save(channel, metadata(t, protobuf.Schemas{
+		Descriptor: desc,
+		Schemas: []protobuf.Schema{
+			{Subtopic: subtopic, Message: messageType},
+			{
+				Subtopic: "senml",
+				Message:  messageType,
+				SenML: &protobuf.SenMLMapping{
+					TimeField: "ts",
+					Fields: []protobuf.SenMLField{
+						{Field: "temperature", Name: "temp", Unit: "Cel"},
+						{Field: "status", Name: "status"},
+						{Field: "location/lat", Name: "lat", Unit: "lat"},
+					},
+				},
+			},
+		},
+	}))
+	require.Nil(t, err, fmt.Sprintf("unexpected error saving schemas: %s", err))
+
+	tr := protobuf.New(registry, senml.New(senml.JSON))
+	data := payload(t, fdp, now.Unix())
+
+	temp, lat, status := 21.5, 44.8, "ok"
+	ts := float64(now.Unix()) * 1e9
+
+	cases := []struct {
+		desc string
+		msg  *messaging.Message
+		res  interface{}
+		err  error
+	}{
+		{
+			desc: "transform protobuf message to JSON",
+			msg: &messaging.Message{
+				Channel:   channel,
+				Subtopic:  subtopic,
+				Publisher: "publisher-1",
+				Protocol:  "mqtt",
+				Payload:   data,
+				Created:   now.UnixNano(),
+			},
+			res: mgjson.Messages{
+				Data: []mgjson.Message{
+					{
+						Channel:   channel,
+						Subtopic:  subtopic,
+						Publisher: "publisher-1",
+						Protocol:  "mqtt",
+						Created:   now.UnixNano(),
+						Payload: map[string]interface{}{
+							"temperature": 21.5,
+							"humidity":    int64(40),
+							"status":      "ok",
+							"ts":          now.Unix(),
+							"location": map[string]interface{}{
+								"lat": 44.8,
+								"lon": 20.4,
+							},
+						},
+					},
+				},
+				Format: "readings",
+			},
+		},
+		{
+			desc: "transform protobuf message to SenML",
+			msg: &messaging.Message{
+				Channel:   channel,
+				Subtopic:  "senml",
+				Publisher: "publisher-1",
+				Protocol:  "mqtt",
+				Payload:   data,
+				Created:   now.UnixNano(),
+			},
+			res: []senml.Message{
+				{Channel: channel, Subtopic: "senml", Publisher: "publisher-1", Protocol: "mqtt", Name: "temp", Unit: "Cel", Time: ts, Value: &temp},
+				{Channel: channel, Subtopic: "senml", Publisher: "publisher-1", Protocol: "mqtt", Name: "status", Time: ts, StringValue: &status},
+				{Channel: channel, Subtopic: "senml", Publisher: "publisher-1", Protocol: "mqtt", Name: "lat", Unit: "lat", Time: ts, Value: &lat},
+			},
+		},
+		{
+			desc: "transform SenML message published to channel without schema",
+			msg: &messaging.Message{
+				Channel:   "channel-2",
+				Subtopic:  subtopic,
+				Publisher: "publisher-1",
+				Protocol:  "mqtt",
+				Payload:   []byte(`[{"n":"temp","u":"Cel","v":21.5,"t":1}]`),
+			},
+			res: []senml.Message{
+				{Channel: "channel-2", Subtopic: subtopic, Publisher: "publisher-1", Protocol: "mqtt", Name: "temp", Unit: "Cel", Time: 1, Value: &temp},
+			},
+		},
+		{
+			desc: "transform invalid protobuf payload",
+			msg: &messaging.Message{
+				Channel:  channel,
+				Subtopic: subtopic,
+				Payload:  []byte{0xff, 0xff, 0xff},
+			},
+			err: protobuf.ErrTransform,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			res, err := tr.Transform(tc.msg)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %s got %s", tc.err, err))
+			assert.Equal(t, tc.res, res)
+		})
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	desc, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{descriptor()}})
+	require.Nil(t, err, fmt.Sprintf("unexpected error marshaling descriptor: %s", err))
+
+	cases := []struct {
+		desc     string
+		metadata map[string]interface{}
+		err      error
+	}{
+		{
+			desc:     "save valid channel-wide schema",
+			metadata: metadata(t, protobuf.Schemas{Descriptor: desc, Schemas: []protobuf.Schema{{Message: messageType}}}),
+		},
+		{
+			desc:     "save channel without schemas",
+			metadata: map[string]interface{}{"location": "room"},
+		},
+		{
+			desc:     "save schema without message type",
+			metadata: metadata(t, protobuf.Schemas{Descriptor: desc, Schemas: []protobuf.Schema{{Subtopic: subtopic}}}),
+			err:      protobuf.ErrInvalidSchema,
+		},
+		{
+			desc:     "save schema with unknown message type",
+			metadata: metadata(t, protobuf.Schemas{Descriptor: desc, Schemas: []protobuf.Schema{{Message: "test.Unknown"}}}),
+			err:      protobuf.ErrInvalidSchema,
+		},
+		{
+			desc:     "save schema without descriptor",
+			metadata: metadata(t, protobuf.Schemas{Schemas: []protobuf.Schema{{Message: messageType}}}),
+			err:      protobuf.ErrInvalidSchema,
+		},
+		{
+			desc:     "save schema with invalid descriptor",
+			metadata: metadata(t, protobuf.Schemas{Descriptor: []byte{0xff}, Schemas: []protobuf.Schema{{Message: messageType}}}),
+			err:      protobuf.ErrInvalidSchema,
+		},
+		{
+			desc:     "save schema with malformed metadata",
+			metadata: map[string]interface{}{protobuf.MetadataKey: "schema"},
+			err:      protobuf.ErrInvalidSchema,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			registry := protobuf.NewRegistry(new(thmocks.ThingsServiceClient), time.Minute)
+			err := registry.Save(channel, tc.metadata)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %s got %s", tc.err, err))
+		})
+	}
+}
+
+func TestRegistryResolve(t *testing.T) {
+	desc, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{descriptor()}})
+	require.Nil(t, err, fmt.Sprintf("unexpected error marshaling descriptor: %s", err))
+	md, err := json.Marshal(metadata(t, protobuf.Schemas{Descriptor: desc, Schemas: []protobuf.Schema{{Message: messageType}}}))
+	require.Nil(t, err, fmt.Sprintf("unexpected error marshaling metadata: %s", err))
+
+	things := new(thmocks.ThingsServiceClient)
+	things.On("ChannelMetadata", mock.Anything, &magistrala.ChannelMetadataReq{ChannelId: channel}).Return(&magistrala.ChannelMetadataRes{Metadata: md}, nil)
+	things.On("ChannelMetadata", mock.Anything, &magistrala.ChannelMetadataReq{ChannelId: "unbound"}).Return(&magistrala.ChannelMetadataRes{}, nil)
+	things.On("ChannelMetadata", mock.Anything, &magistrala.ChannelMetadataReq{ChannelId: "unknown"}).Return(nil, svcerr.ErrNotFound)
+	things.On("ChannelMetadata", mock.Anything, &magistrala.ChannelMetadataReq{ChannelId: "unavailable"}).Return(nil, svcerr.ErrViewEntity)
+	registry := protobuf.NewRegistry(things, time.Minute)
+
+	cases := []struct {
+		desc    string
+		channel string
+		message string
+		err     error
+	}{
+		{
+			desc:    "resolve schema loaded from the things service",
+			channel: channel,
+			message: messageType,
+		},
+		{
+			desc:    "resolve schema of the channel without schemas",
+			channel: "unbound",
+			err:     protobuf.ErrSchemaNotFound,
+		},
+		{
+			desc:    "resolve schema of non-existing channel",
+			channel: "unknown",
+			err:     protobuf.ErrSchemaNotFound,
+		},
+		{
+			desc:    "resolve schema with things service unavailable",
+			channel: "unavailable",
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			schema, md, err := registry.Resolve(context.Background(), tc.channel, "any.subtopic")
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %s got %s", tc.err, err))
+			assert.Equal(t, tc.message, schema.Message)
+			if err == nil {
+				assert.Equal(t, tc.message, string(md.FullName()))
+			}
+		})
+	}
+
+	registry.Remove(channel)
+	err = registry.Save(channel, map[string]interface{}{})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error saving channel without schemas: %s", err))
+	_, _, err = registry.Resolve(context.Background(), channel, "any.subtopic")
+	assert.True(t, errors.Contains(err, protobuf.ErrSchemaNotFound), fmt.Sprintf("expected error %s got %s", protobuf.ErrSchemaNotFound, err))
+}
@@ -13,13 +13,13 @@ import (
 )
 
 func doProvision(svc provision.Service) endpoint.Endpoint {
-	return func(_ context.Context, request interface{}) (interface{}, error) {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(provisionReq)
 		if err := req.validate(); err != nil {
 			return nil, errors.Wrap(apiutil.ErrValidation, err)
 		}
 
-		res, err := svc.Provision(req.domainID, req.token, req.Name, req.ExternalID, req.ExternalKey)
+		res, err := svc.Provision(ctx, req.domainID, req.token, req.Profile, req.Name, req.ExternalID, req.ExternalKey)
 		if err != nil {
 			return nil, err
 		}
@@ -38,13 +38,13 @@ func doProvision(svc provision.Service) endpoint.Endpoint {
 }
 
 func getMapping(svc provision.Service) endpoint.Endpoint {
-	return func(_ context.Context, request interface{}) (interface{}, error) {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(mappingReq)
 		if err := req.validate(); err != nil {
 			return nil, errors.Wrap(apiutil.ErrValidation, err)
 		}
 
-		res, err := svc.Mapping(req.token)
+		res, err := svc.Mapping(ctx, req.domainID, req.token, req.profile)
 		if err != nil {
 			return nil, err
 		}
@@ -52,3 +52,82 @@ func getMapping(svc provision.Service) endpoint.Endpoint {
 		return mappingRes{Data: res}, nil
 	}
 }
+
+func addProfile(svc provision.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(profileReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		p, err := svc.AddProfile(ctx, req.domainID, req.token, req.Profile)
+		if err != nil {
+			return nil, err
+		}
+
+		return profileRes{Profile: p, created: true}, nil
+	}
+}
+
+func viewProfile(svc provision.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewProfileReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		p, err := svc.ViewProfile(ctx, req.domainID, req.token, req.name, req.version)
+		if err != nil {
+			return nil, err
+		}
+
+		return profileRes{Profile: p}, nil
+	}
+}
+
+func updateProfile(svc provision.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(profileReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		p, err := svc.UpdateProfile(ctx, req.domainID, req.token, req.Profile)
+		if err != nil {
+			return nil, err
+		}
+
+		return profileRes{Profile: p}, nil
+	}
+}
+
+func listProfiles(svc provision.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listProfilesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		page, err := svc.ListProfiles(ctx, req.domainID, req.token, req.offset, req.limit)
+		if err != nil {
+			return nil, err
+		}
+
+		return profilesPageRes{page}, nil
+	}
+}
+
+func removeProfile(svc provision.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewProfileReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.RemoveProfile(ctx, req.domainID, req.token, req.name); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/absmach/magistrala/provision/api"
 	"github.com/absmach/magistrala/provision/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 var (
@@ -68,6 +69,7 @@ func TestProvision(t *testing.T) {
 		desc        string
 		token       string
 		domainID    string
+		profile     string
 		data        string
 		contentType string
 		status      int
@@ -100,12 +102,22 @@ func TestProvision(t *testing.T) {
 			contentType: validContenType,
 			svcErr:      nil,
 		},
+		{
+			desc:        "valid request with profile",
+			token:       validToken,
+			domainID:    validID,
+			profile:     "gateway",
+			data:        fmt.Sprintf(`{"profile": "gateway", "name": "test", "external_id": "%s", "external_key": "%s"}`, validID, validID),
+			status:      http.StatusCreated,
+			contentType: validContenType,
+			svcErr:      nil,
+		},
 		{
 			desc:        "empty token",
 			token:       "",
 			domainID:    validID,
 			data:        fmt.Sprintf(`{"name": "test", "external_id": "%s", "external_key": "%s"}`, validID, validID),
-			status:      http.StatusCreated,
+			status:      http.StatusUnauthorized,
 			contentType: validContenType,
 			svcErr:      nil,
 		},
@@ -140,7 +152,7 @@ func TestProvision(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			repocall := svc.On("Provision", validID, tc.token, "test", validID, validID).Return(provision.Result{}, tc.svcErr)
+			repocall := svc.On("Provision", mock.Anything, validID, tc.token, tc.profile, "test", validID, validID).Return(provision.Result{}, tc.svcErr)
 			req := testRequest{
 				client:      is.Client(),
 				method:      http.MethodPost,
@@ -205,7 +217,7 @@ func TestMapping(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			repocall := svc.On("Mapping", tc.token).Return(map[string]interface{}{}, tc.svcErr)
+			repocall := svc.On("Mapping", mock.Anything, tc.domainID, tc.token, "").Return(map[string]interface{}{}, tc.svcErr)
 			req := testRequest{
 				client:      is.Client(),
 				method:      http.MethodGet,
@@ -221,3 +233,162 @@ func TestMapping(t *testing.T) {
 		})
 	}
 }
+
+func TestAddProfile(t *testing.T) {
+	is, svc := newProvisionServer()
+
+	profile := `{"name": "gateway", "things": [{"name": "{{.Name}}"}], "channels": [{"name": "{{.Name}}-data"}]}`
+	cases := []struct {
+		desc        string
+		token       string
+		domainID    string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "add valid profile",
+			token:       validToken,
+			domainID:    validID,
+			data:        profile,
+			status:      http.StatusCreated,
+			contentType: validContenType,
+			svcErr:      nil,
+		},
+		{
+			desc:        "add profile with empty token",
+			token:       "",
+			domainID:    validID,
+			data:        profile,
+			status:      http.StatusUnauthorized,
+			contentType: validContenType,
+			svcErr:      nil,
+		},
+		{
+			desc:        "add profile without name",
+			token:       validToken,
+			domainID:    validID,
+			data:        `{"things": [{"name": "{{.Name}}"}]}`,
+			status:      http.StatusBadRequest,
+			contentType: validContenType,
+			svcErr:      nil,
+		},
+		{
+			desc:        "add profile with invalid content type",
+			token:       validToken,
+			domainID:    validID,
+			data:        profile,
+			status:      http.StatusUnsupportedMediaType,
+			contentType: "text/plain",
+			svcErr:      nil,
+		},
+		{
+			desc:        "add profile with malformed body",
+			token:       validToken,
+			domainID:    validID,
+			data:        `data`,
+			status:      http.StatusBadRequest,
+			contentType: validContenType,
+			svcErr:      nil,
+		},
+		{
+			desc:        "add existing profile",
+			token:       validToken,
+			domainID:    validID,
+			data:        profile,
+			status:      http.StatusConflict,
+			contentType: validContenType,
+			svcErr:      svcerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repocall := svc.On("AddProfile", mock.Anything, tc.domainID, tc.token, mock.Anything).Return(provision.Profile{Name: "gateway"}, tc.svcErr)
+			req := testRequest{
+				client:      is.Client(),
+				method:      http.MethodPost,
+				url:         is.URL + fmt.Sprintf("/%s/profiles", tc.domainID),
+				token:       tc.token,
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+
+			resp, err := req.make()
+			assert.Nil(t, err, tc.desc)
+			assert.Equal(t, tc.status, resp.StatusCode, tc.desc)
+			repocall.Unset()
+		})
+	}
+}
+
+func TestViewProfile(t *testing.T) {
+	is, svc := newProvisionServer()
+
+	cases := []struct {
+		desc     string
+		token    string
+		domainID string
+		query    string
+		version  uint64
+		status   int
+		svcErr   error
+	}{
+		{
+			desc:     "view latest profile version",
+			token:    validToken,
+			domainID: validID,
+			status:   http.StatusOK,
+			svcErr:   nil,
+		},
+		{
+			desc:     "view profile version",
+			token:    validToken,
+			domainID: validID,
+			query:    "?version=2",
+			version:  2,
+			status:   http.StatusOK,
+			svcErr:   nil,
+		},
+		{
+			desc:     "view profile with invalid version",
+			token:    validToken,
+			domainID: validID,
+			query:    "?version=invalid",
+			status:   http.StatusBadRequest,
+			svcErr:   nil,
+		},
+		{
+			desc:     "view profile with empty token",
+			token:    "",
+			domainID: validID,
+			status:   http.StatusUnauthorized,
+			svcErr:   nil,
+		},
+		{
+			desc:     "view non-existing profile",
+			token:    validToken,
+			domainID: validID,
+			status:   http.StatusNotFound,
+			svcErr:   svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repocall := svc.On("ViewProfile", mock.Anything, tc.domainID, tc.token, "gateway", tc.version).Return(provision.Profile{Name: "gateway"}, tc.svcErr)
+			req := testRequest{
+				client: is.Client(),
+				method: http.MethodGet,
+				url:    is.URL + fmt.Sprintf("/%s/profiles/gateway%s", tc.domainID, tc.query),
+				token:  tc.token,
+			}
+
+			resp, err := req.make()
+			assert.Nil(t, err, tc.desc)
+			assert.Equal(t, tc.status, resp.StatusCode, tc.desc)
+			repocall.Unset()
+		})
+	}
+}
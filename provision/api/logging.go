@@ -6,6 +6,7 @@
 package api
 
 import (
+	"context"
 	"log/slog"
 	"time"
 
@@ -24,10 +25,11 @@ func NewLoggingMiddleware(svc provision.Service, logger *slog.Logger) provision.
 	return &loggingMiddleware{logger, svc}
 }
 
-func (lm *loggingMiddleware) Provision(domainID, token, name, externalID, externalKey string) (res provision.Result, err error) {
+func (lm *loggingMiddleware) Provision(ctx context.Context, domainID, token, profile, name, externalID, externalKey string) (res provision.Result, err error) {
 	defer func(begin time.Time) {
 		args := []any{
 			slog.String("duration", time.Since(begin).String()),
+			slog.String("profile", profile),
 			slog.String("name", name),
 			slog.String("external_id", externalID),
 		}
@@ -39,10 +41,10 @@ func (lm *loggingMiddleware) Provision(domainID, token, name, externalID, extern
 		lm.logger.Info("Provision completed successfully", args...)
 	}(time.Now())
 
-	return lm.svc.Provision(domainID, token, name, externalID, externalKey)
+	return lm.svc.Provision(ctx, domainID, token, profile, name, externalID, externalKey)
 }
 
-func (lm *loggingMiddleware) Cert(domainID, token, thingID, duration string) (cert, key string, err error) {
+func (lm *loggingMiddleware) Cert(ctx context.Context, domainID, token, thingID, duration string) (cert, key string, err error) {
 	defer func(begin time.Time) {
 		args := []any{
 			slog.String("duration", time.Since(begin).String()),
@@ -57,13 +59,15 @@ func (lm *loggingMiddleware) Cert(domainID, token, thingID, duration string) (ce
 		lm.logger.Info("Thing certificate created successfully", args...)
 	}(time.Now())
 
-	return lm.svc.Cert(domainID, token, thingID, duration)
+	return lm.svc.Cert(ctx, domainID, token, thingID, duration)
 }
 
-func (lm *loggingMiddleware) Mapping(token string) (res map[string]interface{}, err error) {
+func (lm *loggingMiddleware) Mapping(ctx context.Context, domainID, token, profile string) (res map[string]interface{}, err error) {
 	defer func(begin time.Time) {
 		args := []any{
 			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.String("profile", profile),
 		}
 		if err != nil {
 			args = append(args, slog.Any("error", err))
@@ -73,5 +77,99 @@ func (lm *loggingMiddleware) Mapping(token string) (res map[string]interface{},
 		lm.logger.Info("Mapping completed successfully", args...)
 	}(time.Now())
 
-	return lm.svc.Mapping(token)
+	return lm.svc.Mapping(ctx, domainID, token, profile)
+}
+
+func (lm *loggingMiddleware) AddProfile(ctx context.Context, domainID, token string, p provision.Profile) (res provision.Profile, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.String("name", p.Name),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Add profile failed", args...)
+			return
+		}
+		lm.logger.Info("Add profile completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.AddProfile(ctx, domainID, token, p)
+}
+
+func (lm *loggingMiddleware) ViewProfile(ctx context.Context, domainID, token, name string, version uint64) (res provision.Profile, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.String("name", name),
+			slog.Uint64("version", version),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View profile failed", args...)
+			return
+		}
+		lm.logger.Info("View profile completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewProfile(ctx, domainID, token, name, version)
+}
+
+func (lm *loggingMiddleware) UpdateProfile(ctx context.Context, domainID, token string, p provision.Profile) (res provision.Profile, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.String("name", p.Name),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Update profile failed", args...)
+			return
+		}
+		lm.logger.Info("Update profile completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.UpdateProfile(ctx, domainID, token, p)
+}
+
+func (lm *loggingMiddleware) ListProfiles(ctx context.Context, domainID, token string, offset, limit uint64) (res provision.ProfilesPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.Group("page",
+				slog.Uint64("offset", offset),
+				slog.Uint64("limit", limit),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List profiles failed", args...)
+			return
+		}
+		lm.logger.Info("List profiles completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListProfiles(ctx, domainID, token, offset, limit)
+}
+
+func (lm *loggingMiddleware) RemoveProfile(ctx context.Context, domainID, token, name string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.String("name", name),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove profile failed", args...)
+			return
+		}
+		lm.logger.Info("Remove profile completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveProfile(ctx, domainID, token, name)
 }
@@ -3,17 +3,26 @@
 
 package api
 
-import "github.com/absmach/magistrala/pkg/apiutil"
+import (
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/provision"
+)
+
+const maxLimitSize = 100
 
 type provisionReq struct {
 	token       string
 	domainID    string
+	Profile     string `json:"profile"`
 	Name        string `json:"name"`
 	ExternalID  string `json:"external_id"`
 	ExternalKey string `json:"external_key"`
 }
 
 func (req provisionReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
 	if req.ExternalID == "" {
 		return apiutil.ErrMissingID
 	}
@@ -35,6 +44,7 @@ func (req provisionReq) validate() error {
 type mappingReq struct {
 	token    string
 	domainID string
+	profile  string
 }
 
 func (req mappingReq) validate() error {
@@ -46,3 +56,65 @@ func (req mappingReq) validate() error {
 	}
 	return nil
 }
+
+type profileReq struct {
+	token    string
+	domainID string
+	provision.Profile
+}
+
+func (req profileReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.domainID == "" {
+		return apiutil.ErrMissingDomainID
+	}
+	if req.Name == "" {
+		return apiutil.ErrMissingName
+	}
+
+	return nil
+}
+
+type viewProfileReq struct {
+	token    string
+	domainID string
+	name     string
+	version  uint64
+}
+
+func (req viewProfileReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.domainID == "" {
+		return apiutil.ErrMissingDomainID
+	}
+	if req.name == "" {
+		return apiutil.ErrMissingName
+	}
+
+	return nil
+}
+
+type listProfilesReq struct {
+	token    string
+	domainID string
+	offset   uint64
+	limit    uint64
+}
+
+func (req listProfilesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.domainID == "" {
+		return apiutil.ErrMissingDomainID
+	}
+	if req.limit > maxLimitSize || req.limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
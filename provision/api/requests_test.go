@@ -63,6 +63,17 @@ func TestProvisioReq(t *testing.T) {
 			},
 			err: apiutil.ErrBearerKey,
 		},
+		{
+			desc: "empty token",
+			req: provisionReq{
+				token:       "",
+				domainID:    testsutil.GenerateUUID(t),
+				Name:        "name",
+				ExternalID:  testsutil.GenerateUUID(t),
+				ExternalKey: testsutil.GenerateUUID(t),
+			},
+			err: apiutil.ErrBearerToken,
+		},
 	}
 
 	for _, tc := range cases {
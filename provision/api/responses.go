@@ -5,13 +5,22 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/absmach/magistrala"
 	sdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/absmach/magistrala/provision"
 )
 
-var _ magistrala.Response = (*provisionRes)(nil)
+var (
+	_ magistrala.Response = (*provisionRes)(nil)
+	_ magistrala.Response = (*mappingRes)(nil)
+	_ magistrala.Response = (*profileRes)(nil)
+	_ magistrala.Response = (*profilesPageRes)(nil)
+	_ magistrala.Response = (*removeRes)(nil)
+)
 
 type provisionRes struct {
 	Things      []sdk.Thing       `json:"things"`
@@ -53,3 +62,60 @@ func (res mappingRes) Empty() bool {
 func (res mappingRes) MarshalJSON() ([]byte, error) {
 	return json.Marshal(res.Data)
 }
+
+type profileRes struct {
+	provision.Profile
+	created bool
+}
+
+func (res profileRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res profileRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/%s/profiles/%s", res.DomainID, url.PathEscape(res.Name)),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res profileRes) Empty() bool {
+	return false
+}
+
+type profilesPageRes struct {
+	provision.ProfilesPage
+}
+
+func (res profilesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res profilesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res profilesPageRes) Empty() bool {
+	return false
+}
+
+type removeRes struct{}
+
+func (res removeRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeRes) Empty() bool {
+	return true
+}
@@ -21,6 +21,12 @@ import (
 
 const (
 	contentType = "application/json"
+	offsetKey   = "offset"
+	limitKey    = "limit"
+	versionKey  = "version"
+	profileKey  = "profile"
+	defOffset   = 0
+	defLimit    = 10
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
@@ -46,6 +52,38 @@ func MakeHandler(svc provision.Service, logger *slog.Logger, instanceID string)
 				opts...,
 			).ServeHTTP)
 		})
+		r.Route("/profiles", func(r chi.Router) {
+			r.Post("/", kithttp.NewServer(
+				addProfile(svc),
+				decodeProfileRequest,
+				api.EncodeResponse,
+				opts...,
+			).ServeHTTP)
+			r.Get("/", kithttp.NewServer(
+				listProfiles(svc),
+				decodeListProfilesRequest,
+				api.EncodeResponse,
+				opts...,
+			).ServeHTTP)
+			r.Get("/{name}", kithttp.NewServer(
+				viewProfile(svc),
+				decodeViewProfileRequest,
+				api.EncodeResponse,
+				opts...,
+			).ServeHTTP)
+			r.Put("/{name}", kithttp.NewServer(
+				updateProfile(svc),
+				decodeProfileRequest,
+				api.EncodeResponse,
+				opts...,
+			).ServeHTTP)
+			r.Delete("/{name}", kithttp.NewServer(
+				removeProfile(svc),
+				decodeViewProfileRequest,
+				api.EncodeResponse,
+				opts...,
+			).ServeHTTP)
+		})
 	})
 	r.Handle("/metrics", promhttp.Handler())
 	r.Get("/health", magistrala.Health("provision", instanceID))
@@ -74,9 +112,71 @@ func decodeMappingRequest(_ context.Context, r *http.Request) (interface{}, erro
 		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
 	}
 
+	profile, err := apiutil.ReadStringQuery(r, profileKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
 	req := mappingReq{
 		token:    apiutil.ExtractBearerToken(r),
 		domainID: chi.URLParam(r, "domainID"),
+		profile:  profile,
+	}
+
+	return req, nil
+}
+
+func decodeProfileRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if r.Header.Get("Content-Type") != contentType {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := profileReq{
+		token:    apiutil.ExtractBearerToken(r),
+		domainID: chi.URLParam(r, "domainID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req.Profile); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+	// Name of the updated profile is set by the path.
+	if name := chi.URLParam(r, "name"); name != "" {
+		req.Name = name
+	}
+
+	return req, nil
+}
+
+func decodeViewProfileRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	version, err := apiutil.ReadNumQuery[uint64](r, versionKey, 0)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := viewProfileReq{
+		token:    apiutil.ExtractBearerToken(r),
+		domainID: chi.URLParam(r, "domainID"),
+		name:     chi.URLParam(r, "name"),
+		version:  version,
+	}
+
+	return req, nil
+}
+
+func decodeListProfilesRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, offsetKey, defOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, limitKey, defLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listProfilesReq{
+		token:    apiutil.ExtractBearerToken(r),
+		domainID: chi.URLParam(r, "domainID"),
+		offset:   offset,
+		limit:    limit,
 	}
 
 	return req, nil
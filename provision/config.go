@@ -25,21 +25,16 @@ type ServiceConf struct {
 	ThingsURL  string `toml:"things_url"    env:"MG_PROVISION_THINGS_LOCATION"      envDefault:"http://localhost"`
 	UsersURL   string `toml:"users_url"     env:"MG_PROVISION_USERS_LOCATION"       envDefault:"http://localhost"`
 	HTTPPort   string `toml:"http_port"     env:"MG_PROVISION_HTTP_PORT"            envDefault:"9016"`
-	MgEmail    string `toml:"mg_email"      env:"MG_PROVISION_EMAIL"                envDefault:"test@example.com"`
-	MgUsername string `toml:"mg_username"   env:"MG_PROVISION_USERNAME"             envDefault:"user"`
-	MgPass     string `toml:"mg_pass"       env:"MG_PROVISION_PASS"                 envDefault:"test"`
-	MgDomainID string `toml:"mg_domain_id"  env:"MG_PROVISION_DOMAIN_ID"            envDefault:""`
-	MgAPIKey   string `toml:"mg_api_key"    env:"MG_PROVISION_API_KEY"              envDefault:""`
 	MgBSURL    string `toml:"mg_bs_url"     env:"MG_PROVISION_BS_SVC_URL"           envDefault:"http://localhost:9000"`
 	MgCertsURL string `toml:"mg_certs_url"  env:"MG_PROVISION_CERTS_SVC_URL"        envDefault:"http://localhost:9019"`
 }
 
 // Bootstrap represetns the Bootstrap config.
 type Bootstrap struct {
-	X509Provision bool                   `toml:"x509_provision" env:"MG_PROVISION_X509_PROVISIONING"      envDefault:"false"`
-	Provision     bool                   `toml:"provision"      env:"MG_PROVISION_BS_CONFIG_PROVISIONING" envDefault:"true"`
-	AutoWhiteList bool                   `toml:"autowhite_list" env:"MG_PROVISION_BS_AUTO_WHITELIST"      envDefault:"true"`
-	Content       map[string]interface{} `toml:"content"`
+	X509Provision bool                   `toml:"x509_provision" json:"x509_provision" env:"MG_PROVISION_X509_PROVISIONING"      envDefault:"false"`
+	Provision     bool                   `toml:"provision"      json:"provision"      env:"MG_PROVISION_BS_CONFIG_PROVISIONING" envDefault:"true"`
+	AutoWhiteList bool                   `toml:"autowhite_list" json:"autowhite_list" env:"MG_PROVISION_BS_AUTO_WHITELIST"      envDefault:"true"`
+	Content       map[string]interface{} `toml:"content"        json:"content,omitempty"`
 }
 
 // Gateway represetns the Gateway config.
@@ -71,6 +66,24 @@ type Config struct {
 	InstanceID    string          `env:"MG_MQTT_ADAPTER_INSTANCE_ID" envDefault:""`
 }
 
+// Profile returns the layout of the config file as a profile. It is used
+// when provisioning is requested without a profile name.
+func (c Config) Profile() Profile {
+	p := Profile{
+		Bootstrap: c.Bootstrap,
+		Cert:      c.Cert,
+	}
+	// Request name is used for Things and as a prefix of Channel names.
+	for _, th := range c.Things {
+		p.Things = append(p.Things, Layout{Name: "{{.Name}}", Metadata: th.Metadata})
+	}
+	for _, ch := range c.Channels {
+		p.Channels = append(p.Channels, Layout{Name: "{{.Name}}_" + ch.Name, Metadata: ch.Metadata})
+	}
+
+	return p
+}
+
 // Save - store config in a file.
 func Save(c Config, file string) error {
 	if file == "" {
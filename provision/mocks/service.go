@@ -5,6 +5,8 @@
 package mocks
 
 import (
+	context "context"
+
 	provision "github.com/absmach/magistrala/provision"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -14,9 +16,37 @@ type Service struct {
 	mock.Mock
 }
 
-// Cert provides a mock function with given fields: domainID, token, thingID, duration
-func (_m *Service) Cert(domainID string, token string, thingID string, duration string) (string, string, error) {
-	ret := _m.Called(domainID, token, thingID, duration)
+// AddProfile provides a mock function with given fields: ctx, domainID, token, p
+func (_m *Service) AddProfile(ctx context.Context, domainID string, token string, p provision.Profile) (provision.Profile, error) {
+	ret := _m.Called(ctx, domainID, token, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddProfile")
+	}
+
+	var r0 provision.Profile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, provision.Profile) (provision.Profile, error)); ok {
+		return rf(ctx, domainID, token, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, provision.Profile) provision.Profile); ok {
+		r0 = rf(ctx, domainID, token, p)
+	} else {
+		r0 = ret.Get(0).(provision.Profile)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, provision.Profile) error); ok {
+		r1 = rf(ctx, domainID, token, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Cert provides a mock function with given fields: ctx, domainID, token, thingID, duration
+func (_m *Service) Cert(ctx context.Context, domainID string, token string, thingID string, duration string) (string, string, error) {
+	ret := _m.Called(ctx, domainID, token, thingID, duration)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Cert")
@@ -25,23 +55,23 @@ func (_m *Service) Cert(domainID string, token string, thingID string, duration
 	var r0 string
 	var r1 string
 	var r2 error
-	if rf, ok := ret.Get(0).(func(string, string, string, string) (string, string, error)); ok {
-		return rf(domainID, token, thingID, duration)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) (string, string, error)); ok {
+		return rf(ctx, domainID, token, thingID, duration)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string, string) string); ok {
-		r0 = rf(domainID, token, thingID, duration)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) string); ok {
+		r0 = rf(ctx, domainID, token, thingID, duration)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string, string) string); ok {
-		r1 = rf(domainID, token, thingID, duration)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) string); ok {
+		r1 = rf(ctx, domainID, token, thingID, duration)
 	} else {
 		r1 = ret.Get(1).(string)
 	}
 
-	if rf, ok := ret.Get(2).(func(string, string, string, string) error); ok {
-		r2 = rf(domainID, token, thingID, duration)
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, string, string) error); ok {
+		r2 = rf(ctx, domainID, token, thingID, duration)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -49,9 +79,37 @@ func (_m *Service) Cert(domainID string, token string, thingID string, duration
 	return r0, r1, r2
 }
 
-// Mapping provides a mock function with given fields: token
-func (_m *Service) Mapping(token string) (map[string]interface{}, error) {
-	ret := _m.Called(token)
+// ListProfiles provides a mock function with given fields: ctx, domainID, token, offset, limit
+func (_m *Service) ListProfiles(ctx context.Context, domainID string, token string, offset uint64, limit uint64) (provision.ProfilesPage, error) {
+	ret := _m.Called(ctx, domainID, token, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListProfiles")
+	}
+
+	var r0 provision.ProfilesPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64, uint64) (provision.ProfilesPage, error)); ok {
+		return rf(ctx, domainID, token, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64, uint64) provision.ProfilesPage); ok {
+		r0 = rf(ctx, domainID, token, offset, limit)
+	} else {
+		r0 = ret.Get(0).(provision.ProfilesPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, uint64, uint64) error); ok {
+		r1 = rf(ctx, domainID, token, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Mapping provides a mock function with given fields: ctx, domainID, token, profile
+func (_m *Service) Mapping(ctx context.Context, domainID string, token string, profile string) (map[string]interface{}, error) {
+	ret := _m.Called(ctx, domainID, token, profile)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Mapping")
@@ -59,19 +117,19 @@ func (_m *Service) Mapping(token string) (map[string]interface{}, error) {
 
 	var r0 map[string]interface{}
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (map[string]interface{}, error)); ok {
-		return rf(token)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (map[string]interface{}, error)); ok {
+		return rf(ctx, domainID, token, profile)
 	}
-	if rf, ok := ret.Get(0).(func(string) map[string]interface{}); ok {
-		r0 = rf(token)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) map[string]interface{}); ok {
+		r0 = rf(ctx, domainID, token, profile)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(map[string]interface{})
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(token)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, domainID, token, profile)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -79,9 +137,9 @@ func (_m *Service) Mapping(token string) (map[string]interface{}, error) {
 	return r0, r1
 }
 
-// Provision provides a mock function with given fields: domainID, token, name, externalID, externalKey
-func (_m *Service) Provision(domainID string, token string, name string, externalID string, externalKey string) (provision.Result, error) {
-	ret := _m.Called(domainID, token, name, externalID, externalKey)
+// Provision provides a mock function with given fields: ctx, domainID, token, profile, name, externalID, externalKey
+func (_m *Service) Provision(ctx context.Context, domainID string, token string, profile string, name string, externalID string, externalKey string) (provision.Result, error) {
+	ret := _m.Called(ctx, domainID, token, profile, name, externalID, externalKey)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Provision")
@@ -89,17 +147,91 @@ func (_m *Service) Provision(domainID string, token string, name string, externa
 
 	var r0 provision.Result
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, string, string, string) (provision.Result, error)); ok {
-		return rf(domainID, token, name, externalID, externalKey)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, string) (provision.Result, error)); ok {
+		return rf(ctx, domainID, token, profile, name, externalID, externalKey)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string, string, string) provision.Result); ok {
-		r0 = rf(domainID, token, name, externalID, externalKey)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, string) provision.Result); ok {
+		r0 = rf(ctx, domainID, token, profile, name, externalID, externalKey)
 	} else {
 		r0 = ret.Get(0).(provision.Result)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string, string, string) error); ok {
-		r1 = rf(domainID, token, name, externalID, externalKey)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, string, string) error); ok {
+		r1 = rf(ctx, domainID, token, profile, name, externalID, externalKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveProfile provides a mock function with given fields: ctx, domainID, token, name
+func (_m *Service) RemoveProfile(ctx context.Context, domainID string, token string, name string) error {
+	ret := _m.Called(ctx, domainID, token, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveProfile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, domainID, token, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateProfile provides a mock function with given fields: ctx, domainID, token, p
+func (_m *Service) UpdateProfile(ctx context.Context, domainID string, token string, p provision.Profile) (provision.Profile, error) {
+	ret := _m.Called(ctx, domainID, token, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProfile")
+	}
+
+	var r0 provision.Profile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, provision.Profile) (provision.Profile, error)); ok {
+		return rf(ctx, domainID, token, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, provision.Profile) provision.Profile); ok {
+		r0 = rf(ctx, domainID, token, p)
+	} else {
+		r0 = ret.Get(0).(provision.Profile)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, provision.Profile) error); ok {
+		r1 = rf(ctx, domainID, token, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ViewProfile provides a mock function with given fields: ctx, domainID, token, name, version
+func (_m *Service) ViewProfile(ctx context.Context, domainID string, token string, name string, version uint64) (provision.Profile, error) {
+	ret := _m.Called(ctx, domainID, token, name, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewProfile")
+	}
+
+	var r0 provision.Profile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, uint64) (provision.Profile, error)); ok {
+		return rf(ctx, domainID, token, name, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, uint64) provision.Profile); ok {
+		r0 = rf(ctx, domainID, token, name, version)
+	} else {
+		r0 = ret.Get(0).(provision.Profile)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, uint64) error); ok {
+		r1 = rf(ctx, domainID, token, name, version)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,36 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Migration of Provision service.
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "provision_01",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS profiles (
+						domain_id	VARCHAR(36) NOT NULL,
+						name		VARCHAR(1024) NOT NULL,
+						version		BIGINT NOT NULL CHECK (version > 0),
+						things		JSONB NOT NULL,
+						channels	JSONB NOT NULL,
+						bootstrap	JSONB NOT NULL,
+						cert		JSONB NOT NULL,
+						created_at	TIMESTAMP NOT NULL,
+						PRIMARY KEY	(domain_id, name, version)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS profiles`,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+	"github.com/absmach/magistrala/provision"
+)
+
+const profileColumns = `domain_id, name, version, things, channels, bootstrap, cert, created_at`
+
+var _ provision.ProfileRepository = (*profileRepository)(nil)
+
+type profileRepository struct {
+	db postgres.Database
+}
+
+// NewProfileRepository instantiates a PostgreSQL implementation of profile
+// repository.
+func NewProfileRepository(db postgres.Database) provision.ProfileRepository {
+	return &profileRepository{db: db}
+}
+
+func (repo *profileRepository) Save(ctx context.Context, p provision.Profile) (provision.Profile, error) {
+	q := fmt.Sprintf(`INSERT INTO profiles (%s)
+		VALUES (:domain_id, :name, :version, :things, :channels, :bootstrap, :cert, :created_at)
+		RETURNING %s;`, profileColumns, profileColumns)
+
+	dbp, err := toDBProfile(p)
+	if err != nil {
+		return provision.Profile{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return repo.namedQueryRow(ctx, q, dbp, repoerr.ErrCreateEntity)
+}
+
+func (repo *profileRepository) Update(ctx context.Context, p provision.Profile) (provision.Profile, error) {
+	// The next version is inserted only if the profile exists. Concurrent
+	// updates of the same version fail on the primary key.
+	q := fmt.Sprintf(`INSERT INTO profiles (%s)
+		SELECT :domain_id, :name, MAX(version) + 1, :things, :channels, :bootstrap, :cert, :created_at
+		FROM profiles WHERE domain_id = :domain_id AND name = :name
+		HAVING COUNT(*) > 0
+		RETURNING %s;`, profileColumns, profileColumns)
+
+	dbp, err := toDBProfile(p)
+	if err != nil {
+		return provision.Profile{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return repo.namedQueryRow(ctx, q, dbp, repoerr.ErrUpdateEntity)
+}
+
+func (repo *profileRepository) Retrieve(ctx context.Context, domainID, name string, version uint64) (provision.Profile, error) {
+	q := fmt.Sprintf(`SELECT %s FROM profiles WHERE domain_id = :domain_id AND name = :name
+		ORDER BY version DESC LIMIT 1;`, profileColumns)
+	if version > 0 {
+		q = fmt.Sprintf(`SELECT %s FROM profiles WHERE domain_id = :domain_id AND name = :name AND version = :version;`, profileColumns)
+	}
+
+	dbp := dbProfile{
+		DomainID: domainID,
+		Name:     name,
+		Version:  version,
+	}
+
+	return repo.namedQueryRow(ctx, q, dbp, repoerr.ErrViewEntity)
+}
+
+func (repo *profileRepository) RetrieveAll(ctx context.Context, domainID string, offset, limit uint64) (provision.ProfilesPage, error) {
+	q := fmt.Sprintf(`SELECT %s FROM (
+			SELECT DISTINCT ON (name) %s FROM profiles WHERE domain_id = :domain_id
+			ORDER BY name, version DESC
+		) AS latest ORDER BY name LIMIT :limit OFFSET :offset;`, profileColumns, profileColumns)
+
+	params := map[string]interface{}{
+		"domain_id": domainID,
+		"limit":     limit,
+		"offset":    offset,
+	}
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return provision.ProfilesPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	items := []provision.Profile{}
+	for rows.Next() {
+		var dbp dbProfile
+		if err := rows.StructScan(&dbp); err != nil {
+			return provision.ProfilesPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		p, err := toProfile(dbp)
+		if err != nil {
+			return provision.ProfilesPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		items = append(items, p)
+	}
+
+	cq := `SELECT COUNT(DISTINCT name) FROM profiles WHERE domain_id = :domain_id;`
+	total, err := postgres.Total(ctx, repo.db, cq, params)
+	if err != nil {
+		return provision.ProfilesPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return provision.ProfilesPage{
+		Total:    total,
+		Offset:   offset,
+		Limit:    limit,
+		Profiles: items,
+	}, nil
+}
+
+func (repo *profileRepository) Remove(ctx context.Context, domainID, name string) error {
+	q := `DELETE FROM profiles WHERE domain_id = :domain_id AND name = :name;`
+
+	res, err := repo.db.NamedExecContext(ctx, q, dbProfile{DomainID: domainID, Name: name})
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *profileRepository) namedQueryRow(ctx context.Context, q string, params interface{}, wrapper error) (provision.Profile, error) {
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return provision.Profile{}, postgres.HandleError(wrapper, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return provision.Profile{}, errors.Wrap(repoerr.ErrNotFound, sql.ErrNoRows)
+	}
+	var dbp dbProfile
+	if err := rows.StructScan(&dbp); err != nil {
+		return provision.Profile{}, postgres.HandleError(wrapper, err)
+	}
+
+	return toProfile(dbp)
+}
+
+type dbProfile struct {
+	DomainID  string    `db:"domain_id"`
+	Name      string    `db:"name"`
+	Version   uint64    `db:"version"`
+	Things    []byte    `db:"things"`
+	Channels  []byte    `db:"channels"`
+	Bootstrap []byte    `db:"bootstrap"`
+	Cert      []byte    `db:"cert"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func toDBProfile(p provision.Profile) (dbProfile, error) {
+	things, err := json.Marshal(p.Things)
+	if err != nil {
+		return dbProfile{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	channels, err := json.Marshal(p.Channels)
+	if err != nil {
+		return dbProfile{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	bootstrap, err := json.Marshal(p.Bootstrap)
+	if err != nil {
+		return dbProfile{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	cert, err := json.Marshal(p.Cert)
+	if err != nil {
+		return dbProfile{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return dbProfile{
+		DomainID:  p.DomainID,
+		Name:      p.Name,
+		Version:   p.Version,
+		Things:    things,
+		Channels:  channels,
+		Bootstrap: bootstrap,
+		Cert:      cert,
+		CreatedAt: p.CreatedAt.UTC(),
+	}, nil
+}
+
+func toProfile(dbp dbProfile) (provision.Profile, error) {
+	p := provision.Profile{
+		DomainID:  dbp.DomainID,
+		Name:      dbp.Name,
+		Version:   dbp.Version,
+		CreatedAt: dbp.CreatedAt,
+	}
+	if err := json.Unmarshal(dbp.Things, &p.Things); err != nil {
+		return provision.Profile{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	if err := json.Unmarshal(dbp.Channels, &p.Channels); err != nil {
+		return provision.Profile{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	if err := json.Unmarshal(dbp.Bootstrap, &p.Bootstrap); err != nil {
+		return provision.Profile{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	if err := json.Unmarshal(dbp.Cert, &p.Cert); err != nil {
+		return provision.Profile{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,148 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"bytes"
+	"context"
+	"text/template"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// ErrInvalidProfile indicates a malformed provisioning profile.
+var ErrInvalidProfile = errors.New("invalid provisioning profile")
+
+// Layout describes a Thing or a Channel created by provisioning. Name and
+// string metadata values are Go text templates rendered with the provision
+// request, so {{.Name}} and {{.ExternalID}} can be used.
+type Layout struct {
+	Name     string                 `json:"name"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// Profile represents a named provisioning layout of a domain. Every update
+// of the profile creates a new version, previous versions are kept.
+type Profile struct {
+	DomainID  string    `json:"domain_id,omitempty"`
+	Name      string    `json:"name"`
+	Version   uint64    `json:"version"`
+	Things    []Layout  `json:"things"`
+	Channels  []Layout  `json:"channels"`
+	Bootstrap Bootstrap `json:"bootstrap"`
+	Cert      Cert      `json:"cert"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ProfilesPage contains page related metadata as well as list of Profiles
+// that belong to this page.
+type ProfilesPage struct {
+	Total    uint64    `json:"total"`
+	Offset   uint64    `json:"offset"`
+	Limit    uint64    `json:"limit"`
+	Profiles []Profile `json:"profiles"`
+}
+
+// ProfileRepository specifies a Profile persistence API.
+//
+//go:generate mockery --name ProfileRepository --output=./mocks --filename profiles.go --quiet --note "Copyright (c) Abstract Machines"
+type ProfileRepository interface {
+	// Save persists the first version of the Profile.
+	Save(ctx context.Context, p Profile) (Profile, error)
+
+	// Update persists the Profile as its next version.
+	Update(ctx context.Context, p Profile) (Profile, error)
+
+	// Retrieve retrieves the Profile version, or the latest one if the
+	// version is zero.
+	Retrieve(ctx context.Context, domainID, name string, version uint64) (Profile, error)
+
+	// RetrieveAll retrieves the latest versions of a subset of domain Profiles.
+	RetrieveAll(ctx context.Context, domainID string, offset, limit uint64) (ProfilesPage, error)
+
+	// Remove removes all versions of the Profile.
+	Remove(ctx context.Context, domainID, name string) error
+}
+
+// Validate checks whether the profile describes a valid layout.
+func (p Profile) Validate() error {
+	if len(p.Things) == 0 {
+		return ErrEmptyThingsList
+	}
+	if len(p.Channels) == 0 {
+		return ErrEmptyChannelsList
+	}
+	if p.Cert.TTL != "" {
+		if _, err := time.ParseDuration(p.Cert.TTL); err != nil {
+			return errors.Wrap(ErrInvalidProfile, err)
+		}
+	}
+	for _, l := range append(append([]Layout{}, p.Things...), p.Channels...) {
+		if _, err := l.render(request{}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// request contains the values the layouts are rendered with.
+type request struct {
+	Name       string
+	ExternalID string
+}
+
+func (l Layout) render(req request) (Layout, error) {
+	name, err := renderText(l.Name, req)
+	if err != nil {
+		return Layout{}, err
+	}
+	metadata, err := renderMetadata(l.Metadata, req)
+	if err != nil {
+		return Layout{}, err
+	}
+
+	return Layout{Name: name, Metadata: metadata}, nil
+}
+
+func renderMetadata(metadata map[string]interface{}, req request) (map[string]interface{}, error) {
+	if metadata == nil {
+		return nil, nil
+	}
+	ret := make(map[string]interface{}, len(metadata))
+	for k, v := range metadata {
+		switch val := v.(type) {
+		case string:
+			s, err := renderText(val, req)
+			if err != nil {
+				return nil, err
+			}
+			ret[k] = s
+		case map[string]interface{}:
+			m, err := renderMetadata(val, req)
+			if err != nil {
+				return nil, err
+			}
+			ret[k] = m
+		default:
+			ret[k] = v
+		}
+	}
+
+	return ret, nil
+}
+
+func renderText(text string, req request) (string, error) {
+	t, err := template.New("layout").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", errors.Wrap(ErrInvalidProfile, err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, req); err != nil {
+		return "", errors.Wrap(ErrInvalidProfile, err)
+	}
+
+	return buf.String(), nil
+}
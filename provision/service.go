@@ -4,11 +4,16 @@
 package provision
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"slices"
+	"time"
 
 	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/policies"
 	sdk "github.com/absmach/magistrala/pkg/sdk/go"
 )
 
@@ -24,7 +29,6 @@ const (
 
 var (
 	ErrUnauthorized             = errors.New("unauthorized access")
-	ErrFailedToCreateToken      = errors.New("failed to create access token")
 	ErrEmptyThingsList          = errors.New("things list in configuration empty")
 	ErrThingUpdate              = errors.New("failed to update thing")
 	ErrEmptyChannelsList        = errors.New("channels list in configuration is empty")
@@ -39,9 +43,7 @@ var (
 	ErrFailedBootstrap          = errors.New("failed to create bootstrap config")
 	ErrFailedBootstrapValidate  = errors.New("failed to validate bootstrap config creation")
 	ErrGatewayUpdate            = errors.New("failed to updated gateway metadata")
-
-	limit  uint = 10
-	offset uint = 0
+	ErrFailedProfileRetrieval   = errors.New("failed to retrieve provisioning profile")
 )
 
 var _ Service = (*provisionService)(nil)
@@ -50,30 +52,49 @@ var _ Service = (*provisionService)(nil)
 //
 //go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
 type Service interface {
-	// Provision is the only method this API specifies. Depending on the configuration,
-	// the following actions will can be executed:
+	// Provision creates the layout of the named profile, or of the config
+	// file if the profile name is empty, on behalf of the caller. Depending
+	// on the profile, the following actions will can be executed:
 	// - create a Thing based on external_id (eg. MAC address)
 	// - create multiple Channels
 	// - create Bootstrap configuration
 	// - whitelist Thing in Bootstrap configuration == connect Thing to Channels
-	Provision(domainID, token, name, externalID, externalKey string) (Result, error)
+	// If any of the actions fails, the already created entities are removed.
+	Provision(ctx context.Context, domainID, token, profile, name, externalID, externalKey string) (Result, error)
 
-	// Mapping returns current configuration used for provision
+	// Mapping returns bootstrap content of the profile used for provision
 	// useful for using in ui to create configuration that matches
 	// one created with Provision method.
-	Mapping(token string) (map[string]interface{}, error)
+	Mapping(ctx context.Context, domainID, token, profile string) (map[string]interface{}, error)
 
 	// Certs creates certificate for things that communicate over mTLS
 	// A duration string is a possibly signed sequence of decimal numbers,
 	// each with optional fraction and a unit suffix, such as "300ms", "-1.5h" or "2h45m".
 	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
-	Cert(domainID, token, thingID, duration string) (string, string, error)
+	Cert(ctx context.Context, domainID, token, thingID, duration string) (string, string, error)
+
+	// AddProfile adds the first version of the provisioning profile.
+	AddProfile(ctx context.Context, domainID, token string, p Profile) (Profile, error)
+
+	// ViewProfile returns the profile version, or the latest one if the
+	// version is zero.
+	ViewProfile(ctx context.Context, domainID, token, name string, version uint64) (Profile, error)
+
+	// UpdateProfile adds a new version of the provisioning profile.
+	UpdateProfile(ctx context.Context, domainID, token string, p Profile) (Profile, error)
+
+	// ListProfiles returns the latest versions of the domain profiles.
+	ListProfiles(ctx context.Context, domainID, token string, offset, limit uint64) (ProfilesPage, error)
+
+	// RemoveProfile removes all versions of the provisioning profile.
+	RemoveProfile(ctx context.Context, domainID, token, name string) error
 }
 
 type provisionService struct {
-	logger *slog.Logger
-	sdk    sdk.SDK
-	conf   Config
+	logger   *slog.Logger
+	sdk      sdk.SDK
+	profiles ProfileRepository
+	conf     Config
 }
 
 // Result represent what is created with additional info.
@@ -88,87 +109,111 @@ type Result struct {
 }
 
 // New returns new provision service.
-func New(cfg Config, mgsdk sdk.SDK, logger *slog.Logger) Service {
+func New(cfg Config, mgsdk sdk.SDK, profiles ProfileRepository, logger *slog.Logger) Service {
 	return &provisionService{
-		logger: logger,
-		conf:   cfg,
-		sdk:    mgsdk,
+		logger:   logger,
+		conf:     cfg,
+		sdk:      mgsdk,
+		profiles: profiles,
 	}
 }
 
-// Mapping retrieves current configuration.
-func (ps *provisionService) Mapping(token string) (map[string]interface{}, error) {
-	pm := sdk.PageMetadata{
-		Offset: uint64(offset),
-		Limit:  uint64(limit),
+// Mapping retrieves bootstrap content of the profile.
+func (ps *provisionService) Mapping(ctx context.Context, domainID, token, profile string) (map[string]interface{}, error) {
+	if err := ps.authorize(domainID, token); err != nil {
+		return map[string]interface{}{}, err
 	}
 
-	if _, err := ps.sdk.Users(pm, token); err != nil {
-		return map[string]interface{}{}, errors.Wrap(ErrUnauthorized, err)
+	p, err := ps.profile(ctx, domainID, profile)
+	if err != nil {
+		return map[string]interface{}{}, err
 	}
 
-	return ps.conf.Bootstrap.Content, nil
+	return p.Bootstrap.Content, nil
 }
 
 // Provision is provision method for creating setup according to
-// provision layout specified in config.toml.
-func (ps *provisionService) Provision(domainID, token, name, externalID, externalKey string) (res Result, err error) {
-	var channels []sdk.Channel
-	var things []sdk.Thing
-	defer ps.recover(&err, &things, &channels, &domainID, &token)
+// provision layout specified in the profile.
+func (ps *provisionService) Provision(ctx context.Context, domainID, token, profile, name, externalID, externalKey string) (res Result, err error) {
+	if token == "" {
+		return res, ErrMissingCredentials
+	}
 
-	token, err = ps.createTokenIfEmpty(token)
+	p, err := ps.profile(ctx, domainID, profile)
 	if err != nil {
-		return res, errors.Wrap(ErrFailedToCreateToken, err)
+		return res, err
 	}
-
-	if len(ps.conf.Things) == 0 {
+	if len(p.Things) == 0 {
 		return res, ErrEmptyThingsList
 	}
-	if len(ps.conf.Channels) == 0 {
+	if len(p.Channels) == 0 {
 		return res, ErrEmptyChannelsList
 	}
-	for _, thing := range ps.conf.Things {
-		// If thing in configs contains metadata with external_id
+
+	var rb rollback
+	defer func() {
+		if err != nil {
+			ps.rollback(rb)
+		}
+	}()
+
+	req := request{Name: name, ExternalID: externalID}
+	var things []sdk.Thing
+	for _, layout := range p.Things {
+		layout, err := layout.render(req)
+		if err != nil {
+			return res, err
+		}
+		// If thing in profile contains metadata with external_id
 		// set value for it from the provision request
-		if _, ok := thing.Metadata[externalIDKey]; ok {
-			thing.Metadata[externalIDKey] = externalID
+		if _, ok := layout.Metadata[externalIDKey]; ok {
+			layout.Metadata[externalIDKey] = externalID
 		}
 
 		th := sdk.Thing{
-			Metadata: thing.Metadata,
+			Name:     layout.Name,
+			Metadata: layout.Metadata,
 		}
-		if name == "" {
-			name = thing.Name
+		if th.Name == "" {
+			th.Name = name
 		}
-		th.Name = name
-		th, err := ps.sdk.CreateThing(th, domainID, token)
+		th, err = ps.sdk.CreateThing(th, domainID, token)
 		if err != nil {
 			res.Error = err.Error()
 			return res, errors.Wrap(ErrFailedThingCreation, err)
 		}
+		thingID := th.ID
+		rb.add(func() error { return ps.sdk.DeleteThing(thingID, domainID, token) })
 
 		// Get newly created thing (in order to get the key).
-		th, err = ps.sdk.Thing(th.ID, domainID, token)
+		th, err = ps.sdk.Thing(thingID, domainID, token)
 		if err != nil {
-			e := errors.Wrap(err, fmt.Errorf("thing id: %s", th.ID))
+			e := errors.Wrap(err, fmt.Errorf("thing id: %s", thingID))
 			return res, errors.Wrap(ErrFailedThingRetrieval, e)
 		}
 		things = append(things, th)
 	}
 
-	for _, channel := range ps.conf.Channels {
+	var channels []sdk.Channel
+	for _, layout := range p.Channels {
+		layout, err := layout.render(req)
+		if err != nil {
+			return res, err
+		}
 		ch := sdk.Channel{
-			Name:     name + "_" + channel.Name,
-			Metadata: sdk.Metadata(channel.Metadata),
+			Name:     layout.Name,
+			Metadata: sdk.Metadata(layout.Metadata),
 		}
-		ch, err := ps.sdk.CreateChannel(ch, domainID, token)
+		ch, err = ps.sdk.CreateChannel(ch, domainID, token)
 		if err != nil {
 			return res, errors.Wrap(ErrFailedChannelCreation, err)
 		}
-		ch, err = ps.sdk.Channel(ch.ID, domainID, token)
+		channelID := ch.ID
+		rb.add(func() error { return ps.sdk.DeleteChannel(channelID, domainID, token) })
+
+		ch, err = ps.sdk.Channel(channelID, domainID, token)
 		if err != nil {
-			e := errors.Wrap(err, fmt.Errorf("channel id: %s", ch.ID))
+			e := errors.Wrap(err, fmt.Errorf("channel id: %s", channelID))
 			return res, errors.Wrap(ErrFailedChannelRetrieval, e)
 		}
 		channels = append(channels, ch)
@@ -190,12 +235,12 @@ func (ps *provisionService) Provision(domainID, token, name, externalID, externa
 		for _, ch := range channels {
 			chanIDs = append(chanIDs, ch.ID)
 		}
-		content, err := json.Marshal(ps.conf.Bootstrap.Content)
+		content, err := json.Marshal(p.Bootstrap.Content)
 		if err != nil {
 			return Result{}, errors.Wrap(ErrFailedBootstrap, err)
 		}
 
-		if ps.conf.Bootstrap.Provision && needsBootstrap(thing) {
+		if p.Bootstrap.Provision && needsBootstrap(thing) {
 			bsReq := sdk.BootstrapConfig{
 				ThingID:     thing.ID,
 				ExternalID:  externalID,
@@ -210,6 +255,7 @@ func (ps *provisionService) Provision(domainID, token, name, externalID, externa
 			if err != nil {
 				return Result{}, errors.Wrap(ErrFailedBootstrap, err)
 			}
+			rb.add(func() error { return ps.sdk.RemoveBootstrap(bsid, domainID, token) })
 
 			bsConfig, err = ps.sdk.ViewBootstrap(bsid, domainID, token)
 			if err != nil {
@@ -217,14 +263,19 @@ func (ps *provisionService) Provision(domainID, token, name, externalID, externa
 			}
 		}
 
-		if ps.conf.Bootstrap.X509Provision {
+		if p.Bootstrap.X509Provision {
 			var cert sdk.Cert
 
-			cert, err = ps.sdk.IssueCert(thing.ID, ps.conf.Cert.TTL, domainID, token)
+			cert, err = ps.sdk.IssueCert(thing.ID, p.Cert.TTL, domainID, token)
 			if err != nil {
 				e := errors.Wrap(err, fmt.Errorf("thing id: %s", thing.ID))
 				return res, errors.Wrap(ErrFailedCertCreation, e)
 			}
+			thingID := thing.ID
+			rb.add(func() error {
+				_, err := ps.sdk.RevokeCert(thingID, domainID, token)
+				return err
+			})
 			cert, err := ps.sdk.ViewCert(cert.SerialNumber, domainID, token)
 			if err != nil {
 				return res, errors.Wrap(ErrFailedCertView, err)
@@ -241,7 +292,7 @@ func (ps *provisionService) Provision(domainID, token, name, externalID, externa
 			}
 		}
 
-		if ps.conf.Bootstrap.AutoWhiteList {
+		if p.Bootstrap.AutoWhiteList {
 			if err := ps.sdk.Whitelist(thing.ID, Active, domainID, token); err != nil {
 				res.Error = err.Error()
 				return res, ErrThingUpdate
@@ -250,16 +301,17 @@ func (ps *provisionService) Provision(domainID, token, name, externalID, externa
 		}
 	}
 
-	if err = ps.updateGateway(domainID, token, bsConfig, channels); err != nil {
-		return res, err
+	if bsConfig.ThingID != "" {
+		if err = ps.updateGateway(domainID, token, bsConfig, channels); err != nil {
+			return res, err
+		}
 	}
 	return res, nil
 }
 
-func (ps *provisionService) Cert(domainID, token, thingID, ttl string) (string, string, error) {
-	token, err := ps.createTokenIfEmpty(token)
-	if err != nil {
-		return "", "", errors.Wrap(ErrFailedToCreateToken, err)
+func (ps *provisionService) Cert(ctx context.Context, domainID, token, thingID, ttl string) (string, string, error) {
+	if token == "" {
+		return "", "", ErrMissingCredentials
 	}
 
 	th, err := ps.sdk.Thing(thingID, domainID, token)
@@ -277,32 +329,124 @@ func (ps *provisionService) Cert(domainID, token, thingID, ttl string) (string,
 	return cert.Certificate, cert.Key, err
 }
 
-func (ps *provisionService) createTokenIfEmpty(token string) (string, error) {
-	if token != "" {
-		return token, nil
+func (ps *provisionService) AddProfile(ctx context.Context, domainID, token string, p Profile) (Profile, error) {
+	if err := ps.authorizeAdmin(domainID, token); err != nil {
+		return Profile{}, err
+	}
+	if err := p.Validate(); err != nil {
+		return Profile{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	p.DomainID = domainID
+	p.Version = 1
+	p.CreatedAt = time.Now().UTC()
+
+	saved, err := ps.profiles.Save(ctx, p)
+	if err != nil {
+		return Profile{}, errors.Wrap(svcerr.ErrCreateEntity, err)
 	}
 
-	// If no token in request is provided
-	// use API key provided in config file or env
-	if ps.conf.Server.MgAPIKey != "" {
-		return ps.conf.Server.MgAPIKey, nil
+	return saved, nil
+}
+
+func (ps *provisionService) ViewProfile(ctx context.Context, domainID, token, name string, version uint64) (Profile, error) {
+	if err := ps.authorize(domainID, token); err != nil {
+		return Profile{}, err
 	}
 
-	// If no API key use username and password provided to create access token.
-	if ps.conf.Server.MgUsername == "" || ps.conf.Server.MgPass == "" {
-		return token, ErrMissingCredentials
+	p, err := ps.profiles.Retrieve(ctx, domainID, name, version)
+	if err != nil {
+		return Profile{}, errors.Wrap(svcerr.ErrViewEntity, err)
 	}
 
-	u := sdk.Login{
-		Identity: ps.conf.Server.MgUsername,
-		Secret:   ps.conf.Server.MgPass,
+	return p, nil
+}
+
+func (ps *provisionService) UpdateProfile(ctx context.Context, domainID, token string, p Profile) (Profile, error) {
+	if err := ps.authorizeAdmin(domainID, token); err != nil {
+		return Profile{}, err
+	}
+	if err := p.Validate(); err != nil {
+		return Profile{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
 	}
-	tkn, err := ps.sdk.CreateToken(u)
+	p.DomainID = domainID
+	p.CreatedAt = time.Now().UTC()
+
+	saved, err := ps.profiles.Update(ctx, p)
 	if err != nil {
-		return token, errors.Wrap(ErrFailedToCreateToken, err)
+		return Profile{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
 	}
 
-	return tkn.AccessToken, nil
+	return saved, nil
+}
+
+func (ps *provisionService) ListProfiles(ctx context.Context, domainID, token string, offset, limit uint64) (ProfilesPage, error) {
+	if err := ps.authorize(domainID, token); err != nil {
+		return ProfilesPage{}, err
+	}
+
+	page, err := ps.profiles.RetrieveAll(ctx, domainID, offset, limit)
+	if err != nil {
+		return ProfilesPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (ps *provisionService) RemoveProfile(ctx context.Context, domainID, token, name string) error {
+	if err := ps.authorizeAdmin(domainID, token); err != nil {
+		return err
+	}
+
+	if err := ps.profiles.Remove(ctx, domainID, name); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+// authorize checks whether the caller is a member of the domain.
+func (ps *provisionService) authorize(domainID, token string) error {
+	if token == "" {
+		return ErrMissingCredentials
+	}
+	if _, err := ps.sdk.Domain(domainID, token); err != nil {
+		return errors.Wrap(ErrUnauthorized, err)
+	}
+
+	return nil
+}
+
+// authorizeAdmin checks whether the caller is an administrator of the
+// domain. Profiles are the layouts all domain members provision from, so
+// only administrators manage them.
+func (ps *provisionService) authorizeAdmin(domainID, token string) error {
+	if token == "" {
+		return ErrMissingCredentials
+	}
+	d, err := ps.sdk.DomainPermissions(domainID, token)
+	if err != nil {
+		return errors.Wrap(ErrUnauthorized, err)
+	}
+	if !slices.Contains(d.Permissions, policies.AdminPermission) {
+		return ErrUnauthorized
+	}
+
+	return nil
+}
+
+// profile retrieves the latest version of the named profile, falling back
+// to the layout of the config file if the name is empty.
+func (ps *provisionService) profile(ctx context.Context, domainID, name string) (Profile, error) {
+	if name == "" {
+		return ps.conf.Profile(), nil
+	}
+
+	p, err := ps.profiles.Retrieve(ctx, domainID, name, 0)
+	if err != nil {
+		return Profile{}, errors.Wrap(ErrFailedProfileRetrieval, err)
+	}
+
+	return p, nil
 }
 
 func (ps *provisionService) updateGateway(domainID, token string, bs sdk.BootstrapConfig, channels []sdk.Channel) error {
@@ -345,71 +489,18 @@ func (ps *provisionService) errLog(err error) {
 	}
 }
 
-func clean(ps *provisionService, things []sdk.Thing, channels []sdk.Channel, domainID, token string) {
-	for _, t := range things {
-		err := ps.sdk.DeleteThing(t.ID, domainID, token)
-		ps.errLog(err)
-	}
-	for _, c := range channels {
-		err := ps.sdk.DeleteChannel(c.ID, domainID, token)
-		ps.errLog(err)
-	}
-}
-
-func (ps *provisionService) recover(e *error, ths *[]sdk.Thing, chs *[]sdk.Channel, dm, tkn *string) {
-	if e == nil {
-		return
-	}
-	things, channels, domainID, token, err := *ths, *chs, *dm, *tkn, *e
-
-	if errors.Contains(err, ErrFailedThingRetrieval) || errors.Contains(err, ErrFailedChannelCreation) {
-		for _, th := range things {
-			err := ps.sdk.DeleteThing(th.ID, domainID, token)
-			ps.errLog(err)
-		}
-		return
-	}
-
-	if errors.Contains(err, ErrFailedBootstrap) || errors.Contains(err, ErrFailedChannelRetrieval) {
-		clean(ps, things, channels, domainID, token)
-		return
-	}
+// rollback contains compensating actions of a provisioning run.
+type rollback []func() error
 
-	if errors.Contains(err, ErrFailedBootstrapValidate) || errors.Contains(err, ErrFailedCertCreation) {
-		clean(ps, things, channels, domainID, token)
-		for _, th := range things {
-			if needsBootstrap(th) {
-				ps.errLog(ps.sdk.RemoveBootstrap(th.ID, domainID, token))
-			}
-		}
-		return
-	}
-
-	if errors.Contains(err, ErrFailedBootstrapValidate) || errors.Contains(err, ErrFailedCertCreation) {
-		clean(ps, things, channels, domainID, token)
-		for _, th := range things {
-			if needsBootstrap(th) {
-				bs, err := ps.sdk.ViewBootstrap(th.ID, domainID, token)
-				ps.errLog(errors.Wrap(ErrFailedBootstrapRetrieval, err))
-				ps.errLog(ps.sdk.RemoveBootstrap(bs.ThingID, domainID, token))
-			}
-		}
-	}
+func (rb *rollback) add(action func() error) {
+	*rb = append(*rb, action)
+}
 
-	if errors.Contains(err, ErrThingUpdate) || errors.Contains(err, ErrGatewayUpdate) {
-		clean(ps, things, channels, domainID, token)
-		for _, th := range things {
-			if ps.conf.Bootstrap.X509Provision && needsBootstrap(th) {
-				_, err := ps.sdk.RevokeCert(th.ID, domainID, token)
-				ps.errLog(err)
-			}
-			if needsBootstrap(th) {
-				bs, err := ps.sdk.ViewBootstrap(th.ID, domainID, token)
-				ps.errLog(errors.Wrap(ErrFailedBootstrapRetrieval, err))
-				ps.errLog(ps.sdk.RemoveBootstrap(bs.ThingID, domainID, token))
-			}
-		}
-		return
+// rollback removes the entities created by a failed provisioning run in
+// reverse order of creation.
+func (ps *provisionService) rollback(rb rollback) {
+	for i := len(rb) - 1; i >= 0; i-- {
+		ps.errLog(rb[i]())
 	}
 }
 
@@ -4,6 +4,7 @@
 package provision_test
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -12,9 +13,11 @@ import (
 	"github.com/absmach/magistrala/pkg/errors"
 	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/policies"
 	sdk "github.com/absmach/magistrala/pkg/sdk/go"
 	sdkmocks "github.com/absmach/magistrala/pkg/sdk/mocks"
 	"github.com/absmach/magistrala/provision"
+	"github.com/absmach/magistrala/provision/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -23,7 +26,8 @@ var validToken = "valid"
 
 func TestMapping(t *testing.T) {
 	mgsdk := new(sdkmocks.SDK)
-	svc := provision.New(validConfig, mgsdk, mglog.NewMock())
+	svc := provision.New(validConfig, mgsdk, new(mocks.ProfileRepository), mglog.NewMock())
+	domainID := testsutil.GenerateUUID(t)
 
 	cases := []struct {
 		desc    string
@@ -50,9 +54,8 @@ func TestMapping(t *testing.T) {
 
 	for _, c := range cases {
 		t.Run(c.desc, func(t *testing.T) {
-			pm := sdk.PageMetadata{Offset: uint64(0), Limit: uint64(10)}
-			repocall := mgsdk.On("Users", pm, c.token).Return(sdk.UsersPage{}, c.sdkerr)
-			content, err := svc.Mapping(c.token)
+			repocall := mgsdk.On("Domain", domainID, c.token).Return(sdk.Domain{}, c.sdkerr)
+			content, err := svc.Mapping(context.Background(), domainID, c.token, "")
 			assert.True(t, errors.Contains(err, c.err), fmt.Sprintf("expected error %v, got %v", c.err, err))
 			assert.Equal(t, c.content, content)
 			repocall.Unset()
@@ -73,7 +76,6 @@ func TestCert(t *testing.T) {
 		key         string
 		sdkThingErr error
 		sdkCertErr  error
-		sdkTokenErr error
 		err         error
 	}{
 		{
@@ -87,74 +89,11 @@ func TestCert(t *testing.T) {
 			key:         "key",
 			sdkThingErr: nil,
 			sdkCertErr:  nil,
-			sdkTokenErr: nil,
 			err:         nil,
 		},
 		{
-			desc: "empty token with config API key",
-			config: provision.Config{
-				Server: provision.ServiceConf{MgAPIKey: "key"},
-				Cert:   provision.Cert{TTL: "1h"},
-			},
-			domainID:    testsutil.GenerateUUID(t),
-			token:       "",
-			thingID:     testsutil.GenerateUUID(t),
-			ttl:         "1h",
-			cert:        "cert",
-			key:         "key",
-			sdkThingErr: nil,
-			sdkCertErr:  nil,
-			sdkTokenErr: nil,
-			err:         nil,
-		},
-		{
-			desc: "empty token with username and password",
-			config: provision.Config{
-				Server: provision.ServiceConf{
-					MgUsername: "testUsername",
-					MgPass:     "12345678",
-					MgDomainID: testsutil.GenerateUUID(t),
-				},
-				Cert: provision.Cert{TTL: "1h"},
-			},
-			domainID:    testsutil.GenerateUUID(t),
-			token:       "",
-			thingID:     testsutil.GenerateUUID(t),
-			ttl:         "1h",
-			cert:        "cert",
-			key:         "key",
-			sdkThingErr: nil,
-			sdkCertErr:  nil,
-			sdkTokenErr: nil,
-			err:         nil,
-		},
-		{
-			desc: "empty token with username and invalid password",
-			config: provision.Config{
-				Server: provision.ServiceConf{
-					MgUsername: "testUsername",
-					MgPass:     "12345678",
-					MgDomainID: testsutil.GenerateUUID(t),
-				},
-				Cert: provision.Cert{TTL: "1h"},
-			},
-			domainID:    testsutil.GenerateUUID(t),
-			token:       "",
-			thingID:     testsutil.GenerateUUID(t),
-			ttl:         "1h",
-			cert:        "",
-			key:         "",
-			sdkThingErr: nil,
-			sdkCertErr:  nil,
-			sdkTokenErr: errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, 401),
-			err:         provision.ErrFailedToCreateToken,
-		},
-		{
-			desc: "empty token with empty username and password",
-			config: provision.Config{
-				Server: provision.ServiceConf{},
-				Cert:   provision.Cert{TTL: "1h"},
-			},
+			desc:        "empty token",
+			config:      validConfig,
 			domainID:    testsutil.GenerateUUID(t),
 			token:       "",
 			thingID:     testsutil.GenerateUUID(t),
@@ -163,7 +102,6 @@ func TestCert(t *testing.T) {
 			key:         "",
 			sdkThingErr: nil,
 			sdkCertErr:  nil,
-			sdkTokenErr: nil,
 			err:         provision.ErrMissingCredentials,
 		},
 		{
@@ -177,7 +115,6 @@ func TestCert(t *testing.T) {
 			key:         "",
 			sdkThingErr: errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, 401),
 			sdkCertErr:  nil,
-			sdkTokenErr: nil,
 			err:         provision.ErrUnauthorized,
 		},
 		{
@@ -191,7 +128,6 @@ func TestCert(t *testing.T) {
 			key:         "",
 			sdkThingErr: errors.NewSDKErrorWithStatus(repoerr.ErrNotFound, 404),
 			sdkCertErr:  nil,
-			sdkTokenErr: nil,
 			err:         provision.ErrUnauthorized,
 		},
 		{
@@ -204,7 +140,6 @@ func TestCert(t *testing.T) {
 			cert:        "",
 			key:         "",
 			sdkThingErr: nil,
-			sdkTokenErr: nil,
 			sdkCertErr:  errors.NewSDKError(repoerr.ErrCreateEntity),
 			err:         repoerr.ErrCreateEntity,
 		},
@@ -213,20 +148,233 @@ func TestCert(t *testing.T) {
 	for _, c := range cases {
 		t.Run(c.desc, func(t *testing.T) {
 			mgsdk := new(sdkmocks.SDK)
-			svc := provision.New(c.config, mgsdk, mglog.NewMock())
+			svc := provision.New(c.config, mgsdk, new(mocks.ProfileRepository), mglog.NewMock())
 
 			mgsdk.On("Thing", c.thingID, c.domainID, mock.Anything).Return(sdk.Thing{ID: c.thingID}, c.sdkThingErr)
 			mgsdk.On("IssueCert", c.thingID, c.config.Cert.TTL, c.domainID, mock.Anything).Return(sdk.Cert{SerialNumber: c.serial}, c.sdkCertErr)
 			mgsdk.On("ViewCert", c.serial, mock.Anything, mock.Anything).Return(sdk.Cert{Certificate: c.cert, Key: c.key}, c.sdkCertErr)
-			login := sdk.Login{
-				Identity: c.config.Server.MgUsername,
-				Secret:   c.config.Server.MgPass,
-			}
-			mgsdk.On("CreateToken", login).Return(sdk.Token{AccessToken: validToken}, c.sdkTokenErr)
-			cert, key, err := svc.Cert(c.domainID, c.token, c.thingID, c.ttl)
+			cert, key, err := svc.Cert(context.Background(), c.domainID, c.token, c.thingID, c.ttl)
 			assert.Equal(t, c.cert, cert)
 			assert.Equal(t, c.key, key)
 			assert.True(t, errors.Contains(err, c.err), fmt.Sprintf("expected error %v, got %v", c.err, err))
 		})
 	}
 }
+
+func TestProvision(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	thingID := testsutil.GenerateUUID(t)
+	channelID := testsutil.GenerateUUID(t)
+	externalID := testsutil.GenerateUUID(t)
+	profile := provision.Profile{
+		DomainID: domainID,
+		Name:     "gateway",
+		Version:  1,
+		Things: []provision.Layout{
+			{
+				Name:     "{{.Name}}-gw",
+				Metadata: map[string]interface{}{"external_id": "", "serial": "{{.ExternalID}}"},
+			},
+		},
+		Channels: []provision.Layout{
+			{
+				Name:     "{{.Name}}-data",
+				Metadata: map[string]interface{}{"type": "data"},
+			},
+		},
+		Bootstrap: provision.Bootstrap{Provision: true, AutoWhiteList: true},
+	}
+	thing := sdk.Thing{
+		ID:       thingID,
+		Name:     "test-gw",
+		Metadata: map[string]interface{}{"external_id": externalID, "serial": externalID},
+	}
+
+	cases := []struct {
+		desc           string
+		token          string
+		profile        string
+		repoErr        error
+		channelErr     errors.SDKError
+		bootstrapErr   errors.SDKError
+		err            error
+		removedThing   bool
+		removedChannel bool
+	}{
+		{
+			desc:    "provision with profile",
+			token:   validToken,
+			profile: profile.Name,
+			err:     nil,
+		},
+		{
+			desc:  "provision with empty token",
+			token: "",
+			err:   provision.ErrMissingCredentials,
+		},
+		{
+			desc:    "provision with non-existing profile",
+			token:   validToken,
+			profile: "unknown",
+			repoErr: repoerr.ErrNotFound,
+			err:     provision.ErrFailedProfileRetrieval,
+		},
+		{
+			desc:         "provision with failed channel creation",
+			token:        validToken,
+			profile:      profile.Name,
+			channelErr:   errors.NewSDKError(svcerr.ErrCreateEntity),
+			err:          provision.ErrFailedChannelCreation,
+			removedThing: true,
+		},
+		{
+			desc:           "provision with failed bootstrap",
+			token:          validToken,
+			profile:        profile.Name,
+			bootstrapErr:   errors.NewSDKError(svcerr.ErrCreateEntity),
+			err:            provision.ErrFailedBootstrap,
+			removedThing:   true,
+			removedChannel: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			mgsdk := new(sdkmocks.SDK)
+			repo := new(mocks.ProfileRepository)
+			svc := provision.New(validConfig, mgsdk, repo, mglog.NewMock())
+
+			repo.On("Retrieve", context.Background(), domainID, c.profile, uint64(0)).Return(profile, c.repoErr)
+			mgsdk.On("CreateThing", mock.Anything, domainID, c.token).Return(sdk.Thing{ID: thingID}, nil)
+			mgsdk.On("Thing", thingID, domainID, c.token).Return(thing, nil)
+			mgsdk.On("CreateChannel", mock.Anything, domainID, c.token).Return(sdk.Channel{ID: channelID}, c.channelErr)
+			mgsdk.On("Channel", channelID, domainID, c.token).Return(sdk.Channel{ID: channelID, Metadata: sdk.Metadata{"type": "data"}}, nil)
+			mgsdk.On("AddBootstrap", mock.Anything, domainID, c.token).Return(thingID, c.bootstrapErr)
+			mgsdk.On("ViewBootstrap", thingID, domainID, c.token).Return(sdk.BootstrapConfig{ThingID: thingID, ExternalID: externalID}, nil)
+			mgsdk.On("Whitelist", thingID, provision.Active, domainID, c.token).Return(nil)
+			mgsdk.On("UpdateThing", mock.Anything, domainID, c.token).Return(thing, nil)
+			mgsdk.On("DeleteThing", thingID, domainID, c.token).Return(nil)
+			mgsdk.On("DeleteChannel", channelID, domainID, c.token).Return(nil)
+			mgsdk.On("RemoveBootstrap", thingID, domainID, c.token).Return(nil)
+
+			res, err := svc.Provision(context.Background(), domainID, c.token, c.profile, "test", externalID, "key")
+			assert.True(t, errors.Contains(err, c.err), fmt.Sprintf("expected error %v, got %v", c.err, err))
+			if err == nil {
+				metadata := sdk.Metadata{"external_id": externalID, "serial": externalID}
+				mgsdk.AssertCalled(t, "CreateThing", sdk.Thing{Name: "test-gw", Metadata: metadata}, domainID, c.token)
+				mgsdk.AssertCalled(t, "CreateChannel", sdk.Channel{Name: "test-data", Metadata: sdk.Metadata{"type": "data"}}, domainID, c.token)
+				assert.True(t, res.Whitelisted[thingID], fmt.Sprintf("expected thing %s to be whitelisted", thingID))
+			}
+			if c.removedThing {
+				mgsdk.AssertCalled(t, "DeleteThing", thingID, domainID, c.token)
+			}
+			if c.removedChannel {
+				mgsdk.AssertCalled(t, "DeleteChannel", channelID, domainID, c.token)
+			}
+		})
+	}
+}
+
+func TestAddProfile(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	profile := provision.Profile{
+		Name:     "gateway",
+		Things:   []provision.Layout{{Name: "{{.Name}}"}},
+		Channels: []provision.Layout{{Name: "{{.Name}}-data"}},
+	}
+	invalidName := profile
+	invalidName.Things = []provision.Layout{{Name: "{{.Name"}}
+	invalidVar := profile
+	invalidVar.Things = []provision.Layout{{Name: "{{.Unknown}}"}}
+	invalidTTL := profile
+	invalidTTL.Cert = provision.Cert{TTL: "invalid"}
+	emptyThings := profile
+	emptyThings.Things = nil
+
+	cases := []struct {
+		desc    string
+		token   string
+		profile provision.Profile
+		perms   []string
+		sdkErr  errors.SDKError
+		repoErr error
+		err     error
+	}{
+		{
+			desc:    "add valid profile",
+			token:   validToken,
+			profile: profile,
+			err:     nil,
+		},
+		{
+			desc:    "add profile with unauthorized token",
+			token:   "invalid",
+			profile: profile,
+			sdkErr:  errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, 403),
+			err:     provision.ErrUnauthorized,
+		},
+		{
+			desc:    "add profile as domain member without admin permission",
+			token:   validToken,
+			profile: profile,
+			perms:   []string{"view", "membership"},
+			err:     provision.ErrUnauthorized,
+		},
+		{
+			desc:    "add profile with malformed template",
+			token:   validToken,
+			profile: invalidName,
+			err:     provision.ErrInvalidProfile,
+		},
+		{
+			desc:    "add profile with unknown template variable",
+			token:   validToken,
+			profile: invalidVar,
+			err:     provision.ErrInvalidProfile,
+		},
+		{
+			desc:    "add profile with invalid cert TTL",
+			token:   validToken,
+			profile: invalidTTL,
+			err:     provision.ErrInvalidProfile,
+		},
+		{
+			desc:    "add profile without things",
+			token:   validToken,
+			profile: emptyThings,
+			err:     provision.ErrEmptyThingsList,
+		},
+		{
+			desc:    "add existing profile",
+			token:   validToken,
+			profile: profile,
+			repoErr: repoerr.ErrConflict,
+			err:     svcerr.ErrConflict,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			mgsdk := new(sdkmocks.SDK)
+			repo := new(mocks.ProfileRepository)
+			svc := provision.New(validConfig, mgsdk, repo, mglog.NewMock())
+
+			perms := c.perms
+			if perms == nil {
+				perms = []string{policies.AdminPermission}
+			}
+			mgsdk.On("DomainPermissions", domainID, c.token).Return(sdk.Domain{Permissions: perms}, c.sdkErr)
+			repo.On("Save", context.Background(), mock.Anything).Return(c.profile, c.repoErr)
+			_, err := svc.AddProfile(context.Background(), domainID, c.token, c.profile)
+			assert.True(t, errors.Contains(err, c.err), fmt.Sprintf("expected error %v, got %v", c.err, err))
+			if c.perms != nil {
+				repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+			}
+			if err == nil {
+				repo.AssertCalled(t, "Save", context.Background(), mock.MatchedBy(func(p provision.Profile) bool {
+					return p.DomainID == domainID && p.Version == 1
+				}))
+			}
+		})
+	}
+}
@@ -24,8 +24,14 @@ const svcName = "magistrala.ThingsService"
 var _ magistrala.ThingsServiceClient = (*grpcClient)(nil)
 
 type grpcClient struct {
-	timeout   time.Duration
-	authorize endpoint.Endpoint
+	timeout           time.Duration
+	authorize         endpoint.Endpoint
+	derivePSK         endpoint.Endpoint
+	connectedChannels endpoint.Endpoint
+	channelMetadata   endpoint.Endpoint
+	thingsConnections endpoint.Endpoint
+	createThing       endpoint.Endpoint
+	deleteThing       endpoint.Endpoint
 }
 
 // NewClient returns new gRPC client instance.
@@ -39,6 +45,54 @@ func NewClient(conn *grpc.ClientConn, timeout time.Duration) magistrala.ThingsSe
 			decodeAuthorizeResponse,
 			magistrala.ThingsAuthzRes{},
 		).Endpoint(),
+		derivePSK: kitgrpc.NewClient(
+			conn,
+			svcName,
+			"DerivePSK",
+			encodeDerivePSKRequest,
+			decodeDerivePSKResponse,
+			magistrala.ThingsPSKRes{},
+		).Endpoint(),
+		connectedChannels: kitgrpc.NewClient(
+			conn,
+			svcName,
+			"ConnectedChannels",
+			encodeConnectedChannelsRequest,
+			decodeConnectedChannelsResponse,
+			magistrala.ThingsChannelsRes{},
+		).Endpoint(),
+		channelMetadata: kitgrpc.NewClient(
+			conn,
+			svcName,
+			"ChannelMetadata",
+			encodeChannelMetadataRequest,
+			decodeChannelMetadataResponse,
+			magistrala.ChannelMetadataRes{},
+		).Endpoint(),
+		thingsConnections: kitgrpc.NewClient(
+			conn,
+			svcName,
+			"ThingsConnections",
+			encodeThingsConnectionsRequest,
+			decodeThingsConnectionsResponse,
+			magistrala.ThingsConnectionsRes{},
+		).Endpoint(),
+		createThing: kitgrpc.NewClient(
+			conn,
+			svcName,
+			"CreateThing",
+			encodeCreateThingRequest,
+			decodeCreateThingResponse,
+			magistrala.CreateThingRes{},
+		).Endpoint(),
+		deleteThing: kitgrpc.NewClient(
+			conn,
+			svcName,
+			"DeleteThing",
+			encodeDeleteThingRequest,
+			decodeDeleteThingResponse,
+			magistrala.DeleteThingRes{},
+		).Endpoint(),
 
 		timeout: timeout,
 	}
@@ -51,6 +105,7 @@ func (client grpcClient) Authorize(ctx context.Context, req *magistrala.ThingsAu
 	res, err := client.authorize(ctx, things.AuthzReq{
 		ClientID:   req.GetThingId(),
 		ClientKey:  req.GetThingKey(),
+		ClientPSK:  req.GetThingPsk(),
 		ChannelID:  req.GetChannelId(),
 		Permission: req.GetPermission(),
 	})
@@ -73,10 +128,166 @@ func encodeAuthorizeRequest(_ context.Context, grpcReq interface{}) (interface{}
 		ChannelId:  req.ChannelID,
 		ThingId:    req.ClientID,
 		ThingKey:   req.ClientKey,
+		ThingPsk:   req.ClientPSK,
 		Permission: req.Permission,
 	}, nil
 }
 
+func (client grpcClient) DerivePSK(ctx context.Context, req *magistrala.ThingsPSKReq, _ ...grpc.CallOption) (*magistrala.ThingsPSKRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.derivePSK(ctx, derivePSKReq{ThingID: req.GetThingId()})
+	if err != nil {
+		return &magistrala.ThingsPSKRes{}, decodeError(err)
+	}
+
+	pr := res.(derivePSKRes)
+	return &magistrala.ThingsPSKRes{Psk: pr.psk}, nil
+}
+
+func decodeDerivePSKResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*magistrala.ThingsPSKRes)
+	return derivePSKRes{psk: res.GetPsk()}, nil
+}
+
+func encodeDerivePSKRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(derivePSKReq)
+	return &magistrala.ThingsPSKReq{ThingId: req.ThingID}, nil
+}
+
+func (client grpcClient) ConnectedChannels(ctx context.Context, req *magistrala.ThingsChannelsReq, _ ...grpc.CallOption) (*magistrala.ThingsChannelsRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.connectedChannels(ctx, connectedChannelsReq{ThingID: req.GetThingId(), ThingKey: req.GetThingKey(), ThingPSK: req.GetThingPsk()})
+	if err != nil {
+		return &magistrala.ThingsChannelsRes{}, decodeError(err)
+	}
+
+	cr := res.(connectedChannelsRes)
+	return &magistrala.ThingsChannelsRes{ChannelIds: cr.channelIDs}, nil
+}
+
+func decodeConnectedChannelsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*magistrala.ThingsChannelsRes)
+	return connectedChannelsRes{channelIDs: res.GetChannelIds()}, nil
+}
+
+func encodeConnectedChannelsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(connectedChannelsReq)
+	return &magistrala.ThingsChannelsReq{ThingId: req.ThingID, ThingKey: req.ThingKey, ThingPsk: req.ThingPSK}, nil
+}
+
+func (client grpcClient) ChannelMetadata(ctx context.Context, req *magistrala.ChannelMetadataReq, _ ...grpc.CallOption) (*magistrala.ChannelMetadataRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.channelMetadata(ctx, channelMetadataReq{ChannelID: req.GetChannelId()})
+	if err != nil {
+		return &magistrala.ChannelMetadataRes{}, decodeError(err)
+	}
+
+	mr := res.(channelMetadataRes)
+	return &magistrala.ChannelMetadataRes{Metadata: mr.metadata}, nil
+}
+
+func decodeChannelMetadataResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*magistrala.ChannelMetadataRes)
+	return channelMetadataRes{metadata: res.GetMetadata()}, nil
+}
+
+func encodeChannelMetadataRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(channelMetadataReq)
+	return &magistrala.ChannelMetadataReq{ChannelId: req.ChannelID}, nil
+}
+
+func (client grpcClient) ThingsConnections(ctx context.Context, req *magistrala.ThingsConnectionsReq, _ ...grpc.CallOption) (*magistrala.ThingsConnectionsRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.thingsConnections(ctx, thingsConnectionsReq{DomainID: req.GetDomainId(), ThingIDs: req.GetThingIds()})
+	if err != nil {
+		return &magistrala.ThingsConnectionsRes{}, decodeError(err)
+	}
+
+	cr := res.(thingsConnectionsRes)
+	conns := make([]*magistrala.ThingConnections, 0, len(cr.connections))
+	for thingID, chids := range cr.connections {
+		conns = append(conns, &magistrala.ThingConnections{ThingId: thingID, ChannelIds: chids})
+	}
+	return &magistrala.ThingsConnectionsRes{Connections: conns}, nil
+}
+
+func decodeThingsConnectionsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*magistrala.ThingsConnectionsRes)
+	conns := make(map[string][]string, len(res.GetConnections()))
+	for _, conn := range res.GetConnections() {
+		conns[conn.GetThingId()] = conn.GetChannelIds()
+	}
+	return thingsConnectionsRes{connections: conns}, nil
+}
+
+func encodeThingsConnectionsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(thingsConnectionsReq)
+	return &magistrala.ThingsConnectionsReq{DomainId: req.DomainID, ThingIds: req.ThingIDs}, nil
+}
+
+func (client grpcClient) CreateThing(ctx context.Context, req *magistrala.CreateThingReq, _ ...grpc.CallOption) (*magistrala.CreateThingRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.createThing(ctx, createThingReq{
+		DomainID: req.GetDomainId(),
+		UserID:   req.GetUserId(),
+		ID:       req.GetId(),
+		Name:     req.GetName(),
+		Metadata: req.GetMetadata(),
+	})
+	if err != nil {
+		return &magistrala.CreateThingRes{}, decodeError(err)
+	}
+
+	cr := res.(createThingRes)
+	return &magistrala.CreateThingRes{Id: cr.id, Key: cr.key}, nil
+}
+
+func decodeCreateThingResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*magistrala.CreateThingRes)
+	return createThingRes{id: res.GetId(), key: res.GetKey()}, nil
+}
+
+func encodeCreateThingRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(createThingReq)
+	return &magistrala.CreateThingReq{
+		DomainId: req.DomainID,
+		UserId:   req.UserID,
+		Id:       req.ID,
+		Name:     req.Name,
+		Metadata: req.Metadata,
+	}, nil
+}
+
+func (client grpcClient) DeleteThing(ctx context.Context, req *magistrala.DeleteThingReq, _ ...grpc.CallOption) (*magistrala.DeleteThingRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	if _, err := client.deleteThing(ctx, deleteThingReq{DomainID: req.GetDomainId(), UserID: req.GetUserId(), ID: req.GetId()}); err != nil {
+		return &magistrala.DeleteThingRes{}, decodeError(err)
+	}
+
+	return &magistrala.DeleteThingRes{}, nil
+}
+
+func decodeDeleteThingResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return deleteThingRes{}, nil
+}
+
+func encodeDeleteThingRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(deleteThingReq)
+	return &magistrala.DeleteThingReq{DomainId: req.DomainID, UserId: req.UserID, Id: req.ID}, nil
+}
+
 func decodeError(err error) error {
 	if st, ok := status.FromError(err); ok {
 		switch st.Code() {
@@ -5,7 +5,11 @@ package grpc
 
 import (
 	"context"
+	"encoding/json"
 
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
 	"github.com/absmach/magistrala/things"
 	"github.com/go-kit/kit/endpoint"
 )
@@ -18,6 +22,7 @@ func authorizeEndpoint(svc things.Service) endpoint.Endpoint {
 			ChannelID:  req.ChannelID,
 			ClientID:   req.ThingID,
 			ClientKey:  req.ThingKey,
+			ClientPSK:  req.ThingPSK,
 			Permission: req.Permission,
 		})
 		if err != nil {
@@ -29,3 +34,95 @@ func authorizeEndpoint(svc things.Service) endpoint.Endpoint {
 		}, err
 	}
 }
+
+func derivePSKEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(derivePSKReq)
+
+		psk, err := svc.DerivePSK(ctx, req.ThingID)
+		if err != nil {
+			return derivePSKRes{}, err
+		}
+		return derivePSKRes{psk: psk}, nil
+	}
+}
+
+func connectedChannelsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(connectedChannelsReq)
+
+		chids, err := svc.ConnectedChannels(ctx, req.ThingID, req.ThingKey, req.ThingPSK)
+		if err != nil {
+			return connectedChannelsRes{}, err
+		}
+		return connectedChannelsRes{channelIDs: chids}, nil
+	}
+}
+
+func channelMetadataEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(channelMetadataReq)
+
+		metadata, err := svc.ChannelMetadata(ctx, req.ChannelID)
+		if err != nil {
+			return channelMetadataRes{}, err
+		}
+		data, err := json.Marshal(metadata)
+		if err != nil {
+			return channelMetadataRes{}, err
+		}
+		return channelMetadataRes{metadata: data}, nil
+	}
+}
+
+func thingsConnectionsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(thingsConnectionsReq)
+
+		conns, err := svc.ThingsConnections(ctx, req.DomainID, req.ThingIDs)
+		if err != nil {
+			return thingsConnectionsRes{}, err
+		}
+		return thingsConnectionsRes{connections: conns}, nil
+	}
+}
+
+func createThingEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createThingReq)
+
+		client := things.Client{ID: req.ID, Name: req.Name, Status: things.EnabledStatus}
+		if len(req.Metadata) > 0 {
+			if err := json.Unmarshal(req.Metadata, &client.Metadata); err != nil {
+				return createThingRes{}, errors.Wrap(errors.ErrMalformedEntity, err)
+			}
+		}
+		saved, err := svc.CreateClients(ctx, session(req.DomainID, req.UserID), client)
+		if err != nil {
+			return createThingRes{}, err
+		}
+		return createThingRes{id: saved[0].ID, key: saved[0].Credentials.Secret}, nil
+	}
+}
+
+func deleteThingEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deleteThingReq)
+
+		if err := svc.Delete(ctx, session(req.DomainID, req.UserID), req.ID); err != nil {
+			return deleteThingRes{}, err
+		}
+		return deleteThingRes{}, nil
+	}
+}
+
+// session returns the session of the user in the domain, so the things
+// created and deleted on behalf of the user are authorized as if the user
+// made the request.
+func session(domainID, userID string) authn.Session {
+	return authn.Session{
+		DomainUserID: auth.EncodeDomainUserID(domainID, userID),
+		UserID:       userID,
+		DomainID:     domainID,
+	}
+}
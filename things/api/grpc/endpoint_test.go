@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/authn"
 	"github.com/absmach/magistrala/pkg/errors"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/absmach/magistrala/pkg/policies"
@@ -24,7 +25,15 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-const port = 7000
+const (
+	port    = 7000
+	pskPort = 7001
+	chsPort = 7002
+	mdPort  = 7003
+	tcPort  = 7004
+	ctPort  = 7005
+	dtPort  = 7006
+)
 
 var (
 	thingID   = "testID"
@@ -85,6 +94,25 @@ func TestAuthorize(t *testing.T) {
 			res:          &magistrala.ThingsAuthzRes{Authorized: true, Id: thingID},
 			err:          nil,
 		},
+		{
+			desc:    "authorize by ID and pre-shared key successfully",
+			thingID: thingID,
+			req: &magistrala.ThingsAuthzReq{
+				ThingId:    thingID,
+				ThingPsk:   things.DerivePSK(thingID, clientKey),
+				ChannelId:  channelID,
+				Permission: policies.PublishPermission,
+			},
+			authorizeReq: things.AuthzReq{
+				ClientID:   thingID,
+				ClientPSK:  things.DerivePSK(thingID, clientKey),
+				ChannelID:  channelID,
+				Permission: policies.PublishPermission,
+			},
+			authorizeRes: thingID,
+			res:          &magistrala.ThingsAuthzRes{Authorized: true, Id: thingID},
+			err:          nil,
+		},
 		{
 			desc: "authorize with invalid key",
 			req: &magistrala.ThingsAuthzReq{
@@ -206,3 +234,275 @@ func TestAuthorize(t *testing.T) {
 		svcCall2.Unset()
 	}
 }
+
+func TestDerivePSK(t *testing.T) {
+	svc := new(mocks.Service)
+	startGRPCServer(svc, pskPort)
+	authAddr := fmt.Sprintf("localhost:%d", pskPort)
+	conn, _ := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	client := grpcapi.NewClient(conn, time.Second)
+
+	psk := things.DerivePSK(thingID, clientKey)
+
+	cases := []struct {
+		desc   string
+		req    *magistrala.ThingsPSKReq
+		res    *magistrala.ThingsPSKRes
+		svcRes []byte
+		svcErr error
+		err    error
+	}{
+		{
+			desc:   "derive pre-shared key successfully",
+			req:    &magistrala.ThingsPSKReq{ThingId: thingID},
+			res:    &magistrala.ThingsPSKRes{Psk: psk},
+			svcRes: psk,
+		},
+		{
+			desc:   "derive pre-shared key of disabled or non existing thing",
+			req:    &magistrala.ThingsPSKReq{ThingId: invalid},
+			res:    &magistrala.ThingsPSKRes{},
+			svcErr: svcerr.ErrAuthentication,
+			err:    svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("DerivePSK", mock.Anything, tc.req.GetThingId()).Return(tc.svcRes, tc.svcErr)
+		res, err := client.DerivePSK(context.Background(), tc.req)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res.GetPsk(), res.GetPsk(), fmt.Sprintf("%s: expected %x got %x", tc.desc, tc.res.GetPsk(), res.GetPsk()))
+		svcCall.Unset()
+	}
+}
+
+func TestConnectedChannels(t *testing.T) {
+	svc := new(mocks.Service)
+	startGRPCServer(svc, chsPort)
+	authAddr := fmt.Sprintf("localhost:%d", chsPort)
+	conn, _ := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	client := grpcapi.NewClient(conn, time.Second)
+
+	cases := []struct {
+		desc   string
+		req    *magistrala.ThingsChannelsReq
+		res    *magistrala.ThingsChannelsRes
+		svcRes []string
+		svcErr error
+		err    error
+	}{
+		{
+			desc:   "list connected channels by key successfully",
+			req:    &magistrala.ThingsChannelsReq{ThingKey: clientKey},
+			res:    &magistrala.ThingsChannelsRes{ChannelIds: []string{channelID}},
+			svcRes: []string{channelID},
+		},
+		{
+			desc:   "list connected channels by ID and pre-shared key successfully",
+			req:    &magistrala.ThingsChannelsReq{ThingId: thingID, ThingPsk: things.DerivePSK(thingID, clientKey)},
+			res:    &magistrala.ThingsChannelsRes{ChannelIds: []string{channelID}},
+			svcRes: []string{channelID},
+		},
+		{
+			desc:   "list connected channels with invalid key",
+			req:    &magistrala.ThingsChannelsReq{ThingKey: invalid},
+			res:    &magistrala.ThingsChannelsRes{},
+			svcErr: svcerr.ErrAuthentication,
+			err:    svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("ConnectedChannels", mock.Anything, tc.req.GetThingId(), tc.req.GetThingKey(), tc.req.GetThingPsk()).Return(tc.svcRes, tc.svcErr)
+		res, err := client.ConnectedChannels(context.Background(), tc.req)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res.GetChannelIds(), res.GetChannelIds(), fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res.GetChannelIds(), res.GetChannelIds()))
+		svcCall.Unset()
+	}
+}
+
+func TestChannelMetadata(t *testing.T) {
+	svc := new(mocks.Service)
+	startGRPCServer(svc, mdPort)
+	authAddr := fmt.Sprintf("localhost:%d", mdPort)
+	conn, _ := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	client := grpcapi.NewClient(conn, time.Second)
+
+	cases := []struct {
+		desc   string
+		req    *magistrala.ChannelMetadataReq
+		res    *magistrala.ChannelMetadataRes
+		svcRes map[string]interface{}
+		svcErr error
+		err    error
+	}{
+		{
+			desc:   "retrieve channel metadata successfully",
+			req:    &magistrala.ChannelMetadataReq{ChannelId: channelID},
+			res:    &magistrala.ChannelMetadataRes{Metadata: []byte(`{"retain":true}`)},
+			svcRes: map[string]interface{}{"retain": true},
+		},
+		{
+			desc:   "retrieve metadata of non existing channel",
+			req:    &magistrala.ChannelMetadataReq{ChannelId: invalid},
+			res:    &magistrala.ChannelMetadataRes{},
+			svcErr: svcerr.ErrNotFound,
+			err:    svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("ChannelMetadata", mock.Anything, tc.req.GetChannelId()).Return(tc.svcRes, tc.svcErr)
+		res, err := client.ChannelMetadata(context.Background(), tc.req)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res.GetMetadata(), res.GetMetadata(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.res.GetMetadata(), res.GetMetadata()))
+		svcCall.Unset()
+	}
+}
+
+func TestThingsConnections(t *testing.T) {
+	svc := new(mocks.Service)
+	startGRPCServer(svc, tcPort)
+	authAddr := fmt.Sprintf("localhost:%d", tcPort)
+	conn, _ := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	client := grpcapi.NewClient(conn, time.Second)
+
+	domainID := "testDomainID"
+
+	cases := []struct {
+		desc   string
+		req    *magistrala.ThingsConnectionsReq
+		conns  map[string][]string
+		svcRes map[string][]string
+		svcErr error
+		err    error
+	}{
+		{
+			desc:   "list things connections successfully",
+			req:    &magistrala.ThingsConnectionsReq{DomainId: domainID, ThingIds: []string{thingID, invalid}},
+			conns:  map[string][]string{thingID: {channelID}},
+			svcRes: map[string][]string{thingID: {channelID}},
+		},
+		{
+			desc:   "list things connections with failed to list connections",
+			req:    &magistrala.ThingsConnectionsReq{DomainId: domainID, ThingIds: []string{thingID}},
+			conns:  map[string][]string{},
+			svcErr: svcerr.ErrViewEntity,
+			err:    svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("ThingsConnections", mock.Anything, tc.req.GetDomainId(), tc.req.GetThingIds()).Return(tc.svcRes, tc.svcErr)
+		res, err := client.ThingsConnections(context.Background(), tc.req)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		conns := map[string][]string{}
+		for _, c := range res.GetConnections() {
+			conns[c.GetThingId()] = c.GetChannelIds()
+		}
+		assert.Equal(t, tc.conns, conns, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.conns, conns))
+		svcCall.Unset()
+	}
+}
+
+func TestCreateThing(t *testing.T) {
+	svc := new(mocks.Service)
+	startGRPCServer(svc, ctPort)
+	authAddr := fmt.Sprintf("localhost:%d", ctPort)
+	conn, _ := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	client := grpcapi.NewClient(conn, time.Second)
+
+	domainID := "testDomainID"
+	userID := "testUserID"
+	session := authn.Session{DomainUserID: domainID + "_" + userID, UserID: userID, DomainID: domainID}
+	thing := things.Client{
+		ID:       thingID,
+		Name:     "thing",
+		Metadata: things.Metadata{"model": "sensor"},
+		Status:   things.EnabledStatus,
+	}
+	saved := thing
+	saved.Credentials.Secret = clientKey
+
+	cases := []struct {
+		desc   string
+		req    *magistrala.CreateThingReq
+		res    *magistrala.CreateThingRes
+		svcRes []things.Client
+		svcErr error
+		err    error
+	}{
+		{
+			desc:   "create thing successfully",
+			req:    &magistrala.CreateThingReq{DomainId: domainID, UserId: userID, Id: thingID, Name: "thing", Metadata: []byte(`{"model":"sensor"}`)},
+			res:    &magistrala.CreateThingRes{Id: thingID, Key: clientKey},
+			svcRes: []things.Client{saved},
+		},
+		{
+			desc: "create thing with invalid metadata",
+			req:  &magistrala.CreateThingReq{DomainId: domainID, UserId: userID, Id: thingID, Name: "thing", Metadata: []byte("{")},
+			res:  &magistrala.CreateThingRes{},
+			err:  errors.ErrMalformedEntity,
+		},
+		{
+			desc:   "create thing without permission",
+			req:    &magistrala.CreateThingReq{DomainId: domainID, UserId: userID, Id: thingID, Name: "thing", Metadata: []byte(`{"model":"sensor"}`)},
+			res:    &magistrala.CreateThingRes{},
+			svcErr: svcerr.ErrAuthorization,
+			err:    svcerr.ErrAuthorization,
+		},
+		{
+			desc:   "create existing thing",
+			req:    &magistrala.CreateThingReq{DomainId: domainID, UserId: userID, Id: thingID, Name: "thing", Metadata: []byte(`{"model":"sensor"}`)},
+			res:    &magistrala.CreateThingRes{},
+			svcErr: errors.Wrap(svcerr.ErrCreateEntity, svcerr.ErrConflict),
+			err:    svcerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("CreateClients", mock.Anything, session, thing).Return(tc.svcRes, tc.svcErr)
+		res, err := client.CreateThing(context.Background(), tc.req)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res.GetId(), res.GetId(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.res.GetId(), res.GetId()))
+		assert.Equal(t, tc.res.GetKey(), res.GetKey(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.res.GetKey(), res.GetKey()))
+		svcCall.Unset()
+	}
+}
+
+func TestDeleteThing(t *testing.T) {
+	svc := new(mocks.Service)
+	startGRPCServer(svc, dtPort)
+	authAddr := fmt.Sprintf("localhost:%d", dtPort)
+	conn, _ := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	client := grpcapi.NewClient(conn, time.Second)
+
+	domainID := "testDomainID"
+	userID := "testUserID"
+	session := authn.Session{DomainUserID: domainID + "_" + userID, UserID: userID, DomainID: domainID}
+
+	cases := []struct {
+		desc   string
+		req    *magistrala.DeleteThingReq
+		svcErr error
+		err    error
+	}{
+		{
+			desc: "delete thing successfully",
+			req:  &magistrala.DeleteThingReq{DomainId: domainID, UserId: userID, Id: thingID},
+		},
+		{
+			desc:   "delete thing without permission",
+			req:    &magistrala.DeleteThingReq{DomainId: domainID, UserId: userID, Id: thingID},
+			svcErr: svcerr.ErrAuthorization,
+			err:    svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("Delete", mock.Anything, session, tc.req.GetId()).Return(tc.svcErr)
+		_, err := client.DeleteThing(context.Background(), tc.req)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		svcCall.Unset()
+	}
+}
@@ -6,6 +6,40 @@ package grpc
 type authorizeReq struct {
 	ThingID    string
 	ThingKey   string
+	ThingPSK   []byte
 	ChannelID  string
 	Permission string
 }
+
+type derivePSKReq struct {
+	ThingID string
+}
+
+type connectedChannelsReq struct {
+	ThingID  string
+	ThingKey string
+	ThingPSK []byte
+}
+
+type channelMetadataReq struct {
+	ChannelID string
+}
+
+type thingsConnectionsReq struct {
+	DomainID string
+	ThingIDs []string
+}
+
+type createThingReq struct {
+	DomainID string
+	UserID   string
+	ID       string
+	Name     string
+	Metadata []byte
+}
+
+type deleteThingReq struct {
+	DomainID string
+	UserID   string
+	ID       string
+}
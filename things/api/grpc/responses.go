@@ -7,3 +7,26 @@ type authorizeRes struct {
 	id         string
 	authorized bool
 }
+
+type derivePSKRes struct {
+	psk []byte
+}
+
+type connectedChannelsRes struct {
+	channelIDs []string
+}
+
+type channelMetadataRes struct {
+	metadata []byte
+}
+
+type thingsConnectionsRes struct {
+	connections map[string][]string
+}
+
+type createThingRes struct {
+	id  string
+	key string
+}
+
+type deleteThingRes struct{}
@@ -21,7 +21,13 @@ var _ magistrala.ThingsServiceServer = (*grpcServer)(nil)
 
 type grpcServer struct {
 	magistrala.UnimplementedThingsServiceServer
-	authorize kitgrpc.Handler
+	authorize         kitgrpc.Handler
+	derivePSK         kitgrpc.Handler
+	connectedChannels kitgrpc.Handler
+	channelMetadata   kitgrpc.Handler
+	thingsConnections kitgrpc.Handler
+	createThing       kitgrpc.Handler
+	deleteThing       kitgrpc.Handler
 }
 
 // NewServer returns new AuthServiceServer instance.
@@ -32,6 +38,36 @@ func NewServer(svc things.Service) magistrala.ThingsServiceServer {
 			decodeAuthorizeRequest,
 			encodeAuthorizeResponse,
 		),
+		derivePSK: kitgrpc.NewServer(
+			derivePSKEndpoint(svc),
+			decodeDerivePSKRequest,
+			encodeDerivePSKResponse,
+		),
+		connectedChannels: kitgrpc.NewServer(
+			connectedChannelsEndpoint(svc),
+			decodeConnectedChannelsRequest,
+			encodeConnectedChannelsResponse,
+		),
+		channelMetadata: kitgrpc.NewServer(
+			channelMetadataEndpoint(svc),
+			decodeChannelMetadataRequest,
+			encodeChannelMetadataResponse,
+		),
+		thingsConnections: kitgrpc.NewServer(
+			thingsConnectionsEndpoint(svc),
+			decodeThingsConnectionsRequest,
+			encodeThingsConnectionsResponse,
+		),
+		createThing: kitgrpc.NewServer(
+			createThingEndpoint(svc),
+			decodeCreateThingRequest,
+			encodeCreateThingResponse,
+		),
+		deleteThing: kitgrpc.NewServer(
+			deleteThingEndpoint(svc),
+			decodeDeleteThingRequest,
+			encodeDeleteThingResponse,
+		),
 	}
 }
 
@@ -43,11 +79,60 @@ func (s *grpcServer) Authorize(ctx context.Context, req *magistrala.ThingsAuthzR
 	return res.(*magistrala.ThingsAuthzRes), nil
 }
 
+func (s *grpcServer) DerivePSK(ctx context.Context, req *magistrala.ThingsPSKReq) (*magistrala.ThingsPSKRes, error) {
+	_, res, err := s.derivePSK.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*magistrala.ThingsPSKRes), nil
+}
+
+func (s *grpcServer) ConnectedChannels(ctx context.Context, req *magistrala.ThingsChannelsReq) (*magistrala.ThingsChannelsRes, error) {
+	_, res, err := s.connectedChannels.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*magistrala.ThingsChannelsRes), nil
+}
+
+func (s *grpcServer) ChannelMetadata(ctx context.Context, req *magistrala.ChannelMetadataReq) (*magistrala.ChannelMetadataRes, error) {
+	_, res, err := s.channelMetadata.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*magistrala.ChannelMetadataRes), nil
+}
+
+func (s *grpcServer) ThingsConnections(ctx context.Context, req *magistrala.ThingsConnectionsReq) (*magistrala.ThingsConnectionsRes, error) {
+	_, res, err := s.thingsConnections.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*magistrala.ThingsConnectionsRes), nil
+}
+
+func (s *grpcServer) CreateThing(ctx context.Context, req *magistrala.CreateThingReq) (*magistrala.CreateThingRes, error) {
+	_, res, err := s.createThing.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*magistrala.CreateThingRes), nil
+}
+
+func (s *grpcServer) DeleteThing(ctx context.Context, req *magistrala.DeleteThingReq) (*magistrala.DeleteThingRes, error) {
+	_, res, err := s.deleteThing.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*magistrala.DeleteThingRes), nil
+}
+
 func decodeAuthorizeRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*magistrala.ThingsAuthzReq)
 	return authorizeReq{
 		ThingID:    req.GetThingId(),
 		ThingKey:   req.GetThingKey(),
+		ThingPSK:   req.GetThingPsk(),
 		ChannelID:  req.GetChannelId(),
 		Permission: req.GetPermission(),
 	}, nil
@@ -58,6 +143,75 @@ func encodeAuthorizeResponse(_ context.Context, grpcRes interface{}) (interface{
 	return &magistrala.ThingsAuthzRes{Authorized: res.authorized, Id: res.id}, nil
 }
 
+func decodeDerivePSKRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*magistrala.ThingsPSKReq)
+	return derivePSKReq{ThingID: req.GetThingId()}, nil
+}
+
+func encodeDerivePSKResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(derivePSKRes)
+	return &magistrala.ThingsPSKRes{Psk: res.psk}, nil
+}
+
+func decodeConnectedChannelsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*magistrala.ThingsChannelsReq)
+	return connectedChannelsReq{ThingID: req.GetThingId(), ThingKey: req.GetThingKey(), ThingPSK: req.GetThingPsk()}, nil
+}
+
+func encodeConnectedChannelsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(connectedChannelsRes)
+	return &magistrala.ThingsChannelsRes{ChannelIds: res.channelIDs}, nil
+}
+
+func decodeChannelMetadataRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*magistrala.ChannelMetadataReq)
+	return channelMetadataReq{ChannelID: req.GetChannelId()}, nil
+}
+
+func encodeChannelMetadataResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(channelMetadataRes)
+	return &magistrala.ChannelMetadataRes{Metadata: res.metadata}, nil
+}
+
+func decodeThingsConnectionsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*magistrala.ThingsConnectionsReq)
+	return thingsConnectionsReq{DomainID: req.GetDomainId(), ThingIDs: req.GetThingIds()}, nil
+}
+
+func encodeThingsConnectionsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(thingsConnectionsRes)
+	conns := make([]*magistrala.ThingConnections, 0, len(res.connections))
+	for thingID, chids := range res.connections {
+		conns = append(conns, &magistrala.ThingConnections{ThingId: thingID, ChannelIds: chids})
+	}
+	return &magistrala.ThingsConnectionsRes{Connections: conns}, nil
+}
+
+func decodeCreateThingRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*magistrala.CreateThingReq)
+	return createThingReq{
+		DomainID: req.GetDomainId(),
+		UserID:   req.GetUserId(),
+		ID:       req.GetId(),
+		Name:     req.GetName(),
+		Metadata: req.GetMetadata(),
+	}, nil
+}
+
+func encodeCreateThingResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(createThingRes)
+	return &magistrala.CreateThingRes{Id: res.id, Key: res.key}, nil
+}
+
+func decodeDeleteThingRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*magistrala.DeleteThingReq)
+	return deleteThingReq{DomainID: req.GetDomainId(), UserID: req.GetUserId(), ID: req.GetId()}, nil
+}
+
+func encodeDeleteThingResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return &magistrala.DeleteThingRes{}, nil
+}
+
 func encodeError(err error) error {
 	switch {
 	case errors.Contains(err, nil):
@@ -77,6 +231,10 @@ func encodeError(err error) error {
 		return status.Error(codes.Unauthenticated, err.Error())
 	case errors.Contains(err, svcerr.ErrAuthorization):
 		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Contains(err, svcerr.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Contains(err, svcerr.ErrConflict):
+		return status.Error(codes.AlreadyExists, err.Error())
 	default:
 		return status.Error(codes.Internal, err.Error())
 	}
@@ -7,8 +7,10 @@ import (
 	"context"
 	"encoding/json"
 	"log/slog"
+	"math"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/absmach/magistrala/internal/api"
 	"github.com/absmach/magistrala/pkg/apiutil"
@@ -20,6 +22,8 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
+const lastSeenBeforeKey = "last_seen_before"
+
 func clientsHandler(svc things.Service, r *chi.Mux, authn mgauthn.Authentication, logger *slog.Logger) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
@@ -196,21 +200,43 @@ func decodeListClients(_ context.Context, r *http.Request) (interface{}, error)
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
+	lsb, err := apiutil.ReadNumQuery[int64](r, lastSeenBeforeKey, 0)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	if lsb < 0 || lsb > math.MaxInt32 {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrInvalidQueryParams)
+	}
+	var lastSeenBefore time.Time
+	if lsb != 0 {
+		lastSeenBefore = time.Unix(lsb, 0)
+	}
+
+	// Online and offline statuses filter enabled clients by presence.
+	var online *bool
+	switch s {
+	case things.Online, things.Offline:
+		o := s == things.Online
+		online = &o
+		s = things.Enabled
+	}
 	st, err := things.ToStatus(s)
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
 	req := listClientsReq{
-		status:     st,
-		offset:     o,
-		limit:      l,
-		metadata:   m,
-		name:       n,
-		tag:        t,
-		permission: p,
-		listPerms:  lp,
-		userID:     chi.URLParam(r, "userID"),
-		id:         id,
+		status:         st,
+		online:         online,
+		lastSeenBefore: lastSeenBefore,
+		offset:         o,
+		limit:          l,
+		metadata:       m,
+		name:           n,
+		tag:            t,
+		permission:     p,
+		listPerms:      lp,
+		userID:         chi.URLParam(r, "userID"),
+		id:             id,
 	}
 	return req, nil
 }
@@ -127,15 +127,17 @@ func listClientsEndpoint(svc things.Service) endpoint.Endpoint {
 		}
 
 		pm := things.Page{
-			Status:     req.status,
-			Offset:     req.offset,
-			Limit:      req.limit,
-			Name:       req.name,
-			Tag:        req.tag,
-			Permission: req.permission,
-			Metadata:   req.metadata,
-			ListPerms:  req.listPerms,
-			Id:         req.id,
+			Status:         req.status,
+			Online:         req.online,
+			LastSeenBefore: req.lastSeenBefore,
+			Offset:         req.offset,
+			Limit:          req.limit,
+			Name:           req.name,
+			Tag:            req.tag,
+			Permission:     req.permission,
+			Metadata:       req.metadata,
+			ListPerms:      req.listPerms,
+			Id:             req.id,
 		}
 		page, err := svc.ListClients(ctx, session, req.userID, pm)
 		if err != nil {
@@ -499,6 +499,69 @@ func TestListThings(t *testing.T) {
 			status:   http.StatusBadRequest,
 			err:      apiutil.ErrValidation,
 		},
+		{
+			desc:     "list things with online status",
+			domainID: domainID,
+			token:    validToken,
+			authnRes: mgauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: domainID + "_" + validID, SuperAdmin: false},
+			listThingsResponse: things.ClientsPage{
+				Page: things.Page{
+					Total: 1,
+				},
+				Clients: []things.Client{client},
+			},
+			query:  "status=online",
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:     "list things with offline status",
+			domainID: domainID,
+			token:    validToken,
+			authnRes: mgauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: domainID + "_" + validID, SuperAdmin: false},
+			listThingsResponse: things.ClientsPage{
+				Page: things.Page{
+					Total: 1,
+				},
+				Clients: []things.Client{client},
+			},
+			query:  "status=offline",
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:     "list things with last seen before",
+			domainID: domainID,
+			token:    validToken,
+			authnRes: mgauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: domainID + "_" + validID, SuperAdmin: false},
+			listThingsResponse: things.ClientsPage{
+				Page: things.Page{
+					Total: 1,
+				},
+				Clients: []things.Client{client},
+			},
+			query:  "last_seen_before=1700000000",
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:     "list things with invalid last seen before",
+			domainID: domainID,
+			token:    validToken,
+			authnRes: mgauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: domainID + "_" + validID, SuperAdmin: false},
+			query:    "last_seen_before=invalid",
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrValidation,
+		},
+		{
+			desc:     "list things with negative last seen before",
+			domainID: domainID,
+			token:    validToken,
+			authnRes: mgauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: domainID + "_" + validID, SuperAdmin: false},
+			query:    "last_seen_before=-1",
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrValidation,
+		},
 		{
 			desc:     "list things with limit",
 			domainID: domainID,
@@ -1073,7 +1136,7 @@ func TestUpdateThing(t *testing.T) {
 			}
 
 			if err == nil {
-				assert.Equal(t, tc.clientResponse.ID, resBody.ID, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.clientResponse, resBody.ID))
+				assert.Equal(t, tc.clientResponse.ID, resBody.ID, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.clientResponse.ID, resBody.ID))
 			}
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
@@ -4,6 +4,8 @@
 package http
 
 import (
+	"time"
+
 	"github.com/absmach/magistrala/internal/api"
 	"github.com/absmach/magistrala/pkg/apiutil"
 	"github.com/absmach/magistrala/things"
@@ -71,17 +73,19 @@ func (req viewClientPermsReq) validate() error {
 }
 
 type listClientsReq struct {
-	status     things.Status
-	offset     uint64
-	limit      uint64
-	name       string
-	tag        string
-	permission string
-	visibility string
-	userID     string
-	listPerms  bool
-	metadata   things.Metadata
-	id         string
+	status         things.Status
+	online         *bool
+	lastSeenBefore time.Time
+	offset         uint64
+	limit          uint64
+	name           string
+	tag            string
+	permission     string
+	visibility     string
+	userID         string
+	listPerms      bool
+	metadata       things.Metadata
+	id             string
 }
 
 func (req listClientsReq) validate() error {
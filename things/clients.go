@@ -5,16 +5,33 @@ package things
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"time"
 
 	"github.com/absmach/magistrala/pkg/authn"
 	"github.com/absmach/magistrala/pkg/postgres"
 )
 
+// pskLabel binds the derived pre-shared keys to the DTLS authentication, so
+// they can not be used in place of the client key.
+const pskLabel = "magistrala-dtls-psk"
+
+// DerivePSK returns the DTLS pre-shared key of the client, which is the
+// HMAC-SHA256 of the PSK label followed by the client ID, keyed with the
+// client key.
+func DerivePSK(id, key string) []byte {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(pskLabel))
+	mac.Write([]byte(id))
+	return mac.Sum(nil)
+}
+
 type AuthzReq struct {
 	ChannelID  string
 	ClientID   string
 	ClientKey  string
+	ClientPSK  []byte
 	Permission string
 }
 
@@ -63,6 +80,17 @@ type Repository interface {
 
 	// RetrieveBySecret retrieves a client based on the secret (key).
 	RetrieveBySecret(ctx context.Context, key string) (Client, error)
+
+	// UpdatePresence updates the state of the client connection, the client
+	// last seen time and protocol. The client is online while it has any
+	// connection which is not expired. It returns whether the client online
+	// state has changed.
+	UpdatePresence(ctx context.Context, presence Presence) (bool, error)
+
+	// ExpirePresence removes the connections which expired before the given
+	// time and marks the clients without other connections offline. It
+	// returns the presence of the clients which went offline.
+	ExpirePresence(ctx context.Context, now time.Time) ([]Presence, error)
 }
 
 // Service specifies an API that must be fullfiled by the domain service
@@ -115,8 +143,35 @@ type Service interface {
 	// Authorize used for Clients authorization.
 	Authorize(ctx context.Context, req AuthzReq) (string, error)
 
+	// DerivePSK returns the DTLS pre-shared key of the enabled client with
+	// the given ID. The pre-shared key is derived from the client key, so
+	// the key itself is not disclosed.
+	DerivePSK(ctx context.Context, id string) ([]byte, error)
+
+	// ConnectedChannels returns the IDs of the channels the client is
+	// connected to. The client is identified by its key, or by its ID and
+	// pre-shared key when the key is empty.
+	ConnectedChannels(ctx context.Context, id, key string, psk []byte) ([]string, error)
+
+	// ChannelMetadata returns the metadata of the channel with the given ID.
+	ChannelMetadata(ctx context.Context, id string) (map[string]interface{}, error)
+
+	// ThingsConnections returns the IDs of the channels each of the clients
+	// with the given IDs is connected to. Clients which do not belong to the
+	// domain are omitted.
+	ThingsConnections(ctx context.Context, domainID string, ids []string) (map[string][]string, error)
+
 	// Delete deletes client with given ID.
 	Delete(ctx context.Context, session authn.Session, id string) error
+
+	// UpdatePresence updates the client presence reported by the protocol
+	// adapters. It returns whether the client online state has changed.
+	UpdatePresence(ctx context.Context, presence Presence) (bool, error)
+
+	// ExpirePresence closes the client connections which were not reported
+	// by the protocol adapters within the presence TTL. It returns the
+	// presence of the clients which went offline.
+	ExpirePresence(ctx context.Context) ([]Presence, error)
 }
 
 // Cache contains client caching interface.
@@ -148,6 +203,27 @@ type Client struct {
 	Status      Status      `json:"status,omitempty"` // 1 for enabled, 0 for disabled
 	Permissions []string    `json:"permissions,omitempty"`
 	Identity    string      `json:"identity,omitempty"`
+	Online      bool        `json:"online"`
+	LastSeen    time.Time   `json:"last_seen,omitempty"`
+	Protocol    string      `json:"protocol,omitempty"`
+}
+
+// Presence represents the client activity reported by the protocol adapters.
+type Presence struct {
+	ClientID string
+	// Connection identifies the client connection to the adapter instance.
+	// It is empty for the activity which doesn't belong to a connection,
+	// e.g. a message published over HTTP.
+	Connection string
+	Instance   string
+	// Online is nil if the activity does not open or close the connection,
+	// e.g. a published message.
+	Online   *bool
+	LastSeen time.Time
+	// ExpiresAt is the time the open connection is considered closed
+	// unless the adapter reports it again.
+	ExpiresAt time.Time
+	Protocol  string
 }
 
 // ClientsPage contains page related metadata as well as list.
@@ -167,21 +243,23 @@ type MembersPage struct {
 // Page contains the page metadata that helps navigation.
 
 type Page struct {
-	Total      uint64   `json:"total"`
-	Offset     uint64   `json:"offset"`
-	Limit      uint64   `json:"limit"`
-	Name       string   `json:"name,omitempty"`
-	Id         string   `json:"id,omitempty"`
-	Order      string   `json:"order,omitempty"`
-	Dir        string   `json:"dir,omitempty"`
-	Metadata   Metadata `json:"metadata,omitempty"`
-	Domain     string   `json:"domain,omitempty"`
-	Tag        string   `json:"tag,omitempty"`
-	Permission string   `json:"permission,omitempty"`
-	Status     Status   `json:"status,omitempty"`
-	IDs        []string `json:"ids,omitempty"`
-	Identity   string   `json:"identity,omitempty"`
-	ListPerms  bool     `json:"-"`
+	Total          uint64    `json:"total"`
+	Offset         uint64    `json:"offset"`
+	Limit          uint64    `json:"limit"`
+	Name           string    `json:"name,omitempty"`
+	Id             string    `json:"id,omitempty"`
+	Order          string    `json:"order,omitempty"`
+	Dir            string    `json:"dir,omitempty"`
+	Metadata       Metadata  `json:"metadata,omitempty"`
+	Domain         string    `json:"domain,omitempty"`
+	Tag            string    `json:"tag,omitempty"`
+	Permission     string    `json:"permission,omitempty"`
+	Status         Status    `json:"status,omitempty"`
+	Online         *bool     `json:"online,omitempty"`
+	LastSeenBefore time.Time `json:"last_seen_before,omitempty"`
+	IDs            []string  `json:"ids,omitempty"`
+	Identity       string    `json:"identity,omitempty"`
+	ListPerms      bool      `json:"-"`
 }
 
 // Metadata represents arbitrary JSON.
@@ -0,0 +1,15 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package consumer
+
+import "time"
+
+type presenceEvent struct {
+	thingID    string
+	connID     string
+	instance   string
+	operation  string
+	protocol   string
+	occurredAt time.Time
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package consumer
+
+import (
+	"context"
+	"time"
+
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/presence"
+	"github.com/absmach/magistrala/things"
+)
+
+type eventHandler struct {
+	svc things.Service
+}
+
+// NewEventHandler returns new event store handler.
+func NewEventHandler(svc things.Service) events.EventHandler {
+	return &eventHandler{
+		svc: svc,
+	}
+}
+
+func (es *eventHandler) Handle(ctx context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	pe, err := decodePresence(msg)
+	if err != nil {
+		return err
+	}
+
+	p := things.Presence{
+		ClientID:   pe.thingID,
+		Connection: pe.connID,
+		Instance:   pe.instance,
+		LastSeen:   pe.occurredAt,
+		Protocol:   pe.protocol,
+	}
+	switch pe.operation {
+	case presence.ConnectOp, presence.HeartbeatOp:
+		online := true
+		p.Online = &online
+	case presence.DisconnectOp:
+		online := false
+		p.Online = &online
+	case presence.PublishOp:
+	default:
+		return nil
+	}
+
+	if _, err := es.svc.UpdatePresence(ctx, p); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func decodePresence(event map[string]interface{}) (presenceEvent, error) {
+	pe := presenceEvent{
+		thingID:   events.Read(event, "thing_id", ""),
+		connID:    events.Read(event, "connection", ""),
+		instance:  events.Read(event, "instance", ""),
+		operation: events.Read(event, "operation", ""),
+		protocol:  events.Read(event, "protocol", ""),
+	}
+	if pe.thingID == "" {
+		return presenceEvent{}, svcerr.ErrMalformedEntity
+	}
+
+	occurredAt, err := time.Parse(time.RFC3339Nano, events.Read(event, "occurred_at", ""))
+	if err != nil {
+		return presenceEvent{}, svcerr.ErrMalformedEntity
+	}
+	pe.occurredAt = occurredAt
+
+	return pe, nil
+}
@@ -22,6 +22,7 @@ const (
 	clientListByGroup  = clientPrefix + "list_by_channel"
 	clientIdentify     = clientPrefix + "identify"
 	clientAuthorize    = clientPrefix + "authorize"
+	clientPresence     = clientPrefix + "change_presence"
 )
 
 var (
@@ -36,6 +37,7 @@ var (
 	_ events.Event = (*authorizeClientEvent)(nil)
 	_ events.Event = (*shareClientEvent)(nil)
 	_ events.Event = (*removeClientEvent)(nil)
+	_ events.Event = (*presenceClientEvent)(nil)
 )
 
 type createClientEvent struct {
@@ -334,3 +336,23 @@ func (dce removeClientEvent) Encode() (map[string]interface{}, error) {
 		"id":        dce.id,
 	}, nil
 }
+
+type presenceClientEvent struct {
+	things.Presence
+}
+
+func (pce presenceClientEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation": clientPresence,
+		"id":        pce.ClientID,
+		"last_seen": pce.LastSeen,
+	}
+	if pce.Online != nil {
+		val["online"] = *pce.Online
+	}
+	if pce.Protocol != "" {
+		val["protocol"] = pce.Protocol
+	}
+
+	return val, nil
+}
@@ -0,0 +1,166 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/events/outbox"
+	"github.com/absmach/magistrala/pkg/postgres"
+	"github.com/absmach/magistrala/things"
+)
+
+var _ things.Repository = (*repository)(nil)
+
+// repository writes the events of the thing changes to the outbox in the
+// same transaction as the change itself. Only the repository write and the
+// outbox insert are part of the transaction, so policies and other remote
+// calls made by the service are never run while the transaction is open.
+type repository struct {
+	things.Repository
+	db     postgres.Database
+	outbox events.Publisher
+}
+
+// NewRepositoryMiddleware returns wrapper around things repository which
+// writes the events of the thing changes to the outbox.
+func NewRepositoryMiddleware(repo things.Repository, db postgres.Database, idp magistrala.IDProvider) things.Repository {
+	return &repository{
+		Repository: repo,
+		db:         db,
+		outbox:     outbox.NewPublisher(db, idp, streamID),
+	}
+}
+
+func (repo *repository) Save(ctx context.Context, th ...things.Client) ([]things.Client, error) {
+	var sths []things.Client
+	err := postgres.Transaction(ctx, repo.db, func(ctx context.Context) error {
+		var err error
+		if sths, err = repo.Repository.Save(ctx, th...); err != nil {
+			return err
+		}
+
+		for _, th := range sths {
+			event := createClientEvent{
+				th,
+			}
+			if err := repo.outbox.Publish(ctx, event); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return sths, err
+}
+
+func (repo *repository) Update(ctx context.Context, thing things.Client) (things.Client, error) {
+	return repo.update(ctx, "", func(ctx context.Context) (things.Client, error) {
+		return repo.Repository.Update(ctx, thing)
+	})
+}
+
+func (repo *repository) UpdateTags(ctx context.Context, thing things.Client) (things.Client, error) {
+	return repo.update(ctx, "tags", func(ctx context.Context) (things.Client, error) {
+		return repo.Repository.UpdateTags(ctx, thing)
+	})
+}
+
+func (repo *repository) UpdateSecret(ctx context.Context, thing things.Client) (things.Client, error) {
+	return repo.update(ctx, "secret", func(ctx context.Context) (things.Client, error) {
+		return repo.Repository.UpdateSecret(ctx, thing)
+	})
+}
+
+func (repo *repository) update(ctx context.Context, operation string, fn func(ctx context.Context) (things.Client, error)) (things.Client, error) {
+	var thing things.Client
+	err := postgres.Transaction(ctx, repo.db, func(ctx context.Context) error {
+		var err error
+		if thing, err = fn(ctx); err != nil {
+			return err
+		}
+
+		event := updateClientEvent{
+			thing, operation,
+		}
+
+		return repo.outbox.Publish(ctx, event)
+	})
+
+	return thing, err
+}
+
+func (repo *repository) ChangeStatus(ctx context.Context, thing things.Client) (things.Client, error) {
+	var thi things.Client
+	err := postgres.Transaction(ctx, repo.db, func(ctx context.Context) error {
+		var err error
+		if thi, err = repo.Repository.ChangeStatus(ctx, thing); err != nil {
+			return err
+		}
+
+		event := changeStatusClientEvent{
+			id:        thi.ID,
+			updatedAt: thi.UpdatedAt,
+			updatedBy: thi.UpdatedBy,
+			status:    thi.Status.String(),
+		}
+
+		return repo.outbox.Publish(ctx, event)
+	})
+
+	return thi, err
+}
+
+func (repo *repository) Delete(ctx context.Context, id string) error {
+	return postgres.Transaction(ctx, repo.db, func(ctx context.Context) error {
+		if err := repo.Repository.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		return repo.outbox.Publish(ctx, removeClientEvent{id})
+	})
+}
+
+// UpdatePresence writes the presence event only if the thing online state
+// has changed, since the last seen time is updated on every thing activity.
+func (repo *repository) UpdatePresence(ctx context.Context, presence things.Presence) (bool, error) {
+	var changed bool
+	err := postgres.Transaction(ctx, repo.db, func(ctx context.Context) error {
+		var err error
+		changed, err = repo.Repository.UpdatePresence(ctx, presence)
+		if err != nil || !changed {
+			return err
+		}
+
+		return repo.outbox.Publish(ctx, presenceClientEvent{presence})
+	})
+
+	return changed, err
+}
+
+// ExpirePresence writes the presence events of the clients which went
+// offline.
+func (repo *repository) ExpirePresence(ctx context.Context, now time.Time) ([]things.Presence, error) {
+	var expired []things.Presence
+	err := postgres.Transaction(ctx, repo.db, func(ctx context.Context) error {
+		var err error
+		if expired, err = repo.Repository.ExpirePresence(ctx, now); err != nil {
+			return err
+		}
+
+		for _, presence := range expired {
+			if err := repo.outbox.Publish(ctx, presenceClientEvent{presence}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return expired, err
+}
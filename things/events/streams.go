@@ -6,9 +6,12 @@ package events
 import (
 	"context"
 
+	"github.com/absmach/magistrala"
 	"github.com/absmach/magistrala/pkg/authn"
 	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/events/outbox"
 	"github.com/absmach/magistrala/pkg/events/store"
+	"github.com/absmach/magistrala/pkg/postgres"
 	"github.com/absmach/magistrala/things"
 )
 
@@ -16,14 +19,21 @@ const streamID = "magistrala.things"
 
 var _ things.Service = (*eventStore)(nil)
 
+// eventStore publishes the events of the operations which don't change
+// things to the event store directly, since losing them doesn't leave the
+// event consumers out of sync. The events of the thing changes are written
+// to the outbox by the repository returned by NewRepositoryMiddleware, and
+// the events of the sharing changes, which are stored only as policies, are
+// written to the outbox by the event store.
 type eventStore struct {
 	events.Publisher
-	svc things.Service
+	outbox events.Publisher
+	svc    things.Service
 }
 
 // NewEventStoreMiddleware returns wrapper around things service that sends
 // events to event store.
-func NewEventStoreMiddleware(ctx context.Context, svc things.Service, url string) (things.Service, error) {
+func NewEventStoreMiddleware(ctx context.Context, svc things.Service, db postgres.Database, idp magistrala.IDProvider, url string) (things.Service, error) {
 	publisher, err := store.NewPublisher(ctx, url, streamID)
 	if err != nil {
 		return nil, err
@@ -32,64 +42,24 @@ func NewEventStoreMiddleware(ctx context.Context, svc things.Service, url string
 	return &eventStore{
 		svc:       svc,
 		Publisher: publisher,
+		outbox:    outbox.NewPublisher(db, idp, streamID),
 	}, nil
 }
 
 func (es *eventStore) CreateClients(ctx context.Context, session authn.Session, thing ...things.Client) ([]things.Client, error) {
-	sths, err := es.svc.CreateClients(ctx, session, thing...)
-	if err != nil {
-		return sths, err
-	}
-
-	for _, th := range sths {
-		event := createClientEvent{
-			th,
-		}
-		if err := es.Publish(ctx, event); err != nil {
-			return sths, err
-		}
-	}
-
-	return sths, nil
+	return es.svc.CreateClients(ctx, session, thing...)
 }
 
 func (es *eventStore) Update(ctx context.Context, session authn.Session, thing things.Client) (things.Client, error) {
-	cli, err := es.svc.Update(ctx, session, thing)
-	if err != nil {
-		return cli, err
-	}
-
-	return es.update(ctx, "", cli)
+	return es.svc.Update(ctx, session, thing)
 }
 
 func (es *eventStore) UpdateTags(ctx context.Context, session authn.Session, thing things.Client) (things.Client, error) {
-	cli, err := es.svc.UpdateTags(ctx, session, thing)
-	if err != nil {
-		return cli, err
-	}
-
-	return es.update(ctx, "tags", cli)
+	return es.svc.UpdateTags(ctx, session, thing)
 }
 
 func (es *eventStore) UpdateSecret(ctx context.Context, session authn.Session, id, key string) (things.Client, error) {
-	cli, err := es.svc.UpdateSecret(ctx, session, id, key)
-	if err != nil {
-		return cli, err
-	}
-
-	return es.update(ctx, "secret", cli)
-}
-
-func (es *eventStore) update(ctx context.Context, operation string, thing things.Client) (things.Client, error) {
-	event := updateClientEvent{
-		thing, operation,
-	}
-
-	if err := es.Publish(ctx, event); err != nil {
-		return thing, err
-	}
-
-	return thing, nil
+	return es.svc.UpdateSecret(ctx, session, id, key)
 }
 
 func (es *eventStore) View(ctx context.Context, session authn.Session, id string) (things.Client, error) {
@@ -156,35 +126,11 @@ func (es *eventStore) ListClientsByGroup(ctx context.Context, session authn.Sess
 }
 
 func (es *eventStore) Enable(ctx context.Context, session authn.Session, id string) (things.Client, error) {
-	thi, err := es.svc.Enable(ctx, session, id)
-	if err != nil {
-		return thi, err
-	}
-
-	return es.changeStatus(ctx, thi)
+	return es.svc.Enable(ctx, session, id)
 }
 
 func (es *eventStore) Disable(ctx context.Context, session authn.Session, id string) (things.Client, error) {
-	thi, err := es.svc.Disable(ctx, session, id)
-	if err != nil {
-		return thi, err
-	}
-
-	return es.changeStatus(ctx, thi)
-}
-
-func (es *eventStore) changeStatus(ctx context.Context, thi things.Client) (things.Client, error) {
-	event := changeStatusClientEvent{
-		id:        thi.ID,
-		updatedAt: thi.UpdatedAt,
-		updatedBy: thi.UpdatedBy,
-		status:    thi.Status.String(),
-	}
-	if err := es.Publish(ctx, event); err != nil {
-		return thi, err
-	}
-
-	return thi, nil
+	return es.svc.Disable(ctx, session, id)
 }
 
 func (es *eventStore) Identify(ctx context.Context, key string) (string, error) {
@@ -221,6 +167,22 @@ func (es *eventStore) Authorize(ctx context.Context, req things.AuthzReq) (strin
 	return thingID, nil
 }
 
+func (es *eventStore) DerivePSK(ctx context.Context, id string) ([]byte, error) {
+	return es.svc.DerivePSK(ctx, id)
+}
+
+func (es *eventStore) ConnectedChannels(ctx context.Context, id, key string, psk []byte) ([]string, error) {
+	return es.svc.ConnectedChannels(ctx, id, key, psk)
+}
+
+func (es *eventStore) ChannelMetadata(ctx context.Context, id string) (map[string]interface{}, error) {
+	return es.svc.ChannelMetadata(ctx, id)
+}
+
+func (es *eventStore) ThingsConnections(ctx context.Context, domainID string, ids []string) (map[string][]string, error) {
+	return es.svc.ThingsConnections(ctx, domainID, ids)
+}
+
 func (es *eventStore) Share(ctx context.Context, session authn.Session, id, relation string, userids ...string) error {
 	if err := es.svc.Share(ctx, session, id, relation, userids...); err != nil {
 		return err
@@ -233,7 +195,7 @@ func (es *eventStore) Share(ctx context.Context, session authn.Session, id, rela
 		userIDs:  userids,
 	}
 
-	return es.Publish(ctx, event)
+	return es.outbox.Publish(ctx, event)
 }
 
 func (es *eventStore) Unshare(ctx context.Context, session authn.Session, id, relation string, userids ...string) error {
@@ -248,19 +210,17 @@ func (es *eventStore) Unshare(ctx context.Context, session authn.Session, id, re
 		userIDs:  userids,
 	}
 
-	return es.Publish(ctx, event)
+	return es.outbox.Publish(ctx, event)
 }
 
 func (es *eventStore) Delete(ctx context.Context, session authn.Session, id string) error {
-	if err := es.svc.Delete(ctx, session, id); err != nil {
-		return err
-	}
-
-	event := removeClientEvent{id}
+	return es.svc.Delete(ctx, session, id)
+}
 
-	if err := es.Publish(ctx, event); err != nil {
-		return err
-	}
+func (es *eventStore) UpdatePresence(ctx context.Context, presence things.Presence) (bool, error) {
+	return es.svc.UpdatePresence(ctx, presence)
+}
 
-	return nil
+func (es *eventStore) ExpirePresence(ctx context.Context) ([]things.Presence, error) {
+	return es.svc.ExpirePresence(ctx)
 }
@@ -162,6 +162,22 @@ func (am *authorizationMiddleware) Authorize(ctx context.Context, req things.Aut
 	return am.svc.Authorize(ctx, req)
 }
 
+func (am *authorizationMiddleware) DerivePSK(ctx context.Context, id string) ([]byte, error) {
+	return am.svc.DerivePSK(ctx, id)
+}
+
+func (am *authorizationMiddleware) ConnectedChannels(ctx context.Context, id, key string, psk []byte) ([]string, error) {
+	return am.svc.ConnectedChannels(ctx, id, key, psk)
+}
+
+func (am *authorizationMiddleware) ChannelMetadata(ctx context.Context, id string) (map[string]interface{}, error) {
+	return am.svc.ChannelMetadata(ctx, id)
+}
+
+func (am *authorizationMiddleware) ThingsConnections(ctx context.Context, domainID string, ids []string) (map[string][]string, error) {
+	return am.svc.ThingsConnections(ctx, domainID, ids)
+}
+
 func (am *authorizationMiddleware) Delete(ctx context.Context, session authn.Session, id string) error {
 	if err := am.authorize(ctx, session.DomainID, policies.UserType, policies.UsersKind, session.DomainUserID, policies.DeletePermission, policies.ThingType, id); err != nil {
 		return err
@@ -198,3 +214,11 @@ func (am *authorizationMiddleware) authorize(ctx context.Context, domain, subjTy
 	}
 	return nil
 }
+
+func (am *authorizationMiddleware) UpdatePresence(ctx context.Context, presence things.Presence) (bool, error) {
+	return am.svc.UpdatePresence(ctx, presence)
+}
+
+func (am *authorizationMiddleware) ExpirePresence(ctx context.Context) ([]things.Presence, error) {
+	return am.svc.ExpirePresence(ctx)
+}
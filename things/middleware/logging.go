@@ -248,6 +248,73 @@ func (lm *loggingMiddleware) Authorize(ctx context.Context, req things.AuthzReq)
 	return lm.svc.Authorize(ctx, req)
 }
 
+func (lm *loggingMiddleware) DerivePSK(ctx context.Context, id string) (psk []byte, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("thing_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Derive thing pre-shared key failed", args...)
+			return
+		}
+		lm.logger.Info("Derive thing pre-shared key completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DerivePSK(ctx, id)
+}
+
+func (lm *loggingMiddleware) ConnectedChannels(ctx context.Context, id, key string, psk []byte) (chids []string, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("thing_id", id),
+			slog.Int("channels", len(chids)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List connected channels failed", args...)
+			return
+		}
+		lm.logger.Info("List connected channels completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ConnectedChannels(ctx, id, key, psk)
+}
+
+func (lm *loggingMiddleware) ChannelMetadata(ctx context.Context, id string) (metadata map[string]interface{}, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("channel_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Retrieve channel metadata failed", args...)
+			return
+		}
+		lm.logger.Info("Retrieve channel metadata completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ChannelMetadata(ctx, id)
+}
+
+func (lm *loggingMiddleware) ThingsConnections(ctx context.Context, domainID string, ids []string) (conns map[string][]string, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.Int("things", len(ids)),
+			slog.Int("connected_things", len(conns)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List things connections failed", args...)
+			return
+		}
+		lm.logger.Info("List things connections completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ThingsConnections(ctx, domainID, ids)
+}
+
 func (lm *loggingMiddleware) Share(ctx context.Context, session authn.Session, id, relation string, userids ...string) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -299,3 +366,44 @@ func (lm *loggingMiddleware) Delete(ctx context.Context, session authn.Session,
 	}(time.Now())
 	return lm.svc.Delete(ctx, session, id)
 }
+
+func (lm *loggingMiddleware) UpdatePresence(ctx context.Context, presence things.Presence) (changed bool, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("client_id", presence.ClientID),
+			slog.String("protocol", presence.Protocol),
+			slog.Time("last_seen", presence.LastSeen),
+			slog.Bool("changed", changed),
+		}
+		if presence.Connection != "" {
+			args = append(args, slog.String("instance", presence.Instance), slog.String("connection", presence.Connection))
+		}
+		if presence.Online != nil {
+			args = append(args, slog.Bool("online", *presence.Online))
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Update client presence failed", args...)
+			return
+		}
+		lm.logger.Info("Update client presence completed successfully", args...)
+	}(time.Now())
+	return lm.svc.UpdatePresence(ctx, presence)
+}
+
+func (lm *loggingMiddleware) ExpirePresence(ctx context.Context) (expired []things.Presence, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Int("count", len(expired)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Expire client presence failed", args...)
+			return
+		}
+		lm.logger.Debug("Expire client presence completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ExpirePresence(ctx)
+}
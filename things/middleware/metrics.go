@@ -125,6 +125,38 @@ func (ms *metricsMiddleware) Authorize(ctx context.Context, req things.AuthzReq)
 	return ms.svc.Authorize(ctx, req)
 }
 
+func (ms *metricsMiddleware) DerivePSK(ctx context.Context, id string) ([]byte, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "derive_psk").Add(1)
+		ms.latency.With("method", "derive_psk").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.DerivePSK(ctx, id)
+}
+
+func (ms *metricsMiddleware) ConnectedChannels(ctx context.Context, id, key string, psk []byte) ([]string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "connected_channels").Add(1)
+		ms.latency.With("method", "connected_channels").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ConnectedChannels(ctx, id, key, psk)
+}
+
+func (ms *metricsMiddleware) ChannelMetadata(ctx context.Context, id string) (map[string]interface{}, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "channel_metadata").Add(1)
+		ms.latency.With("method", "channel_metadata").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ChannelMetadata(ctx, id)
+}
+
+func (ms *metricsMiddleware) ThingsConnections(ctx context.Context, domainID string, ids []string) (map[string][]string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "things_connections").Add(1)
+		ms.latency.With("method", "things_connections").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ThingsConnections(ctx, domainID, ids)
+}
+
 func (ms *metricsMiddleware) Share(ctx context.Context, session authn.Session, id, relation string, userids ...string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "share").Add(1)
@@ -148,3 +180,19 @@ func (ms *metricsMiddleware) Delete(ctx context.Context, session authn.Session,
 	}(time.Now())
 	return ms.svc.Delete(ctx, session, id)
 }
+
+func (ms *metricsMiddleware) UpdatePresence(ctx context.Context, presence things.Presence) (bool, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_presence").Add(1)
+		ms.latency.With("method", "update_presence").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.UpdatePresence(ctx, presence)
+}
+
+func (ms *metricsMiddleware) ExpirePresence(ctx context.Context) ([]things.Presence, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "expire_presence").Add(1)
+		ms.latency.With("method", "expire_presence").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ExpirePresence(ctx)
+}
@@ -9,6 +9,8 @@ import (
 
 	things "github.com/absmach/magistrala/things"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -62,6 +64,36 @@ func (_m *Repository) Delete(ctx context.Context, id string) error {
 	return r0
 }
 
+// ExpirePresence provides a mock function with given fields: ctx, now
+func (_m *Repository) ExpirePresence(ctx context.Context, now time.Time) ([]things.Presence, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpirePresence")
+	}
+
+	var r0 []things.Presence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]things.Presence, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []things.Presence); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]things.Presence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveAll provides a mock function with given fields: ctx, pm
 func (_m *Repository) RetrieveAll(ctx context.Context, pm things.Page) (things.ClientsPage, error) {
 	ret := _m.Called(ctx, pm)
@@ -295,6 +327,34 @@ func (_m *Repository) UpdateIdentity(ctx context.Context, client things.Client)
 	return r0, r1
 }
 
+// UpdatePresence provides a mock function with given fields: ctx, presence
+func (_m *Repository) UpdatePresence(ctx context.Context, presence things.Presence) (bool, error) {
+	ret := _m.Called(ctx, presence)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePresence")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, things.Presence) (bool, error)); ok {
+		return rf(ctx, presence)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, things.Presence) bool); ok {
+		r0 = rf(ctx, presence)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, things.Presence) error); ok {
+		r1 = rf(ctx, presence)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateSecret provides a mock function with given fields: ctx, client
 func (_m *Repository) UpdateSecret(ctx context.Context, client things.Client) (things.Client, error) {
 	ret := _m.Called(ctx, client)
@@ -47,6 +47,66 @@ func (_m *Service) Authorize(ctx context.Context, req things.AuthzReq) (string,
 	return r0, r1
 }
 
+// ChannelMetadata provides a mock function with given fields: ctx, id
+func (_m *Service) ChannelMetadata(ctx context.Context, id string) (map[string]interface{}, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChannelMetadata")
+	}
+
+	var r0 map[string]interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[string]interface{}, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string]interface{}); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConnectedChannels provides a mock function with given fields: ctx, id, key, psk
+func (_m *Service) ConnectedChannels(ctx context.Context, id string, key string, psk []byte) ([]string, error) {
+	ret := _m.Called(ctx, id, key, psk)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConnectedChannels")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) ([]string, error)); ok {
+		return rf(ctx, id, key, psk)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) []string); ok {
+		r0 = rf(ctx, id, key, psk)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []byte) error); ok {
+		r1 = rf(ctx, id, key, psk)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateClients provides a mock function with given fields: ctx, session, client
 func (_m *Service) CreateClients(ctx context.Context, session authn.Session, client ...things.Client) ([]things.Client, error) {
 	_va := make([]interface{}, len(client))
@@ -102,6 +162,36 @@ func (_m *Service) Delete(ctx context.Context, session authn.Session, id string)
 	return r0
 }
 
+// DerivePSK provides a mock function with given fields: ctx, id
+func (_m *Service) DerivePSK(ctx context.Context, id string) ([]byte, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DerivePSK")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]byte, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []byte); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Disable provides a mock function with given fields: ctx, session, id
 func (_m *Service) Disable(ctx context.Context, session authn.Session, id string) (things.Client, error) {
 	ret := _m.Called(ctx, session, id)
@@ -158,6 +248,36 @@ func (_m *Service) Enable(ctx context.Context, session authn.Session, id string)
 	return r0, r1
 }
 
+// ExpirePresence provides a mock function with given fields: ctx
+func (_m *Service) ExpirePresence(ctx context.Context) ([]things.Presence, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpirePresence")
+	}
+
+	var r0 []things.Presence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]things.Presence, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []things.Presence); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]things.Presence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Identify provides a mock function with given fields: ctx, key
 func (_m *Service) Identify(ctx context.Context, key string) (string, error) {
 	ret := _m.Called(ctx, key)
@@ -267,6 +387,36 @@ func (_m *Service) Share(ctx context.Context, session authn.Session, id string,
 	return r0
 }
 
+// ThingsConnections provides a mock function with given fields: ctx, domainID, ids
+func (_m *Service) ThingsConnections(ctx context.Context, domainID string, ids []string) (map[string][]string, error) {
+	ret := _m.Called(ctx, domainID, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ThingsConnections")
+	}
+
+	var r0 map[string][]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (map[string][]string, error)); ok {
+		return rf(ctx, domainID, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) map[string][]string); ok {
+		r0 = rf(ctx, domainID, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, domainID, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Unshare provides a mock function with given fields: ctx, session, id, relation, userids
 func (_m *Service) Unshare(ctx context.Context, session authn.Session, id string, relation string, userids ...string) error {
 	_va := make([]interface{}, len(userids))
@@ -320,6 +470,34 @@ func (_m *Service) Update(ctx context.Context, session authn.Session, client thi
 	return r0, r1
 }
 
+// UpdatePresence provides a mock function with given fields: ctx, presence
+func (_m *Service) UpdatePresence(ctx context.Context, presence things.Presence) (bool, error) {
+	ret := _m.Called(ctx, presence)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePresence")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, things.Presence) (bool, error)); ok {
+		return rf(ctx, presence)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, things.Presence) bool); ok {
+		r0 = rf(ctx, presence)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, things.Presence) error); ok {
+		r1 = rf(ctx, presence)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateSecret provides a mock function with given fields: ctx, session, id, key
 func (_m *Service) UpdateSecret(ctx context.Context, session authn.Session, id string, key string) (things.Client, error) {
 	ret := _m.Called(ctx, session, id, key)
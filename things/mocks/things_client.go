@@ -1,9 +1,7 @@
-// Copyright (c) Abstract Machines
-
-// SPDX-License-Identifier: Apache-2.0
-
 // Code generated by mockery v2.43.2. DO NOT EDIT.
 
+// Copyright (c) Abstract Machines
+
 package mocks
 
 import (
@@ -21,14 +19,6 @@ type ThingsServiceClient struct {
 	mock.Mock
 }
 
-type ThingsServiceClient_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *ThingsServiceClient) EXPECT() *ThingsServiceClient_Expecter {
-	return &ThingsServiceClient_Expecter{mock: &_m.Mock}
-}
-
 // Authorize provides a mock function with given fields: ctx, in, opts
 func (_m *ThingsServiceClient) Authorize(ctx context.Context, in *magistrala.ThingsAuthzReq, opts ...grpc.CallOption) (*magistrala.ThingsAuthzRes, error) {
 	_va := make([]interface{}, len(opts))
@@ -66,41 +56,226 @@ func (_m *ThingsServiceClient) Authorize(ctx context.Context, in *magistrala.Thi
 	return r0, r1
 }
 
-// ThingsServiceClient_Authorize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authorize'
-type ThingsServiceClient_Authorize_Call struct {
-	*mock.Call
+// ChannelMetadata provides a mock function with given fields: ctx, in, opts
+func (_m *ThingsServiceClient) ChannelMetadata(ctx context.Context, in *magistrala.ChannelMetadataReq, opts ...grpc.CallOption) (*magistrala.ChannelMetadataRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChannelMetadata")
+	}
+
+	var r0 *magistrala.ChannelMetadataRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.ChannelMetadataReq, ...grpc.CallOption) (*magistrala.ChannelMetadataRes, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.ChannelMetadataReq, ...grpc.CallOption) *magistrala.ChannelMetadataRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*magistrala.ChannelMetadataRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *magistrala.ChannelMetadataReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConnectedChannels provides a mock function with given fields: ctx, in, opts
+func (_m *ThingsServiceClient) ConnectedChannels(ctx context.Context, in *magistrala.ThingsChannelsReq, opts ...grpc.CallOption) (*magistrala.ThingsChannelsRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConnectedChannels")
+	}
+
+	var r0 *magistrala.ThingsChannelsRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.ThingsChannelsReq, ...grpc.CallOption) (*magistrala.ThingsChannelsRes, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.ThingsChannelsReq, ...grpc.CallOption) *magistrala.ThingsChannelsRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*magistrala.ThingsChannelsRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *magistrala.ThingsChannelsReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Authorize is a helper method to define mock.On call
-//   - ctx context.Context
-//   - in *magistrala.ThingsAuthzReq
-//   - opts ...grpc.CallOption
-func (_e *ThingsServiceClient_Expecter) Authorize(ctx interface{}, in interface{}, opts ...interface{}) *ThingsServiceClient_Authorize_Call {
-	return &ThingsServiceClient_Authorize_Call{Call: _e.mock.On("Authorize",
-		append([]interface{}{ctx, in}, opts...)...)}
+// CreateThing provides a mock function with given fields: ctx, in, opts
+func (_m *ThingsServiceClient) CreateThing(ctx context.Context, in *magistrala.CreateThingReq, opts ...grpc.CallOption) (*magistrala.CreateThingRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateThing")
+	}
+
+	var r0 *magistrala.CreateThingRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.CreateThingReq, ...grpc.CallOption) (*magistrala.CreateThingRes, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.CreateThingReq, ...grpc.CallOption) *magistrala.CreateThingRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*magistrala.CreateThingRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *magistrala.CreateThingReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-func (_c *ThingsServiceClient_Authorize_Call) Run(run func(ctx context.Context, in *magistrala.ThingsAuthzReq, opts ...grpc.CallOption)) *ThingsServiceClient_Authorize_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		variadicArgs := make([]grpc.CallOption, len(args)-2)
-		for i, a := range args[2:] {
-			if a != nil {
-				variadicArgs[i] = a.(grpc.CallOption)
-			}
+// DeleteThing provides a mock function with given fields: ctx, in, opts
+func (_m *ThingsServiceClient) DeleteThing(ctx context.Context, in *magistrala.DeleteThingReq, opts ...grpc.CallOption) (*magistrala.DeleteThingRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteThing")
+	}
+
+	var r0 *magistrala.DeleteThingRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.DeleteThingReq, ...grpc.CallOption) (*magistrala.DeleteThingRes, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.DeleteThingReq, ...grpc.CallOption) *magistrala.DeleteThingRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*magistrala.DeleteThingRes)
 		}
-		run(args[0].(context.Context), args[1].(*magistrala.ThingsAuthzReq), variadicArgs...)
-	})
-	return _c
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *magistrala.DeleteThingReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-func (_c *ThingsServiceClient_Authorize_Call) Return(_a0 *magistrala.ThingsAuthzRes, _a1 error) *ThingsServiceClient_Authorize_Call {
-	_c.Call.Return(_a0, _a1)
-	return _c
+// DerivePSK provides a mock function with given fields: ctx, in, opts
+func (_m *ThingsServiceClient) DerivePSK(ctx context.Context, in *magistrala.ThingsPSKReq, opts ...grpc.CallOption) (*magistrala.ThingsPSKRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DerivePSK")
+	}
+
+	var r0 *magistrala.ThingsPSKRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.ThingsPSKReq, ...grpc.CallOption) (*magistrala.ThingsPSKRes, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.ThingsPSKReq, ...grpc.CallOption) *magistrala.ThingsPSKRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*magistrala.ThingsPSKRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *magistrala.ThingsPSKReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-func (_c *ThingsServiceClient_Authorize_Call) RunAndReturn(run func(context.Context, *magistrala.ThingsAuthzReq, ...grpc.CallOption) (*magistrala.ThingsAuthzRes, error)) *ThingsServiceClient_Authorize_Call {
-	_c.Call.Return(run)
-	return _c
+// ThingsConnections provides a mock function with given fields: ctx, in, opts
+func (_m *ThingsServiceClient) ThingsConnections(ctx context.Context, in *magistrala.ThingsConnectionsReq, opts ...grpc.CallOption) (*magistrala.ThingsConnectionsRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ThingsConnections")
+	}
+
+	var r0 *magistrala.ThingsConnectionsRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.ThingsConnectionsReq, ...grpc.CallOption) (*magistrala.ThingsConnectionsRes, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *magistrala.ThingsConnectionsReq, ...grpc.CallOption) *magistrala.ThingsConnectionsRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*magistrala.ThingsConnectionsRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *magistrala.ThingsConnectionsReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NewThingsServiceClient creates a new instance of ThingsServiceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -18,6 +18,7 @@ import (
 	"github.com/absmach/magistrala/pkg/postgres"
 	"github.com/absmach/magistrala/things"
 	"github.com/jackc/pgtype"
+	"github.com/jmoiron/sqlx"
 )
 
 type clientRepo struct {
@@ -33,52 +34,61 @@ func NewRepository(db postgres.Database) things.Repository {
 }
 
 func (repo *clientRepo) Save(ctx context.Context, th ...things.Client) ([]things.Client, error) {
-	tx, err := repo.Repository.DB.BeginTxx(ctx, nil)
-	if err != nil {
-		return []things.Client{}, errors.Wrap(repoerr.ErrCreateEntity, err)
-	}
 	var thingsList []things.Client
 
-	for _, thi := range th {
-		q := `INSERT INTO clients (id, name, tags, domain_id, identity, secret, metadata, created_at, updated_at, updated_by, status)
+	err := postgres.Transaction(ctx, repo.Repository.DB, func(ctx context.Context) error {
+		for _, thi := range th {
+			q := `INSERT INTO clients (id, name, tags, domain_id, identity, secret, metadata, created_at, updated_at, updated_by, status)
         VALUES (:id, :name, :tags, :domain_id, :identity, :secret, :metadata, :created_at, :updated_at, :updated_by, :status)
         RETURNING id, name, tags, identity, secret, metadata, COALESCE(domain_id, '') AS domain_id, status, created_at, updated_at, updated_by`
 
-		dbthi, err := ToDBClient(thi)
-		if err != nil {
-			return []things.Client{}, errors.Wrap(repoerr.ErrCreateEntity, err)
-		}
-
-		row, err := repo.Repository.DB.NamedQueryContext(ctx, q, dbthi)
-		if err != nil {
-			if err := tx.Rollback(); err != nil {
-				return []things.Client{}, postgres.HandleError(repoerr.ErrCreateEntity, err)
+			dbthi, err := ToDBClient(thi)
+			if err != nil {
+				return errors.Wrap(repoerr.ErrCreateEntity, err)
 			}
-			return []things.Client{}, errors.Wrap(repoerr.ErrCreateEntity, err)
-		}
 
-		defer row.Close()
-
-		if row.Next() {
-			dbthi = DBClient{}
-			if err := row.StructScan(&dbthi); err != nil {
-				return []things.Client{}, errors.Wrap(repoerr.ErrFailedOpDB, err)
+			row, err := repo.Repository.DB.NamedQueryContext(ctx, q, dbthi)
+			if err != nil {
+				return postgres.HandleError(repoerr.ErrCreateEntity, err)
 			}
 
-			thing, err := ToClient(dbthi)
+			thing, err := scanClient(row)
 			if err != nil {
-				return []things.Client{}, errors.Wrap(repoerr.ErrFailedOpDB, err)
+				return err
+			}
+			if thing.ID != "" {
+				thingsList = append(thingsList, thing)
 			}
-			thingsList = append(thingsList, thing)
 		}
-	}
-	if err = tx.Commit(); err != nil {
-		return []things.Client{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+
+		return nil
+	})
+	if err != nil {
+		return []things.Client{}, err
 	}
 
 	return thingsList, nil
 }
 
+func scanClient(row *sqlx.Rows) (things.Client, error) {
+	defer row.Close()
+
+	if !row.Next() {
+		return things.Client{}, nil
+	}
+
+	dbthi := DBClient{}
+	if err := row.StructScan(&dbthi); err != nil {
+		return things.Client{}, errors.Wrap(repoerr.ErrFailedOpDB, err)
+	}
+	thing, err := ToClient(dbthi)
+	if err != nil {
+		return things.Client{}, errors.Wrap(repoerr.ErrFailedOpDB, err)
+	}
+
+	return thing, nil
+}
+
 func (repo *clientRepo) RetrieveBySecret(ctx context.Context, key string) (things.Client, error) {
 	q := fmt.Sprintf(`SELECT id, name, tags, COALESCE(domain_id, '') AS domain_id, identity, secret, metadata, created_at, updated_at, updated_by, status
         FROM clients
@@ -126,7 +136,7 @@ func (repo *clientRepo) Update(ctx context.Context, thing things.Client) (things
 
 	q := fmt.Sprintf(`UPDATE clients SET %s updated_at = :updated_at, updated_by = :updated_by
         WHERE id = :id AND status = :status
-        RETURNING id, name, tags, identity, secret,  metadata, COALESCE(domain_id, '') AS domain_id, status, created_at, updated_at, updated_by`,
+        RETURNING id, name, tags, identity, secret,  metadata, COALESCE(domain_id, '') AS domain_id, status, created_at, updated_at, updated_by, online, last_seen, COALESCE(protocol, '') AS protocol`,
 		upq)
 	thing.Status = things.EnabledStatus
 	return repo.update(ctx, thing, q)
@@ -135,7 +145,7 @@ func (repo *clientRepo) Update(ctx context.Context, thing things.Client) (things
 func (repo *clientRepo) UpdateTags(ctx context.Context, thing things.Client) (things.Client, error) {
 	q := `UPDATE clients SET tags = :tags, updated_at = :updated_at, updated_by = :updated_by
         WHERE id = :id AND status = :status
-        RETURNING id, name, tags, identity, metadata, COALESCE(domain_id, '') AS domain_id, status, created_at, updated_at, updated_by`
+        RETURNING id, name, tags, identity, metadata, COALESCE(domain_id, '') AS domain_id, status, created_at, updated_at, updated_by, online, last_seen, COALESCE(protocol, '') AS protocol`
 	thing.Status = things.EnabledStatus
 	return repo.update(ctx, thing, q)
 }
@@ -143,7 +153,7 @@ func (repo *clientRepo) UpdateTags(ctx context.Context, thing things.Client) (th
 func (repo *clientRepo) UpdateIdentity(ctx context.Context, thing things.Client) (things.Client, error) {
 	q := `UPDATE clients SET identity = :identity, updated_at = :updated_at, updated_by = :updated_by
         WHERE id = :id AND status = :status
-        RETURNING id, name, tags, identity, metadata, COALESCE(domain_id, '') AS domain_id, status, created_at, updated_at, updated_by`
+        RETURNING id, name, tags, identity, metadata, COALESCE(domain_id, '') AS domain_id, status, created_at, updated_at, updated_by, online, last_seen, COALESCE(protocol, '') AS protocol`
 	thing.Status = things.EnabledStatus
 	return repo.update(ctx, thing, q)
 }
@@ -151,7 +161,7 @@ func (repo *clientRepo) UpdateIdentity(ctx context.Context, thing things.Client)
 func (repo *clientRepo) UpdateSecret(ctx context.Context, thing things.Client) (things.Client, error) {
 	q := `UPDATE clients SET secret = :secret, updated_at = :updated_at, updated_by = :updated_by
         WHERE id = :id AND status = :status
-        RETURNING id, name, tags, identity, metadata, COALESCE(domain_id, '') AS domain_id, status, created_at, updated_at, updated_by`
+        RETURNING id, name, tags, identity, metadata, COALESCE(domain_id, '') AS domain_id, status, created_at, updated_at, updated_by, online, last_seen, COALESCE(protocol, '') AS protocol`
 	thing.Status = things.EnabledStatus
 	return repo.update(ctx, thing, q)
 }
@@ -159,13 +169,14 @@ func (repo *clientRepo) UpdateSecret(ctx context.Context, thing things.Client) (
 func (repo *clientRepo) ChangeStatus(ctx context.Context, thing things.Client) (things.Client, error) {
 	q := `UPDATE clients SET status = :status, updated_at = :updated_at, updated_by = :updated_by
 		WHERE id = :id
-        RETURNING id, name, tags, identity, metadata, COALESCE(domain_id, '') AS domain_id, status, created_at, updated_at, updated_by`
+        RETURNING id, name, tags, identity, metadata, COALESCE(domain_id, '') AS domain_id, status, created_at, updated_at, updated_by, online, last_seen, COALESCE(protocol, '') AS protocol`
 
 	return repo.update(ctx, thing, q)
 }
 
 func (repo *clientRepo) RetrieveByID(ctx context.Context, id string) (things.Client, error) {
-	q := `SELECT id, name, tags, COALESCE(domain_id, '') AS domain_id, identity, secret, metadata, created_at, updated_at, updated_by, status
+	q := `SELECT id, name, tags, COALESCE(domain_id, '') AS domain_id, identity, secret, metadata, created_at, updated_at, updated_by, status,
+        online, last_seen, COALESCE(protocol, '') AS protocol
         FROM clients WHERE id = :id`
 
 	dbt := DBClient{
@@ -198,7 +209,8 @@ func (repo *clientRepo) RetrieveAll(ctx context.Context, pm things.Page) (things
 	query = applyOrdering(query, pm)
 
 	q := fmt.Sprintf(`SELECT c.id, c.name, c.tags, c.identity, c.metadata, COALESCE(c.domain_id, '') AS domain_id, c.status,
-					c.created_at, c.updated_at, COALESCE(c.updated_by, '') AS updated_by FROM clients c %s ORDER BY c.created_at LIMIT :limit OFFSET :offset;`, query)
+					c.created_at, c.updated_at, COALESCE(c.updated_by, '') AS updated_by, c.online, c.last_seen, COALESCE(c.protocol, '') AS protocol
+					FROM clients c %s ORDER BY c.created_at LIMIT :limit OFFSET :offset;`, query)
 
 	dbPage, err := ToDBClientsPage(pm)
 	if err != nil {
@@ -311,7 +323,8 @@ func (repo *clientRepo) RetrieveAllByIDs(ctx context.Context, pm things.Page) (t
 	query = applyOrdering(query, pm)
 
 	q := fmt.Sprintf(`SELECT c.id, c.name, c.tags, c.identity, c.metadata, COALESCE(c.domain_id, '') AS domain_id, c.status,
-					c.created_at, c.updated_at, COALESCE(c.updated_by, '') AS updated_by FROM clients c %s ORDER BY c.created_at LIMIT :limit OFFSET :offset;`, query)
+					c.created_at, c.updated_at, COALESCE(c.updated_by, '') AS updated_by, c.online, c.last_seen, COALESCE(c.protocol, '') AS protocol
+					FROM clients c %s ORDER BY c.created_at LIMIT :limit OFFSET :offset;`, query)
 
 	dbPage, err := ToDBClientsPage(pm)
 	if err != nil {
@@ -356,6 +369,103 @@ func (repo *clientRepo) RetrieveAllByIDs(ctx context.Context, pm things.Page) (t
 	return page, nil
 }
 
+func (repo *clientRepo) UpdatePresence(ctx context.Context, presence things.Presence) (bool, error) {
+	dbp := toDBPresence(presence)
+	dbp.Now = time.Now().UTC()
+
+	var changed bool
+	err := postgres.Transaction(ctx, repo.Repository.DB, func(ctx context.Context) error {
+		// The client row is locked, so the presence reports of the client are
+		// applied one by one.
+		var online bool
+		q := `SELECT online FROM clients WHERE id = $1 FOR UPDATE`
+		if err := repo.Repository.DB.QueryRowxContext(ctx, q, dbp.ID).Scan(&online); err != nil {
+			if err == sql.ErrNoRows {
+				return repoerr.ErrNotFound
+			}
+			return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+		}
+
+		if dbp.Connection != "" && presence.Online != nil {
+			// Closed connection is kept as expired, so the reports of the
+			// connection which are older than its closing are ignored, since
+			// the adapters streams are not consumed in order.
+			if !*presence.Online {
+				dbp.ExpiresAt = dbp.LastSeen
+			}
+			q = `INSERT INTO client_connections (client_id, protocol, instance, connection, last_seen, expires_at)
+				VALUES (:id, :protocol, :instance, :connection, :last_seen, :expires_at)
+				ON CONFLICT (client_id, protocol, instance, connection) DO UPDATE
+				SET last_seen = EXCLUDED.last_seen, expires_at = EXCLUDED.expires_at
+				WHERE client_connections.last_seen <= EXCLUDED.last_seen`
+			if _, err := repo.Repository.DB.NamedExecContext(ctx, q, dbp); err != nil {
+				return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+			}
+		}
+
+		// The protocol is changed only by the activity which is not older
+		// than the last seen one.
+		q = `UPDATE clients c SET
+				online = EXISTS (SELECT 1 FROM client_connections cc WHERE cc.client_id = c.id AND cc.expires_at > :now),
+				protocol = CASE WHEN c.last_seen IS NULL OR c.last_seen <= :last_seen THEN :protocol ELSE c.protocol END,
+				last_seen = GREATEST(c.last_seen, :last_seen)
+			WHERE c.id = :id
+			RETURNING c.online`
+		row, err := repo.Repository.DB.NamedQueryContext(ctx, q, dbp)
+		if err != nil {
+			return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+		}
+		defer row.Close()
+
+		if !row.Next() {
+			return repoerr.ErrNotFound
+		}
+		var updated bool
+		if err := row.Scan(&updated); err != nil {
+			return errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+		changed = updated != online
+
+		return nil
+	})
+
+	return changed, err
+}
+
+func (repo *clientRepo) ExpirePresence(ctx context.Context, now time.Time) ([]things.Presence, error) {
+	// Clients online without any connection left are marked offline as
+	// well, so clients stay consistent with their connections.
+	q := `WITH expired AS (
+			DELETE FROM client_connections WHERE expires_at <= :now
+		)
+		UPDATE clients c SET online = FALSE
+		WHERE c.online AND NOT EXISTS (SELECT 1 FROM client_connections cc WHERE cc.client_id = c.id AND cc.expires_at > :now)
+		RETURNING c.id, c.last_seen, COALESCE(c.protocol, '') AS protocol`
+
+	rows, err := repo.Repository.DB.NamedQueryContext(ctx, q, dbPresence{Now: now.UTC()})
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer rows.Close()
+
+	var expired []things.Presence
+	for rows.Next() {
+		var dbp dbPresence
+		if err := rows.StructScan(&dbp); err != nil {
+			return nil, errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+		online := false
+		expired = append(expired, things.Presence{
+			ClientID: dbp.ID,
+			Online:   &online,
+			LastSeen: dbp.LastSeen,
+			Protocol: dbp.Protocol,
+		})
+	}
+
+	return expired, nil
+}
+
 func (repo *clientRepo) update(ctx context.Context, thing things.Client, query string) (things.Client, error) {
 	dbc, err := ToDBClient(thing)
 	if err != nil {
@@ -407,6 +517,30 @@ type DBClient struct {
 	UpdatedBy *string          `db:"updated_by,omitempty"`
 	Groups    []groups.Group   `db:"groups,omitempty"`
 	Status    things.Status    `db:"status,omitempty"`
+	Online    bool             `db:"online"`
+	LastSeen  sql.NullTime     `db:"last_seen"`
+	Protocol  string           `db:"protocol"`
+}
+
+type dbPresence struct {
+	ID         string    `db:"id"`
+	Connection string    `db:"connection"`
+	Instance   string    `db:"instance"`
+	LastSeen   time.Time `db:"last_seen"`
+	ExpiresAt  time.Time `db:"expires_at"`
+	Protocol   string    `db:"protocol"`
+	Now        time.Time `db:"now"`
+}
+
+func toDBPresence(p things.Presence) dbPresence {
+	return dbPresence{
+		ID:         p.ClientID,
+		Connection: p.Connection,
+		Instance:   p.Instance,
+		LastSeen:   p.LastSeen.UTC(),
+		ExpiresAt:  p.ExpiresAt.UTC(),
+		Protocol:   p.Protocol,
+	}
 }
 
 func ToDBClient(c things.Client) (DBClient, error) {
@@ -465,6 +599,10 @@ func ToClient(t DBClient) (things.Client, error) {
 	if t.UpdatedAt.Valid {
 		updatedAt = t.UpdatedAt.Time
 	}
+	var lastSeen time.Time
+	if t.LastSeen.Valid {
+		lastSeen = t.LastSeen.Time
+	}
 
 	thg := things.Client{
 		ID:     t.ID,
@@ -480,6 +618,9 @@ func ToClient(t DBClient) (things.Client, error) {
 		UpdatedAt: updatedAt,
 		UpdatedBy: updatedBy,
 		Status:    t.Status,
+		Online:    t.Online,
+		LastSeen:  lastSeen,
+		Protocol:  t.Protocol,
 	}
 	return thg, nil
 }
@@ -500,6 +641,8 @@ func ToDBClientsPage(pm things.Page) (dbClientsPage, error) {
 		Limit:    pm.Limit,
 		Status:   pm.Status,
 		Tag:      pm.Tag,
+		Online:   pm.Online,
+		LastSeen: pm.LastSeenBefore,
 	}, nil
 }
 
@@ -515,6 +658,8 @@ type dbClientsPage struct {
 	Tag      string        `db:"tag"`
 	Status   things.Status `db:"status"`
 	GroupID  string        `db:"group_id"`
+	Online   *bool         `db:"online"`
+	LastSeen time.Time     `db:"last_seen_before"`
 }
 
 func PageQuery(pm things.Page) (string, error) {
@@ -555,6 +700,13 @@ func PageQuery(pm things.Page) (string, error) {
 	if pm.Domain != "" {
 		query = append(query, "c.domain_id = :domain_id")
 	}
+	if pm.Online != nil {
+		query = append(query, "c.online = :online")
+	}
+	// Clients that were never seen are considered as seen before any time.
+	if !pm.LastSeenBefore.IsZero() {
+		query = append(query, "(c.last_seen IS NULL OR c.last_seen < :last_seen_before)")
+	}
 	var emq string
 	if len(query) > 0 {
 		emq = fmt.Sprintf("WHERE %s", strings.Join(query, " AND "))
@@ -564,7 +716,7 @@ func PageQuery(pm things.Page) (string, error) {
 
 func applyOrdering(emq string, pm things.Page) string {
 	switch pm.Order {
-	case "name", "identity", "created_at", "updated_at":
+	case "name", "identity", "created_at", "updated_at", "last_seen":
 		emq = fmt.Sprintf("%s ORDER BY %s", emq, pm.Order)
 		if pm.Dir == api.AscDir || pm.Dir == api.DescDir {
 			emq = fmt.Sprintf("%s %s", emq, pm.Dir)
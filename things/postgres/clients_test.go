@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/0x6flab/namegenerator"
 	"github.com/absmach/magistrala/internal/testsutil"
@@ -426,3 +427,188 @@ func TestRetrieveByID(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdatePresence(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	thing := things.Client{
+		ID:     testsutil.GenerateUUID(t),
+		Name:   thingName,
+		Domain: testsutil.GenerateUUID(t),
+		Credentials: things.Credentials{
+			Identity: thingIdentity,
+			Secret:   testsutil.GenerateUUID(t),
+		},
+		Metadata: things.Metadata{},
+		Status:   things.EnabledStatus,
+	}
+
+	_, err := repo.Save(context.Background(), thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	online, offline := true, false
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	ttl := time.Minute
+
+	// Cases are run in order, since every case depends on the previous presence.
+	cases := []struct {
+		desc     string
+		presence things.Presence
+		changed  bool
+		online   bool
+		lastSeen time.Time
+		protocol string
+		err      error
+	}{
+		{
+			desc:     "connect thing",
+			presence: things.Presence{ClientID: thing.ID, Connection: "conn1", Instance: "mqtt1", Online: &online, LastSeen: now, ExpiresAt: now.Add(ttl), Protocol: "mqtt"},
+			changed:  true,
+			online:   true,
+			lastSeen: now,
+			protocol: "mqtt",
+		},
+		{
+			desc:     "connect thing over another connection",
+			presence: things.Presence{ClientID: thing.ID, Connection: "conn2", Instance: "ws1", Online: &online, LastSeen: now, ExpiresAt: now.Add(ttl), Protocol: "ws"},
+			changed:  false,
+			online:   true,
+			lastSeen: now,
+			protocol: "ws",
+		},
+		{
+			desc:     "publish message",
+			presence: things.Presence{ClientID: thing.ID, LastSeen: now.Add(time.Second), Protocol: "http"},
+			changed:  false,
+			online:   true,
+			lastSeen: now.Add(time.Second),
+			protocol: "http",
+		},
+		{
+			desc:     "disconnect thing from one of connections",
+			presence: things.Presence{ClientID: thing.ID, Connection: "conn1", Instance: "mqtt1", Online: &offline, LastSeen: now.Add(2 * time.Second), Protocol: "mqtt"},
+			changed:  false,
+			online:   true,
+			lastSeen: now.Add(2 * time.Second),
+			protocol: "mqtt",
+		},
+		{
+			desc:     "report heartbeat of connection before its disconnect",
+			presence: things.Presence{ClientID: thing.ID, Connection: "conn1", Instance: "mqtt1", Online: &online, LastSeen: now.Add(time.Second), ExpiresAt: now.Add(time.Second + ttl), Protocol: "mqtt"},
+			changed:  false,
+			online:   true,
+			lastSeen: now.Add(2 * time.Second),
+			protocol: "mqtt",
+		},
+		{
+			desc:     "disconnect thing from last connection",
+			presence: things.Presence{ClientID: thing.ID, Connection: "conn2", Instance: "ws1", Online: &offline, LastSeen: now.Add(3 * time.Second), Protocol: "ws"},
+			changed:  true,
+			online:   false,
+			lastSeen: now.Add(3 * time.Second),
+			protocol: "ws",
+		},
+		{
+			desc:     "connect thing with expired connection",
+			presence: things.Presence{ClientID: thing.ID, Connection: "conn3", Instance: "mqtt1", Online: &online, LastSeen: now.Add(-2 * ttl), ExpiresAt: now.Add(-ttl), Protocol: "mqtt"},
+			changed:  false,
+			online:   false,
+			lastSeen: now.Add(3 * time.Second),
+			protocol: "ws",
+		},
+		{
+			desc:     "update presence of non-existing thing",
+			presence: things.Presence{ClientID: testsutil.GenerateUUID(t), Connection: "conn1", Instance: "mqtt1", Online: &online, LastSeen: now, ExpiresAt: now.Add(ttl), Protocol: "mqtt"},
+			err:      repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		changed, err := repo.UpdatePresence(context.Background(), tc.presence)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, tc.changed, changed, fmt.Sprintf("%s: expected changed %t got %t\n", tc.desc, tc.changed, changed))
+		cli, err := repo.RetrieveByID(context.Background(), thing.ID)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.online, cli.Online, fmt.Sprintf("%s: expected online %t got %t\n", tc.desc, tc.online, cli.Online))
+		assert.True(t, tc.lastSeen.Equal(cli.LastSeen), fmt.Sprintf("%s: expected last seen %s got %s\n", tc.desc, tc.lastSeen, cli.LastSeen))
+		assert.Equal(t, tc.protocol, cli.Protocol, fmt.Sprintf("%s: expected protocol %s got %s\n", tc.desc, tc.protocol, cli.Protocol))
+	}
+}
+
+func TestExpirePresence(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	online := true
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	var ids []string
+	for i := 0; i < 2; i++ {
+		thing := things.Client{
+			ID:     testsutil.GenerateUUID(t),
+			Name:   fmt.Sprintf("%s-%d", thingName, i),
+			Domain: testsutil.GenerateUUID(t),
+			Credentials: things.Credentials{
+				Identity: fmt.Sprintf("%s-%d", thingIdentity, i),
+				Secret:   testsutil.GenerateUUID(t),
+			},
+			Metadata: things.Metadata{},
+			Status:   things.EnabledStatus,
+		}
+		_, err := repo.Save(context.Background(), thing)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+		p := things.Presence{ClientID: thing.ID, Connection: "conn", Instance: "mqtt1", Online: &online, LastSeen: now, ExpiresAt: now.Add(time.Duration(i+1) * time.Minute), Protocol: "mqtt"}
+		_, err = repo.UpdatePresence(context.Background(), p)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		ids = append(ids, thing.ID)
+	}
+
+	cases := []struct {
+		desc    string
+		now     time.Time
+		expired []string
+	}{
+		{
+			desc:    "expire presence before connections expire",
+			now:     now,
+			expired: nil,
+		},
+		{
+			desc:    "expire presence after first connection expires",
+			now:     now.Add(time.Minute),
+			expired: ids[:1],
+		},
+		{
+			desc:    "expire presence after all connections expire",
+			now:     now.Add(2 * time.Minute),
+			expired: ids[1:],
+		},
+	}
+
+	for _, tc := range cases {
+		expired, err := repo.ExpirePresence(context.Background(), tc.now)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		var got []string
+		for _, p := range expired {
+			assert.False(t, *p.Online, fmt.Sprintf("%s: expected thing %s offline", tc.desc, p.ClientID))
+			got = append(got, p.ClientID)
+		}
+		assert.ElementsMatch(t, tc.expired, got, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.expired, got))
+		for _, id := range tc.expired {
+			cli, err := repo.RetrieveByID(context.Background(), id)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.False(t, cli.Online, fmt.Sprintf("%s: expected thing %s offline", tc.desc, id))
+		}
+	}
+}
@@ -36,6 +36,37 @@ func Migration() *migrate.MemoryMigrationSource {
 					`DROP TABLE IF EXISTS clients`,
 				},
 			},
+			{
+				Id: "clients_02",
+				Up: []string{
+					`ALTER TABLE clients ADD COLUMN online BOOLEAN NOT NULL DEFAULT FALSE,
+						ADD COLUMN last_seen TIMESTAMP,
+						ADD COLUMN protocol VARCHAR(32)`,
+				},
+				Down: []string{
+					`ALTER TABLE clients DROP COLUMN online, DROP COLUMN last_seen, DROP COLUMN protocol`,
+				},
+			},
+			{
+				Id: "clients_03",
+				// Connections of the clients to the protocol adapter instances,
+				// which are open until they expire.
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS client_connections (
+						client_id	VARCHAR(36) NOT NULL REFERENCES clients (id) ON DELETE CASCADE,
+						protocol	VARCHAR(32) NOT NULL,
+						instance	VARCHAR(254) NOT NULL,
+						connection	VARCHAR(254) NOT NULL,
+						last_seen	TIMESTAMP NOT NULL,
+						expires_at	TIMESTAMP NOT NULL,
+						PRIMARY KEY (client_id, protocol, instance, connection)
+					)`,
+					`CREATE INDEX IF NOT EXISTS client_connections_expires_at_idx ON client_connections (expires_at)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS client_connections`,
+				},
+			},
 		},
 	}
 }
@@ -4,6 +4,7 @@ package things
 
 import (
 	"context"
+	"crypto/hmac"
 	"time"
 
 	"github.com/absmach/magistrala"
@@ -11,6 +12,7 @@ import (
 	"github.com/absmach/magistrala/pkg/authn"
 	"github.com/absmach/magistrala/pkg/errors"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/groups"
 	"github.com/absmach/magistrala/pkg/policies"
 	"golang.org/x/sync/errgroup"
 )
@@ -19,23 +21,29 @@ type service struct {
 	evaluator   policies.Evaluator
 	policysvc   policies.Service
 	clients     Repository
+	channels    groups.Repository
 	clientCache Cache
 	idProvider  magistrala.IDProvider
+	presenceTTL time.Duration
 }
 
-// NewService returns a new Things service implementation.
-func NewService(policyEvaluator policies.Evaluator, policyService policies.Service, c Repository, tcache Cache, idp magistrala.IDProvider) Service {
+// NewService returns a new Things service implementation. Client connections
+// which are not reported by the protocol adapters within the presence TTL
+// are considered closed.
+func NewService(policyEvaluator policies.Evaluator, policyService policies.Service, c Repository, g groups.Repository, tcache Cache, idp magistrala.IDProvider, presenceTTL time.Duration) Service {
 	return service{
 		evaluator:   policyEvaluator,
 		policysvc:   policyService,
 		clients:     c,
+		channels:    g,
 		clientCache: tcache,
 		idProvider:  idp,
+		presenceTTL: presenceTTL,
 	}
 }
 
 func (svc service) Authorize(ctx context.Context, req AuthzReq) (string, error) {
-	clientID, err := svc.Identify(ctx, req.ClientKey)
+	clientID, err := svc.authenticate(ctx, req)
 	if err != nil {
 		return "", err
 	}
@@ -363,6 +371,25 @@ func (svc service) Delete(ctx context.Context, session authn.Session, id string)
 	return nil
 }
 
+func (svc service) UpdatePresence(ctx context.Context, presence Presence) (bool, error) {
+	presence.ExpiresAt = presence.LastSeen.Add(svc.presenceTTL)
+	changed, err := svc.clients.UpdatePresence(ctx, presence)
+	if err != nil {
+		return false, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return changed, nil
+}
+
+func (svc service) ExpirePresence(ctx context.Context) ([]Presence, error) {
+	expired, err := svc.clients.ExpirePresence(ctx, time.Now())
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return expired, nil
+}
+
 func (svc service) changeClientStatus(ctx context.Context, session authn.Session, client Client) (Client, error) {
 	dbClient, err := svc.clients.RetrieveByID(ctx, client.ID)
 	if err != nil {
@@ -438,6 +465,112 @@ func (svc service) Identify(ctx context.Context, key string) (string, error) {
 	return client.ID, nil
 }
 
+func (svc service) DerivePSK(ctx context.Context, id string) ([]byte, error) {
+	client, err := svc.retrieveEnabled(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return DerivePSK(client.ID, client.Credentials.Secret), nil
+}
+
+func (svc service) ConnectedChannels(ctx context.Context, id, key string, psk []byte) ([]string, error) {
+	clientID, err := svc.authenticate(ctx, AuthzReq{ClientID: id, ClientKey: key, ClientPSK: psk})
+	if err != nil {
+		return nil, err
+	}
+	chids, err := svc.policysvc.ListAllSubjects(ctx, policies.Policy{
+		SubjectType: policies.GroupType,
+		Permission:  policies.GroupRelation,
+		ObjectType:  policies.ThingType,
+		Object:      clientID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return chids.Policies, nil
+}
+
+func (svc service) ThingsConnections(ctx context.Context, domainID string, ids []string) (map[string][]string, error) {
+	if domainID == "" || len(ids) == 0 {
+		return map[string][]string{}, nil
+	}
+	cp, err := svc.clients.RetrieveAllByIDs(ctx, Page{Domain: domainID, IDs: ids, Limit: uint64(len(ids))})
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	chids := make([][]string, len(cp.Clients))
+	g, gctx := errgroup.WithContext(ctx)
+	for i := range cp.Clients {
+		iter := i
+		g.Go(func() error {
+			page, err := svc.policysvc.ListAllSubjects(gctx, policies.Policy{
+				SubjectType: policies.GroupType,
+				Permission:  policies.GroupRelation,
+				ObjectType:  policies.ThingType,
+				Object:      cp.Clients[iter].ID,
+			})
+			if err != nil {
+				return errors.Wrap(svcerr.ErrViewEntity, err)
+			}
+			chids[iter] = page.Policies
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	conns := make(map[string][]string, len(cp.Clients))
+	for i, c := range cp.Clients {
+		conns[c.ID] = chids[i]
+	}
+
+	return conns, nil
+}
+
+func (svc service) ChannelMetadata(ctx context.Context, id string) (map[string]interface{}, error) {
+	channel, err := svc.channels.RetrieveByID(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return channel.Metadata, nil
+}
+
+// authenticate returns the ID of the client issuing the request. The client
+// is identified by its key, or by its ID when the protocol adapter already
+// authenticated the client over DTLS. In the latter case, the request must
+// carry the pre-shared key of the client, since the ID alone is not secret.
+func (svc service) authenticate(ctx context.Context, req AuthzReq) (string, error) {
+	if req.ClientKey != "" || req.ClientID == "" {
+		return svc.Identify(ctx, req.ClientKey)
+	}
+	client, err := svc.retrieveEnabled(ctx, req.ClientID)
+	if err != nil {
+		return "", err
+	}
+	if !hmac.Equal(req.ClientPSK, DerivePSK(client.ID, client.Credentials.Secret)) {
+		return "", svcerr.ErrAuthentication
+	}
+
+	return client.ID, nil
+}
+
+func (svc service) retrieveEnabled(ctx context.Context, id string) (Client, error) {
+	client, err := svc.clients.RetrieveByID(ctx, id)
+	if err != nil {
+		return Client{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if client.Status != EnabledStatus {
+		return Client{}, errors.Wrap(svcerr.ErrAuthentication, svcerr.ErrNotFound)
+	}
+
+	return client, nil
+}
+
 func (svc service) addClientPolicies(ctx context.Context, userID, domainID string, clients []Client) error {
 	policyList := []policies.Policy{}
 	for _, client := range clients {
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/absmach/magistrala/internal/testsutil"
 	mgauthn "github.com/absmach/magistrala/pkg/authn"
@@ -15,6 +16,8 @@ import (
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/absmach/magistrala/pkg/policies"
 	policysvc "github.com/absmach/magistrala/pkg/policies"
+	"github.com/absmach/magistrala/pkg/groups"
+	gmocks "github.com/absmach/magistrala/pkg/groups/mocks"
 	policymocks "github.com/absmach/magistrala/pkg/policies/mocks"
 	"github.com/absmach/magistrala/pkg/uuid"
 	"github.com/absmach/magistrala/things"
@@ -41,6 +44,7 @@ var (
 	validID           = "d4ebb847-5d0e-4e46-bdd9-b6aceaaa3a22"
 	wrongID           = testsutil.GenerateUUID(&testing.T{})
 	errRemovePolicies = errors.New("failed to delete policies")
+	presenceTTL       = 3 * time.Minute
 )
 
 var (
@@ -48,6 +52,7 @@ var (
 	pEvaluator *policymocks.Evaluator
 	cache      *mocks.Cache
 	cRepo      *mocks.Repository
+	gRepo      *gmocks.Repository
 )
 
 func newService() things.Service {
@@ -56,8 +61,9 @@ func newService() things.Service {
 	cache = new(mocks.Cache)
 	idProvider := uuid.NewMock()
 	cRepo = new(mocks.Repository)
+	gRepo = new(gmocks.Repository)
 
-	return things.NewService(pEvaluator, pService, cRepo, cache, idProvider)
+	return things.NewService(pEvaluator, pService, cRepo, gRepo, cache, idProvider, presenceTTL)
 }
 
 func TestCreateClients(t *testing.T) {
@@ -1303,6 +1309,8 @@ func TestAuthorize(t *testing.T) {
 		cacheIDErr          error
 		retrieveBySecretRes things.Client
 		retrieveBySecretErr error
+		retrieveByIDRes     things.Client
+		retrieveByIDErr     error
 		cacheSaveErr        error
 		checkPolicyErr      error
 		id                  string
@@ -1367,11 +1375,42 @@ func TestAuthorize(t *testing.T) {
 			checkPolicyErr:      svcerr.ErrAuthorization,
 			err:                 svcerr.ErrAuthorization,
 		},
+		{
+			desc:            "authorize thing authenticated by ID and pre-shared key",
+			request:         things.AuthzReq{ClientID: valid, ClientPSK: things.DerivePSK(valid, secret), ChannelID: valid, Permission: policies.PublishPermission},
+			retrieveByIDRes: things.Client{ID: valid, Credentials: things.Credentials{Secret: secret}, Status: things.EnabledStatus},
+			id:              valid,
+		},
+		{
+			desc:            "authorize thing authenticated by ID without pre-shared key",
+			request:         things.AuthzReq{ClientID: valid, ChannelID: valid, Permission: policies.PublishPermission},
+			retrieveByIDRes: things.Client{ID: valid, Credentials: things.Credentials{Secret: secret}, Status: things.EnabledStatus},
+			err:             svcerr.ErrAuthentication,
+		},
+		{
+			desc:            "authorize thing authenticated by ID with invalid pre-shared key",
+			request:         things.AuthzReq{ClientID: valid, ClientPSK: things.DerivePSK(valid, invalid), ChannelID: valid, Permission: policies.PublishPermission},
+			retrieveByIDRes: things.Client{ID: valid, Credentials: things.Credentials{Secret: secret}, Status: things.EnabledStatus},
+			err:             svcerr.ErrAuthentication,
+		},
+		{
+			desc:            "authorize disabled thing authenticated by ID",
+			request:         things.AuthzReq{ClientID: valid, ClientPSK: things.DerivePSK(valid, secret), ChannelID: valid, Permission: policies.PublishPermission},
+			retrieveByIDRes: things.Client{ID: valid, Credentials: things.Credentials{Secret: secret}, Status: things.DisabledStatus},
+			err:             svcerr.ErrAuthentication,
+		},
+		{
+			desc:            "authorize non existing thing authenticated by ID",
+			request:         things.AuthzReq{ClientID: valid, ClientPSK: things.DerivePSK(valid, secret), ChannelID: valid, Permission: policies.PublishPermission},
+			retrieveByIDErr: repoerr.ErrNotFound,
+			err:             svcerr.ErrAuthentication,
+		},
 	}
 
 	for _, tc := range cases {
 		cacheCall := cache.On("ID", context.Background(), tc.request.ClientKey).Return(tc.cacheIDRes, tc.cacheIDErr)
 		repoCall := cRepo.On("RetrieveBySecret", context.Background(), tc.request.ClientKey).Return(tc.retrieveBySecretRes, tc.retrieveBySecretErr)
+		repoCall1 := cRepo.On("RetrieveByID", context.Background(), tc.request.ClientID).Return(tc.retrieveByIDRes, tc.retrieveByIDErr)
 		cacheCall1 := cache.On("Save", context.Background(), tc.request.ClientKey, tc.retrieveBySecretRes.ID).Return(tc.cacheSaveErr)
 		policyCall := pEvaluator.On("CheckPolicy", context.Background(), policies.Policy{
 			SubjectType: policies.GroupType,
@@ -1388,6 +1427,281 @@ func TestAuthorize(t *testing.T) {
 		cacheCall.Unset()
 		cacheCall1.Unset()
 		repoCall.Unset()
+		repoCall1.Unset()
+		policyCall.Unset()
+	}
+}
+
+func TestDerivePSK(t *testing.T) {
+	svc := newService()
+
+	cases := []struct {
+		desc            string
+		id              string
+		retrieveByIDRes things.Client
+		retrieveByIDErr error
+		psk             []byte
+		err             error
+	}{
+		{
+			desc:            "derive pre-shared key of enabled thing",
+			id:              thing.ID,
+			retrieveByIDRes: thing,
+			psk:             things.DerivePSK(thing.ID, secret),
+		},
+		{
+			desc:            "derive pre-shared key of disabled thing",
+			id:              thing.ID,
+			retrieveByIDRes: things.Client{ID: thing.ID, Credentials: thing.Credentials, Status: things.DisabledStatus},
+			err:             svcerr.ErrAuthentication,
+		},
+		{
+			desc:            "derive pre-shared key of non existing thing",
+			id:              wrongID,
+			retrieveByIDErr: repoerr.ErrNotFound,
+			err:             svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("RetrieveByID", context.Background(), tc.id).Return(tc.retrieveByIDRes, tc.retrieveByIDErr)
+		psk, err := svc.DerivePSK(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.psk, psk, fmt.Sprintf("%s: expected %x got %x\n", tc.desc, tc.psk, psk))
+		assert.NotContains(t, string(psk), secret, fmt.Sprintf("%s: pre-shared key discloses the thing key\n", tc.desc))
+		repoCall.Unset()
+	}
+}
+
+func TestConnectedChannels(t *testing.T) {
+	svc := newService()
+
+	chID := testsutil.GenerateUUID(t)
+	listReq := policysvc.Policy{
+		SubjectType: policysvc.GroupType,
+		Permission:  policysvc.GroupRelation,
+		ObjectType:  policysvc.ThingType,
+		Object:      thing.ID,
+	}
+
+	cases := []struct {
+		desc            string
+		id              string
+		key             string
+		psk             []byte
+		cacheIDRes      string
+		cacheIDErr      error
+		retrieveByIDRes things.Client
+		retrieveByIDErr error
+		listSubjectsRes policysvc.PolicyPage
+		listSubjectsErr error
+		channels        []string
+		err             error
+	}{
+		{
+			desc:            "list connected channels by key",
+			key:             secret,
+			cacheIDRes:      thing.ID,
+			listSubjectsRes: policysvc.PolicyPage{Policies: []string{chID}},
+			channels:        []string{chID},
+		},
+		{
+			desc:            "list connected channels by ID and pre-shared key",
+			id:              thing.ID,
+			psk:             things.DerivePSK(thing.ID, secret),
+			retrieveByIDRes: thing,
+			listSubjectsRes: policysvc.PolicyPage{Policies: []string{chID}},
+			channels:        []string{chID},
+		},
+		{
+			desc:            "list connected channels by ID without pre-shared key",
+			id:              thing.ID,
+			retrieveByIDRes: thing,
+			listSubjectsRes: policysvc.PolicyPage{Policies: []string{chID}},
+			err:             svcerr.ErrAuthentication,
+		},
+		{
+			desc:            "list connected channels of disabled thing",
+			id:              thing.ID,
+			psk:             things.DerivePSK(thing.ID, secret),
+			retrieveByIDRes: things.Client{ID: thing.ID, Credentials: thing.Credentials, Status: things.DisabledStatus},
+			err:             svcerr.ErrAuthentication,
+		},
+		{
+			desc:            "list connected channels with failed to list subjects",
+			id:              thing.ID,
+			psk:             things.DerivePSK(thing.ID, secret),
+			retrieveByIDRes: thing,
+			listSubjectsErr: svcerr.ErrNotFound,
+			err:             svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		cacheCall := cache.On("ID", mock.Anything, tc.key).Return(tc.cacheIDRes, tc.cacheIDErr)
+		repoCall := cRepo.On("RetrieveByID", context.Background(), tc.id).Return(tc.retrieveByIDRes, tc.retrieveByIDErr)
+		policyCall := pService.On("ListAllSubjects", context.Background(), listReq).Return(tc.listSubjectsRes, tc.listSubjectsErr)
+		channels, err := svc.ConnectedChannels(context.Background(), tc.id, tc.key, tc.psk)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.channels, channels, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.channels, channels))
+		cacheCall.Unset()
+		repoCall.Unset()
 		policyCall.Unset()
 	}
 }
+
+func TestChannelMetadata(t *testing.T) {
+	svc := newService()
+
+	chID := testsutil.GenerateUUID(t)
+	metadata := map[string]interface{}{"schema": map[string]interface{}{"type": "object"}}
+
+	cases := []struct {
+		desc            string
+		id              string
+		retrieveByIDRes groups.Group
+		retrieveByIDErr error
+		metadata        map[string]interface{}
+		err             error
+	}{
+		{
+			desc:            "retrieve channel metadata",
+			id:              chID,
+			retrieveByIDRes: groups.Group{ID: chID, Metadata: metadata},
+			metadata:        metadata,
+		},
+		{
+			desc:            "retrieve metadata of non existing channel",
+			id:              wrongID,
+			retrieveByIDErr: repoerr.ErrNotFound,
+			err:             svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := gRepo.On("RetrieveByID", context.Background(), tc.id).Return(tc.retrieveByIDRes, tc.retrieveByIDErr)
+		md, err := svc.ChannelMetadata(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.metadata, md, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.metadata, md))
+		}
+		repoCall.Unset()
+	}
+}
+
+func TestThingsConnections(t *testing.T) {
+	svc := newService()
+
+	domainID := testsutil.GenerateUUID(t)
+	thingID := testsutil.GenerateUUID(t)
+	otherID := testsutil.GenerateUUID(t)
+	chID := testsutil.GenerateUUID(t)
+	ids := []string{thingID, otherID}
+
+	cases := []struct {
+		desc            string
+		domainID        string
+		ids             []string
+		retrieveAllRes  things.ClientsPage
+		retrieveAllErr  error
+		listSubjectsRes policysvc.PolicyPage
+		listSubjectsErr error
+		conns           map[string][]string
+		err             error
+	}{
+		{
+			desc:            "list things connections",
+			domainID:        domainID,
+			ids:             ids,
+			retrieveAllRes:  things.ClientsPage{Clients: []things.Client{{ID: thingID, Domain: domainID}}},
+			listSubjectsRes: policysvc.PolicyPage{Policies: []string{chID}},
+			conns:           map[string][]string{thingID: {chID}},
+		},
+		{
+			desc:  "list things connections without domain",
+			ids:   ids,
+			conns: map[string][]string{},
+		},
+		{
+			desc:     "list things connections without things",
+			domainID: domainID,
+			conns:    map[string][]string{},
+		},
+		{
+			desc:           "list things connections with failed to retrieve things",
+			domainID:       domainID,
+			ids:            ids,
+			retrieveAllErr: repoerr.ErrViewEntity,
+			err:            svcerr.ErrViewEntity,
+		},
+		{
+			desc:            "list things connections with failed to list subjects",
+			domainID:        domainID,
+			ids:             ids,
+			retrieveAllRes:  things.ClientsPage{Clients: []things.Client{{ID: thingID, Domain: domainID}}},
+			listSubjectsErr: svcerr.ErrNotFound,
+			err:             svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		pm := things.Page{Domain: tc.domainID, IDs: tc.ids, Limit: uint64(len(tc.ids))}
+		listReq := policysvc.Policy{
+			SubjectType: policysvc.GroupType,
+			Permission:  policysvc.GroupRelation,
+			ObjectType:  policysvc.ThingType,
+			Object:      thingID,
+		}
+		repoCall := cRepo.On("RetrieveAllByIDs", context.Background(), pm).Return(tc.retrieveAllRes, tc.retrieveAllErr)
+		policyCall := pService.On("ListAllSubjects", mock.Anything, listReq).Return(tc.listSubjectsRes, tc.listSubjectsErr)
+		conns, err := svc.ThingsConnections(context.Background(), tc.domainID, tc.ids)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.conns, conns, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.conns, conns))
+		repoCall.Unset()
+		policyCall.Unset()
+	}
+}
+
+func TestUpdatePresence(t *testing.T) {
+	svc := newService()
+
+	online := true
+	now := time.Now()
+	presence := things.Presence{
+		ClientID:   validID,
+		Connection: "conn",
+		Instance:   "mqtt1",
+		Online:     &online,
+		LastSeen:   now,
+		Protocol:   "mqtt",
+	}
+	expected := presence
+	expected.ExpiresAt = now.Add(presenceTTL)
+
+	cases := []struct {
+		desc       string
+		repoResult bool
+		repoErr    error
+		changed    bool
+		err        error
+	}{
+		{
+			desc:       "update presence successfully",
+			repoResult: true,
+			changed:    true,
+		},
+		{
+			desc:    "update presence with failed to update presence",
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("UpdatePresence", context.Background(), expected).Return(tc.repoResult, tc.repoErr)
+		changed, err := svc.UpdatePresence(context.Background(), presence)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.changed, changed, fmt.Sprintf("%s: expected changed %t got %t\n", tc.desc, tc.changed, changed))
+		repoCall.Unset()
+	}
+}
@@ -6,6 +6,7 @@ package things
 import (
 	"encoding/json"
 	"strings"
+	"time"
 
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 )
@@ -38,6 +39,13 @@ const (
 	Unknown  = "unknown"
 )
 
+// String representation of the possible client presence values, used as
+// the status filter when listing clients.
+const (
+	Online  = "online"
+	Offline = "offline"
+)
+
 // String converts client/group status to string literal.
 func (s Status) String() string {
 	switch s {
@@ -76,12 +84,18 @@ func (s Status) MarshalJSON() ([]byte, error) {
 
 func (client Client) MarshalJSON() ([]byte, error) {
 	type Alias Client
+	var lastSeen *time.Time
+	if !client.LastSeen.IsZero() {
+		lastSeen = &client.LastSeen
+	}
 	return json.Marshal(&struct {
 		Alias
-		Status string `json:"status,omitempty"`
+		LastSeen *time.Time `json:"last_seen,omitempty"`
+		Status   string     `json:"status,omitempty"`
 	}{
-		Alias:  (Alias)(client),
-		Status: client.Status.String(),
+		Alias:    (Alias)(client),
+		LastSeen: lastSeen,
+		Status:   client.Status.String(),
 	})
 }
 
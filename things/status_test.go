@@ -5,6 +5,7 @@ package things_test
 
 import (
 	"testing"
+	"time"
 
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/absmach/magistrala/things"
@@ -206,34 +207,40 @@ func TestUserMarshalJSON(t *testing.T) {
 	}{
 		{
 			desc:     "Enabled",
-			expected: []byte(`{"id":"","credentials":{},"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","status":"enabled"}`),
+			expected: []byte(`{"id":"","credentials":{},"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","online":false,"status":"enabled"}`),
 			user:     things.Client{Status: things.EnabledStatus},
 			err:      nil,
 		},
 		{
 			desc:     "Disabled",
-			expected: []byte(`{"id":"","credentials":{},"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","status":"disabled"}`),
+			expected: []byte(`{"id":"","credentials":{},"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","online":false,"status":"disabled"}`),
 			user:     things.Client{Status: things.DisabledStatus},
 			err:      nil,
 		},
 		{
 			desc:     "Deleted",
-			expected: []byte(`{"id":"","credentials":{},"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","status":"deleted"}`),
+			expected: []byte(`{"id":"","credentials":{},"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","online":false,"status":"deleted"}`),
 			user:     things.Client{Status: things.DeletedStatus},
 			err:      nil,
 		},
 		{
 			desc:     "All",
-			expected: []byte(`{"id":"","credentials":{},"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","status":"all"}`),
+			expected: []byte(`{"id":"","credentials":{},"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","online":false,"status":"all"}`),
 			user:     things.Client{Status: things.AllStatus},
 			err:      nil,
 		},
 		{
 			desc:     "Unknown",
-			expected: []byte(`{"id":"","credentials":{},"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","status":"unknown"}`),
+			expected: []byte(`{"id":"","credentials":{},"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","online":false,"status":"unknown"}`),
 			user:     things.Client{Status: things.Status(100)},
 			err:      nil,
 		},
+		{
+			desc:     "Online",
+			expected: []byte(`{"id":"","credentials":{},"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","online":true,"protocol":"mqtt","last_seen":"2024-01-01T00:00:00Z","status":"enabled"}`),
+			user:     things.Client{Status: things.EnabledStatus, Online: true, LastSeen: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Protocol: "mqtt"},
+			err:      nil,
+		},
 	}
 
 	for _, tc := range cases {
@@ -120,6 +120,41 @@ func (tm *tracingMiddleware) Authorize(ctx context.Context, req things.AuthzReq)
 	return tm.svc.Authorize(ctx, req)
 }
 
+// DerivePSK traces the "DerivePSK" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) DerivePSK(ctx context.Context, id string) ([]byte, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_derive_psk", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.DerivePSK(ctx, id)
+}
+
+// ConnectedChannels traces the "ConnectedChannels" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) ConnectedChannels(ctx context.Context, id, key string, psk []byte) ([]string, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_connected_channels", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.ConnectedChannels(ctx, id, key, psk)
+}
+
+// ChannelMetadata traces the "ChannelMetadata" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) ChannelMetadata(ctx context.Context, id string) (map[string]interface{}, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_channel_metadata", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.ChannelMetadata(ctx, id)
+}
+
+// ThingsConnections traces the "ThingsConnections" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) ThingsConnections(ctx context.Context, domainID string, ids []string) (map[string][]string, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_things_connections", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+		attribute.Int("things", len(ids)),
+	))
+	defer span.End()
+
+	return tm.svc.ThingsConnections(ctx, domainID, ids)
+}
+
 // Share traces the "Share" operation of the wrapped things.Service.
 func (tm *tracingMiddleware) Share(ctx context.Context, session authn.Session, id, relation string, userids ...string) error {
 	ctx, span := tm.tracer.Start(ctx, "share", trace.WithAttributes(attribute.String("id", id), attribute.String("relation", relation), attribute.StringSlice("user_ids", userids)))
@@ -140,3 +175,17 @@ func (tm *tracingMiddleware) Delete(ctx context.Context, session authn.Session,
 	defer span.End()
 	return tm.svc.Delete(ctx, session, id)
 }
+
+// UpdatePresence traces the "UpdatePresence" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) UpdatePresence(ctx context.Context, presence things.Presence) (bool, error) {
+	ctx, span := tm.tracer.Start(ctx, "update_presence", trace.WithAttributes(attribute.String("id", presence.ClientID), attribute.String("protocol", presence.Protocol)))
+	defer span.End()
+	return tm.svc.UpdatePresence(ctx, presence)
+}
+
+// ExpirePresence traces the "ExpirePresence" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) ExpirePresence(ctx context.Context) ([]things.Presence, error) {
+	ctx, span := tm.tracer.Start(ctx, "expire_presence")
+	defer span.End()
+	return tm.svc.ExpirePresence(ctx)
+}
@@ -0,0 +1,107 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/twins"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func viewTwinEndpoint(svc twins.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewTwinReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		tw, err := svc.ViewTwin(ctx, session, req.thingID)
+		if err != nil {
+			return nil, err
+		}
+
+		return twinRes{Twin: tw}, nil
+	}
+}
+
+func setDesiredEndpoint(svc twins.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(setDesiredReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		tw, err := svc.SetDesired(ctx, session, req.thingID, req.desired)
+		if err != nil {
+			return nil, err
+		}
+
+		return twinRes{Twin: tw}, nil
+	}
+}
+
+func patchDesiredEndpoint(svc twins.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(patchDesiredReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		tw, err := svc.PatchDesired(ctx, session, req.thingID, req.patch)
+		if err != nil {
+			return nil, err
+		}
+
+		return twinRes{Twin: tw}, nil
+	}
+}
+
+func listHistoryEndpoint(svc twins.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listHistoryReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		page, err := svc.ListHistory(ctx, session, req.thingID, req.pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := statesPageRes{
+			PageMetadata: page.PageMetadata,
+			Total:        page.Total,
+			States:       []twins.TwinState{},
+		}
+		res.States = append(res.States, page.States...)
+
+		return res, nil
+	}
+}
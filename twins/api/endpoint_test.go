@@ -0,0 +1,456 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	authnmocks "github.com/absmach/magistrala/pkg/authn/mocks"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/twins"
+	"github.com/absmach/magistrala/twins/api"
+	"github.com/absmach/magistrala/twins/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	validToken       = "valid"
+	validContentType = "application/json"
+	patchContentType = "application/json-patch+json"
+	userID           = testsutil.GenerateUUID(&testing.T{})
+	domainID         = testsutil.GenerateUUID(&testing.T{})
+	thingID          = testsutil.GenerateUUID(&testing.T{})
+	validSession     = mgauthn.Session{UserID: userID, DomainID: domainID, DomainUserID: domainID + "_" + userID}
+	validTwin        = twins.Twin{
+		ThingID:   thingID,
+		ChannelID: testsutil.GenerateUUID(&testing.T{}),
+		Reported:  twins.State{"temperature": 21.0},
+		Desired:   twins.State{"temperature": 23.0},
+		Version:   2,
+		CreatedAt: time.Now(),
+	}
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	token       string
+	contentType string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+
+	return tr.client.Do(req)
+}
+
+func newTwinsServer() (*httptest.Server, *mocks.Service, *authnmocks.Authentication) {
+	svc := new(mocks.Service)
+	authn := new(authnmocks.Authentication)
+	mux := api.MakeHandler(svc, authn, mglog.NewMock(), "twins", "test")
+
+	return httptest.NewServer(mux), svc, authn
+}
+
+func TestViewTwin(t *testing.T) {
+	ts, svc, authn := newTwinsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "view twin successfully",
+			token:    validToken,
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:   "view twin with empty token",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:     "view twin with invalid token",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "view twin of unauthorized thing",
+			token:    validToken,
+			authnRes: validSession,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+		{
+			desc:     "view non-existing twin",
+			token:    validToken,
+			authnRes: validSession,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("ViewTwin", mock.Anything, tc.authnRes, thingID).Return(validTwin, tc.svcErr)
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/twins/%s", ts.URL, domainID, thingID),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var body map[string]interface{}
+				err := json.NewDecoder(res.Body).Decode(&body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Equal(t, thingID, body["thing_id"], fmt.Sprintf("%s: expected thing %s got %v", tc.desc, thingID, body["thing_id"]))
+				assert.Equal(t, map[string]interface{}{"temperature": 23.0}, body["delta"], fmt.Sprintf("%s: expected delta in response got %v", tc.desc, body["delta"]))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestSetDesired(t *testing.T) {
+	ts, svc, authn := newTwinsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		desired     twins.State
+		authnRes    mgauthn.Session
+		authnErr    error
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "set desired state successfully",
+			token:       validToken,
+			data:        `{"temperature":23}`,
+			contentType: validContentType,
+			desired:     twins.State{"temperature": 23.0},
+			authnRes:    validSession,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "set empty desired state",
+			token:       validToken,
+			data:        `{}`,
+			contentType: validContentType,
+			desired:     twins.State{},
+			authnRes:    validSession,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "set desired state with invalid token",
+			token:       "invalid",
+			data:        `{"temperature":23}`,
+			contentType: validContentType,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "set desired state with invalid content type",
+			token:       validToken,
+			data:        `{"temperature":23}`,
+			contentType: "text/plain",
+			authnRes:    validSession,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "set desired state with malformed body",
+			token:       validToken,
+			data:        `{`,
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "set desired state which is not an object",
+			token:       validToken,
+			data:        `[1]`,
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "set null desired state",
+			token:       validToken,
+			data:        `null`,
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "set desired state with conflicting update",
+			token:       validToken,
+			data:        `{"temperature":23}`,
+			contentType: validContentType,
+			desired:     twins.State{"temperature": 23.0},
+			authnRes:    validSession,
+			svcErr:      svcerr.ErrConflict,
+			status:      http.StatusConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("SetDesired", mock.Anything, tc.authnRes, thingID, tc.desired).Return(validTwin, tc.svcErr)
+			req := testRequest{
+				client:      ts.Client(),
+				method:      http.MethodPut,
+				url:         fmt.Sprintf("%s/%s/twins/%s/desired", ts.URL, domainID, thingID),
+				token:       tc.token,
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestPatchDesired(t *testing.T) {
+	ts, svc, authn := newTwinsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		patch       twins.Patch
+		authnRes    mgauthn.Session
+		authnErr    error
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "patch desired state successfully",
+			token:       validToken,
+			data:        `[{"op":"replace","path":"/temperature","value":23}]`,
+			contentType: patchContentType,
+			patch:       twins.Patch{{Op: twins.ReplaceOp, Path: "/temperature", Value: 23.0}},
+			authnRes:    validSession,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "patch desired state with JSON content type",
+			token:       validToken,
+			data:        `[{"op":"remove","path":"/mode"}]`,
+			contentType: validContentType,
+			patch:       twins.Patch{{Op: twins.RemoveOp, Path: "/mode"}},
+			authnRes:    validSession,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "patch desired state with invalid token",
+			token:       "invalid",
+			data:        `[{"op":"remove","path":"/mode"}]`,
+			contentType: patchContentType,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "patch desired state with invalid content type",
+			token:       validToken,
+			data:        `[{"op":"remove","path":"/mode"}]`,
+			contentType: "text/plain",
+			authnRes:    validSession,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "patch desired state with empty patch",
+			token:       validToken,
+			data:        `[]`,
+			contentType: patchContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "patch desired state with invalid operation",
+			token:       validToken,
+			data:        `[{"op":"invalid","path":"/mode"}]`,
+			contentType: patchContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "patch desired state with malformed body",
+			token:       validToken,
+			data:        `{"op":"remove"}`,
+			contentType: patchContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "patch desired state with failed test operation",
+			token:       validToken,
+			data:        `[{"op":"test","path":"/mode","value":"eco"}]`,
+			contentType: patchContentType,
+			patch:       twins.Patch{{Op: twins.TestOp, Path: "/mode", Value: "eco"}},
+			authnRes:    validSession,
+			svcErr:      svcerr.ErrConflict,
+			status:      http.StatusConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("PatchDesired", mock.Anything, tc.authnRes, thingID, tc.patch).Return(validTwin, tc.svcErr)
+			req := testRequest{
+				client:      ts.Client(),
+				method:      http.MethodPatch,
+				url:         fmt.Sprintf("%s/%s/twins/%s/desired", ts.URL, domainID, thingID),
+				token:       tc.token,
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestListHistory(t *testing.T) {
+	ts, svc, authn := newTwinsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		query    string
+		pm       twins.PageMetadata
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "list history successfully",
+			token:    validToken,
+			pm:       twins.PageMetadata{Limit: 10},
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list history with offset and limit",
+			token:    validToken,
+			query:    "offset=2&limit=5",
+			pm:       twins.PageMetadata{Offset: 2, Limit: 5},
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list history with invalid token",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "list history with invalid limit",
+			token:    validToken,
+			query:    "limit=invalid",
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list history with too big limit",
+			token:    validToken,
+			query:    "limit=1000",
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list history of unauthorized thing",
+			token:    validToken,
+			pm:       twins.PageMetadata{Limit: 10},
+			authnRes: validSession,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			page := twins.StatesPage{
+				PageMetadata: tc.pm,
+				Total:        1,
+				States:       []twins.TwinState{{ThingID: thingID, Version: 1, Cause: twins.DesiredCause}},
+			}
+			svcCall := svc.On("ListHistory", mock.Anything, tc.authnRes, thingID, tc.pm).Return(page, tc.svcErr)
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/twins/%s/history?%s", ts.URL, domainID, thingID, tc.query),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var body struct {
+					Total  uint64 `json:"total"`
+					States []struct {
+						Cause string `json:"cause"`
+					} `json:"states"`
+				}
+				err := json.NewDecoder(res.Body).Decode(&body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Equal(t, uint64(1), body.Total, fmt.Sprintf("%s: expected total 1 got %d", tc.desc, body.Total))
+				assert.Equal(t, twins.Desired, body.States[0].Cause, fmt.Sprintf("%s: expected cause %s got %s", tc.desc, twins.Desired, body.States[0].Cause))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
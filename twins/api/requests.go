@@ -0,0 +1,78 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/twins"
+)
+
+type viewTwinReq struct {
+	thingID string
+}
+
+func (req viewTwinReq) validate() error {
+	if req.thingID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type setDesiredReq struct {
+	thingID string
+	desired twins.State
+}
+
+func (req setDesiredReq) validate() error {
+	if req.thingID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.desired == nil {
+		return errors.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type patchDesiredReq struct {
+	thingID string
+	patch   twins.Patch
+}
+
+func (req patchDesiredReq) validate() error {
+	if req.thingID == "" {
+		return apiutil.ErrMissingID
+	}
+	if len(req.patch) == 0 {
+		return apiutil.ErrEmptyList
+	}
+	for _, op := range req.patch {
+		switch op.Op {
+		case twins.AddOp, twins.RemoveOp, twins.ReplaceOp, twins.MoveOp, twins.CopyOp, twins.TestOp:
+		default:
+			return apiutil.ErrInvalidPatchOp
+		}
+	}
+
+	return nil
+}
+
+type listHistoryReq struct {
+	thingID string
+	pm      twins.PageMetadata
+}
+
+func (req listHistoryReq) validate() error {
+	if req.thingID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.pm.Limit > api.MaxLimitSize || req.pm.Limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/twins"
+)
+
+var (
+	_ magistrala.Response = (*twinRes)(nil)
+	_ magistrala.Response = (*statesPageRes)(nil)
+)
+
+type twinRes struct {
+	twins.Twin `json:",inline"`
+}
+
+func (res twinRes) Code() int {
+	return http.StatusOK
+}
+
+func (res twinRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res twinRes) Empty() bool {
+	return false
+}
+
+type statesPageRes struct {
+	twins.PageMetadata `json:",inline"`
+	Total              uint64            `json:"total"`
+	States             []twins.TwinState `json:"states"`
+}
+
+func (res statesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res statesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res statesPageRes) Empty() bool {
+	return false
+}
@@ -0,0 +1,129 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/twins"
+	"github.com/go-chi/chi/v5"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const (
+	thingIDKey = "thingID"
+	// jsonContentType matches both the JSON and the JSON patch content
+	// types, i.e. application/json and application/json-patch+json.
+	jsonContentType = "json"
+)
+
+// MakeHandler returns a HTTP handler for twins API endpoints.
+func MakeHandler(svc twins.Service, authn mgauthn.Authentication, logger *slog.Logger, svcName, instanceID string) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+	}
+
+	mux := chi.NewRouter()
+
+	mux.Group(func(r chi.Router) {
+		r.Use(api.AuthenticateMiddleware(authn, true))
+
+		r.Route("/{domainID}/twins/{thingID}", func(r chi.Router) {
+			r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+				viewTwinEndpoint(svc),
+				decodeViewTwinReq,
+				api.EncodeResponse,
+				opts...,
+			), "view_twin").ServeHTTP)
+
+			r.Put("/desired", otelhttp.NewHandler(kithttp.NewServer(
+				setDesiredEndpoint(svc),
+				decodeSetDesiredReq,
+				api.EncodeResponse,
+				opts...,
+			), "set_desired").ServeHTTP)
+
+			r.Patch("/desired", otelhttp.NewHandler(kithttp.NewServer(
+				patchDesiredEndpoint(svc),
+				decodePatchDesiredReq,
+				api.EncodeResponse,
+				opts...,
+			), "patch_desired").ServeHTTP)
+
+			r.Get("/history", otelhttp.NewHandler(kithttp.NewServer(
+				listHistoryEndpoint(svc),
+				decodeListHistoryReq,
+				api.EncodeResponse,
+				opts...,
+			), "list_history").ServeHTTP)
+		})
+	})
+
+	mux.Get("/health", magistrala.Health(svcName, instanceID))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+func decodeViewTwinReq(_ context.Context, r *http.Request) (interface{}, error) {
+	return viewTwinReq{thingID: chi.URLParam(r, thingIDKey)}, nil
+}
+
+func decodeSetDesiredReq(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := setDesiredReq{thingID: chi.URLParam(r, thingIDKey)}
+	if err := json.NewDecoder(r.Body).Decode(&req.desired); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodePatchDesiredReq(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), jsonContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := patchDesiredReq{thingID: chi.URLParam(r, thingIDKey)}
+	if err := json.NewDecoder(r.Body).Decode(&req.patch); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeListHistoryReq(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listHistoryReq{
+		thingID: chi.URLParam(r, thingIDKey),
+		pm: twins.PageMetadata{
+			Offset: offset,
+			Limit:  limit,
+		},
+	}
+
+	return req, nil
+}
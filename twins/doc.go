@@ -0,0 +1,8 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package twins contains the domain concept definitions needed to support
+// Magistrala twins service functionality. Twins service keeps the digital
+// twin of each thing: the state the thing reports in its messages and the
+// state users desire, and publishes the difference between them to the thing.
+package twins
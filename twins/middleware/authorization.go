@@ -0,0 +1,80 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	mgauthz "github.com/absmach/magistrala/pkg/authz"
+	"github.com/absmach/magistrala/pkg/policies"
+	"github.com/absmach/magistrala/twins"
+)
+
+var _ twins.Service = (*authorizationMiddleware)(nil)
+
+type authorizationMiddleware struct {
+	svc   twins.Service
+	authz mgauthz.Authorization
+}
+
+// AuthorizationMiddleware adds authorization to the twins service. Twins
+// are viewed by users who can view the thing, and their desired state is
+// changed by users who can edit the thing.
+func AuthorizationMiddleware(svc twins.Service, authz mgauthz.Authorization) twins.Service {
+	return &authorizationMiddleware{
+		svc:   svc,
+		authz: authz,
+	}
+}
+
+func (am *authorizationMiddleware) ViewTwin(ctx context.Context, session mgauthn.Session, thingID string) (twins.Twin, error) {
+	if err := am.authorize(ctx, session, policies.ViewPermission, thingID); err != nil {
+		return twins.Twin{}, err
+	}
+
+	return am.svc.ViewTwin(ctx, session, thingID)
+}
+
+func (am *authorizationMiddleware) SetDesired(ctx context.Context, session mgauthn.Session, thingID string, desired twins.State) (twins.Twin, error) {
+	if err := am.authorize(ctx, session, policies.EditPermission, thingID); err != nil {
+		return twins.Twin{}, err
+	}
+
+	return am.svc.SetDesired(ctx, session, thingID, desired)
+}
+
+func (am *authorizationMiddleware) PatchDesired(ctx context.Context, session mgauthn.Session, thingID string, patch twins.Patch) (twins.Twin, error) {
+	if err := am.authorize(ctx, session, policies.EditPermission, thingID); err != nil {
+		return twins.Twin{}, err
+	}
+
+	return am.svc.PatchDesired(ctx, session, thingID, patch)
+}
+
+func (am *authorizationMiddleware) ListHistory(ctx context.Context, session mgauthn.Session, thingID string, pm twins.PageMetadata) (twins.StatesPage, error) {
+	if err := am.authorize(ctx, session, policies.ViewPermission, thingID); err != nil {
+		return twins.StatesPage{}, err
+	}
+
+	return am.svc.ListHistory(ctx, session, thingID, pm)
+}
+
+func (am *authorizationMiddleware) ConsumeBlocking(ctx context.Context, messages interface{}) error {
+	return am.svc.ConsumeBlocking(ctx, messages)
+}
+
+func (am *authorizationMiddleware) authorize(ctx context.Context, session mgauthn.Session, perm, thingID string) error {
+	req := mgauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     session.DomainUserID,
+		Permission:  perm,
+		ObjectType:  policies.ThingType,
+		Object:      thingID,
+	}
+
+	return am.authz.Authorize(ctx, req)
+}
@@ -0,0 +1,124 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/twins"
+)
+
+var _ twins.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger *slog.Logger
+	svc    twins.Service
+}
+
+// LoggingMiddleware adds logging facilities to the twins service.
+func LoggingMiddleware(svc twins.Service, logger *slog.Logger) twins.Service {
+	return &loggingMiddleware{
+		logger: logger,
+		svc:    svc,
+	}
+}
+
+func (lm *loggingMiddleware) ViewTwin(ctx context.Context, session mgauthn.Session, thingID string) (tw twins.Twin, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("thing_id", thingID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View twin failed", args...)
+			return
+		}
+		lm.logger.Info("View twin completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewTwin(ctx, session, thingID)
+}
+
+func (lm *loggingMiddleware) SetDesired(ctx context.Context, session mgauthn.Session, thingID string, desired twins.State) (tw twins.Twin, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("twin",
+				slog.String("thing_id", thingID),
+				slog.Uint64("version", tw.Version),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Set twin desired state failed", args...)
+			return
+		}
+		lm.logger.Info("Set twin desired state completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.SetDesired(ctx, session, thingID, desired)
+}
+
+func (lm *loggingMiddleware) PatchDesired(ctx context.Context, session mgauthn.Session, thingID string, patch twins.Patch) (tw twins.Twin, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("twin",
+				slog.String("thing_id", thingID),
+				slog.Uint64("version", tw.Version),
+				slog.Int("operations", len(patch)),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Patch twin desired state failed", args...)
+			return
+		}
+		lm.logger.Info("Patch twin desired state completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.PatchDesired(ctx, session, thingID, patch)
+}
+
+func (lm *loggingMiddleware) ListHistory(ctx context.Context, session mgauthn.Session, thingID string, pm twins.PageMetadata) (page twins.StatesPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("thing_id", thingID),
+			slog.Group("page",
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List twin history failed", args...)
+			return
+		}
+		lm.logger.Info("List twin history completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListHistory(ctx, session, thingID, pm)
+}
+
+func (lm *loggingMiddleware) ConsumeBlocking(ctx context.Context, messages interface{}) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Update twin reported state failed", args...)
+			return
+		}
+		lm.logger.Debug("Update twin reported state completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ConsumeBlocking(ctx, messages)
+}
@@ -0,0 +1,75 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"time"
+
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/twins"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ twins.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     twins.Service
+}
+
+// MetricsMiddleware instruments twins service by tracking request count and latency.
+func MetricsMiddleware(svc twins.Service, counter metrics.Counter, latency metrics.Histogram) twins.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (mm *metricsMiddleware) ViewTwin(ctx context.Context, session mgauthn.Session, thingID string) (twins.Twin, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_twin").Add(1)
+		mm.latency.With("method", "view_twin").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewTwin(ctx, session, thingID)
+}
+
+func (mm *metricsMiddleware) SetDesired(ctx context.Context, session mgauthn.Session, thingID string, desired twins.State) (twins.Twin, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "set_desired").Add(1)
+		mm.latency.With("method", "set_desired").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.SetDesired(ctx, session, thingID, desired)
+}
+
+func (mm *metricsMiddleware) PatchDesired(ctx context.Context, session mgauthn.Session, thingID string, patch twins.Patch) (twins.Twin, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "patch_desired").Add(1)
+		mm.latency.With("method", "patch_desired").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.PatchDesired(ctx, session, thingID, patch)
+}
+
+func (mm *metricsMiddleware) ListHistory(ctx context.Context, session mgauthn.Session, thingID string, pm twins.PageMetadata) (twins.StatesPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_history").Add(1)
+		mm.latency.With("method", "list_history").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListHistory(ctx, session, thingID, pm)
+}
+
+func (mm *metricsMiddleware) ConsumeBlocking(ctx context.Context, messages interface{}) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "consume").Add(1)
+		mm.latency.With("method", "consume").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ConsumeBlocking(ctx, messages)
+}
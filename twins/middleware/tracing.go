@@ -0,0 +1,64 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/twins"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ twins.Service = (*tracing)(nil)
+
+type tracing struct {
+	tracer trace.Tracer
+	svc    twins.Service
+}
+
+// Tracing adds tracing to the twins service.
+func Tracing(svc twins.Service, tracer trace.Tracer) twins.Service {
+	return &tracing{tracer, svc}
+}
+
+func (tm *tracing) ViewTwin(ctx context.Context, session mgauthn.Session, thingID string) (twins.Twin, error) {
+	ctx, span := tm.tracer.Start(ctx, "view_twin", trace.WithAttributes(attribute.String("thing_id", thingID)))
+	defer span.End()
+
+	return tm.svc.ViewTwin(ctx, session, thingID)
+}
+
+func (tm *tracing) SetDesired(ctx context.Context, session mgauthn.Session, thingID string, desired twins.State) (twins.Twin, error) {
+	ctx, span := tm.tracer.Start(ctx, "set_desired", trace.WithAttributes(attribute.String("thing_id", thingID)))
+	defer span.End()
+
+	return tm.svc.SetDesired(ctx, session, thingID, desired)
+}
+
+func (tm *tracing) PatchDesired(ctx context.Context, session mgauthn.Session, thingID string, patch twins.Patch) (twins.Twin, error) {
+	ctx, span := tm.tracer.Start(ctx, "patch_desired", trace.WithAttributes(
+		attribute.String("thing_id", thingID),
+		attribute.Int("operations", len(patch)),
+	))
+	defer span.End()
+
+	return tm.svc.PatchDesired(ctx, session, thingID, patch)
+}
+
+func (tm *tracing) ListHistory(ctx context.Context, session mgauthn.Session, thingID string, pm twins.PageMetadata) (twins.StatesPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_history", trace.WithAttributes(
+		attribute.String("thing_id", thingID),
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListHistory(ctx, session, thingID, pm)
+}
+
+func (tm *tracing) ConsumeBlocking(ctx context.Context, messages interface{}) error {
+	return tm.svc.ConsumeBlocking(ctx, messages)
+}
@@ -0,0 +1,320 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package twins
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// Supported JSON patch operations as defined in RFC 6902.
+const (
+	AddOp     = "add"
+	RemoveOp  = "remove"
+	ReplaceOp = "replace"
+	MoveOp    = "move"
+	CopyOp    = "copy"
+	TestOp    = "test"
+)
+
+var (
+	// ErrInvalidPatch indicates a malformed JSON patch document.
+	ErrInvalidPatch = errors.New("invalid JSON patch")
+
+	// ErrPatchTest indicates that the value of the patch test operation
+	// does not match the document value.
+	ErrPatchTest = errors.New("JSON patch test failed")
+)
+
+// PatchOp is a single JSON patch operation.
+type PatchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Patch is a JSON patch document as defined in RFC 6902.
+type Patch []PatchOp
+
+// Apply applies the patch operations in order and returns the patched copy
+// of the state. The state is left unchanged if any operation fails.
+func (p Patch) Apply(state State) (State, error) {
+	doc, err := clone(map[string]interface{}(state))
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidPatch, err)
+	}
+	if doc == nil {
+		doc = map[string]interface{}{}
+	}
+
+	for _, op := range p {
+		if doc, err = op.apply(doc); err != nil {
+			return nil, err
+		}
+	}
+
+	ret, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, errors.Wrap(ErrInvalidPatch, errors.New("state must be a JSON object"))
+	}
+
+	return ret, nil
+}
+
+func (op PatchOp) apply(doc interface{}) (interface{}, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case AddOp:
+		val, err := clone(op.Value)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidPatch, err)
+		}
+		return add(doc, path, val)
+	case RemoveOp:
+		doc, _, err := remove(doc, path)
+		return doc, err
+	case ReplaceOp:
+		val, err := clone(op.Value)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidPatch, err)
+		}
+		if doc, _, err = remove(doc, path); err != nil {
+			return nil, err
+		}
+		return add(doc, path, val)
+	case MoveOp:
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		if len(path) > len(from) && strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, errors.Wrap(ErrInvalidPatch, errors.New("cannot move value into its child"))
+		}
+		doc, val, err := remove(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, val)
+	case CopyOp:
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		val, err := get(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if val, err = clone(val); err != nil {
+			return nil, errors.Wrap(ErrInvalidPatch, err)
+		}
+		return add(doc, path, val)
+	case TestOp:
+		val, err := get(doc, path)
+		if err != nil {
+			return nil, errors.Wrap(ErrPatchTest, err)
+		}
+		want, err := clone(op.Value)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidPatch, err)
+		}
+		if !deepEqual(val, want) {
+			return nil, ErrPatchTest
+		}
+		return doc, nil
+	default:
+		return nil, errors.Wrap(ErrInvalidPatch, errors.New("unsupported operation "+op.Op))
+	}
+}
+
+// parsePointer parses JSON pointer as defined in RFC 6901.
+func parsePointer(p string) ([]string, error) {
+	if p == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(p, "/") {
+		return nil, errors.Wrap(ErrInvalidPatch, errors.New("invalid path "+p))
+	}
+	tokens := strings.Split(p[1:], "/")
+	for i, t := range tokens {
+		t = strings.ReplaceAll(t, "~1", "/")
+		tokens[i] = strings.ReplaceAll(t, "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+func get(doc interface{}, path []string) (interface{}, error) {
+	for _, key := range path {
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			val, ok := node[key]
+			if !ok {
+				return nil, errors.Wrap(ErrInvalidPatch, errors.New("path not found "+key))
+			}
+			doc = val
+		case []interface{}:
+			i, err := index(key, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[i]
+		default:
+			return nil, errors.Wrap(ErrInvalidPatch, errors.New("path not found "+key))
+		}
+	}
+
+	return doc, nil
+}
+
+// add sets the value at the path and returns the updated document.
+func add(doc interface{}, path []string, val interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return val, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	key := path[len(path)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[key] = val
+		return doc, nil
+	case []interface{}:
+		i := len(node)
+		if key != "-" {
+			if i, err = index(key, len(node)); err != nil {
+				return nil, err
+			}
+		}
+		node = append(node, nil)
+		copy(node[i+1:], node[i:])
+		node[i] = val
+		return set(doc, path[:len(path)-1], node)
+	default:
+		return nil, errors.Wrap(ErrInvalidPatch, errors.New("path not found "+key))
+	}
+}
+
+// remove removes the value at the path and returns the updated document and
+// the removed value.
+func remove(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil, errors.Wrap(ErrInvalidPatch, errors.New("cannot remove document root"))
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	key := path[len(path)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		val, ok := node[key]
+		if !ok {
+			return nil, nil, errors.Wrap(ErrInvalidPatch, errors.New("path not found "+key))
+		}
+		delete(node, key)
+		return doc, val, nil
+	case []interface{}:
+		i, err := index(key, len(node)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		val := node[i]
+		node = append(node[:i], node[i+1:]...)
+		doc, err := set(doc, path[:len(path)-1], node)
+		return doc, val, err
+	default:
+		return nil, nil, errors.Wrap(ErrInvalidPatch, errors.New("path not found "+key))
+	}
+}
+
+// set replaces the value at the existing path. It is used to store arrays
+// whose length changed.
+func set(doc interface{}, path []string, val interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return val, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	key := path[len(path)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[key] = val
+	case []interface{}:
+		i, err := index(key, len(node)-1)
+		if err != nil {
+			return nil, err
+		}
+		node[i] = val
+	}
+
+	return doc, nil
+}
+
+func index(key string, last int) (int, error) {
+	i, err := strconv.Atoi(key)
+	if err != nil || i < 0 || i > last || (len(key) > 1 && key[0] == '0') {
+		return 0, errors.Wrap(ErrInvalidPatch, errors.New("invalid array index "+key))
+	}
+
+	return i, nil
+}
+
+// clone returns a deep copy of the JSON value, normalizing its types to the
+// ones produced by JSON decoding.
+func clone(val interface{}) (interface{}, error) {
+	b, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+	var ret interface{}
+	if err := json.Unmarshal(b, &ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func deepEqual(a, b interface{}) bool {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k, v := range av {
+			if w, ok := bv[k]; !ok || !deepEqual(v, w) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !deepEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return equal(a, b)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package twins_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/twins"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatchApply(t *testing.T) {
+	state := func() twins.State {
+		return twins.State{
+			"led":   map[string]interface{}{"color": "red", "on": true},
+			"slots": []interface{}{"a", "b"},
+			"a/b":   1.0,
+		}
+	}
+
+	cases := []struct {
+		desc  string
+		patch twins.Patch
+		state twins.State
+		err   error
+	}{
+		{
+			desc:  "add nested field",
+			patch: twins.Patch{{Op: twins.AddOp, Path: "/led/level", Value: 10}},
+			state: twins.State{
+				"led":   map[string]interface{}{"color": "red", "on": true, "level": 10.0},
+				"slots": []interface{}{"a", "b"},
+				"a/b":   1.0,
+			},
+		},
+		{
+			desc:  "add array element",
+			patch: twins.Patch{{Op: twins.AddOp, Path: "/slots/1", Value: "c"}},
+			state: twins.State{
+				"led":   map[string]interface{}{"color": "red", "on": true},
+				"slots": []interface{}{"a", "c", "b"},
+				"a/b":   1.0,
+			},
+		},
+		{
+			desc:  "remove array element",
+			patch: twins.Patch{{Op: twins.RemoveOp, Path: "/slots/0"}},
+			state: twins.State{
+				"led":   map[string]interface{}{"color": "red", "on": true},
+				"slots": []interface{}{"b"},
+				"a/b":   1.0,
+			},
+		},
+		{
+			desc:  "replace escaped field",
+			patch: twins.Patch{{Op: twins.ReplaceOp, Path: "/a~1b", Value: 2}},
+			state: twins.State{
+				"led":   map[string]interface{}{"color": "red", "on": true},
+				"slots": []interface{}{"a", "b"},
+				"a/b":   2.0,
+			},
+		},
+		{
+			desc:  "move field",
+			patch: twins.Patch{{Op: twins.MoveOp, From: "/led/color", Path: "/color"}},
+			state: twins.State{
+				"led":   map[string]interface{}{"on": true},
+				"color": "red",
+				"slots": []interface{}{"a", "b"},
+				"a/b":   1.0,
+			},
+		},
+		{
+			desc:  "copy field",
+			patch: twins.Patch{{Op: twins.CopyOp, From: "/led", Path: "/backup"}},
+			state: twins.State{
+				"led":    map[string]interface{}{"color": "red", "on": true},
+				"backup": map[string]interface{}{"color": "red", "on": true},
+				"slots":  []interface{}{"a", "b"},
+				"a/b":    1.0,
+			},
+		},
+		{
+			desc:  "test nested object",
+			patch: twins.Patch{{Op: twins.TestOp, Path: "/led", Value: map[string]interface{}{"on": true, "color": "red"}}},
+			state: state(),
+		},
+		{
+			desc:  "test mismatched value",
+			patch: twins.Patch{{Op: twins.TestOp, Path: "/led/on", Value: false}},
+			err:   twins.ErrPatchTest,
+		},
+		{
+			desc:  "move field into its child",
+			patch: twins.Patch{{Op: twins.MoveOp, From: "/led", Path: "/led/inner"}},
+			err:   twins.ErrInvalidPatch,
+		},
+		{
+			desc:  "remove out of range array element",
+			patch: twins.Patch{{Op: twins.RemoveOp, Path: "/slots/2"}},
+			err:   twins.ErrInvalidPatch,
+		},
+		{
+			desc:  "replace document root",
+			patch: twins.Patch{{Op: twins.ReplaceOp, Path: "", Value: 1}},
+			err:   twins.ErrInvalidPatch,
+		},
+		{
+			desc:  "apply unsupported operation",
+			patch: twins.Patch{{Op: "merge", Path: "/led"}},
+			err:   twins.ErrInvalidPatch,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			orig := state()
+			patched, err := tc.patch.Apply(orig)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.state, patched, fmt.Sprintf("%s: expected state %v got %v\n", tc.desc, tc.state, patched))
+			assert.Equal(t, state(), orig, fmt.Sprintf("%s: expected original state to stay unchanged", tc.desc))
+		})
+	}
+}
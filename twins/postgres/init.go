@@ -0,0 +1,48 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Migration of twins service.
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "twins_01",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS twins (
+						thing_id		VARCHAR(36) PRIMARY KEY,
+						channel_id		VARCHAR(36),
+						reported		JSONB NOT NULL DEFAULT '{}',
+						desired			JSONB NOT NULL DEFAULT '{}',
+						version			BIGINT NOT NULL CHECK (version > 0),
+						reported_at		TIMESTAMP,
+						desired_at		TIMESTAMP,
+						desired_by		VARCHAR(254),
+						created_at		TIMESTAMP NOT NULL,
+						updated_at		TIMESTAMP
+					)`,
+					`CREATE TABLE IF NOT EXISTS twin_states (
+						thing_id		VARCHAR(36) NOT NULL REFERENCES twins(thing_id) ON DELETE CASCADE,
+						version			BIGINT NOT NULL,
+						cause			SMALLINT NOT NULL CHECK (cause >= 0),
+						reported		JSONB NOT NULL DEFAULT '{}',
+						desired			JSONB NOT NULL DEFAULT '{}',
+						created_by		VARCHAR(254),
+						created_at		TIMESTAMP NOT NULL,
+						PRIMARY KEY (thing_id, version)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS twin_states`,
+					`DROP TABLE IF EXISTS twins`,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,279 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+	"github.com/absmach/magistrala/twins"
+)
+
+const (
+	twinColumns = `thing_id, channel_id, reported, desired, version, reported_at, desired_at, desired_by,
+	created_at, updated_at`
+	stateColumns = `thing_id, version, cause, reported, desired, created_by, created_at`
+)
+
+var _ twins.Repository = (*repository)(nil)
+
+type repository struct {
+	db postgres.Database
+}
+
+// NewRepository instantiates a PostgreSQL implementation of twins repository.
+func NewRepository(db postgres.Database) twins.Repository {
+	return &repository{db: db}
+}
+
+func (repo *repository) Save(ctx context.Context, twin twins.Twin, cause twins.Cause) (err error) {
+	dbt, err := toDBTwin(twin)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = errors.Wrap(apiutil.ErrRollbackTx, errRollback)
+			}
+		}
+	}()
+
+	q := fmt.Sprintf(`INSERT INTO twins (%s)
+		VALUES (:thing_id, :channel_id, :reported, :desired, :version, :reported_at, :desired_at, :desired_by,
+		:created_at, :updated_at);`, twinColumns)
+	// Only the twin which is not changed since the previous version was
+	// retrieved is updated.
+	if twin.Version > 1 {
+		q = `UPDATE twins SET channel_id = :channel_id, reported = :reported, desired = :desired, version = :version,
+			reported_at = :reported_at, desired_at = :desired_at, desired_by = :desired_by, updated_at = :updated_at
+			WHERE thing_id = :thing_id AND version = :prev_version;`
+	}
+	params := struct {
+		dbTwin
+		PrevVersion uint64 `db:"prev_version"`
+	}{
+		dbTwin:      dbt,
+		PrevVersion: twin.Version - 1,
+	}
+
+	res, err := tx.NamedExecContext(ctx, q, params)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if cnt == 0 {
+		err = repoerr.ErrConflict
+		return err
+	}
+
+	createdBy := twin.ThingID
+	if cause == twins.DesiredCause {
+		createdBy = twin.DesiredBy
+	}
+	state := dbState{
+		ThingID:   dbt.ThingID,
+		Version:   dbt.Version,
+		Cause:     cause,
+		Reported:  dbt.Reported,
+		Desired:   dbt.Desired,
+		CreatedBy: nullString(createdBy),
+		CreatedAt: twin.UpdatedAt.UTC(),
+	}
+	q = fmt.Sprintf(`INSERT INTO twin_states (%s)
+		VALUES (:thing_id, :version, :cause, :reported, :desired, :created_by, :created_at);`, stateColumns)
+	if _, err = tx.NamedExecContext(ctx, q, state); err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveByThing(ctx context.Context, thingID string) (twins.Twin, error) {
+	q := fmt.Sprintf(`SELECT %s FROM twins WHERE thing_id = :thing_id;`, twinColumns)
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbTwin{ThingID: thingID})
+	if err != nil {
+		return twins.Twin{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return twins.Twin{}, errors.Wrap(repoerr.ErrNotFound, sql.ErrNoRows)
+	}
+	var dbt dbTwin
+	if err := rows.StructScan(&dbt); err != nil {
+		return twins.Twin{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return toTwin(dbt)
+}
+
+func (repo *repository) RetrieveStates(ctx context.Context, thingID string, pm twins.PageMetadata) (twins.StatesPage, error) {
+	q := fmt.Sprintf(`SELECT %s FROM twin_states WHERE thing_id = :thing_id
+		ORDER BY version DESC LIMIT :limit OFFSET :offset;`, stateColumns)
+
+	params := map[string]interface{}{
+		"thing_id": thingID,
+		"limit":    pm.Limit,
+		"offset":   pm.Offset,
+	}
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return twins.StatesPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var states []twins.TwinState
+	for rows.Next() {
+		var dbs dbState
+		if err := rows.StructScan(&dbs); err != nil {
+			return twins.StatesPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		state, err := toState(dbs)
+		if err != nil {
+			return twins.StatesPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		states = append(states, state)
+	}
+
+	cq := `SELECT COUNT(*) FROM twin_states WHERE thing_id = :thing_id;`
+	total, err := postgres.Total(ctx, repo.db, cq, params)
+	if err != nil {
+		return twins.StatesPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return twins.StatesPage{
+		PageMetadata: pm,
+		Total:        total,
+		States:       states,
+	}, nil
+}
+
+type dbTwin struct {
+	ThingID    string         `db:"thing_id"`
+	ChannelID  sql.NullString `db:"channel_id"`
+	Reported   []byte         `db:"reported"`
+	Desired    []byte         `db:"desired"`
+	Version    uint64         `db:"version"`
+	ReportedAt sql.NullTime   `db:"reported_at"`
+	DesiredAt  sql.NullTime   `db:"desired_at"`
+	DesiredBy  sql.NullString `db:"desired_by"`
+	CreatedAt  time.Time      `db:"created_at"`
+	UpdatedAt  sql.NullTime   `db:"updated_at"`
+}
+
+type dbState struct {
+	ThingID   string         `db:"thing_id"`
+	Version   uint64         `db:"version"`
+	Cause     twins.Cause    `db:"cause"`
+	Reported  []byte         `db:"reported"`
+	Desired   []byte         `db:"desired"`
+	CreatedBy sql.NullString `db:"created_by"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+func toDBTwin(tw twins.Twin) (dbTwin, error) {
+	reported, err := toJSON(tw.Reported)
+	if err != nil {
+		return dbTwin{}, err
+	}
+	desired, err := toJSON(tw.Desired)
+	if err != nil {
+		return dbTwin{}, err
+	}
+
+	return dbTwin{
+		ThingID:    tw.ThingID,
+		ChannelID:  nullString(tw.ChannelID),
+		Reported:   reported,
+		Desired:    desired,
+		Version:    tw.Version,
+		ReportedAt: nullTime(tw.ReportedAt),
+		DesiredAt:  nullTime(tw.DesiredAt),
+		DesiredBy:  nullString(tw.DesiredBy),
+		CreatedAt:  tw.CreatedAt.UTC(),
+		UpdatedAt:  nullTime(tw.UpdatedAt),
+	}, nil
+}
+
+func toTwin(dbt dbTwin) (twins.Twin, error) {
+	var reported, desired twins.State
+	if err := json.Unmarshal(dbt.Reported, &reported); err != nil {
+		return twins.Twin{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	if err := json.Unmarshal(dbt.Desired, &desired); err != nil {
+		return twins.Twin{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return twins.Twin{
+		ThingID:    dbt.ThingID,
+		ChannelID:  dbt.ChannelID.String,
+		Reported:   reported,
+		Desired:    desired,
+		Version:    dbt.Version,
+		ReportedAt: dbt.ReportedAt.Time,
+		DesiredAt:  dbt.DesiredAt.Time,
+		DesiredBy:  dbt.DesiredBy.String,
+		CreatedAt:  dbt.CreatedAt,
+		UpdatedAt:  dbt.UpdatedAt.Time,
+	}, nil
+}
+
+func toState(dbs dbState) (twins.TwinState, error) {
+	var reported, desired twins.State
+	if err := json.Unmarshal(dbs.Reported, &reported); err != nil {
+		return twins.TwinState{}, err
+	}
+	if err := json.Unmarshal(dbs.Desired, &desired); err != nil {
+		return twins.TwinState{}, err
+	}
+
+	return twins.TwinState{
+		ThingID:   dbs.ThingID,
+		Version:   dbs.Version,
+		Cause:     dbs.Cause,
+		Reported:  reported,
+		Desired:   desired,
+		CreatedBy: dbs.CreatedBy.String,
+		CreatedAt: dbs.CreatedAt,
+	}, nil
+}
+
+// toJSON stores empty states as empty JSON objects.
+func toJSON(state twins.State) ([]byte, error) {
+	if state == nil {
+		state = twins.State{}
+	}
+
+	return json.Marshal(state)
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t.UTC(), Valid: !t.IsZero()}
+}
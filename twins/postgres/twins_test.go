@@ -0,0 +1,262 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/twins"
+	tpostgres "github.com/absmach/magistrala/twins/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cleanup(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM twin_states")
+		require.Nil(t, err, fmt.Sprintf("clean twin states unexpected error: %s", err))
+		_, err = db.Exec("DELETE FROM twins")
+		require.Nil(t, err, fmt.Sprintf("clean twins unexpected error: %s", err))
+	})
+}
+
+func TestSave(t *testing.T) {
+	cleanup(t)
+	repo := tpostgres.NewRepository(database)
+
+	thingID := testsutil.GenerateUUID(t)
+	userID := testsutil.GenerateUUID(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	created := twins.Twin{
+		ThingID:    thingID,
+		ChannelID:  testsutil.GenerateUUID(t),
+		Reported:   twins.State{"temperature": 21.0},
+		Version:    1,
+		ReportedAt: now,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	desired := created
+	desired.Desired = twins.State{"temperature": 23.0}
+	desired.DesiredBy = userID
+	desired.DesiredAt = now
+	desired.Version = 2
+
+	cases := []struct {
+		desc  string
+		twin  twins.Twin
+		cause twins.Cause
+		err   error
+	}{
+		{
+			desc:  "save new twin",
+			twin:  created,
+			cause: twins.ReportedCause,
+		},
+		{
+			desc:  "save new twin of the same thing",
+			twin:  created,
+			cause: twins.ReportedCause,
+			err:   repoerr.ErrConflict,
+		},
+		{
+			desc:  "save next twin version",
+			twin:  desired,
+			cause: twins.DesiredCause,
+		},
+		{
+			desc:  "save stale twin version",
+			twin:  desired,
+			cause: twins.DesiredCause,
+			err:   repoerr.ErrConflict,
+		},
+		{
+			desc: "save next version of non-existing twin",
+			twin: twins.Twin{
+				ThingID:   testsutil.GenerateUUID(t),
+				Version:   2,
+				CreatedAt: now,
+			},
+			cause: twins.ReportedCause,
+			err:   repoerr.ErrConflict,
+		},
+		{
+			desc: "save twin with invalid state",
+			twin: twins.Twin{
+				ThingID:   testsutil.GenerateUUID(t),
+				Reported:  twins.State{"invalid": make(chan int)},
+				Version:   1,
+				CreatedAt: now,
+			},
+			cause: twins.ReportedCause,
+			err:   repoerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.Save(context.Background(), tc.twin, tc.cause)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+		})
+	}
+
+	tw, err := repo.RetrieveByThing(context.Background(), thingID)
+	require.Nil(t, err, fmt.Sprintf("retrieve twin unexpected error: %s", err))
+	assert.Equal(t, desired.Version, tw.Version)
+	assert.Equal(t, desired.Reported, tw.Reported)
+	assert.Equal(t, desired.Desired, tw.Desired)
+	assert.Equal(t, desired.DesiredBy, tw.DesiredBy)
+
+	page, err := repo.RetrieveStates(context.Background(), thingID, twins.PageMetadata{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("retrieve states unexpected error: %s", err))
+	require.Len(t, page.States, 2)
+	assert.Equal(t, userID, page.States[0].CreatedBy, "expected desired state created by the user")
+	assert.Equal(t, thingID, page.States[1].CreatedBy, "expected reported state created by the thing")
+}
+
+func TestRetrieveByThing(t *testing.T) {
+	cleanup(t)
+	repo := tpostgres.NewRepository(database)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	tw := twins.Twin{
+		ThingID:    testsutil.GenerateUUID(t),
+		ChannelID:  testsutil.GenerateUUID(t),
+		Reported:   twins.State{"temperature": 21.0, "config": map[string]interface{}{"mode": "eco"}},
+		Desired:    twins.State{"temperature": 23.0},
+		Version:    1,
+		ReportedAt: now,
+		DesiredAt:  now,
+		DesiredBy:  testsutil.GenerateUUID(t),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	err := repo.Save(context.Background(), tw, twins.DesiredCause)
+	require.Nil(t, err, fmt.Sprintf("save twin unexpected error: %s", err))
+
+	empty := twins.Twin{
+		ThingID:   testsutil.GenerateUUID(t),
+		Version:   1,
+		CreatedAt: now,
+	}
+	err = repo.Save(context.Background(), empty, twins.ReportedCause)
+	require.Nil(t, err, fmt.Sprintf("save twin unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		thingID string
+		twin    twins.Twin
+		err     error
+	}{
+		{
+			desc:    "retrieve existing twin",
+			thingID: tw.ThingID,
+			twin:    tw,
+		},
+		{
+			desc:    "retrieve twin with empty states",
+			thingID: empty.ThingID,
+			twin: twins.Twin{
+				ThingID:   empty.ThingID,
+				Reported:  twins.State{},
+				Desired:   twins.State{},
+				Version:   1,
+				CreatedAt: now,
+			},
+		},
+		{
+			desc:    "retrieve non-existing twin",
+			thingID: testsutil.GenerateUUID(t),
+			err:     repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := repo.RetrieveByThing(context.Background(), tc.thingID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.twin, got, fmt.Sprintf("%s: expected twin %v got %v", tc.desc, tc.twin, got))
+			}
+		})
+	}
+}
+
+func TestRetrieveStates(t *testing.T) {
+	cleanup(t)
+	repo := tpostgres.NewRepository(database)
+
+	thingID := testsutil.GenerateUUID(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	num := 10
+
+	tw := twins.Twin{ThingID: thingID, CreatedAt: now}
+	for i := 1; i <= num; i++ {
+		tw.Version = uint64(i)
+		tw.Reported = twins.State{"counter": float64(i)}
+		tw.UpdatedAt = now.Add(time.Duration(i) * time.Second)
+		err := repo.Save(context.Background(), tw, twins.ReportedCause)
+		require.Nil(t, err, fmt.Sprintf("save twin unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc    string
+		thingID string
+		pm      twins.PageMetadata
+		total   uint64
+		size    int
+		version uint64
+	}{
+		{
+			desc:    "retrieve all states",
+			thingID: thingID,
+			pm:      twins.PageMetadata{Limit: 100},
+			total:   uint64(num),
+			size:    num,
+			version: 10,
+		},
+		{
+			desc:    "retrieve states with offset and limit",
+			thingID: thingID,
+			pm:      twins.PageMetadata{Offset: 3, Limit: 2},
+			total:   uint64(num),
+			size:    2,
+			version: 7,
+		},
+		{
+			desc:    "retrieve states with offset out of range",
+			thingID: thingID,
+			pm:      twins.PageMetadata{Offset: 20, Limit: 10},
+			total:   uint64(num),
+		},
+		{
+			desc:    "retrieve states of non-existing twin",
+			thingID: testsutil.GenerateUUID(t),
+			pm:      twins.PageMetadata{Limit: 10},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RetrieveStates(context.Background(), tc.thingID, tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+			assert.Len(t, page.States, tc.size, fmt.Sprintf("%s: expected %d states got %d", tc.desc, tc.size, len(page.States)))
+			if tc.size > 0 {
+				state := page.States[0]
+				assert.Equal(t, tc.version, state.Version, fmt.Sprintf("%s: expected latest version %d got %d", tc.desc, tc.version, state.Version))
+				assert.Equal(t, twins.State{"counter": float64(tc.version)}, state.Reported)
+				assert.Equal(t, twins.ReportedCause, state.Cause)
+				assert.Equal(t, now.Add(time.Duration(tc.version)*time.Second), state.CreatedAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,345 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package twins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+	mgjson "github.com/absmach/magistrala/pkg/transformers/json"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+)
+
+// maxRetries is the number of attempts to save the twin when concurrent
+// state changes conflict.
+const maxRetries = 3
+
+var (
+	// ErrPublishDelta indicates a failure to publish the twin delta.
+	ErrPublishDelta = errors.New("failed to publish twin delta")
+
+	// ErrUnsupportedMessage indicates the consumed messages format is not
+	// supported.
+	ErrUnsupportedMessage = errors.New("unsupported message format")
+)
+
+// DeltaMessage is the payload published to the thing when its twin delta
+// changes.
+type DeltaMessage struct {
+	Version uint64 `json:"version"`
+	Delta   State  `json:"delta"`
+}
+
+// Service specifies an API for managing the thing twins.
+//
+//go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
+type Service interface {
+	// ViewTwin retrieves the twin of the thing.
+	ViewTwin(ctx context.Context, session mgauthn.Session, thingID string) (Twin, error)
+
+	// SetDesired replaces the desired state of the thing twin. The twin is
+	// created if it does not exist.
+	SetDesired(ctx context.Context, session mgauthn.Session, thingID string, desired State) (Twin, error)
+
+	// PatchDesired applies the JSON patch to the desired state of the thing
+	// twin.
+	PatchDesired(ctx context.Context, session mgauthn.Session, thingID string, patch Patch) (Twin, error)
+
+	// ListHistory retrieves the state history of the thing twin.
+	ListHistory(ctx context.Context, session mgauthn.Session, thingID string, pm PageMetadata) (StatesPage, error)
+
+	// ConsumeBlocking updates the reported state of the twins of the things
+	// which published the messages.
+	ConsumeBlocking(ctx context.Context, messages interface{}) error
+}
+
+var _ Service = (*service)(nil)
+
+type service struct {
+	repo      Repository
+	publisher messaging.Publisher
+}
+
+// New instantiates the twins service implementation.
+func New(repo Repository, publisher messaging.Publisher) Service {
+	return &service{
+		repo:      repo,
+		publisher: publisher,
+	}
+}
+
+func (svc *service) ViewTwin(ctx context.Context, session mgauthn.Session, thingID string) (Twin, error) {
+	tw, err := svc.repo.RetrieveByThing(ctx, thingID)
+	if err != nil {
+		return Twin{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return tw, nil
+}
+
+func (svc *service) SetDesired(ctx context.Context, session mgauthn.Session, thingID string, desired State) (Twin, error) {
+	if desired == nil {
+		return Twin{}, svcerr.ErrMalformedEntity
+	}
+
+	return svc.update(ctx, thingID, DesiredCause, func(tw *Twin) (bool, error) {
+		tw.Desired = desired
+		tw.DesiredBy = session.UserID
+		tw.DesiredAt = time.Now()
+		return true, nil
+	})
+}
+
+func (svc *service) PatchDesired(ctx context.Context, session mgauthn.Session, thingID string, patch Patch) (Twin, error) {
+	if len(patch) == 0 {
+		return Twin{}, svcerr.ErrMalformedEntity
+	}
+
+	return svc.update(ctx, thingID, DesiredCause, func(tw *Twin) (bool, error) {
+		desired, err := patch.Apply(tw.Desired)
+		switch {
+		case errors.Contains(err, ErrPatchTest):
+			return false, errors.Wrap(svcerr.ErrConflict, err)
+		case err != nil:
+			return false, errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+		tw.Desired = desired
+		tw.DesiredBy = session.UserID
+		tw.DesiredAt = time.Now()
+		return true, nil
+	})
+}
+
+func (svc *service) ListHistory(ctx context.Context, session mgauthn.Session, thingID string, pm PageMetadata) (StatesPage, error) {
+	page, err := svc.repo.RetrieveStates(ctx, thingID, pm)
+	if err != nil {
+		return StatesPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (svc *service) ConsumeBlocking(ctx context.Context, messages interface{}) error {
+	var reports map[string]*report
+	switch m := messages.(type) {
+	case mgjson.Messages:
+		reports = jsonReports(m.Data)
+	case []senml.Message:
+		reports = senmlReports(m)
+	default:
+		return ErrUnsupportedMessage
+	}
+
+	for thingID, r := range reports {
+		if len(r.state) == 0 {
+			continue
+		}
+		if err := svc.report(ctx, thingID, r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// report merges the reported fields into the reported state of the twin.
+func (svc *service) report(ctx context.Context, thingID string, r *report) error {
+	_, err := svc.update(ctx, thingID, ReportedCause, func(tw *Twin) (bool, error) {
+		changed := tw.ChannelID != r.channelID
+		reported := State{}
+		for key, val := range tw.Reported {
+			reported[key] = val
+		}
+		for key, val := range r.state {
+			if cur, ok := reported[key]; !ok || !deepEqual(cur, val) {
+				reported[key] = val
+				changed = true
+			}
+		}
+		if !changed {
+			return false, nil
+		}
+		tw.Reported = reported
+		tw.ChannelID = r.channelID
+		if r.at.After(tw.ReportedAt) {
+			tw.ReportedAt = r.at
+		}
+		return true, nil
+	})
+
+	return err
+}
+
+// update applies the change to the latest twin version and saves it. Saving
+// is retried if the twin is changed concurrently. The delta is published to
+// the thing if it changed or if the desired state is updated.
+func (svc *service) update(ctx context.Context, thingID string, cause Cause, change func(tw *Twin) (bool, error)) (Twin, error) {
+	for i := 0; i < maxRetries; i++ {
+		tw, err := svc.repo.RetrieveByThing(ctx, thingID)
+		switch {
+		case errors.Contains(err, repoerr.ErrNotFound):
+			tw = Twin{ThingID: thingID, CreatedAt: time.Now()}
+		case err != nil:
+			return Twin{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+
+		prev := tw.Delta()
+		ok, err := change(&tw)
+		if err != nil {
+			return Twin{}, err
+		}
+		if !ok {
+			return tw, nil
+		}
+		tw.Version++
+		tw.UpdatedAt = time.Now()
+
+		err = svc.repo.Save(ctx, tw, cause)
+		switch {
+		case errors.Contains(err, repoerr.ErrConflict):
+			continue
+		case err != nil:
+			return Twin{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+
+		delta := tw.Delta()
+		if cause == DesiredCause || !deepEqual(map[string]interface{}(prev), map[string]interface{}(delta)) {
+			if err := svc.publishDelta(ctx, tw, delta); err != nil {
+				return tw, err
+			}
+		}
+
+		return tw, nil
+	}
+
+	return Twin{}, errors.Wrap(svcerr.ErrConflict, repoerr.ErrConflict)
+}
+
+// publishDelta publishes the non-empty delta to the control subtopic of the
+// channel the thing reports its state to.
+func (svc *service) publishDelta(ctx context.Context, tw Twin, delta State) error {
+	if tw.ChannelID == "" || len(delta) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(DeltaMessage{Version: tw.Version, Delta: delta})
+	if err != nil {
+		return errors.Wrap(ErrPublishDelta, err)
+	}
+	msg := &messaging.Message{
+		Channel:   tw.ChannelID,
+		Subtopic:  fmt.Sprintf("%s.%s", ControlSubtopic, tw.ThingID),
+		Publisher: Publisher,
+		Protocol:  Protocol,
+		Payload:   payload,
+		Created:   time.Now().UnixNano(),
+	}
+	if err := svc.publisher.Publish(ctx, tw.ChannelID, msg); err != nil {
+		return errors.Wrap(ErrPublishDelta, err)
+	}
+
+	return nil
+}
+
+// report is the state reported by the thing in the consumed messages.
+type report struct {
+	channelID string
+	state     State
+	at        time.Time
+	times     map[string]float64
+}
+
+// senmlReports builds the reported state from the latest value of each
+// SenML record name.
+func senmlReports(msgs []senml.Message) map[string]*report {
+	reports := map[string]*report{}
+	for _, msg := range msgs {
+		if skip(msg.Publisher, msg.Protocol, msg.Subtopic) || msg.Name == "" {
+			continue
+		}
+		r := reportOf(reports, msg.Publisher, msg.Channel)
+		if t, ok := r.times[msg.Name]; ok && t > msg.Time {
+			continue
+		}
+
+		var val interface{}
+		switch {
+		case msg.Value != nil:
+			val = *msg.Value
+		case msg.StringValue != nil:
+			val = *msg.StringValue
+		case msg.BoolValue != nil:
+			val = *msg.BoolValue
+		case msg.DataValue != nil:
+			val = *msg.DataValue
+		case msg.Sum != nil:
+			val = *msg.Sum
+		default:
+			continue
+		}
+
+		r.times[msg.Name] = msg.Time
+		r.state[msg.Name] = val
+		r.channelID = msg.Channel
+		at := time.Unix(0, int64(msg.Time*float64(time.Second)))
+		if at.After(r.at) {
+			r.at = at
+		}
+	}
+
+	return reports
+}
+
+// jsonReports builds the reported state from the fields of the latest JSON
+// messages.
+func jsonReports(msgs []mgjson.Message) map[string]*report {
+	reports := map[string]*report{}
+	for _, msg := range msgs {
+		if skip(msg.Publisher, msg.Protocol, msg.Subtopic) {
+			continue
+		}
+		r := reportOf(reports, msg.Publisher, msg.Channel)
+		for key, val := range msg.Payload {
+			if t, ok := r.times[key]; ok && t > float64(msg.Created) {
+				continue
+			}
+			r.times[key] = float64(msg.Created)
+			r.state[key] = val
+		}
+		r.channelID = msg.Channel
+		if at := time.Unix(0, msg.Created); at.After(r.at) {
+			r.at = at
+		}
+	}
+
+	return reports
+}
+
+func reportOf(reports map[string]*report, thingID, channelID string) *report {
+	r, ok := reports[thingID]
+	if !ok {
+		r = &report{channelID: channelID, state: State{}, times: map[string]float64{}}
+		reports[thingID] = r
+	}
+
+	return r
+}
+
+// skip reports whether the message is not a thing state report. Deltas
+// published by the service and messages sent to the things are skipped.
+func skip(publisher, protocol, subtopic string) bool {
+	return publisher == "" ||
+		publisher == Publisher ||
+		protocol == Protocol ||
+		subtopic == ControlSubtopic ||
+		strings.HasPrefix(subtopic, ControlSubtopic+".")
+}
@@ -0,0 +1,287 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package twins_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+	pubsubmocks "github.com/absmach/magistrala/pkg/messaging/mocks"
+	mgjson "github.com/absmach/magistrala/pkg/transformers/json"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"github.com/absmach/magistrala/twins"
+	"github.com/absmach/magistrala/twins/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	domainID  = testsutil.GenerateUUID(&testing.T{})
+	userID    = testsutil.GenerateUUID(&testing.T{})
+	channelID = testsutil.GenerateUUID(&testing.T{})
+	thingID   = testsutil.GenerateUUID(&testing.T{})
+	session   = mgauthn.Session{DomainID: domainID, UserID: userID, DomainUserID: domainID + "_" + userID}
+)
+
+func newService() (twins.Service, *mocks.Repository, *pubsubmocks.PubSub) {
+	repo := new(mocks.Repository)
+	pub := new(pubsubmocks.PubSub)
+
+	return twins.New(repo, pub), repo, pub
+}
+
+func TestSetDesired(t *testing.T) {
+	svc, repo, pub := newService()
+
+	reported := twins.Twin{
+		ThingID:   thingID,
+		ChannelID: channelID,
+		Reported:  twins.State{"temperature": 21.0, "mode": "eco"},
+		Desired:   twins.State{},
+		Version:   3,
+	}
+
+	cases := []struct {
+		desc        string
+		desired     twins.State
+		twin        twins.Twin
+		retrieveErr error
+		saveErr     error
+		version     uint64
+		delta       twins.State
+		err         error
+	}{
+		{
+			desc:    "set desired state of reporting thing",
+			desired: twins.State{"temperature": 23.0, "mode": "eco"},
+			twin:    reported,
+			version: 4,
+			delta:   twins.State{"temperature": 23.0},
+		},
+		{
+			desc:        "set desired state of new twin",
+			desired:     twins.State{"temperature": 23.0},
+			retrieveErr: repoerr.ErrNotFound,
+			version:     1,
+		},
+		{
+			desc:    "set desired state with conflicting save",
+			desired: twins.State{"temperature": 23.0},
+			twin:    reported,
+			saveErr: repoerr.ErrConflict,
+			err:     svcerr.ErrConflict,
+		},
+		{
+			desc:    "set desired state with failed save",
+			desired: twins.State{"temperature": 23.0},
+			twin:    reported,
+			saveErr: repoerr.ErrUpdateEntity,
+			err:     svcerr.ErrUpdateEntity,
+		},
+		{
+			desc: "set empty desired state",
+			err:  svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveByThing", context.Background(), thingID).Return(tc.twin, tc.retrieveErr)
+			repoCall1 := repo.On("Save", context.Background(), mock.Anything, twins.DesiredCause).Return(tc.saveErr)
+			pubCall := pub.On("Publish", context.Background(), channelID, mock.Anything).Return(nil)
+			tw, err := svc.SetDesired(context.Background(), session, thingID, tc.desired)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.version, tw.Version, fmt.Sprintf("%s: expected version %d got %d\n", tc.desc, tc.version, tw.Version))
+				assert.Equal(t, userID, tw.DesiredBy, fmt.Sprintf("%s: expected desired by %s got %s\n", tc.desc, userID, tw.DesiredBy))
+			}
+			if tc.delta != nil {
+				msg := pub.Calls[len(pub.Calls)-1].Arguments.Get(2).(*messaging.Message)
+				assert.Equal(t, "control."+thingID, msg.GetSubtopic(), fmt.Sprintf("%s: expected control subtopic got %s\n", tc.desc, msg.GetSubtopic()))
+				assert.Equal(t, twins.Publisher, msg.GetPublisher(), fmt.Sprintf("%s: expected publisher %s got %s\n", tc.desc, twins.Publisher, msg.GetPublisher()))
+				var delta twins.DeltaMessage
+				assert.Nil(t, json.Unmarshal(msg.GetPayload(), &delta), fmt.Sprintf("%s: unexpected error decoding delta", tc.desc))
+				assert.Equal(t, tc.delta, delta.Delta, fmt.Sprintf("%s: expected delta %v got %v\n", tc.desc, tc.delta, delta.Delta))
+				assert.Equal(t, tc.version, delta.Version, fmt.Sprintf("%s: expected delta version %d got %d\n", tc.desc, tc.version, delta.Version))
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+			pubCall.Unset()
+		})
+	}
+}
+
+func TestPatchDesired(t *testing.T) {
+	svc, repo, pub := newService()
+
+	twin := twins.Twin{
+		ThingID:   thingID,
+		ChannelID: channelID,
+		Reported:  twins.State{"temperature": 21.0},
+		Desired:   twins.State{"temperature": 21.0, "schedule": []interface{}{"08:00"}},
+		Version:   5,
+	}
+
+	cases := []struct {
+		desc    string
+		patch   twins.Patch
+		desired twins.State
+		err     error
+	}{
+		{
+			desc: "patch desired state",
+			patch: twins.Patch{
+				{Op: twins.TestOp, Path: "/temperature", Value: 21},
+				{Op: twins.ReplaceOp, Path: "/temperature", Value: 23},
+				{Op: twins.AddOp, Path: "/schedule/-", Value: "20:00"},
+			},
+			desired: twins.State{"temperature": float64(23), "schedule": []interface{}{"08:00", "20:00"}},
+		},
+		{
+			desc:    "patch desired state removing field",
+			patch:   twins.Patch{{Op: twins.RemoveOp, Path: "/schedule"}},
+			desired: twins.State{"temperature": 21.0},
+		},
+		{
+			desc:  "patch desired state with failed test",
+			patch: twins.Patch{{Op: twins.TestOp, Path: "/temperature", Value: 25}},
+			err:   svcerr.ErrConflict,
+		},
+		{
+			desc:  "patch desired state with missing path",
+			patch: twins.Patch{{Op: twins.ReplaceOp, Path: "/humidity", Value: 40}},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc: "patch desired state with empty patch",
+			err:  svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveByThing", context.Background(), thingID).Return(twin, nil)
+			repoCall1 := repo.On("Save", context.Background(), mock.Anything, twins.DesiredCause).Return(nil)
+			pubCall := pub.On("Publish", context.Background(), channelID, mock.Anything).Return(nil)
+			tw, err := svc.PatchDesired(context.Background(), session, thingID, tc.patch)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.desired, tw.Desired, fmt.Sprintf("%s: expected desired state %v got %v\n", tc.desc, tc.desired, tw.Desired))
+				assert.Equal(t, twin.Version+1, tw.Version, fmt.Sprintf("%s: expected version %d got %d\n", tc.desc, twin.Version+1, tw.Version))
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+			pubCall.Unset()
+		})
+	}
+}
+
+func TestConsumeBlocking(t *testing.T) {
+	svc, repo, pub := newService()
+
+	value := func(v float64) *float64 { return &v }
+	twin := twins.Twin{
+		ThingID:   thingID,
+		ChannelID: channelID,
+		Reported:  twins.State{"temperature": 21.0},
+		Desired:   twins.State{"temperature": 23.0},
+		Version:   2,
+	}
+
+	cases := []struct {
+		desc     string
+		msgs     interface{}
+		reported twins.State
+		save     bool
+		err      error
+	}{
+		{
+			desc: "consume SenML messages",
+			msgs: []senml.Message{
+				{Channel: channelID, Publisher: thingID, Name: "temperature", Value: value(22), Time: 2},
+				{Channel: channelID, Publisher: thingID, Name: "temperature", Value: value(20), Time: 1},
+				{Channel: channelID, Publisher: thingID, Name: "humidity", Value: value(40), Time: 1},
+			},
+			reported: twins.State{"temperature": 22.0, "humidity": 40.0},
+			save:     true,
+		},
+		{
+			desc: "consume SenML messages converging to desired state",
+			msgs: []senml.Message{
+				{Channel: channelID, Publisher: thingID, Name: "temperature", Value: value(23), Time: 1},
+			},
+			reported: twins.State{"temperature": 23.0},
+			save:     true,
+		},
+		{
+			desc: "consume JSON messages",
+			msgs: mgjson.Messages{
+				Data: []mgjson.Message{
+					{Channel: channelID, Publisher: thingID, Created: 1, Payload: map[string]interface{}{"mode": "eco"}},
+				},
+			},
+			reported: twins.State{"temperature": 21.0, "mode": "eco"},
+			save:     true,
+		},
+		{
+			desc: "consume unchanged state",
+			msgs: []senml.Message{
+				{Channel: channelID, Publisher: thingID, Name: "temperature", Value: value(21), Time: 1},
+			},
+		},
+		{
+			desc: "consume delta messages",
+			msgs: mgjson.Messages{
+				Data: []mgjson.Message{
+					{Channel: channelID, Publisher: twins.Publisher, Subtopic: "control." + thingID, Protocol: twins.Protocol, Payload: map[string]interface{}{"delta": 1}},
+				},
+			},
+		},
+		{
+			desc: "consume messages published by the service",
+			msgs: []senml.Message{
+				{Channel: channelID, Publisher: twins.Publisher, Name: "temperature", Value: value(30), Time: 3},
+			},
+		},
+		{
+			desc: "consume unsupported messages",
+			msgs: []byte("{}"),
+			err:  twins.ErrUnsupportedMessage,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo.Calls = nil
+			pub.Calls = nil
+			repoCall := repo.On("RetrieveByThing", context.Background(), thingID).Return(twin, nil)
+			repoCall1 := repo.On("Save", context.Background(), mock.Anything, twins.ReportedCause).Return(nil)
+			pubCall := pub.On("Publish", context.Background(), channelID, mock.Anything).Return(nil)
+			err := svc.ConsumeBlocking(context.Background(), tc.msgs)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			switch tc.save {
+			case true:
+				repo.AssertNumberOfCalls(t, "Save", 1)
+				saved := repo.Calls[len(repo.Calls)-1].Arguments.Get(1).(twins.Twin)
+				assert.Equal(t, tc.reported, saved.Reported, fmt.Sprintf("%s: expected reported state %v got %v\n", tc.desc, tc.reported, saved.Reported))
+				assert.Equal(t, twin.Version+1, saved.Version, fmt.Sprintf("%s: expected version %d got %d\n", tc.desc, twin.Version+1, saved.Version))
+			default:
+				repo.AssertNotCalled(t, "Save", context.Background(), mock.Anything, mock.Anything)
+			}
+			// Delta is published only when it changes and is not empty.
+			pub.AssertNotCalled(t, "Publish", context.Background(), channelID, mock.Anything)
+			repoCall.Unset()
+			repoCall1.Unset()
+			pubCall.Unset()
+		})
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package twins
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+const (
+	// ControlSubtopic is the subtopic prefix the twin delta is published to.
+	// The delta of the thing twin is published to the `control.<thing_id>`
+	// subtopic of the channel the thing reported its state to.
+	ControlSubtopic = "control"
+
+	// Protocol is set as the protocol of the delta messages.
+	Protocol = "twins"
+
+	// Publisher is set as the publisher of the delta messages. It is not a
+	// valid thing ID, so deltas are never mistaken for thing messages.
+	Publisher = "magistrala-twins"
+)
+
+// ErrInvalidCause indicates unsupported state change cause.
+var ErrInvalidCause = errors.New("invalid state change cause")
+
+// State is a JSON document describing the thing state.
+type State map[string]interface{}
+
+// Twin is the digital twin of a thing.
+type Twin struct {
+	ThingID string `json:"thing_id"`
+	// ChannelID is the channel of the latest message the thing reported its
+	// state with. The delta is published to this channel.
+	ChannelID string `json:"channel_id,omitempty"`
+	Reported  State  `json:"reported"`
+	Desired   State  `json:"desired"`
+	// Version is incremented on every state change.
+	Version    uint64    `json:"version"`
+	ReportedAt time.Time `json:"reported_at,omitempty"`
+	DesiredAt  time.Time `json:"desired_at,omitempty"`
+	// DesiredBy is the user who changed the desired state last.
+	DesiredBy string    `json:"desired_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// Delta returns the desired state fields which differ from the reported
+// state.
+func (tw Twin) Delta() State {
+	return Delta(tw.Desired, tw.Reported)
+}
+
+// MarshalJSON includes the twin delta.
+func (tw Twin) MarshalJSON() ([]byte, error) {
+	type twin Twin
+	return json.Marshal(struct {
+		twin
+		Delta State `json:"delta"`
+	}{
+		twin:  twin(tw),
+		Delta: tw.Delta(),
+	})
+}
+
+// Delta returns the fields of the desired state which are missing from or
+// differ from the reported state. Nested objects are compared field by
+// field.
+func Delta(desired, reported State) State {
+	delta := State{}
+	for key, want := range desired {
+		got, ok := reported[key]
+		if !ok {
+			delta[key] = want
+			continue
+		}
+		wantObj, wok := want.(map[string]interface{})
+		gotObj, gok := got.(map[string]interface{})
+		if wok && gok {
+			if d := Delta(wantObj, gotObj); len(d) > 0 {
+				delta[key] = map[string]interface{}(d)
+			}
+			continue
+		}
+		if !equal(want, got) {
+			delta[key] = want
+		}
+	}
+
+	return delta
+}
+
+// equal compares JSON values, so numbers of different Go types are equal
+// if their values are equal.
+func equal(a, b interface{}) bool {
+	if fa, ok := number(a); ok {
+		fb, ok := number(b)
+		return ok && fa == fb
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func number(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// Cause represents the cause of the twin state change.
+type Cause uint8
+
+// Possible state change causes.
+const (
+	// ReportedCause represents a change of the state reported by the thing.
+	ReportedCause Cause = iota
+	// DesiredCause represents a change of the state desired by the user.
+	DesiredCause
+)
+
+// String representation of the possible causes.
+const (
+	Reported = "reported"
+	Desired  = "desired"
+	Unknown  = "unknown"
+)
+
+// String converts cause to string literal.
+func (c Cause) String() string {
+	switch c {
+	case ReportedCause:
+		return Reported
+	case DesiredCause:
+		return Desired
+	default:
+		return Unknown
+	}
+}
+
+// ToCause converts string value to a valid cause.
+func ToCause(cause string) (Cause, error) {
+	switch cause {
+	case Reported:
+		return ReportedCause, nil
+	case Desired:
+		return DesiredCause, nil
+	}
+
+	return Cause(0), ErrInvalidCause
+}
+
+// MarshalJSON converts cause to JSON string.
+func (c Cause) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// TwinState is a snapshot of the twin state of a single version.
+type TwinState struct {
+	ThingID   string    `json:"thing_id"`
+	Version   uint64    `json:"version"`
+	Cause     Cause     `json:"cause"`
+	Reported  State     `json:"reported"`
+	Desired   State     `json:"desired"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+// StatesPage contains page related metadata as well as list of twin states
+// that belong to this page, starting from the latest version.
+type StatesPage struct {
+	PageMetadata
+	Total  uint64      `json:"total"`
+	States []TwinState `json:"states"`
+}
+
+// Repository specifies a twin persistence API.
+//
+//go:generate mockery --name Repository --output=./mocks --filename repository.go --quiet --note "Copyright (c) Abstract Machines"
+type Repository interface {
+	// Save persists the twin and the snapshot of its state. Twin with
+	// version 1 is created, and other twins are updated only if the stored
+	// version precedes the twin version. Otherwise, conflict error is
+	// returned.
+	Save(ctx context.Context, twin Twin, cause Cause) error
+
+	// RetrieveByThing retrieves the twin of the thing.
+	RetrieveByThing(ctx context.Context, thingID string) (Twin, error)
+
+	// RetrieveStates retrieves the state history of the thing twin.
+	RetrieveStates(ctx context.Context, thingID string, pm PageMetadata) (StatesPage, error)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"context"
+	"net"
+	"strings"
+	"syscall"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// ErrForbiddenAddress indicates a webhook endpoint which resolves to an
+// address deliveries are not allowed to.
+var ErrForbiddenAddress = errors.New("webhook url resolves to a forbidden address")
+
+// forbiddenNetworks are the networks of loopback, private, link-local,
+// shared and reserved addresses. Deliveries to them would let users reach
+// the internal services of the platform.
+var forbiddenNetworks = parseNetworks(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"224.0.0.0/4",
+	"240.0.0.0/4",
+	"::/128",
+	"::1/128",
+	"64:ff9b::/96",
+	"fc00::/7",
+	"fe80::/10",
+	"ff00::/8",
+)
+
+// AddressFilter decides which addresses webhook endpoints may resolve to.
+// Addresses of the forbidden networks are rejected unless they belong to
+// one of the allowed networks.
+type AddressFilter struct {
+	allowed []*net.IPNet
+}
+
+// NewAddressFilter returns a new address filter which allows the provided
+// networks in CIDR notation, such as networks of trusted internal receivers.
+func NewAddressFilter(allowed []string) (AddressFilter, error) {
+	f := AddressFilter{}
+	for _, cidr := range allowed {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return AddressFilter{}, err
+		}
+		f.allowed = append(f.allowed, network)
+	}
+
+	return f, nil
+}
+
+// Permitted returns true if deliveries to the IP address are allowed.
+func (f AddressFilter) Permitted(ip net.IP) bool {
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	for _, network := range f.allowed {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	for _, network := range forbiddenNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CheckHost resolves the host and returns an error unless all of its
+// addresses are permitted.
+func (f AddressFilter) CheckHost(ctx context.Context, host string) error {
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return errors.Wrap(ErrInvalidURL, err)
+	}
+	for _, addr := range addrs {
+		if !f.Permitted(addr.IP) {
+			return ErrForbiddenAddress
+		}
+	}
+
+	return nil
+}
+
+// Control rejects connections to the addresses which are not permitted. It
+// is meant to be used as the net.Dialer control function, which is called
+// after the host is resolved, so the checked address is the one dialed.
+func (f AddressFilter) Control(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !f.Permitted(ip) {
+		return ErrForbiddenAddress
+	}
+
+	return nil
+}
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+
+	return networks
+}
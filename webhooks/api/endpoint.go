@@ -0,0 +1,220 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/webhooks"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func createWebhookEndpoint(svc webhooks.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createWebhookReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		wh, err := svc.CreateWebhook(ctx, session, req.webhook())
+		if err != nil {
+			return nil, err
+		}
+
+		return newWebhookRes(wh, true), nil
+	}
+}
+
+func viewWebhookEndpoint(svc webhooks.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(webhookIDReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		wh, err := svc.ViewWebhook(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return newWebhookRes(wh, false), nil
+	}
+}
+
+func listWebhooksEndpoint(svc webhooks.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listWebhooksReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		page, err := svc.ListWebhooks(ctx, session, req.pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := webhooksPageRes{
+			PageMetadata: page.PageMetadata,
+			Total:        page.Total,
+			Webhooks:     []webhookRes{},
+		}
+		for _, wh := range page.Webhooks {
+			res.Webhooks = append(res.Webhooks, newWebhookRes(wh, false))
+		}
+
+		return res, nil
+	}
+}
+
+func updateWebhookEndpoint(svc webhooks.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateWebhookReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		wh, err := svc.UpdateWebhook(ctx, session, req.webhook())
+		if err != nil {
+			return nil, err
+		}
+
+		return newWebhookRes(wh, false), nil
+	}
+}
+
+func enableWebhookEndpoint(svc webhooks.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(webhookIDReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		wh, err := svc.EnableWebhook(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return newWebhookRes(wh, false), nil
+	}
+}
+
+func disableWebhookEndpoint(svc webhooks.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(webhookIDReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		wh, err := svc.DisableWebhook(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return newWebhookRes(wh, false), nil
+	}
+}
+
+func removeWebhookEndpoint(svc webhooks.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(webhookIDReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		if err := svc.RemoveWebhook(ctx, session, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeWebhookRes{}, nil
+	}
+}
+
+func listDeliveriesEndpoint(svc webhooks.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listDeliveriesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		page, err := svc.ListDeliveries(ctx, session, req.pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := deliveriesPageRes{
+			DeliveriesPageMetadata: page.DeliveriesPageMetadata,
+			Total:                  page.Total,
+			Deliveries:             []webhooks.Delivery{},
+		}
+		res.Deliveries = append(res.Deliveries, page.Deliveries...)
+
+		return res, nil
+	}
+}
+
+func redeliverEndpoint(svc webhooks.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(redeliverReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		d, err := svc.Redeliver(ctx, session, req.webhookID, req.deliveryID)
+		if err != nil {
+			return nil, err
+		}
+
+		return deliveryRes{Delivery: d}, nil
+	}
+}
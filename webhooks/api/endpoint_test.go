@@ -0,0 +1,762 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	authnmocks "github.com/absmach/magistrala/pkg/authn/mocks"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/absmach/magistrala/webhooks"
+	"github.com/absmach/magistrala/webhooks/api"
+	"github.com/absmach/magistrala/webhooks/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	validToken       = "valid"
+	validContentType = "application/json"
+	userID           = testsutil.GenerateUUID(&testing.T{})
+	domainID         = testsutil.GenerateUUID(&testing.T{})
+	webhookID        = testsutil.GenerateUUID(&testing.T{})
+	deliveryID       = testsutil.GenerateUUID(&testing.T{})
+	validSession     = mgauthn.Session{UserID: userID, DomainID: domainID, DomainUserID: domainID + "_" + userID}
+	validWebhook     = webhooks.Webhook{
+		ID:       webhookID,
+		Name:     "ci",
+		DomainID: domainID,
+		URL:      "https://203.0.113.10/hooks",
+		Events:   []string{"thing.*"},
+		Status:   webhooks.EnabledStatus,
+	}
+	validReq = `{"name":"ci","url":"https://203.0.113.10/hooks","events":["thing.*"]}`
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	token       string
+	contentType string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+
+	return tr.client.Do(req)
+}
+
+func newWebhooksServer() (*httptest.Server, *mocks.Service, *authnmocks.Authentication) {
+	svc := new(mocks.Service)
+	authn := new(authnmocks.Authentication)
+	mux := api.MakeHandler(svc, authn, mglog.NewMock(), "webhooks", "test")
+
+	return httptest.NewServer(mux), svc, authn
+}
+
+func TestCreateWebhook(t *testing.T) {
+	ws, svc, authn := newWebhooksServer()
+	defer ws.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		authnRes    mgauthn.Session
+		authnErr    error
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "create webhook successfully",
+			token:       validToken,
+			data:        validReq,
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create webhook with empty token",
+			data:        validReq,
+			contentType: validContentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create webhook with invalid token",
+			token:       "invalid",
+			data:        validReq,
+			contentType: validContentType,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create webhook with invalid content type",
+			token:       validToken,
+			data:        validReq,
+			contentType: "text/plain",
+			authnRes:    validSession,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "create webhook with malformed body",
+			token:       validToken,
+			data:        "{",
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create webhook with invalid url scheme",
+			token:       validToken,
+			data:        `{"url":"ftp://203.0.113.10/hooks","events":["*"]}`,
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create webhook without url host",
+			token:       validToken,
+			data:        `{"url":"https:///hooks","events":["*"]}`,
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create webhook without events",
+			token:       validToken,
+			data:        `{"url":"https://203.0.113.10/hooks"}`,
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create webhook with empty event",
+			token:       validToken,
+			data:        `{"url":"https://203.0.113.10/hooks","events":[""]}`,
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create webhook with too long name",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"name":"%s","url":"https://203.0.113.10/hooks","events":["*"]}`, strings.Repeat("a", 1025)),
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create webhook with service error",
+			token:       validToken,
+			data:        validReq,
+			contentType: validContentType,
+			authnRes:    validSession,
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("CreateWebhook", mock.Anything, tc.authnRes, mock.Anything).Return(validWebhook, tc.svcErr)
+			req := testRequest{
+				client:      ws.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/webhooks", ws.URL, domainID),
+				token:       tc.token,
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusCreated {
+				location := fmt.Sprintf("/%s/webhooks/%s", domainID, webhookID)
+				assert.Equal(t, location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, location, res.Header.Get("Location")))
+			}
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestWebhookAddresses(t *testing.T) {
+	repo := new(mocks.Repository)
+	addresses, _ := webhooks.NewAddressFilter([]string{"10.1.0.0/16"})
+	retry := webhooks.RetryConfig{MaxAttempts: 1, InitialInterval: time.Second, MaxInterval: time.Second}
+	svc := webhooks.New(uuid.NewMock(), repo, new(mocks.Sender), addresses, retry)
+	authn := new(authnmocks.Authentication)
+	ws := httptest.NewServer(api.MakeHandler(svc, authn, mglog.NewMock(), "webhooks", "test"))
+	defer ws.Close()
+
+	cases := []struct {
+		desc   string
+		url    string
+		status int
+	}{
+		{
+			desc:   "webhook with public url",
+			url:    "https://203.0.113.10/hooks",
+			status: http.StatusOK,
+		},
+		{
+			desc:   "webhook with allowed network url",
+			url:    "http://10.1.0.5/hooks",
+			status: http.StatusOK,
+		},
+		{
+			desc:   "webhook with loopback url",
+			url:    "http://127.0.0.1:9030/hooks",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "webhook with localhost url",
+			url:    "http://localhost:9030/hooks",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "webhook with IPv6 loopback url",
+			url:    "http://[::1]:9030/hooks",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "webhook with private url",
+			url:    "http://192.168.1.10/hooks",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "webhook with metadata service url",
+			url:    "http://169.254.169.254/latest/meta-data",
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		data := fmt.Sprintf(`{"url":"%s","events":["*"]}`, tc.url)
+		requests := []struct {
+			op     string
+			method string
+			url    string
+			status int
+		}{
+			{
+				op:     "create",
+				method: http.MethodPost,
+				url:    fmt.Sprintf("%s/%s/webhooks", ws.URL, domainID),
+				status: tc.status,
+			},
+			{
+				op:     "update",
+				method: http.MethodPut,
+				url:    fmt.Sprintf("%s/%s/webhooks/%s", ws.URL, domainID, webhookID),
+				status: tc.status,
+			},
+		}
+		for _, r := range requests {
+			desc := fmt.Sprintf("%s %s", r.op, tc.desc)
+			if r.op == "create" && r.status == http.StatusOK {
+				r.status = http.StatusCreated
+			}
+			t.Run(desc, func(t *testing.T) {
+				authnCall := authn.On("Authenticate", mock.Anything, validToken).Return(validSession, nil)
+				repoCall := repo.On("Save", mock.Anything, mock.Anything).Return(validWebhook, nil)
+				repoCall1 := repo.On("Update", mock.Anything, mock.Anything).Return(validWebhook, nil)
+				req := testRequest{
+					client:      ws.Client(),
+					method:      r.method,
+					url:         r.url,
+					token:       validToken,
+					contentType: validContentType,
+					body:        strings.NewReader(data),
+				}
+				res, err := req.make()
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+				assert.Equal(t, r.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, r.status, res.StatusCode))
+				repoCall.Unset()
+				repoCall1.Unset()
+				authnCall.Unset()
+			})
+		}
+	}
+}
+
+func TestViewWebhook(t *testing.T) {
+	ws, svc, authn := newWebhooksServer()
+	defer ws.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "view webhook successfully",
+			token:    validToken,
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "view webhook with invalid token",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "view non-existing webhook",
+			token:    validToken,
+			authnRes: validSession,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("ViewWebhook", mock.Anything, tc.authnRes, webhookID).Return(validWebhook, tc.svcErr)
+			req := testRequest{
+				client: ws.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/webhooks/%s", ws.URL, domainID, webhookID),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestListWebhooks(t *testing.T) {
+	ws, svc, authn := newWebhooksServer()
+	defer ws.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		query    string
+		pm       webhooks.PageMetadata
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "list webhooks successfully",
+			token:    validToken,
+			pm:       webhooks.PageMetadata{Limit: 10, Status: webhooks.AllStatus},
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list webhooks with name and status",
+			token:    validToken,
+			query:    "name=ci&status=enabled&offset=2&limit=5",
+			pm:       webhooks.PageMetadata{Offset: 2, Limit: 5, Name: "ci", Status: webhooks.EnabledStatus},
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list webhooks with invalid token",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "list webhooks with invalid limit",
+			token:    validToken,
+			query:    "limit=invalid",
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list webhooks with limit exceeding maximum",
+			token:    validToken,
+			query:    "limit=1000",
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list webhooks with invalid status",
+			token:    validToken,
+			query:    "status=invalid",
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			page := webhooks.WebhooksPage{PageMetadata: tc.pm, Total: 1, Webhooks: []webhooks.Webhook{validWebhook}}
+			svcCall := svc.On("ListWebhooks", mock.Anything, tc.authnRes, tc.pm).Return(page, tc.svcErr)
+			req := testRequest{
+				client: ws.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/webhooks?%s", ws.URL, domainID, tc.query),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	ws, svc, authn := newWebhooksServer()
+	defer ws.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		authnRes    mgauthn.Session
+		authnErr    error
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "update webhook successfully",
+			token:       validToken,
+			data:        validReq,
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "update webhook with invalid token",
+			token:       "invalid",
+			data:        validReq,
+			contentType: validContentType,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "update webhook with invalid content type",
+			token:       validToken,
+			data:        validReq,
+			contentType: "text/plain",
+			authnRes:    validSession,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "update webhook with invalid url",
+			token:       validToken,
+			data:        `{"url":"203.0.113.10","events":["*"]}`,
+			contentType: validContentType,
+			authnRes:    validSession,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update non-existing webhook",
+			token:       validToken,
+			data:        validReq,
+			contentType: validContentType,
+			authnRes:    validSession,
+			svcErr:      svcerr.ErrNotFound,
+			status:      http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("UpdateWebhook", mock.Anything, tc.authnRes, mock.Anything).Return(validWebhook, tc.svcErr)
+			req := testRequest{
+				client:      ws.Client(),
+				method:      http.MethodPut,
+				url:         fmt.Sprintf("%s/%s/webhooks/%s", ws.URL, domainID, webhookID),
+				token:       tc.token,
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestChangeWebhookStatus(t *testing.T) {
+	ws, svc, authn := newWebhooksServer()
+	defer ws.Close()
+
+	cases := []struct {
+		desc     string
+		op       string
+		svcOp    string
+		token    string
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "enable webhook successfully",
+			op:       "enable",
+			svcOp:    "EnableWebhook",
+			token:    validToken,
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "enable enabled webhook",
+			op:       "enable",
+			svcOp:    "EnableWebhook",
+			token:    validToken,
+			authnRes: validSession,
+			svcErr:   errors.ErrStatusAlreadyAssigned,
+			status:   http.StatusConflict,
+		},
+		{
+			desc:     "disable webhook successfully",
+			op:       "disable",
+			svcOp:    "DisableWebhook",
+			token:    validToken,
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "disable webhook with invalid token",
+			op:       "disable",
+			svcOp:    "DisableWebhook",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On(tc.svcOp, mock.Anything, tc.authnRes, webhookID).Return(validWebhook, tc.svcErr)
+			req := testRequest{
+				client: ws.Client(),
+				method: http.MethodPost,
+				url:    fmt.Sprintf("%s/%s/webhooks/%s/%s", ws.URL, domainID, webhookID, tc.op),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestRemoveWebhook(t *testing.T) {
+	ws, svc, authn := newWebhooksServer()
+	defer ws.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "remove webhook successfully",
+			token:    validToken,
+			authnRes: validSession,
+			status:   http.StatusNoContent,
+		},
+		{
+			desc:     "remove webhook with invalid token",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "remove non-existing webhook",
+			token:    validToken,
+			authnRes: validSession,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("RemoveWebhook", mock.Anything, tc.authnRes, webhookID).Return(tc.svcErr)
+			req := testRequest{
+				client: ws.Client(),
+				method: http.MethodDelete,
+				url:    fmt.Sprintf("%s/%s/webhooks/%s", ws.URL, domainID, webhookID),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestListDeliveries(t *testing.T) {
+	ws, svc, authn := newWebhooksServer()
+	defer ws.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		query    string
+		pm       webhooks.DeliveriesPageMetadata
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "list deliveries successfully",
+			token:    validToken,
+			pm:       webhooks.DeliveriesPageMetadata{Limit: 10, WebhookID: webhookID, Status: webhooks.AllDeliveries},
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list failed deliveries of the operation",
+			token:    validToken,
+			query:    "operation=thing.create&status=failed",
+			pm:       webhooks.DeliveriesPageMetadata{Limit: 10, WebhookID: webhookID, Operation: "thing.create", Status: webhooks.FailedDelivery},
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list deliveries with invalid token",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "list deliveries with invalid status",
+			token:    validToken,
+			query:    "status=invalid",
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list deliveries with invalid offset",
+			token:    validToken,
+			query:    "offset=invalid",
+			authnRes: validSession,
+			status:   http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			page := webhooks.DeliveriesPage{DeliveriesPageMetadata: tc.pm, Total: 0}
+			svcCall := svc.On("ListDeliveries", mock.Anything, tc.authnRes, tc.pm).Return(page, tc.svcErr)
+			req := testRequest{
+				client: ws.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/webhooks/%s/deliveries?%s", ws.URL, domainID, webhookID, tc.query),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestRedeliver(t *testing.T) {
+	ws, svc, authn := newWebhooksServer()
+	defer ws.Close()
+
+	delivery := webhooks.Delivery{ID: deliveryID, WebhookID: webhookID, DomainID: domainID, Status: webhooks.PendingDelivery}
+
+	cases := []struct {
+		desc     string
+		token    string
+		authnRes mgauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "redeliver successfully",
+			token:    validToken,
+			authnRes: validSession,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "redeliver with invalid token",
+			token:    "invalid",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "redeliver non-existing delivery",
+			token:    validToken,
+			authnRes: validSession,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("Redeliver", mock.Anything, tc.authnRes, webhookID, deliveryID).Return(delivery, tc.svcErr)
+			req := testRequest{
+				client: ws.Client(),
+				method: http.MethodPost,
+				url:    fmt.Sprintf("%s/%s/webhooks/%s/deliveries/%s/redeliver", ws.URL, domainID, webhookID, deliveryID),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/url"
+
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/webhooks"
+)
+
+type webhookReq struct {
+	id     string
+	Name   string   `json:"name,omitempty"`
+	URL    string   `json:"url"`
+	Secret string   `json:"secret,omitempty"`
+	Events []string `json:"events"`
+}
+
+func (req webhookReq) webhook() webhooks.Webhook {
+	return webhooks.Webhook{
+		ID:     req.id,
+		Name:   req.Name,
+		URL:    req.URL,
+		Secret: req.Secret,
+		Events: req.Events,
+	}
+}
+
+func (req webhookReq) validate() error {
+	if len(req.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return apiutil.ErrInvalidWebhookURL
+	}
+	if len(req.Events) == 0 {
+		return apiutil.ErrMissingEvents
+	}
+	for _, event := range req.Events {
+		if event == "" {
+			return apiutil.ErrMissingEvents
+		}
+	}
+
+	return nil
+}
+
+type createWebhookReq struct {
+	webhookReq
+}
+
+func (req createWebhookReq) validate() error {
+	return req.webhookReq.validate()
+}
+
+type updateWebhookReq struct {
+	webhookReq
+}
+
+func (req updateWebhookReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return req.webhookReq.validate()
+}
+
+type webhookIDReq struct {
+	id string
+}
+
+func (req webhookIDReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listWebhooksReq struct {
+	pm webhooks.PageMetadata
+}
+
+func (req listWebhooksReq) validate() error {
+	if req.pm.Limit > api.MaxLimitSize || req.pm.Limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+	if len(req.pm.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	return nil
+}
+
+type listDeliveriesReq struct {
+	pm webhooks.DeliveriesPageMetadata
+}
+
+func (req listDeliveriesReq) validate() error {
+	if req.pm.WebhookID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.pm.Limit > api.MaxLimitSize || req.pm.Limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type redeliverReq struct {
+	webhookID  string
+	deliveryID string
+}
+
+func (req redeliverReq) validate() error {
+	if req.webhookID == "" || req.deliveryID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
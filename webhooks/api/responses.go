@@ -0,0 +1,123 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/webhooks"
+)
+
+var (
+	_ magistrala.Response = (*webhookRes)(nil)
+	_ magistrala.Response = (*webhooksPageRes)(nil)
+	_ magistrala.Response = (*removeWebhookRes)(nil)
+	_ magistrala.Response = (*deliveryRes)(nil)
+	_ magistrala.Response = (*deliveriesPageRes)(nil)
+)
+
+type webhookRes struct {
+	webhooks.Webhook `json:",inline"`
+	created          bool
+}
+
+func (res webhookRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res webhookRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/%s/webhooks/%s", res.DomainID, res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res webhookRes) Empty() bool {
+	return false
+}
+
+type webhooksPageRes struct {
+	webhooks.PageMetadata `json:",inline"`
+	Total                 uint64       `json:"total"`
+	Webhooks              []webhookRes `json:"webhooks"`
+}
+
+func (res webhooksPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res webhooksPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res webhooksPageRes) Empty() bool {
+	return false
+}
+
+type removeWebhookRes struct{}
+
+func (res removeWebhookRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeWebhookRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeWebhookRes) Empty() bool {
+	return true
+}
+
+type deliveryRes struct {
+	webhooks.Delivery `json:",inline"`
+}
+
+func (res deliveryRes) Code() int {
+	return http.StatusOK
+}
+
+func (res deliveryRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deliveryRes) Empty() bool {
+	return false
+}
+
+type deliveriesPageRes struct {
+	webhooks.DeliveriesPageMetadata `json:",inline"`
+	Total                           uint64              `json:"total"`
+	Deliveries                      []webhooks.Delivery `json:"deliveries"`
+}
+
+func (res deliveriesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res deliveriesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deliveriesPageRes) Empty() bool {
+	return false
+}
+
+// newWebhookRes hides the signing secret, so it is sent back only when
+// the webhook is created.
+func newWebhookRes(wh webhooks.Webhook, created bool) webhookRes {
+	if !created {
+		wh.Secret = ""
+	}
+
+	return webhookRes{Webhook: wh, created: created}
+}
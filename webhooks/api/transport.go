@@ -0,0 +1,223 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/webhooks"
+	"github.com/go-chi/chi/v5"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const (
+	webhookIDKey  = "webhookID"
+	deliveryIDKey = "deliveryID"
+	operationKey  = "operation"
+)
+
+// MakeHandler returns a HTTP handler for webhooks API endpoints.
+func MakeHandler(svc webhooks.Service, authn mgauthn.Authentication, logger *slog.Logger, svcName, instanceID string) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+	}
+
+	mux := chi.NewRouter()
+
+	mux.Group(func(r chi.Router) {
+		r.Use(api.AuthenticateMiddleware(authn, true))
+
+		r.Route("/{domainID}/webhooks", func(r chi.Router) {
+			r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+				createWebhookEndpoint(svc),
+				decodeCreateWebhookReq,
+				api.EncodeResponse,
+				opts...,
+			), "create_webhook").ServeHTTP)
+
+			r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+				listWebhooksEndpoint(svc),
+				decodeListWebhooksReq,
+				api.EncodeResponse,
+				opts...,
+			), "list_webhooks").ServeHTTP)
+
+			r.Route("/{webhookID}", func(r chi.Router) {
+				r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+					viewWebhookEndpoint(svc),
+					decodeWebhookIDReq,
+					api.EncodeResponse,
+					opts...,
+				), "view_webhook").ServeHTTP)
+
+				r.Put("/", otelhttp.NewHandler(kithttp.NewServer(
+					updateWebhookEndpoint(svc),
+					decodeUpdateWebhookReq,
+					api.EncodeResponse,
+					opts...,
+				), "update_webhook").ServeHTTP)
+
+				r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+					removeWebhookEndpoint(svc),
+					decodeWebhookIDReq,
+					api.EncodeResponse,
+					opts...,
+				), "remove_webhook").ServeHTTP)
+
+				r.Post("/enable", otelhttp.NewHandler(kithttp.NewServer(
+					enableWebhookEndpoint(svc),
+					decodeWebhookIDReq,
+					api.EncodeResponse,
+					opts...,
+				), "enable_webhook").ServeHTTP)
+
+				r.Post("/disable", otelhttp.NewHandler(kithttp.NewServer(
+					disableWebhookEndpoint(svc),
+					decodeWebhookIDReq,
+					api.EncodeResponse,
+					opts...,
+				), "disable_webhook").ServeHTTP)
+
+				r.Get("/deliveries", otelhttp.NewHandler(kithttp.NewServer(
+					listDeliveriesEndpoint(svc),
+					decodeListDeliveriesReq,
+					api.EncodeResponse,
+					opts...,
+				), "list_deliveries").ServeHTTP)
+
+				r.Post("/deliveries/{deliveryID}/redeliver", otelhttp.NewHandler(kithttp.NewServer(
+					redeliverEndpoint(svc),
+					decodeRedeliverReq,
+					api.EncodeResponse,
+					opts...,
+				), "redeliver").ServeHTTP)
+			})
+		})
+	})
+
+	mux.Get("/health", magistrala.Health(svcName, instanceID))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+func decodeCreateWebhookReq(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	var req createWebhookReq
+	if err := json.NewDecoder(r.Body).Decode(&req.webhookReq); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeUpdateWebhookReq(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	var req updateWebhookReq
+	if err := json.NewDecoder(r.Body).Decode(&req.webhookReq); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+	req.id = chi.URLParam(r, webhookIDKey)
+
+	return req, nil
+}
+
+func decodeWebhookIDReq(_ context.Context, r *http.Request) (interface{}, error) {
+	return webhookIDReq{id: chi.URLParam(r, webhookIDKey)}, nil
+}
+
+func decodeListWebhooksReq(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	name, err := apiutil.ReadStringQuery(r, api.NameKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	s, err := apiutil.ReadStringQuery(r, api.StatusKey, webhooks.All)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	status, err := webhooks.ToStatus(s)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listWebhooksReq{
+		pm: webhooks.PageMetadata{
+			Offset: offset,
+			Limit:  limit,
+			Name:   name,
+			Status: status,
+		},
+	}
+
+	return req, nil
+}
+
+func decodeListDeliveriesReq(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	operation, err := apiutil.ReadStringQuery(r, operationKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	s, err := apiutil.ReadStringQuery(r, api.StatusKey, webhooks.All)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	status, err := webhooks.ToDeliveryStatus(s)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listDeliveriesReq{
+		pm: webhooks.DeliveriesPageMetadata{
+			Offset:    offset,
+			Limit:     limit,
+			WebhookID: chi.URLParam(r, webhookIDKey),
+			Operation: operation,
+			Status:    status,
+		},
+	}
+
+	return req, nil
+}
+
+func decodeRedeliverReq(_ context.Context, r *http.Request) (interface{}, error) {
+	req := redeliverReq{
+		webhookID:  chi.URLParam(r, webhookIDKey),
+		deliveryID: chi.URLParam(r, deliveryIDKey),
+	}
+
+	return req, nil
+}
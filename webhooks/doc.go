@@ -0,0 +1,9 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package webhooks contains the domain concept definitions needed to support
+// Magistrala webhooks service functionality. Webhooks service lets domain
+// administrators subscribe external HTTP endpoints to the events of their
+// domain. Deliveries are signed, retried with backoff and recorded in the
+// delivery log.
+package webhooks
@@ -0,0 +1,73 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"time"
+
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/events/store"
+	"github.com/absmach/magistrala/webhooks"
+)
+
+// Start starts consuming events of all services.
+func Start(ctx context.Context, consumer string, sub events.Subscriber, svc webhooks.Service) error {
+	subCfg := events.SubscriberConfig{
+		Consumer: consumer,
+		Stream:   store.StreamAllEvents,
+		Handler:  NewEventHandler(svc),
+	}
+
+	return sub.Subscribe(ctx, subCfg)
+}
+
+type eventHandler struct {
+	svc webhooks.Service
+}
+
+// NewEventHandler returns the handler passing events to the webhooks service.
+func NewEventHandler(svc webhooks.Service) events.EventHandler {
+	return &eventHandler{svc: svc}
+}
+
+func (eh *eventHandler) Handle(ctx context.Context, event events.Event) error {
+	data, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	operation := events.Read(data, "operation", "")
+	if operation == "" {
+		return svcerr.ErrMalformedEntity
+	}
+	delete(data, "operation")
+
+	occurredAt := time.Now()
+	if ts := events.Read(data, "occurred_at", float64(0)); ts != 0 {
+		occurredAt = time.Unix(0, int64(ts))
+	}
+	delete(data, "occurred_at")
+
+	// The domain is taken from the event itself, never from the webhook,
+	// so events are delivered only to webhooks of the domain they belong to.
+	domainID := events.Read(data, "domain", "")
+	if domainID == "" {
+		domainID = events.Read(data, "domain_id", "")
+	}
+
+	// Events relayed from the outbox keep their idempotency key, so webhook
+	// receivers can skip events published more than once.
+	id := events.Read(data, events.IDKey, "")
+	delete(data, events.IDKey)
+
+	return eh.svc.HandleEvent(ctx, webhooks.Event{
+		ID:         id,
+		Operation:  operation,
+		DomainID:   domainID,
+		OccurredAt: occurredAt,
+		Data:       data,
+	})
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package events provides the event store consumer for the webhooks service.
+// Events of all services are passed to the service, which delivers them to
+// the webhooks of the event domain.
+package events
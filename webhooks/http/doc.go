@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package http contains the sender implementation which posts signed
+// deliveries to webhook endpoints.
+package http
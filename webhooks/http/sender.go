@@ -0,0 +1,89 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/webhooks"
+)
+
+const (
+	contentType = "application/json"
+	userAgent   = "Magistrala-Webhooks"
+
+	// maxDrainSize limits the part of the response body which is read, so
+	// the connection can be reused.
+	maxDrainSize = 4096
+)
+
+var errUnexpectedStatus = errors.New("unexpected response status")
+
+var _ webhooks.Sender = (*sender)(nil)
+
+type sender struct {
+	client *http.Client
+}
+
+// NewSender returns a new sender which posts signed deliveries to webhook
+// endpoints. Redirects are not followed, and any 2xx response is considered
+// a successful delivery. Connections are made only to the addresses
+// permitted by the filter, which is checked after the endpoint host is
+// resolved, so the endpoint can't be pointed to internal addresses after
+// the webhook is created.
+func NewSender(timeout time.Duration, addresses webhooks.AddressFilter) webhooks.Sender {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: addresses.Control,
+	}
+
+	return &sender{
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: timeout,
+				MaxIdleConnsPerHost: 2,
+			},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *sender) Send(ctx context.Context, wh webhooks.Webhook, d webhooks.Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+	ts := time.Now().Unix()
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(webhooks.EventHeader, d.Operation)
+	req.Header.Set(webhooks.DeliveryHeader, d.ID)
+	req.Header.Set(webhooks.TimestampHeader, strconv.FormatInt(ts, 10))
+	req.Header.Set(webhooks.SignatureHeader, webhooks.Sign(wh.Secret, ts, d.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainSize))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, errors.Wrap(errUnexpectedStatus, fmt.Errorf("status code %d", resp.StatusCode))
+	}
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/webhooks"
+	whhttp "github.com/absmach/magistrala/webhooks/http"
+	"github.com/stretchr/testify/assert"
+)
+
+const secret = "secret"
+
+func TestSend(t *testing.T) {
+	delivery := webhooks.Delivery{
+		ID:        "delivery",
+		Operation: "thing.create",
+		Payload:   json.RawMessage(`{"operation":"thing.create"}`),
+	}
+
+	cases := []struct {
+		desc   string
+		status int
+		err    bool
+	}{
+		{desc: "send accepted delivery", status: http.StatusOK},
+		{desc: "send delivery with no content response", status: http.StatusNoContent},
+		{desc: "send rejected delivery", status: http.StatusInternalServerError, err: true},
+		{desc: "send redirected delivery", status: http.StatusFound, err: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var verified bool
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				sent, _ := strconv.ParseInt(r.Header.Get(webhooks.TimestampHeader), 10, 64)
+				verified = webhooks.Verify(secret, sent, body, r.Header.Get(webhooks.SignatureHeader)) &&
+					r.Header.Get(webhooks.EventHeader) == delivery.Operation &&
+					r.Header.Get(webhooks.DeliveryHeader) == delivery.ID
+				if tc.status == http.StatusFound {
+					w.Header().Set("Location", "http://localhost")
+				}
+				w.WriteHeader(tc.status)
+			}))
+			defer ts.Close()
+
+			addresses, err := webhooks.NewAddressFilter([]string{"127.0.0.0/8", "::1/128"})
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+			sender := whhttp.NewSender(time.Second, addresses)
+			code, err := sender.Send(context.Background(), webhooks.Webhook{URL: ts.URL, Secret: secret}, delivery)
+			assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, code, fmt.Sprintf("%s: expected status %d got %d\n", tc.desc, tc.status, code))
+			assert.True(t, verified, fmt.Sprintf("%s: expected signed delivery", tc.desc))
+		})
+	}
+}
+
+func TestSendForbiddenAddress(t *testing.T) {
+	var received bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		received = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	sender := whhttp.NewSender(time.Second, webhooks.AddressFilter{})
+	code, err := sender.Send(context.Background(), webhooks.Webhook{URL: ts.URL, Secret: secret}, webhooks.Delivery{ID: "delivery"})
+	assert.ErrorIs(t, err, webhooks.ErrForbiddenAddress, fmt.Sprintf("expected error %s got %s\n", webhooks.ErrForbiddenAddress, err))
+	assert.Equal(t, 0, code, fmt.Sprintf("expected status 0 got %d\n", code))
+	assert.False(t, received, "expected delivery to loopback address not to be sent")
+}
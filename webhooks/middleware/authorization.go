@@ -0,0 +1,123 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	mgauthz "github.com/absmach/magistrala/pkg/authz"
+	"github.com/absmach/magistrala/pkg/policies"
+	"github.com/absmach/magistrala/webhooks"
+)
+
+var _ webhooks.Service = (*authorizationMiddleware)(nil)
+
+type authorizationMiddleware struct {
+	svc   webhooks.Service
+	authz mgauthz.Authorization
+}
+
+// AuthorizationMiddleware adds authorization to the webhooks service.
+// Webhooks and their delivery logs expose all events of the domain, so
+// they are managed and viewed by domain administrators only.
+func AuthorizationMiddleware(svc webhooks.Service, authz mgauthz.Authorization) webhooks.Service {
+	return &authorizationMiddleware{
+		svc:   svc,
+		authz: authz,
+	}
+}
+
+func (am *authorizationMiddleware) CreateWebhook(ctx context.Context, session mgauthn.Session, wh webhooks.Webhook) (webhooks.Webhook, error) {
+	if err := am.checkAdmin(ctx, session); err != nil {
+		return webhooks.Webhook{}, err
+	}
+
+	return am.svc.CreateWebhook(ctx, session, wh)
+}
+
+func (am *authorizationMiddleware) ViewWebhook(ctx context.Context, session mgauthn.Session, id string) (webhooks.Webhook, error) {
+	if err := am.checkAdmin(ctx, session); err != nil {
+		return webhooks.Webhook{}, err
+	}
+
+	return am.svc.ViewWebhook(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) ListWebhooks(ctx context.Context, session mgauthn.Session, pm webhooks.PageMetadata) (webhooks.WebhooksPage, error) {
+	if err := am.checkAdmin(ctx, session); err != nil {
+		return webhooks.WebhooksPage{}, err
+	}
+
+	return am.svc.ListWebhooks(ctx, session, pm)
+}
+
+func (am *authorizationMiddleware) UpdateWebhook(ctx context.Context, session mgauthn.Session, wh webhooks.Webhook) (webhooks.Webhook, error) {
+	if err := am.checkAdmin(ctx, session); err != nil {
+		return webhooks.Webhook{}, err
+	}
+
+	return am.svc.UpdateWebhook(ctx, session, wh)
+}
+
+func (am *authorizationMiddleware) EnableWebhook(ctx context.Context, session mgauthn.Session, id string) (webhooks.Webhook, error) {
+	if err := am.checkAdmin(ctx, session); err != nil {
+		return webhooks.Webhook{}, err
+	}
+
+	return am.svc.EnableWebhook(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) DisableWebhook(ctx context.Context, session mgauthn.Session, id string) (webhooks.Webhook, error) {
+	if err := am.checkAdmin(ctx, session); err != nil {
+		return webhooks.Webhook{}, err
+	}
+
+	return am.svc.DisableWebhook(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) RemoveWebhook(ctx context.Context, session mgauthn.Session, id string) error {
+	if err := am.checkAdmin(ctx, session); err != nil {
+		return err
+	}
+
+	return am.svc.RemoveWebhook(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) ListDeliveries(ctx context.Context, session mgauthn.Session, pm webhooks.DeliveriesPageMetadata) (webhooks.DeliveriesPage, error) {
+	if err := am.checkAdmin(ctx, session); err != nil {
+		return webhooks.DeliveriesPage{}, err
+	}
+
+	return am.svc.ListDeliveries(ctx, session, pm)
+}
+
+func (am *authorizationMiddleware) Redeliver(ctx context.Context, session mgauthn.Session, webhookID, deliveryID string) (webhooks.Delivery, error) {
+	if err := am.checkAdmin(ctx, session); err != nil {
+		return webhooks.Delivery{}, err
+	}
+
+	return am.svc.Redeliver(ctx, session, webhookID, deliveryID)
+}
+
+func (am *authorizationMiddleware) HandleEvent(ctx context.Context, event webhooks.Event) error {
+	return am.svc.HandleEvent(ctx, event)
+}
+
+func (am *authorizationMiddleware) DispatchDeliveries(ctx context.Context) (uint64, error) {
+	return am.svc.DispatchDeliveries(ctx)
+}
+
+func (am *authorizationMiddleware) checkAdmin(ctx context.Context, session mgauthn.Session) error {
+	req := mgauthz.PolicyReq{
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     session.DomainUserID,
+		Permission:  policies.AdminPermission,
+		ObjectType:  policies.DomainType,
+		Object:      session.DomainID,
+	}
+
+	return am.authz.Authorize(ctx, req)
+}
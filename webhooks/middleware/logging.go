@@ -0,0 +1,244 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/webhooks"
+)
+
+var _ webhooks.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger *slog.Logger
+	svc    webhooks.Service
+}
+
+// LoggingMiddleware adds logging facilities to the webhooks service.
+func LoggingMiddleware(svc webhooks.Service, logger *slog.Logger) webhooks.Service {
+	return &loggingMiddleware{
+		logger: logger,
+		svc:    svc,
+	}
+}
+
+func (lm *loggingMiddleware) CreateWebhook(ctx context.Context, session mgauthn.Session, wh webhooks.Webhook) (w webhooks.Webhook, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("webhook",
+				slog.String("id", w.ID),
+				slog.String("name", wh.Name),
+				slog.String("url", wh.URL),
+				slog.Any("events", wh.Events),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create webhook failed", args...)
+			return
+		}
+		lm.logger.Info("Create webhook completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CreateWebhook(ctx, session, wh)
+}
+
+func (lm *loggingMiddleware) ViewWebhook(ctx context.Context, session mgauthn.Session, id string) (w webhooks.Webhook, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("webhook_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View webhook failed", args...)
+			return
+		}
+		lm.logger.Info("View webhook completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewWebhook(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ListWebhooks(ctx context.Context, session mgauthn.Session, pm webhooks.PageMetadata) (page webhooks.WebhooksPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("page",
+				slog.String("name", pm.Name),
+				slog.String("status", pm.Status.String()),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List webhooks failed", args...)
+			return
+		}
+		lm.logger.Info("List webhooks completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListWebhooks(ctx, session, pm)
+}
+
+func (lm *loggingMiddleware) UpdateWebhook(ctx context.Context, session mgauthn.Session, wh webhooks.Webhook) (w webhooks.Webhook, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("webhook",
+				slog.String("id", wh.ID),
+				slog.String("name", wh.Name),
+				slog.String("url", wh.URL),
+				slog.Any("events", wh.Events),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Update webhook failed", args...)
+			return
+		}
+		lm.logger.Info("Update webhook completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.UpdateWebhook(ctx, session, wh)
+}
+
+func (lm *loggingMiddleware) EnableWebhook(ctx context.Context, session mgauthn.Session, id string) (w webhooks.Webhook, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("webhook_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Enable webhook failed", args...)
+			return
+		}
+		lm.logger.Info("Enable webhook completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.EnableWebhook(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) DisableWebhook(ctx context.Context, session mgauthn.Session, id string) (w webhooks.Webhook, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("webhook_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Disable webhook failed", args...)
+			return
+		}
+		lm.logger.Info("Disable webhook completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.DisableWebhook(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) RemoveWebhook(ctx context.Context, session mgauthn.Session, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("webhook_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove webhook failed", args...)
+			return
+		}
+		lm.logger.Info("Remove webhook completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveWebhook(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ListDeliveries(ctx context.Context, session mgauthn.Session, pm webhooks.DeliveriesPageMetadata) (page webhooks.DeliveriesPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("page",
+				slog.String("webhook_id", pm.WebhookID),
+				slog.String("operation", pm.Operation),
+				slog.String("status", pm.Status.String()),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List webhook deliveries failed", args...)
+			return
+		}
+		lm.logger.Info("List webhook deliveries completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListDeliveries(ctx, session, pm)
+}
+
+func (lm *loggingMiddleware) Redeliver(ctx context.Context, session mgauthn.Session, webhookID, deliveryID string) (d webhooks.Delivery, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("delivery",
+				slog.String("id", deliveryID),
+				slog.String("webhook_id", webhookID),
+				slog.String("status", d.Status.String()),
+				slog.Int("response_code", d.ResponseCode),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Redeliver webhook delivery failed", args...)
+			return
+		}
+		lm.logger.Info("Redeliver webhook delivery completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Redeliver(ctx, session, webhookID, deliveryID)
+}
+
+func (lm *loggingMiddleware) HandleEvent(ctx context.Context, event webhooks.Event) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("operation", event.Operation),
+			slog.String("domain_id", event.DomainID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Handle webhook event failed", args...)
+			return
+		}
+		lm.logger.Debug("Handle webhook event completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.HandleEvent(ctx, event)
+}
+
+func (lm *loggingMiddleware) DispatchDeliveries(ctx context.Context) (count uint64, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Uint64("count", count),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Dispatch webhook deliveries failed", args...)
+			return
+		}
+		lm.logger.Debug("Dispatch webhook deliveries completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.DispatchDeliveries(ctx)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"time"
+
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/webhooks"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ webhooks.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     webhooks.Service
+}
+
+// MetricsMiddleware instruments webhooks service by tracking request count and latency.
+func MetricsMiddleware(svc webhooks.Service, counter metrics.Counter, latency metrics.Histogram) webhooks.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (mm *metricsMiddleware) CreateWebhook(ctx context.Context, session mgauthn.Session, wh webhooks.Webhook) (webhooks.Webhook, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_webhook").Add(1)
+		mm.latency.With("method", "create_webhook").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateWebhook(ctx, session, wh)
+}
+
+func (mm *metricsMiddleware) ViewWebhook(ctx context.Context, session mgauthn.Session, id string) (webhooks.Webhook, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_webhook").Add(1)
+		mm.latency.With("method", "view_webhook").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewWebhook(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) ListWebhooks(ctx context.Context, session mgauthn.Session, pm webhooks.PageMetadata) (webhooks.WebhooksPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_webhooks").Add(1)
+		mm.latency.With("method", "list_webhooks").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListWebhooks(ctx, session, pm)
+}
+
+func (mm *metricsMiddleware) UpdateWebhook(ctx context.Context, session mgauthn.Session, wh webhooks.Webhook) (webhooks.Webhook, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "update_webhook").Add(1)
+		mm.latency.With("method", "update_webhook").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.UpdateWebhook(ctx, session, wh)
+}
+
+func (mm *metricsMiddleware) EnableWebhook(ctx context.Context, session mgauthn.Session, id string) (webhooks.Webhook, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "enable_webhook").Add(1)
+		mm.latency.With("method", "enable_webhook").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.EnableWebhook(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) DisableWebhook(ctx context.Context, session mgauthn.Session, id string) (webhooks.Webhook, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "disable_webhook").Add(1)
+		mm.latency.With("method", "disable_webhook").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.DisableWebhook(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) RemoveWebhook(ctx context.Context, session mgauthn.Session, id string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_webhook").Add(1)
+		mm.latency.With("method", "remove_webhook").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveWebhook(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) ListDeliveries(ctx context.Context, session mgauthn.Session, pm webhooks.DeliveriesPageMetadata) (webhooks.DeliveriesPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_deliveries").Add(1)
+		mm.latency.With("method", "list_deliveries").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListDeliveries(ctx, session, pm)
+}
+
+func (mm *metricsMiddleware) Redeliver(ctx context.Context, session mgauthn.Session, webhookID, deliveryID string) (webhooks.Delivery, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "redeliver").Add(1)
+		mm.latency.With("method", "redeliver").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Redeliver(ctx, session, webhookID, deliveryID)
+}
+
+func (mm *metricsMiddleware) HandleEvent(ctx context.Context, event webhooks.Event) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "handle_event").Add(1)
+		mm.latency.With("method", "handle_event").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.HandleEvent(ctx, event)
+}
+
+func (mm *metricsMiddleware) DispatchDeliveries(ctx context.Context) (uint64, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "dispatch_deliveries").Add(1)
+		mm.latency.With("method", "dispatch_deliveries").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.DispatchDeliveries(ctx)
+}
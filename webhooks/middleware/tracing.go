@@ -0,0 +1,123 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/webhooks"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ webhooks.Service = (*tracing)(nil)
+
+type tracing struct {
+	tracer trace.Tracer
+	svc    webhooks.Service
+}
+
+// Tracing adds tracing to the webhooks service.
+func Tracing(svc webhooks.Service, tracer trace.Tracer) webhooks.Service {
+	return &tracing{tracer, svc}
+}
+
+func (tm *tracing) CreateWebhook(ctx context.Context, session mgauthn.Session, wh webhooks.Webhook) (webhooks.Webhook, error) {
+	ctx, span := tm.tracer.Start(ctx, "create_webhook", trace.WithAttributes(
+		attribute.String("name", wh.Name),
+		attribute.StringSlice("events", wh.Events),
+	))
+	defer span.End()
+
+	return tm.svc.CreateWebhook(ctx, session, wh)
+}
+
+func (tm *tracing) ViewWebhook(ctx context.Context, session mgauthn.Session, id string) (webhooks.Webhook, error) {
+	ctx, span := tm.tracer.Start(ctx, "view_webhook", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.ViewWebhook(ctx, session, id)
+}
+
+func (tm *tracing) ListWebhooks(ctx context.Context, session mgauthn.Session, pm webhooks.PageMetadata) (webhooks.WebhooksPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_webhooks", trace.WithAttributes(
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+		attribute.String("status", pm.Status.String()),
+	))
+	defer span.End()
+
+	return tm.svc.ListWebhooks(ctx, session, pm)
+}
+
+func (tm *tracing) UpdateWebhook(ctx context.Context, session mgauthn.Session, wh webhooks.Webhook) (webhooks.Webhook, error) {
+	ctx, span := tm.tracer.Start(ctx, "update_webhook", trace.WithAttributes(
+		attribute.String("id", wh.ID),
+		attribute.StringSlice("events", wh.Events),
+	))
+	defer span.End()
+
+	return tm.svc.UpdateWebhook(ctx, session, wh)
+}
+
+func (tm *tracing) EnableWebhook(ctx context.Context, session mgauthn.Session, id string) (webhooks.Webhook, error) {
+	ctx, span := tm.tracer.Start(ctx, "enable_webhook", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.EnableWebhook(ctx, session, id)
+}
+
+func (tm *tracing) DisableWebhook(ctx context.Context, session mgauthn.Session, id string) (webhooks.Webhook, error) {
+	ctx, span := tm.tracer.Start(ctx, "disable_webhook", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.DisableWebhook(ctx, session, id)
+}
+
+func (tm *tracing) RemoveWebhook(ctx context.Context, session mgauthn.Session, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "remove_webhook", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.RemoveWebhook(ctx, session, id)
+}
+
+func (tm *tracing) ListDeliveries(ctx context.Context, session mgauthn.Session, pm webhooks.DeliveriesPageMetadata) (webhooks.DeliveriesPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_deliveries", trace.WithAttributes(
+		attribute.String("webhook_id", pm.WebhookID),
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+		attribute.String("status", pm.Status.String()),
+	))
+	defer span.End()
+
+	return tm.svc.ListDeliveries(ctx, session, pm)
+}
+
+func (tm *tracing) Redeliver(ctx context.Context, session mgauthn.Session, webhookID, deliveryID string) (webhooks.Delivery, error) {
+	ctx, span := tm.tracer.Start(ctx, "redeliver", trace.WithAttributes(
+		attribute.String("webhook_id", webhookID),
+		attribute.String("delivery_id", deliveryID),
+	))
+	defer span.End()
+
+	return tm.svc.Redeliver(ctx, session, webhookID, deliveryID)
+}
+
+func (tm *tracing) HandleEvent(ctx context.Context, event webhooks.Event) error {
+	ctx, span := tm.tracer.Start(ctx, "handle_event", trace.WithAttributes(
+		attribute.String("operation", event.Operation),
+		attribute.String("domain_id", event.DomainID),
+	))
+	defer span.End()
+
+	return tm.svc.HandleEvent(ctx, event)
+}
+
+func (tm *tracing) DispatchDeliveries(ctx context.Context) (uint64, error) {
+	ctx, span := tm.tracer.Start(ctx, "dispatch_deliveries")
+	defer span.End()
+
+	return tm.svc.DispatchDeliveries(ctx)
+}
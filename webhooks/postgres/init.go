@@ -0,0 +1,57 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Migration of webhooks service.
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "webhooks_01",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS webhooks (
+						id			VARCHAR(36) PRIMARY KEY,
+						name		VARCHAR(1024),
+						domain_id	VARCHAR(36) NOT NULL,
+						url			TEXT NOT NULL,
+						secret		TEXT NOT NULL,
+						events		TEXT[] NOT NULL,
+						status		SMALLINT NOT NULL DEFAULT 0 CHECK (status >= 0),
+						created_by	VARCHAR(254),
+						created_at	TIMESTAMP,
+						updated_by	VARCHAR(254),
+						updated_at	TIMESTAMP
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_webhooks_domain ON webhooks(domain_id, status)`,
+					`CREATE TABLE IF NOT EXISTS deliveries (
+						id				VARCHAR(36) PRIMARY KEY,
+						webhook_id		VARCHAR(36) NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+						domain_id		VARCHAR(36) NOT NULL,
+						event_id		VARCHAR(36) NOT NULL,
+						operation		VARCHAR(1024) NOT NULL,
+						payload			JSONB NOT NULL,
+						status			SMALLINT NOT NULL DEFAULT 0 CHECK (status >= 0),
+						attempts		BIGINT NOT NULL DEFAULT 0,
+						response_code	INTEGER,
+						error			TEXT,
+						created_at		TIMESTAMP NOT NULL,
+						updated_at		TIMESTAMP,
+						next_attempt_at	TIMESTAMP
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_deliveries_webhook ON deliveries(webhook_id, created_at)`,
+					`CREATE INDEX IF NOT EXISTS idx_deliveries_due ON deliveries(status, next_attempt_at)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS deliveries`,
+					`DROP TABLE IF EXISTS webhooks`,
+				},
+			},
+		},
+	}
+}
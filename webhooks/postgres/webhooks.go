@@ -0,0 +1,441 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+	"github.com/absmach/magistrala/webhooks"
+	"github.com/jackc/pgtype"
+)
+
+const (
+	webhookColumns = `id, name, domain_id, url, secret, events, status, created_by, created_at, updated_by, updated_at`
+
+	deliveryColumns = `id, webhook_id, domain_id, event_id, operation, payload, status, attempts, response_code,
+	error, created_at, updated_at, next_attempt_at`
+)
+
+var _ webhooks.Repository = (*repository)(nil)
+
+type repository struct {
+	db postgres.Database
+}
+
+// NewRepository instantiates a PostgreSQL implementation of webhooks repository.
+func NewRepository(db postgres.Database) webhooks.Repository {
+	return &repository{db: db}
+}
+
+func (repo *repository) Save(ctx context.Context, wh webhooks.Webhook) (webhooks.Webhook, error) {
+	q := fmt.Sprintf(`INSERT INTO webhooks (%s)
+		VALUES (:id, :name, :domain_id, :url, :secret, :events, :status, :created_by, :created_at, :updated_by, :updated_at)
+		RETURNING %s;`, webhookColumns, webhookColumns)
+
+	dbwh, err := toDBWebhook(wh)
+	if err != nil {
+		return webhooks.Webhook{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return repo.namedQueryRow(ctx, q, dbwh, repoerr.ErrCreateEntity)
+}
+
+func (repo *repository) RetrieveByID(ctx context.Context, domainID, id string) (webhooks.Webhook, error) {
+	q := fmt.Sprintf(`SELECT %s FROM webhooks WHERE domain_id = :domain_id AND id = :id;`, webhookColumns)
+
+	dbwh := dbWebhook{ID: id, DomainID: domainID}
+
+	return repo.namedQueryRow(ctx, q, dbwh, repoerr.ErrViewEntity)
+}
+
+func (repo *repository) RetrieveAll(ctx context.Context, pm webhooks.PageMetadata) (webhooks.WebhooksPage, error) {
+	query := pageQuery(pm)
+	q := fmt.Sprintf(`SELECT %s FROM webhooks %s ORDER BY created_at LIMIT :limit OFFSET :offset;`, webhookColumns, query)
+
+	params := map[string]interface{}{
+		"domain_id": pm.DomainID,
+		"name":      "%" + pm.Name + "%",
+		"status":    pm.Status,
+		"limit":     pm.Limit,
+		"offset":    pm.Offset,
+	}
+
+	whs, err := repo.namedQuery(ctx, q, params)
+	if err != nil {
+		return webhooks.WebhooksPage{}, err
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM webhooks %s;`, query)
+	total, err := postgres.Total(ctx, repo.db, cq, params)
+	if err != nil {
+		return webhooks.WebhooksPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return webhooks.WebhooksPage{
+		PageMetadata: pm,
+		Total:        total,
+		Webhooks:     whs,
+	}, nil
+}
+
+func (repo *repository) RetrieveByDomain(ctx context.Context, domainID string) ([]webhooks.Webhook, error) {
+	q := fmt.Sprintf(`SELECT %s FROM webhooks WHERE domain_id = :domain_id AND status = :status;`, webhookColumns)
+
+	params := map[string]interface{}{
+		"domain_id": domainID,
+		"status":    webhooks.EnabledStatus,
+	}
+
+	return repo.namedQuery(ctx, q, params)
+}
+
+func (repo *repository) Update(ctx context.Context, wh webhooks.Webhook) (webhooks.Webhook, error) {
+	q := fmt.Sprintf(`UPDATE webhooks SET name = :name, url = :url, secret = COALESCE(NULLIF(:secret, ''), secret),
+		events = :events, updated_by = :updated_by, updated_at = :updated_at
+		WHERE domain_id = :domain_id AND id = :id
+		RETURNING %s;`, webhookColumns)
+
+	dbwh, err := toDBWebhook(wh)
+	if err != nil {
+		return webhooks.Webhook{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return repo.namedQueryRow(ctx, q, dbwh, repoerr.ErrUpdateEntity)
+}
+
+func (repo *repository) ChangeStatus(ctx context.Context, wh webhooks.Webhook) (webhooks.Webhook, error) {
+	q := fmt.Sprintf(`UPDATE webhooks SET status = :status, updated_by = :updated_by, updated_at = :updated_at
+		WHERE domain_id = :domain_id AND id = :id
+		RETURNING %s;`, webhookColumns)
+
+	dbwh, err := toDBWebhook(wh)
+	if err != nil {
+		return webhooks.Webhook{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return repo.namedQueryRow(ctx, q, dbwh, repoerr.ErrUpdateEntity)
+}
+
+func (repo *repository) Remove(ctx context.Context, domainID, id string) error {
+	q := `DELETE FROM webhooks WHERE domain_id = :domain_id AND id = :id;`
+
+	res, err := repo.db.NamedExecContext(ctx, q, dbWebhook{ID: id, DomainID: domainID})
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *repository) SaveDeliveries(ctx context.Context, deliveries []webhooks.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	q := fmt.Sprintf(`INSERT INTO deliveries (%s)
+		VALUES (:id, :webhook_id, :domain_id, :event_id, :operation, :payload, :status, :attempts, :response_code,
+		:error, :created_at, :updated_at, :next_attempt_at);`, deliveryColumns)
+
+	dbds := make([]dbDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		dbds = append(dbds, toDBDelivery(d))
+	}
+	if _, err := repo.db.NamedExecContext(ctx, q, dbds); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveDelivery(ctx context.Context, domainID, id string) (webhooks.Delivery, error) {
+	q := fmt.Sprintf(`SELECT %s FROM deliveries WHERE domain_id = :domain_id AND id = :id;`, deliveryColumns)
+
+	return repo.deliveryRow(ctx, q, dbDelivery{ID: id, DomainID: domainID}, repoerr.ErrViewEntity)
+}
+
+func (repo *repository) RetrieveDeliveries(ctx context.Context, pm webhooks.DeliveriesPageMetadata) (webhooks.DeliveriesPage, error) {
+	query := deliveriesPageQuery(pm)
+	q := fmt.Sprintf(`SELECT %s FROM deliveries %s ORDER BY created_at DESC LIMIT :limit OFFSET :offset;`, deliveryColumns, query)
+
+	params := map[string]interface{}{
+		"domain_id":  pm.DomainID,
+		"webhook_id": pm.WebhookID,
+		"operation":  pm.Operation,
+		"status":     pm.Status,
+		"limit":      pm.Limit,
+		"offset":     pm.Offset,
+	}
+
+	ds, err := repo.deliveries(ctx, q, params)
+	if err != nil {
+		return webhooks.DeliveriesPage{}, err
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM deliveries %s;`, query)
+	total, err := postgres.Total(ctx, repo.db, cq, params)
+	if err != nil {
+		return webhooks.DeliveriesPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return webhooks.DeliveriesPage{
+		DeliveriesPageMetadata: pm,
+		Total:                  total,
+		Deliveries:             ds,
+	}, nil
+}
+
+func (repo *repository) RetrieveDue(ctx context.Context, now, lease time.Time, limit uint64) ([]webhooks.Delivery, error) {
+	// Rows locked by concurrent dispatchers are skipped, so every due
+	// delivery is picked up by a single dispatcher.
+	q := fmt.Sprintf(`UPDATE deliveries SET next_attempt_at = :lease
+		WHERE id IN (
+			SELECT id FROM deliveries WHERE status = :status AND next_attempt_at <= :now
+			ORDER BY next_attempt_at LIMIT :limit FOR UPDATE SKIP LOCKED
+		)
+		RETURNING %s;`, deliveryColumns)
+
+	params := map[string]interface{}{
+		"status": webhooks.PendingDelivery,
+		"now":    now.UTC(),
+		"lease":  lease.UTC(),
+		"limit":  limit,
+	}
+
+	return repo.deliveries(ctx, q, params)
+}
+
+func (repo *repository) UpdateDelivery(ctx context.Context, d webhooks.Delivery) (webhooks.Delivery, error) {
+	q := fmt.Sprintf(`UPDATE deliveries SET status = :status, attempts = :attempts, response_code = :response_code,
+		error = :error, updated_at = :updated_at, next_attempt_at = :next_attempt_at
+		WHERE domain_id = :domain_id AND id = :id
+		RETURNING %s;`, deliveryColumns)
+
+	return repo.deliveryRow(ctx, q, toDBDelivery(d), repoerr.ErrUpdateEntity)
+}
+
+func (repo *repository) namedQueryRow(ctx context.Context, q string, params interface{}, wrapper error) (webhooks.Webhook, error) {
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return webhooks.Webhook{}, postgres.HandleError(wrapper, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return webhooks.Webhook{}, errors.Wrap(repoerr.ErrNotFound, sql.ErrNoRows)
+	}
+	var dbwh dbWebhook
+	if err := rows.StructScan(&dbwh); err != nil {
+		return webhooks.Webhook{}, postgres.HandleError(wrapper, err)
+	}
+
+	return toWebhook(dbwh), nil
+}
+
+func (repo *repository) namedQuery(ctx context.Context, q string, params interface{}) ([]webhooks.Webhook, error) {
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var whs []webhooks.Webhook
+	for rows.Next() {
+		var dbwh dbWebhook
+		if err := rows.StructScan(&dbwh); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		whs = append(whs, toWebhook(dbwh))
+	}
+
+	return whs, nil
+}
+
+func (repo *repository) deliveryRow(ctx context.Context, q string, params interface{}, wrapper error) (webhooks.Delivery, error) {
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return webhooks.Delivery{}, postgres.HandleError(wrapper, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return webhooks.Delivery{}, errors.Wrap(repoerr.ErrNotFound, sql.ErrNoRows)
+	}
+	var dbd dbDelivery
+	if err := rows.StructScan(&dbd); err != nil {
+		return webhooks.Delivery{}, postgres.HandleError(wrapper, err)
+	}
+
+	return toDelivery(dbd), nil
+}
+
+func (repo *repository) deliveries(ctx context.Context, q string, params interface{}) ([]webhooks.Delivery, error) {
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var ds []webhooks.Delivery
+	for rows.Next() {
+		var dbd dbDelivery
+		if err := rows.StructScan(&dbd); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		ds = append(ds, toDelivery(dbd))
+	}
+
+	return ds, nil
+}
+
+func pageQuery(pm webhooks.PageMetadata) string {
+	query := []string{"domain_id = :domain_id"}
+	if pm.Name != "" {
+		query = append(query, "name ILIKE :name")
+	}
+	if pm.Status != webhooks.AllStatus {
+		query = append(query, "status = :status")
+	}
+
+	return fmt.Sprintf("WHERE %s", strings.Join(query, " AND "))
+}
+
+func deliveriesPageQuery(pm webhooks.DeliveriesPageMetadata) string {
+	query := []string{"domain_id = :domain_id"}
+	if pm.WebhookID != "" {
+		query = append(query, "webhook_id = :webhook_id")
+	}
+	if pm.Operation != "" {
+		query = append(query, "operation = :operation")
+	}
+	if pm.Status != webhooks.AllDeliveries {
+		query = append(query, "status = :status")
+	}
+
+	return fmt.Sprintf("WHERE %s", strings.Join(query, " AND "))
+}
+
+type dbWebhook struct {
+	ID        string           `db:"id"`
+	Name      sql.NullString   `db:"name"`
+	DomainID  string           `db:"domain_id"`
+	URL       string           `db:"url"`
+	Secret    string           `db:"secret"`
+	Events    pgtype.TextArray `db:"events"`
+	Status    webhooks.Status  `db:"status"`
+	CreatedBy sql.NullString   `db:"created_by"`
+	CreatedAt time.Time        `db:"created_at"`
+	UpdatedBy sql.NullString   `db:"updated_by"`
+	UpdatedAt sql.NullTime     `db:"updated_at"`
+}
+
+func toDBWebhook(wh webhooks.Webhook) (dbWebhook, error) {
+	var events pgtype.TextArray
+	if err := events.Set(wh.Events); err != nil {
+		return dbWebhook{}, err
+	}
+
+	return dbWebhook{
+		ID:        wh.ID,
+		Name:      nullString(wh.Name),
+		DomainID:  wh.DomainID,
+		URL:       wh.URL,
+		Secret:    wh.Secret,
+		Events:    events,
+		Status:    wh.Status,
+		CreatedBy: nullString(wh.CreatedBy),
+		CreatedAt: wh.CreatedAt.UTC(),
+		UpdatedBy: nullString(wh.UpdatedBy),
+		UpdatedAt: nullTime(wh.UpdatedAt),
+	}, nil
+}
+
+func toWebhook(dbwh dbWebhook) webhooks.Webhook {
+	var events []string
+	for _, e := range dbwh.Events.Elements {
+		events = append(events, e.String)
+	}
+
+	return webhooks.Webhook{
+		ID:        dbwh.ID,
+		Name:      dbwh.Name.String,
+		DomainID:  dbwh.DomainID,
+		URL:       dbwh.URL,
+		Secret:    dbwh.Secret,
+		Events:    events,
+		Status:    dbwh.Status,
+		CreatedBy: dbwh.CreatedBy.String,
+		CreatedAt: dbwh.CreatedAt,
+		UpdatedBy: dbwh.UpdatedBy.String,
+		UpdatedAt: dbwh.UpdatedAt.Time,
+	}
+}
+
+type dbDelivery struct {
+	ID            string                  `db:"id"`
+	WebhookID     string                  `db:"webhook_id"`
+	DomainID      string                  `db:"domain_id"`
+	EventID       string                  `db:"event_id"`
+	Operation     string                  `db:"operation"`
+	Payload       []byte                  `db:"payload"`
+	Status        webhooks.DeliveryStatus `db:"status"`
+	Attempts      uint64                  `db:"attempts"`
+	ResponseCode  sql.NullInt32           `db:"response_code"`
+	Error         sql.NullString          `db:"error"`
+	CreatedAt     time.Time               `db:"created_at"`
+	UpdatedAt     sql.NullTime            `db:"updated_at"`
+	NextAttemptAt sql.NullTime            `db:"next_attempt_at"`
+}
+
+func toDBDelivery(d webhooks.Delivery) dbDelivery {
+	return dbDelivery{
+		ID:            d.ID,
+		WebhookID:     d.WebhookID,
+		DomainID:      d.DomainID,
+		EventID:       d.EventID,
+		Operation:     d.Operation,
+		Payload:       d.Payload,
+		Status:        d.Status,
+		Attempts:      d.Attempts,
+		ResponseCode:  sql.NullInt32{Int32: int32(d.ResponseCode), Valid: d.ResponseCode != 0},
+		Error:         nullString(d.Error),
+		CreatedAt:     d.CreatedAt.UTC(),
+		UpdatedAt:     nullTime(d.UpdatedAt),
+		NextAttemptAt: nullTime(d.NextAttemptAt),
+	}
+}
+
+func toDelivery(dbd dbDelivery) webhooks.Delivery {
+	return webhooks.Delivery{
+		ID:            dbd.ID,
+		WebhookID:     dbd.WebhookID,
+		DomainID:      dbd.DomainID,
+		EventID:       dbd.EventID,
+		Operation:     dbd.Operation,
+		Payload:       dbd.Payload,
+		Status:        dbd.Status,
+		Attempts:      dbd.Attempts,
+		ResponseCode:  int(dbd.ResponseCode.Int32),
+		Error:         dbd.Error.String,
+		CreatedAt:     dbd.CreatedAt,
+		UpdatedAt:     dbd.UpdatedAt.Time,
+		NextAttemptAt: dbd.NextAttemptAt.Time,
+	}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t.UTC(), Valid: !t.IsZero()}
+}
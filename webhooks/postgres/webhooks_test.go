@@ -0,0 +1,636 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/webhooks"
+	"github.com/absmach/magistrala/webhooks/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cleanup(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM deliveries")
+		require.Nil(t, err, fmt.Sprintf("clean deliveries unexpected error: %s", err))
+		_, err = db.Exec("DELETE FROM webhooks")
+		require.Nil(t, err, fmt.Sprintf("clean webhooks unexpected error: %s", err))
+	})
+}
+
+func newWebhook(t *testing.T, domainID string) webhooks.Webhook {
+	return webhooks.Webhook{
+		ID:        testsutil.GenerateUUID(t),
+		Name:      "ci",
+		DomainID:  domainID,
+		URL:       "https://203.0.113.10/hooks",
+		Secret:    "secret",
+		Events:    []string{"thing.*", "channel.create"},
+		Status:    webhooks.EnabledStatus,
+		CreatedBy: testsutil.GenerateUUID(t),
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+}
+
+func newDelivery(t *testing.T, wh webhooks.Webhook) webhooks.Delivery {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	return webhooks.Delivery{
+		ID:            testsutil.GenerateUUID(t),
+		WebhookID:     wh.ID,
+		DomainID:      wh.DomainID,
+		EventID:       testsutil.GenerateUUID(t),
+		Operation:     "thing.create",
+		Payload:       json.RawMessage(`{"operation": "thing.create"}`),
+		Status:        webhooks.PendingDelivery,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}
+}
+
+func TestSave(t *testing.T) {
+	cleanup(t)
+	repo := postgres.NewRepository(database)
+
+	wh := newWebhook(t, testsutil.GenerateUUID(t))
+
+	cases := []struct {
+		desc string
+		wh   webhooks.Webhook
+		err  error
+	}{
+		{
+			desc: "save webhook successfully",
+			wh:   wh,
+		},
+		{
+			desc: "save webhook with duplicate ID",
+			wh:   wh,
+			err:  repoerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			saved, err := repo.Save(context.Background(), tc.wh)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.wh, saved, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.wh, saved))
+			}
+		})
+	}
+}
+
+func TestRetrieveByID(t *testing.T) {
+	cleanup(t)
+	repo := postgres.NewRepository(database)
+
+	wh := newWebhook(t, testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), wh)
+	require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		domainID string
+		id       string
+		err      error
+	}{
+		{
+			desc:     "retrieve existing webhook",
+			domainID: wh.DomainID,
+			id:       wh.ID,
+		},
+		{
+			desc:     "retrieve webhook of another domain",
+			domainID: testsutil.GenerateUUID(t),
+			id:       wh.ID,
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "retrieve non-existing webhook",
+			domainID: wh.DomainID,
+			id:       testsutil.GenerateUUID(t),
+			err:      repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := repo.RetrieveByID(context.Background(), tc.domainID, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, wh, got, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, wh, got))
+			}
+		})
+	}
+}
+
+func TestRetrieveAll(t *testing.T) {
+	cleanup(t)
+	repo := postgres.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	num := 10
+	for i := 0; i < num; i++ {
+		wh := newWebhook(t, domainID)
+		wh.Name = fmt.Sprintf("webhook-%d", i)
+		if i%2 == 1 {
+			wh.Status = webhooks.DisabledStatus
+		}
+		_, err := repo.Save(context.Background(), wh)
+		require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+	}
+	_, err := repo.Save(context.Background(), newWebhook(t, testsutil.GenerateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		pm    webhooks.PageMetadata
+		total uint64
+		size  int
+	}{
+		{
+			desc:  "retrieve all webhooks of the domain",
+			pm:    webhooks.PageMetadata{DomainID: domainID, Limit: uint64(num), Status: webhooks.AllStatus},
+			total: uint64(num),
+			size:  num,
+		},
+		{
+			desc:  "retrieve webhooks with offset and limit",
+			pm:    webhooks.PageMetadata{DomainID: domainID, Offset: 7, Limit: 5, Status: webhooks.AllStatus},
+			total: uint64(num),
+			size:  3,
+		},
+		{
+			desc:  "retrieve disabled webhooks",
+			pm:    webhooks.PageMetadata{DomainID: domainID, Limit: uint64(num), Status: webhooks.DisabledStatus},
+			total: uint64(num / 2),
+			size:  num / 2,
+		},
+		{
+			desc:  "retrieve webhooks by name",
+			pm:    webhooks.PageMetadata{DomainID: domainID, Name: "webhook-3", Limit: uint64(num), Status: webhooks.AllStatus},
+			total: 1,
+			size:  1,
+		},
+		{
+			desc:  "retrieve webhooks of domain without webhooks",
+			pm:    webhooks.PageMetadata{DomainID: testsutil.GenerateUUID(t), Limit: uint64(num), Status: webhooks.AllStatus},
+			total: 0,
+			size:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RetrieveAll(context.Background(), tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+			assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+			assert.Len(t, page.Webhooks, tc.size, fmt.Sprintf("%s: expected %d webhooks got %d\n", tc.desc, tc.size, len(page.Webhooks)))
+		})
+	}
+}
+
+func TestRetrieveByDomain(t *testing.T) {
+	cleanup(t)
+	repo := postgres.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	enabled := newWebhook(t, domainID)
+	disabled := newWebhook(t, domainID)
+	disabled.Status = webhooks.DisabledStatus
+	for _, wh := range []webhooks.Webhook{enabled, disabled} {
+		_, err := repo.Save(context.Background(), wh)
+		require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc     string
+		domainID string
+		webhooks []webhooks.Webhook
+	}{
+		{
+			desc:     "retrieve enabled webhooks of the domain",
+			domainID: domainID,
+			webhooks: []webhooks.Webhook{enabled},
+		},
+		{
+			desc:     "retrieve webhooks of domain without webhooks",
+			domainID: testsutil.GenerateUUID(t),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			whs, err := repo.RetrieveByDomain(context.Background(), tc.domainID)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+			assert.Equal(t, tc.webhooks, whs, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.webhooks, whs))
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cleanup(t)
+	repo := postgres.NewRepository(database)
+
+	wh := newWebhook(t, testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), wh)
+	require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+
+	withSecret := wh
+	withSecret.URL = "https://203.0.113.20/hooks"
+	withSecret.Secret = "new-secret"
+	withSecret.Events = []string{"*"}
+	withSecret.UpdatedBy = testsutil.GenerateUUID(t)
+	withSecret.UpdatedAt = time.Now().UTC().Truncate(time.Microsecond)
+
+	withoutSecret := withSecret
+	withoutSecret.Secret = ""
+
+	notFound := withSecret
+	notFound.ID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc   string
+		wh     webhooks.Webhook
+		secret string
+		err    error
+	}{
+		{
+			desc:   "update webhook with new secret",
+			wh:     withSecret,
+			secret: "new-secret",
+		},
+		{
+			desc:   "update webhook keeping stored secret",
+			wh:     withoutSecret,
+			secret: "new-secret",
+		},
+		{
+			desc: "update non-existing webhook",
+			wh:   notFound,
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := repo.Update(context.Background(), tc.wh)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.wh.URL, got.URL, fmt.Sprintf("%s: expected url %s got %s\n", tc.desc, tc.wh.URL, got.URL))
+				assert.Equal(t, tc.wh.Events, got.Events, fmt.Sprintf("%s: expected events %v got %v\n", tc.desc, tc.wh.Events, got.Events))
+				assert.Equal(t, tc.secret, got.Secret, fmt.Sprintf("%s: expected secret %s got %s\n", tc.desc, tc.secret, got.Secret))
+				assert.Equal(t, tc.wh.UpdatedBy, got.UpdatedBy, fmt.Sprintf("%s: expected updated by %s got %s\n", tc.desc, tc.wh.UpdatedBy, got.UpdatedBy))
+			}
+		})
+	}
+}
+
+func TestChangeStatus(t *testing.T) {
+	cleanup(t)
+	repo := postgres.NewRepository(database)
+
+	wh := newWebhook(t, testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), wh)
+	require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+
+	disabled := wh
+	disabled.Status = webhooks.DisabledStatus
+	disabled.UpdatedBy = testsutil.GenerateUUID(t)
+	disabled.UpdatedAt = time.Now().UTC().Truncate(time.Microsecond)
+
+	notFound := disabled
+	notFound.ID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc string
+		wh   webhooks.Webhook
+		err  error
+	}{
+		{
+			desc: "disable webhook",
+			wh:   disabled,
+		},
+		{
+			desc: "change status of non-existing webhook",
+			wh:   notFound,
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := repo.ChangeStatus(context.Background(), tc.wh)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.wh, got, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.wh, got))
+			}
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	cleanup(t)
+	repo := postgres.NewRepository(database)
+
+	wh := newWebhook(t, testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), wh)
+	require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+	d := newDelivery(t, wh)
+	err = repo.SaveDeliveries(context.Background(), []webhooks.Delivery{d})
+	require.Nil(t, err, fmt.Sprintf("save deliveries unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		domainID string
+		id       string
+		err      error
+	}{
+		{
+			desc:     "remove webhook of another domain",
+			domainID: testsutil.GenerateUUID(t),
+			id:       wh.ID,
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "remove existing webhook",
+			domainID: wh.DomainID,
+			id:       wh.ID,
+		},
+		{
+			desc:     "remove removed webhook",
+			domainID: wh.DomainID,
+			id:       wh.ID,
+			err:      repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.Remove(context.Background(), tc.domainID, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+
+	_, err = repo.RetrieveDelivery(context.Background(), d.DomainID, d.ID)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("expected deliveries of removed webhook to be removed, got %s", err))
+}
+
+func TestSaveDeliveries(t *testing.T) {
+	cleanup(t)
+	repo := postgres.NewRepository(database)
+
+	wh := newWebhook(t, testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), wh)
+	require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+
+	d := newDelivery(t, wh)
+	unknown := newDelivery(t, webhooks.Webhook{ID: testsutil.GenerateUUID(t), DomainID: wh.DomainID})
+
+	cases := []struct {
+		desc       string
+		deliveries []webhooks.Delivery
+		err        error
+	}{
+		{
+			desc:       "save deliveries successfully",
+			deliveries: []webhooks.Delivery{d, newDelivery(t, wh)},
+		},
+		{
+			desc: "save empty deliveries",
+		},
+		{
+			desc:       "save duplicate delivery",
+			deliveries: []webhooks.Delivery{d},
+			err:        repoerr.ErrConflict,
+		},
+		{
+			desc:       "save delivery of non-existing webhook",
+			deliveries: []webhooks.Delivery{unknown},
+			err:        repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.SaveDeliveries(context.Background(), tc.deliveries)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestRetrieveDelivery(t *testing.T) {
+	cleanup(t)
+	repo := postgres.NewRepository(database)
+
+	wh := newWebhook(t, testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), wh)
+	require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+	d := newDelivery(t, wh)
+	err = repo.SaveDeliveries(context.Background(), []webhooks.Delivery{d})
+	require.Nil(t, err, fmt.Sprintf("save deliveries unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		domainID string
+		id       string
+		err      error
+	}{
+		{
+			desc:     "retrieve existing delivery",
+			domainID: d.DomainID,
+			id:       d.ID,
+		},
+		{
+			desc:     "retrieve delivery of another domain",
+			domainID: testsutil.GenerateUUID(t),
+			id:       d.ID,
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "retrieve non-existing delivery",
+			domainID: d.DomainID,
+			id:       testsutil.GenerateUUID(t),
+			err:      repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := repo.RetrieveDelivery(context.Background(), tc.domainID, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.JSONEq(t, string(d.Payload), string(got.Payload), fmt.Sprintf("%s: expected payload %s got %s\n", tc.desc, d.Payload, got.Payload))
+				got.Payload = d.Payload
+				assert.Equal(t, d, got, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, d, got))
+			}
+		})
+	}
+}
+
+func TestRetrieveDeliveries(t *testing.T) {
+	cleanup(t)
+	repo := postgres.NewRepository(database)
+
+	wh := newWebhook(t, testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), wh)
+	require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+
+	num := 10
+	var deliveries []webhooks.Delivery
+	for i := 0; i < num; i++ {
+		d := newDelivery(t, wh)
+		d.CreatedAt = d.CreatedAt.Add(time.Duration(i) * time.Second)
+		if i%2 == 1 {
+			d.Operation = "thing.remove"
+			d.Status = webhooks.FailedDelivery
+		}
+		deliveries = append(deliveries, d)
+	}
+	err = repo.SaveDeliveries(context.Background(), deliveries)
+	require.Nil(t, err, fmt.Sprintf("save deliveries unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		pm    webhooks.DeliveriesPageMetadata
+		total uint64
+		size  int
+	}{
+		{
+			desc:  "retrieve all deliveries of the webhook",
+			pm:    webhooks.DeliveriesPageMetadata{DomainID: wh.DomainID, WebhookID: wh.ID, Limit: uint64(num), Status: webhooks.AllDeliveries},
+			total: uint64(num),
+			size:  num,
+		},
+		{
+			desc:  "retrieve deliveries with offset and limit",
+			pm:    webhooks.DeliveriesPageMetadata{DomainID: wh.DomainID, WebhookID: wh.ID, Offset: 6, Limit: 5, Status: webhooks.AllDeliveries},
+			total: uint64(num),
+			size:  4,
+		},
+		{
+			desc:  "retrieve failed deliveries",
+			pm:    webhooks.DeliveriesPageMetadata{DomainID: wh.DomainID, WebhookID: wh.ID, Limit: uint64(num), Status: webhooks.FailedDelivery},
+			total: uint64(num / 2),
+			size:  num / 2,
+		},
+		{
+			desc:  "retrieve deliveries of the operation",
+			pm:    webhooks.DeliveriesPageMetadata{DomainID: wh.DomainID, WebhookID: wh.ID, Operation: "thing.create", Limit: uint64(num), Status: webhooks.AllDeliveries},
+			total: uint64(num / 2),
+			size:  num / 2,
+		},
+		{
+			desc:  "retrieve deliveries of another domain",
+			pm:    webhooks.DeliveriesPageMetadata{DomainID: testsutil.GenerateUUID(t), WebhookID: wh.ID, Limit: uint64(num), Status: webhooks.AllDeliveries},
+			total: 0,
+			size:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RetrieveDeliveries(context.Background(), tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+			assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+			assert.Len(t, page.Deliveries, tc.size, fmt.Sprintf("%s: expected %d deliveries got %d\n", tc.desc, tc.size, len(page.Deliveries)))
+		})
+	}
+
+	page, err := repo.RetrieveDeliveries(context.Background(), webhooks.DeliveriesPageMetadata{DomainID: wh.DomainID, WebhookID: wh.ID, Limit: 1, Status: webhooks.AllDeliveries})
+	require.Nil(t, err, fmt.Sprintf("retrieve deliveries unexpected error: %s", err))
+	assert.Equal(t, deliveries[num-1].ID, page.Deliveries[0].ID, "expected the latest delivery first")
+}
+
+func TestRetrieveDue(t *testing.T) {
+	cleanup(t)
+	repo := postgres.NewRepository(database)
+
+	wh := newWebhook(t, testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), wh)
+	require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	due := newDelivery(t, wh)
+	due.NextAttemptAt = now.Add(-time.Minute)
+	scheduled := newDelivery(t, wh)
+	scheduled.NextAttemptAt = now.Add(time.Minute)
+	succeeded := newDelivery(t, wh)
+	succeeded.Status = webhooks.SucceededDelivery
+	succeeded.NextAttemptAt = now.Add(-time.Minute)
+	err = repo.SaveDeliveries(context.Background(), []webhooks.Delivery{due, scheduled, succeeded})
+	require.Nil(t, err, fmt.Sprintf("save deliveries unexpected error: %s", err))
+
+	lease := now.Add(30 * time.Second)
+	ds, err := repo.RetrieveDue(context.Background(), now, lease, 10)
+	assert.Nil(t, err, fmt.Sprintf("retrieve due deliveries unexpected error: %s", err))
+	require.Len(t, ds, 1, "expected a single due delivery")
+	assert.Equal(t, due.ID, ds[0].ID, fmt.Sprintf("expected due delivery %s got %s", due.ID, ds[0].ID))
+	assert.Equal(t, lease, ds[0].NextAttemptAt, fmt.Sprintf("expected next attempt to be postponed to %s got %s", lease, ds[0].NextAttemptAt))
+
+	ds, err = repo.RetrieveDue(context.Background(), now, lease, 10)
+	assert.Nil(t, err, fmt.Sprintf("retrieve due deliveries unexpected error: %s", err))
+	assert.Empty(t, ds, "expected leased delivery not to be retrieved again")
+}
+
+func TestUpdateDelivery(t *testing.T) {
+	cleanup(t)
+	repo := postgres.NewRepository(database)
+
+	wh := newWebhook(t, testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), wh)
+	require.Nil(t, err, fmt.Sprintf("save webhook unexpected error: %s", err))
+	d := newDelivery(t, wh)
+	err = repo.SaveDeliveries(context.Background(), []webhooks.Delivery{d})
+	require.Nil(t, err, fmt.Sprintf("save deliveries unexpected error: %s", err))
+
+	failed := d
+	failed.Status = webhooks.FailedDelivery
+	failed.Attempts = 3
+	failed.ResponseCode = 500
+	failed.Error = "unexpected response status"
+	failed.UpdatedAt = time.Now().UTC().Truncate(time.Microsecond)
+
+	notFound := failed
+	notFound.ID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc     string
+		delivery webhooks.Delivery
+		err      error
+	}{
+		{
+			desc:     "update delivery successfully",
+			delivery: failed,
+		},
+		{
+			desc:     "update non-existing delivery",
+			delivery: notFound,
+			err:      repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := repo.UpdateDelivery(context.Background(), tc.delivery)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.delivery.Status, got.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.delivery.Status, got.Status))
+				assert.Equal(t, tc.delivery.Attempts, got.Attempts, fmt.Sprintf("%s: expected attempts %d got %d\n", tc.desc, tc.delivery.Attempts, got.Attempts))
+				assert.Equal(t, tc.delivery.ResponseCode, got.ResponseCode, fmt.Sprintf("%s: expected response code %d got %d\n", tc.desc, tc.delivery.ResponseCode, got.ResponseCode))
+				assert.Equal(t, tc.delivery.Error, got.Error, fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.delivery.Error, got.Error))
+			}
+		})
+	}
+}
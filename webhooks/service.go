@@ -0,0 +1,408 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+const (
+	secretSize = 32
+
+	// dispatchBatch is the maximum number of deliveries attempted at once.
+	dispatchBatch = 100
+
+	// dispatchLease is the time the dispatched deliveries are hidden from
+	// other dispatchers. It has to be longer than the sender timeout.
+	dispatchLease = time.Minute
+)
+
+var (
+	// ErrInvalidURL indicates invalid webhook endpoint URL.
+	ErrInvalidURL = errors.New("invalid webhook url")
+
+	// ErrMissingEvents indicates a webhook without events.
+	ErrMissingEvents = errors.New("missing webhook events")
+
+	// ErrWebhookDisabled indicates that the delivery was not attempted
+	// because the webhook is disabled or removed.
+	ErrWebhookDisabled = errors.New("webhook is disabled")
+)
+
+// Service specifies an API for managing webhooks and delivering the events
+// of their domain to them.
+//
+//go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
+type Service interface {
+	// CreateWebhook creates the webhook in the session domain. Signing
+	// secret is generated unless provided.
+	CreateWebhook(ctx context.Context, session mgauthn.Session, wh Webhook) (Webhook, error)
+
+	// ViewWebhook retrieves the webhook having the provided identifier.
+	ViewWebhook(ctx context.Context, session mgauthn.Session, id string) (Webhook, error)
+
+	// ListWebhooks retrieves webhooks of the session domain.
+	ListWebhooks(ctx context.Context, session mgauthn.Session, pm PageMetadata) (WebhooksPage, error)
+
+	// UpdateWebhook updates the webhook endpoint, events and, if provided,
+	// the signing secret.
+	UpdateWebhook(ctx context.Context, session mgauthn.Session, wh Webhook) (Webhook, error)
+
+	// EnableWebhook enables the webhook, so events are delivered.
+	EnableWebhook(ctx context.Context, session mgauthn.Session, id string) (Webhook, error)
+
+	// DisableWebhook disables the webhook, so events are no longer delivered.
+	DisableWebhook(ctx context.Context, session mgauthn.Session, id string) (Webhook, error)
+
+	// RemoveWebhook removes the webhook and its delivery log.
+	RemoveWebhook(ctx context.Context, session mgauthn.Session, id string) error
+
+	// ListDeliveries retrieves the delivery log of the webhook.
+	ListDeliveries(ctx context.Context, session mgauthn.Session, pm DeliveriesPageMetadata) (DeliveriesPage, error)
+
+	// Redeliver sends the delivery to the webhook endpoint again and
+	// records the attempt. The delivery is not retried if it fails.
+	Redeliver(ctx context.Context, session mgauthn.Session, webhookID, deliveryID string) (Delivery, error)
+
+	// HandleEvent records a pending delivery of the event for every enabled
+	// webhook of the event domain subscribed to the event operation. Events
+	// without ID are assigned a new one.
+	HandleEvent(ctx context.Context, event Event) error
+
+	// DispatchDeliveries attempts the pending deliveries which are due and
+	// returns the number of attempted deliveries.
+	DispatchDeliveries(ctx context.Context) (uint64, error)
+}
+
+var _ Service = (*service)(nil)
+
+type service struct {
+	idProvider magistrala.IDProvider
+	repo       Repository
+	sender     Sender
+	addresses  AddressFilter
+	retry      RetryConfig
+}
+
+// New instantiates the webhooks service implementation. Webhook endpoints
+// are checked against the address filter when they are created or updated.
+func New(idp magistrala.IDProvider, repo Repository, sender Sender, addresses AddressFilter, retry RetryConfig) Service {
+	return &service{
+		idProvider: idp,
+		repo:       repo,
+		sender:     sender,
+		addresses:  addresses,
+		retry:      retry,
+	}
+}
+
+func (svc *service) CreateWebhook(ctx context.Context, session mgauthn.Session, wh Webhook) (Webhook, error) {
+	if err := svc.validate(ctx, wh); err != nil {
+		return Webhook{}, err
+	}
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return Webhook{}, err
+	}
+	if wh.Secret == "" {
+		if wh.Secret, err = newSecret(); err != nil {
+			return Webhook{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+		}
+	}
+	wh.ID = id
+	wh.DomainID = session.DomainID
+	wh.Status = EnabledStatus
+	wh.CreatedBy = session.UserID
+	wh.CreatedAt = time.Now()
+
+	saved, err := svc.repo.Save(ctx, wh)
+	if err != nil {
+		return Webhook{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return saved, nil
+}
+
+func (svc *service) ViewWebhook(ctx context.Context, session mgauthn.Session, id string) (Webhook, error) {
+	wh, err := svc.repo.RetrieveByID(ctx, session.DomainID, id)
+	if err != nil {
+		return Webhook{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return wh, nil
+}
+
+func (svc *service) ListWebhooks(ctx context.Context, session mgauthn.Session, pm PageMetadata) (WebhooksPage, error) {
+	pm.DomainID = session.DomainID
+	page, err := svc.repo.RetrieveAll(ctx, pm)
+	if err != nil {
+		return WebhooksPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (svc *service) UpdateWebhook(ctx context.Context, session mgauthn.Session, wh Webhook) (Webhook, error) {
+	if err := svc.validate(ctx, wh); err != nil {
+		return Webhook{}, err
+	}
+	wh.DomainID = session.DomainID
+	wh.UpdatedBy = session.UserID
+	wh.UpdatedAt = time.Now()
+
+	updated, err := svc.repo.Update(ctx, wh)
+	if err != nil {
+		return Webhook{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return updated, nil
+}
+
+func (svc *service) EnableWebhook(ctx context.Context, session mgauthn.Session, id string) (Webhook, error) {
+	return svc.changeStatus(ctx, session, id, EnabledStatus)
+}
+
+func (svc *service) DisableWebhook(ctx context.Context, session mgauthn.Session, id string) (Webhook, error) {
+	return svc.changeStatus(ctx, session, id, DisabledStatus)
+}
+
+func (svc *service) RemoveWebhook(ctx context.Context, session mgauthn.Session, id string) error {
+	if err := svc.repo.Remove(ctx, session.DomainID, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (svc *service) ListDeliveries(ctx context.Context, session mgauthn.Session, pm DeliveriesPageMetadata) (DeliveriesPage, error) {
+	if _, err := svc.repo.RetrieveByID(ctx, session.DomainID, pm.WebhookID); err != nil {
+		return DeliveriesPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	pm.DomainID = session.DomainID
+	page, err := svc.repo.RetrieveDeliveries(ctx, pm)
+	if err != nil {
+		return DeliveriesPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (svc *service) Redeliver(ctx context.Context, session mgauthn.Session, webhookID, deliveryID string) (Delivery, error) {
+	wh, err := svc.repo.RetrieveByID(ctx, session.DomainID, webhookID)
+	if err != nil {
+		return Delivery{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	d, err := svc.repo.RetrieveDelivery(ctx, session.DomainID, deliveryID)
+	if err != nil {
+		return Delivery{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if d.WebhookID != wh.ID {
+		return Delivery{}, svcerr.ErrNotFound
+	}
+
+	return svc.attempt(ctx, wh, d, false)
+}
+
+func (svc *service) HandleEvent(ctx context.Context, event Event) error {
+	// Events which don't belong to a domain are never delivered, so tenants
+	// can't subscribe to platform events.
+	if event.DomainID == "" || event.Operation == "" {
+		return nil
+	}
+
+	whs, err := svc.repo.RetrieveByDomain(ctx, event.DomainID)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	var matched []Webhook
+	for _, wh := range whs {
+		if wh.Matches(event.Operation) {
+			matched = append(matched, wh)
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+
+	if event.ID == "" {
+		if event.ID, err = svc.idProvider.ID(); err != nil {
+			return err
+		}
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	now := time.Now()
+	deliveries := make([]Delivery, 0, len(matched))
+	for _, wh := range matched {
+		id, err := svc.idProvider.ID()
+		if err != nil {
+			return err
+		}
+		deliveries = append(deliveries, Delivery{
+			ID:            id,
+			WebhookID:     wh.ID,
+			DomainID:      wh.DomainID,
+			EventID:       event.ID,
+			Operation:     event.Operation,
+			Payload:       payload,
+			Status:        PendingDelivery,
+			CreatedAt:     now,
+			NextAttemptAt: now,
+		})
+	}
+	if err := svc.repo.SaveDeliveries(ctx, deliveries); err != nil {
+		return errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (svc *service) DispatchDeliveries(ctx context.Context) (uint64, error) {
+	now := time.Now()
+	deliveries, err := svc.repo.RetrieveDue(ctx, now, now.Add(dispatchLease), dispatchBatch)
+	if err != nil {
+		return 0, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	whs := make(map[string]Webhook)
+	var wg sync.WaitGroup
+	for _, d := range deliveries {
+		wh, ok := whs[d.WebhookID]
+		if !ok {
+			wh, err = svc.repo.RetrieveByID(ctx, d.DomainID, d.WebhookID)
+			switch {
+			case errors.Contains(err, repoerr.ErrNotFound):
+				wh = Webhook{ID: d.WebhookID, Status: DisabledStatus}
+			case err != nil:
+				return 0, errors.Wrap(svcerr.ErrViewEntity, err)
+			}
+			whs[d.WebhookID] = wh
+		}
+		if wh.Status != EnabledStatus {
+			if err := svc.fail(ctx, d, ErrWebhookDisabled); err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		wg.Add(1)
+		go func(wh Webhook, d Delivery) {
+			defer wg.Done()
+			// Failed attempts are recorded in the delivery log.
+			_, _ = svc.attempt(ctx, wh, d, true)
+		}(wh, d)
+	}
+	wg.Wait()
+
+	return uint64(len(deliveries)), nil
+}
+
+// attempt sends the delivery and records the attempt. Failed deliveries
+// are scheduled for the next attempt if retry is set and attempts are not
+// exhausted.
+func (svc *service) attempt(ctx context.Context, wh Webhook, d Delivery, retry bool) (Delivery, error) {
+	code, err := svc.sender.Send(ctx, wh, d)
+	now := time.Now()
+	d.Attempts++
+	d.ResponseCode = code
+	d.UpdatedAt = now
+	d.NextAttemptAt = time.Time{}
+
+	switch {
+	case err == nil:
+		d.Status = SucceededDelivery
+		d.Error = ""
+	case retry && d.Attempts < svc.retry.MaxAttempts:
+		d.Status = PendingDelivery
+		d.Error = err.Error()
+		d.NextAttemptAt = now.Add(svc.retry.Backoff(d.Attempts))
+	default:
+		d.Status = FailedDelivery
+		d.Error = err.Error()
+	}
+
+	updated, err := svc.repo.UpdateDelivery(ctx, d)
+	if err != nil {
+		return Delivery{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return updated, nil
+}
+
+// fail marks the delivery as failed without attempting it.
+func (svc *service) fail(ctx context.Context, d Delivery, reason error) error {
+	d.Status = FailedDelivery
+	d.Error = reason.Error()
+	d.UpdatedAt = time.Now()
+	d.NextAttemptAt = time.Time{}
+	if _, err := svc.repo.UpdateDelivery(ctx, d); err != nil {
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func (svc *service) changeStatus(ctx context.Context, session mgauthn.Session, id string, status Status) (Webhook, error) {
+	wh, err := svc.repo.RetrieveByID(ctx, session.DomainID, id)
+	if err != nil {
+		return Webhook{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if wh.Status == status {
+		return Webhook{}, errors.ErrStatusAlreadyAssigned
+	}
+	wh.Status = status
+	wh.UpdatedBy = session.UserID
+	wh.UpdatedAt = time.Now()
+
+	wh, err = svc.repo.ChangeStatus(ctx, wh)
+	if err != nil {
+		return Webhook{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return wh, nil
+}
+
+func (svc *service) validate(ctx context.Context, wh Webhook) error {
+	u, err := url.Parse(wh.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return errors.Wrap(svcerr.ErrMalformedEntity, ErrInvalidURL)
+	}
+	if err := svc.addresses.CheckHost(ctx, u.Hostname()); err != nil {
+		return errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if len(wh.Events) == 0 {
+		return errors.Wrap(svcerr.ErrMalformedEntity, ErrMissingEvents)
+	}
+	for _, event := range wh.Events {
+		if event == "" {
+			return errors.Wrap(svcerr.ErrMalformedEntity, ErrMissingEvents)
+		}
+	}
+
+	return nil
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,390 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	mgauthn "github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/absmach/magistrala/webhooks"
+	"github.com/absmach/magistrala/webhooks/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const url = "https://203.0.113.10/hooks"
+
+var (
+	domainID = testsutil.GenerateUUID(&testing.T{})
+	userID   = testsutil.GenerateUUID(&testing.T{})
+	session  = mgauthn.Session{DomainID: domainID, UserID: userID, DomainUserID: domainID + "_" + userID}
+	retry    = webhooks.RetryConfig{MaxAttempts: 3, InitialInterval: time.Second, MaxInterval: time.Minute}
+)
+
+func newService() (webhooks.Service, *mocks.Repository, *mocks.Sender) {
+	repo := new(mocks.Repository)
+	sender := new(mocks.Sender)
+	idp := uuid.NewMock()
+
+	addresses, _ := webhooks.NewAddressFilter([]string{"10.1.0.0/16"})
+
+	return webhooks.New(idp, repo, sender, addresses, retry), repo, sender
+}
+
+func savedWebhook(_ context.Context, wh webhooks.Webhook) webhooks.Webhook {
+	return wh
+}
+
+func updatedDelivery(_ context.Context, d webhooks.Delivery) webhooks.Delivery {
+	return d
+}
+
+func TestCreateWebhook(t *testing.T) {
+	cases := []struct {
+		desc    string
+		wh      webhooks.Webhook
+		saveErr error
+		err     error
+	}{
+		{
+			desc: "create webhook",
+			wh:   webhooks.Webhook{Name: "ci", URL: url, Events: []string{"thing.*"}},
+		},
+		{
+			desc: "create webhook with secret",
+			wh:   webhooks.Webhook{URL: url, Secret: "secret", Events: []string{"*"}},
+		},
+		{
+			desc: "create webhook with invalid URL",
+			wh:   webhooks.Webhook{URL: "ftp://example.com", Events: []string{"*"}},
+			err:  webhooks.ErrInvalidURL,
+		},
+		{
+			desc: "create webhook with loopback URL",
+			wh:   webhooks.Webhook{URL: "http://localhost:9030/hooks", Events: []string{"*"}},
+			err:  webhooks.ErrForbiddenAddress,
+		},
+		{
+			desc: "create webhook with private URL",
+			wh:   webhooks.Webhook{URL: "http://192.168.1.10/hooks", Events: []string{"*"}},
+			err:  webhooks.ErrForbiddenAddress,
+		},
+		{
+			desc: "create webhook with link-local URL",
+			wh:   webhooks.Webhook{URL: "http://169.254.169.254/latest/meta-data", Events: []string{"*"}},
+			err:  webhooks.ErrForbiddenAddress,
+		},
+		{
+			desc: "create webhook with IPv4-mapped loopback URL",
+			wh:   webhooks.Webhook{URL: "http://[::ffff:127.0.0.1]/hooks", Events: []string{"*"}},
+			err:  webhooks.ErrForbiddenAddress,
+		},
+		{
+			desc: "create webhook with allowed private URL",
+			wh:   webhooks.Webhook{URL: "http://10.1.2.3/hooks", Events: []string{"*"}},
+		},
+		{
+			desc: "create webhook without events",
+			wh:   webhooks.Webhook{URL: url},
+			err:  webhooks.ErrMissingEvents,
+		},
+		{
+			desc:    "create webhook with failed repo save",
+			wh:      webhooks.Webhook{URL: url, Events: []string{"*"}},
+			saveErr: repoerr.ErrCreateEntity,
+			err:     svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, repo, _ := newService()
+			repo.On("Save", context.Background(), mock.Anything).Return(savedWebhook, tc.saveErr)
+			wh, err := svc.CreateWebhook(context.Background(), session, tc.wh)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.NotEmpty(t, wh.ID, fmt.Sprintf("%s: expected webhook ID to be set", tc.desc))
+				assert.NotEmpty(t, wh.Secret, fmt.Sprintf("%s: expected webhook secret to be set", tc.desc))
+				assert.Equal(t, domainID, wh.DomainID, fmt.Sprintf("%s: expected domain %s got %s\n", tc.desc, domainID, wh.DomainID))
+				assert.Equal(t, webhooks.EnabledStatus, wh.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, webhooks.EnabledStatus, wh.Status))
+			}
+			if tc.wh.Secret != "" && err == nil {
+				assert.Equal(t, tc.wh.Secret, wh.Secret, fmt.Sprintf("%s: expected secret %s got %s\n", tc.desc, tc.wh.Secret, wh.Secret))
+			}
+		})
+	}
+}
+
+func TestHandleEvent(t *testing.T) {
+	thingHook := webhooks.Webhook{ID: testsutil.GenerateUUID(t), DomainID: domainID, URL: url, Events: []string{"thing.*"}}
+	allHook := webhooks.Webhook{ID: testsutil.GenerateUUID(t), DomainID: domainID, URL: url, Events: []string{"*"}}
+	channelHook := webhooks.Webhook{ID: testsutil.GenerateUUID(t), DomainID: domainID, URL: url, Events: []string{"channel.create"}}
+	hooks := []webhooks.Webhook{thingHook, allHook, channelHook}
+	eventID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc        string
+		event       webhooks.Event
+		retrieveErr error
+		saveErr     error
+		webhooks    []string
+		err         error
+	}{
+		{
+			desc:     "handle event matching webhooks",
+			event:    webhooks.Event{Operation: "thing.create", DomainID: domainID, Data: map[string]interface{}{"id": "thing"}},
+			webhooks: []string{thingHook.ID, allHook.ID},
+		},
+		{
+			desc:     "handle event matching single webhook",
+			event:    webhooks.Event{Operation: "user.update", DomainID: domainID},
+			webhooks: []string{allHook.ID},
+		},
+		{
+			desc:     "handle event with idempotency key",
+			event:    webhooks.Event{ID: eventID, Operation: "thing.remove", DomainID: domainID},
+			webhooks: []string{thingHook.ID, allHook.ID},
+		},
+		{
+			desc:  "handle event without domain",
+			event: webhooks.Event{Operation: "user.create"},
+		},
+		{
+			desc:        "handle event with failed webhooks retrieval",
+			event:       webhooks.Event{Operation: "thing.create", DomainID: domainID},
+			retrieveErr: repoerr.ErrViewEntity,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:     "handle event with failed deliveries save",
+			event:    webhooks.Event{Operation: "thing.create", DomainID: domainID},
+			saveErr:  repoerr.ErrCreateEntity,
+			webhooks: []string{thingHook.ID, allHook.ID},
+			err:      svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, repo, _ := newService()
+			repo.On("RetrieveByDomain", context.Background(), domainID).Return(hooks, tc.retrieveErr).Maybe()
+			repo.On("SaveDeliveries", context.Background(), mock.Anything).Return(tc.saveErr).Maybe()
+			err := svc.HandleEvent(context.Background(), tc.event)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if len(tc.webhooks) == 0 {
+				repo.AssertNotCalled(t, "SaveDeliveries", mock.Anything, mock.Anything)
+				return
+			}
+			deliveries := repo.Calls[len(repo.Calls)-1].Arguments.Get(1).([]webhooks.Delivery)
+			assert.Len(t, deliveries, len(tc.webhooks), fmt.Sprintf("%s: expected %d deliveries got %d\n", tc.desc, len(tc.webhooks), len(deliveries)))
+			for i, d := range deliveries {
+				assert.Equal(t, tc.webhooks[i], d.WebhookID, fmt.Sprintf("%s: expected webhook %s got %s\n", tc.desc, tc.webhooks[i], d.WebhookID))
+				assert.Equal(t, webhooks.PendingDelivery, d.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, webhooks.PendingDelivery, d.Status))
+				assert.Equal(t, deliveries[0].EventID, d.EventID, fmt.Sprintf("%s: expected deliveries to share event ID", tc.desc))
+				var event webhooks.Event
+				assert.Nil(t, json.Unmarshal(d.Payload, &event), fmt.Sprintf("%s: unexpected error decoding payload", tc.desc))
+				assert.Equal(t, tc.event.Operation, event.Operation, fmt.Sprintf("%s: expected operation %s got %s\n", tc.desc, tc.event.Operation, event.Operation))
+				assert.Equal(t, d.EventID, event.ID, fmt.Sprintf("%s: expected event ID %s got %s\n", tc.desc, d.EventID, event.ID))
+				if tc.event.ID != "" {
+					assert.Equal(t, tc.event.ID, d.EventID, fmt.Sprintf("%s: expected event ID %s got %s\n", tc.desc, tc.event.ID, d.EventID))
+				}
+			}
+		})
+	}
+}
+
+func TestDispatchDeliveries(t *testing.T) {
+	wh := webhooks.Webhook{ID: testsutil.GenerateUUID(t), DomainID: domainID, URL: url, Events: []string{"*"}, Status: webhooks.EnabledStatus}
+	disabled := wh
+	disabled.Status = webhooks.DisabledStatus
+	delivery := webhooks.Delivery{
+		ID:        testsutil.GenerateUUID(t),
+		WebhookID: wh.ID,
+		DomainID:  domainID,
+		Operation: "thing.create",
+		Payload:   json.RawMessage(`{}`),
+		Status:    webhooks.PendingDelivery,
+	}
+	lastAttempt := delivery
+	lastAttempt.Attempts = retry.MaxAttempts - 1
+
+	cases := []struct {
+		desc        string
+		delivery    webhooks.Delivery
+		webhook     webhooks.Webhook
+		retrieveErr error
+		sendErr     error
+		code        int
+		status      webhooks.DeliveryStatus
+		attempts    uint64
+		retried     bool
+	}{
+		{
+			desc:     "dispatch delivery",
+			delivery: delivery,
+			webhook:  wh,
+			code:     200,
+			status:   webhooks.SucceededDelivery,
+			attempts: 1,
+		},
+		{
+			desc:     "dispatch delivery with failed attempt",
+			delivery: delivery,
+			webhook:  wh,
+			sendErr:  errors.New("unexpected response status"),
+			code:     500,
+			status:   webhooks.PendingDelivery,
+			attempts: 1,
+			retried:  true,
+		},
+		{
+			desc:     "dispatch delivery with last failed attempt",
+			delivery: lastAttempt,
+			webhook:  wh,
+			sendErr:  errors.New("unexpected response status"),
+			code:     500,
+			status:   webhooks.FailedDelivery,
+			attempts: retry.MaxAttempts,
+		},
+		{
+			desc:     "dispatch delivery of disabled webhook",
+			delivery: delivery,
+			webhook:  disabled,
+			status:   webhooks.FailedDelivery,
+		},
+		{
+			desc:        "dispatch delivery of removed webhook",
+			delivery:    delivery,
+			retrieveErr: repoerr.ErrNotFound,
+			status:      webhooks.FailedDelivery,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, repo, sender := newService()
+			repo.On("RetrieveDue", context.Background(), mock.Anything, mock.Anything, mock.Anything).Return([]webhooks.Delivery{tc.delivery}, nil)
+			repo.On("RetrieveByID", context.Background(), domainID, wh.ID).Return(tc.webhook, tc.retrieveErr)
+			repo.On("UpdateDelivery", context.Background(), mock.Anything).Return(updatedDelivery, nil)
+			sender.On("Send", context.Background(), tc.webhook, tc.delivery).Return(tc.code, tc.sendErr).Maybe()
+			count, err := svc.DispatchDeliveries(context.Background())
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, uint64(1), count, fmt.Sprintf("%s: expected 1 dispatched delivery got %d\n", tc.desc, count))
+			d := repo.Calls[len(repo.Calls)-1].Arguments.Get(1).(webhooks.Delivery)
+			assert.Equal(t, tc.status, d.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, d.Status))
+			assert.Equal(t, tc.attempts, d.Attempts, fmt.Sprintf("%s: expected %d attempts got %d\n", tc.desc, tc.attempts, d.Attempts))
+			assert.Equal(t, tc.code, d.ResponseCode, fmt.Sprintf("%s: expected response code %d got %d\n", tc.desc, tc.code, d.ResponseCode))
+			assert.Equal(t, tc.retried, !d.NextAttemptAt.IsZero(), fmt.Sprintf("%s: expected next attempt to be scheduled: %t", tc.desc, tc.retried))
+		})
+	}
+}
+
+func TestRedeliver(t *testing.T) {
+	wh := webhooks.Webhook{ID: testsutil.GenerateUUID(t), DomainID: domainID, URL: url, Events: []string{"*"}}
+	failed := webhooks.Delivery{
+		ID:        testsutil.GenerateUUID(t),
+		WebhookID: wh.ID,
+		DomainID:  domainID,
+		Status:    webhooks.FailedDelivery,
+		Attempts:  retry.MaxAttempts,
+	}
+	other := failed
+	other.WebhookID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc        string
+		delivery    webhooks.Delivery
+		retrieveErr error
+		sendErr     error
+		status      webhooks.DeliveryStatus
+		err         error
+	}{
+		{
+			desc:     "redeliver failed delivery",
+			delivery: failed,
+			status:   webhooks.SucceededDelivery,
+		},
+		{
+			desc:     "redeliver delivery with failed attempt",
+			delivery: failed,
+			sendErr:  errors.New("connection refused"),
+			status:   webhooks.FailedDelivery,
+		},
+		{
+			desc:     "redeliver delivery of other webhook",
+			delivery: other,
+			err:      svcerr.ErrNotFound,
+		},
+		{
+			desc:        "redeliver non-existing delivery",
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, repo, sender := newService()
+			repo.On("RetrieveByID", context.Background(), domainID, wh.ID).Return(wh, nil)
+			repo.On("RetrieveDelivery", context.Background(), domainID, failed.ID).Return(tc.delivery, tc.retrieveErr)
+			repo.On("UpdateDelivery", context.Background(), mock.Anything).Return(updatedDelivery, nil).Maybe()
+			sender.On("Send", context.Background(), wh, tc.delivery).Return(200, tc.sendErr).Maybe()
+			d, err := svc.Redeliver(context.Background(), session, wh.ID, failed.ID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.status, d.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, d.Status))
+				assert.Equal(t, failed.Attempts+1, d.Attempts, fmt.Sprintf("%s: expected %d attempts got %d\n", tc.desc, failed.Attempts+1, d.Attempts))
+				assert.True(t, d.NextAttemptAt.IsZero(), fmt.Sprintf("%s: expected redelivery not to be retried", tc.desc))
+			}
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	cases := []struct {
+		desc      string
+		events    []string
+		operation string
+		matches   bool
+	}{
+		{desc: "match exact operation", events: []string{"thing.create"}, operation: "thing.create", matches: true},
+		{desc: "match prefix", events: []string{"channel.*", "thing.*"}, operation: "thing.update", matches: true},
+		{desc: "match all operations", events: []string{"*"}, operation: "domain.create", matches: true},
+		{desc: "match other operation", events: []string{"thing.create"}, operation: "thing.remove", matches: false},
+		{desc: "match prefix without separator", events: []string{"thing.*"}, operation: "things.create", matches: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			wh := webhooks.Webhook{Events: tc.events}
+			assert.Equal(t, tc.matches, wh.Matches(tc.operation), fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.matches, !tc.matches))
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	cases := []struct {
+		attempts uint64
+		interval time.Duration
+	}{
+		{attempts: 1, interval: time.Second},
+		{attempts: 2, interval: 2 * time.Second},
+		{attempts: 4, interval: 8 * time.Second},
+		{attempts: 10, interval: time.Minute},
+	}
+
+	for _, tc := range cases {
+		interval := retry.Backoff(tc.attempts)
+		assert.Equal(t, tc.interval, interval, fmt.Sprintf("attempt %d: expected interval %s got %s\n", tc.attempts, tc.interval, interval))
+	}
+}
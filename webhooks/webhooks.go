@@ -0,0 +1,337 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// Headers sent with every delivery. The signature is the hex encoded
+// HMAC-SHA256 of `<timestamp>.<body>` computed with the webhook secret,
+// prefixed with `sha256=`. Receivers should reject deliveries with a
+// timestamp too far in the past to prevent replays.
+const (
+	SignatureHeader = "X-Magistrala-Signature"
+	TimestampHeader = "X-Magistrala-Timestamp"
+	EventHeader     = "X-Magistrala-Event"
+	DeliveryHeader  = "X-Magistrala-Delivery"
+
+	signaturePrefix = "sha256="
+)
+
+// Status represents webhook status.
+type Status uint8
+
+// Possible webhook status values.
+const (
+	EnabledStatus Status = iota
+	DisabledStatus
+
+	// AllStatus is used for querying purposes to list webhooks irrespective
+	// of their status. It is never stored in the database.
+	AllStatus
+)
+
+// String representation of the possible status values.
+const (
+	Enabled  = "enabled"
+	Disabled = "disabled"
+	All      = "all"
+	Unknown  = "unknown"
+)
+
+// String converts webhook status to string literal.
+func (s Status) String() string {
+	switch s {
+	case EnabledStatus:
+		return Enabled
+	case DisabledStatus:
+		return Disabled
+	case AllStatus:
+		return All
+	default:
+		return Unknown
+	}
+}
+
+// ToStatus converts string value to a valid webhook status.
+func ToStatus(status string) (Status, error) {
+	switch status {
+	case "", Enabled:
+		return EnabledStatus, nil
+	case Disabled:
+		return DisabledStatus, nil
+	case All:
+		return AllStatus, nil
+	}
+
+	return Status(0), svcerr.ErrInvalidStatus
+}
+
+// MarshalJSON converts webhook status to JSON string.
+func (s Status) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// DeliveryStatus represents delivery status.
+type DeliveryStatus uint8
+
+// Possible delivery status values.
+const (
+	// PendingDelivery represents a delivery which is not yet attempted or
+	// which failed and is scheduled to be retried.
+	PendingDelivery DeliveryStatus = iota
+	// SucceededDelivery represents a delivery the endpoint accepted.
+	SucceededDelivery
+	// FailedDelivery represents a delivery which failed all attempts.
+	FailedDelivery
+
+	// AllDeliveries is used for querying purposes to list deliveries
+	// irrespective of their status. It is never stored in the database.
+	AllDeliveries
+)
+
+// String representation of the possible delivery status values.
+const (
+	Pending   = "pending"
+	Succeeded = "succeeded"
+	Failed    = "failed"
+)
+
+// String converts delivery status to string literal.
+func (s DeliveryStatus) String() string {
+	switch s {
+	case PendingDelivery:
+		return Pending
+	case SucceededDelivery:
+		return Succeeded
+	case FailedDelivery:
+		return Failed
+	case AllDeliveries:
+		return All
+	default:
+		return Unknown
+	}
+}
+
+// ToDeliveryStatus converts string value to a valid delivery status.
+func ToDeliveryStatus(status string) (DeliveryStatus, error) {
+	switch status {
+	case Pending:
+		return PendingDelivery, nil
+	case Succeeded:
+		return SucceededDelivery, nil
+	case Failed:
+		return FailedDelivery, nil
+	case "", All:
+		return AllDeliveries, nil
+	}
+
+	return DeliveryStatus(0), svcerr.ErrInvalidStatus
+}
+
+// MarshalJSON converts delivery status to JSON string.
+func (s DeliveryStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// Webhook delivers the events of its domain to the external HTTP endpoint.
+type Webhook struct {
+	ID       string `json:"id"`
+	Name     string `json:"name,omitempty"`
+	DomainID string `json:"domain_id"`
+	URL      string `json:"url"`
+	// Secret is the key used to sign deliveries. It is returned only when
+	// the webhook is created.
+	Secret string `json:"secret,omitempty"`
+	// Events are the event operations delivered to the webhook, for example
+	// `thing.create`. Event `thing.*` matches all operations with the
+	// `thing.` prefix, and event `*` matches all operations.
+	Events    []string  `json:"events"`
+	Status    Status    `json:"status"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedBy string    `json:"updated_by,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// Matches reports whether the webhook is subscribed to the operation.
+func (wh Webhook) Matches(operation string) bool {
+	for _, event := range wh.Events {
+		switch {
+		case event == "*", event == operation:
+			return true
+		case strings.HasSuffix(event, ".*") && strings.HasPrefix(operation, strings.TrimSuffix(event, "*")):
+			return true
+		}
+	}
+
+	return false
+}
+
+// Event is the platform event delivered to webhooks. Event ID is shared by
+// all deliveries of the event, so receivers can use it to detect duplicates.
+type Event struct {
+	ID         string                 `json:"id"`
+	Operation  string                 `json:"operation"`
+	DomainID   string                 `json:"domain_id"`
+	OccurredAt time.Time              `json:"occurred_at"`
+	Data       map[string]interface{} `json:"data"`
+}
+
+// Delivery is a record of the event sent to the webhook endpoint.
+type Delivery struct {
+	ID        string          `json:"id"`
+	WebhookID string          `json:"webhook_id"`
+	DomainID  string          `json:"domain_id"`
+	EventID   string          `json:"event_id"`
+	Operation string          `json:"operation"`
+	Payload   json.RawMessage `json:"payload"`
+	Status    DeliveryStatus  `json:"status"`
+	Attempts  uint64          `json:"attempts"`
+	// ResponseCode is the HTTP status code returned by the endpoint in the
+	// latest attempt.
+	ResponseCode  int       `json:"response_code,omitempty"`
+	Error         string    `json:"error,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at,omitempty"`
+	NextAttemptAt time.Time `json:"next_attempt_at,omitempty"`
+}
+
+// RetryConfig contains delivery retry parameters. Failed deliveries are
+// retried with exponential backoff starting from the initial interval.
+type RetryConfig struct {
+	MaxAttempts     uint64
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+}
+
+// Backoff returns the interval to wait before the next attempt, after the
+// provided number of failed attempts.
+func (rc RetryConfig) Backoff(attempts uint64) time.Duration {
+	interval := rc.InitialInterval
+	for i := uint64(1); i < attempts && interval < rc.MaxInterval; i++ {
+		interval *= 2
+	}
+	if rc.MaxInterval > 0 && interval > rc.MaxInterval {
+		return rc.MaxInterval
+	}
+
+	return interval
+}
+
+// Sign returns the signature of the delivery payload sent at the provided
+// Unix timestamp.
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(payload)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether the signature matches the delivery payload sent at
+// the provided Unix timestamp.
+func Verify(secret string, timestamp int64, payload []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, payload)), []byte(signature))
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Offset   uint64 `json:"offset"`
+	Limit    uint64 `json:"limit"`
+	DomainID string `json:"domain_id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Status   Status `json:"status,omitempty"`
+}
+
+// WebhooksPage contains page related metadata as well as list of webhooks
+// that belong to this page.
+type WebhooksPage struct {
+	PageMetadata
+	Total    uint64    `json:"total"`
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+// DeliveriesPageMetadata contains deliveries page metadata that helps
+// navigation.
+type DeliveriesPageMetadata struct {
+	Offset    uint64         `json:"offset"`
+	Limit     uint64         `json:"limit"`
+	DomainID  string         `json:"domain_id,omitempty"`
+	WebhookID string         `json:"webhook_id,omitempty"`
+	Operation string         `json:"operation,omitempty"`
+	Status    DeliveryStatus `json:"status,omitempty"`
+}
+
+// DeliveriesPage contains page related metadata as well as list of
+// deliveries that belong to this page.
+type DeliveriesPage struct {
+	DeliveriesPageMetadata
+	Total      uint64     `json:"total"`
+	Deliveries []Delivery `json:"deliveries"`
+}
+
+// Sender sends deliveries to webhook endpoints.
+//
+//go:generate mockery --name Sender --output=./mocks --filename sender.go --quiet --note "Copyright (c) Abstract Machines"
+type Sender interface {
+	// Send posts the delivery payload to the webhook endpoint and returns
+	// the response status code. Any non 2xx response is an error.
+	Send(ctx context.Context, wh Webhook, delivery Delivery) (int, error)
+}
+
+// Repository specifies a webhook and delivery persistence API.
+//
+//go:generate mockery --name Repository --output=./mocks --filename repository.go --quiet --note "Copyright (c) Abstract Machines"
+type Repository interface {
+	// Save persists the webhook.
+	Save(ctx context.Context, wh Webhook) (Webhook, error)
+
+	// RetrieveByID retrieves the webhook of the domain having the provided identifier.
+	RetrieveByID(ctx context.Context, domainID, id string) (Webhook, error)
+
+	// RetrieveAll retrieves webhooks of the domain.
+	RetrieveAll(ctx context.Context, pm PageMetadata) (WebhooksPage, error)
+
+	// RetrieveByDomain retrieves all enabled webhooks of the domain.
+	RetrieveByDomain(ctx context.Context, domainID string) ([]Webhook, error)
+
+	// Update updates the webhook endpoint and events. Secret is updated
+	// only if provided.
+	Update(ctx context.Context, wh Webhook) (Webhook, error)
+
+	// ChangeStatus changes the webhook status.
+	ChangeStatus(ctx context.Context, wh Webhook) (Webhook, error)
+
+	// Remove removes the webhook of the domain and its deliveries.
+	Remove(ctx context.Context, domainID, id string) error
+
+	// SaveDeliveries persists the deliveries.
+	SaveDeliveries(ctx context.Context, deliveries []Delivery) error
+
+	// RetrieveDelivery retrieves the delivery of the domain having the
+	// provided identifier.
+	RetrieveDelivery(ctx context.Context, domainID, id string) (Delivery, error)
+
+	// RetrieveDeliveries retrieves deliveries of the domain.
+	RetrieveDeliveries(ctx context.Context, pm DeliveriesPageMetadata) (DeliveriesPage, error)
+
+	// RetrieveDue retrieves at most limit pending deliveries scheduled
+	// before now and postpones their next attempt until the lease time,
+	// so concurrent dispatchers don't pick them up.
+	RetrieveDue(ctx context.Context, now, lease time.Time, limit uint64) ([]Delivery, error)
+
+	// UpdateDelivery updates the delivery status and attempt details.
+	UpdateDelivery(ctx context.Context, delivery Delivery) (Delivery, error)
+}
@@ -6,12 +6,16 @@ package ws
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/absmach/magistrala"
 	"github.com/absmach/magistrala/pkg/errors"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/absmach/magistrala/pkg/policies"
+	"github.com/absmach/magistrala/pkg/presence"
+	"github.com/absmach/magistrala/pkg/schema"
 )
 
 const chansPrefix = "channels"
@@ -28,6 +32,9 @@ var (
 
 	// ErrEmptyTopic indicate absence of thingKey in the request.
 	ErrEmptyTopic = errors.New("empty topic")
+
+	// ErrNotSubscribed indicates that client is not subscribed to specified channel.
+	ErrNotSubscribed = errors.New("not subscribed to a channel")
 )
 
 // Service specifies web socket service API.
@@ -36,20 +43,39 @@ type Service interface {
 	// and the channelID for subscription. Subtopic is optional.
 	// If the subscription is successful, nil is returned otherwise error is returned.
 	Subscribe(ctx context.Context, thingKey, chanID, subtopic string, client *Client) error
+
+	// Unsubscribe unsubscribes the multiplexed client from the channel and subtopic.
+	Unsubscribe(ctx context.Context, chanID, subtopic string, client *Client) error
+
+	// Publish publishes the message of the multiplexed client using the
+	// thingKey for authorization.
+	Publish(ctx context.Context, thingKey string, msg *messaging.Message, client *Client) error
+
+	// Disconnect unsubscribes the multiplexed client from all the channels
+	// after its connection is closed.
+	Disconnect(ctx context.Context, client *Client) error
 }
 
 var _ Service = (*adapterService)(nil)
 
 type adapterService struct {
-	things magistrala.ThingsServiceClient
-	pubsub messaging.PubSub
+	things     magistrala.ThingsServiceClient
+	pubsub     messaging.PubSub
+	validator  schema.Validator
+	es         presence.EventStore
+	idProvider magistrala.IDProvider
+	logger     *slog.Logger
 }
 
 // New instantiates the WS adapter implementation.
-func New(thingsClient magistrala.ThingsServiceClient, pubsub messaging.PubSub) Service {
+func New(thingsClient magistrala.ThingsServiceClient, pubsub messaging.PubSub, validator schema.Validator, es presence.EventStore, idp magistrala.IDProvider, logger *slog.Logger) Service {
 	return &adapterService{
-		things: thingsClient,
-		pubsub: pubsub,
+		things:     thingsClient,
+		pubsub:     pubsub,
+		validator:  validator,
+		es:         es,
+		idProvider: idp,
+		logger:     logger,
 	}
 }
 
@@ -75,13 +101,138 @@ func (svc *adapterService) Subscribe(ctx context.Context, thingKey, chanID, subt
 		Topic:   subject,
 		Handler: c,
 	}
+	if c.mux {
+		svc.connected(ctx, c, thingID)
+		if c.subscribed(subject) {
+			return nil
+		}
+		if subCfg.ID, err = svc.subscriberID(c); err != nil {
+			c.unsubscribed(subject)
+			return errors.Wrap(ErrFailedSubscription, err)
+		}
+	}
 	if err := svc.pubsub.Subscribe(ctx, subCfg); err != nil {
+		if c.mux {
+			c.unsubscribed(subject)
+		}
 		return ErrFailedSubscription
 	}
 
 	return nil
 }
 
+func (svc *adapterService) Unsubscribe(ctx context.Context, chanID, subtopic string, c *Client) error {
+	subject := fmt.Sprintf("%s.%s", chansPrefix, chanID)
+	if subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, subtopic)
+	}
+	if !c.mux || !c.unsubscribed(subject) {
+		return ErrNotSubscribed
+	}
+
+	if err := svc.pubsub.Unsubscribe(ctx, c.subscriberID, subject); err != nil {
+		return errors.Wrap(errFailedUnsubscribe, err)
+	}
+
+	return nil
+}
+
+func (svc *adapterService) Publish(ctx context.Context, thingKey string, msg *messaging.Message, c *Client) error {
+	if msg.GetChannel() == "" || thingKey == "" {
+		return svcerr.ErrAuthentication
+	}
+	if len(msg.GetPayload()) == 0 {
+		return errFailedMessagePublish
+	}
+
+	thingID, err := svc.authorize(ctx, thingKey, msg.GetChannel(), policies.PublishPermission)
+	if err != nil {
+		return err
+	}
+	svc.connected(ctx, c, thingID)
+
+	msg.Protocol = protocol
+	msg.Publisher = thingID
+	msg.Created = time.Now().UnixNano()
+
+	if err := svc.validator.Validate(ctx, msg.GetChannel(), msg.GetPayload()); err != nil {
+		return errors.Wrap(errFailedPublish, err)
+	}
+
+	if err := svc.pubsub.Publish(ctx, msg.GetChannel(), msg); err != nil {
+		return errors.Wrap(errFailedPublishToMsgBroker, err)
+	}
+
+	if err := svc.es.Published(ctx, thingID); err != nil {
+		svc.logger.Error(errors.Wrap(errFailedPresenceEvent, err).Error())
+	}
+
+	return nil
+}
+
+func (svc *adapterService) Disconnect(ctx context.Context, c *Client) error {
+	c.mu.Lock()
+	topics := c.topics
+	c.topics = make(map[string]struct{})
+	connected := c.connected
+	c.mu.Unlock()
+
+	var err error
+	for topic := range topics {
+		if e := svc.pubsub.Unsubscribe(ctx, c.subscriberID, topic); e != nil && err == nil {
+			err = errors.Wrap(errFailedUnsubscribe, e)
+		}
+	}
+	if connected {
+		if e := svc.es.Disconnect(ctx, c.id, c.subscriberID); e != nil && err == nil {
+			err = errors.Wrap(errFailedPresenceEvent, e)
+		}
+	}
+
+	return err
+}
+
+// connected issues the connect event on the first successful
+// authorization of the multiplexed client.
+func (svc *adapterService) connected(ctx context.Context, c *Client, thingID string) {
+	c.mu.Lock()
+	ok := c.connected
+	c.connected = true
+	c.id = thingID
+	c.mu.Unlock()
+	if ok {
+		return
+	}
+
+	// Subscriber ID is unique for every connection, so it identifies the
+	// connection of the thing as well.
+	connID, err := svc.subscriberID(c)
+	if err != nil {
+		svc.logger.Error(errors.Wrap(errFailedPresenceEvent, err).Error())
+		return
+	}
+	if err := svc.es.Connect(ctx, thingID, connID); err != nil {
+		svc.logger.Error(errors.Wrap(errFailedPresenceEvent, err).Error())
+	}
+}
+
+// subscriberID returns the subscriber ID of the multiplexed client,
+// which is unique for every connection of the thing.
+func (svc *adapterService) subscriberID(c *Client) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.subscriberID == "" {
+		id, err := svc.idProvider.ID()
+		if err != nil {
+			return "", err
+		}
+		c.subscriberID = fmt.Sprintf("%s-%s", c.id, id)
+	}
+
+	return c.subscriberID, nil
+}
+
 // authorize checks if the thingKey is authorized to access the channel
 // and returns the thingID if it is.
 func (svc *adapterService) authorize(ctx context.Context, thingKey, chanID, action string) (string, error) {
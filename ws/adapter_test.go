@@ -7,12 +7,18 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/absmach/magistrala"
 	"github.com/absmach/magistrala/internal/testsutil"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/errors"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/absmach/magistrala/pkg/messaging/mocks"
+	presencemocks "github.com/absmach/magistrala/pkg/presence/mocks"
+	"github.com/absmach/magistrala/pkg/schema"
+	"github.com/absmach/magistrala/pkg/uuid"
 	thmocks "github.com/absmach/magistrala/things/mocks"
 	"github.com/absmach/magistrala/ws"
 	"github.com/stretchr/testify/assert"
@@ -41,7 +47,12 @@ func newService() (ws.Service, *mocks.PubSub, *thmocks.ThingsServiceClient) {
 	pubsub := new(mocks.PubSub)
 	things := new(thmocks.ThingsServiceClient)
 
-	return ws.New(things, pubsub), pubsub, things
+	eventStore := new(presencemocks.EventStore)
+	eventStore.On("Connect", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	eventStore.On("Published", mock.Anything, mock.Anything).Return(nil)
+	eventStore.On("Disconnect", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	return ws.New(things, pubsub, newSchemaCache(), eventStore, uuid.NewMock(), mglog.NewMock()), pubsub, things
 }
 
 func TestSubscribe(t *testing.T) {
@@ -123,3 +134,134 @@ func TestSubscribe(t *testing.T) {
 		repocall1.Unset()
 	}
 }
+
+func TestMuxSubscribe(t *testing.T) {
+	svc, pubsub, things := newService()
+
+	c := ws.NewMuxClient(nil)
+	things.On("Authorize", mock.Anything, &magistrala.ThingsAuthzReq{ThingKey: thingKey, ChannelId: chanID, Permission: "subscribe"}).Return(&magistrala.ThingsAuthzRes{Authorized: true, Id: id}, nil)
+	things.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.ThingsAuthzRes{Authorized: false}, nil)
+
+	var subscriberID string
+	subCall := pubsub.On("Subscribe", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		cfg := args.Get(1).(messaging.SubscriberConfig)
+		if subscriberID == "" {
+			subscriberID = cfg.ID
+		}
+		assert.NotEqual(t, id, cfg.ID, "expected subscriber ID to differ from thing ID")
+		assert.Equal(t, subscriberID, cfg.ID, fmt.Sprintf("expected subscriber ID %s got %s", subscriberID, cfg.ID))
+	}).Return(nil)
+
+	cases := []struct {
+		desc     string
+		thingKey string
+		chanID   string
+		subtopic string
+		err      error
+	}{
+		{
+			desc:     "subscribe to channel",
+			thingKey: thingKey,
+			chanID:   chanID,
+			err:      nil,
+		},
+		{
+			desc:     "subscribe to channel subtopic over the same connection",
+			thingKey: thingKey,
+			chanID:   chanID,
+			subtopic: subTopic,
+			err:      nil,
+		},
+		{
+			desc:     "subscribe again to channel subtopic",
+			thingKey: thingKey,
+			chanID:   chanID,
+			subtopic: subTopic,
+			err:      nil,
+		},
+		{
+			desc:     "subscribe to unauthorized channel",
+			thingKey: thingKey,
+			chanID:   invalidID,
+			err:      svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Subscribe(context.Background(), tc.thingKey, tc.chanID, tc.subtopic, c)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+	pubsub.AssertNumberOfCalls(t, "Subscribe", 2)
+	subCall.Unset()
+
+	pubsub.On("Unsubscribe", mock.Anything, subscriberID, mock.Anything).Return(nil)
+	err := svc.Unsubscribe(context.Background(), chanID, subTopic, c)
+	assert.Nil(t, err, fmt.Sprintf("unsubscribe: unexpected error %s", err))
+	err = svc.Unsubscribe(context.Background(), chanID, subTopic, c)
+	assert.Equal(t, ws.ErrNotSubscribed, err, fmt.Sprintf("unsubscribe again: expected %s got %s", ws.ErrNotSubscribed, err))
+
+	err = svc.Disconnect(context.Background(), c)
+	assert.Nil(t, err, fmt.Sprintf("disconnect: unexpected error %s", err))
+	pubsub.AssertCalled(t, "Unsubscribe", mock.Anything, subscriberID, "channels."+chanID)
+	pubsub.AssertNumberOfCalls(t, "Unsubscribe", 2)
+}
+
+func TestPublish(t *testing.T) {
+	svc, pubsub, things := newService()
+
+	c := ws.NewMuxClient(nil)
+	things.On("Authorize", mock.Anything, &magistrala.ThingsAuthzReq{ThingKey: thingKey, ChannelId: chanID, Permission: "publish"}).Return(&magistrala.ThingsAuthzRes{Authorized: true, Id: id}, nil)
+	things.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.ThingsAuthzRes{Authorized: false}, nil)
+
+	cases := []struct {
+		desc       string
+		thingKey   string
+		msg        *messaging.Message
+		publishErr error
+		err        error
+	}{
+		{
+			desc:     "publish message",
+			thingKey: thingKey,
+			msg:      &messaging.Message{Channel: chanID, Subtopic: subTopic, Payload: msg.Payload},
+		},
+		{
+			desc:     "publish message with empty thing key",
+			thingKey: "",
+			msg:      &messaging.Message{Channel: chanID, Payload: msg.Payload},
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "publish message to unauthorized channel",
+			thingKey: thingKey,
+			msg:      &messaging.Message{Channel: invalidID, Payload: msg.Payload},
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:       "publish message with failed publish",
+			thingKey:   thingKey,
+			msg:        &messaging.Message{Channel: chanID, Payload: msg.Payload},
+			publishErr: errors.New("failed"),
+			err:        errors.New("failed to publish to magistrala message broker"),
+		},
+	}
+
+	for _, tc := range cases {
+		pubCall := pubsub.On("Publish", mock.Anything, chanID, mock.Anything).Return(tc.publishErr)
+		err := svc.Publish(context.Background(), tc.thingKey, tc.msg, c)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, id, tc.msg.GetPublisher(), fmt.Sprintf("%s: expected publisher %s got %s\n", tc.desc, id, tc.msg.GetPublisher()))
+			assert.NotZero(t, tc.msg.GetCreated(), fmt.Sprintf("%s: expected created time to be set\n", tc.desc))
+		}
+		pubCall.Unset()
+	}
+}
+
+// newSchemaCache returns the schema cache of the channels without schema.
+func newSchemaCache() schema.Cache {
+	things := new(thmocks.ThingsServiceClient)
+	things.On("ChannelMetadata", mock.Anything, mock.Anything).Return(&magistrala.ChannelMetadataRes{}, nil)
+
+	return schema.NewCache(things, time.Minute)
+}
@@ -11,10 +11,14 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/absmach/magistrala"
 	mglog "github.com/absmach/magistrala/logger"
 	"github.com/absmach/magistrala/pkg/messaging/mocks"
+	presencemocks "github.com/absmach/magistrala/pkg/presence/mocks"
+	"github.com/absmach/magistrala/pkg/schema"
+	"github.com/absmach/magistrala/pkg/uuid"
 	thmocks "github.com/absmach/magistrala/things/mocks"
 	"github.com/absmach/magistrala/ws"
 	"github.com/absmach/magistrala/ws/api"
@@ -38,7 +42,12 @@ var msg = []byte(`[{"n":"current","t":-1,"v":1.6}]`)
 
 func newService(things magistrala.ThingsServiceClient) (ws.Service, *mocks.PubSub) {
 	pubsub := new(mocks.PubSub)
-	return ws.New(things, pubsub), pubsub
+	eventStore := new(presencemocks.EventStore)
+	eventStore.On("Connect", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	eventStore.On("Published", mock.Anything, mock.Anything).Return(nil)
+	eventStore.On("Disconnect", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	return ws.New(things, pubsub, newSchemaCache(), eventStore, uuid.NewMock(), mglog.NewMock()), pubsub
 }
 
 func newHTTPServer(svc ws.Service) *httptest.Server {
@@ -94,7 +103,11 @@ func TestHandshake(t *testing.T) {
 	svc, pubsub := newService(things)
 	target := newHTTPServer(svc)
 	defer target.Close()
-	handler := ws.NewHandler(pubsub, mglog.NewMock(), things)
+	eventStore := new(presencemocks.EventStore)
+	eventStore.On("Connect", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	eventStore.On("Published", mock.Anything, mock.Anything).Return(nil)
+	eventStore.On("Disconnect", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	handler := ws.NewHandler(pubsub, eventStore, mglog.NewMock(), things, newSchemaCache())
 	ts, err := newProxyHTPPServer(handler, target)
 	require.Nil(t, err)
 	defer ts.Close()
@@ -211,3 +224,118 @@ func TestHandshake(t *testing.T) {
 		})
 	}
 }
+
+func TestMuxHandshake(t *testing.T) {
+	things := new(thmocks.ThingsServiceClient)
+	svc, pubsub := newService(things)
+	target := newHTTPServer(svc)
+	defer target.Close()
+	eventStore := new(presencemocks.EventStore)
+	eventStore.On("Connect", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	eventStore.On("Disconnect", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	handler := ws.NewHandler(pubsub, eventStore, mglog.NewMock(), things, newSchemaCache())
+	ts, err := newProxyHTPPServer(handler, target)
+	require.Nil(t, err)
+	defer ts.Close()
+	things.On("Authorize", mock.Anything, &magistrala.ThingsAuthzReq{ThingKey: thingKey, ChannelId: chanID, Permission: "publish"}).Return(&magistrala.ThingsAuthzRes{Authorized: true, Id: id}, nil)
+	things.On("Authorize", mock.Anything, &magistrala.ThingsAuthzReq{ThingKey: thingKey, ChannelId: chanID, Permission: "subscribe"}).Return(&magistrala.ThingsAuthzRes{Authorized: true, Id: id}, nil)
+	things.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.ThingsAuthzRes{Authorized: false}, nil)
+	pubsub.On("Subscribe", mock.Anything, mock.Anything).Return(nil)
+	pubsub.On("Unsubscribe", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	pubsub.On("Publish", mock.Anything, chanID, mock.Anything).Return(nil)
+
+	u := strings.Replace(ts.URL, "http", "ws", 1) + "/messages"
+	header := http.Header{}
+	header.Add("Authorization", thingKey)
+	conn, res, err := websocket.DefaultDialer.Dial(u, header)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	defer conn.Close()
+	assert.Equal(t, http.StatusSwitchingProtocols, res.StatusCode, fmt.Sprintf("expected status code '%d' got '%d'", http.StatusSwitchingProtocols, res.StatusCode))
+
+	cases := []struct {
+		desc        string
+		messageType int
+		data        string
+		ack         bool
+	}{
+		{
+			desc:        "subscribe to channel",
+			messageType: websocket.TextMessage,
+			data:        fmt.Sprintf(`{"type":"subscribe","id":"1","channel":"%s"}`, chanID),
+			ack:         true,
+		},
+		{
+			desc:        "subscribe to channel subtopic",
+			messageType: websocket.TextMessage,
+			data:        fmt.Sprintf(`{"type":"subscribe","id":"2","channel":"%s","subtopic":"subtopic/nested"}`, chanID),
+			ack:         true,
+		},
+		{
+			desc:        "subscribe to unauthorized channel",
+			messageType: websocket.TextMessage,
+			data:        `{"type":"subscribe","id":"3","channel":"invalid"}`,
+		},
+		{
+			desc:        "publish JSON payload",
+			messageType: websocket.TextMessage,
+			data:        fmt.Sprintf(`{"type":"publish","id":"4","channel":"%s","payload":%s}`, chanID, msg),
+			ack:         true,
+		},
+		{
+			desc:        "publish binary payload",
+			messageType: websocket.BinaryMessage,
+			data:        fmt.Sprintf("{\"type\":\"publish\",\"id\":\"5\",\"channel\":\"%s\"}\n\x00\x01\x02", chanID),
+			ack:         true,
+		},
+		{
+			desc:        "publish to wildcard subtopic",
+			messageType: websocket.TextMessage,
+			data:        fmt.Sprintf(`{"type":"publish","id":"6","channel":"%s","subtopic":">","payload":%s}`, chanID, msg),
+		},
+		{
+			desc:        "unsubscribe from channel subtopic",
+			messageType: websocket.TextMessage,
+			data:        fmt.Sprintf(`{"type":"unsubscribe","id":"7","channel":"%s","subtopic":"subtopic.nested"}`, chanID),
+			ack:         true,
+		},
+		{
+			desc:        "unsubscribe from channel which is not subscribed",
+			messageType: websocket.TextMessage,
+			data:        fmt.Sprintf(`{"type":"unsubscribe","id":"8","channel":"%s","subtopic":"other"}`, chanID),
+		},
+		{
+			desc:        "send frame of unknown type",
+			messageType: websocket.TextMessage,
+			data:        fmt.Sprintf(`{"type":"unknown","id":"9","channel":"%s"}`, chanID),
+		},
+		{
+			desc:        "send malformed frame",
+			messageType: websocket.TextMessage,
+			data:        `{"type":`,
+		},
+	}
+
+	for _, tc := range cases {
+		err := conn.WriteMessage(tc.messageType, []byte(tc.data))
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		messageType, data, err := conn.ReadMessage()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		frame, _, err := ws.DecodeFrame(messageType, data)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		switch tc.ack {
+		case true:
+			assert.Equal(t, ws.AckFrame, frame.Type, fmt.Sprintf("%s: expected ack frame got %s: %s", tc.desc, frame.Type, frame.Error))
+		default:
+			assert.Equal(t, ws.ErrorFrame, frame.Type, fmt.Sprintf("%s: expected error frame got %s", tc.desc, frame.Type))
+			assert.NotEmpty(t, frame.Error, fmt.Sprintf("%s: expected error message", tc.desc))
+		}
+	}
+}
+
+// newSchemaCache returns the schema cache of the channels without schema.
+func newSchemaCache() schema.Cache {
+	things := new(thmocks.ThingsServiceClient)
+	things.On("ChannelMetadata", mock.Anything, mock.Anything).Return(&magistrala.ChannelMetadataRes{}, nil)
+
+	return schema.NewCache(things, time.Minute)
+}
@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/absmach/magistrala/ws"
 	"github.com/go-chi/chi/v5"
 )
@@ -42,17 +43,96 @@ func handshake(ctx context.Context, svc ws.Service) http.HandlerFunc {
 	}
 }
 
-func decodeRequest(r *http.Request) (connReq, error) {
+// muxHandshake upgrades the multiplexed connection and serves its
+// control frames until the connection is closed.
+func muxHandshake(ctx context.Context, svc ws.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		thingKey, err := decodeAuthKey(r)
+		if err != nil {
+			encodeError(w, err)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			logger.Warn(fmt.Sprintf("Failed to upgrade connection to websocket: %s", err.Error()))
+			return
+		}
+		client := ws.NewMuxClient(conn)
+
+		logger.Debug("Successfully upgraded communication to multiplexed WS")
+
+		defer func() {
+			if err := svc.Disconnect(ctx, client); err != nil {
+				logger.Warn(fmt.Sprintf("Failed to disconnect multiplexed connection: %s", err.Error()))
+			}
+			conn.Close()
+		}()
+
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			frame, payload, err := ws.DecodeFrame(messageType, data)
+			if err == nil {
+				err = handleFrame(ctx, svc, thingKey, frame, payload, client)
+			}
+			if err = client.Reply(frame.ID, err); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func handleFrame(ctx context.Context, svc ws.Service, thingKey string, frame ws.Frame, payload []byte, c *ws.Client) error {
+	if frame.Channel == "" {
+		return ws.ErrEmptyTopic
+	}
+	subtopic, err := parseSubTopic(frame.Subtopic)
+	if err != nil {
+		return err
+	}
+
+	switch frame.Type {
+	case ws.SubscribeFrame:
+		return svc.Subscribe(ctx, thingKey, frame.Channel, subtopic, c)
+	case ws.UnsubscribeFrame:
+		return svc.Unsubscribe(ctx, frame.Channel, subtopic, c)
+	case ws.PublishFrame:
+		if strings.ContainsAny(subtopic, "*>") {
+			return errMalformedSubtopic
+		}
+		msg := &messaging.Message{
+			Channel:  frame.Channel,
+			Subtopic: subtopic,
+			Payload:  payload,
+		}
+		return svc.Publish(ctx, thingKey, msg, c)
+	default:
+		return ws.ErrMalformedFrame
+	}
+}
+
+func decodeAuthKey(r *http.Request) (string, error) {
 	authKey := r.Header.Get("Authorization")
 	if authKey == "" {
 		authKeys := r.URL.Query()["authorization"]
 		if len(authKeys) == 0 {
 			logger.Debug("Missing authorization key.")
-			return connReq{}, errUnauthorizedAccess
+			return "", errUnauthorizedAccess
 		}
 		authKey = authKeys[0]
 	}
 
+	return authKey, nil
+}
+
+func decodeRequest(r *http.Request) (connReq, error) {
+	authKey, err := decodeAuthKey(r)
+	if err != nil {
+		return connReq{}, err
+	}
+
 	chanID := chi.URLParam(r, "chanID")
 
 	req := connReq{
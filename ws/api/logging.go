@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/absmach/magistrala/ws"
 )
 
@@ -44,3 +45,65 @@ func (lm *loggingMiddleware) Subscribe(ctx context.Context, thingKey, chanID, su
 
 	return lm.svc.Subscribe(ctx, thingKey, chanID, subtopic, c)
 }
+
+// Unsubscribe logs the unsubscribe request. It logs the channel and subtopic(if present) and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) Unsubscribe(ctx context.Context, chanID, subtopic string, c *ws.Client) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("channel_id", chanID),
+		}
+		if subtopic != "" {
+			args = append(args, "subtopic", subtopic)
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Unsubscribe failed", args...)
+			return
+		}
+		lm.logger.Info("Unsubscribe completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Unsubscribe(ctx, chanID, subtopic, c)
+}
+
+// Publish logs the publish request. It logs the channel and subtopic(if present) and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) Publish(ctx context.Context, thingKey string, msg *messaging.Message, c *ws.Client) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("channel_id", msg.GetChannel()),
+		}
+		if msg.GetSubtopic() != "" {
+			args = append(args, "subtopic", msg.GetSubtopic())
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Publish failed", args...)
+			return
+		}
+		lm.logger.Info("Publish completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Publish(ctx, thingKey, msg, c)
+}
+
+// Disconnect logs the disconnect request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) Disconnect(ctx context.Context, c *ws.Client) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Disconnect failed", args...)
+			return
+		}
+		lm.logger.Info("Disconnect completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Disconnect(ctx, c)
+}
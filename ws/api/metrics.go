@@ -9,6 +9,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/absmach/magistrala/ws"
 	"github.com/go-kit/kit/metrics"
 )
@@ -39,3 +40,33 @@ func (mm *metricsMiddleware) Subscribe(ctx context.Context, thingKey, chanID, su
 
 	return mm.svc.Subscribe(ctx, thingKey, chanID, subtopic, c)
 }
+
+// Unsubscribe instruments Unsubscribe method with metrics.
+func (mm *metricsMiddleware) Unsubscribe(ctx context.Context, chanID, subtopic string, c *ws.Client) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "unsubscribe").Add(1)
+		mm.latency.With("method", "unsubscribe").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Unsubscribe(ctx, chanID, subtopic, c)
+}
+
+// Publish instruments Publish method with metrics.
+func (mm *metricsMiddleware) Publish(ctx context.Context, thingKey string, msg *messaging.Message, c *ws.Client) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "publish").Add(1)
+		mm.latency.With("method", "publish").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Publish(ctx, thingKey, msg, c)
+}
+
+// Disconnect instruments Disconnect method with metrics.
+func (mm *metricsMiddleware) Disconnect(ctx context.Context, c *ws.Client) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "disconnect").Add(1)
+		mm.latency.With("method", "disconnect").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Disconnect(ctx, c)
+}
@@ -42,6 +42,7 @@ func MakeHandler(ctx context.Context, svc ws.Service, l *slog.Logger, instanceID
 	mux := chi.NewRouter()
 	mux.Get("/channels/{chanID}/messages", handshake(ctx, svc))
 	mux.Get("/channels/{chanID}/messages/*", handshake(ctx, svc))
+	mux.Get("/messages", muxHandshake(ctx, svc))
 
 	mux.Get("/health", magistrala.Health(service, instanceID))
 	mux.Handle("/metrics", promhttp.Handler())
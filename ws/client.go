@@ -4,6 +4,9 @@
 package ws
 
 import (
+	"sync"
+	"unicode/utf8"
+
 	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/gorilla/websocket"
 )
@@ -12,6 +15,16 @@ import (
 type Client struct {
 	conn *websocket.Conn
 	id   string
+	mux  bool
+	// subscriberID identifies the subscriptions of the multiplexed client,
+	// so that the same thing can open several multiplexed connections.
+	subscriberID string
+	connected    bool
+	// writeMu serializes the writes to the connection, since the
+	// multiplexed client writes both the messages and the replies.
+	writeMu sync.Mutex
+	mu      sync.Mutex
+	topics  map[string]struct{}
 }
 
 // NewClient returns a new websocket client.
@@ -22,9 +35,21 @@ func NewClient(c *websocket.Conn) *Client {
 	}
 }
 
+// NewMuxClient returns a new multiplexed websocket client. The multiplexed
+// client subscribes to many channels over the same connection and wraps
+// the delivered messages in envelopes.
+func NewMuxClient(c *websocket.Conn) *Client {
+	return &Client{
+		conn:   c,
+		mux:    true,
+		topics: make(map[string]struct{}),
+	}
+}
+
 // Cancel handles the websocket connection after unsubscribing.
 func (c *Client) Cancel() error {
-	if c.conn == nil {
+	if c.conn == nil || c.mux {
+		// The multiplexed connection outlives its subscriptions.
 		return nil
 	}
 	return c.conn.Close()
@@ -37,5 +62,74 @@ func (c *Client) Handle(msg *messaging.Message) error {
 		return nil
 	}
 
-	return c.conn.WriteMessage(websocket.TextMessage, msg.GetPayload())
+	if !c.mux {
+		return c.write(payloadType(msg.GetPayload()), msg.GetPayload())
+	}
+
+	messageType, data, err := EncodeFrame(Frame{
+		Type:      MessageFrame,
+		Channel:   msg.GetChannel(),
+		Subtopic:  msg.GetSubtopic(),
+		Publisher: msg.GetPublisher(),
+		Created:   msg.GetCreated(),
+	}, msg.GetPayload())
+	if err != nil {
+		return err
+	}
+
+	return c.write(messageType, data)
+}
+
+// Reply writes the acknowledgement or error frame of the control frame.
+func (c *Client) Reply(id string, err error) error {
+	frame := Frame{Type: AckFrame, ID: id}
+	if err != nil {
+		frame.Type = ErrorFrame
+		frame.Error = err.Error()
+	}
+	messageType, data, err := EncodeFrame(frame, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.write(messageType, data)
+}
+
+func (c *Client) write(messageType int, data []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	return c.conn.WriteMessage(messageType, data)
+}
+
+// subscribed tracks the topic subscription of the multiplexed client and
+// reports whether the client is already subscribed to the topic.
+func (c *Client) subscribed(topic string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.topics[topic]
+	c.topics[topic] = struct{}{}
+
+	return ok
+}
+
+func (c *Client) unsubscribed(topic string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.topics[topic]
+	delete(c.topics, topic)
+
+	return ok
+}
+
+// payloadType returns the websocket message type of the payload.
+// Payloads which are not valid UTF-8 text are sent as binary messages.
+func payloadType(payload []byte) int {
+	if utf8.Valid(payload) {
+		return websocket.TextMessage
+	}
+
+	return websocket.BinaryMessage
 }
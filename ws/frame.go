@@ -0,0 +1,103 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package ws
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/gorilla/websocket"
+)
+
+// Frame types of the multiplexed connection.
+const (
+	// SubscribeFrame subscribes the connection to the channel and subtopic.
+	SubscribeFrame = "subscribe"
+
+	// UnsubscribeFrame unsubscribes the connection from the channel and subtopic.
+	UnsubscribeFrame = "unsubscribe"
+
+	// PublishFrame publishes the frame payload to the channel and subtopic.
+	PublishFrame = "publish"
+
+	// MessageFrame delivers the message received from the channel.
+	MessageFrame = "message"
+
+	// AckFrame acknowledges the successfully handled control frame.
+	AckFrame = "ack"
+
+	// ErrorFrame reports the failure to handle the control frame.
+	ErrorFrame = "error"
+)
+
+// ErrMalformedFrame indicates the frame which can not be decoded.
+var ErrMalformedFrame = errors.New("malformed frame")
+
+// Frame represents the frame of the multiplexed connection. The ID of the
+// subscribe, unsubscribe and publish frames is the correlation ID, which is
+// returned in the acknowledgement or error frame.
+//
+// The payload of the text frames is the JSON value of the message payload.
+// Payloads which are not JSON are carried by the binary frames, which
+// contain the frame encoded as JSON, followed by a newline and the raw
+// payload.
+type Frame struct {
+	Type      string          `json:"type"`
+	ID        string          `json:"id,omitempty"`
+	Channel   string          `json:"channel,omitempty"`
+	Subtopic  string          `json:"subtopic,omitempty"`
+	Publisher string          `json:"publisher,omitempty"`
+	Created   int64           `json:"created,omitempty"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// EncodeFrame encodes the frame with the payload
+// and returns the websocket message type and data.
+func EncodeFrame(frame Frame, payload []byte) (int, []byte, error) {
+	if len(payload) == 0 || json.Valid(payload) {
+		frame.Payload = payload
+		data, err := json.Marshal(frame)
+		return websocket.TextMessage, data, err
+	}
+
+	frame.Payload = nil
+	header, err := json.Marshal(frame)
+	if err != nil {
+		return 0, nil, err
+	}
+	data := make([]byte, 0, len(header)+1+len(payload))
+	data = append(data, header...)
+	data = append(data, '\n')
+	data = append(data, payload...)
+
+	return websocket.BinaryMessage, data, nil
+}
+
+// DecodeFrame decodes the websocket message and returns the frame and its payload.
+func DecodeFrame(messageType int, data []byte) (Frame, []byte, error) {
+	var frame Frame
+	switch messageType {
+	case websocket.TextMessage:
+		if err := json.Unmarshal(data, &frame); err != nil {
+			return Frame{}, nil, errors.Wrap(ErrMalformedFrame, err)
+		}
+		payload := []byte(frame.Payload)
+		frame.Payload = nil
+		return frame, payload, nil
+	case websocket.BinaryMessage:
+		header, payload, ok := bytes.Cut(data, []byte{'\n'})
+		if !ok {
+			return Frame{}, nil, ErrMalformedFrame
+		}
+		if err := json.Unmarshal(header, &frame); err != nil {
+			return Frame{}, nil, errors.Wrap(ErrMalformedFrame, err)
+		}
+		frame.Payload = nil
+		return frame, payload, nil
+	default:
+		return Frame{}, nil, ErrMalformedFrame
+	}
+}
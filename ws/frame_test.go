@@ -0,0 +1,87 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package ws_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/ws"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrame(t *testing.T) {
+	cases := []struct {
+		desc        string
+		frame       ws.Frame
+		payload     []byte
+		messageType int
+	}{
+		{
+			desc:        "frame with JSON payload",
+			frame:       ws.Frame{Type: ws.MessageFrame, Channel: chanID, Subtopic: subTopic, Publisher: id, Created: 1000},
+			payload:     msg.Payload,
+			messageType: websocket.TextMessage,
+		},
+		{
+			desc:        "frame with binary payload",
+			frame:       ws.Frame{Type: ws.MessageFrame, Channel: chanID, Publisher: id},
+			payload:     []byte{0x00, 0x0a, 0xff},
+			messageType: websocket.BinaryMessage,
+		},
+		{
+			desc:        "frame with text payload which is not JSON",
+			frame:       ws.Frame{Type: ws.PublishFrame, ID: "1", Channel: chanID},
+			payload:     []byte("on"),
+			messageType: websocket.BinaryMessage,
+		},
+		{
+			desc:        "frame without payload",
+			frame:       ws.Frame{Type: ws.AckFrame, ID: "1"},
+			messageType: websocket.TextMessage,
+		},
+	}
+
+	for _, tc := range cases {
+		messageType, data, err := ws.EncodeFrame(tc.frame, tc.payload)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.messageType, messageType, fmt.Sprintf("%s: expected message type %d got %d", tc.desc, tc.messageType, messageType))
+
+		frame, payload, err := ws.DecodeFrame(messageType, data)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.frame, frame, fmt.Sprintf("%s: expected frame %v got %v", tc.desc, tc.frame, frame))
+		assert.Equal(t, string(tc.payload), string(payload), fmt.Sprintf("%s: expected payload %s got %s", tc.desc, tc.payload, payload))
+	}
+}
+
+func TestDecodeMalformedFrame(t *testing.T) {
+	cases := []struct {
+		desc        string
+		messageType int
+		data        []byte
+	}{
+		{
+			desc:        "decode malformed text frame",
+			messageType: websocket.TextMessage,
+			data:        []byte(`{"type":`),
+		},
+		{
+			desc:        "decode binary frame without header",
+			messageType: websocket.BinaryMessage,
+			data:        []byte{0x00, 0x01},
+		},
+		{
+			desc:        "decode binary frame with malformed header",
+			messageType: websocket.BinaryMessage,
+			data:        []byte("type\npayload"),
+		},
+	}
+
+	for _, tc := range cases {
+		_, _, err := ws.DecodeFrame(tc.messageType, tc.data)
+		assert.True(t, errors.Contains(err, ws.ErrMalformedFrame), fmt.Sprintf("%s: expected %s got %s", tc.desc, ws.ErrMalformedFrame, err))
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/absmach/magistrala"
@@ -17,6 +18,8 @@ import (
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/absmach/magistrala/pkg/policies"
+	"github.com/absmach/magistrala/pkg/presence"
+	"github.com/absmach/magistrala/pkg/schema"
 	"github.com/absmach/mgate/pkg/session"
 )
 
@@ -24,6 +27,10 @@ var _ session.Handler = (*handler)(nil)
 
 const protocol = "websocket"
 
+// muxPath is the path of the multiplexed connection, whose control frames
+// are authorized and handled by the ws server.
+const muxPath = "/messages"
+
 // Log message formats.
 const (
 	LogInfoSubscribed   = "subscribed with client_id %s to topics %s"
@@ -44,23 +51,31 @@ var (
 	errFailedPublish            = errors.New("failed to publish")
 	errFailedParseSubtopic      = errors.New("failed to parse subtopic")
 	errFailedPublishToMsgBroker = errors.New("failed to publish to magistrala message broker")
+	errFailedPresenceEvent      = errors.New("failed to publish presence event")
 )
 
 var channelRegExp = regexp.MustCompile(`^\/?channels\/([\w\-]+)\/messages(\/[^?]*)?(\?.*)?$`)
 
 // Event implements events.Event interface.
 type handler struct {
-	pubsub messaging.PubSub
-	things magistrala.ThingsServiceClient
-	logger *slog.Logger
+	pubsub    messaging.PubSub
+	things    magistrala.ThingsServiceClient
+	validator schema.Validator
+	es        presence.EventStore
+	logger    *slog.Logger
+	mu        sync.Mutex
+	sessions  map[*session.Session]string
 }
 
 // NewHandler creates new Handler entity.
-func NewHandler(pubsub messaging.PubSub, logger *slog.Logger, thingsClient magistrala.ThingsServiceClient) session.Handler {
+func NewHandler(pubsub messaging.PubSub, es presence.EventStore, logger *slog.Logger, thingsClient magistrala.ThingsServiceClient, validator schema.Validator) session.Handler {
 	return &handler{
-		logger: logger,
-		pubsub: pubsub,
-		things: thingsClient,
+		logger:    logger,
+		pubsub:    pubsub,
+		things:    thingsClient,
+		validator: validator,
+		es:        es,
+		sessions:  make(map[*session.Session]string),
 	}
 }
 
@@ -76,6 +91,9 @@ func (h *handler) AuthPublish(ctx context.Context, topic *string, payload *[]byt
 	if topic == nil {
 		return errMissingTopicPub
 	}
+	if *topic == muxPath {
+		return nil
+	}
 	s, ok := session.FromContext(ctx)
 	if !ok {
 		return errClientNotInitialized
@@ -89,7 +107,7 @@ func (h *handler) AuthPublish(ctx context.Context, topic *string, payload *[]byt
 		token = string(s.Password)
 	}
 
-	return h.authAccess(ctx, token, *topic, policies.PublishPermission)
+	return h.authAccess(ctx, s, token, *topic, policies.PublishPermission)
 }
 
 // AuthSubscribe is called on device publish,
@@ -112,7 +130,10 @@ func (h *handler) AuthSubscribe(ctx context.Context, topics *[]string) error {
 	}
 
 	for _, v := range *topics {
-		if err := h.authAccess(ctx, token, v, policies.SubscribePermission); err != nil {
+		if v == muxPath {
+			continue
+		}
+		if err := h.authAccess(ctx, s, token, v, policies.SubscribePermission); err != nil {
 			return err
 		}
 	}
@@ -131,6 +152,9 @@ func (h *handler) Publish(ctx context.Context, topic *string, payload *[]byte) e
 	if !ok {
 		return errors.Wrap(errFailedPublish, errClientNotInitialized)
 	}
+	if *topic == muxPath {
+		return nil
+	}
 	h.logger.Info(fmt.Sprintf(LogInfoPublished, s.ID, *topic))
 
 	if len(*payload) == 0 {
@@ -182,10 +206,18 @@ func (h *handler) Publish(ctx context.Context, topic *string, payload *[]byte) e
 		Created:   time.Now().UnixNano(),
 	}
 
+	if err := h.validator.Validate(ctx, msg.GetChannel(), msg.GetPayload()); err != nil {
+		return errors.Wrap(errFailedPublish, err)
+	}
+
 	if err := h.pubsub.Publish(ctx, msg.GetChannel(), &msg); err != nil {
 		return errors.Wrap(errFailedPublishToMsgBroker, err)
 	}
 
+	if err := h.es.Published(ctx, res.GetId()); err != nil {
+		h.logger.Error(errors.Wrap(errFailedPresenceEvent, err).Error())
+	}
+
 	return nil
 }
 
@@ -207,6 +239,19 @@ func (h *handler) Unsubscribe(ctx context.Context, topics *[]string) error {
 	}
 
 	h.logger.Info(fmt.Sprintf(LogInfoUnsubscribed, s.ID, strings.Join(*topics, ",")))
+
+	// Proxy unsubscribes when the websocket connection is closed.
+	h.mu.Lock()
+	thingID, ok := h.sessions[s]
+	delete(h.sessions, s)
+	h.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	if err := h.es.Disconnect(ctx, thingID, s.ID); err != nil {
+		return errors.Wrap(errFailedPresenceEvent, err)
+	}
+
 	return nil
 }
 
@@ -215,7 +260,7 @@ func (h *handler) Disconnect(ctx context.Context) error {
 	return nil
 }
 
-func (h *handler) authAccess(ctx context.Context, password, topic, action string) error {
+func (h *handler) authAccess(ctx context.Context, s *session.Session, password, topic, action string) error {
 	// Topics are in the format:
 	// channels/<channel_id>/messages/<subtopic>/.../ct/<content_type>
 	if !channelRegExp.MatchString(topic) {
@@ -241,10 +286,29 @@ func (h *handler) authAccess(ctx context.Context, password, topic, action string
 	if !res.GetAuthorized() {
 		return errors.Wrap(svcerr.ErrAuthorization, err)
 	}
+	h.connected(ctx, s, res.GetId())
 
 	return nil
 }
 
+// connected issues the connect event on the first successful authorization
+// of the websocket session.
+func (h *handler) connected(ctx context.Context, s *session.Session, thingID string) {
+	h.mu.Lock()
+	_, ok := h.sessions[s]
+	if !ok {
+		h.sessions[s] = thingID
+	}
+	h.mu.Unlock()
+	if ok {
+		return
+	}
+
+	if err := h.es.Connect(ctx, thingID, s.ID); err != nil {
+		h.logger.Error(errors.Wrap(errFailedPresenceEvent, err).Error())
+	}
+}
+
 func parseSubtopic(subtopic string) (string, error) {
 	if subtopic == "" {
 		return subtopic, nil
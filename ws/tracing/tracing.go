@@ -6,7 +6,9 @@ package tracing
 import (
 	"context"
 
+	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/absmach/magistrala/ws"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -16,6 +18,7 @@ const (
 	publishOP     = "publish_op"
 	subscribeOP   = "subscribe_op"
 	unsubscribeOP = "unsubscribe_op"
+	disconnectOP  = "disconnect_op"
 )
 
 type tracingMiddleware struct {
@@ -38,3 +41,33 @@ func (tm *tracingMiddleware) Subscribe(ctx context.Context, thingKey, chanID, su
 
 	return tm.svc.Subscribe(ctx, thingKey, chanID, subtopic, client)
 }
+
+// Unsubscribe traces the "Unsubscribe" operation of the wrapped ws.Service.
+func (tm *tracingMiddleware) Unsubscribe(ctx context.Context, chanID, subtopic string, client *ws.Client) error {
+	ctx, span := tm.tracer.Start(ctx, unsubscribeOP, trace.WithAttributes(
+		attribute.String("channel_id", chanID),
+		attribute.String("subtopic", subtopic),
+	))
+	defer span.End()
+
+	return tm.svc.Unsubscribe(ctx, chanID, subtopic, client)
+}
+
+// Publish traces the "Publish" operation of the wrapped ws.Service.
+func (tm *tracingMiddleware) Publish(ctx context.Context, thingKey string, msg *messaging.Message, client *ws.Client) error {
+	ctx, span := tm.tracer.Start(ctx, publishOP, trace.WithAttributes(
+		attribute.String("channel_id", msg.GetChannel()),
+		attribute.String("subtopic", msg.GetSubtopic()),
+	))
+	defer span.End()
+
+	return tm.svc.Publish(ctx, thingKey, msg, client)
+}
+
+// Disconnect traces the "Disconnect" operation of the wrapped ws.Service.
+func (tm *tracingMiddleware) Disconnect(ctx context.Context, client *ws.Client) error {
+	ctx, span := tm.tracer.Start(ctx, disconnectOP)
+	defer span.End()
+
+	return tm.svc.Disconnect(ctx, client)
+}